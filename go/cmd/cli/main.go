@@ -4,6 +4,7 @@ package main
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
@@ -18,6 +19,7 @@ import (
 	"github.com/smilemakc/mbflow/go/internal/config"
 	"github.com/smilemakc/mbflow/go/internal/infrastructure/storage"
 	"github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgmodels "github.com/smilemakc/mbflow/go/pkg/models"
 	"github.com/smilemakc/mbflow/go/pkg/sdk"
 	"github.com/smilemakc/mbflow/go/pkg/visualization"
 	"golang.org/x/term"
@@ -33,6 +35,13 @@ USAGE:
 COMMANDS:
     workflow show <id>    Show workflow diagram
     workflow list         List all workflows
+    workflow compare <id> Replay recorded inputs through two workflow variants
+    workflow diff <old> <new>  Show what changed between two workflow revisions
+                          (each a workflow ID or a YAML/JSON definition file)
+    workflow run <id>     Run a workflow, or only selected nodes of it
+    execution pause <id>  Pause a running execution at the next node boundary
+    execution resume <id> Resume a paused execution from its checkpoint
+                          (with -from-failure: a failed one from its failed nodes)
     user create           Create user (local or via auth-gateway)
     admin create          Create admin user (requires DATABASE_URL)
     system-key create     Generate a new system key (requires DATABASE_URL)
@@ -48,6 +57,36 @@ WORKFLOW SHOW OPTIONS:
     -color                Use colors in ASCII (default: true)
     -output <file>        Save to file instead of stdout
 
+WORKFLOW COMPARE OPTIONS:
+    -candidate-workflow <id>  Workflow to compare against (default: the same workflow)
+    -override <node.key=val>  Candidate node config override, repeatable (value is JSON or a string)
+    -baseline-override <node.key=val>  Baseline node config override, repeatable
+    -executions <ids>     Comma-separated executions whose inputs are replayed
+    -sample <n>           Recent executions to replay without -executions (default: 10, max: 50)
+    -ignore <paths>       Comma-separated output paths to ignore, e.g. output.generated_at
+    -format <format>      Output format: table, json (default: table)
+    -system-key <key>     System key for the Service API
+    -timeout <duration>   Request timeout (default: 10m)
+    Replays are real executions: nodes with side effects run again for both variants.
+
+WORKFLOW DIFF OPTIONS:
+    -format <format>      Output format: text, json (default: text)
+
+WORKFLOW RUN OPTIONS:
+    -input <json|@file>   Workflow input as JSON, or @path to a JSON file
+    -nodes <ids>          Comma-separated nodes to run instead of the whole workflow
+    -from <id>            Run the nodes from this node on (with -to: up to that node)
+    -to <id>              Run the nodes up to and including this node
+    -boundary <json|@file>  Outputs of the nodes feeding the selection, keyed by node ID
+    -wait                 Wait for the execution to finish and print node results (default: true)
+    -system-key <key>     System key for the Service API
+    -timeout <duration>   Request timeout (default: 10m)
+
+EXECUTION PAUSE/RESUME OPTIONS:
+    -from-failure         Resume a failed execution, re-running only the nodes that did not complete
+    -system-key <key>     System key for the Service API
+    -timeout <duration>   Request timeout (default: 30s)
+
 USER CREATE OPTIONS:
     -email <email>        User email address (required)
     -username <name>      Username (required)
@@ -89,6 +128,25 @@ EXAMPLES:
     # List all workflows
     mbflow-cli workflow list
 
+    # Compare the current model of node "summarize" with gpt-4o-mini on the last 20 inputs
+    mbflow-cli workflow compare wf-123 -override summarize.model=gpt-4o-mini -sample 20
+
+    # Compare a workflow with its edited copy on specific executions
+    mbflow-cli workflow compare wf-123 -candidate-workflow wf-456 -executions ex-1,ex-2 -format json
+
+    # Show what a definition file changes in the stored workflow
+    mbflow-cli workflow diff wf-123 workflows/review.yaml
+
+    # Re-run the "summarize" branch with the output of "fetch" supplied by hand
+    mbflow-cli workflow run wf-123 -from summarize -boundary '{"fetch": {"body": "..."}}'
+
+    # Pause a long-running execution and resume it later
+    mbflow-cli execution pause ex-123
+    mbflow-cli execution resume ex-123
+
+    # Re-run the failed nodes of an execution, reusing the outputs of the completed ones
+    mbflow-cli execution resume ex-123 -from-failure
+
     # Create user in local database
     mbflow-cli user create -email user@example.com -username user -local
 
@@ -113,6 +171,7 @@ EXAMPLES:
 ENVIRONMENT VARIABLES:
     MBFLOW_ENDPOINT       Server endpoint (overridden by -endpoint)
     MBFLOW_API_KEY        API key (overridden by -api-key)
+    MBFLOW_SYSTEM_KEY     System key (overridden by -system-key)
     DATABASE_URL          Database connection string for local user creation
     MBFLOW_AUTH_GRPC_ADDRESS     Auth-gateway gRPC address (e.g., localhost:50051)
 `
@@ -131,7 +190,7 @@ func main() {
 	switch command {
 	case "workflow":
 		if len(os.Args) < 3 {
-			fmt.Fprintln(os.Stderr, "Error: workflow command requires a subcommand (show, list)")
+			fmt.Fprintln(os.Stderr, "Error: workflow command requires a subcommand (show, list, compare, diff, run)")
 			fmt.Fprint(os.Stderr, usage)
 			os.Exit(1)
 		}
@@ -141,11 +200,32 @@ func main() {
 			handleWorkflowShow(os.Args[3:])
 		case "list":
 			handleWorkflowList(os.Args[3:])
+		case "compare":
+			handleWorkflowCompare(os.Args[3:])
+		case "diff":
+			handleWorkflowDiff(os.Args[3:])
+		case "run":
+			handleWorkflowRun(os.Args[3:])
 		default:
 			fmt.Fprintf(os.Stderr, "Error: unknown workflow subcommand: %s\n", subcommand)
 			os.Exit(1)
 		}
 
+	case "execution":
+		if len(os.Args) < 3 {
+			fmt.Fprintln(os.Stderr, "Error: execution command requires a subcommand (pause, resume)")
+			fmt.Fprint(os.Stderr, usage)
+			os.Exit(1)
+		}
+		subcommand := os.Args[2]
+		switch subcommand {
+		case "pause", "resume":
+			handleExecutionControl(subcommand, os.Args[3:])
+		default:
+			fmt.Fprintf(os.Stderr, "Error: unknown execution subcommand: %s\n", subcommand)
+			os.Exit(1)
+		}
+
 	case "user":
 		if len(os.Args) < 3 {
 			fmt.Fprintln(os.Stderr, "Error: user command requires a subcommand (create)")
@@ -348,6 +428,469 @@ func handleWorkflowList(args []string) {
 	}
 }
 
+// nodeOverrideFlags collects repeatable node.key=value flags into node config overrides.
+type nodeOverrideFlags map[string]map[string]any
+
+func (f nodeOverrideFlags) String() string {
+	return fmt.Sprint(map[string]map[string]any(f))
+}
+
+func (f nodeOverrideFlags) Set(value string) error {
+	target, raw, ok := strings.Cut(value, "=")
+	nodeID, key, hasKey := strings.Cut(target, ".")
+	if !ok || !hasKey || nodeID == "" || key == "" {
+		return fmt.Errorf("expected node.key=value, got %q", value)
+	}
+
+	var parsed any
+	if err := json.Unmarshal([]byte(raw), &parsed); err != nil {
+		parsed = raw
+	}
+	if f[nodeID] == nil {
+		f[nodeID] = map[string]any{}
+	}
+	f[nodeID][key] = parsed
+	return nil
+}
+
+func handleWorkflowCompare(args []string) {
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, "Error: workflow compare requires a workflow ID")
+		os.Exit(1)
+	}
+
+	workflowID := args[0]
+	baselineOverrides := nodeOverrideFlags{}
+	candidateOverrides := nodeOverrideFlags{}
+
+	// Parse flags
+	fs := flag.NewFlagSet("workflow compare", flag.ExitOnError)
+	candidateWorkflow := fs.String("candidate-workflow", "", "Workflow to compare against (default: the same workflow)")
+	fs.Var(candidateOverrides, "override", "Candidate node config override node.key=value, repeatable")
+	fs.Var(baselineOverrides, "baseline-override", "Baseline node config override node.key=value, repeatable")
+	executions := fs.String("executions", "", "Comma-separated executions whose inputs are replayed")
+	sample := fs.Int("sample", 0, "Recent executions to replay without -executions (default: 10)")
+	ignore := fs.String("ignore", "", "Comma-separated output paths to ignore")
+	format := fs.String("format", "table", "Output format: table, json")
+	endpoint := fs.String("endpoint", getEnv("MBFLOW_ENDPOINT", "http://localhost:8585"), "MBFlow server endpoint")
+	systemKey := fs.String("system-key", getEnv("MBFLOW_SYSTEM_KEY", ""), "System key for the Service API")
+	timeout := fs.Duration("timeout", 10*time.Minute, "Request timeout")
+
+	if err := fs.Parse(args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing flags: %v\n", err)
+		os.Exit(1)
+	}
+
+	*format = strings.ToLower(*format)
+	if *format != "table" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "Error: invalid format '%s' (must be table or json)\n", *format)
+		os.Exit(1)
+	}
+	if *systemKey == "" {
+		fmt.Fprintln(os.Stderr, "Error: -system-key or MBFLOW_SYSTEM_KEY is required")
+		os.Exit(1)
+	}
+
+	req := &sdk.ServiceCompareWorkflowRequest{
+		Baseline:    pkgmodels.ComparisonVariant{NodeOverrides: baselineOverrides},
+		Candidate:   pkgmodels.ComparisonVariant{WorkflowID: *candidateWorkflow, NodeOverrides: candidateOverrides},
+		SampleSize:  *sample,
+		IgnorePaths: splitList(*ignore),
+	}
+	req.ExecutionIDs = splitList(*executions)
+
+	client, err := sdk.NewServiceClient(sdk.ServiceClientConfig{
+		Endpoint:  *endpoint,
+		SystemKey: *systemKey,
+		Timeout:   *timeout,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to create client: %v\n", err)
+		os.Exit(1)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	report, err := client.Workflows.Compare(ctx, workflowID, req)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to compare workflow '%s': %v\n", workflowID, err)
+		os.Exit(1)
+	}
+
+	if *format == "json" {
+		data, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+	printComparisonReport(report)
+}
+
+func printComparisonReport(report *pkgmodels.ComparisonReport) {
+	fmt.Printf("Compared %d input(s): %d identical, %d different\n\n", report.Total, report.Identical, report.Different)
+
+	fmt.Printf("%-12s %-24s %9s %6s %12s %12s %10s\n", "", "VARIANT", "COMPLETED", "FAILED", "AVG DURATION", "TOKENS", "COST (USD)")
+	for _, side := range []struct {
+		name    string
+		variant pkgmodels.ComparisonVariant
+		totals  pkgmodels.ComparisonTotals
+	}{
+		{"baseline", report.Baseline, report.BaselineTotals},
+		{"candidate", report.Candidate, report.CandidateTotals},
+	} {
+		tokens := side.totals.Usage.PromptTokens + side.totals.Usage.CompletionTokens
+		fmt.Printf("%-12s %-24s %9d %6d %10dms %12d %10.4f\n",
+			side.name, side.variant.Label, side.totals.Completed, side.totals.Failed,
+			side.totals.AvgDurationMs, tokens, side.totals.Usage.CostUSD)
+	}
+	if unpriced := report.BaselineTotals.Usage.UnpricedCalls + report.CandidateTotals.Usage.UnpricedCalls; unpriced > 0 {
+		fmt.Printf("\n%d LLM call(s) used models without a known price and are not included in the cost\n", unpriced)
+	}
+
+	for _, c := range report.Cases {
+		fmt.Println("---")
+		fmt.Printf("Input from:  %s\n", c.SourceExecutionID)
+		fmt.Printf("Baseline:    %s %s (%dms, $%.4f)\n", c.Baseline.Status, c.Baseline.ExecutionID, c.Baseline.DurationMs, c.Baseline.Usage.CostUSD)
+		fmt.Printf("Candidate:   %s %s (%dms, $%.4f)\n", c.Candidate.Status, c.Candidate.ExecutionID, c.Candidate.DurationMs, c.Candidate.Usage.CostUSD)
+		if c.Identical {
+			fmt.Println("Result:      identical")
+			continue
+		}
+		fmt.Printf("Result:      %d difference(s)\n", len(c.Differences))
+		for _, d := range c.Differences {
+			fmt.Printf("  %s\n    - %s\n    + %s\n", d.Path, formatComparisonValue(d.Baseline), formatComparisonValue(d.Candidate))
+		}
+	}
+}
+
+func formatComparisonValue(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+func handleWorkflowDiff(args []string) {
+	if len(args) < 2 {
+		fmt.Fprintln(os.Stderr, "Error: workflow diff requires two workflow IDs or definition files")
+		os.Exit(1)
+	}
+
+	// Parse flags
+	fs := flag.NewFlagSet("workflow diff", flag.ExitOnError)
+	format := fs.String("format", "text", "Output format: text, json")
+	endpoint := fs.String("endpoint", getEnv("MBFLOW_ENDPOINT", "http://localhost:8585"), "MBFlow server endpoint")
+	apiKey := fs.String("api-key", getEnv("MBFLOW_API_KEY", ""), "API key for authentication")
+	timeout := fs.Duration("timeout", 30*time.Second, "Request timeout")
+
+	if err := fs.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing flags: %v\n", err)
+		os.Exit(1)
+	}
+
+	*format = strings.ToLower(*format)
+	if *format != "text" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "Error: invalid format '%s' (must be text or json)\n", *format)
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	var client *sdk.Client
+	load := func(ref string) *pkgmodels.Workflow {
+		if info, err := os.Stat(ref); err == nil && !info.IsDir() {
+			data, err := os.ReadFile(ref)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to read '%s': %v\n", ref, err)
+				os.Exit(1)
+			}
+			workflow, err := pkgmodels.LoadWorkflowDefinition(data)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to load '%s': %v\n", ref, err)
+				os.Exit(1)
+			}
+			return workflow
+		}
+
+		if client == nil {
+			clientOpts := []sdk.ClientOption{sdk.WithHTTPEndpoint(*endpoint)}
+			if *apiKey != "" {
+				clientOpts = append(clientOpts, sdk.WithAPIKey(*apiKey))
+			}
+			var err error
+			if client, err = sdk.NewClient(clientOpts...); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to create client: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		workflow, err := client.Workflows().Get(ctx, ref)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to get workflow '%s': %v\n", ref, err)
+			os.Exit(1)
+		}
+		return workflow
+	}
+
+	diff := pkgmodels.Diff(load(args[0]), load(args[1]))
+
+	if *format == "json" {
+		data, _ := json.MarshalIndent(diff, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+	printWorkflowDiff(diff)
+}
+
+func printWorkflowDiff(diff *pkgmodels.WorkflowDiff) {
+	if diff.IsEmpty() {
+		fmt.Println("No changes")
+		return
+	}
+
+	printChanges := func(prefix string, changes []pkgmodels.FieldChange) {
+		for _, c := range changes {
+			fmt.Printf("      %s%s: %s -> %s\n", prefix, c.Field, formatComparisonValue(c.Old), formatComparisonValue(c.New))
+		}
+	}
+
+	if len(diff.AddedNodes)+len(diff.RemovedNodes)+len(diff.ChangedNodes) > 0 {
+		fmt.Println("Nodes:")
+		for _, node := range diff.AddedNodes {
+			fmt.Printf("  + %s (%s)\n", node.ID, node.Type)
+		}
+		for _, node := range diff.RemovedNodes {
+			fmt.Printf("  - %s (%s)\n", node.ID, node.Type)
+		}
+		for _, change := range diff.ChangedNodes {
+			fmt.Printf("  ~ %s\n", change.ID)
+			printChanges("", change.Fields)
+			printChanges("config.", change.Config)
+		}
+	}
+
+	if len(diff.AddedEdges)+len(diff.RemovedEdges)+len(diff.ChangedEdges) > 0 {
+		fmt.Println("Edges:")
+		for _, edge := range diff.AddedEdges {
+			fmt.Printf("  + %s (%s -> %s)\n", edge.ID, edge.From, edge.To)
+		}
+		for _, edge := range diff.RemovedEdges {
+			fmt.Printf("  - %s (%s -> %s)\n", edge.ID, edge.From, edge.To)
+		}
+		for _, change := range diff.ChangedEdges {
+			fmt.Printf("  ~ %s\n", change.ID)
+			printChanges("", change.Fields)
+		}
+	}
+}
+
+func handleWorkflowRun(args []string) {
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, "Error: workflow run requires a workflow ID")
+		os.Exit(1)
+	}
+
+	workflowID := args[0]
+
+	// Parse flags
+	fs := flag.NewFlagSet("workflow run", flag.ExitOnError)
+	inputFlag := fs.String("input", "", "Workflow input as JSON, or @path to a JSON file")
+	nodes := fs.String("nodes", "", "Comma-separated nodes to run")
+	from := fs.String("from", "", "Run the nodes from this node on")
+	to := fs.String("to", "", "Run the nodes up to and including this node")
+	boundaryFlag := fs.String("boundary", "", "Outputs of the nodes feeding the selection as JSON, or @path to a JSON file")
+	wait := fs.Bool("wait", true, "Wait for the execution to finish")
+	endpoint := fs.String("endpoint", getEnv("MBFLOW_ENDPOINT", "http://localhost:8585"), "MBFlow server endpoint")
+	systemKey := fs.String("system-key", getEnv("MBFLOW_SYSTEM_KEY", ""), "System key for the Service API")
+	timeout := fs.Duration("timeout", 10*time.Minute, "Request timeout")
+
+	if err := fs.Parse(args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing flags: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *systemKey == "" {
+		fmt.Fprintln(os.Stderr, "Error: -system-key or MBFLOW_SYSTEM_KEY is required")
+		os.Exit(1)
+	}
+
+	var input map[string]any
+	if err := readJSONFlag(*inputFlag, &input); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid -input: %v\n", err)
+		os.Exit(1)
+	}
+
+	var selection *pkgmodels.NodeSelection
+	if *nodes != "" || *from != "" || *to != "" || *boundaryFlag != "" {
+		selection = &pkgmodels.NodeSelection{
+			NodeIDs:  splitList(*nodes),
+			FromNode: *from,
+			ToNode:   *to,
+		}
+		if err := readJSONFlag(*boundaryFlag, &selection.BoundaryOutputs); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid -boundary: %v\n", err)
+			os.Exit(1)
+		}
+		if err := selection.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	client, err := sdk.NewServiceClient(sdk.ServiceClientConfig{
+		Endpoint:  *endpoint,
+		SystemKey: *systemKey,
+		Timeout:   *timeout,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to create client: %v\n", err)
+		os.Exit(1)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	var execution *pkgmodels.Execution
+	if selection != nil {
+		execution, err = client.Executions.StartPartial(ctx, workflowID, input, selection)
+	} else {
+		execution, err = client.Executions.Start(ctx, workflowID, input)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to run workflow '%s': %v\n", workflowID, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Execution:   %s\n", execution.ID)
+	if !*wait {
+		return
+	}
+
+	for !execution.Status.IsTerminal() {
+		select {
+		case <-ctx.Done():
+			fmt.Fprintf(os.Stderr, "Error: execution '%s' did not finish: %v\n", execution.ID, ctx.Err())
+			os.Exit(1)
+		case <-time.After(time.Second):
+		}
+		if execution, err = client.Executions.Get(ctx, execution.ID); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to get execution: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	fmt.Printf("Status:      %s (%dms)\n", execution.Status, execution.Duration)
+	if execution.Error != "" {
+		fmt.Printf("Error:       %s\n", execution.Error)
+	}
+	for _, ne := range execution.NodeExecutions {
+		fmt.Println("---")
+		fmt.Printf("Node:        %s (%s)\n", ne.NodeName, ne.Status)
+		if ne.Error != "" {
+			fmt.Printf("Error:       %s\n", ne.Error)
+		}
+		if ne.Output != nil {
+			fmt.Printf("Output:      %s\n", formatComparisonValue(ne.Output))
+		}
+	}
+	if execution.Status != pkgmodels.ExecutionStatusCompleted {
+		os.Exit(1)
+	}
+}
+
+// handleExecutionControl pauses or resumes an execution through the Service API.
+func handleExecutionControl(action string, args []string) {
+	if len(args) < 1 {
+		fmt.Fprintf(os.Stderr, "Error: execution %s requires an execution ID\n", action)
+		os.Exit(1)
+	}
+
+	executionID := args[0]
+
+	fs := flag.NewFlagSet("execution "+action, flag.ExitOnError)
+	endpoint := fs.String("endpoint", getEnv("MBFLOW_ENDPOINT", "http://localhost:8585"), "MBFlow server endpoint")
+	systemKey := fs.String("system-key", getEnv("MBFLOW_SYSTEM_KEY", ""), "System key for the Service API")
+	timeout := fs.Duration("timeout", 30*time.Second, "Request timeout")
+	var fromFailure *bool
+	if action == "resume" {
+		fromFailure = fs.Bool("from-failure", false, "Resume a failed execution from its failed nodes")
+	}
+
+	if err := fs.Parse(args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing flags: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *systemKey == "" {
+		fmt.Fprintln(os.Stderr, "Error: -system-key or MBFLOW_SYSTEM_KEY is required")
+		os.Exit(1)
+	}
+
+	client, err := sdk.NewServiceClient(sdk.ServiceClientConfig{
+		Endpoint:  *endpoint,
+		SystemKey: *systemKey,
+		Timeout:   *timeout,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to create client: %v\n", err)
+		os.Exit(1)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	switch action {
+	case "pause":
+		if err := client.Executions.Pause(ctx, executionID); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to pause execution '%s': %v\n", executionID, err)
+			os.Exit(1)
+		}
+		fmt.Printf("Execution '%s' pauses once its running nodes finish\n", executionID)
+	case "resume":
+		resume := client.Executions.Resume
+		if *fromFailure {
+			resume = client.Executions.ResumeFailed
+		}
+		execution, err := resume(ctx, executionID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to resume execution '%s': %v\n", executionID, err)
+			os.Exit(1)
+		}
+		fmt.Printf("Execution '%s' resumed (%s)\n", execution.ID, execution.Status)
+	}
+}
+
+// readJSONFlag decodes a flag holding JSON, or @path to a JSON file, into v.
+// An empty value leaves v unchanged.
+func readJSONFlag(value string, v any) error {
+	if value == "" {
+		return nil
+	}
+	data := []byte(value)
+	if path, ok := strings.CutPrefix(value, "@"); ok {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(data, v)
+}
+
+// splitList splits a comma-separated flag value, dropping empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
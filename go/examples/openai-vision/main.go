@@ -22,6 +22,7 @@ import (
 
 	"github.com/smilemakc/mbflow/go/pkg/builder"
 	"github.com/smilemakc/mbflow/go/pkg/executor/builtin"
+	"github.com/smilemakc/mbflow/go/pkg/executor/config"
 )
 
 func main() {
@@ -34,18 +35,20 @@ func main() {
 	// Create workflow using builder
 	wf := builder.NewWorkflow("image-analysis", builder.WithDescription("Analyze image with GPT-4o Vision")).
 		AddNode(
-			builder.NewNode("fetch_image", "http", "Fetch Image",
-				builder.WithConfigValue("method", "GET"),
-				builder.WithConfigValue("url", "https://httpbin.org/image/jpeg"),
-			),
+			builder.NewNodeT("fetch_image", "Fetch Image", config.HTTPConfig{
+				Method: "GET",
+				URL:    "https://httpbin.org/image/jpeg",
+			}),
 		).
 		AddNode(
-			builder.NewNode("analyze_image", "llm", "Analyze Image",
-				builder.WithConfigValue("provider", "openai"),
-				builder.WithConfigValue("model", "gpt-4o"),
-				builder.WithConfigValue("api_key", apiKey),
-				builder.WithConfigValue("prompt", "Describe this image in detail. What do you see?"),
-				builder.WithConfigValue("max_tokens", 500),
+			builder.NewNodeT("analyze_image", "Analyze Image", config.LLMConfig{
+				Provider:  "openai",
+				Model:     "gpt-4o",
+				APIKey:    apiKey,
+				Prompt:    "Describe this image in detail. What do you see?",
+				MaxTokens: 500,
+			},
+				// Files have no typed field yet
 				builder.WithConfigValue("files", []map[string]any{
 					{
 						"data":      "{{input.body_base64}}",
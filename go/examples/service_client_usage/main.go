@@ -83,9 +83,9 @@ func main() {
 				{
 					ID:   "node-2",
 					Name: "Process Node",
-					Type: "transform",
+					Type: "script_js",
 					Config: map[string]any{
-						"script": "return { processed: input.data }",
+						"script": "return { processed: input.body }",
 					},
 				},
 			},
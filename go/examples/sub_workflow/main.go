@@ -39,7 +39,7 @@ func main() {
 	parentWF := builder.NewWorkflow("Content Plan Generator",
 		builder.WithDescription("Generate content for all cells in parallel"),
 	).
-		AddNode(builder.NewSubWorkflowNode("fanout", "Generate All Cells", childWF.ID,
+		AddNode(builder.NewSubWorkflowNode("fanout", "Generate All Cells", childWF.ID, nil,
 			builder.WithForEach("input.cells"),
 			builder.WithItemVar("cell"),
 			builder.WithMaxParallelism(3),
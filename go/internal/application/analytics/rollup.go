@@ -0,0 +1,315 @@
+// Package analytics rolls execution metrics up into hourly and daily buckets and
+// prunes raw execution data past its retention, so analytics can cover months of
+// history without keeping every node event.
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+)
+
+var (
+	// ErrRollupInProgress is returned when a rollup is already running.
+	ErrRollupInProgress = errors.New("stats rollup already in progress")
+
+	// ErrInvalidGranularity is returned for granularities other than hour and day.
+	ErrInvalidGranularity = errors.New("granularity must be one of: hour, day")
+
+	// ErrInvalidRange is returned when a query range is empty or too large.
+	ErrInvalidRange = errors.New("invalid time range")
+)
+
+const (
+	// rollupLookback is how far before the newest bucket each run re-aggregates,
+	// so executions that finish after their start hour was rolled up are counted.
+	rollupLookback = 48 * time.Hour
+
+	// maxQueryBuckets bounds the number of buckets a single query may span.
+	maxQueryBuckets = 5000
+)
+
+// Config holds rollup service settings.
+type Config struct {
+	// Interval between rollup runs.
+	Interval time.Duration
+	// RawRetention is how long events and node executions of finished executions are kept; 0 keeps them forever.
+	RawRetention time.Duration
+	// HourlyRetention is how long hourly buckets are kept; 0 keeps them forever. Daily buckets are never pruned.
+	HourlyRetention time.Duration
+}
+
+// RollupResult describes the work done by one rollup run.
+type RollupResult struct {
+	From                  time.Time `json:"from"`
+	To                    time.Time `json:"to"`
+	HourlyBuckets         int       `json:"hourly_buckets"`
+	DailyBuckets          int       `json:"daily_buckets"`
+	DeletedEvents         int       `json:"deleted_events"`
+	DeletedNodeExecutions int       `json:"deleted_node_executions"`
+	DeletedHourlyBuckets  int       `json:"deleted_hourly_buckets"`
+}
+
+// Report is the answer to an analytics query.
+type Report struct {
+	Granularity repository.StatsGranularity        `json:"granularity"`
+	WorkflowID  string                             `json:"workflow_id,omitempty"`
+	From        time.Time                          `json:"from"`
+	To          time.Time                          `json:"to"`
+	Buckets     []*repository.ExecutionStatsBucket `json:"buckets"`
+	Totals      *repository.ExecutionStatsBucket   `json:"totals"`
+}
+
+// RollupService aggregates execution statistics on an interval and answers analytics queries.
+type RollupService struct {
+	config Config
+	repo   repository.ExecutionStatsRepository
+	logger *logger.Logger
+	now    func() time.Time
+
+	runMu sync.Mutex
+	done  chan struct{}
+	wg    sync.WaitGroup
+}
+
+// NewRollupService creates a new rollup service.
+func NewRollupService(cfg Config, repo repository.ExecutionStatsRepository, log *logger.Logger) *RollupService {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 15 * time.Minute
+	}
+	if cfg.RawRetention < 0 {
+		cfg.RawRetention = 0
+	}
+	if cfg.HourlyRetention < 0 {
+		cfg.HourlyRetention = 0
+	}
+
+	return &RollupService{
+		config: cfg,
+		repo:   repo,
+		logger: log,
+		now:    time.Now,
+	}
+}
+
+// Start runs a rollup immediately and then on every interval until Stop is called.
+func (s *RollupService) Start() {
+	s.done = make(chan struct{})
+	s.wg.Add(1)
+	go s.loop()
+}
+
+// Stop stops the rollup loop and waits for a running rollup to finish.
+func (s *RollupService) Stop() {
+	if s.done == nil {
+		return
+	}
+	close(s.done)
+	s.wg.Wait()
+	s.done = nil
+}
+
+func (s *RollupService) loop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.RunOnce(context.Background()); err != nil && !errors.Is(err, ErrRollupInProgress) {
+			s.logger.Error("Execution stats rollup failed", "error", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// RunOnce rolls up hourly and daily buckets and prunes data past its retention.
+func (s *RollupService) RunOnce(ctx context.Context) (*RollupResult, error) {
+	if !s.runMu.TryLock() {
+		return nil, ErrRollupInProgress
+	}
+	defer s.runMu.Unlock()
+
+	now := s.now().UTC()
+	from, err := s.rollupStart(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RollupResult{To: now.Truncate(time.Hour).Add(time.Hour)}
+	if from == nil {
+		// Nothing to roll up yet, but raw data may still be due for pruning.
+		result.From = result.To
+	} else {
+		result.From = *from
+
+		result.HourlyBuckets, err = s.repo.RollupHourly(ctx, result.From, result.To)
+		if err != nil {
+			return nil, err
+		}
+
+		dailyFrom := truncateDay(result.From)
+		if s.config.HourlyRetention > 0 {
+			// Days whose hourly buckets were partly pruned are final; do not recompute them.
+			if complete := truncateDay(now.Add(-s.config.HourlyRetention)).Add(24 * time.Hour); dailyFrom.Before(complete) {
+				dailyFrom = complete
+			}
+		}
+		result.DailyBuckets, err = s.repo.RollupDaily(ctx, dailyFrom, truncateDay(now).Add(24*time.Hour))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if s.config.HourlyRetention > 0 {
+		result.DeletedHourlyBuckets, err = s.repo.DeleteHourlyBefore(ctx, now.Add(-s.config.HourlyRetention))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if s.config.RawRetention > 0 {
+		result.DeletedEvents, result.DeletedNodeExecutions, err = s.repo.DeleteRawBefore(ctx, now.Add(-s.config.RawRetention))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.logger.Debug("Execution stats rolled up",
+		"from", result.From,
+		"to", result.To,
+		"hourly_buckets", result.HourlyBuckets,
+		"daily_buckets", result.DailyBuckets,
+		"deleted_events", result.DeletedEvents,
+		"deleted_node_executions", result.DeletedNodeExecutions,
+		"deleted_hourly_buckets", result.DeletedHourlyBuckets,
+	)
+
+	return result, nil
+}
+
+// rollupStart returns the start of the hourly range to recompute, or nil if there are no executions.
+// The first run backfills from the oldest execution. Later runs re-aggregate a lookback window
+// before the newest bucket, but skip buckets that may contain executions with pruned raw data;
+// those were rolled up before the data was pruned.
+func (s *RollupService) rollupStart(ctx context.Context, now time.Time) (*time.Time, error) {
+	latest, err := s.repo.LatestHourlyBucket(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if latest == nil {
+		earliest, err := s.repo.EarliestExecutionStart(ctx)
+		if err != nil || earliest == nil {
+			return nil, err
+		}
+		from := earliest.UTC().Truncate(time.Hour)
+		return &from, nil
+	}
+
+	from := latest.UTC().Truncate(time.Hour).Add(-rollupLookback)
+	if s.config.RawRetention > 0 {
+		cutoff := now.Add(-s.config.RawRetention)
+		firstIntact := cutoff.Truncate(time.Hour)
+		if firstIntact.Before(cutoff) {
+			firstIntact = firstIntact.Add(time.Hour)
+		}
+		if from.Before(firstIntact) {
+			from = firstIntact
+		}
+	}
+
+	// Executions after the newest bucket were never rolled up and are always included.
+	if next := latest.UTC().Truncate(time.Hour).Add(time.Hour); from.After(next) {
+		from = next
+	}
+	return &from, nil
+}
+
+// Query returns rolled-up buckets in [from, to) for one workflow, or summed over all workflows.
+func (s *RollupService) Query(
+	ctx context.Context,
+	granularity repository.StatsGranularity,
+	workflowID *uuid.UUID,
+	from, to time.Time,
+) (*Report, error) {
+	var step time.Duration
+	switch granularity {
+	case repository.StatsGranularityHour:
+		step = time.Hour
+	case repository.StatsGranularityDay:
+		step = 24 * time.Hour
+	default:
+		return nil, ErrInvalidGranularity
+	}
+
+	if !from.Before(to) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidRange)
+	}
+	if to.Sub(from)/step > maxQueryBuckets {
+		return nil, fmt.Errorf("%w: range spans more than %d %s buckets", ErrInvalidRange, maxQueryBuckets, granularity)
+	}
+
+	buckets, err := s.repo.ListBuckets(ctx, granularity, workflowID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		Granularity: granularity,
+		From:        from,
+		To:          to,
+		Buckets:     buckets,
+		Totals:      Summarize(buckets),
+	}
+	if workflowID != nil {
+		report.WorkflowID = workflowID.String()
+	}
+
+	return report, nil
+}
+
+// Summarize adds buckets into one; the average duration is weighted by completed executions.
+func Summarize(buckets []*repository.ExecutionStatsBucket) *repository.ExecutionStatsBucket {
+	totals := &repository.ExecutionStatsBucket{}
+	var durationSum int64
+
+	for i, b := range buckets {
+		if i == 0 {
+			totals.BucketStart = b.BucketStart
+		}
+		totals.Executions += b.Executions
+		totals.CompletedCount += b.CompletedCount
+		totals.FailedCount += b.FailedCount
+		totals.CancelledCount += b.CancelledCount
+		totals.NodeExecutions += b.NodeExecutions
+		totals.NodeFailures += b.NodeFailures
+		totals.AssertionFailures += b.AssertionFailures
+		totals.MaxDurationMs = max(totals.MaxDurationMs, b.MaxDurationMs)
+		durationSum += b.AverageDurationMs * int64(b.CompletedCount)
+	}
+
+	if totals.CompletedCount > 0 {
+		totals.AverageDurationMs = durationSum / int64(totals.CompletedCount)
+	}
+
+	return totals
+}
+
+// truncateDay returns the start of the UTC day of t.
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
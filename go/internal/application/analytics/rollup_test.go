@@ -0,0 +1,238 @@
+package analytics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smilemakc/mbflow/go/internal/config"
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+)
+
+type timeRange struct {
+	from, to time.Time
+}
+
+type mockStatsRepo struct {
+	latest   *time.Time
+	earliest *time.Time
+	buckets  []*repository.ExecutionStatsBucket
+
+	hourly       []timeRange
+	daily        []timeRange
+	rawCutoff    *time.Time
+	hourlyCutoff *time.Time
+}
+
+func (m *mockStatsRepo) LatestHourlyBucket(ctx context.Context) (*time.Time, error) {
+	return m.latest, nil
+}
+
+func (m *mockStatsRepo) EarliestExecutionStart(ctx context.Context) (*time.Time, error) {
+	return m.earliest, nil
+}
+
+func (m *mockStatsRepo) RollupHourly(ctx context.Context, from, to time.Time) (int, error) {
+	m.hourly = append(m.hourly, timeRange{from, to})
+	return 3, nil
+}
+
+func (m *mockStatsRepo) RollupDaily(ctx context.Context, from, to time.Time) (int, error) {
+	m.daily = append(m.daily, timeRange{from, to})
+	return 1, nil
+}
+
+func (m *mockStatsRepo) DeleteRawBefore(ctx context.Context, cutoff time.Time) (int, int, error) {
+	m.rawCutoff = &cutoff
+	return 10, 4, nil
+}
+
+func (m *mockStatsRepo) DeleteHourlyBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	m.hourlyCutoff = &cutoff
+	return 2, nil
+}
+
+func (m *mockStatsRepo) ListBuckets(
+	ctx context.Context,
+	granularity repository.StatsGranularity,
+	workflowID *uuid.UUID,
+	from, to time.Time,
+) ([]*repository.ExecutionStatsBucket, error) {
+	return m.buckets, nil
+}
+
+func newTestRollupService(cfg Config, repo *mockStatsRepo, now time.Time) *RollupService {
+	log := logger.New(config.LoggingConfig{Level: "error", Format: "json"})
+	s := NewRollupService(cfg, repo, log)
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
+
+func TestRollupService_RunOnce_Backfill(t *testing.T) {
+	now := time.Date(2026, 3, 10, 14, 25, 0, 0, time.UTC)
+	repo := &mockStatsRepo{earliest: ptrTime(time.Date(2026, 3, 1, 9, 40, 0, 0, time.UTC))}
+	s := newTestRollupService(Config{}, repo, now)
+
+	result, err := s.RunOnce(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, repo.hourly, 1)
+	assert.Equal(t, time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC), repo.hourly[0].from)
+	assert.Equal(t, time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC), repo.hourly[0].to)
+
+	require.Len(t, repo.daily, 1)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), repo.daily[0].from)
+	assert.Equal(t, time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC), repo.daily[0].to)
+
+	assert.Equal(t, 3, result.HourlyBuckets)
+	assert.Equal(t, 1, result.DailyBuckets)
+	assert.Nil(t, repo.rawCutoff, "raw data is kept without a retention")
+	assert.Nil(t, repo.hourlyCutoff, "hourly buckets are kept without a retention")
+}
+
+func TestRollupService_RunOnce_NoExecutions(t *testing.T) {
+	now := time.Date(2026, 3, 10, 14, 25, 0, 0, time.UTC)
+	repo := &mockStatsRepo{}
+	s := newTestRollupService(Config{RawRetention: 7 * 24 * time.Hour}, repo, now)
+
+	result, err := s.RunOnce(context.Background())
+	require.NoError(t, err)
+
+	assert.Empty(t, repo.hourly)
+	assert.Empty(t, repo.daily)
+	assert.Zero(t, result.HourlyBuckets)
+	require.NotNil(t, repo.rawCutoff)
+	assert.Equal(t, now.Add(-7*24*time.Hour), *repo.rawCutoff)
+}
+
+func TestRollupService_RunOnce_Incremental(t *testing.T) {
+	now := time.Date(2026, 3, 10, 14, 25, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		cfg        Config
+		latest     time.Time
+		wantHourly time.Time
+		wantDaily  time.Time
+	}{
+		{
+			name:       "re-aggregates lookback window",
+			latest:     time.Date(2026, 3, 10, 13, 0, 0, 0, time.UTC),
+			wantHourly: time.Date(2026, 3, 8, 13, 0, 0, 0, time.UTC),
+			wantDaily:  time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "does not recompute buckets with pruned raw data",
+			cfg:        Config{RawRetention: 24 * time.Hour},
+			latest:     time.Date(2026, 3, 10, 13, 0, 0, 0, time.UTC),
+			wantHourly: time.Date(2026, 3, 9, 15, 0, 0, 0, time.UTC),
+			wantDaily:  time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "includes executions after a newest bucket older than raw retention",
+			cfg:        Config{RawRetention: 24 * time.Hour},
+			latest:     time.Date(2026, 3, 4, 8, 0, 0, 0, time.UTC),
+			wantHourly: time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC),
+			wantDaily:  time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "does not recompute days with pruned hourly buckets",
+			cfg:        Config{HourlyRetention: 30 * time.Hour},
+			latest:     time.Date(2026, 3, 4, 8, 0, 0, 0, time.UTC),
+			wantHourly: time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC),
+			wantDaily:  time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockStatsRepo{latest: ptrTime(tt.latest)}
+			s := newTestRollupService(tt.cfg, repo, now)
+
+			_, err := s.RunOnce(context.Background())
+			require.NoError(t, err)
+
+			require.Len(t, repo.hourly, 1)
+			assert.Equal(t, tt.wantHourly, repo.hourly[0].from)
+			require.Len(t, repo.daily, 1)
+			assert.Equal(t, tt.wantDaily, repo.daily[0].from)
+		})
+	}
+}
+
+func TestRollupService_RunOnce_Retention(t *testing.T) {
+	now := time.Date(2026, 3, 10, 14, 25, 0, 0, time.UTC)
+	repo := &mockStatsRepo{latest: ptrTime(now.Truncate(time.Hour))}
+	s := newTestRollupService(Config{
+		RawRetention:    14 * 24 * time.Hour,
+		HourlyRetention: 90 * 24 * time.Hour,
+	}, repo, now)
+
+	result, err := s.RunOnce(context.Background())
+	require.NoError(t, err)
+
+	require.NotNil(t, repo.rawCutoff)
+	assert.Equal(t, now.Add(-14*24*time.Hour), *repo.rawCutoff)
+	require.NotNil(t, repo.hourlyCutoff)
+	assert.Equal(t, now.Add(-90*24*time.Hour), *repo.hourlyCutoff)
+
+	assert.Equal(t, 10, result.DeletedEvents)
+	assert.Equal(t, 4, result.DeletedNodeExecutions)
+	assert.Equal(t, 2, result.DeletedHourlyBuckets)
+}
+
+func TestRollupService_RunOnce_InProgress(t *testing.T) {
+	s := newTestRollupService(Config{}, &mockStatsRepo{}, time.Now())
+
+	s.runMu.Lock()
+	defer s.runMu.Unlock()
+
+	_, err := s.RunOnce(context.Background())
+	assert.ErrorIs(t, err, ErrRollupInProgress)
+}
+
+func TestRollupService_Query(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	repo := &mockStatsRepo{buckets: []*repository.ExecutionStatsBucket{
+		{BucketStart: from, Executions: 4, CompletedCount: 3, FailedCount: 1, AverageDurationMs: 100, MaxDurationMs: 150, NodeExecutions: 12},
+		{BucketStart: from.Add(24 * time.Hour), Executions: 1, CompletedCount: 1, AverageDurationMs: 500, MaxDurationMs: 500, NodeExecutions: 3, AssertionFailures: 2},
+	}}
+	s := newTestRollupService(Config{}, repo, time.Now())
+
+	workflowID := uuid.New()
+	report, err := s.Query(context.Background(), repository.StatsGranularityDay, &workflowID, from, from.Add(48*time.Hour))
+	require.NoError(t, err)
+
+	assert.Equal(t, workflowID.String(), report.WorkflowID)
+	assert.Len(t, report.Buckets, 2)
+	assert.Equal(t, 5, report.Totals.Executions)
+	assert.Equal(t, 4, report.Totals.CompletedCount)
+	assert.Equal(t, 1, report.Totals.FailedCount)
+	assert.Equal(t, int64(200), report.Totals.AverageDurationMs)
+	assert.Equal(t, int64(500), report.Totals.MaxDurationMs)
+	assert.Equal(t, 15, report.Totals.NodeExecutions)
+	assert.Equal(t, 2, report.Totals.AssertionFailures)
+}
+
+func TestRollupService_Query_Validation(t *testing.T) {
+	s := newTestRollupService(Config{}, &mockStatsRepo{}, time.Now())
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := s.Query(context.Background(), "week", nil, from, from.Add(time.Hour))
+	assert.ErrorIs(t, err, ErrInvalidGranularity)
+
+	_, err = s.Query(context.Background(), repository.StatsGranularityHour, nil, from, from)
+	assert.ErrorIs(t, err, ErrInvalidRange)
+
+	_, err = s.Query(context.Background(), repository.StatsGranularityHour, nil, from, from.Add(365*24*time.Hour))
+	assert.ErrorIs(t, err, ErrInvalidRange)
+}
@@ -0,0 +1,124 @@
+package canary
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+)
+
+// AlertType identifies a canary state transition.
+type AlertType string
+
+const (
+	// AlertTypeFailing is sent when a canary reaches the failure threshold
+	AlertTypeFailing AlertType = "canary.failing"
+
+	// AlertTypeRecovered is sent when a failing canary passes again
+	AlertTypeRecovered AlertType = "canary.recovered"
+)
+
+// Alert is delivered to alert sinks on canary state transitions.
+type Alert struct {
+	Type                AlertType `json:"type"`
+	WorkflowID          string    `json:"workflow_id"`
+	WorkflowName        string    `json:"workflow_name,omitempty"`
+	ExecutionID         string    `json:"execution_id,omitempty"`
+	RunbookURL          string    `json:"runbook_url,omitempty"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	Message             string    `json:"message"`
+	Failures            []string  `json:"failures,omitempty"`
+	Timestamp           time.Time `json:"timestamp"`
+}
+
+// AlertSink delivers canary alerts.
+type AlertSink interface {
+	Name() string
+	Send(ctx context.Context, alert Alert) error
+}
+
+// LogSink writes alerts to the application log.
+type LogSink struct {
+	logger *logger.Logger
+}
+
+// NewLogSink creates an alert sink that logs alerts.
+func NewLogSink(log *logger.Logger) *LogSink {
+	return &LogSink{logger: log}
+}
+
+// Name returns the sink name.
+func (s *LogSink) Name() string { return "log" }
+
+// Send logs the alert; failures are logged as errors, recoveries as info.
+func (s *LogSink) Send(_ context.Context, alert Alert) error {
+	args := []any{
+		"type", alert.Type,
+		"workflow_id", alert.WorkflowID,
+		"workflow_name", alert.WorkflowName,
+		"execution_id", alert.ExecutionID,
+		"runbook_url", alert.RunbookURL,
+		"consecutive_failures", alert.ConsecutiveFailures,
+		"failures", alert.Failures,
+	}
+	if alert.Type == AlertTypeFailing {
+		s.logger.Error(alert.Message, args...)
+	} else {
+		s.logger.Info(alert.Message, args...)
+	}
+	return nil
+}
+
+// WebhookSink posts alerts as JSON to a URL (Slack-compatible "text" field included).
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink creates an alert sink that posts to url.
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name returns the sink name.
+func (s *WebhookSink) Name() string { return "webhook" }
+
+// Send posts the alert.
+func (s *WebhookSink) Send(ctx context.Context, alert Alert) error {
+	payload := struct {
+		Alert
+		Text string `json:"text"`
+	}{
+		Alert: alert,
+		Text:  alert.Message,
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("alert webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
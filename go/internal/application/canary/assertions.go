@@ -0,0 +1,198 @@
+package canary
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// Evaluate checks assertions against a finished execution.
+func Evaluate(execution *models.Execution, assertions []models.CanaryAssertion) []models.CanaryAssertionResult {
+	results := make([]models.CanaryAssertionResult, 0, len(assertions))
+	for _, a := range assertions {
+		results = append(results, evaluateAssertion(execution, a))
+	}
+	return results
+}
+
+func evaluateAssertion(execution *models.Execution, a models.CanaryAssertion) models.CanaryAssertionResult {
+	result := models.CanaryAssertionResult{Assertion: a}
+
+	root, err := assertionRoot(execution, a.NodeID)
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+
+	actual, found := lookupPath(root, a.Path)
+	if found {
+		result.Actual = actual
+	}
+
+	switch a.Op {
+	case models.CanaryOpExists:
+		result.Passed = found
+	case models.CanaryOpNotExists:
+		result.Passed = !found
+	case models.CanaryOpEquals:
+		result.Passed = found && valuesEqual(actual, a.Value)
+	case models.CanaryOpNotEquals:
+		result.Passed = !found || !valuesEqual(actual, a.Value)
+	case models.CanaryOpContains:
+		result.Passed = found && contains(actual, a.Value)
+	case models.CanaryOpGreater, models.CanaryOpGreaterOrEq, models.CanaryOpLess, models.CanaryOpLessOrEq:
+		result.Passed = found && compareNumbers(a.Op, actual, a.Value)
+	case models.CanaryOpMatches:
+		pattern, _ := a.Value.(string)
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			result.Message = "invalid pattern: " + err.Error()
+			return result
+		}
+		result.Passed = found && re.MatchString(fmt.Sprint(actual))
+	default:
+		result.Message = "unsupported operator: " + string(a.Op)
+		return result
+	}
+
+	if !result.Passed {
+		result.Message = describeFailure(a, actual, found)
+	}
+	return result
+}
+
+// assertionRoot returns the execution output or the output of a node.
+func assertionRoot(execution *models.Execution, nodeID string) (any, error) {
+	if nodeID == "" {
+		return execution.Output, nil
+	}
+	for _, ne := range execution.NodeExecutions {
+		if ne != nil && ne.NodeID == nodeID {
+			return ne.Output, nil
+		}
+	}
+	return nil, fmt.Errorf("node %s did not run", nodeID)
+}
+
+// lookupPath resolves a dot-separated path; numeric segments index into arrays.
+func lookupPath(root any, path string) (any, bool) {
+	current := normalize(root)
+	if path == "" {
+		return current, current != nil
+	}
+
+	for _, segment := range strings.Split(path, ".") {
+		switch v := current.(type) {
+		case map[string]any:
+			next, ok := v[segment]
+			if !ok {
+				return nil, false
+			}
+			current = normalize(next)
+		case []any:
+			idx, err := strconv.Atoi(segment)
+			if err != nil || idx < 0 || idx >= len(v) {
+				return nil, false
+			}
+			current = normalize(v[idx])
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// normalize converts typed values (structs, []string, int...) to their JSON representation
+// so comparisons work the same regardless of how an executor built its output.
+func normalize(v any) any {
+	switch v.(type) {
+	case nil, map[string]any, []any, string, bool, float64:
+		return v
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return v
+	}
+	return out
+}
+
+func valuesEqual(actual, expected any) bool {
+	return reflect.DeepEqual(normalize(actual), normalize(expected))
+}
+
+func contains(actual, expected any) bool {
+	switch v := normalize(actual).(type) {
+	case string:
+		return strings.Contains(v, fmt.Sprint(expected))
+	case []any:
+		for _, item := range v {
+			if valuesEqual(item, expected) {
+				return true
+			}
+		}
+	case map[string]any:
+		key, ok := expected.(string)
+		if ok {
+			_, exists := v[key]
+			return exists
+		}
+	}
+	return false
+}
+
+func compareNumbers(op models.CanaryAssertionOp, actual, expected any) bool {
+	a, ok := toFloat(actual)
+	if !ok {
+		return false
+	}
+	b, ok := toFloat(expected)
+	if !ok {
+		return false
+	}
+
+	switch op {
+	case models.CanaryOpGreater:
+		return a > b
+	case models.CanaryOpGreaterOrEq:
+		return a >= b
+	case models.CanaryOpLess:
+		return a < b
+	case models.CanaryOpLessOrEq:
+		return a <= b
+	}
+	return false
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := normalize(v).(type) {
+	case float64:
+		return n, true
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func describeFailure(a models.CanaryAssertion, actual any, found bool) string {
+	target := a.Path
+	if a.NodeID != "" {
+		target = a.NodeID + ":" + a.Path
+	}
+	if !found && a.Op != models.CanaryOpNotExists {
+		return fmt.Sprintf("%s: value not found", target)
+	}
+	if a.Op == models.CanaryOpExists || a.Op == models.CanaryOpNotExists {
+		return fmt.Sprintf("%s: expected %s", target, a.Op)
+	}
+	return fmt.Sprintf("%s: expected %s %v, got %v", target, a.Op, a.Value, actual)
+}
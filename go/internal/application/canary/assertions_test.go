@@ -0,0 +1,55 @@
+package canary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+func TestEvaluate(t *testing.T) {
+	execution := &models.Execution{
+		Output: map[string]any{
+			"status": "ok",
+			"count":  3,
+			"items":  []string{"a", "b"},
+			"user":   map[string]any{"email": "ops@example.com"},
+		},
+		NodeExecutions: []*models.NodeExecution{
+			{NodeID: "fetch", Output: map[string]any{"status_code": 200}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		assertion models.CanaryAssertion
+		passed    bool
+	}{
+		{"eq string", models.CanaryAssertion{Path: "status", Op: models.CanaryOpEquals, Value: "ok"}, true},
+		{"eq int vs float", models.CanaryAssertion{Path: "count", Op: models.CanaryOpEquals, Value: 3.0}, true},
+		{"neq", models.CanaryAssertion{Path: "status", Op: models.CanaryOpNotEquals, Value: "error"}, true},
+		{"exists", models.CanaryAssertion{Path: "user.email", Op: models.CanaryOpExists}, true},
+		{"exists missing", models.CanaryAssertion{Path: "user.name", Op: models.CanaryOpExists}, false},
+		{"not exists", models.CanaryAssertion{Path: "error", Op: models.CanaryOpNotExists}, true},
+		{"array index", models.CanaryAssertion{Path: "items.1", Op: models.CanaryOpEquals, Value: "b"}, true},
+		{"contains array", models.CanaryAssertion{Path: "items", Op: models.CanaryOpContains, Value: "a"}, true},
+		{"contains string", models.CanaryAssertion{Path: "user.email", Op: models.CanaryOpContains, Value: "@example"}, true},
+		{"gt", models.CanaryAssertion{Path: "count", Op: models.CanaryOpGreater, Value: 2}, true},
+		{"lte fails", models.CanaryAssertion{Path: "count", Op: models.CanaryOpLessOrEq, Value: 2}, false},
+		{"matches", models.CanaryAssertion{Path: "user.email", Op: models.CanaryOpMatches, Value: `^[a-z]+@`}, true},
+		{"node output", models.CanaryAssertion{NodeID: "fetch", Path: "status_code", Op: models.CanaryOpEquals, Value: 200}, true},
+		{"node did not run", models.CanaryAssertion{NodeID: "missing", Path: "x", Op: models.CanaryOpExists}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := Evaluate(execution, []models.CanaryAssertion{tt.assertion})
+			assert.Len(t, results, 1)
+			assert.Equal(t, tt.passed, results[0].Passed, results[0].Message)
+			if !tt.passed {
+				assert.NotEmpty(t, results[0].Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,396 @@
+// Package canary runs workflows on a schedule as synthetic monitors, checks their
+// output with assertions and raises alerts when an integration stops working.
+package canary
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+
+	"github.com/smilemakc/mbflow/go/internal/application/engine"
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+var (
+	// ErrRunInProgress is returned when a canary is already running.
+	ErrRunInProgress = errors.New("canary run already in progress")
+
+	// ErrInvalidSchedule is returned for schedules that cannot be parsed.
+	ErrInvalidSchedule = errors.New("invalid canary schedule")
+)
+
+// scheduleParser accepts standard 5-field cron, 6-field cron with seconds and descriptors like "@every 5m".
+var scheduleParser = cron.NewParser(
+	cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
+)
+
+// WorkflowRunner executes a stored workflow synchronously.
+type WorkflowRunner interface {
+	Execute(ctx context.Context, workflowID string, input map[string]any, opts *engine.ExecutionOptions) (*models.Execution, error)
+}
+
+// WorkflowFinder looks up stored workflows.
+type WorkflowFinder interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*storagemodels.WorkflowModel, error)
+}
+
+// Config holds canary service settings.
+type Config struct {
+	// FailureThreshold is the number of consecutive failed runs before a failing alert is sent.
+	FailureThreshold int
+	// RunTimeout bounds a single canary execution.
+	RunTimeout time.Duration
+}
+
+// Service manages canary definitions, schedules runs and raises alerts.
+type Service struct {
+	config    Config
+	repo      repository.CanaryRepository
+	workflows WorkflowFinder
+	runner    WorkflowRunner
+	sinks     []AlertSink
+	logger    *logger.Logger
+
+	cron    *cron.Cron
+	entries map[string]cron.EntryID // workflowID -> entryID
+	running map[string]bool
+	mu      sync.Mutex
+}
+
+// NewService creates a new canary service.
+func NewService(
+	cfg Config,
+	repo repository.CanaryRepository,
+	workflows WorkflowFinder,
+	runner WorkflowRunner,
+	log *logger.Logger,
+	sinks ...AlertSink,
+) *Service {
+	if cfg.FailureThreshold < 1 {
+		cfg.FailureThreshold = 1
+	}
+	if cfg.RunTimeout <= 0 {
+		cfg.RunTimeout = 5 * time.Minute
+	}
+
+	return &Service{
+		config:    cfg,
+		repo:      repo,
+		workflows: workflows,
+		runner:    runner,
+		sinks:     sinks,
+		logger:    log,
+		cron:      cron.New(cron.WithLocation(time.UTC)),
+		entries:   make(map[string]cron.EntryID),
+		running:   make(map[string]bool),
+	}
+}
+
+// Start schedules all enabled canaries and starts the scheduler.
+func (s *Service) Start(ctx context.Context) error {
+	canaries, err := s.repo.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load canaries: %w", err)
+	}
+
+	s.mu.Lock()
+	for _, c := range canaries {
+		if err := s.scheduleLocked(c); err != nil {
+			s.logger.Warn("Failed to schedule canary", "workflow_id", c.WorkflowID, "error", err)
+		}
+	}
+	s.mu.Unlock()
+
+	s.cron.Start()
+	return nil
+}
+
+// Stop stops the scheduler and waits for running canaries to finish.
+func (s *Service) Stop() {
+	<-s.cron.Stop().Done()
+}
+
+// Save creates or updates a canary and reschedules it.
+func (s *Service) Save(ctx context.Context, canary *models.Canary) (*models.Canary, error) {
+	if err := canary.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := scheduleParser.Parse(canary.Schedule); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+	}
+
+	workflowID, err := uuid.Parse(canary.WorkflowID)
+	if err != nil {
+		return nil, models.ErrInvalidWorkflowID
+	}
+	if _, err := s.workflows.FindByID(ctx, workflowID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrWorkflowNotFound
+		}
+		return nil, fmt.Errorf("failed to load workflow: %w", err)
+	}
+
+	if err := s.repo.Save(ctx, canary); err != nil {
+		return nil, err
+	}
+
+	saved, err := s.repo.GetByWorkflowID(ctx, canary.WorkflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	err = s.scheduleLocked(saved)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+// Get returns the canary of a workflow.
+func (s *Service) Get(ctx context.Context, workflowID string) (*models.Canary, error) {
+	return s.repo.GetByWorkflowID(ctx, workflowID)
+}
+
+// List returns all canaries.
+func (s *Service) List(ctx context.Context) ([]*models.Canary, error) {
+	return s.repo.List(ctx)
+}
+
+// Delete removes a canary and stops its schedule.
+func (s *Service) Delete(ctx context.Context, workflowID string) error {
+	if err := s.repo.Delete(ctx, workflowID); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.unscheduleLocked(workflowID)
+	s.mu.Unlock()
+	return nil
+}
+
+// Runs returns the most recent runs of a canary.
+func (s *Service) Runs(ctx context.Context, workflowID string, limit int) ([]*models.CanaryRun, error) {
+	if _, err := s.repo.GetByWorkflowID(ctx, workflowID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListRuns(ctx, workflowID, limit)
+}
+
+// Summary aggregates canary health for the admin overview.
+func (s *Service) Summary(ctx context.Context) (*models.CanarySummary, error) {
+	canaries, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &models.CanarySummary{
+		Total:    len(canaries),
+		Failures: []*models.Canary{},
+	}
+	for _, c := range canaries {
+		if c.Enabled {
+			summary.Enabled++
+		}
+		switch c.Status {
+		case models.CanaryStatusPassing:
+			summary.Passing++
+		case models.CanaryStatusFailing:
+			summary.Failing++
+			summary.Failures = append(summary.Failures, c)
+		default:
+			summary.Unknown++
+		}
+	}
+	return summary, nil
+}
+
+// Run executes a canary now, evaluates its assertions, records the result and sends alerts
+// on state transitions. Assertion failures are reported in the run, not as an error.
+func (s *Service) Run(ctx context.Context, workflowID string) (*models.CanaryRun, error) {
+	canary, err := s.repo.GetByWorkflowID(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.acquire(workflowID) {
+		return nil, ErrRunInProgress
+	}
+	defer s.release(workflowID)
+
+	run := s.execute(ctx, canary)
+
+	previousStatus := canary.Status
+	previousFailures := canary.ConsecutiveFailures
+
+	canary.LastRunAt = &run.CreatedAt
+	canary.LastExecutionID = run.ExecutionID
+	canary.LastError = run.Error
+	if run.Passed {
+		canary.Status = models.CanaryStatusPassing
+		canary.ConsecutiveFailures = 0
+	} else {
+		canary.Status = models.CanaryStatusFailing
+		canary.ConsecutiveFailures++
+	}
+
+	if err := s.repo.RecordRun(ctx, canary, run); err != nil {
+		return nil, fmt.Errorf("failed to record canary run: %w", err)
+	}
+
+	switch {
+	case !run.Passed && canary.ConsecutiveFailures == s.config.FailureThreshold:
+		s.sendAlert(ctx, canary, run, AlertTypeFailing)
+	case run.Passed && previousStatus == models.CanaryStatusFailing && previousFailures >= s.config.FailureThreshold:
+		s.sendAlert(ctx, canary, run, AlertTypeRecovered)
+	}
+
+	return run, nil
+}
+
+// execute runs the workflow and evaluates the result.
+func (s *Service) execute(ctx context.Context, canary *models.Canary) *models.CanaryRun {
+	runCtx, cancel := context.WithTimeout(ctx, s.config.RunTimeout)
+	defer cancel()
+
+	input := make(map[string]any, len(canary.Input))
+	for k, v := range canary.Input {
+		input[k] = v
+	}
+
+	run := &models.CanaryRun{
+		WorkflowID: canary.WorkflowID,
+		CreatedAt:  time.Now(),
+	}
+
+	execution, execErr := s.runner.Execute(runCtx, canary.WorkflowID, input, nil)
+	run.DurationMs = time.Since(run.CreatedAt).Milliseconds()
+	if execution != nil {
+		run.ExecutionID = execution.ID
+		if execution.Duration > 0 {
+			run.DurationMs = execution.Duration
+		}
+	}
+
+	switch {
+	case execErr != nil:
+		run.Error = execErr.Error()
+		return run
+	case execution == nil || execution.Status != models.ExecutionStatusCompleted:
+		status := "unknown"
+		if execution != nil {
+			status = string(execution.Status)
+		}
+		run.Error = "execution finished with status " + status
+		return run
+	}
+
+	run.Results = Evaluate(execution, canary.Assertions)
+
+	failed := 0
+	for _, r := range run.Results {
+		if !r.Passed {
+			failed++
+		}
+	}
+
+	switch {
+	case failed > 0:
+		run.Error = fmt.Sprintf("%d of %d assertions failed", failed, len(run.Results))
+	case canary.MaxDurationMs > 0 && run.DurationMs > canary.MaxDurationMs:
+		run.Error = fmt.Sprintf("execution took %dms, limit is %dms", run.DurationMs, canary.MaxDurationMs)
+	default:
+		run.Passed = true
+	}
+
+	return run
+}
+
+func (s *Service) sendAlert(ctx context.Context, canary *models.Canary, run *models.CanaryRun, alertType AlertType) {
+	name := canary.WorkflowName
+	if name == "" {
+		name = canary.WorkflowID
+	}
+
+	alert := Alert{
+		Type:                alertType,
+		WorkflowID:          canary.WorkflowID,
+		WorkflowName:        canary.WorkflowName,
+		ExecutionID:         run.ExecutionID,
+		RunbookURL:          canary.RunbookURL,
+		ConsecutiveFailures: canary.ConsecutiveFailures,
+		Timestamp:           run.CreatedAt,
+	}
+
+	if alertType == AlertTypeFailing {
+		alert.Message = fmt.Sprintf("Canary %q is failing: %s", name, run.Error)
+		for _, r := range run.Results {
+			if !r.Passed {
+				alert.Failures = append(alert.Failures, r.Message)
+			}
+		}
+	} else {
+		alert.Message = fmt.Sprintf("Canary %q recovered", name)
+	}
+
+	for _, sink := range s.sinks {
+		if err := sink.Send(ctx, alert); err != nil {
+			s.logger.Error("Failed to deliver canary alert", "sink", sink.Name(), "workflow_id", canary.WorkflowID, "error", err)
+		}
+	}
+}
+
+// scheduleLocked (re)schedules a canary; disabled canaries are unscheduled (must hold lock).
+func (s *Service) scheduleLocked(canary *models.Canary) error {
+	s.unscheduleLocked(canary.WorkflowID)
+	if !canary.Enabled {
+		return nil
+	}
+
+	schedule, err := scheduleParser.Parse(canary.Schedule)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+	}
+
+	workflowID := canary.WorkflowID
+	s.entries[workflowID] = s.cron.Schedule(schedule, cron.FuncJob(func() {
+		if _, err := s.Run(context.Background(), workflowID); err != nil && !errors.Is(err, ErrRunInProgress) {
+			s.logger.Error("Canary run failed", "workflow_id", workflowID, "error", err)
+		}
+	}))
+	return nil
+}
+
+func (s *Service) unscheduleLocked(workflowID string) {
+	if entryID, ok := s.entries[workflowID]; ok {
+		s.cron.Remove(entryID)
+		delete(s.entries, workflowID)
+	}
+}
+
+func (s *Service) acquire(workflowID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running[workflowID] {
+		return false
+	}
+	s.running[workflowID] = true
+	return true
+}
+
+func (s *Service) release(workflowID string) {
+	s.mu.Lock()
+	delete(s.running, workflowID)
+	s.mu.Unlock()
+}
@@ -0,0 +1,246 @@
+package canary
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smilemakc/mbflow/go/internal/application/engine"
+	"github.com/smilemakc/mbflow/go/internal/config"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+type mockCanaryRepo struct {
+	mu       sync.Mutex
+	canaries map[string]*models.Canary
+	runs     []*models.CanaryRun
+}
+
+func newMockCanaryRepo() *mockCanaryRepo {
+	return &mockCanaryRepo{canaries: make(map[string]*models.Canary)}
+}
+
+func (m *mockCanaryRepo) Save(ctx context.Context, canary *models.Canary) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c := *canary
+	if existing, ok := m.canaries[canary.WorkflowID]; ok {
+		c.Status = existing.Status
+		c.ConsecutiveFailures = existing.ConsecutiveFailures
+	} else {
+		c.Status = models.CanaryStatusUnknown
+	}
+	m.canaries[canary.WorkflowID] = &c
+	return nil
+}
+
+func (m *mockCanaryRepo) GetByWorkflowID(ctx context.Context, workflowID string) (*models.Canary, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.canaries[workflowID]
+	if !ok {
+		return nil, models.ErrCanaryNotFound
+	}
+	copied := *c
+	return &copied, nil
+}
+
+func (m *mockCanaryRepo) List(ctx context.Context) ([]*models.Canary, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := make([]*models.Canary, 0, len(m.canaries))
+	for _, c := range m.canaries {
+		copied := *c
+		result = append(result, &copied)
+	}
+	return result, nil
+}
+
+func (m *mockCanaryRepo) Delete(ctx context.Context, workflowID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.canaries[workflowID]; !ok {
+		return models.ErrCanaryNotFound
+	}
+	delete(m.canaries, workflowID)
+	return nil
+}
+
+func (m *mockCanaryRepo) RecordRun(ctx context.Context, canary *models.Canary, run *models.CanaryRun) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c := *canary
+	m.canaries[canary.WorkflowID] = &c
+	m.runs = append(m.runs, run)
+	return nil
+}
+
+func (m *mockCanaryRepo) ListRuns(ctx context.Context, workflowID string, limit int) ([]*models.CanaryRun, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.runs, nil
+}
+
+type mockWorkflowFinder struct{}
+
+func (m *mockWorkflowFinder) FindByID(ctx context.Context, id uuid.UUID) (*storagemodels.WorkflowModel, error) {
+	return &storagemodels.WorkflowModel{ID: id}, nil
+}
+
+type mockRunner struct {
+	execution *models.Execution
+	err       error
+	block     chan struct{}
+}
+
+func (m *mockRunner) Execute(ctx context.Context, workflowID string, input map[string]any, opts *engine.ExecutionOptions) (*models.Execution, error) {
+	if m.block != nil {
+		<-m.block
+	}
+	return m.execution, m.err
+}
+
+type recordingSink struct {
+	mu     sync.Mutex
+	alerts []Alert
+}
+
+func (s *recordingSink) Name() string { return "recording" }
+
+func (s *recordingSink) Send(ctx context.Context, alert Alert) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.alerts = append(s.alerts, alert)
+	return nil
+}
+
+func newTestService(t *testing.T, threshold int, runner *mockRunner) (*Service, *mockCanaryRepo, *recordingSink, string) {
+	t.Helper()
+	repo := newMockCanaryRepo()
+	sink := &recordingSink{}
+	log := logger.New(config.LoggingConfig{Level: "error", Format: "json"})
+	svc := NewService(Config{FailureThreshold: threshold}, repo, &mockWorkflowFinder{}, runner, log, sink)
+
+	workflowID := uuid.New().String()
+	_, err := svc.Save(context.Background(), &models.Canary{
+		WorkflowID: workflowID,
+		Schedule:   "@every 1h",
+		Assertions: []models.CanaryAssertion{{Path: "status", Op: models.CanaryOpEquals, Value: "ok"}},
+		Enabled:    true,
+	})
+	require.NoError(t, err)
+	return svc, repo, sink, workflowID
+}
+
+func completed(output map[string]any) *models.Execution {
+	return &models.Execution{ID: uuid.New().String(), Status: models.ExecutionStatusCompleted, Output: output, Duration: 10}
+}
+
+func TestService_Save_InvalidSchedule(t *testing.T) {
+	svc, _, _, workflowID := newTestService(t, 1, &mockRunner{})
+
+	_, err := svc.Save(context.Background(), &models.Canary{WorkflowID: workflowID, Schedule: "not a cron"})
+	assert.ErrorIs(t, err, ErrInvalidSchedule)
+}
+
+func TestService_Run_AlertsOnThresholdAndRecovery(t *testing.T) {
+	runner := &mockRunner{execution: completed(map[string]any{"status": "down"})}
+	svc, repo, sink, workflowID := newTestService(t, 2, runner)
+	ctx := context.Background()
+
+	run, err := svc.Run(ctx, workflowID)
+	require.NoError(t, err)
+	assert.False(t, run.Passed)
+	assert.Empty(t, sink.alerts, "no alert below threshold")
+
+	_, err = svc.Run(ctx, workflowID)
+	require.NoError(t, err)
+	require.Len(t, sink.alerts, 1)
+	assert.Equal(t, AlertTypeFailing, sink.alerts[0].Type)
+	assert.Equal(t, 2, sink.alerts[0].ConsecutiveFailures)
+
+	_, err = svc.Run(ctx, workflowID)
+	require.NoError(t, err)
+	assert.Len(t, sink.alerts, 1, "failing alert is sent once per incident")
+
+	runner.execution = completed(map[string]any{"status": "ok"})
+	run, err = svc.Run(ctx, workflowID)
+	require.NoError(t, err)
+	assert.True(t, run.Passed)
+	require.Len(t, sink.alerts, 2)
+	assert.Equal(t, AlertTypeRecovered, sink.alerts[1].Type)
+
+	stored, err := repo.GetByWorkflowID(ctx, workflowID)
+	require.NoError(t, err)
+	assert.Equal(t, models.CanaryStatusPassing, stored.Status)
+	assert.Zero(t, stored.ConsecutiveFailures)
+	assert.Len(t, repo.runs, 4)
+
+	summary, err := svc.Summary(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.Passing)
+	assert.Empty(t, summary.Failures)
+}
+
+func TestService_Run_ExecutionError(t *testing.T) {
+	runner := &mockRunner{err: errors.New("connection refused")}
+	svc, _, sink, workflowID := newTestService(t, 1, runner)
+
+	run, err := svc.Run(context.Background(), workflowID)
+	require.NoError(t, err)
+	assert.False(t, run.Passed)
+	assert.Contains(t, run.Error, "connection refused")
+	require.Len(t, sink.alerts, 1)
+	assert.Equal(t, AlertTypeFailing, sink.alerts[0].Type)
+}
+
+func TestService_Run_MaxDuration(t *testing.T) {
+	execution := completed(map[string]any{"status": "ok"})
+	execution.Duration = 5000
+	svc, repo, _, workflowID := newTestService(t, 1, &mockRunner{execution: execution})
+
+	repo.canaries[workflowID].MaxDurationMs = 1000
+
+	run, err := svc.Run(context.Background(), workflowID)
+	require.NoError(t, err)
+	assert.False(t, run.Passed)
+	assert.Contains(t, run.Error, "limit is 1000ms")
+}
+
+func TestService_Run_InProgress(t *testing.T) {
+	runner := &mockRunner{execution: completed(map[string]any{"status": "ok"}), block: make(chan struct{})}
+	svc, _, _, workflowID := newTestService(t, 1, runner)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := svc.Run(context.Background(), workflowID)
+		done <- err
+	}()
+
+	require.Eventually(t, func() bool {
+		svc.mu.Lock()
+		defer svc.mu.Unlock()
+		return svc.running[workflowID]
+	}, time.Second, 5*time.Millisecond)
+
+	_, err := svc.Run(context.Background(), workflowID)
+	assert.ErrorIs(t, err, ErrRunInProgress)
+
+	close(runner.block)
+	require.NoError(t, <-done)
+}
+
+func TestService_Run_NotFound(t *testing.T) {
+	svc, _, _, _ := newTestService(t, 1, &mockRunner{})
+
+	_, err := svc.Run(context.Background(), uuid.New().String())
+	assert.ErrorIs(t, err, models.ErrCanaryNotFound)
+}
@@ -0,0 +1,263 @@
+// Package coldstorage moves node execution payloads of old executions out of Postgres
+// into file or object storage and hydrates them back on demand, so the hot database
+// only keeps lightweight node execution rows while history stays accessible. It also
+// purges the payloads of nodes whose retention data label (e.g. retain-7d) expired, and
+// moves whole executions past their retention period out of the database into compressed
+// archives that can be restored.
+package coldstorage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/internal/application/filestorage"
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// ErrArchiveInProgress is returned when an archive run is already running.
+var ErrArchiveInProgress = errors.New("payload archive already in progress")
+
+// DefaultStorageID is the file storage that receives archived payloads.
+const DefaultStorageID = "payload-archive"
+
+// Config holds archiver settings.
+type Config struct {
+	// Interval between archive runs.
+	Interval time.Duration
+	// ArchiveAfter is how long after an execution finished its node payloads are moved to cold storage.
+	ArchiveAfter time.Duration
+	// BatchSize is the number of node executions loaded per query.
+	BatchSize int
+	// PurgeOnly skips archiving, so a run only purges payloads whose retention label expired.
+	PurgeOnly bool
+}
+
+// ArchiveResult describes the work done by one archive run.
+type ArchiveResult struct {
+	Cutoff                 time.Time `json:"cutoff"`
+	ArchivedNodeExecutions int       `json:"archived_node_executions"`
+	ArchivedBytes          int64     `json:"archived_bytes"`
+	PurgedNodeExecutions   int       `json:"purged_node_executions"`
+}
+
+// payload is the document stored for one node execution.
+type payload struct {
+	Input          map[string]any `json:"input,omitempty"`
+	Output         map[string]any `json:"output,omitempty"`
+	Config         map[string]any `json:"config,omitempty"`
+	ResolvedConfig map[string]any `json:"resolved_config,omitempty"`
+}
+
+// Archiver moves node execution payloads to cold storage on an interval and hydrates them on read.
+type Archiver struct {
+	config  Config
+	repo    repository.PayloadArchiveRepository
+	storage filestorage.Storage
+	logger  *logger.Logger
+	now     func() time.Time
+
+	runMu sync.Mutex
+	done  chan struct{}
+	wg    sync.WaitGroup
+}
+
+// NewArchiver creates a new payload archiver.
+func NewArchiver(cfg Config, repo repository.PayloadArchiveRepository, storage filestorage.Storage, log *logger.Logger) *Archiver {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Hour
+	}
+	if cfg.ArchiveAfter <= 0 {
+		cfg.ArchiveAfter = 30 * 24 * time.Hour
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 500
+	}
+
+	return &Archiver{
+		config:  cfg,
+		repo:    repo,
+		storage: storage,
+		logger:  log,
+		now:     time.Now,
+	}
+}
+
+// Start runs an archive pass immediately and then on every interval until Stop is called.
+func (a *Archiver) Start() {
+	a.done = make(chan struct{})
+	a.wg.Add(1)
+	go a.loop()
+}
+
+// Stop stops the archive loop and waits for a running pass to finish.
+func (a *Archiver) Stop() {
+	if a.done == nil {
+		return
+	}
+	close(a.done)
+	a.wg.Wait()
+	a.done = nil
+}
+
+func (a *Archiver) loop() {
+	defer a.wg.Done()
+
+	ticker := time.NewTicker(a.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := a.RunOnce(context.Background()); err != nil && !errors.Is(err, ErrArchiveInProgress) {
+			a.logger.Error("Node payload archive failed", "error", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-a.done:
+			return
+		}
+	}
+}
+
+// RunOnce purges the payloads whose retention label expired and then moves the payloads of all
+// node executions of executions finished before the cutoff to cold storage. Each payload is
+// written before its row is cleared, so a failed run never loses data.
+func (a *Archiver) RunOnce(ctx context.Context) (*ArchiveResult, error) {
+	if !a.runMu.TryLock() {
+		return nil, ErrArchiveInProgress
+	}
+	defer a.runMu.Unlock()
+
+	now := a.now().UTC()
+	result := &ArchiveResult{Cutoff: now.Add(-a.config.ArchiveAfter)}
+
+	if err := a.purgeExpired(ctx, now, result); err != nil {
+		return result, err
+	}
+	if a.config.PurgeOnly {
+		return result, nil
+	}
+
+	for {
+		batch, err := a.repo.FindArchivable(ctx, result.Cutoff, a.config.BatchSize)
+		if err != nil {
+			return result, err
+		}
+
+		for _, ne := range batch {
+			size, err := a.archive(ctx, ne, now)
+			if err != nil {
+				return result, err
+			}
+			result.ArchivedNodeExecutions++
+			result.ArchivedBytes += size
+		}
+
+		if len(batch) < a.config.BatchSize {
+			break
+		}
+	}
+
+	a.logger.Debug("Node payloads archived",
+		"cutoff", result.Cutoff,
+		"node_executions", result.ArchivedNodeExecutions,
+		"bytes", result.ArchivedBytes,
+	)
+
+	return result, nil
+}
+
+// purgeExpired clears the payloads whose retention label expired, deleting archived copies first.
+func (a *Archiver) purgeExpired(ctx context.Context, now time.Time, result *ArchiveResult) error {
+	for {
+		batch, err := a.repo.FindExpired(ctx, now, a.config.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, ne := range batch {
+			if ne.IsPayloadArchived() {
+				if err := a.storage.Delete(ctx, *ne.PayloadRef); err != nil {
+					return fmt.Errorf("failed to delete archived payload of node execution %s: %w", ne.ID, err)
+				}
+			}
+			if err := a.repo.MarkPurged(ctx, ne.ID, now); err != nil {
+				return err
+			}
+			result.PurgedNodeExecutions++
+		}
+
+		if len(batch) < a.config.BatchSize {
+			break
+		}
+	}
+
+	if result.PurgedNodeExecutions > 0 {
+		a.logger.Info("Expired node payloads purged", "node_executions", result.PurgedNodeExecutions)
+	}
+	return nil
+}
+
+// archive stores the payload of one node execution and clears it from the row.
+func (a *Archiver) archive(ctx context.Context, ne *storagemodels.NodeExecutionModel, now time.Time) (int64, error) {
+	data, err := json.Marshal(payload{
+		Input:          ne.InputData,
+		Output:         ne.OutputData,
+		Config:         ne.Config,
+		ResolvedConfig: ne.ResolvedConfig,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode payload of node execution %s: %w", ne.ID, err)
+	}
+
+	executionID := ne.ExecutionID.String()
+	entry, err := a.storage.Store(ctx, &models.FileEntry{
+		Name:        ne.ID.String() + ".json",
+		Path:        fmt.Sprintf("node-payloads/%s/%s.json", executionID, ne.ID),
+		MimeType:    "application/json",
+		Size:        int64(len(data)),
+		ExecutionID: &executionID,
+	}, bytes.NewReader(data))
+	if err != nil {
+		return 0, fmt.Errorf("failed to store payload of node execution %s: %w", ne.ID, err)
+	}
+
+	if err := a.repo.MarkArchived(ctx, ne.ID, entry.Path, now); err != nil {
+		return 0, err
+	}
+	return int64(len(data)), nil
+}
+
+// Hydrate loads archived payloads back into the given node executions.
+// Node executions whose payload is still in the database are left untouched.
+func (a *Archiver) Hydrate(ctx context.Context, nodeExecutions []*storagemodels.NodeExecutionModel) error {
+	for _, ne := range nodeExecutions {
+		if !ne.IsPayloadArchived() {
+			continue
+		}
+
+		_, reader, err := a.storage.Get(ctx, *ne.PayloadRef)
+		if err != nil {
+			return fmt.Errorf("failed to load payload of node execution %s: %w", ne.ID, err)
+		}
+		var p payload
+		err = json.NewDecoder(reader).Decode(&p)
+		reader.Close()
+		if err != nil {
+			return fmt.Errorf("failed to decode payload of node execution %s: %w", ne.ID, err)
+		}
+
+		ne.InputData = p.Input
+		ne.OutputData = p.Output
+		ne.Config = p.Config
+		ne.ResolvedConfig = p.ResolvedConfig
+	}
+	return nil
+}
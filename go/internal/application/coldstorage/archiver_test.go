@@ -0,0 +1,232 @@
+package coldstorage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smilemakc/mbflow/go/internal/application/filestorage"
+	"github.com/smilemakc/mbflow/go/internal/config"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+)
+
+type mockArchiveRepo struct {
+	nodeExecutions []*storagemodels.NodeExecutionModel
+	cutoffs        []time.Time
+	markErr        error
+}
+
+func (m *mockArchiveRepo) FindArchivable(ctx context.Context, cutoff time.Time, limit int) ([]*storagemodels.NodeExecutionModel, error) {
+	m.cutoffs = append(m.cutoffs, cutoff)
+
+	var batch []*storagemodels.NodeExecutionModel
+	for _, ne := range m.nodeExecutions {
+		if !ne.IsPayloadArchived() && !ne.IsPayloadPurged() && len(batch) < limit {
+			batch = append(batch, ne)
+		}
+	}
+	return batch, nil
+}
+
+func (m *mockArchiveRepo) MarkArchived(ctx context.Context, id uuid.UUID, ref string, archivedAt time.Time) error {
+	if m.markErr != nil {
+		return m.markErr
+	}
+	for _, ne := range m.nodeExecutions {
+		if ne.ID == id {
+			ne.PayloadRef = &ref
+			ne.PayloadArchivedAt = &archivedAt
+			ne.InputData = storagemodels.JSONBMap{}
+			ne.OutputData = nil
+			ne.Config = storagemodels.JSONBMap{}
+			ne.ResolvedConfig = storagemodels.JSONBMap{}
+		}
+	}
+	return nil
+}
+
+func (m *mockArchiveRepo) FindExpired(ctx context.Context, now time.Time, limit int) ([]*storagemodels.NodeExecutionModel, error) {
+	var batch []*storagemodels.NodeExecutionModel
+	for _, ne := range m.nodeExecutions {
+		if ne.PayloadExpiresAt != nil && !ne.PayloadExpiresAt.After(now) && !ne.IsPayloadPurged() && len(batch) < limit {
+			batch = append(batch, ne)
+		}
+	}
+	return batch, nil
+}
+
+func (m *mockArchiveRepo) MarkPurged(ctx context.Context, id uuid.UUID, purgedAt time.Time) error {
+	for _, ne := range m.nodeExecutions {
+		if ne.ID == id {
+			ne.PayloadPurgedAt = &purgedAt
+			ne.PayloadRef = nil
+			ne.InputData = storagemodels.JSONBMap{}
+			ne.OutputData = nil
+			ne.Config = storagemodels.JSONBMap{}
+			ne.ResolvedConfig = storagemodels.JSONBMap{}
+		}
+	}
+	return nil
+}
+
+func newTestArchiver(t *testing.T, cfg Config, repo *mockArchiveRepo, now time.Time) *Archiver {
+	t.Helper()
+
+	manager := filestorage.NewStorageManager(&filestorage.ManagerConfig{BasePath: t.TempDir()}, nil)
+	t.Cleanup(func() { manager.Close() })
+	store, err := manager.GetStorage(DefaultStorageID)
+	require.NoError(t, err)
+
+	log := logger.New(config.LoggingConfig{Level: "error", Format: "json"})
+	a := NewArchiver(cfg, repo, store, log)
+	a.now = func() time.Time { return now }
+	return a
+}
+
+func newNodeExecution(executionID uuid.UUID) *storagemodels.NodeExecutionModel {
+	return &storagemodels.NodeExecutionModel{
+		ID:             uuid.New(),
+		ExecutionID:    executionID,
+		Status:         "completed",
+		InputData:      storagemodels.JSONBMap{"city": "Berlin"},
+		OutputData:     storagemodels.JSONBMap{"temperature": float64(21)},
+		Config:         storagemodels.JSONBMap{"url": "{{input.url}}"},
+		ResolvedConfig: storagemodels.JSONBMap{"url": "https://example.com"},
+	}
+}
+
+func TestArchiver_RunOnce_ArchivesAndHydrates(t *testing.T) {
+	executionID := uuid.New()
+	repo := &mockArchiveRepo{nodeExecutions: []*storagemodels.NodeExecutionModel{
+		newNodeExecution(executionID),
+		newNodeExecution(executionID),
+		newNodeExecution(executionID),
+	}}
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	a := newTestArchiver(t, Config{ArchiveAfter: 7 * 24 * time.Hour, BatchSize: 2}, repo, now)
+
+	result, err := a.RunOnce(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, now.Add(-7*24*time.Hour), result.Cutoff)
+	assert.Equal(t, 3, result.ArchivedNodeExecutions)
+	assert.Positive(t, result.ArchivedBytes)
+	// A full batch of two, then a short batch ends the run
+	assert.Len(t, repo.cutoffs, 2)
+
+	ne := repo.nodeExecutions[0]
+	require.True(t, ne.IsPayloadArchived())
+	assert.Equal(t, "node-payloads/"+executionID.String()+"/"+ne.ID.String()+".json", *ne.PayloadRef)
+	assert.Empty(t, ne.InputData)
+	assert.Nil(t, ne.OutputData)
+
+	require.NoError(t, a.Hydrate(context.Background(), repo.nodeExecutions))
+	for _, ne := range repo.nodeExecutions {
+		assert.Equal(t, "Berlin", ne.InputData["city"])
+		assert.Equal(t, float64(21), ne.OutputData["temperature"])
+		assert.Equal(t, "{{input.url}}", ne.Config["url"])
+		assert.Equal(t, "https://example.com", ne.ResolvedConfig["url"])
+	}
+}
+
+func TestArchiver_RunOnce_PurgesExpiredPayloads(t *testing.T) {
+	executionID := uuid.New()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	expired := now.Add(-time.Hour)
+	later := now.Add(time.Hour)
+
+	kept := newNodeExecution(executionID)
+	inRow := newNodeExecution(executionID)
+	inRow.PayloadExpiresAt = &expired
+	archived := newNodeExecution(executionID)
+	archived.PayloadExpiresAt = &expired
+	notYet := newNodeExecution(executionID)
+	notYet.PayloadExpiresAt = &later
+
+	repo := &mockArchiveRepo{nodeExecutions: []*storagemodels.NodeExecutionModel{archived}}
+	a := newTestArchiver(t, Config{ArchiveAfter: time.Minute}, repo, now.Add(-2*time.Hour))
+	_, err := a.RunOnce(context.Background())
+	require.NoError(t, err)
+	require.True(t, archived.IsPayloadArchived())
+	ref := *archived.PayloadRef
+
+	repo.nodeExecutions = []*storagemodels.NodeExecutionModel{kept, inRow, archived, notYet}
+	a.now = func() time.Time { return now }
+	a.config.PurgeOnly = true
+
+	result, err := a.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.PurgedNodeExecutions)
+	assert.Equal(t, 0, result.ArchivedNodeExecutions, "purge-only runs do not archive")
+
+	for _, ne := range []*storagemodels.NodeExecutionModel{inRow, archived} {
+		assert.True(t, ne.IsPayloadPurged())
+		assert.False(t, ne.IsPayloadArchived())
+		assert.Empty(t, ne.InputData)
+	}
+	_, _, err = a.storage.Get(context.Background(), ref)
+	assert.Error(t, err, "archived copy is deleted")
+
+	assert.Equal(t, "Berlin", kept.InputData["city"])
+	assert.Equal(t, "Berlin", notYet.InputData["city"])
+	assert.False(t, kept.IsPayloadArchived())
+}
+
+func TestArchiver_RunOnce_KeepsPayloadWhenMarkFails(t *testing.T) {
+	repo := &mockArchiveRepo{
+		nodeExecutions: []*storagemodels.NodeExecutionModel{newNodeExecution(uuid.New())},
+		markErr:        errors.New("database unavailable"),
+	}
+	a := newTestArchiver(t, Config{}, repo, time.Now())
+
+	result, err := a.RunOnce(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, 0, result.ArchivedNodeExecutions)
+
+	ne := repo.nodeExecutions[0]
+	assert.False(t, ne.IsPayloadArchived())
+	assert.Equal(t, "Berlin", ne.InputData["city"])
+}
+
+func TestArchiver_Hydrate_SkipsRowsNotArchived(t *testing.T) {
+	ne := newNodeExecution(uuid.New())
+	a := newTestArchiver(t, Config{}, &mockArchiveRepo{}, time.Now())
+
+	require.NoError(t, a.Hydrate(context.Background(), []*storagemodels.NodeExecutionModel{ne}))
+	assert.Equal(t, "Berlin", ne.InputData["city"])
+}
+
+func TestArchiver_Hydrate_MissingPayload(t *testing.T) {
+	ne := newNodeExecution(uuid.New())
+	ref := "node-payloads/missing.json"
+	ne.PayloadRef = &ref
+	a := newTestArchiver(t, Config{}, &mockArchiveRepo{}, time.Now())
+
+	err := a.Hydrate(context.Background(), []*storagemodels.NodeExecutionModel{ne})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ne.ID.String())
+}
+
+func TestArchiver_RunOnce_RejectsConcurrentRun(t *testing.T) {
+	a := newTestArchiver(t, Config{}, &mockArchiveRepo{}, time.Now())
+
+	a.runMu.Lock()
+	defer a.runMu.Unlock()
+
+	_, err := a.RunOnce(context.Background())
+	assert.ErrorIs(t, err, ErrArchiveInProgress)
+}
+
+func TestNewArchiver_Defaults(t *testing.T) {
+	a := newTestArchiver(t, Config{}, &mockArchiveRepo{}, time.Now())
+
+	assert.Equal(t, time.Hour, a.config.Interval)
+	assert.Equal(t, 30*24*time.Hour, a.config.ArchiveAfter)
+	assert.Equal(t, 500, a.config.BatchSize)
+}
@@ -0,0 +1,309 @@
+package coldstorage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/smilemakc/mbflow/go/internal/application/filestorage"
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// ErrExecutionArchiveInProgress is returned when an execution archive run is already running.
+var ErrExecutionArchiveInProgress = errors.New("execution archive already in progress")
+
+// DefaultArchivePageSize is the page size used when the filter sets no limit.
+const DefaultArchivePageSize = 50
+
+// ExecutionArchiveConfig holds execution archiver settings.
+type ExecutionArchiveConfig struct {
+	// Interval between archive runs.
+	Interval time.Duration
+	// ArchiveAfter is how long after an execution finished it is moved out of the database.
+	ArchiveAfter time.Duration
+	// BatchSize is the number of executions selected per query.
+	BatchSize int
+}
+
+// ExecutionArchiveResult describes the work done by one execution archive run.
+type ExecutionArchiveResult struct {
+	Cutoff             time.Time `json:"cutoff"`
+	ArchivedExecutions int       `json:"archived_executions"`
+	ArchivedBytes      int64     `json:"archived_bytes"`
+	Failed             int       `json:"failed"`
+}
+
+// executionSummary holds the columns of an exported execution row the archive record keeps.
+type executionSummary struct {
+	ID          uuid.UUID  `json:"id"`
+	WorkflowID  *uuid.UUID `json:"workflow_id"`
+	Status      string     `json:"status"`
+	StartedAt   *time.Time `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+// ExecutionArchiver moves whole finished executions out of the database on an interval: the
+// rows of an execution are written gzipped to storage and deleted, leaving a small archive
+// record behind, until the execution is restored.
+type ExecutionArchiver struct {
+	config  ExecutionArchiveConfig
+	repo    repository.ExecutionArchiveRepository
+	storage filestorage.Storage
+	logger  *logger.Logger
+	now     func() time.Time
+
+	runMu sync.Mutex
+	done  chan struct{}
+	wg    sync.WaitGroup
+}
+
+// NewExecutionArchiver creates a new execution archiver.
+func NewExecutionArchiver(cfg ExecutionArchiveConfig, repo repository.ExecutionArchiveRepository, storage filestorage.Storage, log *logger.Logger) *ExecutionArchiver {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Hour
+	}
+	if cfg.ArchiveAfter <= 0 {
+		cfg.ArchiveAfter = 90 * 24 * time.Hour
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+
+	return &ExecutionArchiver{
+		config:  cfg,
+		repo:    repo,
+		storage: storage,
+		logger:  log,
+		now:     time.Now,
+	}
+}
+
+// Start runs an archive pass immediately and then on every interval until Stop is called.
+func (a *ExecutionArchiver) Start() {
+	a.done = make(chan struct{})
+	a.wg.Add(1)
+	go a.loop()
+}
+
+// Stop stops the archive loop and waits for a running pass to finish.
+func (a *ExecutionArchiver) Stop() {
+	if a.done == nil {
+		return
+	}
+	close(a.done)
+	a.wg.Wait()
+	a.done = nil
+}
+
+func (a *ExecutionArchiver) loop() {
+	defer a.wg.Done()
+
+	ticker := time.NewTicker(a.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := a.RunOnce(context.Background()); err != nil && !errors.Is(err, ErrExecutionArchiveInProgress) {
+			a.logger.Error("Execution archive failed", "error", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-a.done:
+			return
+		}
+	}
+}
+
+// RunOnce archives the executions finished before the cutoff. An execution that cannot be
+// archived is logged and left in the database, and the run moves on; it is retried on the
+// next run.
+func (a *ExecutionArchiver) RunOnce(ctx context.Context) (*ExecutionArchiveResult, error) {
+	if !a.runMu.TryLock() {
+		return nil, ErrExecutionArchiveInProgress
+	}
+	defer a.runMu.Unlock()
+
+	result := &ExecutionArchiveResult{Cutoff: a.now().UTC().Add(-a.config.ArchiveAfter)}
+	for {
+		ids, err := a.repo.FindArchivable(ctx, result.Cutoff, a.config.BatchSize)
+		if err != nil {
+			return result, err
+		}
+
+		archived := 0
+		for _, id := range ids {
+			archive, err := a.Archive(ctx, id)
+			if err != nil {
+				a.logger.Error("Failed to archive execution", "error", err, "execution_id", id)
+				result.Failed++
+				continue
+			}
+			archived++
+			result.ArchivedExecutions++
+			result.ArchivedBytes += archive.SizeBytes
+		}
+
+		// A batch in which nothing could be archived would be selected again
+		if len(ids) < a.config.BatchSize || archived == 0 {
+			break
+		}
+	}
+
+	if result.ArchivedExecutions > 0 || result.Failed > 0 {
+		a.logger.Info("Executions archived",
+			"cutoff", result.Cutoff,
+			"executions", result.ArchivedExecutions,
+			"bytes", result.ArchivedBytes,
+			"failed", result.Failed,
+		)
+	}
+	return result, nil
+}
+
+// Archive moves one execution, with its sub-workflow executions, out of the database. The
+// archive is stored before any row is deleted, so a failure never loses data.
+func (a *ExecutionArchiver) Archive(ctx context.Context, executionID uuid.UUID) (*models.ExecutionArchive, error) {
+	rows, err := a.repo.Export(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+	var summary executionSummary
+	if err := json.Unmarshal(rows.Executions[0], &summary); err != nil {
+		return nil, fmt.Errorf("failed to decode execution %s: %w", executionID, err)
+	}
+
+	data, err := compressRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode execution %s: %w", executionID, err)
+	}
+	entry, err := a.storage.Store(ctx, &models.FileEntry{
+		Name:     executionID.String() + ".json.gz",
+		Path:     fmt.Sprintf("executions/%s.json.gz", executionID),
+		MimeType: "application/gzip",
+		Size:     int64(len(data)),
+	}, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to store execution %s: %w", executionID, err)
+	}
+
+	record := &storagemodels.ExecutionArchiveModel{
+		ExecutionID:        executionID,
+		WorkflowID:         summary.WorkflowID,
+		Status:             summary.Status,
+		StartedAt:          summary.StartedAt,
+		CompletedAt:        summary.CompletedAt,
+		ArchiveRef:         entry.Path,
+		SizeBytes:          int64(len(data)),
+		ExecutionCount:     len(rows.Executions),
+		NodeExecutionCount: len(rows.NodeExecutions),
+		ArchivedAt:         a.now().UTC(),
+	}
+	if err := a.repo.Archive(ctx, record); err != nil {
+		if deleteErr := a.storage.Delete(ctx, entry.Path); deleteErr != nil {
+			a.logger.Warn("Failed to delete unused execution archive", "error", deleteErr, "path", entry.Path)
+		}
+		return nil, err
+	}
+	return record.ToDomain(), nil
+}
+
+// Restore moves an archived execution back into the database, as it was when it was
+// archived, and deletes its archive.
+func (a *ExecutionArchiver) Restore(ctx context.Context, executionID uuid.UUID) (*models.ExecutionArchive, error) {
+	record, err := a.repo.FindByExecutionID(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, reader, err := a.storage.Get(ctx, record.ArchiveRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load archive of execution %s: %w", executionID, err)
+	}
+	rows, err := decompressRows(reader)
+	reader.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode archive of execution %s: %w", executionID, err)
+	}
+
+	if err := a.repo.Restore(ctx, executionID, rows); err != nil {
+		return nil, err
+	}
+	if err := a.storage.Delete(ctx, record.ArchiveRef); err != nil {
+		a.logger.Warn("Failed to delete archive of restored execution", "error", err, "path", record.ArchiveRef)
+	}
+
+	a.logger.Info("Execution restored from archive", "execution_id", executionID, "executions", record.ExecutionCount)
+	return record.ToDomain(), nil
+}
+
+// Get returns the archive of an execution.
+func (a *ExecutionArchiver) Get(ctx context.Context, executionID uuid.UUID) (*models.ExecutionArchive, error) {
+	record, err := a.repo.FindByExecutionID(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+	return record.ToDomain(), nil
+}
+
+// List returns the archives matching the filter, most recently archived first, and their total count.
+func (a *ExecutionArchiver) List(ctx context.Context, filter models.ExecutionArchiveFilter) ([]*models.ExecutionArchive, int, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultArchivePageSize
+	}
+	if filter.Limit > models.MaxExecutionArchivePageSize {
+		filter.Limit = models.MaxExecutionArchivePageSize
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	records, total, err := a.repo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	archives := make([]*models.ExecutionArchive, len(records))
+	for i, record := range records {
+		archives[i] = record.ToDomain()
+	}
+	return archives, total, nil
+}
+
+func compressRows(rows *storagemodels.ExecutionRows) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(rows); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompressRows(r io.Reader) (*storagemodels.ExecutionRows, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	var rows storagemodels.ExecutionRows
+	if err := json.NewDecoder(zr).Decode(&rows); err != nil {
+		return nil, err
+	}
+	if len(rows.Executions) == 0 {
+		return nil, errors.New("archive holds no execution")
+	}
+	return &rows, nil
+}
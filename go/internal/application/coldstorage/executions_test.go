@@ -0,0 +1,159 @@
+package coldstorage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smilemakc/mbflow/go/internal/application/filestorage"
+	"github.com/smilemakc/mbflow/go/internal/config"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// mockExecutionArchiveRepo keeps the rows of finished executions and their archive records in memory.
+type mockExecutionArchiveRepo struct {
+	executions map[uuid.UUID]*storagemodels.ExecutionRows
+	archives   map[uuid.UUID]*storagemodels.ExecutionArchiveModel
+	archiveErr error
+}
+
+func newMockExecutionArchiveRepo() *mockExecutionArchiveRepo {
+	return &mockExecutionArchiveRepo{
+		executions: make(map[uuid.UUID]*storagemodels.ExecutionRows),
+		archives:   make(map[uuid.UUID]*storagemodels.ExecutionArchiveModel),
+	}
+}
+
+func (m *mockExecutionArchiveRepo) add(t *testing.T, completedAt time.Time) uuid.UUID {
+	t.Helper()
+	id := uuid.New()
+	row, err := json.Marshal(map[string]any{"id": id, "status": "completed", "completed_at": completedAt, "output_data": map[string]any{"total": 42}})
+	require.NoError(t, err)
+	m.executions[id] = &storagemodels.ExecutionRows{
+		Executions:     []json.RawMessage{row},
+		NodeExecutions: []json.RawMessage{json.RawMessage(fmt.Sprintf(`{"execution_id": %q, "status": "completed"}`, id))},
+	}
+	return id
+}
+
+func (m *mockExecutionArchiveRepo) FindArchivable(ctx context.Context, cutoff time.Time, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for id := range m.executions {
+		if len(ids) < limit {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (m *mockExecutionArchiveRepo) Export(ctx context.Context, executionID uuid.UUID) (*storagemodels.ExecutionRows, error) {
+	rows, ok := m.executions[executionID]
+	if !ok {
+		return nil, models.ErrExecutionNotFound
+	}
+	return rows, nil
+}
+
+func (m *mockExecutionArchiveRepo) Archive(ctx context.Context, archive *storagemodels.ExecutionArchiveModel) error {
+	if m.archiveErr != nil {
+		return m.archiveErr
+	}
+	m.archives[archive.ExecutionID] = archive
+	delete(m.executions, archive.ExecutionID)
+	return nil
+}
+
+func (m *mockExecutionArchiveRepo) Restore(ctx context.Context, executionID uuid.UUID, rows *storagemodels.ExecutionRows) error {
+	if _, ok := m.archives[executionID]; !ok {
+		return models.ErrExecutionArchiveNotFound
+	}
+	delete(m.archives, executionID)
+	m.executions[executionID] = rows
+	return nil
+}
+
+func (m *mockExecutionArchiveRepo) FindByExecutionID(ctx context.Context, executionID uuid.UUID) (*storagemodels.ExecutionArchiveModel, error) {
+	archive, ok := m.archives[executionID]
+	if !ok {
+		return nil, models.ErrExecutionArchiveNotFound
+	}
+	return archive, nil
+}
+
+func (m *mockExecutionArchiveRepo) List(ctx context.Context, filter models.ExecutionArchiveFilter) ([]*storagemodels.ExecutionArchiveModel, int, error) {
+	var archives []*storagemodels.ExecutionArchiveModel
+	for _, archive := range m.archives {
+		archives = append(archives, archive)
+	}
+	return archives, len(archives), nil
+}
+
+func newTestExecutionArchiver(t *testing.T, repo *mockExecutionArchiveRepo) *ExecutionArchiver {
+	t.Helper()
+
+	manager := filestorage.NewStorageManager(&filestorage.ManagerConfig{BasePath: t.TempDir()}, nil)
+	t.Cleanup(func() { manager.Close() })
+	store, err := manager.GetStorage(DefaultStorageID)
+	require.NoError(t, err)
+
+	log := logger.New(config.LoggingConfig{Level: "error", Format: "json"})
+	return NewExecutionArchiver(ExecutionArchiveConfig{ArchiveAfter: 30 * 24 * time.Hour, BatchSize: 2}, repo, store, log)
+}
+
+func TestExecutionArchiver_ArchivesAndRestores(t *testing.T) {
+	repo := newMockExecutionArchiveRepo()
+	completedAt := time.Date(2026, 1, 5, 9, 30, 0, 0, time.UTC)
+	ids := []uuid.UUID{repo.add(t, completedAt), repo.add(t, completedAt), repo.add(t, completedAt)}
+	a := newTestExecutionArchiver(t, repo)
+
+	result, err := a.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.ArchivedExecutions)
+	assert.Positive(t, result.ArchivedBytes)
+	assert.Empty(t, repo.executions)
+
+	archive, err := a.Get(context.Background(), ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionStatusCompleted, archive.Status)
+	assert.True(t, completedAt.Equal(*archive.CompletedAt))
+	assert.Equal(t, 1, archive.NodeExecutionCount)
+	assert.Equal(t, "executions/"+ids[0].String()+".json.gz", archive.ArchiveRef)
+
+	_, err = a.Restore(context.Background(), ids[0])
+	require.NoError(t, err)
+	rows := repo.executions[ids[0]]
+	require.NotNil(t, rows)
+	require.Len(t, rows.Executions, 1)
+	assert.Contains(t, string(rows.Executions[0]), `"total":42`)
+	require.Len(t, rows.NodeExecutions, 1)
+
+	_, _, err = a.storage.Get(context.Background(), archive.ArchiveRef)
+	assert.Error(t, err, "the archive of a restored execution is deleted")
+	_, err = a.Restore(context.Background(), ids[0])
+	assert.ErrorIs(t, err, models.ErrExecutionArchiveNotFound)
+}
+
+func TestExecutionArchiver_KeepsExecutionWhenArchiveFails(t *testing.T) {
+	repo := newMockExecutionArchiveRepo()
+	id := repo.add(t, time.Now())
+	repo.archiveErr = errors.New("database unavailable")
+	a := newTestExecutionArchiver(t, repo)
+
+	result, err := a.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.ArchivedExecutions)
+	assert.Equal(t, 1, result.Failed)
+	assert.Contains(t, repo.executions, id)
+
+	_, _, err = a.storage.Get(context.Background(), "executions/"+id.String()+".json.gz")
+	assert.Error(t, err, "the unused archive is deleted")
+}
@@ -0,0 +1,263 @@
+// Package comparison runs the same recorded inputs through two workflow variants and
+// reports, case by case, how their outputs, durations and LLM costs differ. It is used
+// to check a prompt, model or workflow change against real traffic before rolling it out.
+package comparison
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/smilemakc/mbflow/go/internal/application/llmcatalog"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// MaxDifferences is the number of differences reported per case; the rest are dropped.
+const MaxDifferences = 50
+
+// Input is a recorded input to replay.
+type Input struct {
+	SourceExecutionID string
+	Data              map[string]any
+	Variables         map[string]any // Runtime variables of the recorded execution
+}
+
+// RunFunc executes the workflow of a variant with an input and returns the finished execution.
+// A nil execution means the execution could not start.
+type RunFunc func(ctx context.Context, variant models.ComparisonVariant, input Input) (*models.Execution, error)
+
+// Options configures a comparison.
+type Options struct {
+	// IgnorePaths are output paths excluded from the diff, e.g. "output.generated_at".
+	// A path also ignores everything below it.
+	IgnorePaths []string
+}
+
+// Compare runs every input through the baseline and then the candidate variant, one at a
+// time, and builds the report. A run that fails is part of the report, not an error.
+func Compare(ctx context.Context, run RunFunc, workflowID string, baseline, candidate models.ComparisonVariant, inputs []Input, opts Options) *models.ComparisonReport {
+	report := &models.ComparisonReport{
+		WorkflowID: workflowID,
+		Baseline:   baseline,
+		Candidate:  candidate,
+		Total:      len(inputs),
+		Cases:      make([]models.ComparisonCase, 0, len(inputs)),
+	}
+
+	for _, input := range inputs {
+		c := models.ComparisonCase{
+			SourceExecutionID: input.SourceExecutionID,
+			Input:             input.Data,
+			Baseline:          runVariant(ctx, run, baseline, input),
+			Candidate:         runVariant(ctx, run, candidate, input),
+		}
+		c.Differences = DiffRuns(c.Baseline, c.Candidate, opts.IgnorePaths)
+		c.Identical = len(c.Differences) == 0
+
+		if c.Identical {
+			report.Identical++
+		} else {
+			report.Different++
+		}
+		report.Cases = append(report.Cases, c)
+	}
+
+	report.BaselineTotals = totals(report.Cases, func(c models.ComparisonCase) models.ComparisonRun { return c.Baseline })
+	report.CandidateTotals = totals(report.Cases, func(c models.ComparisonCase) models.ComparisonRun { return c.Candidate })
+	return report
+}
+
+// runVariant executes one input and summarizes the execution.
+func runVariant(ctx context.Context, run RunFunc, variant models.ComparisonVariant, input Input) models.ComparisonRun {
+	execution, err := run(ctx, variant, input)
+	if execution == nil {
+		result := models.ComparisonRun{Status: models.ExecutionStatusFailed}
+		if err != nil {
+			result.Error = fmt.Sprintf("execution could not start: %v", err)
+		}
+		return result
+	}
+
+	return models.ComparisonRun{
+		ExecutionID: execution.ID,
+		Status:      execution.Status,
+		Output:      execution.Output,
+		Error:       execution.Error,
+		DurationMs:  execution.Duration,
+		Usage:       ExecutionUsage(execution),
+	}
+}
+
+func totals(cases []models.ComparisonCase, side func(models.ComparisonCase) models.ComparisonRun) models.ComparisonTotals {
+	var result models.ComparisonTotals
+	var duration int64
+	for _, c := range cases {
+		run := side(c)
+		if run.Status == models.ExecutionStatusCompleted {
+			result.Completed++
+		} else {
+			result.Failed++
+		}
+		duration += run.DurationMs
+		result.Usage.Add(run.Usage)
+	}
+	if len(cases) > 0 {
+		result.AvgDurationMs = duration / int64(len(cases))
+	}
+	return result
+}
+
+// ExecutionUsage sums the token usage reported by the nodes of an execution and prices it
+// with the catalog list price of the provider and model each node used.
+func ExecutionUsage(execution *models.Execution) models.ComparisonUsage {
+	var usage models.ComparisonUsage
+	for _, ne := range execution.NodeExecutions {
+		tokens, ok := ne.Output["usage"].(map[string]any)
+		if !ok {
+			continue
+		}
+		promptTokens := toInt(tokens["prompt_tokens"])
+		completionTokens := toInt(tokens["completion_tokens"])
+
+		usage.LLMCalls++
+		usage.PromptTokens += promptTokens
+		usage.CompletionTokens += completionTokens
+
+		provider, _ := ne.ResolvedConfig["provider"].(string)
+		model, _ := ne.Output["model"].(string)
+		if model == "" {
+			model, _ = ne.ResolvedConfig["model"].(string)
+		}
+		if price := llmcatalog.Price(models.LLMProvider(provider), model); price != nil {
+			usage.CostUSD += price.Cost(promptTokens, completionTokens)
+		} else {
+			usage.UnpricedCalls++
+		}
+	}
+	return usage
+}
+
+func toInt(v any) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case int32:
+		return int(n)
+	case int64:
+		return int(n)
+	case float64:
+		return int(n)
+	case json.Number:
+		i, _ := n.Int64()
+		return int(i)
+	case string:
+		i, _ := strconv.Atoi(n)
+		return i
+	default:
+		return 0
+	}
+}
+
+// DiffRuns returns the differences between two runs of the same input: their status,
+// error and output, at most MaxDifferences of them. Output values are compared by their
+// JSON encoding, so 10 and 10.0 are equal.
+func DiffRuns(baseline, candidate models.ComparisonRun, ignorePaths []string) []models.OutputDifference {
+	d := &differ{ignore: ignorePaths}
+	if baseline.Status != candidate.Status {
+		d.add("status", baseline.Status, candidate.Status)
+	}
+	if baseline.Error != candidate.Error {
+		d.add("error", baseline.Error, candidate.Error)
+	}
+	d.diff("output", normalize(baseline.Output), normalize(candidate.Output))
+	return d.differences
+}
+
+type differ struct {
+	ignore      []string
+	differences []models.OutputDifference
+}
+
+func (d *differ) add(path string, baseline, candidate any) {
+	if len(d.differences) < MaxDifferences {
+		d.differences = append(d.differences, models.OutputDifference{Path: path, Baseline: baseline, Candidate: candidate})
+	}
+}
+
+func (d *differ) ignored(path string) bool {
+	for _, prefix := range d.ignore {
+		if path == prefix || strings.HasPrefix(path, prefix+".") || strings.HasPrefix(path, prefix+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *differ) diff(path string, baseline, candidate any) {
+	if len(d.differences) >= MaxDifferences || d.ignored(path) {
+		return
+	}
+
+	switch b := baseline.(type) {
+	case map[string]any:
+		c, ok := candidate.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := make(map[string]struct{}, len(b)+len(c))
+		for k := range b {
+			keys[k] = struct{}{}
+		}
+		for k := range c {
+			keys[k] = struct{}{}
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+		for _, k := range sorted {
+			d.diff(path+"."+k, b[k], c[k])
+		}
+		return
+
+	case []any:
+		c, ok := candidate.([]any)
+		if !ok {
+			break
+		}
+		for i := 0; i < len(b) || i < len(c); i++ {
+			var bv, cv any
+			if i < len(b) {
+				bv = b[i]
+			}
+			if i < len(c) {
+				cv = c[i]
+			}
+			d.diff(fmt.Sprintf("%s[%d]", path, i), bv, cv)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(baseline, candidate) {
+		d.add(path, baseline, candidate)
+	}
+}
+
+// normalize round-trips a value through JSON so that Go types produced by executors
+// compare equal to their decoded counterparts.
+func normalize(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return v
+	}
+	return normalized
+}
@@ -0,0 +1,158 @@
+package comparison
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+func llmNode(provider, model string, promptTokens, completionTokens int) *models.NodeExecution {
+	return &models.NodeExecution{
+		NodeID:         "summarize",
+		NodeType:       "llm",
+		ResolvedConfig: map[string]any{"provider": provider, "model": model},
+		Output: map[string]any{
+			"content": "summary",
+			"model":   model,
+			"usage": map[string]any{
+				"prompt_tokens":     promptTokens,
+				"completion_tokens": completionTokens,
+				"total_tokens":      promptTokens + completionTokens,
+			},
+		},
+	}
+}
+
+func TestExecutionUsage(t *testing.T) {
+	execution := &models.Execution{NodeExecutions: []*models.NodeExecution{
+		llmNode("openai", "gpt-4o", 1000, 500),
+		llmNode("anthropic", "claude-next", 200, 100),
+		{NodeID: "notify", NodeType: "http", Output: map[string]any{"status": 200}},
+	}}
+
+	usage := ExecutionUsage(execution)
+
+	assert.Equal(t, 2, usage.LLMCalls)
+	assert.Equal(t, 1200, usage.PromptTokens)
+	assert.Equal(t, 600, usage.CompletionTokens)
+	assert.Equal(t, 1, usage.UnpricedCalls)
+	// gpt-4o: 1000 * $2.50/M + 500 * $10/M
+	assert.InDelta(t, 0.0075, usage.CostUSD, 1e-9)
+}
+
+func TestDiffRuns(t *testing.T) {
+	baseline := models.ComparisonRun{
+		Status: models.ExecutionStatusCompleted,
+		Output: map[string]any{
+			"summary":      "short",
+			"score":        10,
+			"tags":         []any{"a", "b"},
+			"generated_at": "2026-01-01T00:00:00Z",
+		},
+	}
+	candidate := models.ComparisonRun{
+		Status: models.ExecutionStatusCompleted,
+		Output: map[string]any{
+			"summary":      "longer",
+			"score":        10.0,
+			"tags":         []any{"a"},
+			"generated_at": "2026-01-02T00:00:00Z",
+			"extra":        true,
+		},
+	}
+
+	differences := DiffRuns(baseline, candidate, []string{"output.generated_at"})
+
+	assert.Equal(t, []models.OutputDifference{
+		{Path: "output.extra", Baseline: nil, Candidate: true},
+		{Path: "output.summary", Baseline: "short", Candidate: "longer"},
+		{Path: "output.tags[1]", Baseline: "b", Candidate: nil},
+	}, differences)
+}
+
+func TestDiffRuns_StatusAndError(t *testing.T) {
+	baseline := models.ComparisonRun{Status: models.ExecutionStatusCompleted, Output: map[string]any{"ok": true}}
+	candidate := models.ComparisonRun{Status: models.ExecutionStatusFailed, Error: "node summarize failed"}
+
+	differences := DiffRuns(baseline, candidate, nil)
+
+	require.Len(t, differences, 3)
+	assert.Equal(t, "status", differences[0].Path)
+	assert.Equal(t, "error", differences[1].Path)
+	assert.Equal(t, "output", differences[2].Path)
+}
+
+func TestDiffRuns_CapsDifferences(t *testing.T) {
+	baseline := models.ComparisonRun{Output: map[string]any{}}
+	candidate := models.ComparisonRun{Output: map[string]any{}}
+	for i := 0; i < MaxDifferences+10; i++ {
+		baseline.Output[string(rune('a'+i%26))+string(rune('a'+i/26))] = i
+	}
+
+	assert.Len(t, DiffRuns(baseline, candidate, nil), MaxDifferences)
+}
+
+func TestCompare(t *testing.T) {
+	baseline := models.ComparisonVariant{Label: "gpt-4o"}
+	candidate := models.ComparisonVariant{
+		Label:         "gpt-4o-mini",
+		NodeOverrides: map[string]map[string]any{"summarize": {"model": "gpt-4o-mini"}},
+	}
+
+	run := func(ctx context.Context, variant models.ComparisonVariant, input Input) (*models.Execution, error) {
+		if input.Data["fail"] == true && variant.Label == "gpt-4o-mini" {
+			return nil, errors.New("workflow not found")
+		}
+		model := "gpt-4o"
+		duration := int64(200)
+		if override, ok := variant.NodeOverrides["summarize"]; ok {
+			model = override["model"].(string)
+			duration = 100
+		}
+		return &models.Execution{
+			ID:             variant.Label + "-run",
+			Status:         models.ExecutionStatusCompleted,
+			Output:         map[string]any{"summary": input.Data["text"]},
+			Duration:       duration,
+			NodeExecutions: []*models.NodeExecution{llmNode("openai", model, 1000, 1000)},
+		}, nil
+	}
+
+	inputs := []Input{
+		{SourceExecutionID: "exec-1", Data: map[string]any{"text": "hello"}},
+		{SourceExecutionID: "exec-2", Data: map[string]any{"text": "bye", "fail": true}},
+	}
+
+	report := Compare(context.Background(), run, "wf-1", baseline, candidate, inputs, Options{})
+
+	assert.Equal(t, "wf-1", report.WorkflowID)
+	assert.Equal(t, 2, report.Total)
+	assert.Equal(t, 1, report.Identical)
+	assert.Equal(t, 1, report.Different)
+	require.Len(t, report.Cases, 2)
+
+	first := report.Cases[0]
+	assert.True(t, first.Identical)
+	assert.Equal(t, "exec-1", first.SourceExecutionID)
+	assert.Equal(t, "gpt-4o-run", first.Baseline.ExecutionID)
+	assert.Equal(t, "gpt-4o-mini-run", first.Candidate.ExecutionID)
+
+	second := report.Cases[1]
+	assert.False(t, second.Identical)
+	assert.Equal(t, models.ExecutionStatusFailed, second.Candidate.Status)
+	assert.Contains(t, second.Candidate.Error, "workflow not found")
+
+	assert.Equal(t, 2, report.BaselineTotals.Completed)
+	assert.Equal(t, int64(200), report.BaselineTotals.AvgDurationMs)
+	assert.InDelta(t, 0.025, report.BaselineTotals.Usage.CostUSD, 1e-9)
+
+	assert.Equal(t, 1, report.CandidateTotals.Completed)
+	assert.Equal(t, 1, report.CandidateTotals.Failed)
+	assert.Equal(t, int64(50), report.CandidateTotals.AvgDurationMs)
+	assert.InDelta(t, 0.00075, report.CandidateTotals.Usage.CostUSD, 1e-9)
+}
@@ -0,0 +1,194 @@
+// Package deadletter keeps the dead-letter queue: executions that failed once their nodes
+// exhausted their retries, or that a worker gave up on, are parked there until an operator
+// retries them from their failed nodes or discards them. Parking an execution emits an
+// execution.dead_lettered event, so webhooks and other observers can alert on it.
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/internal/application/observer"
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// DefaultPageSize is the page size used when the filter sets no limit.
+const DefaultPageSize = 50
+
+// Resumer continues failed executions from their failed nodes on behalf of a user. It is
+// implemented by engine.ExecutionManager.
+type Resumer interface {
+	ResumeFailed(ctx context.Context, executionID, userID string, isAdmin bool) (*models.Execution, error)
+}
+
+// Notifier delivers events to observers. It is implemented by observer.ObserverManager.
+type Notifier interface {
+	Notify(ctx context.Context, event observer.Event)
+}
+
+// Service parks failed executions in the dead-letter queue and retries or discards them.
+type Service struct {
+	repo     repository.DeadLetterRepository
+	resumer  Resumer
+	notifier Notifier
+	logger   *logger.Logger
+}
+
+// NewService creates a new dead-letter service. notifier may be nil.
+func NewService(repo repository.DeadLetterRepository, resumer Resumer, notifier Notifier, log *logger.Logger) *Service {
+	return &Service{
+		repo:     repo,
+		resumer:  resumer,
+		notifier: notifier,
+		logger:   log,
+	}
+}
+
+// Name returns the observer name.
+func (s *Service) Name() string { return "dead_letter" }
+
+// Filter limits the observer to failed executions.
+func (s *Service) Filter() observer.EventFilter {
+	return observer.NewEventTypeFilter(observer.EventTypeExecutionFailed)
+}
+
+// OnEvent parks a failed execution in the queue and announces it. Executions that are not
+// stored as failed, such as those of ephemeral workflows, are ignored.
+func (s *Service) OnEvent(ctx context.Context, event observer.Event) error {
+	if event.Type != observer.EventTypeExecutionFailed || event.WorkflowID == "" {
+		return nil
+	}
+
+	deadLetter := &models.DeadLetter{ExecutionID: event.ExecutionID}
+	if err := s.repo.Create(ctx, deadLetter); err != nil {
+		if errors.Is(err, models.ErrExecutionNotFailed) || errors.Is(err, models.ErrInvalidExecutionID) {
+			return nil
+		}
+		return err
+	}
+
+	s.logger.Info("Execution dead-lettered", "dead_letter_id", deadLetter.ID,
+		"execution_id", deadLetter.ExecutionID, "workflow_id", deadLetter.WorkflowID)
+
+	if s.notifier != nil {
+		s.notifier.Notify(ctx, observer.Event{
+			Type:        observer.EventTypeExecutionDeadLettered,
+			ExecutionID: deadLetter.ExecutionID,
+			WorkflowID:  deadLetter.WorkflowID,
+			Timestamp:   time.Now(),
+			Status:      string(models.ExecutionStatusFailed),
+			Error:       event.Error,
+			Metadata:    map[string]any{"dead_letter_id": deadLetter.ID},
+		})
+	}
+	return nil
+}
+
+// List returns one page of the dead letters matching the filter, newest first, and the
+// total count of matching dead letters.
+func (s *Service) List(ctx context.Context, filter models.DeadLetterFilter) ([]*models.DeadLetter, int, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultPageSize
+	}
+	if filter.Limit > models.MaxDeadLetterPageSize {
+		filter.Limit = models.MaxDeadLetterPageSize
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	return s.repo.List(ctx, filter)
+}
+
+// Get returns a dead letter.
+func (s *Service) Get(ctx context.Context, id string) (*models.DeadLetter, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// Retry resumes the executions of the dead letters from their failed nodes and takes them
+// out of the queue. An execution that fails again is parked anew. Dead letters whose
+// execution is no longer failed, e.g. because it was resumed directly, are dropped with an
+// error. Each dead letter has its own result.
+func (s *Service) Retry(ctx context.Context, ids []string) ([]models.DeadLetterResult, error) {
+	if err := validateBatch(ids); err != nil {
+		return nil, err
+	}
+
+	results := make([]models.DeadLetterResult, 0, len(ids))
+	for _, id := range ids {
+		result := models.DeadLetterResult{ID: id}
+		if err := s.retry(ctx, id, &result); err != nil {
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// retry takes one dead letter out of the queue and resumes its execution. The entry is
+// removed first, so that a resumed run failing right away parks the execution again; it is
+// put back if the execution cannot resume.
+func (s *Service) retry(ctx context.Context, id string, result *models.DeadLetterResult) error {
+	deadLetter, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	result.ExecutionID = deadLetter.ExecutionID
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	// Dead letters are retried by admins only
+	if _, err := s.resumer.ResumeFailed(ctx, deadLetter.ExecutionID, "", true); err != nil {
+		if !errors.Is(err, models.ErrExecutionNotFailed) {
+			if restoreErr := s.repo.Create(ctx, deadLetter); restoreErr != nil {
+				s.logger.Error("Failed to restore dead letter", "dead_letter_id", id,
+					"execution_id", deadLetter.ExecutionID, "error", restoreErr)
+			}
+		}
+		return err
+	}
+
+	s.logger.Info("Dead letter retried", "dead_letter_id", id, "execution_id", deadLetter.ExecutionID)
+	return nil
+}
+
+// Discard removes the dead letters from the queue; their executions stay failed. Each dead
+// letter has its own result.
+func (s *Service) Discard(ctx context.Context, ids []string) ([]models.DeadLetterResult, error) {
+	if err := validateBatch(ids); err != nil {
+		return nil, err
+	}
+
+	results := make([]models.DeadLetterResult, 0, len(ids))
+	for _, id := range ids {
+		result := models.DeadLetterResult{ID: id}
+		deadLetter, err := s.repo.GetByID(ctx, id)
+		if err == nil {
+			result.ExecutionID = deadLetter.ExecutionID
+			err = s.repo.Delete(ctx, id)
+		}
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			s.logger.Info("Dead letter discarded", "dead_letter_id", id, "execution_id", deadLetter.ExecutionID)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// validateBatch checks the dead letter IDs given to Retry or Discard.
+func validateBatch(ids []string) error {
+	if len(ids) == 0 {
+		return &models.ValidationError{Field: "ids", Message: "ids is required"}
+	}
+	if len(ids) > models.MaxDeadLetterBatch {
+		return &models.ValidationError{Field: "ids", Message: fmt.Sprintf("at most %d ids can be given at once", models.MaxDeadLetterBatch)}
+	}
+	return nil
+}
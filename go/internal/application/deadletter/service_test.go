@@ -0,0 +1,218 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smilemakc/mbflow/go/internal/application/observer"
+	"github.com/smilemakc/mbflow/go/internal/config"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// mockDeadLetterRepo stores dead letters in memory; failed lists the executions stored as failed.
+type mockDeadLetterRepo struct {
+	mu          sync.Mutex
+	failed      map[string]string // execution ID -> workflow ID
+	deadLetters map[string]*models.DeadLetter
+}
+
+func newMockDeadLetterRepo() *mockDeadLetterRepo {
+	return &mockDeadLetterRepo{failed: make(map[string]string), deadLetters: make(map[string]*models.DeadLetter)}
+}
+
+func (m *mockDeadLetterRepo) Create(ctx context.Context, deadLetter *models.DeadLetter) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	workflowID, ok := m.failed[deadLetter.ExecutionID]
+	if !ok {
+		return models.ErrExecutionNotFailed
+	}
+	for _, dl := range m.deadLetters {
+		if dl.ExecutionID == deadLetter.ExecutionID {
+			*deadLetter = *dl
+			return nil
+		}
+	}
+	if deadLetter.ID == "" {
+		deadLetter.ID = uuid.NewString()
+	}
+	if deadLetter.CreatedAt.IsZero() {
+		deadLetter.CreatedAt = time.Now()
+	}
+	deadLetter.WorkflowID = workflowID
+	stored := *deadLetter
+	m.deadLetters[deadLetter.ID] = &stored
+	return nil
+}
+
+func (m *mockDeadLetterRepo) GetByID(ctx context.Context, id string) (*models.DeadLetter, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	dl, ok := m.deadLetters[id]
+	if !ok {
+		return nil, models.ErrDeadLetterNotFound
+	}
+	copied := *dl
+	return &copied, nil
+}
+
+func (m *mockDeadLetterRepo) List(ctx context.Context, filter models.DeadLetterFilter) ([]*models.DeadLetter, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*models.DeadLetter
+	for _, dl := range m.deadLetters {
+		if filter.WorkflowID == "" || dl.WorkflowID == filter.WorkflowID {
+			result = append(result, dl)
+		}
+	}
+	return result, len(result), nil
+}
+
+func (m *mockDeadLetterRepo) Delete(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.deadLetters[id]; !ok {
+		return models.ErrDeadLetterNotFound
+	}
+	delete(m.deadLetters, id)
+	return nil
+}
+
+type mockResumer struct {
+	err     error
+	resumed []string
+}
+
+func (m *mockResumer) ResumeFailed(ctx context.Context, executionID, userID string, isAdmin bool) (*models.Execution, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.resumed = append(m.resumed, executionID)
+	return &models.Execution{ID: executionID, Status: models.ExecutionStatusRunning}, nil
+}
+
+type mockNotifier struct {
+	events []observer.Event
+}
+
+func (m *mockNotifier) Notify(ctx context.Context, event observer.Event) {
+	m.events = append(m.events, event)
+}
+
+func newTestService(t *testing.T) (*Service, *mockDeadLetterRepo, *mockResumer, *mockNotifier) {
+	t.Helper()
+	repo := newMockDeadLetterRepo()
+	resumer := &mockResumer{}
+	notifier := &mockNotifier{}
+	log := logger.New(config.LoggingConfig{Level: "error", Format: "json"})
+	return NewService(repo, resumer, notifier, log), repo, resumer, notifier
+}
+
+// deadLetter parks a failed execution through the observer and returns its entry.
+func deadLetter(t *testing.T, service *Service, repo *mockDeadLetterRepo, workflowID string) *models.DeadLetter {
+	t.Helper()
+	executionID := uuid.NewString()
+	repo.failed[executionID] = workflowID
+	require.NoError(t, service.OnEvent(context.Background(), observer.Event{
+		Type:        observer.EventTypeExecutionFailed,
+		ExecutionID: executionID,
+		WorkflowID:  workflowID,
+		Error:       errors.New("node fetch failed after 3 attempts"),
+	}))
+
+	list, _, err := repo.List(context.Background(), models.DeadLetterFilter{})
+	require.NoError(t, err)
+	for _, dl := range list {
+		if dl.ExecutionID == executionID {
+			return dl
+		}
+	}
+	t.Fatalf("execution %s was not dead-lettered", executionID)
+	return nil
+}
+
+func TestService_OnEvent(t *testing.T) {
+	service, repo, _, notifier := newTestService(t)
+	workflowID := uuid.NewString()
+
+	dl := deadLetter(t, service, repo, workflowID)
+	require.Len(t, notifier.events, 1)
+	event := notifier.events[0]
+	assert.Equal(t, observer.EventTypeExecutionDeadLettered, event.Type)
+	assert.Equal(t, dl.ExecutionID, event.ExecutionID)
+	assert.Equal(t, workflowID, event.WorkflowID)
+	assert.Equal(t, dl.ID, event.Metadata["dead_letter_id"])
+
+	// Executions not stored as failed, e.g. ephemeral ones, are ignored
+	err := service.OnEvent(context.Background(), observer.Event{
+		Type:        observer.EventTypeExecutionFailed,
+		ExecutionID: uuid.NewString(),
+		WorkflowID:  workflowID,
+	})
+	require.NoError(t, err)
+	assert.Len(t, notifier.events, 1)
+	assert.Len(t, repo.deadLetters, 1)
+}
+
+func TestService_Retry(t *testing.T) {
+	service, repo, resumer, _ := newTestService(t)
+	dl := deadLetter(t, service, repo, uuid.NewString())
+	missing := uuid.NewString()
+
+	results, err := service.Retry(context.Background(), []string{dl.ID, missing})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, models.DeadLetterResult{ID: dl.ID, ExecutionID: dl.ExecutionID}, results[0])
+	assert.NotEmpty(t, results[1].Error)
+	assert.Equal(t, []string{dl.ExecutionID}, resumer.resumed)
+	assert.Empty(t, repo.deadLetters)
+}
+
+func TestService_Retry_ShouldRestoreDeadLetterWhenResumeFails(t *testing.T) {
+	service, repo, resumer, _ := newTestService(t)
+	dl := deadLetter(t, service, repo, uuid.NewString())
+
+	resumer.err = errors.New("workflow not found")
+	results, err := service.Retry(context.Background(), []string{dl.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "workflow not found", results[0].Error)
+	restored, err := repo.GetByID(context.Background(), dl.ID)
+	require.NoError(t, err)
+	assert.Equal(t, dl.CreatedAt, restored.CreatedAt)
+
+	// A dead letter whose execution was resumed elsewhere leaves the queue
+	resumer.err = models.ErrExecutionNotFailed
+	results, err = service.Retry(context.Background(), []string{dl.ID})
+	require.NoError(t, err)
+	assert.NotEmpty(t, results[0].Error)
+	assert.Empty(t, repo.deadLetters)
+}
+
+func TestService_Discard(t *testing.T) {
+	service, repo, resumer, _ := newTestService(t)
+	dl := deadLetter(t, service, repo, uuid.NewString())
+
+	results, err := service.Discard(context.Background(), []string{dl.ID})
+	require.NoError(t, err)
+	assert.Equal(t, []models.DeadLetterResult{{ID: dl.ID, ExecutionID: dl.ExecutionID}}, results)
+	assert.Empty(t, repo.deadLetters)
+	assert.Empty(t, resumer.resumed)
+
+	results, err = service.Discard(context.Background(), []string{dl.ID})
+	require.NoError(t, err)
+	assert.Equal(t, models.ErrDeadLetterNotFound.Error(), results[0].Error)
+
+	_, err = service.Discard(context.Background(), nil)
+	var validationErr *models.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	_, err = service.Retry(context.Background(), make([]string, models.MaxDeadLetterBatch+1))
+	assert.ErrorAs(t, err, &validationErr)
+}
@@ -0,0 +1,194 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgengine "github.com/smilemakc/mbflow/go/pkg/engine"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// ListApprovals returns the approvals raised by the approval nodes of an execution: pending
+// while the paused execution waits for a decision, then decided or timed out.
+func (em *ExecutionManager) ListApprovals(ctx context.Context, executionID string) ([]*models.Approval, error) {
+	id, err := uuid.Parse(executionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", models.ErrInvalidExecutionID, executionID)
+	}
+
+	executionModel, err := em.executionRepo.FindByIDWithRelations(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Suspended approval nodes are pending; they expire when their suspension is over
+	var suspensions map[string]*pkgengine.Suspension
+	if executionModel.IsPaused() {
+		state, err := decodeResumeState(executionModel.ResumeState)
+		if err != nil {
+			return nil, err
+		}
+		suspensions = state.Checkpoint.Suspensions
+	}
+
+	nodes := make(map[uuid.UUID]*storagemodels.NodeModel)
+	if executionModel.WorkflowID != nil {
+		workflowModel, err := em.workflowRepo.FindByIDWithRelations(ctx, *executionModel.WorkflowID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load workflow: %w", err)
+		}
+		for _, nodeModel := range workflowModel.Nodes {
+			nodes[nodeModel.ID] = nodeModel
+		}
+	}
+
+	approvals := make([]*models.Approval, 0)
+	for _, nodeExec := range executionModel.NodeExecutions {
+		approval := approvalFromNodeExecution(executionID, nodeExec, nodes, suspensions)
+		if approval != nil {
+			approvals = append(approvals, approval)
+		}
+	}
+	return approvals, nil
+}
+
+// approvalFromNodeExecution returns the approval of an approval node's execution, or nil if
+// the node is not an approval node or neither waits for nor has a decision.
+func approvalFromNodeExecution(
+	executionID string,
+	nodeExec *storagemodels.NodeExecutionModel,
+	nodes map[uuid.UUID]*storagemodels.NodeModel,
+	suspensions map[string]*pkgengine.Suspension,
+) *models.Approval {
+	var nodeID, nodeName, nodeType string
+	if nodeExec.NodeID != nil {
+		if nodeModel, ok := nodes[*nodeExec.NodeID]; ok {
+			nodeID, nodeName, nodeType = nodeModel.NodeID, nodeModel.Name, nodeModel.Type
+		}
+	}
+	if nodeID == "" && nodeExec.NodeKey != nil {
+		nodeID = *nodeExec.NodeKey
+	}
+	if nodeName == "" && nodeExec.NodeName != nil {
+		nodeName = *nodeExec.NodeName
+	}
+	if nodeType == "" && nodeExec.NodeType != nil {
+		nodeType = *nodeExec.NodeType
+	}
+	if nodeType != pkgengine.NodeTypeApproval || nodeID == "" {
+		return nil
+	}
+
+	approval := &models.Approval{
+		ExecutionID: executionID,
+		NodeID:      nodeID,
+		NodeName:    nodeName,
+	}
+	config := map[string]any(nodeExec.ResolvedConfig)
+	approval.Message, _ = config["message"].(string)
+	approval.Approvers, _ = models.ApprovalApprovers(config)
+	if nodeExec.StartedAt != nil {
+		approval.RequestedAt = *nodeExec.StartedAt
+	}
+
+	if suspension, ok := suspensions[nodeID]; ok {
+		approval.Status = models.ApprovalStatusPending
+		expiresAt := suspension.ResumeAt
+		approval.ExpiresAt = &expiresAt
+		return approval
+	}
+
+	decision, _ := nodeExec.OutputData["event"].(map[string]any)
+	status, _ := decision["decision"].(string)
+	switch models.ApprovalStatus(status) {
+	case models.ApprovalStatusApproved, models.ApprovalStatusRejected, models.ApprovalStatusTimeout:
+		approval.Status = models.ApprovalStatus(status)
+	default:
+		return nil
+	}
+	approval.Comment, _ = decision["comment"].(string)
+	approval.DecidedBy, _ = decision["decided_by"].(string)
+	if decidedAt, ok := decision["decided_at"].(string); ok {
+		if t, err := time.Parse(time.RFC3339, decidedAt); err == nil {
+			approval.DecidedAt = &t
+		}
+	} else if nodeExec.CompletedAt != nil {
+		approval.DecidedAt = nodeExec.CompletedAt
+	}
+	return approval
+}
+
+// DecideApproval approves or rejects a pending approval on behalf of the user and returns
+// the decided approval. The execution is claimed before DecideApproval returns and then
+// resumes in its own goroutine, following the edges of the decision. Only one decision is
+// accepted: later ones fail with ErrApprovalNotPending.
+func (em *ExecutionManager) DecideApproval(
+	ctx context.Context,
+	executionID, nodeID string,
+	decision models.ApprovalStatus,
+	comment, userID string,
+) (*models.Approval, error) {
+	if decision != models.ApprovalStatusApproved && decision != models.ApprovalStatusRejected {
+		return nil, &models.ValidationError{Field: "decision", Message: "decision must be approved or rejected"}
+	}
+
+	approvals, err := em.ListApprovals(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+	var approval *models.Approval
+	for _, a := range approvals {
+		if a.NodeID == nodeID {
+			approval = a
+			break
+		}
+	}
+	if approval == nil {
+		return nil, fmt.Errorf("%w: node %s of execution %s", models.ErrApprovalNotFound, nodeID, executionID)
+	}
+	if approval.Status != models.ApprovalStatusPending {
+		return nil, fmt.Errorf("%w: approval is %s", models.ErrApprovalNotPending, approval.Status)
+	}
+	if !approval.CanDecide(userID) {
+		return nil, fmt.Errorf("%w: user is not an approver of node %s", models.ErrForbidden, nodeID)
+	}
+
+	decidedAt := time.Now().UTC()
+	event := &deliveredEvent{
+		key: models.ApprovalEventKey(executionID, nodeID),
+		payload: map[string]any{
+			"decision":   string(decision),
+			"comment":    comment,
+			"decided_by": userID,
+			"decided_at": decidedAt.Format(time.RFC3339),
+		},
+	}
+
+	claimed, err := em.claimResume(ctx, executionID, event, nil)
+	if errors.Is(err, models.ErrExecutionNotPaused) {
+		return nil, fmt.Errorf("%w: %v", models.ErrApprovalNotPending, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		bgCtx := context.Background()
+		// Failures of the resumed workflow itself are reported when it is finalized
+		if resumed, err := em.runResume(bgCtx, claimed, event); err != nil && resumed == nil {
+			execution := &models.Execution{ID: executionID, WorkflowID: claimed.workflow.ID}
+			em.notifyExecutionError(bgCtx, execution, fmt.Errorf("failed to resume execution after approval decision: %w", err))
+		}
+	}()
+
+	approval.Status = decision
+	approval.Comment = comment
+	approval.DecidedBy = userID
+	approval.DecidedAt = &decidedAt
+	approval.ExpiresAt = nil
+	return approval, nil
+}
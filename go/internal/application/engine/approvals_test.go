@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgengine "github.com/smilemakc/mbflow/go/pkg/engine"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApprovalFromNodeExecution(t *testing.T) {
+	nodeUUID := uuid.New()
+	nodes := map[uuid.UUID]*storagemodels.NodeModel{
+		nodeUUID: {ID: nodeUUID, NodeID: "review", Name: "Review", Type: pkgengine.NodeTypeApproval},
+	}
+	startedAt := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	expiresAt := startedAt.Add(48 * time.Hour)
+
+	nodeExec := &storagemodels.NodeExecutionModel{
+		NodeID:         &nodeUUID,
+		Status:         string(models.NodeExecutionStatusRunning),
+		StartedAt:      &startedAt,
+		ResolvedConfig: storagemodels.JSONBMap{"message": "Refund 30 EUR?", "approvers": []any{"user-1"}},
+	}
+
+	t.Run("pending while suspended", func(t *testing.T) {
+		suspensions := map[string]*pkgengine.Suspension{"review": {ResumeAt: expiresAt}}
+
+		approval := approvalFromNodeExecution("exec-1", nodeExec, nodes, suspensions)
+		require.NotNil(t, approval)
+		assert.Equal(t, models.ApprovalStatusPending, approval.Status)
+		assert.Equal(t, "review", approval.NodeID)
+		assert.Equal(t, "Review", approval.NodeName)
+		assert.Equal(t, "Refund 30 EUR?", approval.Message)
+		assert.Equal(t, []string{"user-1"}, approval.Approvers)
+		assert.Equal(t, startedAt, approval.RequestedAt)
+		assert.Equal(t, &expiresAt, approval.ExpiresAt)
+	})
+
+	t.Run("decided", func(t *testing.T) {
+		decided := *nodeExec
+		decided.Status = string(models.NodeExecutionStatusCompleted)
+		decided.OutputData = storagemodels.JSONBMap{
+			"event": map[string]any{
+				"decision":   "rejected",
+				"comment":    "Out of stock",
+				"decided_by": "user-1",
+				"decided_at": "2026-10-16T10:00:00Z",
+			},
+			"timed_out": false,
+		}
+
+		approval := approvalFromNodeExecution("exec-1", &decided, nodes, nil)
+		require.NotNil(t, approval)
+		assert.Equal(t, models.ApprovalStatusRejected, approval.Status)
+		assert.Equal(t, "Out of stock", approval.Comment)
+		assert.Equal(t, "user-1", approval.DecidedBy)
+		require.NotNil(t, approval.DecidedAt)
+		assert.Equal(t, startedAt.Add(time.Hour), *approval.DecidedAt)
+		assert.Nil(t, approval.ExpiresAt)
+	})
+
+	t.Run("other node types", func(t *testing.T) {
+		otherUUID := uuid.New()
+		nodes[otherUUID] = &storagemodels.NodeModel{ID: otherUUID, NodeID: "notify", Type: "http"}
+		other := &storagemodels.NodeExecutionModel{NodeID: &otherUUID}
+
+		assert.Nil(t, approvalFromNodeExecution("exec-1", other, nodes, nil))
+	})
+}
@@ -0,0 +1,221 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// ConcurrencyLimiter hands out the slots of workflow concurrency limits. An execution holds its
+// slot until it releases it or the slot's TTL passes without it being extended, so the slots of
+// an instance that stopped are freed.
+type ConcurrencyLimiter interface {
+	// Acquire gives the execution a slot of the workflow until now+ttl if fewer than limit are
+	// held, and reports whether it did; an execution already holding a slot keeps it. With wait,
+	// an execution not given a slot is put in line, and keeps its place while it asks again
+	// within ttl: free slots go to waiting executions in the order they first asked.
+	Acquire(ctx context.Context, workflowID, executionID string, limit int, wait bool, ttl time.Duration) (bool, error)
+	// Extend keeps the slot of the execution until now+ttl.
+	Extend(ctx context.Context, workflowID, executionID string, ttl time.Duration) error
+	// Release frees the slot of the execution, or its place in line.
+	Release(ctx context.Context, workflowID, executionID string) error
+}
+
+var (
+	// concurrencySlotTTL is how long a slot outlives an instance that stopped extending it.
+	concurrencySlotTTL = 30 * time.Second
+	// concurrencyPollInterval is how often a waiting execution asks for a slot.
+	concurrencyPollInterval = time.Second
+)
+
+// SetConcurrencyLimiter sets the limiter enforcing workflow concurrency limits, such as one
+// shared by all instances. It must be set before executions start.
+func (em *ExecutionManager) SetConcurrencyLimiter(limiter ConcurrencyLimiter) {
+	em.concurrency = limiter
+}
+
+// acquireSlot asks once for a slot of the workflow's concurrency limit for the execution. It
+// reports false if the execution waits in line, and fails with ErrConcurrencyLimitReached if
+// the workflow rejects executions over its limit. Workflows without a limit always get a slot.
+func (em *ExecutionManager) acquireSlot(ctx context.Context, workflow *models.Workflow, executionID string) (bool, error) {
+	limit := workflow.MaxConcurrentExecutions()
+	if limit == 0 || em.concurrency == nil {
+		return true, nil
+	}
+
+	wait := workflow.ConcurrencyOverflow() == models.ConcurrencyOverflowQueue
+	acquired, err := em.concurrency.Acquire(ctx, workflow.ID, executionID, limit, wait, concurrencySlotTTL)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire concurrency slot: %w", err)
+	}
+	if !acquired && !wait {
+		return false, fmt.Errorf("%w: workflow %s runs at most %d executions at once", models.ErrConcurrencyLimitReached, workflow.ID, limit)
+	}
+	return acquired, nil
+}
+
+// holdSlot waits until the execution has a slot of the workflow's concurrency limit and keeps
+// it until the returned function is called. An execution leaving the line because ctx is done
+// gives up its place.
+func (em *ExecutionManager) holdSlot(ctx context.Context, workflow *models.Workflow, executionID string) (func(), error) {
+	for {
+		acquired, err := em.acquireSlot(ctx, workflow, executionID)
+		if err != nil {
+			return nil, err
+		}
+		if acquired {
+			break
+		}
+		select {
+		case <-time.After(concurrencyPollInterval):
+		case <-ctx.Done():
+			em.releaseSlot(workflow, executionID)
+			return nil, fmt.Errorf("stopped waiting for a concurrency slot: %w", ctx.Err())
+		}
+	}
+
+	if workflow.MaxConcurrentExecutions() == 0 || em.concurrency == nil {
+		return func() {}, nil
+	}
+
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(concurrencySlotTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				// A slot not extended now is extended on the next tick, before it expires
+				_ = em.concurrency.Extend(context.Background(), workflow.ID, executionID, concurrencySlotTTL)
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-stopped
+		em.releaseSlot(workflow, executionID)
+	}, nil
+}
+
+// releaseSlot frees the slot of an execution, or its place in line. A slot not freed expires.
+func (em *ExecutionManager) releaseSlot(workflow *models.Workflow, executionID string) {
+	if workflow.MaxConcurrentExecutions() == 0 || em.concurrency == nil {
+		return
+	}
+	_ = em.concurrency.Release(context.Background(), workflow.ID, executionID)
+}
+
+// MemoryConcurrencyLimiter is an in-process ConcurrencyLimiter. Limits are not shared between
+// instances.
+type MemoryConcurrencyLimiter struct {
+	mu        sync.Mutex
+	workflows map[string]*memoryConcurrency
+	now       func() time.Time
+}
+
+// memoryConcurrency holds the slots and the line of one workflow.
+type memoryConcurrency struct {
+	slots   map[string]time.Time // Expiry per execution holding a slot
+	waiting []string             // Executions in line, first asked first
+	seen    map[string]time.Time // Last time each execution in line asked
+}
+
+// NewMemoryConcurrencyLimiter creates an empty in-memory concurrency limiter.
+func NewMemoryConcurrencyLimiter() *MemoryConcurrencyLimiter {
+	return &MemoryConcurrencyLimiter{workflows: make(map[string]*memoryConcurrency), now: time.Now}
+}
+
+// Acquire gives the execution a slot of the workflow if one is free and no execution asked first.
+func (l *MemoryConcurrencyLimiter) Acquire(_ context.Context, workflowID, executionID string, limit int, wait bool, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	wf := l.workflow(workflowID)
+	for id, expiry := range wf.slots {
+		if !expiry.After(now) {
+			delete(wf.slots, id)
+		}
+	}
+	if _, ok := wf.slots[executionID]; ok {
+		wf.slots[executionID] = now.Add(ttl)
+		return true, nil
+	}
+	// Executions that stopped asking leave the line
+	wf.waiting = slices.DeleteFunc(wf.waiting, func(id string) bool {
+		if wf.seen[id].Add(ttl).Before(now) {
+			delete(wf.seen, id)
+			return true
+		}
+		return false
+	})
+
+	ahead := slices.Index(wf.waiting, executionID)
+	if ahead < 0 {
+		ahead = len(wf.waiting)
+	}
+	if ahead < limit-len(wf.slots) {
+		wf.slots[executionID] = now.Add(ttl)
+		l.leaveLine(wf, executionID)
+		return true, nil
+	}
+
+	if wait {
+		if !slices.Contains(wf.waiting, executionID) {
+			wf.waiting = append(wf.waiting, executionID)
+		}
+		wf.seen[executionID] = now
+	}
+	return false, nil
+}
+
+// Extend keeps the slot of the execution until now+ttl.
+func (l *MemoryConcurrencyLimiter) Extend(_ context.Context, workflowID, executionID string, ttl time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.workflow(workflowID).slots[executionID] = l.now().Add(ttl)
+	return nil
+}
+
+// Release frees the slot of the execution, or its place in line.
+func (l *MemoryConcurrencyLimiter) Release(_ context.Context, workflowID, executionID string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	wf, ok := l.workflows[workflowID]
+	if !ok {
+		return nil
+	}
+	delete(wf.slots, executionID)
+	l.leaveLine(wf, executionID)
+	if len(wf.slots) == 0 && len(wf.waiting) == 0 {
+		delete(l.workflows, workflowID)
+	}
+	return nil
+}
+
+// workflow returns the slots of a workflow. The caller holds l.mu.
+func (l *MemoryConcurrencyLimiter) workflow(workflowID string) *memoryConcurrency {
+	wf, ok := l.workflows[workflowID]
+	if !ok {
+		wf = &memoryConcurrency{slots: make(map[string]time.Time), seen: make(map[string]time.Time)}
+		l.workflows[workflowID] = wf
+	}
+	return wf
+}
+
+// leaveLine removes an execution from the line. The caller holds l.mu.
+func (l *MemoryConcurrencyLimiter) leaveLine(wf *memoryConcurrency, executionID string) {
+	wf.waiting = slices.DeleteFunc(wf.waiting, func(id string) bool { return id == executionID })
+	delete(wf.seen, executionID)
+}
@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryConcurrencyLimiter_Line(t *testing.T) {
+	limiter := NewMemoryConcurrencyLimiter()
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	acquire := func(executionID string, wait bool) bool {
+		acquired, err := limiter.Acquire(ctx, "wf-1", executionID, 1, wait, time.Minute)
+		require.NoError(t, err)
+		return acquired
+	}
+
+	assert.True(t, acquire("exec-1", true))
+	assert.True(t, acquire("exec-1", true), "a held slot is kept")
+	assert.False(t, acquire("exec-2", true))
+	assert.False(t, acquire("exec-3", true))
+	other, err := limiter.Acquire(ctx, "wf-2", "exec-5", 1, false, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, other, "each workflow has its own slots")
+
+	require.NoError(t, limiter.Release(ctx, "wf-1", "exec-1"))
+	assert.False(t, acquire("exec-4", false), "executions in line go first")
+	assert.False(t, acquire("exec-3", true), "executions in line get slots in order")
+	assert.True(t, acquire("exec-2", true))
+
+	// exec-3 stops asking: its place in line lapses after the TTL
+	require.NoError(t, limiter.Release(ctx, "wf-1", "exec-2"))
+	now = now.Add(2 * time.Minute)
+	assert.True(t, acquire("exec-4", false))
+}
+
+func TestMemoryConcurrencyLimiter_SlotsExpire(t *testing.T) {
+	limiter := NewMemoryConcurrencyLimiter()
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	acquired, err := limiter.Acquire(ctx, "wf-1", "exec-1", 1, false, time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	now = now.Add(50 * time.Second)
+	require.NoError(t, limiter.Extend(ctx, "wf-1", "exec-1", time.Minute))
+	now = now.Add(50 * time.Second)
+	acquired, err = limiter.Acquire(ctx, "wf-1", "exec-2", 1, false, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired, "an extended slot is still held")
+
+	// The instance running exec-1 stopped extending its slot
+	now = now.Add(time.Minute)
+	acquired, err = limiter.Acquire(ctx, "wf-1", "exec-2", 1, false, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+}
+
+func TestHoldSlot_OverflowPolicies(t *testing.T) {
+	pollInterval := concurrencyPollInterval
+	concurrencyPollInterval = time.Millisecond
+	defer func() { concurrencyPollInterval = pollInterval }()
+
+	em := &ExecutionManager{concurrency: NewMemoryConcurrencyLimiter()}
+	ctx := context.Background()
+	workflow := &models.Workflow{ID: "wf-1", Metadata: map[string]any{
+		models.MetadataMaxConcurrentExecutions: float64(1),
+		models.MetadataConcurrencyOverflow:     "reject",
+	}}
+
+	release, err := em.holdSlot(ctx, workflow, "exec-1")
+	require.NoError(t, err)
+
+	_, err = em.holdSlot(ctx, workflow, "exec-2")
+	assert.ErrorIs(t, err, models.ErrConcurrencyLimitReached)
+
+	// With the queue policy an execution waits until the running one finishes
+	workflow.Metadata[models.MetadataConcurrencyOverflow] = "queue"
+	held := make(chan func(), 1)
+	go func() {
+		releaseNext, err := em.holdSlot(ctx, workflow, "exec-2")
+		assert.NoError(t, err)
+		held <- releaseNext
+	}()
+
+	select {
+	case <-held:
+		t.Fatal("execution got a slot while the workflow is at its limit")
+	case <-time.After(20 * time.Millisecond):
+	}
+	release()
+
+	select {
+	case releaseNext := <-held:
+		releaseNext()
+	case <-time.After(time.Second):
+		t.Fatal("waiting execution did not get the freed slot")
+	}
+
+	// A waiting execution whose context is done leaves the line
+	release, err = em.holdSlot(ctx, workflow, "exec-3")
+	require.NoError(t, err)
+	defer release()
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = em.holdSlot(waitCtx, workflow, "exec-4")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
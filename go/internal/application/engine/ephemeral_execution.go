@@ -23,6 +23,11 @@ func (em *ExecutionManager) ExecuteEphemeral(ctx context.Context, opts *Ephemera
 		return nil, fmt.Errorf("invalid workflow: %w", err)
 	}
 
+	// Ephemeral executions run at once, so over quota they are rejected, not queued
+	if _, err := em.admitExecution(ctx, opts.Propagation.UserID, time.Now(), false); err != nil {
+		return nil, err
+	}
+
 	execution := em.buildEphemeralExecution(opts)
 
 	redactor := NewEventRedactor()
@@ -65,6 +70,8 @@ func (em *ExecutionManager) buildEphemeralExecution(opts *EphemeralExecutionOpti
 
 func (em *ExecutionManager) buildEphemeralDAGExecutor(notifier pkgengine.ExecutionNotifier) *pkgengine.DAGExecutor {
 	nodeExecutor := pkgengine.NewNodeExecutor(em.executorManager)
+	nodeExecutor.SetAdaptiveParallelism(em.parallelism)
+	nodeExecutor.SetHealthGate(em.healthGate)
 	condEvaluator := pkgengine.NewExprConditionEvaluator()
 	workflowLoader := pkgengine.NewNilWorkflowLoader()
 	return pkgengine.NewDAGExecutor(nodeExecutor, condEvaluator, notifier, workflowLoader)
@@ -104,8 +111,10 @@ func (em *ExecutionManager) executeEphemeralSync(
 		execution.Input,
 		execution.Variables,
 	)
+	execState.Propagation = pkgOpts.Propagation
 
 	execErr := dagExecutor.Execute(ctx, execState, pkgOpts)
+	em.recordUsage(ctx, execState, nil)
 
 	em.finalizeEphemeralExecution(execution, execState, opts.Workflow, execErr)
 
@@ -115,7 +124,7 @@ func (em *ExecutionManager) executeEphemeralSync(
 		}
 	}
 
-	em.notifyExecutionCompletion(ctx, execution, execErr)
+	em.notifyExecutionCompletion(ctx, execution, execState.Workflow, execErr)
 
 	return execution, execErr
 }
@@ -159,8 +168,10 @@ func (em *ExecutionManager) executeEphemeralAsync(
 			execution.Input,
 			execution.Variables,
 		)
+		execState.Propagation = pkgOpts.Propagation
 
 		execErr := dagExecutor.Execute(bgCtx, execState, pkgOpts)
+		em.recordUsage(bgCtx, execState, nil)
 
 		em.finalizeEphemeralExecution(execution, execState, opts.Workflow, execErr)
 
@@ -172,7 +183,7 @@ func (em *ExecutionManager) executeEphemeralAsync(
 			}
 		}
 
-		em.notifyExecutionCompletion(bgCtx, execution, execErr)
+		em.notifyExecutionCompletion(bgCtx, execution, execState.Workflow, execErr)
 		em.markEphemeralTerminal(execution.ID)
 	}()
 
@@ -247,6 +258,8 @@ func buildEphemeralNodeExecutions(execState *pkgengine.ExecutionState, workflow
 			nodeExec.CompletedAt = &endTime
 		}
 
+		nodeExec.Metadata = execState.NodeMetadata(node.ID)
+
 		nodeExecs = append(nodeExecs, nodeExec)
 	}
 
@@ -308,6 +321,7 @@ func convertEphemeralToPkgOptions(opts *EphemeralExecutionOptions) *pkgengine.Ex
 
 	pkgOpts.StrictMode = opts.StrictMode
 	pkgOpts.ContinueOnError = opts.ContinueOnError
+	pkgOpts.Propagation = opts.Propagation
 
 	return pkgOpts
 }
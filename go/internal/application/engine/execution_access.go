@@ -0,0 +1,38 @@
+package engine
+
+// mayControl reports whether a user may cancel, pause or resume an execution run for the
+// user runFor of a workflow owned by owner: both of them may. Any user may control an
+// execution of an unowned workflow that runs for nobody. Admins may control any execution;
+// callers check that first.
+func mayControl(userID, runFor, owner string) bool {
+	if runFor == "" && owner == "" {
+		return true
+	}
+	return userID != "" && (userID == runFor || userID == owner)
+}
+
+// controller is the user asking to control a stored execution.
+type controller struct {
+	userID  string
+	isAdmin bool
+}
+
+// mayControl reports whether the user may control the stored execution (see mayControl).
+func (c *claimedResume) mayControl(by *controller) bool {
+	if by.isAdmin {
+		return true
+	}
+	runFor := ""
+	if c.state.Options != nil {
+		runFor = c.state.Options.Propagation.UserID
+	}
+	return mayControl(by.userID, runFor, c.workflow.CreatedBy)
+}
+
+// workspaceID returns the workspace the stored execution runs in.
+func (c *claimedResume) workspaceID() string {
+	if c.state.Options == nil {
+		return ""
+	}
+	return c.state.Options.Propagation.WorkspaceID
+}
@@ -0,0 +1,158 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// ListBreakpoints returns the breakpoints a paused debug execution stopped at, with the
+// inputs their nodes would run with. It fails with ErrNotAtBreakpoint if the execution is not
+// stopped at a breakpoint.
+func (em *ExecutionManager) ListBreakpoints(ctx context.Context, executionID string) ([]*models.Breakpoint, error) {
+	id, err := uuid.Parse(executionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", models.ErrInvalidExecutionID, executionID)
+	}
+
+	executionModel, err := em.executionRepo.FindByIDWithRelations(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	state, err := breakpointState(executionModel)
+	if err != nil {
+		return nil, err
+	}
+	if executionModel.WorkflowID == nil {
+		return nil, fmt.Errorf("execution %s has no stored workflow", executionID)
+	}
+	workflowModel, err := em.workflowRepo.FindByIDWithRelations(ctx, *executionModel.WorkflowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workflow: %w", err)
+	}
+
+	inputs := make(map[uuid.UUID]storagemodels.JSONBMap, len(executionModel.NodeExecutions))
+	for _, nodeExec := range executionModel.NodeExecutions {
+		if nodeExec.NodeID != nil {
+			inputs[*nodeExec.NodeID] = nodeExec.InputData
+		}
+	}
+
+	breakpoints := make([]*models.Breakpoint, 0, len(state.BreakpointsHit))
+	for _, nodeID := range state.BreakpointsHit {
+		breakpoint := &models.Breakpoint{
+			ExecutionID: executionID,
+			NodeID:      nodeID,
+			PausedAt:    state.Checkpoint.Timestamp,
+		}
+		for _, nodeModel := range workflowModel.Nodes {
+			if nodeModel.NodeID != nodeID {
+				continue
+			}
+			breakpoint.NodeName = nodeModel.Name
+			breakpoint.NodeType = nodeModel.Type
+			breakpoint.Config = nodeModel.Config
+			breakpoint.Input = inputs[nodeModel.ID]
+			break
+		}
+		breakpoints = append(breakpoints, breakpoint)
+	}
+	return breakpoints, nil
+}
+
+// ContinueFromBreakpoints claims an execution stopped at breakpoints and resumes it in the
+// background: the nodes it stopped at run, each with its entry in inputs as its input if
+// there is one, and the execution runs on until it finishes or reaches another breakpoint.
+// It returns the execution as claimed, or fails with ErrForbidden if the user is not an admin
+// and may not resume it (see mayControl).
+func (em *ExecutionManager) ContinueFromBreakpoints(
+	ctx context.Context,
+	executionID string,
+	inputs map[string]map[string]any,
+	userID string,
+	isAdmin bool,
+) (*models.Execution, error) {
+	state, err := em.loadBreakpointState(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+	for nodeID := range inputs {
+		if !slices.Contains(state.BreakpointsHit, nodeID) {
+			return nil, &models.ValidationError{Field: "inputs", Message: fmt.Sprintf("execution is not stopped at node %q", nodeID)}
+		}
+	}
+
+	claimed, err := em.claimResume(ctx, executionID, nil, &controller{userID: userID, isAdmin: isAdmin})
+	if err != nil {
+		return nil, err
+	}
+	claimed.breakpointInputs = make(map[string]map[string]any, len(claimed.state.BreakpointsHit))
+	for _, nodeID := range claimed.state.BreakpointsHit {
+		claimed.breakpointInputs[nodeID] = inputs[nodeID]
+	}
+	return em.resumeInBackground(claimed), nil
+}
+
+// AbortAtBreakpoints cancels an execution stopped at breakpoints on behalf of the user. The
+// nodes it stopped at and those downstream of them do not run. It fails with ErrForbidden if
+// the user is not an admin and may not cancel it (see mayControl).
+func (em *ExecutionManager) AbortAtBreakpoints(ctx context.Context, executionID, userID string, isAdmin bool) (*models.Execution, error) {
+	if _, err := em.loadBreakpointState(ctx, executionID); err != nil {
+		return nil, err
+	}
+
+	claimed, err := em.claimResume(ctx, executionID, nil, &controller{userID: userID, isAdmin: isAdmin})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	execution := storagemodels.ExecutionModelToDomain(claimed.executionModel)
+	execution.WorkflowName = claimed.workflow.Name
+	execution.Status = models.ExecutionStatusCancelled
+	execution.Error = fmt.Sprintf("aborted at breakpoints %v by user %s", claimed.state.BreakpointsHit, userID)
+	execution.CompletedAt = &now
+	execution.ResumeAt = nil
+	execution.Duration = execution.CalculateDuration()
+
+	if err := em.executionRepo.Update(ctx, storagemodels.ExecutionDomainToModel(execution)); err != nil {
+		return nil, fmt.Errorf("failed to update execution: %w", err)
+	}
+
+	em.notifyExecutionCompletion(ctx, execution, claimed.workflow, models.ErrExecutionCancelled)
+	return execution, nil
+}
+
+// loadBreakpointState loads the resume state of an execution stopped at breakpoints.
+func (em *ExecutionManager) loadBreakpointState(ctx context.Context, executionID string) (*resumeState, error) {
+	id, err := uuid.Parse(executionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", models.ErrInvalidExecutionID, executionID)
+	}
+	executionModel, err := em.executionRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return breakpointState(executionModel)
+}
+
+// breakpointState returns the resume state of a paused execution that stopped at breakpoints,
+// or fails with ErrNotAtBreakpoint.
+func breakpointState(executionModel *storagemodels.ExecutionModel) (*resumeState, error) {
+	if !executionModel.IsPaused() {
+		return nil, fmt.Errorf("%w: execution %s is %s", models.ErrNotAtBreakpoint, executionModel.ID, executionModel.Status)
+	}
+	state, err := decodeResumeState(executionModel.ResumeState)
+	if err != nil {
+		return nil, err
+	}
+	if len(state.BreakpointsHit) == 0 {
+		return nil, fmt.Errorf("%w: execution %s did not stop at a breakpoint", models.ErrNotAtBreakpoint, executionModel.ID)
+	}
+	return state, nil
+}
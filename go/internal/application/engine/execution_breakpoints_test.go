@@ -0,0 +1,100 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgengine "github.com/smilemakc/mbflow/go/pkg/engine"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakpointState(t *testing.T) {
+	workflow := &models.Workflow{
+		ID:    "wf-1",
+		Nodes: []*models.Node{{ID: "draft", Type: "test"}, {ID: "ask", Type: "test"}},
+		Edges: []*models.Edge{{ID: "e1", From: "draft", To: "ask"}},
+	}
+	registry := executor.NewManager()
+	require.NoError(t, registry.Register("test", executor.NewExecutorFunc(func(ctx context.Context, config map[string]any, input any) (any, error) {
+		return map[string]any{"ok": true}, nil
+	}, nil)))
+	dagExec := pkgengine.NewDAGExecutor(pkgengine.NewNodeExecutor(registry), pkgengine.NewExprConditionEvaluator(), pkgengine.NewNoOpNotifier(), pkgengine.NewNilWorkflowLoader())
+
+	opts := &ExecutionOptions{Breakpoints: []string{"ask"}}
+	execState := pkgengine.NewExecutionState("exec-1", "wf-1", workflow, map[string]any{}, nil)
+	err := dagExec.Execute(context.Background(), execState, convertToPkgOptions(opts))
+	require.True(t, errors.Is(err, models.ErrExecutionPaused), "got %v", err)
+
+	encoded, err := encodeResumeState(newResumeState(execState, opts))
+	require.NoError(t, err)
+	executionModel := &storagemodels.ExecutionModel{ID: uuid.New(), Status: string(models.ExecutionStatusPaused), ResumeState: encoded}
+
+	state, err := breakpointState(executionModel)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ask"}, state.BreakpointsHit)
+	assert.Equal(t, []string{"ask"}, convertFromPkgOptions(state.Options).Breakpoints)
+
+	// Executions paused by a user, or no longer paused, are not at a breakpoint
+	encoded, err = encodeResumeState(newResumeState(pkgengine.NewExecutionState("exec-2", "wf-1", workflow, nil, nil), nil))
+	require.NoError(t, err)
+	_, err = breakpointState(&storagemodels.ExecutionModel{ID: uuid.New(), Status: string(models.ExecutionStatusPaused), ResumeState: encoded})
+	assert.True(t, errors.Is(err, models.ErrNotAtBreakpoint), "got %v", err)
+
+	executionModel.Status = string(models.ExecutionStatusRunning)
+	_, err = breakpointState(executionModel)
+	assert.True(t, errors.Is(err, models.ErrNotAtBreakpoint), "got %v", err)
+}
+
+// breakpointTestRepo stubs the execution repository calls made before an execution stopped at
+// breakpoints is claimed. It does not stub the claim, which a forbidden caller must not reach.
+type breakpointTestRepo struct {
+	recoveryTestRepo
+}
+
+func (r *breakpointTestRepo) FindByID(ctx context.Context, id uuid.UUID) (*storagemodels.ExecutionModel, error) {
+	return r.FindByIDWithRelations(ctx, id)
+}
+
+func TestContinueFromBreakpoints_ChecksAccess(t *testing.T) {
+	owner := uuid.New()
+	workflowID := uuid.New()
+	workflow := &models.Workflow{ID: workflowID.String(), Nodes: []*models.Node{{ID: "ask", Type: "test"}}}
+	registry := executor.NewManager()
+	require.NoError(t, registry.Register("test", executor.NewExecutorFunc(func(ctx context.Context, config map[string]any, input any) (any, error) {
+		return map[string]any{"ok": true}, nil
+	}, nil)))
+	dagExec := pkgengine.NewDAGExecutor(pkgengine.NewNodeExecutor(registry), pkgengine.NewExprConditionEvaluator(), pkgengine.NewNoOpNotifier(), pkgengine.NewNilWorkflowLoader())
+
+	opts := &ExecutionOptions{Breakpoints: []string{"ask"}}
+	execState := pkgengine.NewExecutionState("exec-1", workflowID.String(), workflow, map[string]any{}, nil)
+	execState.Propagation.UserID = "runner-1"
+	err := dagExec.Execute(context.Background(), execState, convertToPkgOptions(opts))
+	require.True(t, errors.Is(err, models.ErrExecutionPaused), "got %v", err)
+	encoded, err := encodeResumeState(newResumeState(execState, opts))
+	require.NoError(t, err)
+
+	execution := &storagemodels.ExecutionModel{ID: uuid.New(), WorkflowID: &workflowID, Status: string(models.ExecutionStatusPaused), ResumeState: encoded}
+	workflowRepo := new(mockEngineWorkflowRepo)
+	workflowRepo.On("FindByIDWithRelations", mock.Anything, workflowID).Return(&storagemodels.WorkflowModel{
+		ID:        workflowID,
+		CreatedBy: &owner,
+		Nodes:     []*storagemodels.NodeModel{{NodeID: "ask", Type: "test"}},
+	}, nil)
+	repo := &breakpointTestRepo{recoveryTestRepo{stale: []*storagemodels.ExecutionModel{execution}}}
+	em := &ExecutionManager{executionRepo: repo, workflowRepo: workflowRepo}
+
+	inputs := map[string]map[string]any{"ask": {"question": "injected"}}
+	_, err = em.ContinueFromBreakpoints(context.Background(), execution.ID.String(), inputs, "other-1", false)
+	assert.True(t, errors.Is(err, models.ErrForbidden), "got %v", err)
+
+	_, err = em.AbortAtBreakpoints(context.Background(), execution.ID.String(), "other-1", false)
+	assert.True(t, errors.Is(err, models.ErrForbidden), "got %v", err)
+	assert.Empty(t, repo.updated)
+}
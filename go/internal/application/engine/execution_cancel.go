@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgengine "github.com/smilemakc/mbflow/go/pkg/engine"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// Cancel aborts an execution running on this instance on behalf of the user. Its running
+// nodes are interrupted through their context, so HTTP requests and streamed LLM calls stop
+// at once, and are saved as cancelled with the output they produced so far. It fails with
+// ErrExecutionNotRunning if the execution is not running here, and with ErrForbidden if the
+// user is not an admin and may not cancel it (see mayControl).
+func (em *ExecutionManager) Cancel(ctx context.Context, executionID, userID string, isAdmin bool) error {
+	em.running.mu.Lock()
+	defer em.running.mu.Unlock()
+
+	running, ok := em.running.executions[executionID]
+	if !ok {
+		return fmt.Errorf("%w: execution %s is not running on this instance", models.ErrExecutionNotRunning, executionID)
+	}
+	if !isAdmin && !running.canControl(userID) {
+		return fmt.Errorf("%w: user %q may not cancel execution %s", models.ErrForbidden, userID, executionID)
+	}
+
+	reason := "system"
+	if userID != "" {
+		reason = "user " + userID
+	}
+	running.state.Cancel(reason)
+	return nil
+}
+
+// canControl reports whether the user may cancel or pause the execution (see mayControl).
+func (r *runningExecution) canControl(userID string) bool {
+	runFor := r.state.Propagation.UserID
+	if runFor == "" && r.opts != nil {
+		runFor = r.opts.Propagation.UserID
+	}
+	owner := ""
+	if r.state.Workflow != nil {
+		owner = r.state.Workflow.CreatedBy
+	}
+	return mayControl(userID, runFor, owner)
+}
+
+// finalizeCancelled saves a cancelled execution with its nodes. It cannot be resumed, so no
+// resume state is kept.
+func (em *ExecutionManager) finalizeCancelled(
+	ctx context.Context,
+	execution *models.Execution,
+	workflowModel *storagemodels.WorkflowModel,
+	execState *pkgengine.ExecutionState,
+	execErr error,
+) error {
+	now := time.Now()
+	execution.Status = models.ExecutionStatusCancelled
+	execution.Error = execErr.Error()
+	execution.CompletedAt = &now
+	execution.Duration = execution.CalculateDuration()
+	execution.NodeExecutions = em.buildNodeExecutions(execState, execState.Workflow, workflowModel)
+
+	executionModel := storagemodels.ExecutionDomainToModel(execution)
+	if err := em.executionRepo.Update(ctx, executionModel); err != nil {
+		return fmt.Errorf("failed to update execution: %w", err)
+	}
+
+	em.notifyExecutionCompletion(ctx, execution, execState.Workflow, execErr)
+	return nil
+}
@@ -0,0 +1,52 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pkgengine "github.com/smilemakc/mbflow/go/pkg/engine"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancel(t *testing.T) {
+	em := &ExecutionManager{}
+
+	err := em.Cancel(context.Background(), "missing", "admin-1", true)
+	require.True(t, errors.Is(err, models.ErrExecutionNotRunning), "got %v", err)
+
+	report := preemptionTestState("report")
+	stop := em.startRunning("report", &ExecutionOptions{Priority: models.ExecutionPriorityNormal}, report)
+	require.NoError(t, em.Cancel(context.Background(), "report", "admin-1", true))
+	assert.True(t, report.CancelRequested())
+	assert.False(t, report.PauseRequested())
+	assert.Equal(t, "user admin-1", report.CancelReason())
+
+	stop()
+	assert.Error(t, em.Cancel(context.Background(), "report", "admin-1", true))
+}
+
+func TestCancel_ChecksAccess(t *testing.T) {
+	em := &ExecutionManager{}
+
+	report := pkgengine.NewExecutionState("report", "wf-1", &models.Workflow{ID: "wf-1", CreatedBy: "owner-1"}, nil, nil)
+	report.Propagation.UserID = "runner-1"
+	defer em.startRunning("report", &ExecutionOptions{Priority: models.ExecutionPriorityNormal}, report)()
+
+	err := em.Cancel(context.Background(), "report", "other-1", false)
+	require.True(t, errors.Is(err, models.ErrForbidden), "got %v", err)
+	err = em.Cancel(context.Background(), "report", "", false)
+	require.True(t, errors.Is(err, models.ErrForbidden), "got %v", err)
+	assert.False(t, report.CancelRequested())
+
+	require.NoError(t, em.Cancel(context.Background(), "report", "owner-1", false))
+	require.NoError(t, em.Cancel(context.Background(), "report", "runner-1", false))
+	assert.True(t, report.CancelRequested())
+
+	// Executions of unowned workflows run for nobody can be cancelled by anyone
+	adhoc := preemptionTestState("adhoc")
+	defer em.startRunning("adhoc", &ExecutionOptions{Priority: models.ExecutionPriorityNormal}, adhoc)()
+	require.NoError(t, em.Cancel(context.Background(), "adhoc", "other-1", false))
+}
@@ -19,6 +19,9 @@ type ExecutionCheckpoint struct {
 	NodeOutputs    map[string]any                        `json:"node_outputs"`
 	NodeStatuses   map[string]models.NodeExecutionStatus `json:"node_statuses"`
 	Variables      map[string]any                        `json:"variables"`
+	Suspensions    map[string]*pkgengine.Suspension      `json:"suspensions,omitempty"`
+	LoopIterations map[string]int                        `json:"loop_iterations,omitempty"`
+	LoopInputs     map[string]any                        `json:"loop_inputs,omitempty"`
 }
 
 // CreateCheckpoint creates a checkpoint from current execution state.
@@ -27,6 +30,7 @@ func CreateCheckpoint(execState *pkgengine.ExecutionState, waveIndex int) *Execu
 	outputs := make(map[string]any)
 	statuses := make(map[string]models.NodeExecutionStatus)
 	variables := make(map[string]any)
+	var suspensions map[string]*pkgengine.Suspension
 
 	for _, node := range execState.Workflow.Nodes {
 		if status, ok := execState.GetNodeStatus(node.ID); ok {
@@ -38,11 +42,18 @@ func CreateCheckpoint(execState *pkgengine.ExecutionState, waveIndex int) *Execu
 		if output, ok := execState.GetNodeOutput(node.ID); ok {
 			outputs[node.ID] = output
 		}
+		if suspension, ok := execState.GetSuspension(node.ID); ok {
+			if suspensions == nil {
+				suspensions = make(map[string]*pkgengine.Suspension)
+			}
+			suspensions[node.ID] = suspension
+		}
 	}
 
 	for k, v := range execState.Variables {
 		variables[k] = v
 	}
+	loopIterations, loopInputs := execState.LoopState()
 
 	return &ExecutionCheckpoint{
 		ExecutionID:    execState.ExecutionID,
@@ -53,6 +64,9 @@ func CreateCheckpoint(execState *pkgengine.ExecutionState, waveIndex int) *Execu
 		NodeOutputs:    outputs,
 		NodeStatuses:   statuses,
 		Variables:      variables,
+		Suspensions:    suspensions,
+		LoopIterations: loopIterations,
+		LoopInputs:     loopInputs,
 	}
 }
 
@@ -72,10 +86,33 @@ func RestoreFromCheckpoint(checkpoint *ExecutionCheckpoint, workflow *models.Wor
 	for k, v := range checkpoint.NodeStatuses {
 		execState.SetNodeStatus(k, v)
 	}
+	for k, v := range checkpoint.Suspensions {
+		execState.SuspendNode(k, v)
+	}
+	execState.RestoreLoopState(checkpoint.LoopIterations, checkpoint.LoopInputs)
 
 	return execState
 }
 
+// KeepCompleted drops the statuses and outputs of the nodes that did not complete, so that an
+// execution restored from the checkpoint runs them again. Suspended nodes are kept.
+func (cp *ExecutionCheckpoint) KeepCompleted() {
+	kept := func(nodeID string) bool {
+		_, suspended := cp.Suspensions[nodeID]
+		return suspended || cp.NodeStatuses[nodeID] == models.NodeExecutionStatusCompleted
+	}
+	for nodeID := range cp.NodeOutputs {
+		if !kept(nodeID) {
+			delete(cp.NodeOutputs, nodeID)
+		}
+	}
+	for nodeID := range cp.NodeStatuses {
+		if !kept(nodeID) {
+			delete(cp.NodeStatuses, nodeID)
+		}
+	}
+}
+
 // Serialize converts checkpoint to JSON.
 func (cp *ExecutionCheckpoint) Serialize() ([]byte, error) {
 	return json.Marshal(cp)
@@ -106,6 +143,11 @@ func ValidateCheckpoint(checkpoint *ExecutionCheckpoint, workflow *models.Workfl
 			return fmt.Errorf("checkpoint references non-existent node: %s", nodeID)
 		}
 	}
+	for nodeID := range checkpoint.Suspensions {
+		if !nodeIDs[nodeID] {
+			return fmt.Errorf("checkpoint references non-existent node: %s", nodeID)
+		}
+	}
 
 	return nil
 }
@@ -106,6 +106,43 @@ func TestRestoreFromCheckpoint(t *testing.T) {
 	}
 }
 
+func TestCheckpoint_LoopStateRoundTrip(t *testing.T) {
+	t.Parallel()
+	workflow := &models.Workflow{
+		ID:    "wf-1",
+		Name:  "Test Workflow",
+		Nodes: []*models.Node{{ID: "node-1", Name: "Node 1"}},
+		Edges: []*models.Edge{},
+	}
+
+	execState := pkgengine.NewExecutionState("exec-1", "wf-1", workflow, map[string]any{}, map[string]any{})
+	execState.IncrementLoopIteration("loop-1")
+	execState.IncrementLoopIteration("loop-1")
+	execState.SetLoopInput("node-1", map[string]any{"retry": "yes"})
+
+	data, err := CreateCheckpoint(execState, 3).Serialize()
+	if err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	checkpoint, err := DeserializeCheckpoint(data)
+	if err != nil {
+		t.Fatalf("DeserializeCheckpoint failed: %v", err)
+	}
+
+	restored := RestoreFromCheckpoint(checkpoint, workflow, map[string]any{})
+
+	if got := restored.GetLoopIteration("loop-1"); got != 2 {
+		t.Errorf("expected loop iteration 2, got %d", got)
+	}
+	input, ok := restored.GetLoopInput("node-1")
+	if !ok {
+		t.Fatal("expected loop input to be restored")
+	}
+	if inputMap, ok := input.(map[string]any); !ok || inputMap["retry"] != "yes" {
+		t.Errorf("expected loop input to match checkpoint, got %v", input)
+	}
+}
+
 func TestCheckpoint_Serialization(t *testing.T) {
 	t.Parallel()
 	checkpoint := &ExecutionCheckpoint{
@@ -231,6 +268,46 @@ func TestCheckpoint_IsNodeCompleted(t *testing.T) {
 	}
 }
 
+func TestCheckpoint_KeepCompleted(t *testing.T) {
+	t.Parallel()
+	workflow := &models.Workflow{
+		ID: "wf-1",
+		Nodes: []*models.Node{
+			{ID: "fetch", Name: "Fetch"},
+			{ID: "transform", Name: "Transform"},
+			{ID: "wait", Name: "Wait"},
+			{ID: "load", Name: "Load"},
+		},
+	}
+
+	execState := pkgengine.NewExecutionState("exec-1", "wf-1", workflow, map[string]any{}, nil)
+	execState.SetNodeStatus("fetch", models.NodeExecutionStatusCompleted)
+	execState.SetNodeOutput("fetch", map[string]any{"rows": 3})
+	execState.SetNodeStatus("transform", models.NodeExecutionStatusFailed)
+	execState.SetNodeOutput("transform", map[string]any{"partial": true})
+	execState.SuspendNode("wait", &pkgengine.Suspension{Output: map[string]any{"waited": true}})
+
+	checkpoint := CreateCheckpoint(execState, 0)
+	checkpoint.KeepCompleted()
+
+	restored := RestoreFromCheckpoint(checkpoint, workflow, map[string]any{})
+	if status, _ := restored.GetNodeStatus("fetch"); status != models.NodeExecutionStatusCompleted {
+		t.Errorf("expected completed node to be kept, got %s", status)
+	}
+	if _, ok := restored.GetNodeOutput("fetch"); !ok {
+		t.Error("expected output of completed node to be kept")
+	}
+	if _, ok := restored.GetNodeStatus("transform"); ok {
+		t.Error("expected failed node status to be dropped")
+	}
+	if _, ok := restored.GetNodeOutput("transform"); ok {
+		t.Error("expected failed node output to be dropped")
+	}
+	if _, ok := restored.GetSuspension("wait"); !ok {
+		t.Error("expected suspended node to be kept")
+	}
+}
+
 func TestCheckpointManager(t *testing.T) {
 	t.Parallel()
 	manager := NewCheckpointManager()
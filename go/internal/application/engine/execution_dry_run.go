@@ -0,0 +1,174 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgengine "github.com/smilemakc/mbflow/go/pkg/engine"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// DryRun walks a stored workflow with the given input without side effects: templates are
+// resolved and edge conditions evaluated as in a real run, but executors that are not
+// side-effect free (HTTP, LLM, ...) are stubbed with mockOutputs, keyed by node ID, or an
+// empty object. No execution is recorded, no concurrency slot is taken and no events are
+// sent to observers.
+func (em *ExecutionManager) DryRun(
+	ctx context.Context,
+	workflowID string,
+	input map[string]any,
+	mockOutputs map[string]any,
+	opts *ExecutionOptions,
+) (*models.DryRunReport, error) {
+	if opts == nil {
+		opts = DefaultExecutionOptions()
+	}
+
+	workflowUUID, err := uuid.Parse(workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workflow ID: %w", err)
+	}
+
+	workflowModel, err := em.workflowRepo.FindByIDWithRelations(ctx, workflowUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workflow: %w", err)
+	}
+
+	workflow := storagemodels.WorkflowModelToDomain(workflowModel)
+
+	if len(opts.NodeConfigOverrides) > 0 {
+		if err := applyNodeConfigOverrides(workflow, opts.NodeConfigOverrides); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Profile != "" {
+		profile, err := workflow.GetLaunchProfile(opts.Profile)
+		if err != nil {
+			return nil, err
+		}
+		input, opts = applyLaunchProfile(profile, input, opts)
+	}
+	if input == nil {
+		input = make(map[string]any)
+	}
+
+	execState := pkgengine.NewExecutionState(
+		uuid.New().String(),
+		workflow.ID,
+		workflow,
+		input,
+		pkgengine.MergeVariables(workflow.Variables, opts.Variables),
+	)
+	execState.Propagation = opts.Propagation
+	if execState.Propagation.UserID == "" {
+		execState.Propagation.UserID = workflow.CreatedBy
+	}
+
+	if len(workflow.Resources) > 0 {
+		resourceMap, err := em.loadAndValidateResources(ctx, workflow)
+		if err != nil {
+			return nil, err
+		}
+		execState.Resources = resourceMap
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	pkgOpts := convertToPkgOptions(opts)
+	pkgOpts.DryRun = true
+	pkgOpts.MockOutputs = mockOutputs
+
+	// A separate DAG executor keeps the run away from observers and webhooks
+	dagExecutor := pkgengine.NewDAGExecutor(
+		em.nodeExecutor,
+		pkgengine.NewExprConditionEvaluator(),
+		pkgengine.NewNoOpNotifier(),
+		NewRepositoryWorkflowLoader(em.workflowRepo),
+	)
+
+	startedAt := time.Now()
+	execErr := dagExecutor.Execute(ctx, execState, pkgOpts)
+
+	report := em.buildDryRunReport(execState, execErr)
+	report.DurationMs = time.Since(startedAt).Milliseconds()
+	return report, nil
+}
+
+// buildDryRunReport reports the nodes of a dry run in the order they ran.
+func (em *ExecutionManager) buildDryRunReport(execState *pkgengine.ExecutionState, execErr error) *models.DryRunReport {
+	workflow := execState.Workflow
+	report := &models.DryRunReport{
+		WorkflowID: workflow.ID,
+		Status:     models.ExecutionStatusCompleted,
+		WouldRun:   []string{},
+		Nodes:      make([]*models.DryRunNode, 0, len(workflow.Nodes)),
+	}
+	if execErr != nil {
+		report.Status = models.ExecutionStatusFailed
+		if errors.Is(execErr, context.Canceled) || errors.Is(execErr, context.DeadlineExceeded) {
+			report.Status = models.ExecutionStatusCancelled
+		}
+		report.Error = execErr.Error()
+	} else {
+		report.Output = em.getFinalOutput(execState)
+	}
+
+	startTimes := make(map[string]time.Time, len(workflow.Nodes))
+	for _, node := range workflow.Nodes {
+		dryRunNode := &models.DryRunNode{
+			NodeID:   node.ID,
+			NodeName: node.Name,
+			NodeType: node.Type,
+			Status:   models.NodeExecutionStatusPending,
+		}
+		if status, ok := execState.GetNodeStatus(node.ID); ok {
+			dryRunNode.Status = status
+		}
+		if startedAt, ok := execState.GetNodeStartTime(node.ID); ok {
+			startTimes[node.ID] = startedAt
+		}
+		if nodeInput, ok := execState.GetNodeInput(node.ID); ok {
+			dryRunNode.Input = nodeInput
+		}
+		if resolvedConfig, ok := execState.GetNodeResolvedConfig(node.ID); ok {
+			dryRunNode.ResolvedConfig = resolvedConfig
+		}
+		if output, ok := execState.GetNodeOutput(node.ID); ok {
+			dryRunNode.Output = output
+		}
+		if nodeErr, ok := execState.GetNodeError(node.ID); ok && nodeErr != nil {
+			dryRunNode.Error = nodeErr.Error()
+		}
+		if annotations, ok := execState.GetNodeAnnotations(node.ID); ok {
+			dryRunNode.Stubbed = annotations["dry_run_stubbed"] == true
+		}
+		report.Nodes = append(report.Nodes, dryRunNode)
+	}
+
+	// Nodes that started come first, by start time; the rest keep the workflow order
+	sort.SliceStable(report.Nodes, func(i, j int) bool {
+		si, iStarted := startTimes[report.Nodes[i].NodeID]
+		sj, jStarted := startTimes[report.Nodes[j].NodeID]
+		if iStarted != jStarted {
+			return iStarted
+		}
+		return iStarted && si.Before(sj)
+	})
+
+	for _, node := range report.Nodes {
+		if node.Status == models.NodeExecutionStatusCompleted {
+			report.WouldRun = append(report.WouldRun, node.NodeID)
+		}
+	}
+	return report
+}
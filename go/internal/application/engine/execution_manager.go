@@ -2,7 +2,9 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -21,9 +23,17 @@ type ExecutionManager struct {
 	executionRepo     repository.ExecutionRepository
 	eventRepo         repository.EventRepository
 	resourceRepo      repository.ResourceRepository
+	nodeExecutor      *pkgengine.NodeExecutor
 	dagExecutor       *pkgengine.DAGExecutor
 	observerManager   *observer.ObserverManager
 	ephemeralRegistry *EphemeralStreamRegistry
+	parallelism       *pkgengine.AdaptiveParallelism
+	healthGate        *pkgengine.ExecutorHealthGate
+	preemption        *PreemptionPolicy
+	queue             ExecutionQueue
+	concurrency       ConcurrencyLimiter
+	quotas            QuotaEnforcer
+	running           runningExecutions
 }
 
 // NewExecutionManager creates a new execution manager.
@@ -48,8 +58,10 @@ func NewExecutionManager(
 		executionRepo:   executionRepo,
 		eventRepo:       eventRepo,
 		resourceRepo:    resourceRepo,
+		nodeExecutor:    nodeExecutor,
 		dagExecutor:     dagExecutor,
 		observerManager: observerManager,
+		concurrency:     NewMemoryConcurrencyLimiter(),
 	}
 
 	if len(ephemeralRegistry) > 0 && ephemeralRegistry[0] != nil {
@@ -59,6 +71,20 @@ func NewExecutionManager(
 	return em
 }
 
+// SetAdaptiveParallelism limits concurrent calls per external provider in all executions,
+// including ephemeral ones, with a shared controller. It must be set before executions start.
+func (em *ExecutionManager) SetAdaptiveParallelism(ap *pkgengine.AdaptiveParallelism) {
+	em.parallelism = ap
+	em.nodeExecutor.SetAdaptiveParallelism(ap)
+}
+
+// SetExecutorHealthGate keeps nodes of all executions, including ephemeral ones, off executors
+// that failed their latest health check. It must be set before executions start.
+func (em *ExecutionManager) SetExecutorHealthGate(gate *pkgengine.ExecutorHealthGate) {
+	em.healthGate = gate
+	em.nodeExecutor.SetHealthGate(gate)
+}
+
 // ObserverManager returns the observer manager used for execution events.
 func (em *ExecutionManager) ObserverManager() *observer.ObserverManager {
 	return em.observerManager
@@ -81,17 +107,31 @@ func (em *ExecutionManager) IsEphemeralExecutionTerminal(executionID string) boo
 	return em.ephemeralRegistry.IsTerminal(executionID)
 }
 
-// Execute executes a workflow synchronously (blocks until completion).
+// Execute executes a workflow synchronously (blocks until completion). An execution of a
+// workflow at its concurrency limit is pending until it gets a slot.
 func (em *ExecutionManager) Execute(
 	ctx context.Context,
 	workflowID string,
 	input map[string]any,
 	opts *ExecutionOptions,
 ) (*models.Execution, error) {
-	execution, workflow, workflowModel, err := em.prepareExecution(ctx, workflowID, input, opts, models.ExecutionStatusRunning)
+	execution, workflow, workflowModel, opts, err := em.prepareExecution(ctx, workflowID, input, opts, models.ExecutionStatusRunning)
+	if err != nil {
+		return nil, err
+	}
+
+	releaseSlot, err := em.holdSlot(ctx, workflow, execution.ID)
 	if err != nil {
+		em.failExecution(context.Background(), storagemodels.ExecutionDomainToModel(execution), err)
 		return nil, err
 	}
+	defer releaseSlot()
+	if execution.Status == models.ExecutionStatusPending {
+		execution.Status = models.ExecutionStatusRunning
+		if err := em.executionRepo.Update(ctx, storagemodels.ExecutionDomainToModel(execution)); err != nil {
+			return nil, fmt.Errorf("failed to update execution status: %w", err)
+		}
+	}
 
 	// Register per-execution webhook observers
 	webhookNames := em.registerWebhookObservers(execution.ID, opts)
@@ -101,32 +141,53 @@ func (em *ExecutionManager) Execute(
 
 	execState, execErr := em.executeWorkflowDAG(ctx, execution, workflow, opts)
 
-	if err := em.finalizeExecution(ctx, execution, workflow, workflowModel, execState, execErr); err != nil {
+	if err := em.finalizeExecution(ctx, execution, workflow, workflowModel, execState, opts, execErr); err != nil {
 		return nil, err
 	}
 
+	// A suspended execution is paused, not failed; it resumes in the background.
+	// One paused on request resumes when asked to.
+	if errors.Is(execErr, models.ErrExecutionSuspended) || errors.Is(execErr, models.ErrExecutionPaused) {
+		return execution, nil
+	}
 	return execution, execErr
 }
 
-// ExecuteAsync executes a workflow asynchronously.
+// ExecuteAsync executes a workflow asynchronously. An execution with a future RunAt is
+// recorded as scheduled and returned without starting.
 func (em *ExecutionManager) ExecuteAsync(
 	ctx context.Context,
 	workflowID string,
 	input map[string]any,
 	opts *ExecutionOptions,
 ) (*models.Execution, error) {
-	execution, workflow, workflowModel, err := em.prepareExecution(ctx, workflowID, input, opts, models.ExecutionStatusPending)
+	execution, workflow, workflowModel, opts, err := em.prepareExecution(ctx, workflowID, input, opts, models.ExecutionStatusPending)
 	if err != nil {
 		return nil, err
 	}
+	if execution.ResumeAt != nil {
+		return execution, nil
+	}
 
-	go func() {
-		// Register per-execution webhook observers
-		webhookNames := em.registerWebhookObservers(execution.ID, opts)
-		defer em.unregisterWebhookObservers(webhookNames)
+	// Workers run queued executions and take the concurrency slot when they do; see ExecutionWorker
+	if em.queue != nil {
+		em.releaseSlot(workflow, execution.ID)
+		if err := em.enqueueExecution(ctx, execution, opts); err != nil {
+			return nil, err
+		}
+		return execution, nil
+	}
 
+	go func() {
 		bgCtx := context.Background()
 
+		releaseSlot, err := em.holdSlot(bgCtx, workflow, execution.ID)
+		if err != nil {
+			em.failExecution(bgCtx, storagemodels.ExecutionDomainToModel(execution), err)
+			return
+		}
+		defer releaseSlot()
+
 		execution.Status = models.ExecutionStatusRunning
 		executionModel := storagemodels.ExecutionDomainToModel(execution)
 		if err := em.executionRepo.Update(bgCtx, executionModel); err != nil {
@@ -134,43 +195,92 @@ func (em *ExecutionManager) ExecuteAsync(
 			return
 		}
 
-		em.notifyExecutionStarted(bgCtx, execution)
-
-		execState, execErr := em.executeWorkflowDAG(bgCtx, execution, workflow, opts)
-
-		if err := em.finalizeExecution(bgCtx, execution, workflow, workflowModel, execState, execErr); err != nil {
-			em.notifyExecutionError(bgCtx, execution, fmt.Errorf("failed to finalize execution: %w", err))
-			return
+		if err := em.runExecution(bgCtx, execution, workflow, workflowModel, opts); err != nil {
+			em.notifyExecutionError(bgCtx, execution, err)
 		}
 	}()
 
 	return execution, nil
 }
 
+// runExecution runs an execution saved as running until it finishes or is paused. It only
+// returns errors saving the execution; failures of the workflow are saved with it.
+func (em *ExecutionManager) runExecution(
+	ctx context.Context,
+	execution *models.Execution,
+	workflow *models.Workflow,
+	workflowModel *storagemodels.WorkflowModel,
+	opts *ExecutionOptions,
+) error {
+	// Register per-execution webhook observers
+	webhookNames := em.registerWebhookObservers(execution.ID, opts)
+	defer em.unregisterWebhookObservers(webhookNames)
+
+	em.notifyExecutionStarted(ctx, execution)
+
+	execState, execErr := em.executeWorkflowDAG(ctx, execution, workflow, opts)
+
+	if err := em.finalizeExecution(ctx, execution, workflow, workflowModel, execState, opts, execErr); err != nil {
+		return fmt.Errorf("failed to finalize execution: %w", err)
+	}
+	return nil
+}
+
 // prepareExecution loads workflow and creates execution record.
+// It returns the options the execution runs with, including launch profile settings.
+// A workflow at its concurrency limit rejects the execution before it is recorded, or
+// records it as pending in line for a slot; otherwise the execution holds a slot. A pending
+// execution with a future RunAt is recorded as scheduled, with its options, and takes no
+// slot until it is due. An execution over its user's daily quota is rejected before it is
+// recorded or, if the quota queues executions and this one may wait, scheduled for the next day.
 func (em *ExecutionManager) prepareExecution(
 	ctx context.Context,
 	workflowID string,
 	input map[string]any,
 	opts *ExecutionOptions,
 	initialStatus models.ExecutionStatus,
-) (*models.Execution, *models.Workflow, *storagemodels.WorkflowModel, error) {
+) (*models.Execution, *models.Workflow, *storagemodels.WorkflowModel, *ExecutionOptions, error) {
 	if opts == nil {
 		opts = DefaultExecutionOptions()
 	}
 
 	workflowUUID, err := uuid.Parse(workflowID)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("invalid workflow ID: %w", err)
+		return nil, nil, nil, nil, fmt.Errorf("invalid workflow ID: %w", err)
 	}
 
 	workflowModel, err := em.workflowRepo.FindByIDWithRelations(ctx, workflowUUID)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to load workflow: %w", err)
+		return nil, nil, nil, nil, fmt.Errorf("failed to load workflow: %w", err)
 	}
 
 	workflow := storagemodels.WorkflowModelToDomain(workflowModel)
 
+	if len(opts.NodeConfigOverrides) > 0 {
+		if err := applyNodeConfigOverrides(workflow, opts.NodeConfigOverrides); err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
+
+	// Reject an invalid selection before an execution is recorded
+	if opts.Selection != nil {
+		if _, _, err := pkgengine.SelectSubgraph(workflow, opts.Selection); err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
+
+	if err := pkgengine.ValidateBreakpoints(workflow, opts.Breakpoints); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	if opts.Profile != "" {
+		profile, err := workflow.GetLaunchProfile(opts.Profile)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		input, opts = applyLaunchProfile(profile, input, opts)
+	}
+
 	execution := &models.Execution{
 		ID:             uuid.New().String(),
 		WorkflowID:     workflow.ID,
@@ -181,13 +291,170 @@ func (em *ExecutionManager) prepareExecution(
 		Variables:      pkgengine.MergeVariables(workflow.Variables, opts.Variables),
 		StartedAt:      time.Now(),
 	}
+	if opts.Profile != "" {
+		execution.Metadata = map[string]any{"launch_profile": opts.Profile}
+	}
+	if opts.Propagation.CorrelationID != "" {
+		if execution.Metadata == nil {
+			execution.Metadata = make(map[string]any)
+		}
+		execution.Metadata["correlation_id"] = opts.Propagation.CorrelationID
+	}
+	if opts.Propagation.WorkspaceID != "" {
+		if execution.Metadata == nil {
+			execution.Metadata = make(map[string]any)
+		}
+		execution.Metadata["workspace_id"] = opts.Propagation.WorkspaceID
+	}
+	if len(opts.NodeConfigOverrides) > 0 {
+		if execution.Metadata == nil {
+			execution.Metadata = make(map[string]any)
+		}
+		execution.Metadata["node_config_overrides"] = opts.NodeConfigOverrides
+	}
+	if opts.Selection != nil {
+		if execution.Metadata == nil {
+			execution.Metadata = make(map[string]any)
+		}
+		execution.Metadata["partial"] = partialExecutionMetadata(opts.Selection)
+	}
+	if len(opts.Breakpoints) > 0 {
+		if execution.Metadata == nil {
+			execution.Metadata = make(map[string]any)
+		}
+		execution.Metadata["breakpoints"] = opts.Breakpoints
+	}
+	for key, value := range opts.Metadata {
+		if execution.Metadata == nil {
+			execution.Metadata = make(map[string]any)
+		}
+		execution.Metadata[key] = value
+	}
+
+	runAt := opts.RunAt
+	scheduled := initialStatus == models.ExecutionStatusPending && runAt.After(execution.StartedAt)
+	admitAt := execution.StartedAt
+	if scheduled {
+		admitAt = runAt
+	}
+	userID := executionUser(opts.Propagation.UserID, workflow)
+	queuedUntil, err := em.admitExecution(ctx, userID, admitAt, initialStatus == models.ExecutionStatusPending)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	// The execution counts against the quota only once it is created
+	created := false
+	defer func() {
+		if !created {
+			countedAt := admitAt
+			if !queuedUntil.IsZero() {
+				countedAt = queuedUntil
+			}
+			em.refundExecution(ctx, userID, countedAt)
+		}
+	}()
+	if !queuedUntil.IsZero() {
+		runAt, scheduled = queuedUntil, true
+		if execution.Metadata == nil {
+			execution.Metadata = make(map[string]any)
+		}
+		execution.Metadata["queued_by_quota"] = true
+	}
+
+	if scheduled {
+		runAt = runAt.UTC()
+		execution.StartedAt = runAt
+		execution.ResumeAt = &runAt
+		if execution.Metadata == nil {
+			execution.Metadata = make(map[string]any)
+		}
+		execution.Metadata["scheduled_for"] = runAt
+	} else {
+		acquired, err := em.acquireSlot(ctx, workflow, execution.ID)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		if !acquired {
+			execution.Status = models.ExecutionStatusPending
+		}
+	}
 
 	executionModel := storagemodels.ExecutionDomainToModel(execution)
+	if scheduled {
+		if executionModel.ResumeState, err = encodeResumeState(newQueuedState(opts)); err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
 	if err := em.executionRepo.Create(ctx, executionModel); err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to create execution: %w", err)
+		em.releaseSlot(workflow, execution.ID)
+		return nil, nil, nil, nil, fmt.Errorf("failed to create execution: %w", err)
+	}
+	created = true
+
+	return execution, workflow, workflowModel, opts, nil
+}
+
+// applyLaunchProfile layers a launch profile under the caller's input and options.
+// Explicit input keys and variables win over the profile; the caller's options are not modified.
+func applyLaunchProfile(profile *models.LaunchProfile, input map[string]any, opts *ExecutionOptions) (map[string]any, *ExecutionOptions) {
+	resolved := *opts
+	resolved.Variables = pkgengine.MergeVariables(profile.Environment, opts.Variables)
+	if profile.MaxParallelism > 0 {
+		resolved.MaxParallelism = profile.MaxParallelism
+	}
+	if profile.TimeoutSeconds > 0 {
+		resolved.Timeout = time.Duration(profile.TimeoutSeconds) * time.Second
+	}
+	return profile.MergeInput(input), &resolved
+}
+
+// partialExecutionMetadata describes the selection of a partial run. Boundary outputs are
+// recorded with the boundary node executions, so only their node IDs are listed.
+func partialExecutionMetadata(selection *models.NodeSelection) map[string]any {
+	metadata := make(map[string]any)
+	if len(selection.NodeIDs) > 0 {
+		metadata["node_ids"] = selection.NodeIDs
+	}
+	if selection.FromNode != "" {
+		metadata["from_node"] = selection.FromNode
 	}
+	if selection.ToNode != "" {
+		metadata["to_node"] = selection.ToNode
+	}
+	if len(selection.BoundaryOutputs) > 0 {
+		boundary := make([]string, 0, len(selection.BoundaryOutputs))
+		for nodeID := range selection.BoundaryOutputs {
+			boundary = append(boundary, nodeID)
+		}
+		sort.Strings(boundary)
+		metadata["boundary_nodes"] = boundary
+	}
+	return metadata
+}
 
-	return execution, workflow, workflowModel, nil
+// applyNodeConfigOverrides merges override values over the configs of the workflow's nodes.
+// The workflow must be a private copy; node configs are replaced, not modified in place.
+func applyNodeConfigOverrides(workflow *models.Workflow, overrides map[string]map[string]any) error {
+	nodes := make(map[string]*models.Node, len(workflow.Nodes))
+	for _, node := range workflow.Nodes {
+		nodes[node.ID] = node
+	}
+
+	for nodeID, values := range overrides {
+		node, ok := nodes[nodeID]
+		if !ok {
+			return fmt.Errorf("config override for unknown node: %s", nodeID)
+		}
+		config := make(map[string]any, len(node.Config)+len(values))
+		for key, value := range node.Config {
+			config[key] = value
+		}
+		for key, value := range values {
+			config[key] = value
+		}
+		node.Config = config
+	}
+	return nil
 }
 
 // executeWorkflowDAG executes the workflow DAG and returns execution state.
@@ -204,6 +471,11 @@ func (em *ExecutionManager) executeWorkflowDAG(
 		execution.Input,
 		execution.Variables,
 	)
+	execState.Propagation = opts.Propagation
+	if execState.Propagation.UserID == "" {
+		// Stored workflows run on behalf of their owner unless the caller says otherwise
+		execState.Propagation.UserID = workflow.CreatedBy
+	}
 
 	// Load and validate workflow resources
 	if len(workflow.Resources) > 0 {
@@ -216,21 +488,48 @@ func (em *ExecutionManager) executeWorkflowDAG(
 
 	// Convert internal options to pkg options
 	pkgOpts := convertToPkgOptions(opts)
+	// Stored executions are persisted while their delay nodes wait
+	pkgOpts.AllowSuspend = true
+
+	stopRunning := em.startRunning(execution.ID, opts, execState)
+	defer stopRunning()
 
 	execErr := em.dagExecutor.Execute(ctx, execState, pkgOpts)
+	em.recordUsage(ctx, execState, nil)
 
 	return execState, execErr
 }
 
 // finalizeExecution updates execution with results and saves to database.
+// An execution with suspended nodes, or paused on request, is saved as paused with the
+// state to resume it; a failed one keeps that state to be resumed from its failed nodes.
+// A cancelled one is saved as cancelled. An execution whose lease was lost is not saved: the
+// worker that reclaimed it owns it now.
 func (em *ExecutionManager) finalizeExecution(
 	ctx context.Context,
 	execution *models.Execution,
 	workflow *models.Workflow,
 	workflowModel *storagemodels.WorkflowModel,
 	execState *pkgengine.ExecutionState,
+	opts *ExecutionOptions,
 	execErr error,
 ) error {
+	if errors.Is(context.Cause(ctx), errLeaseLost) {
+		return errLeaseLost
+	}
+	if errors.Is(execErr, models.ErrExecutionCancelled) {
+		return em.finalizeCancelled(ctx, execution, workflowModel, execState, execErr)
+	}
+	if errors.Is(execErr, models.ErrExecutionSuspended) {
+		return em.suspendExecution(ctx, execution, workflowModel, execState, opts)
+	}
+	if errors.Is(execErr, models.ErrExecutionPreempted) {
+		return em.finalizePreempted(ctx, execution, workflowModel, execState, opts, execErr)
+	}
+	if errors.Is(execErr, models.ErrExecutionPaused) {
+		return em.finalizePaused(ctx, execution, workflowModel, execState, opts)
+	}
+
 	now := time.Now()
 	execution.CompletedAt = &now
 	execution.Duration = execution.CalculateDuration()
@@ -243,14 +542,23 @@ func (em *ExecutionManager) finalizeExecution(
 		execution.Output = em.getFinalOutput(execState)
 	}
 
-	execution.NodeExecutions = em.buildNodeExecutions(execState, workflow, workflowModel)
+	// A partial run reduces execState.Workflow to the executed subgraph
+	execution.NodeExecutions = em.buildNodeExecutions(execState, execState.Workflow, workflowModel)
 
 	executionModel := storagemodels.ExecutionDomainToModel(execution)
+	if execErr != nil && execution.WorkflowID != "" {
+		// A failed execution keeps the state to resume it from its failed nodes
+		encoded, err := encodeResumeState(newResumeState(execState, opts))
+		if err != nil {
+			return err
+		}
+		executionModel.ResumeState = encoded
+	}
 	if err := em.executionRepo.Update(ctx, executionModel); err != nil {
 		return fmt.Errorf("failed to update execution: %w", err)
 	}
 
-	em.notifyExecutionCompletion(ctx, execution, execErr)
+	em.notifyExecutionCompletion(ctx, execution, execState.Workflow, execErr)
 
 	return nil
 }
@@ -271,12 +579,15 @@ func (em *ExecutionManager) notifyExecutionStarted(ctx context.Context, executio
 	}
 }
 
-// notifyExecutionCompletion sends execution completion event.
-func (em *ExecutionManager) notifyExecutionCompletion(ctx context.Context, execution *models.Execution, execErr error) {
+// notifyExecutionCompletion sends execution completion event. Outputs of leaf nodes whose
+// data labels redact them are left out of the event.
+func (em *ExecutionManager) notifyExecutionCompletion(ctx context.Context, execution *models.Execution, workflow *models.Workflow, execErr error) {
 	if em.observerManager != nil {
 		duration := execution.Duration
 		eventType := observer.EventTypeExecutionCompleted
-		if execErr != nil {
+		if execution.Status == models.ExecutionStatusCancelled {
+			eventType = observer.EventTypeExecutionCancelled
+		} else if execErr != nil {
 			eventType = observer.EventTypeExecutionFailed
 		}
 
@@ -286,7 +597,7 @@ func (em *ExecutionManager) notifyExecutionCompletion(ctx context.Context, execu
 			WorkflowID:  execution.WorkflowID,
 			Timestamp:   time.Now(),
 			Status:      string(execution.Status),
-			Output:      execution.Output,
+			Output:      pkgengine.ObservableOutput(workflow, execution.Output),
 			DurationMs:  &duration,
 			Variables:   execution.Variables,
 		}
@@ -395,6 +706,17 @@ func (em *ExecutionManager) buildNodeExecutions(
 			nodeExec.CompletedAt = &endTime
 		}
 
+		nodeExec.Metadata = execState.NodeMetadata(node.ID)
+
+		if policy, err := models.ParseNodeDataLabels(node.Config); err == nil && !policy.IsEmpty() {
+			nodeExec.DataLabels = policy.Labels
+			finishedAt := time.Now()
+			if nodeExec.CompletedAt != nil {
+				finishedAt = *nodeExec.CompletedAt
+			}
+			nodeExec.PayloadExpiresAt = policy.PayloadExpiresAt(finishedAt)
+		}
+
 		nodeExecs = append(nodeExecs, nodeExec)
 	}
 
@@ -509,6 +831,12 @@ func convertToPkgOptions(opts *ExecutionOptions) *pkgengine.ExecutionOptions {
 		MaxTotalMemory:   opts.MaxTotalMemory,
 		EnableMemoryOpts: opts.EnableMemoryOpts,
 		Variables:        opts.Variables,
+		NumberMode:       opts.NumberMode,
+		Propagation:      opts.Propagation,
+		Selection:        opts.Selection,
+		Breakpoints:      opts.Breakpoints,
+
+		NodeTypeConcurrency: opts.NodeTypeConcurrency,
 	}
 
 	if opts.RetryPolicy != nil {
@@ -2,10 +2,14 @@ package engine
 
 import (
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
 	pkgengine "github.com/smilemakc/mbflow/go/pkg/engine"
 	"github.com/smilemakc/mbflow/go/pkg/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // ==================== MergeVariables Tests ====================
@@ -274,3 +278,120 @@ func TestExecutionManager_GetFinalOutput(t *testing.T) {
 		})
 	}
 }
+
+// ==================== applyLaunchProfile Tests ====================
+
+func TestApplyLaunchProfile(t *testing.T) {
+	profile := &models.LaunchProfile{
+		Input:          map[string]any{"mode": "full", "limit": 100},
+		Environment:    map[string]any{"region": "eu-west-1", "tier": "batch"},
+		MaxParallelism: 2,
+		TimeoutSeconds: 60,
+	}
+
+	opts := DefaultExecutionOptions()
+	opts.Profile = "nightly"
+	opts.Variables = map[string]any{"tier": "interactive"}
+
+	input, resolved := applyLaunchProfile(profile, map[string]any{"limit": 5}, opts)
+
+	assert.Equal(t, map[string]any{"mode": "full", "limit": 5}, input)
+	assert.Equal(t, map[string]any{"region": "eu-west-1", "tier": "interactive"}, resolved.Variables)
+	assert.Equal(t, 2, resolved.MaxParallelism)
+	assert.Equal(t, time.Minute, resolved.Timeout)
+	assert.Equal(t, "nightly", resolved.Profile)
+
+	// Caller options are left untouched
+	assert.Equal(t, 10, opts.MaxParallelism)
+	assert.Equal(t, map[string]any{"tier": "interactive"}, opts.Variables)
+}
+
+func TestApplyLaunchProfile_KeepsDefaults(t *testing.T) {
+	opts := DefaultExecutionOptions()
+
+	input, resolved := applyLaunchProfile(&models.LaunchProfile{}, map[string]any{"a": 1}, opts)
+
+	assert.Equal(t, map[string]any{"a": 1}, input)
+	assert.Equal(t, opts.MaxParallelism, resolved.MaxParallelism)
+	assert.Equal(t, opts.Timeout, resolved.Timeout)
+}
+
+// ==================== applyNodeConfigOverrides Tests ====================
+
+func TestApplyNodeConfigOverrides(t *testing.T) {
+	storedConfig := map[string]any{"provider": "openai", "model": "gpt-4o", "prompt": "Summarize {{input.text}}"}
+	workflow := &models.Workflow{
+		Nodes: []*models.Node{
+			{ID: "summarize", Type: "llm", Config: storedConfig},
+			{ID: "notify", Type: "http", Config: map[string]any{"url": "https://example.com"}},
+		},
+	}
+
+	err := applyNodeConfigOverrides(workflow, map[string]map[string]any{
+		"summarize": {"model": "gpt-4o-mini", "temperature": 0.2},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"provider":    "openai",
+		"model":       "gpt-4o-mini",
+		"prompt":      "Summarize {{input.text}}",
+		"temperature": 0.2,
+	}, workflow.Nodes[0].Config)
+	assert.Equal(t, "gpt-4o", storedConfig["model"], "stored config map must not be modified")
+	assert.Equal(t, map[string]any{"url": "https://example.com"}, workflow.Nodes[1].Config)
+}
+
+func TestApplyNodeConfigOverrides_UnknownNode(t *testing.T) {
+	workflow := &models.Workflow{Nodes: []*models.Node{{ID: "summarize", Type: "llm"}}}
+
+	err := applyNodeConfigOverrides(workflow, map[string]map[string]any{"missing": {"model": "x"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing")
+}
+
+// ==================== partialExecutionMetadata Tests ====================
+
+func TestPartialExecutionMetadata(t *testing.T) {
+	metadata := partialExecutionMetadata(&models.NodeSelection{
+		FromNode: "summarize",
+		BoundaryOutputs: map[string]any{
+			"fetch_b": map[string]any{"body": "large payload"},
+			"fetch_a": map[string]any{"body": "large payload"},
+		},
+	})
+
+	assert.Equal(t, map[string]any{
+		"from_node":      "summarize",
+		"boundary_nodes": []string{"fetch_a", "fetch_b"},
+	}, metadata)
+}
+
+func TestBuildNodeExecutions_DataLabels(t *testing.T) {
+	em := &ExecutionManager{}
+	workflow := &models.Workflow{
+		Nodes: []*models.Node{
+			{ID: "lookup", Name: "Lookup", Type: "http", Config: map[string]any{"data_labels": []any{"pii", "retain-1d"}}},
+			{ID: "notify", Name: "Notify", Type: "http"},
+		},
+	}
+	workflowModel := &storagemodels.WorkflowModel{Nodes: []*storagemodels.NodeModel{
+		{ID: uuid.New(), NodeID: "lookup"},
+		{ID: uuid.New(), NodeID: "notify"},
+	}}
+
+	finished := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	state := pkgengine.NewExecutionState("exec-1", "workflow-1", workflow, nil, nil)
+	state.SetNodeEndTime("lookup", finished)
+	state.SetNodeEndTime("notify", finished)
+
+	nodeExecs := em.buildNodeExecutions(state, workflow, workflowModel)
+	require.Len(t, nodeExecs, 2)
+
+	assert.Equal(t, []string{"pii", "retain-1d"}, nodeExecs[0].DataLabels)
+	require.NotNil(t, nodeExecs[0].PayloadExpiresAt)
+	assert.Equal(t, finished.Add(24*time.Hour), *nodeExecs[0].PayloadExpiresAt)
+
+	assert.Empty(t, nodeExecs[1].DataLabels)
+	assert.Nil(t, nodeExecs[1].PayloadExpiresAt)
+}
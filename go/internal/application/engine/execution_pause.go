@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgengine "github.com/smilemakc/mbflow/go/pkg/engine"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// Pause asks an execution running on this instance to stop at the next node boundary on
+// behalf of the user: its running nodes finish and it is saved as paused with a checkpoint of
+// its state until StartResume or Resume continues it. It fails with ErrExecutionNotRunning if
+// the execution is not running here, and with ErrForbidden if the user is not an admin and
+// may not pause it (see mayControl).
+func (em *ExecutionManager) Pause(ctx context.Context, executionID, userID string, isAdmin bool) error {
+	em.running.mu.Lock()
+	defer em.running.mu.Unlock()
+
+	running, ok := em.running.executions[executionID]
+	if !ok {
+		return fmt.Errorf("%w: execution %s is not running on this instance", models.ErrExecutionNotRunning, executionID)
+	}
+	if !isAdmin && !running.canControl(userID) {
+		return fmt.Errorf("%w: user %q may not pause execution %s", models.ErrForbidden, userID, executionID)
+	}
+	running.state.Pause("user " + userID)
+	return nil
+}
+
+// StartResume claims a paused execution on behalf of the user and continues it in the
+// background. It returns the execution as claimed, or fails with ErrExecutionNotPaused if it
+// is not paused and with ErrForbidden if the user is not an admin and may not resume it.
+func (em *ExecutionManager) StartResume(ctx context.Context, executionID, userID string, isAdmin bool) (*models.Execution, error) {
+	claimed, err := em.claimResume(ctx, executionID, nil, &controller{userID: userID, isAdmin: isAdmin})
+	if err != nil {
+		return nil, err
+	}
+	return em.resumeInBackground(claimed), nil
+}
+
+// resumeInBackground runs a claimed execution in its own goroutine and returns it as claimed.
+func (em *ExecutionManager) resumeInBackground(claimed *claimedResume) *models.Execution {
+	execution := storagemodels.ExecutionModelToDomain(claimed.executionModel)
+
+	go func() {
+		bgCtx := context.Background()
+		// Failures of the resumed workflow itself are reported when it is finalized
+		if resumed, err := em.runResume(bgCtx, claimed, nil); err != nil && resumed == nil {
+			failed := &models.Execution{ID: execution.ID, WorkflowID: claimed.workflow.ID}
+			em.notifyExecutionError(bgCtx, failed, fmt.Errorf("failed to resume execution: %w", err))
+		}
+	}()
+
+	execution.WorkflowName = claimed.workflow.Name
+	execution.Status = models.ExecutionStatusRunning
+	execution.Error = ""
+	execution.CompletedAt = nil
+	execution.ResumeAt = nil
+	return execution
+}
+
+// finalizePaused saves an execution paused on request with a checkpoint of its state. It
+// stays paused until it is resumed explicitly.
+func (em *ExecutionManager) finalizePaused(
+	ctx context.Context,
+	execution *models.Execution,
+	workflowModel *storagemodels.WorkflowModel,
+	execState *pkgengine.ExecutionState,
+	opts *ExecutionOptions,
+) error {
+	if execution.Metadata == nil {
+		execution.Metadata = make(map[string]any)
+	}
+	execution.Metadata["paused"] = map[string]any{
+		"at": time.Now().UTC().Format(time.RFC3339),
+		"by": execState.PauseReason(),
+	}
+	return em.pauseExecution(ctx, execution, workflowModel, execState, opts, nil)
+}
@@ -0,0 +1,79 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pkgengine "github.com/smilemakc/mbflow/go/pkg/engine"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPause(t *testing.T) {
+	em := &ExecutionManager{}
+
+	err := em.Pause(context.Background(), "missing", "admin-1", true)
+	require.True(t, errors.Is(err, models.ErrExecutionNotRunning), "got %v", err)
+
+	report := preemptionTestState("report")
+	stop := em.startRunning("report", &ExecutionOptions{Priority: models.ExecutionPriorityNormal}, report)
+	require.NoError(t, em.Pause(context.Background(), "report", "admin-1", true))
+	assert.True(t, report.PauseRequested())
+	assert.False(t, report.Preempted())
+	assert.Equal(t, "user admin-1", report.PauseReason())
+
+	stop()
+	assert.Error(t, em.Pause(context.Background(), "report", "admin-1", true))
+}
+
+func TestPause_ChecksAccess(t *testing.T) {
+	em := &ExecutionManager{}
+
+	report := pkgengine.NewExecutionState("report", "wf-1", &models.Workflow{ID: "wf-1", CreatedBy: "owner-1"}, nil, nil)
+	report.Propagation.UserID = "runner-1"
+	defer em.startRunning("report", &ExecutionOptions{Priority: models.ExecutionPriorityNormal}, report)()
+
+	err := em.Pause(context.Background(), "report", "other-1", false)
+	require.True(t, errors.Is(err, models.ErrForbidden), "got %v", err)
+	assert.False(t, report.PauseRequested())
+
+	require.NoError(t, em.Pause(context.Background(), "report", "runner-1", false))
+	assert.True(t, report.PauseRequested())
+}
+
+func TestClaimedResume_MayControl(t *testing.T) {
+	claimed := &claimedResume{
+		workflow: &models.Workflow{ID: "wf-1", CreatedBy: "owner-1"},
+		state:    &resumeState{Options: &pkgengine.ExecutionOptions{}},
+	}
+	claimed.state.Options.Propagation.UserID = "runner-1"
+
+	assert.False(t, claimed.mayControl(&controller{userID: "other-1"}))
+	assert.False(t, claimed.mayControl(&controller{}))
+	assert.True(t, claimed.mayControl(&controller{userID: "owner-1"}))
+	assert.True(t, claimed.mayControl(&controller{userID: "runner-1"}))
+	assert.True(t, claimed.mayControl(&controller{userID: "other-1", isAdmin: true}))
+
+	// Executions of unowned workflows run for nobody can be resumed by anyone
+	adhoc := &claimedResume{workflow: &models.Workflow{ID: "wf-2"}, state: &resumeState{}}
+	assert.True(t, adhoc.mayControl(&controller{userID: "other-1"}))
+}
+
+func TestStartRunning_IgnoresPausedExecutions(t *testing.T) {
+	em := &ExecutionManager{}
+	em.SetPreemptionPolicy(&PreemptionPolicy{MaxRunning: 1, MinPriority: models.ExecutionPriorityHigh, Checkpoint: true})
+
+	backfill := preemptionTestState("backfill")
+	defer em.startRunning("backfill", &ExecutionOptions{Priority: models.ExecutionPriorityLow}, backfill)()
+	backfill.Pause("user admin-1")
+
+	report := preemptionTestState("report")
+	defer em.startRunning("report", &ExecutionOptions{Priority: models.ExecutionPriorityLow}, report)()
+	defer em.startRunning("urgent", &ExecutionOptions{Priority: models.ExecutionPriorityCritical}, preemptionTestState("urgent"))()
+
+	// The paused execution is already stopping, so the other low-priority one yields
+	assert.False(t, backfill.Preempted())
+	assert.True(t, report.Preempted())
+}
@@ -0,0 +1,190 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// ExecutionQueue hands stored executions started asynchronously to worker processes. A worker
+// leases an execution for a TTL and extends the lease while it runs it; the executions of a
+// worker that stops extending its leases are handed to another worker. Higher-priority
+// executions are leased first, but an execution waiting long enough is leased before newer
+// higher-priority ones, so low-priority executions are not starved.
+type ExecutionQueue interface {
+	// Enqueue adds an execution with its priority and the payload needed to run it.
+	Enqueue(ctx context.Context, executionID string, priority models.ExecutionPriority, payload []byte) error
+	// Lease takes the next execution for the worker until now+ttl; it returns nil if the queue is empty.
+	Lease(ctx context.Context, workerID string, ttl time.Duration) (*ExecutionLease, error)
+	// Extend keeps a lease until now+ttl; it reports false if the worker no longer holds it.
+	Extend(ctx context.Context, lease *ExecutionLease, ttl time.Duration) (bool, error)
+	// Complete removes a leased execution from the queue.
+	Complete(ctx context.Context, lease *ExecutionLease) error
+	// ReclaimExpired queues the executions whose lease expired again and returns how many.
+	ReclaimExpired(ctx context.Context) (int, error)
+}
+
+// errLeaseLost cancels the run of a queued execution whose lease was reassigned to another
+// worker; that worker resumes it from its last checkpoint.
+var errLeaseLost = errors.New("execution lease lost")
+
+// ExecutionLease is an execution leased by a worker.
+type ExecutionLease struct {
+	ExecutionID string
+	WorkerID    string
+	Payload     []byte
+	Attempt     int // 1 for the first lease, incremented every time the execution is reassigned
+}
+
+// SetExecutionQueue makes ExecuteAsync enqueue stored executions for workers instead of
+// running them on this instance. It must be set before executions start.
+func (em *ExecutionManager) SetExecutionQueue(queue ExecutionQueue) {
+	em.queue = queue
+}
+
+// enqueueExecution queues a pending execution with the options it runs with. The execution
+// is saved as failed if it cannot be queued.
+func (em *ExecutionManager) enqueueExecution(ctx context.Context, execution *models.Execution, opts *ExecutionOptions) error {
+	payload, err := json.Marshal(newQueuedState(opts))
+	if err == nil {
+		err = em.queue.Enqueue(ctx, execution.ID, opts.Priority, payload)
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to enqueue execution: %w", err)
+		em.failExecution(ctx, storagemodels.ExecutionDomainToModel(execution), err)
+		return err
+	}
+	return nil
+}
+
+// newQueuedState captures the options of a queued execution. It is a resume state without a
+// checkpoint: the worker runs the execution from the start.
+func newQueuedState(opts *ExecutionOptions) *resumeState {
+	return &resumeState{
+		Options:             convertToPkgOptions(opts),
+		NodeConfigOverrides: opts.NodeConfigOverrides,
+		Webhooks:            opts.Webhooks,
+		Priority:            opts.Priority,
+	}
+}
+
+// RunQueued runs an execution leased from the queue and returns when it finishes or is paused.
+// A pending execution runs from the start once it has a slot of its workflow's concurrency
+// limit; it is saved as failed if the workflow rejects executions over its limit. A running one was leased by a worker that stopped:
+// unless its checkpoint is more recent than staleBefore, meaning another instance is running
+// it, it resumes from its checkpoint or, without one, runs again from the start. Executions
+// that finished meanwhile are ignored.
+func (em *ExecutionManager) RunQueued(ctx context.Context, lease *ExecutionLease, staleBefore time.Time) error {
+	id, err := uuid.Parse(lease.ExecutionID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", models.ErrInvalidExecutionID, lease.ExecutionID)
+	}
+
+	executionModel, err := em.executionRepo.FindByIDWithRelations(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to load execution: %w", err)
+	}
+
+	var claimed bool
+	switch {
+	case executionModel.IsPending():
+		var releaseSlot func()
+		releaseSlot, err = em.holdQueuedSlot(ctx, executionModel)
+		if err != nil {
+			if errors.Is(err, models.ErrConcurrencyLimitReached) {
+				em.failExecution(ctx, executionModel, err)
+				return nil
+			}
+			return err
+		}
+		defer releaseSlot()
+		claimed, err = em.executionRepo.ClaimPending(ctx, id)
+	case executionModel.IsRunning():
+		claimed, err = em.executionRepo.ClaimStale(ctx, id, staleBefore)
+	}
+	if err != nil || !claimed {
+		return err
+	}
+
+	if executionModel.IsRunning() && len(executionModel.ResumeState) > 0 {
+		resume, err := em.loadResume(ctx, executionModel)
+		if err != nil {
+			em.failExecution(ctx, executionModel, fmt.Errorf("execution was interrupted and cannot resume: %w", err))
+			return nil
+		}
+		// Failures of the resumed workflow itself are saved with the execution
+		if resumed, err := em.runResume(ctx, resume, nil); err != nil && resumed == nil {
+			return err
+		}
+		return nil
+	}
+
+	var state resumeState
+	if err := json.Unmarshal(lease.Payload, &state); err != nil {
+		em.failExecution(ctx, executionModel, fmt.Errorf("queued execution has invalid options: %w", err))
+		return nil
+	}
+	if state.Options == nil || executionModel.WorkflowID == nil {
+		em.failExecution(ctx, executionModel, fmt.Errorf("queued execution is incomplete"))
+		return nil
+	}
+	opts := convertFromPkgOptions(state.Options)
+	opts.NodeConfigOverrides = state.NodeConfigOverrides
+	opts.Webhooks = state.Webhooks
+	opts.Priority = state.Priority
+
+	workflowModel, err := em.workflowRepo.FindByIDWithRelations(ctx, *executionModel.WorkflowID)
+	if err != nil {
+		em.failExecution(ctx, executionModel, fmt.Errorf("failed to load workflow: %w", err))
+		return nil
+	}
+	workflow := storagemodels.WorkflowModelToDomain(workflowModel)
+	if len(opts.NodeConfigOverrides) > 0 {
+		if err := applyNodeConfigOverrides(workflow, opts.NodeConfigOverrides); err != nil {
+			em.failExecution(ctx, executionModel, err)
+			return nil
+		}
+	}
+
+	execution := storagemodels.ExecutionModelToDomain(executionModel)
+	execution.WorkflowName = workflow.Name
+	execution.Status = models.ExecutionStatusRunning
+	execution.NodeExecutions = nil
+
+	return em.runExecution(ctx, execution, workflow, workflowModel, opts)
+}
+
+// holdQueuedSlot waits for a slot of the concurrency limit of a queued execution's workflow.
+// An execution whose workflow cannot be loaded fails when it runs.
+func (em *ExecutionManager) holdQueuedSlot(ctx context.Context, executionModel *storagemodels.ExecutionModel) (func(), error) {
+	if executionModel.WorkflowID == nil || em.concurrency == nil {
+		return func() {}, nil
+	}
+	workflowModel, err := em.workflowRepo.FindByIDWithRelations(ctx, *executionModel.WorkflowID)
+	if err != nil {
+		return func() {}, nil
+	}
+	return em.holdSlot(ctx, storagemodels.WorkflowModelToDomain(workflowModel), executionModel.ID.String())
+}
+
+// AbandonQueued saves a queued execution that could not be run in attempts leases as failed.
+func (em *ExecutionManager) AbandonQueued(ctx context.Context, executionID string, attempts int) error {
+	id, err := uuid.Parse(executionID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", models.ErrInvalidExecutionID, executionID)
+	}
+	executionModel, err := em.executionRepo.FindByIDWithRelations(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to load execution: %w", err)
+	}
+	if executionModel.IsPending() || executionModel.IsRunning() {
+		em.failExecution(ctx, executionModel, fmt.Errorf("execution abandoned after %d attempts of workers to run it", attempts))
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+)
+
+// ExecutionRecoverer checkpoints the executions running on this instance on an interval and
+// takes over running executions whose checkpoint is older than staleAfter, left behind by an
+// instance that crashed. Stale executions are claimed before they resume, so several
+// instances may run a recoverer; staleAfter must be well above the interval.
+type ExecutionRecoverer struct {
+	manager    *ExecutionManager
+	interval   time.Duration
+	staleAfter time.Duration
+	batchSize  int
+	logger     *logger.Logger
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewExecutionRecoverer creates a new execution recoverer.
+func NewExecutionRecoverer(manager *ExecutionManager, interval, staleAfter time.Duration, batchSize int, log *logger.Logger) *ExecutionRecoverer {
+	if interval <= 0 {
+		interval = 15 * time.Second
+	}
+	if staleAfter <= interval {
+		staleAfter = 8 * interval
+	}
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	return &ExecutionRecoverer{
+		manager:    manager,
+		interval:   interval,
+		staleAfter: staleAfter,
+		batchSize:  batchSize,
+		logger:     log,
+	}
+}
+
+// Start checkpoints and recovers immediately and then on every interval until Stop is called.
+func (r *ExecutionRecoverer) Start() {
+	r.done = make(chan struct{})
+	r.wg.Add(1)
+	go r.loop()
+}
+
+// Stop stops the loop and checkpoints the running executions a last time. Executions
+// already recovered keep running.
+func (r *ExecutionRecoverer) Stop() {
+	if r.done == nil {
+		return
+	}
+	close(r.done)
+	r.wg.Wait()
+	r.done = nil
+	r.checkpoint()
+}
+
+func (r *ExecutionRecoverer) loop() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.checkpoint()
+
+		recovered, err := r.manager.RecoverStale(context.Background(), time.Now().Add(-r.staleAfter), r.batchSize)
+		if err != nil {
+			r.logger.Error("Recovering interrupted executions failed", "error", err)
+		} else if recovered > 0 {
+			r.logger.Info("Recovering interrupted executions", "count", recovered)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-r.done:
+			return
+		}
+	}
+}
+
+func (r *ExecutionRecoverer) checkpoint() {
+	if _, err := r.manager.CheckpointRunning(context.Background()); err != nil {
+		r.logger.Error("Checkpointing running executions failed", "error", err)
+	}
+}
@@ -0,0 +1,113 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// CheckpointRunning saves the engine state of every execution running on this instance, so
+// that another instance can resume it if this one stops without finishing it. It returns how
+// many executions were checkpointed.
+func (em *ExecutionManager) CheckpointRunning(ctx context.Context) (int, error) {
+	em.running.mu.Lock()
+	running := make(map[string]*runningExecution, len(em.running.executions))
+	for id, execution := range em.running.executions {
+		running[id] = execution
+	}
+	em.running.mu.Unlock()
+
+	checkpointed := 0
+	now := time.Now()
+	for executionID, execution := range running {
+		id, err := uuid.Parse(executionID)
+		if err != nil {
+			continue
+		}
+		encoded, err := encodeResumeState(newResumeState(execution.state, execution.opts))
+		if err != nil {
+			return checkpointed, err
+		}
+		// An execution finished meanwhile keeps the state it was finalized with
+		saved, err := em.executionRepo.SaveCheckpoint(ctx, id, encoded, now)
+		if err != nil {
+			return checkpointed, fmt.Errorf("failed to checkpoint execution %s: %w", executionID, err)
+		}
+		if saved {
+			checkpointed++
+		}
+	}
+	return checkpointed, nil
+}
+
+// RecoverStale takes over up to limit running executions not checkpointed since before, left
+// behind by an instance that stopped while running them, and returns how many were taken
+// over. Each resumes in the background from its last checkpoint: completed nodes keep their
+// outputs and the nodes that were running run again. Executions never checkpointed cannot
+// resume and are marked failed.
+func (em *ExecutionManager) RecoverStale(ctx context.Context, before time.Time, limit int) (int, error) {
+	executions, err := em.executionRepo.FindStaleRunning(ctx, before, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	recovered := 0
+	for _, stale := range executions {
+		executionID := stale.ID.String()
+		if em.isRunningHere(executionID) {
+			continue
+		}
+
+		// Only one instance takes over a stale execution
+		claimed, err := em.executionRepo.ClaimStale(ctx, stale.ID, before)
+		if err != nil {
+			return recovered, err
+		}
+		if !claimed {
+			continue
+		}
+		recovered++
+
+		executionModel, err := em.executionRepo.FindByIDWithRelations(ctx, stale.ID)
+		if err != nil {
+			return recovered, fmt.Errorf("failed to load execution: %w", err)
+		}
+		if len(executionModel.ResumeState) == 0 {
+			em.failExecution(ctx, executionModel, fmt.Errorf("execution was interrupted before its first checkpoint"))
+			continue
+		}
+		resume, err := em.loadResume(ctx, executionModel)
+		if err != nil {
+			em.failExecution(ctx, executionModel, fmt.Errorf("execution was interrupted and cannot resume: %w", err))
+			continue
+		}
+		em.resumeInBackground(resume)
+	}
+	return recovered, nil
+}
+
+// isRunningHere reports whether the execution is running on this instance.
+func (em *ExecutionManager) isRunningHere(executionID string) bool {
+	em.running.mu.Lock()
+	defer em.running.mu.Unlock()
+	_, ok := em.running.executions[executionID]
+	return ok
+}
+
+// failExecution saves a stored execution that cannot run as failed.
+func (em *ExecutionManager) failExecution(ctx context.Context, executionModel *storagemodels.ExecutionModel, cause error) {
+	now := time.Now()
+	execution := storagemodels.ExecutionModelToDomain(executionModel)
+	execution.Status = models.ExecutionStatusFailed
+	execution.Error = cause.Error()
+	execution.CompletedAt = &now
+
+	if err := em.executionRepo.Update(ctx, storagemodels.ExecutionDomainToModel(execution)); err != nil {
+		cause = fmt.Errorf("%w (failed to update execution: %v)", cause, err)
+	}
+	em.notifyExecutionError(ctx, execution, cause)
+}
@@ -0,0 +1,97 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recoveryTestRepo stubs the execution repository calls made by crash recovery.
+type recoveryTestRepo struct {
+	repository.ExecutionRepository
+
+	checkpoints map[uuid.UUID]storagemodels.JSONBMap
+	stale       []*storagemodels.ExecutionModel
+	claimed     []uuid.UUID
+	updated     []*storagemodels.ExecutionModel
+}
+
+func (r *recoveryTestRepo) SaveCheckpoint(_ context.Context, id uuid.UUID, state storagemodels.JSONBMap, _ time.Time) (bool, error) {
+	if r.checkpoints == nil {
+		r.checkpoints = make(map[uuid.UUID]storagemodels.JSONBMap)
+	}
+	r.checkpoints[id] = state
+	return true, nil
+}
+
+func (r *recoveryTestRepo) FindStaleRunning(context.Context, time.Time, int) ([]*storagemodels.ExecutionModel, error) {
+	return r.stale, nil
+}
+
+func (r *recoveryTestRepo) ClaimStale(_ context.Context, id uuid.UUID, _ time.Time) (bool, error) {
+	r.claimed = append(r.claimed, id)
+	return true, nil
+}
+
+func (r *recoveryTestRepo) FindByIDWithRelations(_ context.Context, id uuid.UUID) (*storagemodels.ExecutionModel, error) {
+	for _, execution := range r.stale {
+		if execution.ID == id {
+			return execution, nil
+		}
+	}
+	return nil, models.ErrExecutionNotFound
+}
+
+func (r *recoveryTestRepo) Update(_ context.Context, execution *storagemodels.ExecutionModel) error {
+	r.updated = append(r.updated, execution)
+	return nil
+}
+
+func TestCheckpointRunning(t *testing.T) {
+	repo := &recoveryTestRepo{}
+	em := &ExecutionManager{executionRepo: repo}
+
+	id := uuid.New()
+	workflow := &models.Workflow{ID: "wf-1", Nodes: []*models.Node{{ID: "fetch"}, {ID: "store"}}}
+	execState := RestoreFromCheckpoint(&ExecutionCheckpoint{ExecutionID: id.String(), WorkflowID: "wf-1"}, workflow, nil)
+	execState.SetNodeStatus("fetch", models.NodeExecutionStatusCompleted)
+	execState.SetNodeOutput("fetch", map[string]any{"rows": 3})
+	execState.IncrementLoopIteration("retry")
+	defer em.startRunning(id.String(), &ExecutionOptions{Priority: models.ExecutionPriorityHigh}, execState)()
+
+	count, err := em.CheckpointRunning(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	state, err := decodeResumeState(repo.checkpoints[id])
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionPriorityHigh, state.Priority)
+	assert.Equal(t, []string{"fetch"}, state.Checkpoint.CompletedNodes)
+	assert.Equal(t, 1, state.Checkpoint.LoopIterations["retry"])
+}
+
+func TestRecoverStale_FailsExecutionsNeverCheckpointed(t *testing.T) {
+	workflowID := uuid.New()
+	neverCheckpointed := &storagemodels.ExecutionModel{ID: uuid.New(), WorkflowID: &workflowID, Status: "running"}
+	runningHere := &storagemodels.ExecutionModel{ID: uuid.New(), WorkflowID: &workflowID, Status: "running"}
+	repo := &recoveryTestRepo{stale: []*storagemodels.ExecutionModel{neverCheckpointed, runningHere}}
+	em := &ExecutionManager{executionRepo: repo}
+	defer em.startRunning(runningHere.ID.String(), &ExecutionOptions{}, preemptionTestState(runningHere.ID.String()))()
+
+	recovered, err := em.RecoverStale(context.Background(), time.Now(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, recovered)
+	assert.Equal(t, []uuid.UUID{neverCheckpointed.ID}, repo.claimed)
+
+	require.Len(t, repo.updated, 1)
+	assert.Equal(t, "failed", repo.updated[0].Status)
+	assert.Contains(t, repo.updated[0].Error, "interrupted before its first checkpoint")
+	assert.NotNil(t, repo.updated[0].CompletedAt)
+}
@@ -0,0 +1,495 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/smilemakc/mbflow/go/internal/application/observer"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgengine "github.com/smilemakc/mbflow/go/pkg/engine"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// resumeState is stored with a paused or failed execution, and checkpointed for a running one,
+// and restores its engine state when it resumes.
+// Node inputs, configs and times are restored from the stored node executions.
+type resumeState struct {
+	Checkpoint          *ExecutionCheckpoint        `json:"checkpoint"`
+	Options             *pkgengine.ExecutionOptions `json:"options"`
+	NodeConfigOverrides map[string]map[string]any   `json:"node_config_overrides,omitempty"`
+	Webhooks            []WebhookSubscription       `json:"webhooks,omitempty"`
+	Priority            models.ExecutionPriority    `json:"priority,omitempty"`
+	BreakpointsHit      []string                    `json:"breakpoints_hit,omitempty"` // Breakpoint nodes the execution stopped at
+}
+
+// newResumeState captures the engine state and options of an execution to resume it later.
+func newResumeState(execState *pkgengine.ExecutionState, opts *ExecutionOptions) *resumeState {
+	pkgOpts := convertToPkgOptions(opts)
+	pkgOpts.Propagation = execState.Propagation
+	state := &resumeState{
+		Checkpoint:     CreateCheckpoint(execState, execState.CurrentWave()),
+		Options:        pkgOpts,
+		BreakpointsHit: execState.BreakpointsHit(),
+	}
+	if opts != nil {
+		state.NodeConfigOverrides = opts.NodeConfigOverrides
+		state.Webhooks = opts.Webhooks
+		state.Priority = opts.Priority
+	}
+	return state
+}
+
+// encodeResumeState converts the state to the JSONB column value.
+func encodeResumeState(state *resumeState) (storagemodels.JSONBMap, error) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode resume state: %w", err)
+	}
+	var encoded storagemodels.JSONBMap
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, fmt.Errorf("failed to encode resume state: %w", err)
+	}
+	return encoded, nil
+}
+
+// decodeResumeState reads the state stored with a paused or failed execution.
+func decodeResumeState(encoded storagemodels.JSONBMap) (*resumeState, error) {
+	if len(encoded) == 0 {
+		return nil, fmt.Errorf("execution has no resume state")
+	}
+	data, err := json.Marshal(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode resume state: %w", err)
+	}
+	var state resumeState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode resume state: %w", err)
+	}
+	if state.Checkpoint == nil || state.Options == nil {
+		return nil, fmt.Errorf("resume state is incomplete")
+	}
+	return &state, nil
+}
+
+// suspendExecution saves an execution whose nodes are suspended as paused, together with
+// the state needed to resume it when the earliest suspension is over.
+func (em *ExecutionManager) suspendExecution(
+	ctx context.Context,
+	execution *models.Execution,
+	workflowModel *storagemodels.WorkflowModel,
+	execState *pkgengine.ExecutionState,
+	opts *ExecutionOptions,
+) error {
+	resumeAt, ok := execState.NextResumeAt()
+	if !ok {
+		return fmt.Errorf("execution %s suspended without suspended nodes", execution.ID)
+	}
+	return em.pauseExecution(ctx, execution, workflowModel, execState, opts, &resumeAt)
+}
+
+// pauseExecution saves an execution as paused, together with the state needed to resume it at
+// resumeAt. With a nil resumeAt it stays paused until it is resumed explicitly and events for
+// its waiting nodes do not resume it either.
+func (em *ExecutionManager) pauseExecution(
+	ctx context.Context,
+	execution *models.Execution,
+	workflowModel *storagemodels.WorkflowModel,
+	execState *pkgengine.ExecutionState,
+	opts *ExecutionOptions,
+	resumeAt *time.Time,
+) error {
+	encoded, err := encodeResumeState(newResumeState(execState, opts))
+	if err != nil {
+		return err
+	}
+
+	execution.Status = models.ExecutionStatusPaused
+	execution.Error = ""
+	execution.CompletedAt = nil
+	execution.ResumeAt = resumeAt
+	execution.NodeExecutions = em.buildNodeExecutions(execState, execState.Workflow, workflowModel)
+
+	executionModel := storagemodels.ExecutionDomainToModel(execution)
+	executionModel.ResumeState = encoded
+	if resumeAt != nil {
+		executionModel.EventKeys = execState.EventKeys()
+	}
+	if err := em.executionRepo.Update(ctx, executionModel); err != nil {
+		return fmt.Errorf("failed to update execution: %w", err)
+	}
+
+	em.notifyExecutionPaused(ctx, execution)
+
+	return nil
+}
+
+// Resume continues a paused execution: suspended nodes whose delay is over complete and the
+// nodes downstream of them run. The execution is paused again if other nodes are still suspended.
+func (em *ExecutionManager) Resume(ctx context.Context, executionID string) (*models.Execution, error) {
+	return em.resume(ctx, executionID, nil)
+}
+
+// deliveredEvent is an external event resuming the nodes waiting for its key.
+type deliveredEvent struct {
+	key         string
+	payload     any
+	workspaceID string // Only executions run in the workspace receive the event
+}
+
+// resume continues a paused execution, first delivering the event, if any, to the nodes waiting for it.
+func (em *ExecutionManager) resume(ctx context.Context, executionID string, event *deliveredEvent) (*models.Execution, error) {
+	claimed, err := em.claimResume(ctx, executionID, event, nil)
+	if err != nil {
+		return nil, err
+	}
+	return em.runResume(ctx, claimed, event)
+}
+
+// claimedResume is a paused execution claimed by one caller and ready to run.
+type claimedResume struct {
+	executionModel *storagemodels.ExecutionModel
+	workflowModel  *storagemodels.WorkflowModel
+	workflow       *models.Workflow
+	state          *resumeState
+
+	// breakpointInputs releases the breakpoint nodes the execution stopped at; a non-nil
+	// input replaces the input of its node
+	breakpointInputs map[string]map[string]any
+}
+
+// claimResume loads a paused execution, checks that it can resume and claims it.
+// It fails with ErrExecutionNotPaused if the execution is not (or no longer) paused, and
+// with ErrForbidden if by is not nil and may not control it. by is nil when the engine
+// resumes the execution itself, such as on an event.
+func (em *ExecutionManager) claimResume(ctx context.Context, executionID string, event *deliveredEvent, by *controller) (*claimedResume, error) {
+	id, err := uuid.Parse(executionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", models.ErrInvalidExecutionID, executionID)
+	}
+
+	executionModel, err := em.executionRepo.FindByIDWithRelations(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load execution: %w", err)
+	}
+	if !executionModel.IsPaused() {
+		return nil, fmt.Errorf("%w: execution %s is %s", models.ErrExecutionNotPaused, executionID, executionModel.Status)
+	}
+
+	resume, err := em.loadResume(ctx, executionModel)
+	if err != nil {
+		return nil, err
+	}
+	if event != nil && (!waitsForEvent(resume.state.Checkpoint, event.key) || resume.workspaceID() != event.workspaceID) {
+		return nil, fmt.Errorf("%w: execution %s is not waiting for event %q", models.ErrExecutionNotPaused, executionID, event.key)
+	}
+	if by != nil && !resume.mayControl(by) {
+		return nil, fmt.Errorf("%w: user %q may not resume execution %s", models.ErrForbidden, by.userID, executionID)
+	}
+
+	// Only one caller resumes a paused execution
+	claimed, err := em.executionRepo.ClaimSuspended(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, fmt.Errorf("%w: execution %s was resumed by another caller", models.ErrExecutionNotPaused, executionID)
+	}
+
+	return resume, nil
+}
+
+// loadResume loads the state saved with a stored execution and the workflow to resume it with.
+func (em *ExecutionManager) loadResume(ctx context.Context, executionModel *storagemodels.ExecutionModel) (*claimedResume, error) {
+	executionID := executionModel.ID.String()
+	if executionModel.WorkflowID == nil {
+		return nil, fmt.Errorf("execution %s has no stored workflow to resume", executionID)
+	}
+
+	state, err := decodeResumeState(executionModel.ResumeState)
+	if err != nil {
+		return nil, err
+	}
+
+	workflowModel, err := em.workflowRepo.FindByIDWithRelations(ctx, *executionModel.WorkflowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workflow: %w", err)
+	}
+	workflow := storagemodels.WorkflowModelToDomain(workflowModel)
+	if len(state.NodeConfigOverrides) > 0 {
+		if err := applyNodeConfigOverrides(workflow, state.NodeConfigOverrides); err != nil {
+			return nil, err
+		}
+	}
+	if err := ValidateCheckpoint(state.Checkpoint, workflow); err != nil {
+		return nil, fmt.Errorf("cannot resume execution %s: %w", executionID, err)
+	}
+
+	return &claimedResume{
+		executionModel: executionModel,
+		workflowModel:  workflowModel,
+		workflow:       workflow,
+		state:          state,
+	}, nil
+}
+
+// runResume runs a claimed execution from its saved state until it finishes or is paused again.
+func (em *ExecutionManager) runResume(ctx context.Context, claimed *claimedResume, event *deliveredEvent) (*models.Execution, error) {
+	state, workflow := claimed.state, claimed.workflow
+
+	execution := storagemodels.ExecutionModelToDomain(claimed.executionModel)
+	execution.WorkflowName = workflow.Name
+	execution.Status = models.ExecutionStatusRunning
+	execution.Error = ""
+	execution.CompletedAt = nil
+	execution.ResumeAt = nil
+
+	webhookNames := em.registerWebhookObservers(execution.ID, &ExecutionOptions{Webhooks: state.Webhooks})
+	defer em.unregisterWebhookObservers(webhookNames)
+
+	em.notifyExecutionResumed(ctx, execution)
+
+	opts := convertFromPkgOptions(state.Options)
+	opts.NodeConfigOverrides = state.NodeConfigOverrides
+	opts.Webhooks = state.Webhooks
+	opts.Priority = state.Priority
+
+	execState := RestoreFromCheckpoint(state.Checkpoint, workflow, execution.Input)
+	execState.Propagation = opts.Propagation
+	restoreNodeExecutions(execState, claimed.executionModel.NodeExecutions, claimed.workflowModel)
+	if event != nil {
+		execState.DeliverEvent(event.key, event.payload, time.Now())
+	}
+	for nodeID, input := range claimed.breakpointInputs {
+		execState.ReleaseBreakpoint(nodeID, input)
+	}
+
+	var execErr error
+	if len(workflow.Resources) > 0 {
+		var resourceMap map[string]any
+		if resourceMap, execErr = em.loadAndValidateResources(ctx, workflow); execErr == nil {
+			execState.Resources = resourceMap
+		}
+	}
+	if execErr == nil {
+		pkgOpts := convertToPkgOptions(opts)
+		pkgOpts.AllowSuspend = true
+		stopRunning := em.startRunning(execution.ID, opts, execState)
+		finished := finishedNodes(execState)
+		execErr = em.dagExecutor.Execute(ctx, execState, pkgOpts)
+		stopRunning()
+		em.recordUsage(ctx, execState, finished)
+	}
+
+	if err := em.finalizeExecution(ctx, execution, workflow, claimed.workflowModel, execState, opts, execErr); err != nil {
+		return nil, err
+	}
+
+	if errors.Is(execErr, models.ErrExecutionSuspended) || errors.Is(execErr, models.ErrExecutionPreempted) ||
+		errors.Is(execErr, models.ErrExecutionPaused) {
+		return execution, nil
+	}
+	return execution, execErr
+}
+
+// ResumeDue resumes up to limit paused executions whose resume time has passed, each in its
+// own goroutine, and returns how many were started.
+func (em *ExecutionManager) ResumeDue(ctx context.Context, now time.Time, limit int) (int, error) {
+	executions, err := em.executionRepo.FindDueSuspended(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, executionModel := range executions {
+		executionID := executionModel.ID.String()
+		go func() {
+			bgCtx := context.Background()
+			execution := &models.Execution{ID: executionID}
+			if executionModel.WorkflowID != nil {
+				execution.WorkflowID = executionModel.WorkflowID.String()
+			}
+			// Failures of the resumed workflow itself are reported when it is finalized
+			if resumed, err := em.Resume(bgCtx, executionID); err != nil && resumed == nil {
+				em.notifyExecutionError(bgCtx, execution, fmt.Errorf("failed to resume execution: %w", err))
+			}
+		}()
+	}
+
+	return len(executions), nil
+}
+
+// DeliverEvent delivers an external event to the paused executions waiting for its key and
+// returns how many it resumed. Only the executions run in the workspace that the user may
+// control receive it; admins may deliver events to any execution of the workspace. Each
+// execution is claimed before DeliverEvent returns and then runs in its own goroutine: its
+// nodes waiting for the key complete with the event and the nodes downstream of them run.
+// Events for a key no execution waits for, for example because the wait timed out, are dropped.
+func (em *ExecutionManager) DeliverEvent(ctx context.Context, key string, payload any, workspaceID, userID string, isAdmin bool) (int, error) {
+	if key == "" {
+		return 0, &models.ValidationError{Field: "correlation_key", Message: "correlation key is required"}
+	}
+
+	executions, err := em.executionRepo.FindPausedByEventKey(ctx, key, maxEventDeliveries)
+	if err != nil {
+		return 0, err
+	}
+
+	event := &deliveredEvent{key: key, payload: payload, workspaceID: workspaceID}
+	by := &controller{userID: userID, isAdmin: isAdmin}
+	delivered := 0
+	for _, executionModel := range executions {
+		executionID := executionModel.ID.String()
+		claimed, err := em.claimResume(ctx, executionID, event, by)
+		// Executions of other workspaces and users are left alone as if they did not wait
+		if errors.Is(err, models.ErrExecutionNotPaused) || errors.Is(err, models.ErrForbidden) {
+			continue
+		}
+		if err != nil {
+			return delivered, fmt.Errorf("failed to deliver event to execution %s: %w", executionID, err)
+		}
+
+		delivered++
+		go func() {
+			bgCtx := context.Background()
+			// Failures of the resumed workflow itself are reported when it is finalized
+			if resumed, err := em.runResume(bgCtx, claimed, event); err != nil && resumed == nil {
+				execution := &models.Execution{ID: executionID, WorkflowID: claimed.workflow.ID}
+				em.notifyExecutionError(bgCtx, execution, fmt.Errorf("failed to deliver event %q: %w", key, err))
+			}
+		}()
+	}
+
+	return delivered, nil
+}
+
+// maxEventDeliveries caps the executions one event resumes.
+const maxEventDeliveries = 100
+
+// waitsForEvent reports whether a suspended node of the checkpoint waits for the event key.
+func waitsForEvent(checkpoint *ExecutionCheckpoint, key string) bool {
+	for _, suspension := range checkpoint.Suspensions {
+		if suspension.EventKey == key {
+			return true
+		}
+	}
+	return false
+}
+
+// convertFromPkgOptions converts the stored pkg options of a paused execution back to
+// ExecutionOptions; it is the inverse of convertToPkgOptions.
+func convertFromPkgOptions(pkgOpts *pkgengine.ExecutionOptions) *ExecutionOptions {
+	opts := &ExecutionOptions{
+		StrictMode:       pkgOpts.StrictMode,
+		MaxParallelism:   pkgOpts.MaxParallelism,
+		Timeout:          pkgOpts.Timeout,
+		NodeTimeout:      pkgOpts.NodeTimeout,
+		Variables:        pkgOpts.Variables,
+		ContinueOnError:  pkgOpts.ContinueOnError,
+		MaxOutputSize:    pkgOpts.MaxOutputSize,
+		MaxTotalMemory:   pkgOpts.MaxTotalMemory,
+		EnableMemoryOpts: pkgOpts.EnableMemoryOpts,
+		NumberMode:       pkgOpts.NumberMode,
+		Propagation:      pkgOpts.Propagation,
+		Selection:        pkgOpts.Selection,
+		Breakpoints:      pkgOpts.Breakpoints,
+
+		NodeTypeConcurrency: pkgOpts.NodeTypeConcurrency,
+	}
+
+	if pkgOpts.RetryPolicy != nil {
+		strategy := BackoffConstant
+		switch pkgOpts.RetryPolicy.BackoffStrategy {
+		case pkgengine.BackoffLinear:
+			strategy = BackoffLinear
+		case pkgengine.BackoffExponential:
+			strategy = BackoffExponential
+		}
+		opts.RetryPolicy = &RetryPolicy{
+			MaxAttempts:     pkgOpts.RetryPolicy.MaxAttempts,
+			InitialDelay:    pkgOpts.RetryPolicy.InitialDelay,
+			MaxDelay:        pkgOpts.RetryPolicy.MaxDelay,
+			BackoffStrategy: strategy,
+			RetryableErrors: pkgOpts.RetryPolicy.RetryOn,
+		}
+	}
+
+	return opts
+}
+
+// restoreNodeExecutions restores node inputs, configs, errors and times of a paused
+// execution, so the node executions saved when it finishes are complete.
+func restoreNodeExecutions(
+	execState *pkgengine.ExecutionState,
+	nodeExecutions []*storagemodels.NodeExecutionModel,
+	workflowModel *storagemodels.WorkflowModel,
+) {
+	uuidToLogical := make(map[uuid.UUID]string, len(workflowModel.Nodes))
+	for _, nodeModel := range workflowModel.Nodes {
+		uuidToLogical[nodeModel.ID] = nodeModel.NodeID
+	}
+
+	for _, nodeExec := range nodeExecutions {
+		if nodeExec.NodeID == nil {
+			continue
+		}
+		nodeID, ok := uuidToLogical[*nodeExec.NodeID]
+		if !ok {
+			continue
+		}
+
+		if nodeExec.InputData != nil {
+			execState.SetNodeInput(nodeID, map[string]any(nodeExec.InputData))
+		}
+		if nodeExec.Config != nil {
+			execState.SetNodeConfig(nodeID, nodeExec.Config)
+		}
+		if nodeExec.ResolvedConfig != nil {
+			execState.SetNodeResolvedConfig(nodeID, nodeExec.ResolvedConfig)
+		}
+		if nodeExec.Error != "" {
+			execState.SetNodeError(nodeID, errors.New(nodeExec.Error))
+		}
+		if nodeExec.StartedAt != nil {
+			execState.SetNodeStartTime(nodeID, *nodeExec.StartedAt)
+		}
+		if nodeExec.CompletedAt != nil {
+			execState.SetNodeEndTime(nodeID, *nodeExec.CompletedAt)
+		}
+	}
+}
+
+// notifyExecutionPaused sends execution paused event.
+func (em *ExecutionManager) notifyExecutionPaused(ctx context.Context, execution *models.Execution) {
+	if em.observerManager != nil {
+		event := observer.Event{
+			Type:        observer.EventTypeExecutionPaused,
+			ExecutionID: execution.ID,
+			WorkflowID:  execution.WorkflowID,
+			Timestamp:   time.Now(),
+			Status:      string(execution.Status),
+			Variables:   execution.Variables,
+		}
+		if execution.ResumeAt != nil {
+			event.Metadata = map[string]any{"resume_at": execution.ResumeAt.UTC().Format(time.RFC3339)}
+		}
+		em.observerManager.Notify(ctx, event)
+	}
+}
+
+// notifyExecutionResumed sends execution resumed event.
+func (em *ExecutionManager) notifyExecutionResumed(ctx context.Context, execution *models.Execution) {
+	if em.observerManager != nil {
+		event := observer.Event{
+			Type:        observer.EventTypeExecutionResumed,
+			ExecutionID: execution.ID,
+			WorkflowID:  execution.WorkflowID,
+			Timestamp:   time.Now(),
+			Status:      string(execution.Status),
+			Variables:   execution.Variables,
+		}
+		em.observerManager.Notify(ctx, event)
+	}
+}
@@ -0,0 +1,73 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// ResumeFailed continues a failed execution from the nodes that did not complete: completed
+// nodes keep their stored outputs and are not run again, failed and pending nodes run with
+// the current workflow, so a fixed node config applies. The execution is claimed before
+// ResumeFailed returns and then runs in the background. It fails with ErrExecutionNotFailed
+// if the execution is not (or no longer) failed, and with ErrForbidden if the user is not an
+// admin and may not resume it (see mayControl).
+func (em *ExecutionManager) ResumeFailed(ctx context.Context, executionID, userID string, isAdmin bool) (*models.Execution, error) {
+	claimed, err := em.claimFailed(ctx, executionID, &controller{userID: userID, isAdmin: isAdmin})
+	if err != nil {
+		return nil, err
+	}
+	return em.resumeInBackground(claimed), nil
+}
+
+// claimFailed loads a failed execution, checks that it can resume and that the user may
+// resume it, and claims it.
+func (em *ExecutionManager) claimFailed(ctx context.Context, executionID string, by *controller) (*claimedResume, error) {
+	id, err := uuid.Parse(executionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", models.ErrInvalidExecutionID, executionID)
+	}
+
+	executionModel, err := em.executionRepo.FindByIDWithRelations(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load execution: %w", err)
+	}
+	if !executionModel.IsFailed() {
+		return nil, fmt.Errorf("%w: execution %s is %s", models.ErrExecutionNotFailed, executionID, executionModel.Status)
+	}
+
+	resume, err := em.loadResume(ctx, executionModel)
+	if err != nil {
+		return nil, err
+	}
+	if !resume.mayControl(by) {
+		return nil, fmt.Errorf("%w: user %q may not resume execution %s", models.ErrForbidden, by.userID, executionID)
+	}
+	resume.state.Checkpoint.KeepCompleted()
+	resume.executionModel.NodeExecutions = completedNodeExecutions(executionModel.NodeExecutions)
+
+	// Only one caller resumes a failed execution
+	claimed, err := em.executionRepo.ClaimFailed(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, fmt.Errorf("%w: execution %s was resumed by another caller", models.ErrExecutionNotFailed, executionID)
+	}
+
+	return resume, nil
+}
+
+// completedNodeExecutions returns the node executions that completed; the others run again.
+func completedNodeExecutions(nodeExecutions []*storagemodels.NodeExecutionModel) []*storagemodels.NodeExecutionModel {
+	completed := make([]*storagemodels.NodeExecutionModel, 0, len(nodeExecutions))
+	for _, nodeExec := range nodeExecutions {
+		if nodeExec.IsCompleted() {
+			completed = append(completed, nodeExec)
+		}
+	}
+	return completed
+}
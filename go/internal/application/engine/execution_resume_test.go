@@ -0,0 +1,215 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgengine "github.com/smilemakc/mbflow/go/pkg/engine"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResumeState_RoundTrip(t *testing.T) {
+	workflow := &models.Workflow{
+		ID: "wf-1",
+		Nodes: []*models.Node{
+			{ID: "start", Type: "transform"},
+			{ID: "wait", Type: "delay"},
+			{ID: "notify", Type: "http"},
+		},
+	}
+	execState := pkgengine.NewExecutionState("exec-1", "wf-1", workflow, map[string]any{"order": "o-1"}, map[string]any{"env": "prod"})
+	execState.SetNodeStatus("start", models.NodeExecutionStatusCompleted)
+	execState.SetNodeOutput("start", map[string]any{"ok": true})
+	resumeAt := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	execState.SuspendNode("wait", &pkgengine.Suspension{ResumeAt: resumeAt, Output: map[string]any{"ok": true}})
+
+	opts := &ExecutionOptions{
+		MaxParallelism: 4,
+		NodeTimeout:    time.Minute,
+		RetryPolicy: &RetryPolicy{
+			MaxAttempts:     3,
+			InitialDelay:    time.Second,
+			BackoffStrategy: BackoffExponential,
+			RetryableErrors: []string{"timeout"},
+		},
+		Breakpoints: []string{"notify"},
+	}
+	encoded, err := encodeResumeState(&resumeState{
+		Checkpoint:          CreateCheckpoint(execState, 0),
+		Options:             convertToPkgOptions(opts),
+		NodeConfigOverrides: map[string]map[string]any{"notify": {"url": "https://example.com"}},
+		Webhooks:            []WebhookSubscription{{URL: "https://hooks.example.com", Events: []string{"execution.completed"}}},
+	})
+	require.NoError(t, err)
+
+	state, err := decodeResumeState(encoded)
+	require.NoError(t, err)
+
+	restored := RestoreFromCheckpoint(state.Checkpoint, workflow, map[string]any{"order": "o-1"})
+	suspension, ok := restored.GetSuspension("wait")
+	require.True(t, ok)
+	assert.True(t, resumeAt.Equal(suspension.ResumeAt))
+	assert.Equal(t, map[string]any{"ok": true}, suspension.Output)
+
+	status, _ := restored.GetNodeStatus("start")
+	assert.Equal(t, models.NodeExecutionStatusCompleted, status)
+	assert.Equal(t, "prod", restored.Variables["env"])
+
+	assert.Equal(t, "https://example.com", state.NodeConfigOverrides["notify"]["url"])
+	require.Len(t, state.Webhooks, 1)
+	assert.Equal(t, "https://hooks.example.com", state.Webhooks[0].URL)
+
+	// The stored options convert back to the options the execution started with
+	resumedOpts := convertFromPkgOptions(state.Options)
+	assert.Equal(t, convertToPkgOptions(opts), convertToPkgOptions(resumedOpts))
+}
+
+func TestDecodeResumeState_Missing(t *testing.T) {
+	_, err := decodeResumeState(nil)
+	assert.ErrorContains(t, err, "no resume state")
+
+	_, err = decodeResumeState(storagemodels.JSONBMap{"webhooks": []any{}})
+	assert.ErrorContains(t, err, "incomplete")
+}
+
+func TestRestoreNodeExecutions(t *testing.T) {
+	nodeUUID := uuid.New()
+	workflowModel := &storagemodels.WorkflowModel{
+		Nodes: []*storagemodels.NodeModel{{ID: nodeUUID, NodeID: "start"}},
+	}
+	startedAt := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	completedAt := startedAt.Add(time.Second)
+
+	execState := pkgengine.NewExecutionState("exec-1", "wf-1", &models.Workflow{ID: "wf-1"}, nil, nil)
+	restoreNodeExecutions(execState, []*storagemodels.NodeExecutionModel{
+		{
+			NodeID:      &nodeUUID,
+			InputData:   storagemodels.JSONBMap{"order": "o-1"},
+			Config:      storagemodels.JSONBMap{"type": "passthrough"},
+			Error:       "partial failure",
+			StartedAt:   &startedAt,
+			CompletedAt: &completedAt,
+		},
+		// Node executions of nodes no longer in the workflow are ignored
+		{NodeID: func() *uuid.UUID { id := uuid.New(); return &id }()},
+	}, workflowModel)
+
+	input, ok := execState.GetNodeInput("start")
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{"order": "o-1"}, input)
+
+	config, _ := execState.GetNodeConfig("start")
+	assert.Equal(t, "passthrough", config["type"])
+
+	nodeErr, ok := execState.GetNodeError("start")
+	require.True(t, ok)
+	assert.EqualError(t, nodeErr, "partial failure")
+
+	start, _ := execState.GetNodeStartTime("start")
+	end, _ := execState.GetNodeEndTime("start")
+	assert.Equal(t, startedAt, start)
+	assert.Equal(t, completedAt, end)
+}
+
+func TestWaitsForEvent_SurvivesResumeState(t *testing.T) {
+	workflow := &models.Workflow{ID: "wf-1", Nodes: []*models.Node{{ID: "callback", Type: "wait_for_event"}}}
+	execState := pkgengine.NewExecutionState("exec-1", "wf-1", workflow, nil, nil)
+	execState.SuspendNode("callback", &pkgengine.Suspension{
+		ResumeAt: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC),
+		Output:   map[string]any{"timed_out": true},
+		EventKey: "payment-o-1",
+	})
+
+	encoded, err := encodeResumeState(&resumeState{
+		Checkpoint: CreateCheckpoint(execState, 0),
+		Options:    convertToPkgOptions(nil),
+	})
+	require.NoError(t, err)
+	state, err := decodeResumeState(encoded)
+	require.NoError(t, err)
+
+	assert.True(t, waitsForEvent(state.Checkpoint, "payment-o-1"))
+	assert.False(t, waitsForEvent(state.Checkpoint, "payment-o-2"))
+	assert.Equal(t, []string{"payment-o-1"}, execState.EventKeys())
+}
+
+func TestResumeFailed_ChecksAccess(t *testing.T) {
+	owner := uuid.New()
+	workflowID := uuid.New()
+	workflow := &models.Workflow{ID: workflowID.String(), Nodes: []*models.Node{{ID: "fetch", Type: "test"}}}
+
+	execState := pkgengine.NewExecutionState("exec-1", workflowID.String(), workflow, nil, nil)
+	execState.Propagation.UserID = "runner-1"
+	encoded, err := encodeResumeState(newResumeState(execState, nil))
+	require.NoError(t, err)
+
+	// The repository does not stub ClaimFailed: a forbidden resume must not claim the execution
+	execution := &storagemodels.ExecutionModel{ID: uuid.New(), WorkflowID: &workflowID, Status: "failed", ResumeState: encoded}
+	workflowRepo := new(mockEngineWorkflowRepo)
+	workflowRepo.On("FindByIDWithRelations", mock.Anything, workflowID).Return(&storagemodels.WorkflowModel{
+		ID:        workflowID,
+		CreatedBy: &owner,
+		Nodes:     []*storagemodels.NodeModel{{NodeID: "fetch", Type: "test"}},
+	}, nil)
+	em := &ExecutionManager{executionRepo: &recoveryTestRepo{stale: []*storagemodels.ExecutionModel{execution}}, workflowRepo: workflowRepo}
+
+	_, err = em.ResumeFailed(context.Background(), execution.ID.String(), "other-1", false)
+	assert.True(t, errors.Is(err, models.ErrForbidden), "got %v", err)
+}
+
+// eventTestRepo serves paused executions waiting for an event and records the claims;
+// it claims none, so that no execution runs.
+type eventTestRepo struct {
+	recoveryTestRepo
+	claims []uuid.UUID
+}
+
+func (r *eventTestRepo) FindPausedByEventKey(context.Context, string, int) ([]*storagemodels.ExecutionModel, error) {
+	return r.stale, nil
+}
+
+func (r *eventTestRepo) ClaimSuspended(_ context.Context, id uuid.UUID) (bool, error) {
+	r.claims = append(r.claims, id)
+	return false, nil
+}
+
+func TestDeliverEvent_ScopedToWorkspaceAndUser(t *testing.T) {
+	workflowID := uuid.New()
+	workflow := &models.Workflow{ID: workflowID.String(), Nodes: []*models.Node{{ID: "callback", Type: "wait_for_event"}}}
+	workflowRepo := new(mockEngineWorkflowRepo)
+	workflowRepo.On("FindByIDWithRelations", mock.Anything, workflowID).Return(&storagemodels.WorkflowModel{
+		ID:    workflowID,
+		Nodes: []*storagemodels.NodeModel{{NodeID: "callback", Type: "wait_for_event"}},
+	}, nil)
+
+	waiting := func(workspaceID, userID string) *storagemodels.ExecutionModel {
+		execState := pkgengine.NewExecutionState("exec-1", workflowID.String(), workflow, nil, nil)
+		execState.Propagation.WorkspaceID = workspaceID
+		execState.Propagation.UserID = userID
+		execState.SuspendNode("callback", &pkgengine.Suspension{ResumeAt: time.Now().Add(time.Hour), EventKey: "payment-o-1"})
+		encoded, err := encodeResumeState(newResumeState(execState, nil))
+		require.NoError(t, err)
+		return &storagemodels.ExecutionModel{ID: uuid.New(), WorkflowID: &workflowID, Status: "paused", ResumeState: encoded}
+	}
+	mine := waiting("ws-a", "user-1")
+	otherWorkspace := waiting("ws-b", "user-1")
+	otherUser := waiting("ws-a", "user-2")
+	repo := &eventTestRepo{recoveryTestRepo: recoveryTestRepo{stale: []*storagemodels.ExecutionModel{mine, otherWorkspace, otherUser}}}
+	em := &ExecutionManager{executionRepo: repo, workflowRepo: workflowRepo}
+
+	_, err := em.DeliverEvent(context.Background(), "payment-o-1", nil, "ws-a", "user-1", false)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{mine.ID}, repo.claims)
+
+	repo.claims = nil
+	_, err = em.DeliverEvent(context.Background(), "payment-o-1", nil, "ws-a", "admin-1", true)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{mine.ID, otherUser.ID}, repo.claims)
+}
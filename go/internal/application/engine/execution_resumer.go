@@ -0,0 +1,85 @@
+package engine
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+)
+
+// ExecutionResumer resumes paused executions whose delay is over on an interval, and starts
+// scheduled executions whose start time has come. Executions are claimed before they resume
+// or start, so several instances may run a resumer.
+type ExecutionResumer struct {
+	manager   *ExecutionManager
+	interval  time.Duration
+	batchSize int
+	logger    *logger.Logger
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewExecutionResumer creates a new execution resumer.
+func NewExecutionResumer(manager *ExecutionManager, interval time.Duration, batchSize int, log *logger.Logger) *ExecutionResumer {
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	return &ExecutionResumer{
+		manager:   manager,
+		interval:  interval,
+		batchSize: batchSize,
+		logger:    log,
+	}
+}
+
+// Start scans for due executions immediately and then on every interval until Stop is called.
+func (r *ExecutionResumer) Start() {
+	r.done = make(chan struct{})
+	r.wg.Add(1)
+	go r.loop()
+}
+
+// Stop stops the scan loop. Executions already resumed keep running.
+func (r *ExecutionResumer) Stop() {
+	if r.done == nil {
+		return
+	}
+	close(r.done)
+	r.wg.Wait()
+	r.done = nil
+}
+
+func (r *ExecutionResumer) loop() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		resumed, err := r.manager.ResumeDue(context.Background(), time.Now(), r.batchSize)
+		if err != nil {
+			r.logger.Error("Resuming paused executions failed", "error", err)
+		} else if resumed > 0 {
+			r.logger.Info("Resuming paused executions", "count", resumed)
+		}
+
+		started, err := r.manager.StartDue(context.Background(), time.Now(), r.batchSize)
+		if err != nil {
+			r.logger.Error("Starting scheduled executions failed", "error", err)
+		} else if started > 0 {
+			r.logger.Info("Starting scheduled executions", "count", started)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-r.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// StartDue starts up to limit scheduled executions whose start time has passed and returns
+// how many were started. Each execution is claimed first, so several instances may scan for
+// due executions. With an execution queue they are handed to workers; otherwise each runs in
+// its own goroutine, once it has a slot of its workflow's concurrency limit.
+func (em *ExecutionManager) StartDue(ctx context.Context, now time.Time, limit int) (int, error) {
+	executions, err := em.executionRepo.FindDueScheduled(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	started := 0
+	for _, executionModel := range executions {
+		claimed, err := em.executionRepo.ClaimScheduled(ctx, executionModel.ID, now)
+		if err != nil {
+			return started, err
+		}
+		if !claimed {
+			continue
+		}
+		started++
+
+		executionID := executionModel.ID.String()
+		payload, err := json.Marshal(executionModel.ResumeState)
+		var state resumeState
+		if err == nil {
+			err = json.Unmarshal(payload, &state)
+		}
+		if err != nil || state.Options == nil {
+			em.failExecution(ctx, executionModel, fmt.Errorf("scheduled execution has invalid options: %v", err))
+			continue
+		}
+
+		if em.queue != nil {
+			if err := em.queue.Enqueue(ctx, executionID, state.Priority, payload); err != nil {
+				em.failExecution(ctx, executionModel, fmt.Errorf("failed to enqueue execution: %w", err))
+			}
+			continue
+		}
+
+		lease := &ExecutionLease{ExecutionID: executionID, Payload: payload, Attempt: 1}
+		go func() {
+			bgCtx := context.Background()
+			if err := em.RunQueued(bgCtx, lease, time.Time{}); err != nil {
+				execution := &models.Execution{ID: executionID}
+				if executionModel.WorkflowID != nil {
+					execution.WorkflowID = executionModel.WorkflowID.String()
+				}
+				em.notifyExecutionError(bgCtx, execution, fmt.Errorf("failed to start scheduled execution: %w", err))
+			}
+		}()
+	}
+
+	return started, nil
+}
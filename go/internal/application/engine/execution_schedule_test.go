@@ -0,0 +1,88 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scheduleTestRepo stubs the execution repository calls made when starting scheduled executions.
+type scheduleTestRepo struct {
+	repository.ExecutionRepository
+
+	due     []*storagemodels.ExecutionModel
+	taken   map[uuid.UUID]bool
+	updated []*storagemodels.ExecutionModel
+}
+
+func (r *scheduleTestRepo) FindDueScheduled(context.Context, time.Time, int) ([]*storagemodels.ExecutionModel, error) {
+	return r.due, nil
+}
+
+func (r *scheduleTestRepo) ClaimScheduled(_ context.Context, id uuid.UUID, _ time.Time) (bool, error) {
+	return !r.taken[id], nil
+}
+
+func (r *scheduleTestRepo) Update(_ context.Context, execution *storagemodels.ExecutionModel) error {
+	r.updated = append(r.updated, execution)
+	return nil
+}
+
+func scheduledTestExecution(t *testing.T, opts *ExecutionOptions) *storagemodels.ExecutionModel {
+	t.Helper()
+	workflowID := uuid.New()
+	execution := &storagemodels.ExecutionModel{ID: uuid.New(), WorkflowID: &workflowID, Status: "pending"}
+	if opts != nil {
+		state, err := encodeResumeState(newQueuedState(opts))
+		require.NoError(t, err)
+		execution.ResumeState = state
+	}
+	return execution
+}
+
+func TestStartDue_EnqueuesClaimedExecutions(t *testing.T) {
+	report := scheduledTestExecution(t, &ExecutionOptions{Priority: models.ExecutionPriorityHigh, Variables: map[string]any{"region": "eu"}})
+	startedElsewhere := scheduledTestExecution(t, &ExecutionOptions{})
+	repo := &scheduleTestRepo{
+		due:   []*storagemodels.ExecutionModel{report, startedElsewhere},
+		taken: map[uuid.UUID]bool{startedElsewhere.ID: true},
+	}
+	queue := &workerTestQueue{}
+	em := &ExecutionManager{executionRepo: repo, queue: queue}
+
+	started, err := em.StartDue(context.Background(), time.Now(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, started)
+	assert.Empty(t, repo.updated)
+
+	require.Len(t, queue.pending, 1)
+	assert.Equal(t, report.ID.String(), queue.pending[0].ExecutionID)
+	var state resumeState
+	require.NoError(t, json.Unmarshal(queue.pending[0].Payload, &state))
+	assert.Equal(t, models.ExecutionPriorityHigh, state.Priority)
+	require.NotNil(t, state.Options)
+	assert.Equal(t, "eu", state.Options.Variables["region"])
+}
+
+func TestStartDue_FailsExecutionsWithoutOptions(t *testing.T) {
+	execution := scheduledTestExecution(t, nil)
+	repo := &scheduleTestRepo{due: []*storagemodels.ExecutionModel{execution}}
+	queue := &workerTestQueue{}
+	em := &ExecutionManager{executionRepo: repo, queue: queue}
+
+	started, err := em.StartDue(context.Background(), time.Now(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, started)
+	assert.Empty(t, queue.pending)
+	require.Len(t, repo.updated, 1)
+	assert.Equal(t, "failed", repo.updated[0].Status)
+	assert.Contains(t, repo.updated[0].Error, "invalid options")
+}
@@ -0,0 +1,198 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/internal/application/maintenance"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+)
+
+// ExecutionWorkerOptions configures an execution worker.
+type ExecutionWorkerOptions struct {
+	ID           string        // Worker ID holding the leases; unique per process
+	Concurrency  int           // Executions run at once (default 4)
+	LeaseTTL     time.Duration // Lease kept while a heartbeat extends it (default 30s)
+	PollInterval time.Duration // Wait before leasing again from an empty queue (default 1s)
+	MaxAttempts  int           // Leases of an execution before it is failed; 0 = unlimited
+
+	// ReadOnly stops leasing while read-only mode is on (optional). Executions already
+	// running finish; queued executions wait for read-only mode to end.
+	ReadOnly *maintenance.ReadOnlyMode
+}
+
+// ExecutionWorker leases queued executions and runs them. It extends the lease of each running
+// execution every third of the lease TTL; once a worker stops, its leases expire and the
+// executions are queued again for other workers, which resume them from their checkpoint.
+// A worker that finds its lease lost stops running the execution, so that it does not run
+// alongside the worker that reclaimed it. Every worker reclaims expired leases, so any number
+// of workers may share a queue.
+type ExecutionWorker struct {
+	manager *ExecutionManager
+	queue   ExecutionQueue
+	opts    ExecutionWorkerOptions
+	logger  *logger.Logger
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewExecutionWorker creates a new execution worker.
+func NewExecutionWorker(manager *ExecutionManager, queue ExecutionQueue, opts ExecutionWorkerOptions, log *logger.Logger) *ExecutionWorker {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 4
+	}
+	if opts.LeaseTTL <= 0 {
+		opts.LeaseTTL = 30 * time.Second
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Second
+	}
+
+	return &ExecutionWorker{
+		manager: manager,
+		queue:   queue,
+		opts:    opts,
+		logger:  log,
+	}
+}
+
+// Start starts leasing executions until Stop is called.
+func (w *ExecutionWorker) Start() {
+	w.done = make(chan struct{})
+	w.wg.Add(w.opts.Concurrency + 1)
+	for i := 0; i < w.opts.Concurrency; i++ {
+		go w.leaseLoop()
+	}
+	go w.reclaimLoop()
+}
+
+// Stop stops leasing executions and waits for the running ones to finish.
+func (w *ExecutionWorker) Stop() {
+	if w.done == nil {
+		return
+	}
+	close(w.done)
+	w.wg.Wait()
+	w.done = nil
+}
+
+func (w *ExecutionWorker) leaseLoop() {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		default:
+		}
+
+		if readOnly, _ := w.opts.ReadOnly.Enabled(); readOnly {
+			select {
+			case <-time.After(w.opts.PollInterval):
+			case <-w.done:
+				return
+			}
+			continue
+		}
+
+		lease, err := w.queue.Lease(context.Background(), w.opts.ID, w.opts.LeaseTTL)
+		if err != nil {
+			w.logger.Error("Leasing queued execution failed", "error", err)
+		}
+		if lease == nil {
+			select {
+			case <-time.After(w.opts.PollInterval):
+			case <-w.done:
+				return
+			}
+			continue
+		}
+
+		w.run(lease)
+	}
+}
+
+// run runs a leased execution while extending its lease, then removes it from the queue.
+func (w *ExecutionWorker) run(lease *ExecutionLease) {
+	ctx := context.Background()
+
+	if w.opts.MaxAttempts > 0 && lease.Attempt > w.opts.MaxAttempts {
+		w.logger.Warn("Abandoning queued execution", "execution_id", lease.ExecutionID, "attempts", lease.Attempt-1)
+		if err := w.manager.AbandonQueued(ctx, lease.ExecutionID, lease.Attempt-1); err != nil {
+			w.logger.Error("Abandoning queued execution failed", "execution_id", lease.ExecutionID, "error", err)
+		}
+	} else {
+		runCtx, cancel := context.WithCancelCause(ctx)
+		stopHeartbeat := w.heartbeat(lease, cancel)
+		// An instance running the execution checkpoints it more often than the lease TTL
+		err := w.manager.RunQueued(runCtx, lease, time.Now().Add(-w.opts.LeaseTTL))
+		stopHeartbeat()
+		cancel(nil)
+		if err != nil && !errors.Is(err, errLeaseLost) {
+			w.logger.Error("Running queued execution failed", "execution_id", lease.ExecutionID, "error", err)
+		}
+	}
+
+	if err := w.queue.Complete(ctx, lease); err != nil {
+		w.logger.Error("Completing queued execution failed", "execution_id", lease.ExecutionID, "error", err)
+	}
+}
+
+// heartbeat extends the lease until the returned function is called. It cancels the run
+// with errLeaseLost if the worker no longer holds the lease.
+func (w *ExecutionWorker) heartbeat(lease *ExecutionLease, cancelRun context.CancelCauseFunc) func() {
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(w.opts.LeaseTTL / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
+			extended, err := w.queue.Extend(context.Background(), lease, w.opts.LeaseTTL)
+			if err != nil {
+				w.logger.Error("Extending execution lease failed", "execution_id", lease.ExecutionID, "error", err)
+			} else if !extended {
+				w.logger.Warn("Execution lease lost, stopping its run", "execution_id", lease.ExecutionID)
+				cancelRun(errLeaseLost)
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-stopped
+	}
+}
+
+func (w *ExecutionWorker) reclaimLoop() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.opts.LeaseTTL / 2)
+	defer ticker.Stop()
+
+	for {
+		reclaimed, err := w.queue.ReclaimExpired(context.Background())
+		if err != nil {
+			w.logger.Error("Reclaiming expired execution leases failed", "error", err)
+		} else if reclaimed > 0 {
+			w.logger.Info("Requeued executions of stopped workers", "count", reclaimed)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-w.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package engine
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/smilemakc/mbflow/go/internal/application/maintenance"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgengine "github.com/smilemakc/mbflow/go/pkg/engine"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// workerTestQueue is an in-memory queue handing out leases.
+type workerTestQueue struct {
+	mu        sync.Mutex
+	pending   []*ExecutionLease
+	completed []string
+}
+
+func (q *workerTestQueue) Enqueue(_ context.Context, executionID string, _ models.ExecutionPriority, payload []byte) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pending = append(q.pending, &ExecutionLease{ExecutionID: executionID, Payload: payload, Attempt: 1})
+	return nil
+}
+
+func (q *workerTestQueue) Lease(_ context.Context, workerID string, _ time.Duration) (*ExecutionLease, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.pending) == 0 {
+		return nil, nil
+	}
+	lease := q.pending[0]
+	q.pending = q.pending[1:]
+	lease.WorkerID = workerID
+	return lease, nil
+}
+
+func (q *workerTestQueue) Extend(context.Context, *ExecutionLease, time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (q *workerTestQueue) Complete(_ context.Context, lease *ExecutionLease) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.completed = append(q.completed, lease.ExecutionID)
+	return nil
+}
+
+func (q *workerTestQueue) ReclaimExpired(context.Context) (int, error) {
+	return 0, nil
+}
+
+func (q *workerTestQueue) completedIDs() []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]string(nil), q.completed...)
+}
+
+func TestExecutionWorker_AbandonsAfterMaxAttempts(t *testing.T) {
+	workflowID := uuid.New()
+	execution := &storagemodels.ExecutionModel{ID: uuid.New(), WorkflowID: &workflowID, Status: "running"}
+	repo := &recoveryTestRepo{stale: []*storagemodels.ExecutionModel{execution}}
+	em := &ExecutionManager{executionRepo: repo}
+
+	queue := &workerTestQueue{pending: []*ExecutionLease{{ExecutionID: execution.ID.String(), Attempt: 4}}}
+	worker := NewExecutionWorker(em, queue, ExecutionWorkerOptions{ID: "worker-1", Concurrency: 1, PollInterval: time.Millisecond, MaxAttempts: 3}, logger.Default())
+	worker.Start()
+	require.Eventually(t, func() bool { return len(queue.completedIDs()) == 1 }, time.Second, time.Millisecond)
+	worker.Stop()
+
+	assert.Equal(t, []string{execution.ID.String()}, queue.completedIDs())
+	assert.Empty(t, repo.claimed, "abandoned executions are not run")
+	require.Len(t, repo.updated, 1)
+	assert.Equal(t, "failed", repo.updated[0].Status)
+	assert.Contains(t, repo.updated[0].Error, "abandoned after 3 attempts")
+}
+
+func TestExecutionWorker_PausesWhileReadOnly(t *testing.T) {
+	workflowID := uuid.New()
+	execution := &storagemodels.ExecutionModel{ID: uuid.New(), WorkflowID: &workflowID, Status: "running"}
+	repo := &recoveryTestRepo{stale: []*storagemodels.ExecutionModel{execution}}
+	em := &ExecutionManager{executionRepo: repo}
+
+	readOnly := maintenance.NewReadOnlyMode(true, "")
+	queue := &workerTestQueue{pending: []*ExecutionLease{{ExecutionID: execution.ID.String(), Attempt: 4}}}
+	worker := NewExecutionWorker(em, queue, ExecutionWorkerOptions{
+		ID: "worker-1", Concurrency: 1, PollInterval: time.Millisecond, MaxAttempts: 3, ReadOnly: readOnly,
+	}, logger.Default())
+	worker.Start()
+	defer worker.Stop()
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, queue.completedIDs(), "nothing is leased while read-only")
+
+	readOnly.Disable()
+	require.Eventually(t, func() bool { return len(queue.completedIDs()) == 1 }, time.Second, time.Millisecond)
+}
+
+func TestRunQueued_IgnoresFinishedExecutions(t *testing.T) {
+	workflowID := uuid.New()
+	execution := &storagemodels.ExecutionModel{ID: uuid.New(), WorkflowID: &workflowID, Status: "completed"}
+	repo := &recoveryTestRepo{stale: []*storagemodels.ExecutionModel{execution}}
+	em := &ExecutionManager{executionRepo: repo}
+
+	err := em.RunQueued(context.Background(), &ExecutionLease{ExecutionID: execution.ID.String(), Attempt: 2}, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, repo.claimed)
+	assert.Empty(t, repo.updated)
+}
+
+// lostLeaseQueue is a queue whose leases are reclaimed by another worker as soon as they are extended.
+type lostLeaseQueue struct {
+	workerTestQueue
+}
+
+func (q *lostLeaseQueue) Extend(context.Context, *ExecutionLease, time.Duration) (bool, error) {
+	return false, nil
+}
+
+func TestExecutionWorker_StopsRunWhenLeaseLost(t *testing.T) {
+	var stopped, ranAfter atomic.Bool
+	registry := executor.NewManager()
+	require.NoError(t, registry.Register("wait", executor.NewExecutorFunc(func(ctx context.Context, config map[string]any, input any) (any, error) {
+		select {
+		case <-ctx.Done():
+			stopped.Store(true)
+			return nil, ctx.Err()
+		case <-time.After(5 * time.Second):
+			return map[string]any{"ok": true}, nil
+		}
+	}, nil)))
+	require.NoError(t, registry.Register("after", executor.NewExecutorFunc(func(ctx context.Context, config map[string]any, input any) (any, error) {
+		ranAfter.Store(true)
+		return map[string]any{"ok": true}, nil
+	}, nil)))
+
+	workflowID := uuid.New()
+	workflowModel := &storagemodels.WorkflowModel{
+		ID:    workflowID,
+		Nodes: []*storagemodels.NodeModel{{NodeID: "wait", Type: "wait"}, {NodeID: "after", Type: "after"}},
+		Edges: []*storagemodels.EdgeModel{{EdgeID: "e1", FromNodeID: "wait", ToNodeID: "after"}},
+	}
+	workflowRepo := new(mockEngineWorkflowRepo)
+	workflowRepo.On("FindByIDWithRelations", mock.Anything, workflowID).Return(workflowModel, nil)
+
+	// A running execution checkpointed before any node finished, as a reclaimed one is
+	execState := pkgengine.NewExecutionState("exec-1", workflowID.String(), storagemodels.WorkflowModelToDomain(workflowModel), map[string]any{}, nil)
+	encoded, err := encodeResumeState(newResumeState(execState, nil))
+	require.NoError(t, err)
+	execution := &storagemodels.ExecutionModel{ID: uuid.New(), WorkflowID: &workflowID, Status: "running", ResumeState: encoded}
+	repo := &recoveryTestRepo{stale: []*storagemodels.ExecutionModel{execution}}
+	em := NewExecutionManager(registry, workflowRepo, repo, nil, nil, nil)
+
+	queue := &lostLeaseQueue{}
+	worker := NewExecutionWorker(em, queue, ExecutionWorkerOptions{ID: "worker-1", LeaseTTL: 30 * time.Millisecond}, logger.Default())
+	worker.run(&ExecutionLease{ExecutionID: execution.ID.String(), WorkerID: "worker-1", Attempt: 1})
+
+	assert.True(t, stopped.Load(), "the running node is interrupted")
+	assert.False(t, ranAfter.Load(), "nodes downstream are left to the worker that reclaimed the execution")
+	assert.Empty(t, repo.updated, "the execution is not saved over the reclaiming worker's run")
+}
@@ -64,6 +64,9 @@ func convertToObserverEvent(event pkgengine.ExecutionEvent) observer.Event {
 	if event.Variables != nil {
 		obsEvent.Variables = event.Variables
 	}
+	if event.Metadata != nil {
+		obsEvent.Metadata = event.Metadata
+	}
 
 	return obsEvent
 }
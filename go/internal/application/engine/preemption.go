@@ -0,0 +1,156 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgengine "github.com/smilemakc/mbflow/go/pkg/engine"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// PreemptionPolicy lets high-priority executions preempt lower-priority running ones, such
+// as bulk backfills, when the engine is at its running limit.
+type PreemptionPolicy struct {
+	// MaxRunning is the number of stored executions that may run at once before a starting
+	// execution preempts a lower-priority one; 0 disables automatic preemption.
+	MaxRunning int
+	// MinPriority is the lowest priority of an execution allowed to preempt others.
+	MinPriority models.ExecutionPriority
+	// Checkpoint pauses preempted executions and requeues them from a checkpoint of their
+	// state after RequeueDelay; otherwise they are cancelled once their running nodes finish.
+	Checkpoint   bool
+	RequeueDelay time.Duration
+}
+
+// runningExecution is a stored execution running on this instance.
+type runningExecution struct {
+	priority  models.ExecutionPriority
+	startedAt time.Time
+	state     *pkgengine.ExecutionState
+	opts      *ExecutionOptions
+}
+
+// runningExecutions tracks the stored executions running on this instance.
+type runningExecutions struct {
+	mu         sync.Mutex
+	executions map[string]*runningExecution
+}
+
+// SetPreemptionPolicy enables priority-based preemption of running executions.
+// It must be set before executions start.
+func (em *ExecutionManager) SetPreemptionPolicy(policy *PreemptionPolicy) {
+	em.preemption = policy
+}
+
+// startRunning tracks a stored execution while it runs and applies the preemption policy.
+// Over the running limit, the lowest-priority running execution (the latest started of
+// equals) is preempted if a running execution of at least MinPriority outranks it. The
+// starting execution itself is only preempted, before it runs any node, if it can be
+// requeued. The returned function stops tracking the execution.
+func (em *ExecutionManager) startRunning(executionID string, opts *ExecutionOptions, execState *pkgengine.ExecutionState) func() {
+	em.running.mu.Lock()
+	defer em.running.mu.Unlock()
+
+	if em.running.executions == nil {
+		em.running.executions = make(map[string]*runningExecution)
+	}
+	em.running.executions[executionID] = &runningExecution{
+		priority:  opts.Priority,
+		startedAt: time.Now(),
+		state:     execState,
+		opts:      opts,
+	}
+
+	if policy := em.preemption; policy != nil && policy.MaxRunning > 0 && len(em.running.executions) > policy.MaxRunning {
+		topID, victimID := em.preemptionCandidates()
+		top := em.running.executions[topID]
+		if victimID != "" && top.priority.Rank() >= policy.MinPriority.Rank() &&
+			(victimID != executionID || policy.Checkpoint) {
+			em.running.executions[victimID].state.Preempt(fmt.Sprintf("%s priority execution %s", top.priority, topID))
+		}
+	}
+
+	return func() {
+		em.running.mu.Lock()
+		defer em.running.mu.Unlock()
+		delete(em.running.executions, executionID)
+	}
+}
+
+// preemptionCandidates returns the highest-priority running execution and the execution it
+// would preempt: the lowest-priority one below it, the latest started of equals, or an
+// empty string if none ranks below it. Executions already preempted or paused are ignored.
+// The caller holds em.running.mu.
+func (em *ExecutionManager) preemptionCandidates() (topID, victimID string) {
+	var top, victim *runningExecution
+	for id, running := range em.running.executions {
+		if running.state.Preempted() || running.state.PauseRequested() {
+			continue
+		}
+		if top == nil || running.priority.Rank() > top.priority.Rank() {
+			topID, top = id, running
+		}
+		if victim == nil || running.priority.Rank() < victim.priority.Rank() ||
+			(running.priority.Rank() == victim.priority.Rank() && running.startedAt.After(victim.startedAt)) {
+			victimID, victim = id, running
+		}
+	}
+	if victim == nil || victim.priority.Rank() >= top.priority.Rank() {
+		return topID, ""
+	}
+	return topID, victimID
+}
+
+// Preempt asks an execution running on this instance to stop after its running nodes
+// finish, on behalf of the user. It is paused and requeued, or cancelled, as the preemption
+// policy says. It fails with ErrExecutionNotRunning if the execution is not running here.
+func (em *ExecutionManager) Preempt(ctx context.Context, executionID, userID string) error {
+	em.running.mu.Lock()
+	defer em.running.mu.Unlock()
+
+	running, ok := em.running.executions[executionID]
+	if !ok {
+		return fmt.Errorf("%w: execution %s is not running on this instance", models.ErrExecutionNotRunning, executionID)
+	}
+	running.state.Preempt("user " + userID)
+	return nil
+}
+
+// canRequeue reports whether preempted executions are paused and requeued rather than cancelled.
+func (em *ExecutionManager) canRequeue(execution *models.Execution) bool {
+	return em.preemption != nil && em.preemption.Checkpoint && execution.WorkflowID != ""
+}
+
+// finalizePreempted saves a preempted execution: paused with a checkpoint of its state until
+// the requeue delay is over if the policy checkpoints, cancelled otherwise.
+func (em *ExecutionManager) finalizePreempted(
+	ctx context.Context,
+	execution *models.Execution,
+	workflowModel *storagemodels.WorkflowModel,
+	execState *pkgengine.ExecutionState,
+	opts *ExecutionOptions,
+	execErr error,
+) error {
+	now := time.Now()
+	if execution.Metadata == nil {
+		execution.Metadata = make(map[string]any)
+	}
+	execution.Metadata["preempted"] = map[string]any{
+		"at":  now.UTC().Format(time.RFC3339),
+		"for": execState.PreemptReason(),
+	}
+
+	if em.canRequeue(execution) {
+		resumeAt := now.Add(em.preemption.RequeueDelay)
+		// A node suspended until earlier resumes the execution then
+		if next, ok := execState.NextResumeAt(); ok && next.Before(resumeAt) {
+			resumeAt = next
+		}
+		return em.pauseExecution(ctx, execution, workflowModel, execState, opts, &resumeAt)
+	}
+
+	return em.finalizeCancelled(ctx, execution, workflowModel, execState, execErr)
+}
@@ -0,0 +1,90 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pkgengine "github.com/smilemakc/mbflow/go/pkg/engine"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func preemptionTestState(id string) *pkgengine.ExecutionState {
+	return pkgengine.NewExecutionState(id, "wf-1", &models.Workflow{ID: "wf-1"}, nil, nil)
+}
+
+func TestStartRunning_PreemptsLowestPriority(t *testing.T) {
+	em := &ExecutionManager{}
+	em.SetPreemptionPolicy(&PreemptionPolicy{MaxRunning: 2, MinPriority: models.ExecutionPriorityHigh, Checkpoint: true})
+
+	backfill1, backfill2 := preemptionTestState("backfill-1"), preemptionTestState("backfill-2")
+	defer em.startRunning("backfill-1", &ExecutionOptions{Priority: models.ExecutionPriorityLow}, backfill1)()
+	time.Sleep(time.Millisecond)
+	defer em.startRunning("backfill-2", &ExecutionOptions{Priority: models.ExecutionPriorityLow}, backfill2)()
+
+	urgent := preemptionTestState("urgent")
+	stop := em.startRunning("urgent", &ExecutionOptions{Priority: models.ExecutionPriorityCritical}, urgent)
+	defer stop()
+
+	// The latest started of the lowest-priority executions is preempted
+	assert.False(t, backfill1.Preempted())
+	assert.True(t, backfill2.Preempted())
+	assert.False(t, urgent.Preempted())
+	assert.Equal(t, "critical priority execution urgent", backfill2.PreemptReason())
+}
+
+func TestStartRunning_RespectsPolicy(t *testing.T) {
+	t.Run("below min priority", func(t *testing.T) {
+		em := &ExecutionManager{}
+		em.SetPreemptionPolicy(&PreemptionPolicy{MaxRunning: 1, MinPriority: models.ExecutionPriorityHigh, Checkpoint: true})
+
+		backfill := preemptionTestState("backfill")
+		defer em.startRunning("backfill", &ExecutionOptions{Priority: models.ExecutionPriorityLow}, backfill)()
+		defer em.startRunning("report", &ExecutionOptions{Priority: models.ExecutionPriorityNormal}, preemptionTestState("report"))()
+
+		assert.False(t, backfill.Preempted())
+	})
+
+	t.Run("under the running limit", func(t *testing.T) {
+		em := &ExecutionManager{}
+		em.SetPreemptionPolicy(&PreemptionPolicy{MaxRunning: 2, MinPriority: models.ExecutionPriorityHigh})
+
+		backfill := preemptionTestState("backfill")
+		defer em.startRunning("backfill", &ExecutionOptions{Priority: models.ExecutionPriorityLow}, backfill)()
+		defer em.startRunning("urgent", &ExecutionOptions{Priority: models.ExecutionPriorityCritical}, preemptionTestState("urgent"))()
+
+		assert.False(t, backfill.Preempted())
+	})
+
+	t.Run("starting execution yields only when requeued", func(t *testing.T) {
+		for _, checkpoint := range []bool{true, false} {
+			em := &ExecutionManager{}
+			em.SetPreemptionPolicy(&PreemptionPolicy{MaxRunning: 1, MinPriority: models.ExecutionPriorityHigh, Checkpoint: checkpoint})
+
+			defer em.startRunning("urgent", &ExecutionOptions{Priority: models.ExecutionPriorityHigh}, preemptionTestState("urgent"))()
+			backfill := preemptionTestState("backfill")
+			defer em.startRunning("backfill", &ExecutionOptions{Priority: models.ExecutionPriorityLow}, backfill)()
+
+			assert.Equal(t, checkpoint, backfill.Preempted(), "checkpoint=%v", checkpoint)
+		}
+	})
+}
+
+func TestPreempt(t *testing.T) {
+	em := &ExecutionManager{}
+
+	err := em.Preempt(context.Background(), "missing", "admin-1")
+	require.True(t, errors.Is(err, models.ErrExecutionNotRunning), "got %v", err)
+
+	backfill := preemptionTestState("backfill")
+	stop := em.startRunning("backfill", &ExecutionOptions{Priority: models.ExecutionPriorityNormal}, backfill)
+	require.NoError(t, em.Preempt(context.Background(), "backfill", "admin-1"))
+	assert.True(t, backfill.Preempted())
+	assert.Equal(t, "user admin-1", backfill.PreemptReason())
+
+	stop()
+	assert.Error(t, em.Preempt(context.Background(), "backfill", "admin-1"))
+}
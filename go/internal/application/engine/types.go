@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/smilemakc/mbflow/go/internal/application/observer"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
 	"github.com/smilemakc/mbflow/go/pkg/models"
 )
 
@@ -29,6 +30,30 @@ type ExecutionOptions struct {
 	MaxOutputSize    int64
 	MaxTotalMemory   int64
 	EnableMemoryOpts bool
+	Profile          string               // Name of a workflow launch profile to apply (empty = none)
+	NumberMode       models.NumberMode    // How executors decode JSON numbers (empty = workflow setting)
+	Propagation      executor.Propagation // Correlation ID, workspace, user, rental key and baggage passed to executors
+
+	// NodeConfigOverrides are config values merged over the stored node configs, keyed by node ID.
+	// They apply to this execution only, e.g. to try another model without editing the workflow.
+	NodeConfigOverrides map[string]map[string]any
+	// Metadata is added to the execution metadata.
+	Metadata map[string]any
+	// Selection runs only a subgraph of the workflow, with the outputs of its upstream
+	// boundary nodes supplied by the caller; nil runs every node.
+	Selection *models.NodeSelection
+	// Priority ranks the execution when competing for capacity (empty = normal); see PreemptionPolicy.
+	Priority models.ExecutionPriority
+	// Breakpoints are IDs of nodes the execution pauses before, to be inspected and continued
+	// with ContinueFromBreakpoints or aborted.
+	Breakpoints []string
+	// NodeTypeConcurrency caps the nodes of each executor type running at once, by type,
+	// including those of sub-workflows (e.g. {"llm": 3}); types without a cap are limited
+	// by MaxParallelism only.
+	NodeTypeConcurrency map[string]int
+	// RunAt, if in the future, makes ExecuteAsync record the execution as pending and start it
+	// then instead of now; see StartDue.
+	RunAt time.Time
 }
 
 // RetryPolicy defines the retry behavior for node execution.
@@ -86,4 +111,5 @@ type EphemeralExecutionOptions struct {
 	Timeout          time.Duration
 	NodeTimeout      time.Duration
 	ContinueOnError  bool
+	Propagation      executor.Propagation
 }
@@ -0,0 +1,124 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	pkgengine "github.com/smilemakc/mbflow/go/pkg/engine"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// QuotaEnforcer enforces the daily usage quotas of users on the executions they start.
+type QuotaEnforcer interface {
+	// Admit counts an execution of the user starting at the given time against their quota.
+	// Over quota it fails with ErrQuotaExceeded, unless the execution can wait and the user's
+	// executions over quota are queued: it is then counted on the next day, and Admit returns
+	// when it may start. Otherwise Admit returns the zero time.
+	Admit(ctx context.Context, userID string, at time.Time, canWait bool) (time.Time, error)
+	// Refund gives back an execution counted by Admit on the day of at, for an execution that
+	// could not be created.
+	Refund(ctx context.Context, userID string, at time.Time) error
+	// Record adds the nodes an execution of the user ran, and the LLM tokens they used, to the
+	// user's usage on the day of at.
+	Record(ctx context.Context, userID string, at time.Time, nodeRuns, llmTokens int64) error
+}
+
+// SetQuotaEnforcer makes stored and ephemeral executions count against the daily quotas of
+// the users they run for. It must be set before executions start.
+func (em *ExecutionManager) SetQuotaEnforcer(quotas QuotaEnforcer) {
+	em.quotas = quotas
+}
+
+// admitExecution counts an execution of the user against their quota. It returns when an
+// execution queued by the quota starts, or the zero time if it starts as asked. Executions
+// without a user are not limited.
+func (em *ExecutionManager) admitExecution(ctx context.Context, userID string, at time.Time, canWait bool) (time.Time, error) {
+	if em.quotas == nil || userID == "" {
+		return time.Time{}, nil
+	}
+	return em.quotas.Admit(ctx, userID, at, canWait)
+}
+
+// refundExecution gives back an execution admitted on the day of at that could not be
+// created. It runs even if ctx was cancelled meanwhile.
+func (em *ExecutionManager) refundExecution(ctx context.Context, userID string, at time.Time) {
+	if em.quotas == nil || userID == "" {
+		return
+	}
+	_ = em.quotas.Refund(context.WithoutCancel(ctx), userID, at)
+}
+
+// executionUser returns the user a stored execution runs for: the caller's, or else the
+// workflow owner, as in executeWorkflowDAG.
+func executionUser(userID string, workflow *models.Workflow) string {
+	if userID != "" {
+		return userID
+	}
+	return workflow.CreatedBy
+}
+
+// finishedNodes returns when each node of the execution that finished did, so that the
+// nodes of a resumed execution run before it was paused are not counted again.
+func finishedNodes(execState *pkgengine.ExecutionState) map[string]time.Time {
+	finished := make(map[string]time.Time)
+	for _, node := range execState.Workflow.Nodes {
+		if endTime, ok := execState.GetNodeEndTime(node.ID); ok {
+			finished[node.ID] = endTime
+		}
+	}
+	return finished
+}
+
+// recordUsage adds the nodes the execution ran since finishedBefore was taken, and the LLM
+// tokens they reported, to the usage of the user it runs for. Nodes of sub-workflows are
+// counted by the sub-workflow node only. Usage that cannot be recorded is lost rather than
+// failing the execution.
+func (em *ExecutionManager) recordUsage(ctx context.Context, execState *pkgengine.ExecutionState, finishedBefore map[string]time.Time) {
+	userID := executionUser(execState.Propagation.UserID, execState.Workflow)
+	if em.quotas == nil || userID == "" {
+		return
+	}
+
+	var nodeRuns, tokens int64
+	for _, node := range execState.Workflow.Nodes {
+		endTime, ok := execState.GetNodeEndTime(node.ID)
+		if !ok || endTime.Equal(finishedBefore[node.ID]) {
+			continue
+		}
+		nodeRuns++
+		if output, ok := execState.GetNodeOutput(node.ID); ok {
+			tokens += llmTokens(output)
+		}
+	}
+	if nodeRuns == 0 {
+		return
+	}
+	_ = em.quotas.Record(ctx, userID, time.Now(), nodeRuns, tokens)
+}
+
+// llmTokens returns the total tokens an LLM node reported in the usage of its output, or 0
+// for other nodes.
+func llmTokens(output any) int64 {
+	out, ok := output.(map[string]any)
+	if !ok {
+		return 0
+	}
+	usage, ok := out["usage"].(map[string]any)
+	if !ok {
+		return 0
+	}
+	switch total := usage["total_tokens"].(type) {
+	case int:
+		return int64(total)
+	case int64:
+		return total
+	case float64:
+		return int64(total)
+	case json.Number:
+		n, _ := total.Int64()
+		return n
+	default:
+		return 0
+	}
+}
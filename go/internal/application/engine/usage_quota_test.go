@@ -0,0 +1,81 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pkgengine "github.com/smilemakc/mbflow/go/pkg/engine"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingQuotas records the usage reported to it.
+type recordingQuotas struct {
+	userID              string
+	nodeRuns, llmTokens int64
+}
+
+func (q *recordingQuotas) Admit(context.Context, string, time.Time, bool) (time.Time, error) {
+	return time.Time{}, nil
+}
+
+func (q *recordingQuotas) Refund(context.Context, string, time.Time) error {
+	return nil
+}
+
+func (q *recordingQuotas) Record(_ context.Context, userID string, _ time.Time, nodeRuns, llmTokens int64) error {
+	q.userID = userID
+	q.nodeRuns += nodeRuns
+	q.llmTokens += llmTokens
+	return nil
+}
+
+func TestRecordUsage_CountsNodesRunSinceResume(t *testing.T) {
+	workflow := &models.Workflow{
+		ID:        "wf-1",
+		CreatedBy: "owner-1",
+		Nodes: []*models.Node{
+			{ID: "fetch", Type: "http"},
+			{ID: "ask", Type: "llm"},
+			{ID: "summarize", Type: "llm"},
+			{ID: "notify", Type: "http"},
+		},
+	}
+	execState := pkgengine.NewExecutionState("exec-1", "wf-1", workflow, map[string]any{}, nil)
+	start := time.Now()
+
+	// Run before the execution was paused
+	execState.SetNodeEndTime("fetch", start)
+	execState.SetNodeEndTime("ask", start)
+	execState.SetNodeOutput("ask", map[string]any{"usage": map[string]any{"total_tokens": 500}})
+	finished := finishedNodes(execState)
+
+	execState.SetNodeEndTime("summarize", start.Add(time.Minute))
+	execState.SetNodeOutput("summarize", map[string]any{"usage": map[string]any{"total_tokens": float64(120)}})
+
+	quotas := &recordingQuotas{}
+	em := &ExecutionManager{quotas: quotas}
+	em.recordUsage(context.Background(), execState, finished)
+
+	assert.Equal(t, "owner-1", quotas.userID, "stored workflows run for their owner")
+	assert.Equal(t, int64(1), quotas.nodeRuns)
+	assert.Equal(t, int64(120), quotas.llmTokens)
+}
+
+func TestRecordUsage_CountsAllNodesOfFreshRun(t *testing.T) {
+	workflow := &models.Workflow{ID: "wf-1", Nodes: []*models.Node{{ID: "ask", Type: "llm"}, {ID: "skipped", Type: "http"}}}
+	execState := pkgengine.NewExecutionState("exec-1", "wf-1", workflow, map[string]any{}, nil)
+	execState.Propagation.UserID = "caller-1"
+	execState.SetNodeEndTime("ask", time.Now())
+	execState.SetNodeOutput("ask", map[string]any{"usage": map[string]any{"total_tokens": int64(42)}})
+
+	quotas := &recordingQuotas{}
+	em := &ExecutionManager{quotas: quotas}
+	em.recordUsage(context.Background(), execState, nil)
+
+	require.Equal(t, "caller-1", quotas.userID)
+	assert.Equal(t, int64(1), quotas.nodeRuns)
+	assert.Equal(t, int64(42), quotas.llmTokens)
+}
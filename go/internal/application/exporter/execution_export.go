@@ -0,0 +1,200 @@
+// Package exporter converts execution data into flat datasets for offline analysis.
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// Format is an execution export format.
+type Format string
+
+const (
+	FormatJSONL   Format = "jsonl"
+	FormatParquet Format = "parquet"
+)
+
+// ParseFormat validates a format name (case-insensitive).
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(s)) {
+	case FormatJSONL, "ndjson":
+		return FormatJSONL, nil
+	case FormatParquet:
+		return FormatParquet, nil
+	default:
+		return "", fmt.Errorf("unsupported export format %q (must be jsonl or parquet)", s)
+	}
+}
+
+// ContentType returns the MIME type of the format.
+func (f Format) ContentType() string {
+	if f == FormatParquet {
+		return "application/vnd.apache.parquet"
+	}
+	return "application/x-ndjson"
+}
+
+// NodeExecutionRow is one node execution flattened together with its parent execution.
+// Nested data (input, output, config) is stored as JSON text so the schema stays fixed
+// across node types; use json_extract (DuckDB) or pd.json_normalize to unpack it.
+type NodeExecutionRow struct {
+	ExecutionID     string     `json:"execution_id" parquet:"execution_id"`
+	WorkflowID      string     `json:"workflow_id" parquet:"workflow_id"`
+	WorkflowName    string     `json:"workflow_name" parquet:"workflow_name"`
+	ExecutionStatus string     `json:"execution_status" parquet:"execution_status"`
+	NodeExecutionID string     `json:"node_execution_id" parquet:"node_execution_id"`
+	NodeID          string     `json:"node_id" parquet:"node_id"`
+	NodeName        string     `json:"node_name" parquet:"node_name"`
+	NodeType        string     `json:"node_type" parquet:"node_type"`
+	Status          string     `json:"status" parquet:"status"`
+	StartedAt       time.Time  `json:"started_at" parquet:"started_at"`
+	CompletedAt     *time.Time `json:"completed_at" parquet:"completed_at,optional"`
+	DurationMs      int64      `json:"duration_ms" parquet:"duration_ms"`
+	RetryCount      int64      `json:"retry_count" parquet:"retry_count"`
+	Error           string     `json:"error" parquet:"error,optional"`
+	Input           string     `json:"input" parquet:"input,optional,json"`
+	Output          string     `json:"output" parquet:"output,optional,json"`
+	ResolvedConfig  string     `json:"resolved_config" parquet:"resolved_config,optional,json"`
+	DataLabels      string     `json:"data_labels" parquet:"data_labels,optional"`
+	Redacted        bool       `json:"redacted" parquet:"redacted"`
+}
+
+// FlattenExecution returns one row per node execution, ordered by start time.
+// Node executions whose data labels exclude them from exports (e.g. pii, no-export)
+// keep their row, but input, output and resolved config are left empty and Redacted is set.
+func FlattenExecution(execution *models.Execution) ([]NodeExecutionRow, error) {
+	nodes := make([]*models.NodeExecution, 0, len(execution.NodeExecutions))
+	for _, ne := range execution.NodeExecutions {
+		if ne != nil {
+			nodes = append(nodes, ne)
+		}
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].StartedAt.Before(nodes[j].StartedAt)
+	})
+
+	rows := make([]NodeExecutionRow, 0, len(nodes))
+	for _, ne := range nodes {
+		policy, err := models.NewDataPolicy(ne.DataLabels)
+		if err != nil {
+			return nil, fmt.Errorf("node %s data labels: %w", ne.NodeID, err)
+		}
+		if policy.ExcludeFromExport {
+			ne = redactPayload(ne)
+		}
+
+		input, err := marshalColumn(ne.Input)
+		if err != nil {
+			return nil, fmt.Errorf("node %s input: %w", ne.NodeID, err)
+		}
+		output, err := marshalColumn(ne.Output)
+		if err != nil {
+			return nil, fmt.Errorf("node %s output: %w", ne.NodeID, err)
+		}
+		resolvedConfig, err := marshalColumn(ne.ResolvedConfig)
+		if err != nil {
+			return nil, fmt.Errorf("node %s resolved config: %w", ne.NodeID, err)
+		}
+
+		durationMs := ne.Duration
+		if durationMs == 0 && ne.CompletedAt != nil {
+			durationMs = ne.CompletedAt.Sub(ne.StartedAt).Milliseconds()
+		}
+
+		rows = append(rows, NodeExecutionRow{
+			ExecutionID:     execution.ID,
+			WorkflowID:      execution.WorkflowID,
+			WorkflowName:    execution.WorkflowName,
+			ExecutionStatus: string(execution.Status),
+			NodeExecutionID: ne.ID,
+			NodeID:          ne.NodeID,
+			NodeName:        ne.NodeName,
+			NodeType:        ne.NodeType,
+			Status:          string(ne.Status),
+			StartedAt:       ne.StartedAt.UTC(),
+			CompletedAt:     utcPtr(ne.CompletedAt),
+			DurationMs:      durationMs,
+			RetryCount:      int64(ne.RetryCount),
+			Error:           ne.Error,
+			Input:           input,
+			Output:          output,
+			ResolvedConfig:  resolvedConfig,
+			DataLabels:      strings.Join(policy.Labels, ","),
+			Redacted:        policy.ExcludeFromExport,
+		})
+	}
+
+	return rows, nil
+}
+
+// Write encodes rows in the given format.
+func Write(w io.Writer, format Format, rows []NodeExecutionRow) error {
+	switch format {
+	case FormatJSONL:
+		return WriteJSONL(w, rows)
+	case FormatParquet:
+		return WriteParquet(w, rows)
+	default:
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// WriteJSONL writes one JSON object per line.
+func WriteJSONL(w io.Writer, rows []NodeExecutionRow) error {
+	enc := json.NewEncoder(w)
+	for i := range rows {
+		if err := enc.Encode(&rows[i]); err != nil {
+			return fmt.Errorf("failed to encode row %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// WriteParquet writes rows as a single Parquet file.
+func WriteParquet(w io.Writer, rows []NodeExecutionRow) error {
+	writer := parquet.NewGenericWriter[NodeExecutionRow](w, parquet.Compression(&parquet.Snappy))
+	if _, err := writer.Write(rows); err != nil {
+		return fmt.Errorf("failed to write parquet rows: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to finalize parquet file: %w", err)
+	}
+	return nil
+}
+
+// redactPayload returns a copy of the node execution without input, output and configs.
+func redactPayload(ne *models.NodeExecution) *models.NodeExecution {
+	redacted := *ne
+	redacted.Input = nil
+	redacted.Output = nil
+	redacted.Config = nil
+	redacted.ResolvedConfig = nil
+	return &redacted
+}
+
+// marshalColumn encodes nested data as JSON text; empty maps become an empty string (null).
+func marshalColumn(v map[string]any) (string, error) {
+	if len(v) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func utcPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
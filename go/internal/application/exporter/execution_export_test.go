@@ -0,0 +1,146 @@
+package exporter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testExecution() *models.Execution {
+	start := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	done := start.Add(1500 * time.Millisecond)
+
+	return &models.Execution{
+		ID:           "exec-1",
+		WorkflowID:   "wf-1",
+		WorkflowName: "Pipeline",
+		Status:       models.ExecutionStatusCompleted,
+		NodeExecutions: []*models.NodeExecution{
+			{
+				ID:          "ne-2",
+				NodeID:      "transform",
+				NodeName:    "Transform",
+				NodeType:    "transform",
+				Status:      models.NodeExecutionStatusFailed,
+				Input:       map[string]any{"value": 1},
+				Error:       "boom",
+				StartedAt:   start.Add(time.Second),
+				CompletedAt: &done,
+				RetryCount:  2,
+			},
+			{
+				ID:          "ne-1",
+				NodeID:      "fetch",
+				NodeName:    "Fetch",
+				NodeType:    "http",
+				Status:      models.NodeExecutionStatusCompleted,
+				Input:       map[string]any{"url": "https://example.com"},
+				Output:      map[string]any{"status": 200},
+				StartedAt:   start,
+				CompletedAt: &done,
+				Duration:    1500,
+			},
+		},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	f, err := ParseFormat("PARQUET")
+	require.NoError(t, err)
+	assert.Equal(t, FormatParquet, f)
+
+	f, err = ParseFormat("ndjson")
+	require.NoError(t, err)
+	assert.Equal(t, FormatJSONL, f)
+
+	_, err = ParseFormat("csv")
+	assert.Error(t, err)
+}
+
+func TestFlattenExecution(t *testing.T) {
+	rows, err := FlattenExecution(testExecution())
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	// Ordered by start time
+	assert.Equal(t, "fetch", rows[0].NodeID)
+	assert.Equal(t, "transform", rows[1].NodeID)
+
+	assert.Equal(t, "exec-1", rows[0].ExecutionID)
+	assert.Equal(t, "Pipeline", rows[0].WorkflowName)
+	assert.Equal(t, "completed", rows[0].ExecutionStatus)
+	assert.Equal(t, int64(1500), rows[0].DurationMs)
+	assert.JSONEq(t, `{"status":200}`, rows[0].Output)
+
+	// Duration is derived from timestamps when not recorded
+	assert.Equal(t, int64(500), rows[1].DurationMs)
+	assert.Equal(t, int64(2), rows[1].RetryCount)
+	assert.Equal(t, "boom", rows[1].Error)
+	assert.Empty(t, rows[1].Output)
+}
+
+func TestFlattenExecution_RedactsLabeledNodes(t *testing.T) {
+	execution := testExecution()
+	execution.NodeExecutions[0].DataLabels = []string{"pii", "retain-7d"}
+	execution.NodeExecutions[1].DataLabels = []string{"finance"}
+
+	rows, err := FlattenExecution(execution)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.False(t, rows[0].Redacted)
+	assert.Equal(t, "finance", rows[0].DataLabels)
+	assert.JSONEq(t, `{"status":200}`, rows[0].Output)
+
+	assert.True(t, rows[1].Redacted)
+	assert.Equal(t, "pii,retain-7d", rows[1].DataLabels)
+	assert.Empty(t, rows[1].Input)
+	assert.Equal(t, "boom", rows[1].Error)
+	assert.Equal(t, map[string]any{"value": 1}, execution.NodeExecutions[0].Input, "execution is not modified")
+}
+
+func TestWriteJSONL(t *testing.T) {
+	rows, err := FlattenExecution(testExecution())
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatJSONL, rows))
+
+	scanner := bufio.NewScanner(&buf)
+	var lines []map[string]any
+	for scanner.Scan() {
+		var line map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+	require.Len(t, lines, 2)
+	assert.Equal(t, "ne-1", lines[0]["node_execution_id"])
+	assert.Equal(t, "2024-05-06T07:08:09Z", lines[0]["started_at"])
+}
+
+func TestWriteParquet_RoundTrip(t *testing.T) {
+	rows, err := FlattenExecution(testExecution())
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatParquet, rows))
+	assert.Equal(t, "PAR1", buf.String()[:4])
+
+	read, err := parquet.Read[NodeExecutionRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, read, 2)
+
+	assert.Equal(t, rows[0].NodeID, read[0].NodeID)
+	assert.True(t, rows[0].StartedAt.Equal(read[0].StartedAt))
+	require.NotNil(t, read[0].CompletedAt)
+	assert.True(t, rows[0].CompletedAt.Equal(*read[0].CompletedAt))
+	assert.Equal(t, rows[0].Output, read[0].Output)
+	assert.Equal(t, rows[1].Error, read[1].Error)
+}
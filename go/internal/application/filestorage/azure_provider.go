@@ -0,0 +1,494 @@
+package filestorage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// azureAPIVersion is the Blob service REST API version used for requests and SAS tokens
+const azureAPIVersion = "2021-08-06"
+
+// AzureConfig holds connection settings for Azure Blob Storage.
+// Either AccountKey (Shared Key auth) or SASToken must be set.
+type AzureConfig struct {
+	AccountName string
+	AccountKey  string // Base64 storage account key; required to issue SAS download URLs
+	SASToken    string // Pre-issued SAS token used instead of the account key
+	Container   string
+	Endpoint    string // Empty means https://<account>.blob.core.windows.net
+	Prefix      string // Optional blob name prefix inside the container
+	HTTPClient  *http.Client
+}
+
+// AzureProvider implements Provider for Azure Blob Storage.
+// With an account key it also implements URLSigner by issuing read-only service SAS URLs.
+type AzureProvider struct {
+	config   AzureConfig
+	endpoint *url.URL
+	key      []byte
+	sasQuery url.Values
+	client   *http.Client
+}
+
+// NewAzureProvider creates a new Azure Blob storage provider
+func NewAzureProvider(config AzureConfig) (*AzureProvider, error) {
+	if config.AccountName == "" {
+		return nil, fmt.Errorf("account_name is required for azure storage")
+	}
+	if config.Container == "" {
+		return nil, fmt.Errorf("container is required for azure storage")
+	}
+	if config.AccountKey == "" && config.SASToken == "" {
+		return nil, fmt.Errorf("account_key or sas_token is required for azure storage")
+	}
+
+	p := &AzureProvider{config: config}
+
+	if config.AccountKey != "" {
+		key, err := base64.StdEncoding.DecodeString(config.AccountKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid azure account key: %w", err)
+		}
+		p.key = key
+	}
+
+	if config.SASToken != "" {
+		query, err := url.ParseQuery(strings.TrimPrefix(config.SASToken, "?"))
+		if err != nil || query.Get("sig") == "" {
+			return nil, fmt.Errorf("invalid azure sas token")
+		}
+		p.sasQuery = query
+	}
+
+	rawEndpoint := config.Endpoint
+	if rawEndpoint == "" {
+		rawEndpoint = fmt.Sprintf("https://%s.blob.core.windows.net", config.AccountName)
+	}
+	endpoint, err := url.Parse(rawEndpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid azure endpoint: %s", rawEndpoint)
+	}
+	p.endpoint = endpoint
+
+	p.client = config.HTTPClient
+	if p.client == nil {
+		p.client = &http.Client{Timeout: 5 * time.Minute}
+	}
+
+	p.config.Prefix = strings.Trim(config.Prefix, "/")
+
+	return p, nil
+}
+
+// Type returns the storage type
+func (p *AzureProvider) Type() models.StorageType {
+	return models.StorageTypeAzure
+}
+
+// Store uploads a file as a block blob.
+// The content is buffered in memory since Put Blob requires a Content-Length;
+// file size is already bounded by the manager's MaxFileSize.
+func (p *AzureProvider) Store(ctx context.Context, entry *models.FileEntry, reader io.Reader) (string, error) {
+	relativePath := entry.Path
+	if relativePath == "" {
+		relativePath = p.generatePath(entry)
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file content: %w", err)
+	}
+
+	headers := http.Header{}
+	headers.Set("x-ms-blob-type", "BlockBlob")
+	if entry.MimeType != "" {
+		headers.Set("Content-Type", entry.MimeType)
+	}
+
+	resp, err := p.do(ctx, http.MethodPut, p.blobName(relativePath), nil, headers, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("failed to upload file: %s", readAzureError(resp))
+	}
+
+	sum := sha256.Sum256(data)
+	entry.Size = int64(len(data))
+	entry.Checksum = hex.EncodeToString(sum[:])
+	entry.Path = relativePath
+
+	return relativePath, nil
+}
+
+// generatePath generates a unique blob path
+func (p *AzureProvider) generatePath(entry *models.FileEntry) string {
+	safeName := sanitizeFilename(entry.Name)
+	if safeName == "" {
+		safeName = "file"
+	}
+
+	uniqueID := uuid.New().String()[:8]
+
+	return path.Join(entry.StorageID, uniqueID, safeName)
+}
+
+// Get downloads a blob
+func (p *AzureProvider) Get(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	resp, err := p.do(ctx, http.MethodGet, p.blobName(filePath), nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, fmt.Errorf("file not found: %s", filePath)
+	default:
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("failed to get file: %s", readAzureError(resp))
+	}
+}
+
+// Delete removes a blob. Deleting a missing blob is not an error.
+func (p *AzureProvider) Delete(ctx context.Context, filePath string) error {
+	resp, err := p.do(ctx, http.MethodDelete, p.blobName(filePath), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete file: %s", readAzureError(resp))
+	}
+
+	return nil
+}
+
+// Exists checks if a blob exists
+func (p *AzureProvider) Exists(ctx context.Context, filePath string) (bool, error) {
+	resp, err := p.do(ctx, http.MethodHead, p.blobName(filePath), nil, nil, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to check file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to check file: unexpected status %d", resp.StatusCode)
+	}
+}
+
+// azureListResult is the subset of the List Blobs response used for usage stats
+type azureListResult struct {
+	Blobs struct {
+		Blob []struct {
+			Name       string `xml:"Name"`
+			Properties struct {
+				ContentLength int64 `xml:"Content-Length"`
+			} `xml:"Properties"`
+		} `xml:"Blob"`
+	} `xml:"Blobs"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+// GetUsage returns storage usage statistics for blobs under the configured prefix
+func (p *AzureProvider) GetUsage(ctx context.Context) (*models.StorageUsage, error) {
+	var totalSize int64
+	var fileCount int64
+
+	marker := ""
+	for {
+		query := url.Values{}
+		query.Set("restype", "container")
+		query.Set("comp", "list")
+		if p.config.Prefix != "" {
+			query.Set("prefix", p.config.Prefix+"/")
+		}
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+
+		resp, err := p.do(ctx, http.MethodGet, "", query, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate usage: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			msg := readAzureError(resp)
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to calculate usage: %s", msg)
+		}
+
+		var result azureListResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse list response: %w", err)
+		}
+
+		for _, blob := range result.Blobs.Blob {
+			totalSize += blob.Properties.ContentLength
+			fileCount++
+		}
+
+		if result.NextMarker == "" {
+			break
+		}
+		marker = result.NextMarker
+	}
+
+	return &models.StorageUsage{
+		TotalSize: totalSize,
+		FileCount: fileCount,
+	}, nil
+}
+
+// Close closes the provider
+func (p *AzureProvider) Close() error {
+	return nil
+}
+
+// SignedURL returns a read-only service SAS URL for the blob, valid for the given duration.
+// Requires an account key; providers configured with only a SAS token cannot issue new tokens.
+func (p *AzureProvider) SignedURL(ctx context.Context, filePath string, expires time.Duration) (string, error) {
+	return p.signedURL(filePath, expires, time.Now().UTC())
+}
+
+func (p *AzureProvider) signedURL(filePath string, expires time.Duration, now time.Time) (string, error) {
+	if p.key == nil {
+		return "", ErrSignedURLNotSupported
+	}
+	if expires <= 0 {
+		return "", fmt.Errorf("signed URL expiry must be positive")
+	}
+
+	const timeFormat = "2006-01-02T15:04:05Z"
+	blobName := p.blobName(filePath)
+	// Start slightly in the past to tolerate clock skew between us and Azure
+	start := now.Add(-5 * time.Minute).Format(timeFormat)
+	expiry := now.Add(expires).Format(timeFormat)
+
+	stringToSign := strings.Join([]string{
+		"r",   // signedPermissions
+		start, // signedStart
+		expiry,
+		"/blob/" + p.config.AccountName + "/" + p.config.Container + "/" + blobName,
+		"", // signedIdentifier
+		"", // signedIP
+		"", // signedProtocol
+		azureAPIVersion,
+		"b", // signedResource
+		"",  // signedSnapshotTime
+		"",  // signedEncryptionScope
+		"",  // rscc
+		"",  // rscd
+		"",  // rsce
+		"",  // rscl
+		"",  // rsct
+	}, "\n")
+
+	query := url.Values{}
+	query.Set("sv", azureAPIVersion)
+	query.Set("sr", "b")
+	query.Set("sp", "r")
+	query.Set("st", start)
+	query.Set("se", expiry)
+	query.Set("sig", base64.StdEncoding.EncodeToString(hmacSHA256(p.key, stringToSign)))
+
+	u := p.blobURL(blobName)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// blobName returns the full blob name for a relative path
+func (p *AzureProvider) blobName(relativePath string) string {
+	relativePath = strings.TrimLeft(relativePath, "/")
+	if p.config.Prefix == "" {
+		return relativePath
+	}
+	return p.config.Prefix + "/" + relativePath
+}
+
+// blobURL builds the URL for a blob, or for the container when blobName is empty
+func (p *AzureProvider) blobURL(blobName string) *url.URL {
+	u := *p.endpoint
+	u.Path = strings.TrimRight(u.Path, "/") + "/" + p.config.Container
+	if blobName != "" {
+		u.Path += "/" + blobName
+	}
+	u.RawPath = s3EscapePath(u.Path)
+	return &u
+}
+
+// do builds, authorizes and sends a request to the Blob service
+func (p *AzureProvider) do(ctx context.Context, method, blobName string, query url.Values, headers http.Header, body []byte) (*http.Response, error) {
+	u := p.blobURL(blobName)
+
+	if query == nil {
+		query = url.Values{}
+	}
+	if p.key == nil {
+		for k, values := range p.sasQuery {
+			query[k] = values
+		}
+	}
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for name, values := range headers {
+		for _, v := range values {
+			req.Header.Add(name, v)
+		}
+	}
+	req.ContentLength = int64(len(body))
+	req.Header.Set("x-ms-version", azureAPIVersion)
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+
+	if p.key != nil {
+		p.sign(req)
+	}
+
+	return p.client.Do(req)
+}
+
+// sign adds a Shared Key Authorization header to the request
+func (p *AzureProvider) sign(req *http.Request) {
+	contentLength := ""
+	if req.ContentLength > 0 {
+		contentLength = strconv.FormatInt(req.ContentLength, 10)
+	}
+
+	var msHeaders []string
+	for name := range req.Header {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, "x-ms-") {
+			msHeaders = append(msHeaders, lower)
+		}
+	}
+	sort.Strings(msHeaders)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range msHeaders {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(req.Header.Get(name)) + "\n")
+	}
+
+	var canonicalResource strings.Builder
+	canonicalResource.WriteString("/" + p.config.AccountName + req.URL.EscapedPath())
+	query := req.URL.Query()
+	params := make([]string, 0, len(query))
+	for k := range query {
+		params = append(params, k)
+	}
+	sort.Strings(params)
+	for _, k := range params {
+		values := append([]string(nil), query[k]...)
+		sort.Strings(values)
+		canonicalResource.WriteString("\n" + strings.ToLower(k) + ":" + strings.Join(values, ","))
+	}
+
+	stringToSign := strings.Join([]string{
+		req.Method,
+		req.Header.Get("Content-Encoding"),
+		req.Header.Get("Content-Language"),
+		contentLength,
+		req.Header.Get("Content-MD5"),
+		req.Header.Get("Content-Type"),
+		"", // Date (x-ms-date is used instead)
+		req.Header.Get("If-Modified-Since"),
+		req.Header.Get("If-Match"),
+		req.Header.Get("If-None-Match"),
+		req.Header.Get("If-Unmodified-Since"),
+		req.Header.Get("Range"),
+		canonicalHeaders.String() + canonicalResource.String(),
+	}, "\n")
+
+	signature := base64.StdEncoding.EncodeToString(hmacSHA256(p.key, stringToSign))
+	req.Header.Set("Authorization", "SharedKey "+p.config.AccountName+":"+signature)
+}
+
+// readAzureError extracts a readable error message from a Blob service error response
+func readAzureError(resp *http.Response) string {
+	var azErr struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err := xml.Unmarshal(body, &azErr); err == nil && azErr.Code != "" {
+		return fmt.Sprintf("%s: %s (status %d)", azErr.Code, strings.TrimSpace(azErr.Message), resp.StatusCode)
+	}
+	if code := resp.Header.Get("x-ms-error-code"); code != "" {
+		return fmt.Sprintf("%s (status %d)", code, resp.StatusCode)
+	}
+	return "unexpected status " + strconv.Itoa(resp.StatusCode)
+}
+
+// AzureProviderFactory creates Azure Blob storage providers.
+// Per-storage options (models.StorageConfig.Options) override the factory defaults.
+type AzureProviderFactory struct {
+	defaults AzureConfig
+}
+
+// NewAzureProviderFactory creates a new Azure provider factory with default connection settings
+func NewAzureProviderFactory(defaults AzureConfig) *AzureProviderFactory {
+	return &AzureProviderFactory{defaults: defaults}
+}
+
+// Type returns the storage type
+func (f *AzureProviderFactory) Type() models.StorageType {
+	return models.StorageTypeAzure
+}
+
+// Create creates a new Azure provider
+func (f *AzureProviderFactory) Create(config *models.StorageConfig) (Provider, error) {
+	cfg := f.defaults
+	if config != nil && config.Options != nil {
+		opts := config.Options
+		if v, ok := opts["account_name"].(string); ok && v != "" {
+			cfg.AccountName = v
+		}
+		if v, ok := opts["account_key"].(string); ok && v != "" {
+			cfg.AccountKey = v
+		}
+		if v, ok := opts["sas_token"].(string); ok && v != "" {
+			cfg.SASToken = v
+		}
+		if v, ok := opts["container"].(string); ok && v != "" {
+			cfg.Container = v
+		}
+		if v, ok := opts["endpoint"].(string); ok && v != "" {
+			cfg.Endpoint = v
+		}
+		if v, ok := opts["prefix"].(string); ok && v != "" {
+			cfg.Prefix = v
+		}
+	}
+	return NewAzureProvider(cfg)
+}
@@ -0,0 +1,269 @@
+package filestorage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testAzureAccountKey = base64.StdEncoding.EncodeToString([]byte("azure-test-account-key"))
+
+// fakeAzureBlob is a minimal in-memory Blob service for a single container
+type fakeAzureBlob struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	sasSig  string
+}
+
+func newFakeAzureBlob(t *testing.T) (*fakeAzureBlob, *httptest.Server) {
+	f := &fakeAzureBlob{objects: make(map[string][]byte), sasSig: "fake-signature"}
+	srv := httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func (f *fakeAzureBlob) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	authorized := strings.HasPrefix(r.Header.Get("Authorization"), "SharedKey testacct:") ||
+		r.URL.Query().Get("sig") == f.sasSig
+	if !authorized || r.Header.Get("x-ms-version") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	const containerPath = "/testacct/files"
+
+	if r.URL.Path == containerPath && r.URL.Query().Get("comp") == "list" {
+		prefix := r.URL.Query().Get("prefix")
+		var b strings.Builder
+		b.WriteString("<EnumerationResults><Blobs>")
+		for name, data := range f.objects {
+			if strings.HasPrefix(name, prefix) {
+				fmt.Fprintf(&b, "<Blob><Name>%s</Name><Properties><Content-Length>%d</Content-Length></Properties></Blob>", name, len(data))
+			}
+		}
+		b.WriteString("</Blobs><NextMarker/></EnumerationResults>")
+		w.Write([]byte(b.String()))
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, containerPath+"/")
+	switch r.Method {
+	case http.MethodPut:
+		if r.Header.Get("x-ms-blob-type") != "BlockBlob" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		f.objects[name] = data
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodGet, http.MethodHead:
+		data, ok := f.objects[name]
+		if !ok {
+			w.Header().Set("x-ms-error-code", "BlobNotFound")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(data)
+	case http.MethodDelete:
+		if _, ok := f.objects[name]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.objects, name)
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestAzureProvider_New_Validation(t *testing.T) {
+	_, err := NewAzureProvider(AzureConfig{Container: "files", AccountKey: testAzureAccountKey})
+	assert.Error(t, err, "account name is required")
+
+	_, err = NewAzureProvider(AzureConfig{AccountName: "testacct", AccountKey: testAzureAccountKey})
+	assert.Error(t, err, "container is required")
+
+	_, err = NewAzureProvider(AzureConfig{AccountName: "testacct", Container: "files"})
+	assert.Error(t, err, "credentials are required")
+
+	_, err = NewAzureProvider(AzureConfig{AccountName: "testacct", Container: "files", AccountKey: "not base64!"})
+	assert.Error(t, err)
+
+	_, err = NewAzureProvider(AzureConfig{AccountName: "testacct", Container: "files", SASToken: "sv=2021-08-06"})
+	assert.Error(t, err, "sas token without signature")
+}
+
+func TestAzureProvider_StoreGetDelete(t *testing.T) {
+	tests := []struct {
+		name   string
+		config AzureConfig
+	}{
+		{name: "shared key", config: AzureConfig{AccountKey: testAzureAccountKey}},
+		{name: "sas token", config: AzureConfig{SASToken: "?sv=2021-08-06&sp=rwdl&sig=fake-signature"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, srv := newFakeAzureBlob(t)
+
+			cfg := tt.config
+			cfg.AccountName = "testacct"
+			cfg.Container = "files"
+			cfg.Endpoint = srv.URL + "/testacct"
+			cfg.Prefix = "mbflow"
+			provider, err := NewAzureProvider(cfg)
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			content := []byte("hello azure")
+			entry := &models.FileEntry{StorageID: "default", Name: "hello.txt", MimeType: "text/plain"}
+
+			path, err := provider.Store(ctx, entry, strings.NewReader(string(content)))
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(content)), entry.Size)
+
+			sum := sha256.Sum256(content)
+			assert.Equal(t, hex.EncodeToString(sum[:]), entry.Checksum)
+
+			_, stored := fake.objects["mbflow/"+path]
+			assert.True(t, stored, "blob should be stored under prefix")
+
+			exists, err := provider.Exists(ctx, path)
+			require.NoError(t, err)
+			assert.True(t, exists)
+
+			reader, err := provider.Get(ctx, path)
+			require.NoError(t, err)
+			data, err := io.ReadAll(reader)
+			reader.Close()
+			require.NoError(t, err)
+			assert.Equal(t, content, data)
+
+			usage, err := provider.GetUsage(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), usage.FileCount)
+			assert.Equal(t, int64(len(content)), usage.TotalSize)
+
+			require.NoError(t, provider.Delete(ctx, path))
+			require.NoError(t, provider.Delete(ctx, path), "deleting a missing blob is not an error")
+
+			exists, err = provider.Exists(ctx, path)
+			require.NoError(t, err)
+			assert.False(t, exists)
+
+			_, err = provider.Get(ctx, path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestAzureProvider_SharedKeySignature(t *testing.T) {
+	provider, err := NewAzureProvider(AzureConfig{
+		AccountName: "testacct",
+		AccountKey:  testAzureAccountKey,
+		Container:   "files",
+	})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPut,
+		"https://testacct.blob.core.windows.net/files/res/my%20file.txt?timeout=30", strings.NewReader("hello"))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "text/plain")
+	req.Header.Set("x-ms-blob-type", "BlockBlob")
+	req.Header.Set("x-ms-date", "Mon, 06 May 2024 07:08:09 GMT")
+	req.Header.Set("x-ms-version", azureAPIVersion)
+
+	provider.sign(req)
+
+	stringToSign := "PUT\n\n\n5\n\ntext/plain\n\n\n\n\n\n\n" +
+		"x-ms-blob-type:BlockBlob\nx-ms-date:Mon, 06 May 2024 07:08:09 GMT\nx-ms-version:" + azureAPIVersion + "\n" +
+		"/testacct/files/res/my%20file.txt\ntimeout:30"
+
+	key, _ := base64.StdEncoding.DecodeString(testAzureAccountKey)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(stringToSign))
+	expected := "SharedKey testacct:" + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	assert.Equal(t, expected, req.Header.Get("Authorization"))
+}
+
+func TestAzureProvider_SignedURL(t *testing.T) {
+	provider, err := NewAzureProvider(AzureConfig{
+		AccountName: "testacct",
+		AccountKey:  testAzureAccountKey,
+		Container:   "files",
+	})
+	require.NoError(t, err)
+
+	now := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	signed, err := provider.signedURL("res/abc/my file.pdf", 15*time.Minute, now)
+	require.NoError(t, err)
+
+	u, err := url.Parse(signed)
+	require.NoError(t, err)
+	assert.Equal(t, "testacct.blob.core.windows.net", u.Host)
+	assert.Equal(t, "/files/res/abc/my%20file.pdf", u.EscapedPath())
+
+	q := u.Query()
+	assert.Equal(t, azureAPIVersion, q.Get("sv"))
+	assert.Equal(t, "b", q.Get("sr"))
+	assert.Equal(t, "r", q.Get("sp"))
+	assert.Equal(t, "2024-05-06T07:03:09Z", q.Get("st"))
+	assert.Equal(t, "2024-05-06T07:23:09Z", q.Get("se"))
+
+	stringToSign := "r\n2024-05-06T07:03:09Z\n2024-05-06T07:23:09Z\n/blob/testacct/files/res/abc/my file.pdf\n\n\n\n" +
+		azureAPIVersion + "\nb\n\n\n\n\n\n\n"
+	key, _ := base64.StdEncoding.DecodeString(testAzureAccountKey)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(stringToSign))
+	assert.Equal(t, base64.StdEncoding.EncodeToString(mac.Sum(nil)), q.Get("sig"))
+}
+
+func TestAzureProvider_SignedURL_NotSupportedWithSASToken(t *testing.T) {
+	provider, err := NewAzureProvider(AzureConfig{
+		AccountName: "testacct",
+		SASToken:    "sv=2021-08-06&sp=rwdl&sig=abc",
+		Container:   "files",
+	})
+	require.NoError(t, err)
+
+	_, err = provider.SignedURL(context.Background(), "a.txt", time.Minute)
+	assert.ErrorIs(t, err, ErrSignedURLNotSupported)
+}
+
+func TestAzureProviderFactory_OptionsOverrideDefaults(t *testing.T) {
+	factory := NewAzureProviderFactory(AzureConfig{
+		AccountName: "testacct",
+		AccountKey:  testAzureAccountKey,
+		Container:   "files",
+	})
+	assert.Equal(t, models.StorageTypeAzure, factory.Type())
+
+	provider, err := factory.Create(&models.StorageConfig{
+		Type:    models.StorageTypeAzure,
+		Options: map[string]any{"container": "other", "prefix": "tenant-a"},
+	})
+	require.NoError(t, err)
+
+	azure := provider.(*AzureProvider)
+	assert.Equal(t, "other", azure.config.Container)
+	assert.Equal(t, "tenant-a/x.txt", azure.blobName("x.txt"))
+}
@@ -0,0 +1,355 @@
+package filestorage
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	gcs "google.golang.org/api/storage/v1"
+)
+
+const gcsSigningHost = "storage.googleapis.com"
+
+// GCSConfig holds connection settings for Google Cloud Storage.
+type GCSConfig struct {
+	Bucket          string
+	CredentialsJSON []byte // Service account key; required for signed URLs
+	Prefix          string // Optional object name prefix inside the bucket
+	Endpoint        string // Optional API endpoint override (emulators, tests)
+
+	// clientOptions are appended to the service options; used by tests
+	clientOptions []option.ClientOption
+}
+
+// gcsServiceAccount is the subset of a service account key used for URL signing
+type gcsServiceAccount struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+}
+
+// GCSProvider implements Provider for Google Cloud Storage.
+// It also implements URLSigner using V4 signed URLs when a service account key is configured.
+type GCSProvider struct {
+	config      GCSConfig
+	service     *gcs.Service
+	signerEmail string
+	signerKey   *rsa.PrivateKey
+}
+
+// NewGCSProvider creates a new GCS storage provider
+func NewGCSProvider(ctx context.Context, config GCSConfig) (*GCSProvider, error) {
+	if config.Bucket == "" {
+		return nil, fmt.Errorf("bucket is required for gcs storage")
+	}
+	config.Prefix = strings.Trim(config.Prefix, "/")
+
+	opts := []option.ClientOption{}
+	if len(config.CredentialsJSON) > 0 {
+		creds, err := google.CredentialsFromJSON(ctx, config.CredentialsJSON, gcs.DevstorageReadWriteScope)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse credentials: %w", err)
+		}
+		opts = append(opts, option.WithCredentials(creds))
+	}
+	if config.Endpoint != "" {
+		opts = append(opts, option.WithEndpoint(config.Endpoint))
+	}
+	opts = append(opts, config.clientOptions...)
+
+	service, err := gcs.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gcs service: %w", err)
+	}
+
+	p := &GCSProvider{
+		config:  config,
+		service: service,
+	}
+
+	if len(config.CredentialsJSON) > 0 {
+		if err := p.loadSigner(config.CredentialsJSON); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// loadSigner extracts the service account email and private key for URL signing.
+// Credentials without a private key (e.g. user credentials) simply disable signing.
+func (p *GCSProvider) loadSigner(credentialsJSON []byte) error {
+	var sa gcsServiceAccount
+	if err := json.Unmarshal(credentialsJSON, &sa); err != nil {
+		return fmt.Errorf("failed to parse credentials: %w", err)
+	}
+	if sa.ClientEmail == "" || sa.PrivateKey == "" {
+		return nil
+	}
+
+	block, _ := pem.Decode([]byte(sa.PrivateKey))
+	if block == nil {
+		return fmt.Errorf("invalid private key in credentials")
+	}
+
+	var key *rsa.PrivateKey
+	if parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		rsaKey, ok := parsed.(*rsa.PrivateKey)
+		if !ok {
+			return fmt.Errorf("private key in credentials is not RSA")
+		}
+		key = rsaKey
+	} else {
+		rsaKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("failed to parse private key: %w", err)
+		}
+		key = rsaKey
+	}
+
+	p.signerEmail = sa.ClientEmail
+	p.signerKey = key
+	return nil
+}
+
+// Type returns the storage type
+func (p *GCSProvider) Type() models.StorageType {
+	return models.StorageTypeGCS
+}
+
+// Store uploads a file to the bucket
+func (p *GCSProvider) Store(ctx context.Context, entry *models.FileEntry, reader io.Reader) (string, error) {
+	relativePath := entry.Path
+	if relativePath == "" {
+		relativePath = p.generatePath(entry)
+	}
+
+	hasher := sha256.New()
+	counter := &countingReader{reader: io.TeeReader(reader, hasher)}
+
+	object := &gcs.Object{
+		Name:        p.objectName(relativePath),
+		ContentType: entry.MimeType,
+	}
+
+	call := p.service.Objects.Insert(p.config.Bucket, object).Context(ctx)
+	if entry.MimeType != "" {
+		call = call.Media(counter, googleapi.ContentType(entry.MimeType))
+	} else {
+		call = call.Media(counter)
+	}
+
+	if _, err := call.Do(); err != nil {
+		return "", fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	entry.Size = counter.n
+	entry.Checksum = hex.EncodeToString(hasher.Sum(nil))
+	entry.Path = relativePath
+
+	return relativePath, nil
+}
+
+// countingReader counts bytes read through it
+type countingReader struct {
+	reader io.Reader
+	n      int64
+}
+
+func (r *countingReader) Read(b []byte) (int, error) {
+	n, err := r.reader.Read(b)
+	r.n += int64(n)
+	return n, err
+}
+
+// generatePath generates a unique object path
+func (p *GCSProvider) generatePath(entry *models.FileEntry) string {
+	safeName := sanitizeFilename(entry.Name)
+	if safeName == "" {
+		safeName = "file"
+	}
+
+	uniqueID := uuid.New().String()[:8]
+
+	return path.Join(entry.StorageID, uniqueID, safeName)
+}
+
+// Get downloads a file from the bucket
+func (p *GCSProvider) Get(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	resp, err := p.service.Objects.Get(p.config.Bucket, p.objectName(filePath)).Context(ctx).Download()
+	if err != nil {
+		if isGCSNotFound(err) {
+			return nil, fmt.Errorf("file not found: %s", filePath)
+		}
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+	return resp.Body, nil
+}
+
+// Delete removes a file from the bucket. Deleting a missing object is not an error.
+func (p *GCSProvider) Delete(ctx context.Context, filePath string) error {
+	err := p.service.Objects.Delete(p.config.Bucket, p.objectName(filePath)).Context(ctx).Do()
+	if err != nil && !isGCSNotFound(err) {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
+
+// Exists checks if an object exists
+func (p *GCSProvider) Exists(ctx context.Context, filePath string) (bool, error) {
+	_, err := p.service.Objects.Get(p.config.Bucket, p.objectName(filePath)).Context(ctx).Fields("name").Do()
+	if err == nil {
+		return true, nil
+	}
+	if isGCSNotFound(err) {
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to check file: %w", err)
+}
+
+// GetUsage returns storage usage statistics for objects under the configured prefix
+func (p *GCSProvider) GetUsage(ctx context.Context) (*models.StorageUsage, error) {
+	var totalSize int64
+	var fileCount int64
+
+	call := p.service.Objects.List(p.config.Bucket).Fields("nextPageToken", "items(size)")
+	if p.config.Prefix != "" {
+		call = call.Prefix(p.config.Prefix + "/")
+	}
+
+	err := call.Pages(ctx, func(objects *gcs.Objects) error {
+		for _, obj := range objects.Items {
+			totalSize += int64(obj.Size)
+			fileCount++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate usage: %w", err)
+	}
+
+	return &models.StorageUsage{
+		TotalSize: totalSize,
+		FileCount: fileCount,
+	}, nil
+}
+
+// Close closes the provider
+func (p *GCSProvider) Close() error {
+	return nil
+}
+
+// SignedURL returns a V4 signed GET URL for the object, valid for the given duration.
+func (p *GCSProvider) SignedURL(ctx context.Context, filePath string, expires time.Duration) (string, error) {
+	return p.signedURL(filePath, expires, time.Now().UTC())
+}
+
+func (p *GCSProvider) signedURL(filePath string, expires time.Duration, now time.Time) (string, error) {
+	if p.signerKey == nil {
+		return "", ErrSignedURLNotSupported
+	}
+	if expires <= 0 || expires > 7*24*time.Hour {
+		return "", fmt.Errorf("signed URL expiry must be between 1s and 7 days")
+	}
+
+	timestamp := now.Format("20060102T150405Z")
+	datestamp := now.Format("20060102")
+	scope := datestamp + "/auto/storage/goog4_request"
+
+	canonicalURI := "/" + p.config.Bucket + "/" + s3Escape(p.objectName(filePath), true)
+
+	query := url.Values{}
+	query.Set("X-Goog-Algorithm", "GOOG4-RSA-SHA256")
+	query.Set("X-Goog-Credential", p.signerEmail+"/"+scope)
+	query.Set("X-Goog-Date", timestamp)
+	query.Set("X-Goog-Expires", fmt.Sprintf("%d", int64(expires.Seconds())))
+	query.Set("X-Goog-SignedHeaders", "host")
+	canonicalQuery := s3CanonicalQuery(query)
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		canonicalURI,
+		canonicalQuery,
+		"host:" + gcsSigningHost + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "GOOG4-RSA-SHA256\n" + timestamp + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	digest := sha256.Sum256([]byte(stringToSign))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.signerKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign URL: %w", err)
+	}
+
+	return "https://" + gcsSigningHost + canonicalURI + "?" + canonicalQuery +
+		"&X-Goog-Signature=" + hex.EncodeToString(signature), nil
+}
+
+// objectName returns the full object name for a relative path
+func (p *GCSProvider) objectName(relativePath string) string {
+	relativePath = strings.TrimLeft(relativePath, "/")
+	if p.config.Prefix == "" {
+		return relativePath
+	}
+	return p.config.Prefix + "/" + relativePath
+}
+
+func isGCSNotFound(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound
+}
+
+// GCSProviderFactory creates GCS storage providers.
+// Per-storage options (models.StorageConfig.Options) override the factory defaults.
+type GCSProviderFactory struct {
+	defaults GCSConfig
+}
+
+// NewGCSProviderFactory creates a new GCS provider factory with default connection settings
+func NewGCSProviderFactory(defaults GCSConfig) *GCSProviderFactory {
+	return &GCSProviderFactory{defaults: defaults}
+}
+
+// Type returns the storage type
+func (f *GCSProviderFactory) Type() models.StorageType {
+	return models.StorageTypeGCS
+}
+
+// Create creates a new GCS provider
+func (f *GCSProviderFactory) Create(config *models.StorageConfig) (Provider, error) {
+	cfg := f.defaults
+	if config != nil && config.Options != nil {
+		opts := config.Options
+		if v, ok := opts["bucket"].(string); ok && v != "" {
+			cfg.Bucket = v
+		}
+		if v, ok := opts["prefix"].(string); ok && v != "" {
+			cfg.Prefix = v
+		}
+		if v, ok := opts["credentials"].(string); ok && v != "" {
+			cfg.CredentialsJSON = []byte(v)
+		}
+	}
+	return NewGCSProvider(context.Background(), cfg)
+}
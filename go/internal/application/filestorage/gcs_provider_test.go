@@ -0,0 +1,245 @@
+package filestorage
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+)
+
+// fakeGCS is a minimal in-memory implementation of the GCS JSON API
+type fakeGCS struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func newFakeGCS(t *testing.T) (*fakeGCS, *httptest.Server) {
+	f := &fakeGCS{objects: make(map[string][]byte)}
+	srv := httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func (f *fakeGCS) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	const objectsPath = "/storage/v1/b/test-bucket/o"
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/upload"+objectsPath:
+		_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mr := multipart.NewReader(r.Body, params["boundary"])
+
+		metaPart, err := mr.NextPart()
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var meta struct {
+			Name string `json:"name"`
+		}
+		json.NewDecoder(metaPart).Decode(&meta)
+
+		mediaPart, err := mr.NextPart()
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(mediaPart)
+		f.objects[meta.Name] = data
+
+		json.NewEncoder(w).Encode(map[string]any{"name": meta.Name, "size": fmt.Sprint(len(data))})
+
+	case r.Method == http.MethodGet && r.URL.Path == objectsPath:
+		items := []map[string]any{}
+		for name, data := range f.objects {
+			if strings.HasPrefix(name, r.URL.Query().Get("prefix")) {
+				items = append(items, map[string]any{"name": name, "size": fmt.Sprint(len(data))})
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"items": items})
+
+	case strings.HasPrefix(r.URL.Path, objectsPath+"/"):
+		name := strings.TrimPrefix(r.URL.Path, objectsPath+"/")
+		data, ok := f.objects[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": 404, "message": "Not Found"}})
+			return
+		}
+		switch {
+		case r.Method == http.MethodDelete:
+			delete(f.objects, name)
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Query().Get("alt") == "media":
+			w.Write(data)
+		default:
+			json.NewEncoder(w).Encode(map[string]any{"name": name})
+		}
+
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func newTestGCSProvider(t *testing.T, srv *httptest.Server) *GCSProvider {
+	provider, err := NewGCSProvider(context.Background(), GCSConfig{
+		Bucket:        "test-bucket",
+		Prefix:        "mbflow",
+		Endpoint:      srv.URL + "/storage/v1/",
+		clientOptions: []option.ClientOption{option.WithoutAuthentication()},
+	})
+	require.NoError(t, err)
+	return provider
+}
+
+func testServiceAccountJSON(t *testing.T) ([]byte, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	creds, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"client_email":   "mbflow@test-project.iam.gserviceaccount.com",
+		"private_key":    string(keyPEM),
+		"private_key_id": "key-1",
+		"token_uri":      "https://oauth2.googleapis.com/token",
+	})
+	require.NoError(t, err)
+	return creds, key
+}
+
+func TestGCSProvider_New_RequiresBucket(t *testing.T) {
+	_, err := NewGCSProvider(context.Background(), GCSConfig{})
+	assert.Error(t, err)
+}
+
+func TestGCSProvider_StoreGetDelete(t *testing.T) {
+	fake, srv := newFakeGCS(t)
+	provider := newTestGCSProvider(t, srv)
+	ctx := context.Background()
+
+	content := []byte("hello gcs")
+	entry := &models.FileEntry{StorageID: "default", Name: "hello.txt", MimeType: "text/plain"}
+
+	path, err := provider.Store(ctx, entry, strings.NewReader(string(content)))
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), entry.Size)
+
+	sum := sha256.Sum256(content)
+	assert.Equal(t, hex.EncodeToString(sum[:]), entry.Checksum)
+
+	_, stored := fake.objects["mbflow/"+path]
+	assert.True(t, stored, "object should be stored under prefix")
+
+	exists, err := provider.Exists(ctx, path)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	reader, err := provider.Get(ctx, path)
+	require.NoError(t, err)
+	data, err := io.ReadAll(reader)
+	reader.Close()
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+
+	usage, err := provider.GetUsage(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), usage.FileCount)
+	assert.Equal(t, int64(len(content)), usage.TotalSize)
+
+	require.NoError(t, provider.Delete(ctx, path))
+	require.NoError(t, provider.Delete(ctx, path), "deleting a missing object is not an error")
+
+	exists, err = provider.Exists(ctx, path)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestGCSProvider_SignedURL(t *testing.T) {
+	creds, key := testServiceAccountJSON(t)
+
+	provider, err := NewGCSProvider(context.Background(), GCSConfig{
+		Bucket:          "files",
+		CredentialsJSON: creds,
+	})
+	require.NoError(t, err)
+
+	now := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	signed, err := provider.signedURL("res/abc/my file.pdf", 15*time.Minute, now)
+	require.NoError(t, err)
+
+	u, err := url.Parse(signed)
+	require.NoError(t, err)
+	assert.Equal(t, "storage.googleapis.com", u.Host)
+	assert.Equal(t, "/files/res/abc/my%20file.pdf", u.EscapedPath())
+
+	q := u.Query()
+	assert.Equal(t, "GOOG4-RSA-SHA256", q.Get("X-Goog-Algorithm"))
+	assert.Equal(t, "mbflow@test-project.iam.gserviceaccount.com/20240506/auto/storage/goog4_request", q.Get("X-Goog-Credential"))
+	assert.Equal(t, "20240506T070809Z", q.Get("X-Goog-Date"))
+	assert.Equal(t, "900", q.Get("X-Goog-Expires"))
+
+	// Verify the signature against the canonical request
+	signature, err := hex.DecodeString(q.Get("X-Goog-Signature"))
+	require.NoError(t, err)
+
+	canonicalQuery := strings.SplitN(u.RawQuery, "&X-Goog-Signature=", 2)[0]
+	canonicalRequest := "GET\n/files/res/abc/my%20file.pdf\n" + canonicalQuery + "\nhost:storage.googleapis.com\n\nhost\nUNSIGNED-PAYLOAD"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "GOOG4-RSA-SHA256\n20240506T070809Z\n20240506/auto/storage/goog4_request\n" + hex.EncodeToString(requestHash[:])
+	digest := sha256.Sum256([]byte(stringToSign))
+
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+}
+
+func TestGCSProvider_SignedURL_NotSupportedWithoutKey(t *testing.T) {
+	_, srv := newFakeGCS(t)
+	provider := newTestGCSProvider(t, srv)
+
+	_, err := provider.SignedURL(context.Background(), "a.txt", time.Minute)
+	assert.ErrorIs(t, err, ErrSignedURLNotSupported)
+}
+
+func TestStorageWrapper_SignedURL(t *testing.T) {
+	manager := NewStorageManager(&ManagerConfig{BasePath: t.TempDir()}, nil)
+	defer manager.Close()
+
+	storage, err := manager.GetDefaultStorage()
+	require.NoError(t, err)
+
+	signer, ok := storage.(URLSigner)
+	require.True(t, ok)
+
+	_, err = signer.SignedURL(context.Background(), "a.txt", time.Minute)
+	assert.ErrorIs(t, err, ErrSignedURLNotSupported, "local storage cannot sign URLs")
+}
@@ -16,6 +16,7 @@ import (
 func DefaultManagerConfig() *ManagerConfig {
 	return &ManagerConfig{
 		BasePath:        "./file_storage",
+		StorageType:     models.StorageTypeLocal,
 		MaxFileSize:     100 * 1024 * 1024, // 100MB
 		MaxStorageSize:  0,                 // unlimited
 		CleanupInterval: 1 * time.Hour,
@@ -24,11 +25,12 @@ func DefaultManagerConfig() *ManagerConfig {
 
 // ManagerConfig holds manager configuration
 type ManagerConfig struct {
-	BasePath        string        // Base path for all storages
-	MaxFileSize     int64         // Maximum file size in bytes
-	MaxStorageSize  int64         // Maximum storage size (0 = unlimited)
-	DefaultTTL      time.Duration // Default TTL for files (0 = no expiration)
-	CleanupInterval time.Duration // Interval for cleanup routine
+	BasePath        string             // Base path for all storages
+	StorageType     models.StorageType // Backend used for storages created on demand
+	MaxFileSize     int64              // Maximum file size in bytes
+	MaxStorageSize  int64              // Maximum storage size (0 = unlimited)
+	DefaultTTL      time.Duration      // Default TTL for files (0 = no expiration)
+	CleanupInterval time.Duration      // Interval for cleanup routine
 }
 
 // StorageManager manages multiple storages and observers
@@ -94,9 +96,14 @@ func (m *StorageManager) GetStorage(storageID string) (Storage, error) {
 		return m.wrapStorage(storage), nil
 	}
 
+	storageType := m.config.StorageType
+	if storageType == "" {
+		storageType = models.StorageTypeLocal
+	}
+
 	// Create with default config
 	return m.CreateStorage(storageID, &models.StorageConfig{
-		Type:     models.StorageTypeLocal,
+		Type:     storageType,
 		BasePath: m.config.BasePath,
 	})
 }
@@ -410,10 +417,29 @@ func (s *storageWrapper) Get(ctx context.Context, fileID string) (*models.FileEn
 	return entry, reader, nil
 }
 
-// Delete removes a file
+// SignedURL returns a signed download URL for the file at path if the provider supports it
+func (s *storageWrapper) SignedURL(ctx context.Context, path string, expires time.Duration) (string, error) {
+	signer, ok := s.provider.(URLSigner)
+	if !ok {
+		return "", ErrSignedURLNotSupported
+	}
+	return signer.SignedURL(ctx, path, expires)
+}
+
+// Delete removes a file by path. Like Get, the fileID parameter is treated as the
+// storage path; metadata kept in the repository must be removed by the caller.
 func (s *storageWrapper) Delete(ctx context.Context, fileID string) error {
-	// TODO: Implement with repository integration
-	return fmt.Errorf("not implemented: requires repository integration")
+	if err := s.provider.Delete(ctx, fileID); err != nil {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+
+	s.manager.notifyObservers(ctx, NewFileEvent(EventFileRemoved, s.storage.storageID, &models.FileEntry{
+		ID:        fileID,
+		StorageID: s.storage.storageID,
+		Path:      fileID,
+	}))
+
+	return nil
 }
 
 // List lists files
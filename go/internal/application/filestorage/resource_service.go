@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/smilemakc/mbflow/go/internal/domain/repository"
@@ -156,17 +157,63 @@ func (s *ResourceFileService) GetFile(
 		return nil, nil, fmt.Errorf("file has expired")
 	}
 
+	reader, err := s.OpenFile(ctx, fileModel)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return fileModel, reader, nil
+}
+
+// OpenFile opens the content of a file whose metadata was already looked up and checked.
+func (s *ResourceFileService) OpenFile(ctx context.Context, fileModel *storagemodels.FileModel) (io.ReadCloser, error) {
 	store, err := s.storageManager.GetStorage(fileModel.StorageID)
 	if err != nil {
-		return nil, nil, fmt.Errorf("storage not available: %w", err)
+		return nil, fmt.Errorf("storage not available: %w", err)
 	}
 
-	_, reader, err := store.Get(ctx, fileID)
+	_, reader, err := store.Get(ctx, fileModel.Path)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to retrieve file: %w", err)
+		return nil, fmt.Errorf("failed to retrieve file: %w", err)
 	}
 
-	return fileModel, reader, nil
+	return reader, nil
+}
+
+// GetDownloadURL returns a time-limited direct download URL for a file.
+// Returns ErrSignedURLNotSupported, together with the file, when the underlying backend
+// cannot sign URLs, in which case callers should stream the file through OpenFile instead.
+func (s *ResourceFileService) GetDownloadURL(
+	ctx context.Context,
+	resourceID string,
+	fileID string,
+	expires time.Duration,
+) (*storagemodels.FileModel, string, error) {
+	fileModel, err := s.GetFileMetadata(ctx, resourceID, fileID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if fileModel.IsExpired() {
+		return nil, "", fmt.Errorf("file has expired")
+	}
+
+	store, err := s.storageManager.GetStorage(fileModel.StorageID)
+	if err != nil {
+		return nil, "", fmt.Errorf("storage not available: %w", err)
+	}
+
+	signer, ok := store.(URLSigner)
+	if !ok {
+		return fileModel, "", ErrSignedURLNotSupported
+	}
+
+	signedURL, err := signer.SignedURL(ctx, fileModel.Path, expires)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return fileModel, signedURL, nil
 }
 
 func (s *ResourceFileService) DeleteFile(
@@ -0,0 +1,469 @@
+package filestorage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// S3Config holds connection settings for an S3-compatible object store.
+type S3Config struct {
+	Bucket          string
+	Endpoint        string // Empty means AWS (https://s3.<region>.amazonaws.com)
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	Prefix          string // Optional key prefix inside the bucket
+	UsePathStyle    bool   // Use endpoint/bucket/key instead of bucket.endpoint/key
+	HTTPClient      *http.Client
+}
+
+// S3Provider implements Provider for S3-compatible object storage (AWS S3, MinIO, etc.).
+// Requests are signed with AWS Signature Version 4.
+type S3Provider struct {
+	config   S3Config
+	endpoint *url.URL
+	client   *http.Client
+}
+
+// NewS3Provider creates a new S3 storage provider
+func NewS3Provider(config S3Config) (*S3Provider, error) {
+	if config.Bucket == "" {
+		return nil, fmt.Errorf("bucket is required for s3 storage")
+	}
+	if config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, fmt.Errorf("access_key_id and secret_access_key are required for s3 storage")
+	}
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+
+	rawEndpoint := config.Endpoint
+	if rawEndpoint == "" {
+		rawEndpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", config.Region)
+	}
+	endpoint, err := url.Parse(rawEndpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint: %s", rawEndpoint)
+	}
+
+	client := config.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Minute}
+	}
+
+	config.Prefix = strings.Trim(config.Prefix, "/")
+
+	return &S3Provider{
+		config:   config,
+		endpoint: endpoint,
+		client:   client,
+	}, nil
+}
+
+// Type returns the storage type
+func (p *S3Provider) Type() models.StorageType {
+	return models.StorageTypeS3
+}
+
+// Store uploads a file to the bucket.
+// The content is buffered in memory to compute the payload hash required by SigV4;
+// file size is already bounded by the manager's MaxFileSize.
+func (p *S3Provider) Store(ctx context.Context, entry *models.FileEntry, reader io.Reader) (string, error) {
+	relativePath := entry.Path
+	if relativePath == "" {
+		relativePath = p.generatePath(entry)
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file content: %w", err)
+	}
+
+	headers := http.Header{}
+	if entry.MimeType != "" {
+		headers.Set("Content-Type", entry.MimeType)
+	}
+
+	resp, err := p.do(ctx, http.MethodPut, p.objectKey(relativePath), nil, headers, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to upload file: %s", readS3Error(resp))
+	}
+
+	sum := sha256.Sum256(data)
+	entry.Size = int64(len(data))
+	entry.Checksum = hex.EncodeToString(sum[:])
+	entry.Path = relativePath
+
+	return relativePath, nil
+}
+
+// generatePath generates a unique object path
+func (p *S3Provider) generatePath(entry *models.FileEntry) string {
+	safeName := sanitizeFilename(entry.Name)
+	if safeName == "" {
+		safeName = "file"
+	}
+
+	uniqueID := uuid.New().String()[:8]
+
+	return path.Join(entry.StorageID, uniqueID, safeName)
+}
+
+// Get downloads a file from the bucket
+func (p *S3Provider) Get(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	resp, err := p.do(ctx, http.MethodGet, p.objectKey(filePath), nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, fmt.Errorf("file not found: %s", filePath)
+	default:
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("failed to get file: %s", readS3Error(resp))
+	}
+}
+
+// Delete removes a file from the bucket. Deleting a missing object is not an error.
+func (p *S3Provider) Delete(ctx context.Context, filePath string) error {
+	resp, err := p.do(ctx, http.MethodDelete, p.objectKey(filePath), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete file: %s", readS3Error(resp))
+	}
+
+	return nil
+}
+
+// Exists checks if an object exists
+func (p *S3Provider) Exists(ctx context.Context, filePath string) (bool, error) {
+	resp, err := p.do(ctx, http.MethodHead, p.objectKey(filePath), nil, nil, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to check file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to check file: unexpected status %d", resp.StatusCode)
+	}
+}
+
+// s3ListResult is the subset of the ListObjectsV2 response used for usage stats
+type s3ListResult struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	Contents              []struct {
+		Key  string `xml:"Key"`
+		Size int64  `xml:"Size"`
+	} `xml:"Contents"`
+}
+
+// GetUsage returns storage usage statistics for objects under the configured prefix
+func (p *S3Provider) GetUsage(ctx context.Context) (*models.StorageUsage, error) {
+	var totalSize int64
+	var fileCount int64
+
+	continuationToken := ""
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		if p.config.Prefix != "" {
+			query.Set("prefix", p.config.Prefix+"/")
+		}
+		if continuationToken != "" {
+			query.Set("continuation-token", continuationToken)
+		}
+
+		resp, err := p.do(ctx, http.MethodGet, "", query, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate usage: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			msg := readS3Error(resp)
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to calculate usage: %s", msg)
+		}
+
+		var result s3ListResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse list response: %w", err)
+		}
+
+		for _, obj := range result.Contents {
+			totalSize += obj.Size
+			fileCount++
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		continuationToken = result.NextContinuationToken
+	}
+
+	return &models.StorageUsage{
+		TotalSize: totalSize,
+		FileCount: fileCount,
+	}, nil
+}
+
+// Close closes the provider
+func (p *S3Provider) Close() error {
+	return nil
+}
+
+// objectKey returns the full object key for a relative path
+func (p *S3Provider) objectKey(relativePath string) string {
+	relativePath = strings.TrimLeft(relativePath, "/")
+	if p.config.Prefix == "" {
+		return relativePath
+	}
+	return p.config.Prefix + "/" + relativePath
+}
+
+// objectURL builds the request URL for the given key
+func (p *S3Provider) objectURL(key string, query url.Values) *url.URL {
+	u := *p.endpoint
+	basePath := strings.TrimRight(u.Path, "/")
+
+	if p.config.UsePathStyle {
+		u.Path = basePath + "/" + p.config.Bucket + "/" + key
+	} else {
+		u.Host = p.config.Bucket + "." + u.Host
+		u.Path = basePath + "/" + key
+	}
+	u.RawPath = s3EscapePath(u.Path)
+	u.RawQuery = s3CanonicalQuery(query)
+
+	return &u
+}
+
+// do builds, signs and sends a request to the object store
+func (p *S3Provider) do(ctx context.Context, method, key string, query url.Values, headers http.Header, body []byte) (*http.Response, error) {
+	u := p.objectURL(key, query)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for name, values := range headers {
+		for _, v := range values {
+			req.Header.Add(name, v)
+		}
+	}
+	req.ContentLength = int64(len(body))
+
+	p.sign(req, body, time.Now().UTC())
+
+	return p.client.Do(req)
+}
+
+// sign adds AWS Signature Version 4 headers to the request
+func (p *S3Provider) sign(req *http.Request, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	shortDate := now.Format("20060102")
+
+	payloadHash := sha256.Sum256(body)
+	payloadHex := hex.EncodeToString(payloadHash[:])
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHex)
+	if p.config.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", p.config.SessionToken)
+	}
+
+	signedHeaderNames := []string{"host"}
+	for name := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "content-type" || strings.HasPrefix(lower, "x-amz-") {
+			signedHeaderNames = append(signedHeaderNames, lower)
+		}
+	}
+	sort.Strings(signedHeaderNames)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range signedHeaderNames {
+		value := req.Host
+		if value == "" {
+			value = req.URL.Host
+		}
+		if name != "host" {
+			value = strings.TrimSpace(req.Header.Get(name))
+		}
+		canonicalHeaders.WriteString(name + ":" + value + "\n")
+	}
+	signedHeaders := strings.Join(signedHeaderNames, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHex,
+	}, "\n")
+
+	scope := shortDate + "/" + p.config.Region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	signingKey := hmacSHA256([]byte("AWS4"+p.config.SecretAccessKey), shortDate)
+	signingKey = hmacSHA256(signingKey, p.config.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		p.config.AccessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3Escape percent-encodes a string per the SigV4 rules (RFC 3986 unreserved characters only)
+func s3Escape(s string, keepSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && keepSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func s3EscapePath(p string) string {
+	return s3Escape(p, true)
+}
+
+// s3CanonicalQuery encodes query parameters sorted by key, as required by SigV4
+func s3CanonicalQuery(query url.Values) string {
+	if len(query) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		values := append([]string(nil), query[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, s3Escape(k, false)+"="+s3Escape(v, false))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// readS3Error extracts a readable error message from an S3 error response
+func readS3Error(resp *http.Response) string {
+	var s3Err struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err := xml.Unmarshal(body, &s3Err); err == nil && s3Err.Code != "" {
+		return fmt.Sprintf("%s: %s (status %d)", s3Err.Code, s3Err.Message, resp.StatusCode)
+	}
+	return "unexpected status " + strconv.Itoa(resp.StatusCode)
+}
+
+// S3ProviderFactory creates S3 storage providers.
+// Per-storage options (models.StorageConfig.Options) override the factory defaults.
+type S3ProviderFactory struct {
+	defaults S3Config
+}
+
+// NewS3ProviderFactory creates a new S3 provider factory with default connection settings
+func NewS3ProviderFactory(defaults S3Config) *S3ProviderFactory {
+	return &S3ProviderFactory{defaults: defaults}
+}
+
+// Type returns the storage type
+func (f *S3ProviderFactory) Type() models.StorageType {
+	return models.StorageTypeS3
+}
+
+// Create creates a new S3 provider
+func (f *S3ProviderFactory) Create(config *models.StorageConfig) (Provider, error) {
+	cfg := f.defaults
+	if config != nil && config.Options != nil {
+		opts := config.Options
+		if v, ok := opts["bucket"].(string); ok && v != "" {
+			cfg.Bucket = v
+		}
+		if v, ok := opts["endpoint"].(string); ok && v != "" {
+			cfg.Endpoint = v
+		}
+		if v, ok := opts["region"].(string); ok && v != "" {
+			cfg.Region = v
+		}
+		if v, ok := opts["access_key_id"].(string); ok && v != "" {
+			cfg.AccessKeyID = v
+		}
+		if v, ok := opts["secret_access_key"].(string); ok && v != "" {
+			cfg.SecretAccessKey = v
+		}
+		if v, ok := opts["session_token"].(string); ok && v != "" {
+			cfg.SessionToken = v
+		}
+		if v, ok := opts["prefix"].(string); ok && v != "" {
+			cfg.Prefix = v
+		}
+		if v, ok := opts["use_path_style"].(bool); ok {
+			cfg.UsePathStyle = v
+		}
+	}
+	return NewS3Provider(cfg)
+}
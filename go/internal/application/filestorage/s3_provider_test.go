@@ -0,0 +1,237 @@
+package filestorage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 is a minimal in-memory S3 server supporting path-style object operations
+type fakeS3 struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	requests []*http.Request
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	f := &fakeS3{objects: make(map[string][]byte)}
+	srv := httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func (f *fakeS3) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r)
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, "/test-bucket/")
+	if r.URL.Path == "/test-bucket/" || r.URL.Path == "/test-bucket" {
+		prefix := r.URL.Query().Get("prefix")
+		var b strings.Builder
+		b.WriteString("<ListBucketResult><IsTruncated>false</IsTruncated>")
+		for k, v := range f.objects {
+			if strings.HasPrefix(k, prefix) {
+				fmt.Fprintf(&b, "<Contents><Key>%s</Key><Size>%d</Size></Contents>", k, len(v))
+			}
+		}
+		b.WriteString("</ListBucketResult>")
+		w.Write([]byte(b.String()))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		sum := sha256.Sum256(data)
+		if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.objects[key] = data
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet, http.MethodHead:
+		data, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(data)
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func newTestS3Provider(t *testing.T, srv *httptest.Server) *S3Provider {
+	provider, err := NewS3Provider(S3Config{
+		Bucket:          "test-bucket",
+		Endpoint:        srv.URL,
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "secret",
+		Prefix:          "mbflow",
+		UsePathStyle:    true,
+	})
+	require.NoError(t, err)
+	return provider
+}
+
+func TestS3Provider_New_Validation(t *testing.T) {
+	_, err := NewS3Provider(S3Config{AccessKeyID: "a", SecretAccessKey: "b"})
+	assert.Error(t, err)
+
+	_, err = NewS3Provider(S3Config{Bucket: "bucket"})
+	assert.Error(t, err)
+
+	provider, err := NewS3Provider(S3Config{Bucket: "bucket", AccessKeyID: "a", SecretAccessKey: "b"})
+	require.NoError(t, err)
+	assert.Equal(t, "s3.us-east-1.amazonaws.com", provider.endpoint.Host)
+	assert.Equal(t, models.StorageTypeS3, provider.Type())
+}
+
+func TestS3Provider_StoreGetDelete(t *testing.T) {
+	fake, srv := newFakeS3(t)
+	provider := newTestS3Provider(t, srv)
+	ctx := context.Background()
+
+	content := []byte("hello object storage")
+	entry := &models.FileEntry{StorageID: "default", Name: "hello world.txt", MimeType: "text/plain"}
+
+	path, err := provider.Store(ctx, entry, bytes.NewReader(content))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(path, "default/"))
+	assert.Equal(t, int64(len(content)), entry.Size)
+
+	sum := sha256.Sum256(content)
+	assert.Equal(t, hex.EncodeToString(sum[:]), entry.Checksum)
+
+	_, stored := fake.objects["mbflow/"+path]
+	assert.True(t, stored, "object should be stored under prefix")
+
+	exists, err := provider.Exists(ctx, path)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	reader, err := provider.Get(ctx, path)
+	require.NoError(t, err)
+	data, err := io.ReadAll(reader)
+	reader.Close()
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+
+	usage, err := provider.GetUsage(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), usage.FileCount)
+	assert.Equal(t, int64(len(content)), usage.TotalSize)
+
+	require.NoError(t, provider.Delete(ctx, path))
+
+	exists, err = provider.Exists(ctx, path)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = provider.Get(ctx, path)
+	assert.Error(t, err)
+}
+
+func TestS3Provider_VirtualHostedURL(t *testing.T) {
+	provider, err := NewS3Provider(S3Config{
+		Bucket:          "files",
+		Region:          "eu-west-1",
+		AccessKeyID:     "a",
+		SecretAccessKey: "b",
+	})
+	require.NoError(t, err)
+
+	u := provider.objectURL("dir/my file.txt", nil)
+	assert.Equal(t, "files.s3.eu-west-1.amazonaws.com", u.Host)
+	assert.Equal(t, "/dir/my%20file.txt", u.EscapedPath())
+}
+
+func TestS3Provider_SignIncludesSessionToken(t *testing.T) {
+	provider, err := NewS3Provider(S3Config{
+		Bucket:          "files",
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "secret",
+		SessionToken:    "token",
+	})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, provider.objectURL("a.txt", nil).String(), nil)
+	require.NoError(t, err)
+
+	provider.sign(req, nil, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	auth := req.Header.Get("Authorization")
+	assert.Contains(t, auth, "Credential=AKID/20240102/us-east-1/s3/aws4_request")
+	assert.Contains(t, auth, "SignedHeaders=host;x-amz-content-sha256;x-amz-date;x-amz-security-token")
+	assert.Equal(t, "20240102T030405Z", req.Header.Get("X-Amz-Date"))
+	assert.Equal(t, "token", req.Header.Get("X-Amz-Security-Token"))
+}
+
+func TestS3ProviderFactory_OptionsOverrideDefaults(t *testing.T) {
+	factory := NewS3ProviderFactory(S3Config{
+		Bucket:          "default-bucket",
+		AccessKeyID:     "a",
+		SecretAccessKey: "b",
+	})
+	assert.Equal(t, models.StorageTypeS3, factory.Type())
+
+	provider, err := factory.Create(&models.StorageConfig{
+		Type:    models.StorageTypeS3,
+		Options: map[string]any{"bucket": "other-bucket", "use_path_style": true},
+	})
+	require.NoError(t, err)
+
+	s3 := provider.(*S3Provider)
+	assert.Equal(t, "other-bucket", s3.config.Bucket)
+	assert.True(t, s3.config.UsePathStyle)
+}
+
+func TestStorageManager_DefaultStorageType(t *testing.T) {
+	_, srv := newFakeS3(t)
+
+	cfg := DefaultManagerConfig()
+	cfg.CleanupInterval = 0
+	cfg.StorageType = models.StorageTypeS3
+	manager := NewStorageManager(cfg, nil)
+	defer manager.Close()
+
+	manager.RegisterFactory(NewS3ProviderFactory(S3Config{
+		Bucket:          "test-bucket",
+		Endpoint:        srv.URL,
+		AccessKeyID:     "a",
+		SecretAccessKey: "b",
+		UsePathStyle:    true,
+	}))
+
+	storage, err := manager.GetDefaultStorage()
+	require.NoError(t, err)
+
+	entry, err := storage.Store(context.Background(), &models.FileEntry{Name: "a.txt", MimeType: "text/plain"}, strings.NewReader("abc"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), entry.Size)
+
+	manager.mu.RLock()
+	providerType := manager.storages["default"].provider.Type()
+	manager.mu.RUnlock()
+	assert.Equal(t, models.StorageTypeS3, providerType)
+}
@@ -3,7 +3,9 @@ package filestorage
 
 import (
 	"context"
+	"errors"
 	"io"
+	"time"
 
 	"github.com/smilemakc/mbflow/go/pkg/models"
 )
@@ -33,6 +35,16 @@ type Provider interface {
 	Close() error
 }
 
+// ErrSignedURLNotSupported is returned when the storage backend cannot issue signed URLs.
+var ErrSignedURLNotSupported = errors.New("signed URLs are not supported by this storage backend")
+
+// URLSigner is implemented by providers that can issue time-limited direct download URLs,
+// letting clients fetch large files from object storage without proxying through the API.
+type URLSigner interface {
+	// SignedURL returns a URL granting read access to the file at path until it expires
+	SignedURL(ctx context.Context, path string, expires time.Duration) (string, error)
+}
+
 // Storage is the main interface for file storage operations.
 // It uses a Provider for actual file operations and manages metadata.
 type Storage interface {
@@ -14,11 +14,14 @@ import (
 
 // YAMLWorkflow represents the top-level YAML workflow configuration.
 type YAMLWorkflow struct {
-	Metadata  YAMLMetadata   `yaml:"metadata"`
-	Variables map[string]any `yaml:"variables,omitempty"`
-	Nodes     []YAMLNode     `yaml:"nodes"`
-	Edges     []YAMLEdge     `yaml:"edges,omitempty"`
-	Trigger   *YAMLTrigger   `yaml:"trigger,omitempty"`
+	Metadata       YAMLMetadata                  `yaml:"metadata"`
+	Variables      map[string]any                `yaml:"variables,omitempty"`
+	LaunchProfiles map[string]*YAMLLaunchProfile `yaml:"launch_profiles,omitempty"`
+	Fixtures       map[string]*YAMLFixture       `yaml:"fixtures,omitempty"`
+	Nodes          []YAMLNode                    `yaml:"nodes"`
+	Edges          []YAMLEdge                    `yaml:"edges,omitempty"`
+	Trigger        *YAMLTrigger                  `yaml:"trigger,omitempty"`
+	Triggers       []YAMLTrigger                 `yaml:"triggers,omitempty"` // As written by workflow definitions
 }
 
 // YAMLMetadata represents workflow metadata in YAML.
@@ -29,6 +32,24 @@ type YAMLMetadata struct {
 	Tags        []string `yaml:"tags,omitempty"`
 }
 
+// YAMLLaunchProfile represents a named launch profile in YAML format.
+type YAMLLaunchProfile struct {
+	Description    string         `yaml:"description,omitempty"`
+	Input          map[string]any `yaml:"input,omitempty"`
+	Environment    map[string]any `yaml:"environment,omitempty"`
+	MaxParallelism int            `yaml:"max_parallelism,omitempty"`
+	TimeoutSeconds int            `yaml:"timeout_seconds,omitempty"`
+}
+
+// YAMLFixture represents a named test fixture in YAML format.
+type YAMLFixture struct {
+	Description    string         `yaml:"description,omitempty"`
+	Input          map[string]any `yaml:"input,omitempty"`
+	ExpectedStatus string         `yaml:"expected_status,omitempty"`
+	ExpectedOutput map[string]any `yaml:"expected_output,omitempty"`
+	ExpectedError  string         `yaml:"expected_error,omitempty"`
+}
+
 // YAMLNode represents a node in YAML format.
 type YAMLNode struct {
 	ID          string         `yaml:"id"`
@@ -48,12 +69,14 @@ type YAMLPosition struct {
 
 // YAMLEdge represents an edge in YAML format.
 type YAMLEdge struct {
-	ID           string         `yaml:"id"`
-	From         string         `yaml:"from"`
-	To           string         `yaml:"to"`
-	SourceHandle string         `yaml:"source_handle,omitempty"`
-	Condition    string         `yaml:"condition,omitempty"`
-	Metadata     map[string]any `yaml:"metadata,omitempty"`
+	ID           string             `yaml:"id"`
+	From         string             `yaml:"from"`
+	To           string             `yaml:"to"`
+	SourceHandle string             `yaml:"source_handle,omitempty"`
+	Condition    string             `yaml:"condition,omitempty"`
+	Type         string             `yaml:"type,omitempty"`
+	Loop         *models.LoopConfig `yaml:"loop,omitempty"`
+	Metadata     map[string]any     `yaml:"metadata,omitempty"`
 }
 
 // YAMLTrigger represents a trigger in YAML format.
@@ -70,8 +93,26 @@ type YAMLTrigger struct {
 type ImportResult struct {
 	Workflow   *models.Workflow
 	Trigger    *models.Trigger
+	Triggers   []*models.Trigger // From the triggers list, created along with Trigger
 	NodesCount int
 	EdgesCount int
+
+	// RenamedIDs lists node and edge IDs renamed to resolve collisions (old -> new).
+	RenamedIDs *models.IDRemapResult
+}
+
+// ImportOptions controls how imported node and edge IDs are reconciled with
+// the IDs of the workflow they are merged into.
+type ImportOptions struct {
+	// OnConflict selects the collision strategy; empty means models.IDConflictFail.
+	OnConflict models.IDConflictStrategy
+
+	// IDMap renames imported IDs explicitly (required for collisions with models.IDConflictMap).
+	IDMap models.IDMap
+
+	// ExistingNodeIDs and ExistingEdgeIDs are the IDs already used by the target workflow.
+	ExistingNodeIDs map[string]bool
+	ExistingEdgeIDs map[string]bool
 }
 
 // ValidationError represents a validation error with context.
@@ -98,6 +139,13 @@ func NewYAMLImporter(executorManager executor.Manager) *YAMLImporter {
 
 // ImportFromYAML parses YAML data and converts it to domain models.
 func (i *YAMLImporter) ImportFromYAML(data []byte) (*ImportResult, error) {
+	return i.ImportFromYAMLWithOptions(data, ImportOptions{})
+}
+
+// ImportFromYAMLWithOptions parses YAML data and converts it to domain models,
+// renaming node and edge IDs according to opts. References to renamed nodes in
+// edges, edge conditions and node config templates are rewritten accordingly.
+func (i *YAMLImporter) ImportFromYAMLWithOptions(data []byte, opts ImportOptions) (*ImportResult, error) {
 	var yamlWorkflow YAMLWorkflow
 	if err := yaml.Unmarshal(data, &yamlWorkflow); err != nil {
 		return nil, fmt.Errorf("failed to parse YAML: %w", err)
@@ -110,9 +158,23 @@ func (i *YAMLImporter) ImportFromYAML(data []byte) (*ImportResult, error) {
 
 	// Convert to domain models
 	workflow := i.convertToWorkflow(&yamlWorkflow)
+	renamed, err := workflow.RemapIDs(models.IDRemapOptions{
+		Strategy:        opts.OnConflict,
+		Mapping:         opts.IDMap,
+		ReservedNodeIDs: opts.ExistingNodeIDs,
+		ReservedEdgeIDs: opts.ExistingEdgeIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ID resolution failed: %w", err)
+	}
+
 	var trigger *models.Trigger
 	if yamlWorkflow.Trigger != nil {
-		trigger = i.convertToTrigger(&yamlWorkflow, workflow.ID)
+		trigger = i.convertToTrigger(yamlWorkflow.Trigger, workflow.ID)
+	}
+	var triggers []*models.Trigger
+	for idx := range yamlWorkflow.Triggers {
+		triggers = append(triggers, i.convertToTrigger(&yamlWorkflow.Triggers[idx], workflow.ID))
 	}
 
 	// Validate domain models
@@ -125,12 +187,19 @@ func (i *YAMLImporter) ImportFromYAML(data []byte) (*ImportResult, error) {
 			return nil, fmt.Errorf("trigger validation failed: %w", err)
 		}
 	}
+	for idx, t := range triggers {
+		if err := t.Validate(); err != nil {
+			return nil, fmt.Errorf("triggers[%d] validation failed: %w", idx, err)
+		}
+	}
 
 	return &ImportResult{
 		Workflow:   workflow,
 		Trigger:    trigger,
+		Triggers:   triggers,
 		NodesCount: len(workflow.Nodes),
 		EdgesCount: len(workflow.Edges),
+		RenamedIDs: renamed,
 	}, nil
 }
 
@@ -238,28 +307,44 @@ func (i *YAMLImporter) validateYAML(y *YAMLWorkflow) error {
 		}
 	}
 
-	// Validate trigger if present
+	// Validate triggers if present
 	if y.Trigger != nil {
-		if y.Trigger.Name == "" {
-			return &ValidationError{Field: "trigger.name", Message: "trigger name is required"}
+		if err := validateYAMLTrigger("trigger", y.Trigger); err != nil {
+			return err
 		}
-
-		if y.Trigger.Type == "" {
-			return &ValidationError{Field: "trigger.type", Message: "trigger type is required"}
+	}
+	for idx := range y.Triggers {
+		if err := validateYAMLTrigger(fmt.Sprintf("triggers[%d]", idx), &y.Triggers[idx]); err != nil {
+			return err
 		}
+	}
 
-		validTriggerTypes := map[string]bool{
-			"manual":   true,
-			"cron":     true,
-			"webhook":  true,
-			"event":    true,
-			"interval": true,
-		}
-		if !validTriggerTypes[y.Trigger.Type] {
-			return &ValidationError{
-				Field:   "trigger.type",
-				Message: fmt.Sprintf("invalid trigger type: %s", y.Trigger.Type),
-			}
+	return nil
+}
+
+// validateYAMLTrigger validates a trigger of the YAML structure; field is its path.
+func validateYAMLTrigger(field string, t *YAMLTrigger) error {
+	if t.Name == "" {
+		return &ValidationError{Field: field + ".name", Message: "trigger name is required"}
+	}
+
+	if t.Type == "" {
+		return &ValidationError{Field: field + ".type", Message: "trigger type is required"}
+	}
+
+	validTriggerTypes := map[string]bool{
+		"manual":   true,
+		"cron":     true,
+		"webhook":  true,
+		"event":    true,
+		"interval": true,
+		"email":    true,
+		"slack":    true,
+	}
+	if !validTriggerTypes[t.Type] {
+		return &ValidationError{
+			Field:   field + ".type",
+			Message: fmt.Sprintf("invalid trigger type: %s", t.Type),
 		}
 	}
 
@@ -290,6 +375,38 @@ func (i *YAMLImporter) convertToWorkflow(y *YAMLWorkflow) *models.Workflow {
 		UpdatedAt:   now,
 	}
 
+	if len(y.LaunchProfiles) > 0 {
+		workflow.LaunchProfiles = make(map[string]*models.LaunchProfile, len(y.LaunchProfiles))
+		for name, p := range y.LaunchProfiles {
+			if p == nil {
+				p = &YAMLLaunchProfile{}
+			}
+			workflow.LaunchProfiles[name] = &models.LaunchProfile{
+				Description:    p.Description,
+				Input:          p.Input,
+				Environment:    p.Environment,
+				MaxParallelism: p.MaxParallelism,
+				TimeoutSeconds: p.TimeoutSeconds,
+			}
+		}
+	}
+
+	if len(y.Fixtures) > 0 {
+		workflow.Fixtures = make(map[string]*models.WorkflowFixture, len(y.Fixtures))
+		for name, f := range y.Fixtures {
+			if f == nil {
+				f = &YAMLFixture{}
+			}
+			workflow.Fixtures[name] = &models.WorkflowFixture{
+				Description:    f.Description,
+				Input:          f.Input,
+				ExpectedStatus: models.ExecutionStatus(f.ExpectedStatus),
+				ExpectedOutput: f.ExpectedOutput,
+				ExpectedError:  f.ExpectedError,
+			}
+		}
+	}
+
 	// Convert nodes
 	for _, yamlNode := range y.Nodes {
 		node := &models.Node{
@@ -320,6 +437,8 @@ func (i *YAMLImporter) convertToWorkflow(y *YAMLWorkflow) *models.Workflow {
 			To:           yamlEdge.To,
 			SourceHandle: yamlEdge.SourceHandle,
 			Condition:    yamlEdge.Condition,
+			Type:         models.EdgeType(yamlEdge.Type),
+			Loop:         yamlEdge.Loop,
 			Metadata:     yamlEdge.Metadata,
 		}
 		workflow.Edges = append(workflow.Edges, edge)
@@ -329,18 +448,14 @@ func (i *YAMLImporter) convertToWorkflow(y *YAMLWorkflow) *models.Workflow {
 }
 
 // convertToTrigger converts YAML trigger to domain Trigger.
-func (i *YAMLImporter) convertToTrigger(y *YAMLWorkflow, workflowID string) *models.Trigger {
-	if y.Trigger == nil {
-		return nil
-	}
-
+func (i *YAMLImporter) convertToTrigger(y *YAMLTrigger, workflowID string) *models.Trigger {
 	now := time.Now()
 	enabled := true
-	if y.Trigger.Enabled != nil {
-		enabled = *y.Trigger.Enabled
+	if y.Enabled != nil {
+		enabled = *y.Enabled
 	}
 
-	config := y.Trigger.Config
+	config := y.Config
 	if config == nil {
 		config = make(map[string]any)
 	}
@@ -348,12 +463,12 @@ func (i *YAMLImporter) convertToTrigger(y *YAMLWorkflow, workflowID string) *mod
 	return &models.Trigger{
 		ID:          uuid.New().String(),
 		WorkflowID:  workflowID,
-		Name:        y.Trigger.Name,
-		Description: y.Trigger.Description,
-		Type:        models.TriggerType(y.Trigger.Type),
+		Name:        y.Name,
+		Description: y.Description,
+		Type:        models.TriggerType(y.Type),
 		Config:      config,
 		Enabled:     enabled,
-		Metadata:    y.Trigger.Metadata,
+		Metadata:    y.Metadata,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}
@@ -379,6 +494,32 @@ func (i *YAMLImporter) convertFromWorkflow(workflow *models.Workflow, trigger *m
 		Edges:     make([]YAMLEdge, 0, len(workflow.Edges)),
 	}
 
+	if len(workflow.LaunchProfiles) > 0 {
+		y.LaunchProfiles = make(map[string]*YAMLLaunchProfile, len(workflow.LaunchProfiles))
+		for name, p := range workflow.LaunchProfiles {
+			y.LaunchProfiles[name] = &YAMLLaunchProfile{
+				Description:    p.Description,
+				Input:          p.Input,
+				Environment:    p.Environment,
+				MaxParallelism: p.MaxParallelism,
+				TimeoutSeconds: p.TimeoutSeconds,
+			}
+		}
+	}
+
+	if len(workflow.Fixtures) > 0 {
+		y.Fixtures = make(map[string]*YAMLFixture, len(workflow.Fixtures))
+		for name, f := range workflow.Fixtures {
+			y.Fixtures[name] = &YAMLFixture{
+				Description:    f.Description,
+				Input:          f.Input,
+				ExpectedStatus: string(f.ExpectedStatus),
+				ExpectedOutput: f.ExpectedOutput,
+				ExpectedError:  f.ExpectedError,
+			}
+		}
+	}
+
 	// Convert nodes
 	for _, node := range workflow.Nodes {
 		yamlNode := YAMLNode{
@@ -406,6 +547,8 @@ func (i *YAMLImporter) convertFromWorkflow(workflow *models.Workflow, trigger *m
 			To:           edge.To,
 			SourceHandle: edge.SourceHandle,
 			Condition:    edge.Condition,
+			Type:         string(edge.Type),
+			Loop:         edge.Loop,
 			Metadata:     edge.Metadata,
 		}
 		y.Edges = append(y.Edges, yamlEdge)
@@ -194,6 +194,118 @@ nodes:
 	assert.Equal(t, 30, workflow.Variables["timeout"])
 }
 
+func TestYAMLImporter_ImportFromYAML_WithLaunchProfiles(t *testing.T) {
+	yaml := `
+metadata:
+  name: "Workflow with Profiles"
+
+launch_profiles:
+  nightly:
+    description: "Full nightly sync"
+    input:
+      mode: full
+    environment:
+      region: eu-west-1
+    max_parallelism: 4
+    timeout_seconds: 3600
+
+nodes:
+  - id: request
+    name: "API Request"
+    type: http
+    config:
+      url: "https://api.example.com/sync"
+`
+
+	manager := newMockExecutorManager("http")
+	importer := NewYAMLImporter(manager)
+
+	result, err := importer.ImportFromYAML([]byte(yaml))
+	require.NoError(t, err)
+
+	profile, err := result.Workflow.GetLaunchProfile("nightly")
+	require.NoError(t, err)
+	assert.Equal(t, "Full nightly sync", profile.Description)
+	assert.Equal(t, "full", profile.Input["mode"])
+	assert.Equal(t, "eu-west-1", profile.Environment["region"])
+	assert.Equal(t, 4, profile.MaxParallelism)
+	assert.Equal(t, 3600, profile.TimeoutSeconds)
+
+	exported, err := importer.ExportToYAML(result.Workflow, nil)
+	require.NoError(t, err)
+	assert.Contains(t, string(exported), "launch_profiles:")
+	assert.Contains(t, string(exported), "max_parallelism: 4")
+
+	_, err = importer.ImportFromYAML([]byte(`
+metadata:
+  name: "Bad Profile"
+launch_profiles:
+  "night run": {}
+nodes:
+  - id: request
+    name: "API Request"
+    type: http
+`))
+	assert.Error(t, err)
+}
+
+func TestYAMLImporter_ImportFromYAML_WithFixtures(t *testing.T) {
+	yaml := `
+metadata:
+  name: "Workflow with Fixtures"
+
+fixtures:
+  happy_path:
+    description: "Known customer"
+    input:
+      customer_id: 42
+    expected_output:
+      status: 200
+  missing_customer:
+    input:
+      customer_id: 0
+    expected_status: failed
+    expected_error: "not found"
+
+nodes:
+  - id: request
+    name: "API Request"
+    type: http
+    config:
+      url: "https://api.example.com/customers/{{input.customer_id}}"
+`
+
+	manager := newMockExecutorManager("http")
+	importer := NewYAMLImporter(manager)
+
+	result, err := importer.ImportFromYAML([]byte(yaml))
+	require.NoError(t, err)
+
+	fixture, err := result.Workflow.GetFixture("missing_customer")
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionStatusFailed, fixture.ExpectedStatus)
+	assert.Equal(t, "not found", fixture.ExpectedError)
+	assert.Equal(t, 42, result.Workflow.Fixtures["happy_path"].Input["customer_id"])
+
+	exported, err := importer.ExportToYAML(result.Workflow, nil)
+	require.NoError(t, err)
+	assert.Contains(t, string(exported), "fixtures:")
+	assert.Contains(t, string(exported), "expected_status: failed")
+
+	_, err = importer.ImportFromYAML([]byte(`
+metadata:
+  name: "Bad Fixture"
+fixtures:
+  smoke:
+    expected_status: running
+nodes:
+  - id: request
+    name: "API Request"
+    type: http
+`))
+	assert.Error(t, err)
+}
+
 func TestYAMLImporter_ImportFromYAML_ValidationErrors(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -650,3 +762,127 @@ trigger:
 	// Check default status
 	assert.Equal(t, models.WorkflowStatusDraft, result.Workflow.Status)
 }
+
+func TestYAMLImporter_ImportFromYAMLWithOptions(t *testing.T) {
+	yaml := `
+metadata:
+  name: "Fragment"
+nodes:
+  - id: fetch
+    name: "Fetch"
+    type: http
+  - id: parse
+    name: "Parse"
+    type: transform
+    config:
+      expression: "{{input.fetch.body}}"
+edges:
+  - id: e1
+    from: fetch
+    to: parse
+    condition: "output.fetch.status == 200"
+`
+	manager := newMockExecutorManager("http", "transform")
+	existing := ImportOptions{
+		ExistingNodeIDs: map[string]bool{"fetch": true},
+		ExistingEdgeIDs: map[string]bool{"e1": true},
+	}
+
+	t.Run("fail by default", func(t *testing.T) {
+		_, err := NewYAMLImporter(manager).ImportFromYAMLWithOptions([]byte(yaml), existing)
+
+		var conflictErr *models.IDConflictError
+		require.ErrorAs(t, err, &conflictErr)
+		assert.Equal(t, "fetch", conflictErr.ID)
+	})
+
+	t.Run("suffix", func(t *testing.T) {
+		opts := existing
+		opts.OnConflict = models.IDConflictSuffix
+
+		result, err := NewYAMLImporter(manager).ImportFromYAMLWithOptions([]byte(yaml), opts)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]string{"fetch": "fetch_2"}, result.RenamedIDs.Nodes)
+		assert.Equal(t, map[string]string{"e1": "e1_2"}, result.RenamedIDs.Edges)
+		assert.Equal(t, "fetch_2", result.Workflow.Nodes[0].ID)
+		assert.Equal(t, "{{input.fetch_2.body}}", result.Workflow.Nodes[1].Config["expression"])
+
+		edge := result.Workflow.Edges[0]
+		assert.Equal(t, "e1_2", edge.ID)
+		assert.Equal(t, "fetch_2", edge.From)
+		assert.Equal(t, "output.fetch_2.status == 200", edge.Condition)
+	})
+
+	t.Run("map", func(t *testing.T) {
+		opts := existing
+		opts.OnConflict = models.IDConflictMap
+		opts.IDMap = models.IDMap{
+			Nodes: map[string]string{"fetch": "fetch_orders"},
+			Edges: map[string]string{"e1": "orders_to_parse"},
+		}
+
+		result, err := NewYAMLImporter(manager).ImportFromYAMLWithOptions([]byte(yaml), opts)
+		require.NoError(t, err)
+
+		assert.Equal(t, "fetch_orders", result.Workflow.Edges[0].From)
+		assert.Equal(t, "orders_to_parse", result.Workflow.Edges[0].ID)
+	})
+}
+
+func TestYAMLImporter_ImportWorkflowDefinition(t *testing.T) {
+	definition, err := models.MarshalWorkflowYAML(&models.Workflow{
+		Name: "Review Loop",
+		Nodes: []*models.Node{
+			{ID: "review", Name: "Review", Type: "llm", Config: map[string]any{}},
+			{ID: "improve", Name: "Improve", Type: "llm", Config: map[string]any{}},
+		},
+		Edges: []*models.Edge{
+			{ID: "e1", From: "review", To: "improve", Condition: "output.score < 80"},
+			{ID: "e2", From: "improve", To: "review", Loop: &models.LoopConfig{MaxIterations: 3}},
+		},
+		Triggers: []*models.Trigger{
+			{Name: "Daily", Type: models.TriggerTypeCron, Config: map[string]any{"schedule": "0 9 * * *"}, Enabled: true},
+			{Name: "Run now", Type: models.TriggerTypeManual, Config: map[string]any{}},
+		},
+	})
+	require.NoError(t, err)
+
+	importer := NewYAMLImporter(newMockExecutorManager("llm"))
+	result, err := importer.ImportFromYAML(definition)
+	require.NoError(t, err)
+	require.Len(t, result.Workflow.Edges, 2)
+	require.NotNil(t, result.Workflow.Edges[1].Loop)
+	assert.Equal(t, 3, result.Workflow.Edges[1].Loop.MaxIterations)
+
+	assert.Nil(t, result.Trigger)
+	require.Len(t, result.Triggers, 2)
+	assert.Equal(t, result.Workflow.ID, result.Triggers[0].WorkflowID)
+	assert.Equal(t, "0 9 * * *", result.Triggers[0].Config["schedule"])
+	assert.True(t, result.Triggers[0].Enabled)
+	assert.False(t, result.Triggers[1].Enabled)
+
+	exported, err := importer.ExportToYAML(result.Workflow, nil)
+	require.NoError(t, err)
+	assert.Contains(t, string(exported), "max_iterations: 3")
+}
+
+func TestYAMLImporter_ImportFromYAML_InvalidTriggersEntry(t *testing.T) {
+	yaml := `
+metadata:
+  name: "Scheduled Workflow"
+nodes:
+  - id: start
+    name: "Start"
+    type: http
+triggers:
+  - name: "Daily"
+    type: cron
+  - name: "Sometimes"
+    type: unknown
+`
+	importer := NewYAMLImporter(newMockExecutorManager("http"))
+	_, err := importer.ImportFromYAML([]byte(yaml))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "triggers[1].type")
+}
@@ -0,0 +1,135 @@
+// Package inbox aggregates the work awaiting human action across executions into one
+// queue, the work inbox, so operators do not have to poll every execution.
+// Each kind of work is provided by a Source.
+package inbox
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// DefaultPageSize is the page size used when the filter sets no limit.
+const DefaultPageSize = 50
+
+// Source lists the inbox items of one kind matching a filter, newest first, with their total count.
+type Source interface {
+	Kind() models.InboxItemKind
+	List(ctx context.Context, filter models.InboxFilter) ([]*models.InboxItem, int, error)
+}
+
+// Service merges the items of all sources into one queue.
+type Service struct {
+	sources []Source
+}
+
+// NewService creates a new inbox service over the given sources.
+func NewService(sources ...Source) *Service {
+	return &Service{sources: sources}
+}
+
+// List returns one page of the inbox items matching the filter, newest first, and the
+// total count of matching items.
+func (s *Service) List(ctx context.Context, filter models.InboxFilter) ([]*models.InboxItem, int, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultPageSize
+	}
+	if filter.Limit > models.MaxInboxPageSize {
+		filter.Limit = models.MaxInboxPageSize
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	// The newest offset+limit items of every source are enough to build the merged page
+	sourceFilter := filter
+	sourceFilter.Offset = 0
+	sourceFilter.Limit = filter.Offset + filter.Limit
+
+	var items []*models.InboxItem
+	total := 0
+	for _, source := range s.sources {
+		if !filter.Includes(source.Kind()) {
+			continue
+		}
+		sourceItems, sourceTotal, err := source.List(ctx, sourceFilter)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to list %s inbox items: %w", source.Kind(), err)
+		}
+		items = append(items, sourceItems...)
+		total += sourceTotal
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].CreatedAt.After(items[j].CreatedAt)
+	})
+
+	if filter.Offset >= len(items) {
+		return []*models.InboxItem{}, total, nil
+	}
+	end := filter.Offset + filter.Limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[filter.Offset:end], total, nil
+}
+
+// failedExecutions lists failed executions awaiting review or a re-run.
+type failedExecutions struct {
+	repo repository.InboxRepository
+}
+
+// FailedExecutions returns the source of failed executions that no later execution of their
+// workflow completed. They are assigned to the owner of the workflow.
+func FailedExecutions(repo repository.InboxRepository) Source {
+	return &failedExecutions{repo: repo}
+}
+
+func (s *failedExecutions) Kind() models.InboxItemKind {
+	return models.InboxItemFailedExecution
+}
+
+func (s *failedExecutions) List(ctx context.Context, filter models.InboxFilter) ([]*models.InboxItem, int, error) {
+	return s.repo.ListFailedExecutions(ctx, filter)
+}
+
+// pendingApprovals lists approval nodes waiting for a decision.
+type pendingApprovals struct {
+	repo repository.InboxRepository
+}
+
+// PendingApprovals returns the source of the approval nodes of paused executions waiting for
+// a decision. They are assigned to the owner of the workflow and list their approvers.
+func PendingApprovals(repo repository.InboxRepository) Source {
+	return &pendingApprovals{repo: repo}
+}
+
+func (s *pendingApprovals) Kind() models.InboxItemKind {
+	return models.InboxItemPendingApproval
+}
+
+func (s *pendingApprovals) List(ctx context.Context, filter models.InboxFilter) ([]*models.InboxItem, int, error) {
+	return s.repo.ListPendingApprovals(ctx, filter)
+}
+
+// breakpoints lists debug executions stopped at breakpoints.
+type breakpoints struct {
+	repo repository.InboxRepository
+}
+
+// Breakpoints returns the source of paused executions stopped at breakpoints, waiting to be
+// continued or aborted. They are assigned to the owner of the workflow.
+func Breakpoints(repo repository.InboxRepository) Source {
+	return &breakpoints{repo: repo}
+}
+
+func (s *breakpoints) Kind() models.InboxItemKind {
+	return models.InboxItemBreakpoint
+}
+
+func (s *breakpoints) List(ctx context.Context, filter models.InboxFilter) ([]*models.InboxItem, int, error) {
+	return s.repo.ListBreakpoints(ctx, filter)
+}
@@ -0,0 +1,97 @@
+package inbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSource returns its items newest first, honoring the limit like a repository would.
+type fakeSource struct {
+	kind    models.InboxItemKind
+	items   []*models.InboxItem
+	err     error
+	filters []models.InboxFilter
+}
+
+func (s *fakeSource) Kind() models.InboxItemKind { return s.kind }
+
+func (s *fakeSource) List(ctx context.Context, filter models.InboxFilter) ([]*models.InboxItem, int, error) {
+	s.filters = append(s.filters, filter)
+	if s.err != nil {
+		return nil, 0, s.err
+	}
+	items := s.items
+	if len(items) > filter.Limit {
+		items = items[:filter.Limit]
+	}
+	return items, len(s.items), nil
+}
+
+func inboxItem(kind models.InboxItemKind, executionID string, createdAt time.Time) *models.InboxItem {
+	return &models.InboxItem{Kind: kind, ExecutionID: executionID, CreatedAt: createdAt}
+}
+
+func TestService_ListMergesSourcesNewestFirst(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	failed := &fakeSource{kind: models.InboxItemFailedExecution, items: []*models.InboxItem{
+		inboxItem(models.InboxItemFailedExecution, "f1", now.Add(-time.Minute)),
+		inboxItem(models.InboxItemFailedExecution, "f2", now.Add(-3*time.Minute)),
+	}}
+	other := &fakeSource{kind: "other", items: []*models.InboxItem{
+		inboxItem("other", "o1", now),
+		inboxItem("other", "o2", now.Add(-2*time.Minute)),
+	}}
+	svc := NewService(failed, other)
+
+	items, total, err := svc.List(context.Background(), models.InboxFilter{Assignee: "user-1", Limit: 2, Offset: 1})
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, total)
+	require.Len(t, items, 2)
+	assert.Equal(t, "f1", items[0].ExecutionID)
+	assert.Equal(t, "o2", items[1].ExecutionID)
+
+	// Sources are asked for enough items to build the page, with the caller's filters
+	require.Len(t, failed.filters, 1)
+	assert.Equal(t, 0, failed.filters[0].Offset)
+	assert.Equal(t, 3, failed.filters[0].Limit)
+	assert.Equal(t, "user-1", failed.filters[0].Assignee)
+}
+
+func TestService_ListFiltersKinds(t *testing.T) {
+	failed := &fakeSource{kind: models.InboxItemFailedExecution}
+	other := &fakeSource{kind: "other"}
+	svc := NewService(failed, other)
+
+	_, _, err := svc.List(context.Background(), models.InboxFilter{Kinds: []models.InboxItemKind{models.InboxItemFailedExecution}})
+	require.NoError(t, err)
+
+	assert.Len(t, failed.filters, 1)
+	assert.Empty(t, other.filters)
+	assert.Equal(t, DefaultPageSize, failed.filters[0].Limit)
+}
+
+func TestService_ListPastEnd(t *testing.T) {
+	failed := &fakeSource{kind: models.InboxItemFailedExecution, items: []*models.InboxItem{
+		inboxItem(models.InboxItemFailedExecution, "f1", time.Now()),
+	}}
+
+	items, total, err := NewService(failed).List(context.Background(), models.InboxFilter{Offset: 5})
+	require.NoError(t, err)
+	assert.Empty(t, items)
+	assert.NotNil(t, items)
+	assert.Equal(t, 1, total)
+}
+
+func TestService_ListSourceError(t *testing.T) {
+	failed := &fakeSource{kind: models.InboxItemFailedExecution, err: models.ErrInvalidWorkflowID}
+
+	_, _, err := NewService(failed).List(context.Background(), models.InboxFilter{})
+	assert.True(t, errors.Is(err, models.ErrInvalidWorkflowID))
+}
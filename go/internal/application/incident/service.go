@@ -0,0 +1,356 @@
+// Package incident links workflows to runbooks and incident-tracker projects and
+// opens incidents, through the issue_tracker executor, when a canary alert fires or
+// a workflow fails repeatedly. Every incident is stored with the execution that
+// caused it so that failures can be reviewed from either side.
+package incident
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/smilemakc/mbflow/go/internal/application/canary"
+	"github.com/smilemakc/mbflow/go/internal/application/observer"
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// issueTrackerNodeType is the executor used to open incidents.
+const issueTrackerNodeType = "issue_tracker"
+
+// WorkflowFinder looks up stored workflows.
+type WorkflowFinder interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*storagemodels.WorkflowModel, error)
+}
+
+// ExecutionLister lists the most recent executions of a workflow, newest first.
+type ExecutionLister interface {
+	FindByWorkflowID(ctx context.Context, workflowID uuid.UUID, limit, offset int) ([]*storagemodels.ExecutionModel, error)
+}
+
+// OpenRequest describes an incident to open.
+type OpenRequest struct {
+	WorkflowID  string
+	ExecutionID string
+	Source      models.IncidentSource
+	Title       string
+	Details     string
+	// RunbookURL overrides the runbook of the incident policy
+	RunbookURL string
+	CreatedBy  string
+}
+
+// Service manages incident policies and opens incidents.
+type Service struct {
+	repo       repository.IncidentRepository
+	workflows  WorkflowFinder
+	executions ExecutionLister
+	executors  executor.Manager
+	logger     *logger.Logger
+
+	// mu serializes the repeated-failure check so that concurrent failures of a
+	// workflow open at most one incident per failure streak
+	mu sync.Mutex
+}
+
+// NewService creates a new incident service.
+func NewService(
+	repo repository.IncidentRepository,
+	workflows WorkflowFinder,
+	executions ExecutionLister,
+	executors executor.Manager,
+	log *logger.Logger,
+) *Service {
+	return &Service{
+		repo:       repo,
+		workflows:  workflows,
+		executions: executions,
+		executors:  executors,
+		logger:     log,
+	}
+}
+
+// GetPolicy returns the incident policy of a workflow.
+func (s *Service) GetPolicy(ctx context.Context, workflowID string) (*models.IncidentPolicy, error) {
+	return s.repo.GetPolicy(ctx, workflowID)
+}
+
+// SavePolicy creates or replaces the incident policy of a workflow.
+func (s *Service) SavePolicy(ctx context.Context, policy *models.IncidentPolicy) (*models.IncidentPolicy, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	workflowID, err := uuid.Parse(policy.WorkflowID)
+	if err != nil {
+		return nil, models.ErrInvalidWorkflowID
+	}
+	workflow, err := s.workflows.FindByID(ctx, workflowID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrWorkflowNotFound
+		}
+		return nil, fmt.Errorf("failed to load workflow: %w", err)
+	}
+
+	policy.WorkflowName = workflow.Name
+	if err := s.repo.SavePolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// DeletePolicy removes the incident policy of a workflow; its incidents are kept.
+func (s *Service) DeletePolicy(ctx context.Context, workflowID string) error {
+	return s.repo.DeletePolicy(ctx, workflowID)
+}
+
+// ListByExecution returns the incidents linked to an execution.
+func (s *Service) ListByExecution(ctx context.Context, executionID string) ([]*models.Incident, error) {
+	return s.repo.ListByExecutionID(ctx, executionID)
+}
+
+// ListByWorkflow returns the most recent incidents of a workflow.
+func (s *Service) ListByWorkflow(ctx context.Context, workflowID string, limit int) ([]*models.Incident, error) {
+	return s.repo.ListByWorkflowID(ctx, workflowID, limit)
+}
+
+// Open opens an incident in the tracker of the workflow's incident policy and links it
+// to the execution. It returns models.ErrIncidentTrackerNotConfigured when the workflow
+// has no policy or its policy has no tracker.
+func (s *Service) Open(ctx context.Context, req OpenRequest) (*models.Incident, error) {
+	policy, err := s.repo.GetPolicy(ctx, req.WorkflowID)
+	if err != nil {
+		if errors.Is(err, models.ErrIncidentPolicyNotFound) {
+			return nil, models.ErrIncidentTrackerNotConfigured
+		}
+		return nil, err
+	}
+	if !policy.HasTracker() {
+		return nil, models.ErrIncidentTrackerNotConfigured
+	}
+	return s.open(ctx, policy, req)
+}
+
+// Name returns the alert sink and observer name.
+func (s *Service) Name() string { return "incident" }
+
+// Send opens an incident when a canary starts failing and its workflow's policy asks for it.
+// It implements canary.AlertSink.
+func (s *Service) Send(ctx context.Context, alert canary.Alert) error {
+	if alert.Type != canary.AlertTypeFailing {
+		return nil
+	}
+
+	policy, err := s.repo.GetPolicy(ctx, alert.WorkflowID)
+	if errors.Is(err, models.ErrIncidentPolicyNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !policy.HasTracker() || !policy.OnCanaryAlert {
+		return nil
+	}
+
+	details := alert.Message
+	if len(alert.Failures) > 0 {
+		details += "\n\nFailed assertions:\n- " + strings.Join(alert.Failures, "\n- ")
+	}
+
+	_, err = s.open(ctx, policy, OpenRequest{
+		WorkflowID:  alert.WorkflowID,
+		ExecutionID: alert.ExecutionID,
+		Source:      models.IncidentSourceCanaryAlert,
+		Title:       alert.Message,
+		Details:     details,
+		RunbookURL:  alert.RunbookURL,
+	})
+	return err
+}
+
+// Filter limits the observer to failed executions.
+func (s *Service) Filter() observer.EventFilter {
+	return observer.NewEventTypeFilter(observer.EventTypeExecutionFailed)
+}
+
+// OnEvent opens an incident when a workflow has failed FailureThreshold times in a row.
+// The incident is opened once per failure streak, on the failure that reaches the threshold.
+func (s *Service) OnEvent(ctx context.Context, event observer.Event) error {
+	if event.Type != observer.EventTypeExecutionFailed || event.WorkflowID == "" {
+		return nil
+	}
+
+	policy, err := s.repo.GetPolicy(ctx, event.WorkflowID)
+	if errors.Is(err, models.ErrIncidentPolicyNotFound) || errors.Is(err, models.ErrInvalidWorkflowID) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !policy.HasTracker() || policy.FailureThreshold <= 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	streak, err := s.failureStreak(ctx, event.WorkflowID, event.ExecutionID, policy.FailureThreshold)
+	if err != nil {
+		return err
+	}
+	if streak != policy.FailureThreshold {
+		return nil
+	}
+
+	details := fmt.Sprintf("The workflow failed %d times in a row.", streak)
+	if event.Error != nil {
+		details += "\n\nLast error: " + event.Error.Error()
+	}
+
+	_, err = s.open(ctx, policy, OpenRequest{
+		WorkflowID:  event.WorkflowID,
+		ExecutionID: event.ExecutionID,
+		Source:      models.IncidentSourceRepeatedFailures,
+		Title:       fmt.Sprintf("Workflow %q failed %d times in a row", workflowLabel(policy), streak),
+		Details:     details,
+	})
+	return err
+}
+
+// failureStreak counts the consecutive failed executions of a workflow ending with
+// executionID, looking at no more than threshold+1 finished executions. Executions
+// that are still pending or running are ignored.
+func (s *Service) failureStreak(ctx context.Context, workflowID, executionID string, threshold int) (int, error) {
+	id, err := uuid.Parse(workflowID)
+	if err != nil {
+		return 0, models.ErrInvalidWorkflowID
+	}
+
+	// Executions still in progress are skipped, so fetch a margin beyond the threshold.
+	recent, err := s.executions.FindByWorkflowID(ctx, id, threshold*2+10, 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list executions: %w", err)
+	}
+
+	// The failed execution counts even if it is not in the list yet.
+	streak := 1
+	for _, exec := range recent {
+		if exec.ID.String() == executionID {
+			continue
+		}
+		if exec.IsPending() || exec.IsRunning() {
+			continue
+		}
+		if !exec.IsFailed() || streak > threshold {
+			break
+		}
+		streak++
+	}
+	return streak, nil
+}
+
+// open creates the issue in the tracker and stores the incident.
+func (s *Service) open(ctx context.Context, policy *models.IncidentPolicy, req OpenRequest) (*models.Incident, error) {
+	tracker, err := s.executors.Get(issueTrackerNodeType)
+	if err != nil {
+		return nil, fmt.Errorf("issue tracker executor not available: %w", err)
+	}
+
+	runbookURL := req.RunbookURL
+	if runbookURL == "" {
+		runbookURL = policy.RunbookURL
+	}
+
+	title := req.Title
+	if title == "" {
+		title = fmt.Sprintf("Incident in workflow %q", workflowLabel(policy))
+	}
+
+	config := map[string]any{
+		"provider":      policy.Tracker,
+		"credential_id": policy.CredentialID,
+		"project":       policy.Project,
+		"title":         title,
+		"body":          issueBody(policy, req, runbookURL),
+	}
+	if policy.BaseURL != "" {
+		config["base_url"] = policy.BaseURL
+	}
+	if len(policy.Labels) > 0 {
+		config["labels"] = policy.Labels
+	}
+	if policy.IssueType != "" {
+		config["issue_type"] = policy.IssueType
+	}
+
+	result, err := tracker.Execute(ctx, config, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open incident: %w", err)
+	}
+	output, _ := result.(map[string]any)
+
+	incident := &models.Incident{
+		WorkflowID:  req.WorkflowID,
+		ExecutionID: req.ExecutionID,
+		Source:      req.Source,
+		Title:       title,
+		Tracker:     policy.Tracker,
+		Project:     policy.Project,
+		ExternalID:  stringValue(output["id"]),
+		Key:         stringValue(output["key"]),
+		URL:         stringValue(output["url"]),
+		RunbookURL:  runbookURL,
+		CreatedBy:   req.CreatedBy,
+	}
+	if err := s.repo.Create(ctx, incident); err != nil {
+		// The issue exists in the tracker; log it so it can be linked by hand.
+		s.logger.Error("Failed to store incident", "workflow_id", req.WorkflowID, "execution_id", req.ExecutionID,
+			"url", incident.URL, "error", err)
+		return nil, err
+	}
+
+	s.logger.Info("Incident opened", "incident_id", incident.ID, "workflow_id", incident.WorkflowID,
+		"execution_id", incident.ExecutionID, "source", incident.Source, "url", incident.URL)
+	return incident, nil
+}
+
+// issueBody builds the description of the issue: the details followed by links for the review.
+func issueBody(policy *models.IncidentPolicy, req OpenRequest, runbookURL string) string {
+	var b strings.Builder
+	if req.Details != "" {
+		b.WriteString(req.Details)
+		b.WriteString("\n\n")
+	}
+	fmt.Fprintf(&b, "Workflow: %s (%s)\n", workflowLabel(policy), req.WorkflowID)
+	if req.ExecutionID != "" {
+		fmt.Fprintf(&b, "Execution: %s\n", req.ExecutionID)
+	}
+	if runbookURL != "" {
+		fmt.Fprintf(&b, "Runbook: %s\n", runbookURL)
+	}
+	fmt.Fprintf(&b, "Source: %s\n", req.Source)
+	return b.String()
+}
+
+func workflowLabel(policy *models.IncidentPolicy) string {
+	if policy.WorkflowName != "" {
+		return policy.WorkflowName
+	}
+	return policy.WorkflowID
+}
+
+func stringValue(v any) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,311 @@
+package incident
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smilemakc/mbflow/go/internal/application/canary"
+	"github.com/smilemakc/mbflow/go/internal/application/observer"
+	"github.com/smilemakc/mbflow/go/internal/config"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+type mockIncidentRepo struct {
+	mu        sync.Mutex
+	policies  map[string]*models.IncidentPolicy
+	incidents []*models.Incident
+}
+
+func newMockIncidentRepo() *mockIncidentRepo {
+	return &mockIncidentRepo{policies: make(map[string]*models.IncidentPolicy)}
+}
+
+func (m *mockIncidentRepo) SavePolicy(ctx context.Context, policy *models.IncidentPolicy) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p := *policy
+	m.policies[policy.WorkflowID] = &p
+	return nil
+}
+
+func (m *mockIncidentRepo) GetPolicy(ctx context.Context, workflowID string) (*models.IncidentPolicy, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, ok := m.policies[workflowID]
+	if !ok {
+		return nil, models.ErrIncidentPolicyNotFound
+	}
+	copied := *p
+	return &copied, nil
+}
+
+func (m *mockIncidentRepo) DeletePolicy(ctx context.Context, workflowID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.policies[workflowID]; !ok {
+		return models.ErrIncidentPolicyNotFound
+	}
+	delete(m.policies, workflowID)
+	return nil
+}
+
+func (m *mockIncidentRepo) Create(ctx context.Context, incident *models.Incident) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	incident.ID = uuid.NewString()
+	incident.CreatedAt = time.Now()
+	m.incidents = append(m.incidents, incident)
+	return nil
+}
+
+func (m *mockIncidentRepo) ListByExecutionID(ctx context.Context, executionID string) ([]*models.Incident, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*models.Incident
+	for _, inc := range m.incidents {
+		if inc.ExecutionID == executionID {
+			result = append(result, inc)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockIncidentRepo) ListByWorkflowID(ctx context.Context, workflowID string, limit int) ([]*models.Incident, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*models.Incident
+	for _, inc := range m.incidents {
+		if inc.WorkflowID == workflowID {
+			result = append(result, inc)
+		}
+	}
+	return result, nil
+}
+
+type mockWorkflowFinder struct{}
+
+func (m *mockWorkflowFinder) FindByID(ctx context.Context, id uuid.UUID) (*storagemodels.WorkflowModel, error) {
+	return &storagemodels.WorkflowModel{ID: id, Name: "Checkout"}, nil
+}
+
+// mockExecutions returns executions newest first with the given statuses.
+type mockExecutions struct {
+	statuses []string
+}
+
+func (m *mockExecutions) FindByWorkflowID(ctx context.Context, workflowID uuid.UUID, limit, offset int) ([]*storagemodels.ExecutionModel, error) {
+	result := make([]*storagemodels.ExecutionModel, 0, len(m.statuses))
+	for _, status := range m.statuses {
+		result = append(result, &storagemodels.ExecutionModel{ID: uuid.New(), WorkflowID: &workflowID, Status: status})
+	}
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// mockTracker stands in for the issue_tracker executor.
+type mockTracker struct {
+	*executor.BaseExecutor
+	mu      sync.Mutex
+	configs []map[string]any
+	err     error
+}
+
+func (m *mockTracker) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.configs = append(m.configs, config)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return map[string]any{"id": "9001", "key": "42", "url": "https://github.com/acme/api/issues/42"}, nil
+}
+
+func (m *mockTracker) Validate(config map[string]any) error { return nil }
+
+func newTestService(t *testing.T, statuses ...string) (*Service, *mockIncidentRepo, *mockTracker) {
+	t.Helper()
+	repo := newMockIncidentRepo()
+	tracker := &mockTracker{BaseExecutor: executor.NewBaseExecutor(issueTrackerNodeType)}
+	manager := executor.NewManager()
+	require.NoError(t, manager.Register(issueTrackerNodeType, tracker))
+
+	log := logger.New(config.LoggingConfig{Level: "error", Format: "json"})
+	return NewService(repo, &mockWorkflowFinder{}, &mockExecutions{statuses: statuses}, manager, log), repo, tracker
+}
+
+func testPolicy(workflowID string) *models.IncidentPolicy {
+	return &models.IncidentPolicy{
+		WorkflowID:   workflowID,
+		WorkflowName: "Checkout",
+		RunbookURL:   "https://wiki.acme.io/runbooks/checkout",
+		Tracker:      models.IncidentTrackerGitHub,
+		Project:      "acme/api",
+		CredentialID: uuid.NewString(),
+		Labels:       []string{"incident"},
+	}
+}
+
+func TestService_SavePolicy(t *testing.T) {
+	svc, repo, _ := newTestService(t)
+	workflowID := uuid.NewString()
+
+	saved, err := svc.SavePolicy(context.Background(), &models.IncidentPolicy{
+		WorkflowID: workflowID,
+		RunbookURL: "https://wiki.acme.io/runbooks/checkout",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Checkout", saved.WorkflowName)
+	assert.Contains(t, repo.policies, workflowID)
+
+	_, err = svc.SavePolicy(context.Background(), &models.IncidentPolicy{WorkflowID: workflowID, FailureThreshold: 3})
+	var validationErr *models.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "tracker", validationErr.Field)
+}
+
+func TestService_Open(t *testing.T) {
+	svc, repo, tracker := newTestService(t)
+	workflowID := uuid.NewString()
+	executionID := uuid.NewString()
+
+	_, err := svc.Open(context.Background(), OpenRequest{WorkflowID: workflowID, Source: models.IncidentSourceManual})
+	assert.ErrorIs(t, err, models.ErrIncidentTrackerNotConfigured)
+
+	repo.policies[workflowID] = testPolicy(workflowID)
+	incident, err := svc.Open(context.Background(), OpenRequest{
+		WorkflowID:  workflowID,
+		ExecutionID: executionID,
+		Source:      models.IncidentSourceManual,
+		Title:       "Orders are not synced",
+		Details:     "The ERP node returned 502",
+		CreatedBy:   "user-1",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, executionID, incident.ExecutionID)
+	assert.Equal(t, "42", incident.Key)
+	assert.Equal(t, "9001", incident.ExternalID)
+	assert.Equal(t, "https://github.com/acme/api/issues/42", incident.URL)
+	assert.Equal(t, "https://wiki.acme.io/runbooks/checkout", incident.RunbookURL)
+	assert.Equal(t, "user-1", incident.CreatedBy)
+
+	require.Len(t, tracker.configs, 1)
+	cfg := tracker.configs[0]
+	assert.Equal(t, "github", cfg["provider"])
+	assert.Equal(t, "acme/api", cfg["project"])
+	assert.Equal(t, "Orders are not synced", cfg["title"])
+	assert.Equal(t, []string{"incident"}, cfg["labels"])
+	assert.Contains(t, cfg["body"], "The ERP node returned 502")
+	assert.Contains(t, cfg["body"], "Execution: "+executionID)
+	assert.Contains(t, cfg["body"], "Runbook: https://wiki.acme.io/runbooks/checkout")
+
+	tracker.err = errors.New("github API returned status 404")
+	_, err = svc.Open(context.Background(), OpenRequest{WorkflowID: workflowID, Source: models.IncidentSourceManual})
+	assert.ErrorContains(t, err, "status 404")
+	assert.Len(t, repo.incidents, 1)
+}
+
+func TestService_CanaryAlert(t *testing.T) {
+	svc, repo, tracker := newTestService(t)
+	workflowID := uuid.NewString()
+	alert := canary.Alert{
+		Type:        canary.AlertTypeFailing,
+		WorkflowID:  workflowID,
+		ExecutionID: uuid.NewString(),
+		RunbookURL:  "https://wiki.acme.io/runbooks/canary",
+		Message:     `Canary "Checkout" is failing: 1 assertion(s) failed`,
+		Failures:    []string{"output.status: expected 200"},
+	}
+
+	// No policy: nothing is opened
+	require.NoError(t, svc.Send(context.Background(), alert))
+
+	policy := testPolicy(workflowID)
+	repo.policies[workflowID] = policy
+	require.NoError(t, svc.Send(context.Background(), alert))
+	assert.Empty(t, tracker.configs, "policy does not open incidents on canary alerts")
+
+	policy.OnCanaryAlert = true
+	require.NoError(t, svc.Send(context.Background(), canary.Alert{Type: canary.AlertTypeRecovered, WorkflowID: workflowID}))
+	require.NoError(t, svc.Send(context.Background(), alert))
+
+	require.Len(t, repo.incidents, 1)
+	incident := repo.incidents[0]
+	assert.Equal(t, models.IncidentSourceCanaryAlert, incident.Source)
+	assert.Equal(t, alert.ExecutionID, incident.ExecutionID)
+	assert.Equal(t, "https://wiki.acme.io/runbooks/canary", incident.RunbookURL)
+	assert.Contains(t, tracker.configs[0]["body"], "output.status: expected 200")
+}
+
+func TestService_RepeatedFailures(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []string // previous executions, newest first
+		wantOpen bool
+	}{
+		{"below threshold", []string{"failed", "completed"}, false},
+		{"reaches threshold", []string{"failed", "failed", "completed"}, true},
+		{"running executions are skipped", []string{"running", "failed", "pending", "failed"}, true},
+		{"already above threshold", []string{"failed", "failed", "failed"}, false},
+		{"cancelled breaks the streak", []string{"failed", "cancelled", "failed"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, tracker := newTestService(t, tt.statuses...)
+			workflowID := uuid.NewString()
+			policy := testPolicy(workflowID)
+			policy.FailureThreshold = 3
+			repo.policies[workflowID] = policy
+
+			event := observer.Event{
+				Type:        observer.EventTypeExecutionFailed,
+				WorkflowID:  workflowID,
+				ExecutionID: uuid.NewString(),
+				Error:       errors.New("node http_1 failed"),
+			}
+			require.NoError(t, svc.OnEvent(context.Background(), event))
+
+			if !tt.wantOpen {
+				assert.Empty(t, tracker.configs)
+				return
+			}
+			require.Len(t, repo.incidents, 1)
+			assert.Equal(t, models.IncidentSourceRepeatedFailures, repo.incidents[0].Source)
+			assert.Equal(t, event.ExecutionID, repo.incidents[0].ExecutionID)
+			assert.Equal(t, `Workflow "Checkout" failed 3 times in a row`, repo.incidents[0].Title)
+			assert.Contains(t, tracker.configs[0]["body"], "Last error: node http_1 failed")
+		})
+	}
+}
+
+func TestService_RepeatedFailures_Disabled(t *testing.T) {
+	svc, repo, tracker := newTestService(t, "failed", "failed")
+	workflowID := uuid.NewString()
+	repo.policies[workflowID] = testPolicy(workflowID)
+
+	require.NoError(t, svc.OnEvent(context.Background(), observer.Event{
+		Type:        observer.EventTypeExecutionFailed,
+		WorkflowID:  workflowID,
+		ExecutionID: uuid.NewString(),
+	}))
+	assert.Empty(t, tracker.configs)
+
+	require.NoError(t, svc.OnEvent(context.Background(), observer.Event{
+		Type:       observer.EventTypeExecutionFailed,
+		WorkflowID: uuid.NewString(),
+	}))
+	assert.Empty(t, tracker.configs)
+}
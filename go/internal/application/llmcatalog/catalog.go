@@ -0,0 +1,303 @@
+// Package llmcatalog describes the LLM providers and models the llm executor can use:
+// context windows, list prices and supported features, merged with the models
+// providers report live, so model choices can be checked before a workflow runs.
+package llmcatalog
+
+import (
+	"strings"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// Features lists the request capabilities of a provider or model.
+type Features struct {
+	JSONMode   bool `json:"json_mode"`   // response_format json_object
+	JSONSchema bool `json:"json_schema"` // response_format json_schema
+	Tools      bool `json:"tools"`
+	Vision     bool `json:"vision"`
+	Reasoning  bool `json:"reasoning"`
+}
+
+// Pricing is the list price of a model in USD per million tokens.
+type Pricing struct {
+	Currency         string  `json:"currency"`
+	InputPerMillion  float64 `json:"input_per_million"`
+	OutputPerMillion float64 `json:"output_per_million"`
+}
+
+// ProviderInfo describes an LLM provider.
+type ProviderInfo struct {
+	ID          models.LLMProvider `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	// Supported reports whether the llm executor can run requests for the provider.
+	Supported bool `json:"supported"`
+	// RequiresAPIKey reports whether nodes must set api_key.
+	RequiresAPIKey bool `json:"requires_api_key"`
+	// Configured reports whether the user has an active rental key for the provider,
+	// or the provider needs no key.
+	Configured bool `json:"configured"`
+	// LiveModels reports whether models can be listed from the provider API.
+	LiveModels bool     `json:"live_models"`
+	Features   Features `json:"features"`
+}
+
+// ModelInfo describes a model of a provider.
+type ModelInfo struct {
+	ID              string             `json:"id"`
+	Provider        models.LLMProvider `json:"provider"`
+	Name            string             `json:"name,omitempty"`
+	ContextWindow   int                `json:"context_window,omitempty"`
+	MaxOutputTokens int                `json:"max_output_tokens,omitempty"`
+	Pricing         *Pricing           `json:"pricing,omitempty"`
+	Features        Features           `json:"features"`
+	// Source is "catalog" for models known to MBFlow and "live" for models only the provider reported.
+	Source string `json:"source"`
+	// Available is set when the provider was queried: whether it reported the model.
+	Available *bool `json:"available,omitempty"`
+}
+
+// Model sources.
+const (
+	SourceCatalog = "catalog"
+	SourceLive    = "live"
+)
+
+// catalogProviders lists providers in display order.
+var catalogProviders = []ProviderInfo{
+	{
+		ID:             models.LLMProviderOpenAI,
+		Name:           "OpenAI",
+		Description:    "OpenAI Chat Completions API",
+		Supported:      true,
+		RequiresAPIKey: true,
+		LiveModels:     true,
+		Features:       Features{JSONMode: true, JSONSchema: true, Tools: true, Vision: true, Reasoning: true},
+	},
+	{
+		ID:             models.LLMProviderOpenAIResponses,
+		Name:           "OpenAI Responses",
+		Description:    "OpenAI Responses API with hosted tools and stored conversations",
+		Supported:      true,
+		RequiresAPIKey: true,
+		LiveModels:     true,
+		Features:       Features{JSONMode: true, JSONSchema: true, Tools: true, Vision: true, Reasoning: true},
+	},
+	{
+		ID:             models.LLMProviderAnthropic,
+		Name:           "Anthropic",
+		Description:    "Anthropic Messages API (not yet supported by the llm executor)",
+		RequiresAPIKey: true,
+		LiveModels:     true,
+		Features:       Features{Tools: true, Vision: true, Reasoning: true},
+	},
+	{
+		ID:             models.LLMProviderGemini,
+		Name:           "Google Gemini",
+		Description:    "Google Gemini API",
+		Supported:      true,
+		RequiresAPIKey: true,
+		LiveModels:     true,
+		Features:       Features{JSONMode: true, JSONSchema: true, Tools: true, Vision: true, Reasoning: true},
+	},
+	{
+		ID:             models.LLMProviderAzureOpenAI,
+		Name:           "Azure OpenAI",
+		Description:    "OpenAI models deployed in Azure OpenAI Service; nodes name their deployment, so no models are listed",
+		Supported:      true,
+		RequiresAPIKey: true,
+		Features:       Features{JSONMode: true, JSONSchema: true, Tools: true, Vision: true, Reasoning: true},
+	},
+	{
+		ID:             models.LLMProviderBedrock,
+		Name:           "Amazon Bedrock",
+		Description:    "Amazon Bedrock Converse API (Claude, Titan, Llama) with AWS credentials from a credentials resource",
+		Supported:      true,
+		RequiresAPIKey: true,
+		Features:       Features{Tools: true, Vision: true},
+	},
+	{
+		ID:             models.LLMProviderMistral,
+		Name:           "Mistral AI",
+		Description:    "Mistral AI Chat Completions API",
+		Supported:      true,
+		RequiresAPIKey: true,
+		Features:       Features{JSONMode: true, JSONSchema: true, Tools: true, Vision: true},
+	},
+	{
+		ID:             models.LLMProviderCohere,
+		Name:           "Cohere",
+		Description:    "Cohere Chat API v2",
+		Supported:      true,
+		RequiresAPIKey: true,
+		Features:       Features{JSONMode: true, JSONSchema: true, Tools: true, Vision: true},
+	},
+	{
+		ID:          models.LLMProviderMock,
+		Name:        "Mock",
+		Description: "Deterministic canned responses for development and CI",
+		Supported:   true,
+		Features:    Features{JSONMode: true, JSONSchema: true, Tools: true, Vision: true},
+	},
+}
+
+// usd builds list pricing in USD per million tokens.
+func usd(input, output float64) *Pricing {
+	return &Pricing{Currency: "USD", InputPerMillion: input, OutputPerMillion: output}
+}
+
+var (
+	chatFeatures      = Features{JSONMode: true, JSONSchema: true, Tools: true, Vision: true}
+	reasoningFeatures = Features{JSONMode: true, JSONSchema: true, Tools: true, Vision: true, Reasoning: true}
+)
+
+// openAIModels are served by both OpenAI providers.
+var openAIModels = []ModelInfo{
+	{ID: "gpt-5", Name: "GPT-5", ContextWindow: 400000, MaxOutputTokens: 128000, Pricing: usd(1.25, 10), Features: reasoningFeatures},
+	{ID: "gpt-5-mini", Name: "GPT-5 mini", ContextWindow: 400000, MaxOutputTokens: 128000, Pricing: usd(0.25, 2), Features: reasoningFeatures},
+	{ID: "gpt-4.1", Name: "GPT-4.1", ContextWindow: 1047576, MaxOutputTokens: 32768, Pricing: usd(2, 8), Features: chatFeatures},
+	{ID: "gpt-4.1-mini", Name: "GPT-4.1 mini", ContextWindow: 1047576, MaxOutputTokens: 32768, Pricing: usd(0.4, 1.6), Features: chatFeatures},
+	{ID: "gpt-4.1-nano", Name: "GPT-4.1 nano", ContextWindow: 1047576, MaxOutputTokens: 32768, Pricing: usd(0.1, 0.4), Features: chatFeatures},
+	{ID: "gpt-4o", Name: "GPT-4o", ContextWindow: 128000, MaxOutputTokens: 16384, Pricing: usd(2.5, 10), Features: chatFeatures},
+	{ID: "gpt-4o-mini", Name: "GPT-4o mini", ContextWindow: 128000, MaxOutputTokens: 16384, Pricing: usd(0.15, 0.6), Features: chatFeatures},
+	{ID: "o3", Name: "o3", ContextWindow: 200000, MaxOutputTokens: 100000, Pricing: usd(2, 8), Features: reasoningFeatures},
+	{ID: "o4-mini", Name: "o4-mini", ContextWindow: 200000, MaxOutputTokens: 100000, Pricing: usd(1.1, 4.4), Features: reasoningFeatures},
+	{ID: "o3-mini", Name: "o3-mini", ContextWindow: 200000, MaxOutputTokens: 100000, Pricing: usd(1.1, 4.4),
+		Features: Features{JSONMode: true, JSONSchema: true, Tools: true, Reasoning: true}},
+	{ID: "gpt-4-turbo", Name: "GPT-4 Turbo", ContextWindow: 128000, MaxOutputTokens: 4096, Pricing: usd(10, 30),
+		Features: Features{JSONMode: true, Tools: true, Vision: true}},
+	{ID: "gpt-4", Name: "GPT-4", ContextWindow: 8192, MaxOutputTokens: 8192, Pricing: usd(30, 60),
+		Features: Features{Tools: true}},
+	{ID: "gpt-3.5-turbo", Name: "GPT-3.5 Turbo", ContextWindow: 16385, MaxOutputTokens: 4096, Pricing: usd(0.5, 1.5),
+		Features: Features{JSONMode: true, Tools: true}},
+}
+
+// catalogModels lists the known models of the other providers.
+var catalogModels = map[models.LLMProvider][]ModelInfo{
+	models.LLMProviderAnthropic: {
+		{ID: "claude-opus-4-20250514", Name: "Claude Opus 4", ContextWindow: 200000, MaxOutputTokens: 32000, Pricing: usd(15, 75),
+			Features: Features{Tools: true, Vision: true, Reasoning: true}},
+		{ID: "claude-sonnet-4-20250514", Name: "Claude Sonnet 4", ContextWindow: 200000, MaxOutputTokens: 64000, Pricing: usd(3, 15),
+			Features: Features{Tools: true, Vision: true, Reasoning: true}},
+		{ID: "claude-3-7-sonnet-20250219", Name: "Claude Sonnet 3.7", ContextWindow: 200000, MaxOutputTokens: 64000, Pricing: usd(3, 15),
+			Features: Features{Tools: true, Vision: true, Reasoning: true}},
+		{ID: "claude-3-5-haiku-20241022", Name: "Claude Haiku 3.5", ContextWindow: 200000, MaxOutputTokens: 8192, Pricing: usd(0.8, 4),
+			Features: Features{Tools: true, Vision: true}},
+	},
+	models.LLMProviderGemini: {
+		{ID: "gemini-2.5-pro", Name: "Gemini 2.5 Pro", ContextWindow: 1048576, MaxOutputTokens: 65536, Pricing: usd(1.25, 10), Features: reasoningFeatures},
+		{ID: "gemini-2.5-flash", Name: "Gemini 2.5 Flash", ContextWindow: 1048576, MaxOutputTokens: 65536, Pricing: usd(0.3, 2.5), Features: reasoningFeatures},
+		{ID: "gemini-2.0-flash", Name: "Gemini 2.0 Flash", ContextWindow: 1048576, MaxOutputTokens: 8192, Pricing: usd(0.1, 0.4), Features: chatFeatures},
+		{ID: "gemini-1.5-pro", Name: "Gemini 1.5 Pro", ContextWindow: 2097152, MaxOutputTokens: 8192, Pricing: usd(1.25, 5), Features: chatFeatures},
+		{ID: "gemini-1.5-flash", Name: "Gemini 1.5 Flash", ContextWindow: 1048576, MaxOutputTokens: 8192, Pricing: usd(0.075, 0.3), Features: chatFeatures},
+	},
+	models.LLMProviderBedrock: {
+		{ID: "anthropic.claude-3-5-sonnet-20240620-v1:0", Name: "Claude 3.5 Sonnet", ContextWindow: 200000, MaxOutputTokens: 8192, Pricing: usd(3, 15),
+			Features: Features{Tools: true, Vision: true}},
+		{ID: "anthropic.claude-3-5-haiku-20241022-v1:0", Name: "Claude 3.5 Haiku", ContextWindow: 200000, MaxOutputTokens: 8192, Pricing: usd(0.8, 4),
+			Features: Features{Tools: true}},
+		{ID: "amazon.titan-text-express-v1", Name: "Titan Text Express", ContextWindow: 8192, MaxOutputTokens: 8192, Pricing: usd(0.2, 0.6)},
+		{ID: "amazon.titan-text-lite-v1", Name: "Titan Text Lite", ContextWindow: 4096, MaxOutputTokens: 4096, Pricing: usd(0.15, 0.2)},
+		{ID: "meta.llama3-1-70b-instruct-v1:0", Name: "Llama 3.1 70B Instruct", ContextWindow: 128000, MaxOutputTokens: 2048, Pricing: usd(0.72, 0.72),
+			Features: Features{Tools: true}},
+		{ID: "meta.llama3-1-8b-instruct-v1:0", Name: "Llama 3.1 8B Instruct", ContextWindow: 128000, MaxOutputTokens: 2048, Pricing: usd(0.22, 0.22),
+			Features: Features{Tools: true}},
+	},
+	models.LLMProviderMistral: {
+		{ID: "mistral-large-latest", Name: "Mistral Large", ContextWindow: 128000, MaxOutputTokens: 128000, Pricing: usd(2, 6),
+			Features: Features{JSONMode: true, JSONSchema: true, Tools: true}},
+		{ID: "mistral-medium-latest", Name: "Mistral Medium", ContextWindow: 128000, MaxOutputTokens: 128000, Pricing: usd(0.4, 2), Features: chatFeatures},
+		{ID: "mistral-small-latest", Name: "Mistral Small", ContextWindow: 128000, MaxOutputTokens: 128000, Pricing: usd(0.1, 0.3), Features: chatFeatures},
+		{ID: "pixtral-large-latest", Name: "Pixtral Large", ContextWindow: 128000, MaxOutputTokens: 128000, Pricing: usd(2, 6), Features: chatFeatures},
+		{ID: "codestral-latest", Name: "Codestral", ContextWindow: 256000, MaxOutputTokens: 256000, Pricing: usd(0.3, 0.9),
+			Features: Features{JSONMode: true, JSONSchema: true, Tools: true}},
+		{ID: "ministral-8b-latest", Name: "Ministral 8B", ContextWindow: 128000, MaxOutputTokens: 128000, Pricing: usd(0.1, 0.1),
+			Features: Features{JSONMode: true, JSONSchema: true, Tools: true}},
+	},
+	models.LLMProviderCohere: {
+		{ID: "command-a-03-2025", Name: "Command A", ContextWindow: 256000, MaxOutputTokens: 8000, Pricing: usd(2.5, 10),
+			Features: Features{JSONMode: true, JSONSchema: true, Tools: true}},
+		{ID: "command-a-vision-07-2025", Name: "Command A Vision", ContextWindow: 128000, MaxOutputTokens: 8000, Pricing: usd(2.5, 10),
+			Features: Features{JSONMode: true, JSONSchema: true, Vision: true}},
+		{ID: "command-r-plus-08-2024", Name: "Command R+", ContextWindow: 128000, MaxOutputTokens: 4000, Pricing: usd(2.5, 10),
+			Features: Features{JSONMode: true, JSONSchema: true, Tools: true}},
+		{ID: "command-r-08-2024", Name: "Command R", ContextWindow: 128000, MaxOutputTokens: 4000, Pricing: usd(0.15, 0.6),
+			Features: Features{JSONMode: true, JSONSchema: true, Tools: true}},
+		{ID: "command-r7b-12-2024", Name: "Command R7B", ContextWindow: 128000, MaxOutputTokens: 4000, Pricing: usd(0.0375, 0.15),
+			Features: Features{JSONMode: true, JSONSchema: true, Tools: true}},
+	},
+	models.LLMProviderMock: {
+		{ID: "mock", Name: "Mock", Pricing: usd(0, 0), Features: Features{JSONMode: true, JSONSchema: true, Tools: true, Vision: true}},
+	},
+}
+
+// providerInfo returns the catalog entry of a provider.
+func providerInfo(provider models.LLMProvider) (ProviderInfo, bool) {
+	for _, info := range catalogProviders {
+		if info.ID == provider {
+			return info, true
+		}
+	}
+	return ProviderInfo{}, false
+}
+
+// knownModels returns a copy of the catalog models of a provider.
+func knownModels(provider models.LLMProvider) []ModelInfo {
+	source := catalogModels[provider]
+	if provider == models.LLMProviderOpenAI || provider == models.LLMProviderOpenAIResponses {
+		source = openAIModels
+	}
+
+	result := make([]ModelInfo, len(source))
+	for i, model := range source {
+		model.Provider = provider
+		model.Source = SourceCatalog
+		result[i] = model
+	}
+	return result
+}
+
+// Price returns the list price of a model, or nil when the catalog has none.
+// Dated or suffixed model names providers report, such as gpt-4o-2024-08-06, match the
+// longest catalog model they start with. Azure OpenAI deployments are priced as the
+// OpenAI model they serve.
+func Price(provider models.LLMProvider, model string) *Pricing {
+	source := catalogModels[provider]
+	if provider == models.LLMProviderOpenAI || provider == models.LLMProviderOpenAIResponses || provider == models.LLMProviderAzureOpenAI {
+		source = openAIModels
+	}
+
+	var match *ModelInfo
+	for i := range source {
+		candidate := &source[i]
+		if candidate.ID == model {
+			return candidate.Pricing
+		}
+		if strings.HasPrefix(model, candidate.ID+"-") && (match == nil || len(candidate.ID) > len(match.ID)) {
+			match = candidate
+		}
+	}
+	if match == nil {
+		return nil
+	}
+	return match.Pricing
+}
+
+// Cost returns the cost of the given token counts at this price.
+func (p *Pricing) Cost(promptTokens, completionTokens int) float64 {
+	return (float64(promptTokens)*p.InputPerMillion + float64(completionTokens)*p.OutputPerMillion) / 1_000_000
+}
+
+// rentalKeyProvider maps an llm provider to the provider type of rental keys usable with it.
+func rentalKeyProvider(provider models.LLMProvider) (models.LLMProviderType, bool) {
+	switch provider {
+	case models.LLMProviderOpenAI, models.LLMProviderOpenAIResponses:
+		return models.LLMProviderTypeOpenAI, true
+	case models.LLMProviderAnthropic:
+		return models.LLMProviderTypeAnthropic, true
+	case models.LLMProviderGemini:
+		return models.LLMProviderTypeGoogleAI, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,174 @@
+package llmcatalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// Default API endpoints of providers that list models.
+var defaultBaseURLs = map[models.LLMProvider]string{
+	models.LLMProviderOpenAI:          "https://api.openai.com/v1",
+	models.LLMProviderOpenAIResponses: "https://api.openai.com/v1",
+	models.LLMProviderAnthropic:       "https://api.anthropic.com/v1",
+	models.LLMProviderGemini:          "https://generativelanguage.googleapis.com/v1beta",
+}
+
+// liveModelsMaxBytes limits the size of a model list response.
+const liveModelsMaxBytes = 8 << 20
+
+// liveModel is a model reported by a provider API.
+type liveModel struct {
+	ID              string
+	Name            string
+	ContextWindow   int
+	MaxOutputTokens int
+}
+
+// liveFetcher queries the model list endpoints of providers.
+type liveFetcher struct {
+	client *http.Client
+}
+
+func newLiveFetcher(timeout time.Duration) *liveFetcher {
+	return &liveFetcher{client: &http.Client{Timeout: timeout}}
+}
+
+// fetch lists the text generation models the provider reports for an API key.
+func (f *liveFetcher) fetch(ctx context.Context, provider models.LLMProvider, apiKey, baseURL string) ([]liveModel, error) {
+	if baseURL == "" {
+		baseURL = defaultBaseURLs[provider]
+	}
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	switch provider {
+	case models.LLMProviderOpenAI, models.LLMProviderOpenAIResponses:
+		return f.fetchOpenAI(ctx, baseURL, apiKey)
+	case models.LLMProviderAnthropic:
+		return f.fetchAnthropic(ctx, baseURL, apiKey)
+	case models.LLMProviderGemini:
+		return f.fetchGemini(ctx, baseURL, apiKey)
+	default:
+		return nil, fmt.Errorf("provider %s does not list models", provider)
+	}
+}
+
+// openAINonChatMarkers identify OpenAI models that do not serve chat requests.
+var openAINonChatMarkers = []string{"audio", "realtime", "transcribe", "tts", "image", "embedding", "search"}
+
+func (f *liveFetcher) fetchOpenAI(ctx context.Context, baseURL, apiKey string) ([]liveModel, error) {
+	var resp struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	headers := http.Header{"Authorization": {"Bearer " + apiKey}}
+	if err := f.get(ctx, baseURL+"/models", headers, &resp); err != nil {
+		return nil, err
+	}
+
+	var result []liveModel
+	for _, model := range resp.Data {
+		if !isOpenAIChatModel(model.ID) {
+			continue
+		}
+		result = append(result, liveModel{ID: model.ID})
+	}
+	return result, nil
+}
+
+func isOpenAIChatModel(id string) bool {
+	if !strings.HasPrefix(id, "gpt-") && !strings.HasPrefix(id, "chatgpt-") &&
+		!strings.HasPrefix(id, "o1") && !strings.HasPrefix(id, "o3") && !strings.HasPrefix(id, "o4") {
+		return false
+	}
+	return !slices.ContainsFunc(openAINonChatMarkers, func(marker string) bool {
+		return strings.Contains(id, marker)
+	})
+}
+
+func (f *liveFetcher) fetchAnthropic(ctx context.Context, baseURL, apiKey string) ([]liveModel, error) {
+	var resp struct {
+		Data []struct {
+			ID          string `json:"id"`
+			DisplayName string `json:"display_name"`
+		} `json:"data"`
+	}
+	headers := http.Header{"X-Api-Key": {apiKey}, "Anthropic-Version": {"2023-06-01"}}
+	if err := f.get(ctx, baseURL+"/models?limit=1000", headers, &resp); err != nil {
+		return nil, err
+	}
+
+	result := make([]liveModel, 0, len(resp.Data))
+	for _, model := range resp.Data {
+		result = append(result, liveModel{ID: model.ID, Name: model.DisplayName})
+	}
+	return result, nil
+}
+
+func (f *liveFetcher) fetchGemini(ctx context.Context, baseURL, apiKey string) ([]liveModel, error) {
+	var resp struct {
+		Models []struct {
+			Name                       string   `json:"name"`
+			DisplayName                string   `json:"displayName"`
+			InputTokenLimit            int      `json:"inputTokenLimit"`
+			OutputTokenLimit           int      `json:"outputTokenLimit"`
+			SupportedGenerationMethods []string `json:"supportedGenerationMethods"`
+		} `json:"models"`
+	}
+	headers := http.Header{"X-Goog-Api-Key": {apiKey}}
+	if err := f.get(ctx, baseURL+"/models?pageSize=1000", headers, &resp); err != nil {
+		return nil, err
+	}
+
+	var result []liveModel
+	for _, model := range resp.Models {
+		if !slices.Contains(model.SupportedGenerationMethods, "generateContent") {
+			continue
+		}
+		result = append(result, liveModel{
+			ID:              strings.TrimPrefix(model.Name, "models/"),
+			Name:            model.DisplayName,
+			ContextWindow:   model.InputTokenLimit,
+			MaxOutputTokens: model.OutputTokenLimit,
+		})
+	}
+	return result, nil
+}
+
+// get performs a GET request and decodes the JSON response into out.
+// Response bodies of failed requests are not included in errors, as some providers echo credentials.
+func (f *liveFetcher) get(ctx context.Context, url string, headers http.Header, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create models request: %w", err)
+	}
+	for key, values := range headers {
+		req.Header[key] = values
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("models request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("models request failed: HTTP %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, liveModelsMaxBytes))
+	if err != nil {
+		return fmt.Errorf("failed to read models response: %w", err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to parse models response: %w", err)
+	}
+	return nil
+}
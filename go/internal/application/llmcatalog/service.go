@@ -0,0 +1,316 @@
+package llmcatalog
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/internal/application/rentalkey"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+var (
+	// ErrUnknownProvider is returned when a listing is filtered by a provider not in the catalog.
+	ErrUnknownProvider = errors.New("unknown LLM provider")
+
+	// ErrUnknownFeature is returned when a listing is filtered by an unknown feature.
+	ErrUnknownFeature = errors.New("feature must be one of: json_mode, json_schema, tools, vision, reasoning")
+)
+
+const (
+	defaultCacheTTL = 15 * time.Minute
+	defaultTimeout  = 10 * time.Second
+)
+
+// KeySource looks up the rental keys of a user; *rentalkey.Provider implements it.
+type KeySource interface {
+	GetKeysByProvider(ctx context.Context, ownerID string, provider models.LLMProviderType) ([]*models.RentalKeyResource, error)
+	GetAPIKeyForExecution(ctx context.Context, rentalKeyID, userID string) (*rentalkey.ExecutionCredentials, error)
+}
+
+// Config holds catalog service settings.
+type Config struct {
+	// CacheTTL is how long live model lists are reused (default: 15m).
+	CacheTTL time.Duration
+	// Timeout bounds each provider request (default: 10s).
+	Timeout time.Duration
+	// BaseURLs overrides the API endpoint of providers. A base_url in the
+	// provider_config of a rental key takes precedence.
+	BaseURLs map[models.LLMProvider]string
+}
+
+// ModelFilter narrows a model listing.
+type ModelFilter struct {
+	// Provider limits the listing to one provider; empty lists all providers.
+	Provider models.LLMProvider
+	// Model limits the listing to one model ID.
+	Model string
+	// Feature limits the listing to models supporting a feature, e.g. "vision".
+	Feature string
+	// Refresh queries providers again instead of using cached model lists.
+	Refresh bool
+}
+
+// ModelSource tells where the models of a provider came from.
+type ModelSource struct {
+	Provider  models.LLMProvider `json:"provider"`
+	Source    string             `json:"source"`
+	FetchedAt *time.Time         `json:"fetched_at,omitempty"`
+	// Error is set when the provider could not be queried; the catalog is returned instead.
+	Error string `json:"error,omitempty"`
+}
+
+// ModelList is the answer to a model listing.
+type ModelList struct {
+	Models  []ModelInfo   `json:"models"`
+	Sources []ModelSource `json:"sources"`
+}
+
+// Service lists LLM providers and models. Models are taken from the catalog and,
+// when the user has an active rental key for a provider, from the provider API.
+type Service struct {
+	keys    KeySource
+	cfg     Config
+	fetcher *liveFetcher
+
+	mu    sync.Mutex
+	cache map[string]liveList
+}
+
+// liveList is a cached model list reported by a provider.
+type liveList struct {
+	models    []liveModel
+	fetchedAt time.Time
+}
+
+// NewService creates a catalog service. keys may be nil, in which case models
+// are only listed from the catalog.
+func NewService(keys KeySource, cfg Config) *Service {
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = defaultCacheTTL
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	return &Service{
+		keys:    keys,
+		cfg:     cfg,
+		fetcher: newLiveFetcher(cfg.Timeout),
+		cache:   make(map[string]liveList),
+	}
+}
+
+// Providers lists the providers of the catalog and whether each is configured for the user.
+func (s *Service) Providers(ctx context.Context, userID string) ([]ProviderInfo, error) {
+	result := make([]ProviderInfo, 0, len(catalogProviders))
+	for _, info := range catalogProviders {
+		if !info.RequiresAPIKey {
+			info.Configured = true
+		} else {
+			keys, err := s.activeKeys(ctx, userID, info.ID)
+			if err != nil {
+				return nil, err
+			}
+			info.Configured = len(keys) > 0
+		}
+		result = append(result, info)
+	}
+	return result, nil
+}
+
+// Models lists models matching the filter.
+func (s *Service) Models(ctx context.Context, userID string, filter ModelFilter) (*ModelList, error) {
+	providers := catalogProviders
+	if filter.Provider != "" {
+		info, ok := providerInfo(filter.Provider)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, filter.Provider)
+		}
+		providers = []ProviderInfo{info}
+	}
+	if filter.Feature != "" && !knownFeature(filter.Feature) {
+		return nil, ErrUnknownFeature
+	}
+
+	list := &ModelList{Models: []ModelInfo{}, Sources: make([]ModelSource, 0, len(providers))}
+	for _, info := range providers {
+		providerModels, source, err := s.providerModels(ctx, userID, info, filter.Refresh)
+		if err != nil {
+			return nil, err
+		}
+		list.Sources = append(list.Sources, source)
+		for _, model := range providerModels {
+			if filter.Model != "" && model.ID != filter.Model {
+				continue
+			}
+			if filter.Feature != "" && !hasFeature(model.Features, filter.Feature) {
+				continue
+			}
+			list.Models = append(list.Models, model)
+		}
+	}
+	return list, nil
+}
+
+// providerModels returns the catalog models of a provider, merged with the live list
+// when the user has a key for it.
+func (s *Service) providerModels(ctx context.Context, userID string, info ProviderInfo, refresh bool) ([]ModelInfo, ModelSource, error) {
+	catalog := knownModels(info.ID)
+	source := ModelSource{Provider: info.ID, Source: SourceCatalog}
+	if !info.LiveModels {
+		return catalog, source, nil
+	}
+
+	creds, baseURL, err := s.credentials(ctx, userID, info.ID)
+	if err != nil {
+		return nil, source, err
+	}
+	if creds == nil {
+		return catalog, source, nil
+	}
+
+	live, err := s.liveModels(ctx, info.ID, creds.APIKey, baseURL, refresh)
+	if err != nil {
+		source.Error = err.Error()
+		return catalog, source, nil
+	}
+	fetchedAt := live.fetchedAt
+	source.Source = SourceLive
+	source.FetchedAt = &fetchedAt
+	return mergeModels(info.ID, catalog, live.models), source, nil
+}
+
+// liveModels returns the cached model list of a provider, querying it when the
+// cache is stale or refresh is set.
+func (s *Service) liveModels(ctx context.Context, provider models.LLMProvider, apiKey, baseURL string, refresh bool) (liveList, error) {
+	keyType, _ := rentalKeyProvider(provider)
+	sum := sha256.Sum256([]byte(apiKey))
+	cacheKey := string(keyType) + "\x00" + baseURL + "\x00" + hex.EncodeToString(sum[:])
+
+	s.mu.Lock()
+	cached, ok := s.cache[cacheKey]
+	s.mu.Unlock()
+	if ok && !refresh && time.Since(cached.fetchedAt) < s.cfg.CacheTTL {
+		return cached, nil
+	}
+
+	found, err := s.fetcher.fetch(ctx, provider, apiKey, baseURL)
+	if err != nil {
+		return liveList{}, err
+	}
+	list := liveList{models: found, fetchedAt: time.Now().UTC()}
+
+	s.mu.Lock()
+	s.cache[cacheKey] = list
+	s.mu.Unlock()
+	return list, nil
+}
+
+// activeKeys returns the active rental keys of the user usable with a provider.
+func (s *Service) activeKeys(ctx context.Context, userID string, provider models.LLMProvider) ([]*models.RentalKeyResource, error) {
+	keyType, ok := rentalKeyProvider(provider)
+	if !ok || s.keys == nil || userID == "" {
+		return nil, nil
+	}
+
+	keys, err := s.keys.GetKeysByProvider(ctx, userID, keyType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rental keys: %w", err)
+	}
+	var active []*models.RentalKeyResource
+	for _, key := range keys {
+		if key.Status == models.ResourceStatusActive {
+			active = append(active, key)
+		}
+	}
+	return active, nil
+}
+
+// credentials returns the API key and base URL of the first usable rental key, or nil.
+// Keys over their usage limits are skipped.
+func (s *Service) credentials(ctx context.Context, userID string, provider models.LLMProvider) (*rentalkey.ExecutionCredentials, string, error) {
+	keys, err := s.activeKeys(ctx, userID, provider)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, key := range keys {
+		creds, err := s.keys.GetAPIKeyForExecution(ctx, key.ID, userID)
+		if err != nil {
+			continue
+		}
+		baseURL, _ := key.ProviderConfig["base_url"].(string)
+		if baseURL == "" {
+			baseURL = s.cfg.BaseURLs[provider]
+		}
+		return creds, baseURL, nil
+	}
+	return nil, "", nil
+}
+
+// mergeModels marks which catalog models the provider reported and appends the
+// models only the provider knows, sorted by ID.
+func mergeModels(provider models.LLMProvider, catalog []ModelInfo, live []liveModel) []ModelInfo {
+	reported := make(map[string]bool, len(live))
+	for _, model := range live {
+		reported[model.ID] = true
+	}
+
+	known := make(map[string]bool, len(catalog))
+	result := make([]ModelInfo, 0, len(catalog)+len(live))
+	for _, model := range catalog {
+		known[model.ID] = true
+		available := reported[model.ID]
+		model.Available = &available
+		result = append(result, model)
+	}
+
+	var extra []ModelInfo
+	for _, model := range live {
+		if known[model.ID] {
+			continue
+		}
+		available := true
+		extra = append(extra, ModelInfo{
+			ID:              model.ID,
+			Provider:        provider,
+			Name:            model.Name,
+			ContextWindow:   model.ContextWindow,
+			MaxOutputTokens: model.MaxOutputTokens,
+			Source:          SourceLive,
+			Available:       &available,
+		})
+	}
+	sort.Slice(extra, func(i, j int) bool { return extra[i].ID < extra[j].ID })
+	return append(result, extra...)
+}
+
+func knownFeature(feature string) bool {
+	switch feature {
+	case "json_mode", "json_schema", "tools", "vision", "reasoning":
+		return true
+	default:
+		return false
+	}
+}
+
+func hasFeature(features Features, feature string) bool {
+	switch feature {
+	case "json_mode":
+		return features.JSONMode
+	case "json_schema":
+		return features.JSONSchema
+	case "tools":
+		return features.Tools
+	case "vision":
+		return features.Vision
+	case "reasoning":
+		return features.Reasoning
+	default:
+		return false
+	}
+}
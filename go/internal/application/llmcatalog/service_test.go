@@ -0,0 +1,255 @@
+package llmcatalog
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smilemakc/mbflow/go/internal/application/rentalkey"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// fakeKeySource serves rental keys from memory. Keys in overLimit fail like keys past their limits.
+type fakeKeySource struct {
+	keys      []*models.RentalKeyResource
+	apiKeys   map[string]string
+	overLimit map[string]bool
+}
+
+func (f *fakeKeySource) GetKeysByProvider(_ context.Context, ownerID string, provider models.LLMProviderType) ([]*models.RentalKeyResource, error) {
+	var result []*models.RentalKeyResource
+	for _, key := range f.keys {
+		if key.OwnerID == ownerID && key.Provider == provider {
+			result = append(result, key)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeKeySource) GetAPIKeyForExecution(_ context.Context, rentalKeyID, _ string) (*rentalkey.ExecutionCredentials, error) {
+	if f.overLimit[rentalKeyID] {
+		return nil, models.ErrDailyLimitExceeded
+	}
+	return &rentalkey.ExecutionCredentials{APIKey: f.apiKeys[rentalKeyID]}, nil
+}
+
+func newRentalKey(id, owner string, provider models.LLMProviderType, status models.ResourceStatus, baseURL string) *models.RentalKeyResource {
+	key := models.NewRentalKeyResource(owner, id, provider)
+	key.ID = id
+	key.Status = status
+	if baseURL != "" {
+		key.ProviderConfig["base_url"] = baseURL
+	}
+	return key
+}
+
+func newFakeOpenAI(t *testing.T, apiKey string, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Path != "/models" || r.Header.Get("Authorization") != "Bearer "+apiKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"data":[{"id":"gpt-4o"},{"id":"gpt-4o-mini"},{"id":"gpt-4o-2024-11-20"},
+			{"id":"text-embedding-3-small"},{"id":"gpt-4o-audio-preview"},{"id":"whisper-1"}]}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func findModel(list *ModelList, provider models.LLMProvider, id string) *ModelInfo {
+	for i := range list.Models {
+		if list.Models[i].Provider == provider && list.Models[i].ID == id {
+			return &list.Models[i]
+		}
+	}
+	return nil
+}
+
+func TestService_Providers(t *testing.T) {
+	keys := &fakeKeySource{keys: []*models.RentalKeyResource{
+		newRentalKey("k1", "user-1", models.LLMProviderTypeOpenAI, models.ResourceStatusActive, ""),
+		newRentalKey("k2", "user-1", models.LLMProviderTypeGoogleAI, models.ResourceStatusSuspended, ""),
+	}}
+	svc := NewService(keys, Config{})
+
+	providers, err := svc.Providers(context.Background(), "user-1")
+	require.NoError(t, err)
+
+	configured := map[models.LLMProvider]bool{}
+	for _, p := range providers {
+		configured[p.ID] = p.Configured
+	}
+	assert.Equal(t, map[models.LLMProvider]bool{
+		models.LLMProviderOpenAI:          true,
+		models.LLMProviderOpenAIResponses: true,
+		models.LLMProviderAnthropic:       false,
+		models.LLMProviderGemini:          false,
+		models.LLMProviderAzureOpenAI:     false,
+		models.LLMProviderBedrock:         false,
+		models.LLMProviderMistral:         false,
+		models.LLMProviderCohere:          false,
+		models.LLMProviderMock:            true,
+	}, configured)
+
+	anthropic, _ := providerInfo(models.LLMProviderAnthropic)
+	assert.False(t, anthropic.Supported)
+}
+
+func TestService_Models_CatalogOnly(t *testing.T) {
+	svc := NewService(nil, Config{})
+
+	list, err := svc.Models(context.Background(), "user-1", ModelFilter{Provider: models.LLMProviderGemini})
+	require.NoError(t, err)
+
+	require.Equal(t, []ModelSource{{Provider: models.LLMProviderGemini, Source: SourceCatalog}}, list.Sources)
+	model := findModel(list, models.LLMProviderGemini, "gemini-2.5-pro")
+	require.NotNil(t, model)
+	assert.Equal(t, 1048576, model.ContextWindow)
+	assert.Equal(t, SourceCatalog, model.Source)
+	assert.Nil(t, model.Available)
+	assert.Equal(t, "USD", model.Pricing.Currency)
+}
+
+func TestService_Models_Filters(t *testing.T) {
+	svc := NewService(nil, Config{})
+
+	list, err := svc.Models(context.Background(), "", ModelFilter{Model: "gpt-4o"})
+	require.NoError(t, err)
+	require.Len(t, list.Models, 2)
+	assert.Equal(t, models.LLMProviderOpenAI, list.Models[0].Provider)
+	assert.Equal(t, models.LLMProviderOpenAIResponses, list.Models[1].Provider)
+
+	list, err = svc.Models(context.Background(), "", ModelFilter{Provider: models.LLMProviderOpenAI, Feature: "vision"})
+	require.NoError(t, err)
+	assert.Nil(t, findModel(list, models.LLMProviderOpenAI, "gpt-3.5-turbo"))
+	assert.NotNil(t, findModel(list, models.LLMProviderOpenAI, "gpt-4o"))
+
+	_, err = svc.Models(context.Background(), "", ModelFilter{Provider: "acme"})
+	assert.True(t, errors.Is(err, ErrUnknownProvider))
+
+	_, err = svc.Models(context.Background(), "", ModelFilter{Feature: "telepathy"})
+	assert.True(t, errors.Is(err, ErrUnknownFeature))
+}
+
+func TestService_Models_Live(t *testing.T) {
+	var calls atomic.Int32
+	server := newFakeOpenAI(t, "sk-live", &calls)
+	keys := &fakeKeySource{
+		keys: []*models.RentalKeyResource{
+			newRentalKey("k0", "user-1", models.LLMProviderTypeOpenAI, models.ResourceStatusActive, server.URL),
+			newRentalKey("k1", "user-1", models.LLMProviderTypeOpenAI, models.ResourceStatusActive, server.URL),
+		},
+		apiKeys:   map[string]string{"k0": "sk-spent", "k1": "sk-live"},
+		overLimit: map[string]bool{"k0": true},
+	}
+	svc := NewService(keys, Config{})
+
+	list, err := svc.Models(context.Background(), "user-1", ModelFilter{Provider: models.LLMProviderOpenAI})
+	require.NoError(t, err)
+
+	require.Len(t, list.Sources, 1)
+	assert.Equal(t, SourceLive, list.Sources[0].Source)
+	assert.NotNil(t, list.Sources[0].FetchedAt)
+	assert.Empty(t, list.Sources[0].Error)
+
+	gpt4o := findModel(list, models.LLMProviderOpenAI, "gpt-4o")
+	require.NotNil(t, gpt4o)
+	assert.True(t, *gpt4o.Available)
+	assert.Equal(t, SourceCatalog, gpt4o.Source)
+
+	gpt4 := findModel(list, models.LLMProviderOpenAI, "gpt-4")
+	require.NotNil(t, gpt4)
+	assert.False(t, *gpt4.Available)
+
+	snapshot := findModel(list, models.LLMProviderOpenAI, "gpt-4o-2024-11-20")
+	require.NotNil(t, snapshot)
+	assert.Equal(t, SourceLive, snapshot.Source)
+	assert.Nil(t, findModel(list, models.LLMProviderOpenAI, "text-embedding-3-small"))
+	assert.Nil(t, findModel(list, models.LLMProviderOpenAI, "gpt-4o-audio-preview"))
+
+	// Both OpenAI providers share the cached list of the key
+	_, err = svc.Models(context.Background(), "user-1", ModelFilter{Provider: models.LLMProviderOpenAIResponses})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+
+	_, err = svc.Models(context.Background(), "user-1", ModelFilter{Provider: models.LLMProviderOpenAI, Refresh: true})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestService_Models_LiveFailureFallsBackToCatalog(t *testing.T) {
+	var calls atomic.Int32
+	server := newFakeOpenAI(t, "sk-live", &calls)
+	keys := &fakeKeySource{
+		keys:    []*models.RentalKeyResource{newRentalKey("k1", "user-1", models.LLMProviderTypeOpenAI, models.ResourceStatusActive, "")},
+		apiKeys: map[string]string{"k1": "sk-revoked"},
+	}
+	svc := NewService(keys, Config{BaseURLs: map[models.LLMProvider]string{models.LLMProviderOpenAI: server.URL}})
+
+	list, err := svc.Models(context.Background(), "user-1", ModelFilter{Provider: models.LLMProviderOpenAI})
+	require.NoError(t, err)
+
+	assert.Equal(t, SourceCatalog, list.Sources[0].Source)
+	assert.Contains(t, list.Sources[0].Error, "HTTP 401")
+	assert.NotContains(t, list.Sources[0].Error, "sk-revoked")
+	assert.NotNil(t, findModel(list, models.LLMProviderOpenAI, "gpt-4o"))
+}
+
+func TestLiveFetcher_GeminiAndAnthropic(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/gemini/models" && r.Header.Get("X-Goog-Api-Key") == "g-key":
+			w.Write([]byte(`{"models":[
+				{"name":"models/gemini-2.5-flash","displayName":"Gemini 2.5 Flash","inputTokenLimit":1048576,"outputTokenLimit":65536,"supportedGenerationMethods":["generateContent","countTokens"]},
+				{"name":"models/text-embedding-004","supportedGenerationMethods":["embedContent"]}]}`))
+		case r.URL.Path == "/anthropic/models" && r.Header.Get("X-Api-Key") == "a-key" && r.Header.Get("Anthropic-Version") != "":
+			w.Write([]byte(`{"data":[{"id":"claude-sonnet-4-20250514","display_name":"Claude Sonnet 4"}]}`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	t.Cleanup(server.Close)
+	fetcher := newLiveFetcher(defaultTimeout)
+
+	gemini, err := fetcher.fetch(context.Background(), models.LLMProviderGemini, "g-key", server.URL+"/gemini")
+	require.NoError(t, err)
+	assert.Equal(t, []liveModel{{ID: "gemini-2.5-flash", Name: "Gemini 2.5 Flash", ContextWindow: 1048576, MaxOutputTokens: 65536}}, gemini)
+
+	anthropic, err := fetcher.fetch(context.Background(), models.LLMProviderAnthropic, "a-key", server.URL+"/anthropic/")
+	require.NoError(t, err)
+	assert.Equal(t, []liveModel{{ID: "claude-sonnet-4-20250514", Name: "Claude Sonnet 4"}}, anthropic)
+}
+
+func TestPrice(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider models.LLMProvider
+		model    string
+		want     *Pricing
+	}{
+		{"exact", models.LLMProviderOpenAI, "gpt-4o", usd(2.5, 10)},
+		{"dated snapshot matches longest prefix", models.LLMProviderOpenAI, "gpt-4o-mini-2024-07-18", usd(0.15, 0.6)},
+		{"azure priced as openai", models.LLMProviderAzureOpenAI, "gpt-4.1-mini", usd(0.4, 1.6)},
+		{"bedrock", models.LLMProviderBedrock, "amazon.titan-text-lite-v1", usd(0.15, 0.2)},
+		{"cohere", models.LLMProviderCohere, "command-r-08-2024", usd(0.15, 0.6)},
+		{"unknown model", models.LLMProviderAnthropic, "claude-next", nil},
+		{"no prefix match without separator", models.LLMProviderOpenAI, "gpt-4omni", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Price(tt.provider, tt.model))
+		})
+	}
+}
+
+func TestPricing_Cost(t *testing.T) {
+	assert.InDelta(t, 0.0125, usd(2.5, 10).Cost(1000, 1000), 1e-9)
+}
@@ -0,0 +1,77 @@
+// Package maintenance provides instance-wide maintenance controls such as read-only mode.
+package maintenance
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultReadOnlyMessage is returned to clients when no custom message is set.
+const DefaultReadOnlyMessage = "MBFlow is in read-only mode for maintenance. Please try again later."
+
+// ReadOnlyStatus describes the current read-only state.
+type ReadOnlyStatus struct {
+	Enabled   bool       `json:"enabled"`
+	Message   string     `json:"message,omitempty"`
+	EnabledBy string     `json:"enabled_by,omitempty"`
+	EnabledAt *time.Time `json:"enabled_at,omitempty"`
+}
+
+// ReadOnlyMode is a process-wide switch that rejects mutations while enabled. Besides the
+// APIs, it pauses cron, event and email triggers and the execution worker.
+// It is safe for concurrent use. The state is held in memory, so in multi-replica
+// deployments it must be toggled on each instance (or set via configuration).
+type ReadOnlyMode struct {
+	mu     sync.RWMutex
+	status ReadOnlyStatus
+}
+
+// NewReadOnlyMode creates a read-only switch with the given initial state.
+func NewReadOnlyMode(enabled bool, message string) *ReadOnlyMode {
+	m := &ReadOnlyMode{}
+	if enabled {
+		m.Enable(message, "config")
+	}
+	return m
+}
+
+// Enable turns read-only mode on. An empty message falls back to DefaultReadOnlyMessage.
+func (m *ReadOnlyMode) Enable(message, enabledBy string) {
+	if message == "" {
+		message = DefaultReadOnlyMessage
+	}
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.status = ReadOnlyStatus{
+		Enabled:   true,
+		Message:   message,
+		EnabledBy: enabledBy,
+		EnabledAt: &now,
+	}
+}
+
+// Disable turns read-only mode off.
+func (m *ReadOnlyMode) Disable() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.status = ReadOnlyStatus{}
+}
+
+// Enabled reports whether read-only mode is on and the message to show clients.
+func (m *ReadOnlyMode) Enabled() (bool, string) {
+	if m == nil {
+		return false, ""
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.status.Enabled, m.status.Message
+}
+
+// Status returns a snapshot of the current state.
+func (m *ReadOnlyMode) Status() ReadOnlyStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.status
+}
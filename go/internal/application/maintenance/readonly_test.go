@@ -0,0 +1,44 @@
+package maintenance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadOnlyMode_Toggle(t *testing.T) {
+	mode := NewReadOnlyMode(false, "")
+
+	enabled, _ := mode.Enabled()
+	assert.False(t, enabled)
+
+	mode.Enable("", "admin-1")
+	enabled, message := mode.Enabled()
+	assert.True(t, enabled)
+	assert.Equal(t, DefaultReadOnlyMessage, message)
+
+	status := mode.Status()
+	assert.Equal(t, "admin-1", status.EnabledBy)
+	assert.NotNil(t, status.EnabledAt)
+
+	mode.Disable()
+	enabled, message = mode.Enabled()
+	assert.False(t, enabled)
+	assert.Empty(t, message)
+}
+
+func TestReadOnlyMode_FromConfig(t *testing.T) {
+	mode := NewReadOnlyMode(true, "Upgrading database")
+
+	enabled, message := mode.Enabled()
+	assert.True(t, enabled)
+	assert.Equal(t, "Upgrading database", message)
+	assert.Equal(t, "config", mode.Status().EnabledBy)
+}
+
+func TestReadOnlyMode_NilIsDisabled(t *testing.T) {
+	var mode *ReadOnlyMode
+
+	enabled, _ := mode.Enabled()
+	assert.False(t, enabled)
+}
@@ -115,6 +115,14 @@ func (m *ObserverManager) notifyObserver(ctx context.Context, obs Observer, even
 		}
 	}()
 
+	// Transient events only reach live observers
+	if event.Type.IsTransient() {
+		live, ok := obs.(LiveObserver)
+		if !ok || !live.ReceivesTransientEvents() {
+			return
+		}
+	}
+
 	// Check filter
 	filter := obs.Filter()
 	if filter != nil && !filter.ShouldNotify(event) {
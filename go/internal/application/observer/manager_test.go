@@ -382,6 +382,27 @@ func TestObserverManager_Notify(t *testing.T) {
 		// All notifications should have been received
 		assert.Equal(t, numNotifications, obs.GetCallCount())
 	})
+
+	t.Run("transient events only reach live observers", func(t *testing.T) {
+		mgr := NewObserverManager()
+		obs := NewMockObserver("database")
+		live := &LiveMockObserver{MockObserver: NewMockObserver("websocket")}
+		mgr.Register(obs)
+		mgr.Register(live)
+
+		mgr.Notify(context.Background(), Event{
+			Type:        EventTypeNodeOutputDelta,
+			ExecutionID: "exec-123",
+			Status:      "running",
+			Metadata:    map[string]any{"delta": "Hel", "index": int64(0)},
+		})
+
+		// Give goroutines time to process
+		time.Sleep(10 * time.Millisecond)
+
+		assert.Equal(t, 0, obs.GetCallCount())
+		assert.Equal(t, 1, live.GetCallCount())
+	})
 }
 
 func TestObserverManager_Count(t *testing.T) {
@@ -441,3 +462,12 @@ func (p *PanicObserver) Filter() EventFilter {
 func (p *PanicObserver) OnEvent(ctx context.Context, event Event) error {
 	panic("intentional panic for testing")
 }
+
+// Test helper: LiveMockObserver is a MockObserver that receives transient events
+type LiveMockObserver struct {
+	*MockObserver
+}
+
+func (l *LiveMockObserver) ReceivesTransientEvents() bool {
+	return true
+}
@@ -2,6 +2,7 @@ package observer
 
 import (
 	"context"
+	"strings"
 	"time"
 )
 
@@ -57,6 +58,9 @@ const (
 	EventTypeExecutionStarted   EventType = "execution.started"
 	EventTypeExecutionCompleted EventType = "execution.completed"
 	EventTypeExecutionFailed    EventType = "execution.failed"
+	EventTypeExecutionPaused    EventType = "execution.paused"
+	EventTypeExecutionResumed   EventType = "execution.resumed"
+	EventTypeExecutionCancelled EventType = "execution.cancelled"
 	EventTypeWaveStarted        EventType = "wave.started"
 	EventTypeWaveCompleted      EventType = "wave.completed"
 	EventTypeNodeStarted        EventType = "node.started"
@@ -64,9 +68,52 @@ const (
 	EventTypeNodeFailed         EventType = "node.failed"
 	EventTypeNodeSkipped        EventType = "node.skipped"
 	EventTypeNodeRetrying       EventType = "node.retrying"
+	EventTypeNodeSuspended      EventType = "node.suspended"
+	EventTypeNodeTimedOut       EventType = "node.timed_out"
 	EventTypeExecutionTimeout   EventType = "execution.timeout"
+
+	// EventTypeNodeCompensated is emitted for a completed node once its compensation node has
+	// undone it after a later node failed; Metadata holds the compensation node under
+	// "compensation_node_id"
+	EventTypeNodeCompensated EventType = "node.compensated"
+
+	// EventTypeNodeCancelled is emitted for a running node aborted because its execution was
+	// cancelled; Metadata holds "partial_output" if the node produced output before it stopped
+	EventTypeNodeCancelled EventType = "node.cancelled"
+
+	// EventTypeExecutionDeadLettered follows execution.failed once the failed execution is parked
+	// in the dead-letter queue; Metadata holds the entry under "dead_letter_id"
+	EventTypeExecutionDeadLettered EventType = "execution.dead_lettered"
+
+	EventTypeNodeAssertionFailed EventType = "node.assertion_failed"
+	EventTypeNodeCircuitOpen     EventType = "node.circuit_open"
+
+	// EventTypeNodeOutputDelta carries incremental output of a running node, e.g. streamed
+	// LLM tokens, with the chunk under "delta" and its position under "index" in Metadata
+	EventTypeNodeOutputDelta EventType = "node.output_delta"
 )
 
+// IsTransient reports whether events of the type only matter while the execution is watched
+// live. Transient events are not stored and only reach observers implementing LiveObserver.
+func (t EventType) IsTransient() bool {
+	return t == EventTypeNodeOutputDelta
+}
+
+// LiveObserver is implemented by observers that forward events to live clients and want
+// transient events such as streamed output.
+type LiveObserver interface {
+	Observer
+
+	// ReceivesTransientEvents reports whether the observer receives transient events
+	ReceivesTransientEvents() bool
+}
+
+// IsExecutionEvent reports whether events of the type are about an execution as a whole,
+// such as execution.started or execution.failed, rather than one of its waves or nodes.
+func (t EventType) IsExecutionEvent() bool {
+	return strings.HasPrefix(string(t), "execution.")
+}
+
 // EventFilter defines filtering criteria for events
 type EventFilter interface {
 	ShouldNotify(event Event) bool
@@ -40,6 +40,22 @@ func (h *WebSocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Get execution ID from query parameter (optional)
 	executionID := r.URL.Query().Get("execution_id")
 
+	h.serve(w, r, "execution_id", executionID, func(clientID string, conn *websocket.Conn) *WebSocketClient {
+		return NewWebSocketClient(clientID, conn, h.hub, executionID)
+	})
+}
+
+// ServeWorkflowWatch handles WebSocket upgrade requests of clients watching the executions
+// of a workflow, who receive a summary of each execution event of the workflow
+func (h *WebSocketHandler) ServeWorkflowWatch(w http.ResponseWriter, r *http.Request, workflowID string) {
+	h.serve(w, r, "workflow_id", workflowID, func(clientID string, conn *websocket.Conn) *WebSocketClient {
+		return NewWorkflowWatchClient(clientID, conn, h.hub, workflowID)
+	})
+}
+
+// serve upgrades the connection and registers the client created for it. The client
+// watches what scopeKey names, e.g. an execution ID, which its welcome message includes.
+func (h *WebSocketHandler) serve(w http.ResponseWriter, r *http.Request, scopeKey, scope string, newClient func(clientID string, conn *websocket.Conn) *WebSocketClient) {
 	// Upgrade HTTP connection to WebSocket
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
@@ -51,18 +67,18 @@ func (h *WebSocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	// Create new client
 	clientID := uuid.New().String()
-	client := NewWebSocketClient(clientID, conn, h.hub, executionID)
+	client := newClient(clientID, conn)
 
 	// Register client with hub
 	h.hub.Register(client)
 
 	// Send welcome message
 	welcomeMsg := map[string]any{
-		"type":         "control",
-		"message":      "Connected to MBFlow WebSocket",
-		"client_id":    clientID,
-		"execution_id": executionID,
-		"timestamp":    time.Now().Format(time.RFC3339),
+		"type":      "control",
+		"message":   "Connected to MBFlow WebSocket",
+		"client_id": clientID,
+		scopeKey:    scope,
+		"timestamp": time.Now().Format(time.RFC3339),
 	}
 
 	if data, err := json.Marshal(welcomeMsg); err == nil {
@@ -79,7 +95,7 @@ func (h *WebSocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if h.logger != nil {
 		h.logger.Info("WebSocket connection established",
 			"client_id", clientID,
-			"execution_id", executionID,
+			scopeKey, scope,
 			"remote_addr", r.RemoteAddr,
 		)
 	}
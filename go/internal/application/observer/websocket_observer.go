@@ -27,6 +27,7 @@ type WebSocketClient struct {
 	send          chan []byte
 	hub           *WebSocketHub
 	executionID   string // Filter events by execution ID (optional)
+	workflowID    string // Watch the executions of a workflow instead (optional)
 	subscriptions map[EventType]bool
 	mu            sync.RWMutex
 }
@@ -64,6 +65,8 @@ type EventPayload struct {
 	DurationMs  *int64         `json:"duration_ms,omitempty"`
 	Error       *string        `json:"error,omitempty"`
 	Output      map[string]any `json:"output,omitempty"`
+	Delta       *string        `json:"delta,omitempty"`       // Output chunk of node.output_delta events
+	DeltaIndex  *int64         `json:"delta_index,omitempty"` // Position of the chunk within the node output
 }
 
 // WebSocketObserverOption configures WebSocketObserver
@@ -124,6 +127,11 @@ func (o *WebSocketObserver) Filter() EventFilter {
 	return o.filter
 }
 
+// ReceivesTransientEvents returns true: clients render streamed node output live
+func (o *WebSocketObserver) ReceivesTransientEvents() bool {
+	return true
+}
+
 // OnEvent handles event by broadcasting to WebSocket clients
 func (o *WebSocketObserver) OnEvent(ctx context.Context, event Event) error {
 	// Convert to WebSocket message
@@ -144,6 +152,14 @@ func (o *WebSocketObserver) OnEvent(ctx context.Context, event Event) error {
 	// Broadcast to all connected clients
 	o.hub.BroadcastToExecution(event.ExecutionID, data)
 
+	// Workflow watchers get summaries of execution events, without their output
+	if event.WorkflowID != "" && event.Type.IsExecutionEvent() {
+		message.Event.Output = nil
+		if summary, err := json.Marshal(message); err == nil {
+			o.hub.BroadcastToWorkflow(event.WorkflowID, summary)
+		}
+	}
+
 	return nil
 }
 
@@ -169,6 +185,15 @@ func (o *WebSocketObserver) eventToMessage(event Event) *WebSocketMessage {
 		payload.Error = &errStr
 	}
 
+	if event.Type == EventTypeNodeOutputDelta {
+		if delta, ok := event.Metadata["delta"].(string); ok {
+			payload.Delta = &delta
+		}
+		if index, ok := event.Metadata["index"].(int64); ok {
+			payload.DeltaIndex = &index
+		}
+	}
+
 	return &WebSocketMessage{
 		Type:      "event",
 		Event:     payload,
@@ -248,23 +273,39 @@ func (h *WebSocketHub) Broadcast(message []byte) {
 
 // BroadcastToExecution broadcasts a message to clients subscribed to specific execution
 func (h *WebSocketHub) BroadcastToExecution(executionID string, message []byte) {
+	// Send to clients that:
+	// 1. Have no execution filter (want all events), OR
+	// 2. Are subscribed to this specific execution
+	// Workflow watchers only get the summaries sent by BroadcastToWorkflow.
+	h.sendTo(message, func(client *WebSocketClient) bool {
+		return client.workflowID == "" && (client.executionID == "" || client.executionID == executionID)
+	})
+}
+
+// BroadcastToWorkflow broadcasts a message to clients watching the executions of a workflow
+func (h *WebSocketHub) BroadcastToWorkflow(workflowID string, message []byte) {
+	h.sendTo(message, func(client *WebSocketClient) bool {
+		return client.workflowID == workflowID
+	})
+}
+
+// sendTo sends a message to the connected clients it matches
+func (h *WebSocketHub) sendTo(message []byte, matches func(*WebSocketClient) bool) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
 	for client := range h.clients {
-		// Send to clients that:
-		// 1. Have no execution filter (want all events), OR
-		// 2. Are subscribed to this specific execution
-		if client.executionID == "" || client.executionID == executionID {
-			select {
-			case client.send <- message:
-			default:
-				// Client's send buffer is full, skip
-				if h.logger != nil {
-					h.logger.Warn("WebSocket client send buffer full, skipping message",
-						"client_id", client.ID,
-					)
-				}
+		if !matches(client) {
+			continue
+		}
+		select {
+		case client.send <- message:
+		default:
+			// Client's send buffer is full, skip
+			if h.logger != nil {
+				h.logger.Warn("WebSocket client send buffer full, skipping message",
+					"client_id", client.ID,
+				)
 			}
 		}
 	}
@@ -293,6 +334,15 @@ func NewWebSocketClient(id string, conn *websocket.Conn, hub *WebSocketHub, exec
 	}
 }
 
+// NewWorkflowWatchClient creates a new WebSocket client that receives summaries of the
+// execution events of a workflow: executions starting, completing or failing, without
+// node events or outputs
+func NewWorkflowWatchClient(id string, conn *websocket.Conn, hub *WebSocketHub, workflowID string) *WebSocketClient {
+	client := NewWebSocketClient(id, conn, hub, "")
+	client.workflowID = workflowID
+	return client
+}
+
 // ReadPump reads messages from the WebSocket connection
 func (c *WebSocketClient) ReadPump() {
 	defer func() {
@@ -238,6 +238,28 @@ func TestWebSocketObserver_eventToMessage(t *testing.T) {
 		require.NotNil(t, msg.Event.Error)
 		assert.Equal(t, "node failed", *msg.Event.Error)
 	})
+
+	t.Run("converts output delta event", func(t *testing.T) {
+		nodeID := "node-123"
+		event := Event{
+			Type:        EventTypeNodeOutputDelta,
+			ExecutionID: "exec-123",
+			WorkflowID:  "wf-456",
+			Timestamp:   time.Now(),
+			NodeID:      &nodeID,
+			Status:      "running",
+			Metadata:    map[string]any{"delta": "Hel", "index": int64(3)},
+		}
+
+		msg := obs.eventToMessage(event)
+
+		assert.Equal(t, "node.output_delta", msg.Event.EventType)
+		require.NotNil(t, msg.Event.Delta)
+		assert.Equal(t, "Hel", *msg.Event.Delta)
+		require.NotNil(t, msg.Event.DeltaIndex)
+		assert.Equal(t, int64(3), *msg.Event.DeltaIndex)
+		assert.True(t, obs.ReceivesTransientEvents())
+	})
 }
 
 func TestWebSocketHub_RegisterUnregister(t *testing.T) {
@@ -380,6 +402,58 @@ func TestWebSocketHub_BroadcastToExecution(t *testing.T) {
 	}
 }
 
+func TestWebSocketObserver_WorkflowWatchers(t *testing.T) {
+	log := logger.New(config.LoggingConfig{Level: "debug", Format: "json"})
+	hub := NewWebSocketHub(log)
+	obs := NewWebSocketObserver(hub)
+
+	watcher := NewWorkflowWatchClient("watcher", nil, hub, "wf-1")
+	other := NewWorkflowWatchClient("other", nil, hub, "wf-2")
+	all := NewWebSocketClient("all", nil, hub, "")
+	hub.Register(watcher)
+	hub.Register(other)
+	hub.Register(all)
+	time.Sleep(10 * time.Millisecond)
+
+	nodeID := "fetch"
+	durationMs := int64(1500)
+	events := []Event{
+		{Type: EventTypeExecutionStarted, ExecutionID: "exec-1", WorkflowID: "wf-1", Status: "running"},
+		{Type: EventTypeNodeCompleted, ExecutionID: "exec-1", WorkflowID: "wf-1", NodeID: &nodeID, Output: map[string]any{"body": "..."}},
+		{Type: EventTypeExecutionCompleted, ExecutionID: "exec-1", WorkflowID: "wf-1", Status: "completed",
+			Output: map[string]any{"result": "large"}, DurationMs: &durationMs},
+	}
+	for _, event := range events {
+		require.NoError(t, obs.OnEvent(context.Background(), event))
+	}
+
+	var received []EventPayload
+	for len(received) < 2 {
+		select {
+		case data := <-watcher.send:
+			var msg WebSocketMessage
+			require.NoError(t, json.Unmarshal(data, &msg))
+			received = append(received, *msg.Event)
+		case <-time.After(100 * time.Millisecond):
+			t.Fatalf("watcher received %d of 2 summaries", len(received))
+		}
+	}
+	assert.Equal(t, "execution.started", received[0].EventType)
+	assert.Equal(t, "execution.completed", received[1].EventType)
+	assert.Equal(t, "exec-1", received[1].ExecutionID)
+	assert.Equal(t, &durationMs, received[1].DurationMs)
+	assert.Nil(t, received[1].Output, "summaries leave out outputs")
+
+	select {
+	case <-watcher.send:
+		t.Fatal("watcher should not receive node events")
+	case <-other.send:
+		t.Fatal("watchers of other workflows should not receive the events")
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.Len(t, all.send, 3, "clients watching all executions still receive every event")
+}
+
 func TestWebSocketHub_ClientCount(t *testing.T) {
 	log := logger.New(config.LoggingConfig{Level: "debug", Format: "json"})
 	hub := NewWebSocketHub(log)
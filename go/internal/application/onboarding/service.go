@@ -0,0 +1,368 @@
+// Package onboarding provisions a new workspace in one call: sample workflows from
+// the built-in templates, a default file storage resource, a starter service key and
+// a demo webhook trigger, and starts a first run so that new users see a successful
+// execution right away. The result is recorded in the user's metadata, which also
+// keeps a workspace from being provisioned twice.
+package onboarding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/smilemakc/mbflow/go/internal/application/serviceapi"
+	"github.com/smilemakc/mbflow/go/internal/application/servicekey"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+const (
+	// metadataKey is the user metadata key holding the onboarding State
+	metadataKey = "onboarding"
+
+	// DefaultStorageName is the name of the file storage resource created for the workspace
+	DefaultStorageName = "Default Storage"
+
+	// StarterKeyName is the name of the service key created for the workspace
+	StarterKeyName = "Starter key"
+
+	// sampleProfile is the launch profile holding a template's sample input
+	sampleProfile = "sample"
+)
+
+// Operations creates workflows and triggers and starts executions. It is implemented by serviceapi.Operations.
+type Operations interface {
+	CreateWorkflow(ctx context.Context, params serviceapi.CreateWorkflowParams) (*models.Workflow, error)
+	DeleteWorkflow(ctx context.Context, params serviceapi.DeleteWorkflowParams) error
+	CreateTrigger(ctx context.Context, params serviceapi.CreateTriggerParams) (*models.Trigger, error)
+	DeleteTrigger(ctx context.Context, params serviceapi.DeleteTriggerParams) error
+	StartExecution(ctx context.Context, params serviceapi.StartExecutionParams) (*models.Execution, error)
+}
+
+// UserStore loads and saves users.
+type UserStore interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*storagemodels.UserModel, error)
+	Update(ctx context.Context, user *storagemodels.UserModel) error
+}
+
+// ResourceStore creates and lists resources.
+type ResourceStore interface {
+	Create(ctx context.Context, resource models.Resource) error
+	GetByOwnerAndType(ctx context.Context, ownerID string, resourceType models.ResourceType) ([]models.Resource, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// KeyIssuer creates and deletes service keys. It is implemented by servicekey.Service.
+type KeyIssuer interface {
+	CreateKey(ctx context.Context, userID uuid.UUID, name, description string, createdBy uuid.UUID, expiresInDays *int) (*servicekey.CreateResult, error)
+	DeleteKey(ctx context.Context, id uuid.UUID) error
+}
+
+// Request selects what to provision. The zero value provisions everything.
+type Request struct {
+	// Templates lists the template keys to create; empty means all templates
+	Templates []string
+	// SkipAPIKey leaves out the starter service key
+	SkipAPIKey bool
+	// SkipSampleRun leaves out the first run of the first workflow
+	SkipSampleRun bool
+}
+
+// ProvisionedWorkflow is a workflow created from a template.
+type ProvisionedWorkflow struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Template string `json:"template"`
+}
+
+// State records what onboarding provisioned for a user.
+type State struct {
+	CompletedAt       time.Time             `json:"completed_at"`
+	Workflows         []ProvisionedWorkflow `json:"workflows"`
+	StorageResourceID string                `json:"storage_resource_id"`
+	TriggerID         string                `json:"trigger_id,omitempty"`
+	WebhookPath       string                `json:"webhook_path,omitempty"`
+	ServiceKeyID      string                `json:"service_key_id,omitempty"`
+	SampleExecutionID string                `json:"sample_execution_id,omitempty"`
+}
+
+// Result is the outcome of Provision.
+type Result struct {
+	State
+	// ServiceKey is the plaintext starter key; it is returned only once
+	ServiceKey string `json:"service_key,omitempty"`
+}
+
+// Service provisions new workspaces.
+type Service struct {
+	ops       Operations
+	users     UserStore
+	resources ResourceStore
+	keys      KeyIssuer
+	logger    *logger.Logger
+}
+
+// NewService creates a new onboarding service. keys may be nil, in which case no
+// starter key is created.
+func NewService(ops Operations, users UserStore, resources ResourceStore, keys KeyIssuer, log *logger.Logger) *Service {
+	return &Service{
+		ops:       ops,
+		users:     users,
+		resources: resources,
+		keys:      keys,
+		logger:    log,
+	}
+}
+
+// GetState returns what onboarding provisioned for the user, or nil when the user
+// has not been onboarded.
+func (s *Service) GetState(ctx context.Context, userID uuid.UUID) (*State, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return stateOf(user)
+}
+
+// Provision sets up the user's workspace. It fails with models.ErrAlreadyOnboarded
+// when the workspace was provisioned before. Resources created before a failing step
+// are removed again.
+func (s *Service) Provision(ctx context.Context, userID uuid.UUID, req Request) (*Result, error) {
+	templates, err := selectTemplates(req.Templates)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if state, err := stateOf(user); err != nil {
+		return nil, err
+	} else if state != nil {
+		return nil, models.ErrAlreadyOnboarded
+	}
+
+	var rb rollback
+	result := &Result{}
+
+	storageID, created, err := s.defaultStorage(ctx, userID.String())
+	if err != nil {
+		return nil, err
+	}
+	if created {
+		rb.add(func(ctx context.Context) error { return s.resources.Delete(ctx, storageID) })
+	}
+	result.StorageResourceID = storageID
+
+	for _, t := range templates {
+		workflow, err := s.ops.CreateWorkflow(ctx, workflowParams(t, userID, storageID))
+		if err != nil {
+			rb.run(ctx, s.logger)
+			return nil, fmt.Errorf("failed to create workflow %q: %w", t.Key, err)
+		}
+		workflowID, _ := uuid.Parse(workflow.ID)
+		rb.add(func(ctx context.Context) error {
+			return s.ops.DeleteWorkflow(ctx, serviceapi.DeleteWorkflowParams{WorkflowID: workflowID})
+		})
+		result.Workflows = append(result.Workflows, ProvisionedWorkflow{ID: workflow.ID, Name: workflow.Name, Template: t.Key})
+	}
+
+	first, firstTemplate := result.Workflows[0], templates[0]
+	triggerConfig := map[string]any{}
+	if firstTemplate.SampleInput != nil {
+		triggerConfig["input"] = firstTemplate.SampleInput
+	}
+	trigger, err := s.ops.CreateTrigger(ctx, serviceapi.CreateTriggerParams{
+		WorkflowID:  first.ID,
+		Name:        first.Name + " webhook",
+		Description: "Demo trigger created by onboarding",
+		Type:        string(models.TriggerTypeWebhook),
+		Config:      triggerConfig,
+		Enabled:     true,
+	})
+	if err != nil {
+		rb.run(ctx, s.logger)
+		return nil, fmt.Errorf("failed to create demo trigger: %w", err)
+	}
+	triggerID, _ := uuid.Parse(trigger.ID)
+	rb.add(func(ctx context.Context) error {
+		return s.ops.DeleteTrigger(ctx, serviceapi.DeleteTriggerParams{TriggerID: triggerID})
+	})
+	result.TriggerID = trigger.ID
+	result.WebhookPath = "/api/v1/webhooks/" + trigger.ID
+
+	if !req.SkipAPIKey && s.keys != nil {
+		key, err := s.keys.CreateKey(ctx, userID, StarterKeyName, "Created by onboarding", userID, nil)
+		if err != nil {
+			rb.run(ctx, s.logger)
+			return nil, fmt.Errorf("failed to create starter key: %w", err)
+		}
+		keyID, _ := uuid.Parse(key.Key.ID)
+		rb.add(func(ctx context.Context) error { return s.keys.DeleteKey(ctx, keyID) })
+		result.ServiceKeyID = key.Key.ID
+		result.ServiceKey = key.PlainKey
+	}
+
+	if !req.SkipSampleRun {
+		params := serviceapi.StartExecutionParams{WorkflowID: first.ID}
+		if firstTemplate.SampleInput != nil {
+			params.Profile = sampleProfile
+		}
+		execution, err := s.ops.StartExecution(ctx, params)
+		if err != nil {
+			// The workspace is usable without the first run, so this is not fatal
+			s.logger.Warn("Failed to start sample execution", "error", err, "workflow_id", first.ID, "user_id", userID)
+		} else {
+			result.SampleExecutionID = execution.ID
+		}
+	}
+
+	result.CompletedAt = time.Now()
+	if err := s.saveState(ctx, user, &result.State); err != nil {
+		rb.run(ctx, s.logger)
+		return nil, err
+	}
+
+	s.logger.Info("Workspace onboarded",
+		"user_id", userID,
+		"workflows", len(result.Workflows),
+		"storage_resource_id", result.StorageResourceID,
+		"trigger_id", result.TriggerID,
+	)
+	return result, nil
+}
+
+// defaultStorage returns the user's default file storage resource, creating it when missing.
+func (s *Service) defaultStorage(ctx context.Context, ownerID string) (id string, created bool, err error) {
+	existing, err := s.resources.GetByOwnerAndType(ctx, ownerID, models.ResourceTypeFileStorage)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to list resources: %w", err)
+	}
+	for _, r := range existing {
+		if r != nil && r.GetName() == DefaultStorageName {
+			return r.GetID(), false, nil
+		}
+	}
+
+	resource := models.NewFileStorageResource(ownerID, DefaultStorageName)
+	resource.Description = "File storage created by onboarding"
+	if err := s.resources.Create(ctx, resource); err != nil {
+		return "", false, fmt.Errorf("failed to create file storage: %w", err)
+	}
+	return resource.ID, true, nil
+}
+
+func (s *Service) findUser(ctx context.Context, userID uuid.UUID) (*storagemodels.UserModel, error) {
+	user, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, models.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (s *Service) saveState(ctx context.Context, user *storagemodels.UserModel, state *State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode onboarding state: %w", err)
+	}
+	var value map[string]any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("failed to encode onboarding state: %w", err)
+	}
+
+	if user.Metadata == nil {
+		user.Metadata = make(storagemodels.JSONBMap)
+	}
+	user.Metadata[metadataKey] = value
+	if err := s.users.Update(ctx, user); err != nil {
+		delete(user.Metadata, metadataKey)
+		return fmt.Errorf("failed to save onboarding state: %w", err)
+	}
+	return nil
+}
+
+// stateOf reads the onboarding state from the user's metadata.
+func stateOf(user *storagemodels.UserModel) (*State, error) {
+	value, ok := user.Metadata[metadataKey]
+	if !ok || value == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read onboarding state: %w", err)
+	}
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to read onboarding state: %w", err)
+	}
+	return &state, nil
+}
+
+// selectTemplates resolves template keys, keeping the order of Templates.
+func selectTemplates(keys []string) ([]Template, error) {
+	all := Templates()
+	if len(keys) == 0 {
+		return all, nil
+	}
+
+	wanted := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if _, ok := FindTemplate(key); !ok {
+			return nil, fmt.Errorf("%w: %s", models.ErrOnboardingTemplateNotFound, key)
+		}
+		wanted[key] = true
+	}
+
+	selected := make([]Template, 0, len(wanted))
+	for _, t := range all {
+		if wanted[t.Key] {
+			selected = append(selected, t)
+		}
+	}
+	return selected, nil
+}
+
+func workflowParams(t Template, userID uuid.UUID, storageID string) serviceapi.CreateWorkflowParams {
+	params := serviceapi.CreateWorkflowParams{
+		Name:        t.Name,
+		Description: t.Description,
+		Metadata:    map[string]any{"onboarding_template": t.Key},
+		CreatedBy:   &userID,
+		Nodes:       t.Nodes,
+		Edges:       t.Edges,
+	}
+	if t.SampleInput != nil {
+		params.LaunchProfiles = map[string]*models.LaunchProfile{
+			sampleProfile: {Description: "Sample input", Input: t.SampleInput},
+		}
+	}
+	if t.StorageAlias != "" {
+		params.Resources = []serviceapi.ResourceInput{
+			{ResourceID: storageID, Alias: t.StorageAlias, AccessType: "write"},
+		}
+	}
+	return params
+}
+
+// rollback removes what a failed Provision created, newest first.
+type rollback []func(ctx context.Context) error
+
+func (r *rollback) add(undo func(ctx context.Context) error) {
+	*r = append(*r, undo)
+}
+
+func (r rollback) run(ctx context.Context, log *logger.Logger) {
+	for i := len(r) - 1; i >= 0; i-- {
+		if err := r[i](ctx); err != nil {
+			log.Warn("Failed to roll back onboarding", "error", err)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package onboarding
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smilemakc/mbflow/go/internal/application/serviceapi"
+	"github.com/smilemakc/mbflow/go/internal/application/servicekey"
+	"github.com/smilemakc/mbflow/go/internal/config"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/executor/builtin"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+type mockOperations struct {
+	workflows        map[string]serviceapi.CreateWorkflowParams
+	deletedWorkflows []string
+	triggers         []serviceapi.CreateTriggerParams
+	deletedTriggers  []string
+	executions       []serviceapi.StartExecutionParams
+	executionErr     error
+}
+
+func newMockOperations() *mockOperations {
+	return &mockOperations{workflows: make(map[string]serviceapi.CreateWorkflowParams)}
+}
+
+func (m *mockOperations) CreateWorkflow(ctx context.Context, params serviceapi.CreateWorkflowParams) (*models.Workflow, error) {
+	id := uuid.NewString()
+	m.workflows[id] = params
+	return &models.Workflow{ID: id, Name: params.Name}, nil
+}
+
+func (m *mockOperations) DeleteWorkflow(ctx context.Context, params serviceapi.DeleteWorkflowParams) error {
+	m.deletedWorkflows = append(m.deletedWorkflows, params.WorkflowID.String())
+	return nil
+}
+
+func (m *mockOperations) CreateTrigger(ctx context.Context, params serviceapi.CreateTriggerParams) (*models.Trigger, error) {
+	m.triggers = append(m.triggers, params)
+	return &models.Trigger{ID: uuid.NewString(), WorkflowID: params.WorkflowID, Name: params.Name}, nil
+}
+
+func (m *mockOperations) DeleteTrigger(ctx context.Context, params serviceapi.DeleteTriggerParams) error {
+	m.deletedTriggers = append(m.deletedTriggers, params.TriggerID.String())
+	return nil
+}
+
+func (m *mockOperations) StartExecution(ctx context.Context, params serviceapi.StartExecutionParams) (*models.Execution, error) {
+	if m.executionErr != nil {
+		return nil, m.executionErr
+	}
+	m.executions = append(m.executions, params)
+	return &models.Execution{ID: uuid.NewString(), WorkflowID: params.WorkflowID}, nil
+}
+
+type mockUsers struct {
+	users map[uuid.UUID]*storagemodels.UserModel
+}
+
+func (m *mockUsers) FindByID(ctx context.Context, id uuid.UUID) (*storagemodels.UserModel, error) {
+	return m.users[id], nil
+}
+
+func (m *mockUsers) Update(ctx context.Context, user *storagemodels.UserModel) error {
+	m.users[user.ID] = user
+	return nil
+}
+
+type mockResources struct {
+	resources []models.Resource
+	deleted   []string
+}
+
+func (m *mockResources) Create(ctx context.Context, resource models.Resource) error {
+	fs := resource.(*models.FileStorageResource)
+	fs.ID = uuid.NewString()
+	m.resources = append(m.resources, fs)
+	return nil
+}
+
+func (m *mockResources) GetByOwnerAndType(ctx context.Context, ownerID string, resourceType models.ResourceType) ([]models.Resource, error) {
+	var result []models.Resource
+	for _, r := range m.resources {
+		if r.GetOwnerID() == ownerID && r.GetType() == resourceType {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockResources) Delete(ctx context.Context, id string) error {
+	m.deleted = append(m.deleted, id)
+	return nil
+}
+
+type mockKeys struct {
+	created []string
+	deleted []string
+	err     error
+}
+
+func (m *mockKeys) CreateKey(ctx context.Context, userID uuid.UUID, name, description string, createdBy uuid.UUID, expiresInDays *int) (*servicekey.CreateResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	key := &models.ServiceKey{ID: uuid.NewString(), UserID: userID.String(), Name: name}
+	m.created = append(m.created, key.ID)
+	return &servicekey.CreateResult{Key: key, PlainKey: "sk_test"}, nil
+}
+
+func (m *mockKeys) DeleteKey(ctx context.Context, id uuid.UUID) error {
+	m.deleted = append(m.deleted, id.String())
+	return nil
+}
+
+type testEnv struct {
+	service   *Service
+	ops       *mockOperations
+	users     *mockUsers
+	resources *mockResources
+	keys      *mockKeys
+	userID    uuid.UUID
+}
+
+func newTestEnv() *testEnv {
+	userID := uuid.New()
+	env := &testEnv{
+		ops:       newMockOperations(),
+		users:     &mockUsers{users: map[uuid.UUID]*storagemodels.UserModel{userID: {ID: userID, Username: "alice"}}},
+		resources: &mockResources{},
+		keys:      &mockKeys{},
+		userID:    userID,
+	}
+	log := logger.New(config.LoggingConfig{Level: "error", Format: "json"})
+	env.service = NewService(env.ops, env.users, env.resources, env.keys, log)
+	return env
+}
+
+func TestService_Provision(t *testing.T) {
+	env := newTestEnv()
+	ctx := context.Background()
+
+	result, err := env.service.Provision(ctx, env.userID, Request{})
+	require.NoError(t, err)
+
+	require.Len(t, result.Workflows, len(Templates()))
+	assert.Equal(t, "hello_world", result.Workflows[0].Template)
+	for _, wf := range result.Workflows {
+		params := env.ops.workflows[wf.ID]
+		assert.Equal(t, env.userID, *params.CreatedBy)
+		assert.Equal(t, wf.Template, params.Metadata["onboarding_template"])
+	}
+
+	require.Len(t, env.resources.resources, 1)
+	assert.Equal(t, DefaultStorageName, env.resources.resources[0].GetName())
+	assert.Equal(t, env.resources.resources[0].GetID(), result.StorageResourceID)
+
+	var report ProvisionedWorkflow
+	for _, wf := range result.Workflows {
+		if wf.Template == "order_report" {
+			report = wf
+		}
+	}
+	require.NotEmpty(t, report.ID)
+	assert.Equal(t, []serviceapi.ResourceInput{{ResourceID: result.StorageResourceID, Alias: "files", AccessType: "write"}},
+		env.ops.workflows[report.ID].Resources)
+
+	require.Len(t, env.ops.triggers, 1)
+	trigger := env.ops.triggers[0]
+	assert.Equal(t, result.Workflows[0].ID, trigger.WorkflowID)
+	assert.Equal(t, "webhook", trigger.Type)
+	assert.True(t, trigger.Enabled)
+	assert.Equal(t, map[string]any{"name": "mbflow"}, trigger.Config["input"])
+	assert.Equal(t, "/api/v1/webhooks/"+result.TriggerID, result.WebhookPath)
+
+	assert.Equal(t, "sk_test", result.ServiceKey)
+	assert.Equal(t, env.keys.created, []string{result.ServiceKeyID})
+
+	require.Len(t, env.ops.executions, 1)
+	assert.Equal(t, result.Workflows[0].ID, env.ops.executions[0].WorkflowID)
+	assert.Equal(t, "sample", env.ops.executions[0].Profile)
+	assert.NotEmpty(t, result.SampleExecutionID)
+
+	state, err := env.service.GetState(ctx, env.userID)
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	assert.Equal(t, result.TriggerID, state.TriggerID)
+	assert.Equal(t, result.Workflows, state.Workflows)
+
+	_, err = env.service.Provision(ctx, env.userID, Request{})
+	assert.ErrorIs(t, err, models.ErrAlreadyOnboarded)
+	assert.Len(t, env.ops.workflows, len(Templates()))
+}
+
+func TestService_Provision_Options(t *testing.T) {
+	env := newTestEnv()
+	env.resources.resources = []models.Resource{func() models.Resource {
+		r := models.NewFileStorageResource(env.userID.String(), DefaultStorageName)
+		r.ID = uuid.NewString()
+		return r
+	}()}
+
+	result, err := env.service.Provision(context.Background(), env.userID, Request{
+		Templates:     []string{"order_report", "filter_items"},
+		SkipAPIKey:    true,
+		SkipSampleRun: true,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, result.Workflows, 2)
+	assert.Equal(t, "filter_items", result.Workflows[0].Template)
+	assert.Equal(t, "order_report", result.Workflows[1].Template)
+	assert.Len(t, env.resources.resources, 1, "existing storage is reused")
+	assert.Equal(t, env.resources.resources[0].GetID(), result.StorageResourceID)
+	assert.Empty(t, result.ServiceKey)
+	assert.Empty(t, env.keys.created)
+	assert.Empty(t, env.ops.executions)
+}
+
+func TestService_Provision_Errors(t *testing.T) {
+	t.Run("unknown template", func(t *testing.T) {
+		env := newTestEnv()
+		_, err := env.service.Provision(context.Background(), env.userID, Request{Templates: []string{"nope"}})
+		assert.ErrorIs(t, err, models.ErrOnboardingTemplateNotFound)
+		assert.Empty(t, env.ops.workflows)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		env := newTestEnv()
+		_, err := env.service.Provision(context.Background(), uuid.New(), Request{})
+		assert.ErrorIs(t, err, models.ErrUserNotFound)
+	})
+
+	t.Run("rolls back on failure", func(t *testing.T) {
+		env := newTestEnv()
+		env.keys.err = errors.New("too many keys")
+
+		_, err := env.service.Provision(context.Background(), env.userID, Request{})
+		require.Error(t, err)
+
+		assert.Len(t, env.ops.deletedWorkflows, len(Templates()))
+		assert.Len(t, env.ops.deletedTriggers, 1)
+		assert.Equal(t, []string{env.resources.resources[0].GetID()}, env.resources.deleted)
+
+		state, err := env.service.GetState(context.Background(), env.userID)
+		require.NoError(t, err)
+		assert.Nil(t, state)
+	})
+
+	t.Run("sample run failure is not fatal", func(t *testing.T) {
+		env := newTestEnv()
+		env.ops.executionErr = errors.New("engine busy")
+
+		result, err := env.service.Provision(context.Background(), env.userID, Request{})
+		require.NoError(t, err)
+		assert.Empty(t, result.SampleExecutionID)
+		assert.Empty(t, env.ops.deletedWorkflows)
+	})
+}
+
+func TestTemplates_FilterItems(t *testing.T) {
+	tmpl, ok := FindTemplate("filter_items")
+	require.True(t, ok)
+
+	transform := builtin.NewTransformExecutor()
+	var output any = tmpl.SampleInput
+	for _, node := range tmpl.Nodes {
+		var err error
+		output, err = transform.Execute(context.Background(), node.Config, output)
+		require.NoError(t, err, node.ID)
+	}
+
+	result, ok := output.(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, 2, result["count"])
+	assert.EqualValues(t, 42, result["total"])
+}
+
+func TestTemplates_ValidNodes(t *testing.T) {
+	manager := executor.NewManager()
+	require.NoError(t, builtin.RegisterBuiltins(manager))
+	require.NoError(t, builtin.RegisterXLSX(manager, nil))
+
+	for _, tmpl := range Templates() {
+		for _, node := range tmpl.Nodes {
+			exec, err := manager.Get(node.Type)
+			require.NoError(t, err, "%s/%s", tmpl.Key, node.ID)
+			assert.NoError(t, exec.Validate(node.Config), "%s/%s", tmpl.Key, node.ID)
+		}
+	}
+}
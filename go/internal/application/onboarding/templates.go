@@ -0,0 +1,134 @@
+package onboarding
+
+import (
+	"github.com/smilemakc/mbflow/go/internal/application/serviceapi"
+)
+
+// Template is a sample workflow created for new workspaces.
+type Template struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	// StorageAlias is the alias under which the default file storage resource is
+	// attached to the workflow; empty when the workflow does not store files
+	StorageAlias string `json:"storage_alias,omitempty"`
+
+	// SampleInput is saved as the "sample" launch profile and used for the demo
+	// trigger and the first run
+	SampleInput map[string]any `json:"sample_input,omitempty"`
+
+	Nodes []serviceapi.NodeInput `json:"-"`
+	Edges []serviceapi.EdgeInput `json:"-"`
+}
+
+// Templates returns the built-in sample workflows. The first one is the workflow
+// that receives the demo trigger and the first run.
+func Templates() []Template {
+	return []Template{
+		{
+			Key:         "hello_world",
+			Name:        "Hello World",
+			Description: "Greets the caller by name. Run it, or call its webhook, to see a first execution.",
+			SampleInput: map[string]any{"name": "mbflow"},
+			Nodes: []serviceapi.NodeInput{
+				{
+					ID:   "greet",
+					Name: "Greet",
+					Type: "transform",
+					Config: map[string]any{
+						"type":     "template",
+						"template": "Hello, {{input.name}}! Your first workflow ran successfully.",
+					},
+					Position: map[string]any{"x": 250, "y": 150},
+				},
+			},
+		},
+		{
+			Key:         "filter_items",
+			Name:        "Filter Items",
+			Description: "Keeps the items of an order that cost more than 10 and totals them, using jq and an expression.",
+			SampleInput: map[string]any{
+				"items": []any{
+					map[string]any{"sku": "A-1", "price": 4.5},
+					map[string]any{"sku": "B-2", "price": 12},
+					map[string]any{"sku": "C-3", "price": 30},
+				},
+			},
+			Nodes: []serviceapi.NodeInput{
+				{
+					ID:   "filter",
+					Name: "Filter expensive items",
+					Type: "transform",
+					Config: map[string]any{
+						"type":   "jq",
+						"filter": "[.items[] | select(.price > 10)]",
+					},
+					Position: map[string]any{"x": 250, "y": 150},
+				},
+				{
+					ID:   "summarize",
+					Name: "Summarize",
+					Type: "transform",
+					Config: map[string]any{
+						"type":       "expression",
+						"expression": `{"count": len(input), "total": sum(map(input, #.price))}`,
+					},
+					Position: map[string]any{"x": 250, "y": 300},
+				},
+			},
+			Edges: []serviceapi.EdgeInput{
+				{ID: "filter-summarize", From: "filter", To: "summarize"},
+			},
+		},
+		{
+			Key:          "order_report",
+			Name:         "Order Report",
+			Description:  "Writes a list of orders to an Excel report in the workspace file storage.",
+			StorageAlias: "files",
+			Nodes: []serviceapi.NodeInput{
+				{
+					ID:   "orders",
+					Name: "Sample orders",
+					Type: "transform",
+					Config: map[string]any{
+						"type": "expression",
+						"expression": `[{"order": "A-100", "customer": "Acme", "total": 120.5}, ` +
+							`{"order": "A-101", "customer": "Globex", "total": 89.9}]`,
+					},
+					Position: map[string]any{"x": 250, "y": 150},
+				},
+				{
+					ID:   "report",
+					Name: "Write report",
+					Type: "xlsx",
+					Config: map[string]any{
+						"operation":  "write",
+						"storage_id": "{{resource.files.id}}",
+						"file_name":  "orders-report",
+						"sheet":      "Orders",
+						"columns": []any{
+							map[string]any{"key": "order", "header": "Order"},
+							map[string]any{"key": "customer", "header": "Customer"},
+							map[string]any{"key": "total", "header": "Total", "format": "number"},
+						},
+					},
+					Position: map[string]any{"x": 250, "y": 300},
+				},
+			},
+			Edges: []serviceapi.EdgeInput{
+				{ID: "orders-report", From: "orders", To: "report"},
+			},
+		},
+	}
+}
+
+// FindTemplate returns the built-in template with the given key.
+func FindTemplate(key string) (Template, bool) {
+	for _, t := range Templates() {
+		if t.Key == key {
+			return t, true
+		}
+	}
+	return Template{}, false
+}
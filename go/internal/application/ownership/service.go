@@ -0,0 +1,92 @@
+// Package ownership transfers workflows and resources between users and reports
+// what a departing user owns.
+package ownership
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+var (
+	// ErrInvalidTransfer is returned for transfers that cannot be performed as requested.
+	ErrInvalidTransfer = errors.New("invalid ownership transfer")
+)
+
+// UserLookup finds active (non-deleted) users.
+type UserLookup interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*storagemodels.UserModel, error)
+}
+
+// Service handles ownership reports and transfers.
+type Service struct {
+	repo  repository.OwnershipRepository
+	users UserLookup
+}
+
+// NewService creates a new ownership service.
+func NewService(repo repository.OwnershipRepository, users UserLookup) *Service {
+	return &Service{
+		repo:  repo,
+		users: users,
+	}
+}
+
+// Report returns everything owned by the user. It works for soft-deleted users too,
+// so admins can recover automation orphaned by an earlier deletion.
+func (s *Service) Report(ctx context.Context, userID uuid.UUID) (*models.OwnershipReport, error) {
+	return s.repo.GetOwnershipReport(ctx, userID.String())
+}
+
+// Transfer moves ownership from one user to another. The target must be an active user;
+// the source may already be deleted.
+func (s *Service) Transfer(ctx context.Context, transfer *models.OwnershipTransfer) (*models.OwnershipTransferResult, error) {
+	if transfer.FromUserID == transfer.ToUserID {
+		return nil, fmt.Errorf("%w: source and target user are the same", ErrInvalidTransfer)
+	}
+
+	for _, kind := range transfer.Kinds {
+		if !isKnownKind(kind) {
+			return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidTransfer, kind)
+		}
+	}
+	if len(transfer.WorkflowIDs) > 0 && !transfer.Includes(models.OwnershipKindWorkflows) {
+		return nil, fmt.Errorf("%w: workflow_ids given but workflows are not included", ErrInvalidTransfer)
+	}
+
+	toID, err := uuid.Parse(transfer.ToUserID)
+	if err != nil {
+		return nil, models.ErrInvalidID
+	}
+	if _, err := uuid.Parse(transfer.FromUserID); err != nil {
+		return nil, models.ErrInvalidID
+	}
+
+	target, err := s.users.FindByID(ctx, toID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find target user: %w", err)
+	}
+	if target == nil {
+		return nil, models.ErrUserNotFound
+	}
+	if !target.IsActive {
+		return nil, fmt.Errorf("%w: target user is not active", ErrInvalidTransfer)
+	}
+
+	return s.repo.TransferOwnership(ctx, transfer)
+}
+
+func isKnownKind(kind models.OwnershipKind) bool {
+	for _, k := range models.AllOwnershipKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package ownership
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+type mockOwnershipRepo struct {
+	transfers []*models.OwnershipTransfer
+}
+
+func (m *mockOwnershipRepo) GetOwnershipReport(ctx context.Context, userID string) (*models.OwnershipReport, error) {
+	return &models.OwnershipReport{UserID: userID}, nil
+}
+
+func (m *mockOwnershipRepo) TransferOwnership(ctx context.Context, transfer *models.OwnershipTransfer) (*models.OwnershipTransferResult, error) {
+	m.transfers = append(m.transfers, transfer)
+	return &models.OwnershipTransferResult{FromUserID: transfer.FromUserID, ToUserID: transfer.ToUserID, Workflows: 2}, nil
+}
+
+type mockUserLookup struct {
+	users map[uuid.UUID]*storagemodels.UserModel
+}
+
+func (m *mockUserLookup) FindByID(ctx context.Context, id uuid.UUID) (*storagemodels.UserModel, error) {
+	return m.users[id], nil
+}
+
+func newTestService(users ...*storagemodels.UserModel) (*Service, *mockOwnershipRepo) {
+	repo := &mockOwnershipRepo{}
+	lookup := &mockUserLookup{users: make(map[uuid.UUID]*storagemodels.UserModel)}
+	for _, u := range users {
+		lookup.users[u.ID] = u
+	}
+	return NewService(repo, lookup), repo
+}
+
+func TestService_Transfer(t *testing.T) {
+	from := uuid.New()
+	active := &storagemodels.UserModel{ID: uuid.New(), IsActive: true}
+	inactive := &storagemodels.UserModel{ID: uuid.New(), IsActive: false}
+
+	t.Run("success", func(t *testing.T) {
+		svc, repo := newTestService(active)
+		result, err := svc.Transfer(context.Background(), &models.OwnershipTransfer{
+			FromUserID: from.String(),
+			ToUserID:   active.ID.String(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Workflows)
+		assert.Len(t, repo.transfers, 1)
+	})
+
+	t.Run("same user", func(t *testing.T) {
+		svc, _ := newTestService(active)
+		_, err := svc.Transfer(context.Background(), &models.OwnershipTransfer{
+			FromUserID: active.ID.String(),
+			ToUserID:   active.ID.String(),
+		})
+		assert.ErrorIs(t, err, ErrInvalidTransfer)
+	})
+
+	t.Run("unknown kind", func(t *testing.T) {
+		svc, _ := newTestService(active)
+		_, err := svc.Transfer(context.Background(), &models.OwnershipTransfer{
+			FromUserID: from.String(),
+			ToUserID:   active.ID.String(),
+			Kinds:      []models.OwnershipKind{"secrets"},
+		})
+		assert.ErrorIs(t, err, ErrInvalidTransfer)
+	})
+
+	t.Run("workflow ids without workflows kind", func(t *testing.T) {
+		svc, _ := newTestService(active)
+		_, err := svc.Transfer(context.Background(), &models.OwnershipTransfer{
+			FromUserID:  from.String(),
+			ToUserID:    active.ID.String(),
+			Kinds:       []models.OwnershipKind{models.OwnershipKindResources},
+			WorkflowIDs: []string{uuid.NewString()},
+		})
+		assert.ErrorIs(t, err, ErrInvalidTransfer)
+	})
+
+	t.Run("inactive target", func(t *testing.T) {
+		svc, repo := newTestService(inactive)
+		_, err := svc.Transfer(context.Background(), &models.OwnershipTransfer{
+			FromUserID: from.String(),
+			ToUserID:   inactive.ID.String(),
+		})
+		assert.ErrorIs(t, err, ErrInvalidTransfer)
+		assert.Empty(t, repo.transfers)
+	})
+
+	t.Run("missing target", func(t *testing.T) {
+		svc, _ := newTestService()
+		_, err := svc.Transfer(context.Background(), &models.OwnershipTransfer{
+			FromUserID: from.String(),
+			ToUserID:   uuid.NewString(),
+		})
+		assert.ErrorIs(t, err, models.ErrUserNotFound)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		svc, _ := newTestService(active)
+		_, err := svc.Transfer(context.Background(), &models.OwnershipTransfer{
+			FromUserID: "not-a-uuid",
+			ToUserID:   active.ID.String(),
+		})
+		assert.ErrorIs(t, err, models.ErrInvalidID)
+	})
+}
@@ -0,0 +1,188 @@
+// Package quota enforces daily usage quotas on the executions of users and reports their
+// usage.
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/smilemakc/mbflow/go/internal/application/engine"
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+var _ engine.QuotaEnforcer = (*Service)(nil)
+
+// Accounts finds the billing accounts of users, which carry their quotas.
+type Accounts interface {
+	GetByUserID(ctx context.Context, userID string) (*models.Account, error)
+	SetQuota(ctx context.Context, id string, quota *models.UsageQuota) error
+}
+
+// Service counts the usage of users' executions per UTC day against their quotas.
+type Service struct {
+	accounts Accounts
+	usage    repository.UsageRepository
+	defaults models.UsageQuota
+	now      func() time.Time
+}
+
+// NewService creates a quota service. Users whose billing account has no quota, or who have
+// no account, get the default quota.
+func NewService(accounts Accounts, usage repository.UsageRepository, defaults models.UsageQuota) *Service {
+	return &Service{
+		accounts: accounts,
+		usage:    usage,
+		defaults: defaults,
+		now:      time.Now,
+	}
+}
+
+// Quota returns the quota of a user.
+func (s *Service) Quota(ctx context.Context, userID string) (*models.UsageQuota, error) {
+	account, err := s.accounts.GetByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, models.ErrAccountNotFound) {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	if account != nil && account.Quota != nil {
+		return account.Quota, nil
+	}
+	defaults := s.defaults
+	return &defaults, nil
+}
+
+// SetQuota sets the quota of a user's billing account; nil restores the default quota.
+func (s *Service) SetQuota(ctx context.Context, userID string, quota *models.UsageQuota) error {
+	if quota != nil {
+		if err := quota.Validate(); err != nil {
+			return err
+		}
+	}
+	account, err := s.accounts.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return s.accounts.SetQuota(ctx, account.ID, quota)
+}
+
+// Admit counts an execution of the user starting at the given time against their quota.
+// Once a limit of the day is reached the execution is rejected or, if the user's quota
+// queues executions and this one can wait, counted on the next day and started when it
+// begins, unless the executions already queued for that day fill its quota too. Callers
+// that are not users, such as system keys, are not limited.
+func (s *Service) Admit(ctx context.Context, userID string, at time.Time, canWait bool) (time.Time, error) {
+	if !isUser(userID) {
+		return time.Time{}, nil
+	}
+	quota, err := s.Quota(ctx, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	day := models.UsageDay(at)
+	counted, err := s.usage.CountExecution(ctx, userID, day, quota)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if counted {
+		return time.Time{}, nil
+	}
+	exceeded, err := s.exceeded(ctx, userID, day, quota)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	next := day.Add(24 * time.Hour)
+	if !canWait || quota.OverflowPolicy() != models.QuotaOverflowQueue {
+		return time.Time{}, fmt.Errorf("%w: %s on %s; the quota resets at %s",
+			models.ErrQuotaExceeded, exceeded, day.Format(time.DateOnly), next.Format(time.RFC3339))
+	}
+
+	counted, err = s.usage.CountExecution(ctx, userID, next, quota)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !counted {
+		queued, err := s.exceeded(ctx, userID, next, quota)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Time{}, fmt.Errorf("%w: %s on %s, and %s by executions queued for %s",
+			models.ErrQuotaExceeded, exceeded, day.Format(time.DateOnly), queued, next.Format(time.DateOnly))
+	}
+	return next, nil
+}
+
+// Refund gives back an execution counted by Admit on the day of at, for an execution that
+// could not be created.
+func (s *Service) Refund(ctx context.Context, userID string, at time.Time) error {
+	if !isUser(userID) {
+		return nil
+	}
+	return s.usage.Add(ctx, &models.DailyUsage{UserID: userID, Day: models.UsageDay(at), Executions: -1})
+}
+
+// Record adds the nodes an execution of the user ran, and the LLM tokens they used, to the
+// user's usage on the day of at.
+func (s *Service) Record(ctx context.Context, userID string, at time.Time, nodeRuns, llmTokens int64) error {
+	if !isUser(userID) {
+		return nil
+	}
+	return s.usage.Add(ctx, &models.DailyUsage{
+		UserID:    userID,
+		Day:       models.UsageDay(at),
+		NodeRuns:  nodeRuns,
+		LLMTokens: llmTokens,
+	})
+}
+
+// Status returns the usage of a user today against their quota.
+func (s *Service) Status(ctx context.Context, userID string) (*models.QuotaStatus, error) {
+	quota, err := s.Quota(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	today := models.UsageDay(s.now())
+	usage, err := s.usage.Get(ctx, userID, today)
+	if err != nil {
+		return nil, err
+	}
+	return &models.QuotaStatus{
+		Quota:    quota,
+		Usage:    usage,
+		Exceeded: quota.Exceeded(usage),
+		ResetsAt: today.Add(24 * time.Hour),
+	}, nil
+}
+
+// History returns the usage of a user over the last days, today included, oldest first.
+// Days without usage are left out.
+func (s *Service) History(ctx context.Context, userID string, days int) ([]*models.DailyUsage, error) {
+	today := models.UsageDay(s.now())
+	from := today.AddDate(0, 0, 1-days)
+	return s.usage.List(ctx, userID, from, today.Add(24*time.Hour))
+}
+
+// exceeded describes the limit of the quota the user's usage on the day reached, after an
+// execution could not be counted on it.
+func (s *Service) exceeded(ctx context.Context, userID string, day time.Time, quota *models.UsageQuota) (string, error) {
+	usage, err := s.usage.Get(ctx, userID, day)
+	if err != nil {
+		return "", err
+	}
+	if exceeded := quota.Exceeded(usage); exceeded != "" {
+		return exceeded, nil
+	}
+	// The usage dropped since, as when another start was refunded
+	return "daily quota reached", nil
+}
+
+// isUser reports whether the ID is that of a user, who has usage, rather than another caller.
+func isUser(userID string) bool {
+	_, err := uuid.Parse(userID)
+	return err == nil
+}
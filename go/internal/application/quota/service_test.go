@@ -0,0 +1,204 @@
+package quota
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+type mockAccounts struct {
+	accounts map[string]*models.Account
+}
+
+func (m *mockAccounts) GetByUserID(_ context.Context, userID string) (*models.Account, error) {
+	account, ok := m.accounts[userID]
+	if !ok {
+		return nil, models.ErrAccountNotFound
+	}
+	return account, nil
+}
+
+func (m *mockAccounts) SetQuota(_ context.Context, id string, quota *models.UsageQuota) error {
+	for _, account := range m.accounts {
+		if account.ID == id {
+			account.Quota = quota
+			return nil
+		}
+	}
+	return models.ErrAccountNotFound
+}
+
+type mockUsageRepo struct {
+	usage map[string]*models.DailyUsage
+}
+
+func newMockUsageRepo() *mockUsageRepo {
+	return &mockUsageRepo{usage: make(map[string]*models.DailyUsage)}
+}
+
+func (m *mockUsageRepo) key(userID string, day time.Time) string {
+	return userID + "/" + models.UsageDay(day).Format(time.DateOnly)
+}
+
+func (m *mockUsageRepo) Get(_ context.Context, userID string, day time.Time) (*models.DailyUsage, error) {
+	if usage, ok := m.usage[m.key(userID, day)]; ok {
+		copied := *usage
+		return &copied, nil
+	}
+	return &models.DailyUsage{UserID: userID, Day: models.UsageDay(day)}, nil
+}
+
+func (m *mockUsageRepo) Add(_ context.Context, usage *models.DailyUsage) error {
+	key := m.key(usage.UserID, usage.Day)
+	current, ok := m.usage[key]
+	if !ok {
+		current = &models.DailyUsage{UserID: usage.UserID, Day: models.UsageDay(usage.Day)}
+		m.usage[key] = current
+	}
+	current.Executions += usage.Executions
+	current.NodeRuns += usage.NodeRuns
+	current.LLMTokens += usage.LLMTokens
+	return nil
+}
+
+func (m *mockUsageRepo) CountExecution(ctx context.Context, userID string, day time.Time, quota *models.UsageQuota) (bool, error) {
+	usage, _ := m.Get(ctx, userID, day)
+	if quota.Exceeded(usage) != "" {
+		return false, nil
+	}
+	return true, m.Add(ctx, &models.DailyUsage{UserID: userID, Day: day, Executions: 1})
+}
+
+func (m *mockUsageRepo) List(_ context.Context, userID string, from, to time.Time) ([]*models.DailyUsage, error) {
+	var result []*models.DailyUsage
+	for day := models.UsageDay(from); day.Before(to); day = day.Add(24 * time.Hour) {
+		if usage, ok := m.usage[m.key(userID, day)]; ok {
+			result = append(result, usage)
+		}
+	}
+	return result, nil
+}
+
+var testNow = time.Date(2026, 10, 17, 15, 30, 0, 0, time.UTC)
+
+func newTestService(quota *models.UsageQuota) (*Service, *mockUsageRepo, string) {
+	userID := uuid.NewString()
+	accounts := &mockAccounts{accounts: map[string]*models.Account{
+		userID: {ID: uuid.NewString(), UserID: userID, Quota: quota},
+	}}
+	usage := newMockUsageRepo()
+	service := NewService(accounts, usage, models.UsageQuota{ExecutionsPerDay: 100})
+	service.now = func() time.Time { return testNow }
+	return service, usage, userID
+}
+
+func TestAdmit_RejectsOverQuota(t *testing.T) {
+	service, _, userID := newTestService(&models.UsageQuota{ExecutionsPerDay: 2})
+	ctx := context.Background()
+
+	for range 2 {
+		queuedUntil, err := service.Admit(ctx, userID, testNow, true)
+		require.NoError(t, err)
+		assert.True(t, queuedUntil.IsZero())
+	}
+
+	_, err := service.Admit(ctx, userID, testNow, true)
+	require.ErrorIs(t, err, models.ErrQuotaExceeded)
+	assert.Contains(t, err.Error(), "2 of 2 executions per day used on 2026-10-17")
+	assert.Contains(t, err.Error(), "resets at 2026-10-18T00:00:00Z")
+}
+
+func TestAdmit_QueuesOverQuotaForNextDay(t *testing.T) {
+	service, usage, userID := newTestService(&models.UsageQuota{
+		ExecutionsPerDay: 1,
+		Overflow:         models.QuotaOverflowQueue,
+	})
+	ctx := context.Background()
+	tomorrow := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	_, err := service.Admit(ctx, userID, testNow, true)
+	require.NoError(t, err)
+
+	_, err = service.Admit(ctx, userID, testNow, false)
+	require.ErrorIs(t, err, models.ErrQuotaExceeded, "executions that cannot wait are rejected")
+
+	queuedUntil, err := service.Admit(ctx, userID, testNow, true)
+	require.NoError(t, err)
+	assert.Equal(t, tomorrow, queuedUntil)
+	next, _ := usage.Get(ctx, userID, tomorrow)
+	assert.Equal(t, int64(1), next.Executions)
+
+	_, err = service.Admit(ctx, userID, testNow, true)
+	require.ErrorIs(t, err, models.ErrQuotaExceeded, "tomorrow's quota is taken by the queued execution")
+}
+
+func TestAdmit_RejectsOnceRecordedUsageReachesLimit(t *testing.T) {
+	service, _, userID := newTestService(&models.UsageQuota{LLMTokensPerDay: 1000})
+	ctx := context.Background()
+
+	_, err := service.Admit(ctx, userID, testNow, false)
+	require.NoError(t, err)
+	require.NoError(t, service.Record(ctx, userID, testNow, 3, 1200))
+
+	_, err = service.Admit(ctx, userID, testNow, false)
+	require.ErrorIs(t, err, models.ErrQuotaExceeded)
+	assert.Contains(t, err.Error(), "1200 of 1000 LLM tokens per day used")
+
+	status, err := service.Status(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), status.Usage.Executions)
+	assert.Equal(t, int64(3), status.Usage.NodeRuns)
+	assert.NotEmpty(t, status.Exceeded)
+}
+
+func TestRefund_GivesBackAdmittedExecution(t *testing.T) {
+	service, _, userID := newTestService(&models.UsageQuota{ExecutionsPerDay: 1})
+	ctx := context.Background()
+
+	_, err := service.Admit(ctx, userID, testNow, false)
+	require.NoError(t, err)
+	require.NoError(t, service.Refund(ctx, userID, testNow))
+
+	_, err = service.Admit(ctx, userID, testNow, false)
+	require.NoError(t, err, "the refunded execution no longer counts")
+	_, err = service.Admit(ctx, userID, testNow, false)
+	require.ErrorIs(t, err, models.ErrQuotaExceeded)
+}
+
+func TestAdmit_DoesNotLimitNonUsers(t *testing.T) {
+	service, usage, _ := newTestService(nil)
+
+	queuedUntil, err := service.Admit(context.Background(), "system", testNow, false)
+	require.NoError(t, err)
+	assert.True(t, queuedUntil.IsZero())
+	assert.Empty(t, usage.usage)
+}
+
+func TestQuota_FallsBackToDefault(t *testing.T) {
+	service, _, userID := newTestService(nil)
+	ctx := context.Background()
+
+	quota, err := service.Quota(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), quota.ExecutionsPerDay)
+
+	quota, err = service.Quota(ctx, uuid.NewString())
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), quota.ExecutionsPerDay, "users without an account get the default")
+
+	require.NoError(t, service.SetQuota(ctx, userID, &models.UsageQuota{ExecutionsPerDay: 5}))
+	quota, err = service.Quota(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), quota.ExecutionsPerDay)
+
+	err = service.SetQuota(ctx, userID, &models.UsageQuota{NodeRunsPerDay: -1})
+	var validationErr *models.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "node_runs_per_day", validationErr.Field)
+}
@@ -7,6 +7,7 @@ type OperationError struct {
 	Code       string
 	Message    string
 	HTTPStatus int
+	Details    map[string]any // Optional structured context, returned alongside the message
 }
 
 func (e *OperationError) Error() string {
@@ -0,0 +1,113 @@
+package serviceapi
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// MaxIdempotencyKeyLength is the longest idempotency key accepted.
+const MaxIdempotencyKeyLength = 255
+
+// IdempotencyStore remembers the executions started with an idempotency key, so that a retried
+// start returns the execution started first instead of starting another. Keys are kept per
+// workspace, the one of executor.Propagation.Tenant; it is empty for starts outside any. It is
+// implemented by cache.IdempotencyStore.
+type IdempotencyStore interface {
+	// Reserve claims an unused key for an execution about to start. For a key already used it
+	// returns the execution started with it, or "" while that one is still starting.
+	Reserve(ctx context.Context, workspaceID, key string) (executionID string, reserved bool, err error)
+	// Complete records the execution started for a reserved key.
+	Complete(ctx context.Context, workspaceID, key, executionID string) error
+	// Release frees a reserved key whose execution could not be started.
+	Release(ctx context.Context, workspaceID, key string) error
+}
+
+// idempotencyReservationTTL bounds how long a key stays reserved by a start that never
+// completes, such as one interrupted by a restart.
+const idempotencyReservationTTL = time.Minute
+
+// idempotencyScope scopes an idempotency key to the caller and the workflow, so the keys of
+// different users never collide.
+func idempotencyScope(params StartExecutionParams) string {
+	return params.Propagation.UserID + ":" + params.WorkflowID + ":" + params.IdempotencyKey
+}
+
+// validateIdempotencyKey validates an idempotency key given with a start.
+func validateIdempotencyKey(key string) error {
+	if len(key) > MaxIdempotencyKeyLength {
+		return NewValidationError("INVALID_IDEMPOTENCY_KEY", "idempotency key must be at most 255 characters")
+	}
+	return nil
+}
+
+// newIdempotencyInProgressError reports a start retried while the first one is still starting.
+func newIdempotencyInProgressError() *OperationError {
+	return &OperationError{
+		Code:       "IDEMPOTENCY_KEY_IN_USE",
+		Message:    "an execution with this idempotency key is still starting; retry later",
+		HTTPStatus: http.StatusConflict,
+	}
+}
+
+// MemoryIdempotencyStore is an in-process IdempotencyStore. Keys are not shared between
+// instances, and those of all workspaces are kept together.
+type MemoryIdempotencyStore struct {
+	mu   sync.Mutex
+	ttl  time.Duration
+	keys map[string]memoryIdempotencyKey
+	now  func() time.Time
+}
+
+type memoryIdempotencyKey struct {
+	executionID string // Empty while the execution is starting
+	expiresAt   time.Time
+}
+
+// NewMemoryIdempotencyStore creates an in-memory store remembering keys for ttl after their
+// execution started.
+func NewMemoryIdempotencyStore(ttl time.Duration) *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{ttl: ttl, keys: make(map[string]memoryIdempotencyKey), now: time.Now}
+}
+
+// Reserve claims an unused key, or returns the execution started with it.
+func (s *MemoryIdempotencyStore) Reserve(_ context.Context, workspaceID, key string) (string, bool, error) {
+	key = workspaceID + ":" + key
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for k, entry := range s.keys {
+		if !entry.expiresAt.After(now) {
+			delete(s.keys, k)
+		}
+	}
+	if entry, ok := s.keys[key]; ok {
+		return entry.executionID, false, nil
+	}
+	s.keys[key] = memoryIdempotencyKey{expiresAt: now.Add(idempotencyReservationTTL)}
+	return "", true, nil
+}
+
+// Complete records the execution started for a reserved key.
+func (s *MemoryIdempotencyStore) Complete(_ context.Context, workspaceID, key, executionID string) error {
+	key = workspaceID + ":" + key
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keys[key] = memoryIdempotencyKey{executionID: executionID, expiresAt: s.now().Add(s.ttl)}
+	return nil
+}
+
+// Release frees a reserved key.
+func (s *MemoryIdempotencyStore) Release(_ context.Context, workspaceID, key string) error {
+	key = workspaceID + ":" + key
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.keys[key].executionID == "" {
+		delete(s.keys, key)
+	}
+	return nil
+}
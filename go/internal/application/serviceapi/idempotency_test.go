@@ -0,0 +1,94 @@
+package serviceapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+)
+
+func TestMemoryIdempotencyStore(t *testing.T) {
+	store := NewMemoryIdempotencyStore(time.Hour)
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, reserved, err := store.Reserve(ctx, "ws-1", "key-1")
+	require.NoError(t, err)
+	assert.True(t, reserved)
+
+	executionID, reserved, err := store.Reserve(ctx, "ws-1", "key-1")
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	assert.Empty(t, executionID, "the first start is still running")
+
+	require.NoError(t, store.Complete(ctx, "ws-1", "key-1", "exec-1"))
+	require.NoError(t, store.Release(ctx, "ws-1", "key-1"), "completed keys are kept")
+	executionID, reserved, err = store.Reserve(ctx, "ws-1", "key-1")
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	assert.Equal(t, "exec-1", executionID)
+
+	now = now.Add(2 * time.Hour)
+	_, reserved, err = store.Reserve(ctx, "ws-1", "key-1")
+	require.NoError(t, err)
+	assert.True(t, reserved, "keys expire after the TTL")
+}
+
+func TestStartExecution_ShouldReplayIdempotencyKey(t *testing.T) {
+	// Arrange
+	wfRepo := new(mockWorkflowRepo)
+	execRepo := new(mockExecutionRepo)
+	ops := newTestOperations(wfRepo, execRepo, nil, nil, nil, nil, nil)
+	ops.Idempotency = NewMemoryIdempotencyStore(time.Hour)
+
+	now := time.Now()
+	wfID, execID := uuid.New(), uuid.New()
+	params := StartExecutionParams{
+		WorkflowID:     wfID.String(),
+		IdempotencyKey: "order-42",
+		Propagation:    executor.Propagation{UserID: "user-1"},
+	}
+	require.NoError(t, ops.Idempotency.Complete(context.Background(), params.Propagation.Tenant(), idempotencyScope(params), execID.String()))
+
+	execRepo.On("FindByIDWithRelations", mock.Anything, execID).Return(&storagemodels.ExecutionModel{
+		ID: execID, WorkflowID: &wfID, Status: "running", StartedAt: &now, CreatedAt: now, UpdatedAt: now,
+	}, nil)
+	wfRepo.On("FindByIDWithRelations", mock.Anything, wfID).Return(&storagemodels.WorkflowModel{ID: wfID}, nil)
+
+	// Act
+	execution, err := ops.StartExecution(context.Background(), params)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, execID.String(), execution.ID)
+	execRepo.AssertExpectations(t)
+}
+
+func TestStartExecution_ShouldRejectIdempotencyKeyInUse(t *testing.T) {
+	ops := newTestOperations(nil, nil, nil, nil, nil, nil, nil)
+	ops.Idempotency = NewMemoryIdempotencyStore(time.Hour)
+
+	params := StartExecutionParams{WorkflowID: uuid.NewString(), IdempotencyKey: "order-42"}
+	_, reserved, err := ops.Idempotency.Reserve(context.Background(), "", idempotencyScope(params))
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	_, err = ops.StartExecution(context.Background(), params)
+	var opErr *OperationError
+	require.ErrorAs(t, err, &opErr)
+	assert.Equal(t, "IDEMPOTENCY_KEY_IN_USE", opErr.Code)
+
+	params.IdempotencyKey = strings.Repeat("k", MaxIdempotencyKeyLength+1)
+	_, err = ops.StartExecution(context.Background(), params)
+	require.ErrorAs(t, err, &opErr)
+	assert.Equal(t, "INVALID_IDEMPOTENCY_KEY", opErr.Code)
+}
@@ -230,6 +230,60 @@ func (m *mockExecutionRepo) FindRunning(ctx context.Context) ([]*storagemodels.E
 	return ems, args.Error(1)
 }
 
+func (m *mockExecutionRepo) FindDueSuspended(ctx context.Context, now time.Time, limit int) ([]*storagemodels.ExecutionModel, error) {
+	args := m.Called(ctx, now, limit)
+	ems, _ := args.Get(0).([]*storagemodels.ExecutionModel)
+	return ems, args.Error(1)
+}
+
+func (m *mockExecutionRepo) FindPausedByEventKey(ctx context.Context, key string, limit int) ([]*storagemodels.ExecutionModel, error) {
+	args := m.Called(ctx, key, limit)
+	ems, _ := args.Get(0).([]*storagemodels.ExecutionModel)
+	return ems, args.Error(1)
+}
+
+func (m *mockExecutionRepo) ClaimSuspended(ctx context.Context, id uuid.UUID) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockExecutionRepo) ClaimFailed(ctx context.Context, id uuid.UUID) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockExecutionRepo) ClaimPending(ctx context.Context, id uuid.UUID) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockExecutionRepo) FindDueScheduled(ctx context.Context, now time.Time, limit int) ([]*storagemodels.ExecutionModel, error) {
+	args := m.Called(ctx, now, limit)
+	ems, _ := args.Get(0).([]*storagemodels.ExecutionModel)
+	return ems, args.Error(1)
+}
+
+func (m *mockExecutionRepo) ClaimScheduled(ctx context.Context, id uuid.UUID, now time.Time) (bool, error) {
+	args := m.Called(ctx, id, now)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockExecutionRepo) SaveCheckpoint(ctx context.Context, id uuid.UUID, state storagemodels.JSONBMap, at time.Time) (bool, error) {
+	args := m.Called(ctx, id, state, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockExecutionRepo) FindStaleRunning(ctx context.Context, before time.Time, limit int) ([]*storagemodels.ExecutionModel, error) {
+	args := m.Called(ctx, before, limit)
+	ems, _ := args.Get(0).([]*storagemodels.ExecutionModel)
+	return ems, args.Error(1)
+}
+
+func (m *mockExecutionRepo) ClaimStale(ctx context.Context, id uuid.UUID, before time.Time) (bool, error) {
+	args := m.Called(ctx, id, before)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *mockExecutionRepo) Count(ctx context.Context) (int, error) {
 	args := m.Called(ctx)
 	return args.Int(0), args.Error(1)
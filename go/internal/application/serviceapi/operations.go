@@ -1,12 +1,16 @@
 package serviceapi
 
 import (
+	"context"
+
 	"github.com/smilemakc/mbflow/go/internal/application/engine"
 	"github.com/smilemakc/mbflow/go/internal/application/systemkey"
 	"github.com/smilemakc/mbflow/go/internal/domain/repository"
 	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
 	"github.com/smilemakc/mbflow/go/pkg/crypto"
 	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
 )
 
 // Operations provides transport-agnostic business logic for the Service API.
@@ -21,4 +25,27 @@ type Operations struct {
 	EncryptionSvc   *crypto.EncryptionService
 	AuditService    *systemkey.AuditService
 	Logger          *logger.Logger
+
+	// TriggerListener, if set, is notified after triggers are created, changed or deleted,
+	// so schedulers and webhook registries pick up the change without a restart.
+	TriggerListener TriggerListener
+
+	// PayloadArchive, if set, loads node payloads that were moved to cold storage.
+	PayloadArchive PayloadHydrator
+
+	// Idempotency, if set, remembers executions started with an idempotency key, so a retried
+	// start returns the execution started first.
+	Idempotency IdempotencyStore
+}
+
+// PayloadHydrator loads archived node execution payloads. It is implemented by coldstorage.Archiver.
+type PayloadHydrator interface {
+	Hydrate(ctx context.Context, nodeExecutions []*storagemodels.NodeExecutionModel) error
+}
+
+// TriggerListener receives trigger lifecycle notifications. It is implemented by trigger.Manager.
+type TriggerListener interface {
+	OnTriggerCreated(ctx context.Context, trigger *models.Trigger) error
+	OnTriggerUpdated(ctx context.Context, trigger *models.Trigger) error
+	OnTriggerDeleted(ctx context.Context, triggerID string) error
 }
@@ -0,0 +1,59 @@
+package serviceapi
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// ListBreakpointsParams contains parameters for listing the breakpoints an execution stopped at.
+type ListBreakpointsParams struct {
+	ExecutionID uuid.UUID
+}
+
+// ListBreakpoints returns the breakpoints a paused debug execution stopped at, with the input
+// each node would run with.
+func (o *Operations) ListBreakpoints(ctx context.Context, params ListBreakpointsParams) ([]*models.Breakpoint, error) {
+	return o.ExecutionMgr.ListBreakpoints(ctx, params.ExecutionID.String())
+}
+
+// ContinueFromBreakpointsParams contains parameters for continuing an execution stopped at breakpoints.
+type ContinueFromBreakpointsParams struct {
+	ExecutionID uuid.UUID
+	Inputs      map[string]map[string]any // Replacement inputs, keyed by breakpoint node ID; missing ones keep theirs
+	UserID      string
+	IsAdmin     bool // Admins may continue any execution, others only their own
+}
+
+// ContinueFromBreakpoints runs the nodes an execution stopped at, with the given inputs, and
+// continues it in the background until it finishes or reaches another breakpoint.
+func (o *Operations) ContinueFromBreakpoints(ctx context.Context, params ContinueFromBreakpointsParams) (*models.Execution, error) {
+	execution, err := o.ExecutionMgr.ContinueFromBreakpoints(ctx, params.ExecutionID.String(), params.Inputs, params.UserID, params.IsAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	o.Logger.Info("Execution continued from breakpoints", "execution_id", params.ExecutionID, "user_id", params.UserID, "replaced_inputs", len(params.Inputs))
+	return execution, nil
+}
+
+// AbortAtBreakpointsParams contains parameters for aborting an execution stopped at breakpoints.
+type AbortAtBreakpointsParams struct {
+	ExecutionID uuid.UUID
+	UserID      string
+	IsAdmin     bool // Admins may abort any execution, others only their own
+}
+
+// AbortAtBreakpoints cancels an execution stopped at breakpoints without running the nodes it
+// stopped at.
+func (o *Operations) AbortAtBreakpoints(ctx context.Context, params AbortAtBreakpointsParams) (*models.Execution, error) {
+	execution, err := o.ExecutionMgr.AbortAtBreakpoints(ctx, params.ExecutionID.String(), params.UserID, params.IsAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	o.Logger.Info("Execution aborted at breakpoints", "execution_id", params.ExecutionID, "user_id", params.UserID)
+	return execution, nil
+}
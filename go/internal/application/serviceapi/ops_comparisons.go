@@ -0,0 +1,150 @@
+package serviceapi
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/google/uuid"
+
+	"github.com/smilemakc/mbflow/go/internal/application/comparison"
+	"github.com/smilemakc/mbflow/go/internal/application/engine"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+const (
+	defaultComparisonSampleSize = 10
+	maxComparisonSampleSize     = 50
+
+	// comparisonMetadataKey marks executions started by a comparison, so they are
+	// never sampled as recorded inputs themselves.
+	comparisonMetadataKey = "comparison"
+)
+
+// CompareWorkflowVariantsParams contains parameters for comparing two variants of a workflow.
+type CompareWorkflowVariantsParams struct {
+	WorkflowID uuid.UUID
+	Baseline   models.ComparisonVariant
+	Candidate  models.ComparisonVariant
+	// ExecutionIDs are the recorded executions whose inputs are replayed.
+	// Empty samples the most recent finished executions of the workflow.
+	ExecutionIDs []uuid.UUID
+	SampleSize   int // Default 10, max 50
+	IgnorePaths  []string
+}
+
+// CompareWorkflowVariants replays recorded inputs of a workflow through a baseline and a
+// candidate variant and reports the differences in output, duration and LLM cost.
+// Every replay is a real execution, including the side effects of its nodes.
+func (o *Operations) CompareWorkflowVariants(ctx context.Context, params CompareWorkflowVariantsParams) (*models.ComparisonReport, error) {
+	if params.SampleSize <= 0 {
+		params.SampleSize = defaultComparisonSampleSize
+	}
+	if params.SampleSize > maxComparisonSampleSize || len(params.ExecutionIDs) > maxComparisonSampleSize {
+		return nil, NewValidationError("INVALID_SAMPLE_SIZE", "at most 50 executions can be compared at once")
+	}
+
+	workflowID := params.WorkflowID.String()
+	for i, variant := range []*models.ComparisonVariant{&params.Baseline, &params.Candidate} {
+		if variant.Label == "" {
+			variant.Label = [...]string{"baseline", "candidate"}[i]
+		}
+		if variant.WorkflowID == "" {
+			variant.WorkflowID = workflowID
+		}
+		id, err := uuid.Parse(variant.WorkflowID)
+		if err != nil {
+			return nil, NewValidationError("INVALID_WORKFLOW_ID", "invalid variant workflow_id: "+variant.WorkflowID)
+		}
+		if _, err := o.WorkflowRepo.FindByID(ctx, id); err != nil {
+			o.Logger.Error("Failed to find workflow for comparison", "error", err, "workflow_id", id)
+			return nil, err
+		}
+	}
+	if params.Baseline.WorkflowID == params.Candidate.WorkflowID &&
+		reflect.DeepEqual(params.Baseline.NodeOverrides, params.Candidate.NodeOverrides) {
+		return nil, NewValidationError("IDENTICAL_VARIANTS", "baseline and candidate must differ in workflow or node overrides")
+	}
+
+	inputs, err := o.comparisonInputs(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if len(inputs) == 0 {
+		return nil, NewValidationError("NO_RECORDED_INPUTS", "workflow has no finished executions to replay")
+	}
+
+	report := comparison.Compare(ctx, o.runComparisonVariant, workflowID, params.Baseline, params.Candidate, inputs,
+		comparison.Options{IgnorePaths: params.IgnorePaths})
+
+	o.Logger.Info("Workflow variants compared",
+		"workflow_id", params.WorkflowID,
+		"total", report.Total,
+		"different", report.Different,
+	)
+	return report, nil
+}
+
+// comparisonInputs loads the recorded inputs to replay.
+func (o *Operations) comparisonInputs(ctx context.Context, params CompareWorkflowVariantsParams) ([]comparison.Input, error) {
+	var inputs []comparison.Input
+
+	if len(params.ExecutionIDs) > 0 {
+		for _, id := range params.ExecutionIDs {
+			execModel, err := o.ExecutionRepo.FindByID(ctx, id)
+			if err != nil {
+				o.Logger.Error("Failed to find execution for comparison", "error", err, "execution_id", id)
+				return nil, err
+			}
+			if execModel.WorkflowID == nil || *execModel.WorkflowID != params.WorkflowID {
+				return nil, NewValidationError("INVALID_EXECUTION", "execution "+id.String()+" does not belong to the workflow")
+			}
+			inputs = append(inputs, comparison.Input{
+				SourceExecutionID: id.String(),
+				Data:              execModel.InputData,
+				Variables:         execModel.Variables,
+			})
+		}
+		return inputs, nil
+	}
+
+	// Sample recent finished executions, over-fetching to skip earlier comparison runs.
+	execModels, err := o.ExecutionRepo.FindByWorkflowID(ctx, params.WorkflowID, params.SampleSize*4, 0)
+	if err != nil {
+		o.Logger.Error("Failed to list executions for comparison", "error", err, "workflow_id", params.WorkflowID)
+		return nil, err
+	}
+	for _, execModel := range execModels {
+		if len(inputs) == params.SampleSize {
+			break
+		}
+		if execModel.Status != string(models.ExecutionStatusCompleted) && execModel.Status != string(models.ExecutionStatusFailed) {
+			continue
+		}
+		if _, ok := execModel.Metadata[comparisonMetadataKey]; ok {
+			continue
+		}
+		inputs = append(inputs, comparison.Input{
+			SourceExecutionID: execModel.ID.String(),
+			Data:              execModel.InputData,
+			Variables:         execModel.Variables,
+		})
+	}
+	return inputs, nil
+}
+
+// runComparisonVariant executes the variant workflow synchronously with a recorded input.
+func (o *Operations) runComparisonVariant(ctx context.Context, variant models.ComparisonVariant, input comparison.Input) (*models.Execution, error) {
+	data := input.Data
+	if data == nil {
+		data = map[string]any{}
+	}
+
+	opts := engine.DefaultExecutionOptions()
+	if input.Variables != nil {
+		opts.Variables = input.Variables
+	}
+	opts.NodeConfigOverrides = variant.NodeOverrides
+	opts.Metadata = map[string]any{comparisonMetadataKey: variant.Label}
+
+	return o.ExecutionMgr.Execute(ctx, variant.WorkflowID, data, opts)
+}
@@ -0,0 +1,127 @@
+package serviceapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+func newComparisonWorkflowModel() *storagemodels.WorkflowModel {
+	return &storagemodels.WorkflowModel{ID: uuid.New(), Name: "WF", Status: "active", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+}
+
+func requireOperationError(t *testing.T, err error, code string) {
+	t.Helper()
+	var opErr *OperationError
+	require.True(t, errors.As(err, &opErr), "expected OperationError, got %v", err)
+	assert.Equal(t, code, opErr.Code)
+}
+
+func TestCompareWorkflowVariants_ShouldReject_WhenVariantsIdentical(t *testing.T) {
+	wfRepo := new(mockWorkflowRepo)
+	ops := newTestOperations(wfRepo, nil, nil, nil, nil, nil, nil)
+
+	wfModel := newComparisonWorkflowModel()
+	wfRepo.On("FindByID", mock.Anything, wfModel.ID).Return(wfModel, nil)
+
+	_, err := ops.CompareWorkflowVariants(context.Background(), CompareWorkflowVariantsParams{
+		WorkflowID: wfModel.ID,
+		Baseline:   models.ComparisonVariant{Label: "current"},
+		Candidate:  models.ComparisonVariant{Label: "same", WorkflowID: wfModel.ID.String()},
+	})
+
+	requireOperationError(t, err, "IDENTICAL_VARIANTS")
+}
+
+func TestCompareWorkflowVariants_ShouldReject_WhenSampleTooLarge(t *testing.T) {
+	ops := newTestOperations(nil, nil, nil, nil, nil, nil, nil)
+
+	_, err := ops.CompareWorkflowVariants(context.Background(), CompareWorkflowVariantsParams{
+		WorkflowID: uuid.New(),
+		SampleSize: 51,
+	})
+
+	requireOperationError(t, err, "INVALID_SAMPLE_SIZE")
+}
+
+func TestCompareWorkflowVariants_ShouldReturnError_WhenCandidateWorkflowMissing(t *testing.T) {
+	wfRepo := new(mockWorkflowRepo)
+	ops := newTestOperations(wfRepo, nil, nil, nil, nil, nil, nil)
+
+	wfModel := newComparisonWorkflowModel()
+	candidateID := uuid.New()
+	wfRepo.On("FindByID", mock.Anything, wfModel.ID).Return(wfModel, nil)
+	wfRepo.On("FindByID", mock.Anything, candidateID).Return(nil, models.ErrWorkflowNotFound)
+
+	_, err := ops.CompareWorkflowVariants(context.Background(), CompareWorkflowVariantsParams{
+		WorkflowID: wfModel.ID,
+		Candidate:  models.ComparisonVariant{WorkflowID: candidateID.String()},
+	})
+
+	assert.ErrorIs(t, err, models.ErrWorkflowNotFound)
+}
+
+func TestCompareWorkflowVariants_ShouldReject_WhenNoRecordedInputs(t *testing.T) {
+	wfRepo := new(mockWorkflowRepo)
+	execRepo := new(mockExecutionRepo)
+	ops := newTestOperations(wfRepo, execRepo, nil, nil, nil, nil, nil)
+
+	wfModel := newComparisonWorkflowModel()
+	wfRepo.On("FindByID", mock.Anything, wfModel.ID).Return(wfModel, nil)
+	execRepo.On("FindByWorkflowID", mock.Anything, wfModel.ID, 40, 0).Return([]*storagemodels.ExecutionModel{}, nil)
+
+	_, err := ops.CompareWorkflowVariants(context.Background(), CompareWorkflowVariantsParams{
+		WorkflowID: wfModel.ID,
+		Candidate:  models.ComparisonVariant{NodeOverrides: map[string]map[string]any{"summarize": {"model": "gpt-4o-mini"}}},
+	})
+
+	requireOperationError(t, err, "NO_RECORDED_INPUTS")
+}
+
+func TestComparisonInputs_ShouldSampleFinishedExecutions_SkippingComparisonRuns(t *testing.T) {
+	execRepo := new(mockExecutionRepo)
+	ops := newTestOperations(nil, execRepo, nil, nil, nil, nil, nil)
+
+	workflowID := uuid.New()
+	completed := &storagemodels.ExecutionModel{ID: uuid.New(), Status: "completed", InputData: storagemodels.JSONBMap{"text": "hello"}}
+	running := &storagemodels.ExecutionModel{ID: uuid.New(), Status: "running"}
+	replay := &storagemodels.ExecutionModel{ID: uuid.New(), Status: "completed", Metadata: storagemodels.JSONBMap{"comparison": "candidate"}}
+	failed := &storagemodels.ExecutionModel{ID: uuid.New(), Status: "failed", Variables: storagemodels.JSONBMap{"tier": "pro"}}
+	older := &storagemodels.ExecutionModel{ID: uuid.New(), Status: "completed"}
+	execRepo.On("FindByWorkflowID", mock.Anything, workflowID, 8, 0).
+		Return([]*storagemodels.ExecutionModel{completed, running, replay, failed, older}, nil)
+
+	inputs, err := ops.comparisonInputs(context.Background(), CompareWorkflowVariantsParams{WorkflowID: workflowID, SampleSize: 2})
+
+	require.NoError(t, err)
+	require.Len(t, inputs, 2)
+	assert.Equal(t, completed.ID.String(), inputs[0].SourceExecutionID)
+	assert.Equal(t, "hello", inputs[0].Data["text"])
+	assert.Equal(t, failed.ID.String(), inputs[1].SourceExecutionID)
+	assert.Equal(t, "pro", inputs[1].Variables["tier"])
+}
+
+func TestComparisonInputs_ShouldReject_ExecutionOfOtherWorkflow(t *testing.T) {
+	execRepo := new(mockExecutionRepo)
+	ops := newTestOperations(nil, execRepo, nil, nil, nil, nil, nil)
+
+	otherWorkflowID := uuid.New()
+	execModel := &storagemodels.ExecutionModel{ID: uuid.New(), WorkflowID: &otherWorkflowID, Status: "completed"}
+	execRepo.On("FindByID", mock.Anything, execModel.ID).Return(execModel, nil)
+
+	_, err := ops.comparisonInputs(context.Background(), CompareWorkflowVariantsParams{
+		WorkflowID:   uuid.New(),
+		ExecutionIDs: []uuid.UUID{execModel.ID},
+	})
+
+	requireOperationError(t, err, "INVALID_EXECUTION")
+}
@@ -0,0 +1,41 @@
+package serviceapi
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/smilemakc/mbflow/go/internal/application/engine"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// DryRunWorkflowParams contains parameters for a dry run of a stored workflow.
+type DryRunWorkflowParams struct {
+	WorkflowID  uuid.UUID
+	Input       map[string]any
+	MockOutputs map[string]any // Outputs of stubbed nodes, keyed by node ID; missing ones are empty objects
+	Variables   map[string]any
+	Profile     string // Launch profile name; its input and options are applied under Input and Variables
+
+	// Propagation is passed to the executors that still run
+	Propagation executor.Propagation
+}
+
+// DryRunWorkflow reports which nodes of the workflow would run for the input, with
+// side-effecting executors stubbed by the given mock outputs. Nothing is stored.
+func (o *Operations) DryRunWorkflow(ctx context.Context, params DryRunWorkflowParams) (*models.DryRunReport, error) {
+	opts := engine.DefaultExecutionOptions()
+	opts.Variables = params.Variables
+	opts.Profile = params.Profile
+	opts.Propagation = params.Propagation
+
+	report, err := o.ExecutionMgr.DryRun(ctx, params.WorkflowID.String(), params.Input, params.MockOutputs, opts)
+	if err != nil {
+		o.Logger.Error("Failed to dry-run workflow", "error", err, "workflow_id", params.WorkflowID)
+		return nil, err
+	}
+
+	o.Logger.Info("Workflow dry run", "workflow_id", params.WorkflowID, "status", report.Status, "would_run", len(report.WouldRun))
+	return report, nil
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/smilemakc/mbflow/go/internal/application/engine"
 	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
 	"github.com/smilemakc/mbflow/go/pkg/models"
 )
 
@@ -59,6 +60,8 @@ func (o *Operations) ListExecutions(ctx context.Context, params ListExecutionsPa
 // GetExecutionParams contains parameters for getting an execution.
 type GetExecutionParams struct {
 	ExecutionID uuid.UUID
+	// Full loads node payloads that were moved to cold storage.
+	Full bool
 }
 
 func (o *Operations) GetExecution(ctx context.Context, params GetExecutionParams) (*models.Execution, error) {
@@ -68,6 +71,13 @@ func (o *Operations) GetExecution(ctx context.Context, params GetExecutionParams
 		return nil, err
 	}
 
+	if params.Full {
+		if err := o.hydratePayloads(ctx, execModel.NodeExecutions); err != nil {
+			o.Logger.Error("Failed to hydrate archived payloads", "error", err, "execution_id", params.ExecutionID)
+			return nil, err
+		}
+	}
+
 	if execModel.WorkflowSource == "inline" {
 		return storagemodels.ExecutionModelToDomain(execModel), nil
 	}
@@ -125,16 +135,92 @@ type StartExecutionParams struct {
 	Input      map[string]any
 	Webhooks   []WebhookSubscription
 	Variables  map[string]any
+	Profile    string                // Launch profile name; its input and options are applied under Input and Variables
+	Selection  *models.NodeSelection // Run only these nodes, with the outputs of the nodes feeding them supplied; nil runs all
+
+	// Propagation is passed to executors and forwarded on outbound calls
+	Propagation executor.Propagation
+	// Priority ranks the execution when competing for capacity; high-priority executions may preempt
+	// others and are leased first by workers
+	Priority models.ExecutionPriority
+	// IdempotencyKey, if set, makes retried starts of the workflow by the same caller return the
+	// execution started first with the key instead of starting another
+	IdempotencyKey string
+	// Breakpoints are the IDs of nodes the execution pauses before, for step debugging
+	Breakpoints []string
+	// RunAt or Delay, at most one of them, schedule the execution to start later; it is recorded
+	// as pending until then. A RunAt in the past starts it now
+	RunAt time.Time
+	Delay time.Duration
+	// NodeTypeConcurrency caps the nodes of each executor type running at once, e.g. {"llm": 3}
+	NodeTypeConcurrency map[string]int
 }
 
+// StartExecution starts a stored workflow in the background. A start with an idempotency key
+// already used returns the execution started with it.
 func (o *Operations) StartExecution(ctx context.Context, params StartExecutionParams) (*models.Execution, error) {
 	// Validate webhook subscriptions
 	if err := validateWebhooks(params.Webhooks); err != nil {
 		return nil, err
 	}
+	if err := validateIdempotencyKey(params.IdempotencyKey); err != nil {
+		return nil, err
+	}
+	if err := validateSchedule(params.RunAt, params.Delay); err != nil {
+		return nil, err
+	}
+	if err := validateNodeTypeConcurrency(params.NodeTypeConcurrency); err != nil {
+		return nil, err
+	}
+
+	if params.IdempotencyKey == "" || o.Idempotency == nil {
+		return o.startExecution(ctx, params)
+	}
+
+	workspaceID, key := params.Propagation.Tenant(), idempotencyScope(params)
+	executionID, reserved, err := o.Idempotency.Reserve(ctx, workspaceID, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check idempotency key: %w", err)
+	}
+	if !reserved {
+		if executionID == "" {
+			return nil, newIdempotencyInProgressError()
+		}
+		id, err := uuid.Parse(executionID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", models.ErrInvalidExecutionID, executionID)
+		}
+		o.Logger.Info("Idempotent execution start replayed", "execution_id", executionID, "workflow_id", params.WorkflowID)
+		return o.GetExecution(ctx, GetExecutionParams{ExecutionID: id})
+	}
+
+	execution, err := o.startExecution(ctx, params)
+	if err != nil {
+		if releaseErr := o.Idempotency.Release(context.Background(), workspaceID, key); releaseErr != nil {
+			o.Logger.Error("Failed to release idempotency key", "error", releaseErr, "workflow_id", params.WorkflowID)
+		}
+		return nil, err
+	}
+	if err := o.Idempotency.Complete(ctx, workspaceID, key, execution.ID); err != nil {
+		o.Logger.Error("Failed to record idempotency key", "error", err, "execution_id", execution.ID)
+	}
+	return execution, nil
+}
+
+func (o *Operations) startExecution(ctx context.Context, params StartExecutionParams) (*models.Execution, error) {
 
 	opts := engine.DefaultExecutionOptions()
 	opts.Variables = params.Variables
+	opts.Profile = params.Profile
+	opts.Propagation = params.Propagation
+	opts.Selection = params.Selection
+	opts.Priority = params.Priority
+	opts.Breakpoints = params.Breakpoints
+	opts.NodeTypeConcurrency = params.NodeTypeConcurrency
+	opts.RunAt = params.RunAt
+	if params.Delay > 0 {
+		opts.RunAt = time.Now().Add(params.Delay)
+	}
 
 	// Convert serviceapi webhooks to engine webhooks
 	if len(params.Webhooks) > 0 {
@@ -155,10 +241,38 @@ func (o *Operations) StartExecution(ctx context.Context, params StartExecutionPa
 		return nil, err
 	}
 
+	if execution.ResumeAt != nil {
+		o.Logger.Info("Workflow execution scheduled via service API", "execution_id", execution.ID, "workflow_id", params.WorkflowID, "run_at", *execution.ResumeAt)
+		return execution, nil
+	}
 	o.Logger.Info("Workflow execution started via service API", "execution_id", execution.ID, "workflow_id", params.WorkflowID)
 	return execution, nil
 }
 
+// validateSchedule checks the scheduled start of an execution.
+func validateSchedule(runAt time.Time, delay time.Duration) error {
+	if !runAt.IsZero() && delay != 0 {
+		return NewValidationError("INVALID_SCHEDULE", "run_at and delay cannot both be set")
+	}
+	if delay < 0 {
+		return NewValidationError("INVALID_SCHEDULE", "delay must not be negative")
+	}
+	return nil
+}
+
+// validateNodeTypeConcurrency checks the per-type concurrency caps of an execution.
+func validateNodeTypeConcurrency(limits map[string]int) error {
+	for nodeType, limit := range limits {
+		if nodeType == "" {
+			return NewValidationError("INVALID_NODE_TYPE_CONCURRENCY", "node type is required")
+		}
+		if limit < 1 {
+			return NewValidationError("INVALID_NODE_TYPE_CONCURRENCY", fmt.Sprintf("concurrency of %s nodes must be at least 1", nodeType))
+		}
+	}
+	return nil
+}
+
 // validateWebhooks validates webhook subscription configurations.
 func validateWebhooks(webhooks []WebhookSubscription) error {
 	for i, wh := range webhooks {
@@ -178,18 +292,22 @@ func validateWebhooks(webhooks []WebhookSubscription) error {
 }
 
 var validEventTypes = map[string]bool{
-	"execution.started":   true,
-	"execution.completed": true,
-	"execution.failed":    true,
-	"execution.cancelled": true,
-	"execution.timeout":   true,
-	"wave.started":        true,
-	"wave.completed":      true,
-	"node.started":        true,
-	"node.completed":      true,
-	"node.failed":         true,
-	"node.skipped":        true,
-	"node.retrying":       true,
+	"execution.started":       true,
+	"execution.completed":     true,
+	"execution.failed":        true,
+	"execution.cancelled":     true,
+	"execution.timeout":       true,
+	"execution.dead_lettered": true,
+	"wave.started":            true,
+	"wave.completed":          true,
+	"node.started":            true,
+	"node.completed":          true,
+	"node.failed":             true,
+	"node.skipped":            true,
+	"node.retrying":           true,
+	"node.timed_out":          true,
+	"node.compensated":        true,
+	"node.cancelled":          true,
 }
 
 func isValidEventType(s string) bool {
@@ -205,6 +323,7 @@ type EphemeralExecutionParams struct {
 	Variables        map[string]any
 	PersistExecution bool
 	Webhooks         []WebhookSubscription
+	Propagation      executor.Propagation
 }
 
 func (o *Operations) StartEphemeralExecution(ctx context.Context, params EphemeralExecutionParams) (*models.Execution, error) {
@@ -223,6 +342,7 @@ func (o *Operations) StartEphemeralExecution(ctx context.Context, params Ephemer
 		Input:            params.Input,
 		Variables:        params.Variables,
 		CredentialIDs:    params.CredentialIDs,
+		Propagation:      params.Propagation,
 	}
 
 	if len(params.Webhooks) > 0 {
@@ -289,13 +409,151 @@ func normalizeEphemeralExecutionParams(params *EphemeralExecutionParams) error {
 	return nil
 }
 
+// DeliverEventParams contains parameters for delivering an external event to waiting executions.
+type DeliverEventParams struct {
+	CorrelationKey string
+	Payload        any
+	WorkspaceID    string // Only executions run in the workspace receive the event
+	UserID         string
+	IsAdmin        bool // Admins may deliver events to any execution, others only to their own
+}
+
+// DeliverEvent resumes the executions of the workspace that the user may control and whose
+// wait_for_event nodes wait for the correlation key, and returns how many it resumed.
+func (o *Operations) DeliverEvent(ctx context.Context, params DeliverEventParams) (int, error) {
+	delivered, err := o.ExecutionMgr.DeliverEvent(ctx, params.CorrelationKey, params.Payload, params.WorkspaceID, params.UserID, params.IsAdmin)
+	if err != nil {
+		o.Logger.Error("Failed to deliver event", "error", err, "correlation_key", params.CorrelationKey)
+		return 0, err
+	}
+
+	o.Logger.Info("Event delivered", "correlation_key", params.CorrelationKey, "executions", delivered)
+	return delivered, nil
+}
+
+// ListApprovalsParams contains parameters for listing the approvals of an execution.
+type ListApprovalsParams struct {
+	ExecutionID uuid.UUID
+}
+
+// ListApprovals returns the approvals raised by the approval nodes of an execution.
+func (o *Operations) ListApprovals(ctx context.Context, params ListApprovalsParams) ([]*models.Approval, error) {
+	approvals, err := o.ExecutionMgr.ListApprovals(ctx, params.ExecutionID.String())
+	if err != nil {
+		o.Logger.Error("Failed to list approvals", "error", err, "execution_id", params.ExecutionID)
+		return nil, err
+	}
+	return approvals, nil
+}
+
+// DecideApprovalParams contains parameters for approving or rejecting an approval.
+type DecideApprovalParams struct {
+	ExecutionID uuid.UUID
+	NodeID      string
+	Decision    models.ApprovalStatus
+	Comment     string
+	UserID      string
+}
+
+// DecideApproval approves or rejects a pending approval and resumes its execution.
+func (o *Operations) DecideApproval(ctx context.Context, params DecideApprovalParams) (*models.Approval, error) {
+	approval, err := o.ExecutionMgr.DecideApproval(ctx, params.ExecutionID.String(), params.NodeID, params.Decision, params.Comment, params.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	o.Logger.Info("Approval decided", "execution_id", params.ExecutionID, "node_id", params.NodeID, "decision", params.Decision, "user_id", params.UserID)
+	return approval, nil
+}
+
 // CancelExecutionParams contains parameters for cancelling an execution.
 type CancelExecutionParams struct {
 	ExecutionID uuid.UUID
+	UserID      string
+	IsAdmin     bool // Admins may cancel any execution, others only their own
 }
 
+// CancelExecution aborts a running execution: its running nodes are interrupted, keeping the
+// output they produced so far, and it is saved as cancelled.
 func (o *Operations) CancelExecution(ctx context.Context, params CancelExecutionParams) error {
-	return NewNotImplementedError("execution cancellation not yet implemented")
+	if err := o.ExecutionMgr.Cancel(ctx, params.ExecutionID.String(), params.UserID, params.IsAdmin); err != nil {
+		return err
+	}
+
+	o.Logger.Info("Execution cancelled", "execution_id", params.ExecutionID, "user_id", params.UserID)
+	return nil
+}
+
+// PreemptExecutionParams contains parameters for preempting a running execution.
+type PreemptExecutionParams struct {
+	ExecutionID uuid.UUID
+	UserID      string
+}
+
+// PreemptExecution stops a running execution after its running nodes finish, to free capacity
+// for urgent work. It is requeued from a checkpoint or cancelled, as the preemption policy says.
+func (o *Operations) PreemptExecution(ctx context.Context, params PreemptExecutionParams) error {
+	if err := o.ExecutionMgr.Preempt(ctx, params.ExecutionID.String(), params.UserID); err != nil {
+		return err
+	}
+
+	o.Logger.Info("Execution preempted", "execution_id", params.ExecutionID, "user_id", params.UserID)
+	return nil
+}
+
+// PauseExecutionParams contains parameters for pausing a running execution.
+type PauseExecutionParams struct {
+	ExecutionID uuid.UUID
+	UserID      string
+	IsAdmin     bool // Admins may pause any execution, others only their own
+}
+
+// PauseExecution stops a running execution at the next node boundary and saves it as paused
+// with a checkpoint of its state, until ResumeExecution continues it.
+func (o *Operations) PauseExecution(ctx context.Context, params PauseExecutionParams) error {
+	if err := o.ExecutionMgr.Pause(ctx, params.ExecutionID.String(), params.UserID, params.IsAdmin); err != nil {
+		return err
+	}
+
+	o.Logger.Info("Execution pause requested", "execution_id", params.ExecutionID, "user_id", params.UserID)
+	return nil
+}
+
+// ResumeExecutionParams contains parameters for resuming a paused execution.
+type ResumeExecutionParams struct {
+	ExecutionID uuid.UUID
+	UserID      string
+	IsAdmin     bool // Admins may resume any execution, others only their own
+}
+
+// ResumeExecution continues a paused execution from its checkpoint in the background.
+func (o *Operations) ResumeExecution(ctx context.Context, params ResumeExecutionParams) (*models.Execution, error) {
+	execution, err := o.ExecutionMgr.StartResume(ctx, params.ExecutionID.String(), params.UserID, params.IsAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	o.Logger.Info("Execution resumed", "execution_id", params.ExecutionID, "user_id", params.UserID)
+	return execution, nil
+}
+
+// ResumeFailedExecutionParams contains parameters for resuming a failed execution.
+type ResumeFailedExecutionParams struct {
+	ExecutionID uuid.UUID
+	UserID      string
+	IsAdmin     bool // Admins may resume any execution, others only their own
+}
+
+// ResumeFailedExecution continues a failed execution in the background from the nodes that
+// did not complete, reusing the outputs of the completed ones.
+func (o *Operations) ResumeFailedExecution(ctx context.Context, params ResumeFailedExecutionParams) (*models.Execution, error) {
+	execution, err := o.ExecutionMgr.ResumeFailed(ctx, params.ExecutionID.String(), params.UserID, params.IsAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	o.Logger.Info("Execution resumed from failure", "execution_id", params.ExecutionID, "user_id", params.UserID)
+	return execution, nil
 }
 
 // RetryExecutionParams contains parameters for retrying an execution.
@@ -360,8 +618,7 @@ func (o *Operations) GetNodeResult(ctx context.Context, params GetNodeResultPara
 	if execModel.WorkflowSource == "inline" {
 		for _, ne := range execModel.NodeExecutions {
 			if ne.NodeKey != nil && *ne.NodeKey == params.NodeID {
-				nodeExec := storagemodels.NodeExecutionModelToDomain(ne)
-				return nodeExec, nil
+				return o.nodeResult(ctx, ne)
 			}
 		}
 		return nil, NewValidationError("NODE_EXECUTION_NOT_FOUND", "Node execution not found")
@@ -387,7 +644,10 @@ func (o *Operations) GetNodeResult(ctx context.Context, params GetNodeResultPara
 			continue
 		}
 		if logicalID, ok := nodeIDMap[*ne.NodeID]; ok && logicalID == params.NodeID {
-			nodeExec := storagemodels.NodeExecutionModelToDomain(ne)
+			nodeExec, err := o.nodeResult(ctx, ne)
+			if err != nil {
+				return nil, err
+			}
 			nodeExec.NodeID = params.NodeID
 			return nodeExec, nil
 		}
@@ -396,6 +656,24 @@ func (o *Operations) GetNodeResult(ctx context.Context, params GetNodeResultPara
 	return nil, NewValidationError("NODE_EXECUTION_NOT_FOUND", "Node execution not found")
 }
 
+// nodeResult converts a node execution to its domain model, loading an archived payload first.
+func (o *Operations) nodeResult(ctx context.Context, ne *storagemodels.NodeExecutionModel) (*models.NodeExecution, error) {
+	if err := o.hydratePayloads(ctx, []*storagemodels.NodeExecutionModel{ne}); err != nil {
+		o.Logger.Error("Failed to hydrate archived payload", "error", err, "node_execution_id", ne.ID)
+		return nil, err
+	}
+	return storagemodels.NodeExecutionModelToDomain(ne), nil
+}
+
+// hydratePayloads loads node payloads that were moved to cold storage.
+// Without a configured archive the rows are returned as stored.
+func (o *Operations) hydratePayloads(ctx context.Context, nodeExecutions []*storagemodels.NodeExecutionModel) error {
+	if o.PayloadArchive == nil {
+		return nil
+	}
+	return o.PayloadArchive.Hydrate(ctx, nodeExecutions)
+}
+
 func getLogLevel(eventType string) string {
 	switch eventType {
 	case "execution.failed", "node.failed":
@@ -404,7 +682,7 @@ func getLogLevel(eventType string) string {
 		return "success"
 	case "execution.started", "node.started", "wave.started":
 		return "info"
-	case "node.retrying":
+	case "node.retrying", "node.timed_out", "node.compensated", "node.cancelled":
 		return "warning"
 	default:
 		return "info"
@@ -464,6 +742,21 @@ func formatLogMessage(eventType string, payload map[string]any) string {
 			return fmt.Sprintf("Node '%s' retrying", nodeName)
 		}
 		return "Node retrying"
+	case "node.timed_out":
+		if nodeName, ok := payload["node_name"].(string); ok {
+			return fmt.Sprintf("Node '%s' timed out", nodeName)
+		}
+		return "Node timed out"
+	case "node.compensated":
+		if nodeName, ok := payload["node_name"].(string); ok {
+			return fmt.Sprintf("Node '%s' compensated", nodeName)
+		}
+		return "Node compensated"
+	case "node.cancelled":
+		if nodeName, ok := payload["node_name"].(string); ok {
+			return fmt.Sprintf("Node '%s' cancelled", nodeName)
+		}
+		return "Node cancelled"
 	default:
 		return eventType
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/smilemakc/mbflow/go/internal/application/engine"
 	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
 	"github.com/smilemakc/mbflow/go/pkg/models"
 )
@@ -303,17 +304,69 @@ func TestGetExecution_ShouldHandleMissingWorkflow_Gracefully(t *testing.T) {
 	require.NotNil(t, result)
 }
 
+type fakePayloadHydrator struct {
+	calls int
+}
+
+func (f *fakePayloadHydrator) Hydrate(ctx context.Context, nodeExecutions []*storagemodels.NodeExecutionModel) error {
+	f.calls++
+	for _, ne := range nodeExecutions {
+		if ne.IsPayloadArchived() {
+			ne.OutputData = storagemodels.JSONBMap{"result": "from cold storage"}
+		}
+	}
+	return nil
+}
+
+func TestGetExecution_ShouldHydrateArchivedPayloads_WhenFull(t *testing.T) {
+	execID := uuid.New()
+	ref := "node-payloads/" + execID.String() + "/ne.json"
+	now := time.Now()
+
+	newExecModel := func() *storagemodels.ExecutionModel {
+		return &storagemodels.ExecutionModel{
+			ID: execID, WorkflowSource: "inline", Status: "completed", StartedAt: &now,
+			CreatedAt: now, UpdatedAt: now,
+			NodeExecutions: []*storagemodels.NodeExecutionModel{
+				{
+					ID: uuid.New(), ExecutionID: execID, Status: "completed",
+					PayloadRef: &ref, PayloadArchivedAt: &now, CreatedAt: now, UpdatedAt: now,
+				},
+			},
+		}
+	}
+
+	execRepo := new(mockExecutionRepo)
+	execRepo.On("FindByIDWithRelations", mock.Anything, execID).Return(newExecModel(), nil).Once()
+	execRepo.On("FindByIDWithRelations", mock.Anything, execID).Return(newExecModel(), nil).Once()
+	hydrator := &fakePayloadHydrator{}
+	ops := newTestOperations(nil, execRepo, nil, nil, nil, nil, nil)
+	ops.PayloadArchive = hydrator
+
+	light, err := ops.GetExecution(context.Background(), GetExecutionParams{ExecutionID: execID})
+	require.NoError(t, err)
+	require.Len(t, light.NodeExecutions, 1)
+	assert.True(t, light.NodeExecutions[0].PayloadArchived)
+	assert.Empty(t, light.NodeExecutions[0].Output)
+	assert.Equal(t, 0, hydrator.calls)
+
+	full, err := ops.GetExecution(context.Background(), GetExecutionParams{ExecutionID: execID, Full: true})
+	require.NoError(t, err)
+	require.Len(t, full.NodeExecutions, 1)
+	assert.True(t, full.NodeExecutions[0].PayloadArchived)
+	assert.Equal(t, "from cold storage", full.NodeExecutions[0].Output["result"])
+	assert.Equal(t, 1, hydrator.calls)
+}
+
 // --- CancelExecution ---
 
-func TestCancelExecution_ShouldReturnNotImplementedError(t *testing.T) {
+func TestCancelExecution_ShouldRequireRunningExecution(t *testing.T) {
 	ops := newTestOperations(nil, nil, nil, nil, nil, nil, nil)
+	ops.ExecutionMgr = &engine.ExecutionManager{}
 
-	err := ops.CancelExecution(context.Background(), CancelExecutionParams{ExecutionID: uuid.New()})
+	err := ops.CancelExecution(context.Background(), CancelExecutionParams{ExecutionID: uuid.New(), UserID: "user-1"})
 
-	require.Error(t, err)
-	var opErr *OperationError
-	require.ErrorAs(t, err, &opErr)
-	assert.Equal(t, "NOT_IMPLEMENTED", opErr.Code)
+	assert.ErrorIs(t, err, models.ErrExecutionNotRunning)
 }
 
 // --- RetryExecution ---
@@ -0,0 +1,194 @@
+package serviceapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/smilemakc/mbflow/go/internal/application/engine"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// ListWorkflowFixturesParams contains parameters for listing the fixtures of a workflow.
+type ListWorkflowFixturesParams struct {
+	WorkflowID uuid.UUID
+}
+
+func (o *Operations) ListWorkflowFixtures(ctx context.Context, params ListWorkflowFixturesParams) (map[string]*models.WorkflowFixture, error) {
+	workflowModel, err := o.WorkflowRepo.FindByID(ctx, params.WorkflowID)
+	if err != nil {
+		o.Logger.Error("Failed to find workflow for fixtures", "error", err, "workflow_id", params.WorkflowID)
+		return nil, err
+	}
+
+	fixtures := storagemodels.FixturesFromStorage(workflowModel.Fixtures)
+	if fixtures == nil {
+		fixtures = map[string]*models.WorkflowFixture{}
+	}
+	return fixtures, nil
+}
+
+// PutWorkflowFixtureParams contains parameters for creating or replacing a fixture.
+type PutWorkflowFixtureParams struct {
+	WorkflowID uuid.UUID
+	Name       string
+	Fixture    *models.WorkflowFixture
+}
+
+func (o *Operations) PutWorkflowFixture(ctx context.Context, params PutWorkflowFixtureParams) (*models.WorkflowFixture, error) {
+	if err := models.ValidateFixtures(map[string]*models.WorkflowFixture{params.Name: params.Fixture}); err != nil {
+		return nil, NewValidationError("INVALID_FIXTURE", err.Error())
+	}
+
+	workflowModel, err := o.WorkflowRepo.FindByID(ctx, params.WorkflowID)
+	if err != nil {
+		o.Logger.Error("Failed to find workflow for fixture update", "error", err, "workflow_id", params.WorkflowID)
+		return nil, err
+	}
+
+	fixtures := storagemodels.FixturesFromStorage(workflowModel.Fixtures)
+	if fixtures == nil {
+		fixtures = make(map[string]*models.WorkflowFixture, 1)
+	}
+	fixtures[params.Name] = params.Fixture
+	workflowModel.Fixtures = storagemodels.FixturesToStorage(fixtures)
+
+	if err := o.WorkflowRepo.Update(ctx, workflowModel); err != nil {
+		o.Logger.Error("Failed to save workflow fixture", "error", err, "workflow_id", params.WorkflowID, "fixture", params.Name)
+		return nil, err
+	}
+	return params.Fixture, nil
+}
+
+// DeleteWorkflowFixtureParams contains parameters for deleting a fixture.
+type DeleteWorkflowFixtureParams struct {
+	WorkflowID uuid.UUID
+	Name       string
+}
+
+func (o *Operations) DeleteWorkflowFixture(ctx context.Context, params DeleteWorkflowFixtureParams) error {
+	workflowModel, err := o.WorkflowRepo.FindByID(ctx, params.WorkflowID)
+	if err != nil {
+		o.Logger.Error("Failed to find workflow for fixture deletion", "error", err, "workflow_id", params.WorkflowID)
+		return err
+	}
+
+	fixtures := storagemodels.FixturesFromStorage(workflowModel.Fixtures)
+	if _, ok := fixtures[params.Name]; !ok {
+		return fmt.Errorf("%w: %s", models.ErrFixtureNotFound, params.Name)
+	}
+	delete(fixtures, params.Name)
+
+	workflowModel.Fixtures = storagemodels.FixturesToStorage(fixtures)
+	if workflowModel.Fixtures == nil {
+		workflowModel.Fixtures = storagemodels.JSONBMap{}
+	}
+
+	if err := o.WorkflowRepo.Update(ctx, workflowModel); err != nil {
+		o.Logger.Error("Failed to delete workflow fixture", "error", err, "workflow_id", params.WorkflowID, "fixture", params.Name)
+		return err
+	}
+	return nil
+}
+
+// RunWorkflowFixturesParams contains parameters for running the fixtures of a workflow.
+type RunWorkflowFixturesParams struct {
+	WorkflowID uuid.UUID
+	Names      []string // Fixtures to run; empty runs all of them
+}
+
+// FixtureResult is the outcome of running one fixture.
+type FixtureResult struct {
+	Name        string                 `json:"name"`
+	Passed      bool                   `json:"passed"`
+	Status      models.ExecutionStatus `json:"status,omitempty"`
+	ExecutionID string                 `json:"execution_id,omitempty"`
+	Output      map[string]any         `json:"output,omitempty"`
+	Failures    []string               `json:"failures,omitempty"`
+	DurationMs  int64                  `json:"duration_ms"`
+}
+
+// RunWorkflowFixturesResult is the report of a fixture run.
+// Passed is true when every fixture passed, including when the workflow has none.
+type RunWorkflowFixturesResult struct {
+	WorkflowID string          `json:"workflow_id"`
+	Passed     bool            `json:"passed"`
+	Total      int             `json:"total"`
+	Failed     int             `json:"failed"`
+	Results    []FixtureResult `json:"results"`
+}
+
+// RunWorkflowFixtures executes the workflow once per fixture, one fixture at a time in
+// name order, and checks each execution against the fixture's expectations.
+func (o *Operations) RunWorkflowFixtures(ctx context.Context, params RunWorkflowFixturesParams) (*RunWorkflowFixturesResult, error) {
+	workflowModel, err := o.WorkflowRepo.FindByID(ctx, params.WorkflowID)
+	if err != nil {
+		o.Logger.Error("Failed to find workflow for fixture run", "error", err, "workflow_id", params.WorkflowID)
+		return nil, err
+	}
+	workflow := &models.Workflow{Fixtures: storagemodels.FixturesFromStorage(workflowModel.Fixtures)}
+
+	names := params.Names
+	if len(names) == 0 {
+		for name := range workflow.Fixtures {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	fixtures := make([]*models.WorkflowFixture, len(names))
+	for i, name := range names {
+		fixture, err := workflow.GetFixture(name)
+		if err != nil {
+			return nil, err
+		}
+		fixtures[i] = fixture
+	}
+
+	result := &RunWorkflowFixturesResult{
+		WorkflowID: params.WorkflowID.String(),
+		Passed:     true,
+		Total:      len(names),
+		Results:    make([]FixtureResult, 0, len(names)),
+	}
+	for i, name := range names {
+		fixtureResult := o.runWorkflowFixture(ctx, params.WorkflowID.String(), name, fixtures[i])
+		if !fixtureResult.Passed {
+			result.Passed = false
+			result.Failed++
+		}
+		result.Results = append(result.Results, fixtureResult)
+	}
+
+	o.Logger.Info("Workflow fixtures run", "workflow_id", params.WorkflowID, "total", result.Total, "failed", result.Failed)
+	return result, nil
+}
+
+// runWorkflowFixture executes the workflow synchronously with the fixture input.
+func (o *Operations) runWorkflowFixture(ctx context.Context, workflowID, name string, fixture *models.WorkflowFixture) FixtureResult {
+	startTime := time.Now()
+	result := FixtureResult{Name: name}
+
+	input := fixture.Input
+	if input == nil {
+		input = map[string]any{}
+	}
+
+	execution, execErr := o.ExecutionMgr.Execute(ctx, workflowID, input, engine.DefaultExecutionOptions())
+	result.DurationMs = time.Since(startTime).Milliseconds()
+	if execution == nil {
+		result.Failures = []string{fmt.Sprintf("execution could not start: %v", execErr)}
+		return result
+	}
+
+	result.Status = execution.Status
+	result.ExecutionID = execution.ID
+	result.Output = execution.Output
+	result.Failures = fixture.Check(execution)
+	result.Passed = len(result.Failures) == 0
+	return result
+}
@@ -0,0 +1,158 @@
+package serviceapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+func newFixtureWorkflowModel(fixtures map[string]*models.WorkflowFixture) *storagemodels.WorkflowModel {
+	return &storagemodels.WorkflowModel{
+		ID: uuid.New(), Name: "WF", Status: "draft", CreatedAt: time.Now(), UpdatedAt: time.Now(),
+		Fixtures: storagemodels.FixturesToStorage(fixtures),
+	}
+}
+
+// --- ListWorkflowFixtures ---
+
+func TestListWorkflowFixtures_ShouldReturnFixtures(t *testing.T) {
+	wfRepo := new(mockWorkflowRepo)
+	ops := newTestOperations(wfRepo, nil, nil, nil, nil, nil, nil)
+
+	wfModel := newFixtureWorkflowModel(map[string]*models.WorkflowFixture{
+		"smoke": {Input: map[string]any{"id": "42"}},
+	})
+	wfRepo.On("FindByID", mock.Anything, wfModel.ID).Return(wfModel, nil)
+
+	fixtures, err := ops.ListWorkflowFixtures(context.Background(), ListWorkflowFixturesParams{WorkflowID: wfModel.ID})
+
+	require.NoError(t, err)
+	require.Contains(t, fixtures, "smoke")
+	assert.Equal(t, "42", fixtures["smoke"].Input["id"])
+}
+
+func TestListWorkflowFixtures_ShouldReturnEmptyMap_WhenNone(t *testing.T) {
+	wfRepo := new(mockWorkflowRepo)
+	ops := newTestOperations(wfRepo, nil, nil, nil, nil, nil, nil)
+
+	wfModel := newFixtureWorkflowModel(nil)
+	wfRepo.On("FindByID", mock.Anything, wfModel.ID).Return(wfModel, nil)
+
+	fixtures, err := ops.ListWorkflowFixtures(context.Background(), ListWorkflowFixturesParams{WorkflowID: wfModel.ID})
+
+	require.NoError(t, err)
+	assert.NotNil(t, fixtures)
+	assert.Empty(t, fixtures)
+}
+
+// --- PutWorkflowFixture ---
+
+func TestPutWorkflowFixture_ShouldAddFixture_KeepingOthers(t *testing.T) {
+	wfRepo := new(mockWorkflowRepo)
+	ops := newTestOperations(wfRepo, nil, nil, nil, nil, nil, nil)
+
+	wfModel := newFixtureWorkflowModel(map[string]*models.WorkflowFixture{"smoke": {}})
+	wfRepo.On("FindByID", mock.Anything, wfModel.ID).Return(wfModel, nil)
+	wfRepo.On("Update", mock.Anything, mock.MatchedBy(func(m *storagemodels.WorkflowModel) bool {
+		fixtures := storagemodels.FixturesFromStorage(m.Fixtures)
+		return len(fixtures) == 2 && fixtures["refund"] != nil && fixtures["refund"].ExpectedStatus == models.ExecutionStatusFailed
+	})).Return(nil)
+
+	fixture, err := ops.PutWorkflowFixture(context.Background(), PutWorkflowFixtureParams{
+		WorkflowID: wfModel.ID,
+		Name:       "refund",
+		Fixture:    &models.WorkflowFixture{ExpectedStatus: models.ExecutionStatusFailed},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionStatusFailed, fixture.ExpectedStatus)
+	wfRepo.AssertExpectations(t)
+}
+
+func TestPutWorkflowFixture_ShouldRejectInvalidFixture(t *testing.T) {
+	wfRepo := new(mockWorkflowRepo)
+	ops := newTestOperations(wfRepo, nil, nil, nil, nil, nil, nil)
+
+	_, err := ops.PutWorkflowFixture(context.Background(), PutWorkflowFixtureParams{
+		WorkflowID: uuid.New(),
+		Name:       "bad name",
+		Fixture:    &models.WorkflowFixture{},
+	})
+
+	var opErr *OperationError
+	require.True(t, errors.As(err, &opErr))
+	assert.Equal(t, "INVALID_FIXTURE", opErr.Code)
+	wfRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+// --- DeleteWorkflowFixture ---
+
+func TestDeleteWorkflowFixture_ShouldRemoveFixture(t *testing.T) {
+	wfRepo := new(mockWorkflowRepo)
+	ops := newTestOperations(wfRepo, nil, nil, nil, nil, nil, nil)
+
+	wfModel := newFixtureWorkflowModel(map[string]*models.WorkflowFixture{"smoke": {}})
+	wfRepo.On("FindByID", mock.Anything, wfModel.ID).Return(wfModel, nil)
+	wfRepo.On("Update", mock.Anything, mock.MatchedBy(func(m *storagemodels.WorkflowModel) bool {
+		return m.Fixtures != nil && len(m.Fixtures) == 0
+	})).Return(nil)
+
+	err := ops.DeleteWorkflowFixture(context.Background(), DeleteWorkflowFixtureParams{WorkflowID: wfModel.ID, Name: "smoke"})
+
+	require.NoError(t, err)
+	wfRepo.AssertExpectations(t)
+}
+
+func TestDeleteWorkflowFixture_ShouldReturnNotFound_WhenMissing(t *testing.T) {
+	wfRepo := new(mockWorkflowRepo)
+	ops := newTestOperations(wfRepo, nil, nil, nil, nil, nil, nil)
+
+	wfModel := newFixtureWorkflowModel(nil)
+	wfRepo.On("FindByID", mock.Anything, wfModel.ID).Return(wfModel, nil)
+
+	err := ops.DeleteWorkflowFixture(context.Background(), DeleteWorkflowFixtureParams{WorkflowID: wfModel.ID, Name: "smoke"})
+
+	assert.ErrorIs(t, err, models.ErrFixtureNotFound)
+	wfRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+// --- RunWorkflowFixtures ---
+
+func TestRunWorkflowFixtures_ShouldPass_WhenWorkflowHasNoFixtures(t *testing.T) {
+	wfRepo := new(mockWorkflowRepo)
+	ops := newTestOperations(wfRepo, nil, nil, nil, nil, nil, nil)
+
+	wfModel := newFixtureWorkflowModel(nil)
+	wfRepo.On("FindByID", mock.Anything, wfModel.ID).Return(wfModel, nil)
+
+	result, err := ops.RunWorkflowFixtures(context.Background(), RunWorkflowFixturesParams{WorkflowID: wfModel.ID})
+
+	require.NoError(t, err)
+	assert.True(t, result.Passed)
+	assert.Equal(t, 0, result.Total)
+	assert.Empty(t, result.Results)
+}
+
+func TestRunWorkflowFixtures_ShouldReturnNotFound_WhenUnknownFixtureRequested(t *testing.T) {
+	wfRepo := new(mockWorkflowRepo)
+	ops := newTestOperations(wfRepo, nil, nil, nil, nil, nil, nil)
+
+	wfModel := newFixtureWorkflowModel(map[string]*models.WorkflowFixture{"smoke": {}})
+	wfRepo.On("FindByID", mock.Anything, wfModel.ID).Return(wfModel, nil)
+
+	_, err := ops.RunWorkflowFixtures(context.Background(), RunWorkflowFixturesParams{
+		WorkflowID: wfModel.ID,
+		Names:      []string{"smoke", "missing"},
+	})
+
+	assert.ErrorIs(t, err, models.ErrFixtureNotFound)
+}
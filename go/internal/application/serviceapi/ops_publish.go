@@ -0,0 +1,114 @@
+package serviceapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgengine "github.com/smilemakc/mbflow/go/pkg/engine"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// CheckWorkflowPublishParams contains parameters for checking whether a workflow can be published.
+type CheckWorkflowPublishParams struct {
+	WorkflowID uuid.UUID
+	Checks     []models.PublishCheck // Checks to run; empty runs the checks the workflow requires
+}
+
+// CheckWorkflowPublish runs publish checks without publishing the workflow.
+func (o *Operations) CheckWorkflowPublish(ctx context.Context, params CheckWorkflowPublishParams) (*models.PublishReport, error) {
+	workflowModel, err := o.WorkflowRepo.FindByID(ctx, params.WorkflowID)
+	if err != nil {
+		o.Logger.Error("Failed to find workflow for publish checks", "error", err, "workflow_id", params.WorkflowID)
+		return nil, err
+	}
+
+	workflow := storagemodels.WorkflowModelToDomain(workflowModel)
+	checks := params.Checks
+	if len(checks) == 0 {
+		checks = workflow.RequiredPublishChecks()
+	}
+	return o.runPublishChecks(ctx, workflow, checks)
+}
+
+// runPublishChecks runs the given checks against the workflow in order.
+func (o *Operations) runPublishChecks(ctx context.Context, workflow *models.Workflow, checks []models.PublishCheck) (*models.PublishReport, error) {
+	report := &models.PublishReport{Passed: true, Checks: make([]models.PublishCheckResult, 0, len(checks))}
+	for _, check := range checks {
+		switch check {
+		case models.PublishCheckLint:
+			report.Add(check, o.lintWorkflow(workflow))
+		case models.PublishCheckNoPlaintextSecrets:
+			var problems []string
+			for _, path := range workflow.FindPlaintextSecrets() {
+				problems = append(problems, path+": looks like a plaintext secret; use a credentials resource or an {{env.*}} variable")
+			}
+			report.Add(check, problems)
+		case models.PublishCheckInputSchema:
+			report.Add(check, workflow.CheckInputSchema())
+		case models.PublishCheckFixtures:
+			report.Add(check, o.checkWorkflowFixtures(ctx, workflow))
+		default:
+			return nil, NewValidationError("INVALID_PUBLISH_CHECK", fmt.Sprintf("unknown publish check %q", check))
+		}
+	}
+	return report, nil
+}
+
+// lintWorkflow reports structural problems: validation errors, cycles and node types
+// without a registered executor.
+func (o *Operations) lintWorkflow(workflow *models.Workflow) []string {
+	var problems []string
+	if err := workflow.Validate(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if _, err := pkgengine.TopologicalSort(pkgengine.BuildDAG(workflow)); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if o.ExecutorManager != nil {
+		for _, node := range workflow.Nodes {
+			if node.Type != "" && !o.ExecutorManager.Has(node.Type) {
+				problems = append(problems, fmt.Sprintf("node %s: unknown node type %q", node.ID, node.Type))
+			}
+		}
+	}
+	return problems
+}
+
+// checkWorkflowFixtures runs every fixture in name order and reports the failures.
+func (o *Operations) checkWorkflowFixtures(ctx context.Context, workflow *models.Workflow) []string {
+	if len(workflow.Fixtures) == 0 {
+		return []string{"workflow has no fixtures"}
+	}
+
+	names := make([]string, 0, len(workflow.Fixtures))
+	for name := range workflow.Fixtures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []string
+	for _, name := range names {
+		result := o.runWorkflowFixture(ctx, workflow.ID, name, workflow.Fixtures[name])
+		for _, failure := range result.Failures {
+			problems = append(problems, fmt.Sprintf("fixture %s: %s", name, failure))
+		}
+	}
+	return problems
+}
+
+// newPublishChecksFailedError returns the error of a publish blocked by failing checks,
+// carrying the checklist report in its details.
+func newPublishChecksFailedError(report *models.PublishReport) *OperationError {
+	return &OperationError{
+		Code:       "PUBLISH_CHECKS_FAILED",
+		Message:    "publish blocked by failing checks: " + strings.Join(report.Failed(), ", "),
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Details:    map[string]any{"report": report},
+	}
+}
@@ -114,7 +114,13 @@ func (o *Operations) CreateTrigger(ctx context.Context, params CreateTriggerPara
 		return nil, err
 	}
 
-	return triggerModelToDomain(triggerModel, params.Name, params.Description), nil
+	trigger := triggerModelToDomain(triggerModel, params.Name, params.Description)
+	if o.TriggerListener != nil {
+		if err := o.TriggerListener.OnTriggerCreated(ctx, trigger); err != nil {
+			o.Logger.Warn("Failed to activate created trigger", "error", err, "trigger_id", trigger.ID)
+		}
+	}
+	return trigger, nil
 }
 
 // UpdateTriggerParams contains parameters for updating a trigger.
@@ -154,7 +160,9 @@ func (o *Operations) UpdateTrigger(ctx context.Context, params UpdateTriggerPara
 		return nil, err
 	}
 
-	return triggerModelToDomain(triggerModel, params.Name, params.Description), nil
+	trigger := triggerModelToDomain(triggerModel, params.Name, params.Description)
+	o.notifyTriggerUpdated(ctx, trigger)
+	return trigger, nil
 }
 
 // DeleteTriggerParams contains parameters for deleting a trigger.
@@ -167,9 +175,25 @@ func (o *Operations) DeleteTrigger(ctx context.Context, params DeleteTriggerPara
 		o.Logger.Error("Failed to delete trigger", "error", err, "trigger_id", params.TriggerID)
 		return err
 	}
+	if o.TriggerListener != nil {
+		if err := o.TriggerListener.OnTriggerDeleted(ctx, params.TriggerID.String()); err != nil {
+			o.Logger.Warn("Failed to deactivate deleted trigger", "error", err, "trigger_id", params.TriggerID)
+		}
+	}
 	return nil
 }
 
+// notifyTriggerUpdated tells the trigger listener about a changed trigger.
+// The change is already persisted, so listener errors are logged, not returned.
+func (o *Operations) notifyTriggerUpdated(ctx context.Context, trigger *models.Trigger) {
+	if o.TriggerListener == nil {
+		return
+	}
+	if err := o.TriggerListener.OnTriggerUpdated(ctx, trigger); err != nil {
+		o.Logger.Warn("Failed to reload updated trigger", "error", err, "trigger_id", trigger.ID)
+	}
+}
+
 func isValidTriggerType(t string) bool {
 	validTypes := map[string]bool{
 		"manual":   true,
@@ -177,6 +201,8 @@ func isValidTriggerType(t string) bool {
 		"webhook":  true,
 		"event":    true,
 		"interval": true,
+		"email":    true,
+		"slack":    true,
 	}
 	return validTypes[t]
 }
@@ -208,7 +234,9 @@ func (o *Operations) EnableTrigger(ctx context.Context, params EnableTriggerPara
 		o.Logger.Error("Failed to find trigger after enable", "error", err, "trigger_id", params.TriggerID)
 		return nil, models.ErrTriggerNotFound
 	}
-	return triggerModelToDomain(triggerModel, "", ""), nil
+	trigger := triggerModelToDomain(triggerModel, "", "")
+	o.notifyTriggerUpdated(ctx, trigger)
+	return trigger, nil
 }
 
 type DisableTriggerParams struct {
@@ -225,7 +253,9 @@ func (o *Operations) DisableTrigger(ctx context.Context, params DisableTriggerPa
 		o.Logger.Error("Failed to find trigger after disable", "error", err, "trigger_id", params.TriggerID)
 		return nil, models.ErrTriggerNotFound
 	}
-	return triggerModelToDomain(triggerModel, "", ""), nil
+	trigger := triggerModelToDomain(triggerModel, "", "")
+	o.notifyTriggerUpdated(ctx, trigger)
+	return trigger, nil
 }
 
 func triggerModelToDomain(tm *storagemodels.TriggerModel, name, description string) *models.Trigger {
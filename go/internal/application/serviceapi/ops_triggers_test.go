@@ -724,3 +724,61 @@ func TestDisableTrigger_ShouldReturnNotFound_WhenFindAfterDisableFails(t *testin
 	assert.Nil(t, result)
 	assert.ErrorIs(t, err, models.ErrTriggerNotFound)
 }
+
+// --- TriggerListener ---
+
+type recordingTriggerListener struct {
+	created []string
+	updated []string
+	deleted []string
+}
+
+func (l *recordingTriggerListener) OnTriggerCreated(_ context.Context, trigger *models.Trigger) error {
+	l.created = append(l.created, trigger.ID)
+	return nil
+}
+
+func (l *recordingTriggerListener) OnTriggerUpdated(_ context.Context, trigger *models.Trigger) error {
+	l.updated = append(l.updated, trigger.ID)
+	return nil
+}
+
+func (l *recordingTriggerListener) OnTriggerDeleted(_ context.Context, triggerID string) error {
+	l.deleted = append(l.deleted, triggerID)
+	return errors.New("listener errors are not returned")
+}
+
+func TestTriggerListener_ShouldBeNotified_OnLifecycleChanges(t *testing.T) {
+	wfRepo := new(mockWorkflowRepo)
+	trigRepo := new(mockTriggerRepo)
+	listener := &recordingTriggerListener{}
+	ops := newTestOperations(wfRepo, nil, trigRepo, nil, nil, nil, nil)
+	ops.TriggerListener = listener
+
+	wfID := uuid.New()
+	wfRepo.On("FindByID", mock.Anything, wfID).Return(&storagemodels.WorkflowModel{ID: wfID}, nil)
+	trigRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	created, err := ops.CreateTrigger(context.Background(), CreateTriggerParams{
+		WorkflowID: wfID.String(),
+		Name:       "Hook",
+		Type:       "webhook",
+		Enabled:    true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{created.ID}, listener.created)
+
+	trigID := uuid.MustParse(created.ID)
+	trigRepo.On("Disable", mock.Anything, trigID).Return(nil)
+	trigRepo.On("FindByID", mock.Anything, trigID).Return(&storagemodels.TriggerModel{
+		ID: trigID, WorkflowID: wfID, Type: "webhook", Enabled: false,
+		Config: storagemodels.JSONBMap{}, CreatedAt: time.Now(), UpdatedAt: time.Now(),
+	}, nil)
+	_, err = ops.DisableTrigger(context.Background(), DisableTriggerParams{TriggerID: trigID})
+	require.NoError(t, err)
+	assert.Equal(t, []string{created.ID}, listener.updated)
+
+	trigRepo.On("Delete", mock.Anything, trigID).Return(nil)
+	require.NoError(t, ops.DeleteTrigger(context.Background(), DeleteTriggerParams{TriggerID: trigID}))
+	assert.Equal(t, []string{created.ID}, listener.deleted)
+}
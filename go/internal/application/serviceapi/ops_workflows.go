@@ -79,14 +79,16 @@ func (o *Operations) GetWorkflow(ctx context.Context, params GetWorkflowParams)
 
 // CreateWorkflowParams contains parameters for creating a workflow.
 type CreateWorkflowParams struct {
-	Name        string
-	Description string
-	Variables   map[string]any
-	Metadata    map[string]any
-	CreatedBy   *uuid.UUID
-	Nodes       []NodeInput
-	Edges       []EdgeInput
-	Resources   []ResourceInput
+	Name           string
+	Description    string
+	Variables      map[string]any
+	Metadata       map[string]any
+	LaunchProfiles map[string]*models.LaunchProfile
+	CreatedBy      *uuid.UUID
+	Nodes          []NodeInput
+	Edges          []EdgeInput
+	Resources      []ResourceInput
+	Triggers       []*models.Trigger // Created with the workflow, such as those of a builder workflow
 }
 
 func (o *Operations) CreateWorkflow(ctx context.Context, params CreateWorkflowParams) (*models.Workflow, error) {
@@ -102,16 +104,27 @@ func (o *Operations) CreateWorkflow(ctx context.Context, params CreateWorkflowPa
 		return nil, NewValidationError("EDGE_VALIDATION_FAILED", err.Error())
 	}
 
+	if err := models.ValidateLaunchProfiles(params.LaunchProfiles); err != nil {
+		return nil, NewValidationError("INVALID_LAUNCH_PROFILE", err.Error())
+	}
+
+	for idx, trigger := range params.Triggers {
+		if err := trigger.ValidateDefinition(); err != nil {
+			return nil, NewValidationError("TRIGGER_VALIDATION_FAILED", fmt.Sprintf("triggers[%d]: %s", idx, err.Error()))
+		}
+	}
+
 	workflowModel := &storagemodels.WorkflowModel{
-		ID:          uuid.New(),
-		Name:        params.Name,
-		Description: params.Description,
-		Status:      "draft",
-		Version:     1,
-		Variables:   storagemodels.JSONBMap(params.Variables),
-		Metadata:    storagemodels.JSONBMap(params.Metadata),
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		ID:             uuid.New(),
+		Name:           params.Name,
+		Description:    params.Description,
+		Status:         "draft",
+		Version:        1,
+		Variables:      storagemodels.JSONBMap(params.Variables),
+		Metadata:       storagemodels.JSONBMap(params.Metadata),
+		LaunchProfiles: storagemodels.LaunchProfilesToStorage(params.LaunchProfiles),
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
 	}
 
 	if params.CreatedBy != nil {
@@ -142,6 +155,7 @@ func (o *Operations) CreateWorkflow(ctx context.Context, params CreateWorkflowPa
 				ToNodeID:     edgeReq.To,
 				SourceHandle: edgeReq.SourceHandle,
 				Condition:    storagemodels.JSONBMap(edgeReq.Condition),
+				Type:         edgeReq.Type,
 			}
 			if edgeReq.Loop != nil {
 				em.Loop = storagemodels.JSONBMap{
@@ -177,7 +191,25 @@ func (o *Operations) CreateWorkflow(ctx context.Context, params CreateWorkflowPa
 		return nil, err
 	}
 
-	return storagemodels.WorkflowModelToDomain(workflowModel), nil
+	workflow := storagemodels.WorkflowModelToDomain(workflowModel)
+	for _, t := range params.Triggers {
+		trigger, err := o.CreateTrigger(ctx, CreateTriggerParams{
+			WorkflowID:  workflowModel.ID.String(),
+			Name:        t.Name,
+			Description: t.Description,
+			Type:        string(t.Type),
+			Config:      t.Config,
+			Enabled:     t.Enabled,
+		})
+		if err != nil {
+			// Rollback workflow creation
+			_ = o.WorkflowRepo.HardDelete(ctx, workflowModel.ID)
+			return nil, err
+		}
+		workflow.Triggers = append(workflow.Triggers, trigger)
+	}
+
+	return workflow, nil
 }
 
 // NodeInput represents a node in an update request.
@@ -197,6 +229,7 @@ type EdgeInput struct {
 	SourceHandle string
 	Condition    map[string]any
 	Loop         *LoopInput
+	Type         string
 }
 
 // LoopInput represents loop configuration for an edge.
@@ -213,14 +246,15 @@ type ResourceInput struct {
 
 // UpdateWorkflowParams contains parameters for updating a workflow.
 type UpdateWorkflowParams struct {
-	WorkflowID  uuid.UUID
-	Name        string
-	Description string
-	Variables   map[string]any
-	Metadata    map[string]any
-	Nodes       []NodeInput
-	Edges       []EdgeInput
-	Resources   []ResourceInput
+	WorkflowID     uuid.UUID
+	Name           string
+	Description    string
+	Variables      map[string]any
+	Metadata       map[string]any
+	LaunchProfiles map[string]*models.LaunchProfile // nil keeps the current profiles
+	Nodes          []NodeInput
+	Edges          []EdgeInput
+	Resources      []ResourceInput
 }
 
 func (o *Operations) UpdateWorkflow(ctx context.Context, params UpdateWorkflowParams) (*models.Workflow, error) {
@@ -232,6 +266,10 @@ func (o *Operations) UpdateWorkflow(ctx context.Context, params UpdateWorkflowPa
 		return nil, NewValidationError("EDGE_VALIDATION_FAILED", err.Error())
 	}
 
+	if err := models.ValidateLaunchProfiles(params.LaunchProfiles); err != nil {
+		return nil, NewValidationError("INVALID_LAUNCH_PROFILE", err.Error())
+	}
+
 	workflowModel, err := o.WorkflowRepo.FindByID(ctx, params.WorkflowID)
 	if err != nil {
 		o.Logger.Error("Failed to find workflow for update", "error", err, "workflow_id", params.WorkflowID)
@@ -250,6 +288,12 @@ func (o *Operations) UpdateWorkflow(ctx context.Context, params UpdateWorkflowPa
 	if params.Metadata != nil {
 		workflowModel.Metadata = storagemodels.JSONBMap(params.Metadata)
 	}
+	if params.LaunchProfiles != nil {
+		workflowModel.LaunchProfiles = storagemodels.LaunchProfilesToStorage(params.LaunchProfiles)
+		if workflowModel.LaunchProfiles == nil {
+			workflowModel.LaunchProfiles = storagemodels.JSONBMap{}
+		}
+	}
 
 	if params.Nodes != nil {
 		workflowModel.Nodes = make([]*storagemodels.NodeModel, len(params.Nodes))
@@ -275,6 +319,7 @@ func (o *Operations) UpdateWorkflow(ctx context.Context, params UpdateWorkflowPa
 				ToNodeID:     edgeReq.To,
 				SourceHandle: edgeReq.SourceHandle,
 				Condition:    storagemodels.JSONBMap(edgeReq.Condition),
+				Type:         edgeReq.Type,
 			}
 			if edgeReq.Loop != nil {
 				em.Loop = storagemodels.JSONBMap{
@@ -338,12 +383,27 @@ type PublishWorkflowParams struct {
 	WorkflowID uuid.UUID
 }
 
+// PublishWorkflow activates the workflow once every publish check required by its
+// metadata passes; otherwise it fails with the checklist report in the error details.
 func (o *Operations) PublishWorkflow(ctx context.Context, params PublishWorkflowParams) (*models.Workflow, error) {
 	workflowModel, err := o.WorkflowRepo.FindByID(ctx, params.WorkflowID)
 	if err != nil {
 		o.Logger.Error("Failed to find workflow for publish", "error", err, "workflow_id", params.WorkflowID)
 		return nil, err
 	}
+
+	workflow := storagemodels.WorkflowModelToDomain(workflowModel)
+	if checks := workflow.RequiredPublishChecks(); len(checks) > 0 {
+		report, err := o.runPublishChecks(ctx, workflow, checks)
+		if err != nil {
+			return nil, err
+		}
+		if !report.Passed {
+			o.Logger.Info("Workflow publish blocked by failing checks", "workflow_id", params.WorkflowID, "failed", report.Failed())
+			return nil, newPublishChecksFailedError(report)
+		}
+	}
+
 	workflowModel.Status = "active"
 	if err := o.WorkflowRepo.Update(ctx, workflowModel); err != nil {
 		o.Logger.Error("Failed to publish workflow", "error", err, "workflow_id", params.WorkflowID)
@@ -484,10 +544,12 @@ func (o *Operations) validateNodes(nodes []NodeInput) error {
 
 	// Types that bypass executor validation:
 	// - "comment": UI-only annotation node, not executed
-	// - "sub_workflow": handled directly by the DAG engine (see dag_executor.go)
+	// - "sub_workflow", "foreach", "while": handled directly by the DAG engine (see dag_executor.go)
 	uiOnlyTypes := map[string]bool{
 		"comment":      true,
 		"sub_workflow": true,
+		"foreach":      true,
+		"while":        true,
 	}
 
 	nodeIDs := make(map[string]bool)
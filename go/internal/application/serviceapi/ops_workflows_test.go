@@ -267,6 +267,73 @@ func TestCreateWorkflow_ShouldNotSetCreatedBy_WhenNil(t *testing.T) {
 	assert.Nil(t, savedModel.CreatedBy)
 }
 
+func TestCreateWorkflow_ShouldCreateTriggers_WhenProvided(t *testing.T) {
+	wfRepo := new(mockWorkflowRepo)
+	trigRepo := new(mockTriggerRepo)
+	ops := newTestOperations(wfRepo, nil, trigRepo, nil, nil, nil, nil)
+
+	var savedModel *storagemodels.WorkflowModel
+	wfRepo.On("Create", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			savedModel = args.Get(1).(*storagemodels.WorkflowModel)
+		}).
+		Return(nil)
+	wfRepo.On("FindByID", mock.Anything, mock.Anything).Return(&storagemodels.WorkflowModel{}, nil)
+	trigRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.TriggerModel")).Return(nil)
+
+	result, err := ops.CreateWorkflow(context.Background(), CreateWorkflowParams{
+		Name: "Test",
+		Triggers: []*models.Trigger{
+			{Name: "Daily", Type: models.TriggerTypeCron, Config: map[string]any{"schedule": "0 9 * * *"}, Enabled: true},
+			{Name: "Run now", Type: models.TriggerTypeManual, Config: map[string]any{}},
+		},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Triggers, 2)
+	assert.Equal(t, savedModel.ID.String(), result.Triggers[0].WorkflowID)
+	assert.Equal(t, "Daily", result.Triggers[0].Name)
+	assert.True(t, result.Triggers[0].Enabled)
+	assert.False(t, result.Triggers[1].Enabled)
+	trigRepo.AssertNumberOfCalls(t, "Create", 2)
+}
+
+func TestCreateWorkflow_ShouldReturnError_WhenTriggerInvalid(t *testing.T) {
+	wfRepo := new(mockWorkflowRepo)
+	ops := newTestOperations(wfRepo, nil, nil, nil, nil, nil, nil)
+
+	result, err := ops.CreateWorkflow(context.Background(), CreateWorkflowParams{
+		Name:     "Test",
+		Triggers: []*models.Trigger{{Name: "Daily", Type: models.TriggerTypeCron, Config: map[string]any{}}},
+	})
+
+	assert.Nil(t, result)
+	var opErr *OperationError
+	require.ErrorAs(t, err, &opErr)
+	assert.Equal(t, "TRIGGER_VALIDATION_FAILED", opErr.Code)
+	wfRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCreateWorkflow_ShouldDeleteWorkflow_WhenTriggerCreateFails(t *testing.T) {
+	wfRepo := new(mockWorkflowRepo)
+	trigRepo := new(mockTriggerRepo)
+	ops := newTestOperations(wfRepo, nil, trigRepo, nil, nil, nil, nil)
+
+	wfRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	wfRepo.On("FindByID", mock.Anything, mock.Anything).Return(&storagemodels.WorkflowModel{}, nil)
+	wfRepo.On("HardDelete", mock.Anything, mock.Anything).Return(nil)
+	trigRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("create failed"))
+
+	result, err := ops.CreateWorkflow(context.Background(), CreateWorkflowParams{
+		Name:     "Test",
+		Triggers: []*models.Trigger{{Name: "Run now", Type: models.TriggerTypeManual, Config: map[string]any{}}},
+	})
+
+	assert.Nil(t, result)
+	require.Error(t, err)
+	wfRepo.AssertCalled(t, "HardDelete", mock.Anything, mock.Anything)
+}
+
 func TestCreateWorkflow_ShouldPersistNodesAndEdges_WhenProvided(t *testing.T) {
 	wfRepo := new(mockWorkflowRepo)
 	ops := newTestOperations(wfRepo, nil, nil, nil, nil, nil, newMockExecutorManager("transform", "llm"))
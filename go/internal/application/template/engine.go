@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor/compilecache"
 )
 
 // Engine is the main template resolution engine.
@@ -39,6 +42,11 @@ func (e *Engine) Resolve(data any) (any, error) {
 
 	switch v := data.(type) {
 	case string:
+		if e.options.PreserveTypes {
+			if value, ok := e.resolveSinglePlaceholder(v); ok {
+				return value, nil
+			}
+		}
 		return e.ResolveString(v)
 	case map[string]any:
 		return e.resolveMap(v)
@@ -53,58 +61,122 @@ func (e *Engine) Resolve(data any) (any, error) {
 // ResolveString resolves templates in a single string.
 // Example: "Hello {{env.name}}" -> "Hello World"
 func (e *Engine) ResolveString(template string) (string, error) {
-	if template == "" {
+	if !strings.Contains(template, "{{") {
 		return template, nil
 	}
 
+	parts, _ := compiledTemplates.GetOrCompile(template, func() ([]templatePart, error) {
+		return compileTemplate(template), nil
+	})
+
 	var resolveErr error
-	result := templatePattern.ReplaceAllStringFunc(template, func(match string) string {
-		// Extract the variable reference (remove {{ and }})
-		varRef := strings.TrimSpace(match[2 : len(match)-2])
+	var b strings.Builder
+	for _, part := range parts {
+		if part.placeholder == "" {
+			b.WriteString(part.literal)
+			continue
+		}
 
-		// Parse variable type and path
-		varType, path := e.parseVariableRef(varRef)
-		if varType == "" {
+		if part.varType == "" {
 			// Only set error in strict mode
 			if e.options.StrictMode {
-				resolveErr = fmt.Errorf("%w: invalid variable reference '%s'", ErrInvalidTemplate, varRef)
+				resolveErr = fmt.Errorf("%w: invalid variable reference '%s'", ErrInvalidTemplate, part.varRef)
 			}
 			if e.options.PlaceholderOnMissing {
-				return match
+				b.WriteString(part.placeholder)
 			}
-			return ""
+			continue
 		}
 
 		// Resolve the variable
-		value, err := e.resolver.ResolveVariable(varType, path)
+		value, err := e.resolver.ResolveVariable(part.varType, part.path)
 		if err != nil {
 			// Only set error in strict mode
 			if e.options.StrictMode {
 				resolveErr = &TemplateError{
 					Template: template,
-					Variable: varType,
-					Path:     path,
+					Variable: part.varType,
+					Path:     part.path,
 					Err:      err,
 				}
-				return ""
+				continue
 			}
 
-			// Non-strict mode: return placeholder or empty
+			// Non-strict mode: keep placeholder or leave empty
 			if e.options.PlaceholderOnMissing {
-				return match
+				b.WriteString(part.placeholder)
 			}
-			return ""
+			continue
 		}
 
 		// Convert value to string
-		return e.valueToString(value)
-	})
+		b.WriteString(e.valueToString(value))
+	}
 
 	if resolveErr != nil {
 		return "", resolveErr
 	}
 
-	return result, nil
+	return b.String(), nil
+}
+
+// resolveSinglePlaceholder resolves a template consisting of exactly one placeholder to the
+// value it references. It reports false for other templates and for values that cannot be
+// resolved, which are then handled by ResolveString according to the options.
+func (e *Engine) resolveSinglePlaceholder(template string) (any, bool) {
+	if !strings.HasPrefix(template, "{{") || !strings.HasSuffix(template, "}}") {
+		return nil, false
+	}
+	parts, _ := compiledTemplates.GetOrCompile(template, func() ([]templatePart, error) {
+		return compileTemplate(template), nil
+	})
+	if len(parts) != 1 || parts[0].placeholder == "" || parts[0].varType == "" {
+		return nil, false
+	}
+	value, err := e.resolver.ResolveVariable(parts[0].varType, parts[0].path)
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// compiledTemplates caches template strings split into literals and parsed
+// placeholders, so that node configs are not re-parsed on every execution.
+var compiledTemplates = compilecache.New[[]templatePart]("template", 4*compilecache.DefaultCapacity)
+
+// templatePart is a literal piece or a placeholder of a compiled template.
+type templatePart struct {
+	literal     string
+	placeholder string // the whole placeholder, e.g. "{{input.name}}"; empty for literals
+	varRef      string
+	varType     string // empty when the reference is invalid
+	path        string
+}
+
+// compileTemplate splits a template string into literals and placeholders.
+func compileTemplate(template string) []templatePart {
+	matches := templatePattern.FindAllStringSubmatchIndex(template, -1)
+	parts := make([]templatePart, 0, 2*len(matches)+1)
+
+	last := 0
+	for _, m := range matches {
+		if m[0] > last {
+			parts = append(parts, templatePart{literal: template[last:m[0]]})
+		}
+		varRef := strings.TrimSpace(template[m[2]:m[3]])
+		varType, path := parseVariableRef(varRef)
+		parts = append(parts, templatePart{
+			placeholder: template[m[0]:m[1]],
+			varRef:      varRef,
+			varType:     varType,
+			path:        path,
+		})
+		last = m[1]
+	}
+	if last < len(template) {
+		parts = append(parts, templatePart{literal: template[last:]})
+	}
+	return parts
 }
 
 // resolveMap resolves templates in all values of a map.
@@ -182,6 +254,10 @@ func (e *Engine) resolveComplex(data any) (any, error) {
 //   - "env.items[0].name" -> ("env", "items[0].name")
 //   - "input" -> ("input", "") - returns entire input object
 func (e *Engine) parseVariableRef(ref string) (string, string) {
+	return parseVariableRef(ref)
+}
+
+func parseVariableRef(ref string) (string, string) {
 	parts := strings.SplitN(ref, ".", 2)
 
 	varType := strings.TrimSpace(parts[0])
@@ -215,8 +291,14 @@ func (e *Engine) valueToString(value any) string {
 		return fmt.Sprintf("%d", v)
 	case uint, uint8, uint16, uint32, uint64:
 		return fmt.Sprintf("%d", v)
-	case float32, float64:
-		return fmt.Sprintf("%v", v)
+	case float64:
+		// Plain notation: amounts such as 1234567.5 must not render as 1.2345675e+06
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case json.Number:
+		// Preserved numbers render with their exact digits
+		return v.String()
 	default:
 		// For complex types, marshal to JSON
 		if data, err := json.Marshal(v); err == nil {
@@ -1,6 +1,7 @@
 package template
 
 import (
+	"encoding/json"
 	"errors"
 	"reflect"
 	"testing"
@@ -324,6 +325,9 @@ func TestEngine_ValueToString(t *testing.T) {
 	ctx.InputVars["string"] = "text"
 	ctx.InputVars["number"] = 42
 	ctx.InputVars["float"] = 3.14
+	ctx.InputVars["large_float"] = 1234567.5
+	ctx.InputVars["json_number"] = json.Number("9007199254740993")
+	ctx.InputVars["amount"] = map[string]any{"value": json.Number("10.10")}
 	ctx.InputVars["bool"] = true
 	ctx.InputVars["object"] = map[string]any{"key": "value"}
 
@@ -349,6 +353,21 @@ func TestEngine_ValueToString(t *testing.T) {
 			template: "{{input.float}}",
 			want:     "3.14",
 		},
+		{
+			name:     "large float",
+			template: "{{input.large_float}}",
+			want:     "1234567.5",
+		},
+		{
+			name:     "json number",
+			template: "{{input.json_number}}",
+			want:     "9007199254740993",
+		},
+		{
+			name:     "nested json number",
+			template: "{{input.amount.value}}",
+			want:     "10.10",
+		},
 		{
 			name:     "bool",
 			template: "{{input.bool}}",
@@ -1772,3 +1791,90 @@ func TestEngine_ComplexScenario_WithResources(t *testing.T) {
 		t.Errorf("metadata.bucket = %v, want my-data-bucket", metadata["bucket"])
 	}
 }
+
+func TestEngine_ResolveString_ReusesCompiledTemplates(t *testing.T) {
+	tmpl := "Order {{input.cached_order_id}} for {{env.cached_customer}}, total {{input.missing_total}}"
+	before := compiledTemplates.Stats()
+
+	for i, want := range []string{"Order 1 for Acme, total ", "Order 2 for Acme, total ", "Order 3 for Acme, total "} {
+		ctx := NewVariableContext()
+		ctx.InputVars = map[string]any{"cached_order_id": i + 1}
+		ctx.WorkflowVars = map[string]any{"cached_customer": "Acme"}
+		engine := NewEngineWithDefaults(ctx)
+
+		got, err := engine.ResolveString(tmpl)
+		if err != nil {
+			t.Fatalf("ResolveString() error = %v", err)
+		}
+		if got != want {
+			t.Errorf("ResolveString() = %q, want %q", got, want)
+		}
+	}
+
+	after := compiledTemplates.Stats()
+	if misses := after.Misses - before.Misses; misses != 1 {
+		t.Errorf("template compiled %d times, want 1", misses)
+	}
+	if hits := after.Hits - before.Hits; hits != 2 {
+		t.Errorf("cache hits = %d, want 2", hits)
+	}
+
+	// Strings without placeholders are not cached
+	engine := NewEngineWithDefaults(NewVariableContext())
+	if got, err := engine.ResolveString("plain text"); err != nil || got != "plain text" {
+		t.Errorf("ResolveString(plain) = %q, %v", got, err)
+	}
+	if compiledTemplates.Stats().Misses != after.Misses {
+		t.Error("plain string was compiled")
+	}
+}
+
+func TestEngine_ResolveString_CompiledTemplateOptions(t *testing.T) {
+	tmpl := "a {{bogus}} b {{input.missing}} c"
+
+	lenient := NewEngine(NewVariableContext(), TemplateOptions{PlaceholderOnMissing: true})
+	if got, err := lenient.ResolveString(tmpl); err != nil || got != tmpl {
+		t.Errorf("lenient ResolveString() = %q, %v; want the template unchanged", got, err)
+	}
+
+	strict := NewEngine(NewVariableContext(), TemplateOptions{StrictMode: true})
+	if _, err := strict.ResolveString(tmpl); err == nil {
+		t.Error("strict ResolveString() expected error")
+	}
+}
+
+func TestEngine_Resolve_PreserveTypes(t *testing.T) {
+	ctx := NewVariableContext()
+	ctx.InputVars = map[string]any{
+		"count": 3,
+		"items": []any{"a", "b"},
+		"user":  map[string]any{"name": "Ann"},
+	}
+	engine := NewEngine(ctx, TemplateOptions{PreserveTypes: true})
+
+	got, err := engine.Resolve(map[string]any{
+		"count":   "{{input.count}}",
+		"items":   "{{input.items}}",
+		"user":    "{{ input.user }}",
+		"text":    "{{input.count}} items",
+		"missing": "{{input.missing}}",
+	})
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	want := map[string]any{
+		"count":   3,
+		"items":   []any{"a", "b"},
+		"user":    map[string]any{"name": "Ann"},
+		"text":    "3 items",
+		"missing": "",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Resolve() = %#v, want %#v", got, want)
+	}
+
+	plain, _ := NewEngineWithDefaults(ctx).Resolve("{{input.count}}")
+	if plain != "3" {
+		t.Errorf("Resolve() without PreserveTypes = %#v, want \"3\"", plain)
+	}
+}
@@ -1,6 +1,7 @@
 package template
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -257,7 +258,7 @@ func (r *Resolver) resolveField(value any, field string) any {
 	// Try JSON unmarshaling for complex types
 	if data, err := json.Marshal(value); err == nil {
 		var m map[string]any
-		if err := json.Unmarshal(data, &m); err == nil {
+		if err := unmarshalUseNumber(data, &m); err == nil {
 			return m[field]
 		}
 	}
@@ -322,7 +323,7 @@ func (r *Resolver) indexArray(value any, index int) (any, error) {
 	// Try JSON array
 	if data, err := json.Marshal(value); err == nil {
 		var arr []any
-		if err := json.Unmarshal(data, &arr); err == nil {
+		if err := unmarshalUseNumber(data, &arr); err == nil {
 			if index < 0 || index >= len(arr) {
 				return nil, fmt.Errorf("%w: index %d, length %d", ErrArrayOutOfBounds, index, len(arr))
 			}
@@ -403,3 +404,11 @@ func parseArrayIndices(expr string) []int {
 
 	return indices
 }
+
+// unmarshalUseNumber decodes JSON with numbers as json.Number, so values read through
+// the JSON fallbacks keep their exact digits when rendered.
+func unmarshalUseNumber(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
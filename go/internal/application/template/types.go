@@ -93,6 +93,10 @@ type TemplateOptions struct {
 	// Only applies when StrictMode is false
 	// If false, replaces with empty string instead
 	PlaceholderOnMissing bool
+
+	// PreserveTypes returns the resolved value itself, instead of its string form,
+	// when a string consists of a single placeholder such as "{{input.items}}"
+	PreserveTypes bool
 }
 
 // DefaultOptions returns the default template options.
@@ -0,0 +1,55 @@
+package trigger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// CredentialResolver resolves decrypted credentials by resource ID.
+type CredentialResolver interface {
+	GetDecrypted(ctx context.Context, resourceID string) (*models.CredentialsResource, error)
+}
+
+// resolveWorkflowCredential decrypts a credential referenced by a trigger. The credential
+// must be attached to the workflow as a resource, so a trigger can only use credentials
+// that belong to the workflow owner.
+func resolveWorkflowCredential(
+	ctx context.Context,
+	workflowRepo repository.WorkflowRepository,
+	credentials CredentialResolver,
+	workflowID, credentialID string,
+) (*models.CredentialsResource, error) {
+	if credentials == nil {
+		return nil, fmt.Errorf("credentials are not available (encryption is not configured)")
+	}
+
+	workflowUUID, err := uuid.Parse(workflowID)
+	if err != nil {
+		return nil, models.ErrInvalidWorkflowID
+	}
+	workflow, err := workflowRepo.FindByIDWithRelations(ctx, workflowUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workflow: %w", err)
+	}
+
+	attached := false
+	for _, res := range workflow.Resources {
+		if res != nil && res.ResourceID.String() == credentialID {
+			attached = true
+			break
+		}
+	}
+	if !attached {
+		return nil, fmt.Errorf("credential %s is not attached to the workflow as a resource", credentialID)
+	}
+
+	cred, err := credentials.GetDecrypted(ctx, credentialID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve credential %s: %w", credentialID, err)
+	}
+	return cred, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/robfig/cron/v3"
 	"github.com/smilemakc/mbflow/go/internal/application/engine"
+	"github.com/smilemakc/mbflow/go/internal/application/maintenance"
 	"github.com/smilemakc/mbflow/go/internal/domain/repository"
 	"github.com/smilemakc/mbflow/go/internal/infrastructure/cache"
 	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
@@ -21,6 +22,7 @@ type CronScheduler struct {
 	workflowRepo repository.WorkflowRepository
 	executionMgr *engine.ExecutionManager
 	cache        *cache.RedisCache
+	readOnly     *maintenance.ReadOnlyMode
 
 	cron    *cron.Cron
 	entries map[string]cron.EntryID // triggerID -> entryID
@@ -33,6 +35,8 @@ type CronSchedulerConfig struct {
 	WorkflowRepo repository.WorkflowRepository
 	ExecutionMgr *engine.ExecutionManager
 	Cache        *cache.RedisCache
+	// ReadOnly skips scheduled runs while read-only mode is on (optional)
+	ReadOnly *maintenance.ReadOnlyMode
 }
 
 // NewCronScheduler creates a new cron scheduler
@@ -45,6 +49,7 @@ func NewCronScheduler(cfg CronSchedulerConfig) (*CronScheduler, error) {
 		workflowRepo: cfg.WorkflowRepo,
 		executionMgr: cfg.ExecutionMgr,
 		cache:        cfg.Cache,
+		readOnly:     cfg.ReadOnly,
 		cron:         c,
 		entries:      make(map[string]cron.EntryID),
 	}, nil
@@ -230,6 +235,10 @@ func (cs *CronScheduler) createJob(trigger *models.Trigger) cron.Job {
 
 // executeTrigger executes a workflow triggered by the cron schedule
 func (cs *CronScheduler) executeTrigger(ctx context.Context, trigger *models.Trigger) error {
+	if err := checkReadOnly(cs.readOnly); err != nil {
+		return err
+	}
+
 	// Get default input from trigger config
 	input := make(map[string]any)
 	if defaultInput, ok := trigger.Config["input"].(map[string]any); ok {
@@ -237,7 +246,7 @@ func (cs *CronScheduler) executeTrigger(ctx context.Context, trigger *models.Tri
 	}
 
 	// Execute workflow
-	_, err := cs.executionMgr.Execute(ctx, trigger.WorkflowID, input, nil)
+	_, err := cs.executionMgr.Execute(ctx, trigger.WorkflowID, input, triggerExecutionOptions(trigger))
 	if err != nil {
 		return fmt.Errorf("failed to execute workflow: %w", err)
 	}
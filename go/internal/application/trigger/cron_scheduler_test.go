@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/smilemakc/mbflow/go/internal/application/maintenance"
 	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
 	"github.com/smilemakc/mbflow/go/pkg/models"
 	"github.com/stretchr/testify/assert"
@@ -814,3 +815,12 @@ func TestCronScheduler_updateNextExecution(t *testing.T) {
 // These functions are best tested in integration tests with full infrastructure.
 // They are currently at 0% and 20% coverage respectively and will remain so
 // until proper integration test infrastructure is available.
+
+func TestCronScheduler_SkipsRunsWhileReadOnly(t *testing.T) {
+	// No execution manager: a run that was not skipped would panic
+	cs, err := NewCronScheduler(CronSchedulerConfig{ReadOnly: maintenance.NewReadOnlyMode(true, "")})
+	require.NoError(t, err)
+
+	err = cs.executeTrigger(context.Background(), &models.Trigger{ID: uuid.New().String(), WorkflowID: uuid.New().String()})
+	assert.ErrorIs(t, err, errReadOnly)
+}
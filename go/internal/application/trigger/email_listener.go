@@ -0,0 +1,654 @@
+package trigger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/smilemakc/mbflow/go/internal/application/engine"
+	"github.com/smilemakc/mbflow/go/internal/application/filestorage"
+	"github.com/smilemakc/mbflow/go/internal/application/maintenance"
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/cache"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+const (
+	// emailIdleTimeout re-issues IDLE before the 29 minute server timeout (RFC 2177).
+	emailIdleTimeout = 25 * time.Minute
+
+	// emailDefaultPollInterval is used in poll mode or when the server lacks IDLE.
+	emailDefaultPollInterval = 60 * time.Second
+
+	// emailMinPollInterval protects mail servers from aggressive polling.
+	emailMinPollInterval = 10 * time.Second
+
+	// emailMaxBackoff caps the reconnect delay after connection errors.
+	emailMaxBackoff = 5 * time.Minute
+)
+
+// workflowExecutor starts workflow executions.
+type workflowExecutor interface {
+	Execute(ctx context.Context, workflowID string, input map[string]any, opts *engine.ExecutionOptions) (*models.Execution, error)
+}
+
+// EmailListener watches IMAP mailboxes for email triggers and starts an execution per new message.
+// Each trigger gets its own connection that uses IDLE when the server supports it and
+// falls back to polling otherwise.
+type EmailListener struct {
+	triggerRepo  repository.TriggerRepository
+	workflowRepo repository.WorkflowRepository
+	executionMgr workflowExecutor
+	cache        *cache.RedisCache
+	credentials  CredentialResolver
+	storage      filestorage.Manager
+	readOnly     *maintenance.ReadOnlyMode
+
+	watchers map[string]*emailWatcher // triggerID -> watcher
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup // in-flight executions
+	mu       sync.Mutex
+}
+
+// EmailListenerConfig holds configuration for email listener
+type EmailListenerConfig struct {
+	TriggerRepo  repository.TriggerRepository
+	WorkflowRepo repository.WorkflowRepository
+	ExecutionMgr *engine.ExecutionManager
+	Cache        *cache.RedisCache
+	// Credentials resolves IMAP credentials; email triggers cannot connect without it.
+	Credentials CredentialResolver
+	// FileStorage stores attachments; when nil attachments are reported without file IDs.
+	FileStorage filestorage.Manager
+	// ReadOnly stops reading the mailbox while read-only mode is on (optional). Messages
+	// received meanwhile are processed once it is turned off.
+	ReadOnly *maintenance.ReadOnlyMode
+}
+
+// emailWatcher is the running state of one email trigger.
+type emailWatcher struct {
+	trigger *models.Trigger
+	config  emailTriggerConfig
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// emailTriggerConfig is the parsed trigger configuration with defaults applied.
+type emailTriggerConfig struct {
+	dial            imapDialConfig
+	credentialID    string
+	mailbox         string
+	idle            bool
+	pollInterval    time.Duration
+	markSeen        bool
+	processExisting bool
+	storageID       string
+	input           map[string]any
+}
+
+// emailCursor tracks the last processed message of a mailbox.
+type emailCursor struct {
+	UIDValidity uint32    `json:"uid_validity"`
+	LastUID     uint32    `json:"last_uid"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// NewEmailListener creates a new email listener
+func NewEmailListener(cfg EmailListenerConfig) *EmailListener {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	el := &EmailListener{
+		triggerRepo:  cfg.TriggerRepo,
+		workflowRepo: cfg.WorkflowRepo,
+		cache:        cfg.Cache,
+		credentials:  cfg.Credentials,
+		storage:      cfg.FileStorage,
+		readOnly:     cfg.ReadOnly,
+		watchers:     make(map[string]*emailWatcher),
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+	if cfg.ExecutionMgr != nil {
+		el.executionMgr = cfg.ExecutionMgr
+	}
+	return el
+}
+
+// Start starts watchers for the enabled email triggers
+func (el *EmailListener) Start(ctx context.Context, triggers []*storagemodels.TriggerModel) error {
+	for _, trigger := range triggers {
+		if trigger.Type != string(models.TriggerTypeEmail) {
+			continue
+		}
+		if err := el.AddTrigger(ctx, el.modelToDomain(trigger)); err != nil {
+			fmt.Printf("failed to add email trigger %s: %v\n", trigger.ID, err)
+		}
+	}
+	return nil
+}
+
+// Stop stops all watchers and waits for in-flight executions
+func (el *EmailListener) Stop() error {
+	el.cancel()
+
+	el.mu.Lock()
+	watchers := make([]*emailWatcher, 0, len(el.watchers))
+	for id, w := range el.watchers {
+		watchers = append(watchers, w)
+		delete(el.watchers, id)
+	}
+	el.mu.Unlock()
+
+	for _, w := range watchers {
+		<-w.done
+	}
+	el.wg.Wait()
+	return nil
+}
+
+// AddTrigger starts watching the mailbox of an email trigger
+func (el *EmailListener) AddTrigger(ctx context.Context, trigger *models.Trigger) error {
+	if trigger.Type != models.TriggerTypeEmail {
+		return nil // Not an email trigger
+	}
+
+	cfg, err := parseEmailTriggerConfig(trigger.Config)
+	if err != nil {
+		return err
+	}
+
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	if el.ctx.Err() != nil {
+		return fmt.Errorf("email listener is stopped")
+	}
+	el.stopWatcherLocked(trigger.ID)
+
+	watchCtx, cancel := context.WithCancel(el.ctx)
+	w := &emailWatcher{
+		trigger: trigger,
+		config:  cfg,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	el.watchers[trigger.ID] = w
+
+	go func() {
+		defer close(w.done)
+		el.watch(watchCtx, w)
+	}()
+
+	return nil
+}
+
+// RemoveTrigger stops watching the mailbox of an email trigger
+func (el *EmailListener) RemoveTrigger(ctx context.Context, triggerID string) error {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	el.stopWatcherLocked(triggerID)
+	return nil
+}
+
+// stopWatcherLocked stops a watcher and waits for it to exit (must hold lock)
+func (el *EmailListener) stopWatcherLocked(triggerID string) {
+	w, ok := el.watchers[triggerID]
+	if !ok {
+		return
+	}
+	w.cancel()
+	<-w.done
+	delete(el.watchers, triggerID)
+}
+
+// watch keeps a session open for the trigger, reconnecting with backoff on errors
+func (el *EmailListener) watch(ctx context.Context, w *emailWatcher) {
+	backoff := 5 * time.Second
+
+	for ctx.Err() == nil {
+		connected, err := el.session(ctx, w)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			backoff = 5 * time.Second
+		}
+		if err != nil {
+			fmt.Printf("email trigger %s: %v (reconnecting in %s)\n", w.trigger.ID, err, backoff)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, emailMaxBackoff)
+	}
+}
+
+// session connects, processes new messages and waits for more until an error occurs.
+// connected reports whether login and mailbox selection succeeded.
+func (el *EmailListener) session(ctx context.Context, w *emailWatcher) (connected bool, err error) {
+	if err := checkReadOnly(el.readOnly); err != nil {
+		return false, err
+	}
+
+	username, password, err := el.resolveCredentials(ctx, w.trigger, w.config.credentialID)
+	if err != nil {
+		return false, err
+	}
+
+	client, err := dialIMAP(ctx, w.config.dial)
+	if err != nil {
+		return false, err
+	}
+	defer client.Logout()
+
+	if err := client.Login(username, password); err != nil {
+		return false, err
+	}
+
+	mailbox, err := client.Select(w.config.mailbox)
+	if err != nil {
+		return false, err
+	}
+
+	cursor, err := el.initCursor(ctx, client, w, mailbox)
+	if err != nil {
+		return true, err
+	}
+
+	idle := false
+	if w.config.idle {
+		if idle, err = client.HasCapability("IDLE"); err != nil {
+			return true, err
+		}
+	}
+
+	for {
+		if err := el.processNew(ctx, client, w, cursor); err != nil {
+			return true, err
+		}
+
+		if idle {
+			err := client.Idle(ctx, emailIdleTimeout)
+			switch {
+			case ctx.Err() != nil:
+				return true, nil
+			case err != nil && !errors.Is(err, errIMAPIdleTimeout):
+				return true, err
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return true, nil
+		case <-time.After(w.config.pollInterval):
+		}
+		if err := client.Noop(); err != nil {
+			return true, err
+		}
+	}
+}
+
+// initCursor loads the saved cursor or starts a new one. A new cursor (or a changed
+// UIDVALIDITY) starts after the newest message, optionally processing unseen messages first.
+func (el *EmailListener) initCursor(ctx context.Context, client *imapClient, w *emailWatcher, mailbox *imapMailbox) (*emailCursor, error) {
+	cursor, err := el.loadCursor(ctx, w.trigger.ID)
+	if err == nil && cursor.UIDValidity == mailbox.UIDValidity {
+		return cursor, nil
+	}
+
+	cursor = &emailCursor{UIDValidity: mailbox.UIDValidity}
+	if mailbox.UIDNext > 0 {
+		cursor.LastUID = mailbox.UIDNext - 1
+	} else {
+		uids, err := client.UIDSearch("ALL")
+		if err != nil {
+			return nil, err
+		}
+		if len(uids) > 0 {
+			cursor.LastUID = slices.Max(uids)
+		}
+	}
+
+	if w.config.processExisting {
+		unseen, err := client.UIDSearch("UNSEEN")
+		if err != nil {
+			return nil, err
+		}
+		slices.Sort(unseen)
+		for _, uid := range unseen {
+			if err := el.processMessage(ctx, client, w, uid); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	el.saveCursor(ctx, w.trigger.ID, cursor)
+	return cursor, nil
+}
+
+// processNew processes messages with a UID above the cursor in ascending order
+func (el *EmailListener) processNew(ctx context.Context, client *imapClient, w *emailWatcher, cursor *emailCursor) error {
+	// "n:*" always matches the newest message, even when its UID is below n.
+	uids, err := client.UIDSearch(fmt.Sprintf("UID %d:*", cursor.LastUID+1))
+	if err != nil {
+		return err
+	}
+	slices.Sort(uids)
+
+	for _, uid := range uids {
+		if uid <= cursor.LastUID {
+			continue
+		}
+		// Leave the rest for the next session, which waits for read-only mode to end
+		if err := checkReadOnly(el.readOnly); err != nil {
+			return err
+		}
+		if err := el.processMessage(ctx, client, w, uid); err != nil {
+			return err
+		}
+		cursor.LastUID = uid
+		el.saveCursor(ctx, w.trigger.ID, cursor)
+	}
+	return nil
+}
+
+// processMessage fetches and parses a message, stores its attachments and starts an execution.
+// Only IMAP errors are returned; a message that cannot be parsed or executed is logged and skipped.
+func (el *EmailListener) processMessage(ctx context.Context, client *imapClient, w *emailWatcher, uid uint32) error {
+	raw, err := client.UIDFetchMessage(uid)
+	if err != nil {
+		return err
+	}
+
+	msg, err := parseEmailMessage(raw)
+	if err != nil {
+		fmt.Printf("email trigger %s: skipping message %d: %v\n", w.trigger.ID, uid, err)
+	} else {
+		input := el.buildInput(ctx, w, uid, msg)
+		el.wg.Add(1)
+		go func() {
+			defer el.wg.Done()
+			execCtx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			defer cancel()
+
+			if err := el.executeTrigger(execCtx, w.trigger, input); err != nil {
+				fmt.Printf("trigger %s execution failed: %v\n", w.trigger.ID, err)
+			}
+		}()
+	}
+
+	if w.config.markSeen {
+		return client.UIDMarkSeen(uid)
+	}
+	return nil
+}
+
+// buildInput merges the trigger's default input with the parsed message
+func (el *EmailListener) buildInput(ctx context.Context, w *emailWatcher, uid uint32, msg *emailMessage) map[string]any {
+	input := make(map[string]any, len(w.config.input)+1)
+	for k, v := range w.config.input {
+		input[k] = v
+	}
+
+	var date string
+	if !msg.Date.IsZero() {
+		date = msg.Date.UTC().Format(time.RFC3339)
+	}
+
+	input["email"] = map[string]any{
+		"uid":         uid,
+		"mailbox":     w.config.mailbox,
+		"message_id":  msg.MessageID,
+		"subject":     msg.Subject,
+		"from":        formatAddress(msg.From),
+		"to":          formatAddresses(msg.To),
+		"cc":          formatAddresses(msg.Cc),
+		"reply_to":    formatAddresses(msg.ReplyTo),
+		"date":        date,
+		"in_reply_to": msg.InReplyTo,
+		"references":  msg.References,
+		"headers":     msg.Headers,
+		"text":        msg.Text,
+		"html":        msg.HTML,
+		"attachments": el.storeAttachments(ctx, w, msg),
+	}
+	return input
+}
+
+// storeAttachments saves attachments to file storage and returns their descriptors
+func (el *EmailListener) storeAttachments(ctx context.Context, w *emailWatcher, msg *emailMessage) []any {
+	result := make([]any, 0, len(msg.Attachments))
+	if len(msg.Attachments) == 0 {
+		return result
+	}
+
+	var storage filestorage.Storage
+	var storageErr error
+	if el.storage == nil {
+		storageErr = fmt.Errorf("file storage is not available")
+	} else {
+		storage, storageErr = el.storage.GetStorage(w.config.storageID)
+	}
+
+	workflowID := w.trigger.WorkflowID
+	for _, att := range msg.Attachments {
+		info := map[string]any{
+			"file_name":  att.FileName,
+			"mime_type":  att.MimeType,
+			"size":       len(att.Data),
+			"content_id": att.ContentID,
+			"inline":     att.Inline,
+		}
+
+		if storageErr != nil {
+			info["error"] = storageErr.Error()
+			result = append(result, info)
+			continue
+		}
+
+		stored, err := storage.Store(ctx, &models.FileEntry{
+			Name:        att.FileName,
+			MimeType:    att.MimeType,
+			Size:        int64(len(att.Data)),
+			AccessScope: models.ScopeWorkflow,
+			Tags:        []string{"email"},
+			WorkflowID:  &workflowID,
+			Metadata: map[string]any{
+				"source":     "email_trigger",
+				"trigger_id": w.trigger.ID,
+				"message_id": msg.MessageID,
+			},
+		}, bytes.NewReader(att.Data))
+		if err != nil {
+			info["error"] = err.Error()
+		} else {
+			info["file_id"] = stored.ID
+			info["storage_id"] = stored.StorageID
+			info["path"] = stored.Path
+		}
+		result = append(result, info)
+	}
+	return result
+}
+
+// executeTrigger executes a workflow for a received message
+func (el *EmailListener) executeTrigger(ctx context.Context, trigger *models.Trigger, input map[string]any) error {
+	if _, err := el.executionMgr.Execute(ctx, trigger.WorkflowID, input, triggerExecutionOptions(trigger)); err != nil {
+		return fmt.Errorf("failed to execute workflow: %w", err)
+	}
+
+	// Update trigger state
+	state, err := LoadTriggerState(ctx, el.cache, trigger.ID)
+	if err != nil {
+		state = NewTriggerState(trigger.ID)
+	}
+	state.MarkExecuted()
+
+	if err := state.Save(ctx, el.cache); err != nil {
+		fmt.Printf("failed to save trigger state: %v\n", err)
+	}
+
+	// Update last triggered timestamp in database
+	triggerUUID, _ := uuid.Parse(trigger.ID)
+	if err := el.triggerRepo.MarkTriggered(ctx, triggerUUID); err != nil {
+		fmt.Printf("failed to mark trigger as triggered: %v\n", err)
+	}
+
+	return nil
+}
+
+// resolveCredentials returns the IMAP username and password.
+func (el *EmailListener) resolveCredentials(ctx context.Context, trigger *models.Trigger, credentialID string) (string, string, error) {
+	cred, err := resolveWorkflowCredential(ctx, el.workflowRepo, el.credentials, trigger.WorkflowID, credentialID)
+	if err != nil {
+		return "", "", err
+	}
+
+	switch cred.CredentialType {
+	case models.CredentialTypeBasicAuth:
+		username, password := cred.GetBasicAuth()
+		return username, password, nil
+	case models.CredentialTypeCustom:
+		return cred.DecryptedData["username"], cred.DecryptedData["password"], nil
+	default:
+		return "", "", fmt.Errorf("credential %s has unsupported type %s (expected basic_auth or custom)",
+			credentialID, cred.CredentialType)
+	}
+}
+
+// loadCursor loads the mailbox cursor from Redis
+func (el *EmailListener) loadCursor(ctx context.Context, triggerID string) (*emailCursor, error) {
+	data, err := el.cache.System().Get(ctx, getEmailCursorKey(triggerID))
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor emailCursor
+	if err := json.Unmarshal([]byte(data), &cursor); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal email cursor: %w", err)
+	}
+	return &cursor, nil
+}
+
+// saveCursor persists the mailbox cursor; failures only risk reprocessing after a restart
+func (el *EmailListener) saveCursor(ctx context.Context, triggerID string, cursor *emailCursor) {
+	cursor.UpdatedAt = time.Now()
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return
+	}
+	if err := el.cache.System().Set(ctx, getEmailCursorKey(triggerID), string(data), 0); err != nil {
+		fmt.Printf("failed to save email cursor for trigger %s: %v\n", triggerID, err)
+	}
+}
+
+// getEmailCursorKey returns the system-namespace Redis key for the mailbox cursor
+func getEmailCursorKey(triggerID string) string {
+	return fmt.Sprintf("trigger:%s:email_cursor", triggerID)
+}
+
+// DeleteEmailCursor deletes the mailbox cursor of a trigger
+func DeleteEmailCursor(ctx context.Context, cache *cache.RedisCache, triggerID string) error {
+	return cache.System().Delete(ctx, getEmailCursorKey(triggerID))
+}
+
+// parseEmailTriggerConfig parses trigger config and applies defaults
+func parseEmailTriggerConfig(config map[string]any) (emailTriggerConfig, error) {
+	cfg := emailTriggerConfig{
+		mailbox:      "INBOX",
+		idle:         true,
+		pollInterval: emailDefaultPollInterval,
+		markSeen:     true,
+		storageID:    "default",
+	}
+
+	host, _ := config["host"].(string)
+	if host == "" {
+		return cfg, fmt.Errorf("host not found in trigger config")
+	}
+	cfg.credentialID, _ = config["credential_id"].(string)
+	if cfg.credentialID == "" {
+		return cfg, fmt.Errorf("credential_id not found in trigger config")
+	}
+
+	tlsMode, _ := config["tls"].(string)
+	if tlsMode == "" {
+		tlsMode = "tls"
+	}
+	port := 993
+	if tlsMode != "tls" {
+		port = 143
+	}
+	if p, ok := config["port"].(float64); ok && p > 0 {
+		port = int(p)
+	}
+	insecure, _ := config["insecure_skip_verify"].(bool)
+	cfg.dial = imapDialConfig{Host: host, Port: port, TLS: tlsMode, InsecureSkipVerify: insecure}
+
+	if mailbox, ok := config["mailbox"].(string); ok && mailbox != "" {
+		cfg.mailbox = mailbox
+	}
+	if mode, ok := config["mode"].(string); ok && mode == "poll" {
+		cfg.idle = false
+	}
+
+	switch v := config["poll_interval"].(type) {
+	case float64:
+		cfg.pollInterval = time.Duration(v) * time.Second
+	case string:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid poll_interval: %w", err)
+		}
+		cfg.pollInterval = d
+	}
+	cfg.pollInterval = max(cfg.pollInterval, emailMinPollInterval)
+
+	if markSeen, ok := config["mark_seen"].(bool); ok {
+		cfg.markSeen = markSeen
+	}
+	cfg.processExisting, _ = config["process_existing"].(bool)
+	if storageID, ok := config["storage_id"].(string); ok && storageID != "" {
+		cfg.storageID = storageID
+	}
+	cfg.input, _ = config["input"].(map[string]any)
+
+	return cfg, nil
+}
+
+// modelToDomainTrigger converts storage model to domain model
+func (el *EmailListener) modelToDomain(tm *storagemodels.TriggerModel) *models.Trigger {
+	trigger := &models.Trigger{
+		ID:         tm.ID.String(),
+		WorkflowID: tm.WorkflowID.String(),
+		Type:       models.TriggerType(tm.Type),
+		Config:     make(map[string]any),
+		Enabled:    tm.Enabled,
+		CreatedAt:  tm.CreatedAt,
+		UpdatedAt:  tm.UpdatedAt,
+	}
+
+	if tm.Config != nil {
+		trigger.Config = map[string]any(tm.Config)
+	}
+
+	if tm.LastTriggeredAt != nil {
+		trigger.LastRun = tm.LastTriggeredAt
+	}
+
+	return trigger
+}
@@ -0,0 +1,426 @@
+package trigger
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smilemakc/mbflow/go/internal/application/engine"
+	"github.com/smilemakc/mbflow/go/internal/application/filestorage"
+	"github.com/smilemakc/mbflow/go/internal/application/maintenance"
+	"github.com/smilemakc/mbflow/go/internal/config"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/cache"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// fakeIMAPServer implements the IMAP subset used by the email listener.
+type fakeIMAPServer struct {
+	listener net.Listener
+	username string
+	password string
+
+	mu       sync.Mutex
+	messages map[uint32][]byte
+	seen     map[uint32]bool
+	uidNext  uint32
+	newMail  chan struct{}
+}
+
+func newFakeIMAPServer(t *testing.T) *fakeIMAPServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &fakeIMAPServer{
+		listener: ln,
+		username: "inbox@example.com",
+		password: `p"ss\word`,
+		messages: make(map[uint32][]byte),
+		seen:     make(map[uint32]bool),
+		uidNext:  10,
+		newMail:  make(chan struct{}, 10),
+	}
+	go s.serve()
+	t.Cleanup(func() { ln.Close() })
+	return s
+}
+
+func (s *fakeIMAPServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeIMAPServer) deliver(raw string) uint32 {
+	s.mu.Lock()
+	uid := s.uidNext
+	s.messages[uid] = []byte(raw)
+	s.uidNext++
+	s.mu.Unlock()
+
+	s.newMail <- struct{}{}
+	return uid
+}
+
+func (s *fakeIMAPServer) isSeen(uid uint32) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.seen[uid]
+}
+
+func (s *fakeIMAPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeIMAPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	w := func(format string, args ...any) { fmt.Fprintf(conn, format+"\r\n", args...) }
+
+	w("* OK fake IMAP ready")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		tag, cmd, _ := strings.Cut(line, " ")
+		upper := strings.ToUpper(cmd)
+
+		switch {
+		case upper == "CAPABILITY":
+			w("* CAPABILITY IMAP4rev1 IDLE")
+			w("%s OK done", tag)
+		case strings.HasPrefix(upper, "LOGIN "):
+			user, _ := imapQuote(s.username)
+			pass, _ := imapQuote(s.password)
+			if cmd[len("LOGIN "):] != user+" "+pass {
+				w("%s NO authentication failed", tag)
+				continue
+			}
+			w("%s OK logged in", tag)
+		case strings.HasPrefix(upper, "SELECT "):
+			s.mu.Lock()
+			w("* %d EXISTS", len(s.messages))
+			w("* OK [UIDVALIDITY 7] ok")
+			w("* OK [UIDNEXT %d] ok", s.uidNext)
+			s.mu.Unlock()
+			w("%s OK [READ-WRITE] selected", tag)
+		case strings.HasPrefix(upper, "UID SEARCH "):
+			w("* SEARCH%s", s.search(cmd[len("UID SEARCH "):]))
+			w("%s OK search done", tag)
+		case strings.HasPrefix(upper, "UID FETCH "):
+			uid := uint32(mustAtoi(strings.Fields(cmd)[2]))
+			s.mu.Lock()
+			raw := s.messages[uid]
+			s.mu.Unlock()
+			fmt.Fprintf(conn, "* 1 FETCH (UID %d BODY[] {%d}\r\n%s)\r\n", uid, len(raw), raw)
+			w("%s OK fetch done", tag)
+		case strings.HasPrefix(upper, "UID STORE "):
+			uid := uint32(mustAtoi(strings.Fields(cmd)[2]))
+			s.mu.Lock()
+			s.seen[uid] = true
+			s.mu.Unlock()
+			w("%s OK store done", tag)
+		case upper == "IDLE":
+			w("+ idling")
+			done := make(chan struct{})
+			go func() {
+				r.ReadString('\n') // DONE
+				close(done)
+			}()
+			select {
+			case <-s.newMail:
+				s.mu.Lock()
+				w("* %d EXISTS", len(s.messages))
+				s.mu.Unlock()
+				<-done
+			case <-done:
+			}
+			w("%s OK idle done", tag)
+		case upper == "NOOP":
+			w("%s OK noop", tag)
+		case upper == "LOGOUT":
+			w("* BYE")
+			w("%s OK logout", tag)
+			return
+		default:
+			w("%s BAD unknown command", tag)
+		}
+	}
+}
+
+// search supports "ALL", "UNSEEN" and "UID n:*".
+func (s *fakeIMAPServer) search(criteria string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var uids []int
+	var newest uint32
+	for uid := range s.messages {
+		newest = max(newest, uid)
+	}
+	for uid := range s.messages {
+		switch {
+		case criteria == "ALL":
+		case criteria == "UNSEEN":
+			if s.seen[uid] {
+				continue
+			}
+		case strings.HasPrefix(criteria, "UID "):
+			from := uint32(mustAtoi(strings.TrimSuffix(strings.TrimPrefix(criteria, "UID "), ":*")))
+			if uid < from && uid != newest {
+				continue
+			}
+		}
+		uids = append(uids, int(uid))
+	}
+	sort.Ints(uids)
+
+	var sb strings.Builder
+	for _, uid := range uids {
+		sb.WriteString(" " + strconv.Itoa(uid))
+	}
+	return sb.String()
+}
+
+func mustAtoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+type fakeCredentialResolver struct {
+	cred *models.CredentialsResource
+}
+
+func (f *fakeCredentialResolver) GetDecrypted(ctx context.Context, resourceID string) (*models.CredentialsResource, error) {
+	return f.cred, nil
+}
+
+type recordingExecutor struct {
+	inputs chan map[string]any
+}
+
+func (r *recordingExecutor) Execute(ctx context.Context, workflowID string, input map[string]any, opts *engine.ExecutionOptions) (*models.Execution, error) {
+	r.inputs <- input
+	return &models.Execution{ID: uuid.New().String(), WorkflowID: workflowID}, nil
+}
+
+type emailListenerFixture struct {
+	listener *EmailListener
+	server   *fakeIMAPServer
+	executor *recordingExecutor
+	trigger  *models.Trigger
+}
+
+func setupEmailListener(t *testing.T, extraConfig map[string]any) *emailListenerFixture {
+	t.Helper()
+
+	server := newFakeIMAPServer(t)
+	credentialID := uuid.New()
+	workflowID := uuid.New()
+
+	mr := miniredis.RunT(t)
+	redisCache, err := cache.NewRedisCache(config.RedisConfig{URL: "redis://" + mr.Addr(), PoolSize: 5})
+	require.NoError(t, err)
+	t.Cleanup(func() { redisCache.Close() })
+
+	workflowRepo := new(mockWorkflowRepo)
+	workflowRepo.On("FindByIDWithRelations", mock.Anything, workflowID).Return(&storagemodels.WorkflowModel{
+		ID:        workflowID,
+		Resources: []*storagemodels.WorkflowResourceModel{{WorkflowID: workflowID, ResourceID: credentialID}},
+	}, nil)
+	triggerRepo := new(mockTriggerRepo)
+	triggerRepo.On("MarkTriggered", mock.Anything, mock.Anything).Return(nil)
+
+	storageConfig := filestorage.DefaultManagerConfig()
+	storageConfig.BasePath = t.TempDir()
+	storageManager := filestorage.NewStorageManager(storageConfig, logger.New(config.LoggingConfig{Level: "error", Format: "json"}))
+	t.Cleanup(func() { storageManager.Close() })
+
+	listener := NewEmailListener(EmailListenerConfig{
+		TriggerRepo:  triggerRepo,
+		WorkflowRepo: workflowRepo,
+		Cache:        redisCache,
+		Credentials: &fakeCredentialResolver{cred: &models.CredentialsResource{
+			CredentialType: models.CredentialTypeBasicAuth,
+			DecryptedData:  map[string]string{"username": server.username, "password": server.password},
+		}},
+		FileStorage: storageManager,
+	})
+	executor := &recordingExecutor{inputs: make(chan map[string]any, 10)}
+	listener.executionMgr = executor
+	t.Cleanup(func() { listener.Stop() })
+
+	triggerConfig := map[string]any{
+		"host":          "127.0.0.1",
+		"port":          float64(server.port()),
+		"tls":           "none",
+		"credential_id": credentialID.String(),
+		"input":         map[string]any{"source": "support"},
+	}
+	for k, v := range extraConfig {
+		triggerConfig[k] = v
+	}
+
+	return &emailListenerFixture{
+		listener: listener,
+		server:   server,
+		executor: executor,
+		trigger: &models.Trigger{
+			ID:         uuid.New().String(),
+			WorkflowID: workflowID.String(),
+			Type:       models.TriggerTypeEmail,
+			Config:     triggerConfig,
+			Enabled:    true,
+		},
+	}
+}
+
+func (f *emailListenerFixture) nextInput(t *testing.T) map[string]any {
+	t.Helper()
+	select {
+	case input := <-f.executor.inputs:
+		return input
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for execution")
+		return nil
+	}
+}
+
+func (f *emailListenerFixture) waitConnected(t *testing.T) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		_, err := f.listener.loadCursor(context.Background(), f.trigger.ID)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestEmailListener_NewMessageStartsExecution(t *testing.T) {
+	f := setupEmailListener(t, nil)
+	existing := f.server.deliver("From: old@example.com\r\nSubject: Old\r\n\r\nold\r\n")
+	<-f.server.newMail // delivered before the listener connected
+
+	require.NoError(t, f.listener.AddTrigger(context.Background(), f.trigger))
+	f.waitConnected(t)
+
+	uid := f.server.deliver(testMultipartEmail)
+	input := f.nextInput(t)
+
+	assert.Equal(t, "support", input["source"])
+	email := input["email"].(map[string]any)
+	assert.Equal(t, uid, email["uid"])
+	assert.Equal(t, "INBOX", email["mailbox"])
+	assert.Equal(t, "Order № 42", email["subject"])
+	assert.Equal(t, "anna@example.com", email["from"].(map[string]any)["address"])
+	assert.Len(t, email["to"], 2)
+	assert.Contains(t, email["text"], "Café")
+
+	attachments := email["attachments"].([]any)
+	require.Len(t, attachments, 1)
+	att := attachments[0].(map[string]any)
+	assert.Equal(t, "order.csv", att["file_name"])
+	assert.NotEmpty(t, att["file_id"], att["error"])
+	assert.Equal(t, "default", att["storage_id"])
+
+	require.Eventually(t, func() bool { return f.server.isSeen(uid) }, 5*time.Second, 10*time.Millisecond)
+	assert.False(t, f.server.isSeen(existing), "messages received before the trigger started are skipped")
+
+	select {
+	case extra := <-f.executor.inputs:
+		t.Fatalf("unexpected execution: %v", extra)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestEmailListener_ProcessExisting(t *testing.T) {
+	f := setupEmailListener(t, map[string]any{"process_existing": true, "mark_seen": false})
+	f.server.deliver("From: old@example.com\r\nSubject: Waiting\r\n\r\nbody\r\n")
+	<-f.server.newMail
+
+	require.NoError(t, f.listener.AddTrigger(context.Background(), f.trigger))
+
+	input := f.nextInput(t)
+	assert.Equal(t, "Waiting", input["email"].(map[string]any)["subject"])
+	assert.False(t, f.server.isSeen(10), "mark_seen=false leaves messages unread")
+}
+
+func TestEmailListener_WaitsWhileReadOnly(t *testing.T) {
+	f := setupEmailListener(t, map[string]any{"process_existing": true})
+	f.listener.readOnly = maintenance.NewReadOnlyMode(true, "")
+	uid := f.server.deliver("From: old@example.com\r\nSubject: Waiting\r\n\r\nbody\r\n")
+	<-f.server.newMail
+
+	require.NoError(t, f.listener.AddTrigger(context.Background(), f.trigger))
+
+	select {
+	case input := <-f.executor.inputs:
+		t.Fatalf("unexpected execution while read-only: %v", input)
+	case <-time.After(200 * time.Millisecond):
+	}
+	_, err := f.listener.loadCursor(context.Background(), f.trigger.ID)
+	assert.Error(t, err, "the mailbox is not read, so the message is processed later")
+	assert.False(t, f.server.isSeen(uid))
+}
+
+func TestEmailListener_CredentialMustBeAttached(t *testing.T) {
+	f := setupEmailListener(t, nil)
+
+	_, _, err := f.listener.resolveCredentials(context.Background(), f.trigger, uuid.New().String())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not attached")
+}
+
+func TestParseEmailTriggerConfig(t *testing.T) {
+	cfg, err := parseEmailTriggerConfig(map[string]any{
+		"host":          "imap.example.com",
+		"credential_id": "cred",
+		"poll_interval": "1s",
+		"mode":          "poll",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 993, cfg.dial.Port)
+	assert.Equal(t, "tls", cfg.dial.TLS)
+	assert.Equal(t, "INBOX", cfg.mailbox)
+	assert.False(t, cfg.idle)
+	assert.True(t, cfg.markSeen)
+	assert.Equal(t, emailMinPollInterval, cfg.pollInterval)
+
+	cfg, err = parseEmailTriggerConfig(map[string]any{"host": "imap.example.com", "credential_id": "cred", "tls": "starttls"})
+	require.NoError(t, err)
+	assert.Equal(t, 143, cfg.dial.Port)
+
+	_, err = parseEmailTriggerConfig(map[string]any{"host": "imap.example.com"})
+	assert.Error(t, err)
+}
+
+func TestTrigger_ValidateEmailConfig(t *testing.T) {
+	trigger := &models.Trigger{
+		WorkflowID: uuid.New().String(),
+		Name:       "inbox",
+		Type:       models.TriggerTypeEmail,
+		Config:     map[string]any{"host": "imap.example.com", "credential_id": "cred", "password": "secret"},
+	}
+	assert.Error(t, trigger.Validate(), "inline passwords are rejected")
+
+	delete(trigger.Config, "password")
+	assert.NoError(t, trigger.Validate())
+}
@@ -0,0 +1,280 @@
+package trigger
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// emailMaxParts caps the number of MIME parts walked per message.
+const emailMaxParts = 200
+
+// emailMessage is a parsed inbound email.
+type emailMessage struct {
+	MessageID   string
+	Subject     string
+	From        *mail.Address
+	To          []*mail.Address
+	Cc          []*mail.Address
+	ReplyTo     []*mail.Address
+	Date        time.Time
+	InReplyTo   string
+	References  []string
+	Headers     map[string]string
+	Text        string
+	HTML        string
+	Attachments []emailAttachment
+}
+
+// emailAttachment is a decoded attachment or inline file of a message.
+type emailAttachment struct {
+	FileName  string
+	MimeType  string
+	ContentID string
+	Inline    bool
+	Data      []byte
+}
+
+var headerDecoder = &mime.WordDecoder{CharsetReader: charsetReader}
+
+// parseEmailMessage parses a raw RFC 5322 message, decoding headers, bodies and attachments.
+func parseEmailMessage(raw []byte) (*emailMessage, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse message: %w", err)
+	}
+
+	parsed := &emailMessage{
+		MessageID:  strings.Trim(msg.Header.Get("Message-Id"), "<> "),
+		Subject:    decodeHeader(msg.Header.Get("Subject")),
+		InReplyTo:  strings.Trim(msg.Header.Get("In-Reply-To"), "<> "),
+		References: parseMessageIDs(msg.Header.Get("References")),
+		Headers:    make(map[string]string, len(msg.Header)),
+	}
+
+	for name, values := range msg.Header {
+		if len(values) > 0 {
+			parsed.Headers[name] = decodeHeader(values[0])
+		}
+	}
+
+	if from, err := parseAddressList(msg.Header, "From"); err == nil && len(from) > 0 {
+		parsed.From = from[0]
+	}
+	parsed.To, _ = parseAddressList(msg.Header, "To")
+	parsed.Cc, _ = parseAddressList(msg.Header, "Cc")
+	parsed.ReplyTo, _ = parseAddressList(msg.Header, "Reply-To")
+	if date, err := msg.Header.Date(); err == nil {
+		parsed.Date = date
+	}
+
+	walker := &partWalker{message: parsed}
+	if err := walker.walk(msg.Header, msg.Body); err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
+
+// partHeader is implemented by mail.Header and textproto.MIMEHeader.
+type partHeader interface {
+	Get(key string) string
+}
+
+type partWalker struct {
+	message *emailMessage
+	parts   int
+}
+
+func (w *partWalker) walk(header partHeader, body io.Reader) error {
+	w.parts++
+	if w.parts > emailMaxParts {
+		return fmt.Errorf("message has more than %d MIME parts", emailMaxParts)
+	}
+
+	contentType := header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "text/plain; charset=us-ascii"
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, params = "application/octet-stream", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		boundary := params["boundary"]
+		if boundary == "" {
+			return fmt.Errorf("multipart message without boundary")
+		}
+		reader := multipart.NewReader(body, boundary)
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read MIME part: %w", err)
+			}
+			if err := w.walk(part.Header, part); err != nil {
+				return err
+			}
+		}
+	}
+
+	data, err := io.ReadAll(decodeTransferEncoding(header.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return fmt.Errorf("failed to decode MIME part: %w", err)
+	}
+
+	disposition, dispParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	fileName := decodeHeader(dispParams["filename"])
+	if fileName == "" {
+		fileName = decodeHeader(params["name"])
+	}
+
+	isBody := disposition != "attachment" && fileName == "" &&
+		(mediaType == "text/plain" || mediaType == "text/html")
+	if isBody {
+		text := toUTF8(data, params["charset"])
+		if mediaType == "text/plain" && w.message.Text == "" {
+			w.message.Text = text
+			return nil
+		}
+		if mediaType == "text/html" && w.message.HTML == "" {
+			w.message.HTML = text
+			return nil
+		}
+	}
+
+	if fileName == "" {
+		fileName = fmt.Sprintf("part-%d%s", w.parts, extensionFor(mediaType))
+	}
+
+	w.message.Attachments = append(w.message.Attachments, emailAttachment{
+		FileName:  fileName,
+		MimeType:  mediaType,
+		ContentID: strings.Trim(header.Get("Content-Id"), "<> "),
+		Inline:    disposition == "inline",
+		Data:      data,
+	})
+	return nil
+}
+
+func decodeTransferEncoding(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, &newlineStripper{r: body})
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	default:
+		return body
+	}
+}
+
+// newlineStripper drops CR/LF so base64 bodies wrapped at 76 columns decode cleanly.
+type newlineStripper struct {
+	r io.Reader
+}
+
+func (n *newlineStripper) Read(p []byte) (int, error) {
+	for {
+		count, err := n.r.Read(p)
+		out := 0
+		for _, b := range p[:count] {
+			if b != '\r' && b != '\n' {
+				p[out] = b
+				out++
+			}
+		}
+		if out > 0 || err != nil {
+			return out, err
+		}
+	}
+}
+
+func decodeHeader(value string) string {
+	if value == "" {
+		return ""
+	}
+	decoded, err := headerDecoder.DecodeHeader(value)
+	if err != nil {
+		return value
+	}
+	return decoded
+}
+
+func parseAddressList(header mail.Header, key string) ([]*mail.Address, error) {
+	if header.Get(key) == "" {
+		return nil, nil
+	}
+	parser := &mail.AddressParser{WordDecoder: headerDecoder}
+	return parser.ParseList(header.Get(key))
+}
+
+func parseMessageIDs(value string) []string {
+	var ids []string
+	for _, field := range strings.Fields(value) {
+		if id := strings.Trim(field, "<>,"); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// charsetReader supports UTF-8/ASCII and ISO-8859-1 encoded words; other charsets
+// are passed through unchanged.
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	return strings.NewReader(toUTF8(data, charset)), nil
+}
+
+func toUTF8(data []byte, charset string) string {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1", "windows-1252":
+		if !utf8.Valid(data) {
+			runes := make([]rune, len(data))
+			for i, b := range data {
+				runes[i] = rune(b)
+			}
+			return string(runes)
+		}
+	}
+	return string(data)
+}
+
+func extensionFor(mediaType string) string {
+	if exts, err := mime.ExtensionsByType(mediaType); err == nil && len(exts) > 0 {
+		return exts[0]
+	}
+	return ".bin"
+}
+
+// formatAddress renders an address for workflow input.
+func formatAddress(addr *mail.Address) map[string]any {
+	if addr == nil {
+		return nil
+	}
+	return map[string]any{
+		"name":    addr.Name,
+		"address": addr.Address,
+	}
+}
+
+func formatAddresses(addrs []*mail.Address) []any {
+	result := make([]any, 0, len(addrs))
+	for _, addr := range addrs {
+		result = append(result, formatAddress(addr))
+	}
+	return result
+}
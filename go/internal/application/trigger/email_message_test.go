@@ -0,0 +1,87 @@
+package trigger
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMultipartEmail = "From: =?UTF-8?B?0JDQvdC90LA=?= <anna@example.com>\r\n" +
+	"To: support@example.com, Bob <bob@example.com>\r\n" +
+	"Cc: team@example.com\r\n" +
+	"Subject: =?UTF-8?Q?Order_=E2=84=96_42?=\r\n" +
+	"Date: Mon, 02 Jan 2006 15:04:05 +0000\r\n" +
+	"Message-ID: <abc123@example.com>\r\n" +
+	"In-Reply-To: <prev@example.com>\r\n" +
+	"X-Priority: 1\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=\"outer\"\r\n" +
+	"\r\n" +
+	"--outer\r\n" +
+	"Content-Type: multipart/alternative; boundary=\"inner\"\r\n" +
+	"\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/plain; charset=iso-8859-1\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"Caf=E9 order attached.\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/html; charset=utf-8\r\n" +
+	"\r\n" +
+	"<p>Order attached.</p>\r\n" +
+	"--inner--\r\n" +
+	"--outer\r\n" +
+	"Content-Type: text/csv; name=\"order.csv\"\r\n" +
+	"Content-Disposition: attachment; filename=\"order.csv\"\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"aWQsY291bnQK\r\n" +
+	"NDIsMwo=\r\n" +
+	"--outer--\r\n"
+
+func TestParseEmailMessage_Multipart(t *testing.T) {
+	msg, err := parseEmailMessage([]byte(testMultipartEmail))
+	require.NoError(t, err)
+
+	assert.Equal(t, "abc123@example.com", msg.MessageID)
+	assert.Equal(t, "Order № 42", msg.Subject)
+	assert.Equal(t, "prev@example.com", msg.InReplyTo)
+	require.NotNil(t, msg.From)
+	assert.Equal(t, "Анна", msg.From.Name)
+	assert.Equal(t, "anna@example.com", msg.From.Address)
+	require.Len(t, msg.To, 2)
+	assert.Equal(t, "bob@example.com", msg.To[1].Address)
+	require.Len(t, msg.Cc, 1)
+	assert.Equal(t, 2006, msg.Date.Year())
+	assert.Equal(t, "1", msg.Headers["X-Priority"])
+
+	assert.Equal(t, "Café order attached.", strings.TrimSpace(msg.Text))
+	assert.Equal(t, "<p>Order attached.</p>", strings.TrimSpace(msg.HTML))
+
+	require.Len(t, msg.Attachments, 1)
+	att := msg.Attachments[0]
+	assert.Equal(t, "order.csv", att.FileName)
+	assert.Equal(t, "text/csv", att.MimeType)
+	assert.False(t, att.Inline)
+	assert.Equal(t, "id,count\n42,3\n", string(att.Data))
+}
+
+func TestParseEmailMessage_PlainText(t *testing.T) {
+	raw := "From: alerts@example.com\r\nSubject: Ping\r\n\r\nhello\r\n"
+
+	msg, err := parseEmailMessage([]byte(raw))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Ping", msg.Subject)
+	assert.Equal(t, "hello\r\n", msg.Text)
+	assert.Empty(t, msg.HTML)
+	assert.Empty(t, msg.Attachments)
+	assert.Empty(t, msg.To)
+}
+
+func TestParseEmailMessage_Invalid(t *testing.T) {
+	_, err := parseEmailMessage([]byte("not a message"))
+	assert.Error(t, err)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"github.com/smilemakc/mbflow/go/internal/application/engine"
+	"github.com/smilemakc/mbflow/go/internal/application/maintenance"
 	"github.com/smilemakc/mbflow/go/internal/domain/repository"
 	"github.com/smilemakc/mbflow/go/internal/infrastructure/cache"
 	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
@@ -22,6 +23,7 @@ type EventListener struct {
 	workflowRepo repository.WorkflowRepository
 	executionMgr *engine.ExecutionManager
 	cache        *cache.RedisCache
+	readOnly     *maintenance.ReadOnlyMode
 
 	pubsub      *redis.PubSub
 	triggers    map[string][]*models.Trigger // eventType -> triggers
@@ -37,6 +39,8 @@ type EventListenerConfig struct {
 	WorkflowRepo repository.WorkflowRepository
 	ExecutionMgr *engine.ExecutionManager
 	Cache        *cache.RedisCache
+	// ReadOnly drops events while read-only mode is on (optional)
+	ReadOnly *maintenance.ReadOnlyMode
 }
 
 // NewEventListener creates a new event listener
@@ -46,6 +50,7 @@ func NewEventListener(cfg EventListenerConfig) (*EventListener, error) {
 		workflowRepo: cfg.WorkflowRepo,
 		executionMgr: cfg.ExecutionMgr,
 		cache:        cfg.Cache,
+		readOnly:     cfg.ReadOnly,
 		triggers:     make(map[string][]*models.Trigger),
 		stopChan:     make(chan struct{}),
 		stoppedChan:  make(chan struct{}),
@@ -256,6 +261,10 @@ func (el *EventListener) matchesFilter(event Event, trigger *models.Trigger) boo
 
 // executeTrigger executes a workflow triggered by an event
 func (el *EventListener) executeTrigger(ctx context.Context, trigger *models.Trigger, eventData map[string]any) error {
+	if err := checkReadOnly(el.readOnly); err != nil {
+		return err
+	}
+
 	// Merge trigger input with event data
 	input := make(map[string]any)
 
@@ -272,7 +281,7 @@ func (el *EventListener) executeTrigger(ctx context.Context, trigger *models.Tri
 	}
 
 	// Execute workflow
-	_, err := el.executionMgr.Execute(ctx, trigger.WorkflowID, input, nil)
+	_, err := el.executionMgr.Execute(ctx, trigger.WorkflowID, input, triggerExecutionOptions(trigger))
 	if err != nil {
 		return fmt.Errorf("failed to execute workflow: %w", err)
 	}
@@ -308,7 +317,14 @@ func (el *EventListener) getChannels() []string {
 
 // getEventChannel returns the Redis channel for an event type
 func (el *EventListener) getEventChannel(eventType string) string {
-	return fmt.Sprintf("mbflow:events:%s", eventType)
+	return eventChannel(el.cache, eventType)
+}
+
+// eventChannel returns the system-namespaced channel events of the given type
+// are published on, so listeners never see another deployment's events that
+// share the Redis instance.
+func eventChannel(c *cache.RedisCache, eventType string) string {
+	return c.System().Channel("events:" + eventType)
 }
 
 // modelToDomain converts storage model to domain model
@@ -351,8 +367,7 @@ func PublishEvent(ctx context.Context, cache *cache.RedisCache, event Event) err
 		return fmt.Errorf("failed to marshal event: %w", err)
 	}
 
-	channel := fmt.Sprintf("mbflow:events:%s", event.Type)
-	if err := cache.Client().Publish(ctx, channel, string(data)).Err(); err != nil {
+	if err := cache.System().Publish(ctx, "events:"+event.Type, string(data)); err != nil {
 		return fmt.Errorf("failed to publish event: %w", err)
 	}
 
@@ -8,13 +8,25 @@ import (
 	"testing"
 	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/google/uuid"
+	"github.com/smilemakc/mbflow/go/internal/config"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/cache"
 	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
 	"github.com/smilemakc/mbflow/go/pkg/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+func newEventTestCache(t *testing.T) *cache.RedisCache {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	redisCache, err := cache.NewRedisCache(config.RedisConfig{URL: "redis://" + mr.Addr(), PoolSize: 5})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = redisCache.Close() })
+	return redisCache
+}
+
 func TestEventListener_MatchesFilter(t *testing.T) {
 	el, err := NewEventListener(EventListenerConfig{})
 	require.NoError(t, err)
@@ -151,7 +163,7 @@ func TestEventListener_MatchesFilter(t *testing.T) {
 }
 
 func TestEventListener_GetEventChannel(t *testing.T) {
-	el, err := NewEventListener(EventListenerConfig{})
+	el, err := NewEventListener(EventListenerConfig{Cache: newEventTestCache(t)})
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -160,11 +172,11 @@ func TestEventListener_GetEventChannel(t *testing.T) {
 	}{
 		{
 			eventType: "user.created",
-			expected:  "mbflow:events:user.created",
+			expected:  "mbflow:system:events:user.created",
 		},
 		{
 			eventType: "order.completed",
-			expected:  "mbflow:events:order.completed",
+			expected:  "mbflow:system:events:order.completed",
 		},
 	}
 
@@ -176,6 +188,30 @@ func TestEventListener_GetEventChannel(t *testing.T) {
 	}
 }
 
+func TestPublishEvent_DeliversOnListenerChannel(t *testing.T) {
+	redisCache := newEventTestCache(t)
+	el, err := NewEventListener(EventListenerConfig{Cache: redisCache})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	pubsub := redisCache.Client().Subscribe(ctx, el.getEventChannel("user.created"))
+	defer pubsub.Close()
+	_, err = pubsub.Receive(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, PublishEvent(ctx, redisCache, Event{Type: "user.created", Source: "test"}))
+
+	select {
+	case msg := <-pubsub.Channel():
+		assert.Equal(t, "mbflow:system:events:user.created", msg.Channel)
+		var event Event
+		require.NoError(t, json.Unmarshal([]byte(msg.Payload), &event))
+		assert.Equal(t, "user.created", event.Type)
+	case <-time.After(2 * time.Second):
+		t.Fatal("event was not delivered on the listener channel")
+	}
+}
+
 func TestEventListener_AddRemoveTrigger(t *testing.T) {
 	t.Skip("Requires Redis connection")
 
@@ -621,7 +657,7 @@ func TestEventListener_ComplexFilterMatching(t *testing.T) {
 }
 
 func TestEventListener_GetChannels(t *testing.T) {
-	el, err := NewEventListener(EventListenerConfig{})
+	el, err := NewEventListener(EventListenerConfig{Cache: newEventTestCache(t)})
 	require.NoError(t, err)
 
 	ctx := context.Background()
@@ -648,9 +684,9 @@ func TestEventListener_GetChannels(t *testing.T) {
 	// Verify all channels are present
 	assert.Len(t, channels, 3)
 	expectedChannels := map[string]bool{
-		"mbflow:events:user.created":    false,
-		"mbflow:events:order.completed": false,
-		"mbflow:events:task.updated":    false,
+		"mbflow:system:events:user.created":    false,
+		"mbflow:system:events:order.completed": false,
+		"mbflow:system:events:task.updated":    false,
 	}
 
 	for _, channel := range channels {
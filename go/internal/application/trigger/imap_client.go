@@ -0,0 +1,436 @@
+package trigger
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// imapMaxLiteral caps the size of a single literal (message) read from the server.
+const imapMaxLiteral = 50 * 1024 * 1024
+
+// errIMAPIdleTimeout is returned by idle when no mailbox change arrived before the timeout.
+var errIMAPIdleTimeout = errors.New("imap idle timeout")
+
+// imapDialConfig holds connection settings for an IMAP server.
+type imapDialConfig struct {
+	Host               string
+	Port               int
+	TLS                string // tls, starttls, none
+	InsecureSkipVerify bool
+	Timeout            time.Duration
+}
+
+// imapResponse is a single server response line with any literals it contained.
+// Literals are replaced in Line by their size marker ({n}).
+type imapResponse struct {
+	Line     string
+	Literals [][]byte
+}
+
+// imapMailbox holds the state returned by SELECT.
+type imapMailbox struct {
+	Exists      uint32
+	UIDValidity uint32
+	UIDNext     uint32
+}
+
+// imapClient is a minimal IMAP4rev1 client (RFC 3501) covering what the email
+// trigger needs: LOGIN, SELECT, UID SEARCH/FETCH/STORE and IDLE (RFC 2177).
+type imapClient struct {
+	conn    net.Conn
+	reader  *bufio.Reader
+	timeout time.Duration
+	tag     int
+	caps    map[string]bool
+	mu      sync.Mutex
+}
+
+// dialIMAP connects to the server and reads the greeting, upgrading with STARTTLS when configured.
+func dialIMAP(ctx context.Context, cfg imapDialConfig) (*imapClient, error) {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	dialer := &net.Dialer{Timeout: timeout}
+	tlsConfig := &tls.Config{ServerName: cfg.Host, InsecureSkipVerify: cfg.InsecureSkipVerify} // #nosec G402 -- opt-in per trigger
+
+	var conn net.Conn
+	var err error
+	if cfg.TLS == "tls" {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+
+	c := newIMAPClient(conn, timeout)
+	greeting, err := c.readResponse()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to read greeting: %w", err)
+	}
+	if !strings.HasPrefix(greeting.Line, "* OK") && !strings.HasPrefix(greeting.Line, "* PREAUTH") {
+		conn.Close()
+		return nil, fmt.Errorf("unexpected greeting: %s", greeting.Line)
+	}
+
+	if cfg.TLS == "starttls" {
+		if _, err := c.command("STARTTLS"); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("STARTTLS failed: %w", err)
+		}
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("TLS handshake failed: %w", err)
+		}
+		c.conn = tlsConn
+		c.reader = bufio.NewReader(tlsConn)
+	}
+
+	return c, nil
+}
+
+func newIMAPClient(conn net.Conn, timeout time.Duration) *imapClient {
+	return &imapClient{
+		conn:    conn,
+		reader:  bufio.NewReader(conn),
+		timeout: timeout,
+	}
+}
+
+// Close closes the connection without logging out.
+func (c *imapClient) Close() error {
+	return c.conn.Close()
+}
+
+// Logout ends the session and closes the connection.
+func (c *imapClient) Logout() error {
+	_, err := c.command("LOGOUT")
+	c.conn.Close()
+	return err
+}
+
+// Login authenticates with a username and password.
+func (c *imapClient) Login(username, password string) error {
+	user, err := imapQuote(username)
+	if err != nil {
+		return fmt.Errorf("invalid username: %w", err)
+	}
+	pass, err := imapQuote(password)
+	if err != nil {
+		return fmt.Errorf("invalid password: %w", err)
+	}
+	if _, err := c.command("LOGIN " + user + " " + pass); err != nil {
+		return fmt.Errorf("login failed: %w", err)
+	}
+	return nil
+}
+
+// HasCapability reports whether the server advertises a capability (e.g. "IDLE").
+func (c *imapClient) HasCapability(name string) (bool, error) {
+	if c.caps == nil {
+		responses, err := c.command("CAPABILITY")
+		if err != nil {
+			return false, err
+		}
+		c.caps = make(map[string]bool)
+		for _, r := range responses {
+			if fields, ok := untagged(r.Line, "CAPABILITY"); ok {
+				for _, f := range fields {
+					c.caps[strings.ToUpper(f)] = true
+				}
+			}
+		}
+	}
+	return c.caps[strings.ToUpper(name)], nil
+}
+
+// Select opens a mailbox.
+func (c *imapClient) Select(mailbox string) (*imapMailbox, error) {
+	name, err := imapQuote(mailbox)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mailbox: %w", err)
+	}
+
+	responses, err := c.command("SELECT " + name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select %s: %w", mailbox, err)
+	}
+
+	mb := &imapMailbox{}
+	for _, r := range responses {
+		line := r.Line
+		switch {
+		case strings.HasSuffix(line, " EXISTS"):
+			fields := strings.Fields(line)
+			if len(fields) == 3 {
+				mb.Exists = parseUint32(fields[1])
+			}
+		case strings.Contains(line, "[UIDVALIDITY "):
+			mb.UIDValidity = parseUint32(responseCode(line, "UIDVALIDITY"))
+		case strings.Contains(line, "[UIDNEXT "):
+			mb.UIDNext = parseUint32(responseCode(line, "UIDNEXT"))
+		}
+	}
+	return mb, nil
+}
+
+// UIDSearch returns the UIDs matching the search criteria.
+func (c *imapClient) UIDSearch(criteria string) ([]uint32, error) {
+	responses, err := c.command("UID SEARCH " + criteria)
+	if err != nil {
+		return nil, fmt.Errorf("search failed: %w", err)
+	}
+
+	var uids []uint32
+	for _, r := range responses {
+		if fields, ok := untagged(r.Line, "SEARCH"); ok {
+			for _, f := range fields {
+				if uid := parseUint32(f); uid > 0 {
+					uids = append(uids, uid)
+				}
+			}
+		}
+	}
+	return uids, nil
+}
+
+// UIDFetchMessage returns the raw RFC 5322 message without setting \Seen.
+func (c *imapClient) UIDFetchMessage(uid uint32) ([]byte, error) {
+	responses, err := c.command(fmt.Sprintf("UID FETCH %d (UID BODY.PEEK[])", uid))
+	if err != nil {
+		return nil, fmt.Errorf("fetch failed: %w", err)
+	}
+
+	for _, r := range responses {
+		if strings.HasPrefix(r.Line, "* ") && strings.Contains(r.Line, " FETCH ") && len(r.Literals) > 0 {
+			return r.Literals[len(r.Literals)-1], nil
+		}
+	}
+	return nil, fmt.Errorf("message %d not found", uid)
+}
+
+// UIDMarkSeen sets the \Seen flag on a message.
+func (c *imapClient) UIDMarkSeen(uid uint32) error {
+	if _, err := c.command(fmt.Sprintf(`UID STORE %d +FLAGS.SILENT (\Seen)`, uid)); err != nil {
+		return fmt.Errorf("failed to mark message %d as seen: %w", uid, err)
+	}
+	return nil
+}
+
+// Noop asks the server for pending mailbox updates.
+func (c *imapClient) Noop() error {
+	_, err := c.command("NOOP")
+	return err
+}
+
+// Idle waits until the server reports new messages, the timeout elapses (errIMAPIdleTimeout)
+// or ctx is cancelled. The IDLE command is always terminated before returning.
+func (c *imapClient) Idle(ctx context.Context, timeout time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tag := c.nextTag()
+	if err := c.writeLine(tag + " IDLE"); err != nil {
+		return err
+	}
+
+	cont, err := c.readResponse()
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(cont.Line, "+") {
+		return fmt.Errorf("server rejected IDLE: %s", cont.Line)
+	}
+
+	// Wake the blocked read when ctx is cancelled.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = c.conn.SetReadDeadline(time.Now())
+		case <-stop:
+		}
+	}()
+
+	_ = c.conn.SetReadDeadline(time.Now().Add(timeout))
+	result := errIMAPIdleTimeout
+	for {
+		resp, err := c.readLineNoDeadline()
+		if err != nil {
+			var netErr net.Error
+			if !errors.As(err, &netErr) || !netErr.Timeout() {
+				return err
+			}
+			if ctx.Err() != nil {
+				result = ctx.Err()
+			}
+			break
+		}
+		if strings.HasPrefix(resp.Line, "* ") && (strings.HasSuffix(resp.Line, " EXISTS") || strings.HasSuffix(resp.Line, " RECENT")) {
+			result = nil
+			break
+		}
+	}
+
+	_ = c.conn.SetReadDeadline(time.Time{})
+	if err := c.writeLine("DONE"); err != nil {
+		return err
+	}
+	if _, err := c.readUntilTagged(tag); err != nil {
+		return err
+	}
+	return result
+}
+
+// command sends a tagged command and returns the untagged responses, or an error for NO/BAD.
+func (c *imapClient) command(cmd string) ([]imapResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tag := c.nextTag()
+	if err := c.writeLine(tag + " " + cmd); err != nil {
+		return nil, err
+	}
+	return c.readUntilTagged(tag)
+}
+
+func (c *imapClient) readUntilTagged(tag string) ([]imapResponse, error) {
+	var responses []imapResponse
+	for {
+		resp, err := c.readResponse()
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(resp.Line, tag+" ") {
+			responses = append(responses, *resp)
+			continue
+		}
+
+		status := strings.TrimPrefix(resp.Line, tag+" ")
+		if strings.HasPrefix(status, "OK") {
+			return responses, nil
+		}
+		return nil, fmt.Errorf("imap: %s", status)
+	}
+}
+
+func (c *imapClient) nextTag() string {
+	c.tag++
+	return fmt.Sprintf("A%04d", c.tag)
+}
+
+func (c *imapClient) writeLine(line string) error {
+	_ = c.conn.SetWriteDeadline(time.Now().Add(c.timeout))
+	_, err := io.WriteString(c.conn, line+"\r\n")
+	return err
+}
+
+// readResponse reads one response with the client timeout as read deadline.
+func (c *imapClient) readResponse() (*imapResponse, error) {
+	_ = c.conn.SetReadDeadline(time.Now().Add(c.timeout))
+	return c.readLineNoDeadline()
+}
+
+// readLineNoDeadline reads one logical response line, consuming literals ({n}\r\n<n bytes>).
+func (c *imapClient) readLineNoDeadline() (*imapResponse, error) {
+	resp := &imapResponse{}
+	var line strings.Builder
+
+	for {
+		part, err := c.reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		part = strings.TrimRight(part, "\r\n")
+		line.WriteString(part)
+
+		size, ok := literalSize(part)
+		if !ok {
+			break
+		}
+		if size > imapMaxLiteral {
+			return nil, fmt.Errorf("literal of %d bytes exceeds limit", size)
+		}
+		literal := make([]byte, size)
+		if _, err := io.ReadFull(c.reader, literal); err != nil {
+			return nil, err
+		}
+		resp.Literals = append(resp.Literals, literal)
+	}
+
+	resp.Line = line.String()
+	return resp, nil
+}
+
+// literalSize parses a trailing literal marker "{n}".
+func literalSize(line string) (int, bool) {
+	if !strings.HasSuffix(line, "}") {
+		return 0, false
+	}
+	start := strings.LastIndexByte(line, '{')
+	if start < 0 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(line[start+1:len(line)-1], "+"))
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+// untagged returns the fields of an untagged response with the given name ("* NAME a b c").
+func untagged(line, name string) ([]string, bool) {
+	prefix := "* " + name
+	if line != prefix && !strings.HasPrefix(line, prefix+" ") {
+		return nil, false
+	}
+	return strings.Fields(strings.TrimPrefix(line, prefix)), true
+}
+
+// responseCode returns the value of a bracketed response code, e.g. "[UIDNEXT 42]".
+func responseCode(line, code string) string {
+	start := strings.Index(line, "["+code+" ")
+	if start < 0 {
+		return ""
+	}
+	rest := line[start+len(code)+2:]
+	end := strings.IndexByte(rest, ']')
+	if end < 0 {
+		return ""
+	}
+	return rest[:end]
+}
+
+// imapQuote returns s as a quoted string. CR and LF cannot be quoted.
+func imapQuote(s string) (string, error) {
+	if strings.ContainsAny(s, "\r\n") {
+		return "", fmt.Errorf("value must not contain line breaks")
+	}
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`, nil
+}
+
+func parseUint32(s string) uint32 {
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0
+	}
+	return uint32(n)
+}
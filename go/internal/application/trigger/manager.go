@@ -3,10 +3,13 @@ package trigger
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 
 	"github.com/smilemakc/mbflow/go/internal/application/engine"
+	"github.com/smilemakc/mbflow/go/internal/application/filestorage"
+	"github.com/smilemakc/mbflow/go/internal/application/maintenance"
 	"github.com/smilemakc/mbflow/go/internal/domain/repository"
 	"github.com/smilemakc/mbflow/go/internal/infrastructure/cache"
 	"github.com/smilemakc/mbflow/go/pkg/models"
@@ -19,11 +22,16 @@ type Manager struct {
 	workflowRepo repository.WorkflowRepository
 	executionMgr *engine.ExecutionManager
 	cache        *cache.RedisCache
+	credentials  CredentialResolver
+	fileStorage  filestorage.Manager
+	readOnly     *maintenance.ReadOnlyMode
 
 	// Trigger handlers
 	cronScheduler   *CronScheduler
 	eventListener   *EventListener
 	webhookRegistry *WebhookRegistry
+	emailListener   *EmailListener
+	slackRegistry   *SlackRegistry
 
 	// Lifecycle
 	ctx    context.Context
@@ -38,6 +46,14 @@ type ManagerConfig struct {
 	WorkflowRepo repository.WorkflowRepository
 	ExecutionMgr *engine.ExecutionManager
 	Cache        *cache.RedisCache
+	// Credentials resolves IMAP credentials for email triggers and
+	// signing secrets for Slack triggers (optional)
+	Credentials CredentialResolver
+	// FileStorage stores email attachments (optional)
+	FileStorage filestorage.Manager
+	// ReadOnly pauses cron, event and email triggers while read-only mode is on (optional).
+	// Webhook and Slack triggers arrive over HTTP and are rejected by the API instead.
+	ReadOnly *maintenance.ReadOnlyMode
 }
 
 // NewManager creates a new trigger manager
@@ -62,6 +78,9 @@ func NewManager(cfg ManagerConfig) (*Manager, error) {
 		workflowRepo: cfg.WorkflowRepo,
 		executionMgr: cfg.ExecutionMgr,
 		cache:        cfg.Cache,
+		credentials:  cfg.Credentials,
+		fileStorage:  cfg.FileStorage,
+		readOnly:     cfg.ReadOnly,
 		ctx:          ctx,
 		cancel:       cancel,
 	}
@@ -83,6 +102,7 @@ func (m *Manager) initializeHandlers() error {
 		WorkflowRepo: m.workflowRepo,
 		ExecutionMgr: m.executionMgr,
 		Cache:        m.cache,
+		ReadOnly:     m.readOnly,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create cron scheduler: %w", err)
@@ -95,6 +115,7 @@ func (m *Manager) initializeHandlers() error {
 		WorkflowRepo: m.workflowRepo,
 		ExecutionMgr: m.executionMgr,
 		Cache:        m.cache,
+		ReadOnly:     m.readOnly,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create event listener: %w", err)
@@ -110,6 +131,26 @@ func (m *Manager) initializeHandlers() error {
 	})
 	m.webhookRegistry = webhookRegistry
 
+	// Initialize email (IMAP) listener
+	m.emailListener = NewEmailListener(EmailListenerConfig{
+		TriggerRepo:  m.triggerRepo,
+		WorkflowRepo: m.workflowRepo,
+		ExecutionMgr: m.executionMgr,
+		Cache:        m.cache,
+		Credentials:  m.credentials,
+		FileStorage:  m.fileStorage,
+		ReadOnly:     m.readOnly,
+	})
+
+	// Initialize Slack registry
+	m.slackRegistry = NewSlackRegistry(SlackRegistryConfig{
+		TriggerRepo:  m.triggerRepo,
+		WorkflowRepo: m.workflowRepo,
+		ExecutionMgr: m.executionMgr,
+		Cache:        m.cache,
+		Credentials:  m.credentials,
+	})
+
 	return nil
 }
 
@@ -139,6 +180,16 @@ func (m *Manager) Start() error {
 		return fmt.Errorf("failed to register webhooks: %w", err)
 	}
 
+	// Register Slack triggers
+	if err := m.slackRegistry.RegisterAll(m.ctx, triggers); err != nil {
+		return fmt.Errorf("failed to register slack triggers: %w", err)
+	}
+
+	// Start email listener
+	if err := m.emailListener.Start(m.ctx, triggers); err != nil {
+		return fmt.Errorf("failed to start email listener: %w", err)
+	}
+
 	return nil
 }
 
@@ -164,6 +215,13 @@ func (m *Manager) Stop() error {
 		}
 	}
 
+	// Stop email listener
+	if m.emailListener != nil {
+		if err := m.emailListener.Stop(); err != nil {
+			return fmt.Errorf("failed to stop email listener: %w", err)
+		}
+	}
+
 	// Wait for all goroutines to complete
 	m.wg.Wait()
 
@@ -202,6 +260,10 @@ func (m *Manager) OnTriggerCreated(ctx context.Context, trigger *models.Trigger)
 		return m.webhookRegistry.RegisterWebhook(ctx, trigger)
 	case models.TriggerTypeInterval:
 		return m.cronScheduler.AddTrigger(ctx, trigger)
+	case models.TriggerTypeEmail:
+		return m.emailListener.AddTrigger(ctx, trigger)
+	case models.TriggerTypeSlack:
+		return m.slackRegistry.AddTrigger(ctx, trigger)
 	}
 
 	return nil
@@ -240,6 +302,16 @@ func (m *Manager) OnTriggerDeleted(ctx context.Context, triggerID string) error
 		fmt.Printf("failed to unregister webhook: %v\n", err)
 	}
 
+	// Remove from Slack registry
+	if err := m.slackRegistry.RemoveTrigger(ctx, triggerID); err != nil {
+		fmt.Printf("failed to remove slack trigger: %v\n", err)
+	}
+
+	// Stop email listener watcher
+	if err := m.emailListener.RemoveTrigger(ctx, triggerID); err != nil {
+		fmt.Printf("failed to remove email trigger: %v\n", err)
+	}
+
 	// Clear trigger state
 	if err := m.clearTriggerState(ctx, triggerID); err != nil {
 		fmt.Printf("failed to clear trigger state: %v\n", err)
@@ -268,14 +340,52 @@ func (m *Manager) updateTriggerState(ctx context.Context, triggerID string) erro
 	return nil
 }
 
-// clearTriggerState clears trigger state from Redis
+// clearTriggerState clears trigger state and the email cursor from Redis
 func (m *Manager) clearTriggerState(ctx context.Context, triggerID string) error {
+	if err := DeleteEmailCursor(ctx, m.cache, triggerID); err != nil {
+		return err
+	}
 	return DeleteTriggerState(ctx, m.cache, triggerID)
 }
 
+// triggerExecutionOptions returns the execution options for a trigger run, or nil for defaults.
+// A "profile" in the trigger config runs the workflow with that launch profile, a "priority"
+// ranks its executions.
+// errReadOnly is returned when a trigger would fire while the instance is in read-only mode.
+var errReadOnly = errors.New("instance is in read-only mode")
+
+// checkReadOnly returns errReadOnly while read-only mode is on.
+func checkReadOnly(mode *maintenance.ReadOnlyMode) error {
+	if enabled, _ := mode.Enabled(); enabled {
+		return errReadOnly
+	}
+	return nil
+}
+
+func triggerExecutionOptions(trigger *models.Trigger) *engine.ExecutionOptions {
+	profile, _ := trigger.Config["profile"].(string)
+	priority, _ := trigger.Config["priority"].(string)
+	if profile == "" && priority == "" {
+		return nil
+	}
+
+	opts := engine.DefaultExecutionOptions()
+	opts.Profile = profile
+	// Trigger configs are validated when saved
+	opts.Priority, _ = models.ParseExecutionPriority(priority)
+	return opts
+}
+
 // WebhookRegistry returns the webhook registry for HTTP webhook handling
 func (m *Manager) WebhookRegistry() *WebhookRegistry {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	return m.webhookRegistry
 }
+
+// SlackRegistry returns the Slack registry for Slack Events API handling
+func (m *Manager) SlackRegistry() *SlackRegistry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.slackRegistry
+}
@@ -5,8 +5,10 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/smilemakc/mbflow/go/internal/application/engine"
 	"github.com/smilemakc/mbflow/go/internal/infrastructure/cache"
 	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -195,3 +197,19 @@ func TestWorkflowRepository_AllMethods(t *testing.T) {
 
 	repo.AssertExpectations(t)
 }
+
+// TestTriggerExecutionOptions tests launch profile and priority selection from trigger config
+func TestTriggerExecutionOptions(t *testing.T) {
+	assert.Nil(t, triggerExecutionOptions(&models.Trigger{Config: map[string]any{}}))
+
+	opts := triggerExecutionOptions(&models.Trigger{Config: map[string]any{"profile": "nightly"}})
+	assert.NotNil(t, opts)
+	assert.Equal(t, "nightly", opts.Profile)
+	assert.Equal(t, engine.DefaultExecutionOptions().MaxParallelism, opts.MaxParallelism)
+	assert.Equal(t, models.ExecutionPriorityNormal, opts.Priority)
+
+	opts = triggerExecutionOptions(&models.Trigger{Config: map[string]any{"priority": "low"}})
+	assert.NotNil(t, opts)
+	assert.Empty(t, opts.Profile)
+	assert.Equal(t, models.ExecutionPriorityLow, opts.Priority)
+}
@@ -0,0 +1,486 @@
+package trigger
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/smilemakc/mbflow/go/internal/application/engine"
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/cache"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+const (
+	// slackMaxClockSkew rejects requests with older timestamps to prevent replays.
+	slackMaxClockSkew = 5 * time.Minute
+
+	// slackSecretTTL is how long a resolved signing secret is reused before it is decrypted again.
+	slackSecretTTL = 5 * time.Minute
+
+	// slackEventDedupTTL covers Slack's retry window for unacknowledged events.
+	slackEventDedupTTL = time.Hour
+)
+
+var (
+	// ErrSlackTriggerNotFound is returned for unknown or non-Slack trigger IDs.
+	ErrSlackTriggerNotFound = errors.New("slack trigger not found")
+
+	// ErrSlackTriggerDisabled is returned for disabled triggers.
+	ErrSlackTriggerDisabled = errors.New("slack trigger is disabled")
+
+	// ErrSlackInvalidSignature is returned when the request signature cannot be verified.
+	ErrSlackInvalidSignature = errors.New("invalid slack signature")
+)
+
+// asyncWorkflowExecutor starts workflow executions without waiting for them to finish.
+type asyncWorkflowExecutor interface {
+	ExecuteAsync(ctx context.Context, workflowID string, input map[string]any, opts *engine.ExecutionOptions) (*models.Execution, error)
+}
+
+// SlackRegistry dispatches Slack Events API callbacks and slash commands to Slack triggers.
+// Slack expects an answer within 3 seconds, so executions are started asynchronously.
+type SlackRegistry struct {
+	triggerRepo  repository.TriggerRepository
+	workflowRepo repository.WorkflowRepository
+	executionMgr asyncWorkflowExecutor
+	cache        *cache.RedisCache
+	credentials  CredentialResolver
+
+	triggers map[string]*models.Trigger // triggerID -> trigger
+	secrets  map[string]slackSecret     // triggerID -> resolved signing secret
+	now      func() time.Time
+	mu       sync.RWMutex
+}
+
+// SlackRegistryConfig holds configuration for Slack registry
+type SlackRegistryConfig struct {
+	TriggerRepo  repository.TriggerRepository
+	WorkflowRepo repository.WorkflowRepository
+	ExecutionMgr *engine.ExecutionManager
+	Cache        *cache.RedisCache
+	Credentials  CredentialResolver
+}
+
+type slackSecret struct {
+	value     string
+	expiresAt time.Time
+}
+
+// SlackRequest is an inbound request from Slack.
+type SlackRequest struct {
+	Body        []byte
+	ContentType string
+	Timestamp   string // X-Slack-Request-Timestamp
+	Signature   string // X-Slack-Signature
+}
+
+// SlackResult describes how a Slack request was handled.
+type SlackResult struct {
+	// Challenge is set for url_verification requests and must be echoed back.
+	Challenge string
+	// ExecutionID is set when a workflow execution was started.
+	ExecutionID string
+	// Ignored explains why a verified request did not start an execution.
+	Ignored string
+	// Response is the slash command reply body, if any.
+	Response map[string]any
+}
+
+// NewSlackRegistry creates a new Slack registry
+func NewSlackRegistry(cfg SlackRegistryConfig) *SlackRegistry {
+	sr := &SlackRegistry{
+		triggerRepo:  cfg.TriggerRepo,
+		workflowRepo: cfg.WorkflowRepo,
+		cache:        cfg.Cache,
+		credentials:  cfg.Credentials,
+		triggers:     make(map[string]*models.Trigger),
+		secrets:      make(map[string]slackSecret),
+		now:          time.Now,
+	}
+	if cfg.ExecutionMgr != nil {
+		sr.executionMgr = cfg.ExecutionMgr
+	}
+	return sr
+}
+
+// RegisterAll registers all Slack triggers
+func (sr *SlackRegistry) RegisterAll(ctx context.Context, triggers []*storagemodels.TriggerModel) error {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	for _, trigger := range triggers {
+		if trigger.Type == string(models.TriggerTypeSlack) {
+			domainTrigger := sr.modelToDomain(trigger)
+			sr.triggers[domainTrigger.ID] = domainTrigger
+		}
+	}
+
+	return nil
+}
+
+// AddTrigger registers a new Slack trigger
+func (sr *SlackRegistry) AddTrigger(ctx context.Context, trigger *models.Trigger) error {
+	if trigger.Type != models.TriggerTypeSlack {
+		return nil // Not a Slack trigger
+	}
+
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	sr.triggers[trigger.ID] = trigger
+	delete(sr.secrets, trigger.ID)
+	return nil
+}
+
+// RemoveTrigger unregisters a Slack trigger
+func (sr *SlackRegistry) RemoveTrigger(ctx context.Context, triggerID string) error {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	delete(sr.triggers, triggerID)
+	delete(sr.secrets, triggerID)
+	return nil
+}
+
+// GetTrigger retrieves a Slack trigger by ID
+func (sr *SlackRegistry) GetTrigger(triggerID string) (*models.Trigger, bool) {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	trigger, exists := sr.triggers[triggerID]
+	return trigger, exists
+}
+
+// HandleRequest verifies a Slack request and starts the trigger's workflow for
+// matching events and slash commands.
+func (sr *SlackRegistry) HandleRequest(ctx context.Context, triggerID string, req SlackRequest) (*SlackResult, error) {
+	trigger, exists := sr.GetTrigger(triggerID)
+	if !exists {
+		return nil, ErrSlackTriggerNotFound
+	}
+	if !trigger.Enabled {
+		return nil, ErrSlackTriggerDisabled
+	}
+
+	secret, err := sr.signingSecret(ctx, trigger)
+	if err != nil {
+		return nil, err
+	}
+	if err := sr.verifySignature(secret, req); err != nil {
+		return nil, err
+	}
+
+	if strings.HasPrefix(req.ContentType, "application/x-www-form-urlencoded") {
+		form, err := url.ParseQuery(string(req.Body))
+		if err != nil {
+			return nil, fmt.Errorf("invalid form body: %w", err)
+		}
+		return sr.handleCommand(ctx, trigger, form)
+	}
+
+	var envelope struct {
+		Type      string         `json:"type"`
+		Challenge string         `json:"challenge"`
+		EventID   string         `json:"event_id"`
+		EventTime int64          `json:"event_time"`
+		TeamID    string         `json:"team_id"`
+		APIAppID  string         `json:"api_app_id"`
+		Event     map[string]any `json:"event"`
+	}
+	if err := json.Unmarshal(req.Body, &envelope); err != nil {
+		return nil, fmt.Errorf("invalid JSON body: %w", err)
+	}
+
+	switch envelope.Type {
+	case "url_verification":
+		return &SlackResult{Challenge: envelope.Challenge}, nil
+	case "event_callback":
+	default:
+		return &SlackResult{Ignored: fmt.Sprintf("unsupported request type %q", envelope.Type)}, nil
+	}
+
+	event := envelope.Event
+	eventType, _ := event["type"].(string)
+	channel := slackEventChannel(event)
+
+	if types := stringList(trigger.Config["event_types"]); len(types) > 0 && !containsString(types, eventType) {
+		return &SlackResult{Ignored: fmt.Sprintf("event type %q is not subscribed", eventType)}, nil
+	}
+	if channels := stringList(trigger.Config["channels"]); len(channels) > 0 && !containsString(channels, channel) {
+		return &SlackResult{Ignored: fmt.Sprintf("channel %q is not subscribed", channel)}, nil
+	}
+	if isSlackBotEvent(event) && !configBool(trigger.Config, "include_bots", false) {
+		return &SlackResult{Ignored: "bot event"}, nil
+	}
+
+	// Slack retries events that were not acknowledged in time; run each event once.
+	if envelope.EventID != "" {
+		first, err := sr.markEventSeen(ctx, trigger.ID, envelope.EventID)
+		if err != nil {
+			fmt.Printf("slack trigger %s: failed to deduplicate event: %v\n", trigger.ID, err)
+		} else if !first {
+			return &SlackResult{Ignored: "duplicate event"}, nil
+		}
+	}
+
+	payload := map[string]any{
+		"type":       "event",
+		"event_id":   envelope.EventID,
+		"event_time": envelope.EventTime,
+		"team_id":    envelope.TeamID,
+		"api_app_id": envelope.APIAppID,
+		"event_type": eventType,
+		"channel":    channel,
+		"event":      event,
+	}
+	for _, key := range []string{"user", "text", "ts", "thread_ts", "subtype", "channel_type"} {
+		if v, ok := event[key]; ok {
+			payload[key] = v
+		}
+	}
+
+	executionID, err := sr.execute(ctx, trigger, payload)
+	if err != nil {
+		return nil, err
+	}
+	return &SlackResult{ExecutionID: executionID}, nil
+}
+
+// handleCommand handles a slash command request.
+func (sr *SlackRegistry) handleCommand(ctx context.Context, trigger *models.Trigger, form url.Values) (*SlackResult, error) {
+	command := form.Get("command")
+	if command == "" {
+		// Interactive payloads (buttons, modals) are form-encoded too but not supported yet.
+		return &SlackResult{Ignored: "unsupported form payload"}, nil
+	}
+
+	if commands := stringList(trigger.Config["commands"]); len(commands) > 0 && !containsString(commands, command) {
+		return &SlackResult{Ignored: fmt.Sprintf("command %q is not subscribed", command)}, nil
+	}
+	if channels := stringList(trigger.Config["channels"]); len(channels) > 0 && !containsString(channels, form.Get("channel_id")) {
+		return &SlackResult{Ignored: fmt.Sprintf("channel %q is not subscribed", form.Get("channel_id"))}, nil
+	}
+
+	payload := map[string]any{"type": "command"}
+	for _, key := range []string{
+		"command", "text", "user_id", "user_name", "channel_id", "channel_name",
+		"team_id", "team_domain", "response_url", "trigger_id", "api_app_id",
+	} {
+		payload[key] = form.Get(key)
+	}
+
+	executionID, err := sr.execute(ctx, trigger, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SlackResult{ExecutionID: executionID}
+	if text, _ := trigger.Config["command_response"].(string); text != "" {
+		result.Response = map[string]any{"response_type": "ephemeral", "text": text}
+	}
+	return result, nil
+}
+
+// execute starts the workflow with the trigger's default input and the Slack payload
+func (sr *SlackRegistry) execute(ctx context.Context, trigger *models.Trigger, payload map[string]any) (string, error) {
+	input := make(map[string]any)
+	if defaultInput, ok := trigger.Config["input"].(map[string]any); ok {
+		for k, v := range defaultInput {
+			input[k] = v
+		}
+	}
+	input["slack"] = payload
+
+	execution, err := sr.executionMgr.ExecuteAsync(ctx, trigger.WorkflowID, input, triggerExecutionOptions(trigger))
+	if err != nil {
+		return "", fmt.Errorf("failed to execute workflow: %w", err)
+	}
+
+	// Update trigger state
+	state, err := LoadTriggerState(ctx, sr.cache, trigger.ID)
+	if err != nil {
+		state = NewTriggerState(trigger.ID)
+	}
+	state.MarkExecuted()
+
+	if err := state.Save(ctx, sr.cache); err != nil {
+		fmt.Printf("failed to save trigger state: %v\n", err)
+	}
+
+	// Update last triggered timestamp in database
+	triggerUUID, _ := uuid.Parse(trigger.ID)
+	if err := sr.triggerRepo.MarkTriggered(ctx, triggerUUID); err != nil {
+		fmt.Printf("failed to mark trigger as triggered: %v\n", err)
+	}
+
+	return execution.ID, nil
+}
+
+// signingSecret returns the app signing secret from the trigger's credential
+func (sr *SlackRegistry) signingSecret(ctx context.Context, trigger *models.Trigger) (string, error) {
+	sr.mu.RLock()
+	cached, ok := sr.secrets[trigger.ID]
+	sr.mu.RUnlock()
+	if ok && sr.now().Before(cached.expiresAt) {
+		return cached.value, nil
+	}
+
+	credentialID, _ := trigger.Config["credential_id"].(string)
+	cred, err := resolveWorkflowCredential(ctx, sr.workflowRepo, sr.credentials, trigger.WorkflowID, credentialID)
+	if err != nil {
+		return "", err
+	}
+
+	var secret string
+	switch cred.CredentialType {
+	case models.CredentialTypeAPIKey:
+		secret = cred.GetAPIKey()
+	case models.CredentialTypeCustom:
+		secret = cred.GetCustomValue("signing_secret")
+	default:
+		return "", fmt.Errorf("credential %s has unsupported type %s (expected api_key or custom)",
+			credentialID, cred.CredentialType)
+	}
+	if secret == "" {
+		return "", fmt.Errorf("credential %s does not contain a signing secret", credentialID)
+	}
+
+	sr.mu.Lock()
+	sr.secrets[trigger.ID] = slackSecret{value: secret, expiresAt: sr.now().Add(slackSecretTTL)}
+	sr.mu.Unlock()
+
+	return secret, nil
+}
+
+// verifySignature checks the v0 request signature
+// See: https://api.slack.com/authentication/verifying-requests-from-slack
+func (sr *SlackRegistry) verifySignature(secret string, req SlackRequest) error {
+	if req.Timestamp == "" || req.Signature == "" {
+		return fmt.Errorf("%w: missing signature headers", ErrSlackInvalidSignature)
+	}
+
+	ts, err := strconv.ParseInt(req.Timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid timestamp", ErrSlackInvalidSignature)
+	}
+	if skew := sr.now().Sub(time.Unix(ts, 0)); skew > slackMaxClockSkew || skew < -slackMaxClockSkew {
+		return fmt.Errorf("%w: timestamp outside the allowed window", ErrSlackInvalidSignature)
+	}
+
+	if !hmac.Equal([]byte(req.Signature), []byte(computeSlackSignature(secret, req.Timestamp, req.Body))) {
+		return ErrSlackInvalidSignature
+	}
+	return nil
+}
+
+// markEventSeen records an event ID and reports whether it was seen for the first time
+func (sr *SlackRegistry) markEventSeen(ctx context.Context, triggerID, eventID string) (bool, error) {
+	key := fmt.Sprintf("trigger:%s:slack_event:%s", triggerID, eventID)
+	count, err := sr.cache.System().Increment(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	if count == 1 {
+		if err := sr.cache.System().Expire(ctx, key, slackEventDedupTTL); err != nil {
+			fmt.Printf("failed to set slack event expiration: %v\n", err)
+		}
+	}
+	return count == 1, nil
+}
+
+// modelToDomain converts storage model to domain model
+func (sr *SlackRegistry) modelToDomain(tm *storagemodels.TriggerModel) *models.Trigger {
+	trigger := &models.Trigger{
+		ID:         tm.ID.String(),
+		WorkflowID: tm.WorkflowID.String(),
+		Type:       models.TriggerType(tm.Type),
+		Config:     make(map[string]any),
+		Enabled:    tm.Enabled,
+		CreatedAt:  tm.CreatedAt,
+		UpdatedAt:  tm.UpdatedAt,
+	}
+
+	if tm.Config != nil {
+		trigger.Config = map[string]any(tm.Config)
+	}
+
+	if tm.LastTriggeredAt != nil {
+		trigger.LastRun = tm.LastTriggeredAt
+	}
+
+	return trigger
+}
+
+// computeSlackSignature computes the v0 signature of a request body
+func computeSlackSignature(secret, timestamp string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte("v0:" + timestamp + ":"))
+	h.Write(body)
+	return "v0=" + hex.EncodeToString(h.Sum(nil))
+}
+
+// slackEventChannel returns the channel of an event (reactions carry it in item.channel)
+func slackEventChannel(event map[string]any) string {
+	if channel, ok := event["channel"].(string); ok {
+		return channel
+	}
+	if item, ok := event["item"].(map[string]any); ok {
+		if channel, ok := item["channel"].(string); ok {
+			return channel
+		}
+	}
+	return ""
+}
+
+// isSlackBotEvent reports whether an event was produced by a bot, including our own replies
+func isSlackBotEvent(event map[string]any) bool {
+	if botID, _ := event["bot_id"].(string); botID != "" {
+		return true
+	}
+	subtype, _ := event["subtype"].(string)
+	return subtype == "bot_message"
+}
+
+func stringList(raw any) []string {
+	switch v := raw.(type) {
+	case []string:
+		return v
+	case []any:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+func configBool(config map[string]any, key string, def bool) bool {
+	if v, ok := config[key].(bool); ok {
+		return v
+	}
+	return def
+}
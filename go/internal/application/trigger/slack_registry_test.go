@@ -0,0 +1,267 @@
+package trigger
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/smilemakc/mbflow/go/internal/application/engine"
+	"github.com/smilemakc/mbflow/go/internal/config"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/cache"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testSlackSecret = "8f742231b10e8888abcd99yyyzzz85a5"
+
+type asyncRecordingExecutor struct {
+	inputs []map[string]any
+}
+
+func (r *asyncRecordingExecutor) ExecuteAsync(ctx context.Context, workflowID string, input map[string]any, opts *engine.ExecutionOptions) (*models.Execution, error) {
+	r.inputs = append(r.inputs, input)
+	return &models.Execution{ID: uuid.New().String(), WorkflowID: workflowID}, nil
+}
+
+type slackRegistryFixture struct {
+	registry *SlackRegistry
+	executor *asyncRecordingExecutor
+	trigger  *models.Trigger
+	now      time.Time
+}
+
+func setupSlackRegistry(t *testing.T, extraConfig map[string]any) *slackRegistryFixture {
+	t.Helper()
+
+	credentialID := uuid.New()
+	workflowID := uuid.New()
+
+	mr := miniredis.RunT(t)
+	redisCache, err := cache.NewRedisCache(config.RedisConfig{URL: "redis://" + mr.Addr(), PoolSize: 5})
+	require.NoError(t, err)
+	t.Cleanup(func() { redisCache.Close() })
+
+	workflowRepo := new(mockWorkflowRepo)
+	workflowRepo.On("FindByIDWithRelations", mock.Anything, workflowID).Return(&storagemodels.WorkflowModel{
+		ID:        workflowID,
+		Resources: []*storagemodels.WorkflowResourceModel{{WorkflowID: workflowID, ResourceID: credentialID}},
+	}, nil)
+	triggerRepo := new(mockTriggerRepo)
+	triggerRepo.On("MarkTriggered", mock.Anything, mock.Anything).Return(nil)
+
+	registry := NewSlackRegistry(SlackRegistryConfig{
+		TriggerRepo:  triggerRepo,
+		WorkflowRepo: workflowRepo,
+		Cache:        redisCache,
+		Credentials: &fakeCredentialResolver{cred: &models.CredentialsResource{
+			CredentialType: models.CredentialTypeCustom,
+			DecryptedData:  map[string]string{"signing_secret": testSlackSecret},
+		}},
+	})
+	executor := &asyncRecordingExecutor{}
+	registry.executionMgr = executor
+	now := time.Unix(1700000000, 0)
+	registry.now = func() time.Time { return now }
+
+	triggerConfig := map[string]any{
+		"credential_id": credentialID.String(),
+		"input":         map[string]any{"source": "slack"},
+	}
+	for k, v := range extraConfig {
+		triggerConfig[k] = v
+	}
+
+	trigger := &models.Trigger{
+		ID:         uuid.New().String(),
+		WorkflowID: workflowID.String(),
+		Type:       models.TriggerTypeSlack,
+		Config:     triggerConfig,
+		Enabled:    true,
+	}
+	require.NoError(t, registry.AddTrigger(context.Background(), trigger))
+
+	return &slackRegistryFixture{registry: registry, executor: executor, trigger: trigger, now: now}
+}
+
+func (f *slackRegistryFixture) signed(contentType, body string) SlackRequest {
+	ts := strconv.FormatInt(f.now.Unix(), 10)
+	return SlackRequest{
+		Body:        []byte(body),
+		ContentType: contentType,
+		Timestamp:   ts,
+		Signature:   computeSlackSignature(testSlackSecret, ts, []byte(body)),
+	}
+}
+
+func (f *slackRegistryFixture) handle(t *testing.T, req SlackRequest) (*SlackResult, error) {
+	t.Helper()
+	return f.registry.HandleRequest(context.Background(), f.trigger.ID, req)
+}
+
+func TestSlackRegistry_URLVerification(t *testing.T) {
+	f := setupSlackRegistry(t, nil)
+
+	result, err := f.handle(t, f.signed("application/json", `{"type":"url_verification","challenge":"3eZbrw1a"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "3eZbrw1a", result.Challenge)
+	assert.Empty(t, f.executor.inputs)
+}
+
+func TestSlackRegistry_Signature(t *testing.T) {
+	f := setupSlackRegistry(t, nil)
+	body := `{"type":"url_verification","challenge":"x"}`
+
+	tampered := f.signed("application/json", body)
+	tampered.Body = []byte(`{"type":"url_verification","challenge":"y"}`)
+	_, err := f.handle(t, tampered)
+	assert.ErrorIs(t, err, ErrSlackInvalidSignature)
+
+	missing := f.signed("application/json", body)
+	missing.Signature = ""
+	_, err = f.handle(t, missing)
+	assert.ErrorIs(t, err, ErrSlackInvalidSignature)
+
+	stale := f.signed("application/json", body)
+	staleTS := strconv.FormatInt(f.now.Add(-10*time.Minute).Unix(), 10)
+	stale.Timestamp = staleTS
+	stale.Signature = computeSlackSignature(testSlackSecret, staleTS, []byte(body))
+	_, err = f.handle(t, stale)
+	assert.ErrorIs(t, err, ErrSlackInvalidSignature)
+
+	_, err = f.registry.HandleRequest(context.Background(), uuid.New().String(), f.signed("application/json", body))
+	assert.ErrorIs(t, err, ErrSlackTriggerNotFound)
+}
+
+func TestSlackRegistry_EventCallback(t *testing.T) {
+	f := setupSlackRegistry(t, map[string]any{
+		"event_types": []any{"app_mention"},
+		"channels":    []any{"C123"},
+	})
+
+	event := `{"type":"event_callback","event_id":"Ev1","event_time":1700000000,"team_id":"T1",` +
+		`"event":{"type":"app_mention","user":"U1","text":"<@B1> deploy","ts":"1700000000.000100","channel":"C123"}}`
+
+	result, err := f.handle(t, f.signed("application/json", event))
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.ExecutionID)
+
+	require.Len(t, f.executor.inputs, 1)
+	input := f.executor.inputs[0]
+	assert.Equal(t, "slack", input["source"])
+	slack := input["slack"].(map[string]any)
+	assert.Equal(t, "event", slack["type"])
+	assert.Equal(t, "app_mention", slack["event_type"])
+	assert.Equal(t, "C123", slack["channel"])
+	assert.Equal(t, "U1", slack["user"])
+	assert.Equal(t, "<@B1> deploy", slack["text"])
+
+	// Slack retries carry the same event ID
+	result, err = f.handle(t, f.signed("application/json", event))
+	require.NoError(t, err)
+	assert.Equal(t, "duplicate event", result.Ignored)
+	assert.Len(t, f.executor.inputs, 1)
+}
+
+func TestSlackRegistry_EventFilters(t *testing.T) {
+	f := setupSlackRegistry(t, map[string]any{
+		"event_types": []any{"message"},
+		"channels":    []any{"C123"},
+	})
+
+	tests := []struct {
+		name  string
+		event string
+	}{
+		{"other event type", `{"type":"reaction_added","item":{"channel":"C123"}}`},
+		{"other channel", `{"type":"message","channel":"C999","text":"hi"}`},
+		{"bot message", `{"type":"message","channel":"C123","bot_id":"B1","text":"echo"}`},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := `{"type":"event_callback","event_id":"Ev` + strconv.Itoa(i) + `","event":` + tt.event + `}`
+			result, err := f.handle(t, f.signed("application/json", body))
+			require.NoError(t, err)
+			assert.NotEmpty(t, result.Ignored)
+			assert.Empty(t, result.ExecutionID)
+		})
+	}
+	assert.Empty(t, f.executor.inputs)
+}
+
+func TestSlackRegistry_SlashCommand(t *testing.T) {
+	f := setupSlackRegistry(t, map[string]any{
+		"commands":         []any{"/deploy"},
+		"command_response": "Deploy started",
+	})
+
+	form := url.Values{
+		"command":      {"/deploy"},
+		"text":         {"api production"},
+		"user_id":      {"U1"},
+		"channel_id":   {"C123"},
+		"response_url": {"https://hooks.slack.com/commands/T1/1/abc"},
+	}
+	result, err := f.handle(t, f.signed("application/x-www-form-urlencoded", form.Encode()))
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.ExecutionID)
+	assert.Equal(t, map[string]any{"response_type": "ephemeral", "text": "Deploy started"}, result.Response)
+
+	require.Len(t, f.executor.inputs, 1)
+	slack := f.executor.inputs[0]["slack"].(map[string]any)
+	assert.Equal(t, "command", slack["type"])
+	assert.Equal(t, "/deploy", slack["command"])
+	assert.Equal(t, "api production", slack["text"])
+	assert.Equal(t, "https://hooks.slack.com/commands/T1/1/abc", slack["response_url"])
+
+	form.Set("command", "/rollback")
+	result, err = f.handle(t, f.signed("application/x-www-form-urlencoded", form.Encode()))
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.Ignored)
+	assert.Len(t, f.executor.inputs, 1)
+}
+
+func TestSlackRegistry_DisabledAndRemoved(t *testing.T) {
+	f := setupSlackRegistry(t, nil)
+	body := `{"type":"url_verification","challenge":"x"}`
+
+	f.trigger.Enabled = false
+	_, err := f.handle(t, f.signed("application/json", body))
+	assert.ErrorIs(t, err, ErrSlackTriggerDisabled)
+
+	require.NoError(t, f.registry.RemoveTrigger(context.Background(), f.trigger.ID))
+	_, err = f.handle(t, f.signed("application/json", body))
+	assert.ErrorIs(t, err, ErrSlackTriggerNotFound)
+}
+
+func TestTrigger_ValidateSlackConfig(t *testing.T) {
+	base := func() *models.Trigger {
+		return &models.Trigger{
+			WorkflowID: uuid.New().String(),
+			Name:       "slack",
+			Type:       models.TriggerTypeSlack,
+			Config:     map[string]any{"credential_id": uuid.New().String()},
+		}
+	}
+
+	assert.NoError(t, base().Validate())
+
+	missing := base()
+	delete(missing.Config, "credential_id")
+	assert.Error(t, missing.Validate())
+
+	inline := base()
+	inline.Config["signing_secret"] = "abc"
+	assert.Error(t, inline.Validate())
+
+	badFilter := base()
+	badFilter.Config["event_types"] = "message"
+	assert.Error(t, badFilter.Validate())
+}
@@ -3,9 +3,11 @@ package trigger
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/redis/go-redis/v9"
 	"github.com/smilemakc/mbflow/go/internal/infrastructure/cache"
 )
 
@@ -49,8 +51,9 @@ func (ts *TriggerState) Save(ctx context.Context, cache *cache.RedisCache) error
 		return fmt.Errorf("failed to marshal trigger state: %w", err)
 	}
 
-	// Store with no expiration - state persists until trigger is deleted
-	if err := cache.Set(ctx, key, string(data), 0); err != nil {
+	// Store in the system namespace with no expiration - state persists until
+	// trigger is deleted and is never subject to workspace quotas or eviction
+	if err := cache.System().Set(ctx, key, string(data), 0); err != nil {
 		return fmt.Errorf("failed to save trigger state: %w", err)
 	}
 
@@ -61,7 +64,10 @@ func (ts *TriggerState) Save(ctx context.Context, cache *cache.RedisCache) error
 func LoadTriggerState(ctx context.Context, cache *cache.RedisCache, triggerID string) (*TriggerState, error) {
 	key := getTriggerStateKey(triggerID)
 
-	data, err := cache.Get(ctx, key)
+	data, err := cache.System().Get(ctx, key)
+	if errors.Is(err, redis.Nil) {
+		data, err = migrateLegacyTriggerState(ctx, cache, key)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to load trigger state: %w", err)
 	}
@@ -77,10 +83,29 @@ func LoadTriggerState(ctx context.Context, cache *cache.RedisCache, triggerID st
 // DeleteTriggerState deletes trigger state from Redis
 func DeleteTriggerState(ctx context.Context, cache *cache.RedisCache, triggerID string) error {
 	key := getTriggerStateKey(triggerID)
-	return cache.Delete(ctx, key)
+	if err := cache.Delete(ctx, key); err != nil {
+		return err
+	}
+	return cache.System().Delete(ctx, key)
+}
+
+// migrateLegacyTriggerState moves state saved before keys were namespaced, under the raw key,
+// to the system namespace and returns it. It returns redis.Nil if there is none.
+func migrateLegacyTriggerState(ctx context.Context, cache *cache.RedisCache, key string) (string, error) {
+	data, err := cache.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	if err := cache.System().Set(ctx, key, data, 0); err != nil {
+		return "", err
+	}
+	if err := cache.Delete(ctx, key); err != nil {
+		return "", err
+	}
+	return data, nil
 }
 
-// getTriggerStateKey returns the Redis key for trigger state
+// getTriggerStateKey returns the system-namespace Redis key for trigger state
 func getTriggerStateKey(triggerID string) string {
 	return fmt.Sprintf("trigger:%s:state", triggerID)
 }
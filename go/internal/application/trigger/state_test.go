@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/smilemakc/mbflow/go/internal/config"
 	"github.com/smilemakc/mbflow/go/internal/infrastructure/cache"
 	"github.com/stretchr/testify/assert"
@@ -109,6 +110,27 @@ func TestDeleteTriggerState(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestLoadTriggerState_MigratesLegacyKey(t *testing.T) {
+	mr := miniredis.RunT(t)
+	redisCache, err := cache.NewRedisCache(config.RedisConfig{URL: "redis://" + mr.Addr(), PoolSize: 5})
+	require.NoError(t, err)
+	t.Cleanup(func() { redisCache.Close() })
+	ctx := context.Background()
+
+	// State saved before keys were namespaced
+	require.NoError(t, mr.Set("trigger:legacy:state", `{"trigger_id":"legacy","execution_count":7}`))
+
+	state, err := LoadTriggerState(ctx, redisCache, "legacy")
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), state.ExecutionCount)
+	assert.False(t, mr.Exists("trigger:legacy:state"))
+	assert.True(t, mr.Exists("mbflow:system:trigger:legacy:state"))
+
+	require.NoError(t, DeleteTriggerState(ctx, redisCache, "legacy"))
+	_, err = LoadTriggerState(ctx, redisCache, "legacy")
+	assert.Error(t, err)
+}
+
 func TestGetTriggerStateKey(t *testing.T) {
 	triggerID := "test-123"
 	expected := "trigger:test-123:state"
@@ -96,6 +96,16 @@ func (wr *WebhookRegistry) GetWebhook(triggerID string) (*models.Trigger, bool)
 
 // ExecuteWebhook executes a workflow triggered by a webhook
 func (wr *WebhookRegistry) ExecuteWebhook(ctx context.Context, triggerID string, payload map[string]any, headers map[string]string, sourceIP string) (string, error) {
+	return wr.ExecuteWebhookRequest(ctx, triggerID, payload, &WebhookRequest{
+		Method:   "POST",
+		Headers:  headers,
+		SourceIP: sourceIP,
+	})
+}
+
+// ExecuteWebhookRequest executes a workflow triggered by a webhook, exposing the request
+// metadata to the trigger's input_template and to the workflow as input.request.
+func (wr *WebhookRegistry) ExecuteWebhookRequest(ctx context.Context, triggerID string, payload map[string]any, req *WebhookRequest) (string, error) {
 	// Get trigger
 	trigger, exists := wr.GetWebhook(triggerID)
 	if !exists {
@@ -106,6 +116,15 @@ func (wr *WebhookRegistry) ExecuteWebhook(ctx context.Context, triggerID string,
 		return "", fmt.Errorf("webhook trigger is disabled")
 	}
 
+	// Match the request path against the trigger's path_pattern
+	pattern, _ := trigger.Config["path_pattern"].(string)
+	pathParams, ok := matchWebhookPath(pattern, req.Path)
+	if !ok {
+		return "", fmt.Errorf("webhook path not found: %s", req.Path)
+	}
+	req.PathParams = pathParams
+	headers, sourceIP := req.Headers, req.SourceIP
+
 	// Validate signature if secret is configured
 	if err := wr.validateSignature(trigger, payload, headers); err != nil {
 		return "", fmt.Errorf("signature validation failed: %w", err)
@@ -121,31 +140,23 @@ func (wr *WebhookRegistry) ExecuteWebhook(ctx context.Context, triggerID string,
 		return "", fmt.Errorf("rate limit exceeded: %w", err)
 	}
 
-	// Merge trigger input with payload
-	input := make(map[string]any)
-
-	// First add trigger's default input
-	if defaultInput, ok := trigger.Config["input"].(map[string]any); ok {
-		for k, v := range defaultInput {
-			input[k] = v
-		}
-	}
-
-	// Then add payload (overrides trigger input)
-	for k, v := range payload {
-		input[k] = v
+	// Merge trigger input with the payload (or its input_template) and the request metadata
+	request := req.ToMap()
+	input, err := buildWebhookInput(trigger.Config, payload, request)
+	if err != nil {
+		return "", err
 	}
 
-	// Add webhook metadata
+	// Add webhook metadata, with the headers of the request metadata that omit credentials
 	input["_webhook"] = map[string]any{
 		"trigger_id": triggerID,
-		"headers":    headers,
+		"headers":    request["headers"],
 		"source_ip":  sourceIP,
 		"timestamp":  time.Now().Unix(),
 	}
 
 	// Execute workflow
-	execution, err := wr.executionMgr.Execute(ctx, trigger.WorkflowID, input, nil)
+	execution, err := wr.executionMgr.Execute(ctx, trigger.WorkflowID, input, triggerExecutionOptions(trigger))
 	if err != nil {
 		return "", fmt.Errorf("failed to execute workflow: %w", err)
 	}
@@ -244,11 +255,12 @@ func (wr *WebhookRegistry) checkIPWhitelist(trigger *models.Trigger, sourceIP st
 // checkRateLimit checks if trigger has exceeded rate limit
 func (wr *WebhookRegistry) checkRateLimit(ctx context.Context, triggerID string) error {
 	// Get rate limit configuration
-	// For now, use a simple fixed rate limit: 100 requests per minute
+	// For now, use a simple fixed rate limit: 100 requests per minute. Counters written under
+	// the raw key before namespacing expire within the minute, so they need no migration
 	key := fmt.Sprintf("trigger:%s:ratelimit", triggerID)
 
 	// Increment counter
-	count, err := wr.cache.Increment(ctx, key)
+	count, err := wr.cache.System().Increment(ctx, key)
 	if err != nil {
 		// If error, allow request (fail open)
 		return nil
@@ -256,7 +268,7 @@ func (wr *WebhookRegistry) checkRateLimit(ctx context.Context, triggerID string)
 
 	// Set expiration on first increment
 	if count == 1 {
-		if err := wr.cache.Expire(ctx, key, time.Minute); err != nil {
+		if err := wr.cache.System().Expire(ctx, key, time.Minute); err != nil {
 			fmt.Printf("failed to set rate limit expiration: %v\n", err)
 		}
 	}
@@ -0,0 +1,162 @@
+package trigger
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/internal/application/template"
+)
+
+// WebhookRequest describes the HTTP request that fired a webhook trigger.
+// It is exposed to the workflow as input.request and to input templates as {{input.request}},
+// without the headers carrying the caller's credentials (see isSensitiveWebhookHeader).
+type WebhookRequest struct {
+	Method     string
+	Path       string // Path after /webhooks/{trigger_id}, "/" when empty
+	PathParams map[string]string
+	Query      map[string][]string
+	Headers    map[string]string
+	SourceIP   string
+	Host       string
+	ReceivedAt time.Time
+}
+
+// ToMap converts the request into the structure available to workflows.
+// Query parameters with a single value are strings, repeated parameters arrays of strings.
+func (r *WebhookRequest) ToMap() map[string]any {
+	pathParams := make(map[string]any, len(r.PathParams))
+	for k, v := range r.PathParams {
+		pathParams[k] = v
+	}
+	query := make(map[string]any, len(r.Query))
+	for k, values := range r.Query {
+		if len(values) == 1 {
+			query[k] = values[0]
+			continue
+		}
+		items := make([]any, len(values))
+		for i, v := range values {
+			items[i] = v
+		}
+		query[k] = items
+	}
+	headers := make(map[string]any, len(r.Headers))
+	for k, v := range r.Headers {
+		if !isSensitiveWebhookHeader(k) {
+			headers[k] = v
+		}
+	}
+
+	path := r.Path
+	if path == "" {
+		path = "/"
+	}
+	receivedAt := r.ReceivedAt
+	if receivedAt.IsZero() {
+		receivedAt = time.Now()
+	}
+
+	return map[string]any{
+		"method":      r.Method,
+		"path":        path,
+		"path_params": pathParams,
+		"query":       query,
+		"headers":     headers,
+		"source_ip":   r.SourceIP,
+		"host":        r.Host,
+		"received_at": receivedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// sensitiveWebhookHeaders carry the caller's credentials.
+var sensitiveWebhookHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+}
+
+// isSensitiveWebhookHeader reports whether a request header carries credentials or a
+// signature, such as X-Webhook-Signature or X-Hub-Signature-256. Those are checked by the
+// trigger and kept out of the workflow input, where they would be stored with the execution.
+func isSensitiveWebhookHeader(name string) bool {
+	name = http.CanonicalHeaderKey(name)
+	return sensitiveWebhookHeaders[name] || strings.Contains(name, "Signature")
+}
+
+// matchWebhookPath matches a request path against a trigger's path pattern and returns
+// the path parameters. Pattern segments starting with ":" capture one segment, and a last
+// segment starting with "*" captures the rest of the path. An empty pattern matches any path.
+func matchWebhookPath(pattern, path string) (map[string]string, bool) {
+	params := make(map[string]string)
+	if pattern == "" {
+		return params, true
+	}
+
+	patternParts := splitWebhookPath(pattern)
+	pathParts := splitWebhookPath(path)
+	for i, part := range patternParts {
+		if strings.HasPrefix(part, "*") && i == len(patternParts)-1 {
+			if len(pathParts) < i {
+				return nil, false
+			}
+			params[part[1:]] = strings.Join(pathParts[i:], "/")
+			return params, true
+		}
+		if i >= len(pathParts) {
+			return nil, false
+		}
+		if strings.HasPrefix(part, ":") {
+			params[part[1:]] = pathParts[i]
+		} else if part != pathParts[i] {
+			return nil, false
+		}
+	}
+	if len(pathParts) != len(patternParts) {
+		return nil, false
+	}
+	return params, true
+}
+
+// splitWebhookPath splits a path into its non-empty segments.
+func splitWebhookPath(path string) []string {
+	var parts []string
+	for _, part := range strings.Split(path, "/") {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// buildWebhookInput assembles the workflow input of a webhook execution: the trigger's
+// default input, then the payload, or the trigger's input_template resolved against
+// {{input.body}} and {{input.request}}, and finally the request metadata under "request".
+// The request metadata always wins over payload fields so callers cannot spoof it.
+func buildWebhookInput(config map[string]any, payload map[string]any, request map[string]any) (map[string]any, error) {
+	input := make(map[string]any)
+
+	if defaultInput, ok := config["input"].(map[string]any); ok {
+		for k, v := range defaultInput {
+			input[k] = v
+		}
+	}
+
+	data := payload
+	if inputTemplate, ok := config["input_template"].(map[string]any); ok && len(inputTemplate) > 0 {
+		vars := template.NewVariableContext()
+		vars.InputVars = map[string]any{"body": payload, "request": request}
+		resolved, err := template.NewEngine(vars, template.TemplateOptions{PreserveTypes: true}).ResolveConfig(inputTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply input_template: %w", err)
+		}
+		data = resolved
+	}
+	for k, v := range data {
+		input[k] = v
+	}
+
+	input["request"] = request
+	return input, nil
+}
@@ -0,0 +1,125 @@
+package trigger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchWebhookPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		path    string
+		want    map[string]string
+		ok      bool
+	}{
+		{"no pattern", "", "/anything/goes", map[string]string{}, true},
+		{"static", "/orders", "/orders/", map[string]string{}, true},
+		{"parameters", "/orders/:order_id/:action", "/orders/42/refund", map[string]string{"order_id": "42", "action": "refund"}, true},
+		{"wildcard", "/files/*path", "/files/a/b/c.txt", map[string]string{"path": "a/b/c.txt"}, true},
+		{"empty wildcard", "/files/*path", "/files", map[string]string{"path": ""}, true},
+		{"static mismatch", "/orders/:id", "/customers/42", nil, false},
+		{"too short", "/orders/:id", "/orders", nil, false},
+		{"too long", "/orders/:id", "/orders/42/extra", nil, false},
+		{"root", "/orders/:id", "", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, ok := matchWebhookPath(tt.pattern, tt.path)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, params)
+		})
+	}
+}
+
+func TestWebhookRequest_ToMap(t *testing.T) {
+	req := &WebhookRequest{
+		Method:     "POST",
+		Path:       "/orders/42",
+		PathParams: map[string]string{"order_id": "42"},
+		Query:      map[string][]string{"dry_run": {"true"}, "tag": {"a", "b"}},
+		Headers:    map[string]string{"X-Tenant-Id": "acme"},
+		SourceIP:   "203.0.113.7",
+		Host:       "flows.example.com",
+		ReceivedAt: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	assert.Equal(t, map[string]any{
+		"method":      "POST",
+		"path":        "/orders/42",
+		"path_params": map[string]any{"order_id": "42"},
+		"query":       map[string]any{"dry_run": "true", "tag": []any{"a", "b"}},
+		"headers":     map[string]any{"X-Tenant-Id": "acme"},
+		"source_ip":   "203.0.113.7",
+		"host":        "flows.example.com",
+		"received_at": "2025-03-01T12:00:00Z",
+	}, req.ToMap())
+
+	assert.Equal(t, "/", (&WebhookRequest{}).ToMap()["path"])
+}
+
+func TestWebhookRequest_ToMapOmitsCredentials(t *testing.T) {
+	req := &WebhookRequest{Headers: map[string]string{
+		"Authorization":       "Bearer secret",
+		"Proxy-Authorization": "Basic c2VjcmV0",
+		"Cookie":              "session=secret",
+		"X-Webhook-Signature": "abc123",
+		"X-Hub-Signature-256": "sha256=abc123",
+		"Stripe-Signature":    "t=1,v1=abc123",
+		"X-Tenant-Id":         "acme",
+	}}
+
+	assert.Equal(t, map[string]any{"X-Tenant-Id": "acme"}, req.ToMap()["headers"])
+}
+
+func TestBuildWebhookInput(t *testing.T) {
+	request := map[string]any{
+		"method":      "POST",
+		"source_ip":   "203.0.113.7",
+		"path_params": map[string]any{"order_id": "42"},
+		"headers":     map[string]any{"X-Tenant-Id": "acme"},
+	}
+
+	t.Run("payload", func(t *testing.T) {
+		input, err := buildWebhookInput(
+			map[string]any{"input": map[string]any{"channel": "web", "amount": 0}},
+			map[string]any{"amount": 10, "request": "spoofed"},
+			request,
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, "web", input["channel"])
+		assert.Equal(t, 10, input["amount"])
+		assert.Equal(t, request, input["request"], "request metadata cannot be overridden by the payload")
+	})
+
+	t.Run("input template", func(t *testing.T) {
+		input, err := buildWebhookInput(
+			map[string]any{
+				"input": map[string]any{"channel": "web"},
+				"input_template": map[string]any{
+					"order_id": "{{input.request.path_params.order_id}}",
+					"tenant":   "{{input.request.headers.X-Tenant-Id}}",
+					"items":    "{{input.body.order.items}}",
+					"summary":  "{{input.body.order.count}} items from {{input.request.source_ip}}",
+				},
+			},
+			map[string]any{"order": map[string]any{"items": []any{"a", "b"}, "count": 2}},
+			request,
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]any{
+			"channel":  "web",
+			"order_id": "42",
+			"tenant":   "acme",
+			"items":    []any{"a", "b"},
+			"summary":  "2 items from 203.0.113.7",
+			"request":  request,
+		}, input)
+	})
+}
@@ -432,6 +432,249 @@ func TestConfig_Validate_ValidLogFormats(t *testing.T) {
 	}
 }
 
+func TestConfig_Validate_FileStorageBackend(t *testing.T) {
+	tests := []struct {
+		name        string
+		fileStorage FileStorageConfig
+		wantErr     string
+	}{
+		{name: "empty backend", fileStorage: FileStorageConfig{}},
+		{name: "local backend", fileStorage: FileStorageConfig{Backend: "local"}},
+		{
+			name: "s3 backend",
+			fileStorage: FileStorageConfig{Backend: "s3", S3: S3StorageConfig{
+				Bucket: "files", AccessKeyID: "key", SecretAccessKey: "secret",
+			}},
+		},
+		{name: "s3 without bucket", fileStorage: FileStorageConfig{Backend: "s3"}, wantErr: "MBFLOW_S3_BUCKET"},
+		{
+			name:        "s3 without credentials",
+			fileStorage: FileStorageConfig{Backend: "s3", S3: S3StorageConfig{Bucket: "files"}},
+			wantErr:     "MBFLOW_S3_ACCESS_KEY_ID",
+		},
+		{name: "gcs backend", fileStorage: FileStorageConfig{Backend: "gcs", GCS: GCSStorageConfig{Bucket: "files"}}},
+		{name: "gcs without bucket", fileStorage: FileStorageConfig{Backend: "gcs"}, wantErr: "MBFLOW_GCS_BUCKET"},
+		{
+			name: "azure backend with account key",
+			fileStorage: FileStorageConfig{Backend: "azure", Azure: AzureStorageConfig{
+				AccountName: "acct", Container: "files", AccountKey: "a2V5",
+			}},
+		},
+		{
+			name: "azure backend with sas token",
+			fileStorage: FileStorageConfig{Backend: "azure", Azure: AzureStorageConfig{
+				AccountName: "acct", Container: "files", SASToken: "sv=2021-08-06&sig=abc",
+			}},
+		},
+		{name: "azure without container", fileStorage: FileStorageConfig{Backend: "azure"}, wantErr: "MBFLOW_AZURE_CONTAINER"},
+		{
+			name:        "azure without credentials",
+			fileStorage: FileStorageConfig{Backend: "azure", Azure: AzureStorageConfig{AccountName: "acct", Container: "files"}},
+			wantErr:     "MBFLOW_AZURE_ACCOUNT_KEY",
+		},
+		{name: "unknown backend", fileStorage: FileStorageConfig{Backend: "ftp"}, wantErr: "invalid MBFLOW_FILE_STORAGE_BACKEND"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Server: ServerConfig{
+					Port: 8080,
+				},
+				Database: DatabaseConfig{
+					URL:            "postgres://localhost:5432/test",
+					MaxConnections: 10,
+					MinConnections: 5,
+				},
+				Logging: LoggingConfig{
+					Level:  "info",
+					Format: "json",
+				},
+				Auth:        validAuthConfig(),
+				FileStorage: tt.fileStorage,
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestConfig_Validate_ScriptPythonRuntime(t *testing.T) {
+	tests := []struct {
+		name         string
+		scriptPython ScriptPythonConfig
+		wantErr      string
+	}{
+		{name: "disabled with unknown runtime", scriptPython: ScriptPythonConfig{Runtime: "vm"}},
+		{name: "docker runtime", scriptPython: ScriptPythonConfig{Enabled: true, Runtime: "docker"}},
+		{name: "process runtime", scriptPython: ScriptPythonConfig{Enabled: true, Runtime: "process"}},
+		{name: "unknown runtime", scriptPython: ScriptPythonConfig{Enabled: true, Runtime: "vm"}, wantErr: "invalid MBFLOW_SCRIPT_PYTHON_RUNTIME"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Server: ServerConfig{
+					Port: 8080,
+				},
+				Database: DatabaseConfig{
+					URL:            "postgres://localhost:5432/test",
+					MaxConnections: 10,
+					MinConnections: 5,
+				},
+				Logging: LoggingConfig{
+					Level:  "info",
+					Format: "json",
+				},
+				Auth:         validAuthConfig(),
+				ScriptPython: tt.scriptPython,
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestConfig_Validate_Parallelism(t *testing.T) {
+	tests := []struct {
+		name        string
+		parallelism AdaptiveParallelismConfig
+		wantErr     string
+	}{
+		{name: "disabled with invalid bounds", parallelism: AdaptiveParallelismConfig{Min: 5, Max: 1}},
+		{name: "valid bounds", parallelism: AdaptiveParallelismConfig{Enabled: true, Min: 1, Max: 10,
+			Providers: map[string]ParallelismBounds{"openai": {Min: 2, Max: 20}}}},
+		{name: "min above max", parallelism: AdaptiveParallelismConfig{Enabled: true, Min: 5, Max: 1}, wantErr: "invalid adaptive parallelism bounds"},
+		{name: "zero min", parallelism: AdaptiveParallelismConfig{Enabled: true, Min: 0, Max: 10}, wantErr: "invalid adaptive parallelism bounds"},
+		{name: "invalid provider bounds", parallelism: AdaptiveParallelismConfig{Enabled: true, Min: 1, Max: 10,
+			Providers: map[string]ParallelismBounds{"openai": {Min: 0, Max: 5}}}, wantErr: "bounds for openai"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Server: ServerConfig{
+					Port: 8080,
+				},
+				Database: DatabaseConfig{
+					URL:            "postgres://localhost:5432/test",
+					MaxConnections: 10,
+					MinConnections: 5,
+				},
+				Logging: LoggingConfig{
+					Level:  "info",
+					Format: "json",
+				},
+				Auth:        validAuthConfig(),
+				Parallelism: tt.parallelism,
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestConfig_Validate_Worker(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		worker  WorkerConfig
+		wantErr string
+	}{
+		{name: "in process", mode: "server"},
+		{name: "server with queue", mode: "server", worker: WorkerConfig{Queue: "redis", LeaseTTL: time.Minute}},
+		{name: "worker", mode: "worker", worker: WorkerConfig{Queue: "redis", LeaseTTL: time.Minute}},
+		{name: "worker without queue", mode: "worker", wantErr: "requires MBFLOW_EXECUTION_QUEUE"},
+		{name: "unknown mode", mode: "scheduler", wantErr: "invalid MBFLOW_MODE"},
+		{name: "unknown queue", mode: "server", worker: WorkerConfig{Queue: "nats", LeaseTTL: time.Minute}, wantErr: "invalid MBFLOW_EXECUTION_QUEUE"},
+		{name: "lease shorter than checkpoints", mode: "worker", worker: WorkerConfig{Queue: "redis", LeaseTTL: 10 * time.Second}, wantErr: "invalid MBFLOW_WORKER_LEASE_TTL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Server: ServerConfig{
+					Port: 8080,
+					Mode: tt.mode,
+				},
+				Database: DatabaseConfig{
+					URL:            "postgres://localhost:5432/test",
+					MaxConnections: 10,
+					MinConnections: 5,
+				},
+				Logging: LoggingConfig{
+					Level:  "info",
+					Format: "json",
+				},
+				Auth:   validAuthConfig(),
+				Worker: tt.worker,
+				CrashRecovery: CrashRecoveryConfig{
+					Enabled:            true,
+					CheckpointInterval: 15 * time.Second,
+					StaleAfter:         2 * time.Minute,
+				},
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestConfig_Validate_LLMCacheBackend(t *testing.T) {
+	for _, backend := range []string{"", "memory", "redis", "memcached"} {
+		t.Run(backend, func(t *testing.T) {
+			cfg := &Config{
+				Server: ServerConfig{
+					Port: 8080,
+				},
+				Database: DatabaseConfig{
+					URL:            "postgres://localhost:5432/test",
+					MaxConnections: 10,
+					MinConnections: 5,
+				},
+				Logging: LoggingConfig{
+					Level:  "info",
+					Format: "json",
+				},
+				Auth:     validAuthConfig(),
+				LLMCache: LLMCacheConfig{Backend: backend},
+			}
+
+			err := cfg.Validate()
+			if backend == "memcached" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid MBFLOW_LLM_CACHE_BACKEND")
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
 // ==================== Helper Functions Tests ====================
 
 func TestGetEnv_WithValue(t *testing.T) {
@@ -673,6 +916,16 @@ func TestParseHTTPHeaders_InvalidFormat(t *testing.T) {
 	}
 }
 
+func TestParseParallelismBounds(t *testing.T) {
+	result := parseParallelismBounds("openai=2:20, API.example.com = 1:5,broken,gemini=x:3,anthropic=4")
+	assert.Equal(t, map[string]ParallelismBounds{
+		"openai":          {Min: 2, Max: 20},
+		"api.example.com": {Min: 1, Max: 5},
+	}, result)
+
+	assert.Empty(t, parseParallelismBounds(""))
+}
+
 // ==================== Helper Functions ====================
 
 // validAuthConfig returns an AuthConfig that passes validation.
@@ -20,6 +20,9 @@ type AccountRepository interface {
 	// Update updates an existing account
 	Update(ctx context.Context, account *models.Account) error
 
+	// SetQuota sets the daily usage quota of an account; nil restores the default quota
+	SetQuota(ctx context.Context, id string, quota *models.UsageQuota) error
+
 	// UpdateBalance atomically updates account balance
 	UpdateBalance(ctx context.Context, id string, newBalance float64) error
 
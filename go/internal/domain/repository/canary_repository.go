@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// CanaryRepository defines the interface for canary definitions and run history
+type CanaryRepository interface {
+	// Save creates or replaces the canary definition of a workflow, keeping its run state
+	Save(ctx context.Context, canary *models.Canary) error
+
+	// GetByWorkflowID returns the canary of a workflow or models.ErrCanaryNotFound
+	GetByWorkflowID(ctx context.Context, workflowID string) (*models.Canary, error)
+
+	// List returns all canaries ordered by workflow name
+	List(ctx context.Context) ([]*models.Canary, error)
+
+	// Delete removes a canary and its run history
+	Delete(ctx context.Context, workflowID string) error
+
+	// RecordRun stores a run and updates the canary's status fields in one transaction
+	RecordRun(ctx context.Context, canary *models.Canary, run *models.CanaryRun) error
+
+	// ListRuns returns the most recent runs of a canary, newest first
+	ListRuns(ctx context.Context, workflowID string, limit int) ([]*models.CanaryRun, error)
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// DeadLetterRepository defines the interface for the dead-letter queue of failed executions
+type DeadLetterRepository interface {
+	// Create parks the failed execution of a stored workflow in the queue and fills in the
+	// dead letter; ID and created_at are set unless given. An execution already in the queue
+	// keeps its entry, refreshed with the new error. It returns models.ErrExecutionNotFailed
+	// for executions that are not failed or whose workflow is not stored.
+	Create(ctx context.Context, deadLetter *models.DeadLetter) error
+
+	// GetByID returns a dead letter or models.ErrDeadLetterNotFound
+	GetByID(ctx context.Context, id string) (*models.DeadLetter, error)
+
+	// List returns the dead letters matching the filter, newest first, and their total count
+	List(ctx context.Context, filter models.DeadLetterFilter) ([]*models.DeadLetter, int, error)
+
+	// Delete removes a dead letter or returns models.ErrDeadLetterNotFound
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgmodels "github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// ExecutionArchiveRepository defines the interface for moving whole executions out of the
+// database and back
+type ExecutionArchiveRepository interface {
+	// FindArchivable returns up to limit IDs of root executions finished before the cutoff,
+	// oldest first. Executions in the dead-letter queue are left alone
+	FindArchivable(ctx context.Context, cutoff time.Time, limit int) ([]uuid.UUID, error)
+
+	// Export returns the rows of an execution and its sub-workflow executions, with their
+	// node executions, events and notes
+	Export(ctx context.Context, executionID uuid.UUID) (*models.ExecutionRows, error)
+
+	// Archive records the archive and deletes the archived execution with all its rows, in one
+	// transaction
+	Archive(ctx context.Context, archive *models.ExecutionArchiveModel) error
+
+	// Restore inserts exported rows back and deletes the archive record of the execution, in
+	// one transaction
+	Restore(ctx context.Context, executionID uuid.UUID, rows *models.ExecutionRows) error
+
+	// FindByExecutionID returns the archive of an execution or pkgmodels.ErrExecutionArchiveNotFound
+	FindByExecutionID(ctx context.Context, executionID uuid.UUID) (*models.ExecutionArchiveModel, error)
+
+	// List returns the archives matching the filter, most recently archived first, and their total count
+	List(ctx context.Context, filter pkgmodels.ExecutionArchiveFilter) ([]*models.ExecutionArchiveModel, int, error)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// ExecutionNoteRepository defines the interface for notes attached to executions
+type ExecutionNoteRepository interface {
+	// Create stores a new note and sets its ID and timestamps
+	Create(ctx context.Context, note *models.ExecutionNote) error
+
+	// GetByID returns a note or models.ErrExecutionNoteNotFound
+	GetByID(ctx context.Context, id string) (*models.ExecutionNote, error)
+
+	// ListByExecutionID returns the notes of an execution, oldest first.
+	// A non-empty nodeID only returns the notes on that node.
+	ListByExecutionID(ctx context.Context, executionID, nodeID string) ([]*models.ExecutionNote, error)
+
+	// UpdateBody replaces the body of a note and sets its updated_at
+	UpdateBody(ctx context.Context, note *models.ExecutionNote) error
+
+	// Delete removes a note or returns models.ErrExecutionNoteNotFound
+	Delete(ctx context.Context, id string) error
+}
@@ -37,6 +37,37 @@ type ExecutionRepository interface {
 	// FindRunning retrieves all running executions
 	FindRunning(ctx context.Context) ([]*models.ExecutionModel, error)
 
+	// FindDueSuspended retrieves paused executions whose resume time has passed
+	FindDueSuspended(ctx context.Context, now time.Time, limit int) ([]*models.ExecutionModel, error)
+
+	// FindPausedByEventKey retrieves paused executions with a node waiting for the event key
+	FindPausedByEventKey(ctx context.Context, key string, limit int) ([]*models.ExecutionModel, error)
+
+	// ClaimSuspended moves a paused execution to running; it reports false if it is no longer paused
+	ClaimSuspended(ctx context.Context, id uuid.UUID) (bool, error)
+
+	// ClaimFailed moves a failed execution to running; it reports false if it is no longer failed
+	ClaimFailed(ctx context.Context, id uuid.UUID) (bool, error)
+
+	// ClaimPending moves a pending execution to running; it reports false if it is no longer pending
+	ClaimPending(ctx context.Context, id uuid.UUID) (bool, error)
+
+	// FindDueScheduled retrieves pending executions scheduled to start at or before now
+	FindDueScheduled(ctx context.Context, now time.Time, limit int) ([]*models.ExecutionModel, error)
+
+	// ClaimScheduled unschedules a scheduled execution, leaving it pending with its start time
+	// set to now; it reports false if it is no longer scheduled
+	ClaimScheduled(ctx context.Context, id uuid.UUID, now time.Time) (bool, error)
+
+	// SaveCheckpoint stores the state of a running execution; it reports false if it is no longer running
+	SaveCheckpoint(ctx context.Context, id uuid.UUID, state models.JSONBMap, at time.Time) (bool, error)
+
+	// FindStaleRunning retrieves running executions not checkpointed since before
+	FindStaleRunning(ctx context.Context, before time.Time, limit int) ([]*models.ExecutionModel, error)
+
+	// ClaimStale takes over a stale running execution; it reports false if it was checkpointed since
+	ClaimStale(ctx context.Context, id uuid.UUID, before time.Time) (bool, error)
+
 	// Count returns the total count of executions
 	Count(ctx context.Context) (int, error)
 
@@ -85,4 +116,7 @@ type ExecutionStatistics struct {
 	AverageDuration *time.Duration `json:"average_duration,omitempty"`
 	SuccessRate     float64        `json:"success_rate"`
 	FailureRate     float64        `json:"failure_rate"`
+
+	// AssertionFailures counts failed node output assertions (all actions) in the period
+	AssertionFailures int `json:"assertion_failures"`
 }
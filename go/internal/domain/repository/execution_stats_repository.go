@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StatsGranularity is the bucket size of execution statistics rollups
+type StatsGranularity string
+
+const (
+	StatsGranularityHour StatsGranularity = "hour"
+	StatsGranularityDay  StatsGranularity = "day"
+)
+
+// ExecutionStatsBucket holds execution metrics aggregated over one time bucket
+type ExecutionStatsBucket struct {
+	BucketStart       time.Time `json:"bucket_start"`
+	Executions        int       `json:"executions"`
+	CompletedCount    int       `json:"completed_count"`
+	FailedCount       int       `json:"failed_count"`
+	CancelledCount    int       `json:"cancelled_count"`
+	AverageDurationMs int64     `json:"average_duration_ms"`
+	MaxDurationMs     int64     `json:"max_duration_ms"`
+	NodeExecutions    int       `json:"node_executions"`
+	NodeFailures      int       `json:"node_failures"`
+	AssertionFailures int       `json:"assertion_failures"`
+}
+
+// ExecutionStatsRepository defines the interface for hourly and daily execution statistics rollups
+type ExecutionStatsRepository interface {
+	// LatestHourlyBucket returns the start of the newest hourly bucket, or nil if nothing was rolled up yet
+	LatestHourlyBucket(ctx context.Context) (*time.Time, error)
+
+	// EarliestExecutionStart returns the start time of the oldest stored workflow execution, or nil if there is none
+	EarliestExecutionStart(ctx context.Context) (*time.Time, error)
+
+	// RollupHourly recomputes hourly buckets for executions started in [from, to) and returns the number of rows written
+	RollupHourly(ctx context.Context, from, to time.Time) (int, error)
+
+	// RollupDaily recomputes daily buckets in [from, to) from the hourly table and returns the number of rows written
+	RollupDaily(ctx context.Context, from, to time.Time) (int, error)
+
+	// DeleteRawBefore removes events and node executions of executions finished before the cutoff
+	DeleteRawBefore(ctx context.Context, cutoff time.Time) (events int, nodeExecutions int, err error)
+
+	// DeleteHourlyBefore removes hourly buckets that start before the cutoff
+	DeleteHourlyBefore(ctx context.Context, cutoff time.Time) (int, error)
+
+	// ListBuckets returns buckets in [from, to) ordered by time; without a workflow ID all workflows are summed
+	ListBuckets(ctx context.Context, granularity StatsGranularity, workflowID *uuid.UUID, from, to time.Time) ([]*ExecutionStatsBucket, error)
+}
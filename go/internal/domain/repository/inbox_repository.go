@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// InboxRepository finds the work awaiting human action that the work inbox aggregates
+type InboxRepository interface {
+	// ListFailedExecutions returns the failed executions matching the filter that no later
+	// execution of their workflow completed, newest first, and their total count
+	ListFailedExecutions(ctx context.Context, filter models.InboxFilter) ([]*models.InboxItem, int, error)
+
+	// ListPendingApprovals returns the approval nodes of paused executions waiting for a
+	// decision that match the filter, newest first, and their total count
+	ListPendingApprovals(ctx context.Context, filter models.InboxFilter) ([]*models.InboxItem, int, error)
+
+	// ListBreakpoints returns the paused executions stopped at breakpoints that match the
+	// filter, newest first, and their total count
+	ListBreakpoints(ctx context.Context, filter models.InboxFilter) ([]*models.InboxItem, int, error)
+}
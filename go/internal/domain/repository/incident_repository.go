@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// IncidentRepository defines the interface for incident policies and the incidents opened for executions
+type IncidentRepository interface {
+	// SavePolicy creates or replaces the incident policy of a workflow
+	SavePolicy(ctx context.Context, policy *models.IncidentPolicy) error
+
+	// GetPolicy returns the incident policy of a workflow or models.ErrIncidentPolicyNotFound
+	GetPolicy(ctx context.Context, workflowID string) (*models.IncidentPolicy, error)
+
+	// DeletePolicy removes the incident policy of a workflow or returns models.ErrIncidentPolicyNotFound
+	DeletePolicy(ctx context.Context, workflowID string) error
+
+	// Create stores an incident and sets its ID and created_at
+	Create(ctx context.Context, incident *models.Incident) error
+
+	// ListByExecutionID returns the incidents linked to an execution, newest first
+	ListByExecutionID(ctx context.Context, executionID string) ([]*models.Incident, error)
+
+	// ListByWorkflowID returns the most recent incidents of a workflow, newest first
+	ListByWorkflowID(ctx context.Context, workflowID string, limit int) ([]*models.Incident, error)
+}
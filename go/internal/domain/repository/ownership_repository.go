@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// OwnershipRepository defines cross-entity ownership queries used for transfers and offboarding
+type OwnershipRepository interface {
+	// GetOwnershipReport lists everything owned by a user, including soft-deleted users
+	GetOwnershipReport(ctx context.Context, userID string) (*models.OwnershipReport, error)
+
+	// TransferOwnership atomically reassigns owned objects from one user to another
+	TransferOwnership(ctx context.Context, transfer *models.OwnershipTransfer) (*models.OwnershipTransferResult, error)
+}
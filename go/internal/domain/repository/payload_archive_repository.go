@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+)
+
+// PayloadArchiveRepository defines the interface for moving node execution payloads to cold storage
+type PayloadArchiveRepository interface {
+	// FindArchivable returns up to limit node executions of executions finished before the cutoff
+	// whose payloads are still stored in the database, oldest first
+	FindArchivable(ctx context.Context, cutoff time.Time, limit int) ([]*models.NodeExecutionModel, error)
+
+	// MarkArchived records the storage path of a node execution payload and clears the payload columns
+	MarkArchived(ctx context.Context, id uuid.UUID, ref string, archivedAt time.Time) error
+
+	// FindExpired returns up to limit node executions whose retention label expired before now
+	// and whose payloads were not purged yet, soonest expiry first
+	FindExpired(ctx context.Context, now time.Time, limit int) ([]*models.NodeExecutionModel, error)
+
+	// MarkPurged clears the payload columns and the cold storage reference of a node execution
+	MarkPurged(ctx context.Context, id uuid.UUID, purgedAt time.Time) error
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// UsageRepository defines the interface for the daily usage of the executions of users
+type UsageRepository interface {
+	// Get returns the usage of a user on a UTC day, all zero if nothing was recorded
+	Get(ctx context.Context, userID string, day time.Time) (*models.DailyUsage, error)
+
+	// Add atomically adds the counters of usage to the usage of its user on its day
+	Add(ctx context.Context, usage *models.DailyUsage) error
+
+	// CountExecution atomically adds an execution to the usage of a user on a UTC day unless
+	// that usage already reached a limit of the quota; it reports whether it was added
+	CountExecution(ctx context.Context, userID string, day time.Time, quota *models.UsageQuota) (bool, error)
+
+	// List returns the usage of a user on the days in [from, to), oldest first; days without usage are left out
+	List(ctx context.Context, userID string, from, to time.Time) ([]*models.DailyUsage, error)
+}
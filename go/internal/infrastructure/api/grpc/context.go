@@ -53,6 +53,11 @@ func ContextWithIsAdmin(ctx context.Context, isAdmin bool) context.Context {
 	return context.WithValue(ctx, ctxKeyIsAdmin, isAdmin)
 }
 
+func IsAdminFromContext(ctx context.Context) bool {
+	v, _ := ctx.Value(ctxKeyIsAdmin).(bool)
+	return v
+}
+
 func ContextWithAuthMethod(ctx context.Context, method string) context.Context {
 	return context.WithValue(ctx, ctxKeyAuthMethod, method)
 }
@@ -26,6 +26,10 @@ func mapError(err error) error {
 		return status.Errorf(codes.NotFound, "workflow not found")
 	case errors.Is(err, models.ErrExecutionNotFound):
 		return status.Errorf(codes.NotFound, "execution not found")
+	case errors.Is(err, models.ErrExecutionNotRunning):
+		return status.Errorf(codes.FailedPrecondition, "execution is not running on this instance")
+	case errors.Is(err, models.ErrQuotaExceeded):
+		return status.Errorf(codes.ResourceExhausted, "%s", err.Error())
 	case errors.Is(err, models.ErrTriggerNotFound):
 		return status.Errorf(codes.NotFound, "trigger not found")
 	case errors.Is(err, models.ErrResourceNotFound):
@@ -96,8 +96,11 @@ func (s *ServiceAPIServer) CancelExecution(ctx context.Context, req *pb.CancelEx
 		return nil, status.Errorf(codes.InvalidArgument, "invalid execution ID")
 	}
 
+	userID, _ := UserIDFromContext(ctx)
 	if err := s.ops.CancelExecution(ctx, serviceapi.CancelExecutionParams{
 		ExecutionID: execUUID,
+		UserID:      userID,
+		IsAdmin:     IsAdminFromContext(ctx),
 	}); err != nil {
 		return nil, mapError(err)
 	}
@@ -11,6 +11,7 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	"github.com/smilemakc/mbflow/go/internal/application/maintenance"
 	"github.com/smilemakc/mbflow/go/internal/application/systemkey"
 	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
 	"github.com/smilemakc/mbflow/go/internal/infrastructure/storage"
@@ -79,6 +80,28 @@ func ImpersonationInterceptor(userRepo *storage.UserRepository, systemUserID str
 	}
 }
 
+// ReadOnlyInterceptor rejects mutating calls with codes.Unavailable while read-only mode is on.
+// Methods named List*, Get* and Stream* are treated as reads and always pass through.
+func ReadOnlyInterceptor(mode *maintenance.ReadOnlyMode) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		enabled, message := mode.Enabled()
+		if enabled && !isReadOnlyMethod(info.FullMethod) {
+			return nil, status.Error(codes.Unavailable, message)
+		}
+		return handler(ctx, req)
+	}
+}
+
+func isReadOnlyMethod(fullMethod string) bool {
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	for _, prefix := range []string{"List", "Get", "Stream"} {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // AuditInterceptor logs actions via AuditService after the handler executes.
 func AuditInterceptor(auditService *systemkey.AuditService, log *logger.Logger) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
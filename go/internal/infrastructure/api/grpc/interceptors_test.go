@@ -18,6 +18,7 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	"github.com/smilemakc/mbflow/go/internal/application/maintenance"
 	"github.com/smilemakc/mbflow/go/internal/application/systemkey"
 	"github.com/smilemakc/mbflow/go/internal/config"
 	"github.com/smilemakc/mbflow/go/internal/domain/repository"
@@ -1296,3 +1297,37 @@ func (r *capturingSystemKeyRepository) Count(_ context.Context) (int64, error) {
 }
 
 var _ repository.SystemKeyRepository = (*capturingSystemKeyRepository)(nil)
+
+// ---------------------------------------------------------------------------
+// ReadOnlyInterceptor
+// ---------------------------------------------------------------------------
+
+func TestReadOnlyInterceptor_ShouldRejectMutations_WhenEnabled(t *testing.T) {
+	mode := maintenance.NewReadOnlyMode(true, "maintenance")
+	interceptor := ReadOnlyInterceptor(mode)
+
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+
+	resp, err := interceptor(context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: "/serviceapi.MBFlowServiceAPI/StartExecution"}, handler)
+	assert.Nil(t, resp)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.Unavailable, st.Code())
+	assert.Equal(t, "maintenance", st.Message())
+
+	resp, err = interceptor(context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: "/serviceapi.MBFlowServiceAPI/ListWorkflows"}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+}
+
+func TestReadOnlyInterceptor_ShouldPassThrough_WhenDisabled(t *testing.T) {
+	interceptor := ReadOnlyInterceptor(maintenance.NewReadOnlyMode(false, ""))
+
+	resp, err := interceptor(context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: "/serviceapi.MBFlowServiceAPI/CreateWorkflow"},
+		func(ctx context.Context, req any) (any, error) { return "ok", nil })
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+}
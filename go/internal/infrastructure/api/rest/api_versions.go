@@ -0,0 +1,229 @@
+package rest
+
+import (
+	_ "embed"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// API versions. /api/v1 is frozen: its routes, DTOs and error shape do not change.
+// Breaking improvements (cursor pagination, typed errors, workspaces) go to /api/v2,
+// whose handlers call the same serviceapi operations and only differ in DTO mapping.
+const (
+	APIVersionV1 = "v1"
+	APIVersionV2 = "v2"
+
+	// HeaderAPIVersion is set on every versioned response. On /api/v2 a client may also
+	// send it to pin the version it was written against; a mismatch is rejected.
+	HeaderAPIVersion = "API-Version"
+)
+
+// APIVersionInfo describes a published API version.
+type APIVersionInfo struct {
+	Version  string `json:"version"`
+	Status   string `json:"status"` // "stable" or "beta"
+	BasePath string `json:"base_path"`
+	OpenAPI  string `json:"openapi"`
+}
+
+// APIVersions lists the published API versions, oldest first.
+var APIVersions = []APIVersionInfo{
+	{Version: APIVersionV1, Status: "stable", BasePath: "/api/v1", OpenAPI: "/swagger/doc.json"},
+	{Version: APIVersionV2, Status: "beta", BasePath: "/api/v2", OpenAPI: "/api/v2/openapi.json"},
+}
+
+//go:embed openapi_v2.json
+var openAPIv2 []byte
+
+// HandleListVersions lists the published API versions and their OpenAPI documents.
+func HandleListVersions(c *gin.Context) {
+	respondJSON(c, http.StatusOK, gin.H{
+		"versions": APIVersions,
+		"default":  APIVersionV1,
+	})
+}
+
+// HandleOpenAPIv2 serves the OpenAPI document of API v2.
+// API v1 is documented by the generated Swagger document.
+func HandleOpenAPIv2(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", openAPIv2)
+}
+
+// APIVersionMiddleware stamps responses with the API version of the route group.
+// With strict set, requests that pin a different version in the API-Version header
+// are rejected; v1 is not strict so existing integrations keep working unchanged.
+func APIVersionMiddleware(version string, strict bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header(HeaderAPIVersion, version)
+
+		if requested := c.GetHeader(HeaderAPIVersion); strict && requested != "" && requested != version {
+			respondV2Error(c, NewAPIErrorWithDetails("UNSUPPORTED_API_VERSION",
+				"API-Version header does not match the requested path", http.StatusBadRequest,
+				map[string]any{"requested": requested, "served": version}))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// ============================================================================
+// Typed errors (v2)
+// ============================================================================
+
+// Error types of API v2. Clients branch on the type; the code narrows it down.
+const (
+	ErrorTypeInvalidRequest = "invalid_request"
+	ErrorTypeAuthentication = "authentication"
+	ErrorTypePermission     = "permission"
+	ErrorTypeNotFound       = "not_found"
+	ErrorTypeConflict       = "conflict"
+	ErrorTypeQuota          = "quota"
+	ErrorTypeRateLimit      = "rate_limit"
+	ErrorTypeInternal       = "internal"
+)
+
+// V2Error is the error body of API v2, sent as {"error": {...}}.
+type V2Error struct {
+	Type      string         `json:"type"`
+	Code      string         `json:"code"`
+	Message   string         `json:"message"`
+	Details   map[string]any `json:"details,omitempty"`
+	RequestID string         `json:"request_id,omitempty"`
+}
+
+// errorTypeForStatus maps an HTTP status to its API v2 error type.
+func errorTypeForStatus(status int) string {
+	switch {
+	case status == http.StatusUnauthorized:
+		return ErrorTypeAuthentication
+	case status == http.StatusForbidden:
+		return ErrorTypePermission
+	case status == http.StatusNotFound:
+		return ErrorTypeNotFound
+	case status == http.StatusConflict:
+		return ErrorTypeConflict
+	case status == http.StatusPaymentRequired:
+		return ErrorTypeQuota
+	case status == http.StatusTooManyRequests:
+		return ErrorTypeRateLimit
+	case status >= 400 && status < 500:
+		return ErrorTypeInvalidRequest
+	default:
+		return ErrorTypeInternal
+	}
+}
+
+// respondV2Error writes err in the API v2 error envelope.
+// Errors are translated exactly as in v1, so codes are shared between versions.
+func respondV2Error(c *gin.Context, err error) {
+	apiErr := TranslateError(err)
+	c.JSON(apiErr.HTTPStatus, gin.H{"error": V2Error{
+		Type:      errorTypeForStatus(apiErr.HTTPStatus),
+		Code:      apiErr.Code,
+		Message:   apiErr.Message,
+		Details:   apiErr.Details,
+		RequestID: GetRequestID(c),
+	}})
+}
+
+// ============================================================================
+// Cursor pagination (v2)
+// ============================================================================
+
+const (
+	v2DefaultPageSize = 50
+	v2MaxPageSize     = 200
+)
+
+// pageCursor is the decoded form of an API v2 cursor. Cursors are opaque to clients,
+// so the position encoding can move from offsets to keys without a new version.
+type pageCursor struct {
+	Offset int `json:"o"`
+}
+
+func encodeCursor(cur pageCursor) string {
+	data, _ := json.Marshal(cur)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (pageCursor, error) {
+	var cur pageCursor
+	if s == "" {
+		return cur, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cur, NewAPIError("INVALID_CURSOR", "cursor is malformed", http.StatusBadRequest)
+	}
+	if err := json.Unmarshal(data, &cur); err != nil || cur.Offset < 0 {
+		return pageCursor{}, NewAPIError("INVALID_CURSOR", "cursor is malformed", http.StatusBadRequest)
+	}
+	return cur, nil
+}
+
+// pageRequest holds the pagination parameters of an API v2 list request.
+type pageRequest struct {
+	Limit  int
+	Offset int
+}
+
+// parsePageRequest reads limit and cursor. Unlike v1, invalid values are rejected
+// instead of silently replaced by defaults.
+func parsePageRequest(c *gin.Context) (pageRequest, error) {
+	page := pageRequest{Limit: v2DefaultPageSize}
+
+	if raw := c.Query("limit"); raw != "" {
+		limit := parseIntQuery(raw, -1)
+		if limit < 1 || limit > v2MaxPageSize {
+			return page, NewAPIErrorWithDetails("INVALID_PARAMETER", "limit must be between 1 and 200",
+				http.StatusBadRequest, map[string]any{"parameter": "limit"})
+		}
+		page.Limit = limit
+	}
+
+	cur, err := decodeCursor(c.Query("cursor"))
+	if err != nil {
+		return page, err
+	}
+	page.Offset = cur.Offset
+	return page, nil
+}
+
+// PageInfo is the pagination block of API v2 list responses.
+type PageInfo struct {
+	Limit      int    `json:"limit"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// fetchLimit is the number of rows to request from the shared core: one more than
+// the page size, so the presence of a next page is known without counting.
+func (p pageRequest) fetchLimit() int {
+	return p.Limit + 1
+}
+
+// pageInfo builds the pagination block from the number of rows fetched with fetchLimit
+// and returns how many of them belong to the page.
+func (p pageRequest) pageInfo(fetched int) (PageInfo, int) {
+	info := PageInfo{Limit: p.Limit}
+	if fetched <= p.Limit {
+		return info, fetched
+	}
+	info.HasMore = true
+	info.NextCursor = encodeCursor(pageCursor{Offset: p.Offset + p.Limit})
+	return info, p.Limit
+}
+
+// respondPage writes an API v2 list response.
+func respondPage(c *gin.Context, data any, page PageInfo) {
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+		"page": page,
+	})
+}
@@ -0,0 +1,184 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+func TestAPIVersionMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/api/v1/workflows", APIVersionMiddleware(APIVersionV1, false), ok)
+	router.GET("/api/v2/workflows", APIVersionMiddleware(APIVersionV2, true), ok)
+
+	tests := []struct {
+		name      string
+		path      string
+		requested string
+		want      int
+	}{
+		{"v1 without header", "/api/v1/workflows", "", http.StatusOK},
+		{"v1 ignores mismatch", "/api/v1/workflows", "v2", http.StatusOK},
+		{"v2 without header", "/api/v2/workflows", "", http.StatusOK},
+		{"v2 matching header", "/api/v2/workflows", "v2", http.StatusOK},
+		{"v2 rejects mismatch", "/api/v2/workflows", "v1", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.requested != "" {
+				req.Header.Set(HeaderAPIVersion, tt.requested)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Code)
+			assert.NotEmpty(t, w.Header().Get(HeaderAPIVersion))
+		})
+	}
+}
+
+func TestRespondV2Error(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		err    error
+		status int
+		typ    string
+		code   string
+	}{
+		{models.ErrWorkflowNotFound, http.StatusNotFound, ErrorTypeNotFound, "WORKFLOW_NOT_FOUND"},
+		{ErrInvalidID, http.StatusBadRequest, ErrorTypeInvalidRequest, "INVALID_ID"},
+		{models.ErrUnauthorized, http.StatusUnauthorized, ErrorTypeAuthentication, "UNAUTHORIZED"},
+		{models.ErrInsufficientBalance, http.StatusPaymentRequired, ErrorTypeQuota, "INSUFFICIENT_BALANCE"},
+		{errors.New("boom"), http.StatusInternalServerError, ErrorTypeInternal, "INTERNAL_ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v2/workflows", nil)
+			c.Set(ContextKeyRequestID, "req-1")
+
+			respondV2Error(c, tt.err)
+
+			assert.Equal(t, tt.status, w.Code)
+			var body struct {
+				Error V2Error `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, tt.typ, body.Error.Type)
+			assert.Equal(t, tt.code, body.Error.Code)
+			assert.NotEmpty(t, body.Error.Message)
+			assert.Equal(t, "req-1", body.Error.RequestID)
+		})
+	}
+}
+
+func TestParsePageRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	parse := func(query string) (pageRequest, error) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v2/executions?"+query, nil)
+		return parsePageRequest(c)
+	}
+
+	page, err := parse("")
+	require.NoError(t, err)
+	assert.Equal(t, pageRequest{Limit: 50}, page)
+
+	page, err = parse("limit=10&cursor=" + encodeCursor(pageCursor{Offset: 30}))
+	require.NoError(t, err)
+	assert.Equal(t, pageRequest{Limit: 10, Offset: 30}, page)
+
+	for _, query := range []string{"limit=0", "limit=201", "limit=abc", "cursor=!!", "cursor=e30x"} {
+		_, err := parse(query)
+		assert.Error(t, err, query)
+	}
+}
+
+func TestPageRequest_PageInfo(t *testing.T) {
+	page := pageRequest{Limit: 2, Offset: 4}
+	assert.Equal(t, 3, page.fetchLimit())
+
+	info, n := page.pageInfo(3)
+	assert.Equal(t, 2, n)
+	assert.True(t, info.HasMore)
+	cur, err := decodeCursor(info.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, 6, cur.Offset)
+
+	info, n = page.pageInfo(2)
+	assert.Equal(t, 2, n)
+	assert.False(t, info.HasMore)
+	assert.Empty(t, info.NextCursor)
+}
+
+func TestToExecutionV2(t *testing.T) {
+	completed := time.Now()
+	exec := &models.Execution{
+		ID:             "exec-1",
+		WorkflowID:     "wf-1",
+		Status:         models.ExecutionStatusFailed,
+		Error:          "node failed",
+		Duration:       1500,
+		CompletedAt:    &completed,
+		NodeExecutions: []*models.NodeExecution{{ID: "ne-1"}},
+		Metadata:       map[string]any{"correlation_id": "corr-1", "workspace_id": "ws-1"},
+	}
+
+	summary := toExecutionV2(exec, false)
+	assert.Equal(t, "failed", summary.Status)
+	assert.Equal(t, &ExecutionErrorV2{Message: "node failed"}, summary.Error)
+	assert.Equal(t, int64(1500), summary.DurationMs)
+	assert.Equal(t, "corr-1", summary.CorrelationID)
+	assert.Equal(t, "ws-1", summary.WorkspaceID)
+	assert.Nil(t, summary.NodeExecutions)
+
+	detail := toExecutionV2(exec, true)
+	assert.Len(t, detail.NodeExecutions, 1)
+}
+
+func TestToWorkflowV2(t *testing.T) {
+	wf := &models.Workflow{
+		ID:     "wf-1",
+		Name:   "orders",
+		Status: models.WorkflowStatusActive,
+		Nodes:  []*models.Node{{ID: "a"}, {ID: "b"}},
+		Edges:  []*models.Edge{{ID: "e", From: "a", To: "b"}},
+	}
+
+	summary := toWorkflowV2(wf, false)
+	assert.Equal(t, 2, summary.NodeCount)
+	assert.Equal(t, 1, summary.EdgeCount)
+	assert.Equal(t, []string{}, summary.Tags)
+	assert.Nil(t, summary.Nodes)
+
+	detail := toWorkflowV2(wf, true)
+	assert.Len(t, detail.Nodes, 2)
+	assert.Len(t, detail.Edges, 1)
+}
+
+func TestOpenAPIv2Document(t *testing.T) {
+	var doc struct {
+		OpenAPI string         `json:"openapi"`
+		Paths   map[string]any `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(openAPIv2, &doc))
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+	assert.Contains(t, doc.Paths, "/workflows")
+	assert.Contains(t, doc.Paths, "/executions/{id}")
+}
@@ -69,7 +69,7 @@ func TranslateError(err error) *APIError {
 
 	var opErr *serviceapi.OperationError
 	if errors.As(err, &opErr) {
-		return NewAPIError(opErr.Code, opErr.Message, opErr.HTTPStatus)
+		return NewAPIErrorWithDetails(opErr.Code, opErr.Message, opErr.HTTPStatus, opErr.Details)
 	}
 
 	switch {
@@ -77,8 +77,46 @@ func TranslateError(err error) *APIError {
 		return NewAPIError("WORKFLOW_NOT_FOUND", "Workflow not found", http.StatusNotFound)
 	case errors.Is(err, models.ErrExecutionNotFound):
 		return NewAPIError("EXECUTION_NOT_FOUND", "Execution not found", http.StatusNotFound)
+	case errors.Is(err, models.ErrExecutionNotRunning):
+		return NewAPIError("EXECUTION_NOT_RUNNING", "Execution is not running on this instance", http.StatusConflict)
+	case errors.Is(err, models.ErrExecutionNotPaused):
+		return NewAPIError("EXECUTION_NOT_PAUSED", "Execution is not paused", http.StatusConflict)
+	case errors.Is(err, models.ErrNotAtBreakpoint):
+		return NewAPIError("EXECUTION_NOT_AT_BREAKPOINT", "Execution is not stopped at a breakpoint", http.StatusConflict)
+	case errors.Is(err, models.ErrExecutionNotFailed):
+		return NewAPIError("EXECUTION_NOT_FAILED", "Execution has not failed", http.StatusConflict)
+	case errors.Is(err, models.ErrConcurrencyLimitReached):
+		return NewAPIError("CONCURRENCY_LIMIT_REACHED", err.Error(), http.StatusTooManyRequests)
+	case errors.Is(err, models.ErrQuotaExceeded):
+		return NewAPIError("QUOTA_EXCEEDED", err.Error(), http.StatusTooManyRequests)
+	case errors.Is(err, models.ErrApprovalNotFound):
+		return NewAPIError("APPROVAL_NOT_FOUND", "Approval not found", http.StatusNotFound)
+	case errors.Is(err, models.ErrApprovalNotPending):
+		return NewAPIError("APPROVAL_NOT_PENDING", "Approval has already been decided", http.StatusConflict)
+	case errors.Is(err, models.ErrExecutionNoteNotFound):
+		return NewAPIError("EXECUTION_NOTE_NOT_FOUND", "Execution note not found", http.StatusNotFound)
+	case errors.Is(err, models.ErrDeadLetterNotFound):
+		return NewAPIError("DEAD_LETTER_NOT_FOUND", "Dead letter not found", http.StatusNotFound)
+	case errors.Is(err, models.ErrExecutionArchiveNotFound):
+		return NewAPIError("EXECUTION_ARCHIVE_NOT_FOUND", "Execution archive not found", http.StatusNotFound)
 	case errors.Is(err, models.ErrTriggerNotFound):
 		return NewAPIError("TRIGGER_NOT_FOUND", "Trigger not found", http.StatusNotFound)
+	case errors.Is(err, models.ErrCanaryNotFound):
+		return NewAPIError("CANARY_NOT_FOUND", "Canary not found", http.StatusNotFound)
+	case errors.Is(err, models.ErrIncidentPolicyNotFound):
+		return NewAPIError("INCIDENT_POLICY_NOT_FOUND", "Incident policy not found", http.StatusNotFound)
+	case errors.Is(err, models.ErrIncidentTrackerNotConfigured):
+		return NewAPIError("INCIDENT_TRACKER_NOT_CONFIGURED", "No incident tracker is configured for the workflow", http.StatusConflict)
+	case errors.Is(err, models.ErrAlreadyOnboarded):
+		return NewAPIError("ALREADY_ONBOARDED", "The workspace has already been onboarded", http.StatusConflict)
+	case errors.Is(err, models.ErrOnboardingTemplateNotFound):
+		return NewAPIError("ONBOARDING_TEMPLATE_NOT_FOUND", err.Error(), http.StatusBadRequest)
+	case errors.Is(err, models.ErrInvalidNodeSelection):
+		return NewAPIError("INVALID_NODE_SELECTION", err.Error(), http.StatusBadRequest)
+	case errors.Is(err, models.ErrLaunchProfileNotFound):
+		return NewAPIError("LAUNCH_PROFILE_NOT_FOUND", err.Error(), http.StatusNotFound)
+	case errors.Is(err, models.ErrFixtureNotFound):
+		return NewAPIError("FIXTURE_NOT_FOUND", err.Error(), http.StatusNotFound)
 	case errors.Is(err, models.ErrNodeNotFound):
 		return NewAPIError("NODE_NOT_FOUND", "Node not found", http.StatusNotFound)
 	case errors.Is(err, models.ErrEdgeNotFound):
@@ -0,0 +1,105 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/smilemakc/mbflow/go/internal/application/analytics"
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+)
+
+// defaultAnalyticsRanges is the query range used when "from" is omitted, per granularity
+var defaultAnalyticsRanges = map[repository.StatsGranularity]time.Duration{
+	repository.StatsGranularityHour: 48 * time.Hour,
+	repository.StatsGranularityDay:  30 * 24 * time.Hour,
+}
+
+// AnalyticsHandlers handles execution analytics endpoints (admin only)
+type AnalyticsHandlers struct {
+	rollup *analytics.RollupService
+	logger *logger.Logger
+}
+
+// NewAnalyticsHandlers creates a new AnalyticsHandlers instance
+func NewAnalyticsHandlers(rollup *analytics.RollupService, log *logger.Logger) *AnalyticsHandlers {
+	return &AnalyticsHandlers{
+		rollup: rollup,
+		logger: log,
+	}
+}
+
+// HandleGetExecutionStats returns rolled-up execution statistics
+// GET /api/v1/admin/analytics/executions?granularity=day&workflow_id=&from=&to=
+func (h *AnalyticsHandlers) HandleGetExecutionStats(c *gin.Context) {
+	granularity := repository.StatsGranularity(c.DefaultQuery("granularity", string(repository.StatsGranularityDay)))
+	defaultRange, ok := defaultAnalyticsRanges[granularity]
+	if !ok {
+		respondAPIError(c, NewAPIError("INVALID_GRANULARITY", analytics.ErrInvalidGranularity.Error(), http.StatusBadRequest))
+		return
+	}
+
+	to := time.Now().UTC()
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			respondAPIError(c, NewAPIError("INVALID_TIME_RANGE", "to must be an RFC 3339 timestamp", http.StatusBadRequest))
+			return
+		}
+		to = parsed
+	}
+
+	from := to.Add(-defaultRange)
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			respondAPIError(c, NewAPIError("INVALID_TIME_RANGE", "from must be an RFC 3339 timestamp", http.StatusBadRequest))
+			return
+		}
+		from = parsed
+	}
+
+	var workflowID *uuid.UUID
+	if raw := c.Query("workflow_id"); raw != "" {
+		parsed, err := uuid.Parse(raw)
+		if err != nil {
+			respondAPIError(c, ErrInvalidID)
+			return
+		}
+		workflowID = &parsed
+	}
+
+	report, err := h.rollup.Query(c.Request.Context(), granularity, workflowID, from, to)
+	if err != nil {
+		if errors.Is(err, analytics.ErrInvalidRange) {
+			respondAPIError(c, NewAPIError("INVALID_TIME_RANGE", err.Error(), http.StatusBadRequest))
+			return
+		}
+		h.logger.Error("Failed to query execution stats", "error", err, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+
+	respondJSON(c, http.StatusOK, report)
+}
+
+// HandleRunRollup runs a rollup immediately and returns what it did
+// POST /api/v1/admin/analytics/rollup
+func (h *AnalyticsHandlers) HandleRunRollup(c *gin.Context) {
+	result, err := h.rollup.RunOnce(c.Request.Context())
+	if err != nil {
+		if errors.Is(err, analytics.ErrRollupInProgress) {
+			respondAPIError(c, NewAPIError("ROLLUP_IN_PROGRESS", err.Error(), http.StatusConflict))
+			return
+		}
+		h.logger.Error("Failed to run execution stats rollup", "error", err, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+
+	respondJSON(c, http.StatusOK, result)
+}
@@ -0,0 +1,161 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/smilemakc/mbflow/go/internal/application/serviceapi"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// ApprovalHandlers handles the decisions on approval nodes of executions
+type ApprovalHandlers struct {
+	ops    *serviceapi.Operations
+	logger *logger.Logger
+}
+
+// NewApprovalHandlers creates a new ApprovalHandlers instance
+func NewApprovalHandlers(ops *serviceapi.Operations, log *logger.Logger) *ApprovalHandlers {
+	return &ApprovalHandlers{ops: ops, logger: log}
+}
+
+// DecideApprovalRequest represents a request to approve or reject an approval
+type DecideApprovalRequest struct {
+	Comment string `json:"comment,omitempty"`
+}
+
+// HandleListApprovals lists the approvals of an execution
+//
+//	@Summary		List execution approvals
+//	@Description	Lists the approvals raised by the approval nodes of an execution: pending while the execution waits for a decision, then approved, rejected or timeout.
+//	@Tags			executions
+//	@Produce		json
+//	@Param			id	path		string										true	"Execution ID"	format(uuid)
+//	@Success		200	{object}	object{approvals=[]models.Approval,total=int}	"Execution approvals"
+//	@Failure		400	{object}	APIError									"Invalid execution ID"
+//	@Failure		404	{object}	APIError									"Execution not found"
+//	@Failure		500	{object}	APIError									"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/executions/{id}/approvals [get]
+func (h *ApprovalHandlers) HandleListApprovals(c *gin.Context) {
+	executionID, ok := h.getExecutionID(c)
+	if !ok {
+		return
+	}
+
+	approvals, err := h.ops.ListApprovals(c.Request.Context(), serviceapi.ListApprovalsParams{ExecutionID: executionID})
+	if err != nil {
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"approvals": approvals,
+		"total":     len(approvals),
+	})
+}
+
+// HandleApprove approves a pending approval
+//
+//	@Summary		Approve
+//	@Description	Approves the pending approval of an approval node; the execution resumes along the node's "approved" edges.
+//	@Description	Only the node's approvers may decide when it lists any. An approval is decided once.
+//	@Tags			executions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Execution ID"	format(uuid)
+//	@Param			node_id	path		string					true	"Approval node ID"
+//	@Param			request	body		DecideApprovalRequest	false	"Comment"
+//	@Success		200		{object}	models.Approval			"Decided approval"
+//	@Failure		400		{object}	APIError				"Invalid request"
+//	@Failure		401		{object}	APIError				"Authentication required"
+//	@Failure		403		{object}	APIError				"Not an approver"
+//	@Failure		404		{object}	APIError				"Approval not found"
+//	@Failure		409		{object}	APIError				"Approval already decided"
+//	@Failure		500		{object}	APIError				"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/executions/{id}/approvals/{node_id}/approve [post]
+func (h *ApprovalHandlers) HandleApprove(c *gin.Context) {
+	h.decide(c, models.ApprovalStatusApproved)
+}
+
+// HandleReject rejects a pending approval
+//
+//	@Summary		Reject
+//	@Description	Rejects the pending approval of an approval node; the execution resumes along the node's "rejected" edges.
+//	@Description	Only the node's approvers may decide when it lists any. An approval is decided once.
+//	@Tags			executions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Execution ID"	format(uuid)
+//	@Param			node_id	path		string					true	"Approval node ID"
+//	@Param			request	body		DecideApprovalRequest	false	"Comment"
+//	@Success		200		{object}	models.Approval			"Decided approval"
+//	@Failure		400		{object}	APIError				"Invalid request"
+//	@Failure		401		{object}	APIError				"Authentication required"
+//	@Failure		403		{object}	APIError				"Not an approver"
+//	@Failure		404		{object}	APIError				"Approval not found"
+//	@Failure		409		{object}	APIError				"Approval already decided"
+//	@Failure		500		{object}	APIError				"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/executions/{id}/approvals/{node_id}/reject [post]
+func (h *ApprovalHandlers) HandleReject(c *gin.Context) {
+	h.decide(c, models.ApprovalStatusRejected)
+}
+
+// decide records the caller's decision on the approval named by the path parameters
+func (h *ApprovalHandlers) decide(c *gin.Context, decision models.ApprovalStatus) {
+	executionID, ok := h.getExecutionID(c)
+	if !ok {
+		return
+	}
+	nodeID, ok := getParam(c, "node_id")
+	if !ok {
+		return
+	}
+
+	var req DecideApprovalRequest
+	if c.Request.ContentLength != 0 {
+		if err := bindJSON(c, &req); err != nil {
+			return
+		}
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		respondAPIError(c, ErrUnauthorized)
+		return
+	}
+
+	approval, err := h.ops.DecideApproval(c.Request.Context(), serviceapi.DecideApprovalParams{
+		ExecutionID: executionID,
+		NodeID:      nodeID,
+		Decision:    decision,
+		Comment:     req.Comment,
+		UserID:      userID,
+	})
+	if err != nil {
+		h.logger.Error("Failed to decide approval", "error", err, "execution_id", executionID, "node_id", nodeID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	respondJSON(c, http.StatusOK, approval)
+}
+
+func (h *ApprovalHandlers) getExecutionID(c *gin.Context) (uuid.UUID, bool) {
+	executionID, ok := getParam(c, "id")
+	if !ok {
+		return uuid.Nil, false
+	}
+
+	execUUID, err := uuid.Parse(executionID)
+	if err != nil {
+		respondAPIError(c, ErrInvalidID)
+		return uuid.Nil, false
+	}
+	return execUUID, true
+}
@@ -0,0 +1,148 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/smilemakc/mbflow/go/internal/application/coldstorage"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// ArchiveHandlers handles executions archived out of the database (admin only)
+type ArchiveHandlers struct {
+	archiver *coldstorage.ExecutionArchiver
+	logger   *logger.Logger
+}
+
+// NewArchiveHandlers creates a new ArchiveHandlers instance
+func NewArchiveHandlers(archiver *coldstorage.ExecutionArchiver, log *logger.Logger) *ArchiveHandlers {
+	return &ArchiveHandlers{
+		archiver: archiver,
+		logger:   log,
+	}
+}
+
+// HandleListArchives lists archived executions
+//
+//	@Summary		List archived executions
+//	@Description	Lists the executions moved out of the database into compressed archives, most recently archived first. Admin only.
+//	@Tags			admin
+//	@Produce		json
+//	@Param			workflow_id	query		string	false	"Filter by workflow ID"	format(uuid)
+//	@Param			limit		query		int		false	"Maximum number of results"	default(50)
+//	@Param			offset		query		int		false	"Offset for pagination"		default(0)
+//	@Success		200			{object}	object{data=[]models.ExecutionArchive,total=int,limit=int,offset=int}	"Archived executions"
+//	@Failure		400			{object}	APIError																"Invalid workflow ID"
+//	@Failure		403			{object}	APIError																"Admin access required"
+//	@Failure		500			{object}	APIError																"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/admin/archives [get]
+func (h *ArchiveHandlers) HandleListArchives(c *gin.Context) {
+	filter := models.ExecutionArchiveFilter{
+		WorkflowID: c.Query("workflow_id"),
+		Limit:      getQueryInt(c, "limit", coldstorage.DefaultArchivePageSize),
+		Offset:     getQueryInt(c, "offset", 0),
+	}
+
+	archives, total, err := h.archiver.List(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.Error("Failed to list execution archives", "error", err, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	respondList(c, http.StatusOK, archives, total, filter.Limit, filter.Offset)
+}
+
+// HandleGetArchive returns the archive of an execution
+//
+//	@Summary		Get execution archive
+//	@Description	Returns the archive record of an archived execution. Admin only.
+//	@Tags			admin
+//	@Produce		json
+//	@Param			execution_id	path		string					true	"Execution ID"	format(uuid)
+//	@Success		200				{object}	models.ExecutionArchive	"Execution archive"
+//	@Failure		400				{object}	APIError				"Invalid execution ID"
+//	@Failure		403				{object}	APIError				"Admin access required"
+//	@Failure		404				{object}	APIError				"Execution archive not found"
+//	@Security		BearerAuth
+//	@Router			/admin/archives/{execution_id} [get]
+func (h *ArchiveHandlers) HandleGetArchive(c *gin.Context) {
+	executionID, err := uuid.Parse(c.Param("execution_id"))
+	if err != nil {
+		respondAPIError(c, ErrInvalidID)
+		return
+	}
+
+	archive, err := h.archiver.Get(c.Request.Context(), executionID)
+	if err != nil {
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	respondJSON(c, http.StatusOK, archive)
+}
+
+// HandleRestoreArchive moves an archived execution back into the database
+//
+//	@Summary		Restore archived execution
+//	@Description	Moves an archived execution, with its sub-workflow executions, node executions, events and notes, back into the database and deletes its archive. Admin only.
+//	@Tags			admin
+//	@Produce		json
+//	@Param			execution_id	path		string					true	"Execution ID"	format(uuid)
+//	@Success		200				{object}	models.ExecutionArchive	"Archive the execution was restored from"
+//	@Failure		400				{object}	APIError				"Invalid execution ID"
+//	@Failure		403				{object}	APIError				"Admin access required"
+//	@Failure		404				{object}	APIError				"Execution archive not found"
+//	@Failure		500				{object}	APIError				"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/admin/archives/{execution_id}/restore [post]
+func (h *ArchiveHandlers) HandleRestoreArchive(c *gin.Context) {
+	executionID, err := uuid.Parse(c.Param("execution_id"))
+	if err != nil {
+		respondAPIError(c, ErrInvalidID)
+		return
+	}
+
+	archive, err := h.archiver.Restore(c.Request.Context(), executionID)
+	if err != nil {
+		h.logger.Error("Failed to restore execution", "error", err, "execution_id", executionID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	adminID, _ := GetUserID(c)
+	h.logger.Info("Execution restored", "execution_id", executionID, "admin_id", adminID, "request_id", GetRequestID(c))
+	respondJSON(c, http.StatusOK, archive)
+}
+
+// HandleRunArchive runs an archive pass now
+//
+//	@Summary		Run execution archive
+//	@Description	Archives the executions past the retention period now instead of waiting for the next scheduled run. Admin only.
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{object}	coldstorage.ExecutionArchiveResult	"Archive run result"
+//	@Failure		403	{object}	APIError							"Admin access required"
+//	@Failure		409	{object}	APIError							"An archive run is already in progress"
+//	@Failure		500	{object}	APIError							"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/admin/archives/run [post]
+func (h *ArchiveHandlers) HandleRunArchive(c *gin.Context) {
+	result, err := h.archiver.RunOnce(c.Request.Context())
+	if errors.Is(err, coldstorage.ErrExecutionArchiveInProgress) {
+		respondAPIError(c, NewAPIError("ARCHIVE_IN_PROGRESS", "An execution archive run is already in progress", http.StatusConflict))
+		return
+	}
+	if err != nil {
+		h.logger.Error("Execution archive run failed", "error", err, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	respondJSON(c, http.StatusOK, result)
+}
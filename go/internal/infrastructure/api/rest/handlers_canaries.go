@@ -0,0 +1,213 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smilemakc/mbflow/go/internal/application/canary"
+	"github.com/smilemakc/mbflow/go/internal/application/maintenance"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// CanaryHandlers handles canary (synthetic monitoring) endpoints (admin only)
+type CanaryHandlers struct {
+	service *canary.Service
+	logger  *logger.Logger
+}
+
+// NewCanaryHandlers creates a new CanaryHandlers instance
+func NewCanaryHandlers(service *canary.Service, log *logger.Logger) *CanaryHandlers {
+	return &CanaryHandlers{
+		service: service,
+		logger:  log,
+	}
+}
+
+// SaveCanaryRequest represents a request to create or update a canary
+type SaveCanaryRequest struct {
+	Schedule      string                   `json:"schedule" binding:"required"`
+	Input         map[string]any           `json:"input"`
+	Assertions    []models.CanaryAssertion `json:"assertions"`
+	MaxDurationMs int64                    `json:"max_duration_ms"`
+	RunbookURL    string                   `json:"runbook_url"`
+	Enabled       *bool                    `json:"enabled"`
+}
+
+// HandleListCanaries handles GET /api/v1/admin/canaries
+func (h *CanaryHandlers) HandleListCanaries(c *gin.Context) {
+	canaries, err := h.service.List(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to list canaries", "error", err, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"canaries": canaries,
+		"total":    len(canaries),
+	})
+}
+
+// HandleGetCanary handles GET /api/v1/admin/canaries/:workflow_id
+func (h *CanaryHandlers) HandleGetCanary(c *gin.Context) {
+	workflowID, ok := getParam(c, "workflow_id")
+	if !ok {
+		return
+	}
+
+	result, err := h.service.Get(c.Request.Context(), workflowID)
+	if err != nil {
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+
+	respondJSON(c, http.StatusOK, result)
+}
+
+// HandleSaveCanary marks a workflow as a canary or updates its definition
+// PUT /api/v1/admin/canaries/:workflow_id
+func (h *CanaryHandlers) HandleSaveCanary(c *gin.Context) {
+	workflowID, ok := getParam(c, "workflow_id")
+	if !ok {
+		return
+	}
+
+	var req SaveCanaryRequest
+	if err := bindJSON(c, &req); err != nil {
+		return
+	}
+
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	result, err := h.service.Save(c.Request.Context(), &models.Canary{
+		WorkflowID:    workflowID,
+		Schedule:      req.Schedule,
+		Input:         req.Input,
+		Assertions:    req.Assertions,
+		MaxDurationMs: req.MaxDurationMs,
+		RunbookURL:    req.RunbookURL,
+		Enabled:       enabled,
+	})
+	if err != nil {
+		if errors.Is(err, canary.ErrInvalidSchedule) {
+			respondAPIError(c, NewAPIError("INVALID_SCHEDULE", err.Error(), http.StatusBadRequest))
+			return
+		}
+		h.logger.Error("Failed to save canary", "error", err, "workflow_id", workflowID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+
+	adminID, _ := GetUserID(c)
+	h.logger.Info("Canary saved", "workflow_id", workflowID, "schedule", result.Schedule, "enabled", result.Enabled, "admin_id", adminID)
+
+	respondJSON(c, http.StatusOK, result)
+}
+
+// HandleDeleteCanary handles DELETE /api/v1/admin/canaries/:workflow_id
+func (h *CanaryHandlers) HandleDeleteCanary(c *gin.Context) {
+	workflowID, ok := getParam(c, "workflow_id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), workflowID); err != nil {
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// HandleRunCanary runs a canary immediately and returns the run result
+// POST /api/v1/admin/canaries/:workflow_id/run
+func (h *CanaryHandlers) HandleRunCanary(c *gin.Context) {
+	workflowID, ok := getParam(c, "workflow_id")
+	if !ok {
+		return
+	}
+
+	run, err := h.service.Run(c.Request.Context(), workflowID)
+	if err != nil {
+		if errors.Is(err, canary.ErrRunInProgress) {
+			respondAPIError(c, NewAPIError("CANARY_RUN_IN_PROGRESS", err.Error(), http.StatusConflict))
+			return
+		}
+		h.logger.Error("Failed to run canary", "error", err, "workflow_id", workflowID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+
+	respondJSON(c, http.StatusOK, run)
+}
+
+// HandleListCanaryRuns handles GET /api/v1/admin/canaries/:workflow_id/runs
+func (h *CanaryHandlers) HandleListCanaryRuns(c *gin.Context) {
+	workflowID, ok := getParam(c, "workflow_id")
+	if !ok {
+		return
+	}
+
+	limit := getQueryInt(c, "limit", 20)
+	if limit < 1 || limit > 200 {
+		limit = 20
+	}
+
+	runs, err := h.service.Runs(c.Request.Context(), workflowID, limit)
+	if err != nil {
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"runs":  runs,
+		"total": len(runs),
+	})
+}
+
+// AdminOverviewHandlers serves the admin overview (system health at a glance)
+type AdminOverviewHandlers struct {
+	canaries *canary.Service
+	readOnly *maintenance.ReadOnlyMode
+	logger   *logger.Logger
+}
+
+// NewAdminOverviewHandlers creates a new AdminOverviewHandlers instance
+func NewAdminOverviewHandlers(canaries *canary.Service, readOnly *maintenance.ReadOnlyMode, log *logger.Logger) *AdminOverviewHandlers {
+	return &AdminOverviewHandlers{
+		canaries: canaries,
+		readOnly: readOnly,
+		logger:   log,
+	}
+}
+
+// AdminOverviewResponse is the response of GET /api/v1/admin/overview
+type AdminOverviewResponse struct {
+	ReadOnly maintenance.ReadOnlyStatus `json:"read_only"`
+	Canaries *models.CanarySummary      `json:"canaries"`
+}
+
+// HandleGetOverview handles GET /api/v1/admin/overview
+func (h *AdminOverviewHandlers) HandleGetOverview(c *gin.Context) {
+	resp := AdminOverviewResponse{
+		ReadOnly: h.readOnly.Status(),
+	}
+
+	if h.canaries != nil {
+		summary, err := h.canaries.Summary(c.Request.Context())
+		if err != nil {
+			h.logger.Error("Failed to summarize canaries", "error", err, "request_id", GetRequestID(c))
+			respondAPIErrorWithRequestID(c, err)
+			return
+		}
+		resp.Canaries = summary
+	}
+
+	respondJSON(c, http.StatusOK, resp)
+}
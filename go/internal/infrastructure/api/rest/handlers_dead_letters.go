@@ -0,0 +1,170 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/smilemakc/mbflow/go/internal/application/deadletter"
+	"github.com/smilemakc/mbflow/go/internal/application/serviceapi"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// DeadLetterHandlers handles the dead-letter queue of failed executions (admin only)
+type DeadLetterHandlers struct {
+	ops         *serviceapi.Operations
+	deadLetters *deadletter.Service
+	logger      *logger.Logger
+}
+
+// NewDeadLetterHandlers creates a new DeadLetterHandlers instance
+func NewDeadLetterHandlers(ops *serviceapi.Operations, service *deadletter.Service, log *logger.Logger) *DeadLetterHandlers {
+	return &DeadLetterHandlers{
+		ops:         ops,
+		deadLetters: service,
+		logger:      log,
+	}
+}
+
+// DeadLetterBatchRequest represents a request to retry or discard dead letters
+type DeadLetterBatchRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// HandleListDeadLetters lists the dead-letter queue
+//
+//	@Summary		List dead letters
+//	@Description	Lists the failed executions parked in the dead-letter queue, newest first. Admin only.
+//	@Tags			admin
+//	@Produce		json
+//	@Param			workflow_id	query		string	false	"Filter by workflow ID"	format(uuid)
+//	@Param			limit		query		int		false	"Maximum number of results"	default(50)
+//	@Param			offset		query		int		false	"Offset for pagination"		default(0)
+//	@Success		200			{object}	object{data=[]models.DeadLetter,total=int,limit=int,offset=int}	"Dead letters"
+//	@Failure		400			{object}	APIError														"Invalid workflow ID"
+//	@Failure		403			{object}	APIError														"Admin access required"
+//	@Failure		500			{object}	APIError														"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/admin/dead-letters [get]
+func (h *DeadLetterHandlers) HandleListDeadLetters(c *gin.Context) {
+	filter := models.DeadLetterFilter{
+		WorkflowID: c.Query("workflow_id"),
+		Limit:      getQueryInt(c, "limit", deadletter.DefaultPageSize),
+		Offset:     getQueryInt(c, "offset", 0),
+	}
+
+	deadLetters, total, err := h.deadLetters.List(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.Error("Failed to list dead letters", "error", err, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	respondList(c, http.StatusOK, deadLetters, total, filter.Limit, filter.Offset)
+}
+
+// HandleGetDeadLetter returns a dead letter with its execution
+//
+//	@Summary		Inspect dead letter
+//	@Description	Returns a dead letter with its failed execution, including the node executions. Admin only.
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path		string												true	"Dead letter ID"	format(uuid)
+//	@Success		200	{object}	object{dead_letter=models.DeadLetter,execution=models.Execution}	"Dead letter"
+//	@Failure		403	{object}	APIError											"Admin access required"
+//	@Failure		404	{object}	APIError											"Dead letter not found"
+//	@Security		BearerAuth
+//	@Router			/admin/dead-letters/{id} [get]
+func (h *DeadLetterHandlers) HandleGetDeadLetter(c *gin.Context) {
+	id, ok := getParam(c, "id")
+	if !ok {
+		return
+	}
+
+	deadLetter, err := h.deadLetters.Get(c.Request.Context(), id)
+	if err != nil {
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	executionID, err := uuid.Parse(deadLetter.ExecutionID)
+	if err != nil {
+		respondAPIError(c, ErrInvalidID)
+		return
+	}
+	execution, err := h.ops.GetExecution(c.Request.Context(), serviceapi.GetExecutionParams{ExecutionID: executionID})
+	if err != nil {
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"dead_letter": deadLetter,
+		"execution":   execution,
+	})
+}
+
+// HandleRetryDeadLetters retries dead letters
+//
+//	@Summary		Retry dead letters
+//	@Description	Resumes the executions of the given dead letters in the background from their failed nodes
+//	@Description	and takes them out of the queue; an execution that fails again is parked anew. Each dead
+//	@Description	letter has its own result, with an error if it was not retried. Admin only.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		DeadLetterBatchRequest									true	"Dead letter IDs"
+//	@Success		200		{object}	object{results=[]models.DeadLetterResult}				"Results"
+//	@Failure		400		{object}	APIError												"Invalid request"
+//	@Failure		403		{object}	APIError												"Admin access required"
+//	@Security		BearerAuth
+//	@Router			/admin/dead-letters/retry [post]
+func (h *DeadLetterHandlers) HandleRetryDeadLetters(c *gin.Context) {
+	var req DeadLetterBatchRequest
+	if err := bindJSON(c, &req); err != nil {
+		return
+	}
+
+	results, err := h.deadLetters.Retry(c.Request.Context(), req.IDs)
+	if err != nil {
+		respondAPIError(c, TranslateError(err))
+		return
+	}
+
+	adminID, _ := GetUserID(c)
+	h.logger.Info("Dead letters retried", "count", len(req.IDs), "admin_id", adminID, "request_id", GetRequestID(c))
+	respondJSON(c, http.StatusOK, gin.H{"results": results})
+}
+
+// HandleDiscardDeadLetters discards dead letters
+//
+//	@Summary		Discard dead letters
+//	@Description	Removes the given dead letters from the queue; their executions stay failed. Each dead
+//	@Description	letter has its own result, with an error if it was not discarded. Admin only.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		DeadLetterBatchRequest									true	"Dead letter IDs"
+//	@Success		200		{object}	object{results=[]models.DeadLetterResult}				"Results"
+//	@Failure		400		{object}	APIError												"Invalid request"
+//	@Failure		403		{object}	APIError												"Admin access required"
+//	@Security		BearerAuth
+//	@Router			/admin/dead-letters/discard [post]
+func (h *DeadLetterHandlers) HandleDiscardDeadLetters(c *gin.Context) {
+	var req DeadLetterBatchRequest
+	if err := bindJSON(c, &req); err != nil {
+		return
+	}
+
+	results, err := h.deadLetters.Discard(c.Request.Context(), req.IDs)
+	if err != nil {
+		respondAPIError(c, TranslateError(err))
+		return
+	}
+
+	adminID, _ := GetUserID(c)
+	h.logger.Info("Dead letters discarded", "count", len(req.IDs), "admin_id", adminID, "request_id", GetRequestID(c))
+	respondJSON(c, http.StatusOK, gin.H{"results": results})
+}
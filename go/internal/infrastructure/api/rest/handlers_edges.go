@@ -89,6 +89,7 @@ func (h *EdgeHandlers) HandleAddEdge(c *gin.Context) {
 		Loop         *struct {
 			MaxIterations int `json:"max_iterations"`
 		} `json:"loop,omitempty"`
+		Type     string         `json:"type,omitempty"`
 		Metadata map[string]any `json:"metadata,omitempty"`
 	}
 
@@ -175,6 +176,7 @@ func (h *EdgeHandlers) HandleAddEdge(c *gin.Context) {
 		FromNodeID:   req.From,
 		ToNodeID:     req.To,
 		SourceHandle: req.SourceHandle,
+		Type:         req.Type,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
@@ -193,6 +195,12 @@ func (h *EdgeHandlers) HandleAddEdge(c *gin.Context) {
 		}
 	}
 
+	// Validate edge type against the rest of the edge
+	if err := storagemodels.EdgeModelToDomain(edgeModel).Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	if err := h.workflowRepo.CreateEdge(c.Request.Context(), edgeModel); err != nil {
 		h.logger.Error("Failed to create edge", "error", err, "workflow_id", workflowUUID, "edge_id", req.ID, "from", req.From, "to", req.To)
 
@@ -340,6 +348,7 @@ func (h *EdgeHandlers) HandleUpdateEdge(c *gin.Context) {
 		Loop         *struct {
 			MaxIterations int `json:"max_iterations"`
 		} `json:"loop,omitempty"`
+		Type     *string        `json:"type,omitempty"`
 		Metadata map[string]any `json:"metadata,omitempty"`
 	}
 
@@ -450,6 +459,17 @@ func (h *EdgeHandlers) HandleUpdateEdge(c *gin.Context) {
 		}
 	}
 
+	// Update edge type
+	if req.Type != nil {
+		edgeModel.Type = *req.Type
+	}
+
+	// Validate edge type against the rest of the edge
+	if err := storagemodels.EdgeModelToDomain(edgeModel).Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	if err := h.workflowRepo.UpdateEdge(c.Request.Context(), edgeModel); err != nil {
 		h.logger.Error("Failed to update edge", "error", err, "workflow_id", workflowUUID, "edge_id", edgeID)
 		respondError(c, http.StatusInternalServerError, err.Error())
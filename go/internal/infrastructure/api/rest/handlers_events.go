@@ -0,0 +1,79 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smilemakc/mbflow/go/internal/application/serviceapi"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+)
+
+// maxEventPayloadSize caps the body of a delivered event.
+const maxEventPayloadSize = 1 << 20
+
+// EventHandlers delivers external events to executions waiting for them in wait_for_event nodes
+type EventHandlers struct {
+	ops    *serviceapi.Operations
+	logger *logger.Logger
+}
+
+// NewEventHandlers creates a new EventHandlers instance
+func NewEventHandlers(ops *serviceapi.Operations, log *logger.Logger) *EventHandlers {
+	return &EventHandlers{ops: ops, logger: log}
+}
+
+// HandleDeliverEvent delivers an event to the executions waiting for its correlation key
+//
+//	@Summary		Deliver event
+//	@Description	Resumes the executions whose wait_for_event nodes wait for the correlation key; the request body becomes the node's event.
+//	@Description	Only executions run in the workspace of the X-MBFlow-Workspace-ID header receive the event, and of those only the ones
+//	@Description	the caller owns or runs, unless the caller is an admin. Other executions are left alone as if they did not wait.
+//	@Description	JSON bodies are delivered parsed, other bodies as a string. Events no execution waits for are dropped.
+//	@Tags			events
+//	@Accept			json
+//	@Produce		json
+//	@Param			correlation_key			path		string						true	"Correlation key"
+//	@Param			X-MBFlow-Workspace-ID	header		string						false	"Workspace of the waiting executions"
+//	@Param			event					body		object						false	"Event payload"
+//	@Success		202						{object}	object{delivered=int}		"Number of executions resumed by the event"
+//	@Failure		400						{object}	APIError					"Invalid request"
+//	@Failure		401						{object}	APIError					"Not authenticated"
+//	@Failure		500						{object}	APIError					"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/events/{correlation_key} [post]
+func (h *EventHandlers) HandleDeliverEvent(c *gin.Context) {
+	key := c.Param("correlation_key")
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxEventPayloadSize+1))
+	if err != nil {
+		respondAPIError(c, ErrInvalidParameter)
+		return
+	}
+	if len(body) > maxEventPayloadSize {
+		respondAPIError(c, NewAPIError("EVENT_TOO_LARGE", "event payload exceeds 1 MB limit", http.StatusRequestEntityTooLarge))
+		return
+	}
+
+	var payload any
+	if len(body) > 0 && json.Unmarshal(body, &payload) != nil {
+		payload = string(body)
+	}
+
+	userID, _ := GetUserID(c)
+	delivered, err := h.ops.DeliverEvent(c.Request.Context(), serviceapi.DeliverEventParams{
+		CorrelationKey: key,
+		Payload:        payload,
+		WorkspaceID:    c.GetHeader(executor.HeaderWorkspaceID),
+		UserID:         userID,
+		IsAdmin:        IsAdmin(c),
+	})
+	if err != nil {
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"delivered": delivered})
+}
@@ -0,0 +1,132 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/smilemakc/mbflow/go/internal/application/serviceapi"
+)
+
+// ContinueFromBreakpointsRequest represents a request to continue an execution stopped at breakpoints
+type ContinueFromBreakpointsRequest struct {
+	Inputs map[string]map[string]any `json:"inputs,omitempty"`
+}
+
+// HandleListBreakpoints lists the breakpoints a debug execution stopped at
+//
+//	@Summary		List execution breakpoints
+//	@Description	Lists the breakpoint nodes a paused debug execution stopped at, with the input and config each would run with.
+//	@Tags			executions
+//	@Produce		json
+//	@Param			id	path		string												true	"Execution ID"	format(uuid)
+//	@Success		200	{object}	object{breakpoints=[]models.Breakpoint,total=int}	"Execution breakpoints"
+//	@Failure		400	{object}	APIError											"Invalid execution ID"
+//	@Failure		404	{object}	APIError											"Execution not found"
+//	@Failure		409	{object}	APIError											"Execution not stopped at a breakpoint"
+//	@Security		BearerAuth
+//	@Router			/executions/{id}/breakpoints [get]
+func (h *ExecutionHandlers) HandleListBreakpoints(c *gin.Context) {
+	executionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondAPIError(c, ErrInvalidID)
+		return
+	}
+
+	breakpoints, err := h.ops.ListBreakpoints(c.Request.Context(), serviceapi.ListBreakpointsParams{ExecutionID: executionID})
+	if err != nil {
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"breakpoints": breakpoints,
+		"total":       len(breakpoints),
+	})
+}
+
+// HandleContinueFromBreakpoints continues a debug execution stopped at breakpoints
+//
+//	@Summary		Continue from breakpoints
+//	@Description	Runs the nodes a debug execution stopped at and continues it in the background until it finishes or reaches another breakpoint.
+//	@Description	Inputs, keyed by node ID, replace the input of those nodes; the others run with the input they stopped with.
+//	@Description	Only admins, the user it runs for and the owner of its workflow may continue it.
+//	@Tags			executions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Execution ID"	format(uuid)
+//	@Param			request	body		ContinueFromBreakpointsRequest	false	"Replacement inputs"
+//	@Success		202		{object}	models.Execution				"Execution continued"
+//	@Failure		400		{object}	APIError						"Invalid request"
+//	@Failure		401		{object}	APIError						"Not authenticated"
+//	@Failure		403		{object}	APIError						"Not allowed to continue the execution"
+//	@Failure		409		{object}	APIError						"Execution not stopped at a breakpoint"
+//	@Security		BearerAuth
+//	@Router			/executions/{id}/breakpoints/continue [post]
+func (h *ExecutionHandlers) HandleContinueFromBreakpoints(c *gin.Context) {
+	executionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondAPIError(c, ErrInvalidID)
+		return
+	}
+
+	var req ContinueFromBreakpointsRequest
+	if c.Request.ContentLength != 0 {
+		if err := bindJSON(c, &req); err != nil {
+			return
+		}
+	}
+
+	userID, _ := GetUserID(c)
+	execution, err := h.ops.ContinueFromBreakpoints(c.Request.Context(), serviceapi.ContinueFromBreakpointsParams{
+		ExecutionID: executionID,
+		Inputs:      req.Inputs,
+		UserID:      userID,
+		IsAdmin:     IsAdmin(c),
+	})
+	if err != nil {
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	h.logger.Info("Execution continued from breakpoints", "execution_id", executionID, "user_id", userID, "request_id", GetRequestID(c))
+	respondJSON(c, http.StatusAccepted, execution)
+}
+
+// HandleAbortAtBreakpoints aborts a debug execution stopped at breakpoints
+//
+//	@Summary		Abort at breakpoints
+//	@Description	Cancels a debug execution stopped at breakpoints; the nodes it stopped at do not run. Only admins,
+//	@Description	the user it runs for and the owner of its workflow may abort it.
+//	@Tags			executions
+//	@Produce		json
+//	@Param			id	path		string				true	"Execution ID"	format(uuid)
+//	@Success		200	{object}	models.Execution	"Execution aborted"
+//	@Failure		400	{object}	APIError			"Invalid execution ID"
+//	@Failure		401	{object}	APIError			"Not authenticated"
+//	@Failure		403	{object}	APIError			"Not allowed to abort the execution"
+//	@Failure		409	{object}	APIError			"Execution not stopped at a breakpoint"
+//	@Security		BearerAuth
+//	@Router			/executions/{id}/breakpoints/abort [post]
+func (h *ExecutionHandlers) HandleAbortAtBreakpoints(c *gin.Context) {
+	executionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondAPIError(c, ErrInvalidID)
+		return
+	}
+
+	userID, _ := GetUserID(c)
+	execution, err := h.ops.AbortAtBreakpoints(c.Request.Context(), serviceapi.AbortAtBreakpointsParams{
+		ExecutionID: executionID,
+		UserID:      userID,
+		IsAdmin:     IsAdmin(c),
+	})
+	if err != nil {
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	h.logger.Info("Execution aborted at breakpoints", "execution_id", executionID, "user_id", userID, "request_id", GetRequestID(c))
+	respondJSON(c, http.StatusOK, execution)
+}
@@ -0,0 +1,273 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/smilemakc/mbflow/go/internal/application/serviceapi"
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// ExecutionNoteHandlers handles notes users attach to executions and node executions
+type ExecutionNoteHandlers struct {
+	ops    *serviceapi.Operations
+	notes  repository.ExecutionNoteRepository
+	logger *logger.Logger
+}
+
+// NewExecutionNoteHandlers creates a new ExecutionNoteHandlers instance
+func NewExecutionNoteHandlers(ops *serviceapi.Operations, notes repository.ExecutionNoteRepository, log *logger.Logger) *ExecutionNoteHandlers {
+	return &ExecutionNoteHandlers{
+		ops:    ops,
+		notes:  notes,
+		logger: log,
+	}
+}
+
+// CreateExecutionNoteRequest represents a request to add a note to an execution
+type CreateExecutionNoteRequest struct {
+	Body   string `json:"body"`
+	NodeID string `json:"node_id,omitempty"`
+}
+
+// UpdateExecutionNoteRequest represents a request to edit a note
+type UpdateExecutionNoteRequest struct {
+	Body string `json:"body"`
+}
+
+// HandleListNotes lists the notes of an execution
+//
+//	@Summary		List execution notes
+//	@Description	Lists the notes attached to an execution, oldest first. With node_id only the notes on that node are returned.
+//	@Tags			executions
+//	@Produce		json
+//	@Param			id		path		string	true	"Execution ID"	format(uuid)
+//	@Param			node_id	query		string	false	"Filter by node ID"
+//	@Success		200		{object}	object{notes=[]models.ExecutionNote,total=int}	"Execution notes"
+//	@Failure		400		{object}	APIError										"Invalid execution ID"
+//	@Failure		404		{object}	APIError										"Execution not found"
+//	@Failure		500		{object}	APIError										"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/executions/{id}/notes [get]
+func (h *ExecutionNoteHandlers) HandleListNotes(c *gin.Context) {
+	execution, ok := h.getExecution(c)
+	if !ok {
+		return
+	}
+
+	notes, err := h.notes.ListByExecutionID(c.Request.Context(), execution.ID, c.Query("node_id"))
+	if err != nil {
+		h.logger.Error("Failed to list execution notes", "error", err, "execution_id", execution.ID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"notes": notes,
+		"total": len(notes),
+	})
+}
+
+// HandleCreateNote attaches a note to an execution or one of its node executions
+//
+//	@Summary		Add execution note
+//	@Description	Attaches a note to an execution. With node_id the note is about the execution of that node.
+//	@Tags			executions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Execution ID"	format(uuid)
+//	@Param			request	body		CreateExecutionNoteRequest	true	"Note"
+//	@Success		201		{object}	models.ExecutionNote		"Created note"
+//	@Failure		400		{object}	APIError					"Invalid request"
+//	@Failure		401		{object}	APIError					"Authentication required"
+//	@Failure		404		{object}	APIError					"Execution or node not found"
+//	@Failure		500		{object}	APIError					"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/executions/{id}/notes [post]
+func (h *ExecutionNoteHandlers) HandleCreateNote(c *gin.Context) {
+	var req CreateExecutionNoteRequest
+	if err := bindJSON(c, &req); err != nil {
+		return
+	}
+
+	execution, ok := h.getExecution(c)
+	if !ok {
+		return
+	}
+
+	if req.NodeID != "" && !hasNodeExecution(execution, req.NodeID) {
+		respondAPIError(c, NewAPIError("NODE_NOT_FOUND", "Node "+req.NodeID+" was not executed in this execution", http.StatusNotFound))
+		return
+	}
+
+	note := &models.ExecutionNote{
+		ExecutionID: execution.ID,
+		NodeID:      req.NodeID,
+		Body:        req.Body,
+	}
+	if err := note.Validate(); err != nil {
+		respondAPIError(c, TranslateError(err))
+		return
+	}
+
+	if user, ok := GetUser(c); ok {
+		note.AuthorID = user.ID
+		note.AuthorName = user.FullName
+		if note.AuthorName == "" {
+			note.AuthorName = user.Username
+		}
+	} else if userID, ok := GetUserID(c); ok {
+		note.AuthorID = userID
+	}
+
+	if err := h.notes.Create(c.Request.Context(), note); err != nil {
+		h.logger.Error("Failed to create execution note", "error", err, "execution_id", execution.ID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	h.logger.Info("Execution note created", "note_id", note.ID, "execution_id", execution.ID, "node_id", note.NodeID, "author_id", note.AuthorID)
+	respondJSON(c, http.StatusCreated, note)
+}
+
+// HandleUpdateNote edits the body of a note
+//
+//	@Summary		Edit execution note
+//	@Description	Replaces the body of a note. Only the author of the note or an admin may edit it.
+//	@Tags			executions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Execution ID"	format(uuid)
+//	@Param			note_id	path		string						true	"Note ID"		format(uuid)
+//	@Param			request	body		UpdateExecutionNoteRequest	true	"Note"
+//	@Success		200		{object}	models.ExecutionNote		"Updated note"
+//	@Failure		400		{object}	APIError					"Invalid request"
+//	@Failure		403		{object}	APIError					"Not the author of the note"
+//	@Failure		404		{object}	APIError					"Note not found"
+//	@Failure		500		{object}	APIError					"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/executions/{id}/notes/{note_id} [patch]
+func (h *ExecutionNoteHandlers) HandleUpdateNote(c *gin.Context) {
+	var req UpdateExecutionNoteRequest
+	if err := bindJSON(c, &req); err != nil {
+		return
+	}
+
+	note, ok := h.getOwnNote(c)
+	if !ok {
+		return
+	}
+
+	note.Body = req.Body
+	if err := note.Validate(); err != nil {
+		respondAPIError(c, TranslateError(err))
+		return
+	}
+
+	if err := h.notes.UpdateBody(c.Request.Context(), note); err != nil {
+		h.logger.Error("Failed to update execution note", "error", err, "note_id", note.ID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	respondJSON(c, http.StatusOK, note)
+}
+
+// HandleDeleteNote deletes a note
+//
+//	@Summary		Delete execution note
+//	@Description	Deletes a note. Only the author of the note or an admin may delete it.
+//	@Tags			executions
+//	@Param			id		path	string	true	"Execution ID"	format(uuid)
+//	@Param			note_id	path	string	true	"Note ID"		format(uuid)
+//	@Success		204		"Note deleted"
+//	@Failure		403		{object}	APIError	"Not the author of the note"
+//	@Failure		404		{object}	APIError	"Note not found"
+//	@Failure		500		{object}	APIError	"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/executions/{id}/notes/{note_id} [delete]
+func (h *ExecutionNoteHandlers) HandleDeleteNote(c *gin.Context) {
+	note, ok := h.getOwnNote(c)
+	if !ok {
+		return
+	}
+
+	if err := h.notes.Delete(c.Request.Context(), note.ID); err != nil {
+		h.logger.Error("Failed to delete execution note", "error", err, "note_id", note.ID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	userID, _ := GetUserID(c)
+	h.logger.Info("Execution note deleted", "note_id", note.ID, "execution_id", note.ExecutionID, "user_id", userID)
+	c.Status(http.StatusNoContent)
+}
+
+// getExecution loads the execution named by the id path parameter, responding with an error when it fails
+func (h *ExecutionNoteHandlers) getExecution(c *gin.Context) (*models.Execution, bool) {
+	executionID, ok := getParam(c, "id")
+	if !ok {
+		return nil, false
+	}
+
+	execUUID, err := uuid.Parse(executionID)
+	if err != nil {
+		respondAPIError(c, ErrInvalidID)
+		return nil, false
+	}
+
+	execution, err := h.ops.GetExecution(c.Request.Context(), serviceapi.GetExecutionParams{
+		ExecutionID: execUUID,
+	})
+	if err != nil {
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return nil, false
+	}
+
+	return execution, true
+}
+
+// getOwnNote loads the note named by the path parameters and checks that the caller
+// may change it: its author or an admin
+func (h *ExecutionNoteHandlers) getOwnNote(c *gin.Context) (*models.ExecutionNote, bool) {
+	executionID, ok := getParam(c, "id")
+	if !ok {
+		return nil, false
+	}
+	noteID, ok := getParam(c, "note_id")
+	if !ok {
+		return nil, false
+	}
+
+	note, err := h.notes.GetByID(c.Request.Context(), noteID)
+	if err != nil {
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return nil, false
+	}
+	if execUUID, err := uuid.Parse(executionID); err != nil || execUUID.String() != note.ExecutionID {
+		respondAPIError(c, TranslateError(models.ErrExecutionNoteNotFound))
+		return nil, false
+	}
+
+	userID, _ := GetUserID(c)
+	if !IsAdmin(c) && (userID == "" || userID != note.AuthorID) {
+		respondAPIError(c, ErrForbidden)
+		return nil, false
+	}
+
+	return note, true
+}
+
+// hasNodeExecution reports whether the node with the given logical ID ran in the execution
+func hasNodeExecution(execution *models.Execution, nodeID string) bool {
+	for _, ne := range execution.NodeExecutions {
+		if ne.NodeID == nodeID {
+			return true
+		}
+	}
+	return false
+}
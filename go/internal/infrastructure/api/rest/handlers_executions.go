@@ -1,11 +1,15 @@
 package rest
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/smilemakc/mbflow/go/internal/application/exporter"
 	"github.com/smilemakc/mbflow/go/internal/application/serviceapi"
 	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
 	"github.com/smilemakc/mbflow/go/pkg/models"
@@ -23,15 +27,25 @@ func NewExecutionHandlers(ops *serviceapi.Operations, log *logger.Logger) *Execu
 // HandleRunExecution starts a new workflow execution
 //
 //	@Summary		Start workflow execution
-//	@Description	Starts a new execution of the specified workflow with optional input parameters
+//	@Description	Starts a new execution of the specified workflow with optional input parameters.
+//	@Description	A launch profile supplies base input and options; request input and variables are layered on top.
+//	@Description	A selection runs only some nodes; the outputs of the nodes feeding them are given as boundary_outputs.
+//	@Description	Priority (low, normal, high, critical; default normal) ranks the execution when the engine is at its running limit: high-priority executions may preempt lower-priority ones, and queued executions are run by workers in priority order.
+//	@Description	A retried request with the same Idempotency-Key header returns the execution started first instead of starting another.
+//	@Description	Breakpoints (node IDs) start a debug execution that pauses before each of those nodes; see /executions/{id}/breakpoints.
+//	@Description	node_type_concurrency caps the nodes of each type running at once, sub-workflows included, e.g. {"llm": 3, "http": 20}.
+//	@Description	run_at (RFC 3339) or delay (e.g. 15m, 2h) schedules the execution: it is saved as pending with resume_at set to its start time and starts then, even if the server restarts meanwhile.
 //	@Tags			executions
 //	@Accept			json
 //	@Produce		json
 //	@Param			workflow_id	path		string												false	"Workflow ID (can also be provided in body)"	format(uuid)
-//	@Param			request		body		object{workflow_id=string,input=object,async=bool}	true	"Execution request"
+//	@Param			profile		query		string												false	"Launch profile name (can also be provided in body)"
+//	@Param			Idempotency-Key	header	string												false	"Key identifying the start; replays within the TTL return the original execution"
+//	@Param			request		body		object{workflow_id=string,input=object,profile=string,selection=models.NodeSelection,priority=string,breakpoints=[]string,run_at=string,delay=string,node_type_concurrency=object,async=bool}	true	"Execution request"
 //	@Success		202			{object}	models.Execution									"Started execution"
 //	@Failure		400			{object}	APIError											"Invalid request"
-//	@Failure		404			{object}	APIError											"Workflow not found"
+//	@Failure		404			{object}	APIError											"Workflow or launch profile not found"
+//	@Failure		409			{object}	APIError											"An execution with the idempotency key is still starting"
 //	@Failure		500			{object}	APIError											"Internal server error"
 //	@Security		BearerAuth
 //	@Router			/executions [post]
@@ -41,6 +55,13 @@ func (h *ExecutionHandlers) HandleRunExecution(c *gin.Context) {
 		WorkflowID string `json:"workflow_id"`
 		Input      map[string]any `json:"input"`
 		Variables  map[string]any `json:"variables,omitempty"`
+		Profile    string `json:"profile,omitempty"`
+		Selection  *models.NodeSelection `json:"selection,omitempty"`
+		Priority   string `json:"priority,omitempty"`
+		Breakpoints []string `json:"breakpoints,omitempty"`
+		RunAt      *time.Time `json:"run_at,omitempty"`
+		Delay      string     `json:"delay,omitempty"`
+		NodeTypeConcurrency map[string]int `json:"node_type_concurrency,omitempty"`
 		Async      bool   `json:"async"`
 		Webhooks   []struct {
 			URL     string            `json:"url"`
@@ -50,8 +71,11 @@ func (h *ExecutionHandlers) HandleRunExecution(c *gin.Context) {
 		} `json:"webhooks,omitempty"`
 	}
 
-	if err := bindJSON(c, &req); err != nil {
-		return
+	// A profile run needs no body: POST /workflows/{id}/execute?profile=nightly
+	if c.Request.ContentLength != 0 || c.Query("profile") == "" {
+		if err := bindJSON(c, &req); err != nil {
+			return
+		}
 	}
 
 	if workflowID := c.Param("workflow_id"); workflowID != "" {
@@ -63,10 +87,41 @@ func (h *ExecutionHandlers) HandleRunExecution(c *gin.Context) {
 		return
 	}
 
+	if profile := c.Query("profile"); profile != "" {
+		req.Profile = profile
+	}
+
+	priority, err := models.ParseExecutionPriority(req.Priority)
+	if err != nil {
+		respondAPIError(c, TranslateError(err))
+		return
+	}
+
+	var delay time.Duration
+	if req.Delay != "" {
+		if delay, err = time.ParseDuration(req.Delay); err != nil {
+			respondAPIError(c, NewAPIError("INVALID_SCHEDULE", "delay must be a duration such as 30s, 15m or 2h", http.StatusBadRequest))
+			return
+		}
+	}
+
 	params := serviceapi.StartExecutionParams{
-		WorkflowID: req.WorkflowID,
-		Input:      req.Input,
-		Variables:  req.Variables,
+		WorkflowID:  req.WorkflowID,
+		Input:       req.Input,
+		Variables:   req.Variables,
+		Profile:     req.Profile,
+		Selection:   req.Selection,
+		Priority:    priority,
+		Breakpoints: req.Breakpoints,
+		Delay:       delay,
+		NodeTypeConcurrency: req.NodeTypeConcurrency,
+		Propagation: executionPropagation(c),
+
+		IdempotencyKey: c.GetHeader(HeaderIdempotencyKey),
+	}
+
+	if req.RunAt != nil {
+		params.RunAt = *req.RunAt
 	}
 
 	if len(req.Webhooks) > 0 {
@@ -83,23 +138,29 @@ func (h *ExecutionHandlers) HandleRunExecution(c *gin.Context) {
 
 	execution, err := h.ops.StartExecution(c.Request.Context(), params)
 	if err != nil {
-		h.logger.Error("Failed to start workflow execution", "error", err, "workflow_id", req.WorkflowID, "request_id", GetRequestID(c))
+		h.logger.Error("Failed to start workflow execution", "error", err, "workflow_id", req.WorkflowID, "profile", req.Profile, "request_id", GetRequestID(c))
 		respondAPIErrorWithRequestID(c, TranslateError(err))
 		return
 	}
 
-	h.logger.Info("Workflow execution started", "execution_id", execution.ID, "workflow_id", req.WorkflowID, "request_id", GetRequestID(c))
+	if execution.ResumeAt != nil {
+		h.logger.Info("Workflow execution scheduled", "execution_id", execution.ID, "workflow_id", req.WorkflowID, "run_at", *execution.ResumeAt, "request_id", GetRequestID(c))
+	} else {
+		h.logger.Info("Workflow execution started", "execution_id", execution.ID, "workflow_id", req.WorkflowID, "request_id", GetRequestID(c))
+	}
 	respondJSON(c, http.StatusAccepted, execution)
 }
 
 // HandleGetExecution retrieves an execution by ID
 //
 //	@Summary		Get execution by ID
-//	@Description	Retrieves a specific workflow execution by its unique identifier
+//	@Description	Retrieves a specific workflow execution by its unique identifier.
+//	@Description	Node payloads of old executions may be archived to cold storage; pass full=true to load them.
 //	@Tags			executions
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		string				true	"Execution ID"	format(uuid)
+//	@Param			id		path		string				true	"Execution ID"	format(uuid)
+//	@Param			full	query		bool				false	"Load node payloads archived to cold storage"	default(false)
 //	@Success		200	{object}	models.Execution	"Execution details"
 //	@Failure		400	{object}	APIError			"Invalid execution ID"
 //	@Failure		404	{object}	APIError			"Execution not found"
@@ -122,6 +183,7 @@ func (h *ExecutionHandlers) HandleGetExecution(c *gin.Context) {
 
 	execution, err := h.ops.GetExecution(c.Request.Context(), serviceapi.GetExecutionParams{
 		ExecutionID: execUUID,
+		Full:        c.DefaultQuery("full", "false") == "true",
 	})
 	if err != nil {
 		h.logger.Error("Failed to find execution", "error", err, "execution_id", execUUID, "request_id", GetRequestID(c))
@@ -259,6 +321,69 @@ func (h *ExecutionHandlers) HandleGetNodeResult(c *gin.Context) {
 	respondJSON(c, http.StatusOK, nodeExec)
 }
 
+// HandleExportExecution exports node executions as a flat dataset for notebooks
+//
+//	@Summary		Export execution data
+//	@Description	Exports one row per node execution (ids, timings, inputs/outputs as JSON) in JSON Lines or Parquet format
+//	@Tags			executions
+//	@Produce		application/x-ndjson,application/vnd.apache.parquet
+//	@Param			id		path		string		true	"Execution ID"					format(uuid)
+//	@Param			format	query		string		false	"Export format (jsonl, parquet)"	default(jsonl)
+//	@Success		200		{file}		binary		"Execution dataset"
+//	@Failure		400		{object}	APIError	"Invalid execution ID or format"
+//	@Failure		404		{object}	APIError	"Execution not found"
+//	@Failure		500		{object}	APIError	"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/executions/{id}/export [get]
+func (h *ExecutionHandlers) HandleExportExecution(c *gin.Context) {
+	executionID := c.Param("id")
+	if executionID == "" {
+		respondAPIError(c, ErrMissingParameter)
+		return
+	}
+
+	execUUID, err := uuid.Parse(executionID)
+	if err != nil {
+		h.logger.Error("Invalid execution ID in ExportExecution", "error", err, "execution_id", executionID, "request_id", GetRequestID(c))
+		respondAPIError(c, ErrInvalidID)
+		return
+	}
+
+	format, err := exporter.ParseFormat(c.DefaultQuery("format", string(exporter.FormatJSONL)))
+	if err != nil {
+		respondAPIError(c, NewAPIError("INVALID_FORMAT", "Format must be 'jsonl' or 'parquet'", http.StatusBadRequest))
+		return
+	}
+
+	execution, err := h.ops.GetExecution(c.Request.Context(), serviceapi.GetExecutionParams{
+		ExecutionID: execUUID,
+		Full:        true,
+	})
+	if err != nil {
+		h.logger.Error("Failed to find execution for export", "error", err, "execution_id", execUUID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	rows, err := exporter.FlattenExecution(execution)
+	if err != nil {
+		h.logger.Error("Failed to flatten execution", "error", err, "execution_id", execUUID, "request_id", GetRequestID(c))
+		respondAPIError(c, NewAPIError("EXPORT_ERROR", "Failed to export execution", http.StatusInternalServerError))
+		return
+	}
+
+	// Encode fully before writing so encoding errors can still produce a JSON error response
+	var buf bytes.Buffer
+	if err := exporter.Write(&buf, format, rows); err != nil {
+		h.logger.Error("Failed to encode execution export", "error", err, "execution_id", execUUID, "format", format, "request_id", GetRequestID(c))
+		respondAPIError(c, NewAPIError("EXPORT_ERROR", "Failed to export execution", http.StatusInternalServerError))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"execution-%s.%s\"", execUUID, format))
+	c.Data(http.StatusOK, format.ContentType(), buf.Bytes())
+}
+
 const maxWorkflowSnapshotSize = 1_048_576
 
 func (h *ExecutionHandlers) HandleRunEphemeralExecution(c *gin.Context) {
@@ -345,8 +470,188 @@ func (h *ExecutionHandlers) HandleRunEphemeralExecution(c *gin.Context) {
 	respondJSON(c, status, execution)
 }
 
+// HandleCancelExecution cancels a running execution
+//
+//	@Summary		Cancel execution
+//	@Description	Aborts a running execution: in-flight HTTP requests and LLM calls of its running nodes are interrupted,
+//	@Description	the nodes are saved as cancelled with the output they produced so far, and a node.cancelled event is sent for each.
+//	@Description	The execution is saved as cancelled. Only admins, the user it runs for and the owner of its workflow
+//	@Description	may cancel it.
+//	@Tags			executions
+//	@Produce		json
+//	@Param			id	path		string					true	"Execution ID"	format(uuid)
+//	@Success		202	{object}	object{execution_id=string}	"Cancellation requested"
+//	@Failure		400	{object}	APIError				"Invalid execution ID"
+//	@Failure		401	{object}	APIError				"Not authenticated"
+//	@Failure		403	{object}	APIError				"Not allowed to cancel the execution"
+//	@Failure		409	{object}	APIError				"Execution not running on this instance"
+//	@Security		BearerAuth
+//	@Router			/executions/{id}/cancel [post]
 func (h *ExecutionHandlers) HandleCancelExecution(c *gin.Context) {
-	respondAPIError(c, NewAPIError("NOT_IMPLEMENTED", "execution cancellation not yet implemented", http.StatusNotImplemented))
+	executionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondAPIError(c, ErrInvalidID)
+		return
+	}
+
+	userID, _ := GetUserID(c)
+	if err := h.ops.CancelExecution(c.Request.Context(), serviceapi.CancelExecutionParams{
+		ExecutionID: executionID,
+		UserID:      userID,
+		IsAdmin:     IsAdmin(c),
+	}); err != nil {
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	h.logger.Info("Execution cancellation requested", "execution_id", executionID, "user_id", userID, "request_id", GetRequestID(c))
+	respondJSON(c, http.StatusAccepted, gin.H{"execution_id": executionID.String()})
+}
+
+// HandlePauseExecution pauses a running execution
+//
+//	@Summary		Pause execution
+//	@Description	Stops a running execution at the next node boundary: its running nodes finish and it is saved
+//	@Description	as paused with a checkpoint of its state until it is resumed. Only admins, the user it runs for
+//	@Description	and the owner of its workflow may pause it.
+//	@Tags			executions
+//	@Produce		json
+//	@Param			id	path		string					true	"Execution ID"	format(uuid)
+//	@Success		202	{object}	object{execution_id=string}	"Pause requested"
+//	@Failure		400	{object}	APIError				"Invalid execution ID"
+//	@Failure		401	{object}	APIError				"Not authenticated"
+//	@Failure		403	{object}	APIError				"Not allowed to pause the execution"
+//	@Failure		409	{object}	APIError				"Execution not running on this instance"
+//	@Security		BearerAuth
+//	@Router			/executions/{id}/pause [post]
+func (h *ExecutionHandlers) HandlePauseExecution(c *gin.Context) {
+	executionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondAPIError(c, ErrInvalidID)
+		return
+	}
+
+	userID, _ := GetUserID(c)
+	if err := h.ops.PauseExecution(c.Request.Context(), serviceapi.PauseExecutionParams{
+		ExecutionID: executionID,
+		UserID:      userID,
+		IsAdmin:     IsAdmin(c),
+	}); err != nil {
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	h.logger.Info("Execution pause requested", "execution_id", executionID, "user_id", userID, "request_id", GetRequestID(c))
+	respondJSON(c, http.StatusAccepted, gin.H{"execution_id": executionID.String()})
+}
+
+// HandleResumeExecution resumes a paused execution
+//
+//	@Summary		Resume execution
+//	@Description	Continues a paused execution from its checkpoint in the background. Only admins, the user it
+//	@Description	runs for and the owner of its workflow may resume it.
+//	@Tags			executions
+//	@Produce		json
+//	@Param			id	path		string				true	"Execution ID"	format(uuid)
+//	@Success		202	{object}	models.Execution	"Execution resumed"
+//	@Failure		400	{object}	APIError			"Invalid execution ID"
+//	@Failure		401	{object}	APIError			"Not authenticated"
+//	@Failure		403	{object}	APIError			"Not allowed to resume the execution"
+//	@Failure		409	{object}	APIError			"Execution not paused"
+//	@Security		BearerAuth
+//	@Router			/executions/{id}/resume [post]
+func (h *ExecutionHandlers) HandleResumeExecution(c *gin.Context) {
+	executionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondAPIError(c, ErrInvalidID)
+		return
+	}
+
+	userID, _ := GetUserID(c)
+	execution, err := h.ops.ResumeExecution(c.Request.Context(), serviceapi.ResumeExecutionParams{
+		ExecutionID: executionID,
+		UserID:      userID,
+		IsAdmin:     IsAdmin(c),
+	})
+	if err != nil {
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	h.logger.Info("Execution resumed", "execution_id", executionID, "user_id", userID, "request_id", GetRequestID(c))
+	respondJSON(c, http.StatusAccepted, execution)
+}
+
+// HandleResumeFailedExecution resumes a failed execution from its failed nodes
+//
+//	@Summary		Resume failed execution
+//	@Description	Continues a failed execution in the background: completed nodes keep their outputs and
+//	@Description	only the failed and pending nodes run again, with the current workflow. Only admins, the user it
+//	@Description	ran for and the owner of its workflow may resume it.
+//	@Tags			executions
+//	@Produce		json
+//	@Param			id	path		string				true	"Execution ID"	format(uuid)
+//	@Success		202	{object}	models.Execution	"Execution resumed"
+//	@Failure		400	{object}	APIError			"Invalid execution ID"
+//	@Failure		401	{object}	APIError			"Not authenticated"
+//	@Failure		403	{object}	APIError			"Not allowed to resume the execution"
+//	@Failure		409	{object}	APIError			"Execution has not failed"
+//	@Security		BearerAuth
+//	@Router			/executions/{id}/resume-failed [post]
+func (h *ExecutionHandlers) HandleResumeFailedExecution(c *gin.Context) {
+	executionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondAPIError(c, ErrInvalidID)
+		return
+	}
+
+	userID, _ := GetUserID(c)
+	execution, err := h.ops.ResumeFailedExecution(c.Request.Context(), serviceapi.ResumeFailedExecutionParams{
+		ExecutionID: executionID,
+		UserID:      userID,
+		IsAdmin:     IsAdmin(c),
+	})
+	if err != nil {
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	h.logger.Info("Execution resumed from failure", "execution_id", executionID, "user_id", userID, "request_id", GetRequestID(c))
+	respondJSON(c, http.StatusAccepted, execution)
+}
+
+// HandlePreemptExecution preempts a running execution
+//
+//	@Summary		Preempt execution
+//	@Description	Stops a running execution after its running nodes finish, to free capacity for urgent work.
+//	@Description	It is paused and requeued from a checkpoint, or cancelled, as the preemption policy says. Admin only.
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path		string					true	"Execution ID"	format(uuid)
+//	@Success		202	{object}	object{execution_id=string}	"Preemption requested"
+//	@Failure		400	{object}	APIError				"Invalid execution ID"
+//	@Failure		403	{object}	APIError				"Admin access required"
+//	@Failure		409	{object}	APIError				"Execution not running on this instance"
+//	@Security		BearerAuth
+//	@Router			/admin/executions/{id}/preempt [post]
+func (h *ExecutionHandlers) HandlePreemptExecution(c *gin.Context) {
+	executionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondAPIError(c, ErrInvalidID)
+		return
+	}
+
+	userID, _ := GetUserID(c)
+	if err := h.ops.PreemptExecution(c.Request.Context(), serviceapi.PreemptExecutionParams{
+		ExecutionID: executionID,
+		UserID:      userID,
+	}); err != nil {
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	h.logger.Info("Execution preemption requested", "execution_id", executionID, "user_id", userID, "request_id", GetRequestID(c))
+	respondJSON(c, http.StatusAccepted, gin.H{"execution_id": executionID.String()})
 }
 
 func (h *ExecutionHandlers) HandleRetryExecution(c *gin.Context) {
@@ -550,9 +550,9 @@ func TestHandlers_GetNodeResult_ExecutionNotFound(t *testing.T) {
 	testutil.AssertErrorResponse(t, w, http.StatusNotFound, "")
 }
 
-// ========== CANCEL EXECUTION TESTS (Placeholder) ==========
+// ========== CANCEL EXECUTION TESTS ==========
 
-func TestHandlers_CancelExecution_NotImplemented(t *testing.T) {
+func TestHandlers_CancelExecution_NotRunning(t *testing.T) {
 	t.Parallel()
 	_, router, _, cleanup := setupExecutionHandlersTest(t)
 	defer cleanup()
@@ -560,9 +560,7 @@ func TestHandlers_CancelExecution_NotImplemented(t *testing.T) {
 	randomID := uuid.New().String()
 	w := testutil.MakeRequest(t, router, "POST", fmt.Sprintf("/api/v1/executions/%s/cancel", randomID), nil)
 
-	// Check if endpoint is implemented
-	// Actual behavior depends on implementation
-	assert.NotEqual(t, http.StatusNotFound, w.Code, "Cancel endpoint should exist")
+	testutil.AssertErrorResponse(t, w, http.StatusConflict, "not running")
 }
 
 // ========== RETRY EXECUTION TESTS (Placeholder) ==========
@@ -3,16 +3,24 @@ package rest
 import (
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/smilemakc/mbflow/go/internal/application/filestorage"
 	"github.com/smilemakc/mbflow/go/internal/domain/repository"
 	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
 	"github.com/smilemakc/mbflow/go/pkg/models"
 )
 
+const (
+	defaultSignedURLExpiry = 15 * time.Minute
+	maxSignedURLExpiry     = 7 * 24 * time.Hour
+)
+
 type FileStorageHandlers struct {
 	resourceRepo repository.FileStorageRepository
 	fileService  *filestorage.ResourceFileService
@@ -276,7 +284,22 @@ func (h *FileStorageHandlers) DownloadFile(c *gin.Context) {
 		return
 	}
 
-	fileModel, reader, err := h.fileService.GetFile(c.Request.Context(), resourceID, fileID)
+	// The file is streamed through the API unless asked otherwise: mode=redirect sends the
+	// client to a signed URL when the backend supports it, mode=url returns the signed URL as JSON.
+	var fileModel *storagemodels.FileModel
+	if mode := getQuery(c, "mode", ""); mode == "redirect" || mode == "url" {
+		var done bool
+		if fileModel, done = h.respondSignedURL(c, resourceID, fileID, mode); done {
+			return
+		}
+	}
+
+	var reader io.ReadCloser
+	if fileModel != nil {
+		reader, err = h.fileService.OpenFile(c.Request.Context(), fileModel)
+	} else {
+		fileModel, reader, err = h.fileService.GetFile(c.Request.Context(), resourceID, fileID)
+	}
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			respondError(c, http.StatusNotFound, "file not found")
@@ -302,6 +325,54 @@ func (h *FileStorageHandlers) DownloadFile(c *gin.Context) {
 	c.DataFromReader(http.StatusOK, fileModel.Size, fileModel.MimeType, reader, nil)
 }
 
+// respondSignedURL answers the download request with a signed URL. When the backend cannot
+// sign URLs and the file should be streamed instead, it returns the file and false.
+func (h *FileStorageHandlers) respondSignedURL(c *gin.Context, resourceID, fileID, mode string) (*storagemodels.FileModel, bool) {
+	expires := defaultSignedURLExpiry
+	if seconds := getQueryInt(c, "expires_in", 0); seconds > 0 {
+		expires = time.Duration(seconds) * time.Second
+		if expires > maxSignedURLExpiry {
+			expires = maxSignedURLExpiry
+		}
+	}
+
+	fileModel, signedURL, err := h.fileService.GetDownloadURL(c.Request.Context(), resourceID, fileID, expires)
+	if err != nil {
+		switch {
+		case errors.Is(err, filestorage.ErrSignedURLNotSupported):
+			if mode == "url" {
+				respondError(c, http.StatusBadRequest, err.Error())
+				return nil, true
+			}
+			return fileModel, false
+		case strings.Contains(err.Error(), "not found"):
+			respondError(c, http.StatusNotFound, "file not found")
+		case strings.Contains(err.Error(), "expired"):
+			respondError(c, http.StatusGone, "file has expired")
+		case strings.Contains(err.Error(), "does not belong"):
+			respondError(c, http.StatusForbidden, "access denied")
+		default:
+			h.logger.Error("Failed to sign download URL", "error", err, "file_id", fileID)
+			respondError(c, http.StatusInternalServerError, "failed to generate download URL")
+		}
+		return nil, true
+	}
+
+	if mode == "url" {
+		respondJSON(c, http.StatusOK, gin.H{
+			"url":        signedURL,
+			"expires_at": time.Now().Add(expires),
+			"name":       fileModel.Name,
+			"mime_type":  fileModel.MimeType,
+			"size":       fileModel.Size,
+		})
+		return nil, true
+	}
+
+	c.Redirect(http.StatusTemporaryRedirect, signedURL)
+	return nil, true
+}
+
 func (h *FileStorageHandlers) DeleteFile(c *gin.Context) {
 	userID, ok := GetUserID(c)
 	if !ok {
@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -9,12 +10,14 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
 
 	"github.com/smilemakc/mbflow/go/internal/application/importer"
 	"github.com/smilemakc/mbflow/go/internal/domain/repository"
 	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
 	storagemodels "github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
 	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
 )
 
 // ImportHandlers provides HTTP handlers for workflow import/export endpoints.
@@ -50,14 +53,63 @@ type ImportResponse struct {
 	NodesCount int     `json:"nodes_count"`
 	EdgesCount int     `json:"edges_count"`
 	TriggerID  *string `json:"trigger_id,omitempty"`
+
+	// TriggerIDs lists the triggers created from the triggers list of the YAML.
+	TriggerIDs []string `json:"trigger_ids,omitempty"`
+
+	// Merged is true when the nodes were merged into an existing workflow.
+	Merged bool `json:"merged,omitempty"`
+
+	// RenamedIDs lists imported node/edge IDs that were renamed (old -> new).
+	RenamedIDs *models.IDRemapResult `json:"renamed_ids,omitempty"`
 }
 
 // HandleImportWorkflow handles POST /api/v1/workflows/import
 // Accepts YAML via multipart form file upload or raw YAML body.
+//
+// Query parameters:
+//   - target_workflow_id: merge the imported nodes and edges into an existing workflow
+//     instead of creating a new one (the imported triggers, if any, are ignored)
+//   - on_conflict: fail (default), suffix or map - how colliding node/edge IDs are resolved
+//
+// Multipart uploads may include an "id_map" field or file (YAML or JSON,
+// {"nodes": {"old": "new"}, "edges": {"old": "new"}}) with explicit renames.
 func (h *ImportHandlers) HandleImportWorkflow(c *gin.Context) {
 	var yamlData []byte
 	var err error
 
+	opts := importer.ImportOptions{
+		OnConflict: models.IDConflictStrategy(strings.ToLower(c.DefaultQuery("on_conflict", string(models.IDConflictFail)))),
+	}
+	if !opts.OnConflict.IsValid() {
+		respondAPIError(c, NewAPIError("INVALID_CONFLICT_STRATEGY",
+			"on_conflict must be 'fail', 'suffix' or 'map'", http.StatusBadRequest))
+		return
+	}
+
+	var target *storagemodels.WorkflowModel
+	if targetID := c.Query("target_workflow_id"); targetID != "" {
+		targetUUID, err := uuid.Parse(targetID)
+		if err != nil {
+			respondAPIError(c, ErrInvalidID)
+			return
+		}
+		target, err = h.workflowRepo.FindByIDWithRelations(c.Request.Context(), targetUUID)
+		if err != nil {
+			h.logger.Error("Failed to find target workflow", "error", err, "workflow_id", targetUUID, "request_id", GetRequestID(c))
+			respondAPIErrorWithRequestID(c, TranslateError(err))
+			return
+		}
+		opts.ExistingNodeIDs = make(map[string]bool, len(target.Nodes))
+		for _, node := range target.Nodes {
+			opts.ExistingNodeIDs[node.NodeID] = true
+		}
+		opts.ExistingEdgeIDs = make(map[string]bool, len(target.Edges))
+		for _, edge := range target.Edges {
+			opts.ExistingEdgeIDs[edge.EdgeID] = true
+		}
+	}
+
 	contentType := c.GetHeader("Content-Type")
 
 	// Handle multipart form file upload
@@ -66,6 +118,9 @@ func (h *ImportHandlers) HandleImportWorkflow(c *gin.Context) {
 		if err != nil {
 			return // Error already responded
 		}
+		if opts.IDMap, err = h.readIDMap(c); err != nil {
+			return // Error already responded
+		}
 	} else if strings.Contains(contentType, "yaml") || strings.Contains(contentType, "text/plain") {
 		// Handle raw YAML body
 		yamlData, err = io.ReadAll(c.Request.Body)
@@ -94,23 +149,33 @@ func (h *ImportHandlers) HandleImportWorkflow(c *gin.Context) {
 	}
 
 	// Import workflow
-	result, err := h.importer.ImportFromYAML(cleanData)
+	result, err := h.importer.ImportFromYAMLWithOptions(cleanData, opts)
 	if err != nil {
 		h.logger.Error("Failed to import workflow", "error", err, "request_id", GetRequestID(c))
+		var conflictErr *models.IDConflictError
+		if errors.As(err, &conflictErr) {
+			respondAPIError(c, NewAPIError("ID_CONFLICT", err.Error(), http.StatusConflict))
+			return
+		}
 		respondAPIError(c, NewAPIError("IMPORT_ERROR", err.Error(), http.StatusBadRequest))
 		return
 	}
 
+	if target != nil {
+		h.mergeIntoWorkflow(c, target, result)
+		return
+	}
+
 	// Convert to storage model and save
 	workflowModel, err := h.saveWorkflow(c, result)
 	if err != nil {
 		return // Error already responded
 	}
 
-	// Save trigger if present
+	// Save triggers if present
 	var triggerID *string
 	if result.Trigger != nil {
-		tid, err := h.saveTrigger(c, result, workflowModel.ID)
+		tid, err := h.saveTrigger(c, result.Trigger, workflowModel.ID)
 		if err != nil {
 			// Rollback workflow creation
 			_ = h.workflowRepo.HardDelete(c.Request.Context(), workflowModel.ID)
@@ -119,6 +184,15 @@ func (h *ImportHandlers) HandleImportWorkflow(c *gin.Context) {
 		tidStr := tid.String()
 		triggerID = &tidStr
 	}
+	var triggerIDs []string
+	for _, trigger := range result.Triggers {
+		tid, err := h.saveTrigger(c, trigger, workflowModel.ID)
+		if err != nil {
+			_ = h.workflowRepo.HardDelete(c.Request.Context(), workflowModel.ID)
+			return // Error already responded
+		}
+		triggerIDs = append(triggerIDs, tid.String())
+	}
 
 	// Return response
 	response := ImportResponse{
@@ -128,6 +202,8 @@ func (h *ImportHandlers) HandleImportWorkflow(c *gin.Context) {
 		NodesCount: result.NodesCount,
 		EdgesCount: result.EdgesCount,
 		TriggerID:  triggerID,
+		TriggerIDs: triggerIDs,
+		RenamedIDs: result.RenamedIDs,
 	}
 
 	h.logger.Info("Workflow imported successfully",
@@ -168,21 +244,78 @@ func (h *ImportHandlers) handleMultipartUpload(c *gin.Context) ([]byte, error) {
 	return data, nil
 }
 
+// readIDMap reads the optional "id_map" multipart field or file.
+func (h *ImportHandlers) readIDMap(c *gin.Context) (models.IDMap, error) {
+	var idMap models.IDMap
+
+	data := []byte(c.Request.FormValue("id_map"))
+	if len(data) == 0 {
+		file, _, err := c.Request.FormFile("id_map")
+		if err != nil {
+			return idMap, nil // id_map is optional
+		}
+		defer file.Close()
+		if data, err = io.ReadAll(file); err != nil {
+			respondAPIError(c, NewAPIError("READ_ERROR", "Failed to read id_map", http.StatusBadRequest))
+			return idMap, err
+		}
+	}
+
+	// YAML is a superset of JSON, so one decoder handles both formats.
+	if err := yaml.Unmarshal(data, &idMap); err != nil {
+		respondAPIError(c, NewAPIError("INVALID_ID_MAP", fmt.Sprintf("Failed to parse id_map: %v", err), http.StatusBadRequest))
+		return idMap, err
+	}
+	return idMap, nil
+}
+
+// mergeIntoWorkflow appends the imported nodes and edges to an existing workflow.
+func (h *ImportHandlers) mergeIntoWorkflow(c *gin.Context, target *storagemodels.WorkflowModel, result *importer.ImportResult) {
+	nodes, edges := h.convertNodesAndEdges(result.Workflow, target.ID)
+	target.Nodes = append(target.Nodes, nodes...)
+	target.Edges = append(target.Edges, edges...)
+
+	if err := h.workflowRepo.Update(c.Request.Context(), target); err != nil {
+		h.logger.Error("Failed to merge imported workflow", "error", err, "workflow_id", target.ID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	h.logger.Info("Workflow merged successfully",
+		"workflow_id", target.ID,
+		"nodes_count", result.NodesCount,
+		"edges_count", result.EdgesCount,
+		"renamed", result.RenamedIDs != nil && result.RenamedIDs.Renamed(),
+		"request_id", GetRequestID(c))
+
+	respondJSON(c, http.StatusOK, ImportResponse{
+		WorkflowID: target.ID.String(),
+		Name:       target.Name,
+		Status:     target.Status,
+		NodesCount: result.NodesCount,
+		EdgesCount: result.EdgesCount,
+		Merged:     true,
+		RenamedIDs: result.RenamedIDs,
+	})
+}
+
 // saveWorkflow converts the import result to storage model and saves it.
 func (h *ImportHandlers) saveWorkflow(c *gin.Context, result *importer.ImportResult) (*storagemodels.WorkflowModel, error) {
 	workflow := result.Workflow
 	now := time.Now()
 
 	workflowModel := &storagemodels.WorkflowModel{
-		ID:          uuid.New(),
-		Name:        workflow.Name,
-		Description: workflow.Description,
-		Status:      "draft",
-		Version:     workflow.Version,
-		Variables:   storagemodels.JSONBMap(workflow.Variables),
-		Metadata:    storagemodels.JSONBMap(workflow.Metadata),
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		ID:             uuid.New(),
+		Name:           workflow.Name,
+		Description:    workflow.Description,
+		Status:         "draft",
+		Version:        workflow.Version,
+		Variables:      storagemodels.JSONBMap(workflow.Variables),
+		LaunchProfiles: storagemodels.LaunchProfilesToStorage(workflow.LaunchProfiles),
+		Fixtures:       storagemodels.FixturesToStorage(workflow.Fixtures),
+		Metadata:       storagemodels.JSONBMap(workflow.Metadata),
+		CreatedAt:      now,
+		UpdatedAt:      now,
 	}
 
 	// Set created_by if user is authenticated
@@ -190,13 +323,28 @@ func (h *ImportHandlers) saveWorkflow(c *gin.Context, result *importer.ImportRes
 		workflowModel.CreatedBy = &userID
 	}
 
-	// Convert nodes
-	workflowModel.Nodes = make([]*storagemodels.NodeModel, 0, len(workflow.Nodes))
+	workflowModel.Nodes, workflowModel.Edges = h.convertNodesAndEdges(workflow, workflowModel.ID)
+
+	// Save workflow
+	if err := h.workflowRepo.Create(c.Request.Context(), workflowModel); err != nil {
+		h.logger.Error("Failed to create workflow", "error", err, "workflow_name", workflow.Name, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return nil, err
+	}
+
+	return workflowModel, nil
+}
+
+// convertNodesAndEdges converts imported nodes and edges to storage models of the given workflow.
+func (h *ImportHandlers) convertNodesAndEdges(workflow *models.Workflow, workflowID uuid.UUID) ([]*storagemodels.NodeModel, []*storagemodels.EdgeModel) {
+	now := time.Now()
+
+	nodes := make([]*storagemodels.NodeModel, 0, len(workflow.Nodes))
 	for _, node := range workflow.Nodes {
 		nodeModel := &storagemodels.NodeModel{
 			ID:         uuid.New(),
 			NodeID:     node.ID,
-			WorkflowID: workflowModel.ID,
+			WorkflowID: workflowID,
 			Name:       node.Name,
 			Type:       node.Type,
 			Config:     storagemodels.JSONBMap(node.Config),
@@ -209,18 +357,18 @@ func (h *ImportHandlers) saveWorkflow(c *gin.Context, result *importer.ImportRes
 				"y": node.Position.Y,
 			}
 		}
-		workflowModel.Nodes = append(workflowModel.Nodes, nodeModel)
+		nodes = append(nodes, nodeModel)
 	}
 
-	// Convert edges
-	workflowModel.Edges = make([]*storagemodels.EdgeModel, 0, len(workflow.Edges))
+	edges := make([]*storagemodels.EdgeModel, 0, len(workflow.Edges))
 	for _, edge := range workflow.Edges {
 		edgeModel := &storagemodels.EdgeModel{
 			ID:         uuid.New(),
 			EdgeID:     edge.ID,
-			WorkflowID: workflowModel.ID,
+			WorkflowID: workflowID,
 			FromNodeID: edge.From,
 			ToNodeID:   edge.To,
+			Type:       string(edge.Type),
 			CreatedAt:  now,
 			UpdatedAt:  now,
 		}
@@ -235,22 +383,14 @@ func (h *ImportHandlers) saveWorkflow(c *gin.Context, result *importer.ImportRes
 			}
 			edgeModel.Condition["source_handle"] = edge.SourceHandle
 		}
-		workflowModel.Edges = append(workflowModel.Edges, edgeModel)
+		edges = append(edges, edgeModel)
 	}
 
-	// Save workflow
-	if err := h.workflowRepo.Create(c.Request.Context(), workflowModel); err != nil {
-		h.logger.Error("Failed to create workflow", "error", err, "workflow_name", workflow.Name, "request_id", GetRequestID(c))
-		respondAPIErrorWithRequestID(c, TranslateError(err))
-		return nil, err
-	}
-
-	return workflowModel, nil
+	return nodes, edges
 }
 
-// saveTrigger saves the trigger for the imported workflow.
-func (h *ImportHandlers) saveTrigger(c *gin.Context, result *importer.ImportResult, workflowID uuid.UUID) (uuid.UUID, error) {
-	trigger := result.Trigger
+// saveTrigger saves a trigger of the imported workflow.
+func (h *ImportHandlers) saveTrigger(c *gin.Context, trigger *models.Trigger, workflowID uuid.UUID) (uuid.UUID, error) {
 	now := time.Now()
 
 	// Store name and description in config
@@ -0,0 +1,97 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smilemakc/mbflow/go/internal/application/inbox"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// InboxHandlers handles the work inbox, the queue of work awaiting human action across executions
+type InboxHandlers struct {
+	inbox  *inbox.Service
+	logger *logger.Logger
+}
+
+// NewInboxHandlers creates a new InboxHandlers instance
+func NewInboxHandlers(service *inbox.Service, log *logger.Logger) *InboxHandlers {
+	return &InboxHandlers{
+		inbox:  service,
+		logger: log,
+	}
+}
+
+// HandleListInbox lists the work awaiting human action
+//
+//	@Summary		List work inbox
+//	@Description	Lists the work awaiting human action across executions, newest first: failed executions no later run of their workflow completed,
+//	@Description	approvals waiting for a decision and debug executions stopped at breakpoints. Users see the work assigned to them;
+//	@Description	admins see everyone's, or that of the assignee they filter by (me for their own).
+//	@Tags			inbox
+//	@Produce		json
+//	@Param			assignee	query		string	false	"Filter by assignee user ID, or me; other users than the caller are for admins only"
+//	@Param			workflow_id	query		string	false	"Filter by workflow ID"	format(uuid)
+//	@Param			kind		query		string	false	"Comma-separated item kinds (failed_execution, pending_approval, breakpoint)"
+//	@Param			since		query		string	false	"Only items waiting since this RFC 3339 time"
+//	@Param			limit		query		int		false	"Maximum number of results"	default(50)
+//	@Param			offset		query		int		false	"Offset for pagination"		default(0)
+//	@Success		200			{object}	object{data=[]models.InboxItem,total=int,limit=int,offset=int}	"Inbox items"
+//	@Failure		400			{object}	APIError														"Invalid filter"
+//	@Failure		401			{object}	APIError														"Authentication required"
+//	@Failure		403			{object}	APIError														"Not allowed to list the work of other users"
+//	@Failure		500			{object}	APIError														"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/inbox [get]
+func (h *InboxHandlers) HandleListInbox(c *gin.Context) {
+	filter := models.InboxFilter{
+		Assignee:   c.Query("assignee"),
+		WorkflowID: c.Query("workflow_id"),
+		Limit:      getQueryInt(c, "limit", inbox.DefaultPageSize),
+		Offset:     getQueryInt(c, "offset", 0),
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		respondAPIError(c, ErrUnauthorized)
+		return
+	}
+	if filter.Assignee == "me" {
+		filter.Assignee = userID
+	}
+	// Users see the work assigned to them; admins may look at anyone's, or everyone's
+	if !IsAdmin(c) {
+		if filter.Assignee != "" && filter.Assignee != userID {
+			respondAPIError(c, ErrForbidden)
+			return
+		}
+		filter.Assignee = userID
+	}
+
+	kinds, err := models.ParseInboxItemKinds(c.Query("kind"))
+	if err != nil {
+		respondAPIError(c, err)
+		return
+	}
+	filter.Kinds = kinds
+
+	if since := c.Query("since"); since != "" {
+		filter.Since, err = time.Parse(time.RFC3339, since)
+		if err != nil {
+			respondAPIError(c, &models.ValidationError{Field: "since", Message: "since must be an RFC 3339 time"})
+			return
+		}
+	}
+
+	items, total, err := h.inbox.List(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.Error("Failed to list inbox", "error", err, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+
+	respondList(c, http.StatusOK, items, total, filter.Limit, filter.Offset)
+}
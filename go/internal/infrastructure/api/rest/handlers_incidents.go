@@ -0,0 +1,247 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/smilemakc/mbflow/go/internal/application/incident"
+	"github.com/smilemakc/mbflow/go/internal/application/serviceapi"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// IncidentHandlers handles incident policies (admin only) and the incidents linked to executions
+type IncidentHandlers struct {
+	ops     *serviceapi.Operations
+	service *incident.Service
+	logger  *logger.Logger
+}
+
+// NewIncidentHandlers creates a new IncidentHandlers instance
+func NewIncidentHandlers(ops *serviceapi.Operations, service *incident.Service, log *logger.Logger) *IncidentHandlers {
+	return &IncidentHandlers{
+		ops:     ops,
+		service: service,
+		logger:  log,
+	}
+}
+
+// SaveIncidentPolicyRequest represents a request to create or update the incident policy of a workflow
+type SaveIncidentPolicyRequest struct {
+	RunbookURL       string   `json:"runbook_url"`
+	Tracker          string   `json:"tracker"`
+	Project          string   `json:"project"`
+	BaseURL          string   `json:"base_url"`
+	CredentialID     string   `json:"credential_id"`
+	Labels           []string `json:"labels"`
+	IssueType        string   `json:"issue_type"`
+	OnCanaryAlert    bool     `json:"on_canary_alert"`
+	FailureThreshold int      `json:"failure_threshold"`
+}
+
+// OpenIncidentRequest represents a request to open an incident for an execution
+type OpenIncidentRequest struct {
+	Title   string `json:"title"`
+	Details string `json:"details"`
+}
+
+// HandleGetPolicy handles GET /api/v1/admin/incident-policies/:workflow_id
+func (h *IncidentHandlers) HandleGetPolicy(c *gin.Context) {
+	workflowID, ok := getParam(c, "workflow_id")
+	if !ok {
+		return
+	}
+
+	policy, err := h.service.GetPolicy(c.Request.Context(), workflowID)
+	if err != nil {
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+
+	respondJSON(c, http.StatusOK, policy)
+}
+
+// HandleSavePolicy sets the runbook and incident-tracker project of a workflow
+// PUT /api/v1/admin/incident-policies/:workflow_id
+func (h *IncidentHandlers) HandleSavePolicy(c *gin.Context) {
+	workflowID, ok := getParam(c, "workflow_id")
+	if !ok {
+		return
+	}
+
+	var req SaveIncidentPolicyRequest
+	if err := bindJSON(c, &req); err != nil {
+		return
+	}
+
+	policy, err := h.service.SavePolicy(c.Request.Context(), &models.IncidentPolicy{
+		WorkflowID:       workflowID,
+		RunbookURL:       req.RunbookURL,
+		Tracker:          req.Tracker,
+		Project:          req.Project,
+		BaseURL:          req.BaseURL,
+		CredentialID:     req.CredentialID,
+		Labels:           req.Labels,
+		IssueType:        req.IssueType,
+		OnCanaryAlert:    req.OnCanaryAlert,
+		FailureThreshold: req.FailureThreshold,
+	})
+	if err != nil {
+		h.logger.Error("Failed to save incident policy", "error", err, "workflow_id", workflowID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+
+	adminID, _ := GetUserID(c)
+	h.logger.Info("Incident policy saved", "workflow_id", workflowID, "tracker", policy.Tracker, "admin_id", adminID)
+
+	respondJSON(c, http.StatusOK, policy)
+}
+
+// HandleDeletePolicy handles DELETE /api/v1/admin/incident-policies/:workflow_id
+func (h *IncidentHandlers) HandleDeletePolicy(c *gin.Context) {
+	workflowID, ok := getParam(c, "workflow_id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeletePolicy(c.Request.Context(), workflowID); err != nil {
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// HandleListWorkflowIncidents handles GET /api/v1/admin/incident-policies/:workflow_id/incidents
+func (h *IncidentHandlers) HandleListWorkflowIncidents(c *gin.Context) {
+	workflowID, ok := getParam(c, "workflow_id")
+	if !ok {
+		return
+	}
+
+	limit := getQueryInt(c, "limit", 20)
+	if limit < 1 || limit > 200 {
+		limit = 20
+	}
+
+	incidents, err := h.service.ListByWorkflow(c.Request.Context(), workflowID, limit)
+	if err != nil {
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"incidents": incidents,
+		"total":     len(incidents),
+	})
+}
+
+// HandleListExecutionIncidents lists the incidents linked to an execution
+//
+//	@Summary		List execution incidents
+//	@Description	Lists the incidents opened for an execution, newest first.
+//	@Tags			executions
+//	@Produce		json
+//	@Param			id	path		string										true	"Execution ID"	format(uuid)
+//	@Success		200	{object}	object{incidents=[]models.Incident,total=int}	"Incidents"
+//	@Failure		400	{object}	APIError									"Invalid execution ID"
+//	@Failure		404	{object}	APIError									"Execution not found"
+//	@Failure		500	{object}	APIError									"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/executions/{id}/incidents [get]
+func (h *IncidentHandlers) HandleListExecutionIncidents(c *gin.Context) {
+	execution, ok := h.getExecution(c)
+	if !ok {
+		return
+	}
+
+	incidents, err := h.service.ListByExecution(c.Request.Context(), execution.ID)
+	if err != nil {
+		h.logger.Error("Failed to list execution incidents", "error", err, "execution_id", execution.ID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"incidents": incidents,
+		"total":     len(incidents),
+	})
+}
+
+// HandleOpenIncident opens an incident for an execution in the tracker of its workflow
+//
+//	@Summary		Open incident
+//	@Description	Opens an issue in the incident tracker configured for the execution's workflow and links it to the execution.
+//	@Tags			executions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string				true	"Execution ID"	format(uuid)
+//	@Param			request	body		OpenIncidentRequest	true	"Incident"
+//	@Success		201		{object}	models.Incident		"Opened incident"
+//	@Failure		400		{object}	APIError			"Invalid request"
+//	@Failure		401		{object}	APIError			"Authentication required"
+//	@Failure		404		{object}	APIError			"Execution not found"
+//	@Failure		409		{object}	APIError			"No incident tracker configured for the workflow"
+//	@Failure		500		{object}	APIError			"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/executions/{id}/incidents [post]
+func (h *IncidentHandlers) HandleOpenIncident(c *gin.Context) {
+	var req OpenIncidentRequest
+	if err := bindJSON(c, &req); err != nil {
+		return
+	}
+
+	execution, ok := h.getExecution(c)
+	if !ok {
+		return
+	}
+
+	details := req.Details
+	if details == "" && execution.Error != "" {
+		details = "Execution error: " + execution.Error
+	}
+
+	userID, _ := GetUserID(c)
+	result, err := h.service.Open(c.Request.Context(), incident.OpenRequest{
+		WorkflowID:  execution.WorkflowID,
+		ExecutionID: execution.ID,
+		Source:      models.IncidentSourceManual,
+		Title:       req.Title,
+		Details:     details,
+		CreatedBy:   userID,
+	})
+	if err != nil {
+		h.logger.Error("Failed to open incident", "error", err, "execution_id", execution.ID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+
+	respondJSON(c, http.StatusCreated, result)
+}
+
+// getExecution loads the execution named by the id path parameter, responding with an error when it fails
+func (h *IncidentHandlers) getExecution(c *gin.Context) (*models.Execution, bool) {
+	executionID, ok := getParam(c, "id")
+	if !ok {
+		return nil, false
+	}
+
+	execUUID, err := uuid.Parse(executionID)
+	if err != nil {
+		respondAPIError(c, ErrInvalidID)
+		return nil, false
+	}
+
+	execution, err := h.ops.GetExecution(c.Request.Context(), serviceapi.GetExecutionParams{
+		ExecutionID: execUUID,
+	})
+	if err != nil {
+		respondAPIErrorWithRequestID(c, err)
+		return nil, false
+	}
+
+	return execution, true
+}
@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smilemakc/mbflow/go/internal/application/llmcatalog"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// LLMCatalogHandlers handles LLM provider and model discovery endpoints
+type LLMCatalogHandlers struct {
+	catalog *llmcatalog.Service
+	logger  *logger.Logger
+}
+
+// NewLLMCatalogHandlers creates a new LLMCatalogHandlers instance
+func NewLLMCatalogHandlers(catalog *llmcatalog.Service, log *logger.Logger) *LLMCatalogHandlers {
+	return &LLMCatalogHandlers{
+		catalog: catalog,
+		logger:  log,
+	}
+}
+
+// HandleListProviders handles GET /api/v1/llm/providers
+func (h *LLMCatalogHandlers) HandleListProviders(c *gin.Context) {
+	userID, _ := GetUserID(c)
+
+	providers, err := h.catalog.Providers(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("Failed to list LLM providers", "error", err, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"providers": providers,
+		"total":     len(providers),
+	})
+}
+
+// HandleListModels handles GET /api/v1/llm/models?provider=&model=&feature=&refresh=
+func (h *LLMCatalogHandlers) HandleListModels(c *gin.Context) {
+	userID, _ := GetUserID(c)
+
+	filter := llmcatalog.ModelFilter{
+		Provider: models.LLMProvider(c.Query("provider")),
+		Model:    c.Query("model"),
+		Feature:  c.Query("feature"),
+	}
+	if raw := c.Query("refresh"); raw != "" {
+		refresh, err := strconv.ParseBool(raw)
+		if err != nil {
+			respondAPIError(c, NewAPIError("INVALID_REFRESH", "refresh must be a boolean", http.StatusBadRequest))
+			return
+		}
+		filter.Refresh = refresh
+	}
+
+	list, err := h.catalog.Models(c.Request.Context(), userID, filter)
+	if err != nil {
+		switch {
+		case errors.Is(err, llmcatalog.ErrUnknownProvider):
+			respondAPIError(c, NewAPIError("UNKNOWN_PROVIDER", err.Error(), http.StatusBadRequest))
+		case errors.Is(err, llmcatalog.ErrUnknownFeature):
+			respondAPIError(c, NewAPIError("INVALID_FEATURE", err.Error(), http.StatusBadRequest))
+		default:
+			h.logger.Error("Failed to list LLM models", "error", err, "request_id", GetRequestID(c))
+			respondAPIErrorWithRequestID(c, err)
+		}
+		return
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"models":  list.Models,
+		"sources": list.Sources,
+		"total":   len(list.Models),
+	})
+}
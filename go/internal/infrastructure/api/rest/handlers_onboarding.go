@@ -0,0 +1,126 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smilemakc/mbflow/go/internal/application/onboarding"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+)
+
+// OnboardingHandlers handles self-serve workspace onboarding
+type OnboardingHandlers struct {
+	service *onboarding.Service
+	logger  *logger.Logger
+}
+
+// NewOnboardingHandlers creates a new OnboardingHandlers instance
+func NewOnboardingHandlers(service *onboarding.Service, log *logger.Logger) *OnboardingHandlers {
+	return &OnboardingHandlers{
+		service: service,
+		logger:  log,
+	}
+}
+
+// OnboardingRequest represents a request to provision the current user's workspace
+type OnboardingRequest struct {
+	// Templates lists the sample workflows to create; empty creates all of them
+	Templates     []string `json:"templates,omitempty"`
+	SkipAPIKey    bool     `json:"skip_api_key,omitempty"`
+	SkipSampleRun bool     `json:"skip_sample_run,omitempty"`
+}
+
+// OnboardingStatusResponse represents the onboarding status of the current user
+type OnboardingStatusResponse struct {
+	Onboarded bool              `json:"onboarded"`
+	State     *onboarding.State `json:"state,omitempty"`
+}
+
+// HandleListTemplates lists the sample workflows that onboarding can create
+//
+//	@Summary		List onboarding templates
+//	@Description	Returns the sample workflows that onboarding can create.
+//	@Tags			onboarding
+//	@Produce		json
+//	@Success		200	{object}	map[string]interface{}	"Templates"
+//	@Router			/onboarding/templates [get]
+func (h *OnboardingHandlers) HandleListTemplates(c *gin.Context) {
+	templates := onboarding.Templates()
+	respondJSON(c, http.StatusOK, gin.H{
+		"templates": templates,
+		"total":     len(templates),
+	})
+}
+
+// HandleGetStatus returns whether the current user's workspace has been provisioned
+//
+//	@Summary		Get onboarding status
+//	@Description	Returns whether the current user's workspace has been provisioned and what was created.
+//	@Tags			onboarding
+//	@Produce		json
+//	@Success		200	{object}	OnboardingStatusResponse	"Onboarding status"
+//	@Failure		401	{object}	APIError					"Authentication required"
+//	@Failure		500	{object}	APIError					"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/onboarding [get]
+func (h *OnboardingHandlers) HandleGetStatus(c *gin.Context) {
+	userID, ok := GetUserIDAsUUID(c)
+	if !ok {
+		respondAPIError(c, ErrUnauthorized)
+		return
+	}
+
+	state, err := h.service.GetState(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("Failed to get onboarding state", "error", err, "user_id", userID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+
+	respondJSON(c, http.StatusOK, OnboardingStatusResponse{Onboarded: state != nil, State: state})
+}
+
+// HandleProvision provisions the current user's workspace
+//
+//	@Summary		Provision workspace
+//	@Description	Creates sample workflows, a default file storage resource, a demo webhook trigger and a starter service key,
+//	@Description	and starts a first run. The service key is returned only in this response. The body is optional.
+//	@Tags			onboarding
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		OnboardingRequest	false	"Onboarding options"
+//	@Success		201		{object}	onboarding.Result	"Provisioned workspace"
+//	@Failure		400		{object}	APIError			"Invalid request"
+//	@Failure		401		{object}	APIError			"Authentication required"
+//	@Failure		409		{object}	APIError			"Workspace already onboarded"
+//	@Failure		500		{object}	APIError			"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/onboarding [post]
+func (h *OnboardingHandlers) HandleProvision(c *gin.Context) {
+	userID, ok := GetUserIDAsUUID(c)
+	if !ok {
+		respondAPIError(c, ErrUnauthorized)
+		return
+	}
+
+	var req OnboardingRequest
+	if c.Request.ContentLength != 0 {
+		if err := bindJSON(c, &req); err != nil {
+			return
+		}
+	}
+
+	result, err := h.service.Provision(c.Request.Context(), userID, onboarding.Request{
+		Templates:     req.Templates,
+		SkipAPIKey:    req.SkipAPIKey,
+		SkipSampleRun: req.SkipSampleRun,
+	})
+	if err != nil {
+		h.logger.Error("Failed to provision workspace", "error", err, "user_id", userID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+
+	respondJSON(c, http.StatusCreated, result)
+}
@@ -0,0 +1,109 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/smilemakc/mbflow/go/internal/application/ownership"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// OwnershipHandlers handles ownership transfer and offboarding endpoints (admin only)
+type OwnershipHandlers struct {
+	service *ownership.Service
+	logger  *logger.Logger
+}
+
+// NewOwnershipHandlers creates a new OwnershipHandlers instance
+func NewOwnershipHandlers(service *ownership.Service, log *logger.Logger) *OwnershipHandlers {
+	return &OwnershipHandlers{
+		service: service,
+		logger:  log,
+	}
+}
+
+// TransferOwnershipRequest represents a request to transfer a user's workflows and resources
+type TransferOwnershipRequest struct {
+	ToUserID    string                 `json:"to_user_id" binding:"required,uuid"`
+	Kinds       []models.OwnershipKind `json:"kinds"`
+	WorkflowIDs []string               `json:"workflow_ids"`
+	ResourceIDs []string               `json:"resource_ids"`
+}
+
+// HandleGetOwnershipReport lists everything a user owns (offboarding report)
+// GET /api/v1/admin/users/:id/ownership
+func (h *OwnershipHandlers) HandleGetOwnershipReport(c *gin.Context) {
+	idStr, ok := getParam(c, "id")
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(idStr)
+	if err != nil {
+		respondAPIError(c, ErrInvalidID)
+		return
+	}
+
+	report, err := h.service.Report(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("Failed to build ownership report", "error", err, "user_id", userID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+
+	respondJSON(c, http.StatusOK, report)
+}
+
+// HandleTransferOwnership transfers a user's workflows, triggers, credentials and resources to another user
+// POST /api/v1/admin/users/:id/transfer-ownership
+func (h *OwnershipHandlers) HandleTransferOwnership(c *gin.Context) {
+	idStr, ok := getParam(c, "id")
+	if !ok {
+		return
+	}
+
+	fromUserID, err := uuid.Parse(idStr)
+	if err != nil {
+		respondAPIError(c, ErrInvalidID)
+		return
+	}
+
+	var req TransferOwnershipRequest
+	if err := bindJSON(c, &req); err != nil {
+		return
+	}
+
+	result, err := h.service.Transfer(c.Request.Context(), &models.OwnershipTransfer{
+		FromUserID:  fromUserID.String(),
+		ToUserID:    req.ToUserID,
+		Kinds:       req.Kinds,
+		WorkflowIDs: req.WorkflowIDs,
+		ResourceIDs: req.ResourceIDs,
+	})
+	if err != nil {
+		if errors.Is(err, ownership.ErrInvalidTransfer) {
+			respondAPIError(c, NewAPIError("INVALID_TRANSFER", err.Error(), http.StatusBadRequest))
+			return
+		}
+		h.logger.Error("Failed to transfer ownership", "error", err, "from_user_id", fromUserID, "to_user_id", req.ToUserID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+
+	adminID, _ := GetUserID(c)
+	h.logger.Info("Ownership transferred",
+		"admin_id", adminID,
+		"from_user_id", result.FromUserID,
+		"to_user_id", result.ToUserID,
+		"workflows", result.Workflows,
+		"triggers", result.Triggers,
+		"credentials", result.Credentials,
+		"resources", result.Resources,
+	)
+
+	respondJSON(c, http.StatusOK, result)
+}
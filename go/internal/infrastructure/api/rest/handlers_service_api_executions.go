@@ -59,6 +59,7 @@ func (h *ServiceAPIExecutionHandlers) GetExecution(c *gin.Context) {
 
 	execution, err := h.ops.GetExecution(c.Request.Context(), serviceapi.GetExecutionParams{
 		ExecutionID: execUUID,
+		Full:        c.DefaultQuery("full", "false") == "true",
 	})
 	if err != nil {
 		respondAPIErrorWithRequestID(c, TranslateError(err))
@@ -77,16 +78,27 @@ func (h *ServiceAPIExecutionHandlers) StartExecution(c *gin.Context) {
 	var req struct {
 		Input     map[string]any `json:"input"`
 		Variables map[string]any `json:"variables,omitempty"`
+		Profile   string                `json:"profile,omitempty"`
+		Selection *models.NodeSelection `json:"selection,omitempty"`
 	}
 
 	if err := bindJSON(c, &req); err != nil {
 		return
 	}
 
+	if profile := c.Query("profile"); profile != "" {
+		req.Profile = profile
+	}
+
 	execution, err := h.ops.StartExecution(c.Request.Context(), serviceapi.StartExecutionParams{
-		WorkflowID: workflowID,
-		Input:      req.Input,
-		Variables:  req.Variables,
+		WorkflowID:  workflowID,
+		Input:       req.Input,
+		Variables:   req.Variables,
+		Profile:     req.Profile,
+		Selection:   req.Selection,
+		Propagation: executionPropagation(c),
+
+		IdempotencyKey: c.GetHeader(HeaderIdempotencyKey),
 	})
 	if err != nil {
 		respondAPIErrorWithRequestID(c, TranslateError(err))
@@ -150,6 +162,7 @@ func (h *ServiceAPIExecutionHandlers) StartEphemeralExecution(c *gin.Context) {
 		CredentialIDs:    req.CredentialIDs,
 		Variables:        req.Variables,
 		PersistExecution: req.PersistExecution,
+		Propagation:      executionPropagation(c),
 	}
 
 	if len(req.Webhooks) > 0 {
@@ -179,7 +192,85 @@ func (h *ServiceAPIExecutionHandlers) StartEphemeralExecution(c *gin.Context) {
 }
 
 func (h *ServiceAPIExecutionHandlers) CancelExecution(c *gin.Context) {
-	respondAPIError(c, NewAPIError("NOT_IMPLEMENTED", "execution cancellation not yet implemented", http.StatusNotImplemented))
+	execUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondAPIError(c, ErrInvalidID)
+		return
+	}
+
+	userID, _ := GetUserID(c)
+	if err := h.ops.CancelExecution(c.Request.Context(), serviceapi.CancelExecutionParams{
+		ExecutionID: execUUID,
+		UserID:      userID,
+		IsAdmin:     IsAdmin(c),
+	}); err != nil {
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	respondJSON(c, http.StatusAccepted, gin.H{"execution_id": execUUID.String()})
+}
+
+func (h *ServiceAPIExecutionHandlers) PauseExecution(c *gin.Context) {
+	execUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondAPIError(c, ErrInvalidID)
+		return
+	}
+
+	userID, _ := GetUserID(c)
+	if err := h.ops.PauseExecution(c.Request.Context(), serviceapi.PauseExecutionParams{
+		ExecutionID: execUUID,
+		UserID:      userID,
+		IsAdmin:     IsAdmin(c),
+	}); err != nil {
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	respondJSON(c, http.StatusAccepted, gin.H{"execution_id": execUUID.String()})
+}
+
+func (h *ServiceAPIExecutionHandlers) ResumeExecution(c *gin.Context) {
+	execUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondAPIError(c, ErrInvalidID)
+		return
+	}
+
+	userID, _ := GetUserID(c)
+	execution, err := h.ops.ResumeExecution(c.Request.Context(), serviceapi.ResumeExecutionParams{
+		ExecutionID: execUUID,
+		UserID:      userID,
+		IsAdmin:     IsAdmin(c),
+	})
+	if err != nil {
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	respondJSON(c, http.StatusAccepted, execution)
+}
+
+func (h *ServiceAPIExecutionHandlers) ResumeFailedExecution(c *gin.Context) {
+	execUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondAPIError(c, ErrInvalidID)
+		return
+	}
+
+	userID, _ := GetUserID(c)
+	execution, err := h.ops.ResumeFailedExecution(c.Request.Context(), serviceapi.ResumeFailedExecutionParams{
+		ExecutionID: execUUID,
+		UserID:      userID,
+		IsAdmin:     IsAdmin(c),
+	})
+	if err != nil {
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	respondJSON(c, http.StatusAccepted, execution)
 }
 
 func (h *ServiceAPIExecutionHandlers) RetryExecution(c *gin.Context) {
@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/smilemakc/mbflow/go/internal/application/serviceapi"
+	"github.com/smilemakc/mbflow/go/pkg/models"
 )
 
 type ServiceAPIWorkflowHandlers struct {
@@ -68,10 +69,12 @@ func (h *ServiceAPIWorkflowHandlers) GetWorkflow(c *gin.Context) {
 
 func (h *ServiceAPIWorkflowHandlers) CreateWorkflow(c *gin.Context) {
 	var req struct {
-		Name        string         `json:"name" binding:"required"`
-		Description string         `json:"description,omitempty"`
-		Variables   map[string]any `json:"variables,omitempty"`
-		Metadata    map[string]any `json:"metadata,omitempty"`
+		Name           string                           `json:"name" binding:"required"`
+		Description    string                           `json:"description,omitempty"`
+		Variables      map[string]any                   `json:"variables,omitempty"`
+		LaunchProfiles map[string]*models.LaunchProfile `json:"launch_profiles,omitempty"`
+		Metadata       map[string]any                   `json:"metadata,omitempty"`
+		Triggers       []*models.Trigger                `json:"triggers,omitempty"`
 	}
 
 	if err := bindJSON(c, &req); err != nil {
@@ -84,11 +87,13 @@ func (h *ServiceAPIWorkflowHandlers) CreateWorkflow(c *gin.Context) {
 	}
 
 	workflow, err := h.ops.CreateWorkflow(c.Request.Context(), serviceapi.CreateWorkflowParams{
-		Name:        req.Name,
-		Description: req.Description,
-		Variables:   req.Variables,
-		Metadata:    req.Metadata,
-		CreatedBy:   createdBy,
+		Name:           req.Name,
+		Description:    req.Description,
+		Variables:      req.Variables,
+		LaunchProfiles: req.LaunchProfiles,
+		Metadata:       req.Metadata,
+		Triggers:       req.Triggers,
+		CreatedBy:      createdBy,
 	})
 	if err != nil {
 		respondAPIErrorWithRequestID(c, TranslateError(err))
@@ -132,7 +137,7 @@ func (h *ServiceAPIWorkflowHandlers) UpdateWorkflow(c *gin.Context) {
 		for i, e := range req.Edges {
 			edges[i] = serviceapi.EdgeInput{
 				ID: e.ID, From: e.From, To: e.To,
-				Condition: e.Condition,
+				Condition: e.Condition, Type: e.Type,
 			}
 		}
 	}
@@ -148,14 +153,15 @@ func (h *ServiceAPIWorkflowHandlers) UpdateWorkflow(c *gin.Context) {
 	}
 
 	workflow, err := h.ops.UpdateWorkflow(c.Request.Context(), serviceapi.UpdateWorkflowParams{
-		WorkflowID:  workflowUUID,
-		Name:        req.Name,
-		Description: req.Description,
-		Variables:   req.Variables,
-		Metadata:    req.Metadata,
-		Nodes:       nodes,
-		Edges:       edges,
-		Resources:   resources,
+		WorkflowID:     workflowUUID,
+		Name:           req.Name,
+		Description:    req.Description,
+		Variables:      req.Variables,
+		LaunchProfiles: req.LaunchProfiles,
+		Metadata:       req.Metadata,
+		Nodes:          nodes,
+		Edges:          edges,
+		Resources:      resources,
 	})
 	if err != nil {
 		respondAPIErrorWithRequestID(c, TranslateError(err))
@@ -186,3 +192,33 @@ func (h *ServiceAPIWorkflowHandlers) DeleteWorkflow(c *gin.Context) {
 
 	respondJSON(c, http.StatusOK, gin.H{"message": "workflow deleted successfully"})
 }
+
+func (h *ServiceAPIWorkflowHandlers) CompareWorkflow(c *gin.Context) {
+	workflowID, ok := getParam(c, "id")
+	if !ok {
+		return
+	}
+
+	workflowUUID, err := uuid.Parse(workflowID)
+	if err != nil {
+		respondAPIError(c, ErrInvalidID)
+		return
+	}
+
+	var req CompareWorkflowRequest
+	if err := bindJSON(c, &req); err != nil {
+		return
+	}
+	params, ok := req.toParams(c, workflowUUID)
+	if !ok {
+		return
+	}
+
+	report, err := h.ops.CompareWorkflowVariants(c.Request.Context(), params)
+	if err != nil {
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	respondJSON(c, http.StatusOK, report)
+}
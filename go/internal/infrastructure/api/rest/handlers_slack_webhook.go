@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smilemakc/mbflow/go/internal/application/trigger"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+)
+
+// slackMaxBodyBytes caps Slack request bodies (events are a few KB).
+const slackMaxBodyBytes = 1 << 20
+
+// SlackWebhookHandlers provides HTTP handlers for Slack Events API and slash commands
+type SlackWebhookHandlers struct {
+	slackRegistry *trigger.SlackRegistry
+	logger        *logger.Logger
+}
+
+// NewSlackWebhookHandlers creates a new SlackWebhookHandlers instance
+func NewSlackWebhookHandlers(slackRegistry *trigger.SlackRegistry, log *logger.Logger) *SlackWebhookHandlers {
+	return &SlackWebhookHandlers{
+		slackRegistry: slackRegistry,
+		logger:        log,
+	}
+}
+
+// HandleSlackWebhook handles POST /api/v1/webhooks/slack/{trigger_id}
+// The same URL is used as the Events API request URL and as the slash command URL.
+func (h *SlackWebhookHandlers) HandleSlackWebhook(c *gin.Context) {
+	triggerID := c.Param("trigger_id")
+	if triggerID == "" {
+		respondError(c, http.StatusBadRequest, "trigger_id is required")
+		return
+	}
+
+	// Read raw body for signature validation
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, slackMaxBodyBytes))
+	if err != nil {
+		h.logger.Error("Failed to read request body", "error", err, "trigger_id", triggerID)
+		respondError(c, http.StatusBadRequest, "failed to read request body")
+		return
+	}
+
+	result, err := h.slackRegistry.HandleRequest(c.Request.Context(), triggerID, trigger.SlackRequest{
+		Body:        body,
+		ContentType: c.GetHeader("Content-Type"),
+		Timestamp:   c.GetHeader("X-Slack-Request-Timestamp"),
+		Signature:   c.GetHeader("X-Slack-Signature"),
+	})
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, trigger.ErrSlackTriggerNotFound):
+			statusCode = http.StatusNotFound
+		case errors.Is(err, trigger.ErrSlackTriggerDisabled):
+			statusCode = http.StatusForbidden
+		case errors.Is(err, trigger.ErrSlackInvalidSignature):
+			statusCode = http.StatusUnauthorized
+		}
+
+		h.logger.Error("Failed to handle Slack request", "error", err, "trigger_id", triggerID,
+			"retry_num", c.GetHeader("X-Slack-Retry-Num"))
+		respondError(c, statusCode, err.Error())
+		return
+	}
+
+	switch {
+	case result.Challenge != "":
+		c.JSON(http.StatusOK, gin.H{"challenge": result.Challenge})
+	case result.Response != nil:
+		c.JSON(http.StatusOK, result.Response)
+	case result.ExecutionID == "":
+		h.logger.Debug("Slack request ignored", "trigger_id", triggerID, "reason", result.Ignored)
+		c.Status(http.StatusOK)
+	default:
+		h.logger.Info("Slack trigger executed", "trigger_id", triggerID, "execution_id", result.ExecutionID)
+		// Slash commands show a non-empty body to the user, so acknowledge with an empty 200.
+		c.Status(http.StatusOK)
+	}
+}
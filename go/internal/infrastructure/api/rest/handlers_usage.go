@@ -0,0 +1,142 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/smilemakc/mbflow/go/internal/application/quota"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+const (
+	defaultUsageHistoryDays = 7
+	maxUsageHistoryDays     = 90
+)
+
+// UsageHandlers handles the daily usage and quotas of users' executions
+type UsageHandlers struct {
+	quotas *quota.Service
+	logger *logger.Logger
+}
+
+// NewUsageHandlers creates a new UsageHandlers instance
+func NewUsageHandlers(quotas *quota.Service, log *logger.Logger) *UsageHandlers {
+	return &UsageHandlers{
+		quotas: quotas,
+		logger: log,
+	}
+}
+
+// UsageResponse is the usage of a user today against their quota, and over the last days
+type UsageResponse struct {
+	*models.QuotaStatus
+	History []*models.DailyUsage `json:"history"`
+}
+
+// HandleGetUsage returns the current user's usage against their daily quota
+// GET /api/v1/account/usage?days=7
+func (h *UsageHandlers) HandleGetUsage(c *gin.Context) {
+	userID, ok := GetUserID(c)
+	if !ok {
+		respondAPIError(c, ErrUnauthorized)
+		return
+	}
+	h.respondUsage(c, userID)
+}
+
+// HandleGetUserUsage returns a user's usage against their daily quota
+// GET /api/v1/admin/users/:id/usage?days=7
+func (h *UsageHandlers) HandleGetUserUsage(c *gin.Context) {
+	userID, ok := h.userParam(c)
+	if !ok {
+		return
+	}
+	h.respondUsage(c, userID)
+}
+
+// HandleSetUserQuota sets the daily quota of a user's billing account
+// PUT /api/v1/admin/users/:id/quota
+func (h *UsageHandlers) HandleSetUserQuota(c *gin.Context) {
+	userID, ok := h.userParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.UsageQuota
+	if err := bindJSON(c, &req); err != nil {
+		return
+	}
+
+	if err := h.quotas.SetQuota(c.Request.Context(), userID, &req); err != nil {
+		h.logger.Error("Failed to set quota", "error", err, "user_id", userID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+
+	adminID, _ := GetUserID(c)
+	h.logger.Info("Usage quota set",
+		"admin_id", adminID,
+		"user_id", userID,
+		"executions_per_day", req.ExecutionsPerDay,
+		"node_runs_per_day", req.NodeRunsPerDay,
+		"llm_tokens_per_day", req.LLMTokensPerDay,
+		"overflow", req.OverflowPolicy(),
+	)
+	h.respondUsage(c, userID)
+}
+
+// HandleDeleteUserQuota restores the default daily quota of a user
+// DELETE /api/v1/admin/users/:id/quota
+func (h *UsageHandlers) HandleDeleteUserQuota(c *gin.Context) {
+	userID, ok := h.userParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.quotas.SetQuota(c.Request.Context(), userID, nil); err != nil {
+		h.logger.Error("Failed to reset quota", "error", err, "user_id", userID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+	h.respondUsage(c, userID)
+}
+
+// userParam returns the user ID in the path, responding with an error if it is invalid
+func (h *UsageHandlers) userParam(c *gin.Context) (string, bool) {
+	idStr, ok := getParam(c, "id")
+	if !ok {
+		return "", false
+	}
+	if _, err := uuid.Parse(idStr); err != nil {
+		respondAPIError(c, ErrInvalidID)
+		return "", false
+	}
+	return idStr, true
+}
+
+// respondUsage responds with the usage of the user today and over the last ?days days
+func (h *UsageHandlers) respondUsage(c *gin.Context, userID string) {
+	days := getQueryInt(c, "days", defaultUsageHistoryDays)
+	if days < 1 || days > maxUsageHistoryDays {
+		respondAPIError(c, NewAPIError("INVALID_DAYS", "days must be between 1 and 90", http.StatusBadRequest))
+		return
+	}
+
+	status, err := h.quotas.Status(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("Failed to get usage", "error", err, "user_id", userID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+	history, err := h.quotas.History(c.Request.Context(), userID, days)
+	if err != nil {
+		h.logger.Error("Failed to get usage history", "error", err, "user_id", userID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, err)
+		return
+	}
+
+	respondJSON(c, http.StatusOK, &UsageResponse{QuotaStatus: status, History: history})
+}
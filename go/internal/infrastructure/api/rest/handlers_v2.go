@@ -0,0 +1,299 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/smilemakc/mbflow/go/internal/application/serviceapi"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// V2Handlers serves API v2. The handlers call the same serviceapi operations as v1;
+// only request parsing, pagination, error envelope and DTO mapping differ.
+type V2Handlers struct {
+	ops    *serviceapi.Operations
+	logger *logger.Logger
+}
+
+func NewV2Handlers(ops *serviceapi.Operations, log *logger.Logger) *V2Handlers {
+	return &V2Handlers{ops: ops, logger: log}
+}
+
+// ============================================================================
+// DTOs
+// ============================================================================
+
+// WorkflowV2 is the API v2 representation of a workflow.
+// Lists return summaries; nodes and edges are only included by the get endpoint.
+type WorkflowV2 struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Version     int            `json:"version"`
+	Status      string         `json:"status"`
+	Tags        []string       `json:"tags"`
+	NodeCount   int            `json:"node_count"`
+	EdgeCount   int            `json:"edge_count"`
+	Nodes       []*models.Node `json:"nodes,omitempty"`
+	Edges       []*models.Edge `json:"edges,omitempty"`
+	Variables   map[string]any `json:"variables,omitempty"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+	CreatedBy   string         `json:"created_by,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+func toWorkflowV2(wf *models.Workflow, withGraph bool) WorkflowV2 {
+	dto := WorkflowV2{
+		ID:          wf.ID,
+		Name:        wf.Name,
+		Description: wf.Description,
+		Version:     wf.Version,
+		Status:      string(wf.Status),
+		Tags:        wf.Tags,
+		NodeCount:   len(wf.Nodes),
+		EdgeCount:   len(wf.Edges),
+		Variables:   wf.Variables,
+		Metadata:    wf.Metadata,
+		CreatedBy:   wf.CreatedBy,
+		CreatedAt:   wf.CreatedAt,
+		UpdatedAt:   wf.UpdatedAt,
+	}
+	if dto.Tags == nil {
+		dto.Tags = []string{}
+	}
+	if withGraph {
+		dto.Nodes = wf.Nodes
+		dto.Edges = wf.Edges
+	}
+	return dto
+}
+
+// ExecutionErrorV2 describes why an execution failed.
+type ExecutionErrorV2 struct {
+	Message string `json:"message"`
+}
+
+// ExecutionV2 is the API v2 representation of an execution.
+// Lists return summaries; node executions are only included by the get endpoint.
+type ExecutionV2 struct {
+	ID             string                  `json:"id"`
+	WorkflowID     string                  `json:"workflow_id"`
+	WorkflowName   string                  `json:"workflow_name,omitempty"`
+	WorkspaceID    string                  `json:"workspace_id,omitempty"`
+	Status         string                  `json:"status"`
+	Input          map[string]any          `json:"input,omitempty"`
+	Output         map[string]any          `json:"output,omitempty"`
+	Error          *ExecutionErrorV2       `json:"error,omitempty"`
+	NodeExecutions []*models.NodeExecution `json:"node_executions,omitempty"`
+	CorrelationID  string                  `json:"correlation_id,omitempty"`
+	TriggeredBy    string                  `json:"triggered_by,omitempty"`
+	StartedAt      time.Time               `json:"started_at"`
+	CompletedAt    *time.Time              `json:"completed_at,omitempty"`
+	DurationMs     int64                   `json:"duration_ms"`
+	Metadata       map[string]any          `json:"metadata,omitempty"`
+}
+
+func toExecutionV2(exec *models.Execution, withNodes bool) ExecutionV2 {
+	dto := ExecutionV2{
+		ID:           exec.ID,
+		WorkflowID:   exec.WorkflowID,
+		WorkflowName: exec.WorkflowName,
+		Status:       string(exec.Status),
+		Input:        exec.Input,
+		Output:       exec.Output,
+		TriggeredBy:  exec.TriggeredBy,
+		StartedAt:    exec.StartedAt,
+		CompletedAt:  exec.CompletedAt,
+		DurationMs:   exec.Duration,
+		Metadata:     exec.Metadata,
+	}
+	if exec.Error != "" {
+		dto.Error = &ExecutionErrorV2{Message: exec.Error}
+	}
+	if id, ok := exec.Metadata["correlation_id"].(string); ok {
+		dto.CorrelationID = id
+	}
+	if id, ok := exec.Metadata["workspace_id"].(string); ok {
+		dto.WorkspaceID = id
+	}
+	if withNodes {
+		dto.NodeExecutions = exec.NodeExecutions
+	}
+	return dto
+}
+
+// ============================================================================
+// Workflows
+// ============================================================================
+
+// HandleListWorkflows lists workflows with cursor pagination.
+// Query: limit (1-200, default 50), cursor, status, user_id.
+func (h *V2Handlers) HandleListWorkflows(c *gin.Context) {
+	page, err := parsePageRequest(c)
+	if err != nil {
+		respondV2Error(c, err)
+		return
+	}
+
+	params := serviceapi.ListWorkflowsParams{
+		Limit:  page.fetchLimit(),
+		Offset: page.Offset,
+	}
+	if status := c.Query("status"); status != "" {
+		params.Status = &status
+	}
+	if userIDParam := c.Query("user_id"); userIDParam != "" {
+		requestedUserID, err := uuid.Parse(userIDParam)
+		if err != nil {
+			respondV2Error(c, NewAPIError("INVALID_USER_ID", "Invalid user_id format", http.StatusBadRequest))
+			return
+		}
+		if currentUserID, ok := GetUserIDAsUUID(c); ok && !IsAdmin(c) && requestedUserID != currentUserID {
+			respondV2Error(c, NewAPIError("FORBIDDEN", "You can only view your own workflows", http.StatusForbidden))
+			return
+		}
+		params.UserID = &requestedUserID
+	}
+
+	result, err := h.ops.ListWorkflows(c.Request.Context(), params)
+	if err != nil {
+		h.logger.Error("Failed to list workflows", "error", err, "api_version", APIVersionV2, "request_id", GetRequestID(c))
+		respondV2Error(c, err)
+		return
+	}
+
+	info, n := page.pageInfo(len(result.Workflows))
+	data := make([]WorkflowV2, 0, n)
+	for _, wf := range result.Workflows[:n] {
+		data = append(data, toWorkflowV2(wf, false))
+	}
+	respondPage(c, data, info)
+}
+
+// HandleGetWorkflow returns a workflow with its nodes and edges.
+func (h *V2Handlers) HandleGetWorkflow(c *gin.Context) {
+	workflowUUID, err := uuid.Parse(c.Param("workflow_id"))
+	if err != nil {
+		respondV2Error(c, ErrInvalidID)
+		return
+	}
+
+	workflow, err := h.ops.GetWorkflow(c.Request.Context(), serviceapi.GetWorkflowParams{WorkflowID: workflowUUID})
+	if err != nil {
+		h.logger.Error("Failed to find workflow", "error", err, "workflow_id", workflowUUID, "api_version", APIVersionV2, "request_id", GetRequestID(c))
+		respondV2Error(c, err)
+		return
+	}
+
+	respondJSON(c, http.StatusOK, toWorkflowV2(workflow, true))
+}
+
+// ============================================================================
+// Executions
+// ============================================================================
+
+// HandleStartExecution starts an execution of a workflow.
+// The workspace is taken from the X-MBFlow-Workspace-ID header.
+func (h *V2Handlers) HandleStartExecution(c *gin.Context) {
+	var req struct {
+		Input     map[string]any        `json:"input"`
+		Variables map[string]any        `json:"variables,omitempty"`
+		Profile   string                `json:"profile,omitempty"`
+		Selection *models.NodeSelection `json:"selection,omitempty"`
+	}
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondV2Error(c, ErrInvalidJSON)
+			return
+		}
+	}
+
+	workflowID := c.Param("workflow_id")
+	if _, err := uuid.Parse(workflowID); err != nil {
+		respondV2Error(c, ErrInvalidID)
+		return
+	}
+
+	execution, err := h.ops.StartExecution(c.Request.Context(), serviceapi.StartExecutionParams{
+		WorkflowID:  workflowID,
+		Input:       req.Input,
+		Variables:   req.Variables,
+		Profile:     req.Profile,
+		Selection:   req.Selection,
+		Propagation: executionPropagation(c),
+	})
+	if err != nil {
+		h.logger.Error("Failed to start workflow execution", "error", err, "workflow_id", workflowID, "api_version", APIVersionV2, "request_id", GetRequestID(c))
+		respondV2Error(c, err)
+		return
+	}
+
+	respondJSON(c, http.StatusAccepted, toExecutionV2(execution, false))
+}
+
+// HandleListExecutions lists executions with cursor pagination, most recent first.
+// Query: limit (1-200, default 50), cursor, workflow_id, status.
+func (h *V2Handlers) HandleListExecutions(c *gin.Context) {
+	page, err := parsePageRequest(c)
+	if err != nil {
+		respondV2Error(c, err)
+		return
+	}
+
+	params := serviceapi.ListExecutionsParams{
+		Limit:  page.fetchLimit(),
+		Offset: page.Offset,
+	}
+	if workflowID := c.Query("workflow_id"); workflowID != "" {
+		wfUUID, err := uuid.Parse(workflowID)
+		if err != nil {
+			respondV2Error(c, NewAPIErrorWithDetails("INVALID_ID", "Invalid ID format", http.StatusBadRequest,
+				map[string]any{"parameter": "workflow_id"}))
+			return
+		}
+		params.WorkflowID = &wfUUID
+	}
+	if status := c.Query("status"); status != "" {
+		params.Status = &status
+	}
+
+	result, err := h.ops.ListExecutions(c.Request.Context(), params)
+	if err != nil {
+		h.logger.Error("Failed to list executions", "error", err, "api_version", APIVersionV2, "request_id", GetRequestID(c))
+		respondV2Error(c, err)
+		return
+	}
+
+	info, n := page.pageInfo(len(result.Executions))
+	data := make([]ExecutionV2, 0, n)
+	for _, exec := range result.Executions[:n] {
+		data = append(data, toExecutionV2(exec, false))
+	}
+	respondPage(c, data, info)
+}
+
+// HandleGetExecution returns an execution with its node executions.
+// Query: full=true loads node payloads archived to cold storage.
+func (h *V2Handlers) HandleGetExecution(c *gin.Context) {
+	execUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondV2Error(c, ErrInvalidID)
+		return
+	}
+
+	execution, err := h.ops.GetExecution(c.Request.Context(), serviceapi.GetExecutionParams{
+		ExecutionID: execUUID,
+		Full:        c.DefaultQuery("full", "false") == "true",
+	})
+	if err != nil {
+		h.logger.Error("Failed to find execution", "error", err, "execution_id", execUUID, "api_version", APIVersionV2, "request_id", GetRequestID(c))
+		respondV2Error(c, err)
+		return
+	}
+
+	respondJSON(c, http.StatusOK, toExecutionV2(execution, true))
+}
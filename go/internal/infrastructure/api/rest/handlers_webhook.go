@@ -1,12 +1,15 @@
 package rest
 
 import (
+	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/smilemakc/mbflow/go/internal/application/trigger"
 	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+	"github.com/smilemakc/mbflow/go/pkg/models"
 )
 
 // WebhookHandlers provides HTTP handlers for webhook trigger endpoints
@@ -23,7 +26,8 @@ func NewWebhookHandlers(webhookRegistry *trigger.WebhookRegistry, log *logger.Lo
 	}
 }
 
-// HandleWebhook handles POST /api/v1/webhooks/{trigger_id}
+// HandleWebhook handles POST /api/v1/webhooks/{trigger_id} and
+// POST /api/v1/webhooks/{trigger_id}/{path...}
 func (h *WebhookHandlers) HandleWebhook(c *gin.Context) {
 	triggerID := c.Param("trigger_id")
 	if triggerID == "" {
@@ -50,13 +54,20 @@ func (h *WebhookHandlers) HandleWebhook(c *gin.Context) {
 	// Get source IP
 	sourceIP := getSourceIP(c)
 
-	// Execute webhook
-	executionID, err := h.webhookRegistry.ExecuteWebhook(
+	// Execute webhook with the request metadata
+	executionID, err := h.webhookRegistry.ExecuteWebhookRequest(
 		c.Request.Context(),
 		triggerID,
 		payload,
-		headers,
-		sourceIP,
+		&trigger.WebhookRequest{
+			Method:     c.Request.Method,
+			Path:       c.Param("path"),
+			Query:      c.Request.URL.Query(),
+			Headers:    headers,
+			SourceIP:   sourceIP,
+			Host:       c.Request.Host,
+			ReceivedAt: time.Now(),
+		},
 	)
 	if err != nil {
 		// Determine appropriate status code
@@ -71,7 +82,8 @@ func (h *WebhookHandlers) HandleWebhook(c *gin.Context) {
 			statusCode = http.StatusUnauthorized
 		} else if strings.Contains(errorMsg, "IP not whitelisted") {
 			statusCode = http.StatusForbidden
-		} else if strings.Contains(errorMsg, "rate limit exceeded") {
+		} else if strings.Contains(errorMsg, "rate limit exceeded") || errors.Is(err, models.ErrConcurrencyLimitReached) ||
+			errors.Is(err, models.ErrQuotaExceeded) {
 			statusCode = http.StatusTooManyRequests
 		}
 
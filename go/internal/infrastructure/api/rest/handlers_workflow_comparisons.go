@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/smilemakc/mbflow/go/internal/application/serviceapi"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// CompareWorkflowRequest is the body of a workflow comparison request.
+type CompareWorkflowRequest struct {
+	Baseline     models.ComparisonVariant `json:"baseline"`
+	Candidate    models.ComparisonVariant `json:"candidate"`
+	ExecutionIDs []string                 `json:"execution_ids,omitempty"`
+	SampleSize   int                      `json:"sample_size,omitempty"`
+	IgnorePaths  []string                 `json:"ignore_paths,omitempty"`
+}
+
+// toParams converts the request into operation parameters, responding with an error when an execution ID is invalid.
+func (r *CompareWorkflowRequest) toParams(c *gin.Context, workflowUUID uuid.UUID) (serviceapi.CompareWorkflowVariantsParams, bool) {
+	params := serviceapi.CompareWorkflowVariantsParams{
+		WorkflowID:  workflowUUID,
+		Baseline:    r.Baseline,
+		Candidate:   r.Candidate,
+		SampleSize:  r.SampleSize,
+		IgnorePaths: r.IgnorePaths,
+	}
+	for _, id := range r.ExecutionIDs {
+		executionUUID, err := uuid.Parse(id)
+		if err != nil {
+			respondAPIError(c, NewAPIError("INVALID_EXECUTION_ID", "Invalid execution ID: "+id, http.StatusBadRequest))
+			return params, false
+		}
+		params.ExecutionIDs = append(params.ExecutionIDs, executionUUID)
+	}
+	return params, true
+}
+
+// HandleCompareWorkflow replays recorded inputs through two variants of a workflow
+//
+//	@Summary		Compare workflow variants
+//	@Description	Runs the inputs of recorded executions through a baseline and a candidate variant and returns a side-by-side
+//	@Description	report of output differences, durations and estimated LLM costs. A variant is a stored workflow, by default
+//	@Description	this one, with optional node config overrides such as another model. Without execution_ids the most recent
+//	@Description	finished executions are replayed. Replays are real executions, so nodes with side effects run again.
+//	@Tags			workflows
+//	@Accept			json
+//	@Produce		json
+//	@Param			workflow_id	path		string					true	"Workflow ID"	format(uuid)
+//	@Param			request		body		CompareWorkflowRequest	true	"Variants and inputs to compare"
+//	@Success		200			{object}	models.ComparisonReport	"Comparison report"
+//	@Failure		400			{object}	APIError				"Invalid request or identical variants"
+//	@Failure		404			{object}	APIError				"Workflow or execution not found"
+//	@Failure		500			{object}	APIError				"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/workflows/{workflow_id}/compare [post]
+func (h *WorkflowHandlers) HandleCompareWorkflow(c *gin.Context) {
+	workflowUUID, ok := h.parseWorkflowID(c)
+	if !ok {
+		return
+	}
+
+	var req CompareWorkflowRequest
+	if err := bindJSON(c, &req); err != nil {
+		return
+	}
+	params, ok := req.toParams(c, workflowUUID)
+	if !ok {
+		return
+	}
+
+	report, err := h.ops.CompareWorkflowVariants(c.Request.Context(), params)
+	if err != nil {
+		h.logger.Error("Failed to compare workflow variants", "error", err, "workflow_id", workflowUUID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	respondJSON(c, http.StatusOK, report)
+}
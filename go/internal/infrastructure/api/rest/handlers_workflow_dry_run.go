@@ -0,0 +1,61 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smilemakc/mbflow/go/internal/application/serviceapi"
+)
+
+// HandleDryRunWorkflow simulates a run of a workflow
+//
+//	@Summary		Dry-run workflow
+//	@Description	Walks the workflow with the given input: templates are resolved and edge conditions evaluated,
+//	@Description	but nodes whose executors have side effects (HTTP, LLM, ...) are not executed. Their output is
+//	@Description	taken from mock_outputs, keyed by node ID, or is an empty object. Nothing is stored.
+//	@Tags			workflows
+//	@Accept			json
+//	@Produce		json
+//	@Param			workflow_id	path		string																		true	"Workflow ID"	format(uuid)
+//	@Param			request		body		object{input=object,mock_outputs=object,variables=object,profile=string}	false	"Dry run request"
+//	@Success		200			{object}	models.DryRunReport															"Dry run report"
+//	@Failure		400			{object}	APIError																	"Invalid request"
+//	@Failure		404			{object}	APIError																	"Workflow or launch profile not found"
+//	@Failure		500			{object}	APIError																	"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/workflows/{workflow_id}/dry-run [post]
+func (h *WorkflowHandlers) HandleDryRunWorkflow(c *gin.Context) {
+	workflowUUID, ok := h.parseWorkflowID(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Input       map[string]any `json:"input,omitempty"`
+		MockOutputs map[string]any `json:"mock_outputs,omitempty"`
+		Variables   map[string]any `json:"variables,omitempty"`
+		Profile     string         `json:"profile,omitempty"`
+	}
+	if c.Request.ContentLength != 0 {
+		if err := bindJSON(c, &req); err != nil {
+			return
+		}
+	}
+
+	report, err := h.ops.DryRunWorkflow(c.Request.Context(), serviceapi.DryRunWorkflowParams{
+		WorkflowID:  workflowUUID,
+		Input:       req.Input,
+		MockOutputs: req.MockOutputs,
+		Variables:   req.Variables,
+		Profile:     req.Profile,
+		Propagation: executionPropagation(c),
+	})
+	if err != nil {
+		h.logger.Error("Failed to dry-run workflow", "error", err, "workflow_id", workflowUUID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	respondJSON(c, http.StatusOK, report)
+}
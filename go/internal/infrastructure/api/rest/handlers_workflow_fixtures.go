@@ -0,0 +1,172 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/smilemakc/mbflow/go/internal/application/serviceapi"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// HandleListWorkflowFixtures lists the test fixtures of a workflow
+//
+//	@Summary		List workflow fixtures
+//	@Description	Returns the named sample inputs and expected results stored with the workflow
+//	@Tags			workflows
+//	@Produce		json
+//	@Param			workflow_id	path		string									true	"Workflow ID"	format(uuid)
+//	@Success		200			{object}	object{fixtures=object}					"Fixtures keyed by name"
+//	@Failure		400			{object}	APIError								"Invalid workflow ID"
+//	@Failure		404			{object}	APIError								"Workflow not found"
+//	@Failure		500			{object}	APIError								"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/workflows/{workflow_id}/fixtures [get]
+func (h *WorkflowHandlers) HandleListWorkflowFixtures(c *gin.Context) {
+	workflowUUID, ok := h.parseWorkflowID(c)
+	if !ok {
+		return
+	}
+
+	fixtures, err := h.ops.ListWorkflowFixtures(c.Request.Context(), serviceapi.ListWorkflowFixturesParams{
+		WorkflowID: workflowUUID,
+	})
+	if err != nil {
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{"fixtures": fixtures})
+}
+
+// HandlePutWorkflowFixture creates or replaces a workflow test fixture
+//
+//	@Summary		Save workflow fixture
+//	@Description	Creates or replaces the fixture with the given name
+//	@Tags			workflows
+//	@Accept			json
+//	@Produce		json
+//	@Param			workflow_id	path		string					true	"Workflow ID"	format(uuid)
+//	@Param			name		path		string					true	"Fixture name"
+//	@Param			request		body		models.WorkflowFixture	true	"Fixture"
+//	@Success		200			{object}	models.WorkflowFixture	"Saved fixture"
+//	@Failure		400			{object}	APIError				"Invalid workflow ID or fixture"
+//	@Failure		404			{object}	APIError				"Workflow not found"
+//	@Failure		500			{object}	APIError				"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/workflows/{workflow_id}/fixtures/{name} [put]
+func (h *WorkflowHandlers) HandlePutWorkflowFixture(c *gin.Context) {
+	workflowUUID, ok := h.parseWorkflowID(c)
+	if !ok {
+		return
+	}
+
+	var fixture models.WorkflowFixture
+	if err := bindJSON(c, &fixture); err != nil {
+		return
+	}
+
+	saved, err := h.ops.PutWorkflowFixture(c.Request.Context(), serviceapi.PutWorkflowFixtureParams{
+		WorkflowID: workflowUUID,
+		Name:       c.Param("name"),
+		Fixture:    &fixture,
+	})
+	if err != nil {
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	respondJSON(c, http.StatusOK, saved)
+}
+
+// HandleDeleteWorkflowFixture deletes a workflow test fixture
+//
+//	@Summary		Delete workflow fixture
+//	@Tags			workflows
+//	@Produce		json
+//	@Param			workflow_id	path		string		true	"Workflow ID"	format(uuid)
+//	@Param			name		path		string		true	"Fixture name"
+//	@Success		200			{object}	object		"Fixture deleted"
+//	@Failure		400			{object}	APIError	"Invalid workflow ID"
+//	@Failure		404			{object}	APIError	"Workflow or fixture not found"
+//	@Failure		500			{object}	APIError	"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/workflows/{workflow_id}/fixtures/{name} [delete]
+func (h *WorkflowHandlers) HandleDeleteWorkflowFixture(c *gin.Context) {
+	workflowUUID, ok := h.parseWorkflowID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.ops.DeleteWorkflowFixture(c.Request.Context(), serviceapi.DeleteWorkflowFixtureParams{
+		WorkflowID: workflowUUID,
+		Name:       c.Param("name"),
+	}); err != nil {
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{"message": "fixture deleted successfully"})
+}
+
+// HandleRunWorkflowFixtures runs the test fixtures of a workflow
+//
+//	@Summary		Run workflow fixtures
+//	@Description	Executes the workflow once per fixture and checks each execution against the expected status, error and output.
+//	@Description	The body is optional: without names, all fixtures run.
+//	@Tags			workflows
+//	@Accept			json
+//	@Produce		json
+//	@Param			workflow_id	path		string										true	"Workflow ID"	format(uuid)
+//	@Param			request		body		object{names=[]string}						false	"Fixtures to run"
+//	@Success		200			{object}	serviceapi.RunWorkflowFixturesResult		"Fixture report"
+//	@Failure		400			{object}	APIError									"Invalid workflow ID"
+//	@Failure		404			{object}	APIError									"Workflow or fixture not found"
+//	@Failure		500			{object}	APIError									"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/workflows/{workflow_id}/fixtures/run [post]
+func (h *WorkflowHandlers) HandleRunWorkflowFixtures(c *gin.Context) {
+	workflowUUID, ok := h.parseWorkflowID(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Names []string `json:"names,omitempty"`
+	}
+	if c.Request.ContentLength != 0 {
+		if err := bindJSON(c, &req); err != nil {
+			return
+		}
+	}
+
+	report, err := h.ops.RunWorkflowFixtures(c.Request.Context(), serviceapi.RunWorkflowFixturesParams{
+		WorkflowID: workflowUUID,
+		Names:      req.Names,
+	})
+	if err != nil {
+		h.logger.Error("Failed to run workflow fixtures", "error", err, "workflow_id", workflowUUID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	respondJSON(c, http.StatusOK, report)
+}
+
+// parseWorkflowID reads the workflow_id path parameter, responding with an error when it is missing or invalid.
+func (h *WorkflowHandlers) parseWorkflowID(c *gin.Context) (uuid.UUID, bool) {
+	workflowID := c.Param("workflow_id")
+	if workflowID == "" {
+		respondAPIError(c, ErrMissingParameter)
+		return uuid.Nil, false
+	}
+
+	workflowUUID, err := uuid.Parse(workflowID)
+	if err != nil {
+		h.logger.Error("Invalid workflow ID format", "error", err, "workflow_id", workflowID, "request_id", GetRequestID(c))
+		respondAPIError(c, ErrInvalidID)
+		return uuid.Nil, false
+	}
+	return workflowUUID, true
+}
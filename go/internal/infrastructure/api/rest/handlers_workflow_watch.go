@@ -0,0 +1,59 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/smilemakc/mbflow/go/internal/application/observer"
+	"github.com/smilemakc/mbflow/go/internal/application/serviceapi"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+)
+
+// WorkflowWatchHandlers streams the activity of all executions of a workflow to live clients
+type WorkflowWatchHandlers struct {
+	ops    *serviceapi.Operations
+	ws     *observer.WebSocketHandler
+	logger *logger.Logger
+}
+
+// NewWorkflowWatchHandlers creates a new WorkflowWatchHandlers instance. ws is nil when the
+// WebSocket observer is disabled.
+func NewWorkflowWatchHandlers(ops *serviceapi.Operations, ws *observer.WebSocketHandler, log *logger.Logger) *WorkflowWatchHandlers {
+	return &WorkflowWatchHandlers{ops: ops, ws: ws, logger: log}
+}
+
+// HandleWatchWorkflow streams summaries of the executions of a workflow over a WebSocket
+//
+//	@Summary		Watch workflow executions
+//	@Description	Upgrades to a WebSocket that receives an event message whenever an execution of the workflow starts,
+//	@Description	completes, fails, pauses, resumes or is cancelled. Messages carry the execution ID, status, duration and
+//	@Description	error, but no node events or outputs; watch an execution for those. Only executions run by the server
+//	@Description	instance the client is connected to are reported.
+//	@Tags			workflows
+//	@Param			workflow_id	path	string	true	"Workflow ID"	format(uuid)
+//	@Success		101			"Switching protocols"
+//	@Failure		400			{object}	APIError	"Invalid workflow ID"
+//	@Failure		404			{object}	APIError	"Workflow not found"
+//	@Failure		503			{object}	APIError	"WebSocket observer disabled"
+//	@Security		BearerAuth
+//	@Router			/workflows/{workflow_id}/watch [get]
+func (h *WorkflowWatchHandlers) HandleWatchWorkflow(c *gin.Context) {
+	workflowUUID, err := uuid.Parse(c.Param("workflow_id"))
+	if err != nil {
+		respondAPIError(c, ErrInvalidID)
+		return
+	}
+
+	if h.ws == nil {
+		respondAPIError(c, NewAPIError("WATCH_UNAVAILABLE", "workflow watching requires the WebSocket observer (MBFLOW_OBSERVER_WEBSOCKET_ENABLED)", http.StatusServiceUnavailable))
+		return
+	}
+
+	if _, err := h.ops.GetWorkflow(c.Request.Context(), serviceapi.GetWorkflowParams{WorkflowID: workflowUUID}); err != nil {
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	h.ws.ServeWorkflowWatch(c.Writer, c.Request, workflowUUID.String())
+}
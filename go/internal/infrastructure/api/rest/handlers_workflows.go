@@ -2,6 +2,7 @@ package rest
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -23,11 +24,12 @@ func NewWorkflowHandlers(ops *serviceapi.Operations, log *logger.Logger) *Workfl
 // HandleCreateWorkflow creates a new workflow
 //
 //	@Summary		Create a new workflow
-//	@Description	Creates a new workflow with the specified name and optional description, variables, and metadata
+//	@Description	Creates a new workflow with the specified name and optional description, variables, launch profiles, metadata,
+//	@Description	and triggers, which are created with the workflow
 //	@Tags			workflows
 //	@Accept			json
 //	@Produce		json
-//	@Param			request	body		object{name=string,description=string,variables=object,metadata=object}	true	"Workflow creation request"
+//	@Param			request	body		object{name=string,description=string,variables=object,launch_profiles=object,metadata=object,triggers=[]models.Trigger}	true	"Workflow creation request"
 //	@Success		201		{object}	models.Workflow											"Created workflow"
 //	@Failure		400		{object}	APIError												"Invalid request"
 //	@Failure		401		{object}	APIError												"Unauthorized"
@@ -36,10 +38,12 @@ func NewWorkflowHandlers(ops *serviceapi.Operations, log *logger.Logger) *Workfl
 //	@Router			/workflows [post]
 func (h *WorkflowHandlers) HandleCreateWorkflow(c *gin.Context) {
 	var req struct {
-		Name        string         `json:"name" binding:"required"`
-		Description string         `json:"description,omitempty"`
-		Variables   map[string]any `json:"variables,omitempty"`
-		Metadata    map[string]any `json:"metadata,omitempty"`
+		Name           string                           `json:"name" binding:"required"`
+		Description    string                           `json:"description,omitempty"`
+		Variables      map[string]any                   `json:"variables,omitempty"`
+		LaunchProfiles map[string]*models.LaunchProfile `json:"launch_profiles,omitempty"`
+		Metadata       map[string]any                   `json:"metadata,omitempty"`
+		Triggers       []*models.Trigger                `json:"triggers,omitempty"`
 	}
 
 	if err := bindJSON(c, &req); err != nil {
@@ -47,10 +51,12 @@ func (h *WorkflowHandlers) HandleCreateWorkflow(c *gin.Context) {
 	}
 
 	params := serviceapi.CreateWorkflowParams{
-		Name:        req.Name,
-		Description: req.Description,
-		Variables:   req.Variables,
-		Metadata:    req.Metadata,
+		Name:           req.Name,
+		Description:    req.Description,
+		Variables:      req.Variables,
+		LaunchProfiles: req.LaunchProfiles,
+		Metadata:       req.Metadata,
+		Triggers:       req.Triggers,
 	}
 
 	if userID, ok := GetUserIDAsUUID(c); ok {
@@ -168,13 +174,14 @@ func (h *WorkflowHandlers) HandleListWorkflows(c *gin.Context) {
 }
 
 type UpdateWorkflowRequest struct {
-	Name        string            `json:"name,omitempty"`
-	Description string            `json:"description,omitempty"`
-	Variables   map[string]any    `json:"variables,omitempty"`
-	Metadata    map[string]any    `json:"metadata,omitempty"`
-	Nodes       []NodeRequest     `json:"nodes,omitempty"`
-	Edges       []EdgeRequest     `json:"edges,omitempty"`
-	Resources   []ResourceRequest `json:"resources,omitempty"`
+	Name           string                           `json:"name,omitempty"`
+	Description    string                           `json:"description,omitempty"`
+	Variables      map[string]any                   `json:"variables,omitempty"`
+	LaunchProfiles map[string]*models.LaunchProfile `json:"launch_profiles,omitempty"`
+	Metadata       map[string]any                   `json:"metadata,omitempty"`
+	Nodes          []NodeRequest                    `json:"nodes,omitempty"`
+	Edges          []EdgeRequest                    `json:"edges,omitempty"`
+	Resources      []ResourceRequest                `json:"resources,omitempty"`
 }
 
 type ResourceRequest struct {
@@ -200,12 +207,13 @@ type EdgeRequest struct {
 	Loop         *struct {
 		MaxIterations int `json:"max_iterations"`
 	} `json:"loop,omitempty"`
+	Type string `json:"type,omitempty"`
 }
 
 // HandleUpdateWorkflow updates an existing workflow
 //
 //	@Summary		Update workflow
-//	@Description	Updates a workflow's name, description, variables, metadata, nodes, edges, and resources
+//	@Description	Updates a workflow's name, description, variables, launch profiles, metadata, nodes, edges, and resources
 //	@Tags			workflows
 //	@Accept			json
 //	@Produce		json
@@ -237,11 +245,12 @@ func (h *WorkflowHandlers) HandleUpdateWorkflow(c *gin.Context) {
 	}
 
 	params := serviceapi.UpdateWorkflowParams{
-		WorkflowID:  workflowUUID,
-		Name:        req.Name,
-		Description: req.Description,
-		Variables:   req.Variables,
-		Metadata:    req.Metadata,
+		WorkflowID:     workflowUUID,
+		Name:           req.Name,
+		Description:    req.Description,
+		Variables:      req.Variables,
+		LaunchProfiles: req.LaunchProfiles,
+		Metadata:       req.Metadata,
 	}
 
 	if req.Nodes != nil {
@@ -266,6 +275,7 @@ func (h *WorkflowHandlers) HandleUpdateWorkflow(c *gin.Context) {
 				To:           e.To,
 				SourceHandle: e.SourceHandle,
 				Condition:    e.Condition,
+				Type:         e.Type,
 			}
 			if e.Loop != nil {
 				ei.Loop = &serviceapi.LoopInput{MaxIterations: e.Loop.MaxIterations}
@@ -337,7 +347,8 @@ func (h *WorkflowHandlers) HandleDeleteWorkflow(c *gin.Context) {
 // HandlePublishWorkflow publishes a workflow
 //
 //	@Summary		Publish workflow
-//	@Description	Publishes a workflow, making it available for execution
+//	@Description	Publishes a workflow, making it available for execution. Checks listed in the workflow's
+//	@Description	publish_checks metadata must pass first; otherwise the checklist report is returned in details.report.
 //	@Tags			workflows
 //	@Accept			json
 //	@Produce		json
@@ -345,6 +356,7 @@ func (h *WorkflowHandlers) HandleDeleteWorkflow(c *gin.Context) {
 //	@Success		200			{object}	models.Workflow	"Published workflow"
 //	@Failure		400			{object}	APIError		"Invalid workflow ID or workflow cannot be published"
 //	@Failure		404			{object}	APIError		"Workflow not found"
+//	@Failure		422			{object}	APIError		"Required publish checks failed"
 //	@Failure		500			{object}	APIError		"Internal server error"
 //	@Security		BearerAuth
 //	@Router			/workflows/{workflow_id}/publish [post]
@@ -374,6 +386,49 @@ func (h *WorkflowHandlers) HandlePublishWorkflow(c *gin.Context) {
 	respondJSON(c, http.StatusOK, workflow)
 }
 
+// HandleCheckWorkflowPublish runs publish checks without publishing the workflow
+//
+//	@Summary		Check workflow publish readiness
+//	@Description	Runs the given publish checks, or the ones the workflow requires, and returns the checklist report
+//	@Tags			workflows
+//	@Produce		json
+//	@Param			workflow_id	path		string					true	"Workflow ID"	format(uuid)
+//	@Param			checks		query		string					false	"Comma-separated checks: lint, no_plaintext_secrets, input_schema, fixtures"
+//	@Success		200			{object}	models.PublishReport	"Checklist report"
+//	@Failure		400			{object}	APIError				"Invalid workflow ID or check name"
+//	@Failure		404			{object}	APIError				"Workflow not found"
+//	@Failure		500			{object}	APIError				"Internal server error"
+//	@Security		BearerAuth
+//	@Router			/workflows/{workflow_id}/publish/checks [get]
+func (h *WorkflowHandlers) HandleCheckWorkflowPublish(c *gin.Context) {
+	workflowUUID, ok := h.parseWorkflowID(c)
+	if !ok {
+		return
+	}
+
+	var checks []models.PublishCheck
+	if raw := c.Query("checks"); raw != "" {
+		parsed, err := models.ParsePublishChecks(strings.Split(raw, ","))
+		if err != nil {
+			respondAPIError(c, NewAPIError("INVALID_PARAMETER", "checks: "+err.Error(), http.StatusBadRequest))
+			return
+		}
+		checks = parsed
+	}
+
+	report, err := h.ops.CheckWorkflowPublish(c.Request.Context(), serviceapi.CheckWorkflowPublishParams{
+		WorkflowID: workflowUUID,
+		Checks:     checks,
+	})
+	if err != nil {
+		h.logger.Error("Failed to check workflow publish", "error", err, "workflow_id", workflowUUID, "request_id", GetRequestID(c))
+		respondAPIErrorWithRequestID(c, TranslateError(err))
+		return
+	}
+
+	respondJSON(c, http.StatusOK, report)
+}
+
 // HandleUnpublishWorkflow unpublishes a workflow
 //
 //	@Summary		Unpublish workflow
@@ -9,6 +9,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
 )
 
 // parseIntQuery parses integer query parameter with default value
@@ -134,3 +136,25 @@ func getQueryInt(c *gin.Context, name string, defaultValue int) int {
 	value := c.Query(name)
 	return parseIntQuery(value, defaultValue)
 }
+
+// HeaderIdempotencyKey identifies an execution start; a retried start with the same key
+// returns the execution started first.
+const HeaderIdempotencyKey = "Idempotency-Key"
+
+// executionPropagation builds the propagation context for an execution started by this request.
+// The correlation ID is taken from the X-Correlation-ID header and falls back to the request ID;
+// the workspace from X-MBFlow-Workspace-ID and baggage from the W3C baggage header.
+func executionPropagation(c *gin.Context) executor.Propagation {
+	propagation := executor.Propagation{
+		CorrelationID: c.GetHeader(executor.HeaderCorrelationID),
+		WorkspaceID:   c.GetHeader(executor.HeaderWorkspaceID),
+		Baggage:       executor.ParseBaggage(c.GetHeader(executor.HeaderBaggage)),
+	}
+	if propagation.CorrelationID == "" {
+		propagation.CorrelationID = GetRequestID(c)
+	}
+	if userID, ok := GetUserID(c); ok {
+		propagation.UserID = userID
+	}
+	return propagation
+}
@@ -0,0 +1,107 @@
+package rest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smilemakc/mbflow/go/internal/application/maintenance"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/logger"
+)
+
+// readOnlyRetryAfterSeconds is the Retry-After hint sent with 503 responses.
+const readOnlyRetryAfterSeconds = "60"
+
+// ReadOnlyMiddleware rejects mutating requests while the instance is in read-only mode.
+type ReadOnlyMiddleware struct {
+	mode   *maintenance.ReadOnlyMode
+	exempt []string
+	logger *logger.Logger
+}
+
+// NewReadOnlyMiddleware creates the middleware. Paths in exempt (matched by prefix)
+// stay writable, e.g. login and the admin toggle itself.
+func NewReadOnlyMiddleware(mode *maintenance.ReadOnlyMode, log *logger.Logger, exempt ...string) *ReadOnlyMiddleware {
+	return &ReadOnlyMiddleware{
+		mode:   mode,
+		exempt: exempt,
+		logger: log,
+	}
+}
+
+// Enforce returns a gin middleware that answers mutations with 503 while read-only mode is on.
+// GET, HEAD and OPTIONS requests always pass through.
+func (m *ReadOnlyMiddleware) Enforce() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		enabled, message := m.mode.Enabled()
+		if !enabled || isSafeMethod(c.Request.Method) || m.isExempt(c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+
+		c.Header("Retry-After", readOnlyRetryAfterSeconds)
+		respondErrorWithDetails(c, http.StatusServiceUnavailable, message, "READ_ONLY_MODE", map[string]any{
+			"read_only": true,
+		})
+		c.Abort()
+	}
+}
+
+func (m *ReadOnlyMiddleware) isExempt(path string) bool {
+	for _, prefix := range m.exempt {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// MaintenanceHandlers exposes the read-only toggle to administrators.
+type MaintenanceHandlers struct {
+	mode   *maintenance.ReadOnlyMode
+	logger *logger.Logger
+}
+
+// NewMaintenanceHandlers creates maintenance handlers.
+func NewMaintenanceHandlers(mode *maintenance.ReadOnlyMode, log *logger.Logger) *MaintenanceHandlers {
+	return &MaintenanceHandlers{
+		mode:   mode,
+		logger: log,
+	}
+}
+
+// SetReadOnlyRequest is the request body for toggling read-only mode.
+type SetReadOnlyRequest struct {
+	Enabled bool   `json:"enabled"`
+	Message string `json:"message,omitempty"`
+}
+
+// HandleGetReadOnly handles GET /api/v1/admin/read-only
+func (h *MaintenanceHandlers) HandleGetReadOnly(c *gin.Context) {
+	respondJSON(c, http.StatusOK, h.mode.Status())
+}
+
+// HandleSetReadOnly handles PUT /api/v1/admin/read-only
+func (h *MaintenanceHandlers) HandleSetReadOnly(c *gin.Context) {
+	var req SetReadOnlyRequest
+	if err := bindJSON(c, &req); err != nil {
+		return
+	}
+
+	userID, _ := GetUserID(c)
+	if req.Enabled {
+		h.mode.Enable(req.Message, userID)
+	} else {
+		h.mode.Disable()
+	}
+
+	if h.logger != nil {
+		h.logger.Warn("Read-only mode changed", "enabled", req.Enabled, "user_id", userID)
+	}
+
+	respondJSON(c, http.StatusOK, h.mode.Status())
+}
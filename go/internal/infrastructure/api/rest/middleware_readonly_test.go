@@ -0,0 +1,73 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smilemakc/mbflow/go/internal/application/maintenance"
+)
+
+func setupReadOnlyRouter(mode *maintenance.ReadOnlyMode) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(NewReadOnlyMiddleware(mode, nil, "/api/v1/admin/read-only").Enforce())
+
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/api/v1/workflows", ok)
+	router.POST("/api/v1/workflows", ok)
+	router.POST("/api/v1/executions", ok)
+	router.PUT("/api/v1/admin/read-only", ok)
+	return router
+}
+
+func TestReadOnlyMiddleware_Disabled(t *testing.T) {
+	router := setupReadOnlyRouter(maintenance.NewReadOnlyMode(false, ""))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/workflows", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestReadOnlyMiddleware_Enabled(t *testing.T) {
+	router := setupReadOnlyRouter(maintenance.NewReadOnlyMode(true, "Back at 14:00 UTC"))
+
+	tests := []struct {
+		method string
+		path   string
+		want   int
+	}{
+		{http.MethodGet, "/api/v1/workflows", http.StatusOK},
+		{http.MethodPost, "/api/v1/workflows", http.StatusServiceUnavailable},
+		{http.MethodPost, "/api/v1/executions", http.StatusServiceUnavailable},
+		{http.MethodPut, "/api/v1/admin/read-only", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestReadOnlyMiddleware_ResponseBody(t *testing.T) {
+	router := setupReadOnlyRouter(maintenance.NewReadOnlyMode(true, "Back at 14:00 UTC"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/executions", nil))
+
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "READ_ONLY_MODE", body["code"])
+	assert.Equal(t, "Back at 14:00 UTC", body["message"])
+}
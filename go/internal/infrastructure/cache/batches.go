@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/smilemakc/mbflow/go/pkg/executor/builtin"
+)
+
+// batchAddScript appends ARGV[1] to the items list KEYS[1], opening the batch described by
+// the hash KEYS[2] with ID ARGV[2] and opening time ARGV[3] if there is none. A positive
+// ARGV[4] (ms) expires both keys that long after the last item.
+var batchAddScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[2]) == 0 then
+  redis.call('HSET', KEYS[2], 'id', ARGV[2], 'opened_at', ARGV[3])
+end
+local size = redis.call('RPUSH', KEYS[1], ARGV[1])
+local ttl = tonumber(ARGV[4])
+if ttl > 0 then
+  redis.call('PEXPIRE', KEYS[1], ttl)
+  redis.call('PEXPIRE', KEYS[2], ttl)
+end
+local meta = redis.call('HMGET', KEYS[2], 'id', 'opened_at')
+return {meta[1], size, meta[2]}
+`)
+
+// batchTakeScript removes and returns the items of the batch if its ID is ARGV[1].
+var batchTakeScript = redis.NewScript(`
+if redis.call('HGET', KEYS[2], 'id') ~= ARGV[1] then
+  return false
+end
+local items = redis.call('LRANGE', KEYS[1], 0, -1)
+redis.call('DEL', KEYS[1], KEYS[2])
+return items
+`)
+
+// BatchStore keeps the open batches of batcher nodes in the namespace of the workspace the
+// execution runs in (see RedisCache.Tenant), so they survive restarts, are shared by every
+// instance and count against the workspace quota. Under a workspace TTL policy a batch left
+// without new items for that long expires. It satisfies builtin.BatchStore.
+type BatchStore struct {
+	cache *RedisCache
+}
+
+// NewBatchStore creates a batch store on the given cache.
+func NewBatchStore(c *RedisCache) *BatchStore {
+	return &BatchStore{cache: c}
+}
+
+// Add appends item to the open batch under key, opening a batch if there is none.
+func (s *BatchStore) Add(ctx context.Context, key string, item []byte) (builtin.OpenBatch, error) {
+	ns := s.cache.Tenant(ctx)
+	ttl := ns.policy.effectiveTTL(0)
+	if ns.tracked() {
+		for _, k := range batchKeys(key) {
+			if err := ns.reserve(ctx, k, ttl); err != nil {
+				return builtin.OpenBatch{}, err
+			}
+		}
+	}
+
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	result, err := batchAddScript.Run(ctx, s.cache.client, ns.keys(batchKeys(key)), item, uuid.New().String(), now, ttl.Milliseconds()).Slice()
+	if err != nil {
+		return builtin.OpenBatch{}, err
+	}
+	if len(result) != 3 {
+		return builtin.OpenBatch{}, fmt.Errorf("unexpected batch add result: %v", result)
+	}
+
+	id, _ := result[0].(string)
+	size, _ := result[1].(int64)
+	openedAtMs, err := strconv.ParseInt(fmt.Sprint(result[2]), 10, 64)
+	if err != nil {
+		return builtin.OpenBatch{}, fmt.Errorf("invalid batch opening time: %w", err)
+	}
+	return builtin.OpenBatch{ID: id, Size: int(size), OpenedAt: time.UnixMilli(openedAtMs)}, nil
+}
+
+// Take removes and returns the items of the batch under key if it is still the batch with the ID.
+func (s *BatchStore) Take(ctx context.Context, key, batchID string) ([][]byte, bool, error) {
+	ns := s.cache.Tenant(ctx)
+	result, err := batchTakeScript.Run(ctx, s.cache.client, ns.keys(batchKeys(key)), batchID).StringSlice()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if ns.tracked() {
+		keys := batchKeys(key)
+		if err := s.cache.client.ZRem(ctx, ns.indexKey(), keys[0], keys[1]).Err(); err != nil {
+			return nil, false, err
+		}
+	}
+
+	items := make([][]byte, len(result))
+	for i, item := range result {
+		items[i] = []byte(item)
+	}
+	return items, true, nil
+}
+
+// batchKeys returns the namespace-relative keys of the items list and the metadata hash of
+// the batch under key.
+func batchKeys(key string) []string {
+	return []string{key + ":items", key + ":meta"}
+}
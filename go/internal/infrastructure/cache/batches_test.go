@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchStore_AddTake(t *testing.T) {
+	s := miniredis.RunT(t)
+	cache := setupCache(t, s)
+	defer cache.Close()
+
+	store := NewBatchStore(cache)
+	ctx := context.Background()
+
+	first, err := store.Add(ctx, "batcher:wf:node", []byte(`"a"`))
+	require.NoError(t, err)
+	assert.Equal(t, 1, first.Size)
+	assert.NotEmpty(t, first.ID)
+	assert.True(t, s.Exists("mbflow:system:batcher:wf:node:items"))
+
+	second, err := store.Add(ctx, "batcher:wf:node", []byte(`"b"`))
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+	assert.Equal(t, 2, second.Size)
+	assert.Equal(t, first.OpenedAt, second.OpenedAt)
+
+	_, ok, err := store.Take(ctx, "batcher:wf:node", "other-batch")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	items, ok, err := store.Take(ctx, "batcher:wf:node", first.ID)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, [][]byte{[]byte(`"a"`), []byte(`"b"`)}, items)
+
+	// Released batches cannot be taken twice, and the next item opens a new one
+	_, ok, err = store.Take(ctx, "batcher:wf:node", first.ID)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	next, err := store.Add(ctx, "batcher:wf:node", []byte(`"c"`))
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID, next.ID)
+	assert.Equal(t, 1, next.Size)
+}
+
+func TestBatchStore_WorkspaceNamespace(t *testing.T) {
+	s := miniredis.RunT(t)
+	cache := setupCache(t, s)
+	defer cache.Close()
+
+	cache.SetDefaultWorkspacePolicy(NamespacePolicy{MaxKeys: 2, DefaultTTL: time.Hour})
+	store := NewBatchStore(cache)
+	ctx := executor.WithExecutionContext(context.Background(), &executor.ExecutionContextData{
+		Propagation: executor.Propagation{WorkspaceID: "ws-1"},
+	})
+
+	batch, err := store.Add(ctx, "batcher:wf:node", []byte(`"a"`))
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, s.TTL("mbflow:ws:ws-1:batcher:wf:node:items"))
+	assert.Equal(t, time.Hour, s.TTL("mbflow:ws:ws-1:batcher:wf:node:meta"))
+
+	// A batch uses two keys of the quota
+	_, err = store.Add(ctx, "batcher:wf:other", []byte(`"b"`))
+	assert.ErrorIs(t, err, ErrQuotaExceeded)
+
+	// Taking the batch gives them back
+	_, ok, err := store.Take(ctx, "batcher:wf:node", batch.ID)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	_, err = store.Add(ctx, "batcher:wf:other", []byte(`"b"`))
+	require.NoError(t, err)
+}
@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// concurrencyAcquireScript gives execution ARGV[1] a slot of a workflow until ARGV[4] (unix ms)
+// if fewer than ARGV[2] are held and no execution in line asked first. KEYS: slots (sorted by
+// expiry), line (sorted by first ask), last ask per execution in line. ARGV[3] is now (unix ms),
+// ARGV[5] is 1 to put the execution in line and ARGV[6] the TTL (ms) of slots and places in line.
+var concurrencyAcquireScript = redis.NewScript(`
+local id = ARGV[1]
+local limit = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[6])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now)
+local acquired = 0
+if redis.call('ZSCORE', KEYS[1], id) then
+  redis.call('ZADD', KEYS[1], ARGV[4], id)
+  acquired = 1
+else
+  for _, waiting in ipairs(redis.call('ZRANGE', KEYS[2], 0, -1)) do
+    local seen = tonumber(redis.call('HGET', KEYS[3], waiting) or 0)
+    if seen + ttl < now then
+      redis.call('ZREM', KEYS[2], waiting)
+      redis.call('HDEL', KEYS[3], waiting)
+    end
+  end
+  local ahead = redis.call('ZRANK', KEYS[2], id) or redis.call('ZCARD', KEYS[2])
+  if ahead < limit - redis.call('ZCARD', KEYS[1]) then
+    redis.call('ZADD', KEYS[1], ARGV[4], id)
+    redis.call('ZREM', KEYS[2], id)
+    redis.call('HDEL', KEYS[3], id)
+    acquired = 1
+  elseif ARGV[5] == '1' then
+    redis.call('ZADD', KEYS[2], 'NX', now, id)
+    redis.call('HSET', KEYS[3], id, now)
+  end
+end
+for _, key in ipairs(KEYS) do
+  redis.call('PEXPIRE', key, ttl)
+end
+return acquired
+`)
+
+// concurrencyExtendScript keeps the slot of execution ARGV[1] until ARGV[2] (unix ms).
+var concurrencyExtendScript = redis.NewScript(`
+redis.call('ZADD', KEYS[1], ARGV[2], ARGV[1])
+redis.call('PEXPIRE', KEYS[1], ARGV[3])
+return 1
+`)
+
+// ConcurrencyLimiter keeps the slots of workflow concurrency limits in the system namespace,
+// so every instance shares them. Per workflow it keeps the slots sorted by expiry, the line of
+// waiting executions sorted by when they first asked, and when each of them last asked. It
+// satisfies engine.ConcurrencyLimiter.
+type ConcurrencyLimiter struct {
+	ns *NamespacedCache
+}
+
+// NewConcurrencyLimiter creates a concurrency limiter on the given cache.
+func NewConcurrencyLimiter(c *RedisCache) *ConcurrencyLimiter {
+	return &ConcurrencyLimiter{ns: c.System()}
+}
+
+// Acquire gives the execution a slot of the workflow if one is free and no execution asked first.
+func (l *ConcurrencyLimiter) Acquire(ctx context.Context, workflowID, executionID string, limit int, wait bool, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	waitFlag := 0
+	if wait {
+		waitFlag = 1
+	}
+	acquired, err := concurrencyAcquireScript.Run(ctx, l.ns.cache.client, l.keys(workflowID),
+		executionID, limit, now.UnixMilli(), now.Add(ttl).UnixMilli(), waitFlag, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return acquired == 1, nil
+}
+
+// Extend keeps the slot of the execution until now+ttl.
+func (l *ConcurrencyLimiter) Extend(ctx context.Context, workflowID, executionID string, ttl time.Duration) error {
+	return concurrencyExtendScript.Run(ctx, l.ns.cache.client, l.keys(workflowID)[:1],
+		executionID, time.Now().Add(ttl).UnixMilli(), ttl.Milliseconds()).Err()
+}
+
+// Release frees the slot of the execution, or its place in line.
+func (l *ConcurrencyLimiter) Release(ctx context.Context, workflowID, executionID string) error {
+	keys := l.keys(workflowID)
+	_, err := l.ns.cache.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, keys[0], executionID)
+		pipe.ZRem(ctx, keys[1], executionID)
+		pipe.HDel(ctx, keys[2], executionID)
+		return nil
+	})
+	return err
+}
+
+// keys returns the keys of the slots, the line and the last asks of a workflow.
+func (l *ConcurrencyLimiter) keys(workflowID string) []string {
+	prefix := "workflow_concurrency:" + workflowID
+	return []string{
+		l.ns.Key(prefix + ":slots"),
+		l.ns.Key(prefix + ":line"),
+		l.ns.Key(prefix + ":asked"),
+	}
+}
@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrencyLimiter_Line(t *testing.T) {
+	s := miniredis.RunT(t)
+	cache := setupCache(t, s)
+	defer cache.Close()
+
+	limiter := NewConcurrencyLimiter(cache)
+	ctx := context.Background()
+
+	acquire := func(executionID string, wait bool) bool {
+		acquired, err := limiter.Acquire(ctx, "wf-1", executionID, 2, wait, time.Minute)
+		require.NoError(t, err)
+		return acquired
+	}
+
+	assert.True(t, acquire("exec-1", true))
+	assert.True(t, acquire("exec-2", true))
+	assert.True(t, acquire("exec-2", true), "a held slot is kept")
+	assert.False(t, acquire("exec-3", true))
+	assert.False(t, acquire("exec-4", true))
+
+	other, err := limiter.Acquire(ctx, "wf-2", "exec-5", 2, false, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, other, "each workflow has its own slots")
+
+	require.NoError(t, limiter.Release(ctx, "wf-1", "exec-1"))
+	assert.False(t, acquire("exec-6", false), "executions in line go first")
+	assert.False(t, acquire("exec-4", true), "executions in line get slots in order")
+	assert.True(t, acquire("exec-3", true))
+
+	// A waiting execution leaving the line lets the next one through
+	require.NoError(t, limiter.Release(ctx, "wf-1", "exec-2"))
+	require.NoError(t, limiter.Release(ctx, "wf-1", "exec-4"))
+	assert.True(t, acquire("exec-6", false))
+}
+
+func TestConcurrencyLimiter_SlotsExpire(t *testing.T) {
+	s := miniredis.RunT(t)
+	cache := setupCache(t, s)
+	defer cache.Close()
+
+	limiter := NewConcurrencyLimiter(cache)
+	ctx := context.Background()
+
+	// The instance holding exec-1 stops before extending its slot
+	acquired, err := limiter.Acquire(ctx, "wf-1", "exec-1", 1, false, -time.Second)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	acquired, err = limiter.Acquire(ctx, "wf-1", "exec-2", 1, false, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	require.NoError(t, limiter.Extend(ctx, "wf-1", "exec-2", time.Minute))
+	acquired, err = limiter.Acquire(ctx, "wf-1", "exec-3", 1, false, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+}
@@ -0,0 +1,165 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/smilemakc/mbflow/go/internal/application/engine"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// queueEnqueueScript stores the payload ARGV[2] of execution ARGV[1] and adds it to the
+// pending set with score ARGV[3].
+var queueEnqueueScript = redis.NewScript(`
+redis.call('HSET', KEYS[2], ARGV[1], ARGV[2])
+redis.call('HDEL', KEYS[5], ARGV[1])
+redis.call('ZADD', KEYS[1], ARGV[3], ARGV[1])
+return 1
+`)
+
+// queueLeaseScript takes the pending execution with the lowest score for worker ARGV[1]
+// until ARGV[2] (unix ms).
+var queueLeaseScript = redis.NewScript(`
+local next = redis.call('ZRANGE', KEYS[1], 0, 0)
+local id = next[1]
+if not id then
+  return false
+end
+redis.call('ZREM', KEYS[1], id)
+redis.call('ZADD', KEYS[3], ARGV[2], id)
+redis.call('HSET', KEYS[4], id, ARGV[1])
+local attempt = redis.call('HINCRBY', KEYS[5], id, 1)
+local payload = redis.call('HGET', KEYS[2], id)
+return {id, payload or '', attempt}
+`)
+
+// queueExtendScript keeps the lease of execution ARGV[1] until ARGV[3] if worker ARGV[2] holds it.
+var queueExtendScript = redis.NewScript(`
+if redis.call('HGET', KEYS[2], ARGV[1]) ~= ARGV[2] then
+  return 0
+end
+redis.call('ZADD', KEYS[1], ARGV[3], ARGV[1])
+return 1
+`)
+
+// queueCompleteScript removes execution ARGV[1] if worker ARGV[2] holds its lease.
+var queueCompleteScript = redis.NewScript(`
+if redis.call('HGET', KEYS[3], ARGV[1]) ~= ARGV[2] then
+  return 0
+end
+redis.call('ZREM', KEYS[2], ARGV[1])
+redis.call('HDEL', KEYS[1], ARGV[1])
+redis.call('HDEL', KEYS[3], ARGV[1])
+redis.call('HDEL', KEYS[4], ARGV[1])
+return 1
+`)
+
+// queueReclaimScript puts the executions whose lease expired before ARGV[1] (unix ms) back at
+// the head of the pending set.
+var queueReclaimScript = redis.NewScript(`
+local ids = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1])
+for _, id in ipairs(ids) do
+  redis.call('ZREM', KEYS[1], id)
+  redis.call('HDEL', KEYS[2], id)
+  redis.call('ZADD', KEYS[3], 0, id)
+end
+return #ids
+`)
+
+// ExecutionQueue queues executions for workers in the system namespace: the pending
+// executions sorted by score, the payloads, the leases sorted by expiry with their workers,
+// and the lease attempts. It satisfies engine.ExecutionQueue.
+//
+// The score of a pending execution is its enqueue time (unix ms) less the aging step for
+// every priority rank above low, and the lowest score is leased first. A critical execution
+// thus goes ahead of low-priority ones queued up to three aging steps before it, and no
+// execution waits behind executions queued more than three steps after it.
+type ExecutionQueue struct {
+	ns    *NamespacedCache
+	aging time.Duration
+}
+
+// NewExecutionQueue creates an execution queue on the given cache. An execution waiting for
+// aging ranks with executions one priority above it that are queued now (1 minute when <= 0).
+func NewExecutionQueue(c *RedisCache, aging time.Duration) *ExecutionQueue {
+	if aging <= 0 {
+		aging = time.Minute
+	}
+	return &ExecutionQueue{ns: c.System(), aging: aging}
+}
+
+// Enqueue adds an execution with its priority and the payload needed to run it.
+func (q *ExecutionQueue) Enqueue(ctx context.Context, executionID string, priority models.ExecutionPriority, payload []byte) error {
+	score := time.Now().Add(-time.Duration(priority.Rank()) * q.aging).UnixMilli()
+	return queueEnqueueScript.Run(ctx, q.ns.cache.client, q.keys(), executionID, payload, score).Err()
+}
+
+// Lease takes the next execution for the worker until now+ttl; it returns nil if the queue is empty.
+func (q *ExecutionQueue) Lease(ctx context.Context, workerID string, ttl time.Duration) (*engine.ExecutionLease, error) {
+	expiry := time.Now().Add(ttl).UnixMilli()
+	result, err := queueLeaseScript.Run(ctx, q.ns.cache.client, q.keys(), workerID, expiry).Slice()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(result) != 3 {
+		return nil, fmt.Errorf("unexpected execution lease result: %v", result)
+	}
+
+	id, _ := result[0].(string)
+	payload, _ := result[1].(string)
+	attempt, _ := result[2].(int64)
+	return &engine.ExecutionLease{
+		ExecutionID: id,
+		WorkerID:    workerID,
+		Payload:     []byte(payload),
+		Attempt:     int(attempt),
+	}, nil
+}
+
+// Extend keeps a lease until now+ttl; it reports false if the worker no longer holds it.
+func (q *ExecutionQueue) Extend(ctx context.Context, lease *engine.ExecutionLease, ttl time.Duration) (bool, error) {
+	keys := q.keys()
+	expiry := time.Now().Add(ttl).UnixMilli()
+	extended, err := queueExtendScript.Run(ctx, q.ns.cache.client, []string{keys[2], keys[3]},
+		lease.ExecutionID, lease.WorkerID, expiry).Int()
+	if err != nil {
+		return false, err
+	}
+	return extended == 1, nil
+}
+
+// Complete removes a leased execution from the queue. Nothing is removed if the lease
+// was reassigned to another worker.
+func (q *ExecutionQueue) Complete(ctx context.Context, lease *engine.ExecutionLease) error {
+	keys := q.keys()
+	return queueCompleteScript.Run(ctx, q.ns.cache.client, keys[1:],
+		lease.ExecutionID, lease.WorkerID).Err()
+}
+
+// ReclaimExpired queues the executions whose lease expired again and returns how many.
+func (q *ExecutionQueue) ReclaimExpired(ctx context.Context) (int, error) {
+	keys := q.keys()
+	reclaimed, err := queueReclaimScript.Run(ctx, q.ns.cache.client, []string{keys[2], keys[3], keys[0]}, time.Now().UnixMilli()).Int()
+	if err != nil {
+		return 0, err
+	}
+	return reclaimed, nil
+}
+
+// keys returns the keys of the pending set, the payloads, the leases, the lease owners and
+// the lease attempts.
+func (q *ExecutionQueue) keys() []string {
+	return []string{
+		q.ns.Key("execution_queue:pending"),
+		q.ns.Key("execution_queue:payloads"),
+		q.ns.Key("execution_queue:leases"),
+		q.ns.Key("execution_queue:workers"),
+		q.ns.Key("execution_queue:attempts"),
+	}
+}
@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionQueue_LeaseComplete(t *testing.T) {
+	s := miniredis.RunT(t)
+	cache := setupCache(t, s)
+	defer cache.Close()
+
+	queue := NewExecutionQueue(cache, time.Minute)
+	ctx := context.Background()
+
+	lease, err := queue.Lease(ctx, "worker-1", time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, lease, "empty queue")
+
+	require.NoError(t, queue.Enqueue(ctx, "exec-1", models.ExecutionPriorityNormal, []byte(`{"a":1}`)))
+	require.NoError(t, queue.Enqueue(ctx, "exec-2", models.ExecutionPriorityNormal, []byte(`{"b":2}`)))
+
+	lease, err = queue.Lease(ctx, "worker-1", time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, lease)
+	assert.Equal(t, "exec-1", lease.ExecutionID, "executions are leased in order")
+	assert.Equal(t, "worker-1", lease.WorkerID)
+	assert.Equal(t, []byte(`{"a":1}`), lease.Payload)
+	assert.Equal(t, 1, lease.Attempt)
+
+	extended, err := queue.Extend(ctx, lease, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, extended)
+
+	require.NoError(t, queue.Complete(ctx, lease))
+	extended, err = queue.Extend(ctx, lease, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, extended, "completed executions have no lease")
+
+	next, err := queue.Lease(ctx, "worker-2", time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, "exec-2", next.ExecutionID)
+}
+
+func TestExecutionQueue_ReclaimExpired(t *testing.T) {
+	s := miniredis.RunT(t)
+	cache := setupCache(t, s)
+	defer cache.Close()
+
+	queue := NewExecutionQueue(cache, time.Minute)
+	ctx := context.Background()
+
+	require.NoError(t, queue.Enqueue(ctx, "exec-1", models.ExecutionPriorityNormal, []byte(`{}`)))
+	require.NoError(t, queue.Enqueue(ctx, "exec-2", models.ExecutionPriorityNormal, []byte(`{}`)))
+
+	// worker-1 dies holding exec-1
+	dead, err := queue.Lease(ctx, "worker-1", -time.Second)
+	require.NoError(t, err)
+	require.NotNil(t, dead)
+
+	reclaimed, err := queue.ReclaimExpired(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reclaimed)
+
+	// The reclaimed execution is reassigned before the executions still waiting
+	lease, err := queue.Lease(ctx, "worker-2", time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, lease)
+	assert.Equal(t, "exec-1", lease.ExecutionID)
+	assert.Equal(t, 2, lease.Attempt)
+
+	// The dead worker no longer holds the lease and cannot complete it
+	extended, err := queue.Extend(ctx, dead, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, extended)
+	require.NoError(t, queue.Complete(ctx, dead))
+	extended, err = queue.Extend(ctx, lease, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, extended)
+
+	reclaimed, err = queue.ReclaimExpired(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, reclaimed)
+}
+
+func TestExecutionQueue_Priority(t *testing.T) {
+	s := miniredis.RunT(t)
+	cache := setupCache(t, s)
+	defer cache.Close()
+
+	queue := NewExecutionQueue(cache, time.Hour)
+	ctx := context.Background()
+
+	require.NoError(t, queue.Enqueue(ctx, "backfill", models.ExecutionPriorityLow, []byte(`{}`)))
+	require.NoError(t, queue.Enqueue(ctx, "report", models.ExecutionPriorityNormal, []byte(`{}`)))
+	require.NoError(t, queue.Enqueue(ctx, "urgent", models.ExecutionPriorityCritical, []byte(`{}`)))
+	require.NoError(t, queue.Enqueue(ctx, "report-2", models.ExecutionPriorityNormal, []byte(`{}`)))
+
+	var leased []string
+	for {
+		lease, err := queue.Lease(ctx, "worker-1", time.Minute)
+		require.NoError(t, err)
+		if lease == nil {
+			break
+		}
+		leased = append(leased, lease.ExecutionID)
+	}
+	assert.Equal(t, []string{"urgent", "report", "report-2", "backfill"}, leased)
+}
+
+func TestExecutionQueue_PriorityAging(t *testing.T) {
+	s := miniredis.RunT(t)
+	cache := setupCache(t, s)
+	defer cache.Close()
+
+	queue := NewExecutionQueue(cache, time.Millisecond)
+	ctx := context.Background()
+
+	// A low-priority execution waiting longer than three aging steps goes ahead of a new critical one
+	require.NoError(t, queue.Enqueue(ctx, "backfill", models.ExecutionPriorityLow, []byte(`{}`)))
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, queue.Enqueue(ctx, "urgent", models.ExecutionPriorityCritical, []byte(`{}`)))
+
+	lease, err := queue.Lease(ctx, "worker-1", time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, lease)
+	assert.Equal(t, "backfill", lease.ExecutionID)
+}
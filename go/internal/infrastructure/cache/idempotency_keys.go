@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// idempotencyReservationTTL bounds how long a key stays reserved by a start that never
+// completes, such as one interrupted by a restart.
+const idempotencyReservationTTL = time.Minute
+
+// idempotencyReleaseScript deletes the key KEYS[1] if it is still only reserved.
+var idempotencyReleaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == '' then
+  redis.call('DEL', KEYS[1])
+end
+return 1
+`)
+
+// IdempotencyStore keeps the idempotency keys of execution starts in the namespace of the
+// workspace starting them, so a start retried against any instance returns the execution
+// started first and the keys of a workspace count against its quota. Starts without a
+// workspace keep their keys in the system namespace. A key holds the ID of its execution, or
+// an empty string while the execution is starting. It satisfies serviceapi.IdempotencyStore.
+type IdempotencyStore struct {
+	cache *RedisCache
+	ttl   time.Duration
+}
+
+// NewIdempotencyStore creates an idempotency store remembering keys for ttl after their
+// execution started.
+func NewIdempotencyStore(c *RedisCache, ttl time.Duration) *IdempotencyStore {
+	return &IdempotencyStore{cache: c, ttl: ttl}
+}
+
+// Reserve claims an unused key, or returns the execution started with it.
+func (s *IdempotencyStore) Reserve(ctx context.Context, workspaceID, key string) (string, bool, error) {
+	ns := s.cache.tenant(workspaceID)
+	reserved, err := ns.SetNX(ctx, idempotencyKey(key), "", idempotencyReservationTTL)
+	if err != nil {
+		return "", false, err
+	}
+	if reserved {
+		return "", true, nil
+	}
+
+	executionID, err := ns.Get(ctx, idempotencyKey(key))
+	if errors.Is(err, redis.Nil) {
+		// The reservation expired meanwhile; the caller retries
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return executionID, false, nil
+}
+
+// Complete records the execution started for a reserved key.
+func (s *IdempotencyStore) Complete(ctx context.Context, workspaceID, key, executionID string) error {
+	return s.cache.tenant(workspaceID).Set(ctx, idempotencyKey(key), executionID, s.ttl)
+}
+
+// Release frees a reserved key.
+func (s *IdempotencyStore) Release(ctx context.Context, workspaceID, key string) error {
+	ns := s.cache.tenant(workspaceID)
+	return idempotencyReleaseScript.Run(ctx, s.cache.client, []string{ns.Key(idempotencyKey(key))}).Err()
+}
+
+// idempotencyKey returns the namespace-relative key of an idempotency key.
+func idempotencyKey(key string) string {
+	return "idempotency:executions:" + key
+}
@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyStore(t *testing.T) {
+	s := miniredis.RunT(t)
+	cache := setupCache(t, s)
+	defer cache.Close()
+
+	store := NewIdempotencyStore(cache, time.Hour)
+	ctx := context.Background()
+
+	executionID, reserved, err := store.Reserve(ctx, "", "user-1:wf-1:key-1")
+	require.NoError(t, err)
+	assert.True(t, reserved)
+	assert.Empty(t, executionID)
+
+	// A retry while the first start is running sees the reservation
+	executionID, reserved, err = store.Reserve(ctx, "", "user-1:wf-1:key-1")
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	assert.Empty(t, executionID)
+
+	require.NoError(t, store.Complete(ctx, "", "user-1:wf-1:key-1", "exec-1"))
+	require.NoError(t, store.Release(ctx, "", "user-1:wf-1:key-1"), "completed keys are kept")
+	executionID, reserved, err = store.Reserve(ctx, "", "user-1:wf-1:key-1")
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	assert.Equal(t, "exec-1", executionID)
+
+	s.FastForward(2 * time.Hour)
+	_, reserved, err = store.Reserve(ctx, "", "user-1:wf-1:key-1")
+	require.NoError(t, err)
+	assert.True(t, reserved, "keys expire after the TTL")
+
+	// A start that failed frees its key
+	require.NoError(t, store.Release(ctx, "", "user-1:wf-1:key-1"))
+	_, reserved, err = store.Reserve(ctx, "", "user-1:wf-1:key-1")
+	require.NoError(t, err)
+	assert.True(t, reserved)
+}
+
+func TestIdempotencyStore_WorkspaceNamespace(t *testing.T) {
+	s := miniredis.RunT(t)
+	cache := setupCache(t, s)
+	defer cache.Close()
+
+	store := NewIdempotencyStore(cache, time.Hour)
+	ctx := context.Background()
+
+	_, reserved, err := store.Reserve(ctx, "ws-1", "user-1:wf-1:key-1")
+	require.NoError(t, err)
+	require.True(t, reserved)
+	require.NoError(t, store.Complete(ctx, "ws-1", "user-1:wf-1:key-1", "exec-1"))
+	assert.True(t, s.Exists("mbflow:ws:ws-1:idempotency:executions:user-1:wf-1:key-1"))
+
+	// The same key in another workspace is unused
+	_, reserved, err = store.Reserve(ctx, "ws-2", "user-1:wf-1:key-1")
+	require.NoError(t, err)
+	assert.True(t, reserved)
+}
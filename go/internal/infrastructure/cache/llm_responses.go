@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// LLMResponseStore keeps cached LLM responses in the namespace of the workspace they were
+// requested for (see RedisCache.Tenant), so every instance serves the same entries and the
+// cache of a workspace counts against its quota. It satisfies builtin.LLMResponseCache.
+type LLMResponseStore struct {
+	cache *RedisCache
+}
+
+// NewLLMResponseStore creates a response store on the given cache.
+func NewLLMResponseStore(c *RedisCache) *LLMResponseStore {
+	return &LLMResponseStore{cache: c}
+}
+
+// Get returns the response stored under key; ok is false on a miss.
+func (s *LLMResponseStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := s.cache.Tenant(ctx).Get(ctx, key)
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return []byte(value), true, nil
+}
+
+// Set stores a response under key for ttl.
+func (s *LLMResponseStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.cache.Tenant(ctx).Set(ctx, key, value, ttl)
+}
@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLLMResponseStore_GetSet(t *testing.T) {
+	s := miniredis.RunT(t)
+	cache := setupCache(t, s)
+	defer cache.Close()
+
+	store := NewLLMResponseStore(cache)
+	ctx := context.Background()
+
+	_, ok, err := store.Get(ctx, "llm:openai:abc")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, store.Set(ctx, "llm:openai:abc", []byte(`{"content":"hi"}`), time.Hour))
+	assert.True(t, s.Exists("mbflow:system:llm:openai:abc"))
+	assert.Equal(t, time.Hour, s.TTL("mbflow:system:llm:openai:abc"))
+
+	value, ok, err := store.Get(ctx, "llm:openai:abc")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, `{"content":"hi"}`, string(value))
+}
+
+func TestLLMResponseStore_WorkspaceNamespace(t *testing.T) {
+	s := miniredis.RunT(t)
+	cache := setupCache(t, s)
+	defer cache.Close()
+
+	cache.SetDefaultWorkspacePolicy(NamespacePolicy{MaxKeys: 10, MaxTTL: time.Minute})
+	store := NewLLMResponseStore(cache)
+	ctx := executor.WithExecutionContext(context.Background(), &executor.ExecutionContextData{
+		Propagation: executor.Propagation{WorkspaceID: "ws-1"},
+	})
+
+	require.NoError(t, store.Set(ctx, "llm:openai:abc", []byte(`{"content":"hi"}`), time.Hour))
+	assert.True(t, s.Exists("mbflow:ws:ws-1:llm:openai:abc"))
+	assert.Equal(t, time.Minute, s.TTL("mbflow:ws:ws-1:llm:openai:abc"), "bounded by the workspace policy")
+
+	usage, err := cache.Workspace("ws-1").Usage(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), usage)
+
+	// Other workspaces do not see the entry
+	_, ok, err := store.Get(context.Background(), "llm:openai:abc")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,356 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+)
+
+const (
+	// DefaultKeyPrefix is the root prefix applied to all namespaced keys.
+	DefaultKeyPrefix = "mbflow"
+
+	systemNamespace = "system"
+	indexKeySuffix  = "__keys"
+)
+
+// ErrQuotaExceeded is returned when a workspace has reached its key quota.
+var ErrQuotaExceeded = errors.New("workspace cache quota exceeded")
+
+// NamespacePolicy limits how much of the shared Redis a single workspace may use.
+// Zero values disable the corresponding limit.
+type NamespacePolicy struct {
+	MaxKeys    int64         // Maximum number of live keys in the namespace
+	DefaultTTL time.Duration // TTL applied when a key is written without one
+	MaxTTL     time.Duration // Upper bound for any TTL requested by callers
+}
+
+// effectiveTTL resolves the TTL to use for a write under this policy.
+func (p NamespacePolicy) effectiveTTL(ttl time.Duration) time.Duration {
+	if ttl <= 0 {
+		ttl = p.DefaultTTL
+	}
+	if p.MaxTTL > 0 && (ttl <= 0 || ttl > p.MaxTTL) {
+		ttl = p.MaxTTL
+	}
+	return ttl
+}
+
+// NamespacedCache is a view over RedisCache that scopes every key to a namespace
+// and enforces the namespace policy.
+//
+// Workspace namespaces always write keys with a TTL when the policy defines one,
+// so with a "volatile-*" maxmemory policy Redis evicts tenant cache entries before
+// it can touch the TTL-less system keys (trigger schedules and state).
+type NamespacedCache struct {
+	cache     *RedisCache
+	namespace string
+	prefix    string
+	policy    NamespacePolicy
+}
+
+// System returns the namespace for platform-owned keys (trigger state, schedules,
+// rate limit counters). It has no quota and no forced TTL.
+func (c *RedisCache) System() *NamespacedCache {
+	return &NamespacedCache{
+		cache:     c,
+		namespace: systemNamespace,
+		prefix:    c.rootPrefix() + ":" + systemNamespace + ":",
+	}
+}
+
+// Workspace returns the namespace for the given workspace, governed by its policy.
+func (c *RedisCache) Workspace(workspaceID string) *NamespacedCache {
+	return &NamespacedCache{
+		cache:     c,
+		namespace: workspaceID,
+		prefix:    c.rootPrefix() + ":ws:" + workspaceID + ":",
+		policy:    c.WorkspacePolicy(workspaceID),
+	}
+}
+
+// Tenant returns the namespace of the workspace the execution running in ctx belongs to
+// (see executor.Propagation.Tenant), or the system namespace when ctx carries no execution
+// or the execution has neither a workspace nor a user.
+func (c *RedisCache) Tenant(ctx context.Context) *NamespacedCache {
+	if data, ok := executor.GetExecutionContext(ctx); ok {
+		return c.tenant(data.Tenant())
+	}
+	return c.System()
+}
+
+// tenant returns the namespace of the workspace, or the system namespace for an empty ID.
+func (c *RedisCache) tenant(workspaceID string) *NamespacedCache {
+	if workspaceID == "" {
+		return c.System()
+	}
+	return c.Workspace(workspaceID)
+}
+
+// SetDefaultWorkspacePolicy sets the policy applied to workspaces without an override.
+func (c *RedisCache) SetDefaultWorkspacePolicy(policy NamespacePolicy) {
+	c.policyMu.Lock()
+	defer c.policyMu.Unlock()
+	c.defaultPolicy = policy
+}
+
+// SetWorkspacePolicy overrides the policy for a single workspace.
+func (c *RedisCache) SetWorkspacePolicy(workspaceID string, policy NamespacePolicy) {
+	c.policyMu.Lock()
+	defer c.policyMu.Unlock()
+	if c.policies == nil {
+		c.policies = make(map[string]NamespacePolicy)
+	}
+	c.policies[workspaceID] = policy
+}
+
+// WorkspacePolicy returns the effective policy for a workspace.
+func (c *RedisCache) WorkspacePolicy(workspaceID string) NamespacePolicy {
+	c.policyMu.RLock()
+	defer c.policyMu.RUnlock()
+	if policy, ok := c.policies[workspaceID]; ok {
+		return policy
+	}
+	return c.defaultPolicy
+}
+
+func (c *RedisCache) rootPrefix() string {
+	if c.keyPrefix == "" {
+		return DefaultKeyPrefix
+	}
+	return c.keyPrefix
+}
+
+// Namespace returns the namespace name (workspace ID or "system").
+func (n *NamespacedCache) Namespace() string {
+	return n.namespace
+}
+
+// Key returns the fully qualified Redis key for a namespace-relative key.
+func (n *NamespacedCache) Key(key string) string {
+	return n.prefix + key
+}
+
+// keys returns the fully qualified Redis keys for namespace-relative keys.
+func (n *NamespacedCache) keys(keys []string) []string {
+	fullKeys := make([]string, len(keys))
+	for i, key := range keys {
+		fullKeys[i] = n.Key(key)
+	}
+	return fullKeys
+}
+
+// Channel returns the fully qualified pub/sub channel name.
+func (n *NamespacedCache) Channel(channel string) string {
+	return n.prefix + channel
+}
+
+func (n *NamespacedCache) indexKey() string {
+	return n.prefix + indexKeySuffix
+}
+
+// tracked reports whether writes in this namespace are counted against a quota.
+func (n *NamespacedCache) tracked() bool {
+	return n.policy.MaxKeys > 0
+}
+
+// Set stores a value, applying the namespace TTL policy and key quota.
+func (n *NamespacedCache) Set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	ttl = n.policy.effectiveTTL(ttl)
+	fullKey := n.Key(key)
+
+	if n.tracked() {
+		if err := n.reserve(ctx, key, ttl); err != nil {
+			return err
+		}
+	}
+
+	return n.cache.client.Set(ctx, fullKey, value, ttl).Err()
+}
+
+// SetNX stores a value if the key does not exist, applying the namespace TTL policy and
+// key quota. It reports whether the value was stored.
+func (n *NamespacedCache) SetNX(ctx context.Context, key string, value any, ttl time.Duration) (bool, error) {
+	ttl = n.policy.effectiveTTL(ttl)
+
+	if n.tracked() {
+		if err := n.reserve(ctx, key, ttl); err != nil {
+			return false, err
+		}
+	}
+
+	return n.cache.client.SetNX(ctx, n.Key(key), value, ttl).Result()
+}
+
+// namespaceReserveScript records member ARGV[1] in the namespace index KEYS[1] until ARGV[3]
+// (unix ms), unless the index already holds ARGV[4] other live keys. ARGV[2] is now (unix ms).
+// It returns whether the key was recorded and how many live keys the index held.
+var namespaceReserveScript = redis.NewScript(`
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', ARGV[2])
+local count = redis.call('ZCARD', KEYS[1])
+if not redis.call('ZSCORE', KEYS[1], ARGV[1]) and count >= tonumber(ARGV[4]) then
+  return {0, count}
+end
+redis.call('ZADD', KEYS[1], ARGV[3], ARGV[1])
+return {1, count}
+`)
+
+// reserve records the key in the namespace index, failing if the quota is exhausted.
+// The index is a sorted set scored by expiry so expired keys stop counting; overwriting a
+// key does not consume quota. The check and the record are atomic, so concurrent writers
+// cannot exceed the quota together.
+func (n *NamespacedCache) reserve(ctx context.Context, key string, ttl time.Duration) error {
+	now := time.Now()
+	expiry := int64(1<<53 - 1)
+	if ttl > 0 {
+		expiry = now.Add(ttl).UnixMilli()
+	}
+
+	result, err := namespaceReserveScript.Run(ctx, n.cache.client, []string{n.indexKey()},
+		key, now.UnixMilli(), expiry, n.policy.MaxKeys).Int64Slice()
+	if err != nil {
+		return err
+	}
+	if result[0] == 0 {
+		return fmt.Errorf("%w: %s has %d/%d keys", ErrQuotaExceeded, n.namespace, result[1], n.policy.MaxKeys)
+	}
+	return nil
+}
+
+// Get retrieves a value by namespace-relative key.
+func (n *NamespacedCache) Get(ctx context.Context, key string) (string, error) {
+	return n.cache.client.Get(ctx, n.Key(key)).Result()
+}
+
+// Delete deletes namespace-relative keys.
+func (n *NamespacedCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	fullKeys := make([]string, len(keys))
+	members := make([]any, len(keys))
+	for i, key := range keys {
+		fullKeys[i] = n.Key(key)
+		members[i] = key
+	}
+
+	pipe := n.cache.client.TxPipeline()
+	pipe.Del(ctx, fullKeys...)
+	if n.tracked() {
+		pipe.ZRem(ctx, n.indexKey(), members...)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Exists counts how many of the given namespace-relative keys exist.
+func (n *NamespacedCache) Exists(ctx context.Context, keys ...string) (int64, error) {
+	fullKeys := make([]string, len(keys))
+	for i, key := range keys {
+		fullKeys[i] = n.Key(key)
+	}
+	return n.cache.client.Exists(ctx, fullKeys...).Result()
+}
+
+// Expire sets a timeout on a key, bounded by the namespace MaxTTL.
+func (n *NamespacedCache) Expire(ctx context.Context, key string, ttl time.Duration) error {
+	ttl = n.policy.effectiveTTL(ttl)
+	if err := n.cache.client.Expire(ctx, n.Key(key), ttl).Err(); err != nil {
+		return err
+	}
+	if n.tracked() {
+		return n.cache.client.ZAddXX(ctx, n.indexKey(), redis.Z{
+			Score:  float64(time.Now().Add(ttl).UnixMilli()),
+			Member: key,
+		}).Err()
+	}
+	return nil
+}
+
+// Increment increments a counter. New counters in workspace namespaces consume quota
+// and receive the default TTL.
+func (n *NamespacedCache) Increment(ctx context.Context, key string) (int64, error) {
+	fullKey := n.Key(key)
+
+	if n.tracked() || n.policy.DefaultTTL > 0 || n.policy.MaxTTL > 0 {
+		exists, err := n.cache.client.Exists(ctx, fullKey).Result()
+		if err != nil {
+			return 0, err
+		}
+		if exists == 0 {
+			ttl := n.policy.effectiveTTL(0)
+			if n.tracked() {
+				if err := n.reserve(ctx, key, ttl); err != nil {
+					return 0, err
+				}
+			}
+			count, err := n.cache.client.Incr(ctx, fullKey).Result()
+			if err != nil {
+				return 0, err
+			}
+			if ttl > 0 {
+				if err := n.cache.client.Expire(ctx, fullKey, ttl).Err(); err != nil {
+					return 0, err
+				}
+			}
+			return count, nil
+		}
+	}
+
+	return n.cache.client.Incr(ctx, fullKey).Result()
+}
+
+// Publish publishes a message to a namespace-scoped channel.
+func (n *NamespacedCache) Publish(ctx context.Context, channel string, message any) error {
+	return n.cache.client.Publish(ctx, n.Channel(channel), message).Err()
+}
+
+// Usage returns the number of live keys tracked for the namespace.
+// Only namespaces with a key quota are tracked.
+func (n *NamespacedCache) Usage(ctx context.Context) (int64, error) {
+	if !n.tracked() {
+		return 0, nil
+	}
+	client := n.cache.client
+	index := n.indexKey()
+	if err := client.ZRemRangeByScore(ctx, index, "-inf", fmt.Sprintf("%d", time.Now().UnixMilli())).Err(); err != nil {
+		return 0, err
+	}
+	return client.ZCard(ctx, index).Result()
+}
+
+// Flush removes every key in the namespace. Used when a workspace is deleted.
+func (n *NamespacedCache) Flush(ctx context.Context) (int64, error) {
+	client := n.cache.client
+	var removed int64
+	var cursor uint64
+	pattern := escapeGlob(n.prefix) + "*"
+	for {
+		keys, next, err := client.Scan(ctx, cursor, pattern, 500).Result()
+		if err != nil {
+			return removed, err
+		}
+		if len(keys) > 0 {
+			deleted, err := client.Del(ctx, keys...).Result()
+			if err != nil {
+				return removed, err
+			}
+			removed += deleted
+		}
+		cursor = next
+		if cursor == 0 {
+			return removed, nil
+		}
+	}
+}
+
+// escapeGlob escapes Redis glob metacharacters in a key prefix.
+func escapeGlob(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+	return replacer.Replace(s)
+}
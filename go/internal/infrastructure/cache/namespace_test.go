@@ -0,0 +1,224 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespacedCache_KeysArePrefixed(t *testing.T) {
+	s := miniredis.RunT(t)
+	cache := setupCache(t, s)
+	defer cache.Close()
+
+	ctx := context.Background()
+
+	require.NoError(t, cache.Workspace("ws-1").Set(ctx, "node:result", "a", 0))
+	require.NoError(t, cache.Workspace("ws-2").Set(ctx, "node:result", "b", 0))
+	require.NoError(t, cache.System().Set(ctx, "trigger:1:state", "c", 0))
+
+	assert.True(t, s.Exists("mbflow:ws:ws-1:node:result"))
+	assert.True(t, s.Exists("mbflow:ws:ws-2:node:result"))
+	assert.True(t, s.Exists("mbflow:system:trigger:1:state"))
+
+	value, err := cache.Workspace("ws-1").Get(ctx, "node:result")
+	require.NoError(t, err)
+	assert.Equal(t, "a", value)
+
+	value, err = cache.Workspace("ws-2").Get(ctx, "node:result")
+	require.NoError(t, err)
+	assert.Equal(t, "b", value)
+}
+
+func TestNamespacedCache_TTLPolicy(t *testing.T) {
+	s := miniredis.RunT(t)
+	cache := setupCache(t, s)
+	defer cache.Close()
+
+	cache.SetDefaultWorkspacePolicy(NamespacePolicy{DefaultTTL: time.Minute, MaxTTL: time.Hour})
+	ctx := context.Background()
+
+	ws := cache.Workspace("ws-1")
+	require.NoError(t, ws.Set(ctx, "no-ttl", "v", 0))
+	require.NoError(t, ws.Set(ctx, "long-ttl", "v", 48*time.Hour))
+
+	assert.Equal(t, time.Minute, s.TTL("mbflow:ws:ws-1:no-ttl"))
+	assert.Equal(t, time.Hour, s.TTL("mbflow:ws:ws-1:long-ttl"))
+
+	// System keys are never forced to expire
+	require.NoError(t, cache.System().Set(ctx, "schedule", "v", 0))
+	assert.Equal(t, time.Duration(0), s.TTL("mbflow:system:schedule"))
+}
+
+func TestNamespacedCache_Quota(t *testing.T) {
+	s := miniredis.RunT(t)
+	cache := setupCache(t, s)
+	defer cache.Close()
+
+	cache.SetWorkspacePolicy("small", NamespacePolicy{MaxKeys: 2})
+	ctx := context.Background()
+
+	small := cache.Workspace("small")
+	require.NoError(t, small.Set(ctx, "a", "1", 0))
+	require.NoError(t, small.Set(ctx, "b", "1", 0))
+
+	// Overwriting an existing key does not consume quota
+	require.NoError(t, small.Set(ctx, "a", "2", 0))
+
+	err := small.Set(ctx, "c", "1", 0)
+	require.ErrorIs(t, err, ErrQuotaExceeded)
+
+	_, err = small.Increment(ctx, "counter")
+	require.ErrorIs(t, err, ErrQuotaExceeded)
+
+	usage, err := small.Usage(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), usage)
+
+	// Other workspaces are unaffected
+	require.NoError(t, cache.Workspace("other").Set(ctx, "c", "1", 0))
+
+	// Deleting frees quota
+	require.NoError(t, small.Delete(ctx, "b"))
+	require.NoError(t, small.Set(ctx, "c", "1", 0))
+}
+
+func TestNamespacedCache_QuotaHoldsUnderConcurrentWrites(t *testing.T) {
+	s := miniredis.RunT(t)
+	cache := setupCache(t, s)
+	defer cache.Close()
+
+	cache.SetWorkspacePolicy("ws", NamespacePolicy{MaxKeys: 5})
+	ctx := context.Background()
+	ws := cache.Workspace("ws")
+
+	var wg sync.WaitGroup
+	var stored atomic.Int64
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ws.Set(ctx, fmt.Sprintf("key-%d", i), "v", 0) == nil {
+				stored.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(5), stored.Load())
+	usage, err := ws.Usage(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), usage)
+}
+
+func TestNamespacedCache_QuotaReleasedOnExpiry(t *testing.T) {
+	s := miniredis.RunT(t)
+	cache := setupCache(t, s)
+	defer cache.Close()
+
+	cache.SetWorkspacePolicy("ws", NamespacePolicy{MaxKeys: 1})
+	ctx := context.Background()
+
+	ws := cache.Workspace("ws")
+	require.NoError(t, ws.Set(ctx, "a", "1", 50*time.Millisecond))
+	require.ErrorIs(t, ws.Set(ctx, "b", "1", 0), ErrQuotaExceeded)
+
+	time.Sleep(60 * time.Millisecond)
+	require.NoError(t, ws.Set(ctx, "b", "1", 0))
+}
+
+func TestNamespacedCache_Flush(t *testing.T) {
+	s := miniredis.RunT(t)
+	cache := setupCache(t, s)
+	defer cache.Close()
+
+	ctx := context.Background()
+	cache.SetWorkspacePolicy("ws-1", NamespacePolicy{MaxKeys: 10})
+
+	require.NoError(t, cache.Workspace("ws-1").Set(ctx, "a", "1", 0))
+	require.NoError(t, cache.Workspace("ws-1").Set(ctx, "b", "1", 0))
+	require.NoError(t, cache.Workspace("ws-2").Set(ctx, "a", "1", 0))
+
+	_, err := cache.Workspace("ws-1").Flush(ctx)
+	require.NoError(t, err)
+
+	assert.False(t, s.Exists("mbflow:ws:ws-1:a"))
+	assert.False(t, s.Exists("mbflow:ws:ws-1:b"))
+	assert.True(t, s.Exists("mbflow:ws:ws-2:a"))
+
+	usage, err := cache.Workspace("ws-1").Usage(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), usage)
+}
+
+func TestNamespacedCache_Increment_AppliesDefaultTTL(t *testing.T) {
+	s := miniredis.RunT(t)
+	cache := setupCache(t, s)
+	defer cache.Close()
+
+	cache.SetDefaultWorkspacePolicy(NamespacePolicy{DefaultTTL: time.Minute})
+	ctx := context.Background()
+
+	ws := cache.Workspace("ws")
+	count, err := ws.Increment(ctx, "hits")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	count, err = ws.Increment(ctx, "hits")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	assert.Equal(t, time.Minute, s.TTL("mbflow:ws:ws:hits"))
+}
+
+func TestRedisCache_Tenant(t *testing.T) {
+	s := miniredis.RunT(t)
+	cache := setupCache(t, s)
+	defer cache.Close()
+
+	ctx := context.Background()
+	assert.Equal(t, "system", cache.Tenant(ctx).Namespace(), "outside executions")
+
+	inWorkspace := executor.WithExecutionContext(ctx, &executor.ExecutionContextData{
+		Propagation: executor.Propagation{WorkspaceID: "ws-1", UserID: "user-1"},
+	})
+	assert.Equal(t, "ws-1", cache.Tenant(inWorkspace).Namespace())
+
+	personal := executor.WithExecutionContext(ctx, &executor.ExecutionContextData{
+		Propagation: executor.Propagation{UserID: "user-1"},
+	})
+	assert.Equal(t, "user-1", cache.Tenant(personal).Namespace(), "the user's personal workspace")
+
+	anonymous := executor.WithExecutionContext(ctx, &executor.ExecutionContextData{})
+	assert.Equal(t, "system", cache.Tenant(anonymous).Namespace())
+}
+
+func TestNamespacedCache_SetNX(t *testing.T) {
+	s := miniredis.RunT(t)
+	cache := setupCache(t, s)
+	defer cache.Close()
+
+	cache.SetDefaultWorkspacePolicy(NamespacePolicy{MaxKeys: 1, DefaultTTL: time.Minute})
+	ctx := context.Background()
+	ws := cache.Workspace("ws-1")
+
+	stored, err := ws.SetNX(ctx, "a", "1", 0)
+	require.NoError(t, err)
+	assert.True(t, stored)
+	assert.Equal(t, time.Minute, s.TTL("mbflow:ws:ws-1:a"))
+
+	stored, err = ws.SetNX(ctx, "a", "2", 0)
+	require.NoError(t, err)
+	assert.False(t, stored)
+
+	_, err = ws.SetNX(ctx, "b", "1", 0)
+	assert.ErrorIs(t, err, ErrQuotaExceeded)
+}
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// rateLimitReserveScript reserves the next slot of the limit KEYS[1] with the generic cell
+// rate algorithm. The key holds the theoretical arrival time of the next slot in
+// microseconds of the Redis clock, so instances with skewed clocks share one schedule.
+// ARGV: interval (us), burst, max wait (us, -1 for none). Returns {reserved, wait (us)}.
+var rateLimitReserveScript = redis.NewScript(`
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+local interval = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local max_wait = tonumber(ARGV[3])
+local tat = tonumber(redis.call('GET', KEYS[1]) or now)
+if tat < now then
+  tat = now
+end
+local next_tat = tat + interval
+local wait = next_tat - burst * interval - now
+if wait < 0 then
+  wait = 0
+end
+if max_wait >= 0 and wait > max_wait then
+  return {0, wait}
+end
+redis.call('SET', KEYS[1], string.format('%d', next_tat), 'PX', math.ceil((next_tat - now) / 1000) + 1000)
+return {1, wait}
+`)
+
+// RateLimitStore keeps the limits of rate_limit nodes in the namespace of the workspace the
+// execution runs in (see RedisCache.Tenant), so every instance shares them and workspaces
+// never consume each other's slots. A limit is a single key expiring with its schedule, so
+// it is not counted against the workspace quota. It satisfies builtin.RateLimitStore.
+type RateLimitStore struct {
+	cache *RedisCache
+}
+
+// NewRateLimitStore creates a rate limit store on the given cache.
+func NewRateLimitStore(c *RedisCache) *RateLimitStore {
+	return &RateLimitStore{cache: c}
+}
+
+// Reserve reserves the next slot of the limit under key and returns how long to wait for it.
+func (s *RateLimitStore) Reserve(ctx context.Context, key string, interval time.Duration, burst int, maxWait time.Duration) (time.Duration, bool, error) {
+	maxWaitUs := int64(-1)
+	if maxWait >= 0 {
+		maxWaitUs = maxWait.Microseconds()
+	}
+	result, err := rateLimitReserveScript.Run(ctx, s.cache.client, []string{s.cache.Tenant(ctx).Key(key)},
+		interval.Microseconds(), burst, maxWaitUs).Int64Slice()
+	if err != nil {
+		return 0, false, err
+	}
+	if len(result) != 2 {
+		return 0, false, fmt.Errorf("unexpected rate limit result: %v", result)
+	}
+	return time.Duration(result[1]) * time.Microsecond, result[0] == 1, nil
+}
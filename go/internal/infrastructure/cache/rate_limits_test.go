@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitStore_Reserve(t *testing.T) {
+	s := miniredis.RunT(t)
+	cache := setupCache(t, s)
+	defer cache.Close()
+
+	store := NewRateLimitStore(cache)
+	ctx := context.Background()
+
+	wait, ok, err := store.Reserve(ctx, "rate_limit:api", time.Minute, 1, -1)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Zero(t, wait)
+	assert.True(t, s.Exists("mbflow:system:rate_limit:api"))
+
+	// The next slot is a minute later
+	wait, ok, err = store.Reserve(ctx, "rate_limit:api", time.Minute, 1, -1)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.InDelta(t, time.Minute, wait, float64(time.Second))
+
+	// Waiting longer than max_wait reserves nothing
+	wait, ok, err = store.Reserve(ctx, "rate_limit:api", time.Minute, 1, time.Second)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.InDelta(t, 2*time.Minute, wait, float64(time.Second))
+}
+
+func TestRateLimitStore_WorkspaceNamespace(t *testing.T) {
+	s := miniredis.RunT(t)
+	cache := setupCache(t, s)
+	defer cache.Close()
+
+	cache.SetDefaultWorkspacePolicy(NamespacePolicy{MaxKeys: 10})
+	store := NewRateLimitStore(cache)
+	wsCtx := func(workspaceID string) context.Context {
+		return executor.WithExecutionContext(context.Background(), &executor.ExecutionContextData{
+			Propagation: executor.Propagation{WorkspaceID: workspaceID},
+		})
+	}
+
+	wait, ok, err := store.Reserve(wsCtx("ws-1"), "rate_limit:api", time.Minute, 1, -1)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Zero(t, wait)
+	assert.True(t, s.Exists("mbflow:ws:ws-1:rate_limit:api"))
+
+	// Another workspace has its own slots
+	wait, ok, err = store.Reserve(wsCtx("ws-2"), "rate_limit:api", time.Minute, 1, -1)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Zero(t, wait)
+
+	usage, err := cache.Workspace("ws-1").Usage(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, usage, "limits are not counted against the quota")
+}
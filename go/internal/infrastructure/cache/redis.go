@@ -4,6 +4,7 @@ package cache
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -11,8 +12,15 @@ import (
 )
 
 // RedisCache wraps the Redis client.
+// Methods on RedisCache operate on raw keys; use System or Workspace
+// to get a namespaced view with per-workspace quotas and TTL policies.
 type RedisCache struct {
-	client *redis.Client
+	client    *redis.Client
+	keyPrefix string
+
+	policyMu      sync.RWMutex
+	defaultPolicy NamespacePolicy
+	policies      map[string]NamespacePolicy
 }
 
 // NewRedisCache creates a new Redis cache client.
@@ -46,7 +54,14 @@ func NewRedisCache(cfg config.RedisConfig) (*RedisCache, error) {
 	}
 
 	return &RedisCache{
-		client: client,
+		client:    client,
+		keyPrefix: cfg.KeyPrefix,
+		defaultPolicy: NamespacePolicy{
+			MaxKeys:    cfg.WorkspaceMaxKeys,
+			DefaultTTL: cfg.WorkspaceDefaultTTL,
+			MaxTTL:     cfg.WorkspaceMaxTTL,
+		},
+		policies: make(map[string]NamespacePolicy),
 	}, nil
 }
 
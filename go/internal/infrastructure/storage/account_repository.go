@@ -102,6 +102,22 @@ func (r *AccountRepositoryImpl) Update(ctx context.Context, account *pkgmodels.A
 	return err
 }
 
+func (r *AccountRepositoryImpl) SetQuota(ctx context.Context, id string, quota *pkgmodels.UsageQuota) error {
+	accountID, err := uuid.Parse(id)
+	if err != nil {
+		return pkgmodels.ErrInvalidID
+	}
+
+	_, err = r.db.NewUpdate().
+		Model((*models.BillingAccountModel)(nil)).
+		Set("quota = ?", quota).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", accountID).
+		Exec(ctx)
+
+	return err
+}
+
 func (r *AccountRepositoryImpl) UpdateBalance(ctx context.Context, id string, newBalance float64) error {
 	accountID, err := uuid.Parse(id)
 	if err != nil {
@@ -0,0 +1,218 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgmodels "github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+var _ repository.CanaryRepository = (*CanaryRepository)(nil)
+
+// CanaryRepository implements repository.CanaryRepository
+type CanaryRepository struct {
+	db bun.IDB
+}
+
+// NewCanaryRepository creates a new CanaryRepository
+func NewCanaryRepository(db bun.IDB) *CanaryRepository {
+	return &CanaryRepository{db: db}
+}
+
+// Save creates or replaces the canary definition of a workflow
+func (r *CanaryRepository) Save(ctx context.Context, canary *pkgmodels.Canary) error {
+	model, err := models.FromCanaryDomain(canary)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	model.CreatedAt = now
+	model.UpdatedAt = now
+	if model.Input == nil {
+		model.Input = make(models.JSONBMap)
+	}
+	if model.Assertions == nil {
+		model.Assertions = []pkgmodels.CanaryAssertion{}
+	}
+	if model.Status == "" {
+		model.Status = string(pkgmodels.CanaryStatusUnknown)
+	}
+
+	_, err = r.db.NewInsert().
+		Model(model).
+		On("CONFLICT (workflow_id) DO UPDATE").
+		Set("schedule = EXCLUDED.schedule").
+		Set("input = EXCLUDED.input").
+		Set("assertions = EXCLUDED.assertions").
+		Set("max_duration_ms = EXCLUDED.max_duration_ms").
+		Set("runbook_url = EXCLUDED.runbook_url").
+		Set("enabled = EXCLUDED.enabled").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to save canary: %w", err)
+	}
+
+	*canary = *model.ToDomain()
+	return nil
+}
+
+// GetByWorkflowID returns the canary of a workflow
+func (r *CanaryRepository) GetByWorkflowID(ctx context.Context, workflowID string) (*pkgmodels.Canary, error) {
+	id, err := uuid.Parse(workflowID)
+	if err != nil {
+		return nil, pkgmodels.ErrInvalidWorkflowID
+	}
+
+	model := &models.CanaryModel{}
+	err = r.db.NewSelect().
+		Model(model).
+		Relation("Workflow", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Column("name")
+		}).
+		Where("c.workflow_id = ?", id).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, pkgmodels.ErrCanaryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ToDomain(), nil
+}
+
+// List returns all canaries ordered by workflow name
+func (r *CanaryRepository) List(ctx context.Context) ([]*pkgmodels.Canary, error) {
+	var modelList []*models.CanaryModel
+	err := r.db.NewSelect().
+		Model(&modelList).
+		Relation("Workflow", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Column("name")
+		}).
+		Order("workflow.name ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	canaries := make([]*pkgmodels.Canary, len(modelList))
+	for i, m := range modelList {
+		canaries[i] = m.ToDomain()
+	}
+	return canaries, nil
+}
+
+// Delete removes a canary and its run history
+func (r *CanaryRepository) Delete(ctx context.Context, workflowID string) error {
+	id, err := uuid.Parse(workflowID)
+	if err != nil {
+		return pkgmodels.ErrInvalidWorkflowID
+	}
+
+	res, err := r.db.NewDelete().
+		Model((*models.CanaryModel)(nil)).
+		Where("workflow_id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return pkgmodels.ErrCanaryNotFound
+	}
+	return nil
+}
+
+// RecordRun stores a run and updates the canary's status fields
+func (r *CanaryRepository) RecordRun(ctx context.Context, canary *pkgmodels.Canary, run *pkgmodels.CanaryRun) error {
+	workflowID, err := uuid.Parse(canary.WorkflowID)
+	if err != nil {
+		return pkgmodels.ErrInvalidWorkflowID
+	}
+
+	if run.CreatedAt.IsZero() {
+		run.CreatedAt = time.Now()
+	}
+
+	runModel := &models.CanaryRunModel{
+		ID:         uuid.New(),
+		WorkflowID: workflowID,
+		Passed:     run.Passed,
+		DurationMs: run.DurationMs,
+		Error:      run.Error,
+		Results:    run.Results,
+		CreatedAt:  run.CreatedAt,
+	}
+	if runModel.Results == nil {
+		runModel.Results = []pkgmodels.CanaryAssertionResult{}
+	}
+	if run.ExecutionID != "" {
+		if id, err := uuid.Parse(run.ExecutionID); err == nil {
+			runModel.ExecutionID = &id
+		}
+	}
+
+	var lastExecutionID *uuid.UUID
+	if canary.LastExecutionID != "" {
+		if id, err := uuid.Parse(canary.LastExecutionID); err == nil {
+			lastExecutionID = &id
+		}
+	}
+
+	return r.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(runModel).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to insert canary run: %w", err)
+		}
+
+		_, err := tx.NewUpdate().
+			Model((*models.CanaryModel)(nil)).
+			Set("status = ?", string(canary.Status)).
+			Set("consecutive_failures = ?", canary.ConsecutiveFailures).
+			Set("last_run_at = ?", canary.LastRunAt).
+			Set("last_execution_id = ?", lastExecutionID).
+			Set("last_error = ?", canary.LastError).
+			Where("workflow_id = ?", workflowID).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to update canary status: %w", err)
+		}
+
+		run.ID = runModel.ID.String()
+		return nil
+	})
+}
+
+// ListRuns returns the most recent runs of a canary, newest first
+func (r *CanaryRepository) ListRuns(ctx context.Context, workflowID string, limit int) ([]*pkgmodels.CanaryRun, error) {
+	id, err := uuid.Parse(workflowID)
+	if err != nil {
+		return nil, pkgmodels.ErrInvalidWorkflowID
+	}
+
+	var modelList []*models.CanaryRunModel
+	err = r.db.NewSelect().
+		Model(&modelList).
+		Where("workflow_id = ?", id).
+		Order("created_at DESC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]*pkgmodels.CanaryRun, len(modelList))
+	for i, m := range modelList {
+		runs[i] = m.ToDomain()
+	}
+	return runs, nil
+}
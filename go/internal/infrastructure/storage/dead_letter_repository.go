@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgmodels "github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+var _ repository.DeadLetterRepository = (*DeadLetterRepository)(nil)
+
+// DeadLetterRepository implements repository.DeadLetterRepository
+type DeadLetterRepository struct {
+	db bun.IDB
+}
+
+// NewDeadLetterRepository creates a new DeadLetterRepository
+func NewDeadLetterRepository(db bun.IDB) *DeadLetterRepository {
+	return &DeadLetterRepository{db: db}
+}
+
+// Create parks a failed execution in the queue, or refreshes the entry of an execution
+// already there. The workflow and error are taken from the stored execution.
+func (r *DeadLetterRepository) Create(ctx context.Context, deadLetter *pkgmodels.DeadLetter) error {
+	executionID, err := uuid.Parse(deadLetter.ExecutionID)
+	if err != nil {
+		return pkgmodels.ErrInvalidExecutionID
+	}
+
+	id := uuid.New()
+	if deadLetter.ID != "" {
+		if id, err = uuid.Parse(deadLetter.ID); err != nil {
+			return pkgmodels.ErrInvalidID
+		}
+	}
+	createdAt := deadLetter.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	// Executions of ephemeral workflows have no stored workflow and are not queued
+	model := &models.DeadLetterModel{}
+	err = r.db.NewRaw(`INSERT INTO mbflow_dead_letters (id, execution_id, workflow_id, error, created_at)
+		SELECT ?, ex.id, ex.workflow_id, COALESCE(ex.error, ''), ?
+		FROM mbflow_executions AS ex
+		WHERE ex.id = ? AND ex.status = ? AND ex.workflow_id IS NOT NULL
+		ON CONFLICT (execution_id) DO UPDATE SET error = EXCLUDED.error, created_at = EXCLUDED.created_at
+		RETURNING *`,
+		id, createdAt, executionID, string(pkgmodels.ExecutionStatusFailed)).
+		Scan(ctx, model)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: execution %s", pkgmodels.ErrExecutionNotFailed, deadLetter.ExecutionID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create dead letter: %w", err)
+	}
+
+	*deadLetter = *model.ToDomain()
+	return nil
+}
+
+// GetByID returns a dead letter
+func (r *DeadLetterRepository) GetByID(ctx context.Context, id string) (*pkgmodels.DeadLetter, error) {
+	deadLetterID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, pkgmodels.ErrInvalidID
+	}
+
+	model := &models.DeadLetterModel{}
+	err = r.db.NewSelect().
+		Model(model).
+		Relation("Workflow", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Column("name")
+		}).
+		Where("dl.id = ?", deadLetterID).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, pkgmodels.ErrDeadLetterNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ToDomain(), nil
+}
+
+// List returns the dead letters matching the filter, newest first, and their total count
+func (r *DeadLetterRepository) List(ctx context.Context, filter pkgmodels.DeadLetterFilter) ([]*pkgmodels.DeadLetter, int, error) {
+	var modelList []*models.DeadLetterModel
+	query := r.db.NewSelect().
+		Model(&modelList).
+		Relation("Workflow", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Column("name")
+		})
+
+	if filter.WorkflowID != "" {
+		workflowID, err := uuid.Parse(filter.WorkflowID)
+		if err != nil {
+			return nil, 0, pkgmodels.ErrInvalidWorkflowID
+		}
+		query = query.Where("dl.workflow_id = ?", workflowID)
+	}
+
+	total, err := query.
+		Order("dl.created_at DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	deadLetters := make([]*pkgmodels.DeadLetter, len(modelList))
+	for i, m := range modelList {
+		deadLetters[i] = m.ToDomain()
+	}
+	return deadLetters, total, nil
+}
+
+// Delete removes a dead letter
+func (r *DeadLetterRepository) Delete(ctx context.Context, id string) error {
+	deadLetterID, err := uuid.Parse(id)
+	if err != nil {
+		return pkgmodels.ErrInvalidID
+	}
+
+	res, err := r.db.NewDelete().
+		Model((*models.DeadLetterModel)(nil)).
+		Where("id = ?", deadLetterID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return pkgmodels.ErrDeadLetterNotFound
+	}
+	return nil
+}
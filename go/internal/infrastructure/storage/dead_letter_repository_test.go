@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgmodels "github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/smilemakc/mbflow/go/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadLetterRepo_Lifecycle(t *testing.T) {
+	t.Parallel()
+	db, cleanup := testutil.SetupTestTx(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	workflow := createTestWorkflow(t, NewWorkflowRepository(db))
+	executionRepo := NewExecutionRepository(db)
+	execution := &models.ExecutionModel{
+		WorkflowID: uuidPtr(workflow.ID),
+		Status:     "failed",
+		Error:      "node fetch failed after 3 attempts",
+	}
+	require.NoError(t, executionRepo.Create(ctx, execution))
+	running := &models.ExecutionModel{
+		WorkflowID: uuidPtr(workflow.ID),
+		Status:     "running",
+	}
+	require.NoError(t, executionRepo.Create(ctx, running))
+	repo := NewDeadLetterRepository(db)
+
+	err := repo.Create(ctx, &pkgmodels.DeadLetter{ExecutionID: running.ID.String()})
+	assert.ErrorIs(t, err, pkgmodels.ErrExecutionNotFailed)
+
+	deadLetter := &pkgmodels.DeadLetter{ExecutionID: execution.ID.String()}
+	require.NoError(t, repo.Create(ctx, deadLetter))
+	require.NotEmpty(t, deadLetter.ID)
+	assert.Equal(t, workflow.ID.String(), deadLetter.WorkflowID)
+	assert.Equal(t, "node fetch failed after 3 attempts", deadLetter.Error)
+
+	// The same execution failing again keeps its entry
+	again := &pkgmodels.DeadLetter{ExecutionID: execution.ID.String()}
+	require.NoError(t, repo.Create(ctx, again))
+	assert.Equal(t, deadLetter.ID, again.ID)
+
+	found, err := repo.GetByID(ctx, deadLetter.ID)
+	require.NoError(t, err)
+	assert.Equal(t, workflow.Name, found.WorkflowName)
+
+	list, total, err := repo.List(ctx, pkgmodels.DeadLetterFilter{WorkflowID: workflow.ID.String(), Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, list, 1)
+	assert.Equal(t, execution.ID.String(), list[0].ExecutionID)
+
+	_, total, err = repo.List(ctx, pkgmodels.DeadLetterFilter{WorkflowID: uuid.NewString(), Limit: 10})
+	require.NoError(t, err)
+	assert.Zero(t, total)
+
+	require.NoError(t, repo.Delete(ctx, deadLetter.ID))
+	assert.ErrorIs(t, repo.Delete(ctx, deadLetter.ID), pkgmodels.ErrDeadLetterNotFound)
+	_, err = repo.GetByID(ctx, deadLetter.ID)
+	assert.ErrorIs(t, err, pkgmodels.ErrDeadLetterNotFound)
+}
@@ -0,0 +1,236 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgmodels "github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+var _ repository.ExecutionArchiveRepository = (*ExecutionArchiveRepository)(nil)
+
+// executionTreeQuery selects the IDs of an execution and its sub-workflow executions, parents first.
+const executionTreeQuery = `WITH RECURSIVE tree AS (
+		SELECT id, 0 AS depth FROM mbflow_executions WHERE id = ?
+		UNION ALL
+		SELECT ex.id, tree.depth + 1 FROM mbflow_executions AS ex JOIN tree ON ex.parent_execution_id = tree.id
+	)`
+
+// exportedRow is a row selected as a JSON object
+type exportedRow struct {
+	ID  uuid.UUID       `bun:"id"`
+	Row json.RawMessage `bun:"row"`
+}
+
+// ExecutionArchiveRepository implements repository.ExecutionArchiveRepository using Bun ORM
+type ExecutionArchiveRepository struct {
+	db bun.IDB
+}
+
+// NewExecutionArchiveRepository creates a new ExecutionArchiveRepository
+func NewExecutionArchiveRepository(db bun.IDB) *ExecutionArchiveRepository {
+	return &ExecutionArchiveRepository{db: db}
+}
+
+// FindArchivable returns root executions finished before the cutoff, oldest first. Sub-workflow
+// executions are archived with their root, and dead letters wait for a retry or a discard.
+func (r *ExecutionArchiveRepository) FindArchivable(ctx context.Context, cutoff time.Time, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.NewSelect().
+		Table("mbflow_executions").
+		Column("id").
+		Where("parent_execution_id IS NULL").
+		Where("status IN (?)", bun.In([]string{"completed", "failed", "cancelled"})).
+		Where("completed_at < ?", cutoff).
+		Where("NOT EXISTS (SELECT 1 FROM mbflow_dead_letters AS dl WHERE dl.execution_id = mbflow_executions.id)").
+		Order("completed_at ASC").
+		Limit(limit).
+		Scan(ctx, &ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find archivable executions: %w", err)
+	}
+	return ids, nil
+}
+
+// Export returns the rows of an execution tree as JSON objects, with every column of the tables
+func (r *ExecutionArchiveRepository) Export(ctx context.Context, executionID uuid.UUID) (*models.ExecutionRows, error) {
+	var executions []exportedRow
+	err := r.db.NewRaw(executionTreeQuery+`
+		SELECT ex.id, to_jsonb(ex) AS row
+		FROM mbflow_executions AS ex JOIN tree ON tree.id = ex.id
+		ORDER BY tree.depth, ex.created_at`, executionID).
+		Scan(ctx, &executions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export executions: %w", err)
+	}
+	if len(executions) == 0 {
+		return nil, pkgmodels.ErrExecutionNotFound
+	}
+
+	ids := make([]uuid.UUID, len(executions))
+	rows := &models.ExecutionRows{Executions: make([]json.RawMessage, len(executions))}
+	for i, ex := range executions {
+		ids[i] = ex.ID
+		rows.Executions[i] = ex.Row
+	}
+
+	if rows.NodeExecutions, err = r.exportTable(ctx, "mbflow_node_executions", "created_at", ids); err != nil {
+		return nil, err
+	}
+	if rows.Events, err = r.exportTable(ctx, "mbflow_events", "sequence", ids); err != nil {
+		return nil, err
+	}
+	if rows.Notes, err = r.exportTable(ctx, "mbflow_execution_notes", "created_at", ids); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// exportTable returns the rows of a table that belong to the given executions
+func (r *ExecutionArchiveRepository) exportTable(ctx context.Context, table, order string, executionIDs []uuid.UUID) ([]json.RawMessage, error) {
+	var exported []exportedRow
+	err := r.db.NewRaw("SELECT t.id, to_jsonb(t) AS row FROM ? AS t WHERE t.execution_id IN (?) ORDER BY t.?",
+		bun.Ident(table), bun.In(executionIDs), bun.Ident(order)).
+		Scan(ctx, &exported)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export %s: %w", table, err)
+	}
+
+	rows := make([]json.RawMessage, len(exported))
+	for i, row := range exported {
+		rows[i] = row.Row
+	}
+	return rows, nil
+}
+
+// Archive records the archive and deletes the execution tree. Node executions are deleted
+// explicitly since their foreign key does not cascade; events, notes and sub-workflow
+// executions go with the execution. Nothing is deleted if the execution changed status or
+// finished again since it was exported.
+func (r *ExecutionArchiveRepository) Archive(ctx context.Context, archive *models.ExecutionArchiveModel) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(archive).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to create execution archive: %w", err)
+		}
+
+		_, err := tx.NewRaw(executionTreeQuery+`
+			DELETE FROM mbflow_node_executions WHERE execution_id IN (SELECT id FROM tree)`, archive.ExecutionID).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to delete node executions: %w", err)
+		}
+
+		res, err := tx.NewDelete().
+			Table("mbflow_executions").
+			Where("id = ?", archive.ExecutionID).
+			Where("status = ?", archive.Status).
+			Where("completed_at = ?", archive.CompletedAt).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to delete execution: %w", err)
+		}
+		if rowsAffected(res) == 0 {
+			return fmt.Errorf("execution %s changed since it was exported", archive.ExecutionID)
+		}
+		return nil
+	})
+}
+
+// Restore inserts the exported rows back, each column as it was archived, and deletes the
+// archive record
+func (r *ExecutionArchiveRepository) Restore(ctx context.Context, executionID uuid.UUID, rows *models.ExecutionRows) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewDelete().
+			Model((*models.ExecutionArchiveModel)(nil)).
+			Where("execution_id = ?", executionID).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to delete execution archive: %w", err)
+		}
+		if rowsAffected(res) == 0 {
+			return pkgmodels.ErrExecutionArchiveNotFound
+		}
+
+		tables := []struct {
+			name string
+			rows []json.RawMessage
+		}{
+			{"mbflow_executions", rows.Executions},
+			{"mbflow_node_executions", rows.NodeExecutions},
+			{"mbflow_events", rows.Events},
+			{"mbflow_execution_notes", rows.Notes},
+		}
+		for _, table := range tables {
+			if len(table.rows) == 0 {
+				continue
+			}
+			data, err := json.Marshal(table.rows)
+			if err != nil {
+				return fmt.Errorf("failed to encode %s: %w", table.name, err)
+			}
+			_, err = tx.NewRaw("INSERT INTO ? SELECT * FROM jsonb_populate_recordset(NULL::?, ?::jsonb)",
+				bun.Ident(table.name), bun.Ident(table.name), string(data)).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to restore %s: %w", table.name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// FindByExecutionID returns the archive of an execution
+func (r *ExecutionArchiveRepository) FindByExecutionID(ctx context.Context, executionID uuid.UUID) (*models.ExecutionArchiveModel, error) {
+	archive := &models.ExecutionArchiveModel{}
+	err := r.db.NewSelect().
+		Model(archive).
+		Relation("Workflow", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Column("name")
+		}).
+		Where("ea.execution_id = ?", executionID).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, pkgmodels.ErrExecutionArchiveNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+// List returns the archives matching the filter, most recently archived first, and their total count
+func (r *ExecutionArchiveRepository) List(ctx context.Context, filter pkgmodels.ExecutionArchiveFilter) ([]*models.ExecutionArchiveModel, int, error) {
+	var archives []*models.ExecutionArchiveModel
+	query := r.db.NewSelect().
+		Model(&archives).
+		Relation("Workflow", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Column("name")
+		})
+
+	if filter.WorkflowID != "" {
+		workflowID, err := uuid.Parse(filter.WorkflowID)
+		if err != nil {
+			return nil, 0, pkgmodels.ErrInvalidWorkflowID
+		}
+		query = query.Where("ea.workflow_id = ?", workflowID)
+	}
+
+	total, err := query.
+		Order("ea.archived_at DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return archives, total, nil
+}
@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgmodels "github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/smilemakc/mbflow/go/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionArchiveRepo_ArchiveAndRestore(t *testing.T) {
+	t.Parallel()
+	db, cleanup := testutil.SetupTestTx(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	workflow := createTestWorkflow(t, NewWorkflowRepository(db))
+	executionRepo := NewExecutionRepository(db)
+	completedAt := time.Now().Add(-48 * time.Hour).Truncate(time.Microsecond)
+	execution := &models.ExecutionModel{
+		WorkflowID:  uuidPtr(workflow.ID),
+		Status:      "completed",
+		CompletedAt: &completedAt,
+		OutputData:  models.JSONBMap{"summary": "done"},
+	}
+	require.NoError(t, executionRepo.Create(ctx, execution))
+	require.NoError(t, executionRepo.CreateNodeExecution(ctx, &models.NodeExecutionModel{
+		ExecutionID: execution.ID,
+		NodeID:      uuidPtr(workflow.Nodes[0].ID),
+		Status:      "completed",
+		OutputData:  models.JSONBMap{"value": 42},
+	}))
+	now := time.Now()
+	recent := &models.ExecutionModel{
+		WorkflowID:  uuidPtr(workflow.ID),
+		Status:      "completed",
+		CompletedAt: &now,
+	}
+	require.NoError(t, executionRepo.Create(ctx, recent))
+	repo := NewExecutionArchiveRepository(db)
+
+	ids, err := repo.FindArchivable(ctx, time.Now().Add(-24*time.Hour), 10)
+	require.NoError(t, err)
+	assert.Contains(t, ids, execution.ID)
+	assert.NotContains(t, ids, recent.ID)
+
+	rows, err := repo.Export(ctx, execution.ID)
+	require.NoError(t, err)
+	require.Len(t, rows.Executions, 1)
+	require.Len(t, rows.NodeExecutions, 1)
+
+	require.NoError(t, repo.Archive(ctx, &models.ExecutionArchiveModel{
+		ExecutionID:        execution.ID,
+		WorkflowID:         execution.WorkflowID,
+		Status:             execution.Status,
+		CompletedAt:        execution.CompletedAt,
+		ArchiveRef:         "executions/" + execution.ID.String() + ".json.gz",
+		ExecutionCount:     1,
+		NodeExecutionCount: 1,
+	}))
+	_, err = executionRepo.FindByID(ctx, execution.ID)
+	assert.Error(t, err)
+
+	archive, err := repo.FindByExecutionID(ctx, execution.ID)
+	require.NoError(t, err)
+	assert.Equal(t, workflow.Name, archive.ToDomain().WorkflowName)
+	list, total, err := repo.List(ctx, pkgmodels.ExecutionArchiveFilter{WorkflowID: workflow.ID.String(), Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, list, 1)
+
+	require.NoError(t, repo.Restore(ctx, execution.ID, rows))
+	restored, err := executionRepo.FindByIDWithRelations(ctx, execution.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "done", restored.OutputData["summary"])
+	require.Len(t, restored.NodeExecutions, 1)
+	assert.EqualValues(t, 42, restored.NodeExecutions[0].OutputData["value"])
+
+	_, err = repo.FindByExecutionID(ctx, execution.ID)
+	assert.ErrorIs(t, err, pkgmodels.ErrExecutionArchiveNotFound)
+	assert.ErrorIs(t, repo.Restore(ctx, execution.ID, rows), pkgmodels.ErrExecutionArchiveNotFound)
+}
@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgmodels "github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+var _ repository.ExecutionNoteRepository = (*ExecutionNoteRepository)(nil)
+
+// ExecutionNoteRepository implements repository.ExecutionNoteRepository
+type ExecutionNoteRepository struct {
+	db bun.IDB
+}
+
+// NewExecutionNoteRepository creates a new ExecutionNoteRepository
+func NewExecutionNoteRepository(db bun.IDB) *ExecutionNoteRepository {
+	return &ExecutionNoteRepository{db: db}
+}
+
+// Create stores a new note and sets its ID and timestamps
+func (r *ExecutionNoteRepository) Create(ctx context.Context, note *pkgmodels.ExecutionNote) error {
+	executionID, err := uuid.Parse(note.ExecutionID)
+	if err != nil {
+		return pkgmodels.ErrInvalidExecutionID
+	}
+
+	now := time.Now()
+	model := &models.ExecutionNoteModel{
+		ID:          uuid.New(),
+		ExecutionID: executionID,
+		NodeID:      note.NodeID,
+		AuthorName:  note.AuthorName,
+		Body:        note.Body,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if note.AuthorID != "" {
+		if id, err := uuid.Parse(note.AuthorID); err == nil {
+			model.AuthorID = &id
+		}
+	}
+
+	if _, err := r.db.NewInsert().Model(model).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to create execution note: %w", err)
+	}
+
+	*note = *model.ToDomain()
+	return nil
+}
+
+// GetByID returns a note
+func (r *ExecutionNoteRepository) GetByID(ctx context.Context, id string) (*pkgmodels.ExecutionNote, error) {
+	noteID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, pkgmodels.ErrInvalidID
+	}
+
+	model := &models.ExecutionNoteModel{}
+	err = r.db.NewSelect().
+		Model(model).
+		Where("en.id = ?", noteID).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, pkgmodels.ErrExecutionNoteNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ToDomain(), nil
+}
+
+// ListByExecutionID returns the notes of an execution, oldest first
+func (r *ExecutionNoteRepository) ListByExecutionID(ctx context.Context, executionID, nodeID string) ([]*pkgmodels.ExecutionNote, error) {
+	id, err := uuid.Parse(executionID)
+	if err != nil {
+		return nil, pkgmodels.ErrInvalidExecutionID
+	}
+
+	var modelList []*models.ExecutionNoteModel
+	query := r.db.NewSelect().
+		Model(&modelList).
+		Where("en.execution_id = ?", id)
+	if nodeID != "" {
+		query = query.Where("en.node_id = ?", nodeID)
+	}
+	if err := query.Order("en.created_at ASC").Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	notes := make([]*pkgmodels.ExecutionNote, len(modelList))
+	for i, m := range modelList {
+		notes[i] = m.ToDomain()
+	}
+	return notes, nil
+}
+
+// UpdateBody replaces the body of a note and sets its updated_at
+func (r *ExecutionNoteRepository) UpdateBody(ctx context.Context, note *pkgmodels.ExecutionNote) error {
+	id, err := uuid.Parse(note.ID)
+	if err != nil {
+		return pkgmodels.ErrInvalidID
+	}
+
+	note.UpdatedAt = time.Now()
+	res, err := r.db.NewUpdate().
+		Model((*models.ExecutionNoteModel)(nil)).
+		Set("body = ?", note.Body).
+		Set("updated_at = ?", note.UpdatedAt).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update execution note: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return pkgmodels.ErrExecutionNoteNotFound
+	}
+	return nil
+}
+
+// Delete removes a note
+func (r *ExecutionNoteRepository) Delete(ctx context.Context, id string) error {
+	noteID, err := uuid.Parse(id)
+	if err != nil {
+		return pkgmodels.ErrInvalidID
+	}
+
+	res, err := r.db.NewDelete().
+		Model((*models.ExecutionNoteModel)(nil)).
+		Where("id = ?", noteID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return pkgmodels.ErrExecutionNoteNotFound
+	}
+	return nil
+}
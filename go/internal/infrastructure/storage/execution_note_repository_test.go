@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgmodels "github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/smilemakc/mbflow/go/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupExecutionNoteRepoTest(t *testing.T) (*ExecutionNoteRepository, string, func()) {
+	t.Helper()
+	db, cleanup := testutil.SetupTestTx(t)
+
+	workflow := createTestWorkflow(t, NewWorkflowRepository(db))
+	execution := &models.ExecutionModel{
+		WorkflowID: uuidPtr(workflow.ID),
+		Status:     "failed",
+	}
+	require.NoError(t, NewExecutionRepository(db).Create(context.Background(), execution))
+
+	return NewExecutionNoteRepository(db), execution.ID.String(), cleanup
+}
+
+func TestExecutionNoteRepo_CreateAndList(t *testing.T) {
+	t.Parallel()
+	repo, executionID, cleanup := setupExecutionNoteRepoTest(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	first := &pkgmodels.ExecutionNote{ExecutionID: executionID, AuthorName: "alice", Body: "Upstream API returned 503"}
+	require.NoError(t, repo.Create(ctx, first))
+	assert.NotEmpty(t, first.ID)
+	assert.False(t, first.CreatedAt.IsZero())
+
+	second := &pkgmodels.ExecutionNote{ExecutionID: executionID, NodeID: "node2", AuthorName: "bob", Body: "Retried manually"}
+	require.NoError(t, repo.Create(ctx, second))
+
+	notes, err := repo.ListByExecutionID(ctx, executionID, "")
+	require.NoError(t, err)
+	require.Len(t, notes, 2)
+	assert.Equal(t, first.ID, notes[0].ID)
+	assert.Equal(t, "node2", notes[1].NodeID)
+
+	notes, err = repo.ListByExecutionID(ctx, executionID, "node2")
+	require.NoError(t, err)
+	require.Len(t, notes, 1)
+	assert.Equal(t, "Retried manually", notes[0].Body)
+
+	notes, err = repo.ListByExecutionID(ctx, uuid.New().String(), "")
+	require.NoError(t, err)
+	assert.Empty(t, notes)
+}
+
+func TestExecutionNoteRepo_UpdateAndDelete(t *testing.T) {
+	t.Parallel()
+	repo, executionID, cleanup := setupExecutionNoteRepoTest(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	note := &pkgmodels.ExecutionNote{ExecutionID: executionID, Body: "Investigating"}
+	require.NoError(t, repo.Create(ctx, note))
+
+	note.Body = "Root cause: expired token"
+	require.NoError(t, repo.UpdateBody(ctx, note))
+
+	found, err := repo.GetByID(ctx, note.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Root cause: expired token", found.Body)
+
+	require.NoError(t, repo.Delete(ctx, note.ID))
+	_, err = repo.GetByID(ctx, note.ID)
+	assert.ErrorIs(t, err, pkgmodels.ErrExecutionNoteNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, note.ID), pkgmodels.ErrExecutionNoteNotFound)
+}
@@ -45,7 +45,7 @@ func (r *ExecutionRepository) Update(ctx context.Context, execution *models.Exec
 		_, err := tx.NewUpdate().
 			Model(execution).
 			Column("status", "output_data", "error", "completed_at", "variables", "updated_at").
-			Column("workflow_source").
+			Column("workflow_source", "resume_at", "resume_state", "event_keys").
 			Where("id = ?", execution.ID).
 			Exec(ctx)
 		if err != nil {
@@ -194,6 +194,202 @@ func (r *ExecutionRepository) FindRunning(ctx context.Context) ([]*models.Execut
 	return executions, nil
 }
 
+// FindDueSuspended retrieves paused executions whose resume time has passed, earliest first
+func (r *ExecutionRepository) FindDueSuspended(ctx context.Context, now time.Time, limit int) ([]*models.ExecutionModel, error) {
+	var executions []*models.ExecutionModel
+	err := r.db.NewSelect().
+		Model(&executions).
+		Where("status = ?", "paused").
+		Where("resume_at <= ?", now).
+		Order("resume_at ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find due suspended executions: %w", err)
+	}
+	return executions, nil
+}
+
+// FindPausedByEventKey retrieves paused executions with a node waiting for the event key
+func (r *ExecutionRepository) FindPausedByEventKey(ctx context.Context, key string, limit int) ([]*models.ExecutionModel, error) {
+	var executions []*models.ExecutionModel
+	err := r.db.NewSelect().
+		Model(&executions).
+		Where("status = ?", "paused").
+		Where("event_keys @> ARRAY[?]::text[]", key).
+		Order("started_at ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find executions waiting for event: %w", err)
+	}
+	return executions, nil
+}
+
+// ClaimSuspended moves a paused execution back to running so that only one resumer resumes it.
+// It reports false if the execution is no longer paused.
+func (r *ExecutionRepository) ClaimSuspended(ctx context.Context, id uuid.UUID) (bool, error) {
+	now := time.Now()
+	res, err := r.db.NewUpdate().
+		Model((*models.ExecutionModel)(nil)).
+		Set("status = ?", "running").
+		Set("updated_at = ?", now).
+		Set("checkpointed_at = ?", now).
+		Where("id = ?", id).
+		Where("status = ?", "paused").
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim suspended execution: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim suspended execution: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// ClaimFailed moves a failed execution back to running so that only one caller resumes it.
+// It reports false if the execution is no longer failed.
+func (r *ExecutionRepository) ClaimFailed(ctx context.Context, id uuid.UUID) (bool, error) {
+	now := time.Now()
+	res, err := r.db.NewUpdate().
+		Model((*models.ExecutionModel)(nil)).
+		Set("status = ?", "running").
+		Set("updated_at = ?", now).
+		Set("checkpointed_at = ?", now).
+		Where("id = ?", id).
+		Where("status = ?", "failed").
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim failed execution: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim failed execution: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// ClaimPending moves a pending execution to running so that only one worker runs it.
+// It reports false if the execution is no longer pending.
+func (r *ExecutionRepository) ClaimPending(ctx context.Context, id uuid.UUID) (bool, error) {
+	now := time.Now()
+	res, err := r.db.NewUpdate().
+		Model((*models.ExecutionModel)(nil)).
+		Set("status = ?", "running").
+		Set("updated_at = ?", now).
+		Set("checkpointed_at = ?", now).
+		Where("id = ?", id).
+		Where("status = ?", "pending").
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim pending execution: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim pending execution: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// FindDueScheduled retrieves pending executions whose scheduled start has come, earliest first
+func (r *ExecutionRepository) FindDueScheduled(ctx context.Context, now time.Time, limit int) ([]*models.ExecutionModel, error) {
+	var executions []*models.ExecutionModel
+	err := r.db.NewSelect().
+		Model(&executions).
+		Where("status = ?", "pending").
+		Where("resume_at <= ?", now).
+		Order("resume_at ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find due scheduled executions: %w", err)
+	}
+	return executions, nil
+}
+
+// ClaimScheduled clears the scheduled start of a pending execution so that only one instance
+// starts it, and records when it actually starts.
+func (r *ExecutionRepository) ClaimScheduled(ctx context.Context, id uuid.UUID, now time.Time) (bool, error) {
+	res, err := r.db.NewUpdate().
+		Model((*models.ExecutionModel)(nil)).
+		Set("resume_at = NULL").
+		Set("started_at = ?", now).
+		Set("updated_at = ?", now).
+		Where("id = ?", id).
+		Where("status = ?", "pending").
+		Where("resume_at IS NOT NULL").
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim scheduled execution: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim scheduled execution: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// SaveCheckpoint stores the state of a running execution and the time it was saved.
+// It reports false if the execution is no longer running.
+func (r *ExecutionRepository) SaveCheckpoint(ctx context.Context, id uuid.UUID, state models.JSONBMap, at time.Time) (bool, error) {
+	res, err := r.db.NewUpdate().
+		Model((*models.ExecutionModel)(nil)).
+		Set("resume_state = ?", state).
+		Set("checkpointed_at = ?", at).
+		Where("id = ?", id).
+		Where("status = ?", "running").
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to save execution checkpoint: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to save execution checkpoint: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// FindStaleRunning retrieves running executions not checkpointed since before, oldest first.
+// Executions never checkpointed count from their start.
+func (r *ExecutionRepository) FindStaleRunning(ctx context.Context, before time.Time, limit int) ([]*models.ExecutionModel, error) {
+	var executions []*models.ExecutionModel
+	err := r.db.NewSelect().
+		Model(&executions).
+		Where("status = ?", "running").
+		Where("COALESCE(checkpointed_at, started_at) < ?", before).
+		OrderExpr("COALESCE(checkpointed_at, started_at) ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stale running executions: %w", err)
+	}
+	return executions, nil
+}
+
+// ClaimStale takes over a running execution not checkpointed since before by refreshing its
+// checkpoint time, so that only one instance recovers it. It reports false if the execution
+// is no longer running or was checkpointed since.
+func (r *ExecutionRepository) ClaimStale(ctx context.Context, id uuid.UUID, before time.Time) (bool, error) {
+	now := time.Now()
+	res, err := r.db.NewUpdate().
+		Model((*models.ExecutionModel)(nil)).
+		Set("checkpointed_at = ?", now).
+		Set("updated_at = ?", now).
+		Where("id = ?", id).
+		Where("status = ?", "running").
+		Where("COALESCE(checkpointed_at, started_at) < ?", before).
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim stale execution: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim stale execution: %w", err)
+	}
+	return affected > 0, nil
+}
+
 // Count returns the total count of executions
 func (r *ExecutionRepository) Count(ctx context.Context) (int, error) {
 	count, err := r.db.NewSelect().
@@ -403,6 +599,24 @@ func (r *ExecutionRepository) GetStatistics(ctx context.Context, workflowID *uui
 		stats.AverageDuration = &duration
 	}
 
+	// Count failed node output assertions
+	assertionFailures, err := r.db.NewSelect().
+		Model((*models.EventModel)(nil)).
+		Join("JOIN mbflow_executions AS ex ON ex.id = ev.execution_id").
+		Where("ev.event_type = ?", "node.assertion_failed").
+		Where("ex.started_at >= ? AND ex.started_at <= ?", from, to).
+		Apply(func(q *bun.SelectQuery) *bun.SelectQuery {
+			if workflowID != nil {
+				return q.Where("ex.workflow_id = ?", *workflowID)
+			}
+			return q
+		}).
+		Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count assertion failures: %w", err)
+	}
+	stats.AssertionFailures = assertionFailures
+
 	// Calculate success and failure rates
 	if stats.TotalExecutions > 0 {
 		stats.SuccessRate = float64(stats.CompletedCount) / float64(stats.TotalExecutions)
@@ -0,0 +1,270 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+)
+
+var _ repository.ExecutionStatsRepository = (*ExecutionStatsRepository)(nil)
+
+const (
+	executionStatsHourlyTable = "mbflow_execution_stats_hourly"
+	executionStatsDailyTable  = "mbflow_execution_stats_daily"
+)
+
+// executionStatsTables maps a granularity to its rollup table
+var executionStatsTables = map[repository.StatsGranularity]string{
+	repository.StatsGranularityHour: executionStatsHourlyTable,
+	repository.StatsGranularityDay:  executionStatsDailyTable,
+}
+
+// executionStatsUpsert is the conflict clause shared by both rollup tables
+const executionStatsUpsert = `
+ON CONFLICT (workflow_id, bucket_start) DO UPDATE SET
+	executions = EXCLUDED.executions,
+	completed = EXCLUDED.completed,
+	failed = EXCLUDED.failed,
+	cancelled = EXCLUDED.cancelled,
+	duration_ms_sum = EXCLUDED.duration_ms_sum,
+	duration_ms_max = EXCLUDED.duration_ms_max,
+	node_executions = EXCLUDED.node_executions,
+	node_failures = EXCLUDED.node_failures,
+	assertion_failures = EXCLUDED.assertion_failures,
+	updated_at = EXCLUDED.updated_at`
+
+// ExecutionStatsRepository implements repository.ExecutionStatsRepository
+type ExecutionStatsRepository struct {
+	db bun.IDB
+}
+
+// NewExecutionStatsRepository creates a new ExecutionStatsRepository
+func NewExecutionStatsRepository(db bun.IDB) *ExecutionStatsRepository {
+	return &ExecutionStatsRepository{db: db}
+}
+
+// LatestHourlyBucket returns the start of the newest hourly bucket
+func (r *ExecutionStatsRepository) LatestHourlyBucket(ctx context.Context) (*time.Time, error) {
+	var latest bun.NullTime
+	err := r.db.NewRaw("SELECT MAX(bucket_start) FROM ?", bun.Ident(executionStatsHourlyTable)).Scan(ctx, &latest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest hourly bucket: %w", err)
+	}
+	if latest.IsZero() {
+		return nil, nil
+	}
+	return &latest.Time, nil
+}
+
+// EarliestExecutionStart returns the start time of the oldest stored workflow execution
+func (r *ExecutionStatsRepository) EarliestExecutionStart(ctx context.Context) (*time.Time, error) {
+	var earliest bun.NullTime
+	err := r.db.NewRaw("SELECT MIN(started_at) FROM mbflow_executions WHERE workflow_id IS NOT NULL").Scan(ctx, &earliest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get earliest execution: %w", err)
+	}
+	if earliest.IsZero() {
+		return nil, nil
+	}
+	return &earliest.Time, nil
+}
+
+// RollupHourly recomputes hourly buckets for executions started in [from, to).
+// Inline (ephemeral) executions have no workflow and are not rolled up.
+func (r *ExecutionStatsRepository) RollupHourly(ctx context.Context, from, to time.Time) (int, error) {
+	query := `
+WITH ex AS (
+	SELECT id, workflow_id, status,
+		date_trunc('hour', started_at, 'UTC') AS bucket_start,
+		CASE WHEN status = 'completed' AND completed_at IS NOT NULL
+			THEN (EXTRACT(EPOCH FROM (completed_at - started_at)) * 1000)::BIGINT END AS duration_ms
+	FROM mbflow_executions
+	WHERE workflow_id IS NOT NULL AND started_at >= ? AND started_at < ?
+), ne AS (
+	SELECT ne.execution_id, COUNT(*) AS total, COUNT(*) FILTER (WHERE ne.status = 'failed') AS failed
+	FROM mbflow_node_executions AS ne
+	JOIN ex ON ex.id = ne.execution_id
+	GROUP BY ne.execution_id
+), ev AS (
+	SELECT ev.execution_id, COUNT(*) AS total
+	FROM mbflow_events AS ev
+	JOIN ex ON ex.id = ev.execution_id
+	WHERE ev.event_type = 'node.assertion_failed'
+	GROUP BY ev.execution_id
+)
+INSERT INTO ? (workflow_id, bucket_start, executions, completed, failed, cancelled,
+	duration_ms_sum, duration_ms_max, node_executions, node_failures, assertion_failures, updated_at)
+SELECT ex.workflow_id, ex.bucket_start,
+	COUNT(*),
+	COUNT(*) FILTER (WHERE ex.status = 'completed'),
+	COUNT(*) FILTER (WHERE ex.status = 'failed'),
+	COUNT(*) FILTER (WHERE ex.status = 'cancelled'),
+	COALESCE(SUM(ex.duration_ms), 0),
+	COALESCE(MAX(ex.duration_ms), 0),
+	COALESCE(SUM(ne.total), 0),
+	COALESCE(SUM(ne.failed), 0),
+	COALESCE(SUM(ev.total), 0),
+	NOW()
+FROM ex
+LEFT JOIN ne ON ne.execution_id = ex.id
+LEFT JOIN ev ON ev.execution_id = ex.id
+GROUP BY ex.workflow_id, ex.bucket_start` + executionStatsUpsert
+
+	res, err := r.db.NewRaw(query, from, to, bun.Ident(executionStatsHourlyTable)).Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to roll up hourly stats: %w", err)
+	}
+	return rowsAffected(res), nil
+}
+
+// RollupDaily recomputes daily buckets in [from, to) from the hourly table
+func (r *ExecutionStatsRepository) RollupDaily(ctx context.Context, from, to time.Time) (int, error) {
+	query := `
+INSERT INTO ? (workflow_id, bucket_start, executions, completed, failed, cancelled,
+	duration_ms_sum, duration_ms_max, node_executions, node_failures, assertion_failures, updated_at)
+SELECT workflow_id, date_trunc('day', bucket_start, 'UTC') AS day_start,
+	SUM(executions), SUM(completed), SUM(failed), SUM(cancelled),
+	SUM(duration_ms_sum), MAX(duration_ms_max),
+	SUM(node_executions), SUM(node_failures), SUM(assertion_failures),
+	NOW()
+FROM ?
+WHERE bucket_start >= ? AND bucket_start < ?
+GROUP BY workflow_id, day_start` + executionStatsUpsert
+
+	res, err := r.db.NewRaw(query,
+		bun.Ident(executionStatsDailyTable), bun.Ident(executionStatsHourlyTable), from, to,
+	).Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to roll up daily stats: %w", err)
+	}
+	return rowsAffected(res), nil
+}
+
+// DeleteRawBefore removes events and node executions of executions finished before the cutoff.
+// Running executions keep their raw data regardless of age.
+func (r *ExecutionStatsRepository) DeleteRawBefore(ctx context.Context, cutoff time.Time) (int, int, error) {
+	finished := r.db.NewSelect().
+		Table("mbflow_executions").
+		Column("id").
+		Where("status IN (?)", bun.In([]string{"completed", "failed", "cancelled"})).
+		Where("completed_at < ?", cutoff)
+
+	res, err := r.db.NewDelete().
+		TableExpr("mbflow_events").
+		Where("execution_id IN (?)", finished).
+		Exec(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to delete raw events: %w", err)
+	}
+	events := rowsAffected(res)
+
+	res, err = r.db.NewDelete().
+		TableExpr("mbflow_node_executions").
+		Where("execution_id IN (?)", finished).
+		Exec(ctx)
+	if err != nil {
+		return events, 0, fmt.Errorf("failed to delete raw node executions: %w", err)
+	}
+
+	return events, rowsAffected(res), nil
+}
+
+// DeleteHourlyBefore removes hourly buckets that start before the cutoff
+func (r *ExecutionStatsRepository) DeleteHourlyBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	res, err := r.db.NewDelete().
+		TableExpr(executionStatsHourlyTable).
+		Where("bucket_start < ?", cutoff).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete hourly stats: %w", err)
+	}
+	return rowsAffected(res), nil
+}
+
+// ListBuckets returns buckets in [from, to) ordered by time
+func (r *ExecutionStatsRepository) ListBuckets(
+	ctx context.Context,
+	granularity repository.StatsGranularity,
+	workflowID *uuid.UUID,
+	from, to time.Time,
+) ([]*repository.ExecutionStatsBucket, error) {
+	table, ok := executionStatsTables[granularity]
+	if !ok {
+		return nil, fmt.Errorf("unsupported stats granularity: %s", granularity)
+	}
+
+	var rows []struct {
+		BucketStart       time.Time `bun:"bucket_start"`
+		Executions        int       `bun:"executions"`
+		Completed         int       `bun:"completed"`
+		Failed            int       `bun:"failed"`
+		Cancelled         int       `bun:"cancelled"`
+		DurationMsSum     int64     `bun:"duration_ms_sum"`
+		DurationMsMax     int64     `bun:"duration_ms_max"`
+		NodeExecutions    int       `bun:"node_executions"`
+		NodeFailures      int       `bun:"node_failures"`
+		AssertionFailures int       `bun:"assertion_failures"`
+	}
+
+	err := r.db.NewSelect().
+		TableExpr("? AS st", bun.Ident(table)).
+		Column("bucket_start").
+		ColumnExpr("SUM(executions) AS executions").
+		ColumnExpr("SUM(completed) AS completed").
+		ColumnExpr("SUM(failed) AS failed").
+		ColumnExpr("SUM(cancelled) AS cancelled").
+		ColumnExpr("SUM(duration_ms_sum) AS duration_ms_sum").
+		ColumnExpr("MAX(duration_ms_max) AS duration_ms_max").
+		ColumnExpr("SUM(node_executions) AS node_executions").
+		ColumnExpr("SUM(node_failures) AS node_failures").
+		ColumnExpr("SUM(assertion_failures) AS assertion_failures").
+		Where("bucket_start >= ? AND bucket_start < ?", from, to).
+		Apply(func(q *bun.SelectQuery) *bun.SelectQuery {
+			if workflowID != nil {
+				return q.Where("workflow_id = ?", *workflowID)
+			}
+			return q
+		}).
+		Group("bucket_start").
+		Order("bucket_start ASC").
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stats buckets: %w", err)
+	}
+
+	buckets := make([]*repository.ExecutionStatsBucket, len(rows))
+	for i, row := range rows {
+		bucket := &repository.ExecutionStatsBucket{
+			BucketStart:       row.BucketStart.UTC(),
+			Executions:        row.Executions,
+			CompletedCount:    row.Completed,
+			FailedCount:       row.Failed,
+			CancelledCount:    row.Cancelled,
+			MaxDurationMs:     row.DurationMsMax,
+			NodeExecutions:    row.NodeExecutions,
+			NodeFailures:      row.NodeFailures,
+			AssertionFailures: row.AssertionFailures,
+		}
+		if row.Completed > 0 {
+			bucket.AverageDurationMs = row.DurationMsSum / int64(row.Completed)
+		}
+		buckets[i] = bucket
+	}
+
+	return buckets, nil
+}
+
+// rowsAffected returns the affected row count of a result, or 0 if the driver does not report it
+func rowsAffected(res sql.Result) int {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0
+	}
+	return int(n)
+}
@@ -0,0 +1,282 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgmodels "github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+var _ repository.InboxRepository = (*InboxRepository)(nil)
+
+// InboxRepository implements repository.InboxRepository
+type InboxRepository struct {
+	db bun.IDB
+}
+
+// NewInboxRepository creates a new InboxRepository
+func NewInboxRepository(db bun.IDB) *InboxRepository {
+	return &InboxRepository{db: db}
+}
+
+// failedExecutionRow is a failed execution with its workflow and first failed node
+type failedExecutionRow struct {
+	ID           uuid.UUID  `bun:"id"`
+	WorkflowID   uuid.UUID  `bun:"workflow_id"`
+	WorkflowName string     `bun:"workflow_name"`
+	CreatedBy    *uuid.UUID `bun:"created_by"`
+	Error        string     `bun:"error"`
+	NodeName     *string    `bun:"node_name"`
+	StartedAt    *time.Time `bun:"started_at"`
+	CompletedAt  *time.Time `bun:"completed_at"`
+}
+
+// ListFailedExecutions returns the failed executions matching the filter that no later
+// execution of their workflow completed, newest first, and their total count
+func (r *InboxRepository) ListFailedExecutions(ctx context.Context, filter pkgmodels.InboxFilter) ([]*pkgmodels.InboxItem, int, error) {
+	query := r.db.NewSelect().
+		TableExpr("mbflow_executions AS ex").
+		ColumnExpr("ex.id, ex.workflow_id, ex.error, ex.started_at, ex.completed_at").
+		ColumnExpr("w.name AS workflow_name, w.created_by").
+		ColumnExpr(`(SELECT ne.node_name FROM mbflow_node_executions AS ne
+			WHERE ne.execution_id = ex.id AND ne.status = 'failed'
+			ORDER BY ne.completed_at LIMIT 1) AS node_name`).
+		Join("JOIN mbflow_workflows AS w ON w.id = ex.workflow_id").
+		Where("ex.status = ?", string(pkgmodels.ExecutionStatusFailed)).
+		Where(`NOT EXISTS (SELECT 1 FROM mbflow_executions AS later
+			WHERE later.workflow_id = ex.workflow_id AND later.status = ? AND later.started_at > ex.started_at)`,
+			string(pkgmodels.ExecutionStatusCompleted))
+
+	query, err := whereInboxWorkflow(query, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if filter.Assignee != "" {
+		query, err = whereAssignedToOwner(query, filter.Assignee)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("COALESCE(ex.completed_at, ex.started_at) >= ?", filter.Since)
+	}
+
+	var rows []failedExecutionRow
+	total, err := query.
+		OrderExpr("COALESCE(ex.completed_at, ex.started_at) DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		ScanAndCount(ctx, &rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]*pkgmodels.InboxItem, 0, len(rows))
+	for _, row := range rows {
+		item := &pkgmodels.InboxItem{
+			Kind:         pkgmodels.InboxItemFailedExecution,
+			ExecutionID:  row.ID.String(),
+			WorkflowID:   row.WorkflowID.String(),
+			WorkflowName: row.WorkflowName,
+			Summary:      row.Error,
+		}
+		if row.CreatedBy != nil {
+			item.Assignee = row.CreatedBy.String()
+		}
+		if row.NodeName != nil {
+			item.NodeName = *row.NodeName
+		}
+		switch {
+		case row.CompletedAt != nil:
+			item.CreatedAt = *row.CompletedAt
+		case row.StartedAt != nil:
+			item.CreatedAt = *row.StartedAt
+		}
+		items = append(items, item)
+	}
+
+	return items, total, nil
+}
+
+// pendingApprovalRow is an approval node execution waiting for a decision, with its workflow
+type pendingApprovalRow struct {
+	ExecutionID    uuid.UUID       `bun:"execution_id"`
+	WorkflowID     uuid.UUID       `bun:"workflow_id"`
+	WorkflowName   string          `bun:"workflow_name"`
+	CreatedBy      *uuid.UUID      `bun:"created_by"`
+	NodeID         string          `bun:"node_id"`
+	NodeName       *string         `bun:"node_name"`
+	ResolvedConfig models.JSONBMap `bun:"resolved_config"`
+	StartedAt      *time.Time      `bun:"started_at"`
+}
+
+// ListPendingApprovals returns the approval nodes of paused executions waiting for a decision
+// that match the filter, newest first, and their total count
+func (r *InboxRepository) ListPendingApprovals(ctx context.Context, filter pkgmodels.InboxFilter) ([]*pkgmodels.InboxItem, int, error) {
+	query := r.db.NewSelect().
+		TableExpr("mbflow_node_executions AS ne").
+		ColumnExpr("ex.id AS execution_id, ex.workflow_id").
+		ColumnExpr("w.name AS workflow_name, w.created_by").
+		ColumnExpr("COALESCE(n.node_id, ne.node_key) AS node_id, COALESCE(n.name, ne.node_name) AS node_name").
+		ColumnExpr("ne.resolved_config, ne.started_at").
+		Join("JOIN mbflow_executions AS ex ON ex.id = ne.execution_id").
+		Join("JOIN mbflow_workflows AS w ON w.id = ex.workflow_id").
+		Join("LEFT JOIN mbflow_nodes AS n ON n.id = ne.node_id").
+		Where("ex.status = ?", string(pkgmodels.ExecutionStatusPaused)).
+		Where("COALESCE(n.type, ne.node_type) = 'approval'").
+		// A pending approval node waits for the event deciding it (see models.ApprovalEventKey)
+		Where("'approval:' || ex.id::text || ':' || COALESCE(n.node_id, ne.node_key) = ANY(ex.event_keys)")
+
+	query, err := whereInboxWorkflow(query, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if filter.Assignee != "" {
+		// Approvals without approvers are the workflow owner's to decide
+		query = query.Where(`(ne.resolved_config->'approvers' @> to_jsonb(?::text)
+			OR (COALESCE(ne.resolved_config->'approvers', 'null') IN ('null', '""', '[]') AND w.created_by::text = ?))`,
+			filter.Assignee, filter.Assignee)
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("ne.started_at >= ?", filter.Since)
+	}
+
+	var rows []pendingApprovalRow
+	total, err := query.
+		OrderExpr("ne.started_at DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		ScanAndCount(ctx, &rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]*pkgmodels.InboxItem, 0, len(rows))
+	for _, row := range rows {
+		config := map[string]any(row.ResolvedConfig)
+		item := &pkgmodels.InboxItem{
+			Kind:         pkgmodels.InboxItemPendingApproval,
+			ExecutionID:  row.ExecutionID.String(),
+			WorkflowID:   row.WorkflowID.String(),
+			WorkflowName: row.WorkflowName,
+			NodeID:       row.NodeID,
+		}
+		item.Summary, _ = config["message"].(string)
+		item.Approvers, _ = pkgmodels.ApprovalApprovers(config)
+		if row.CreatedBy != nil {
+			item.Assignee = row.CreatedBy.String()
+		}
+		if row.NodeName != nil {
+			item.NodeName = *row.NodeName
+		}
+		if row.StartedAt != nil {
+			item.CreatedAt = *row.StartedAt
+		}
+		items = append(items, item)
+	}
+
+	return items, total, nil
+}
+
+// breakpointRow is a paused execution stopped at breakpoints, with its workflow
+type breakpointRow struct {
+	ID           uuid.UUID  `bun:"id"`
+	WorkflowID   uuid.UUID  `bun:"workflow_id"`
+	WorkflowName string     `bun:"workflow_name"`
+	CreatedBy    *uuid.UUID `bun:"created_by"`
+	Breakpoints  []string   `bun:"breakpoints,type:jsonb"`
+	NodeName     *string    `bun:"node_name"`
+	UpdatedAt    time.Time  `bun:"updated_at"`
+}
+
+// ListBreakpoints returns the paused executions stopped at breakpoints that match the filter,
+// newest first, and their total count
+func (r *InboxRepository) ListBreakpoints(ctx context.Context, filter pkgmodels.InboxFilter) ([]*pkgmodels.InboxItem, int, error) {
+	query := r.db.NewSelect().
+		TableExpr("mbflow_executions AS ex").
+		ColumnExpr("ex.id, ex.workflow_id, ex.updated_at").
+		ColumnExpr("ex.resume_state->'breakpoints_hit' AS breakpoints").
+		ColumnExpr("w.name AS workflow_name, w.created_by").
+		ColumnExpr(`(SELECT n.name FROM mbflow_nodes AS n
+			WHERE n.workflow_id = ex.workflow_id AND n.node_id = ex.resume_state->'breakpoints_hit'->>0) AS node_name`).
+		Join("JOIN mbflow_workflows AS w ON w.id = ex.workflow_id").
+		Where("ex.status = ?", string(pkgmodels.ExecutionStatusPaused)).
+		Where("jsonb_array_length(COALESCE(ex.resume_state->'breakpoints_hit', '[]')) > 0")
+
+	query, err := whereInboxWorkflow(query, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if filter.Assignee != "" {
+		query, err = whereAssignedToOwner(query, filter.Assignee)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("ex.updated_at >= ?", filter.Since)
+	}
+
+	var rows []breakpointRow
+	total, err := query.
+		OrderExpr("ex.updated_at DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		ScanAndCount(ctx, &rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]*pkgmodels.InboxItem, 0, len(rows))
+	for _, row := range rows {
+		item := &pkgmodels.InboxItem{
+			Kind:         pkgmodels.InboxItemBreakpoint,
+			ExecutionID:  row.ID.String(),
+			WorkflowID:   row.WorkflowID.String(),
+			WorkflowName: row.WorkflowName,
+			Summary:      "Stopped at breakpoints " + strings.Join(row.Breakpoints, ", "),
+			CreatedAt:    row.UpdatedAt,
+		}
+		if len(row.Breakpoints) > 0 {
+			item.NodeID = row.Breakpoints[0]
+		}
+		if row.CreatedBy != nil {
+			item.Assignee = row.CreatedBy.String()
+		}
+		if row.NodeName != nil {
+			item.NodeName = *row.NodeName
+		}
+		items = append(items, item)
+	}
+
+	return items, total, nil
+}
+
+// whereInboxWorkflow selects the items of the filter's workflow, if any, from a query joining
+// the executions as ex
+func whereInboxWorkflow(query *bun.SelectQuery, filter pkgmodels.InboxFilter) (*bun.SelectQuery, error) {
+	if filter.WorkflowID == "" {
+		return query, nil
+	}
+	workflowID, err := uuid.Parse(filter.WorkflowID)
+	if err != nil {
+		return nil, pkgmodels.ErrInvalidWorkflowID
+	}
+	return query.Where("ex.workflow_id = ?", workflowID), nil
+}
+
+// whereAssignedToOwner selects the items of the workflows the assignee owns from a query
+// joining the workflows as w
+func whereAssignedToOwner(query *bun.SelectQuery, assignee string) (*bun.SelectQuery, error) {
+	owner, err := uuid.Parse(assignee)
+	if err != nil {
+		return nil, &pkgmodels.ValidationError{Field: "assignee", Message: "assignee must be a user ID"}
+	}
+	return query.Where("w.created_by = ?", owner), nil
+}
@@ -0,0 +1,197 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgmodels "github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+var _ repository.IncidentRepository = (*IncidentRepository)(nil)
+
+// IncidentRepository implements repository.IncidentRepository
+type IncidentRepository struct {
+	db bun.IDB
+}
+
+// NewIncidentRepository creates a new IncidentRepository
+func NewIncidentRepository(db bun.IDB) *IncidentRepository {
+	return &IncidentRepository{db: db}
+}
+
+// SavePolicy creates or replaces the incident policy of a workflow
+func (r *IncidentRepository) SavePolicy(ctx context.Context, policy *pkgmodels.IncidentPolicy) error {
+	model, err := models.FromIncidentPolicyDomain(policy)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	model.CreatedAt = now
+	model.UpdatedAt = now
+
+	_, err = r.db.NewInsert().
+		Model(model).
+		On("CONFLICT (workflow_id) DO UPDATE").
+		Set("runbook_url = EXCLUDED.runbook_url").
+		Set("tracker = EXCLUDED.tracker").
+		Set("project = EXCLUDED.project").
+		Set("base_url = EXCLUDED.base_url").
+		Set("credential_id = EXCLUDED.credential_id").
+		Set("labels = EXCLUDED.labels").
+		Set("issue_type = EXCLUDED.issue_type").
+		Set("on_canary_alert = EXCLUDED.on_canary_alert").
+		Set("failure_threshold = EXCLUDED.failure_threshold").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to save incident policy: %w", err)
+	}
+
+	name := policy.WorkflowName
+	*policy = *model.ToDomain()
+	policy.WorkflowName = name
+	return nil
+}
+
+// GetPolicy returns the incident policy of a workflow
+func (r *IncidentRepository) GetPolicy(ctx context.Context, workflowID string) (*pkgmodels.IncidentPolicy, error) {
+	id, err := uuid.Parse(workflowID)
+	if err != nil {
+		return nil, pkgmodels.ErrInvalidWorkflowID
+	}
+
+	model := &models.IncidentPolicyModel{}
+	err = r.db.NewSelect().
+		Model(model).
+		Relation("Workflow", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Column("name")
+		}).
+		Where("ip.workflow_id = ?", id).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, pkgmodels.ErrIncidentPolicyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ToDomain(), nil
+}
+
+// DeletePolicy removes the incident policy of a workflow
+func (r *IncidentRepository) DeletePolicy(ctx context.Context, workflowID string) error {
+	id, err := uuid.Parse(workflowID)
+	if err != nil {
+		return pkgmodels.ErrInvalidWorkflowID
+	}
+
+	res, err := r.db.NewDelete().
+		Model((*models.IncidentPolicyModel)(nil)).
+		Where("workflow_id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return pkgmodels.ErrIncidentPolicyNotFound
+	}
+	return nil
+}
+
+// Create stores an incident and sets its ID and created_at
+func (r *IncidentRepository) Create(ctx context.Context, incident *pkgmodels.Incident) error {
+	workflowID, err := uuid.Parse(incident.WorkflowID)
+	if err != nil {
+		return pkgmodels.ErrInvalidWorkflowID
+	}
+
+	model := &models.IncidentModel{
+		ID:         uuid.New(),
+		WorkflowID: workflowID,
+		Source:     string(incident.Source),
+		Title:      incident.Title,
+		Tracker:    incident.Tracker,
+		Project:    incident.Project,
+		ExternalID: incident.ExternalID,
+		Key:        incident.Key,
+		URL:        incident.URL,
+		RunbookURL: incident.RunbookURL,
+		CreatedAt:  time.Now(),
+	}
+	if incident.ExecutionID != "" {
+		id, err := uuid.Parse(incident.ExecutionID)
+		if err != nil {
+			return pkgmodels.ErrInvalidExecutionID
+		}
+		model.ExecutionID = &id
+	}
+	if incident.CreatedBy != "" {
+		if id, err := uuid.Parse(incident.CreatedBy); err == nil {
+			model.CreatedBy = &id
+		}
+	}
+
+	if _, err := r.db.NewInsert().Model(model).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to create incident: %w", err)
+	}
+
+	*incident = *model.ToDomain()
+	return nil
+}
+
+// ListByExecutionID returns the incidents linked to an execution, newest first
+func (r *IncidentRepository) ListByExecutionID(ctx context.Context, executionID string) ([]*pkgmodels.Incident, error) {
+	id, err := uuid.Parse(executionID)
+	if err != nil {
+		return nil, pkgmodels.ErrInvalidExecutionID
+	}
+
+	var modelList []*models.IncidentModel
+	err = r.db.NewSelect().
+		Model(&modelList).
+		Where("inc.execution_id = ?", id).
+		Order("inc.created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return incidentsToDomain(modelList), nil
+}
+
+// ListByWorkflowID returns the most recent incidents of a workflow, newest first
+func (r *IncidentRepository) ListByWorkflowID(ctx context.Context, workflowID string, limit int) ([]*pkgmodels.Incident, error) {
+	id, err := uuid.Parse(workflowID)
+	if err != nil {
+		return nil, pkgmodels.ErrInvalidWorkflowID
+	}
+
+	var modelList []*models.IncidentModel
+	err = r.db.NewSelect().
+		Model(&modelList).
+		Where("inc.workflow_id = ?", id).
+		Order("inc.created_at DESC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return incidentsToDomain(modelList), nil
+}
+
+func incidentsToDomain(modelList []*models.IncidentModel) []*pkgmodels.Incident {
+	incidents := make([]*pkgmodels.Incident, len(modelList))
+	for i, m := range modelList {
+		incidents[i] = m.ToDomain()
+	}
+	return incidents
+}
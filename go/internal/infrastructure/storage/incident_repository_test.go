@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgmodels "github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/smilemakc/mbflow/go/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupIncidentRepoTest(t *testing.T) (*IncidentRepository, string, string, func()) {
+	t.Helper()
+	db, cleanup := testutil.SetupTestTx(t)
+
+	workflow := createTestWorkflow(t, NewWorkflowRepository(db))
+	execution := &models.ExecutionModel{
+		WorkflowID: uuidPtr(workflow.ID),
+		Status:     "failed",
+	}
+	require.NoError(t, NewExecutionRepository(db).Create(context.Background(), execution))
+
+	return NewIncidentRepository(db), workflow.ID.String(), execution.ID.String(), cleanup
+}
+
+func TestIncidentRepo_Policy(t *testing.T) {
+	t.Parallel()
+	repo, workflowID, _, cleanup := setupIncidentRepoTest(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	_, err := repo.GetPolicy(ctx, workflowID)
+	assert.ErrorIs(t, err, pkgmodels.ErrIncidentPolicyNotFound)
+
+	policy := &pkgmodels.IncidentPolicy{
+		WorkflowID:       workflowID,
+		RunbookURL:       "https://wiki.acme.io/runbooks/api",
+		Tracker:          pkgmodels.IncidentTrackerGitHub,
+		Project:          "acme/api",
+		CredentialID:     uuid.New().String(),
+		Labels:           []string{"incident"},
+		FailureThreshold: 3,
+	}
+	require.NoError(t, repo.SavePolicy(ctx, policy))
+
+	policy.FailureThreshold = 5
+	policy.OnCanaryAlert = true
+	require.NoError(t, repo.SavePolicy(ctx, policy))
+
+	found, err := repo.GetPolicy(ctx, workflowID)
+	require.NoError(t, err)
+	assert.Equal(t, 5, found.FailureThreshold)
+	assert.True(t, found.OnCanaryAlert)
+	assert.Equal(t, []string{"incident"}, found.Labels)
+	assert.Equal(t, policy.CredentialID, found.CredentialID)
+	assert.NotEmpty(t, found.WorkflowName)
+
+	require.NoError(t, repo.DeletePolicy(ctx, workflowID))
+	assert.ErrorIs(t, repo.DeletePolicy(ctx, workflowID), pkgmodels.ErrIncidentPolicyNotFound)
+}
+
+func TestIncidentRepo_CreateAndList(t *testing.T) {
+	t.Parallel()
+	repo, workflowID, executionID, cleanup := setupIncidentRepoTest(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	first := &pkgmodels.Incident{
+		WorkflowID:  workflowID,
+		ExecutionID: executionID,
+		Source:      pkgmodels.IncidentSourceRepeatedFailures,
+		Title:       "Workflow failed 3 times in a row",
+		Tracker:     pkgmodels.IncidentTrackerGitHub,
+		Project:     "acme/api",
+		Key:         "42",
+		URL:         "https://github.com/acme/api/issues/42",
+	}
+	require.NoError(t, repo.Create(ctx, first))
+	assert.NotEmpty(t, first.ID)
+	assert.False(t, first.CreatedAt.IsZero())
+
+	second := &pkgmodels.Incident{
+		WorkflowID: workflowID,
+		Source:     pkgmodels.IncidentSourceCanaryAlert,
+		Title:      "Canary is failing",
+		Tracker:    pkgmodels.IncidentTrackerGitHub,
+		Project:    "acme/api",
+	}
+	require.NoError(t, repo.Create(ctx, second))
+
+	incidents, err := repo.ListByExecutionID(ctx, executionID)
+	require.NoError(t, err)
+	require.Len(t, incidents, 1)
+	assert.Equal(t, "42", incidents[0].Key)
+	assert.Equal(t, pkgmodels.IncidentSourceRepeatedFailures, incidents[0].Source)
+
+	incidents, err = repo.ListByWorkflowID(ctx, workflowID, 10)
+	require.NoError(t, err)
+	require.Len(t, incidents, 2)
+	assert.Equal(t, second.ID, incidents[0].ID)
+}
@@ -13,13 +13,14 @@ import (
 type BillingAccountModel struct {
 	bun.BaseModel `bun:"table:mbflow_billing_accounts,alias:ba"`
 
-	ID        uuid.UUID `bun:"id,pk,type:uuid,default:uuid_generate_v4()" json:"id"`
-	UserID    uuid.UUID `bun:"user_id,notnull,type:uuid" json:"user_id" validate:"required"`
-	Balance   float64   `bun:"balance,notnull,default:0" json:"balance" validate:"min=0"`
-	Currency  string    `bun:"currency,notnull,default:'USD'" json:"currency" validate:"required,len=3"`
-	Status    string    `bun:"status,notnull,default:'active'" json:"status" validate:"required,oneof=active suspended closed"`
-	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
-	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp" json:"updated_at"`
+	ID        uuid.UUID             `bun:"id,pk,type:uuid,default:uuid_generate_v4()" json:"id"`
+	UserID    uuid.UUID             `bun:"user_id,notnull,type:uuid" json:"user_id" validate:"required"`
+	Balance   float64               `bun:"balance,notnull,default:0" json:"balance" validate:"min=0"`
+	Currency  string                `bun:"currency,notnull,default:'USD'" json:"currency" validate:"required,len=3"`
+	Status    string                `bun:"status,notnull,default:'active'" json:"status" validate:"required,oneof=active suspended closed"`
+	Quota     *pkgmodels.UsageQuota `bun:"quota,type:jsonb" json:"quota,omitempty"`
+	CreatedAt time.Time             `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time             `bun:"updated_at,notnull,default:current_timestamp" json:"updated_at"`
 
 	// Relations
 	User         *UserModel          `bun:"rel:belongs-to,join:user_id=id" json:"user,omitempty"`
@@ -155,6 +156,7 @@ func ToAccountDomain(a *BillingAccountModel) *pkgmodels.Account {
 		Balance:   a.Balance,
 		Currency:  a.Currency,
 		Status:    pkgmodels.AccountStatus(a.Status),
+		Quota:     a.Quota,
 		CreatedAt: a.CreatedAt,
 		UpdatedAt: a.UpdatedAt,
 	}
@@ -216,6 +218,7 @@ func FromAccountDomain(account *pkgmodels.Account) *BillingAccountModel {
 		Balance:   account.Balance,
 		Currency:  account.Currency,
 		Status:    string(account.Status),
+		Quota:     account.Quota,
 		CreatedAt: account.CreatedAt,
 		UpdatedAt: account.UpdatedAt,
 	}
@@ -0,0 +1,169 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	pkgmodels "github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// CanaryModel represents a canary (synthetic monitoring) definition in the database
+type CanaryModel struct {
+	bun.BaseModel `bun:"table:mbflow_canaries,alias:c"`
+
+	WorkflowID          uuid.UUID                   `bun:"workflow_id,pk,type:uuid" json:"workflow_id"`
+	Schedule            string                      `bun:"schedule,notnull" json:"schedule"`
+	Input               JSONBMap                    `bun:"input,type:jsonb,notnull,default:'{}'" json:"input"`
+	Assertions          []pkgmodels.CanaryAssertion `bun:"assertions,type:jsonb,notnull" json:"assertions"`
+	MaxDurationMs       int64                       `bun:"max_duration_ms,notnull" json:"max_duration_ms"`
+	RunbookURL          string                      `bun:"runbook_url,nullzero" json:"runbook_url,omitempty"`
+	Enabled             bool                        `bun:"enabled,notnull" json:"enabled"`
+	Status              string                      `bun:"status,notnull,default:'unknown'" json:"status"`
+	ConsecutiveFailures int                         `bun:"consecutive_failures,notnull" json:"consecutive_failures"`
+	LastRunAt           *time.Time                  `bun:"last_run_at" json:"last_run_at,omitempty"`
+	LastExecutionID     *uuid.UUID                  `bun:"last_execution_id,type:uuid" json:"last_execution_id,omitempty"`
+	LastError           string                      `bun:"last_error,nullzero" json:"last_error,omitempty"`
+	CreatedAt           time.Time                   `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt           time.Time                   `bun:"updated_at,notnull,default:current_timestamp" json:"updated_at"`
+
+	// Relationships
+	Workflow *WorkflowModel `bun:"rel:belongs-to,join:workflow_id=id" json:"workflow,omitempty"`
+}
+
+// TableName returns the table name for CanaryModel
+func (CanaryModel) TableName() string {
+	return "mbflow_canaries"
+}
+
+// BeforeInsert hook to set timestamps and defaults
+func (c *CanaryModel) BeforeInsert(ctx any) error {
+	now := time.Now()
+	c.CreatedAt = now
+	c.UpdatedAt = now
+	if c.Input == nil {
+		c.Input = make(JSONBMap)
+	}
+	if c.Assertions == nil {
+		c.Assertions = []pkgmodels.CanaryAssertion{}
+	}
+	if c.Status == "" {
+		c.Status = string(pkgmodels.CanaryStatusUnknown)
+	}
+	return nil
+}
+
+// ToDomain converts the DB model to the domain model
+func (c *CanaryModel) ToDomain() *pkgmodels.Canary {
+	if c == nil {
+		return nil
+	}
+
+	canary := &pkgmodels.Canary{
+		WorkflowID:          c.WorkflowID.String(),
+		Schedule:            c.Schedule,
+		Input:               map[string]any(c.Input),
+		Assertions:          c.Assertions,
+		MaxDurationMs:       c.MaxDurationMs,
+		RunbookURL:          c.RunbookURL,
+		Enabled:             c.Enabled,
+		Status:              pkgmodels.CanaryStatus(c.Status),
+		ConsecutiveFailures: c.ConsecutiveFailures,
+		LastRunAt:           c.LastRunAt,
+		LastError:           c.LastError,
+		CreatedAt:           c.CreatedAt,
+		UpdatedAt:           c.UpdatedAt,
+	}
+	if c.LastExecutionID != nil {
+		canary.LastExecutionID = c.LastExecutionID.String()
+	}
+	if c.Workflow != nil {
+		canary.WorkflowName = c.Workflow.Name
+	}
+	return canary
+}
+
+// FromCanaryDomain creates a DB model from the domain model
+func FromCanaryDomain(c *pkgmodels.Canary) (*CanaryModel, error) {
+	workflowID, err := uuid.Parse(c.WorkflowID)
+	if err != nil {
+		return nil, pkgmodels.ErrInvalidWorkflowID
+	}
+
+	model := &CanaryModel{
+		WorkflowID:          workflowID,
+		Schedule:            c.Schedule,
+		Input:               JSONBMap(c.Input),
+		Assertions:          c.Assertions,
+		MaxDurationMs:       c.MaxDurationMs,
+		RunbookURL:          c.RunbookURL,
+		Enabled:             c.Enabled,
+		Status:              string(c.Status),
+		ConsecutiveFailures: c.ConsecutiveFailures,
+		LastRunAt:           c.LastRunAt,
+		LastError:           c.LastError,
+		CreatedAt:           c.CreatedAt,
+		UpdatedAt:           c.UpdatedAt,
+	}
+	if c.LastExecutionID != "" {
+		if id, err := uuid.Parse(c.LastExecutionID); err == nil {
+			model.LastExecutionID = &id
+		}
+	}
+	return model, nil
+}
+
+// CanaryRunModel represents a single canary run in the database
+type CanaryRunModel struct {
+	bun.BaseModel `bun:"table:mbflow_canary_runs,alias:cr"`
+
+	ID          uuid.UUID                         `bun:"id,pk,type:uuid,default:uuid_generate_v4()" json:"id"`
+	WorkflowID  uuid.UUID                         `bun:"workflow_id,notnull,type:uuid" json:"workflow_id"`
+	ExecutionID *uuid.UUID                        `bun:"execution_id,type:uuid" json:"execution_id,omitempty"`
+	Passed      bool                              `bun:"passed,notnull" json:"passed"`
+	DurationMs  int64                             `bun:"duration_ms,notnull" json:"duration_ms"`
+	Error       string                            `bun:"error,nullzero" json:"error,omitempty"`
+	Results     []pkgmodels.CanaryAssertionResult `bun:"results,type:jsonb,notnull" json:"results"`
+	CreatedAt   time.Time                         `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// TableName returns the table name for CanaryRunModel
+func (CanaryRunModel) TableName() string {
+	return "mbflow_canary_runs"
+}
+
+// BeforeInsert hook to set timestamps and defaults
+func (r *CanaryRunModel) BeforeInsert(ctx any) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = time.Now()
+	}
+	if r.Results == nil {
+		r.Results = []pkgmodels.CanaryAssertionResult{}
+	}
+	return nil
+}
+
+// ToDomain converts the DB model to the domain model
+func (r *CanaryRunModel) ToDomain() *pkgmodels.CanaryRun {
+	if r == nil {
+		return nil
+	}
+
+	run := &pkgmodels.CanaryRun{
+		ID:         r.ID.String(),
+		WorkflowID: r.WorkflowID.String(),
+		Passed:     r.Passed,
+		DurationMs: r.DurationMs,
+		Error:      r.Error,
+		Results:    r.Results,
+		CreatedAt:  r.CreatedAt,
+	}
+	if r.ExecutionID != nil {
+		run.ExecutionID = r.ExecutionID.String()
+	}
+	return run
+}
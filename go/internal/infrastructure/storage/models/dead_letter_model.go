@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	pkgmodels "github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// DeadLetterModel represents a failed execution in the dead-letter queue in the database
+type DeadLetterModel struct {
+	bun.BaseModel `bun:"table:mbflow_dead_letters,alias:dl"`
+
+	ID          uuid.UUID `bun:"id,pk,type:uuid" json:"id"`
+	ExecutionID uuid.UUID `bun:"execution_id,notnull,type:uuid" json:"execution_id"`
+	WorkflowID  uuid.UUID `bun:"workflow_id,notnull,type:uuid" json:"workflow_id"`
+	Error       string    `bun:"error,notnull" json:"error"`
+	CreatedAt   time.Time `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+
+	// Relationships
+	Workflow *WorkflowModel `bun:"rel:belongs-to,join:workflow_id=id" json:"workflow,omitempty"`
+}
+
+// TableName returns the table name for DeadLetterModel
+func (DeadLetterModel) TableName() string {
+	return "mbflow_dead_letters"
+}
+
+// ToDomain converts the DB model to the domain model
+func (d *DeadLetterModel) ToDomain() *pkgmodels.DeadLetter {
+	if d == nil {
+		return nil
+	}
+
+	deadLetter := &pkgmodels.DeadLetter{
+		ID:          d.ID.String(),
+		ExecutionID: d.ExecutionID.String(),
+		WorkflowID:  d.WorkflowID.String(),
+		Error:       d.Error,
+		CreatedAt:   d.CreatedAt,
+	}
+	if d.Workflow != nil {
+		deadLetter.WorkflowName = d.Workflow.Name
+	}
+	return deadLetter
+}
@@ -19,6 +19,7 @@ type EdgeModel struct {
 	SourceHandle string    `bun:"source_handle" json:"source_handle,omitempty"`
 	Condition    JSONBMap  `bun:"condition,type:jsonb" json:"condition,omitempty"`
 	Loop         JSONBMap  `bun:"loop,type:jsonb" json:"loop,omitempty"`
+	Type         string    `bun:"type,nullzero" json:"type,omitempty" validate:"omitempty,oneof=compensation"`
 	CreatedAt  time.Time `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
 	UpdatedAt  time.Time `bun:"updated_at,notnull,default:current_timestamp" json:"updated_at"`
 
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	pkgmodels "github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// ExecutionArchiveModel represents an execution moved to object storage in the database
+type ExecutionArchiveModel struct {
+	bun.BaseModel `bun:"table:mbflow_execution_archives,alias:ea"`
+
+	ExecutionID        uuid.UUID  `bun:"execution_id,pk,type:uuid" json:"execution_id"`
+	WorkflowID         *uuid.UUID `bun:"workflow_id,type:uuid" json:"workflow_id,omitempty"`
+	Status             string     `bun:"status,notnull" json:"status"`
+	StartedAt          *time.Time `bun:"started_at" json:"started_at,omitempty"`
+	CompletedAt        *time.Time `bun:"completed_at" json:"completed_at,omitempty"`
+	ArchiveRef         string     `bun:"archive_ref,notnull" json:"archive_ref"`
+	SizeBytes          int64      `bun:"size_bytes,notnull" json:"size_bytes"`
+	ExecutionCount     int        `bun:"execution_count,notnull" json:"execution_count"`
+	NodeExecutionCount int        `bun:"node_execution_count,notnull" json:"node_execution_count"`
+	ArchivedAt         time.Time  `bun:"archived_at,notnull,default:current_timestamp" json:"archived_at"`
+
+	// Relationships
+	Workflow *WorkflowModel `bun:"rel:belongs-to,join:workflow_id=id" json:"workflow,omitempty"`
+}
+
+// TableName returns the table name for ExecutionArchiveModel
+func (ExecutionArchiveModel) TableName() string {
+	return "mbflow_execution_archives"
+}
+
+// ToDomain converts the DB model to the domain model
+func (a *ExecutionArchiveModel) ToDomain() *pkgmodels.ExecutionArchive {
+	if a == nil {
+		return nil
+	}
+
+	archive := &pkgmodels.ExecutionArchive{
+		ExecutionID:        a.ExecutionID.String(),
+		Status:             pkgmodels.ExecutionStatus(a.Status),
+		StartedAt:          a.StartedAt,
+		CompletedAt:        a.CompletedAt,
+		ArchiveRef:         a.ArchiveRef,
+		SizeBytes:          a.SizeBytes,
+		ExecutionCount:     a.ExecutionCount,
+		NodeExecutionCount: a.NodeExecutionCount,
+		ArchivedAt:         a.ArchivedAt,
+	}
+	if a.WorkflowID != nil {
+		archive.WorkflowID = a.WorkflowID.String()
+	}
+	if a.Workflow != nil {
+		archive.WorkflowName = a.Workflow.Name
+	}
+	return archive
+}
+
+// ExecutionRows holds the rows of an execution as JSON objects keyed by column, so that an
+// archive keeps every column whether or not the Go models map it. Executions are ordered
+// parents first: the archived execution, then its sub-workflow executions.
+type ExecutionRows struct {
+	Executions     []json.RawMessage `json:"executions"`
+	NodeExecutions []json.RawMessage `json:"node_executions,omitempty"`
+	Events         []json.RawMessage `json:"events,omitempty"`
+	Notes          []json.RawMessage `json:"notes,omitempty"`
+}
@@ -25,9 +25,16 @@ type ExecutionModel struct {
 	StrictMode  bool       `bun:"strict_mode,default:false" json:"strict_mode"`
 	Error       string     `bun:"error" json:"error,omitempty"`
 	Metadata    JSONBMap   `bun:"metadata,type:jsonb,default:'{}'" json:"metadata,omitempty"`
+	ResumeAt    *time.Time `bun:"resume_at" json:"resume_at,omitempty"`
+	ResumeState JSONBMap   `bun:"resume_state,type:jsonb" json:"-"`
+	// CheckpointedAt is when the instance running the execution last saved its ResumeState
+	CheckpointedAt *time.Time `bun:"checkpointed_at" json:"checkpointed_at,omitempty"`
 	CreatedAt   time.Time  `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
 	UpdatedAt   time.Time  `bun:"updated_at,notnull,default:current_timestamp" json:"updated_at"`
 
+	// EventKeys are the correlation keys the suspended nodes of a paused execution wait for
+	EventKeys StringArray `bun:"event_keys,type:text[],default:'{}'" json:"event_keys,omitempty"`
+
 	// Relationships
 	Workflow       *WorkflowModel        `bun:"rel:belongs-to,join:workflow_id=id" json:"workflow,omitempty"`
 	Trigger        *TriggerModel         `bun:"rel:belongs-to,join:trigger_id=id" json:"trigger,omitempty"`
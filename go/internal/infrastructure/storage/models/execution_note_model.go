@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	pkgmodels "github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// ExecutionNoteModel represents a note attached to an execution in the database
+type ExecutionNoteModel struct {
+	bun.BaseModel `bun:"table:mbflow_execution_notes,alias:en"`
+
+	ID          uuid.UUID  `bun:"id,pk,type:uuid" json:"id"`
+	ExecutionID uuid.UUID  `bun:"execution_id,notnull,type:uuid" json:"execution_id"`
+	NodeID      string     `bun:"node_id,nullzero" json:"node_id,omitempty"`
+	AuthorID    *uuid.UUID `bun:"author_id,type:uuid" json:"author_id,omitempty"`
+	AuthorName  string     `bun:"author_name,notnull" json:"author_name"`
+	Body        string     `bun:"body,notnull" json:"body"`
+	CreatedAt   time.Time  `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt   time.Time  `bun:"updated_at,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// TableName returns the table name for ExecutionNoteModel
+func (ExecutionNoteModel) TableName() string {
+	return "mbflow_execution_notes"
+}
+
+// ToDomain converts the DB model to the domain model
+func (n *ExecutionNoteModel) ToDomain() *pkgmodels.ExecutionNote {
+	if n == nil {
+		return nil
+	}
+
+	note := &pkgmodels.ExecutionNote{
+		ID:          n.ID.String(),
+		ExecutionID: n.ExecutionID.String(),
+		NodeID:      n.NodeID,
+		AuthorName:  n.AuthorName,
+		Body:        n.Body,
+		CreatedAt:   n.CreatedAt,
+		UpdatedAt:   n.UpdatedAt,
+	}
+	if n.AuthorID != nil {
+		note.AuthorID = n.AuthorID.String()
+	}
+	return note
+}
@@ -0,0 +1,149 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	pkgmodels "github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// IncidentPolicyModel represents the incident policy of a workflow in the database
+type IncidentPolicyModel struct {
+	bun.BaseModel `bun:"table:mbflow_incident_policies,alias:ip"`
+
+	WorkflowID       uuid.UUID  `bun:"workflow_id,pk,type:uuid" json:"workflow_id"`
+	RunbookURL       string     `bun:"runbook_url,nullzero" json:"runbook_url,omitempty"`
+	Tracker          string     `bun:"tracker,nullzero" json:"tracker,omitempty"`
+	Project          string     `bun:"project,nullzero" json:"project,omitempty"`
+	BaseURL          string     `bun:"base_url,nullzero" json:"base_url,omitempty"`
+	CredentialID     *uuid.UUID `bun:"credential_id,type:uuid" json:"credential_id,omitempty"`
+	Labels           []string   `bun:"labels,type:jsonb,notnull" json:"labels"`
+	IssueType        string     `bun:"issue_type,nullzero" json:"issue_type,omitempty"`
+	OnCanaryAlert    bool       `bun:"on_canary_alert,notnull" json:"on_canary_alert"`
+	FailureThreshold int        `bun:"failure_threshold,notnull" json:"failure_threshold"`
+	CreatedAt        time.Time  `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt        time.Time  `bun:"updated_at,notnull,default:current_timestamp" json:"updated_at"`
+
+	// Relationships
+	Workflow *WorkflowModel `bun:"rel:belongs-to,join:workflow_id=id" json:"workflow,omitempty"`
+}
+
+// TableName returns the table name for IncidentPolicyModel
+func (IncidentPolicyModel) TableName() string {
+	return "mbflow_incident_policies"
+}
+
+// ToDomain converts the DB model to the domain model
+func (p *IncidentPolicyModel) ToDomain() *pkgmodels.IncidentPolicy {
+	if p == nil {
+		return nil
+	}
+
+	policy := &pkgmodels.IncidentPolicy{
+		WorkflowID:       p.WorkflowID.String(),
+		RunbookURL:       p.RunbookURL,
+		Tracker:          p.Tracker,
+		Project:          p.Project,
+		BaseURL:          p.BaseURL,
+		Labels:           p.Labels,
+		IssueType:        p.IssueType,
+		OnCanaryAlert:    p.OnCanaryAlert,
+		FailureThreshold: p.FailureThreshold,
+		CreatedAt:        p.CreatedAt,
+		UpdatedAt:        p.UpdatedAt,
+	}
+	if p.CredentialID != nil {
+		policy.CredentialID = p.CredentialID.String()
+	}
+	if p.Workflow != nil {
+		policy.WorkflowName = p.Workflow.Name
+	}
+	return policy
+}
+
+// FromIncidentPolicyDomain creates a DB model from the domain model
+func FromIncidentPolicyDomain(p *pkgmodels.IncidentPolicy) (*IncidentPolicyModel, error) {
+	workflowID, err := uuid.Parse(p.WorkflowID)
+	if err != nil {
+		return nil, pkgmodels.ErrInvalidWorkflowID
+	}
+
+	model := &IncidentPolicyModel{
+		WorkflowID:       workflowID,
+		RunbookURL:       p.RunbookURL,
+		Tracker:          p.Tracker,
+		Project:          p.Project,
+		BaseURL:          p.BaseURL,
+		Labels:           p.Labels,
+		IssueType:        p.IssueType,
+		OnCanaryAlert:    p.OnCanaryAlert,
+		FailureThreshold: p.FailureThreshold,
+		CreatedAt:        p.CreatedAt,
+		UpdatedAt:        p.UpdatedAt,
+	}
+	if model.Labels == nil {
+		model.Labels = []string{}
+	}
+	if p.CredentialID != "" {
+		id, err := uuid.Parse(p.CredentialID)
+		if err != nil {
+			return nil, &pkgmodels.ValidationError{Field: "credential_id", Message: "credential ID must be a UUID"}
+		}
+		model.CredentialID = &id
+	}
+	return model, nil
+}
+
+// IncidentModel represents an incident linked to an execution in the database
+type IncidentModel struct {
+	bun.BaseModel `bun:"table:mbflow_incidents,alias:inc"`
+
+	ID          uuid.UUID  `bun:"id,pk,type:uuid" json:"id"`
+	WorkflowID  uuid.UUID  `bun:"workflow_id,notnull,type:uuid" json:"workflow_id"`
+	ExecutionID *uuid.UUID `bun:"execution_id,type:uuid" json:"execution_id,omitempty"`
+	Source      string     `bun:"source,notnull" json:"source"`
+	Title       string     `bun:"title,notnull" json:"title"`
+	Tracker     string     `bun:"tracker,notnull" json:"tracker"`
+	Project     string     `bun:"project,notnull" json:"project"`
+	ExternalID  string     `bun:"external_id,nullzero" json:"external_id,omitempty"`
+	Key         string     `bun:"key,nullzero" json:"key,omitempty"`
+	URL         string     `bun:"url,nullzero" json:"url,omitempty"`
+	RunbookURL  string     `bun:"runbook_url,nullzero" json:"runbook_url,omitempty"`
+	CreatedBy   *uuid.UUID `bun:"created_by,type:uuid" json:"created_by,omitempty"`
+	CreatedAt   time.Time  `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// TableName returns the table name for IncidentModel
+func (IncidentModel) TableName() string {
+	return "mbflow_incidents"
+}
+
+// ToDomain converts the DB model to the domain model
+func (i *IncidentModel) ToDomain() *pkgmodels.Incident {
+	if i == nil {
+		return nil
+	}
+
+	incident := &pkgmodels.Incident{
+		ID:         i.ID.String(),
+		WorkflowID: i.WorkflowID.String(),
+		Source:     pkgmodels.IncidentSource(i.Source),
+		Title:      i.Title,
+		Tracker:    i.Tracker,
+		Project:    i.Project,
+		ExternalID: i.ExternalID,
+		Key:        i.Key,
+		URL:        i.URL,
+		RunbookURL: i.RunbookURL,
+		CreatedAt:  i.CreatedAt,
+	}
+	if i.ExecutionID != nil {
+		incident.ExecutionID = i.ExecutionID.String()
+	}
+	if i.CreatedBy != nil {
+		incident.CreatedBy = i.CreatedBy.String()
+	}
+	return incident
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -273,16 +274,98 @@ func WorkflowToStorage(w *pkgmodels.Workflow, workflowID uuid.UUID) *WorkflowMod
 	}
 
 	return &WorkflowModel{
-		ID:          workflowID,
-		Name:        w.Name,
-		Description: w.Description,
-		Version:     w.Version,
-		Status:      string(w.Status),
-		Variables:   JSONBMap(w.Variables),
-		Metadata:    metadata,
-		Nodes:       storageNodes,
-		Edges:       storageEdges,
+		ID:             workflowID,
+		Name:           w.Name,
+		Description:    w.Description,
+		Version:        w.Version,
+		Status:         string(w.Status),
+		Variables:      JSONBMap(w.Variables),
+		Metadata:       metadata,
+		Nodes:          storageNodes,
+		Edges:          storageEdges,
+		LaunchProfiles: LaunchProfilesToStorage(w.LaunchProfiles),
+		Fixtures:       FixturesToStorage(w.Fixtures),
+	}
+}
+
+// LaunchProfilesToStorage converts domain launch profiles to a JSONB map keyed by profile name
+func LaunchProfilesToStorage(profiles map[string]*pkgmodels.LaunchProfile) JSONBMap {
+	if len(profiles) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(profiles)
+	if err != nil {
+		return nil
+	}
+
+	var result JSONBMap
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil
+	}
+	return result
+}
+
+// LaunchProfilesFromStorage converts a JSONB map to domain launch profiles.
+// Entries that don't decode as a profile are skipped.
+func LaunchProfilesFromStorage(data JSONBMap) map[string]*pkgmodels.LaunchProfile {
+	if len(data) == 0 {
+		return nil
+	}
+
+	profiles := make(map[string]*pkgmodels.LaunchProfile, len(data))
+	for name, raw := range data {
+		encoded, err := json.Marshal(raw)
+		if err != nil {
+			continue
+		}
+		var profile pkgmodels.LaunchProfile
+		if err := json.Unmarshal(encoded, &profile); err != nil {
+			continue
+		}
+		profiles[name] = &profile
 	}
+	return profiles
+}
+
+// FixturesToStorage converts domain workflow fixtures to a JSONB map keyed by fixture name
+func FixturesToStorage(fixtures map[string]*pkgmodels.WorkflowFixture) JSONBMap {
+	if len(fixtures) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(fixtures)
+	if err != nil {
+		return nil
+	}
+
+	var result JSONBMap
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil
+	}
+	return result
+}
+
+// FixturesFromStorage converts a JSONB map to domain workflow fixtures.
+// Entries that don't decode as a fixture are skipped.
+func FixturesFromStorage(data JSONBMap) map[string]*pkgmodels.WorkflowFixture {
+	if len(data) == 0 {
+		return nil
+	}
+
+	fixtures := make(map[string]*pkgmodels.WorkflowFixture, len(data))
+	for name, raw := range data {
+		encoded, err := json.Marshal(raw)
+		if err != nil {
+			continue
+		}
+		var fixture pkgmodels.WorkflowFixture
+		if err := json.Unmarshal(encoded, &fixture); err != nil {
+			continue
+		}
+		fixtures[name] = &fixture
+	}
+	return fixtures
 }
 
 // NodeToStorage converts a domain node to a storage node model
@@ -325,6 +408,7 @@ func EdgeToStorage(e *pkgmodels.Edge, workflowID uuid.UUID) *EdgeModel {
 		SourceHandle: e.SourceHandle,
 		Condition:    condition,
 		Loop:         loop,
+		Type:         string(e.Type),
 	}
 }
 
@@ -367,19 +451,21 @@ func WorkflowFromStorage(sw *WorkflowModel) *pkgmodels.Workflow {
 	}
 
 	return &pkgmodels.Workflow{
-		ID:          sw.ID.String(),
-		Name:        sw.Name,
-		Description: sw.Description,
-		Version:     sw.Version,
-		Status:      pkgmodels.WorkflowStatus(sw.Status),
-		Tags:        tags,
-		Nodes:       nodes,
-		Edges:       edges,
-		Resources:   WorkflowResourcesFromStorage(sw.Resources),
-		Variables:   variables,
-		Metadata:    metadata,
-		CreatedAt:   sw.CreatedAt,
-		UpdatedAt:   sw.UpdatedAt,
+		ID:             sw.ID.String(),
+		Name:           sw.Name,
+		Description:    sw.Description,
+		Version:        sw.Version,
+		Status:         pkgmodels.WorkflowStatus(sw.Status),
+		Tags:           tags,
+		Nodes:          nodes,
+		Edges:          edges,
+		Resources:      WorkflowResourcesFromStorage(sw.Resources),
+		Variables:      variables,
+		LaunchProfiles: LaunchProfilesFromStorage(sw.LaunchProfiles),
+		Fixtures:       FixturesFromStorage(sw.Fixtures),
+		Metadata:       metadata,
+		CreatedAt:      sw.CreatedAt,
+		UpdatedAt:      sw.UpdatedAt,
 	}
 }
 
@@ -434,6 +520,7 @@ func EdgeFromStorage(se *EdgeModel) *pkgmodels.Edge {
 		SourceHandle: se.SourceHandle,
 		Condition:    condition,
 		Loop:         loop,
+		Type:         pkgmodels.EdgeType(se.Type),
 	}
 }
 
@@ -505,6 +592,9 @@ func WorkflowModelToDomain(wm *WorkflowModel) *pkgmodels.Workflow {
 		workflow.Metadata = map[string]any(wm.Metadata)
 	}
 
+	workflow.LaunchProfiles = LaunchProfilesFromStorage(wm.LaunchProfiles)
+	workflow.Fixtures = FixturesFromStorage(wm.Fixtures)
+
 	workflow.Nodes = make([]*pkgmodels.Node, 0, len(wm.Nodes))
 	for _, nm := range wm.Nodes {
 		workflow.Nodes = append(workflow.Nodes, NodeModelToDomain(nm))
@@ -572,6 +662,7 @@ func EdgeModelToDomain(em *EdgeModel) *pkgmodels.Edge {
 		From:         em.FromNodeID,
 		To:           em.ToNodeID,
 		SourceHandle: em.SourceHandle,
+		Type:         pkgmodels.EdgeType(em.Type),
 	}
 
 	if em.Condition != nil {
@@ -632,6 +723,10 @@ func ExecutionModelToDomain(exm *ExecutionModel) *pkgmodels.Execution {
 		exec.Error = exm.Error
 	}
 
+	if exm.ResumeAt != nil {
+		exec.ResumeAt = exm.ResumeAt
+	}
+
 	if len(exm.NodeExecutions) > 0 {
 		exec.NodeExecutions = make([]*pkgmodels.NodeExecution, len(exm.NodeExecutions))
 		for i, ne := range exm.NodeExecutions {
@@ -674,6 +769,10 @@ func ExecutionDomainToModel(exec *pkgmodels.Execution) *ExecutionModel {
 		exm.CompletedAt = exec.CompletedAt
 	}
 
+	if exec.ResumeAt != nil {
+		exm.ResumeAt = exec.ResumeAt
+	}
+
 	if len(exec.NodeExecutions) > 0 {
 		exm.NodeExecutions = make([]*NodeExecutionModel, 0, len(exec.NodeExecutions))
 		for _, ne := range exec.NodeExecutions {
@@ -744,6 +843,13 @@ func NodeExecutionModelToDomain(nem *NodeExecutionModel) *pkgmodels.NodeExecutio
 		ne.Error = nem.Error
 	}
 
+	ne.PayloadArchived = nem.IsPayloadArchived()
+	ne.PayloadPurged = nem.IsPayloadPurged()
+	if len(nem.DataLabels) > 0 {
+		ne.DataLabels = []string(nem.DataLabels)
+	}
+	ne.PayloadExpiresAt = nem.PayloadExpiresAt
+
 	return ne
 }
 
@@ -754,13 +860,15 @@ func NodeExecutionDomainToModel(ne *pkgmodels.NodeExecution) *NodeExecutionModel
 	}
 
 	nem := &NodeExecutionModel{
-		Status:         string(ne.Status),
-		InputData:      JSONBMap(ne.Input),
-		OutputData:     JSONBMap(ne.Output),
-		Config:         JSONBMap(ne.Config),
-		ResolvedConfig: JSONBMap(ne.ResolvedConfig),
-		RetryCount:     ne.RetryCount,
-		Error:          ne.Error,
+		Status:           string(ne.Status),
+		InputData:        JSONBMap(ne.Input),
+		OutputData:       JSONBMap(ne.Output),
+		Config:           JSONBMap(ne.Config),
+		ResolvedConfig:   JSONBMap(ne.ResolvedConfig),
+		RetryCount:       ne.RetryCount,
+		Error:            ne.Error,
+		DataLabels:       StringArray(ne.DataLabels),
+		PayloadExpiresAt: ne.PayloadExpiresAt,
 	}
 
 	if ne.ID != "" {
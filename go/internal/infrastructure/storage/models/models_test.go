@@ -455,6 +455,56 @@ func TestWorkflowRoundTrip_PreservesData(t *testing.T) {
 	assert.Len(t, convertedWorkflow.Edges, 1)
 }
 
+func TestLaunchProfiles_RoundTrip(t *testing.T) {
+	original := &models.Workflow{
+		Name: "Profiles",
+		LaunchProfiles: map[string]*models.LaunchProfile{
+			"nightly": {
+				Description:    "Full nightly run",
+				Input:          map[string]any{"mode": "full"},
+				Environment:    map[string]any{"region": "eu-west-1"},
+				MaxParallelism: 4,
+				TimeoutSeconds: 3600,
+			},
+		},
+	}
+
+	storageWorkflow := WorkflowToStorage(original, uuid.New())
+	require.NotNil(t, storageWorkflow.LaunchProfiles)
+
+	converted := WorkflowModelToDomain(storageWorkflow)
+	require.Contains(t, converted.LaunchProfiles, "nightly")
+	assert.Equal(t, original.LaunchProfiles["nightly"], converted.LaunchProfiles["nightly"])
+
+	assert.Nil(t, LaunchProfilesToStorage(nil))
+	assert.Nil(t, LaunchProfilesFromStorage(JSONBMap{}))
+}
+
+func TestFixtures_RoundTrip(t *testing.T) {
+	original := &models.Workflow{
+		Name: "Fixtures",
+		Fixtures: map[string]*models.WorkflowFixture{
+			"missing_customer": {
+				Description:    "Unknown customer fails",
+				Input:          map[string]any{"customer_id": "c-0"},
+				ExpectedStatus: models.ExecutionStatusFailed,
+				ExpectedOutput: map[string]any{"found": false},
+				ExpectedError:  "not found",
+			},
+		},
+	}
+
+	storageWorkflow := WorkflowToStorage(original, uuid.New())
+	require.NotNil(t, storageWorkflow.Fixtures)
+
+	converted := WorkflowModelToDomain(storageWorkflow)
+	require.Contains(t, converted.Fixtures, "missing_customer")
+	assert.Equal(t, original.Fixtures["missing_customer"], converted.Fixtures["missing_customer"])
+
+	assert.Nil(t, FixturesToStorage(nil))
+	assert.Nil(t, FixturesFromStorage(JSONBMap{}))
+}
+
 // Test Node Mappers
 
 func TestNodeFromStorage_WithPosition(t *testing.T) {
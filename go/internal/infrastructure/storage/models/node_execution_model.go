@@ -17,7 +17,7 @@ type NodeExecutionModel struct {
 	NodeKey     *string    `bun:"node_key" json:"node_key,omitempty"`
 	NodeName    *string    `bun:"node_name" json:"node_name,omitempty"`
 	NodeType    *string    `bun:"node_type" json:"node_type,omitempty"`
-	Status      string     `bun:"status,notnull,default:'pending'" json:"status" validate:"required,oneof=pending running completed failed skipped retrying"`
+	Status      string     `bun:"status,notnull,default:'pending'" json:"status" validate:"required,oneof=pending running completed failed skipped retrying timed_out compensated cancelled"`
 	StartedAt      *time.Time `bun:"started_at" json:"started_at,omitempty"`
 	CompletedAt    *time.Time `bun:"completed_at" json:"completed_at,omitempty"`
 	InputData      JSONBMap   `bun:"input_data,type:jsonb,default:'{}'" json:"input_data,omitempty"`
@@ -30,6 +30,15 @@ type NodeExecutionModel struct {
 	CreatedAt      time.Time  `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
 	UpdatedAt      time.Time  `bun:"updated_at,notnull,default:current_timestamp" json:"updated_at"`
 
+	// Cold storage: once archived, input, output and configs live in file storage at PayloadRef
+	PayloadRef        *string    `bun:"payload_ref" json:"payload_ref,omitempty"`
+	PayloadArchivedAt *time.Time `bun:"payload_archived_at" json:"payload_archived_at,omitempty"`
+
+	// Data labels: a retention label sets PayloadExpiresAt, after which the archiver purges the payload
+	DataLabels       StringArray `bun:"data_labels,type:text[],default:'{}'" json:"data_labels,omitempty"`
+	PayloadExpiresAt *time.Time  `bun:"payload_expires_at" json:"payload_expires_at,omitempty"`
+	PayloadPurgedAt  *time.Time  `bun:"payload_purged_at" json:"payload_purged_at,omitempty"`
+
 	// Relationships
 	Execution *ExecutionModel `bun:"rel:belongs-to,join:execution_id=id" json:"execution,omitempty"`
 	Node      *NodeModel      `bun:"rel:belongs-to,join:node_id=id" json:"node,omitempty"`
@@ -85,6 +94,21 @@ func (ne *NodeExecutionModel) IsSkipped() bool {
 	return ne.Status == "skipped"
 }
 
+// IsTimedOut returns true if node execution ran out of its timeout
+func (ne *NodeExecutionModel) IsTimedOut() bool {
+	return ne.Status == "timed_out"
+}
+
+// IsCompensated returns true if node execution was rolled back by its compensation node
+func (ne *NodeExecutionModel) IsCompensated() bool {
+	return ne.Status == "compensated"
+}
+
+// IsCancelled returns true if node execution was aborted because its execution was cancelled
+func (ne *NodeExecutionModel) IsCancelled() bool {
+	return ne.Status == "cancelled"
+}
+
 // IsRetrying returns true if node execution is in retrying status
 func (ne *NodeExecutionModel) IsRetrying() bool {
 	return ne.Status == "retrying"
@@ -95,6 +119,16 @@ func (ne *NodeExecutionModel) IsTerminal() bool {
 	return ne.IsCompleted() || ne.IsFailed() || ne.IsSkipped()
 }
 
+// IsPayloadArchived returns true if the payload was moved to cold storage
+func (ne *NodeExecutionModel) IsPayloadArchived() bool {
+	return ne.PayloadRef != nil
+}
+
+// IsPayloadPurged returns true if the payload was purged by its retention label
+func (ne *NodeExecutionModel) IsPayloadPurged() bool {
+	return ne.PayloadPurgedAt != nil
+}
+
 // Duration returns the execution duration if completed
 func (ne *NodeExecutionModel) Duration() *time.Duration {
 	if ne.StartedAt == nil || ne.CompletedAt == nil {
@@ -13,7 +13,7 @@ type TriggerModel struct {
 
 	ID              uuid.UUID  `bun:"id,pk,type:uuid,default:uuid_generate_v4()" json:"id"`
 	WorkflowID      uuid.UUID  `bun:"workflow_id,notnull,type:uuid" json:"workflow_id" validate:"required"`
-	Type            string     `bun:"type,notnull" json:"type" validate:"required,oneof=manual cron webhook event interval"`
+	Type            string     `bun:"type,notnull" json:"type" validate:"required,oneof=manual cron webhook event interval email slack"`
 	Config          JSONBMap   `bun:"config,type:jsonb,notnull,default:'{}'" json:"config"`
 	Enabled         bool       `bun:"enabled,notnull,default:true" json:"enabled"`
 	LastTriggeredAt *time.Time `bun:"last_triggered_at" json:"last_triggered_at,omitempty"`
@@ -74,6 +74,16 @@ func (t *TriggerModel) IsInterval() bool {
 	return t.Type == "interval"
 }
 
+// IsEmail returns true if trigger is email (IMAP) type
+func (t *TriggerModel) IsEmail() bool {
+	return t.Type == "email"
+}
+
+// IsSlack returns true if trigger is Slack (Events API / slash command) type
+func (t *TriggerModel) IsSlack() bool {
+	return t.Type == "slack"
+}
+
 // MarkTriggered updates the last triggered timestamp
 func (t *TriggerModel) MarkTriggered() {
 	now := time.Now()
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	pkgmodels "github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// UsageDailyModel represents the usage of a user's executions on one UTC day in the database
+type UsageDailyModel struct {
+	bun.BaseModel `bun:"table:mbflow_usage_daily,alias:ud"`
+
+	UserID     uuid.UUID `bun:"user_id,pk,type:uuid" json:"user_id"`
+	Day        time.Time `bun:"day,pk,type:date" json:"day"`
+	Executions int64     `bun:"executions,notnull,default:0" json:"executions"`
+	NodeRuns   int64     `bun:"node_runs,notnull,default:0" json:"node_runs"`
+	LLMTokens  int64     `bun:"llm_tokens,notnull,default:0" json:"llm_tokens"`
+	UpdatedAt  time.Time `bun:"updated_at,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// TableName returns the table name for UsageDailyModel
+func (UsageDailyModel) TableName() string {
+	return "mbflow_usage_daily"
+}
+
+// ToDomain converts the DB model to the domain model
+func (u *UsageDailyModel) ToDomain() *pkgmodels.DailyUsage {
+	if u == nil {
+		return nil
+	}
+	return &pkgmodels.DailyUsage{
+		UserID:     u.UserID.String(),
+		Day:        pkgmodels.UsageDay(u.Day),
+		Executions: u.Executions,
+		NodeRuns:   u.NodeRuns,
+		LLMTokens:  u.LLMTokens,
+	}
+}
@@ -11,17 +11,19 @@ import (
 type WorkflowModel struct {
 	bun.BaseModel `bun:"table:mbflow_workflows,alias:w"`
 
-	ID          uuid.UUID  `bun:"id,pk,type:uuid,default:uuid_generate_v4()" json:"id"`
-	Name        string     `bun:"name,notnull" json:"name" validate:"required,max=255"`
-	Description string     `bun:"description" json:"description,omitempty"`
-	Status      string     `bun:"status,notnull,default:'draft'" json:"status" validate:"required,oneof=draft active archived"`
-	Version     int        `bun:"version,notnull,default:1" json:"version" validate:"gte=1"`
-	Variables   JSONBMap   `bun:"variables,type:jsonb,default:'{}'" json:"variables,omitempty"`
-	Metadata    JSONBMap   `bun:"metadata,type:jsonb,default:'{}'" json:"metadata,omitempty"`
-	CreatedBy   *uuid.UUID `bun:"created_by,type:uuid" json:"created_by,omitempty"`
-	CreatedAt   time.Time  `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
-	UpdatedAt   time.Time  `bun:"updated_at,notnull,default:current_timestamp" json:"updated_at"`
-	DeletedAt   *time.Time `bun:"deleted_at" json:"deleted_at,omitempty"`
+	ID             uuid.UUID  `bun:"id,pk,type:uuid,default:uuid_generate_v4()" json:"id"`
+	Name           string     `bun:"name,notnull" json:"name" validate:"required,max=255"`
+	Description    string     `bun:"description" json:"description,omitempty"`
+	Status         string     `bun:"status,notnull,default:'draft'" json:"status" validate:"required,oneof=draft active archived"`
+	Version        int        `bun:"version,notnull,default:1" json:"version" validate:"gte=1"`
+	Variables      JSONBMap   `bun:"variables,type:jsonb,default:'{}'" json:"variables,omitempty"`
+	Metadata       JSONBMap   `bun:"metadata,type:jsonb,default:'{}'" json:"metadata,omitempty"`
+	LaunchProfiles JSONBMap   `bun:"launch_profiles,type:jsonb,default:'{}'" json:"launch_profiles,omitempty"`
+	Fixtures       JSONBMap   `bun:"fixtures,type:jsonb,default:'{}'" json:"fixtures,omitempty"`
+	CreatedBy      *uuid.UUID `bun:"created_by,type:uuid" json:"created_by,omitempty"`
+	CreatedAt      time.Time  `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt      time.Time  `bun:"updated_at,notnull,default:current_timestamp" json:"updated_at"`
+	DeletedAt      *time.Time `bun:"deleted_at" json:"deleted_at,omitempty"`
 
 	// Relationships
 	Nodes     []*NodeModel             `bun:"rel:has-many,join:id=workflow_id" json:"nodes,omitempty"`
@@ -49,6 +51,12 @@ func (w *WorkflowModel) BeforeInsert(ctx any) error {
 	if w.Variables == nil {
 		w.Variables = make(JSONBMap)
 	}
+	if w.LaunchProfiles == nil {
+		w.LaunchProfiles = make(JSONBMap)
+	}
+	if w.Fixtures == nil {
+		w.Fixtures = make(JSONBMap)
+	}
 	return nil
 }
 
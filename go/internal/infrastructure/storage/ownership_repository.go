@@ -0,0 +1,246 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgmodels "github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+var _ repository.OwnershipRepository = (*OwnershipRepository)(nil)
+
+// OwnershipRepository implements ownership reports and transfers across workflows and resources
+type OwnershipRepository struct {
+	db bun.IDB
+}
+
+// NewOwnershipRepository creates a new OwnershipRepository
+func NewOwnershipRepository(db bun.IDB) *OwnershipRepository {
+	return &OwnershipRepository{db: db}
+}
+
+// GetOwnershipReport lists everything owned by a user
+func (r *OwnershipRepository) GetOwnershipReport(ctx context.Context, userID string) (*pkgmodels.OwnershipReport, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, pkgmodels.ErrInvalidID
+	}
+
+	report := &pkgmodels.OwnershipReport{
+		UserID:      userID,
+		Workflows:   []*pkgmodels.OwnedWorkflow{},
+		Triggers:    []*pkgmodels.OwnedTrigger{},
+		Credentials: []*pkgmodels.OwnedResource{},
+		Resources:   []*pkgmodels.OwnedResource{},
+		ServiceKeys: []*pkgmodels.OwnedServiceKey{},
+	}
+
+	var workflows []models.WorkflowModel
+	err = r.db.NewSelect().
+		Model(&workflows).
+		Column("id", "name", "status", "updated_at").
+		Where("created_by = ?", userUUID).
+		Where("deleted_at IS NULL").
+		Order("name ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list owned workflows: %w", err)
+	}
+	for _, wf := range workflows {
+		report.Workflows = append(report.Workflows, &pkgmodels.OwnedWorkflow{
+			ID:        wf.ID.String(),
+			Name:      wf.Name,
+			Status:    wf.Status,
+			UpdatedAt: wf.UpdatedAt,
+		})
+	}
+
+	var triggers []models.TriggerModel
+	err = r.db.NewSelect().
+		Model(&triggers).
+		Column("t.id", "t.workflow_id", "t.type", "t.enabled").
+		Join("JOIN mbflow_workflows AS w ON w.id = t.workflow_id").
+		Where("w.created_by = ?", userUUID).
+		Where("w.deleted_at IS NULL").
+		Order("t.created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list owned triggers: %w", err)
+	}
+	for _, t := range triggers {
+		report.Triggers = append(report.Triggers, &pkgmodels.OwnedTrigger{
+			ID:         t.ID.String(),
+			WorkflowID: t.WorkflowID.String(),
+			Type:       t.Type,
+			Enabled:    t.Enabled,
+		})
+	}
+
+	var resources []models.ResourceModel
+	err = r.db.NewSelect().
+		Model(&resources).
+		Column("id", "type", "name", "status").
+		Where("owner_id = ?", userUUID).
+		Where("deleted_at IS NULL").
+		Order("name ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list owned resources: %w", err)
+	}
+	for _, res := range resources {
+		owned := &pkgmodels.OwnedResource{
+			ID:     res.ID.String(),
+			Type:   pkgmodels.ResourceType(res.Type),
+			Name:   res.Name,
+			Status: res.Status,
+		}
+		if owned.Type == pkgmodels.ResourceTypeCredentials {
+			report.Credentials = append(report.Credentials, owned)
+		} else {
+			report.Resources = append(report.Resources, owned)
+		}
+	}
+
+	var keys []models.ServiceKeyModel
+	err = r.db.NewSelect().
+		Model(&keys).
+		Column("id", "name", "key_prefix", "last_used_at").
+		Where("user_id = ?", userUUID).
+		Where("status = ?", "active").
+		Order("created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list owned service keys: %w", err)
+	}
+	for _, key := range keys {
+		report.ServiceKeys = append(report.ServiceKeys, &pkgmodels.OwnedServiceKey{
+			ID:         key.ID.String(),
+			Name:       key.Name,
+			KeyPrefix:  key.KeyPrefix,
+			LastUsedAt: key.LastUsedAt,
+		})
+	}
+
+	return report, nil
+}
+
+// TransferOwnership reassigns workflows and resources in a single transaction.
+// Triggers have no owner of their own and follow their workflow.
+func (r *OwnershipRepository) TransferOwnership(ctx context.Context, transfer *pkgmodels.OwnershipTransfer) (*pkgmodels.OwnershipTransferResult, error) {
+	fromUUID, err := uuid.Parse(transfer.FromUserID)
+	if err != nil {
+		return nil, pkgmodels.ErrInvalidID
+	}
+	toUUID, err := uuid.Parse(transfer.ToUserID)
+	if err != nil {
+		return nil, pkgmodels.ErrInvalidID
+	}
+	workflowIDs, err := parseUUIDs(transfer.WorkflowIDs)
+	if err != nil {
+		return nil, err
+	}
+	resourceIDs, err := parseUUIDs(transfer.ResourceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &pkgmodels.OwnershipTransferResult{
+		FromUserID: transfer.FromUserID,
+		ToUserID:   transfer.ToUserID,
+	}
+	now := time.Now()
+
+	err = r.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		if transfer.Includes(pkgmodels.OwnershipKindWorkflows) {
+			var moved []uuid.UUID
+			query := tx.NewUpdate().
+				Model((*models.WorkflowModel)(nil)).
+				Set("created_by = ?", toUUID).
+				Set("updated_at = ?", now).
+				Where("created_by = ?", fromUUID).
+				Where("deleted_at IS NULL").
+				Returning("id")
+			if len(workflowIDs) > 0 {
+				query = query.Where("id IN (?)", bun.In(workflowIDs))
+			}
+			if _, err := query.Exec(ctx, &moved); err != nil {
+				return fmt.Errorf("failed to transfer workflows: %w", err)
+			}
+			result.Workflows = len(moved)
+
+			if len(moved) > 0 {
+				count, err := tx.NewSelect().
+					Model((*models.TriggerModel)(nil)).
+					Where("workflow_id IN (?)", bun.In(moved)).
+					Count(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to count transferred triggers: %w", err)
+				}
+				result.Triggers = count
+			}
+		}
+
+		if transfer.Includes(pkgmodels.OwnershipKindCredentials) {
+			n, err := transferResources(ctx, tx, fromUUID, toUUID, resourceIDs, now, "type = ?")
+			if err != nil {
+				return fmt.Errorf("failed to transfer credentials: %w", err)
+			}
+			result.Credentials = n
+		}
+
+		if transfer.Includes(pkgmodels.OwnershipKindResources) {
+			n, err := transferResources(ctx, tx, fromUUID, toUUID, resourceIDs, now, "type <> ?")
+			if err != nil {
+				return fmt.Errorf("failed to transfer resources: %w", err)
+			}
+			result.Resources = n
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// transferResources moves resources matching typeCondition (compared against the credentials type)
+func transferResources(ctx context.Context, tx bun.Tx, from, to uuid.UUID, ids []uuid.UUID, now time.Time, typeCondition string) (int, error) {
+	query := tx.NewUpdate().
+		Model((*models.ResourceModel)(nil)).
+		Set("owner_id = ?", to).
+		Set("updated_at = ?", now).
+		Where("owner_id = ?", from).
+		Where(typeCondition, string(pkgmodels.ResourceTypeCredentials)).
+		Where("deleted_at IS NULL")
+	if len(ids) > 0 {
+		query = query.Where("id IN (?)", bun.In(ids))
+	}
+
+	res, err := query.Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	n, _ := res.RowsAffected()
+	return int(n), nil
+}
+
+func parseUUIDs(ids []string) ([]uuid.UUID, error) {
+	parsed := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		u, err := uuid.Parse(id)
+		if err != nil {
+			return nil, pkgmodels.ErrInvalidID
+		}
+		parsed = append(parsed, u)
+	}
+	return parsed, nil
+}
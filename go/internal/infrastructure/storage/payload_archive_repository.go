@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+)
+
+var _ repository.PayloadArchiveRepository = (*PayloadArchiveRepository)(nil)
+
+// PayloadArchiveRepository implements repository.PayloadArchiveRepository using Bun ORM
+type PayloadArchiveRepository struct {
+	db bun.IDB
+}
+
+// NewPayloadArchiveRepository creates a new PayloadArchiveRepository
+func NewPayloadArchiveRepository(db bun.IDB) *PayloadArchiveRepository {
+	return &PayloadArchiveRepository{db: db}
+}
+
+// FindArchivable returns node executions of finished executions completed before the cutoff
+// that still hold their payload. Running executions are never archived.
+func (r *PayloadArchiveRepository) FindArchivable(ctx context.Context, cutoff time.Time, limit int) ([]*models.NodeExecutionModel, error) {
+	finished := r.db.NewSelect().
+		Table("mbflow_executions").
+		Column("id").
+		Where("status IN (?)", bun.In([]string{"completed", "failed", "cancelled"})).
+		Where("completed_at < ?", cutoff)
+
+	var nodeExecutions []*models.NodeExecutionModel
+	err := r.db.NewSelect().
+		Model(&nodeExecutions).
+		Where("ne.payload_ref IS NULL").
+		Where("ne.payload_purged_at IS NULL").
+		Where("ne.execution_id IN (?)", finished).
+		Order("ne.created_at ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find archivable node executions: %w", err)
+	}
+	return nodeExecutions, nil
+}
+
+// MarkArchived records where the payload was stored and clears the payload columns
+func (r *PayloadArchiveRepository) MarkArchived(ctx context.Context, id uuid.UUID, ref string, archivedAt time.Time) error {
+	_, err := r.db.NewUpdate().
+		Model((*models.NodeExecutionModel)(nil)).
+		Set("payload_ref = ?", ref).
+		Set("payload_archived_at = ?", archivedAt).
+		Set("input_data = '{}'::jsonb").
+		Set("output_data = NULL").
+		Set("config = '{}'::jsonb").
+		Set("resolved_config = '{}'::jsonb").
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to mark node execution payload archived: %w", err)
+	}
+	return nil
+}
+
+// FindExpired returns node executions whose retention label expired before now and
+// whose payloads are still stored, either in the row or in cold storage
+func (r *PayloadArchiveRepository) FindExpired(ctx context.Context, now time.Time, limit int) ([]*models.NodeExecutionModel, error) {
+	var nodeExecutions []*models.NodeExecutionModel
+	err := r.db.NewSelect().
+		Model(&nodeExecutions).
+		Where("ne.payload_expires_at IS NOT NULL").
+		Where("ne.payload_expires_at <= ?", now).
+		Where("ne.payload_purged_at IS NULL").
+		Order("ne.payload_expires_at ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expired node execution payloads: %w", err)
+	}
+	return nodeExecutions, nil
+}
+
+// MarkPurged clears the payload columns and the cold storage reference
+func (r *PayloadArchiveRepository) MarkPurged(ctx context.Context, id uuid.UUID, purgedAt time.Time) error {
+	_, err := r.db.NewUpdate().
+		Model((*models.NodeExecutionModel)(nil)).
+		Set("payload_purged_at = ?", purgedAt).
+		Set("payload_ref = NULL").
+		Set("input_data = '{}'::jsonb").
+		Set("output_data = NULL").
+		Set("config = '{}'::jsonb").
+		Set("resolved_config = '{}'::jsonb").
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to mark node execution payload purged: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/smilemakc/mbflow/go/internal/domain/repository"
+	"github.com/smilemakc/mbflow/go/internal/infrastructure/storage/models"
+	pkgmodels "github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+var _ repository.UsageRepository = (*UsageRepository)(nil)
+
+// UsageRepository implements repository.UsageRepository
+type UsageRepository struct {
+	db bun.IDB
+}
+
+// NewUsageRepository creates a new UsageRepository
+func NewUsageRepository(db bun.IDB) *UsageRepository {
+	return &UsageRepository{db: db}
+}
+
+// Get returns the usage of a user on a UTC day, all zero if nothing was recorded
+func (r *UsageRepository) Get(ctx context.Context, userID string, day time.Time) (*pkgmodels.DailyUsage, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, pkgmodels.ErrInvalidID
+	}
+	day = pkgmodels.UsageDay(day)
+
+	model := new(models.UsageDailyModel)
+	err = r.db.NewSelect().
+		Model(model).
+		Where("user_id = ? AND day = ?", userUUID, day).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &pkgmodels.DailyUsage{UserID: userID, Day: day}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage: %w", err)
+	}
+	return model.ToDomain(), nil
+}
+
+// Add atomically adds the counters of usage to the usage of its user on its day
+func (r *UsageRepository) Add(ctx context.Context, usage *pkgmodels.DailyUsage) error {
+	userUUID, err := uuid.Parse(usage.UserID)
+	if err != nil {
+		return pkgmodels.ErrInvalidID
+	}
+
+	model := &models.UsageDailyModel{
+		UserID:     userUUID,
+		Day:        pkgmodels.UsageDay(usage.Day),
+		Executions: usage.Executions,
+		NodeRuns:   usage.NodeRuns,
+		LLMTokens:  usage.LLMTokens,
+		UpdatedAt:  time.Now(),
+	}
+	_, err = r.db.NewInsert().
+		Model(model).
+		On("CONFLICT (user_id, day) DO UPDATE").
+		Set("executions = ud.executions + EXCLUDED.executions").
+		Set("node_runs = ud.node_runs + EXCLUDED.node_runs").
+		Set("llm_tokens = ud.llm_tokens + EXCLUDED.llm_tokens").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to add usage: %w", err)
+	}
+	return nil
+}
+
+// CountExecution atomically adds an execution to the usage of a user on a UTC day unless
+// that usage already reached a limit of the quota; it reports whether it was added. The
+// limits are checked in the conditional upsert, so concurrent starts cannot overshoot them.
+func (r *UsageRepository) CountExecution(ctx context.Context, userID string, day time.Time, quota *pkgmodels.UsageQuota) (bool, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return false, pkgmodels.ErrInvalidID
+	}
+
+	model := &models.UsageDailyModel{
+		UserID:     userUUID,
+		Day:        pkgmodels.UsageDay(day),
+		Executions: 1,
+		UpdatedAt:  time.Now(),
+	}
+	res, err := r.db.NewInsert().
+		Model(model).
+		On("CONFLICT (user_id, day) DO UPDATE").
+		Set("executions = ud.executions + 1").
+		Set("updated_at = EXCLUDED.updated_at").
+		Where("(?0 = 0 OR ud.executions < ?0)", quota.ExecutionsPerDay).
+		Where("(?0 = 0 OR ud.node_runs < ?0)", quota.NodeRunsPerDay).
+		Where("(?0 = 0 OR ud.llm_tokens < ?0)", quota.LLMTokensPerDay).
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to count execution: %w", err)
+	}
+	added, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to count execution: %w", err)
+	}
+	return added > 0, nil
+}
+
+// List returns the usage of a user on the days in [from, to), oldest first
+func (r *UsageRepository) List(ctx context.Context, userID string, from, to time.Time) ([]*pkgmodels.DailyUsage, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, pkgmodels.ErrInvalidID
+	}
+
+	var rows []*models.UsageDailyModel
+	err = r.db.NewSelect().
+		Model(&rows).
+		Where("user_id = ?", userUUID).
+		Where("day >= ? AND day < ?", pkgmodels.UsageDay(from), pkgmodels.UsageDay(to)).
+		Order("day ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list usage: %w", err)
+	}
+
+	usage := make([]*pkgmodels.DailyUsage, len(rows))
+	for i, row := range rows {
+		usage[i] = row.ToDomain()
+	}
+	return usage, nil
+}
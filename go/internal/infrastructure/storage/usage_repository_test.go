@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pkgmodels "github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/smilemakc/mbflow/go/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsageRepo_CountExecution(t *testing.T) {
+	t.Parallel()
+	db, cleanup := testutil.SetupTestTx(t)
+	defer cleanup()
+
+	repo := NewUsageRepository(db)
+	userID := createCredentialsTestUser(t, db)
+	ctx := context.Background()
+	day := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	quota := &pkgmodels.UsageQuota{ExecutionsPerDay: 2, LLMTokensPerDay: 1000}
+
+	for range 2 {
+		counted, err := repo.CountExecution(ctx, userID, day, quota)
+		require.NoError(t, err)
+		assert.True(t, counted)
+	}
+	counted, err := repo.CountExecution(ctx, userID, day, quota)
+	require.NoError(t, err)
+	assert.False(t, counted, "the executions limit is reached")
+
+	usage, err := repo.Get(ctx, userID, day)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), usage.Executions)
+
+	// Other limits are checked too
+	next := day.Add(24 * time.Hour)
+	require.NoError(t, repo.Add(ctx, &pkgmodels.DailyUsage{UserID: userID, Day: next, LLMTokens: 1200}))
+	counted, err = repo.CountExecution(ctx, userID, next, quota)
+	require.NoError(t, err)
+	assert.False(t, counted, "the LLM tokens limit is reached")
+
+	counted, err = repo.CountExecution(ctx, userID, next, &pkgmodels.UsageQuota{})
+	require.NoError(t, err)
+	assert.True(t, counted, "zero limits do not limit")
+}
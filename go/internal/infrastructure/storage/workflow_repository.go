@@ -88,7 +88,7 @@ func (r *WorkflowRepository) Update(ctx context.Context, workflow *models.Workfl
 		workflow.UpdatedAt = time.Now()
 		_, err := tx.NewUpdate().
 			Model(workflow).
-			Column("name", "description", "version", "status", "variables", "metadata", "updated_at").
+			Column("name", "description", "version", "status", "variables", "launch_profiles", "fixtures", "metadata", "updated_at").
 			Where("id = ?", workflow.ID).
 			Exec(ctx)
 		if err != nil {
@@ -228,7 +228,7 @@ func (r *WorkflowRepository) syncEdges(
 
 			_, err := tx.NewUpdate().
 				Model(incomingEdge).
-				Column("from_node_id", "to_node_id", "condition", "updated_at").
+				Column("from_node_id", "to_node_id", "condition", "type", "updated_at").
 				Where("id = ?", existing.ID).
 				Exec(ctx)
 			if err != nil {
@@ -573,7 +573,7 @@ func (r *WorkflowRepository) CreateEdge(ctx context.Context, edge *models.EdgeMo
 func (r *WorkflowRepository) UpdateEdge(ctx context.Context, edge *models.EdgeModel) error {
 	_, err := r.db.NewUpdate().
 		Model(edge).
-		Column("from_node_id", "to_node_id", "condition", "updated_at").
+		Column("from_node_id", "to_node_id", "condition", "type", "updated_at").
 		Where("workflow_id = ? AND edge_id = ?", edge.WorkflowID, edge.EdgeID).
 		Exec(ctx)
 	return err
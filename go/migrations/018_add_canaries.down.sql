@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS mbflow_canary_runs CASCADE;
+DROP TABLE IF EXISTS mbflow_canaries CASCADE;
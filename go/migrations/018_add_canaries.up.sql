@@ -0,0 +1,40 @@
+-- Migration: 018_add_canaries
+-- Description: Add canary (synthetic monitoring) definitions and run history
+-- Date: 2026-10-16
+
+CREATE TABLE mbflow_canaries (
+    workflow_id UUID PRIMARY KEY REFERENCES mbflow_workflows(id) ON DELETE CASCADE,
+    schedule VARCHAR(255) NOT NULL,
+    input JSONB NOT NULL DEFAULT '{}',
+    assertions JSONB NOT NULL DEFAULT '[]',
+    max_duration_ms BIGINT NOT NULL DEFAULT 0,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    status VARCHAR(20) NOT NULL DEFAULT 'unknown',
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    last_run_at TIMESTAMP WITH TIME ZONE,
+    last_execution_id UUID,
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT mbflow_canaries_status_check CHECK (status IN ('unknown', 'passing', 'failing'))
+);
+
+CREATE INDEX idx_mbflow_canaries_enabled ON mbflow_canaries(enabled) WHERE enabled = true;
+CREATE INDEX idx_mbflow_canaries_status ON mbflow_canaries(status);
+
+CREATE TABLE mbflow_canary_runs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workflow_id UUID NOT NULL REFERENCES mbflow_canaries(workflow_id) ON DELETE CASCADE,
+    execution_id UUID,
+    passed BOOLEAN NOT NULL,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    error TEXT,
+    results JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_mbflow_canary_runs_workflow_created ON mbflow_canary_runs(workflow_id, created_at DESC);
+
+COMMENT ON TABLE mbflow_canaries IS 'Workflows run on a schedule as synthetic monitors with output assertions';
+COMMENT ON TABLE mbflow_canary_runs IS 'History of canary runs and assertion results';
@@ -0,0 +1,7 @@
+DELETE FROM mbflow_triggers WHERE type = 'email';
+
+ALTER TABLE mbflow_triggers DROP CONSTRAINT IF EXISTS mbflow_triggers_type_check;
+ALTER TABLE mbflow_triggers
+    ADD CONSTRAINT mbflow_triggers_type_check CHECK (type IN ('manual', 'cron', 'webhook', 'event', 'interval'));
+
+COMMENT ON COLUMN mbflow_triggers.type IS 'Trigger type: manual, cron, webhook, event, interval';
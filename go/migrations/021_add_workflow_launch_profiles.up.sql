@@ -0,0 +1,6 @@
+-- Named launch profiles: predefined input sets and execution options per workflow
+
+ALTER TABLE mbflow_workflows
+    ADD COLUMN launch_profiles JSONB DEFAULT '{}';
+
+COMMENT ON COLUMN mbflow_workflows.launch_profiles IS 'Launch profiles keyed by name: {input, environment, max_parallelism, timeout_seconds}';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS mbflow_execution_stats_daily CASCADE;
+DROP TABLE IF EXISTS mbflow_execution_stats_hourly CASCADE;
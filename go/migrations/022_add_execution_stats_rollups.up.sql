@@ -0,0 +1,46 @@
+-- Migration: 022_add_execution_stats_rollups
+-- Description: Add hourly and daily execution statistics rollups
+-- Date: 2026-10-16
+
+CREATE TABLE mbflow_execution_stats_hourly (
+    workflow_id UUID NOT NULL,
+    bucket_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    executions INTEGER NOT NULL DEFAULT 0,
+    completed INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    cancelled INTEGER NOT NULL DEFAULT 0,
+    duration_ms_sum BIGINT NOT NULL DEFAULT 0,
+    duration_ms_max BIGINT NOT NULL DEFAULT 0,
+    node_executions INTEGER NOT NULL DEFAULT 0,
+    node_failures INTEGER NOT NULL DEFAULT 0,
+    assertion_failures INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (workflow_id, bucket_start)
+);
+
+CREATE INDEX idx_mbflow_execution_stats_hourly_bucket ON mbflow_execution_stats_hourly(bucket_start);
+
+CREATE TABLE mbflow_execution_stats_daily (
+    workflow_id UUID NOT NULL,
+    bucket_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    executions INTEGER NOT NULL DEFAULT 0,
+    completed INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    cancelled INTEGER NOT NULL DEFAULT 0,
+    duration_ms_sum BIGINT NOT NULL DEFAULT 0,
+    duration_ms_max BIGINT NOT NULL DEFAULT 0,
+    node_executions INTEGER NOT NULL DEFAULT 0,
+    node_failures INTEGER NOT NULL DEFAULT 0,
+    assertion_failures INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (workflow_id, bucket_start)
+);
+
+CREATE INDEX idx_mbflow_execution_stats_daily_bucket ON mbflow_execution_stats_daily(bucket_start);
+
+COMMENT ON TABLE mbflow_execution_stats_hourly IS 'Execution metrics of stored workflows aggregated per UTC hour of execution start';
+COMMENT ON TABLE mbflow_execution_stats_daily IS 'Execution metrics of stored workflows aggregated per UTC day, rolled up from the hourly table';
+COMMENT ON COLUMN mbflow_execution_stats_hourly.duration_ms_sum IS 'Summed duration of completed executions; divide by completed for the average';
+COMMENT ON COLUMN mbflow_execution_stats_daily.duration_ms_sum IS 'Summed duration of completed executions; divide by completed for the average';
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS mbflow_execution_notes CASCADE;
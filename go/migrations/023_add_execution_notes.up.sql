@@ -0,0 +1,19 @@
+-- Migration: 023_add_execution_notes
+-- Description: Add notes users attach to executions and node executions
+-- Date: 2026-10-16
+
+CREATE TABLE mbflow_execution_notes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    execution_id UUID NOT NULL REFERENCES mbflow_executions(id) ON DELETE CASCADE,
+    node_id VARCHAR(255),
+    author_id UUID REFERENCES mbflow_users(id) ON DELETE SET NULL,
+    author_name VARCHAR(255) NOT NULL DEFAULT '',
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_mbflow_execution_notes_execution ON mbflow_execution_notes(execution_id, created_at);
+
+COMMENT ON TABLE mbflow_execution_notes IS 'Comments users attach to executions, e.g. findings of an investigation';
+COMMENT ON COLUMN mbflow_execution_notes.node_id IS 'Logical node ID for notes on a node execution; NULL for notes on the whole execution';
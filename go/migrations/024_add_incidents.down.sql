@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS mbflow_incidents CASCADE;
+DROP TABLE IF EXISTS mbflow_incident_policies CASCADE;
+ALTER TABLE mbflow_canaries DROP COLUMN IF EXISTS runbook_url;
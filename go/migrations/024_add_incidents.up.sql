@@ -0,0 +1,48 @@
+-- Migration: 024_add_incidents
+-- Description: Add runbook references, workflow incident policies and incidents linked to executions
+-- Date: 2026-10-16
+
+ALTER TABLE mbflow_canaries ADD COLUMN runbook_url TEXT;
+
+CREATE TABLE mbflow_incident_policies (
+    workflow_id UUID PRIMARY KEY REFERENCES mbflow_workflows(id) ON DELETE CASCADE,
+    runbook_url TEXT,
+    tracker VARCHAR(20),
+    project VARCHAR(255),
+    base_url TEXT,
+    credential_id UUID,
+    labels JSONB NOT NULL DEFAULT '[]',
+    issue_type VARCHAR(100),
+    on_canary_alert BOOLEAN NOT NULL DEFAULT false,
+    failure_threshold INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT mbflow_incident_policies_tracker_check CHECK (tracker IN ('github', 'gitlab', 'jira')),
+    CONSTRAINT mbflow_incident_policies_threshold_check CHECK (failure_threshold >= 0)
+);
+
+CREATE TABLE mbflow_incidents (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workflow_id UUID NOT NULL REFERENCES mbflow_workflows(id) ON DELETE CASCADE,
+    execution_id UUID REFERENCES mbflow_executions(id) ON DELETE SET NULL,
+    source VARCHAR(30) NOT NULL,
+    title TEXT NOT NULL,
+    tracker VARCHAR(20) NOT NULL,
+    project VARCHAR(255) NOT NULL,
+    external_id VARCHAR(255),
+    key VARCHAR(255),
+    url TEXT,
+    runbook_url TEXT,
+    created_by UUID REFERENCES mbflow_users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT mbflow_incidents_source_check CHECK (source IN ('canary_alert', 'repeated_failures', 'manual'))
+);
+
+CREATE INDEX idx_mbflow_incidents_execution ON mbflow_incidents(execution_id);
+CREATE INDEX idx_mbflow_incidents_workflow_created ON mbflow_incidents(workflow_id, created_at DESC);
+
+COMMENT ON COLUMN mbflow_canaries.runbook_url IS 'Runbook linked from canary alerts';
+COMMENT ON TABLE mbflow_incident_policies IS 'Runbook and incident-tracker project of a workflow, and when incidents are opened automatically';
+COMMENT ON TABLE mbflow_incidents IS 'Issues opened in an incident tracker, linked to the execution that caused them';
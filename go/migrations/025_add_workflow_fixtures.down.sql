@@ -0,0 +1,2 @@
+ALTER TABLE mbflow_workflows
+    DROP COLUMN IF EXISTS fixtures;
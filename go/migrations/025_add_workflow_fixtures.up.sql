@@ -0,0 +1,6 @@
+-- Inline test fixtures: named sample inputs and expected results stored with the workflow
+
+ALTER TABLE mbflow_workflows
+    ADD COLUMN fixtures JSONB DEFAULT '{}';
+
+COMMENT ON COLUMN mbflow_workflows.fixtures IS 'Test fixtures keyed by name: {description, input, expected_status, expected_output, expected_error}';
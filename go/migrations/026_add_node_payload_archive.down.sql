@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_mbflow_node_executions_payload_unarchived;
+
+ALTER TABLE mbflow_node_executions
+    DROP COLUMN IF EXISTS payload_archived_at,
+    DROP COLUMN IF EXISTS payload_ref;
@@ -0,0 +1,15 @@
+-- Migration: 026_add_node_payload_archive
+-- Description: Track node execution payloads moved to cold storage
+-- Date: 2026-10-16
+
+ALTER TABLE mbflow_node_executions
+    ADD COLUMN payload_ref TEXT,
+    ADD COLUMN payload_archived_at TIMESTAMP WITH TIME ZONE;
+
+-- Archiver scans for finished node executions whose payloads are still in the database
+CREATE INDEX idx_mbflow_node_executions_payload_unarchived
+    ON mbflow_node_executions (execution_id)
+    WHERE payload_ref IS NULL;
+
+COMMENT ON COLUMN mbflow_node_executions.payload_ref IS 'Storage path of the archived input/output/config payload; NULL while the payload is kept in the row';
+COMMENT ON COLUMN mbflow_node_executions.payload_archived_at IS 'When the payload was moved to cold storage';
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_mbflow_node_executions_payload_expires_at;
+
+ALTER TABLE mbflow_node_executions
+    DROP COLUMN IF EXISTS payload_purged_at,
+    DROP COLUMN IF EXISTS payload_expires_at,
+    DROP COLUMN IF EXISTS data_labels;
@@ -0,0 +1,17 @@
+-- Migration: 027_add_node_data_labels
+-- Description: Data-classification and retention labels on node executions
+-- Date: 2026-10-16
+
+ALTER TABLE mbflow_node_executions
+    ADD COLUMN data_labels TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN payload_expires_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN payload_purged_at TIMESTAMP WITH TIME ZONE;
+
+-- Archiver scans for labeled payloads whose retention has run out
+CREATE INDEX idx_mbflow_node_executions_payload_expires_at
+    ON mbflow_node_executions (payload_expires_at)
+    WHERE payload_expires_at IS NOT NULL AND payload_purged_at IS NULL;
+
+COMMENT ON COLUMN mbflow_node_executions.data_labels IS 'Data-classification and retention labels of the node (e.g. pii, retain-7d)';
+COMMENT ON COLUMN mbflow_node_executions.payload_expires_at IS 'When a retention label purges the input/output/config payload; NULL keeps it';
+COMMENT ON COLUMN mbflow_node_executions.payload_purged_at IS 'When the payload was purged by its retention label';
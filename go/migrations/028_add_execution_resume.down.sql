@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_mbflow_executions_resume_at;
+
+ALTER TABLE mbflow_executions
+    DROP COLUMN IF EXISTS resume_state,
+    DROP COLUMN IF EXISTS resume_at;
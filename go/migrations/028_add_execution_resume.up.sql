@@ -0,0 +1,15 @@
+-- Migration: 028_add_execution_resume
+-- Description: Resume time and state of executions paused by a delay node
+-- Date: 2026-10-16
+
+ALTER TABLE mbflow_executions
+    ADD COLUMN resume_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN resume_state JSONB;
+
+-- Resumer scans for paused executions whose delay is over
+CREATE INDEX idx_mbflow_executions_resume_at
+    ON mbflow_executions (resume_at)
+    WHERE status = 'paused';
+
+COMMENT ON COLUMN mbflow_executions.resume_at IS 'When a paused execution resumes its earliest suspended node; NULL when not paused';
+COMMENT ON COLUMN mbflow_executions.resume_state IS 'Engine state of a paused execution (node outputs, suspensions, options) used to resume it';
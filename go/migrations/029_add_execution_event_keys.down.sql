@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_mbflow_executions_event_keys;
+
+ALTER TABLE mbflow_executions
+    DROP COLUMN IF EXISTS event_keys;
@@ -0,0 +1,13 @@
+-- Migration: 029_add_execution_event_keys
+-- Description: Event keys paused executions wait for (wait_for_event nodes)
+-- Date: 2026-10-16
+
+ALTER TABLE mbflow_executions
+    ADD COLUMN event_keys TEXT[] NOT NULL DEFAULT '{}';
+
+-- Event delivery looks up paused executions by the key they wait for
+CREATE INDEX idx_mbflow_executions_event_keys
+    ON mbflow_executions USING GIN (event_keys)
+    WHERE status = 'paused';
+
+COMMENT ON COLUMN mbflow_executions.event_keys IS 'Correlation keys of events the suspended nodes of a paused execution wait for; empty when not paused';
@@ -0,0 +1,10 @@
+-- Migration: 030_add_node_timed_out_status
+-- Description: Timed-out status of nodes that ran out of their timeout
+-- Date: 2026-10-17
+
+ALTER TABLE mbflow_node_executions
+    DROP CONSTRAINT mbflow_node_executions_status_check;
+
+ALTER TABLE mbflow_node_executions
+    ADD CONSTRAINT mbflow_node_executions_status_check
+    CHECK (status IN ('pending', 'running', 'completed', 'failed', 'skipped', 'retrying', 'timed_out'));
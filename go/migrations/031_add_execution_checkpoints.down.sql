@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_mbflow_executions_checkpointed_at;
+
+ALTER TABLE mbflow_executions
+    DROP COLUMN IF EXISTS checkpointed_at;
+
+COMMENT ON COLUMN mbflow_executions.resume_state IS 'Engine state of a paused execution (node outputs, suspensions, options) used to resume it';
@@ -0,0 +1,14 @@
+-- Migration: 031_add_execution_checkpoints
+-- Description: Checkpoint time of running executions, used to recover them after a crash
+-- Date: 2026-10-17
+
+ALTER TABLE mbflow_executions
+    ADD COLUMN checkpointed_at TIMESTAMP WITH TIME ZONE;
+
+-- Crash recovery scans for running executions whose checkpoint is stale
+CREATE INDEX idx_mbflow_executions_checkpointed_at
+    ON mbflow_executions ((COALESCE(checkpointed_at, started_at)))
+    WHERE status = 'running';
+
+COMMENT ON COLUMN mbflow_executions.resume_state IS 'Engine state (node outputs, suspensions, loop counters, options) used to resume a paused or failed execution, or to recover a running one after a crash';
+COMMENT ON COLUMN mbflow_executions.checkpointed_at IS 'When the instance running the execution last saved its state; a running execution not checkpointed for a while is recovered by another instance';
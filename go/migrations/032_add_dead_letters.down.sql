@@ -0,0 +1 @@
+DROP TABLE IF EXISTS mbflow_dead_letters CASCADE;
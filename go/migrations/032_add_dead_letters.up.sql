@@ -0,0 +1,17 @@
+-- Migration: 032_add_dead_letters
+-- Description: Add the dead-letter queue of failed executions awaiting a retry or a discard
+-- Date: 2026-10-17
+
+CREATE TABLE mbflow_dead_letters (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    execution_id UUID NOT NULL UNIQUE REFERENCES mbflow_executions(id) ON DELETE CASCADE,
+    workflow_id UUID NOT NULL REFERENCES mbflow_workflows(id) ON DELETE CASCADE,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_mbflow_dead_letters_created ON mbflow_dead_letters(created_at DESC);
+CREATE INDEX idx_mbflow_dead_letters_workflow_created ON mbflow_dead_letters(workflow_id, created_at DESC);
+
+COMMENT ON TABLE mbflow_dead_letters IS 'Executions that failed after exhausting their retries, kept until they are retried or discarded';
+COMMENT ON COLUMN mbflow_dead_letters.error IS 'Error the execution failed with';
@@ -0,0 +1,16 @@
+-- Migration: 033_add_compensation_edges
+-- Description: Edge type for compensation edges and compensated status of nodes rolled back by them
+-- Date: 2026-10-17
+
+ALTER TABLE mbflow_edges
+    ADD COLUMN type VARCHAR(20)
+    CONSTRAINT mbflow_edges_type_check CHECK (type IS NULL OR type = 'compensation');
+
+COMMENT ON COLUMN mbflow_edges.type IS 'Edge type: NULL for regular edges, compensation for edges to the node that undoes the source node';
+
+ALTER TABLE mbflow_node_executions
+    DROP CONSTRAINT mbflow_node_executions_status_check;
+
+ALTER TABLE mbflow_node_executions
+    ADD CONSTRAINT mbflow_node_executions_status_check
+    CHECK (status IN ('pending', 'running', 'completed', 'failed', 'skipped', 'retrying', 'timed_out', 'compensated'));
@@ -0,0 +1,24 @@
+-- Migration: 034_add_execution_archives
+-- Description: Index executions moved out of the database into compressed archives in object storage
+-- Date: 2026-10-17
+
+CREATE TABLE mbflow_execution_archives (
+    execution_id UUID PRIMARY KEY,
+    workflow_id UUID REFERENCES mbflow_workflows(id) ON DELETE SET NULL,
+    status VARCHAR(50) NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    archive_ref TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    execution_count INTEGER NOT NULL DEFAULT 1,
+    node_execution_count INTEGER NOT NULL DEFAULT 0,
+    archived_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_mbflow_execution_archives_archived ON mbflow_execution_archives(archived_at DESC);
+CREATE INDEX idx_mbflow_execution_archives_workflow ON mbflow_execution_archives(workflow_id, archived_at DESC) WHERE workflow_id IS NOT NULL;
+
+COMMENT ON TABLE mbflow_execution_archives IS 'Finished executions whose rows were compressed into object storage and deleted; restored ones are removed';
+COMMENT ON COLUMN mbflow_execution_archives.archive_ref IS 'Storage path of the gzipped JSON archive of the execution rows';
+COMMENT ON COLUMN mbflow_execution_archives.size_bytes IS 'Compressed size of the archive';
+COMMENT ON COLUMN mbflow_execution_archives.execution_count IS 'Number of executions in the archive: the execution and its sub-workflow executions';
@@ -0,0 +1,8 @@
+UPDATE mbflow_node_executions SET status = 'failed' WHERE status = 'cancelled';
+
+ALTER TABLE mbflow_node_executions
+    DROP CONSTRAINT mbflow_node_executions_status_check;
+
+ALTER TABLE mbflow_node_executions
+    ADD CONSTRAINT mbflow_node_executions_status_check
+    CHECK (status IN ('pending', 'running', 'completed', 'failed', 'skipped', 'retrying', 'timed_out', 'compensated'));
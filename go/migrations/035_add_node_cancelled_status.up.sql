@@ -0,0 +1,10 @@
+-- Migration: 035_add_node_cancelled_status
+-- Description: Cancelled status of nodes aborted while running because their execution was cancelled
+-- Date: 2026-10-17
+
+ALTER TABLE mbflow_node_executions
+    DROP CONSTRAINT mbflow_node_executions_status_check;
+
+ALTER TABLE mbflow_node_executions
+    ADD CONSTRAINT mbflow_node_executions_status_check
+    CHECK (status IN ('pending', 'running', 'completed', 'failed', 'skipped', 'retrying', 'timed_out', 'compensated', 'cancelled'));
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS mbflow_usage_daily CASCADE;
+
+ALTER TABLE mbflow_billing_accounts DROP COLUMN IF EXISTS quota;
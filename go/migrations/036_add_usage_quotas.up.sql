@@ -0,0 +1,23 @@
+-- Migration: 036_add_usage_quotas
+-- Description: Daily usage of each user's executions, and per-account usage quotas
+-- Date: 2026-10-17
+
+ALTER TABLE mbflow_billing_accounts ADD COLUMN quota JSONB;
+
+COMMENT ON COLUMN mbflow_billing_accounts.quota IS 'Daily usage quota of the user''s executions (executions, node runs, LLM tokens, overflow policy); NULL applies the server default';
+
+CREATE TABLE mbflow_usage_daily (
+    user_id UUID NOT NULL REFERENCES mbflow_users(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    executions BIGINT NOT NULL DEFAULT 0,
+    node_runs BIGINT NOT NULL DEFAULT 0,
+    llm_tokens BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, day)
+);
+
+COMMENT ON TABLE mbflow_usage_daily IS 'Usage of the executions of each user per UTC day, counted against their quota';
+COMMENT ON COLUMN mbflow_usage_daily.executions IS 'Executions started, or scheduled by the quota to start, on the day';
+COMMENT ON COLUMN mbflow_usage_daily.node_runs IS 'Nodes run by executions finishing on the day';
+COMMENT ON COLUMN mbflow_usage_daily.llm_tokens IS 'LLM tokens reported by nodes run on the day';
@@ -59,6 +59,23 @@
 //	workflow.Connect("check", "failure", builder.WhenFalse("output.success"))
 //	workflow.Connect("check", "error", builder.WhenEqual("output.status", "error"))
 //
+// Use Switch for multi-way routing, where only the first matching case is taken:
+//
+//	workflow.Switch("classify").
+//	    Case(`output.kind == "invoice"`, "book").
+//	    Case(`output.kind == "receipt"`, "archive").
+//	    Default("review")
+//
+// # Loops
+//
+// Iterate without hand-wiring loop edges:
+//
+//	workflow.ForEach("each_order", "input.orders", orderBuilder) // run orderBuilder's workflow per order
+//	workflow.Connect("improve", "review", builder.WithWhile("output.score < 80", 3))
+//
+// WithWhile goes back to review while improve's output matches, at most 3 times; the other
+// edges from improve are followed once it no longer does.
+//
 // # Positioning
 //
 // Position nodes using several strategies:
@@ -95,6 +112,18 @@
 //	    builder.WithStrictValidation(),
 //	).AddNode(...).MustBuild()
 //
+// # Triggers
+//
+// Add the triggers that start the workflow; Build() returns them in workflow.Triggers.
+// They are created along with the workflow when it is created through the API with
+// them, or imported from its definition:
+//
+//	workflow := builder.NewWorkflow("Daily Report",
+//	    builder.WithCronTrigger("0 9 * * 1-5", builder.TriggerConfigValue("timezone", "Europe/Berlin")),
+//	    builder.WithWebhookTrigger("/reports/:team", "signing-secret"),
+//	    builder.WithManualTrigger(map[string]any{"type": "object"}, builder.TriggerName("Run now")),
+//	).AddNode(...).MustBuild()
+//
 // # Node Options
 //
 // HTTP node options:
@@ -106,7 +135,7 @@
 //   - HTTPTimeout(duration) - Request timeout
 //
 // LLM node options:
-//   - LLMProvider(provider) - openai, anthropic
+//   - LLMProvider(provider) - openai, anthropic, gemini, azure_openai, bedrock, mistral, cohere
 //   - LLMModel(model) - Model name
 //   - LLMPrompt(prompt) - Prompt template
 //   - LLMAPIKey(key) - API key
@@ -115,6 +144,12 @@
 //   - LLMTopP(topP) - Top-p sampling (0-1, validated)
 //   - LLMSystemPrompt(prompt) - System prompt
 //   - LLMJSONMode() - Enable JSON response mode
+//   - LLMSafetySetting(category, threshold) - Gemini safety threshold per harm category
+//   - LLMBaseURL(url) - Provider API base URL (Azure OpenAI resource endpoint)
+//   - LLMAzureDeployment(name), LLMAPIVersion(version), LLMAzureADToken(token) - Azure OpenAI options
+//   - LLMCredentialID(id), LLMRegion(region), LLMAssumeRole(roleARN, externalID) - Bedrock options
+//   - LLMStream(bool) - Stream the response as node.output_delta events
+//   - LLMCache(ttlSeconds) - Reuse responses of identical requests from the response cache
 //
 // Transform node options:
 //   - TransformType(type) - passthrough, expression, jq, template
@@ -122,6 +157,59 @@
 //   - TransformJQ(filter) - JQ filter
 //   - TransformTemplate(tmpl) - Template string
 //
+// Delay node options:
+//   - DelayFor(duration) - Suspend the branch for a duration
+//   - DelayUntil(timestamp) - Suspend the branch until an RFC 3339 timestamp (or template)
+//
+// Wait-for-event node options:
+//   - WaitForEventKey(key) - Correlation key the event is delivered with (or template)
+//   - WaitForEventTimeout(duration) - Wait at most this long (default 24h)
+//   - Edge options FromEventBranch() / FromTimeoutBranch() route on the outcome
+//
+// Approval node options:
+//   - ApprovalMessage(message) - What the approvers are asked to decide (or template)
+//   - ApprovalApprovers(userIDs...) - Users allowed to decide (default: any user)
+//   - ApprovalTimeout(duration) - Wait at most this long for a decision (default 168h)
+//   - Edge options FromApprovedBranch() / FromRejectedBranch() / FromTimeoutBranch() route on the decision
+//
+// Batcher node options:
+//   - BatchMaxSize(n) - Release the batch once it holds n items
+//   - BatchWindow(duration) - Release the batch this long after its first item
+//   - BatchItem(item) - Value added to the batch (default: node input)
+//   - BatchGroup(group) - Batch items separately per group (or template)
+//   - Edge options FromReleasedBranch() / FromPendingBranch() route on whether the batch was released
+//
+// Validate node options:
+//   - ValidateSchema(schema) - JSON Schema the data must match
+//   - ValidateData(data) - Value to validate (default: node input)
+//   - ValidateOnInvalid(action) - fail (default), route (follow error edges) or annotate
+//
+// Rate limit node options:
+//   - RateLimit(limit, per) - Executions allowed per "second", "minute", "hour" or duration
+//   - RateLimitBurst(n) - Executions allowed at once after an idle period (default 1)
+//   - RateLimitKey(key) - Share the limit with every node using the key
+//   - RateLimitMaxWait(duration) - Fail instead of waiting longer for a slot
+//
+// Sub-workflow node:
+//   - NewSubWorkflowNode(id, name, workflowID, inputMapping) - Run a stored workflow once; its output is the node output
+//   - NewEmbeddedSubWorkflowNode(id, name, body, inputMapping) - Same, with the workflow built by another WorkflowBuilder
+//   - inputMapping - Child input keys to paths in the node input, e.g. {"email": "input.customer.email"}; nil passes the node input
+//   - WithForEach(path) - Fan out over an array instead, one child per item
+//   - WithItemVar(name), WithMaxParallelism(n), WithOnError(strategy), WithChunkSize(n), WithReducerWorkflow(id) - Fan-out options
+//
+// Foreach node:
+//   - NewForEachNode(id, name, forEach, body) - Run the body's nodes once per array item, results in order
+//   - WorkflowBuilder.ForEach(id, forEach, bodyBuilder) - Add a foreach node running another builder's workflow
+//   - WithItemVar(name), WithMaxParallelism(n), WithOnError(strategy) - As for sub-workflow nodes
+//
+// While node:
+//   - NewWhileNode(id, name, condition, body) - Rerun the body while the condition over its output holds
+//   - WhileMaxIterations(n) - Hard cap on iterations (default 10); {{input.iteration}} counts from 0
+//
+// Typed nodes:
+//   - NewNodeT(id, name, cfg) - Node from a typed config of package executor/config, e.g. config.HTTPConfig;
+//     the node type comes from the config, which is validated and marshalled into the config map
+//
 // Generic node options:
 //   - WithNodeDescription(desc) - Node description
 //   - WithPosition(x, y) - Absolute position
@@ -129,6 +217,8 @@
 //   - WithNodeMetadata(key, value) - Node metadata
 //   - WithConfig(config) - Raw config map (escape hatch)
 //   - WithConfigValue(key, value) - Single config value
+//   - WithDataLabels(labels...) - Data-classification and retention labels (pii, retain-7d)
+//   - WithTimeout(d) - Node timeout; a timed-out node follows its FromTimeoutBranch() edges
 //
 // # Error Handling
 //
@@ -147,6 +237,17 @@
 //	    AddNode(...).
 //	    MustBuild()
 //
+// # Workflow Definitions
+//
+// Export a workflow as a YAML or JSON definition to keep it under version control, and
+// load it again as a *models.Workflow:
+//
+//	data, err := builder.NewWorkflow("Test").AddNode(...).ToYAML() // or ToJSON()
+//	workflow, err := models.LoadWorkflowDefinition(data)
+//
+// Definitions use the layout of the workflow import format, so the API imports them too,
+// creating their triggers with the workflow.
+//
 // # Validation
 //
 // The builder validates at multiple levels:
@@ -14,8 +14,12 @@ type EdgeBuilder struct {
 	condition    string
 	sourceHandle string
 	loop         *models.LoopConfig
+	edgeType     models.EdgeType
 	metadata     map[string]any
 	err          error
+
+	// whileCondition is set by WithWhile until the workflow builder expands the edge.
+	whileCondition string
 }
 
 // EdgeOption is a function that configures an EdgeBuilder.
@@ -56,6 +60,7 @@ func (eb *EdgeBuilder) Build() (*models.Edge, error) {
 		SourceHandle: eb.sourceHandle,
 		Condition:    eb.condition,
 		Loop:         eb.loop,
+		Type:         eb.edgeType,
 		Metadata:     eb.metadata,
 	}
 
@@ -163,6 +168,55 @@ func FromFalseBranch() EdgeOption {
 	}
 }
 
+// FromEventBranch creates an edge from a wait_for_event node followed when the event arrives.
+func FromEventBranch() EdgeOption {
+	return func(eb *EdgeBuilder) error {
+		eb.sourceHandle = "event"
+		return nil
+	}
+}
+
+// FromTimeoutBranch creates an edge followed when its source node times out: a wait_for_event
+// or approval node reaching its timeout, or any node running out of its WithTimeout.
+func FromTimeoutBranch() EdgeOption {
+	return func(eb *EdgeBuilder) error {
+		eb.sourceHandle = "timeout"
+		return nil
+	}
+}
+
+// FromApprovedBranch creates an edge from an approval node followed when it is approved.
+func FromApprovedBranch() EdgeOption {
+	return func(eb *EdgeBuilder) error {
+		eb.sourceHandle = "approved"
+		return nil
+	}
+}
+
+// FromRejectedBranch creates an edge from an approval node followed when it is rejected.
+func FromRejectedBranch() EdgeOption {
+	return func(eb *EdgeBuilder) error {
+		eb.sourceHandle = "rejected"
+		return nil
+	}
+}
+
+// FromReleasedBranch creates an edge from a batcher node followed by the execution releasing the batch.
+func FromReleasedBranch() EdgeOption {
+	return func(eb *EdgeBuilder) error {
+		eb.sourceHandle = "released"
+		return nil
+	}
+}
+
+// FromPendingBranch creates an edge from a batcher node followed while the batch stays open.
+func FromPendingBranch() EdgeOption {
+	return func(eb *EdgeBuilder) error {
+		eb.sourceHandle = "pending"
+		return nil
+	}
+}
+
 // WithLoop marks this edge as a loop (back) edge with the specified max iterations.
 // Loop edges are excluded from topological sort and enable controlled re-execution of wave ranges.
 func WithLoop(maxIterations int) EdgeOption {
@@ -174,3 +228,12 @@ func WithLoop(maxIterations int) EdgeOption {
 		return nil
 	}
 }
+
+// AsCompensation marks this edge as a compensation edge: its target node undoes the source
+// node, e.g. a refund for a payment, and only runs when a later node fails the execution.
+func AsCompensation() EdgeOption {
+	return func(eb *EdgeBuilder) error {
+		eb.edgeType = models.EdgeTypeCompensation
+		return nil
+	}
+}
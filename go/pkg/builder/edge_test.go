@@ -288,6 +288,23 @@ func TestWithEdgeMetadata_VariousTypes(t *testing.T) {
 	assert.NotNil(t, edge.Metadata["slice"])
 }
 
+// ==================== AsCompensation Tests ====================
+
+func TestAsCompensation_Success(t *testing.T) {
+	edge, err := NewEdge("charge", "refund", AsCompensation()).Build()
+
+	require.NoError(t, err)
+	assert.Equal(t, models.EdgeTypeCompensation, edge.Type)
+	assert.True(t, edge.IsCompensation())
+}
+
+func TestAsCompensation_WithCondition(t *testing.T) {
+	edge, err := NewEdge("charge", "refund", AsCompensation(), WithCondition("output.charged")).Build()
+
+	assert.Error(t, err)
+	assert.Nil(t, edge)
+}
+
 // ==================== Complex Integration Tests ====================
 
 func TestEdgeBuilder_ConditionalEdgeWithMetadata(t *testing.T) {
@@ -0,0 +1,72 @@
+package builder
+
+import "fmt"
+
+// ForEach adds a foreach node that runs the workflow built by body once per item of the
+// for_each array, e.g. "input.items". Options apply as for NewForEachNode.
+func (wb *WorkflowBuilder) ForEach(id, forEach string, body *WorkflowBuilder, opts ...NodeOption) *WorkflowBuilder {
+	if wb.err != nil {
+		return wb
+	}
+	if body == nil {
+		wb.err = fmt.Errorf("foreach %s has no body", id)
+		return wb
+	}
+	wf, err := body.Build()
+	if err != nil {
+		wb.err = fmt.Errorf("foreach %s body: %w", id, err)
+		return wb
+	}
+	return wb.AddNode(NewForEachNode(id, id, forEach, wf, opts...))
+}
+
+// WithWhile makes the edge loop back to its target while the condition over the source's
+// output (as "output") holds, at most maxIterations times. Build expands it into a
+// passthrough gate node entered when the condition is true, the loop edge from the gate,
+// and the negated condition on the source's other outgoing edges, so the workflow only
+// continues once the loop is done.
+//
+//	Connect("improve", "review", WithWhile("output.score < 80", 3))
+func WithWhile(condition string, maxIterations int) EdgeOption {
+	return func(eb *EdgeBuilder) error {
+		if condition == "" {
+			return fmt.Errorf("while condition cannot be empty")
+		}
+		if err := WithLoop(maxIterations)(eb); err != nil {
+			return err
+		}
+		eb.whileCondition = condition
+		return nil
+	}
+}
+
+// expandWhileLoops replaces the edges built with WithWhile by their gate nodes and edges.
+func (wb *WorkflowBuilder) expandWhileLoops() {
+	for _, eb := range wb.edges {
+		if eb.err != nil || eb.whileCondition == "" {
+			continue
+		}
+		condition, from := eb.whileCondition, eb.from
+		gateID := fmt.Sprintf("while_%s_%s", from, eb.to)
+
+		for _, other := range wb.edges {
+			if other == eb || other.from != from || other.loop != nil || other.sourceHandle != "" ||
+				other.edgeType != "" || other.whileCondition != "" {
+				continue
+			}
+			if other.condition == "" {
+				other.condition = fmt.Sprintf("!(%s)", condition)
+			} else {
+				other.condition = fmt.Sprintf("(%s) && !(%s)", other.condition, condition)
+			}
+		}
+
+		wb.AddNode(NewPassthroughNode(gateID, fmt.Sprintf("While %s", condition)))
+		if eb.id == fmt.Sprintf("edge_%s_%s", from, eb.to) {
+			eb.id = fmt.Sprintf("edge_%s_%s", gateID, eb.to)
+		}
+		eb.from = gateID
+		eb.whileCondition = ""
+		wb.edges = append(wb.edges, NewEdge(from, gateID, WithCondition(condition)))
+	}
+}
@@ -0,0 +1,117 @@
+package builder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/engine"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+func TestWorkflowBuilder_ForEach(t *testing.T) {
+	t.Parallel()
+
+	body := NewWorkflow("Body").AddNode(NewNode("charge", "http", "Charge"))
+	wf, err := NewWorkflow("Orders").
+		AddNode(NewNode("load", "transform", "Load")).
+		ForEach("each_order", "input.orders", body, WithItemVar("order")).
+		Connect("load", "each_order").
+		Build()
+	if err != nil {
+		t.Fatalf("build failed: %v", err)
+	}
+
+	node := wf.Nodes[1]
+	if node.ID != "each_order" || node.Type != "foreach" {
+		t.Fatalf("expected foreach node each_order, got: %s %s", node.ID, node.Type)
+	}
+	if node.Config["for_each"] != "input.orders" || node.Config["item_var"] != "order" {
+		t.Fatalf("unexpected config: %v", node.Config)
+	}
+
+	_, err = NewWorkflow("Orders").ForEach("each_order", "input.orders", NewWorkflow("Empty")).Build()
+	if err == nil {
+		t.Fatal("expected error for an empty body")
+	}
+}
+
+func TestWithWhile_ExpandsIntoGateAndLoopEdge(t *testing.T) {
+	t.Parallel()
+
+	wf, err := NewWorkflow("Review").
+		AddNode(NewNode("review", "transform", "Review")).
+		AddNode(NewNode("improve", "transform", "Improve")).
+		AddNode(NewNode("publish", "transform", "Publish")).
+		AddNode(NewNode("notify", "transform", "Notify")).
+		Connect("review", "improve").
+		Connect("improve", "publish").
+		Connect("improve", "notify", WithCondition("output.notify")).
+		Connect("improve", "review", WithWhile("output.score < 80", 3)).
+		Build()
+	if err != nil {
+		t.Fatalf("build failed: %v", err)
+	}
+
+	edges := make(map[string]*models.Edge)
+	for _, edge := range wf.Edges {
+		edges[edge.From+">"+edge.To] = edge
+	}
+	gate := "while_improve_review"
+	if edge := edges["improve>"+gate]; edge == nil || edge.Condition != "output.score < 80" {
+		t.Fatalf("expected conditional edge into the gate, got: %v", edge)
+	}
+	if edge := edges[gate+">review"]; edge == nil || edge.Loop == nil || edge.Loop.MaxIterations != 3 {
+		t.Fatalf("expected loop edge from the gate, got: %v", edge)
+	}
+	if edge := edges["improve>publish"]; edge.Condition != "!(output.score < 80)" {
+		t.Errorf("expected the exit edge to wait for the loop, got: %q", edge.Condition)
+	}
+	if edge := edges["improve>notify"]; edge.Condition != "(output.notify) && !(output.score < 80)" {
+		t.Errorf("expected the conditions to be combined, got: %q", edge.Condition)
+	}
+	if edges["improve>review"] != nil {
+		t.Error("expected no direct edge back to review")
+	}
+
+	if _, err := NewWorkflow("Bad").
+		AddNode(NewNode("a", "transform", "A")).
+		AddNode(NewNode("b", "transform", "B")).
+		Connect("b", "a", WithWhile("", 3)).
+		Build(); err == nil {
+		t.Fatal("expected error for an empty condition")
+	}
+}
+
+func TestWithWhile_LoopsUntilConditionFails(t *testing.T) {
+	t.Parallel()
+
+	wf := NewWorkflow("Count").
+		AddNode(NewNode("count", "transform", "Count")).
+		AddNode(NewPassthroughNode("done", "Done")).
+		Connect("count", "done").
+		Connect("count", "count", WithWhile("output.n < 3", 10)).
+		MustBuild()
+
+	registry := executor.NewManager()
+	registry.Register("transform", &executor.ExecutorFunc{
+		ExecuteFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			in, _ := input.(map[string]any)
+			if config["type"] == "passthrough" {
+				return in, nil
+			}
+			n, _ := in["n"].(int)
+			return map[string]any{"n": n + 1}, nil
+		},
+	})
+	dagExec := engine.NewDAGExecutor(engine.NewNodeExecutor(registry), engine.NewExprConditionEvaluator(), engine.NewNoOpNotifier(), nil)
+	execState := engine.NewExecutionState("exec-1", wf.ID, wf, map[string]any{"n": 0}, nil)
+
+	if err := dagExec.Execute(context.Background(), execState, engine.DefaultExecutionOptions()); err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+	output, ok := execState.GetNodeOutput("done")
+	if !ok || output.(map[string]any)["n"] != 3 {
+		t.Fatalf("expected done to run once n reached 3, got: %v", output)
+	}
+}
@@ -2,6 +2,7 @@ package builder
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/smilemakc/mbflow/go/pkg/models"
 )
@@ -126,10 +127,43 @@ func WithConfigValue(key string, value any) NodeOption {
 	}
 }
 
-// NewSubWorkflowNode creates a sub_workflow node for fan-out execution.
-func NewSubWorkflowNode(id, name, workflowID string, opts ...NodeOption) *NodeBuilder {
+// WithDataLabels sets data-classification and retention labels, e.g. "pii" or "retain-7d".
+// Labels from repeated calls are combined.
+func WithDataLabels(labels ...string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		existing, _ := nb.config[models.NodeDataLabelsConfigKey].([]string)
+		combined := append(append([]string{}, existing...), labels...)
+		if _, err := models.NewDataPolicy(combined); err != nil {
+			return err
+		}
+		nb.config[models.NodeDataLabelsConfigKey] = combined
+		return nil
+	}
+}
+
+// WithTimeout sets how long the node may run. A node running out of time is marked timed
+// out and continues down its FromTimeoutBranch() edges, or fails the execution without any.
+// It is separate from the "timeout" setting of executors such as http or redis.
+func WithTimeout(d time.Duration) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if d < time.Millisecond {
+			return fmt.Errorf("timeout must be at least 1ms")
+		}
+		nb.config[models.NodeTimeoutConfigKey] = d.Milliseconds()
+		return nil
+	}
+}
+
+// NewSubWorkflowNode creates a sub_workflow node running the workflow with the given ID.
+// inputMapping sets child input keys to paths in the node input, e.g. "input.customer.id";
+// with a nil mapping the child receives the node input. The child runs once and its output
+// becomes the node output, unless WithForEach fans it out over an array.
+func NewSubWorkflowNode(id, name, workflowRef string, inputMapping map[string]string, opts ...NodeOption) *NodeBuilder {
 	nb := NewNode(id, "sub_workflow", name)
-	nb.config["workflow_id"] = workflowID
+	nb.config["workflow_id"] = workflowRef
+	if inputMapping != nil {
+		nb.config["input_mapping"] = inputMapping
+	}
 	for _, opt := range opts {
 		if err := opt(nb); err != nil {
 			nb.err = err
@@ -163,6 +197,27 @@ func WithMaxParallelism(n int) NodeOption {
 	}
 }
 
+// WithChunkSize splits the for_each array into chunks of n items, one child execution per chunk.
+// The child receives the chunk as "chunk" (unless WithItemVar is set), plus offset and total_items.
+func WithChunkSize(n int) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if n <= 0 {
+			return fmt.Errorf("chunk size must be positive, got %d", n)
+		}
+		nb.config["chunk_size"] = n
+		return nil
+	}
+}
+
+// WithReducerWorkflow sets a workflow run once after the children, with their outputs as
+// input.results; its output becomes the node's "reduced" field.
+func WithReducerWorkflow(workflowID string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		nb.config["reducer_workflow_id"] = workflowID
+		return nil
+	}
+}
+
 // WithOnError sets the error handling strategy: "fail_fast" or "collect_partial".
 func WithOnError(strategy string) NodeOption {
 	return func(nb *NodeBuilder) error {
@@ -0,0 +1,36 @@
+package builder
+
+import (
+	"fmt"
+	"time"
+)
+
+// ApprovalMessage sets what the approvers are asked to decide, e.g. "Refund {{input.amount}} EUR?".
+func ApprovalMessage(message string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		nb.config["message"] = message
+		return nil
+	}
+}
+
+// ApprovalApprovers restricts who may decide the approval to the user IDs.
+func ApprovalApprovers(userIDs ...string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if len(userIDs) == 0 {
+			return fmt.Errorf("approvers cannot be empty")
+		}
+		nb.config["approvers"] = userIDs
+		return nil
+	}
+}
+
+// ApprovalTimeout sets how long the node waits for a decision before taking its timeout branch.
+func ApprovalTimeout(d time.Duration) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if d <= 0 {
+			return fmt.Errorf("approval timeout must be positive")
+		}
+		nb.config["timeout"] = d.String()
+		return nil
+	}
+}
@@ -0,0 +1,44 @@
+package builder
+
+import (
+	"fmt"
+	"time"
+)
+
+// BatchMaxSize releases the batch once it holds n items.
+func BatchMaxSize(n int) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if n <= 0 {
+			return fmt.Errorf("batch max size must be positive, got %d", n)
+		}
+		nb.config["max_size"] = n
+		return nil
+	}
+}
+
+// BatchWindow releases the batch this long after its first item.
+func BatchWindow(d time.Duration) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if d <= 0 {
+			return fmt.Errorf("batch window must be positive")
+		}
+		nb.config["window"] = d.String()
+		return nil
+	}
+}
+
+// BatchItem sets the value added to the batch (or template); the node input by default.
+func BatchItem(item any) NodeOption {
+	return func(nb *NodeBuilder) error {
+		nb.config["item"] = item
+		return nil
+	}
+}
+
+// BatchGroup batches items separately per group, e.g. "{{input.customer_id}}".
+func BatchGroup(group string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		nb.config["group"] = group
+		return nil
+	}
+}
@@ -0,0 +1,29 @@
+package builder
+
+import (
+	"fmt"
+	"time"
+)
+
+// DelayFor suspends the branch for a fixed duration.
+func DelayFor(d time.Duration) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if d < 0 {
+			return fmt.Errorf("delay duration must not be negative")
+		}
+		nb.config["duration"] = d.String()
+		return nil
+	}
+}
+
+// DelayUntil suspends the branch until a timestamp: an RFC 3339 string or a template
+// resolving to one, e.g. "{{input.send_at}}".
+func DelayUntil(timestamp string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if timestamp == "" {
+			return fmt.Errorf("delay timestamp cannot be empty")
+		}
+		nb.config["until"] = timestamp
+		return nil
+	}
+}
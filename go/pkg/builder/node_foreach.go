@@ -0,0 +1,30 @@
+package builder
+
+import (
+	"fmt"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// NewForEachNode creates a foreach node that runs the body workflow's nodes and edges once
+// per item of the for_each array, e.g. one built with NewWorkflow(...).Build().
+// WithItemVar, WithMaxParallelism, WithOnError and WithChunkSize apply as for sub-workflows.
+func NewForEachNode(id, name, forEach string, body *models.Workflow, opts ...NodeOption) *NodeBuilder {
+	nb := NewNode(id, "foreach", name)
+	if body == nil || len(body.Nodes) == 0 {
+		nb.err = fmt.Errorf("foreach body must have at least one node")
+		return nb
+	}
+	nb.config["for_each"] = forEach
+	nb.config["body"] = map[string]any{
+		"nodes": body.Nodes,
+		"edges": body.Edges,
+	}
+	for _, opt := range opts {
+		if err := opt(nb); err != nil {
+			nb.err = err
+			return nb
+		}
+	}
+	return nb
+}
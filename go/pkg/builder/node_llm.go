@@ -10,9 +10,14 @@ import (
 func LLMProvider(provider models.LLMProvider) NodeOption {
 	return func(nb *NodeBuilder) error {
 		validProviders := map[models.LLMProvider]bool{
-			models.LLMProviderOpenAI:    true,
-			models.LLMProviderAnthropic: true,
-			models.LLMProviderGemini:    true,
+			models.LLMProviderOpenAI:      true,
+			models.LLMProviderAnthropic:   true,
+			models.LLMProviderGemini:      true,
+			models.LLMProviderAzureOpenAI: true,
+			models.LLMProviderBedrock:     true,
+			models.LLMProviderMistral:     true,
+			models.LLMProviderCohere:      true,
+			models.LLMProviderMock:        true,
 		}
 		if !validProviders[provider] {
 			return fmt.Errorf("unsupported LLM provider: %s", provider)
@@ -132,6 +137,26 @@ func LLMStop(stop []string) NodeOption {
 	}
 }
 
+// LLMSafetySetting sets the Gemini block threshold for a harm category,
+// e.g. LLMSafetySetting("HARM_CATEGORY_HARASSMENT", "BLOCK_ONLY_HIGH").
+func LLMSafetySetting(category, threshold string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if category == "" {
+			return fmt.Errorf("safety setting category cannot be empty")
+		}
+		if threshold == "" {
+			return fmt.Errorf("safety setting threshold cannot be empty")
+		}
+		settings, _ := nb.config["safety_settings"].(map[string]any)
+		if settings == nil {
+			settings = map[string]any{}
+		}
+		settings[category] = threshold
+		nb.config["safety_settings"] = settings
+		return nil
+	}
+}
+
 // NewOpenAINode creates a new OpenAI LLM node builder.
 func NewOpenAINode(id, name, model, prompt string, opts ...NodeOption) *NodeBuilder {
 	allOpts := []NodeOption{
@@ -165,6 +190,260 @@ func NewGeminiNode(id, name, model, prompt string, opts ...NodeOption) *NodeBuil
 	return NewNode(id, "llm", name, allOpts...)
 }
 
+// NewMistralNode creates a new Mistral AI LLM node builder.
+func NewMistralNode(id, name, model, prompt string, opts ...NodeOption) *NodeBuilder {
+	allOpts := []NodeOption{
+		LLMProvider(models.LLMProviderMistral),
+		LLMModel(model),
+		LLMPrompt(prompt),
+	}
+	allOpts = append(allOpts, opts...)
+	return NewNode(id, "llm", name, allOpts...)
+}
+
+// NewCohereNode creates a new Cohere LLM node builder.
+func NewCohereNode(id, name, model, prompt string, opts ...NodeOption) *NodeBuilder {
+	allOpts := []NodeOption{
+		LLMProvider(models.LLMProviderCohere),
+		LLMModel(model),
+		LLMPrompt(prompt),
+	}
+	allOpts = append(allOpts, opts...)
+	return NewNode(id, "llm", name, allOpts...)
+}
+
+// NewAzureOpenAINode creates a new Azure OpenAI LLM node builder for a deployment of the
+// resource at endpoint (e.g. https://my-resource.openai.azure.com). Authenticate with
+// LLMAPIKey or LLMAzureADToken.
+func NewAzureOpenAINode(id, name, endpoint, deployment, prompt string, opts ...NodeOption) *NodeBuilder {
+	allOpts := []NodeOption{
+		LLMProvider(models.LLMProviderAzureOpenAI),
+		LLMBaseURL(endpoint),
+		LLMAzureDeployment(deployment),
+		LLMPrompt(prompt),
+	}
+	allOpts = append(allOpts, opts...)
+	return NewNode(id, "llm", name, allOpts...)
+}
+
+// LLMBaseURL sets the provider API base URL; for Azure OpenAI it is the resource endpoint.
+func LLMBaseURL(baseURL string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if baseURL == "" {
+			return fmt.Errorf("base URL cannot be empty")
+		}
+		nb.config["base_url"] = baseURL
+		return nil
+	}
+}
+
+// LLMAzureDeployment sets the Azure OpenAI deployment name, which selects the model.
+func LLMAzureDeployment(deployment string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if deployment == "" {
+			return fmt.Errorf("deployment cannot be empty")
+		}
+		nb.config["deployment"] = deployment
+		return nil
+	}
+}
+
+// LLMAPIVersion sets the Azure OpenAI API version (default 2024-10-21).
+func LLMAPIVersion(version string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		nb.config["api_version"] = version
+		return nil
+	}
+}
+
+// LLMAzureADToken authenticates Azure OpenAI requests with a Microsoft Entra ID (Azure AD)
+// access token instead of an API key, e.g. "{{env.AZURE_OPENAI_TOKEN}}".
+func LLMAzureADToken(token string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if token == "" {
+			return fmt.Errorf("azure AD token cannot be empty")
+		}
+		nb.config["azure_ad_token"] = token
+		return nil
+	}
+}
+
+// NewBedrockNode creates a new Amazon Bedrock LLM node builder for a model ID such as
+// "anthropic.claude-3-5-sonnet-20240620-v1:0". AWS credentials come from the credentials
+// resource credentialID, e.g. "{{resource.aws.id}}".
+func NewBedrockNode(id, name, model, credentialID, prompt string, opts ...NodeOption) *NodeBuilder {
+	allOpts := []NodeOption{
+		LLMProvider(models.LLMProviderBedrock),
+		LLMModel(model),
+		LLMCredentialID(credentialID),
+		LLMPrompt(prompt),
+	}
+	allOpts = append(allOpts, opts...)
+	return NewNode(id, "llm", name, allOpts...)
+}
+
+// LLMCredentialID sets the credentials resource the provider authenticates with.
+func LLMCredentialID(credentialID string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if credentialID == "" {
+			return fmt.Errorf("credential ID cannot be empty")
+		}
+		nb.config["credential_id"] = credentialID
+		return nil
+	}
+}
+
+// LLMRegion sets the AWS region of a Bedrock node, overriding the credential's region.
+func LLMRegion(region string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if region == "" {
+			return fmt.Errorf("region cannot be empty")
+		}
+		nb.config["region"] = region
+		return nil
+	}
+}
+
+// LLMAssumeRole makes a Bedrock node assume an IAM role before calling the model.
+// externalID may be empty when the role's trust policy requires none.
+func LLMAssumeRole(roleARN, externalID string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if roleARN == "" {
+			return fmt.Errorf("role ARN cannot be empty")
+		}
+		nb.config["role_arn"] = roleARN
+		if externalID != "" {
+			nb.config["external_id"] = externalID
+		}
+		return nil
+	}
+}
+
+// LLMStream streams the response from the provider, publishing text chunks as
+// node.output_delta events while the node output still holds the assembled content.
+func LLMStream(stream bool) NodeOption {
+	return func(nb *NodeBuilder) error {
+		nb.config["stream"] = stream
+		return nil
+	}
+}
+
+// LLMCache serves the node from the LLM response cache when an identical request was
+// answered before. ttlSeconds <= 0 keeps responses for the server default.
+// Intended for deterministic prompts (temperature 0).
+func LLMCache(ttlSeconds int) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if ttlSeconds <= 0 {
+			nb.config["cache"] = true
+			return nil
+		}
+		nb.config["cache"] = map[string]any{"ttl": ttlSeconds}
+		return nil
+	}
+}
+
+// NewMockLLMNode creates an LLM node using the mock provider, which answers
+// deterministically without an API key. Use LLMMockResponse to set the answer.
+func NewMockLLMNode(id, name, prompt string, opts ...NodeOption) *NodeBuilder {
+	allOpts := []NodeOption{
+		LLMProvider(models.LLMProviderMock),
+		LLMPrompt(prompt),
+	}
+	allOpts = append(allOpts, opts...)
+	return NewNode(id, "llm", name, allOpts...)
+}
+
+// LLMMockResponse sets the canned response of a mock provider node.
+// A string sets the content; an object may also set tool_calls, finish_reason and usage.
+func LLMMockResponse(response any) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if response == nil {
+			return fmt.Errorf("mock response cannot be nil")
+		}
+		mock, _ := nb.config["mock"].(map[string]any)
+		if mock == nil {
+			mock = map[string]any{}
+		}
+		mock["response"] = response
+		nb.config["mock"] = mock
+		return nil
+	}
+}
+
+// LLMMockRule adds a rule answering prompts that contain the given substring.
+func LLMMockRule(contains string, response any) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if contains == "" {
+			return fmt.Errorf("mock rule substring cannot be empty")
+		}
+		if response == nil {
+			return fmt.Errorf("mock rule response cannot be nil")
+		}
+		mock, _ := nb.config["mock"].(map[string]any)
+		if mock == nil {
+			mock = map[string]any{}
+		}
+		rules, _ := mock["rules"].([]any)
+		mock["rules"] = append(rules, map[string]any{"contains": contains, "response": response})
+		nb.config["mock"] = mock
+		return nil
+	}
+}
+
+// LLMBatch enables batch mode: the prompt is sent once per item of the input array at
+// itemsPath (empty for an input that is itself an array), with each item appended to it.
+// Results are returned in input order with per-item errors.
+func LLMBatch(itemsPath string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		batch, _ := nb.config["batch"].(map[string]any)
+		if batch == nil {
+			batch = map[string]any{}
+		}
+		if itemsPath != "" {
+			batch["items_path"] = itemsPath
+		}
+		nb.config["batch"] = batch
+		return nil
+	}
+}
+
+// LLMBatchConcurrency sets how many batch requests run at once and, when
+// requestsPerMinute is positive, the rate at which they start.
+func LLMBatchConcurrency(concurrency, requestsPerMinute int) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if concurrency < 1 {
+			return fmt.Errorf("batch concurrency must be at least 1")
+		}
+		if requestsPerMinute < 0 {
+			return fmt.Errorf("batch requests per minute cannot be negative")
+		}
+		batch, _ := nb.config["batch"].(map[string]any)
+		if batch == nil {
+			batch = map[string]any{}
+		}
+		batch["concurrency"] = concurrency
+		if requestsPerMinute > 0 {
+			batch["requests_per_minute"] = requestsPerMinute
+		}
+		nb.config["batch"] = batch
+		return nil
+	}
+}
+
+// LLMProviderBatch submits batch items to the provider batch API (OpenAI only),
+// which is cheaper but may take up to the completion window to finish.
+func LLMProviderBatch() NodeOption {
+	return func(nb *NodeBuilder) error {
+		batch, _ := nb.config["batch"].(map[string]any)
+		if batch == nil {
+			batch = map[string]any{}
+		}
+		batch["mode"] = "provider"
+		nb.config["batch"] = batch
+		return nil
+	}
+}
+
 // NewLLMNode creates a new generic LLM node builder.
 // You must specify the provider using LLMProvider option.
 func NewLLMNode(id, name string, opts ...NodeOption) *NodeBuilder {
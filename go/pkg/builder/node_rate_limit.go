@@ -0,0 +1,52 @@
+package builder
+
+import (
+	"fmt"
+	"time"
+)
+
+// RateLimit allows limit executions per period ("second", "minute", "hour" or a duration
+// string such as "10s").
+func RateLimit(limit int, per string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if limit <= 0 {
+			return fmt.Errorf("rate limit must be positive, got %d", limit)
+		}
+		nb.config["limit"] = limit
+		nb.config["per"] = per
+		return nil
+	}
+}
+
+// RateLimitBurst allows n executions at once after an idle period.
+func RateLimitBurst(n int) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if n <= 0 {
+			return fmt.Errorf("burst must be positive, got %d", n)
+		}
+		nb.config["burst"] = n
+		return nil
+	}
+}
+
+// RateLimitKey names the limit so every node using the name shares it, e.g. "stripe-api".
+func RateLimitKey(key string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if key == "" {
+			return fmt.Errorf("rate limit key cannot be empty")
+		}
+		nb.config["key"] = key
+		return nil
+	}
+}
+
+// RateLimitMaxWait fails the node instead of waiting longer than d for a slot.
+func RateLimitMaxWait(d time.Duration) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if d < 0 {
+			return fmt.Errorf("max wait must not be negative")
+		}
+		nb.config["max_wait"] = d.String()
+		return nil
+	}
+}
@@ -0,0 +1,74 @@
+package builder
+
+import (
+	"fmt"
+)
+
+// SlackText sets the message text (also used as the notification fallback for blocks).
+func SlackText(text string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if text == "" {
+			return fmt.Errorf("slack text cannot be empty")
+		}
+		nb.config["text"] = text
+		return nil
+	}
+}
+
+// SlackBlocks sets Block Kit blocks for a Slack message.
+func SlackBlocks(blocks ...map[string]any) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if len(blocks) == 0 {
+			return fmt.Errorf("slack blocks cannot be empty")
+		}
+		items := make([]any, len(blocks))
+		for i, block := range blocks {
+			items[i] = block
+		}
+		nb.config["blocks"] = items
+		return nil
+	}
+}
+
+// SlackThreadTS posts the message or file as a reply in a thread.
+func SlackThreadTS(threadTS string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if threadTS == "" {
+			return fmt.Errorf("thread_ts cannot be empty")
+		}
+		nb.config["thread_ts"] = threadTS
+		return nil
+	}
+}
+
+// SlackFileUpload switches the node to the file operation and uploads a file from storage.
+func SlackFileUpload(fileID string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if fileID == "" {
+			return fmt.Errorf("file ID cannot be empty")
+		}
+		nb.config["operation"] = "file"
+		nb.config["file_id"] = fileID
+		return nil
+	}
+}
+
+// SlackInitialComment sets the message posted together with an uploaded file.
+func SlackInitialComment(comment string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		nb.config["initial_comment"] = comment
+		return nil
+	}
+}
+
+// NewSlackNode creates a new Slack node builder.
+// credentialID references a credentials resource holding the bot token,
+// typically "{{resource.<alias>.id}}".
+func NewSlackNode(id, name, credentialID, channel string, opts ...NodeOption) *NodeBuilder {
+	allOpts := []NodeOption{
+		WithConfigValue("credential_id", credentialID),
+		WithConfigValue("channel", channel),
+	}
+	allOpts = append(allOpts, opts...)
+	return NewNode(id, "slack", name, allOpts...)
+}
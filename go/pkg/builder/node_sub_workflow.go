@@ -0,0 +1,33 @@
+package builder
+
+import "fmt"
+
+// NewEmbeddedSubWorkflowNode creates a sub_workflow node that embeds the workflow built by
+// body instead of referencing a stored one, so composite workflows can be assembled in one
+// go. inputMapping and the options apply as for NewSubWorkflowNode.
+func NewEmbeddedSubWorkflowNode(id, name string, body *WorkflowBuilder, inputMapping map[string]string, opts ...NodeOption) *NodeBuilder {
+	nb := NewNode(id, "sub_workflow", name)
+	if body == nil {
+		nb.err = fmt.Errorf("embedded sub-workflow %s has no body", id)
+		return nb
+	}
+	wf, err := body.Build()
+	if err != nil {
+		nb.err = fmt.Errorf("embedded sub-workflow %s: %w", id, err)
+		return nb
+	}
+	nb.config["body"] = map[string]any{
+		"nodes": wf.Nodes,
+		"edges": wf.Edges,
+	}
+	if inputMapping != nil {
+		nb.config["input_mapping"] = inputMapping
+	}
+	for _, opt := range opts {
+		if err := opt(nb); err != nil {
+			nb.err = err
+			return nb
+		}
+	}
+	return nb
+}
@@ -416,7 +416,7 @@ func TestNewHTTPPatchNode_Success(t *testing.T) {
 // ==================== Transform Node Tests ====================
 
 func TestTransformType_AllValidTypes(t *testing.T) {
-	types := []string{"passthrough", "expression", "jq", "template"}
+	types := []string{"passthrough", "expression", "jq", "template", "csv_parse", "csv_generate", "xml"}
 
 	for _, ttype := range types {
 		t.Run(ttype, func(t *testing.T) {
@@ -555,6 +555,62 @@ func TestNewTemplateNode_Success(t *testing.T) {
 	assert.Equal(t, "Hello {{input.name}}", node.Config["template"])
 }
 
+func TestNewCSVParseNode_Success(t *testing.T) {
+	node, err := NewCSVParseNode("csv-node", "Parse CSV",
+		CSVDelimiter("auto"),
+		CSVHeader("auto"),
+		CSVInferTypes(false),
+	).Build()
+
+	require.NoError(t, err)
+	assert.Equal(t, "csv_parse", node.Config["type"])
+	assert.Equal(t, "auto", node.Config["delimiter"])
+	assert.Equal(t, "auto", node.Config["header"])
+	assert.Equal(t, false, node.Config["infer_types"])
+
+	_, err = NewCSVParseNode("csv-node", "Parse CSV", CSVHeader("yes")).Build()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid CSV header mode")
+}
+
+func TestNewCSVGenerateNode_Success(t *testing.T) {
+	node, err := NewCSVGenerateNode("csv-node", "Export CSV",
+		CSVColumns("id", "name"),
+		CSVIncludeHeader(false),
+	).Build()
+
+	require.NoError(t, err)
+	assert.Equal(t, "csv_generate", node.Config["type"])
+	assert.Equal(t, []string{"id", "name"}, node.Config["columns"])
+	assert.Equal(t, false, node.Config["include_header"])
+}
+
+func TestNewXMLXPathNode_Success(t *testing.T) {
+	node, err := NewXMLXPathNode("xml-node", "Extract",
+		XMLXPath("customer", "/order/customer"),
+		XMLXPath("note", "//x:note"),
+		XMLNamespace("x", "urn:extra"),
+	).Build()
+
+	require.NoError(t, err)
+	assert.Equal(t, "xml", node.Config["type"])
+	assert.Equal(t, "xpath", node.Config["operation"])
+	assert.Equal(t, map[string]any{"customer": "/order/customer", "note": "//x:note"}, node.Config["xpath"])
+	assert.Equal(t, map[string]any{"x": "urn:extra"}, node.Config["namespaces"])
+
+	_, err = NewXMLParseNode("xml-node", "Parse", XMLOperation("convert")).Build()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid XML operation")
+}
+
+func TestNewXMLSerializeNode_Success(t *testing.T) {
+	node, err := NewXMLSerializeNode("xml-node", "Export XML", XMLRootName("orders")).Build()
+
+	require.NoError(t, err)
+	assert.Equal(t, "serialize", node.Config["operation"])
+	assert.Equal(t, "orders", node.Config["root_name"])
+}
+
 func TestNewTransformNode_Generic(t *testing.T) {
 	node, err := NewTransformNode("transform-node", "Transform",
 		TransformType("passthrough"),
@@ -908,6 +964,149 @@ func TestNewAnthropicNode_Success(t *testing.T) {
 	assert.Equal(t, "Test prompt", node.Config["prompt"])
 }
 
+func TestNewGeminiNode_Success(t *testing.T) {
+	node, err := NewGeminiNode("gemini-node", "Gemini LLM", "gemini-2.5-flash", "Test prompt",
+		LLMAPIKey("test-key"),
+		LLMSystemPrompt("Answer in JSON"),
+		LLMJSONMode(),
+		LLMSafetySetting("HARM_CATEGORY_HARASSMENT", "BLOCK_ONLY_HIGH"),
+		LLMSafetySetting("HARM_CATEGORY_HATE_SPEECH", "BLOCK_LOW_AND_ABOVE"),
+	).Build()
+
+	require.NoError(t, err)
+	assert.Equal(t, "gemini", node.Config["provider"])
+	assert.Equal(t, "gemini-2.5-flash", node.Config["model"])
+	assert.Equal(t, "Answer in JSON", node.Config["system_prompt"])
+	assert.Equal(t, map[string]any{"type": "json_object"}, node.Config["response_format"])
+	assert.Equal(t, map[string]any{
+		"HARM_CATEGORY_HARASSMENT":  "BLOCK_ONLY_HIGH",
+		"HARM_CATEGORY_HATE_SPEECH": "BLOCK_LOW_AND_ABOVE",
+	}, node.Config["safety_settings"])
+
+	_, err = NewGeminiNode("gemini-node", "Gemini LLM", "gemini-2.5-flash", "Test prompt",
+		LLMSafetySetting("HARM_CATEGORY_HARASSMENT", ""),
+	).Build()
+	assert.Error(t, err)
+}
+
+func TestNewAzureOpenAINode_Success(t *testing.T) {
+	node, err := NewAzureOpenAINode("azure-node", "Azure LLM", "https://res.openai.azure.com", "gpt-4o-prod", "Test prompt",
+		LLMAPIVersion("2025-01-01-preview"),
+		LLMAzureADToken("{{env.AZURE_OPENAI_TOKEN}}"),
+	).Build()
+
+	require.NoError(t, err)
+	assert.Equal(t, "azure_openai", node.Config["provider"])
+	assert.Equal(t, "https://res.openai.azure.com", node.Config["base_url"])
+	assert.Equal(t, "gpt-4o-prod", node.Config["deployment"])
+	assert.Equal(t, "2025-01-01-preview", node.Config["api_version"])
+	assert.Equal(t, "{{env.AZURE_OPENAI_TOKEN}}", node.Config["azure_ad_token"])
+
+	_, err = NewAzureOpenAINode("azure-node", "Azure LLM", "https://res.openai.azure.com", "", "Test prompt").Build()
+	assert.Error(t, err)
+}
+
+func TestNewBedrockNode_Success(t *testing.T) {
+	node, err := NewBedrockNode("bedrock-node", "Bedrock LLM", "anthropic.claude-3-5-sonnet-20240620-v1:0", "{{resource.aws.id}}", "Test prompt",
+		LLMRegion("eu-central-1"),
+		LLMAssumeRole("arn:aws:iam::123456789012:role/bedrock-invoke", "ext-1"),
+		LLMStream(true),
+	).Build()
+
+	require.NoError(t, err)
+	assert.Equal(t, "bedrock", node.Config["provider"])
+	assert.Equal(t, "anthropic.claude-3-5-sonnet-20240620-v1:0", node.Config["model"])
+	assert.Equal(t, "{{resource.aws.id}}", node.Config["credential_id"])
+	assert.Equal(t, "eu-central-1", node.Config["region"])
+	assert.Equal(t, "arn:aws:iam::123456789012:role/bedrock-invoke", node.Config["role_arn"])
+	assert.Equal(t, "ext-1", node.Config["external_id"])
+	assert.Equal(t, true, node.Config["stream"])
+
+	_, err = NewBedrockNode("bedrock-node", "Bedrock LLM", "amazon.titan-text-express-v1", "", "Test prompt").Build()
+	assert.Error(t, err)
+}
+
+func TestLLMCache(t *testing.T) {
+	node, err := NewOpenAINode("llm-node", "LLM", "gpt-4o", "Classify: {{input.text}}",
+		LLMTemperature(0),
+		LLMCache(0),
+	).Build()
+	require.NoError(t, err)
+	assert.Equal(t, true, node.Config["cache"])
+
+	node, err = NewOpenAINode("llm-node", "LLM", "gpt-4o", "Classify: {{input.text}}", LLMCache(3600)).Build()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"ttl": 3600}, node.Config["cache"])
+}
+
+func TestNewMistralNode_Success(t *testing.T) {
+	node, err := NewMistralNode("mistral-node", "Mistral LLM", "mistral-small-latest", "Test prompt",
+		LLMAPIKey("test-key"),
+		LLMTemperature(0.3),
+		LLMMaxTokens(256),
+	).Build()
+
+	require.NoError(t, err)
+	assert.Equal(t, "mistral", node.Config["provider"])
+	assert.Equal(t, "mistral-small-latest", node.Config["model"])
+	assert.Equal(t, "Test prompt", node.Config["prompt"])
+	assert.Equal(t, 0.3, node.Config["temperature"])
+	assert.Equal(t, 256, node.Config["max_tokens"])
+}
+
+func TestNewCohereNode_Success(t *testing.T) {
+	node, err := NewCohereNode("cohere-node", "Cohere LLM", "command-r-plus-08-2024", "Test prompt",
+		LLMAPIKey("test-key"),
+		LLMJSONMode(),
+	).Build()
+
+	require.NoError(t, err)
+	assert.Equal(t, "cohere", node.Config["provider"])
+	assert.Equal(t, "command-r-plus-08-2024", node.Config["model"])
+	assert.Equal(t, "test-key", node.Config["api_key"])
+}
+
+func TestNewMockLLMNode_Success(t *testing.T) {
+	node, err := NewMockLLMNode("mock-node", "Mock LLM", "Classify {{input.text}}",
+		LLMMockResponse("positive"),
+		LLMMockRule("refund", "negative"),
+	).Build()
+
+	require.NoError(t, err)
+	assert.Equal(t, "llm", node.Type)
+	assert.Equal(t, "mock", node.Config["provider"])
+	assert.NotContains(t, node.Config, "model")
+	assert.Equal(t, map[string]any{
+		"response": "positive",
+		"rules":    []any{map[string]any{"contains": "refund", "response": "negative"}},
+	}, node.Config["mock"])
+}
+
+func TestLLMBatch_Options(t *testing.T) {
+	node, err := NewOpenAINode("classify", "Classify", "gpt-4o-mini", "Classify the sentiment:",
+		LLMAPIKey("sk-test"),
+		LLMBatch("reviews"),
+		LLMBatchConcurrency(8, 600),
+	).Build()
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"items_path":          "reviews",
+		"concurrency":         8,
+		"requests_per_minute": 600,
+	}, node.Config["batch"])
+
+	node, err = NewOpenAINode("classify", "Classify", "gpt-4o-mini", "Classify:",
+		LLMAPIKey("sk-test"),
+		LLMProviderBatch(),
+	).Build()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"mode": "provider"}, node.Config["batch"])
+
+	_, err = NewOpenAINode("classify", "Classify", "gpt-4o-mini", "Classify:", LLMBatchConcurrency(0, 0)).Build()
+	assert.Error(t, err)
+}
+
 func TestNewLLMNode_Generic(t *testing.T) {
 	node, err := NewLLMNode("llm-node", "Generic LLM",
 		LLMProvider(models.LLMProviderOpenAI),
@@ -921,6 +1120,56 @@ func TestNewLLMNode_Generic(t *testing.T) {
 	assert.Equal(t, "openai", node.Config["provider"])
 }
 
+// ==================== Slack Node Tests ====================
+
+func TestNewSlackNode_Message(t *testing.T) {
+	node, err := NewSlackNode("notify", "Notify", "{{resource.slack.id}}", "C123",
+		SlackText("Deploy finished"),
+		SlackBlocks(map[string]any{"type": "section", "text": map[string]any{"type": "mrkdwn", "text": "*Done*"}}),
+		SlackThreadTS("1700000000.000100"),
+	).Build()
+
+	require.NoError(t, err)
+	assert.Equal(t, "slack", node.Type)
+	assert.Equal(t, "{{resource.slack.id}}", node.Config["credential_id"])
+	assert.Equal(t, "C123", node.Config["channel"])
+	assert.Equal(t, "Deploy finished", node.Config["text"])
+	assert.Len(t, node.Config["blocks"], 1)
+	assert.Equal(t, "1700000000.000100", node.Config["thread_ts"])
+	assert.Nil(t, node.Config["operation"])
+}
+
+func TestNewSlackNode_FileUpload(t *testing.T) {
+	node, err := NewSlackNode("upload", "Upload", "cred-1", "C123",
+		SlackFileUpload("file-1"),
+		SlackInitialComment("Nightly report"),
+	).Build()
+
+	require.NoError(t, err)
+	assert.Equal(t, "file", node.Config["operation"])
+	assert.Equal(t, "file-1", node.Config["file_id"])
+	assert.Equal(t, "Nightly report", node.Config["initial_comment"])
+}
+
+func TestSlackOptions_Empty(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  NodeOption
+	}{
+		{"text", SlackText("")},
+		{"blocks", SlackBlocks()},
+		{"thread", SlackThreadTS("")},
+		{"file", SlackFileUpload("")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSlackNode("n", "N", "cred-1", "C123", tt.opt).Build()
+			assert.Error(t, err)
+		})
+	}
+}
+
 // ==================== Complex Integration Tests ====================
 
 func TestNodeBuilder_HTTPWithAllOptions(t *testing.T) {
@@ -976,3 +1225,135 @@ func TestNodeBuilder_LLMWithAllOptions(t *testing.T) {
 	assert.NotNil(t, node.Position)
 	assert.Equal(t, "gpt", node.Metadata["model_type"])
 }
+
+func TestNodeBuilder_WithDataLabels(t *testing.T) {
+	node, err := NewNode("test-node", "http", "Test Node",
+		WithDataLabels("pii"),
+		WithDataLabels("retain-7d"),
+	).Build()
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pii", "retain-7d"}, node.Config["data_labels"])
+
+	_, err = NewNode("test-node", "http", "Test Node", WithDataLabels("retain-forever")).Build()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid retention label")
+}
+
+func TestNodeBuilder_WithTimeout(t *testing.T) {
+	node, err := NewNode("test-node", "http", "Test Node", WithTimeout(1500*time.Millisecond)).Build()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500), node.Config[models.NodeTimeoutConfigKey])
+	assert.NotContains(t, node.Config, "timeout")
+
+	_, err = NewNode("test-node", "http", "Test Node", WithTimeout(0)).Build()
+	assert.Error(t, err)
+}
+
+func TestDelayOptions(t *testing.T) {
+	node, err := NewNode("wait", "delay", "Wait", DelayFor(90*time.Minute)).Build()
+	require.NoError(t, err)
+	assert.Equal(t, "1h30m0s", node.Config["duration"])
+
+	node, err = NewNode("wait", "delay", "Wait", DelayUntil("{{input.send_at}}")).Build()
+	require.NoError(t, err)
+	assert.Equal(t, "{{input.send_at}}", node.Config["until"])
+
+	_, err = NewNode("wait", "delay", "Wait", DelayFor(-time.Second)).Build()
+	assert.Error(t, err)
+	_, err = NewNode("wait", "delay", "Wait", DelayUntil("")).Build()
+	assert.Error(t, err)
+}
+
+func TestWaitForEventOptions(t *testing.T) {
+	node, err := NewNode("callback", "wait_for_event", "Callback",
+		WaitForEventKey("payment-{{input.order_id}}"),
+		WaitForEventTimeout(2*time.Hour),
+	).Build()
+	require.NoError(t, err)
+	assert.Equal(t, "payment-{{input.order_id}}", node.Config["correlation_key"])
+	assert.Equal(t, "2h0m0s", node.Config["timeout"])
+
+	_, err = NewNode("callback", "wait_for_event", "Callback", WaitForEventKey("")).Build()
+	assert.Error(t, err)
+	_, err = NewNode("callback", "wait_for_event", "Callback", WaitForEventTimeout(0)).Build()
+	assert.Error(t, err)
+}
+
+func TestApprovalOptions(t *testing.T) {
+	node, err := NewNode("review", "approval", "Review",
+		ApprovalMessage("Refund {{input.amount}} EUR?"),
+		ApprovalApprovers("user-1", "user-2"),
+		ApprovalTimeout(48*time.Hour),
+	).Build()
+	require.NoError(t, err)
+	assert.Equal(t, "Refund {{input.amount}} EUR?", node.Config["message"])
+	assert.Equal(t, []string{"user-1", "user-2"}, node.Config["approvers"])
+	assert.Equal(t, "48h0m0s", node.Config["timeout"])
+
+	_, err = NewNode("review", "approval", "Review", ApprovalApprovers()).Build()
+	assert.Error(t, err)
+	_, err = NewNode("review", "approval", "Review", ApprovalTimeout(0)).Build()
+	assert.Error(t, err)
+}
+
+func TestBatcherOptions(t *testing.T) {
+	node, err := NewNode("batch", "batcher", "Batch Orders",
+		BatchMaxSize(100),
+		BatchWindow(5*time.Minute),
+		BatchItem("{{input.order}}"),
+		BatchGroup("{{input.customer_id}}"),
+	).Build()
+	require.NoError(t, err)
+	assert.Equal(t, 100, node.Config["max_size"])
+	assert.Equal(t, "5m0s", node.Config["window"])
+	assert.Equal(t, "{{input.order}}", node.Config["item"])
+	assert.Equal(t, "{{input.customer_id}}", node.Config["group"])
+
+	_, err = NewNode("batch", "batcher", "Batch", BatchMaxSize(0)).Build()
+	assert.Error(t, err)
+	_, err = NewNode("batch", "batcher", "Batch", BatchWindow(0)).Build()
+	assert.Error(t, err)
+
+	edge, err := NewEdge("batch", "send", FromReleasedBranch()).Build()
+	require.NoError(t, err)
+	assert.Equal(t, "released", edge.SourceHandle)
+}
+
+func TestValidateOptions(t *testing.T) {
+	schema := map[string]any{"type": "object", "required": []any{"email"}}
+	node, err := NewNode("check", "validate", "Check Order",
+		ValidateSchema(schema),
+		ValidateData("{{input.order}}"),
+		ValidateOnInvalid("route"),
+	).Build()
+	require.NoError(t, err)
+	assert.Equal(t, schema, node.Config["schema"])
+	assert.Equal(t, "{{input.order}}", node.Config["data"])
+	assert.Equal(t, "route", node.Config["on_invalid"])
+
+	_, err = NewNode("check", "validate", "Check", ValidateSchema(nil)).Build()
+	assert.Error(t, err)
+	_, err = NewNode("check", "validate", "Check", ValidateOnInvalid("ignore")).Build()
+	assert.Error(t, err)
+}
+
+func TestRateLimitOptions(t *testing.T) {
+	node, err := NewNode("throttle", "rate_limit", "Throttle",
+		RateLimit(100, "minute"),
+		RateLimitBurst(10),
+		RateLimitKey("stripe-api"),
+		RateLimitMaxWait(30*time.Second),
+	).Build()
+	require.NoError(t, err)
+	assert.Equal(t, 100, node.Config["limit"])
+	assert.Equal(t, "minute", node.Config["per"])
+	assert.Equal(t, 10, node.Config["burst"])
+	assert.Equal(t, "stripe-api", node.Config["key"])
+	assert.Equal(t, "30s", node.Config["max_wait"])
+
+	_, err = NewNode("throttle", "rate_limit", "Throttle", RateLimit(0, "second")).Build()
+	assert.Error(t, err)
+	_, err = NewNode("throttle", "rate_limit", "Throttle", RateLimitBurst(0)).Build()
+	assert.Error(t, err)
+}
@@ -5,17 +5,20 @@ import (
 )
 
 // TransformType sets the transformation type.
-// Valid types: passthrough, expression, jq, template
+// Valid types: passthrough, expression, jq, template, csv_parse, csv_generate, xml
 func TransformType(ttype string) NodeOption {
 	return func(nb *NodeBuilder) error {
 		validTypes := map[string]bool{
-			"passthrough": true,
-			"expression":  true,
-			"jq":          true,
-			"template":    true,
+			"passthrough":  true,
+			"expression":   true,
+			"jq":           true,
+			"template":     true,
+			"csv_parse":    true,
+			"csv_generate": true,
+			"xml":          true,
 		}
 		if !validTypes[ttype] {
-			return fmt.Errorf("invalid transform type: %s (valid: passthrough, expression, jq, template)", ttype)
+			return fmt.Errorf("invalid transform type: %s (valid: passthrough, expression, jq, template, csv_parse, csv_generate, xml)", ttype)
 		}
 		nb.config["type"] = ttype
 		return nil
@@ -56,6 +59,131 @@ func TransformTemplate(tmpl string) NodeOption {
 	}
 }
 
+// CSVDelimiter sets the field delimiter for CSV transforms.
+// Use "\t" for tabs, or "auto" to detect the delimiter when parsing.
+func CSVDelimiter(delimiter string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if delimiter == "" {
+			return fmt.Errorf("CSV delimiter cannot be empty")
+		}
+		nb.config["delimiter"] = delimiter
+		return nil
+	}
+}
+
+// CSVHeader sets how csv_parse finds column names: first_row, none or auto.
+func CSVHeader(mode string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		switch mode {
+		case "first_row", "none", "auto":
+		default:
+			return fmt.Errorf("invalid CSV header mode: %s (valid: first_row, none, auto)", mode)
+		}
+		nb.config["header"] = mode
+		return nil
+	}
+}
+
+// CSVColumns sets the column names for csv_parse, or the column order for csv_generate.
+func CSVColumns(columns ...string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if len(columns) == 0 {
+			return fmt.Errorf("CSV columns cannot be empty")
+		}
+		nb.config["columns"] = columns
+		return nil
+	}
+}
+
+// CSVInferTypes sets whether csv_parse converts numbers, booleans and empty fields.
+func CSVInferTypes(infer bool) NodeOption {
+	return func(nb *NodeBuilder) error {
+		nb.config["infer_types"] = infer
+		return nil
+	}
+}
+
+// CSVIncludeHeader sets whether csv_generate writes a header row.
+func CSVIncludeHeader(include bool) NodeOption {
+	return func(nb *NodeBuilder) error {
+		nb.config["include_header"] = include
+		return nil
+	}
+}
+
+// XMLOperation sets the operation of xml transforms: parse, serialize or xpath.
+func XMLOperation(operation string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		switch operation {
+		case "parse", "serialize", "xpath":
+		default:
+			return fmt.Errorf("invalid XML operation: %s (valid: parse, serialize, xpath)", operation)
+		}
+		nb.config["operation"] = operation
+		return nil
+	}
+}
+
+// XMLXPath adds a named XPath expression to extract with the xpath operation.
+func XMLXPath(name, expr string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if name == "" {
+			return fmt.Errorf("XPath name cannot be empty")
+		}
+		if expr == "" {
+			return fmt.Errorf("XPath expression cannot be empty")
+		}
+
+		expressions, ok := nb.config["xpath"].(map[string]any)
+		if !ok {
+			expressions = make(map[string]any)
+			nb.config["xpath"] = expressions
+		}
+		expressions[name] = expr
+		return nil
+	}
+}
+
+// XMLNamespace binds a prefix to a namespace URI for XPath expressions, and declares
+// it on the root element when serializing. An empty prefix is the default namespace.
+func XMLNamespace(prefix, uri string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if uri == "" {
+			return fmt.Errorf("XML namespace URI cannot be empty")
+		}
+
+		namespaces, ok := nb.config["namespaces"].(map[string]any)
+		if !ok {
+			namespaces = make(map[string]any)
+			nb.config["namespaces"] = namespaces
+		}
+		namespaces[prefix] = uri
+		return nil
+	}
+}
+
+// XMLForceArray sets element names that xml parsing always returns as arrays.
+func XMLForceArray(names ...string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if len(names) == 0 {
+			return fmt.Errorf("XML force_array names cannot be empty")
+		}
+		nb.config["force_array"] = names
+		return nil
+	}
+}
+
+// XMLRootName sets the root element name used when serializing to XML.
+func XMLRootName(name string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if name == "" {
+			return fmt.Errorf("XML root name cannot be empty")
+		}
+		nb.config["root_name"] = name
+		return nil
+	}
+}
+
 // TransformMapping sets field mappings for transform operations.
 func TransformMapping(mapping map[string]string) NodeOption {
 	return func(nb *NodeBuilder) error {
@@ -105,6 +233,42 @@ func NewTemplateNode(id, name, tmpl string, opts ...NodeOption) *NodeBuilder {
 	return NewNode(id, "transform", name, allOpts...)
 }
 
+// NewCSVParseNode creates a new transform node that parses CSV into an array of objects.
+func NewCSVParseNode(id, name string, opts ...NodeOption) *NodeBuilder {
+	allOpts := []NodeOption{TransformType("csv_parse")}
+	allOpts = append(allOpts, opts...)
+	return NewNode(id, "transform", name, allOpts...)
+}
+
+// NewCSVGenerateNode creates a new transform node that serializes an array to CSV.
+func NewCSVGenerateNode(id, name string, opts ...NodeOption) *NodeBuilder {
+	allOpts := []NodeOption{TransformType("csv_generate")}
+	allOpts = append(allOpts, opts...)
+	return NewNode(id, "transform", name, allOpts...)
+}
+
+// NewXMLParseNode creates a new transform node that parses XML into an object.
+func NewXMLParseNode(id, name string, opts ...NodeOption) *NodeBuilder {
+	allOpts := []NodeOption{TransformType("xml"), XMLOperation("parse")}
+	allOpts = append(allOpts, opts...)
+	return NewNode(id, "transform", name, allOpts...)
+}
+
+// NewXMLSerializeNode creates a new transform node that serializes an object to XML.
+func NewXMLSerializeNode(id, name string, opts ...NodeOption) *NodeBuilder {
+	allOpts := []NodeOption{TransformType("xml"), XMLOperation("serialize")}
+	allOpts = append(allOpts, opts...)
+	return NewNode(id, "transform", name, allOpts...)
+}
+
+// NewXMLXPathNode creates a new transform node that extracts values from XML with XPath.
+// Add the expressions with XMLXPath.
+func NewXMLXPathNode(id, name string, opts ...NodeOption) *NodeBuilder {
+	allOpts := []NodeOption{TransformType("xml"), XMLOperation("xpath")}
+	allOpts = append(allOpts, opts...)
+	return NewNode(id, "transform", name, allOpts...)
+}
+
 // NewTransformNode creates a new generic transform node.
 // You must specify the type using TransformType option.
 func NewTransformNode(id, name string, opts ...NodeOption) *NodeBuilder {
@@ -0,0 +1,43 @@
+package builder
+
+import (
+	"fmt"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor/config"
+)
+
+// TypedConfig constrains NewNodeT to the typed node configs of package config, such as
+// config.HTTPConfig, or to other config structs whose pointers name their node type and
+// validate the config.
+type TypedConfig[T any] interface {
+	*T
+	NodeType() string
+	Validate() error
+}
+
+// NewNodeT creates a node from a typed config, so that the compiler checks its fields
+// instead of WithConfigValue keys. The config is validated, then marshalled into the
+// node's config map through its JSON tags; options apply afterwards.
+//
+//	builder.NewNodeT("fetch", "Fetch", config.HTTPConfig{Method: "GET", URL: "https://api.example.com"})
+func NewNodeT[T any, PT TypedConfig[T]](id, name string, cfg T, opts ...NodeOption) *NodeBuilder {
+	typed := PT(&cfg)
+	nb := NewNode(id, typed.NodeType(), name)
+	if err := typed.Validate(); err != nil {
+		nb.err = fmt.Errorf("invalid %s config: %w", typed.NodeType(), err)
+		return nb
+	}
+	values, err := config.ToMap(typed)
+	if err != nil {
+		nb.err = err
+		return nb
+	}
+	nb.config = values
+	for _, opt := range opts {
+		if err := opt(nb); err != nil {
+			nb.err = err
+			return nb
+		}
+	}
+	return nb
+}
@@ -0,0 +1,54 @@
+package builder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor/config"
+)
+
+func TestNewNodeT_MarshalsTypedConfig(t *testing.T) {
+	t.Parallel()
+
+	node, err := NewNodeT[config.HTTPConfig]("fetch", "Fetch", config.HTTPConfig{
+		Method:  "POST",
+		URL:     "https://api.example.com/orders",
+		Headers: map[string]string{"Accept": "application/json"},
+		Timeout: 30,
+	}, WithNodeDescription("Creates the order")).Build()
+	if err != nil {
+		t.Fatalf("build failed: %v", err)
+	}
+	if node.Type != "http" {
+		t.Fatalf("expected type=http, got: %s", node.Type)
+	}
+	if node.Config["method"] != "POST" || node.Config["url"] != "https://api.example.com/orders" {
+		t.Fatalf("unexpected config: %v", node.Config)
+	}
+	if headers, _ := node.Config["headers"].(map[string]any); headers["Accept"] != "application/json" {
+		t.Fatalf("expected headers in config, got: %v", node.Config["headers"])
+	}
+	if _, ok := node.Config["body"]; ok {
+		t.Fatal("expected empty optional fields to be left out")
+	}
+	if node.Description != "Creates the order" {
+		t.Fatalf("expected options to apply, got description %q", node.Description)
+	}
+}
+
+func TestNewNodeT_ValidatesConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewNodeT("fetch", "Fetch", config.HTTPConfig{Method: "FETCH", URL: "https://example.com"}).Build()
+	if err == nil || !strings.Contains(err.Error(), "invalid http config") {
+		t.Fatalf("expected invalid http config error, got: %v", err)
+	}
+
+	node, err := NewNodeT("copy", "Copy", config.TransformConfig{}).Build()
+	if err != nil {
+		t.Fatalf("build failed: %v", err)
+	}
+	if node.Type != "transform" || node.Config["type"] != "passthrough" {
+		t.Fatalf("expected a passthrough transform, got: %s %v", node.Type, node.Config)
+	}
+}
@@ -0,0 +1,36 @@
+package builder
+
+import "fmt"
+
+// ValidateSchema sets the JSON Schema the node validates against.
+func ValidateSchema(schema map[string]any) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if len(schema) == 0 {
+			return fmt.Errorf("schema cannot be empty")
+		}
+		nb.config["schema"] = schema
+		return nil
+	}
+}
+
+// ValidateData sets the value to validate (or template); the node input by default.
+func ValidateData(data any) NodeOption {
+	return func(nb *NodeBuilder) error {
+		nb.config["data"] = data
+		return nil
+	}
+}
+
+// ValidateOnInvalid sets what invalid data does: "fail", "route" (follow error edges)
+// or "annotate" (continue with the violations in the output).
+func ValidateOnInvalid(action string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		switch action {
+		case "fail", "route", "annotate":
+		default:
+			return fmt.Errorf("on_invalid must be one of: fail, route, annotate")
+		}
+		nb.config["on_invalid"] = action
+		return nil
+	}
+}
@@ -0,0 +1,28 @@
+package builder
+
+import (
+	"fmt"
+	"time"
+)
+
+// WaitForEventKey sets the correlation key the node waits for, e.g. "payment-{{input.order_id}}".
+func WaitForEventKey(key string) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if key == "" {
+			return fmt.Errorf("correlation key cannot be empty")
+		}
+		nb.config["correlation_key"] = key
+		return nil
+	}
+}
+
+// WaitForEventTimeout sets how long the node waits before taking its timeout branch.
+func WaitForEventTimeout(d time.Duration) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if d <= 0 {
+			return fmt.Errorf("wait timeout must be positive")
+		}
+		nb.config["timeout"] = d.String()
+		return nil
+	}
+}
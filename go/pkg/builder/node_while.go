@@ -0,0 +1,44 @@
+package builder
+
+import (
+	"fmt"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// NewWhileNode creates a while node that runs the body workflow's nodes and edges, then
+// again while the condition over the body's output (as "output") is true.
+func NewWhileNode(id, name, condition string, body *models.Workflow, opts ...NodeOption) *NodeBuilder {
+	nb := NewNode(id, "while", name)
+	if body == nil || len(body.Nodes) == 0 {
+		nb.err = fmt.Errorf("while body must have at least one node")
+		return nb
+	}
+	if condition == "" {
+		nb.err = fmt.Errorf("while condition cannot be empty")
+		return nb
+	}
+	nb.config["condition"] = condition
+	nb.config["body"] = map[string]any{
+		"nodes": body.Nodes,
+		"edges": body.Edges,
+	}
+	for _, opt := range opts {
+		if err := opt(nb); err != nil {
+			nb.err = err
+			return nb
+		}
+	}
+	return nb
+}
+
+// WhileMaxIterations caps how many times a while node runs its body.
+func WhileMaxIterations(n int) NodeOption {
+	return func(nb *NodeBuilder) error {
+		if n <= 0 {
+			return fmt.Errorf("max iterations must be positive, got %d", n)
+		}
+		nb.config["max_iterations"] = n
+		return nil
+	}
+}
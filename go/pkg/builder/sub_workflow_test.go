@@ -2,12 +2,14 @@ package builder
 
 import (
 	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
 )
 
 func TestNewSubWorkflowNode(t *testing.T) {
 	t.Parallel()
 
-	nb := NewSubWorkflowNode("fanout", "Generate Cells", "child-wf-id",
+	nb := NewSubWorkflowNode("fanout", "Generate Cells", "child-wf-id", nil,
 		WithForEach("input.cells"),
 		WithItemVar("cell"),
 		WithMaxParallelism(5),
@@ -42,7 +44,7 @@ func TestSubWorkflowNode_InWorkflow(t *testing.T) {
 
 	wf, err := NewWorkflow("Test WF").
 		AddNode(NewNode("source", "transform", "Source")).
-		AddNode(NewSubWorkflowNode("fanout", "Fan Out", "child-wf",
+		AddNode(NewSubWorkflowNode("fanout", "Fan Out", "child-wf", nil,
 			WithForEach("input.items"),
 		)).
 		AddNode(NewNode("sink", "transform", "Sink")).
@@ -72,3 +74,156 @@ func TestSubWorkflowNode_InWorkflow(t *testing.T) {
 		t.Fatal("fanout node not found")
 	}
 }
+
+func TestSubWorkflowNode_Chunking(t *testing.T) {
+	t.Parallel()
+
+	nb := NewSubWorkflowNode("fanout", "Process Rows", "row-batch-wf", nil,
+		WithForEach("input.rows"),
+		WithChunkSize(1000),
+		WithReducerWorkflow("merge-wf"),
+	)
+	if nb.err != nil {
+		t.Fatalf("unexpected error: %v", nb.err)
+	}
+	if nb.config["chunk_size"] != 1000 {
+		t.Fatalf("expected chunk_size=1000, got: %v", nb.config["chunk_size"])
+	}
+	if nb.config["reducer_workflow_id"] != "merge-wf" {
+		t.Fatalf("expected reducer_workflow_id=merge-wf, got: %v", nb.config["reducer_workflow_id"])
+	}
+
+	nb = NewSubWorkflowNode("fanout", "Process Rows", "row-batch-wf", nil, WithChunkSize(0))
+	if nb.err == nil {
+		t.Fatal("expected error for chunk size 0")
+	}
+}
+
+func TestNewSubWorkflowNode_InputMapping(t *testing.T) {
+	t.Parallel()
+
+	node, err := NewSubWorkflowNode("enrich", "Enrich", "enrich-wf",
+		map[string]string{"email": "input.customer.email"},
+	).Build()
+	if err != nil {
+		t.Fatalf("failed to build node: %v", err)
+	}
+	mapping, ok := node.Config["input_mapping"].(map[string]string)
+	if !ok || mapping["email"] != "input.customer.email" {
+		t.Fatalf("expected input_mapping with email, got: %v", node.Config["input_mapping"])
+	}
+	if _, ok := node.Config["for_each"]; ok {
+		t.Fatal("expected no for_each without WithForEach")
+	}
+}
+
+func TestNewEmbeddedSubWorkflowNode(t *testing.T) {
+	t.Parallel()
+
+	body := NewWorkflow("Enrich").
+		AddNode(NewNode("lookup", "http", "Lookup")).
+		AddNode(NewNode("shape", "transform", "Shape")).
+		Connect("lookup", "shape")
+
+	wf, err := NewWorkflow("Onboarding").
+		AddNode(NewNode("source", "transform", "Source")).
+		AddNode(NewEmbeddedSubWorkflowNode("enrich", "Enrich", body, map[string]string{"email": "input.email"})).
+		Connect("source", "enrich").
+		Build()
+	if err != nil {
+		t.Fatalf("build failed: %v", err)
+	}
+
+	var node *models.Node
+	for _, n := range wf.Nodes {
+		if n.ID == "enrich" {
+			node = n
+		}
+	}
+	if node == nil || node.Type != "sub_workflow" {
+		t.Fatalf("expected sub_workflow node enrich, got: %v", node)
+	}
+	if _, ok := node.Config["workflow_id"]; ok {
+		t.Fatal("expected no workflow_id for an embedded sub-workflow")
+	}
+	bodyConfig, ok := node.Config["body"].(map[string]any)
+	if !ok || len(bodyConfig["nodes"].([]*models.Node)) != 2 || len(bodyConfig["edges"].([]*models.Edge)) != 1 {
+		t.Fatalf("expected body with two nodes and an edge, got: %v", node.Config["body"])
+	}
+
+	invalid := NewWorkflow("Broken").Connect("missing", "nodes")
+	if _, err := NewEmbeddedSubWorkflowNode("enrich", "Enrich", invalid, nil).Build(); err == nil {
+		t.Fatal("expected error for an invalid body")
+	}
+	if _, err := NewEmbeddedSubWorkflowNode("enrich", "Enrich", nil, nil).Build(); err == nil {
+		t.Fatal("expected error for missing body")
+	}
+}
+
+func TestNewForEachNode(t *testing.T) {
+	t.Parallel()
+
+	body, err := NewWorkflow("Body").
+		AddNode(NewNode("double", "transform", "Double")).
+		Build()
+	if err != nil {
+		t.Fatalf("failed to build body: %v", err)
+	}
+
+	node, err := NewForEachNode("each", "Each Order", "input.orders", body,
+		WithItemVar("order"),
+		WithMaxParallelism(3),
+	).Build()
+	if err != nil {
+		t.Fatalf("failed to build node: %v", err)
+	}
+	if node.Type != "foreach" {
+		t.Fatalf("expected type=foreach, got: %s", node.Type)
+	}
+	if node.Config["for_each"] != "input.orders" {
+		t.Fatalf("expected for_each=input.orders, got: %v", node.Config["for_each"])
+	}
+	bodyConfig, ok := node.Config["body"].(map[string]any)
+	if !ok || len(bodyConfig["nodes"].([]*models.Node)) != 1 {
+		t.Fatalf("expected body with one node, got: %v", node.Config["body"])
+	}
+	if node.Config["max_parallelism"] != 3 {
+		t.Fatalf("expected max_parallelism=3, got: %v", node.Config["max_parallelism"])
+	}
+
+	if _, err := NewForEachNode("each", "Each", "input.orders", nil).Build(); err == nil {
+		t.Fatal("expected error for missing body")
+	}
+}
+
+func TestNewWhileNode(t *testing.T) {
+	t.Parallel()
+
+	body, err := NewWorkflow("Body").
+		AddNode(NewNode("fetch", "http", "Fetch Page")).
+		Build()
+	if err != nil {
+		t.Fatalf("failed to build body: %v", err)
+	}
+
+	node, err := NewWhileNode("pages", "Fetch Pages", "output.has_more", body, WhileMaxIterations(50)).Build()
+	if err != nil {
+		t.Fatalf("failed to build node: %v", err)
+	}
+	if node.Type != "while" {
+		t.Fatalf("expected type=while, got: %s", node.Type)
+	}
+	if node.Config["condition"] != "output.has_more" {
+		t.Fatalf("expected condition=output.has_more, got: %v", node.Config["condition"])
+	}
+	if node.Config["max_iterations"] != 50 {
+		t.Fatalf("expected max_iterations=50, got: %v", node.Config["max_iterations"])
+	}
+
+	if _, err := NewWhileNode("pages", "Fetch Pages", "", body).Build(); err == nil {
+		t.Fatal("expected error for empty condition")
+	}
+	if _, err := NewWhileNode("pages", "Fetch Pages", "true", body, WhileMaxIterations(0)).Build(); err == nil {
+		t.Fatal("expected error for zero max iterations")
+	}
+}
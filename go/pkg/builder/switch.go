@@ -0,0 +1,69 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SwitchBuilder routes the output of a node to the first of several targets whose
+// condition holds. Create one with WorkflowBuilder.Switch.
+type SwitchBuilder struct {
+	wb         *WorkflowBuilder
+	from       string
+	conditions []string
+}
+
+// Switch starts multi-way routing from a node. Each Case connects the node to a target
+// when its condition over the node's output (as "output") holds and no earlier case's does;
+// Default connects the target taken when none does. Finish with Default or End.
+//
+//	wb.Switch("classify").
+//	    Case(`output.kind == "invoice"`, "book").
+//	    Case(`output.kind == "receipt"`, "archive").
+//	    Default("review")
+func (wb *WorkflowBuilder) Switch(nodeID string) *SwitchBuilder {
+	return &SwitchBuilder{wb: wb, from: nodeID}
+}
+
+// Case connects the switch node to the target when the condition holds and no earlier
+// case's condition does.
+func (sb *SwitchBuilder) Case(condition, target string, opts ...EdgeOption) *SwitchBuilder {
+	if sb.wb.err != nil {
+		return sb
+	}
+	if condition == "" {
+		sb.wb.err = fmt.Errorf("switch %s: case for %s has an empty condition", sb.from, target)
+		return sb
+	}
+	guarded := condition
+	if guard := sb.unmatched(); guard != "" {
+		guarded = fmt.Sprintf("(%s) && %s", condition, guard)
+	}
+	sb.wb.Connect(sb.from, target, append([]EdgeOption{WithCondition(guarded)}, opts...)...)
+	sb.conditions = append(sb.conditions, condition)
+	return sb
+}
+
+// Default connects the switch node to the target taken when no case matches and returns
+// the workflow builder.
+func (sb *SwitchBuilder) Default(target string, opts ...EdgeOption) *WorkflowBuilder {
+	if guard := sb.unmatched(); guard != "" {
+		opts = append([]EdgeOption{WithCondition(guard)}, opts...)
+	}
+	return sb.wb.Connect(sb.from, target, opts...)
+}
+
+// End returns the workflow builder of a switch without a default target; nothing follows
+// the switch node when no case matches.
+func (sb *SwitchBuilder) End() *WorkflowBuilder {
+	return sb.wb
+}
+
+// unmatched returns a condition holding when none of the cases so far match.
+func (sb *SwitchBuilder) unmatched() string {
+	negated := make([]string, len(sb.conditions))
+	for i, condition := range sb.conditions {
+		negated[i] = fmt.Sprintf("!(%s)", condition)
+	}
+	return strings.Join(negated, " && ")
+}
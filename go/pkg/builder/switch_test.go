@@ -0,0 +1,90 @@
+package builder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/engine"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+func newSwitchWorkflow() *WorkflowBuilder {
+	return NewWorkflow("Documents").
+		AddNode(NewPassthroughNode("classify", "Classify")).
+		AddNode(NewPassthroughNode("book", "Book")).
+		AddNode(NewPassthroughNode("archive", "Archive")).
+		AddNode(NewPassthroughNode("review", "Review")).
+		Switch("classify").
+		Case(`output.kind == "invoice"`, "book").
+		Case(`output.amount > 0`, "archive").
+		Default("review")
+}
+
+func TestSwitch_GeneratesExclusiveConditions(t *testing.T) {
+	t.Parallel()
+
+	wf, err := newSwitchWorkflow().Build()
+	if err != nil {
+		t.Fatalf("build failed: %v", err)
+	}
+
+	want := map[string]string{
+		"book":    `output.kind == "invoice"`,
+		"archive": `(output.amount > 0) && !(output.kind == "invoice")`,
+		"review":  `!(output.kind == "invoice") && !(output.amount > 0)`,
+	}
+	if len(wf.Edges) != len(want) {
+		t.Fatalf("expected %d edges, got %d", len(want), len(wf.Edges))
+	}
+	for _, edge := range wf.Edges {
+		if edge.From != "classify" || edge.Condition != want[edge.To] {
+			t.Errorf("edge %s -> %s: got condition %q, want %q", edge.From, edge.To, edge.Condition, want[edge.To])
+		}
+	}
+
+	_, err = NewWorkflow("Bad").
+		AddNode(NewPassthroughNode("a", "A")).
+		AddNode(NewPassthroughNode("b", "B")).
+		Switch("a").Case("", "b").End().
+		Build()
+	if err == nil {
+		t.Fatal("expected error for an empty case condition")
+	}
+}
+
+func TestSwitch_RoutesToFirstMatchingCase(t *testing.T) {
+	t.Parallel()
+
+	registry := executor.NewManager()
+	registry.Register("transform", &executor.ExecutorFunc{
+		ExecuteFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			return input, nil
+		},
+	})
+	dagExec := engine.NewDAGExecutor(engine.NewNodeExecutor(registry), engine.NewExprConditionEvaluator(), engine.NewNoOpNotifier(), nil)
+
+	tests := map[string]struct {
+		input map[string]any
+		taken string
+	}{
+		"first case":   {map[string]any{"kind": "invoice", "amount": 10}, "book"},
+		"second case":  {map[string]any{"kind": "receipt", "amount": 10}, "archive"},
+		"default case": {map[string]any{"kind": "letter", "amount": 0}, "review"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			wf := newSwitchWorkflow().MustBuild()
+			execState := engine.NewExecutionState("exec-1", wf.ID, wf, tt.input, nil)
+			if err := dagExec.Execute(context.Background(), execState, engine.DefaultExecutionOptions()); err != nil {
+				t.Fatalf("execution failed: %v", err)
+			}
+			for _, target := range []string{"book", "archive", "review"} {
+				status, _ := execState.GetNodeStatus(target)
+				if ran := status == models.NodeExecutionStatusCompleted; ran != (target == tt.taken) {
+					t.Errorf("%s: status %s", target, status)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package builder
+
+import (
+	"fmt"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// TriggerOption is a function that configures a trigger of the workflow.
+type TriggerOption func(*models.Trigger) error
+
+// WithCronTrigger adds a trigger that runs the workflow on a cron schedule,
+// e.g. "0 9 * * 1-5".
+func WithCronTrigger(spec string, opts ...TriggerOption) WorkflowOption {
+	return withTrigger(models.TriggerTypeCron, map[string]any{"schedule": spec}, opts)
+}
+
+// WithWebhookTrigger adds a trigger that runs the workflow on HTTP requests to its
+// webhook. path is an optional path pattern under the webhook URL, such as
+// "/orders/:order_id"; its parameters become workflow input. auth is the secret
+// requests are signed with; empty accepts unsigned requests.
+func WithWebhookTrigger(path, auth string, opts ...TriggerOption) WorkflowOption {
+	config := make(map[string]any)
+	if path != "" {
+		config["path_pattern"] = path
+	}
+	if auth != "" {
+		config["secret"] = auth
+	}
+	return withTrigger(models.TriggerTypeWebhook, config, opts)
+}
+
+// WithManualTrigger adds a trigger for runs started by users. A non-nil schema is the
+// JSON schema of the input they provide, kept as the workflow's input schema.
+func WithManualTrigger(schema map[string]any, opts ...TriggerOption) WorkflowOption {
+	add := withTrigger(models.TriggerTypeManual, map[string]any{}, opts)
+	return func(wb *WorkflowBuilder) error {
+		if err := add(wb); err != nil {
+			return err
+		}
+		if schema != nil {
+			wb.workflow.Metadata[models.MetadataInputSchema] = schema
+		}
+		return nil
+	}
+}
+
+// TriggerName sets the trigger name. It defaults to the workflow name followed by
+// the trigger type.
+func TriggerName(name string) TriggerOption {
+	return func(t *models.Trigger) error {
+		t.Name = name
+		return nil
+	}
+}
+
+// TriggerDescription sets the trigger description.
+func TriggerDescription(desc string) TriggerOption {
+	return func(t *models.Trigger) error {
+		t.Description = desc
+		return nil
+	}
+}
+
+// TriggerDisabled creates the trigger disabled.
+func TriggerDisabled() TriggerOption {
+	return func(t *models.Trigger) error {
+		t.Enabled = false
+		return nil
+	}
+}
+
+// TriggerConfigValue sets a trigger config value, such as "timezone" of a cron
+// trigger or "profile" to run the workflow with a launch profile.
+func TriggerConfigValue(key string, value any) TriggerOption {
+	return func(t *models.Trigger) error {
+		if key == "" {
+			return fmt.Errorf("trigger config key cannot be empty")
+		}
+		t.Config[key] = value
+		return nil
+	}
+}
+
+// withTrigger returns an option adding a trigger of the type to the workflow.
+func withTrigger(triggerType models.TriggerType, config map[string]any, opts []TriggerOption) WorkflowOption {
+	return func(wb *WorkflowBuilder) error {
+		trigger := &models.Trigger{
+			Name:    fmt.Sprintf("%s %s", wb.workflow.Name, triggerType),
+			Type:    triggerType,
+			Config:  config,
+			Enabled: true,
+		}
+		for _, opt := range opts {
+			if err := opt(trigger); err != nil {
+				return fmt.Errorf("%s trigger: %w", triggerType, err)
+			}
+		}
+		if err := trigger.ValidateDefinition(); err != nil {
+			return fmt.Errorf("%s trigger: %w", triggerType, err)
+		}
+		wb.workflow.Triggers = append(wb.workflow.Triggers, trigger)
+		return nil
+	}
+}
@@ -0,0 +1,94 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+func TestWorkflowTriggers(t *testing.T) {
+	t.Parallel()
+
+	schema := map[string]any{"type": "object", "required": []any{"team"}}
+	wf, err := NewWorkflow("Reports",
+		WithCronTrigger("0 9 * * 1-5", TriggerConfigValue("timezone", "Europe/Berlin")),
+		WithWebhookTrigger("/reports/:team", "s3cret", TriggerDescription("Reports on demand")),
+		WithManualTrigger(schema, TriggerName("Run now"), TriggerDisabled()),
+	).AddNode(NewPassthroughNode("start", "Start")).Build()
+	if err != nil {
+		t.Fatalf("build failed: %v", err)
+	}
+
+	if len(wf.Triggers) != 3 {
+		t.Fatalf("expected 3 triggers, got %d", len(wf.Triggers))
+	}
+
+	cron := wf.Triggers[0]
+	if cron.Type != models.TriggerTypeCron || cron.Name != "Reports cron" || !cron.Enabled {
+		t.Errorf("unexpected cron trigger: %+v", cron)
+	}
+	if cron.Config["schedule"] != "0 9 * * 1-5" || cron.Config["timezone"] != "Europe/Berlin" {
+		t.Errorf("unexpected cron config: %v", cron.Config)
+	}
+
+	webhook := wf.Triggers[1]
+	if webhook.Config["path_pattern"] != "/reports/:team" || webhook.Config["secret"] != "s3cret" {
+		t.Errorf("unexpected webhook config: %v", webhook.Config)
+	}
+	if webhook.Description != "Reports on demand" {
+		t.Errorf("expected description to be set, got %q", webhook.Description)
+	}
+
+	manual := wf.Triggers[2]
+	if manual.Type != models.TriggerTypeManual || manual.Name != "Run now" || manual.Enabled {
+		t.Errorf("unexpected manual trigger: %+v", manual)
+	}
+	if _, ok := wf.Metadata[models.MetadataInputSchema].(map[string]any); !ok {
+		t.Errorf("expected the manual trigger schema as input schema, got %v", wf.Metadata)
+	}
+
+	for _, trigger := range wf.Triggers {
+		trigger.WorkflowID = "wf-1"
+		if err := trigger.Validate(); err != nil {
+			t.Errorf("%s trigger is not valid once stored: %v", trigger.Type, err)
+		}
+	}
+}
+
+func TestWorkflowTriggers_WebhookWithoutPathOrSecret(t *testing.T) {
+	t.Parallel()
+
+	wf, err := NewWorkflow("Hooks", WithWebhookTrigger("", "")).
+		AddNode(NewPassthroughNode("start", "Start")).
+		Build()
+	if err != nil {
+		t.Fatalf("build failed: %v", err)
+	}
+	if len(wf.Triggers[0].Config) != 0 {
+		t.Errorf("expected empty webhook config, got %v", wf.Triggers[0].Config)
+	}
+}
+
+func TestWorkflowTriggers_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opt  WorkflowOption
+	}{
+		{"empty schedule", WithCronTrigger("")},
+		{"relative path", WithWebhookTrigger("reports", "")},
+		{"empty name", WithManualTrigger(nil, TriggerName(""))},
+		{"empty config key", WithCronTrigger("@daily", TriggerConfigValue("", "x"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWorkflow("Invalid", tt.opt).
+				AddNode(NewPassthroughNode("start", "Start")).
+				Build()
+			if err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -81,6 +81,14 @@ func ValidateTransformConfig(config map[string]any) error {
 		if _, ok := config["template"]; !ok {
 			return fmt.Errorf("Template transform requires 'template' field")
 		}
+	case "csv_parse", "csv_generate":
+		// All fields are optional
+	case "xml":
+		if config["operation"] == "xpath" {
+			if _, ok := config["xpath"]; !ok {
+				return fmt.Errorf("XML xpath transform requires 'xpath' field")
+			}
+		}
 	default:
 		return fmt.Errorf("invalid transform type: %s", typeStr)
 	}
@@ -173,6 +173,11 @@ func (wb *WorkflowBuilder) Build() (*models.Workflow, error) {
 		return nil, wb.err
 	}
 
+	wb.expandWhileLoops()
+	if wb.err != nil {
+		return nil, wb.err
+	}
+
 	// Build all nodes in insertion order
 	nodes := make([]*models.Node, 0, len(wb.nodes))
 	for _, id := range wb.nodeOrder {
@@ -213,3 +218,22 @@ func (wb *WorkflowBuilder) MustBuild() *models.Workflow {
 	}
 	return wf
 }
+
+// ToYAML builds the workflow and writes its definition as YAML, to keep under version
+// control and load again with models.LoadWorkflowDefinition.
+func (wb *WorkflowBuilder) ToYAML() ([]byte, error) {
+	wf, err := wb.Build()
+	if err != nil {
+		return nil, err
+	}
+	return models.MarshalWorkflowYAML(wf)
+}
+
+// ToJSON builds the workflow and writes its definition as JSON.
+func (wb *WorkflowBuilder) ToJSON() ([]byte, error) {
+	wf, err := wb.Build()
+	if err != nil {
+		return nil, err
+	}
+	return models.MarshalWorkflowJSON(wf)
+}
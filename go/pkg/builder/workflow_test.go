@@ -191,3 +191,48 @@ func TestWorkflowMustBuildPanic(t *testing.T) {
 		Connect("nonexistent1", "nonexistent2").
 		MustBuild()
 }
+
+func TestWorkflowBuilder_ToYAMLAndJSON(t *testing.T) {
+	t.Parallel()
+
+	newBuilder := func() *WorkflowBuilder {
+		return NewWorkflow("Review", WithTags("content"), WithCronTrigger("0 9 * * *")).
+			AddNode(NewNode("review", "llm", "Review")).
+			AddNode(NewNode("publish", "http", "Publish")).
+			Connect("review", "review_again", WithWhile("output.score < 80", 3)).
+			AddNode(NewNode("review_again", "llm", "Review Again")).
+			Connect("review", "publish")
+	}
+
+	for name, export := range map[string]func(*WorkflowBuilder) ([]byte, error){
+		"yaml": (*WorkflowBuilder).ToYAML,
+		"json": (*WorkflowBuilder).ToJSON,
+	} {
+		t.Run(name, func(t *testing.T) {
+			data, err := export(newBuilder())
+			if err != nil {
+				t.Fatalf("export failed: %v", err)
+			}
+			wf, err := models.LoadWorkflowDefinition(data)
+			if err != nil {
+				t.Fatalf("load failed: %v\n%s", err, data)
+			}
+			built := newBuilder().MustBuild()
+			if len(wf.Nodes) != len(built.Nodes) || len(wf.Edges) != len(built.Edges) {
+				t.Fatalf("expected %d nodes and %d edges, got %d and %d", len(built.Nodes), len(built.Edges), len(wf.Nodes), len(wf.Edges))
+			}
+			for i, edge := range built.Edges {
+				if wf.Edges[i].ID != edge.ID || wf.Edges[i].Condition != edge.Condition || wf.Edges[i].IsLoop() != edge.IsLoop() {
+					t.Errorf("edge %d: got %+v, want %+v", i, wf.Edges[i], edge)
+				}
+			}
+			if len(wf.Triggers) != 1 || wf.Triggers[0].Name != "Review cron" || wf.Triggers[0].Config["schedule"] != "0 9 * * *" {
+				t.Errorf("expected the cron trigger to survive, got: %+v", wf.Triggers)
+			}
+		})
+	}
+
+	if _, err := NewWorkflow("Empty").ToYAML(); err == nil {
+		t.Fatal("expected error for a workflow that does not build")
+	}
+}
@@ -0,0 +1,293 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// ParallelismBounds limits the concurrency the adaptive controller may choose for a provider.
+type ParallelismBounds struct {
+	Min int
+	Max int
+}
+
+// AdaptiveParallelismConfig configures an AdaptiveParallelism controller.
+type AdaptiveParallelismConfig struct {
+	// Default bounds of providers without an entry in Providers
+	Default ParallelismBounds
+
+	// Providers overrides the bounds per provider key (see ProviderKey)
+	Providers map[string]ParallelismBounds
+
+	// LatencyTarget lowers the limit by one while the average latency of a provider
+	// is above it (0 = react to rate limiting only)
+	LatencyTarget time.Duration
+
+	// OnChange is called after the limit of a provider changed
+	OnChange func(provider string, limit int, reason string)
+}
+
+// Reasons passed to AdaptiveParallelismConfig.OnChange.
+const (
+	ParallelismReasonRateLimited = "rate_limited"
+	ParallelismReasonSlow        = "latency"
+	ParallelismReasonRecovered   = "recovered"
+)
+
+// latencyEWMAWeight is the weight of the newest sample in the average latency.
+const latencyEWMAWeight = 0.2
+
+// AdaptiveParallelism limits concurrent calls to each external provider and tunes the
+// limit from observed outcomes: a rate-limited response (HTTP 429, quota errors) halves
+// it, latency above the target lowers it by one, and a run of successes raises it by one,
+// always within the provider's bounds. One controller is shared by all executions, as
+// provider quotas are shared too.
+type AdaptiveParallelism struct {
+	cfg AdaptiveParallelismConfig
+
+	mu       sync.Mutex
+	limiters map[string]*providerLimiter
+}
+
+// ProviderParallelism is a snapshot of the adaptive limit of a provider.
+type ProviderParallelism struct {
+	Provider  string        `json:"provider"`
+	Limit     int           `json:"limit"`
+	Min       int           `json:"min"`
+	Max       int           `json:"max"`
+	InFlight  int           `json:"in_flight"`
+	Latency   time.Duration `json:"latency"`
+	Throttled int64         `json:"throttled"`
+}
+
+// providerLimiter is the concurrency limit of one provider.
+type providerLimiter struct {
+	bounds ParallelismBounds
+
+	limit        int
+	inFlight     int
+	successes    int
+	latency      time.Duration
+	throttled    int64
+	lastDecrease time.Time
+	changed      chan struct{}
+}
+
+// NewAdaptiveParallelism creates a controller. Bounds with Max <= 0 default to
+// DefaultMaxParallelism, and Min is clamped to [1, Max].
+func NewAdaptiveParallelism(cfg AdaptiveParallelismConfig) *AdaptiveParallelism {
+	cfg.Default = normalizeBounds(cfg.Default)
+	providers := make(map[string]ParallelismBounds, len(cfg.Providers))
+	for key, bounds := range cfg.Providers {
+		providers[strings.ToLower(key)] = normalizeBounds(bounds)
+	}
+	cfg.Providers = providers
+
+	return &AdaptiveParallelism{
+		cfg:      cfg,
+		limiters: make(map[string]*providerLimiter),
+	}
+}
+
+func normalizeBounds(bounds ParallelismBounds) ParallelismBounds {
+	if bounds.Max <= 0 {
+		bounds.Max = DefaultMaxParallelism
+	}
+	if bounds.Min < 1 {
+		bounds.Min = 1
+	}
+	if bounds.Min > bounds.Max {
+		bounds.Min = bounds.Max
+	}
+	return bounds
+}
+
+// Acquire waits for a free slot of the provider and returns the function that releases it
+// with the outcome of the call. An empty provider is not limited.
+func (ap *AdaptiveParallelism) Acquire(ctx context.Context, provider string) (func(err error), error) {
+	if provider == "" {
+		return func(error) {}, nil
+	}
+
+	l := ap.limiter(provider)
+	for {
+		ap.mu.Lock()
+		if l.inFlight < l.limit {
+			l.inFlight++
+			ap.mu.Unlock()
+			start := time.Now()
+			var once sync.Once
+			return func(err error) {
+				once.Do(func() { ap.release(provider, l, start, err) })
+			}, nil
+		}
+		changed := l.changed
+		ap.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for %s parallelism slot: %w", provider, ctx.Err())
+		case <-changed:
+		}
+	}
+}
+
+// limiter returns the limiter of a provider, creating it at the maximum of its bounds.
+func (ap *AdaptiveParallelism) limiter(provider string) *providerLimiter {
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+
+	if l, ok := ap.limiters[provider]; ok {
+		return l
+	}
+	bounds, ok := ap.cfg.Providers[provider]
+	if !ok {
+		bounds = ap.cfg.Default
+	}
+	l := &providerLimiter{
+		bounds:  bounds,
+		limit:   bounds.Max,
+		changed: make(chan struct{}),
+	}
+	ap.limiters[provider] = l
+	return l
+}
+
+// release frees a slot and adjusts the limit from the outcome of the call started at start.
+func (ap *AdaptiveParallelism) release(provider string, l *providerLimiter, start time.Time, err error) {
+	elapsed := time.Since(start)
+
+	ap.mu.Lock()
+	l.inFlight--
+	previous := l.limit
+	reason := ""
+
+	switch {
+	case IsRateLimitError(err):
+		l.throttled++
+		l.successes = 0
+		// Calls that started before the last decrease saw the old limit; one burst halves once
+		if !start.Before(l.lastDecrease) {
+			l.limit = max(l.bounds.Min, l.limit/2)
+			l.lastDecrease = time.Now()
+			reason = ParallelismReasonRateLimited
+		}
+	case err != nil:
+		// Other failures say nothing about the provider's capacity
+	default:
+		if l.latency == 0 {
+			l.latency = elapsed
+		} else {
+			l.latency = time.Duration(latencyEWMAWeight*float64(elapsed) + (1-latencyEWMAWeight)*float64(l.latency))
+		}
+
+		if ap.cfg.LatencyTarget > 0 && l.latency > ap.cfg.LatencyTarget {
+			l.successes = 0
+			if !start.Before(l.lastDecrease) {
+				l.limit = max(l.bounds.Min, l.limit-1)
+				l.lastDecrease = time.Now()
+				reason = ParallelismReasonSlow
+			}
+			break
+		}
+
+		l.successes++
+		if l.successes >= l.limit && l.limit < l.bounds.Max {
+			l.limit++
+			l.successes = 0
+			reason = ParallelismReasonRecovered
+		}
+	}
+
+	current := l.limit
+	close(l.changed)
+	l.changed = make(chan struct{})
+	ap.mu.Unlock()
+
+	if current != previous && ap.cfg.OnChange != nil {
+		ap.cfg.OnChange(provider, current, reason)
+	}
+}
+
+// Snapshot returns the current limits of the providers seen so far, sorted by provider.
+func (ap *AdaptiveParallelism) Snapshot() []ProviderParallelism {
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+
+	result := make([]ProviderParallelism, 0, len(ap.limiters))
+	for provider, l := range ap.limiters {
+		result = append(result, ProviderParallelism{
+			Provider:  provider,
+			Limit:     l.limit,
+			Min:       l.bounds.Min,
+			Max:       l.bounds.Max,
+			InFlight:  l.inFlight,
+			Latency:   l.latency,
+			Throttled: l.throttled,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Provider < result[j].Provider })
+	return result
+}
+
+// ProviderKey returns the external provider a node calls, used to share its adaptive
+// limit: the provider of llm nodes (e.g. "openai") and the host of http nodes
+// (e.g. "api.example.com"). Other nodes return "" and are not limited.
+func ProviderKey(node *models.Node, config map[string]any) string {
+	switch node.Type {
+	case "llm":
+		provider, _ := config["provider"].(string)
+		return strings.ToLower(provider)
+	case "http":
+		rawURL, _ := config["url"].(string)
+		parsed, err := url.Parse(rawURL)
+		if err != nil {
+			return ""
+		}
+		return strings.ToLower(parsed.Hostname())
+	default:
+		return ""
+	}
+}
+
+// rateLimitMarkers identify provider errors caused by rate limits or exhausted quotas.
+var rateLimitMarkers = []string{
+	"http 429",
+	"status 429",
+	"too many requests",
+	"rate limit",
+	"rate_limit",
+	"ratelimit",
+	"resource_exhausted",
+	"throttlingexception",
+	"insufficient_quota",
+}
+
+// IsRateLimitError reports whether err says the provider rejected the call for exceeding
+// its rate limit or quota.
+func IsRateLimitError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var llmErr *models.LLMError
+	if errors.As(err, &llmErr) && (llmErr.Code == "429" || llmErr.Code == "rate_limit_exceeded") {
+		return true
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, marker := range rateLimitMarkers {
+		if strings.Contains(message, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,281 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+func providerLimit(t *testing.T, ap *AdaptiveParallelism, provider string) int {
+	t.Helper()
+	for _, p := range ap.Snapshot() {
+		if p.Provider == provider {
+			return p.Limit
+		}
+	}
+	t.Fatalf("provider %s not in snapshot", provider)
+	return 0
+}
+
+func TestAdaptiveParallelism_RateLimitHalvesLimit(t *testing.T) {
+	t.Parallel()
+
+	var changes []string
+	ap := NewAdaptiveParallelism(AdaptiveParallelismConfig{
+		Default: ParallelismBounds{Min: 2, Max: 8},
+		OnChange: func(provider string, limit int, reason string) {
+			changes = append(changes, fmt.Sprintf("%s=%d:%s", provider, limit, reason))
+		},
+	})
+
+	release, err := ap.Acquire(context.Background(), "openai")
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	release(errors.New("HTTP 429: Too Many Requests"))
+	if got := providerLimit(t, ap, "openai"); got != 4 {
+		t.Errorf("expected limit 4 after rate limit, got %d", got)
+	}
+
+	// Further rate limits halve down to the lower bound only
+	for i := 0; i < 3; i++ {
+		release, _ := ap.Acquire(context.Background(), "openai")
+		release(&models.LLMError{Provider: models.LLMProviderOpenAI, Code: "rate_limit_exceeded", Message: "slow down"})
+	}
+	if got := providerLimit(t, ap, "openai"); got != 2 {
+		t.Errorf("expected limit clamped to 2, got %d", got)
+	}
+	if len(changes) != 2 || changes[0] != "openai=4:rate_limited" || changes[1] != "openai=2:rate_limited" {
+		t.Errorf("unexpected changes: %v", changes)
+	}
+}
+
+func TestAdaptiveParallelism_BurstHalvesOnce(t *testing.T) {
+	t.Parallel()
+
+	ap := NewAdaptiveParallelism(AdaptiveParallelismConfig{Default: ParallelismBounds{Min: 1, Max: 8}})
+
+	// Four calls started together all hit the rate limit; they saw the same limit
+	releases := make([]func(error), 4)
+	for i := range releases {
+		release, err := ap.Acquire(context.Background(), "gemini")
+		if err != nil {
+			t.Fatalf("acquire: %v", err)
+		}
+		releases[i] = release
+	}
+	time.Sleep(time.Millisecond)
+	for _, release := range releases {
+		release(errors.New("RESOURCE_EXHAUSTED: quota exceeded"))
+	}
+
+	if got := providerLimit(t, ap, "gemini"); got != 4 {
+		t.Errorf("expected one halving to 4, got %d", got)
+	}
+}
+
+func TestAdaptiveParallelism_SuccessesRaiseLimit(t *testing.T) {
+	t.Parallel()
+
+	ap := NewAdaptiveParallelism(AdaptiveParallelismConfig{Default: ParallelismBounds{Min: 1, Max: 3}})
+
+	release, _ := ap.Acquire(context.Background(), "api.example.com")
+	release(errors.New("HTTP 429: slow down"))
+	if got := providerLimit(t, ap, "api.example.com"); got != 1 {
+		t.Fatalf("expected limit 1, got %d", got)
+	}
+
+	// A run of successes as long as the limit raises it by one, up to the upper bound
+	for i := 0; i < 10; i++ {
+		release, _ := ap.Acquire(context.Background(), "api.example.com")
+		release(nil)
+	}
+	if got := providerLimit(t, ap, "api.example.com"); got != 3 {
+		t.Errorf("expected limit raised to 3, got %d", got)
+	}
+
+	// Other failures do not change the limit
+	release, _ = ap.Acquire(context.Background(), "api.example.com")
+	release(errors.New("HTTP 500: internal error"))
+	if got := providerLimit(t, ap, "api.example.com"); got != 3 {
+		t.Errorf("expected limit 3 after non rate limit error, got %d", got)
+	}
+}
+
+func TestAdaptiveParallelism_LatencyAboveTargetLowersLimit(t *testing.T) {
+	t.Parallel()
+
+	ap := NewAdaptiveParallelism(AdaptiveParallelismConfig{
+		Default:       ParallelismBounds{Min: 1, Max: 4},
+		LatencyTarget: time.Millisecond,
+	})
+
+	release, _ := ap.Acquire(context.Background(), "anthropic")
+	time.Sleep(5 * time.Millisecond)
+	release(nil)
+
+	if got := providerLimit(t, ap, "anthropic"); got != 3 {
+		t.Errorf("expected limit lowered to 3, got %d", got)
+	}
+}
+
+func TestAdaptiveParallelism_ProviderBoundsAndBlocking(t *testing.T) {
+	t.Parallel()
+
+	ap := NewAdaptiveParallelism(AdaptiveParallelismConfig{
+		Default:   ParallelismBounds{Min: 1, Max: 10},
+		Providers: map[string]ParallelismBounds{"OpenAI": {Min: 1, Max: 2}},
+	})
+
+	first, _ := ap.Acquire(context.Background(), "openai")
+	_, _ = ap.Acquire(context.Background(), "openai")
+
+	// The third call waits for a free slot
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := ap.Acquire(ctx, "openai"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected acquire to wait until the deadline, got %v", err)
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		release, err := ap.Acquire(context.Background(), "openai")
+		if err == nil {
+			release(nil)
+		}
+		close(acquired)
+	}()
+	first(nil)
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("waiting call did not get the released slot")
+	}
+
+	// Unknown providers use the default bounds; an empty provider is not limited
+	if _, err := ap.Acquire(context.Background(), "mistral"); err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	if got := providerLimit(t, ap, "mistral"); got != 10 {
+		t.Errorf("expected default limit 10, got %d", got)
+	}
+	release, err := ap.Acquire(context.Background(), "")
+	if err != nil {
+		t.Fatalf("acquire without provider: %v", err)
+	}
+	release(nil)
+}
+
+func TestProviderKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		node   *models.Node
+		config map[string]any
+		want   string
+	}{
+		{&models.Node{Type: "llm"}, map[string]any{"provider": "OpenAI"}, "openai"},
+		{&models.Node{Type: "http"}, map[string]any{"url": "https://API.example.com:8443/v1/items"}, "api.example.com"},
+		{&models.Node{Type: "http"}, map[string]any{}, ""},
+		{&models.Node{Type: "transform"}, map[string]any{"provider": "openai"}, ""},
+	}
+	for _, tt := range tests {
+		if got := ProviderKey(tt.node, tt.config); got != tt.want {
+			t.Errorf("ProviderKey(%s, %v) = %q, want %q", tt.node.Type, tt.config, got, tt.want)
+		}
+	}
+}
+
+func TestIsRateLimitError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("HTTP 429: {\"error\":\"slow down\"}"), true},
+		{fmt.Errorf("node execution failed: %w", &models.LLMError{Code: "429", Message: "busy"}), true},
+		{errors.New("Anthropic API error: rate_limit_error"), true},
+		{errors.New("bedrock: ThrottlingException: Too many tokens"), true},
+		{errors.New("HTTP 500: internal error"), false},
+		{&models.LLMError{Code: "invalid_api_key", Message: "Incorrect API key"}, false},
+	}
+	for _, tt := range tests {
+		if got := IsRateLimitError(tt.err); got != tt.want {
+			t.Errorf("IsRateLimitError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestNodeExecutor_AdaptiveParallelismLimitsProvider(t *testing.T) {
+	t.Parallel()
+
+	var running, peak atomic.Int32
+	mockExec := &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			current := running.Add(1)
+			defer running.Add(-1)
+			for {
+				old := peak.Load()
+				if current <= old || peak.CompareAndSwap(old, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return map[string]any{"ok": true}, nil
+		},
+	}
+	registry := executor.NewManager()
+	if err := registry.Register("llm", mockExec); err != nil {
+		t.Fatalf("failed to register executor: %v", err)
+	}
+
+	nodeExec := NewNodeExecutor(registry)
+	nodeExec.SetAdaptiveParallelism(NewAdaptiveParallelism(AdaptiveParallelismConfig{
+		Default: ParallelismBounds{Min: 1, Max: 2},
+	}))
+
+	var wg sync.WaitGroup
+	results := make([]*NodeExecutionResult, 6)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result, err := nodeExec.Execute(context.Background(), &NodeContext{
+				ExecutionID: "exec-1",
+				NodeID:      fmt.Sprintf("node-%d", i),
+				Node: &models.Node{
+					ID:     fmt.Sprintf("node-%d", i),
+					Type:   "llm",
+					Config: map[string]any{"provider": "openai"},
+				},
+			})
+			if err != nil {
+				t.Errorf("execute: %v", err)
+			}
+			results[i] = result
+		}(i)
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got != 2 {
+		t.Errorf("expected at most 2 concurrent openai calls, peak was %d", got)
+	}
+	throttled := 0
+	for _, result := range results {
+		if result != nil && result.Annotations["throttled_ms"] != nil {
+			throttled++
+		}
+	}
+	if throttled == 0 {
+		t.Error("expected waiting nodes to be annotated with throttled_ms")
+	}
+}
@@ -0,0 +1,96 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// AssertionFailure records a node output assertion that did not hold.
+type AssertionFailure struct {
+	Name       string                 `json:"name"`
+	Expression string                 `json:"expression"`
+	Action     models.AssertionAction `json:"action"`
+	Message    string                 `json:"message"`
+}
+
+// EvaluateAssertions checks assertions against a node output and returns the failed ones.
+// An expression that cannot be evaluated counts as a failure.
+func EvaluateAssertions(evaluator ConditionEvaluator, assertions []*models.NodeAssertion, output any) []*AssertionFailure {
+	var failures []*AssertionFailure
+
+	for _, assertion := range assertions {
+		passed, err := evaluator.Evaluate(assertion.Expression, output)
+		if err == nil && passed {
+			continue
+		}
+
+		message := assertion.Message
+		if err != nil {
+			message = fmt.Sprintf("assertion error: %v", err)
+		} else if message == "" {
+			message = fmt.Sprintf("expression '%s' is false", assertion.Expression)
+		}
+
+		failures = append(failures, &AssertionFailure{
+			Name:       assertion.Name,
+			Expression: assertion.Expression,
+			Action:     assertion.Action,
+			Message:    message,
+		})
+	}
+
+	return failures
+}
+
+// checkAssertions evaluates the node's output assertions, records failures and emits
+// an event per failure. Returns an error if an assertion with the fail action failed.
+func (de *DAGExecutor) checkAssertions(
+	ctx context.Context,
+	execState *ExecutionState,
+	node *models.Node,
+	output any,
+) error {
+	assertions, err := models.ParseNodeAssertions(node.Config)
+	if err != nil {
+		return fmt.Errorf("invalid assertions: %w", err)
+	}
+	if len(assertions) == 0 {
+		return nil
+	}
+
+	failures := EvaluateAssertions(de.conditionEvaluator, assertions, output)
+	if len(failures) == 0 {
+		return nil
+	}
+
+	execState.SetNodeAssertionFailures(node.ID, failures)
+
+	var failErr error
+	for _, failure := range failures {
+		de.safeNotify(ctx, ExecutionEvent{
+			Type:        EventTypeNodeAssertionFailed,
+			ExecutionID: execState.ExecutionID,
+			WorkflowID:  execState.WorkflowID,
+			Timestamp:   time.Now(),
+			Status:      string(failure.Action),
+			NodeID:      node.ID,
+			NodeName:    node.Name,
+			NodeType:    node.Type,
+			Message:     failure.Message,
+			Metadata: map[string]any{
+				"assertion":  failure.Name,
+				"expression": failure.Expression,
+				"action":     string(failure.Action),
+			},
+		})
+
+		if failure.Action == models.AssertionActionFail && failErr == nil {
+			failErr = fmt.Errorf("assertion '%s' failed: %s", failure.Name, failure.Message)
+		}
+	}
+
+	return failErr
+}
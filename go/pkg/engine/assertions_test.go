@@ -0,0 +1,196 @@
+package engine
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// newAssertionTestExecutor returns a DAG executor whose "test" nodes output {"items": []}.
+func newAssertionTestExecutor(notifier ExecutionNotifier) *DAGExecutor {
+	mockExec := &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			return map[string]any{"items": []any{}}, nil
+		},
+	}
+
+	registry := executor.NewManager()
+	registry.Register("test", mockExec)
+
+	return NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), notifier, NewNilWorkflowLoader())
+}
+
+func TestEvaluateAssertions(t *testing.T) {
+	t.Parallel()
+
+	assertions := []*models.NodeAssertion{
+		{Name: "has_items", Expression: "len(output.items) > 0", Action: models.AssertionActionWarn},
+		{Name: "has_status", Expression: "output.status == 'ok'", Action: models.AssertionActionFail, Message: "bad status"},
+		{Name: "broken", Expression: "output.items +", Action: models.AssertionActionWarn},
+	}
+
+	output := map[string]any{"items": []any{1}, "status": "error"}
+	failures := EvaluateAssertions(NewExprConditionEvaluator(), assertions, output)
+
+	if len(failures) != 2 {
+		t.Fatalf("expected 2 failures, got %d", len(failures))
+	}
+	if failures[0].Name != "has_status" || failures[0].Message != "bad status" {
+		t.Errorf("unexpected first failure: %+v", failures[0])
+	}
+	if failures[1].Name != "broken" || !strings.Contains(failures[1].Message, "assertion error") {
+		t.Errorf("unexpected second failure: %+v", failures[1])
+	}
+}
+
+func TestDAGExecutor_Assertion_Warn(t *testing.T) {
+	t.Parallel()
+
+	notifier := &recordingNotifier{}
+	dagExec := newAssertionTestExecutor(notifier)
+
+	workflow := &models.Workflow{
+		ID:   "wf-1",
+		Name: "Assertion Warn",
+		Nodes: []*models.Node{
+			{ID: "fetch", Name: "Fetch", Type: "test", Config: map[string]any{
+				"assertions": []any{"len(output.items) > 0"},
+			}},
+			{ID: "next", Name: "Next", Type: "test"},
+		},
+		Edges: []*models.Edge{
+			{ID: "e1", From: "fetch", To: "next"},
+		},
+	}
+
+	execState := NewExecutionState("exec-1", "wf-1", workflow, map[string]any{}, map[string]any{})
+	if err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions()); err != nil {
+		t.Fatalf("DAG execution failed: %v", err)
+	}
+
+	if status, _ := execState.GetNodeStatus("next"); status != models.NodeExecutionStatusCompleted {
+		t.Errorf("expected next to be completed, got %v", status)
+	}
+
+	failures, ok := execState.GetNodeAssertionFailures("fetch")
+	if !ok || len(failures) != 1 || failures[0].Action != models.AssertionActionWarn {
+		t.Fatalf("expected one warn failure, got %+v", failures)
+	}
+
+	found := false
+	for _, event := range notifier.events {
+		if event.Type == EventTypeNodeAssertionFailed && event.NodeID == "fetch" {
+			found = true
+			if event.Metadata["expression"] != "len(output.items) > 0" {
+				t.Errorf("unexpected event metadata: %v", event.Metadata)
+			}
+		}
+	}
+	if !found {
+		t.Error("expected node.assertion_failed event")
+	}
+}
+
+func TestDAGExecutor_Assertion_Fail(t *testing.T) {
+	t.Parallel()
+
+	dagExec := newAssertionTestExecutor(NewNoOpNotifier())
+
+	workflow := &models.Workflow{
+		ID:   "wf-1",
+		Name: "Assertion Fail",
+		Nodes: []*models.Node{
+			{ID: "fetch", Name: "Fetch", Type: "test", Config: map[string]any{
+				"assertions": []any{
+					map[string]any{"name": "non_empty", "expression": "len(output.items) > 0", "action": "fail"},
+				},
+			}},
+			{ID: "next", Name: "Next", Type: "test"},
+		},
+		Edges: []*models.Edge{
+			{ID: "e1", From: "fetch", To: "next"},
+		},
+	}
+
+	execState := NewExecutionState("exec-1", "wf-1", workflow, map[string]any{}, map[string]any{})
+	err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions())
+	if err == nil || !strings.Contains(err.Error(), "assertion 'non_empty' failed") {
+		t.Fatalf("expected assertion error, got %v", err)
+	}
+
+	if status, _ := execState.GetNodeStatus("fetch"); status != models.NodeExecutionStatusFailed {
+		t.Errorf("expected fetch to be failed, got %v", status)
+	}
+	if _, ok := execState.GetNodeOutput("fetch"); !ok {
+		t.Error("expected fetch output to be kept for inspection")
+	}
+	if _, ok := execState.GetNodeStatus("next"); ok {
+		t.Error("expected next not to run")
+	}
+}
+
+func TestDAGExecutor_Assertion_Route(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		expression   string
+		wantNext     models.NodeExecutionStatus
+		wantOnFailed models.NodeExecutionStatus
+	}{
+		{
+			name:         "failed assertion follows error edge",
+			expression:   "len(output.items) > 0",
+			wantNext:     models.NodeExecutionStatusSkipped,
+			wantOnFailed: models.NodeExecutionStatusCompleted,
+		},
+		{
+			name:         "passed assertion follows regular edges",
+			expression:   "len(output.items) == 0",
+			wantNext:     models.NodeExecutionStatusCompleted,
+			wantOnFailed: models.NodeExecutionStatusSkipped,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dagExec := newAssertionTestExecutor(NewNoOpNotifier())
+
+			workflow := &models.Workflow{
+				ID:   "wf-1",
+				Name: "Assertion Route",
+				Nodes: []*models.Node{
+					{ID: "fetch", Name: "Fetch", Type: "test", Config: map[string]any{
+						"assertions": []any{
+							map[string]any{"expression": tt.expression, "action": "route"},
+						},
+					}},
+					{ID: "next", Name: "Next", Type: "test"},
+					{ID: "on-failed", Name: "On Failed", Type: "test"},
+				},
+				Edges: []*models.Edge{
+					{ID: "e1", From: "fetch", To: "next"},
+					{ID: "e2", From: "fetch", To: "on-failed", SourceHandle: SourceHandleError},
+				},
+			}
+
+			execState := NewExecutionState("exec-1", "wf-1", workflow, map[string]any{}, map[string]any{})
+			if err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions()); err != nil {
+				t.Fatalf("DAG execution failed: %v", err)
+			}
+
+			if status, _ := execState.GetNodeStatus("fetch"); status != models.NodeExecutionStatusCompleted {
+				t.Errorf("expected fetch to be completed, got %v", status)
+			}
+			if status, _ := execState.GetNodeStatus("next"); status != tt.wantNext {
+				t.Errorf("expected next to be %v, got %v", tt.wantNext, status)
+			}
+			if status, _ := execState.GetNodeStatus("on-failed"); status != tt.wantOnFailed {
+				t.Errorf("expected on-failed to be %v, got %v", tt.wantOnFailed, status)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package engine
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// ValidateBreakpoints checks that every breakpoint names a node of the workflow.
+func ValidateBreakpoints(workflow *models.Workflow, breakpoints []string) error {
+	for _, nodeID := range breakpoints {
+		if _, err := workflow.GetNode(nodeID); err != nil {
+			return &models.ValidationError{Field: "breakpoints", Message: fmt.Sprintf("node %q does not exist", nodeID)}
+		}
+	}
+	return nil
+}
+
+// ReleaseBreakpoint lets a node the execution stopped at run once it resumes. A non-nil
+// input replaces the input the node gets from its parents, including for its templates.
+func (es *ExecutionState) ReleaseBreakpoint(nodeID string, input map[string]any) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	es.releasedBreakpoints[nodeID] = true
+	if input != nil {
+		es.breakpointInputs[nodeID] = input
+	}
+}
+
+// BreakpointsHit returns the IDs of the nodes the execution stopped at, sorted.
+func (es *ExecutionState) BreakpointsHit() []string {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+	hit := make([]string, 0, len(es.breakpointsHit))
+	for nodeID := range es.breakpointsHit {
+		hit = append(hit, nodeID)
+	}
+	sort.Strings(hit)
+	return hit
+}
+
+// breakpointInput returns the input given to a released breakpoint node.
+func (es *ExecutionState) breakpointInput(nodeID string) (map[string]any, bool) {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+	input, ok := es.breakpointInputs[nodeID]
+	return input, ok
+}
+
+// clearBreakpointInput drops the input given to a released breakpoint node once it is used.
+func (es *ExecutionState) clearBreakpointInput(nodeID string) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	delete(es.breakpointInputs, nodeID)
+}
+
+// stopAtBreakpoint pauses the execution before a breakpoint node runs and reports whether it
+// did. The node stays pending with the input it would run with, so that it can be inspected
+// while the execution is paused. A released node passes its breakpoint once: a loop coming
+// back to it stops again.
+func (de *DAGExecutor) stopAtBreakpoint(execState *ExecutionState, node *models.Node, opts *ExecutionOptions) bool {
+	if !slices.Contains(opts.Breakpoints, node.ID) {
+		return false
+	}
+
+	execState.mu.Lock()
+	released := execState.releasedBreakpoints[node.ID]
+	delete(execState.releasedBreakpoints, node.ID)
+	execState.mu.Unlock()
+	if released {
+		return false
+	}
+
+	input := nodeInput(execState, node, GetRegularParentNodes(execState.Workflow, node))
+	execState.SetNodeInput(node.ID, input)
+	execState.SetNodeStatus(node.ID, models.NodeExecutionStatusPending)
+
+	execState.mu.Lock()
+	execState.breakpointsHit[node.ID] = true
+	execState.mu.Unlock()
+
+	execState.Pause("breakpoint")
+	execState.interrupted.Add(1)
+	return true
+}
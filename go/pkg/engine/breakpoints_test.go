@@ -0,0 +1,141 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// newBreakpointTestExecutor runs "prompt" nodes, which output their resolved "text" config,
+// and records the inputs they ran with.
+func newBreakpointTestExecutor() (*DAGExecutor, map[string]any) {
+	var mu sync.Mutex
+	inputs := make(map[string]any)
+
+	registry := executor.NewManager()
+	registry.Register("prompt", &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			mu.Lock()
+			inputs[config["name"].(string)] = input
+			mu.Unlock()
+			return map[string]any{"text": config["text"]}, nil
+		},
+	})
+
+	dagExec := NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), &recordingNotifier{}, NewNilWorkflowLoader())
+	return dagExec, inputs
+}
+
+// promptWorkflow drafts a prompt, sends it to a model and formats the answer.
+func promptWorkflow() *models.Workflow {
+	return &models.Workflow{
+		ID: "wf-1",
+		Nodes: []*models.Node{
+			{ID: "draft", Name: "draft", Type: "prompt", Config: map[string]any{"name": "draft", "text": "Summarize {{input.topic}}"}},
+			{ID: "ask", Name: "ask", Type: "prompt", Config: map[string]any{"name": "ask", "text": "Q: {{input.text}}"}},
+			{ID: "format", Name: "format", Type: "prompt", Config: map[string]any{"name": "format", "text": "A: {{input.text}}"}},
+		},
+		Edges: []*models.Edge{
+			{ID: "e1", From: "draft", To: "ask"},
+			{ID: "e2", From: "ask", To: "format"},
+		},
+	}
+}
+
+// resumedState copies the statuses and outputs of a paused execution, as a checkpoint does.
+func resumedState(paused *ExecutionState) *ExecutionState {
+	execState := NewExecutionState(paused.ExecutionID, paused.WorkflowID, paused.Workflow, paused.Input, nil)
+	for _, node := range paused.Workflow.Nodes {
+		if status, ok := paused.GetNodeStatus(node.ID); ok {
+			execState.SetNodeStatus(node.ID, status)
+		}
+		if output, ok := paused.GetNodeOutput(node.ID); ok {
+			execState.SetNodeOutput(node.ID, output)
+		}
+	}
+	return execState
+}
+
+func TestBreakpoints_ShouldPauseBeforeNode(t *testing.T) {
+	t.Parallel()
+
+	dagExec, inputs := newBreakpointTestExecutor()
+	execState := NewExecutionState("exec-1", "wf-1", promptWorkflow(), map[string]any{"topic": "tides"}, nil)
+	opts := DefaultExecutionOptions()
+	opts.Breakpoints = []string{"ask"}
+
+	err := dagExec.Execute(context.Background(), execState, opts)
+	if !errors.Is(err, models.ErrExecutionPaused) {
+		t.Fatalf("expected ErrExecutionPaused, got %v", err)
+	}
+	if hit := execState.BreakpointsHit(); fmt.Sprint(hit) != "[ask]" {
+		t.Errorf("expected to stop at ask, got %v", hit)
+	}
+	if status, _ := execState.GetNodeStatus("ask"); status != models.NodeExecutionStatusPending {
+		t.Errorf("expected ask to stay pending, got %s", status)
+	}
+	if _, ran := inputs["ask"]; ran {
+		t.Error("expected ask not to run")
+	}
+	input, _ := execState.GetNodeInput("ask")
+	if text := input.(map[string]any)["text"]; text != "Summarize tides" {
+		t.Errorf("expected the input of ask to be recorded, got %v", text)
+	}
+}
+
+func TestBreakpoints_ShouldContinueWithReplacedInput(t *testing.T) {
+	t.Parallel()
+
+	dagExec, inputs := newBreakpointTestExecutor()
+	opts := DefaultExecutionOptions()
+	opts.Breakpoints = []string{"ask", "format"}
+
+	paused := NewExecutionState("exec-1", "wf-1", promptWorkflow(), map[string]any{"topic": "tides"}, nil)
+	if err := dagExec.Execute(context.Background(), paused, opts); !errors.Is(err, models.ErrExecutionPaused) {
+		t.Fatalf("expected ErrExecutionPaused, got %v", err)
+	}
+
+	// The replaced input feeds the templates of the released node
+	execState := resumedState(paused)
+	execState.ReleaseBreakpoint("ask", map[string]any{"text": "Summarize tides in one line"})
+	if err := dagExec.Execute(context.Background(), execState, opts); !errors.Is(err, models.ErrExecutionPaused) {
+		t.Fatalf("expected to stop at the next breakpoint, got %v", err)
+	}
+	output, _ := execState.GetNodeOutput("ask")
+	if text := output.(map[string]any)["text"]; text != "Q: Summarize tides in one line" {
+		t.Errorf("expected ask to run with the replaced input, got %v", text)
+	}
+	if hit := execState.BreakpointsHit(); fmt.Sprint(hit) != "[format]" {
+		t.Errorf("expected to stop at format, got %v", hit)
+	}
+
+	execState = resumedState(execState)
+	execState.ReleaseBreakpoint("format", nil)
+	if err := dagExec.Execute(context.Background(), execState, opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	output, _ = execState.GetNodeOutput("format")
+	if text := output.(map[string]any)["text"]; text != "A: Q: Summarize tides in one line" {
+		t.Errorf("unexpected output of format: %v", text)
+	}
+	if len(inputs) != 3 {
+		t.Errorf("expected every node to run once, got %v", inputs)
+	}
+}
+
+func TestValidateBreakpoints(t *testing.T) {
+	t.Parallel()
+
+	if err := ValidateBreakpoints(promptWorkflow(), []string{"ask"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	var validationErr *models.ValidationError
+	if err := ValidateBreakpoints(promptWorkflow(), []string{"missing"}); !errors.As(err, &validationErr) {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+}
@@ -0,0 +1,170 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// Cancel aborts the execution: the context of its running nodes, and of their HTTP and LLM
+// calls, is cancelled, the nodes are marked cancelled with what they produced so far, no
+// other node starts, and Execute returns ErrExecutionCancelled. Unlike Pause, the execution
+// cannot be resumed. The reason says who cancelled it.
+func (es *ExecutionState) Cancel(reason string) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	if es.cancelled.Load() {
+		return
+	}
+	es.cancelReason = reason
+	es.cancelled.Store(true)
+	if es.abort != nil {
+		es.abort(es.cancelErrLocked())
+	}
+}
+
+// CancelRequested reports whether Cancel was called.
+func (es *ExecutionState) CancelRequested() bool {
+	return es.cancelled.Load()
+}
+
+// CancelReason returns the reason given to Cancel.
+func (es *ExecutionState) CancelReason() string {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+	return es.cancelReason
+}
+
+// cancelErrLocked returns the error of an execution stopped by Cancel. The caller holds es.mu.
+func (es *ExecutionState) cancelErrLocked() error {
+	if es.cancelReason == "" {
+		return models.ErrExecutionCancelled
+	}
+	return fmt.Errorf("%w by %s", models.ErrExecutionCancelled, es.cancelReason)
+}
+
+// cancellable returns a context that Cancel aborts, already aborted if Cancel was called
+// before the execution started, and the function to call once Execute returns.
+func (es *ExecutionState) cancellable(ctx context.Context) (context.Context, func()) {
+	ctx, abort := context.WithCancelCause(ctx)
+
+	es.mu.Lock()
+	es.abort = abort
+	if es.cancelled.Load() {
+		abort(es.cancelErrLocked())
+	}
+	es.mu.Unlock()
+
+	return ctx, func() {
+		es.mu.Lock()
+		es.abort = nil
+		es.mu.Unlock()
+		abort(nil)
+	}
+}
+
+// cancelledErr makes the error that ended an execution stopped by Cancel say so.
+func (es *ExecutionState) cancelledErr(err error) error {
+	if !es.cancelled.Load() || errors.Is(err, models.ErrExecutionCancelled) {
+		return err
+	}
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+	return fmt.Errorf("%w: %w", es.cancelErrLocked(), err)
+}
+
+// executionCancelled reports whether the execution context was cancelled, as opposed to
+// running out of time.
+func executionCancelled(ctx context.Context) bool {
+	return errors.Is(ctx.Err(), context.Canceled)
+}
+
+// streamedOutput collects the text a node streamed, to keep it if the node is cancelled.
+type streamedOutput struct {
+	mu   sync.Mutex
+	text strings.Builder
+}
+
+// wrap returns onDelta extended to record each delta; nil stays nil, as the node does not stream.
+func (s *streamedOutput) wrap(onDelta func(delta string)) func(delta string) {
+	if onDelta == nil {
+		return nil
+	}
+	return func(delta string) {
+		s.mu.Lock()
+		s.text.WriteString(delta)
+		s.mu.Unlock()
+		onDelta(delta)
+	}
+}
+
+func (s *streamedOutput) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.text.String()
+}
+
+// cancelNode marks a node aborted by the cancellation of its execution as cancelled. Its
+// output records what it produced before it stopped under "partial_output": the output the
+// executor returned with its error, or else the text it streamed.
+func (de *DAGExecutor) cancelNode(
+	ctx context.Context,
+	execState *ExecutionState,
+	node *models.Node,
+	execResult *NodeExecutionResult,
+	streamed string,
+	cause error,
+	nodeStartTime time.Time,
+) error {
+	err := fmt.Errorf("%w: node %s aborted: %v", models.ErrExecutionCancelled, node.ID, cause)
+
+	var partial any
+	if execResult != nil && execResult.Output != nil {
+		partial = execResult.Output
+	} else if streamed != "" {
+		partial = streamed
+	}
+
+	output := map[string]any{
+		"cancelled": true,
+		"error":     err.Error(),
+	}
+	if partial != nil {
+		output["partial_output"] = partial
+	}
+
+	execState.SetNodeError(node.ID, err)
+	execState.SetNodeStatus(node.ID, models.NodeExecutionStatusCancelled)
+	execState.SetNodeEndTime(node.ID, time.Now())
+	execState.SetNodeOutput(node.ID, output)
+	if execResult != nil {
+		execState.SetNodeInput(node.ID, execResult.Input)
+		execState.SetNodeConfig(node.ID, execResult.Config)
+		execState.SetNodeResolvedConfig(node.ID, execResult.ResolvedConfig)
+	}
+
+	event := ExecutionEvent{
+		Type:        EventTypeNodeCancelled,
+		ExecutionID: execState.ExecutionID,
+		WorkflowID:  execState.WorkflowID,
+		Timestamp:   time.Now(),
+		Status:      string(models.NodeExecutionStatusCancelled),
+		NodeID:      node.ID,
+		NodeName:    node.Name,
+		NodeType:    node.Type,
+		Error:       err,
+		DurationMs:  time.Since(nodeStartTime).Milliseconds(),
+	}
+	// Redacted outputs stay out of events, as with completed nodes
+	if partial != nil && !isRedacted(node) {
+		event.Metadata = map[string]any{"partial_output": partial}
+	}
+	de.safeNotify(ctx, event)
+
+	return err
+}
@@ -0,0 +1,133 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// newCancellationTestExecutor runs "llm" nodes, which stream two chunks and then wait for
+// their context, and "http" nodes, which return a partial body with the context error.
+// started receives the ID of every node once it is waiting.
+func newCancellationTestExecutor(started chan<- string) (*DAGExecutor, *recordingNotifier) {
+	registry := executor.NewManager()
+	registry.Register("llm", &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			if onDelta := executor.OutputDeltaFunc(ctx); onDelta != nil {
+				onDelta("The tide ")
+				onDelta("turns")
+			}
+			started <- config["id"].(string)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	})
+	registry.Register("http", &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			started <- config["id"].(string)
+			<-ctx.Done()
+			return map[string]any{"body": "partial"}, ctx.Err()
+		},
+	})
+
+	notifier := &recordingNotifier{}
+	return NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), notifier, NewNilWorkflowLoader()), notifier
+}
+
+func TestCancel_AbortsRunningNodesWithPartialOutputs(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan string, 2)
+	dagExec, notifier := newCancellationTestExecutor(started)
+	workflow := &models.Workflow{
+		ID: "wf-1",
+		Nodes: []*models.Node{
+			{ID: "ask", Name: "ask", Type: "llm", Config: map[string]any{"id": "ask"}},
+			{ID: "fetch", Name: "fetch", Type: "http", Config: map[string]any{"id": "fetch"}},
+			{ID: "store", Name: "store", Type: "http", Config: map[string]any{"id": "store"}},
+		},
+		Edges: []*models.Edge{{ID: "e1", From: "fetch", To: "store"}},
+	}
+	execState := NewExecutionState("exec-1", "wf-1", workflow, map[string]any{}, nil)
+
+	done := make(chan error, 1)
+	go func() { done <- dagExec.Execute(context.Background(), execState, DefaultExecutionOptions()) }()
+	for range 2 {
+		select {
+		case <-started:
+		case <-time.After(time.Second):
+			t.Fatal("nodes did not start")
+		}
+	}
+	execState.Cancel("user admin-1")
+
+	var err error
+	select {
+	case err = <-done:
+	case <-time.After(time.Second):
+		t.Fatal("execution did not stop after cancel")
+	}
+	if !errors.Is(err, models.ErrExecutionCancelled) {
+		t.Fatalf("expected ErrExecutionCancelled, got %v", err)
+	}
+
+	for _, id := range []string{"ask", "fetch"} {
+		if status, _ := execState.GetNodeStatus(id); status != models.NodeExecutionStatusCancelled {
+			t.Errorf("expected %s to be cancelled, got %s", id, status)
+		}
+	}
+	if status, ok := execState.GetNodeStatus("store"); ok && status != models.NodeExecutionStatusSkipped {
+		t.Errorf("expected store not to run, got %s", status)
+	}
+
+	askOutput, _ := execState.GetNodeOutput("ask")
+	if partial := askOutput.(map[string]any)["partial_output"]; partial != "The tide turns" {
+		t.Errorf("expected the streamed text as partial output, got %v", partial)
+	}
+	fetchOutput, _ := execState.GetNodeOutput("fetch")
+	if partial, _ := fetchOutput.(map[string]any)["partial_output"].(map[string]any); partial["body"] != "partial" {
+		t.Errorf("expected the returned output as partial output, got %v", fetchOutput)
+	}
+
+	cancelled := 0
+	notifier.mu.Lock()
+	for _, event := range notifier.events {
+		if event.Type == EventTypeNodeCancelled {
+			cancelled++
+			if event.Metadata["partial_output"] == nil {
+				t.Errorf("expected partial output in the %s event", event.NodeID)
+			}
+		}
+	}
+	notifier.mu.Unlock()
+	if cancelled != 2 {
+		t.Errorf("expected 2 node.cancelled events, got %d", cancelled)
+	}
+}
+
+func TestCancel_BeforeExecuteRunsNothing(t *testing.T) {
+	t.Parallel()
+
+	dagExec, _ := newCancellationTestExecutor(make(chan string, 1))
+	workflow := &models.Workflow{
+		ID:    "wf-1",
+		Nodes: []*models.Node{{ID: "fetch", Name: "fetch", Type: "http", Config: map[string]any{"id": "fetch"}}},
+	}
+	execState := NewExecutionState("exec-1", "wf-1", workflow, map[string]any{}, nil)
+	execState.Cancel("user admin-1")
+
+	err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions())
+	if !errors.Is(err, models.ErrExecutionCancelled) {
+		t.Fatalf("expected ErrExecutionCancelled, got %v", err)
+	}
+	if status, ok := execState.GetNodeStatus("fetch"); ok && status != models.NodeExecutionStatusPending {
+		t.Errorf("expected fetch not to run, got %s", status)
+	}
+	if execState.CancelReason() != "user admin-1" {
+		t.Errorf("unexpected cancel reason %q", execState.CancelReason())
+	}
+}
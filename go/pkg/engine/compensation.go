@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// compensate undoes the completed steps of a failed execution. The compensation node of every
+// completed step runs once, one at a time, starting with the step that completed last; ties
+// are broken by node ID. A step whose compensation node succeeds is marked compensated. A
+// failed compensation does not stop the others: the errors of all failed compensations are
+// returned together. Nothing is compensated once the execution context is done, as the
+// compensation nodes could not run.
+func (de *DAGExecutor) compensate(
+	ctx context.Context,
+	execState *ExecutionState,
+	dag *DAG,
+	opts *ExecutionOptions,
+) error {
+	if len(dag.CompensationEdges) == 0 || ctx.Err() != nil {
+		return nil
+	}
+
+	type completedStep struct {
+		edge    *models.Edge
+		endTime time.Time
+	}
+	var steps []completedStep
+	for _, edge := range dag.CompensationEdges {
+		if status, _ := execState.GetNodeStatus(edge.From); status != models.NodeExecutionStatusCompleted {
+			continue
+		}
+		if dag.Index.NodesByID[edge.From] == nil || dag.Index.NodesByID[edge.To] == nil {
+			continue
+		}
+		endTime, _ := execState.GetNodeEndTime(edge.From)
+		steps = append(steps, completedStep{edge: edge, endTime: endTime})
+	}
+	sort.Slice(steps, func(i, j int) bool {
+		if !steps[i].endTime.Equal(steps[j].endTime) {
+			return steps[i].endTime.After(steps[j].endTime)
+		}
+		return steps[i].edge.From < steps[j].edge.From
+	})
+
+	var errs []error
+	for _, s := range steps {
+		step := dag.Index.NodesByID[s.edge.From]
+		compensation := dag.Index.NodesByID[s.edge.To]
+		if err := de.executeNode(ctx, execState, compensation, opts); err != nil {
+			errs = append(errs, fmt.Errorf("compensation node %s of node %s failed: %w", compensation.ID, step.ID, err))
+			continue
+		}
+
+		execState.SetNodeStatus(step.ID, models.NodeExecutionStatusCompensated)
+		de.safeNotify(ctx, ExecutionEvent{
+			Type:        EventTypeNodeCompensated,
+			ExecutionID: execState.ExecutionID,
+			WorkflowID:  execState.WorkflowID,
+			Timestamp:   time.Now(),
+			Status:      string(models.NodeExecutionStatusCompensated),
+			NodeID:      step.ID,
+			NodeName:    step.Name,
+			NodeType:    step.Type,
+			Metadata:    map[string]any{"compensation_node_id": compensation.ID},
+		})
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,194 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// newCompensationTestExecutor runs "step" nodes, which fail when their config sets "fail",
+// and records the order in which nodes ran along with their inputs.
+func newCompensationTestExecutor(notifier ExecutionNotifier) (*DAGExecutor, func() []string, map[string]any) {
+	var mu sync.Mutex
+	var ran []string
+	inputs := make(map[string]any)
+
+	registry := executor.NewManager()
+	registry.Register("step", &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			name, _ := config["name"].(string)
+			mu.Lock()
+			ran = append(ran, name)
+			inputs[name] = input
+			mu.Unlock()
+			if fail, _ := config["fail"].(bool); fail {
+				return nil, errors.New(name + " unavailable")
+			}
+			return map[string]any{name: true}, nil
+		},
+	})
+
+	dagExec := NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), notifier, NewNilWorkflowLoader())
+	return dagExec, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), ran...)
+	}, inputs
+}
+
+// orderWorkflow charges, reserves stock and ships an order; charge and reserve are undone
+// by refund and release.
+func orderWorkflow(failing ...string) *models.Workflow {
+	node := func(id string) *models.Node {
+		config := map[string]any{"name": id}
+		for _, f := range failing {
+			if f == id {
+				config["fail"] = true
+			}
+		}
+		return &models.Node{ID: id, Name: id, Type: "step", Config: config}
+	}
+	return &models.Workflow{
+		ID:    "wf-1",
+		Name:  "Orders",
+		Nodes: []*models.Node{node("charge"), node("reserve"), node("ship"), node("refund"), node("release")},
+		Edges: []*models.Edge{
+			{ID: "e1", From: "charge", To: "reserve"},
+			{ID: "e2", From: "reserve", To: "ship"},
+			{ID: "c1", From: "charge", To: "refund", Type: models.EdgeTypeCompensation},
+			{ID: "c2", From: "reserve", To: "release", Type: models.EdgeTypeCompensation},
+		},
+	}
+}
+
+func TestCompensation_ShouldUndoCompletedStepsInReverseOrder(t *testing.T) {
+	t.Parallel()
+
+	recorder := &recordingNotifier{}
+	dagExec, ran, inputs := newCompensationTestExecutor(recorder)
+	execState := NewExecutionState("exec-1", "wf-1", orderWorkflow("ship"), map[string]any{}, map[string]any{})
+
+	err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions())
+	if err == nil || !strings.Contains(err.Error(), "ship unavailable") {
+		t.Fatalf("expected the shipment failure, got: %v", err)
+	}
+
+	expected := []string{"charge", "reserve", "ship", "release", "refund"}
+	if got := ran(); strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected nodes to run in order %v, got %v", expected, got)
+	}
+	for _, id := range []string{"charge", "reserve"} {
+		if status, _ := execState.GetNodeStatus(id); status != models.NodeExecutionStatusCompensated {
+			t.Errorf("expected %s to be compensated, got %s", id, status)
+		}
+	}
+	if status, _ := execState.GetNodeStatus("refund"); status != models.NodeExecutionStatusCompleted {
+		t.Errorf("expected refund to be completed, got %s", status)
+	}
+	if input, _ := inputs["refund"].(map[string]any); input["charge"] != true {
+		t.Errorf("expected refund to get the charge output, got %v", inputs["refund"])
+	}
+
+	var compensated []string
+	for _, event := range recorder.events {
+		if event.Type == EventTypeNodeCompensated {
+			compensated = append(compensated, event.NodeID+"<-"+event.Metadata["compensation_node_id"].(string))
+		}
+	}
+	if strings.Join(compensated, ",") != "reserve<-release,charge<-refund" {
+		t.Errorf("unexpected node.compensated events: %v", compensated)
+	}
+}
+
+func TestCompensation_ShouldContinueAfterFailedCompensation(t *testing.T) {
+	t.Parallel()
+
+	dagExec, ran, _ := newCompensationTestExecutor(NewNoOpNotifier())
+	execState := NewExecutionState("exec-1", "wf-1", orderWorkflow("ship", "release"), map[string]any{}, map[string]any{})
+
+	err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions())
+	if err == nil || !strings.Contains(err.Error(), "ship unavailable") ||
+		!strings.Contains(err.Error(), "compensation node release of node reserve failed") {
+		t.Fatalf("expected the shipment and release failures, got: %v", err)
+	}
+
+	if got := ran(); got[len(got)-1] != "refund" {
+		t.Errorf("expected refund to run after the failed release, got %v", got)
+	}
+	if status, _ := execState.GetNodeStatus("reserve"); status != models.NodeExecutionStatusCompleted {
+		t.Errorf("expected reserve to stay completed, got %s", status)
+	}
+	if status, _ := execState.GetNodeStatus("charge"); status != models.NodeExecutionStatusCompensated {
+		t.Errorf("expected charge to be compensated, got %s", status)
+	}
+}
+
+func TestCompensation_ShouldNotRunOnSuccess(t *testing.T) {
+	t.Parallel()
+
+	dagExec, ran, _ := newCompensationTestExecutor(NewNoOpNotifier())
+	workflow := orderWorkflow()
+	execState := NewExecutionState("exec-1", "wf-1", workflow, map[string]any{}, map[string]any{})
+
+	if err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions()); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if got := ran(); strings.Join(got, ",") != "charge,reserve,ship" {
+		t.Errorf("expected only the steps to run, got %v", got)
+	}
+
+	leaves := FindLeafNodes(workflow)
+	if len(leaves) != 1 || leaves[0].ID != "ship" {
+		t.Errorf("expected ship to be the only leaf node, got %v", leaves)
+	}
+}
+
+func TestCompensation_ShouldSkipWhenCancelled(t *testing.T) {
+	t.Parallel()
+
+	dagExec, ran, _ := newCompensationTestExecutor(NewNoOpNotifier())
+	ctx, cancel := context.WithCancel(context.Background())
+	workflow := orderWorkflow()
+	workflow.Nodes[2].Config["fail"] = true
+	execState := NewExecutionState("exec-1", "wf-1", workflow, map[string]any{}, map[string]any{})
+	dag := BuildDAG(workflow)
+
+	if err := dagExec.Execute(ctx, execState, DefaultExecutionOptions()); err == nil {
+		t.Fatal("expected the shipment failure")
+	}
+	before := len(ran())
+
+	// A cancelled execution leaves its completed steps as they are
+	for _, id := range []string{"charge", "reserve"} {
+		execState.SetNodeStatus(id, models.NodeExecutionStatusCompleted)
+	}
+	cancel()
+	if err := dagExec.compensate(ctx, execState, dag, DefaultExecutionOptions()); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(ran()) != before {
+		t.Errorf("expected no compensation to run, got %v", ran()[before:])
+	}
+}
+
+func TestSelectSubgraph_ShouldKeepCompensationNodes(t *testing.T) {
+	t.Parallel()
+
+	selection := &models.NodeSelection{FromNode: "reserve", BoundaryOutputs: map[string]any{"charge": map[string]any{}}}
+	sub, boundary, err := SelectSubgraph(orderWorkflow(), selection)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if got := strings.Join(nodeIDs(sub.Nodes), ","); got != "charge,reserve,ship,release" {
+		t.Errorf("unexpected subgraph nodes: %s", got)
+	}
+	if strings.Join(boundary, ",") != "charge" {
+		t.Errorf("expected charge as the only boundary node, got %v", boundary)
+	}
+}
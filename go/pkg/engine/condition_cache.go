@@ -7,6 +7,9 @@ import (
 
 	"github.com/expr-lang/expr"
 	"github.com/expr-lang/expr/vm"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/executor/compilecache"
 )
 
 // ConditionCache is a thread-safe LRU cache for compiled expression programs.
@@ -38,8 +41,9 @@ func NewConditionCache(capacity int) *ConditionCache {
 
 // Get retrieves a compiled program from cache.
 func (cc *ConditionCache) Get(condition string) (*vm.Program, bool) {
-	cc.mu.RLock()
-	defer cc.mu.RUnlock()
+	// MoveToFront changes the list, so a read lock is not enough
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
 
 	if element, found := cc.cache[condition]; found {
 		cc.lruList.MoveToFront(element)
@@ -104,7 +108,7 @@ func (cc *ConditionCache) CompileAndCache(condition string, env any) (*vm.Progra
 		return program, nil
 	}
 
-	program, err := expr.Compile(condition, expr.Env(env), expr.AsBool())
+	program, err := expr.Compile(condition, conditionOptions(env)...)
 	if err != nil {
 		return nil, err
 	}
@@ -114,16 +118,23 @@ func (cc *ConditionCache) CompileAndCache(condition string, env any) (*vm.Progra
 	return program, nil
 }
 
-// ExprConditionEvaluator implements ConditionEvaluator using expr-lang with caching.
-type ExprConditionEvaluator struct {
-	cache *ConditionCache
+// conditionOptions returns the compile options of edge conditions: boolean expressions
+// with the decimal functions of the executor package.
+func conditionOptions(env any) []expr.Option {
+	options := []expr.Option{expr.Env(env), expr.AsBool()}
+	return append(options, executor.DecimalFunctions...)
 }
 
+// conditionPrograms holds the compiled edge conditions of all evaluators, so that
+// conditions compiled by one execution are reused by the next.
+var conditionPrograms = compilecache.New[*vm.Program]("condition", compilecache.DefaultCapacity)
+
+// ExprConditionEvaluator implements ConditionEvaluator using expr-lang with caching.
+type ExprConditionEvaluator struct{}
+
 // NewExprConditionEvaluator creates a new ExprConditionEvaluator.
 func NewExprConditionEvaluator() *ExprConditionEvaluator {
-	return &ExprConditionEvaluator{
-		cache: NewConditionCache(100),
-	}
+	return &ExprConditionEvaluator{}
 }
 
 // Evaluate evaluates a condition expression against node output using expr-lang.
@@ -133,10 +144,12 @@ func (e *ExprConditionEvaluator) Evaluate(condition string, nodeOutput any) (boo
 	}
 
 	env := map[string]any{
-		"output": nodeOutput,
+		"output": executor.NormalizeNumbers(nodeOutput),
 	}
 
-	program, err := e.cache.CompileAndCache(condition, env)
+	program, err := conditionPrograms.GetOrCompile(condition, func() (*vm.Program, error) {
+		return expr.Compile(condition, conditionOptions(env)...)
+	})
 	if err != nil {
 		return false, fmt.Errorf("failed to compile condition: %w", err)
 	}
@@ -7,12 +7,46 @@ const (
 
 	// SourceHandleFalse represents the "false" branch from a conditional node
 	SourceHandleFalse = "false"
+
+	// SourceHandleError represents the branch taken when a routed node assertion fails,
+	// or when a validate node routing invalid data finds violations
+	SourceHandleError = "error"
+
+	// SourceHandleEvent represents the branch taken when a wait_for_event node receives its event
+	SourceHandleEvent = "event"
+
+	// SourceHandleTimeout represents the branch taken when a wait_for_event node times out
+	SourceHandleTimeout = "timeout"
+
+	// SourceHandleApproved represents the branch taken when an approval node is approved
+	SourceHandleApproved = "approved"
+
+	// SourceHandleRejected represents the branch taken when an approval node is rejected
+	SourceHandleRejected = "rejected"
+
+	// SourceHandleReleased represents the branch taken when a batcher node releases its batch
+	SourceHandleReleased = "released"
+
+	// SourceHandlePending represents the branch taken when a batcher node keeps its batch open
+	SourceHandlePending = "pending"
 )
 
 // Node types
 const (
 	// NodeTypeConditional represents a conditional/branching node
 	NodeTypeConditional = "conditional"
+
+	// NodeTypeWaitForEvent represents a node waiting for an external event
+	NodeTypeWaitForEvent = "wait_for_event"
+
+	// NodeTypeApproval represents a node waiting for a human decision
+	NodeTypeApproval = "approval"
+
+	// NodeTypeBatcher represents a node accumulating items across executions
+	NodeTypeBatcher = "batcher"
+
+	// NodeTypeValidate represents a node validating its input against a JSON Schema
+	NodeTypeValidate = "validate"
 )
 
 // Default configuration values
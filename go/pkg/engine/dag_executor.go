@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/smilemakc/mbflow/go/pkg/executor"
 	"github.com/smilemakc/mbflow/go/pkg/models"
 )
 
@@ -35,21 +37,51 @@ func (de *DAGExecutor) Execute(
 	execState *ExecutionState,
 	opts *ExecutionOptions,
 ) error {
+	// A partial run executes the selected subgraph; its boundary nodes only provide outputs
+	var boundary map[string]bool
+	if opts.Selection != nil {
+		var err error
+		if boundary, err = applyNodeSelection(execState, opts.Selection); err != nil {
+			return err
+		}
+	}
+
+	if execState.typeLimiter == nil && execState.ParentExecutionID == "" {
+		execState.typeLimiter = newNodeTypeLimiter(opts.NodeTypeConcurrency)
+	}
+
+	ctx, release := execState.cancellable(ctx)
+	defer release()
+
 	dag := BuildDAG(execState.Workflow)
 
 	waves, err := TopologicalSort(dag)
 	if err != nil {
 		return fmt.Errorf("DAG validation failed: %w", err)
 	}
+	waves = withoutNodes(waves, boundary)
+
+	// A resumed execution completes the nodes whose suspension is over and only runs
+	// the nodes that did not finish before it was suspended
+	de.resumeDueNodes(ctx, execState, time.Now())
+	waves = withoutNodes(waves, execState.settledNodes())
 
 	waveIdx := 0
 	for waveIdx < len(waves) {
 		if err := ctx.Err(); err != nil {
-			return fmt.Errorf("execution cancelled: %w", err)
+			return execState.cancelledErr(fmt.Errorf("execution cancelled: %w", err))
+		}
+		if execState.stopRequested() {
+			return fmt.Errorf("%w before wave %d", execState.stopErr(), waveIdx)
 		}
 
+		execState.wave.Store(int32(waveIdx))
 		if err := de.executeWave(ctx, execState, waves[waveIdx], waveIdx, opts); err != nil {
-			return fmt.Errorf("wave %d execution failed: %w", waveIdx, err)
+			err = fmt.Errorf("wave %d execution failed: %w", waveIdx, err)
+			if compErr := de.compensate(ctx, execState, dag, opts); compErr != nil {
+				err = fmt.Errorf("%w; %w", err, compErr)
+			}
+			return execState.cancelledErr(err)
 		}
 
 		if jumpTarget := de.processLoopEdges(ctx, execState, dag, waves, waveIdx); jumpTarget >= 0 {
@@ -60,7 +92,10 @@ func (de *DAGExecutor) Execute(
 		waveIdx++
 	}
 
-	return nil
+	if execState.interrupted.Load() > 0 {
+		return fmt.Errorf("%w in the last wave", execState.stopErr())
+	}
+	return suspendedError(execState)
 }
 
 // executeWave executes all nodes in a wave in parallel.
@@ -132,6 +167,18 @@ func (de *DAGExecutor) executeWave(
 			semaphore <- struct{}{}
 			defer func() { <-semaphore }()
 
+			// A preempted or paused execution finishes its running nodes and leaves the others pending
+			if execState.stopRequested() {
+				execState.interrupted.Add(1)
+				return
+			}
+
+			// Nodes downstream of a suspended node run when the execution resumes
+			if execState.blockedBySuspension(n) != "" {
+				execState.deferNode(n.ID)
+				return
+			}
+
 			shouldExec, skipReason := de.shouldExecuteNode(execState, n)
 			if !shouldExec {
 				execState.SetNodeStatus(n.ID, models.NodeExecutionStatusSkipped)
@@ -149,6 +196,10 @@ func (de *DAGExecutor) executeWave(
 				return
 			}
 
+			if de.stopAtBreakpoint(execState, n, opts) {
+				return
+			}
+
 			if err := de.executeNode(ctx, execState, n, opts); err != nil {
 				nodeErr := fmt.Errorf("node %s failed: %w", n.ID, err)
 				errChan <- nodeErr
@@ -245,26 +296,31 @@ func (de *DAGExecutor) executeNode(
 		NodeType:    node.Type,
 	})
 
-	// Sub-workflow fan-out: handled by engine, not by executor
-	if node.Type == "sub_workflow" {
+	// Sub-workflow and foreach fan-out: handled by engine, not by executor
+	if node.Type == NodeTypeSubWorkflow || node.Type == NodeTypeForEach {
 		return de.executeSubWorkflow(ctx, execState, node, opts)
 	}
+	// While loops rerun their inline body, also handled by engine
+	if node.Type == NodeTypeWhile {
+		return de.executeWhile(ctx, execState, node, opts)
+	}
 
 	// Create node-specific context with timeout
 	nodeCtx := ctx
-	nodeTimeoutMs := GetNodeTimeout(node)
-	if nodeTimeoutMs > 0 {
-		var cancel context.CancelFunc
-		nodeCtx, cancel = context.WithTimeout(ctx, time.Duration(nodeTimeoutMs)*time.Millisecond)
-		defer cancel()
-	} else if opts.NodeTimeout > 0 {
+	nodeTimeout := time.Duration(GetNodeTimeout(node)) * time.Millisecond
+	if nodeTimeout <= 0 {
+		nodeTimeout = opts.NodeTimeout
+	}
+	if nodeTimeout > 0 {
 		var cancel context.CancelFunc
-		nodeCtx, cancel = context.WithTimeout(ctx, opts.NodeTimeout)
+		nodeCtx, cancel = context.WithTimeout(ctx, nodeTimeout)
 		defer cancel()
 	}
 
 	parentNodes := GetRegularParentNodes(execState.Workflow, node)
 	nodeExecCtx := PrepareNodeContext(execState, node, parentNodes, opts)
+	var streamed streamedOutput
+	nodeExecCtx.OnOutputDelta = streamed.wrap(de.outputDeltaNotifier(ctx, execState, node))
 
 	// Execute node with retry policy
 	var execResult *NodeExecutionResult
@@ -287,13 +343,42 @@ func (de *DAGExecutor) executeNode(
 	}
 
 	execErr = retryPolicy.Execute(nodeCtx, func() error {
+		// The slot is held per attempt, so a node waiting to retry leaves it to others
+		release, err := execState.typeLimiter.acquire(nodeCtx, node.Type)
+		if err != nil {
+			return err
+		}
 		result, err := de.nodeExecutor.Execute(nodeCtx, nodeExecCtx)
+		release()
 		if result != nil {
 			execResult = result
 		}
 		return err
 	})
 
+	if suspend, ok := executor.AsSuspend(execErr); ok {
+		if opts.AllowSuspend {
+			de.suspendNode(ctx, execState, node, execResult, suspend)
+			return nil
+		}
+		if execErr = waitUntil(ctx, suspend.ResumeAt); execErr == nil {
+			if execResult == nil {
+				execResult = &NodeExecutionResult{}
+			}
+			execResult.Output = suspend.Output
+		}
+	}
+
+	// Cancelling the execution aborts its running nodes, which keep what they produced
+	if execErr != nil && executionCancelled(ctx) {
+		return de.cancelNode(ctx, execState, node, execResult, streamed.String(), execErr, nodeStartTime)
+	}
+
+	// A node that ran out of its own time is timed out rather than failed
+	if execErr != nil && nodeTimeout > 0 && errors.Is(nodeCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		return de.timeOutNode(ctx, execState, node, execResult, nodeTimeout, execErr, nodeStartTime)
+	}
+
 	if execErr != nil {
 		nodeEndTime := time.Now()
 		execState.SetNodeError(node.ID, execErr)
@@ -304,6 +389,30 @@ func (de *DAGExecutor) executeNode(
 			execState.SetNodeInput(node.ID, execResult.Input)
 			execState.SetNodeConfig(node.ID, execResult.Config)
 			execState.SetNodeResolvedConfig(node.ID, execResult.ResolvedConfig)
+			if len(execResult.Annotations) > 0 {
+				execState.SetNodeAnnotations(node.ID, execResult.Annotations)
+			}
+		}
+
+		var circuitErr *executor.CircuitOpenError
+		if errors.As(execErr, &circuitErr) {
+			de.safeNotify(ctx, ExecutionEvent{
+				Type:        EventTypeNodeCircuitOpen,
+				ExecutionID: execState.ExecutionID,
+				WorkflowID:  execState.WorkflowID,
+				Timestamp:   time.Now(),
+				Status:      "failed",
+				NodeID:      node.ID,
+				NodeName:    node.Name,
+				NodeType:    node.Type,
+				Error:       execErr,
+				Message:     circuitErr.Error(),
+				Metadata: map[string]any{
+					"error_code": circuitErr.Code(),
+					"circuit":    circuitErr.Key,
+					"retry_at":   circuitErr.RetryAt,
+				},
+			})
 		}
 
 		nodeDuration := time.Since(nodeStartTime).Milliseconds()
@@ -341,6 +450,32 @@ func (de *DAGExecutor) executeNode(
 	execState.SetNodeInput(node.ID, execResult.Input)
 	execState.SetNodeConfig(node.ID, execResult.Config)
 	execState.SetNodeResolvedConfig(node.ID, execResult.ResolvedConfig)
+	if len(execResult.Annotations) > 0 {
+		execState.SetNodeAnnotations(node.ID, execResult.Annotations)
+	}
+
+	// Check output assertions
+	if err := de.checkAssertions(ctx, execState, node, execResult.Output); err != nil {
+		execState.SetNodeError(node.ID, err)
+		execState.SetNodeStatus(node.ID, models.NodeExecutionStatusFailed)
+		execState.SetNodeEndTime(node.ID, nodeEndTime)
+
+		de.safeNotify(ctx, ExecutionEvent{
+			Type:        EventTypeNodeFailed,
+			ExecutionID: execState.ExecutionID,
+			WorkflowID:  execState.WorkflowID,
+			Timestamp:   time.Now(),
+			Status:      "failed",
+			NodeID:      node.ID,
+			NodeName:    node.Name,
+			NodeType:    node.Type,
+			Error:       err,
+			DurationMs:  time.Since(nodeStartTime).Milliseconds(),
+		})
+
+		return err
+	}
+
 	execState.SetNodeStatus(node.ID, models.NodeExecutionStatusCompleted)
 	execState.SetNodeEndTime(node.ID, nodeEndTime)
 
@@ -361,7 +496,7 @@ func (de *DAGExecutor) executeNode(
 	}
 
 	nodeDuration := time.Since(nodeStartTime).Milliseconds()
-	de.safeNotify(ctx, ExecutionEvent{
+	completedEvent := ExecutionEvent{
 		Type:        EventTypeNodeCompleted,
 		ExecutionID: execState.ExecutionID,
 		WorkflowID:  execState.WorkflowID,
@@ -372,11 +507,41 @@ func (de *DAGExecutor) executeNode(
 		NodeType:    node.Type,
 		DurationMs:  nodeDuration,
 		Output:      ToMapInterface(execResult.Output),
-	})
+	}
+	if isRedacted(node) {
+		completedEvent.Output = nil
+		completedEvent.Metadata = map[string]any{"redacted": true}
+	}
+	de.safeNotify(ctx, completedEvent)
 
 	return nil
 }
 
+// outputDeltaNotifier returns the function that publishes incremental node output, such as
+// streamed LLM tokens, as node.output_delta events. Events carry the chunk under "delta" and
+// its position under "index" in their metadata, as observers may deliver them out of order;
+// a node.retrying event restarts the sequence. Nodes whose data labels redact their payloads
+// are not streamed.
+func (de *DAGExecutor) outputDeltaNotifier(ctx context.Context, execState *ExecutionState, node *models.Node) func(delta string) {
+	if isRedacted(node) {
+		return nil
+	}
+	var index atomic.Int64
+	return func(delta string) {
+		de.safeNotify(ctx, ExecutionEvent{
+			Type:        EventTypeNodeOutputDelta,
+			ExecutionID: execState.ExecutionID,
+			WorkflowID:  execState.WorkflowID,
+			Timestamp:   time.Now(),
+			Status:      "running",
+			NodeID:      node.ID,
+			NodeName:    node.Name,
+			NodeType:    node.Type,
+			Metadata:    map[string]any{"delta": delta, "index": index.Add(1) - 1},
+		})
+	}
+}
+
 // processLoopEdges checks if any loop edge should fire after the current wave.
 // Returns the wave index to jump to, or -1 if no loop fires.
 func (de *DAGExecutor) processLoopEdges(
@@ -514,23 +679,45 @@ func (de *DAGExecutor) shouldExecuteNode(
 			continue
 		}
 
+		// A timed-out node continues down its timeout edges only
+		if sourceStatus == models.NodeExecutionStatusTimedOut {
+			if edge.SourceHandle != SourceHandleTimeout {
+				allSkipReasons = append(allSkipReasons, fmt.Sprintf("edge from %s: node timed out, following timeout edges", sourceNode.ID))
+				continue
+			}
+			if reason := de.conditionSkipReason(execState, edge, sourceNode); reason != "" {
+				allSkipReasons = append(allSkipReasons, reason)
+				continue
+			}
+			hasValidPath = true
+			break
+		}
+
 		if sourceStatus != models.NodeExecutionStatusCompleted {
 			allSkipReasons = append(allSkipReasons, fmt.Sprintf("parent %s not completed (%s)", sourceNode.ID, sourceStatus))
 			continue
 		}
 
-		// Evaluate edge condition
-		if edge.Condition != "" {
-			output, _ := execState.GetNodeOutput(sourceNode.ID)
-			passed, err := de.conditionEvaluator.Evaluate(edge.Condition, output)
-			if err != nil {
-				allSkipReasons = append(allSkipReasons, fmt.Sprintf("edge from %s: condition error: %v", sourceNode.ID, err))
-				continue
-			}
-			if !passed {
-				allSkipReasons = append(allSkipReasons, fmt.Sprintf("edge from %s: condition '%s' is false", sourceNode.ID, edge.Condition))
-				continue
+		// Other nodes than wait_for_event and approval nodes take their timeout edges only when timed out
+		if edge.SourceHandle == SourceHandleTimeout && sourceNode.Type != NodeTypeWaitForEvent && sourceNode.Type != NodeTypeApproval {
+			allSkipReasons = append(allSkipReasons, fmt.Sprintf("edge from %s: timeout branch not active", sourceNode.ID))
+			continue
+		}
+
+		// A routed assertion failure or routed invalid data sends the source down its error edges only
+		if routed := execState.HasRoutedAssertionFailure(sourceNode.ID) || validationRouted(execState, sourceNode); routed != (edge.SourceHandle == SourceHandleError) {
+			if routed {
+				allSkipReasons = append(allSkipReasons, fmt.Sprintf("edge from %s: assertion or validation failed, following error edges", sourceNode.ID))
+			} else {
+				allSkipReasons = append(allSkipReasons, fmt.Sprintf("edge from %s: error branch not active", sourceNode.ID))
 			}
+			continue
+		}
+
+		// Evaluate edge condition
+		if reason := de.conditionSkipReason(execState, edge, sourceNode); reason != "" {
+			allSkipReasons = append(allSkipReasons, reason)
+			continue
 		}
 
 		// Check sourceHandle routing for conditional nodes
@@ -546,6 +733,30 @@ func (de *DAGExecutor) shouldExecuteNode(
 			}
 		}
 
+		// Check event/timeout routing for wait_for_event nodes
+		if sourceNode.Type == NodeTypeWaitForEvent && edge.SourceHandle != "" {
+			if !eventBranchActive(edge, execState, sourceNode) {
+				allSkipReasons = append(allSkipReasons, fmt.Sprintf("edge from %s: %s branch not active", sourceNode.ID, edge.SourceHandle))
+				continue
+			}
+		}
+
+		// Check decision routing for approval nodes
+		if sourceNode.Type == NodeTypeApproval && edge.SourceHandle != "" {
+			if !approvalBranchActive(edge, execState, sourceNode) {
+				allSkipReasons = append(allSkipReasons, fmt.Sprintf("edge from %s: %s branch not active", sourceNode.ID, edge.SourceHandle))
+				continue
+			}
+		}
+
+		// Check release routing for batcher nodes
+		if sourceNode.Type == NodeTypeBatcher && edge.SourceHandle != "" {
+			if !batchBranchActive(edge, execState, sourceNode) {
+				allSkipReasons = append(allSkipReasons, fmt.Sprintf("edge from %s: %s branch not active", sourceNode.ID, edge.SourceHandle))
+				continue
+			}
+		}
+
 		hasValidPath = true
 		break
 	}
@@ -600,6 +811,95 @@ func evaluateSourceHandleCondition(
 	return true, nil
 }
 
+// conditionSkipReason evaluates the condition of an edge on the output of its source node
+// and returns why the edge does not pass, or "" if it passes.
+func (de *DAGExecutor) conditionSkipReason(execState *ExecutionState, edge *models.Edge, sourceNode *models.Node) string {
+	if edge.Condition == "" {
+		return ""
+	}
+	output, _ := execState.GetNodeOutput(sourceNode.ID)
+	passed, err := de.conditionEvaluator.Evaluate(edge.Condition, output)
+	if err != nil {
+		return fmt.Sprintf("edge from %s: condition error: %v", sourceNode.ID, err)
+	}
+	if !passed {
+		return fmt.Sprintf("edge from %s: condition '%s' is false", sourceNode.ID, edge.Condition)
+	}
+	return ""
+}
+
+// eventBranchActive checks if the edge's sourceHandle matches the outcome of a
+// wait_for_event node: "timeout" when it timed out, "event" otherwise.
+func eventBranchActive(edge *models.Edge, execState *ExecutionState, sourceNode *models.Node) bool {
+	output, _ := execState.GetNodeOutput(sourceNode.ID)
+	timedOut := false
+	if mapOutput, ok := output.(map[string]any); ok {
+		timedOut, _ = mapOutput["timed_out"].(bool)
+	}
+
+	switch edge.SourceHandle {
+	case SourceHandleEvent:
+		return !timedOut
+	case SourceHandleTimeout:
+		return timedOut
+	default:
+		return true
+	}
+}
+
+// approvalBranchActive checks if the edge's sourceHandle matches the decision of an approval
+// node: "approved", "rejected" or "timeout". Other handles are always active.
+func approvalBranchActive(edge *models.Edge, execState *ExecutionState, sourceNode *models.Node) bool {
+	switch edge.SourceHandle {
+	case SourceHandleApproved, SourceHandleRejected, SourceHandleTimeout:
+	default:
+		return true
+	}
+
+	output, _ := execState.GetNodeOutput(sourceNode.ID)
+	decision := ""
+	if mapOutput, ok := output.(map[string]any); ok {
+		if event, ok := mapOutput["event"].(map[string]any); ok {
+			decision, _ = event["decision"].(string)
+		}
+	}
+	return decision == edge.SourceHandle
+}
+
+// batchBranchActive checks if the edge's sourceHandle matches the outcome of a batcher node:
+// "released" when it released its batch, "pending" otherwise. Other handles are always active.
+func batchBranchActive(edge *models.Edge, execState *ExecutionState, sourceNode *models.Node) bool {
+	output, _ := execState.GetNodeOutput(sourceNode.ID)
+	released := false
+	if mapOutput, ok := output.(map[string]any); ok {
+		released, _ = mapOutput["released"].(bool)
+	}
+
+	switch edge.SourceHandle {
+	case SourceHandleReleased:
+		return released
+	case SourceHandlePending:
+		return !released
+	default:
+		return true
+	}
+}
+
+// validationRouted reports whether a validate node configured to route invalid data found
+// violations, so that it follows its "error" edges only.
+func validationRouted(execState *ExecutionState, sourceNode *models.Node) bool {
+	if sourceNode.Type != NodeTypeValidate || sourceNode.Config["on_invalid"] != "route" {
+		return false
+	}
+	output, _ := execState.GetNodeOutput(sourceNode.ID)
+	mapOutput, ok := output.(map[string]any)
+	if !ok {
+		return false
+	}
+	valid, ok := mapOutput["valid"].(bool)
+	return ok && !valid
+}
+
 // convertRetryPolicy converts pkg/engine RetryPolicy to InternalRetryPolicy.
 func convertRetryPolicy(rp *RetryPolicy) *InternalRetryPolicy {
 	if rp == nil {
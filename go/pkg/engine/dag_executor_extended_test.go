@@ -41,7 +41,7 @@ func TestDAGExecutor_NodeTimeout(t *testing.T) {
 				Name: "Slow Node",
 				Type: "test",
 				Config: map[string]any{
-					"timeout": 50, // 50ms timeout
+					models.NodeTimeoutConfigKey: 50, // 50ms timeout
 				},
 			},
 		},
@@ -52,13 +52,122 @@ func TestDAGExecutor_NodeTimeout(t *testing.T) {
 	opts := DefaultExecutionOptions()
 
 	err := dagExec.Execute(context.Background(), execState, opts)
-	if err == nil {
-		t.Error("expected timeout error")
+	if !errors.Is(err, models.ErrNodeTimeout) {
+		t.Errorf("expected node timeout error, got %v", err)
 	}
 
 	status, _ := execState.GetNodeStatus("node-1")
-	if status != models.NodeExecutionStatusFailed {
-		t.Errorf("expected Failed status, got %v", status)
+	if status != models.NodeExecutionStatusTimedOut {
+		t.Errorf("expected TimedOut status, got %v", status)
+	}
+}
+
+// TestDAGExecutor_NodeTimeout_RoutesToTimeoutEdges tests that a timed-out node with timeout
+// edges continues down them only, with edge conditions evaluated on its timeout output
+func TestDAGExecutor_NodeTimeout_RoutesToTimeoutEdges(t *testing.T) {
+	mockExec := &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			if delay, ok := config["delay"].(int); ok {
+				select {
+				case <-time.After(time.Duration(delay) * time.Millisecond):
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+			return map[string]any{"result": "completed"}, nil
+		},
+	}
+
+	registry := executor.NewManager()
+	registry.Register("test", mockExec)
+
+	notifier := &recordingNotifier{}
+	dagExec := NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), notifier, NewNilWorkflowLoader())
+
+	workflow := &models.Workflow{
+		ID:   "wf-1",
+		Name: "Timeout Routing",
+		Nodes: []*models.Node{
+			{ID: "slow", Name: "Slow", Type: "test", Config: map[string]any{models.NodeTimeoutConfigKey: 20, "delay": 500}},
+			{ID: "fast", Name: "Fast", Type: "test", Config: map[string]any{models.NodeTimeoutConfigKey: 1000}},
+			{ID: "on-success", Name: "On Success", Type: "test"},
+			{ID: "on-timeout", Name: "On Timeout", Type: "test"},
+			{ID: "on-short-timeout", Name: "On Short Timeout", Type: "test"},
+			{ID: "fast-timeout", Name: "Fast Timeout", Type: "test"},
+		},
+		Edges: []*models.Edge{
+			{ID: "e1", From: "slow", To: "on-success"},
+			{ID: "e2", From: "slow", To: "on-timeout", SourceHandle: SourceHandleTimeout},
+			{ID: "e3", From: "slow", To: "on-short-timeout", SourceHandle: SourceHandleTimeout, Condition: "output.timeout_ms < 10"},
+			{ID: "e4", From: "fast", To: "fast-timeout", SourceHandle: SourceHandleTimeout},
+		},
+	}
+
+	execState := NewExecutionState("exec-1", "wf-1", workflow, map[string]any{}, map[string]any{})
+	if err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions()); err != nil {
+		t.Fatalf("expected execution to continue down the timeout edges, got %v", err)
+	}
+
+	expected := map[string]models.NodeExecutionStatus{
+		"slow":             models.NodeExecutionStatusTimedOut,
+		"fast":             models.NodeExecutionStatusCompleted,
+		"on-success":       models.NodeExecutionStatusSkipped,
+		"on-timeout":       models.NodeExecutionStatusCompleted,
+		"on-short-timeout": models.NodeExecutionStatusSkipped,
+		"fast-timeout":     models.NodeExecutionStatusSkipped,
+	}
+	for nodeID, want := range expected {
+		if status, _ := execState.GetNodeStatus(nodeID); status != want {
+			t.Errorf("expected %s to be %s, got %s", nodeID, want, status)
+		}
+	}
+
+	if err, _ := execState.GetNodeError("slow"); !errors.Is(err, models.ErrNodeTimeout) {
+		t.Errorf("expected node timeout error, got %v", err)
+	}
+
+	found := false
+	for _, event := range notifier.events {
+		if event.Type == EventTypeNodeTimedOut && event.NodeID == "slow" {
+			found = event.Status == "timed_out"
+		}
+	}
+	if !found {
+		t.Error("expected node.timed_out event")
+	}
+}
+
+// TestDAGExecutor_ExecutorTimeoutIsNotNodeTimeout tests that the "timeout" an executor reads
+// as its own setting, often in seconds, does not set the node timeout
+func TestDAGExecutor_ExecutorTimeoutIsNotNodeTimeout(t *testing.T) {
+	mockExec := &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			select {
+			case <-time.After(50 * time.Millisecond):
+				return map[string]any{"result": "completed"}, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		},
+	}
+
+	registry := executor.NewManager()
+	registry.Register("test", mockExec)
+	dagExec := NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), NewNoOpNotifier(), NewNilWorkflowLoader())
+
+	workflow := &models.Workflow{
+		ID:    "wf-1",
+		Name:  "Executor Timeout",
+		Nodes: []*models.Node{{ID: "query", Name: "Query", Type: "test", Config: map[string]any{"timeout": 30}}},
+		Edges: []*models.Edge{},
+	}
+
+	execState := NewExecutionState("exec-1", "wf-1", workflow, map[string]any{}, map[string]any{})
+	if err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions()); err != nil {
+		t.Fatalf("expected the node to run past 30ms, got %v", err)
+	}
+	if status, _ := execState.GetNodeStatus("query"); status != models.NodeExecutionStatusCompleted {
+		t.Errorf("expected Completed status, got %v", status)
 	}
 }
 
@@ -391,3 +500,117 @@ func TestConditionCacheIntegration(t *testing.T) {
 	// Condition evaluation is delegated to ConditionEvaluator interface.
 	// Cache behavior is internal to ExprConditionEvaluator.
 }
+
+// TestDAGExecutor_OutputDeltaEvents tests that incremental node output is published as node.output_delta events
+func TestDAGExecutor_OutputDeltaEvents(t *testing.T) {
+	mockExec := &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			onDelta := executor.OutputDeltaFunc(ctx)
+			if onDelta == nil {
+				return nil, errors.New("expected output delta function in execution context")
+			}
+			onDelta("Hel")
+			onDelta("lo")
+			return map[string]any{"content": "Hello"}, nil
+		},
+	}
+
+	registry := executor.NewManager()
+	registry.Register("llm", mockExec)
+
+	recorder := &recordingNotifier{}
+	dagExec := NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), recorder, NewNilWorkflowLoader())
+
+	workflow := &models.Workflow{
+		ID:    "wf-1",
+		Nodes: []*models.Node{{ID: "N1", Name: "Chat", Type: "llm"}},
+	}
+	execState := NewExecutionState("exec-1", "wf-1", workflow, map[string]any{}, map[string]any{})
+
+	if err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions()); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	var deltas []ExecutionEvent
+	for _, event := range recorder.events {
+		if event.Type == EventTypeNodeOutputDelta {
+			deltas = append(deltas, event)
+		}
+	}
+	if len(deltas) != 2 {
+		t.Fatalf("expected 2 output delta events, got %d", len(deltas))
+	}
+	for i, want := range []string{"Hel", "lo"} {
+		event := deltas[i]
+		if event.NodeID != "N1" || event.ExecutionID != "exec-1" {
+			t.Errorf("delta %d: unexpected node/execution %s/%s", i, event.NodeID, event.ExecutionID)
+		}
+		if event.Metadata["delta"] != want {
+			t.Errorf("delta %d: expected %q, got %v", i, want, event.Metadata["delta"])
+		}
+		if event.Metadata["index"] != int64(i) {
+			t.Errorf("delta %d: expected index %d, got %v", i, i, event.Metadata["index"])
+		}
+	}
+
+	if output, ok := execState.GetNodeOutput("N1"); !ok || output.(map[string]any)["content"] != "Hello" {
+		t.Errorf("expected assembled node output, got %v", output)
+	}
+}
+
+// TestDAGExecutor_CircuitOpen tests that nodes failing fast on an open circuit breaker are
+// not retried and emit a circuit open event
+func TestDAGExecutor_CircuitOpen(t *testing.T) {
+	attempts := 0
+	retryAt := time.Now().Add(time.Minute)
+	mockExec := &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			attempts++
+			return nil, fmt.Errorf("LLM execution failed: %w", &executor.CircuitOpenError{Key: "openai", RetryAt: retryAt})
+		},
+	}
+
+	registry := executor.NewManager()
+	registry.Register("test", mockExec)
+
+	notifier := &recordingNotifier{}
+	dagExec := NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), notifier, NewNilWorkflowLoader())
+
+	workflow := &models.Workflow{
+		ID:    "wf-1",
+		Name:  "Circuit Open",
+		Nodes: []*models.Node{{ID: "node-1", Name: "Ask", Type: "test", Config: map[string]any{}}},
+	}
+
+	execState := NewExecutionState("exec-1", "wf-1", workflow, map[string]any{}, map[string]any{})
+	opts := DefaultExecutionOptions()
+	opts.RetryPolicy = &RetryPolicy{
+		MaxAttempts:     3,
+		InitialDelay:    10 * time.Millisecond,
+		BackoffStrategy: BackoffConstant,
+	}
+
+	err := dagExec.Execute(context.Background(), execState, opts)
+	if !errors.Is(err, models.ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+
+	var event *ExecutionEvent
+	for i := range notifier.events {
+		if notifier.events[i].Type == EventTypeNodeCircuitOpen {
+			event = &notifier.events[i]
+		}
+	}
+	if event == nil {
+		t.Fatal("expected node.circuit_open event")
+	}
+	if event.NodeID != "node-1" || event.Metadata["error_code"] != executor.CircuitOpenErrorCode || event.Metadata["circuit"] != "openai" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+}
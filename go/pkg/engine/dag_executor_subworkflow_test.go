@@ -2,7 +2,9 @@ package engine
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"sync/atomic"
 	"testing"
 
@@ -413,3 +415,304 @@ func TestSubWorkflow_InvalidConfig(t *testing.T) {
 		t.Fatal("expected error for missing workflow_id")
 	}
 }
+
+// newChunkedSubWorkflowExecutor builds an executor whose child workflow sums its chunk
+// and whose reducer workflow adds up the chunk sums.
+func newChunkedSubWorkflowExecutor(reduceErr error) *DAGExecutor {
+	childWF := &models.Workflow{
+		ID:    "sum-chunk",
+		Name:  "Sum Chunk",
+		Nodes: []*models.Node{{ID: "sum", Name: "Sum", Type: "sum", Config: map[string]any{}}},
+	}
+	reducerWF := &models.Workflow{
+		ID:    "sum-results",
+		Name:  "Sum Results",
+		Nodes: []*models.Node{{ID: "reduce", Name: "Reduce", Type: "reduce", Config: map[string]any{}}},
+	}
+
+	registry := executor.NewManager()
+	registry.Register("sum", &executor.ExecutorFunc{
+		ExecuteFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			inputMap, _ := input.(map[string]any)
+			chunk, _ := inputMap["chunk"].([]any)
+			sum := 0.0
+			for _, v := range chunk {
+				sum += v.(float64)
+			}
+			return map[string]any{
+				"sum":         sum,
+				"count":       len(chunk),
+				"offset":      inputMap["offset"],
+				"total_items": inputMap["total_items"],
+			}, nil
+		},
+	})
+	registry.Register("reduce", &executor.ExecutorFunc{
+		ExecuteFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			if reduceErr != nil {
+				return nil, reduceErr
+			}
+			inputMap, _ := input.(map[string]any)
+			results, _ := inputMap["results"].([]any)
+			total := 0.0
+			for _, r := range results {
+				total += r.(map[string]any)["sum"].(float64)
+			}
+			return map[string]any{"total": total, "chunks": len(results)}, nil
+		},
+	})
+
+	loader := NewMockWorkflowLoader(map[string]*models.Workflow{
+		"sum-chunk":   childWF,
+		"sum-results": reducerWF,
+	})
+	return NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), NewNoOpNotifier(), loader)
+}
+
+func newChunkedParentWorkflow(config map[string]any) *models.Workflow {
+	nodeConfig := map[string]any{
+		"workflow_id": "sum-chunk",
+		"for_each":    "input.rows",
+		"chunk_size":  4,
+	}
+	for k, v := range config {
+		nodeConfig[k] = v
+	}
+	return &models.Workflow{
+		ID:    "parent-wf",
+		Name:  "Parent",
+		Nodes: []*models.Node{{ID: "fanout", Name: "Fan Out", Type: "sub_workflow", Config: nodeConfig}},
+	}
+}
+
+func testRows(n int) []any {
+	rows := make([]any, n)
+	for i := range rows {
+		rows[i] = float64(i + 1)
+	}
+	return rows
+}
+
+func TestSubWorkflow_ChunkSize(t *testing.T) {
+	t.Parallel()
+
+	dagExec := newChunkedSubWorkflowExecutor(nil)
+	parentWF := newChunkedParentWorkflow(map[string]any{"max_parallelism": 1})
+	execState := NewExecutionState("exec-1", "parent-wf", parentWF, map[string]any{"rows": testRows(10)}, nil)
+
+	if err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions()); err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	output, _ := execState.GetNodeOutput("fanout")
+	outputMap := output.(map[string]any)
+	summary := outputMap["summary"].(map[string]any)
+	if summary["total"] != 3 || summary["completed"] != 3 {
+		t.Fatalf("expected 3 completed chunks, got: %v", summary)
+	}
+	if summary["total_items"] != 10 || summary["chunk_size"] != 4 {
+		t.Fatalf("expected total_items=10 and chunk_size=4, got: %v", summary)
+	}
+	if _, ok := outputMap["reduced"]; ok {
+		t.Fatal("expected no reduced output without a reducer")
+	}
+
+	items := outputMap["items"].([]any)
+	want := []struct {
+		sum           float64
+		count, offset int
+	}{{10, 4, 0}, {26, 4, 4}, {19, 2, 8}}
+	for i, w := range want {
+		result := items[i].(map[string]any)["output"].(map[string]any)
+		if result["sum"] != w.sum || result["count"] != w.count || result["offset"] != w.offset || result["total_items"] != 10 {
+			t.Fatalf("chunk %d: expected sum=%v count=%d offset=%d, got: %v", i, w.sum, w.count, w.offset, result)
+		}
+	}
+}
+
+func TestSubWorkflow_ChunkReducer(t *testing.T) {
+	t.Parallel()
+
+	dagExec := newChunkedSubWorkflowExecutor(nil)
+	parentWF := newChunkedParentWorkflow(map[string]any{
+		"chunk_size":          json.Number("1000"),
+		"max_parallelism":     float64(4),
+		"reducer_workflow_id": "sum-results",
+	})
+	execState := NewExecutionState("exec-1", "parent-wf", parentWF, map[string]any{"rows": testRows(100000)}, nil)
+
+	if err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions()); err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	output, _ := execState.GetNodeOutput("fanout")
+	outputMap := output.(map[string]any)
+	reduced := outputMap["reduced"].(map[string]any)
+	if reduced["chunks"] != 100 {
+		t.Fatalf("expected reducer to receive 100 chunk results, got: %v", reduced["chunks"])
+	}
+	if reduced["total"] != float64(100000*100001/2) {
+		t.Fatalf("expected total=%d, got: %v", 100000*100001/2, reduced["total"])
+	}
+	if reducer := outputMap["reducer"].(map[string]any); reducer["status"] != "completed" {
+		t.Fatalf("expected completed reducer, got: %v", reducer)
+	}
+}
+
+func TestSubWorkflow_ChunkReducerEmpty(t *testing.T) {
+	t.Parallel()
+
+	dagExec := newChunkedSubWorkflowExecutor(nil)
+	parentWF := newChunkedParentWorkflow(map[string]any{"reducer_workflow_id": "sum-results"})
+	execState := NewExecutionState("exec-1", "parent-wf", parentWF, map[string]any{"rows": []any{}}, nil)
+
+	if err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions()); err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	output, _ := execState.GetNodeOutput("fanout")
+	reduced := output.(map[string]any)["reduced"].(map[string]any)
+	if reduced["total"] != 0.0 || reduced["chunks"] != 0 {
+		t.Fatalf("expected empty reduction, got: %v", reduced)
+	}
+}
+
+func TestSubWorkflow_ChunkReducerFailure(t *testing.T) {
+	t.Parallel()
+
+	dagExec := newChunkedSubWorkflowExecutor(fmt.Errorf("simulated reducer failure"))
+	parentWF := newChunkedParentWorkflow(map[string]any{"reducer_workflow_id": "sum-results"})
+	execState := NewExecutionState("exec-1", "parent-wf", parentWF, map[string]any{"rows": testRows(10)}, nil)
+
+	err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions())
+	if err == nil {
+		t.Fatal("expected error when the reducer fails")
+	}
+	if status, _ := execState.GetNodeStatus("fanout"); status != models.NodeExecutionStatusFailed {
+		t.Fatalf("expected failed node, got: %v", status)
+	}
+}
+
+func TestSubWorkflow_ChunkInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]map[string]any{
+		"negative chunk_size": {"chunk_size": -1},
+		"string chunk_size":   {"chunk_size": "10"},
+		"missing reducer":     {"reducer_workflow_id": "missing"},
+	}
+	for name, config := range tests {
+		t.Run(name, func(t *testing.T) {
+			dagExec := newChunkedSubWorkflowExecutor(nil)
+			execState := NewExecutionState("exec-1", "parent-wf", newChunkedParentWorkflow(config), map[string]any{"rows": testRows(3)}, nil)
+			if err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions()); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+// newGreetingExecutor builds an executor whose "greet" nodes greet input.name and whose
+// "upper" nodes shout the greeting.
+func newGreetingExecutor(loader WorkflowLoader) *DAGExecutor {
+	registry := executor.NewManager()
+	registry.Register("greet", &executor.ExecutorFunc{
+		ExecuteFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			name, _ := input.(map[string]any)["name"].(string)
+			return map[string]any{"greeting": "hello " + name}, nil
+		},
+	})
+	registry.Register("upper", &executor.ExecutorFunc{
+		ExecuteFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			greeting, _ := input.(map[string]any)["greeting"].(string)
+			return map[string]any{"greeting": strings.ToUpper(greeting)}, nil
+		},
+	})
+	return NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), NewNoOpNotifier(), loader)
+}
+
+func TestSubWorkflow_RunsOnceWithInputMapping(t *testing.T) {
+	t.Parallel()
+
+	loader := NewMockWorkflowLoader(map[string]*models.Workflow{
+		"greeter": {
+			ID:    "greeter",
+			Nodes: []*models.Node{{ID: "greet", Name: "Greet", Type: "greet"}},
+		},
+	})
+	parentWF := &models.Workflow{
+		ID: "parent-wf",
+		Nodes: []*models.Node{{
+			ID: "call", Name: "Call", Type: NodeTypeSubWorkflow,
+			Config: map[string]any{
+				"workflow_id":   "greeter",
+				"input_mapping": map[string]any{"name": "input.customer.first_name"},
+			},
+		}},
+	}
+	input := map[string]any{"customer": map[string]any{"first_name": "Ada"}}
+	execState := NewExecutionState("exec-1", "parent-wf", parentWF, input, nil)
+
+	if err := newGreetingExecutor(loader).Execute(context.Background(), execState, DefaultExecutionOptions()); err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+	output, _ := execState.GetNodeOutput("call")
+	if greeting := output.(map[string]any)["greeting"]; greeting != "hello Ada" {
+		t.Fatalf("expected the child output as node output, got: %v", output)
+	}
+}
+
+func TestSubWorkflow_EmbeddedBody(t *testing.T) {
+	t.Parallel()
+
+	parentWF := &models.Workflow{
+		ID: "parent-wf",
+		Nodes: []*models.Node{{
+			ID: "call", Name: "Call", Type: NodeTypeSubWorkflow,
+			Config: map[string]any{
+				"body": map[string]any{
+					"nodes": []any{
+						map[string]any{"id": "greet", "name": "Greet", "type": "greet"},
+						map[string]any{"id": "shout", "name": "Shout", "type": "upper"},
+					},
+					"edges": []any{map[string]any{"id": "e1", "from": "greet", "to": "shout"}},
+				},
+			},
+		}},
+	}
+	execState := NewExecutionState("exec-1", "parent-wf", parentWF, map[string]any{"name": "Grace"}, nil)
+
+	if err := newGreetingExecutor(NewNilWorkflowLoader()).Execute(context.Background(), execState, DefaultExecutionOptions()); err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+	output, _ := execState.GetNodeOutput("call")
+	if greeting := output.(map[string]any)["greeting"]; greeting != "HELLO GRACE" {
+		t.Fatalf("expected the embedded workflow output, got: %v", output)
+	}
+}
+
+func TestSubWorkflow_InputMappingInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]map[string]any{
+		"missing path":          {"input_mapping": map[string]any{"name": "input.nobody"}},
+		"non-string path":       {"input_mapping": map[string]any{"name": 1}},
+		"reducer without items": {"reducer_workflow_id": "greeter"},
+	}
+	for name, config := range tests {
+		t.Run(name, func(t *testing.T) {
+			config["workflow_id"] = "greeter"
+			loader := NewMockWorkflowLoader(map[string]*models.Workflow{
+				"greeter": {ID: "greeter", Nodes: []*models.Node{{ID: "greet", Name: "Greet", Type: "greet"}}},
+			})
+			parentWF := &models.Workflow{
+				ID:    "parent-wf",
+				Nodes: []*models.Node{{ID: "call", Name: "Call", Type: NodeTypeSubWorkflow, Config: config}},
+			}
+			execState := NewExecutionState("exec-1", "parent-wf", parentWF, map[string]any{}, nil)
+			if err := newGreetingExecutor(loader).Execute(context.Background(), execState, DefaultExecutionOptions()); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
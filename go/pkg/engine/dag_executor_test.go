@@ -1205,3 +1205,93 @@ func TestContainsError(t *testing.T) {
 		t.Error("empty slice should not contain any error")
 	}
 }
+
+func TestDAGExecutor_BatcherRoutesOnRelease(t *testing.T) {
+	t.Parallel()
+
+	for _, released := range []bool{true, false} {
+		registry := executor.NewManager()
+		registry.Register(NodeTypeBatcher, &mockExecutor{
+			executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+				return map[string]any{"released": released, "batch_id": "b1"}, nil
+			},
+		})
+		registry.Register("test", &mockExecutor{
+			executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+				return map[string]any{}, nil
+			},
+		})
+
+		workflow := &models.Workflow{
+			ID: "wf-batch",
+			Nodes: []*models.Node{
+				{ID: "batch", Name: "Batch", Type: NodeTypeBatcher},
+				{ID: "send", Name: "Send", Type: "test"},
+				{ID: "ack", Name: "Ack", Type: "test"},
+			},
+			Edges: []*models.Edge{
+				{ID: "e1", From: "batch", To: "send", SourceHandle: SourceHandleReleased},
+				{ID: "e2", From: "batch", To: "ack", SourceHandle: SourceHandlePending},
+			},
+		}
+
+		dagExec := NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), NewNoOpNotifier(), nil)
+		execState := NewExecutionState("exec-batch", "wf-batch", workflow, map[string]any{}, nil)
+		if err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions()); err != nil {
+			t.Fatalf("execution failed: %v", err)
+		}
+
+		sendWant, ackWant := models.NodeExecutionStatusCompleted, models.NodeExecutionStatusSkipped
+		if !released {
+			sendWant, ackWant = ackWant, sendWant
+		}
+		if got, _ := execState.GetNodeStatus("send"); got != sendWant {
+			t.Errorf("released=%v: expected send %s, got %s", released, sendWant, got)
+		}
+		if got, _ := execState.GetNodeStatus("ack"); got != ackWant {
+			t.Errorf("released=%v: expected ack %s, got %s", released, ackWant, got)
+		}
+	}
+}
+
+func TestDAGExecutor_ValidateRoutesInvalidDataToErrorEdges(t *testing.T) {
+	t.Parallel()
+
+	registry := executor.NewManager()
+	registry.Register(NodeTypeValidate, &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			return map[string]any{"valid": false, "violations": []any{map[string]any{"path": "$"}}}, nil
+		},
+	})
+	registry.Register("test", &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			return map[string]any{}, nil
+		},
+	})
+
+	workflow := &models.Workflow{
+		ID: "wf-validate",
+		Nodes: []*models.Node{
+			{ID: "check", Name: "Check", Type: NodeTypeValidate, Config: map[string]any{"on_invalid": "route"}},
+			{ID: "process", Name: "Process", Type: "test"},
+			{ID: "reject", Name: "Reject", Type: "test"},
+		},
+		Edges: []*models.Edge{
+			{ID: "e1", From: "check", To: "process"},
+			{ID: "e2", From: "check", To: "reject", SourceHandle: SourceHandleError},
+		},
+	}
+
+	dagExec := NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), NewNoOpNotifier(), nil)
+	execState := NewExecutionState("exec-validate", "wf-validate", workflow, map[string]any{}, nil)
+	if err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions()); err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	if got, _ := execState.GetNodeStatus("process"); got != models.NodeExecutionStatusSkipped {
+		t.Errorf("expected process skipped, got %s", got)
+	}
+	if got, _ := execState.GetNodeStatus("reject"); got != models.NodeExecutionStatusCompleted {
+		t.Errorf("expected reject completed, got %s", got)
+	}
+}
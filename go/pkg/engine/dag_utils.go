@@ -8,11 +8,12 @@ import (
 
 // DAG represents workflow graph with indexed lookups.
 type DAG struct {
-	Nodes     map[string]*models.Node
-	Edges     map[string][]string // nodeID -> []childNodeIDs
-	InDegree  map[string]int      // nodeID -> number of parents
-	Index     *DAGIndex           // Indexed lookups for O(1) access
-	LoopEdges []*models.Edge      // Loop (back) edges excluded from topological sort
+	Nodes             map[string]*models.Node
+	Edges             map[string][]string // nodeID -> []childNodeIDs
+	InDegree          map[string]int      // nodeID -> number of parents
+	Index             *DAGIndex           // Indexed lookups for O(1) access
+	LoopEdges         []*models.Edge      // Loop (back) edges excluded from topological sort
+	CompensationEdges []*models.Edge      // Edges to compensation nodes, which are left out of Nodes
 }
 
 // DAGIndex provides O(1) lookups for common operations.
@@ -49,6 +50,13 @@ func BuildDAG(workflow *models.Workflow) *DAG {
 			dag.LoopEdges = append(dag.LoopEdges, edge)
 			continue
 		}
+		// Compensation nodes only run when a later node fails, so they are not scheduled
+		if edge.IsCompensation() {
+			dag.CompensationEdges = append(dag.CompensationEdges, edge)
+			delete(dag.Nodes, edge.To)
+			delete(dag.InDegree, edge.To)
+			continue
+		}
 
 		dag.Edges[edge.From] = append(dag.Edges[edge.From], edge.To)
 		dag.InDegree[edge.To]++
@@ -128,16 +136,22 @@ func FlattenWaves(waves [][]*models.Node) []string {
 	return result
 }
 
-// FindLeafNodes finds nodes with no outgoing edges.
+// FindLeafNodes finds nodes with no outgoing edges. Compensation edges and nodes are not
+// considered.
 func FindLeafNodes(workflow *models.Workflow) []*models.Node {
 	hasOutgoing := make(map[string]bool)
+	compensation := make(map[string]bool)
 	for _, edge := range workflow.Edges {
+		if edge.IsCompensation() {
+			compensation[edge.To] = true
+			continue
+		}
 		hasOutgoing[edge.From] = true
 	}
 
 	var leaves []*models.Node
 	for _, node := range workflow.Nodes {
-		if !hasOutgoing[node.ID] {
+		if !hasOutgoing[node.ID] && !compensation[node.ID] {
 			leaves = append(leaves, node)
 		}
 	}
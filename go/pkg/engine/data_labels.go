@@ -0,0 +1,47 @@
+package engine
+
+import (
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// isRedacted reports whether the node's data labels keep its payloads out of observer events.
+// Labels are validated with the workflow, so a parse error is treated as unlabeled.
+func isRedacted(node *models.Node) bool {
+	policy, err := models.ParseNodeDataLabels(node.Config)
+	return err == nil && policy.Redact
+}
+
+// ObservableOutput returns the final execution output as it may be sent to observers: the
+// outputs of leaf nodes labeled pii or confidential are removed. The output itself is not modified.
+func ObservableOutput(workflow *models.Workflow, output map[string]any) map[string]any {
+	if workflow == nil || output == nil {
+		return output
+	}
+
+	leafNodes := FindLeafNodes(workflow)
+	if len(leafNodes) == 1 {
+		// A single leaf's output is the execution output itself
+		if isRedacted(leafNodes[0]) {
+			return nil
+		}
+		return output
+	}
+
+	var observable map[string]any
+	for _, node := range leafNodes {
+		if _, ok := output[node.ID]; !ok || !isRedacted(node) {
+			continue
+		}
+		if observable == nil {
+			observable = make(map[string]any, len(output))
+			for key, value := range output {
+				observable[key] = value
+			}
+		}
+		delete(observable, node.ID)
+	}
+	if observable == nil {
+		return output
+	}
+	return observable
+}
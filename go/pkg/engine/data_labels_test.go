@@ -0,0 +1,99 @@
+package engine
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+func TestDAGExecutor_RedactsLabeledNodeEvents(t *testing.T) {
+	t.Parallel()
+
+	streamed := make(map[string]bool)
+	mockExec := &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			nodeID := config["nodeID"].(string)
+			if onDelta := executor.OutputDeltaFunc(ctx); onDelta != nil {
+				streamed[nodeID] = true
+				onDelta("chunk")
+			}
+			return map[string]any{"email": "jane@example.com"}, nil
+		},
+	}
+
+	registry := executor.NewManager()
+	registry.Register("test", mockExec)
+	recorder := &recordingNotifier{}
+	dagExec := NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), recorder, NewNilWorkflowLoader())
+
+	workflow := &models.Workflow{
+		ID: "wf-labels",
+		Nodes: []*models.Node{
+			{ID: "lookup", Name: "Lookup", Type: "test", Config: map[string]any{"nodeID": "lookup", "data_labels": []any{"pii", "retain-7d"}}},
+			{ID: "notify", Name: "Notify", Type: "test", Config: map[string]any{"nodeID": "notify"}},
+		},
+		Edges: []*models.Edge{{ID: "e1", From: "lookup", To: "notify"}},
+	}
+	execState := NewExecutionState("exec-1", "wf-labels", workflow, map[string]any{}, nil)
+
+	if err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions()); err != nil {
+		t.Fatalf("DAG execution failed: %v", err)
+	}
+
+	if streamed["lookup"] || !streamed["notify"] {
+		t.Errorf("expected only the unlabeled node to stream, got %v", streamed)
+	}
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	completed := make(map[string]ExecutionEvent)
+	for _, event := range recorder.events {
+		if event.Type == EventTypeNodeOutputDelta && event.NodeID == "lookup" {
+			t.Error("expected no output delta events for the labeled node")
+		}
+		if event.Type == EventTypeNodeCompleted {
+			completed[event.NodeID] = event
+		}
+	}
+	if event := completed["lookup"]; event.Output != nil || event.Metadata["redacted"] != true {
+		t.Errorf("expected redacted node.completed event for lookup, got output %v metadata %v", event.Output, event.Metadata)
+	}
+	if event := completed["notify"]; event.Output == nil {
+		t.Error("expected node.completed output for the unlabeled node")
+	}
+
+	// The labels only affect events; downstream nodes still receive the data
+	if output, ok := execState.GetNodeOutput("lookup"); !ok || output.(map[string]any)["email"] != "jane@example.com" {
+		t.Errorf("expected lookup output kept in execution state, got %v", output)
+	}
+}
+
+func TestObservableOutput(t *testing.T) {
+	t.Parallel()
+
+	labeled := &models.Node{ID: "a", Config: map[string]any{"data_labels": []string{"confidential"}}}
+	plain := &models.Node{ID: "b", Config: map[string]any{"data_labels": []string{"no-export"}}}
+
+	single := &models.Workflow{Nodes: []*models.Node{labeled}}
+	if got := ObservableOutput(single, map[string]any{"secret": 1}); got != nil {
+		t.Errorf("expected single labeled leaf output to be dropped, got %v", got)
+	}
+
+	multi := &models.Workflow{Nodes: []*models.Node{labeled, plain}}
+	output := map[string]any{"a": map[string]any{"secret": 1}, "b": map[string]any{"ok": true}}
+	got := ObservableOutput(multi, output)
+	if want := map[string]any{"b": map[string]any{"ok": true}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if _, ok := output["a"]; !ok {
+		t.Error("ObservableOutput must not modify the output")
+	}
+
+	unlabeled := &models.Workflow{Nodes: []*models.Node{{ID: "c"}}}
+	if got := ObservableOutput(unlabeled, map[string]any{"x": 1}); got["x"] != 1 {
+		t.Errorf("expected unlabeled output unchanged, got %v", got)
+	}
+}
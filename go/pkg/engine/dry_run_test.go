@@ -0,0 +1,119 @@
+package engine
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// pureMockExecutor is a mockExecutor that declares itself free of side effects.
+type pureMockExecutor struct {
+	mockExecutor
+}
+
+func (p *pureMockExecutor) SideEffectFree() bool { return true }
+
+// newDryRunTestExecutor registers a side-effecting "http" executor that counts its calls and
+// a pure "shape" executor that echoes its resolved config.
+func newDryRunTestExecutor() (*DAGExecutor, *atomic.Int32) {
+	var httpCalls atomic.Int32
+	registry := executor.NewManager()
+	registry.Register("http", &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			httpCalls.Add(1)
+			return map[string]any{"status": 500}, nil
+		},
+	})
+	registry.Register("shape", &pureMockExecutor{mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			return map[string]any{"label": config["label"]}, nil
+		},
+	}})
+
+	dagExec := NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), NewNoOpNotifier(), NewNilWorkflowLoader())
+	return dagExec, &httpCalls
+}
+
+// routingWorkflow calls an API and goes to ok or retry depending on the response status.
+func routingWorkflow() *models.Workflow {
+	return &models.Workflow{
+		ID: "wf-1",
+		Nodes: []*models.Node{
+			{ID: "call", Name: "call", Type: "http", Config: map[string]any{"url": "https://api.example.com/{{input.path}}"}},
+			{ID: "ok", Name: "ok", Type: "shape", Config: map[string]any{"label": "status {{input.status}}"}},
+			{ID: "retry", Name: "retry", Type: "shape", Config: map[string]any{"label": "retry"}},
+		},
+		Edges: []*models.Edge{
+			{ID: "e1", From: "call", To: "ok", Condition: "output.status == 200"},
+			{ID: "e2", From: "call", To: "retry", Condition: "output.status != 200"},
+		},
+	}
+}
+
+func TestDryRun_ShouldStubSideEffectsWithMockOutputs(t *testing.T) {
+	t.Parallel()
+
+	dagExec, httpCalls := newDryRunTestExecutor()
+	execState := NewExecutionState("exec-1", "wf-1", routingWorkflow(), map[string]any{"path": "orders"}, map[string]any{})
+	opts := DefaultExecutionOptions()
+	opts.DryRun = true
+	opts.MockOutputs = map[string]any{"call": map[string]any{"status": 200}}
+
+	if err := dagExec.Execute(context.Background(), execState, opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if httpCalls.Load() != 0 {
+		t.Errorf("expected the http executor not to run, it ran %d times", httpCalls.Load())
+	}
+	if annotations, _ := execState.GetNodeAnnotations("call"); annotations["dry_run_stubbed"] != true {
+		t.Errorf("expected call to be annotated as stubbed, got %v", annotations)
+	}
+	if config, _ := execState.GetNodeResolvedConfig("call"); config["url"] != "https://api.example.com/orders" {
+		t.Errorf("expected the url template to be resolved, got %v", config["url"])
+	}
+
+	// The edge conditions see the mock output
+	if status, _ := execState.GetNodeStatus("ok"); status != models.NodeExecutionStatusCompleted {
+		t.Errorf("expected ok to run, got %s", status)
+	}
+	if status, _ := execState.GetNodeStatus("retry"); status != models.NodeExecutionStatusSkipped {
+		t.Errorf("expected retry to be skipped, got %s", status)
+	}
+
+	// Pure executors run for real
+	output, _ := execState.GetNodeOutput("ok")
+	if label := output.(map[string]any)["label"]; label != "status 200" {
+		t.Errorf("expected ok to run with the mock output as input, got %v", label)
+	}
+	if annotations, _ := execState.GetNodeAnnotations("ok"); annotations["dry_run_stubbed"] == true {
+		t.Error("expected ok not to be stubbed")
+	}
+}
+
+func TestDryRun_ShouldDefaultMissingMockOutputToEmptyObject(t *testing.T) {
+	t.Parallel()
+
+	dagExec, httpCalls := newDryRunTestExecutor()
+	execState := NewExecutionState("exec-1", "wf-1", routingWorkflow(), map[string]any{}, map[string]any{})
+	opts := DefaultExecutionOptions()
+	opts.DryRun = true
+
+	if err := dagExec.Execute(context.Background(), execState, opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if httpCalls.Load() != 0 {
+		t.Errorf("expected the http executor not to run, it ran %d times", httpCalls.Load())
+	}
+	output, _ := execState.GetNodeOutput("call")
+	if stub, ok := output.(map[string]any); !ok || len(stub) != 0 {
+		t.Errorf("expected an empty object, got %v", output)
+	}
+	if status, _ := execState.GetNodeStatus("retry"); status != models.NodeExecutionStatusCompleted {
+		t.Errorf("expected retry to run on the empty output, got %s", status)
+	}
+}
@@ -21,6 +21,7 @@ type ExecutionEvent struct {
 	Timestamp   time.Time
 	Input       map[string]any
 	Variables   map[string]any
+	Metadata    map[string]any
 
 	// Loop-related fields
 	LoopEdgeID    string `json:"-"`
@@ -1,10 +1,13 @@
 package engine
 
 import (
+	"context"
 	"encoding/json"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/smilemakc/mbflow/go/pkg/executor"
 	"github.com/smilemakc/mbflow/go/pkg/models"
 )
 
@@ -18,6 +21,9 @@ type ExecutionState struct {
 	Variables   map[string]any
 	Resources   map[string]any // alias -> resource data for template resolution
 
+	// Propagation is passed to every executor and inherited by sub-workflow executions
+	Propagation executor.Propagation
+
 	// Node execution tracking
 	NodeOutputs         map[string]any                        // nodeID -> output
 	NodeInputs          map[string]any                        // nodeID -> input (passed to executor)
@@ -27,11 +33,42 @@ type ExecutionState struct {
 	NodeEndTimes        map[string]time.Time                  // nodeID -> end time
 	NodeConfigs         map[string]map[string]any             // nodeID -> original config
 	NodeResolvedConfigs map[string]map[string]any             // nodeID -> resolved config
+	NodeAssertions      map[string][]*AssertionFailure        // nodeID -> failed output assertions
+	NodeAnnotations     map[string]map[string]any             // nodeID -> annotations added by node hooks
+	Suspensions         map[string]*Suspension                // nodeID -> suspension of a node waiting to resume
 
 	// Loop tracking
 	LoopIterations map[string]int // edgeID -> iteration count
 	LoopInputs     map[string]any // nodeID -> loop input override
 
+	// deferred holds nodes not run because a node upstream of them is suspended
+	deferred map[string]bool
+
+	// wave is the index of the wave being run
+	wave atomic.Int32
+
+	// preempted is set by Preempt and paused by Pause; interrupted counts the nodes left
+	// pending because of either
+	preempted     atomic.Bool
+	preemptReason string
+	paused        atomic.Bool
+	pauseReason   string
+	interrupted   atomic.Int32
+
+	// cancelled is set by Cancel; abort cancels the context of the running Execute
+	cancelled    atomic.Bool
+	cancelReason string
+	abort        context.CancelCauseFunc
+
+	// breakpointsHit holds the breakpoint nodes the execution stopped at; released ones run
+	// once when it resumes, with their replacement inputs if any
+	breakpointsHit      map[string]bool
+	releasedBreakpoints map[string]bool
+	breakpointInputs    map[string]map[string]any
+
+	// typeLimiter holds the NodeTypeConcurrency slots, shared with sub-workflow executions
+	typeLimiter *nodeTypeLimiter
+
 	// Sub-workflow parent tracking
 	ParentExecutionID string
 	ParentNodeID      string
@@ -58,6 +95,13 @@ func NewExecutionState(executionID, workflowID string, workflow *models.Workflow
 		NodeEndTimes:        make(map[string]time.Time),
 		NodeConfigs:         make(map[string]map[string]any),
 		NodeResolvedConfigs: make(map[string]map[string]any),
+		NodeAssertions:      make(map[string][]*AssertionFailure),
+		NodeAnnotations:     make(map[string]map[string]any),
+		Suspensions:         make(map[string]*Suspension),
+		deferred:            make(map[string]bool),
+		breakpointsHit:      make(map[string]bool),
+		releasedBreakpoints: make(map[string]bool),
+		breakpointInputs:    make(map[string]map[string]any),
 		LoopIterations:      make(map[string]int),
 		LoopInputs:          make(map[string]any),
 	}
@@ -183,6 +227,67 @@ func (es *ExecutionState) GetNodeResolvedConfig(nodeID string) (map[string]any,
 	return config, ok
 }
 
+// SetNodeAssertionFailures safely sets the failed output assertions of a node.
+func (es *ExecutionState) SetNodeAssertionFailures(nodeID string, failures []*AssertionFailure) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	es.NodeAssertions[nodeID] = failures
+}
+
+// GetNodeAssertionFailures safely gets the failed output assertions of a node.
+func (es *ExecutionState) GetNodeAssertionFailures(nodeID string) ([]*AssertionFailure, bool) {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+	failures, ok := es.NodeAssertions[nodeID]
+	return failures, ok
+}
+
+// HasRoutedAssertionFailure reports whether a node failed an assertion with the route action.
+func (es *ExecutionState) HasRoutedAssertionFailure(nodeID string) bool {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+	for _, failure := range es.NodeAssertions[nodeID] {
+		if failure.Action == models.AssertionActionRoute {
+			return true
+		}
+	}
+	return false
+}
+
+// SetNodeAnnotations safely sets the hook annotations of a node.
+func (es *ExecutionState) SetNodeAnnotations(nodeID string, annotations map[string]any) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	es.NodeAnnotations[nodeID] = annotations
+}
+
+// GetNodeAnnotations safely gets the hook annotations of a node.
+func (es *ExecutionState) GetNodeAnnotations(nodeID string) (map[string]any, bool) {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+	annotations, ok := es.NodeAnnotations[nodeID]
+	return annotations, ok
+}
+
+// NodeMetadata returns the metadata recorded for a node execution
+// (failed assertions and hook annotations), or nil if there is none.
+func (es *ExecutionState) NodeMetadata(nodeID string) map[string]any {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+
+	var metadata map[string]any
+	if failures, ok := es.NodeAssertions[nodeID]; ok {
+		metadata = map[string]any{"assertion_failures": failures}
+	}
+	if annotations, ok := es.NodeAnnotations[nodeID]; ok {
+		if metadata == nil {
+			metadata = make(map[string]any)
+		}
+		metadata["annotations"] = annotations
+	}
+	return metadata
+}
+
 // GetLoopIteration returns the current iteration count for a loop edge.
 func (es *ExecutionState) GetLoopIteration(edgeID string) int {
 	es.mu.RLock()
@@ -220,6 +325,38 @@ func (es *ExecutionState) ClearLoopInput(nodeID string) {
 	delete(es.LoopInputs, nodeID)
 }
 
+// LoopState returns copies of the loop iteration counts and loop input overrides.
+func (es *ExecutionState) LoopState() (map[string]int, map[string]any) {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+	iterations := make(map[string]int, len(es.LoopIterations))
+	for edgeID, count := range es.LoopIterations {
+		iterations[edgeID] = count
+	}
+	inputs := make(map[string]any, len(es.LoopInputs))
+	for nodeID, input := range es.LoopInputs {
+		inputs[nodeID] = input
+	}
+	return iterations, inputs
+}
+
+// RestoreLoopState sets the loop iteration counts and loop input overrides saved by LoopState.
+func (es *ExecutionState) RestoreLoopState(iterations map[string]int, inputs map[string]any) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	for edgeID, count := range iterations {
+		es.LoopIterations[edgeID] = count
+	}
+	for nodeID, input := range inputs {
+		es.LoopInputs[nodeID] = input
+	}
+}
+
+// CurrentWave returns the index of the wave the execution is running, or ran last.
+func (es *ExecutionState) CurrentWave() int {
+	return int(es.wave.Load())
+}
+
 // ResetNodeForLoop clears all execution state for a node so it can be re-executed in a loop.
 func (es *ExecutionState) ResetNodeForLoop(nodeID string) {
 	es.mu.Lock()
@@ -232,6 +369,8 @@ func (es *ExecutionState) ResetNodeForLoop(nodeID string) {
 	delete(es.NodeEndTimes, nodeID)
 	delete(es.NodeConfigs, nodeID)
 	delete(es.NodeResolvedConfigs, nodeID)
+	delete(es.NodeAssertions, nodeID)
+	delete(es.NodeAnnotations, nodeID)
 }
 
 // ClearNodeOutput removes output for a specific node (for memory optimization).
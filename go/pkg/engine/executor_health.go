@@ -0,0 +1,77 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// UnhealthyExecutorPolicy decides what happens to a node whose executor failed its latest
+// health check.
+type UnhealthyExecutorPolicy string
+
+const (
+	// UnhealthyExecutorFail fails the node right away
+	UnhealthyExecutorFail UnhealthyExecutorPolicy = "fail"
+
+	// UnhealthyExecutorQueue holds the node until the executor is healthy again, failing it
+	// after the queue timeout
+	UnhealthyExecutorQueue UnhealthyExecutorPolicy = "queue"
+)
+
+// ExecutorHealthGate keeps nodes off executors that failed their latest health check.
+type ExecutorHealthGate struct {
+	Monitor      *executor.HealthMonitor
+	Policy       UnhealthyExecutorPolicy
+	QueueTimeout time.Duration // Longest a queued node waits (default 5m)
+	PollInterval time.Duration // How often a queued node rechecks the monitor (default 1s)
+}
+
+// Wait returns nil once the executor of the node type is healthy for a node with the
+// resolved config: for executors checked per target, such as pooled database clients, only
+// the target the node connects to counts. An unhealthy executor fails the node with
+// ErrExecutorUnhealthy, right away or, with the queue policy, when it stays unhealthy for the
+// queue timeout.
+func (g *ExecutorHealthGate) Wait(ctx context.Context, nodeType string, config map[string]any) error {
+	if g.Monitor.HealthyFor(nodeType, config) {
+		return nil
+	}
+	if g.Policy != UnhealthyExecutorQueue {
+		return g.unhealthyError(nodeType, config)
+	}
+
+	queueTimeout := g.QueueTimeout
+	if queueTimeout <= 0 {
+		queueTimeout = 5 * time.Minute
+	}
+	pollInterval := g.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+
+	deadline := time.NewTimer(queueTimeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if g.Monitor.HealthyFor(nodeType, config) {
+				return nil
+			}
+		case <-deadline.C:
+			return fmt.Errorf("%w (queued for %s)", g.unhealthyError(nodeType, config), queueTimeout)
+		case <-ctx.Done():
+			return fmt.Errorf("cancelled while waiting for healthy %s executor: %w", nodeType, ctx.Err())
+		}
+	}
+}
+
+func (g *ExecutorHealthGate) unhealthyError(nodeType string, config map[string]any) error {
+	status, _ := g.Monitor.StatusFor(nodeType, config)
+	return fmt.Errorf("%w: %s: %s", models.ErrExecutorUnhealthy, nodeType, status.Error)
+}
@@ -0,0 +1,141 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// flakyExecutor is a mock executor whose health check fails until healthy is set.
+type flakyExecutor struct {
+	mockExecutor
+	healthy atomic.Bool
+}
+
+func (e *flakyExecutor) Health(ctx context.Context) error {
+	if e.healthy.Load() {
+		return nil
+	}
+	return errors.New("provider unreachable")
+}
+
+func newHealthGateTest(policy UnhealthyExecutorPolicy) (*NodeExecutor, *flakyExecutor, *executor.HealthMonitor) {
+	registry := executor.NewManager()
+	flaky := &flakyExecutor{mockExecutor: mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			return "ok", nil
+		},
+	}}
+	registry.Register("llm", flaky)
+
+	monitor := executor.NewHealthMonitor(registry, time.Second)
+	monitor.Check(context.Background())
+
+	nodeExec := NewNodeExecutor(registry)
+	nodeExec.SetHealthGate(&ExecutorHealthGate{
+		Monitor:      monitor,
+		Policy:       policy,
+		QueueTimeout: time.Second,
+		PollInterval: 5 * time.Millisecond,
+	})
+	return nodeExec, flaky, monitor
+}
+
+func TestNodeExecutor_HealthGateFails(t *testing.T) {
+	t.Parallel()
+
+	nodeExec, _, _ := newHealthGateTest(UnhealthyExecutorFail)
+	_, err := nodeExec.Execute(context.Background(), &NodeContext{Node: &models.Node{ID: "ask", Type: "llm"}})
+	if !errors.Is(err, models.ErrExecutorUnhealthy) {
+		t.Fatalf("expected ErrExecutorUnhealthy, got %v", err)
+	}
+}
+
+func TestNodeExecutor_HealthGateQueuesUntilHealthy(t *testing.T) {
+	t.Parallel()
+
+	nodeExec, flaky, monitor := newHealthGateTest(UnhealthyExecutorQueue)
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		flaky.healthy.Store(true)
+		monitor.Check(context.Background())
+	}()
+
+	result, err := nodeExec.Execute(context.Background(), &NodeContext{Node: &models.Node{ID: "ask", Type: "llm"}})
+	if err != nil {
+		t.Fatalf("expected queued node to run once healthy, got %v", err)
+	}
+	if result.Output != "ok" {
+		t.Errorf("expected output ok, got %v", result.Output)
+	}
+}
+
+func TestExecutorHealthGate_QueueTimeout(t *testing.T) {
+	t.Parallel()
+
+	nodeExec, _, _ := newHealthGateTest(UnhealthyExecutorQueue)
+	nodeExec.healthGate.QueueTimeout = 20 * time.Millisecond
+
+	_, err := nodeExec.Execute(context.Background(), &NodeContext{Node: &models.Node{ID: "ask", Type: "llm"}})
+	if !errors.Is(err, models.ErrExecutorUnhealthy) {
+		t.Fatalf("expected ErrExecutorUnhealthy after queue timeout, got %v", err)
+	}
+}
+
+// pooledFlakyExecutor is a mock executor pooling clients by host, of which only "down" fails its health check.
+type pooledFlakyExecutor struct {
+	mockExecutor
+}
+
+func (e *pooledFlakyExecutor) Health(ctx context.Context) error {
+	return errors.New("down unreachable")
+}
+
+func (e *pooledFlakyExecutor) HealthTargets(ctx context.Context) map[string]error {
+	return map[string]error{"up": nil, "down": errors.New("down unreachable")}
+}
+
+func (e *pooledFlakyExecutor) HealthTarget(config map[string]any) string {
+	host, _ := config["host"].(string)
+	return host
+}
+
+func TestNodeExecutor_HealthGatePerTarget(t *testing.T) {
+	t.Parallel()
+
+	registry := executor.NewManager()
+	registry.Register("redis", &pooledFlakyExecutor{mockExecutor: mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			return "ok", nil
+		},
+	}})
+	monitor := executor.NewHealthMonitor(registry, time.Second)
+	monitor.Check(context.Background())
+
+	nodeExec := NewNodeExecutor(registry)
+	nodeExec.SetHealthGate(&ExecutorHealthGate{Monitor: monitor, Policy: UnhealthyExecutorFail})
+
+	result, err := nodeExec.Execute(context.Background(), &NodeContext{
+		Node: &models.Node{ID: "up", Type: "redis", Config: map[string]any{"host": "up"}},
+	})
+	if err != nil {
+		t.Fatalf("expected the node on the healthy pool to run, got %v", err)
+	}
+	if result.Output != "ok" {
+		t.Errorf("expected output ok, got %v", result.Output)
+	}
+
+	// The target is resolved from templates before the gate
+	_, err = nodeExec.Execute(context.Background(), &NodeContext{
+		Node:              &models.Node{ID: "down", Type: "redis", Config: map[string]any{"host": "{{env.host}}"}},
+		WorkflowVariables: map[string]any{"host": "down"},
+	})
+	if !errors.Is(err, models.ErrExecutorUnhealthy) {
+		t.Fatalf("expected ErrExecutorUnhealthy for the node on the unhealthy pool, got %v", err)
+	}
+}
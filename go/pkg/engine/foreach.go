@@ -0,0 +1,62 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// NodeTypeForEach is a fan-out node that runs its inline body once per item of an array.
+//
+// Config:
+//   - for_each: Path of the array in the node input, e.g. "input.orders" (required)
+//   - body: The branch run per item, {"nodes": [...], "edges": [...]} (required)
+//   - item_var, max_parallelism, on_error, timeout_per_item, chunk_size: As for sub_workflow
+//
+// Each branch receives {item_var: item, "index", "total"} merged over the execution input.
+// The output has the sub_workflow fields plus "results", the branch outputs in item order
+// (nil for branches that did not complete).
+const NodeTypeForEach = "foreach"
+
+// parseInlineBody returns the inline body workflow of a foreach or while node.
+func parseInlineBody(node *models.Node) (*models.Workflow, error) {
+	raw, ok := node.Config["body"]
+	if !ok || raw == nil {
+		return nil, fmt.Errorf("body is required")
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid body: %w", err)
+	}
+	var body struct {
+		Nodes []*models.Node `json:"nodes"`
+		Edges []*models.Edge `json:"edges"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, fmt.Errorf("invalid body: %w", err)
+	}
+	if len(body.Nodes) == 0 {
+		return nil, fmt.Errorf("body must have at least one node")
+	}
+
+	return &models.Workflow{
+		ID:    node.ID,
+		Name:  node.Name,
+		Nodes: body.Nodes,
+		Edges: body.Edges,
+	}, nil
+}
+
+// forEachResults returns the outputs of the branches in item order, nil for branches that
+// did not complete.
+func forEachResults(results []subWorkflowItemResult) []any {
+	outputs := make([]any, len(results))
+	for i, r := range results {
+		if r.Status == "completed" {
+			outputs[i] = r.Output
+		}
+	}
+	return outputs
+}
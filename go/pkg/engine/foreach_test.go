@@ -0,0 +1,159 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+func forEachTestWorkflow(config map[string]any) *models.Workflow {
+	return &models.Workflow{
+		ID:   "parent-wf",
+		Name: "Parent",
+		Nodes: []*models.Node{
+			{ID: "each", Name: "Each", Type: NodeTypeForEach, Config: config},
+		},
+	}
+}
+
+func TestForEach_CollectsResultsInOrder(t *testing.T) {
+	t.Parallel()
+
+	var running, maxRunning int32
+	registry := executor.NewManager()
+	registry.Register("transform", &executor.ExecutorFunc{
+		ExecuteFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			n := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				peak := atomic.LoadInt32(&maxRunning)
+				if n <= peak || atomic.CompareAndSwapInt32(&maxRunning, peak, n) {
+					break
+				}
+			}
+			item := input.(map[string]any)["item"].(float64)
+			// Later items finish first
+			time.Sleep(time.Duration(10-item) * time.Millisecond)
+			return item * 2, nil
+		},
+	})
+	dagExec := NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), NewNoOpNotifier(), nil)
+
+	workflow := forEachTestWorkflow(map[string]any{
+		"for_each":        "input.items",
+		"max_parallelism": 2,
+		"body": map[string]any{
+			"nodes": []any{
+				map[string]any{"id": "double", "name": "Double", "type": "transform"},
+			},
+		},
+	})
+	input := map[string]any{"items": []any{float64(1), float64(2), float64(3), float64(4), float64(5)}}
+	execState := NewExecutionState("exec-1", "parent-wf", workflow, input, nil)
+
+	if err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions()); err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	output, _ := execState.GetNodeOutput("each")
+	results, ok := output.(map[string]any)["results"].([]any)
+	if !ok {
+		t.Fatalf("expected results array, got: %v", output)
+	}
+	want := []float64{2, 4, 6, 8, 10}
+	if len(results) != len(want) {
+		t.Fatalf("expected %d results, got %d", len(want), len(results))
+	}
+	for i, w := range want {
+		if results[i] != w {
+			t.Errorf("results[%d] = %v, want %v", i, results[i], w)
+		}
+	}
+	if peak := atomic.LoadInt32(&maxRunning); peak > 2 {
+		t.Errorf("expected at most 2 concurrent branches, got %d", peak)
+	}
+}
+
+func TestForEach_CollectPartialLeavesFailedResultsNil(t *testing.T) {
+	t.Parallel()
+
+	registry := executor.NewManager()
+	registry.Register("transform", &executor.ExecutorFunc{
+		ExecuteFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			item := input.(map[string]any)["order"].(string)
+			if item == "bad" {
+				return nil, fmt.Errorf("invalid order")
+			}
+			return "ok:" + item, nil
+		},
+	})
+	dagExec := NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), NewNoOpNotifier(), nil)
+
+	workflow := forEachTestWorkflow(map[string]any{
+		"for_each": "input.orders",
+		"item_var": "order",
+		"on_error": SubWorkflowOnErrorCollect,
+		"body": map[string]any{
+			"nodes": []*models.Node{{ID: "check", Name: "Check", Type: "transform"}},
+		},
+	})
+	input := map[string]any{"orders": []string{"a", "bad", "c"}}
+	execState := NewExecutionState("exec-1", "parent-wf", workflow, input, nil)
+
+	if err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions()); err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	output, _ := execState.GetNodeOutput("each")
+	results := output.(map[string]any)["results"].([]any)
+	if len(results) != 3 || results[0] != "ok:a" || results[1] != nil || results[2] != "ok:c" {
+		t.Errorf("unexpected results: %v", results)
+	}
+	summary := output.(map[string]any)["summary"].(map[string]any)
+	if summary["failed"] != 1 {
+		t.Errorf("expected 1 failed branch, got %v", summary["failed"])
+	}
+}
+
+func TestForEach_EmptyArray(t *testing.T) {
+	t.Parallel()
+
+	dagExec := NewDAGExecutor(NewNodeExecutor(executor.NewManager()), NewExprConditionEvaluator(), NewNoOpNotifier(), nil)
+	workflow := forEachTestWorkflow(map[string]any{
+		"for_each": "input.items",
+		"body": map[string]any{
+			"nodes": []any{map[string]any{"id": "noop", "name": "Noop", "type": "transform"}},
+		},
+	})
+	execState := NewExecutionState("exec-1", "parent-wf", workflow, map[string]any{"items": []any{}}, nil)
+
+	if err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions()); err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	output, _ := execState.GetNodeOutput("each")
+	if results := output.(map[string]any)["results"].([]any); len(results) != 0 {
+		t.Errorf("expected no results, got %v", results)
+	}
+}
+
+func TestForEach_RequiresBody(t *testing.T) {
+	t.Parallel()
+
+	tests := []map[string]any{
+		{"for_each": "input.items"},
+		{"for_each": "input.items", "body": map[string]any{"nodes": []any{}}},
+		{"for_each": "input.items", "body": "not a workflow"},
+	}
+	for _, config := range tests {
+		_, err := parseSubWorkflowConfig(&models.Node{ID: "each", Type: NodeTypeForEach, Config: config})
+		if err == nil {
+			t.Errorf("expected error for config %v", config)
+		}
+	}
+}
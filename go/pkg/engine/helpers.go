@@ -81,13 +81,14 @@ func GetNodePriority(node *models.Node) int {
 	return DefaultNodePriority
 }
 
-// GetNodeTimeout extracts timeout from node config, returns 0 if not found.
+// GetNodeTimeout extracts the engine timeout of a node in milliseconds from its config
+// (models.NodeTimeoutConfigKey), returns 0 if not found.
 func GetNodeTimeout(node *models.Node) int64 {
 	if node.Config == nil {
 		return 0
 	}
 
-	if timeout, ok := node.Config["timeout"]; ok {
+	if timeout, ok := node.Config[models.NodeTimeoutConfigKey]; ok {
 		switch t := timeout.(type) {
 		case int:
 			return int64(t)
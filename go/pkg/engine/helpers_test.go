@@ -240,7 +240,7 @@ func TestGetNodeTimeout(t *testing.T) {
 			name: "node with int timeout",
 			node: &models.Node{
 				ID:     "node-1",
-				Config: map[string]any{"timeout": 5000},
+				Config: map[string]any{models.NodeTimeoutConfigKey: 5000},
 			},
 			expected: 5000,
 		},
@@ -248,7 +248,7 @@ func TestGetNodeTimeout(t *testing.T) {
 			name: "node with int64 timeout",
 			node: &models.Node{
 				ID:     "node-2",
-				Config: map[string]any{"timeout": int64(10000)},
+				Config: map[string]any{models.NodeTimeoutConfigKey: int64(10000)},
 			},
 			expected: 10000,
 		},
@@ -256,10 +256,18 @@ func TestGetNodeTimeout(t *testing.T) {
 			name: "node with float64 timeout",
 			node: &models.Node{
 				ID:     "node-3",
-				Config: map[string]any{"timeout": 3000.0},
+				Config: map[string]any{models.NodeTimeoutConfigKey: 3000.0},
 			},
 			expected: 3000,
 		},
+		{
+			name: "executor timeout is not the node timeout",
+			node: &models.Node{
+				ID:     "node-7",
+				Config: map[string]any{"timeout": 30},
+			},
+			expected: 0,
+		},
 		{
 			name: "node without timeout",
 			node: &models.Node{
@@ -280,7 +288,7 @@ func TestGetNodeTimeout(t *testing.T) {
 			name: "node with invalid timeout type",
 			node: &models.Node{
 				ID:     "node-6",
-				Config: map[string]any{"timeout": "5s"},
+				Config: map[string]any{models.NodeTimeoutConfigKey: "5s"},
 			},
 			expected: 0,
 		},
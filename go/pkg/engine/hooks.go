@@ -0,0 +1,88 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// NodeHook is engine middleware invoked around every node execution.
+// Hooks run on every attempt, after templates in the node config are resolved.
+// BeforeNode hooks run in registration order, AfterNode hooks in reverse order.
+type NodeHook interface {
+	// BeforeNode runs before the executor. It may modify the input and resolved config,
+	// short-circuit the executor with NodeHookContext.ShortCircuit, or return an error
+	// to fail the node without running it.
+	BeforeNode(ctx context.Context, hc *NodeHookContext) error
+
+	// AfterNode runs after the executor, or after a short-circuit. It may replace the
+	// output or the error. A returned error fails the node.
+	AfterNode(ctx context.Context, hc *NodeHookContext) error
+}
+
+// NodeHookFuncs adapts a pair of functions to NodeHook; either may be nil.
+type NodeHookFuncs struct {
+	Before func(ctx context.Context, hc *NodeHookContext) error
+	After  func(ctx context.Context, hc *NodeHookContext) error
+}
+
+// BeforeNode calls Before if set.
+func (f NodeHookFuncs) BeforeNode(ctx context.Context, hc *NodeHookContext) error {
+	if f.Before == nil {
+		return nil
+	}
+	return f.Before(ctx, hc)
+}
+
+// AfterNode calls After if set.
+func (f NodeHookFuncs) AfterNode(ctx context.Context, hc *NodeHookContext) error {
+	if f.After == nil {
+		return nil
+	}
+	return f.After(ctx, hc)
+}
+
+// NodeHookContext holds the node execution seen and modified by hooks.
+type NodeHookContext struct {
+	ExecutionID string
+	Node        *models.Node
+
+	// Input is the input passed to the executor.
+	Input map[string]any
+	// Config is the node config with templates resolved.
+	Config map[string]any
+
+	// Output is the executor output, available to AfterNode.
+	Output any
+	// Err is the executor error, available to AfterNode. Setting it to nil recovers the node.
+	Err error
+
+	shortCircuited bool
+	annotations    map[string]any
+}
+
+// ShortCircuit skips the executor and uses output as the node output.
+// Remaining BeforeNode hooks are not called; AfterNode hooks still run.
+func (hc *NodeHookContext) ShortCircuit(output any) {
+	hc.shortCircuited = true
+	hc.Output = output
+}
+
+// ShortCircuited reports whether a hook skipped the executor.
+func (hc *NodeHookContext) ShortCircuited() bool {
+	return hc.shortCircuited
+}
+
+// Annotate records a value on the node execution; annotations are stored under
+// "annotations" in the node execution metadata.
+func (hc *NodeHookContext) Annotate(key string, value any) {
+	if hc.annotations == nil {
+		hc.annotations = make(map[string]any)
+	}
+	hc.annotations[key] = value
+}
+
+// Annotations returns the annotations recorded so far.
+func (hc *NodeHookContext) Annotations() map[string]any {
+	return hc.annotations
+}
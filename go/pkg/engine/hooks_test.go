@@ -0,0 +1,241 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// newHookTestNodeExecutor returns a node executor whose "test" nodes echo their config and input.
+func newHookTestNodeExecutor(t *testing.T, calls *int, hooks ...NodeHook) *NodeExecutor {
+	t.Helper()
+
+	mockExec := &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			*calls++
+			return map[string]any{"config": config, "input": input}, nil
+		},
+	}
+
+	registry := executor.NewManager()
+	if err := registry.Register("test", mockExec); err != nil {
+		t.Fatalf("failed to register executor: %v", err)
+	}
+
+	nodeExec := NewNodeExecutor(registry)
+	nodeExec.Use(hooks...)
+	return nodeExec
+}
+
+func newHookTestNodeContext() *NodeContext {
+	return &NodeContext{
+		ExecutionID: "exec-1",
+		NodeID:      "node-1",
+		Node: &models.Node{
+			ID:     "node-1",
+			Name:   "Node",
+			Type:   "test",
+			Config: map[string]any{"region": "{{input.region}}"},
+		},
+		DirectParentOutput: map[string]any{"region": "us"},
+	}
+}
+
+func TestNodeHooks_MutateInputAndConfig(t *testing.T) {
+	t.Parallel()
+
+	var order []string
+	calls := 0
+	nodeExec := newHookTestNodeExecutor(t, &calls,
+		NodeHookFuncs{
+			Before: func(ctx context.Context, hc *NodeHookContext) error {
+				order = append(order, "before-1")
+				if hc.Config["region"] != "us" {
+					t.Errorf("expected resolved config, got %v", hc.Config)
+				}
+				hc.Config["region"] = "eu"
+				return nil
+			},
+			After: func(ctx context.Context, hc *NodeHookContext) error {
+				order = append(order, "after-1")
+				return nil
+			},
+		},
+		NodeHookFuncs{
+			Before: func(ctx context.Context, hc *NodeHookContext) error {
+				order = append(order, "before-2")
+				hc.Input = map[string]any{"redacted": true}
+				hc.Annotate("residency", "eu")
+				return nil
+			},
+			After: func(ctx context.Context, hc *NodeHookContext) error {
+				order = append(order, "after-2")
+				hc.Output = map[string]any{"wrapped": hc.Output}
+				return nil
+			},
+		},
+	)
+
+	result, err := nodeExec.Execute(context.Background(), newHookTestNodeContext())
+	if err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	if strings.Join(order, ",") != "before-1,before-2,after-2,after-1" {
+		t.Errorf("unexpected hook order: %v", order)
+	}
+	if calls != 1 {
+		t.Errorf("expected executor to run once, ran %d times", calls)
+	}
+
+	wrapped, ok := result.Output.(map[string]any)["wrapped"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected wrapped output, got %v", result.Output)
+	}
+	if wrapped["config"].(map[string]any)["region"] != "eu" {
+		t.Errorf("expected executor to see modified config, got %v", wrapped["config"])
+	}
+	if wrapped["input"].(map[string]any)["redacted"] != true {
+		t.Errorf("expected executor to see modified input, got %v", wrapped["input"])
+	}
+	if result.ResolvedConfig["region"] != "eu" || result.Input.(map[string]any)["redacted"] != true {
+		t.Errorf("expected result to carry modified input and config, got %v / %v", result.Input, result.ResolvedConfig)
+	}
+	if result.Annotations["residency"] != "eu" {
+		t.Errorf("expected annotation, got %v", result.Annotations)
+	}
+}
+
+func TestNodeHooks_ShortCircuit(t *testing.T) {
+	t.Parallel()
+
+	afterCalled := false
+	secondBeforeCalled := false
+	calls := 0
+	nodeExec := newHookTestNodeExecutor(t, &calls,
+		NodeHookFuncs{
+			Before: func(ctx context.Context, hc *NodeHookContext) error {
+				hc.ShortCircuit(map[string]any{"cached": true})
+				return nil
+			},
+			After: func(ctx context.Context, hc *NodeHookContext) error {
+				afterCalled = hc.ShortCircuited()
+				return nil
+			},
+		},
+		NodeHookFuncs{
+			Before: func(ctx context.Context, hc *NodeHookContext) error {
+				secondBeforeCalled = true
+				return nil
+			},
+		},
+	)
+
+	result, err := nodeExec.Execute(context.Background(), newHookTestNodeContext())
+	if err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	if calls != 0 {
+		t.Errorf("expected executor to be skipped, ran %d times", calls)
+	}
+	if secondBeforeCalled {
+		t.Error("expected remaining before hooks to be skipped")
+	}
+	if !afterCalled {
+		t.Error("expected after hook to run on short-circuit")
+	}
+	if result.Output.(map[string]any)["cached"] != true {
+		t.Errorf("unexpected output: %v", result.Output)
+	}
+}
+
+func TestNodeHooks_BeforeError(t *testing.T) {
+	t.Parallel()
+
+	denied := errors.New("destination outside the EU")
+	calls := 0
+	nodeExec := newHookTestNodeExecutor(t, &calls, NodeHookFuncs{
+		Before: func(ctx context.Context, hc *NodeHookContext) error {
+			return denied
+		},
+	})
+
+	_, err := nodeExec.Execute(context.Background(), newHookTestNodeContext())
+	if !errors.Is(err, denied) {
+		t.Fatalf("expected hook error, got %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("expected executor to be skipped, ran %d times", calls)
+	}
+}
+
+func TestNodeHooks_AfterRecoversError(t *testing.T) {
+	t.Parallel()
+
+	registry := executor.NewManager()
+	registry.Register("test", &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			return nil, errors.New("upstream unavailable")
+		},
+	})
+
+	nodeExec := NewNodeExecutor(registry)
+	nodeExec.Use(NodeHookFuncs{
+		After: func(ctx context.Context, hc *NodeHookContext) error {
+			if hc.Err != nil {
+				hc.Annotate("recovered", hc.Err.Error())
+				hc.Output = map[string]any{"fallback": true}
+				hc.Err = nil
+			}
+			return nil
+		},
+	})
+
+	result, err := nodeExec.Execute(context.Background(), newHookTestNodeContext())
+	if err != nil {
+		t.Fatalf("expected error to be recovered, got %v", err)
+	}
+	if result.Output.(map[string]any)["fallback"] != true {
+		t.Errorf("unexpected output: %v", result.Output)
+	}
+	if result.Annotations["recovered"] != "upstream unavailable" {
+		t.Errorf("unexpected annotations: %v", result.Annotations)
+	}
+}
+
+func TestStandaloneExecutor_NodeHookAnnotations(t *testing.T) {
+	t.Parallel()
+
+	registry := executor.NewManager()
+	registry.Register("test", &mockExecutor{})
+
+	standalone := NewStandaloneExecutor(registry, NodeHookFuncs{
+		Before: func(ctx context.Context, hc *NodeHookContext) error {
+			hc.Annotate("tag", hc.Node.ID)
+			return nil
+		},
+	})
+
+	workflow := &models.Workflow{
+		Name:  "Hooks",
+		Nodes: []*models.Node{{ID: "a", Name: "A", Type: "test"}},
+	}
+
+	execution, err := standalone.ExecuteStandalone(context.Background(), workflow, nil, nil)
+	if err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+	if len(execution.NodeExecutions) != 1 {
+		t.Fatalf("expected one node execution, got %d", len(execution.NodeExecutions))
+	}
+
+	annotations, ok := execution.NodeExecutions[0].Metadata["annotations"].(map[string]any)
+	if !ok || annotations["tag"] != "a" {
+		t.Errorf("expected annotations in node metadata, got %v", execution.NodeExecutions[0].Metadata)
+	}
+}
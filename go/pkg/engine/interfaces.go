@@ -20,8 +20,9 @@ type ExecutionRunner interface {
 	CancelExecution(ctx context.Context, executionID string) error
 }
 
-// StandaloneExecutor executes workflows without persistence.
+// StandaloneExecutor executes workflows without the server or its database.
 // This is useful for testing, demos, and simple automation scripts.
+// History is kept only when it is created with NewPersistentStandaloneExecutor.
 type StandaloneExecutor interface {
 	// ExecuteStandalone executes a workflow synchronously without persistence.
 	// All execution happens in-memory and no data is stored to a database.
@@ -106,6 +107,13 @@ const (
 	EventTypeNodeFailed               = "node.failed"
 	EventTypeNodeSkipped              = "node.skipped"
 	EventTypeNodeRetrying             = "node.retrying"
+	EventTypeNodeAssertionFailed      = "node.assertion_failed"
+	EventTypeNodeCircuitOpen          = "node.circuit_open"
+	EventTypeNodeTimedOut             = "node.timed_out"
+	EventTypeNodeCancelled            = "node.cancelled"
+	EventTypeNodeOutputDelta          = "node.output_delta"
+	EventTypeNodeSuspended            = "node.suspended"
+	EventTypeNodeCompensated          = "node.compensated"
 	EventTypeLoopIteration            = "loop.iteration"
 	EventTypeLoopExhausted            = "loop.exhausted"
 	EventTypeSubWorkflowProgress      = "sub_workflow.progress"
@@ -3,6 +3,7 @@ package engine
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/smilemakc/mbflow/go/pkg/executor"
 	"github.com/smilemakc/mbflow/go/pkg/models"
@@ -11,6 +12,9 @@ import (
 // NodeExecutor executes a single node with automatic template resolution.
 type NodeExecutor struct {
 	executorManager executor.Manager
+	hooks           []NodeHook
+	parallelism     *AdaptiveParallelism
+	healthGate      *ExecutorHealthGate
 }
 
 // NewNodeExecutor creates a new node executor.
@@ -20,17 +24,37 @@ func NewNodeExecutor(manager executor.Manager) *NodeExecutor {
 	}
 }
 
+// Use registers hooks invoked around every node execution.
+// Hooks must be registered before the executor is used.
+func (ne *NodeExecutor) Use(hooks ...NodeHook) {
+	ne.hooks = append(ne.hooks, hooks...)
+}
+
+// SetAdaptiveParallelism limits concurrent executor calls per external provider with ap.
+// It must be set before the executor is used.
+func (ne *NodeExecutor) SetAdaptiveParallelism(ap *AdaptiveParallelism) {
+	ne.parallelism = ap
+}
+
+// SetHealthGate keeps nodes off executors that failed their latest health check.
+// It must be set before the executor is used.
+func (ne *NodeExecutor) SetHealthGate(gate *ExecutorHealthGate) {
+	ne.healthGate = gate
+}
+
 // NodeExecutionResult contains the result of node execution along with metadata.
 type NodeExecutionResult struct {
 	Output         any
 	Input          any
 	Config         map[string]any
 	ResolvedConfig map[string]any
+	Annotations    map[string]any
 }
 
 // NodeContext holds context for single node execution.
 type NodeContext struct {
 	ExecutionID        string
+	WorkflowID         string
 	NodeID             string
 	Node               *models.Node
 	WorkflowVariables  map[string]any
@@ -38,6 +62,11 @@ type NodeContext struct {
 	DirectParentOutput map[string]any
 	Resources          map[string]any
 	StrictMode         bool
+	NumberMode         models.NumberMode
+	Propagation        executor.Propagation
+	OnOutputDelta      func(delta string) // receives incremental output, e.g. streamed LLM tokens (nil = not streamed)
+	DryRun             bool               // stub out the executor unless it is side-effect free
+	MockOutput         any                // output of the node when the dry run stubs it out (nil = empty object)
 }
 
 // Execute executes a single node with automatic template resolution.
@@ -47,13 +76,17 @@ type NodeContext struct {
 //  2. Build ExecutionContextData from node context
 //  3. Create template engine from ExecutionContextData
 //  4. Resolve templates in config to get ResolvedConfig
-//  5. Execute with resolved config
-//  6. Return NodeExecutionResult with metadata
+//  5. Run BeforeNode hooks, which may change input and config or short-circuit
+//  6. Execute with resolved config (ExecutionContextData is available via ctx), or
+//     use the mock output when a dry run stubs out the executor
+//  7. Run AfterNode hooks, which may change output or error
+//  8. Return NodeExecutionResult with metadata
 func (ne *NodeExecutor) Execute(ctx context.Context, nodeCtx *NodeContext) (*NodeExecutionResult, error) {
 	baseExecutor, err := ne.executorManager.Get(nodeCtx.Node.Type)
 	if err != nil {
 		return nil, fmt.Errorf("executor not found for type %s: %w", nodeCtx.Node.Type, err)
 	}
+	stubbed := nodeCtx.DryRun && !executor.IsSideEffectFree(baseExecutor)
 
 	execCtxData := &executor.ExecutionContextData{
 		WorkflowVariables:  nodeCtx.WorkflowVariables,
@@ -61,6 +94,15 @@ func (ne *NodeExecutor) Execute(ctx context.Context, nodeCtx *NodeContext) (*Nod
 		ParentNodeOutput:   nodeCtx.DirectParentOutput,
 		Resources:          nodeCtx.Resources,
 		StrictMode:         nodeCtx.StrictMode,
+		NumberMode:         nodeCtx.NumberMode,
+		ExecutionID:        nodeCtx.ExecutionID,
+		WorkflowID:         nodeCtx.WorkflowID,
+		NodeID:             nodeCtx.NodeID,
+		Propagation:        nodeCtx.Propagation,
+		OnOutputDelta:      nodeCtx.OnOutputDelta,
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		execCtxData.Deadline = deadline
 	}
 
 	templateEngine := executor.NewTemplateEngine(execCtxData)
@@ -70,37 +112,150 @@ func (ne *NodeExecutor) Execute(ctx context.Context, nodeCtx *NodeContext) (*Nod
 		return nil, fmt.Errorf("template resolution failed: %w", err)
 	}
 
-	output, err := baseExecutor.Execute(ctx, resolvedConfig, nodeCtx.DirectParentOutput)
+	// The health of executors checked per target depends on the target the node connects to
+	if ne.healthGate != nil && !stubbed {
+		if err := ne.healthGate.Wait(ctx, nodeCtx.Node.Type, resolvedConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	hc := &NodeHookContext{
+		ExecutionID: nodeCtx.ExecutionID,
+		Node:        nodeCtx.Node,
+		Input:       nodeCtx.DirectParentOutput,
+		Config:      resolvedConfig,
+	}
+
+	newResult := func() *NodeExecutionResult {
+		return &NodeExecutionResult{
+			Output:         hc.Output,
+			Input:          hc.Input,
+			Config:         nodeCtx.Node.Config,
+			ResolvedConfig: hc.Config,
+			Annotations:    hc.Annotations(),
+		}
+	}
+
+	// Hooks see and may change the resolved input and config before the executor runs
+	for _, hook := range ne.hooks {
+		if err := hook.BeforeNode(ctx, hc); err != nil {
+			return newResult(), fmt.Errorf("node hook rejected execution: %w", err)
+		}
+		if hc.ShortCircuited() {
+			break
+		}
+	}
 
-	result := &NodeExecutionResult{
-		Output:         output,
-		Input:          nodeCtx.DirectParentOutput,
-		Config:         nodeCtx.Node.Config,
-		ResolvedConfig: resolvedConfig,
+	if !hc.ShortCircuited() && stubbed {
+		output := nodeCtx.MockOutput
+		if output == nil {
+			output = map[string]any{}
+		}
+		hc.ShortCircuit(output)
+		hc.Annotate("dry_run_stubbed", true)
+	}
+
+	if !hc.ShortCircuited() {
+		execCtxData.ParentNodeOutput = hc.Input
+		hc.Output, hc.Err = ne.runExecutor(executor.WithExecutionContext(ctx, execCtxData), baseExecutor, hc)
+	}
+
+	for i := len(ne.hooks) - 1; i >= 0; i-- {
+		if err := ne.hooks[i].AfterNode(ctx, hc); err != nil {
+			hc.Err = err
+		}
 	}
 
+	if hc.Err != nil {
+		return newResult(), fmt.Errorf("node execution failed: %w", hc.Err)
+	}
+
+	return newResult(), nil
+}
+
+// runExecutor runs the executor, holding a slot of the node's provider when adaptive
+// parallelism is enabled. Time spent waiting for the slot is annotated as "throttled_ms".
+func (ne *NodeExecutor) runExecutor(ctx context.Context, baseExecutor executor.Executor, hc *NodeHookContext) (any, error) {
+	if ne.parallelism == nil {
+		return baseExecutor.Execute(ctx, hc.Config, hc.Input)
+	}
+
+	waitStart := time.Now()
+	release, err := ne.parallelism.Acquire(ctx, ProviderKey(hc.Node, hc.Config))
 	if err != nil {
-		return result, fmt.Errorf("node execution failed: %w", err)
+		return nil, err
+	}
+	if waited := time.Since(waitStart); waited >= time.Millisecond {
+		hc.Annotate("throttled_ms", waited.Milliseconds())
 	}
 
-	return result, nil
+	output, err := baseExecutor.Execute(ctx, hc.Config, hc.Input)
+	release(err)
+	return output, err
 }
 
 // PrepareNodeContext builds NodeContext from execution state and node.
-//
-// Input merging strategy:
-//   - No parents: uses execution input
-//   - Single parent: merges execution input with parent output (parent output takes precedence)
-//   - Multiple parents: merges outputs namespaced by parent node ID
+// The input of the node is described at nodeInput.
 func PrepareNodeContext(
 	execState *ExecutionState,
 	node *models.Node,
 	parentNodes []*models.Node,
 	opts *ExecutionOptions,
 ) *NodeContext {
+	directParentOutput := nodeInput(execState, node, parentNodes)
+	execState.ClearLoopInput(node.ID)
+	execState.clearBreakpointInput(node.ID)
+
+	numberMode := opts.NumberMode
+	if numberMode == "" && execState.Workflow != nil {
+		numberMode = execState.Workflow.NumberMode()
+	}
+
+	return &NodeContext{
+		ExecutionID:        execState.ExecutionID,
+		WorkflowID:         execState.WorkflowID,
+		NodeID:             node.ID,
+		Node:               node,
+		WorkflowVariables:  execState.Workflow.Variables,
+		ExecutionVariables: execState.Variables,
+		DirectParentOutput: directParentOutput,
+		Resources:          execState.Resources,
+		StrictMode:         opts.StrictMode,
+		NumberMode:         numberMode,
+		Propagation:        execState.Propagation,
+		DryRun:             opts.DryRun,
+		MockOutput:         opts.MockOutputs[node.ID],
+	}
+}
+
+// mergeParentOutputs merges outputs from multiple parent nodes.
+// Outputs are namespaced by parent node ID to avoid collisions.
+func mergeParentOutputs(execState *ExecutionState, parentNodes []*models.Node) map[string]any {
+	merged := make(map[string]any)
+
+	for _, parent := range parentNodes {
+		if output, ok := execState.GetNodeOutput(parent.ID); ok {
+			merged[parent.ID] = output
+		}
+	}
+
+	return merged
+}
+
+// nodeInput returns the input of a node.
+//
+// Input merging strategy:
+//   - Input given to a released breakpoint node: replaces the merged input
+//   - Loop input: merges execution input with the loop input
+//   - No parents: uses execution input
+//   - Single parent: merges execution input with parent output (parent output takes precedence)
+//   - Multiple parents: merges outputs namespaced by parent node ID
+func nodeInput(execState *ExecutionState, node *models.Node, parentNodes []*models.Node) map[string]any {
 	var directParentOutput map[string]any
 
-	if loopInput, ok := execState.GetLoopInput(node.ID); ok {
+	if input, ok := execState.breakpointInput(node.ID); ok {
+		directParentOutput = input
+	} else if loopInput, ok := execState.GetLoopInput(node.ID); ok {
 		directParentOutput = make(map[string]any)
 		for k, v := range execState.Input {
 			directParentOutput[k] = v
@@ -110,7 +265,6 @@ func PrepareNodeContext(
 				directParentOutput[k] = v
 			}
 		}
-		execState.ClearLoopInput(node.ID)
 	} else if len(parentNodes) == 1 {
 		directParentOutput = make(map[string]any)
 
@@ -132,28 +286,5 @@ func PrepareNodeContext(
 		directParentOutput = execState.Input
 	}
 
-	return &NodeContext{
-		ExecutionID:        execState.ExecutionID,
-		NodeID:             node.ID,
-		Node:               node,
-		WorkflowVariables:  execState.Workflow.Variables,
-		ExecutionVariables: execState.Variables,
-		DirectParentOutput: directParentOutput,
-		Resources:          execState.Resources,
-		StrictMode:         opts.StrictMode,
-	}
-}
-
-// mergeParentOutputs merges outputs from multiple parent nodes.
-// Outputs are namespaced by parent node ID to avoid collisions.
-func mergeParentOutputs(execState *ExecutionState, parentNodes []*models.Node) map[string]any {
-	merged := make(map[string]any)
-
-	for _, parent := range parentNodes {
-		if output, ok := execState.GetNodeOutput(parent.ID); ok {
-			merged[parent.ID] = output
-		}
-	}
-
-	return merged
+	return directParentOutput
 }
@@ -260,3 +260,51 @@ func TestPrepareNodeContext_NoParents(t *testing.T) {
 		t.Errorf("expected initialData=test-value, got %v", val)
 	}
 }
+
+func TestStandaloneExecutor_PropagatesExecutionContext(t *testing.T) {
+	t.Parallel()
+
+	var got *executor.ExecutionContextData
+	registry := executor.NewManager()
+	registry.Register("test", &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			got, _ = executor.GetExecutionContext(ctx)
+			return map[string]any{}, nil
+		},
+	})
+
+	workflow := &models.Workflow{
+		ID:    "wf-1",
+		Name:  "Propagation",
+		Nodes: []*models.Node{{ID: "a", Name: "A", Type: "test"}},
+	}
+	opts := DefaultExecutionOptions()
+	opts.Propagation = executor.Propagation{
+		CorrelationID: "corr-1",
+		WorkspaceID:   "ws-1",
+		UserID:        "user-1",
+		RentalKeyID:   "rk-1",
+		Baggage:       map[string]string{"tenant": "acme"},
+	}
+
+	execution, err := NewStandaloneExecutor(registry).ExecuteStandalone(context.Background(), workflow, nil, opts)
+	if err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+	if got == nil {
+		t.Fatal("expected execution context to be passed to the executor")
+	}
+
+	if got.ExecutionID != execution.ID || got.WorkflowID != "wf-1" || got.NodeID != "a" {
+		t.Errorf("unexpected identity: execution=%q workflow=%q node=%q", got.ExecutionID, got.WorkflowID, got.NodeID)
+	}
+	if got.CorrelationID != "corr-1" || got.WorkspaceID != "ws-1" || got.UserID != "user-1" || got.RentalKeyID != "rk-1" {
+		t.Errorf("unexpected propagation: %+v", got.Propagation)
+	}
+	if got.Baggage["tenant"] != "acme" {
+		t.Errorf("unexpected baggage: %v", got.Baggage)
+	}
+	if remaining, ok := got.Remaining(); !ok || remaining <= 0 || remaining > opts.NodeTimeout {
+		t.Errorf("expected the node timeout as deadline, got %v (set: %v)", remaining, ok)
+	}
+}
@@ -0,0 +1,67 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// timeOutNode marks a node that ran out of its timeout as timed out. Its output records the
+// timeout so edge conditions can inspect it. A node with timeout edges continues down them;
+// without any, the timeout fails the execution like any node error.
+func (de *DAGExecutor) timeOutNode(
+	ctx context.Context,
+	execState *ExecutionState,
+	node *models.Node,
+	execResult *NodeExecutionResult,
+	timeout time.Duration,
+	cause error,
+	nodeStartTime time.Time,
+) error {
+	err := fmt.Errorf("%w after %s: %v", models.ErrNodeTimeout, timeout, cause)
+
+	execState.SetNodeError(node.ID, err)
+	execState.SetNodeStatus(node.ID, models.NodeExecutionStatusTimedOut)
+	execState.SetNodeEndTime(node.ID, time.Now())
+	execState.SetNodeOutput(node.ID, map[string]any{
+		"timed_out":  true,
+		"timeout_ms": timeout.Milliseconds(),
+		"error":      err.Error(),
+	})
+	if execResult != nil {
+		execState.SetNodeInput(node.ID, execResult.Input)
+		execState.SetNodeConfig(node.ID, execResult.Config)
+		execState.SetNodeResolvedConfig(node.ID, execResult.ResolvedConfig)
+	}
+
+	de.safeNotify(ctx, ExecutionEvent{
+		Type:        EventTypeNodeTimedOut,
+		ExecutionID: execState.ExecutionID,
+		WorkflowID:  execState.WorkflowID,
+		Timestamp:   time.Now(),
+		Status:      string(models.NodeExecutionStatusTimedOut),
+		NodeID:      node.ID,
+		NodeName:    node.Name,
+		NodeType:    node.Type,
+		Error:       err,
+		DurationMs:  time.Since(nodeStartTime).Milliseconds(),
+		Metadata:    map[string]any{"timeout_ms": timeout.Milliseconds()},
+	})
+
+	if hasTimeoutEdges(execState.Workflow, node.ID) {
+		return nil
+	}
+	return err
+}
+
+// hasTimeoutEdges reports whether a node has outgoing edges with the "timeout" source handle.
+func hasTimeoutEdges(workflow *models.Workflow, nodeID string) bool {
+	for _, edge := range workflow.Edges {
+		if edge.From == nodeID && edge.SourceHandle == SourceHandleTimeout {
+			return true
+		}
+	}
+	return false
+}
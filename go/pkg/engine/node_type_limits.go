@@ -0,0 +1,45 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+)
+
+// nodeTypeLimiter caps the nodes of each executor type running at once in an execution and
+// its sub-workflow executions, e.g. so a fan-out of llm nodes stays within a provider's
+// rate limit. Types without a cap are not limited.
+type nodeTypeLimiter struct {
+	slots map[string]chan struct{}
+}
+
+// newNodeTypeLimiter returns a limiter for the caps by node type, or nil when no cap is positive.
+func newNodeTypeLimiter(limits map[string]int) *nodeTypeLimiter {
+	slots := make(map[string]chan struct{})
+	for nodeType, limit := range limits {
+		if limit > 0 {
+			slots[nodeType] = make(chan struct{}, limit)
+		}
+	}
+	if len(slots) == 0 {
+		return nil
+	}
+	return &nodeTypeLimiter{slots: slots}
+}
+
+// acquire waits for a free slot of the node type and returns the function that releases it.
+func (l *nodeTypeLimiter) acquire(ctx context.Context, nodeType string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	slots, ok := l.slots[nodeType]
+	if !ok {
+		return func() {}, nil
+	}
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for %s concurrency slot: %w", nodeType, ctx.Err())
+	}
+}
@@ -0,0 +1,98 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// peakExecutor records the most calls it ran at once.
+type peakExecutor struct {
+	running, peak int32
+}
+
+func (p *peakExecutor) executor() *executor.ExecutorFunc {
+	return &executor.ExecutorFunc{
+		ExecuteFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			n := atomic.AddInt32(&p.running, 1)
+			defer atomic.AddInt32(&p.running, -1)
+			for {
+				peak := atomic.LoadInt32(&p.peak)
+				if n <= peak || atomic.CompareAndSwapInt32(&p.peak, peak, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return map[string]any{}, nil
+		},
+	}
+}
+
+func TestNodeTypeConcurrency_CapsTypeWithinWave(t *testing.T) {
+	t.Parallel()
+
+	llm, http := &peakExecutor{}, &peakExecutor{}
+	registry := executor.NewManager()
+	registry.Register("llm", llm.executor())
+	registry.Register("http", http.executor())
+	dagExec := NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), NewNoOpNotifier(), nil)
+
+	workflow := &models.Workflow{ID: "wf-1"}
+	for i := 0; i < 6; i++ {
+		workflow.Nodes = append(workflow.Nodes,
+			&models.Node{ID: fmt.Sprintf("ask-%d", i), Name: "Ask", Type: "llm"},
+			&models.Node{ID: fmt.Sprintf("fetch-%d", i), Name: "Fetch", Type: "http"},
+		)
+	}
+	opts := DefaultExecutionOptions()
+	opts.MaxParallelism = 12
+	opts.NodeTypeConcurrency = map[string]int{"llm": 2}
+
+	execState := NewExecutionState("exec-1", "wf-1", workflow, map[string]any{}, nil)
+	if err := dagExec.Execute(context.Background(), execState, opts); err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	if peak := atomic.LoadInt32(&llm.peak); peak != 2 {
+		t.Errorf("expected 2 concurrent llm nodes, got %d", peak)
+	}
+	if peak := atomic.LoadInt32(&http.peak); peak <= 2 {
+		t.Errorf("expected http nodes to be limited by max parallelism only, got %d concurrent", peak)
+	}
+}
+
+func TestNodeTypeConcurrency_SharedWithForEachItems(t *testing.T) {
+	t.Parallel()
+
+	llm := &peakExecutor{}
+	registry := executor.NewManager()
+	registry.Register("llm", llm.executor())
+	dagExec := NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), NewNoOpNotifier(), nil)
+
+	workflow := forEachTestWorkflow(map[string]any{
+		"for_each":        "input.items",
+		"max_parallelism": 8,
+		"body": map[string]any{
+			"nodes": []any{
+				map[string]any{"id": "ask", "name": "Ask", "type": "llm"},
+			},
+		},
+	})
+	input := map[string]any{"items": []any{1, 2, 3, 4, 5, 6, 7, 8}}
+	opts := DefaultExecutionOptions()
+	opts.NodeTypeConcurrency = map[string]int{"llm": 3}
+
+	execState := NewExecutionState("exec-1", "parent-wf", workflow, input, nil)
+	if err := dagExec.Execute(context.Background(), execState, opts); err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	if peak := atomic.LoadInt32(&llm.peak); peak > 3 {
+		t.Errorf("expected at most 3 concurrent llm nodes across items, got %d", peak)
+	}
+}
@@ -5,6 +5,9 @@ package engine
 
 import (
 	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
 )
 
 // ExecutionOptions configures workflow execution behavior.
@@ -30,6 +33,11 @@ type ExecutionOptions struct {
 	// MaxParallelism is an alias for MaxConcurrency (for backward compatibility)
 	MaxParallelism int
 
+	// NodeTypeConcurrency caps the nodes of each executor type running at once, e.g.
+	// {"llm": 3, "http": 20}. The caps are shared with the sub-workflow and foreach
+	// executions of the node, so a fan-out cannot exceed them either.
+	NodeTypeConcurrency map[string]int
+
 	// MaxOutputSize limits the size of node outputs in bytes (0 = unlimited)
 	MaxOutputSize int64
 
@@ -41,6 +49,37 @@ type ExecutionOptions struct {
 
 	// Variables are workflow-level variables available to all nodes
 	Variables map[string]any
+
+	// NumberMode selects how executors decode JSON numbers; empty uses the
+	// workflow's "json_numbers" metadata, and float when that is not set either
+	NumberMode models.NumberMode
+
+	// Propagation (correlation ID, workspace, user, rental key, baggage) is passed to
+	// every executor and forwarded on outbound HTTP and LLM calls
+	Propagation executor.Propagation
+
+	// Selection runs only a subgraph of the workflow, with the outputs of the nodes
+	// feeding it supplied by the caller; nil runs every node
+	Selection *models.NodeSelection
+
+	// AllowSuspend lets nodes such as delay suspend their branch: the node is recorded in the
+	// state's Suspensions and Execute returns models.ErrExecutionSuspended once nothing else can
+	// run, so the caller can persist the execution and resume it later. Without it the engine
+	// waits for the node inline.
+	AllowSuspend bool
+
+	// DryRun stubs out the executors with side effects, such as HTTP and LLM nodes: instead
+	// of running, their nodes output their entry in MockOutputs, or an empty object.
+	// Executors implementing executor.SideEffectFree still run, so templates, transforms
+	// and edge conditions behave as in a real execution.
+	DryRun bool
+
+	// MockOutputs holds the outputs of the nodes stubbed out by a dry run, by node ID
+	MockOutputs map[string]any
+
+	// Breakpoints are IDs of nodes the execution pauses before, as with ExecutionState.Pause,
+	// until the node is released with ExecutionState.ReleaseBreakpoint and the execution resumed
+	Breakpoints []string
 }
 
 // RetryPolicy configures retry behavior for node execution.
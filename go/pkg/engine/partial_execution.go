@@ -0,0 +1,183 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// SelectSubgraph returns a copy of the workflow reduced to the selected nodes, their
+// upstream boundary nodes and the edges between them, along with the sorted IDs of the
+// boundary nodes. Every boundary node must have an output in selection.BoundaryOutputs.
+func SelectSubgraph(workflow *models.Workflow, selection *models.NodeSelection) (*models.Workflow, []string, error) {
+	if err := selection.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	dag := BuildDAG(workflow)
+	selected, err := selectNodes(dag, selection)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Selected nodes keep their compensation nodes
+	for _, edge := range dag.CompensationEdges {
+		if selected[edge.From] {
+			selected[edge.To] = true
+		}
+	}
+
+	// Boundary nodes feed the selection through regular edges without being part of it
+	boundary := make(map[string]bool)
+	for _, edge := range workflow.Edges {
+		if !edge.IsLoop() && selected[edge.To] && !selected[edge.From] {
+			boundary[edge.From] = true
+		}
+	}
+
+	boundaryIDs := sortedKeys(boundary)
+	var missing []string
+	for _, id := range boundaryIDs {
+		if _, ok := selection.BoundaryOutputs[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, nil, fmt.Errorf("%w: missing boundary outputs for %s", models.ErrInvalidNodeSelection, strings.Join(missing, ", "))
+	}
+	for id := range selection.BoundaryOutputs {
+		if !boundary[id] {
+			return nil, nil, fmt.Errorf("%w: node %s does not feed the selected nodes", models.ErrInvalidNodeSelection, id)
+		}
+	}
+
+	sub := *workflow
+	sub.Nodes = make([]*models.Node, 0, len(selected)+len(boundary))
+	for _, node := range workflow.Nodes {
+		if selected[node.ID] || boundary[node.ID] {
+			sub.Nodes = append(sub.Nodes, node)
+		}
+	}
+	sub.Edges = make([]*models.Edge, 0, len(workflow.Edges))
+	for _, edge := range workflow.Edges {
+		if selected[edge.To] && (selected[edge.From] || boundary[edge.From]) {
+			sub.Edges = append(sub.Edges, edge)
+		}
+	}
+
+	return &sub, boundaryIDs, nil
+}
+
+// selectNodes resolves a selection to a set of node IDs. A range holds the nodes that are
+// both reachable from FromNode and upstream of ToNode; an empty end leaves that side open.
+func selectNodes(dag *DAG, selection *models.NodeSelection) (map[string]bool, error) {
+	selected := make(map[string]bool)
+	if len(selection.NodeIDs) > 0 {
+		for _, id := range selection.NodeIDs {
+			if _, ok := dag.Nodes[id]; !ok {
+				return nil, fmt.Errorf("%w: unknown node %s", models.ErrInvalidNodeSelection, id)
+			}
+			selected[id] = true
+		}
+		return selected, nil
+	}
+
+	var downstream, upstream map[string]bool
+	if selection.FromNode != "" {
+		if _, ok := dag.Nodes[selection.FromNode]; !ok {
+			return nil, fmt.Errorf("%w: unknown from_node %s", models.ErrInvalidNodeSelection, selection.FromNode)
+		}
+		downstream = reachableNodes(selection.FromNode, func(id string) []string {
+			return dag.Edges[id]
+		})
+	}
+	if selection.ToNode != "" {
+		if _, ok := dag.Nodes[selection.ToNode]; !ok {
+			return nil, fmt.Errorf("%w: unknown to_node %s", models.ErrInvalidNodeSelection, selection.ToNode)
+		}
+		upstream = reachableNodes(selection.ToNode, func(id string) []string {
+			parents := make([]string, 0, len(dag.Index.ParentsByNode[id]))
+			for _, parent := range dag.Index.ParentsByNode[id] {
+				parents = append(parents, parent.ID)
+			}
+			return parents
+		})
+	}
+
+	for id := range dag.Nodes {
+		if (downstream == nil || downstream[id]) && (upstream == nil || upstream[id]) {
+			selected[id] = true
+		}
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("%w: %s is not upstream of %s", models.ErrInvalidNodeSelection, selection.FromNode, selection.ToNode)
+	}
+	return selected, nil
+}
+
+// reachableNodes returns the start node and every node reachable from it via next.
+func reachableNodes(start string, next func(id string) []string) map[string]bool {
+	visited := map[string]bool{start: true}
+	queue := []string{start}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, nextID := range next(id) {
+			if !visited[nextID] {
+				visited[nextID] = true
+				queue = append(queue, nextID)
+			}
+		}
+	}
+	return visited
+}
+
+// applyNodeSelection reduces the execution to the selected subgraph and marks the boundary
+// nodes completed with their supplied outputs. It returns the boundary node IDs, which are
+// not executed.
+func applyNodeSelection(execState *ExecutionState, selection *models.NodeSelection) (map[string]bool, error) {
+	sub, boundaryIDs, err := SelectSubgraph(execState.Workflow, selection)
+	if err != nil {
+		return nil, err
+	}
+
+	execState.Workflow = sub
+	boundary := make(map[string]bool, len(boundaryIDs))
+	for _, id := range boundaryIDs {
+		execState.SetNodeOutput(id, selection.BoundaryOutputs[id])
+		execState.SetNodeStatus(id, models.NodeExecutionStatusCompleted)
+		execState.SetNodeAnnotations(id, map[string]any{"boundary_output": true})
+		boundary[id] = true
+	}
+	return boundary, nil
+}
+
+// withoutNodes removes the given nodes from the waves, dropping waves left empty.
+func withoutNodes(waves [][]*models.Node, excluded map[string]bool) [][]*models.Node {
+	if len(excluded) == 0 {
+		return waves
+	}
+	filtered := make([][]*models.Node, 0, len(waves))
+	for _, wave := range waves {
+		kept := make([]*models.Node, 0, len(wave))
+		for _, node := range wave {
+			if !excluded[node.ID] {
+				kept = append(kept, node)
+			}
+		}
+		if len(kept) > 0 {
+			filtered = append(filtered, kept)
+		}
+	}
+	return filtered
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
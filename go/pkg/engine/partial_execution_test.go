@@ -0,0 +1,177 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// partialTestWorkflow is a -> b -> c -> d with a side branch a -> e.
+func partialTestWorkflow() *models.Workflow {
+	node := func(id string) *models.Node {
+		return &models.Node{ID: id, Name: id, Type: "test", Config: map[string]any{"nodeID": id}}
+	}
+	return &models.Workflow{
+		ID:    "wf-partial",
+		Nodes: []*models.Node{node("a"), node("b"), node("c"), node("d"), node("e")},
+		Edges: []*models.Edge{
+			{ID: "a-b", From: "a", To: "b"},
+			{ID: "b-c", From: "b", To: "c"},
+			{ID: "c-d", From: "c", To: "d"},
+			{ID: "a-e", From: "a", To: "e"},
+		},
+	}
+}
+
+func nodeIDs(nodes []*models.Node) []string {
+	ids := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		ids = append(ids, node.ID)
+	}
+	return ids
+}
+
+func TestSelectSubgraph(t *testing.T) {
+	t.Parallel()
+	workflow := partialTestWorkflow()
+
+	tests := []struct {
+		name         string
+		selection    *models.NodeSelection
+		wantNodes    []string
+		wantBoundary []string
+	}{
+		{
+			name:         "node ids",
+			selection:    &models.NodeSelection{NodeIDs: []string{"c"}, BoundaryOutputs: map[string]any{"b": map[string]any{}}},
+			wantNodes:    []string{"b", "c"},
+			wantBoundary: []string{"b"},
+		},
+		{
+			name:         "range",
+			selection:    &models.NodeSelection{FromNode: "b", ToNode: "c", BoundaryOutputs: map[string]any{"a": map[string]any{}}},
+			wantNodes:    []string{"a", "b", "c"},
+			wantBoundary: []string{"a"},
+		},
+		{
+			name:         "from node only",
+			selection:    &models.NodeSelection{FromNode: "c", BoundaryOutputs: map[string]any{"b": map[string]any{}}},
+			wantNodes:    []string{"b", "c", "d"},
+			wantBoundary: []string{"b"},
+		},
+		{
+			name:         "to node only",
+			selection:    &models.NodeSelection{ToNode: "b"},
+			wantNodes:    []string{"a", "b"},
+			wantBoundary: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, boundary, err := SelectSubgraph(workflow, tt.selection)
+			if err != nil {
+				t.Fatalf("SelectSubgraph failed: %v", err)
+			}
+			if got := nodeIDs(sub.Nodes); !reflect.DeepEqual(got, tt.wantNodes) {
+				t.Errorf("expected nodes %v, got %v", tt.wantNodes, got)
+			}
+			if !reflect.DeepEqual(boundary, tt.wantBoundary) {
+				t.Errorf("expected boundary %v, got %v", tt.wantBoundary, boundary)
+			}
+		})
+	}
+
+	if len(workflow.Nodes) != 5 || len(workflow.Edges) != 4 {
+		t.Error("SelectSubgraph must not modify the workflow")
+	}
+}
+
+func TestSelectSubgraph_InvalidSelection(t *testing.T) {
+	t.Parallel()
+	workflow := partialTestWorkflow()
+
+	tests := []struct {
+		name      string
+		selection *models.NodeSelection
+		wantErr   string
+	}{
+		{"empty", &models.NodeSelection{}, "node_ids, from_node or to_node is required"},
+		{"ids and range", &models.NodeSelection{NodeIDs: []string{"b"}, FromNode: "a"}, "cannot be combined"},
+		{"unknown node", &models.NodeSelection{NodeIDs: []string{"x"}}, "unknown node x"},
+		{"unknown from node", &models.NodeSelection{FromNode: "x"}, "unknown from_node x"},
+		{"disjoint range", &models.NodeSelection{FromNode: "e", ToNode: "d"}, "e is not upstream of d"},
+		{"missing boundary output", &models.NodeSelection{NodeIDs: []string{"c", "e"}}, "missing boundary outputs for a, b"},
+		{"unrelated boundary output", &models.NodeSelection{NodeIDs: []string{"b"}, BoundaryOutputs: map[string]any{"a": nil, "d": nil}}, "node d does not feed the selected nodes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := SelectSubgraph(workflow, tt.selection)
+			if !errors.Is(err, models.ErrInvalidNodeSelection) {
+				t.Fatalf("expected ErrInvalidNodeSelection, got %v", err)
+			}
+			if !stringContains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %q", tt.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestDAGExecutor_Execute_Selection(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	inputs := make(map[string]any)
+	mockExec := &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			nodeID := config["nodeID"].(string)
+			mu.Lock()
+			inputs[nodeID] = input
+			mu.Unlock()
+			return map[string]any{"from": nodeID}, nil
+		},
+	}
+
+	registry := executor.NewManager()
+	registry.Register("test", mockExec)
+	dagExec := NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), NewNoOpNotifier(), NewNilWorkflowLoader())
+
+	execState := NewExecutionState("exec-1", "wf-partial", partialTestWorkflow(), map[string]any{}, nil)
+	opts := DefaultExecutionOptions()
+	opts.Selection = &models.NodeSelection{
+		FromNode:        "b",
+		ToNode:          "c",
+		BoundaryOutputs: map[string]any{"a": map[string]any{"text": "supplied"}},
+	}
+
+	if err := dagExec.Execute(context.Background(), execState, opts); err != nil {
+		t.Fatalf("DAG execution failed: %v", err)
+	}
+
+	if len(inputs) != 2 || inputs["b"] == nil || inputs["c"] == nil {
+		t.Fatalf("expected only b and c to run, got %v", inputs)
+	}
+	if got := inputs["b"].(map[string]any)["text"]; got != "supplied" {
+		t.Errorf("expected b to receive the boundary output, got %v", inputs["b"])
+	}
+
+	status, _ := execState.GetNodeStatus("a")
+	if status != models.NodeExecutionStatusCompleted {
+		t.Errorf("expected boundary node a to be completed, got %v", status)
+	}
+	if _, ok := execState.GetNodeStatus("d"); ok {
+		t.Error("expected node d outside the selection to have no status")
+	}
+	if got := nodeIDs(execState.Workflow.Nodes); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("expected execution workflow reduced to a, b, c, got %v", got)
+	}
+	if annotations, _ := execState.GetNodeAnnotations("a"); annotations["boundary_output"] != true {
+		t.Errorf("expected boundary node a to be annotated, got %v", annotations)
+	}
+}
@@ -0,0 +1,43 @@
+package engine
+
+import "github.com/smilemakc/mbflow/go/pkg/models"
+
+// Pause asks the execution to stop at the next node boundary so that it can be resumed
+// later: nodes already running finish, no other node starts, and Execute returns
+// ErrExecutionPaused. Like with Preempt, nodes that did not run stay pending for an
+// execution resumed from a checkpoint of the state. The reason says who paused it.
+func (es *ExecutionState) Pause(reason string) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	if es.paused.Load() {
+		return
+	}
+	es.pauseReason = reason
+	es.paused.Store(true)
+}
+
+// PauseRequested reports whether Pause was called.
+func (es *ExecutionState) PauseRequested() bool {
+	return es.paused.Load()
+}
+
+// PauseReason returns the reason given to Pause.
+func (es *ExecutionState) PauseReason() string {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+	return es.pauseReason
+}
+
+// stopRequested reports whether the execution was preempted or paused and starts no more nodes.
+func (es *ExecutionState) stopRequested() bool {
+	return es.preempted.Load() || es.paused.Load()
+}
+
+// stopErr returns the error of an execution stopped by Pause or Preempt. A pause wins, as
+// it always keeps the execution resumable.
+func (es *ExecutionState) stopErr() error {
+	if es.paused.Load() {
+		return models.ErrExecutionPaused
+	}
+	return models.ErrExecutionPreempted
+}
@@ -0,0 +1,77 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+func TestDAGExecutor_PauseStopsAtNodeBoundary(t *testing.T) {
+	t.Parallel()
+
+	var execState *ExecutionState
+	registry := executor.NewManager()
+	registry.Register("test", &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			if config["pause"] == true {
+				execState.Pause("user admin")
+			}
+			return map[string]any{"ok": true}, nil
+		},
+	})
+
+	workflow := &models.Workflow{
+		ID: "wf-pause",
+		Nodes: []*models.Node{
+			{ID: "extract", Name: "Extract", Type: "test", Config: map[string]any{"pause": true}},
+			{ID: "load", Name: "Load", Type: "test"},
+		},
+		Edges: []*models.Edge{
+			{ID: "e1", From: "extract", To: "load"},
+		},
+	}
+
+	dagExec := NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), &recordingNotifier{}, NewNilWorkflowLoader())
+	execState = NewExecutionState("exec-pause", "wf-pause", workflow, map[string]any{}, nil)
+
+	err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions())
+	if !errors.Is(err, models.ErrExecutionPaused) {
+		t.Fatalf("expected ErrExecutionPaused, got %v", err)
+	}
+	if status, _ := execState.GetNodeStatus("extract"); status != models.NodeExecutionStatusCompleted {
+		t.Errorf("expected running node to finish, got %s", status)
+	}
+	if status, ok := execState.GetNodeStatus("load"); ok && status.IsTerminal() {
+		t.Errorf("expected downstream node to stay pending, got %s", status)
+	}
+	if reason := execState.PauseReason(); reason != "user admin" {
+		t.Errorf("unexpected pause reason %q", reason)
+	}
+}
+
+func TestDAGExecutor_PauseWinsOverPreempt(t *testing.T) {
+	t.Parallel()
+
+	registry := executor.NewManager()
+	registry.Register("test", &mockExecutor{})
+
+	workflow := &models.Workflow{
+		ID:    "wf-pause",
+		Nodes: []*models.Node{{ID: "only", Name: "Only", Type: "test"}},
+	}
+	dagExec := NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), &recordingNotifier{}, NewNilWorkflowLoader())
+	execState := NewExecutionState("exec-pause", "wf-pause", workflow, map[string]any{}, nil)
+	execState.Preempt("high priority execution exec-urgent")
+	execState.Pause("user admin")
+
+	err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions())
+	if !errors.Is(err, models.ErrExecutionPaused) {
+		t.Fatalf("expected ErrExecutionPaused, got %v", err)
+	}
+	if status, ok := execState.GetNodeStatus("only"); ok && status.IsTerminal() {
+		t.Errorf("expected node not to run, got %s", status)
+	}
+}
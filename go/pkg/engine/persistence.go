@@ -0,0 +1,115 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// StandalonePersistence stores the history of standalone executions, so embedded
+// deployments get durable history without running the server.
+// LoadWorkflow is also used to load sub-workflows.
+type StandalonePersistence interface {
+	WorkflowLoader
+
+	// SaveExecution creates or replaces an execution. It is called when the execution
+	// starts and again with the final state and node executions.
+	SaveExecution(ctx context.Context, execution *models.Execution) error
+
+	// SaveNodeEvent appends a node event (node.started, node.completed, ...) of an execution.
+	SaveNodeEvent(ctx context.Context, event *models.Event) error
+}
+
+// persistenceNotifier saves node events and remembers the first error per execution,
+// since notifications cannot fail the execution.
+type persistenceNotifier struct {
+	persistence StandalonePersistence
+	sequence    atomic.Int64
+
+	mu   sync.Mutex
+	errs map[string]error
+}
+
+func newPersistenceNotifier(persistence StandalonePersistence) *persistenceNotifier {
+	return &persistenceNotifier{
+		persistence: persistence,
+		errs:        make(map[string]error),
+	}
+}
+
+// Notify saves node events and ignores all others.
+func (n *persistenceNotifier) Notify(ctx context.Context, event ExecutionEvent) {
+	if !strings.HasPrefix(event.Type, "node.") {
+		return
+	}
+
+	if err := n.persistence.SaveNodeEvent(ctx, nodeEventRecord(event, n.sequence.Add(1))); err != nil {
+		n.mu.Lock()
+		if _, ok := n.errs[event.ExecutionID]; !ok {
+			n.errs[event.ExecutionID] = fmt.Errorf("failed to save %s event for node %s: %w", event.Type, event.NodeID, err)
+		}
+		n.mu.Unlock()
+	}
+}
+
+// takeErr returns and clears the first error recorded for the execution.
+func (n *persistenceNotifier) takeErr(executionID string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	err := n.errs[executionID]
+	delete(n.errs, executionID)
+	return err
+}
+
+// nodeEventRecord converts an engine event to the stored event model.
+func nodeEventRecord(event ExecutionEvent, sequence int64) *models.Event {
+	payload := map[string]any{
+		"node_id":    event.NodeID,
+		"node_name":  event.NodeName,
+		"node_type":  event.NodeType,
+		"wave_index": event.WaveIndex,
+	}
+	if event.Status != "" {
+		payload["status"] = event.Status
+	}
+	if event.Error != nil {
+		payload["error"] = event.Error.Error()
+	}
+	if event.Output != nil {
+		payload["output"] = event.Output
+	}
+	if event.DurationMs > 0 {
+		payload["duration_ms"] = event.DurationMs
+	}
+	if event.Message != "" {
+		payload["message"] = event.Message
+	}
+	if len(event.Metadata) > 0 {
+		payload["metadata"] = event.Metadata
+	}
+
+	return &models.Event{
+		ID:          uuid.New().String(),
+		ExecutionID: event.ExecutionID,
+		EventType:   event.Type,
+		Sequence:    sequence,
+		Payload:     payload,
+		CreatedAt:   event.Timestamp,
+	}
+}
+
+// persistExecution saves the execution and combines the result with earlier node event errors.
+func persistExecution(ctx context.Context, persistence StandalonePersistence, notifier *persistenceNotifier, execution *models.Execution) error {
+	// Save even if the caller's context is done, so the final state is not lost
+	err := persistence.SaveExecution(context.WithoutCancel(ctx), execution)
+	if err != nil {
+		err = fmt.Errorf("failed to save execution: %w", err)
+	}
+	return errors.Join(notifier.takeErr(execution.ID), err)
+}
@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// memoryPersistence is an in-memory StandalonePersistence for tests.
+type memoryPersistence struct {
+	MockWorkflowLoader
+
+	mu         sync.Mutex
+	saves      []models.ExecutionStatus
+	events     []*models.Event
+	eventError error
+}
+
+func (m *memoryPersistence) SaveExecution(ctx context.Context, execution *models.Execution) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.saves = append(m.saves, execution.Status)
+	return nil
+}
+
+func (m *memoryPersistence) SaveNodeEvent(ctx context.Context, event *models.Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.eventError != nil {
+		return m.eventError
+	}
+	m.events = append(m.events, event)
+	return nil
+}
+
+func TestPersistentStandaloneExecutor(t *testing.T) {
+	t.Parallel()
+
+	registry := executor.NewManager()
+	registry.Register("test", &mockExecutor{})
+
+	persistence := &memoryPersistence{}
+	standalone := NewPersistentStandaloneExecutor(registry, persistence)
+
+	workflow := &models.Workflow{
+		Name:  "Persisted",
+		Nodes: []*models.Node{{ID: "a", Name: "A", Type: "test"}},
+	}
+
+	execution, err := standalone.ExecuteStandalone(context.Background(), workflow, nil, nil)
+	if err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	if len(persistence.saves) != 2 || persistence.saves[0] != models.ExecutionStatusRunning || persistence.saves[1] != models.ExecutionStatusCompleted {
+		t.Errorf("expected running and completed saves, got %v", persistence.saves)
+	}
+	if len(persistence.events) != 2 {
+		t.Fatalf("expected 2 node events, got %d", len(persistence.events))
+	}
+	for _, event := range persistence.events {
+		if event.ExecutionID != execution.ID || event.Payload["node_id"] != "a" {
+			t.Errorf("unexpected event: %+v", event)
+		}
+	}
+}
+
+func TestPersistentStandaloneExecutor_EventError(t *testing.T) {
+	t.Parallel()
+
+	registry := executor.NewManager()
+	registry.Register("test", &mockExecutor{})
+
+	diskFull := errors.New("disk full")
+	persistence := &memoryPersistence{eventError: diskFull}
+	standalone := NewPersistentStandaloneExecutor(registry, persistence)
+
+	workflow := &models.Workflow{
+		Name:  "Persisted",
+		Nodes: []*models.Node{{ID: "a", Name: "A", Type: "test"}},
+	}
+
+	execution, err := standalone.ExecuteStandalone(context.Background(), workflow, nil, nil)
+	if !errors.Is(err, diskFull) {
+		t.Fatalf("expected persistence error, got %v", err)
+	}
+	if execution == nil || execution.Status != models.ExecutionStatusCompleted {
+		t.Errorf("expected completed execution alongside the error, got %+v", execution)
+	}
+}
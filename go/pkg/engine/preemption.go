@@ -0,0 +1,27 @@
+package engine
+
+// Preempt asks the execution to stop gracefully so that higher-priority work can run: nodes
+// already running finish, no other node starts, and Execute returns ErrExecutionPreempted.
+// Nodes that did not run stay pending, so an execution resumed from a checkpoint of the
+// state runs them. The reason says what the execution was preempted for.
+func (es *ExecutionState) Preempt(reason string) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	if es.preempted.Load() {
+		return
+	}
+	es.preemptReason = reason
+	es.preempted.Store(true)
+}
+
+// Preempted reports whether Preempt was called.
+func (es *ExecutionState) Preempted() bool {
+	return es.preempted.Load()
+}
+
+// PreemptReason returns the reason given to Preempt.
+func (es *ExecutionState) PreemptReason() string {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+	return es.preemptReason
+}
@@ -7,6 +7,9 @@ import (
 	"math"
 	"strings"
 	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
 )
 
 // InternalBackoffStrategy defines how retry delays are calculated.
@@ -57,6 +60,16 @@ func (rp *InternalRetryPolicy) ShouldRetry(err error) bool {
 		return false
 	}
 
+	// A suspended node is not a failure
+	if _, ok := executor.AsSuspend(err); ok {
+		return false
+	}
+
+	// An open circuit breaker keeps failing fast until its cooldown ends
+	if errors.Is(err, models.ErrCircuitOpen) {
+		return false
+	}
+
 	if len(rp.RetryableErrors) == 0 {
 		return true
 	}
@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -13,12 +14,16 @@ import (
 // standaloneExecutor implements StandaloneExecutor using the unified DAGExecutor.
 type standaloneExecutor struct {
 	dagExecutor *DAGExecutor
+	persistence StandalonePersistence
+	notifier    *persistenceNotifier
 }
 
 // NewStandaloneExecutor creates a new standalone executor that runs workflows
 // in-memory without persistence. Uses SimpleConditionEvaluator and NoOpNotifier.
-func NewStandaloneExecutor(executorManager executor.Manager) StandaloneExecutor {
+// Hooks, if given, are invoked around every node execution.
+func NewStandaloneExecutor(executorManager executor.Manager, hooks ...NodeHook) StandaloneExecutor {
 	nodeExecutor := NewNodeExecutor(executorManager)
+	nodeExecutor.Use(hooks...)
 	return &standaloneExecutor{
 		dagExecutor: NewDAGExecutor(
 			nodeExecutor,
@@ -29,6 +34,26 @@ func NewStandaloneExecutor(executorManager executor.Manager) StandaloneExecutor
 	}
 }
 
+// NewPersistentStandaloneExecutor creates a standalone executor that saves executions
+// and node events to persistence and loads sub-workflows from it.
+// Persistence errors do not stop the workflow; they are returned together with the
+// execution (joined with the execution error, if any).
+func NewPersistentStandaloneExecutor(executorManager executor.Manager, persistence StandalonePersistence, hooks ...NodeHook) StandaloneExecutor {
+	nodeExecutor := NewNodeExecutor(executorManager)
+	nodeExecutor.Use(hooks...)
+	notifier := newPersistenceNotifier(persistence)
+	return &standaloneExecutor{
+		dagExecutor: NewDAGExecutor(
+			nodeExecutor,
+			NewExprConditionEvaluator(),
+			notifier,
+			persistence,
+		),
+		persistence: persistence,
+		notifier:    notifier,
+	}
+}
+
 // ExecuteStandalone executes a workflow synchronously without persistence.
 func (e *standaloneExecutor) ExecuteStandalone(
 	ctx context.Context,
@@ -69,7 +94,14 @@ func (e *standaloneExecutor) ExecuteStandalone(
 		StartedAt:    time.Now(),
 	}
 
+	if e.persistence != nil {
+		if err := e.persistence.SaveExecution(ctx, execution); err != nil {
+			return nil, fmt.Errorf("failed to save execution: %w", err)
+		}
+	}
+
 	state := NewExecutionState(execution.ID, workflow.ID, workflow, input, execution.Variables)
+	state.Propagation = opts.Propagation
 
 	execErr := e.dagExecutor.Execute(ctx, state, opts)
 
@@ -82,10 +114,17 @@ func (e *standaloneExecutor) ExecuteStandalone(
 		execution.Error = execErr.Error()
 	} else {
 		execution.Status = models.ExecutionStatusCompleted
-		execution.Output = getFinalOutputFromState(state, workflow)
+		execution.Output = getFinalOutputFromState(state, state.Workflow)
 	}
 
-	execution.NodeExecutions = buildNodeExecutionsFromState(state, workflow)
+	// A partial run reduces state.Workflow to the executed subgraph
+	execution.NodeExecutions = buildNodeExecutionsFromState(state, state.Workflow)
+
+	if e.persistence != nil {
+		if err := persistExecution(ctx, e.persistence, e.notifier, execution); err != nil {
+			return execution, errors.Join(execErr, err)
+		}
+	}
 
 	return execution, execErr
 }
@@ -158,6 +197,8 @@ func buildNodeExecutionsFromState(state *ExecutionState, workflow *models.Workfl
 			nodeExec.CompletedAt = &endTime
 		}
 
+		nodeExec.Metadata = state.NodeMetadata(node.ID)
+
 		nodeExecs = append(nodeExecs, nodeExec)
 	}
 
@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"sync"
@@ -15,6 +16,7 @@ import (
 const (
 	NodeTypeSubWorkflow       = "sub_workflow"
 	SubWorkflowDefaultItemVar = "item"
+	SubWorkflowChunkItemVar   = "chunk"
 	SubWorkflowDefaultOnError = "fail_fast"
 	SubWorkflowOnErrorCollect = "collect_partial"
 )
@@ -27,6 +29,15 @@ type subWorkflowConfig struct {
 	MaxParallelism int
 	OnError        string
 	TimeoutPerItem time.Duration
+	// ChunkSize groups the items into arrays of this size, one child execution per chunk.
+	ChunkSize int
+	// ReducerWorkflowID is run once after the children to combine their outputs.
+	ReducerWorkflowID string
+	// Body is the inline child workflow of a foreach node, or of a sub_workflow node
+	// embedding its child, run instead of WorkflowID.
+	Body *models.Workflow
+	// InputMapping sets child input keys to paths in the node input, e.g. "input.customer.id".
+	InputMapping map[string]string
 }
 
 // subWorkflowItemResult holds the result of a single child execution.
@@ -39,7 +50,7 @@ type subWorkflowItemResult struct {
 	DurationMs  int64  `json:"duration_ms,omitempty"`
 }
 
-// executeSubWorkflow handles fan-out execution of sub-workflow nodes.
+// executeSubWorkflow handles fan-out execution of sub_workflow and foreach nodes.
 func (de *DAGExecutor) executeSubWorkflow(
 	ctx context.Context,
 	execState *ExecutionState,
@@ -48,28 +59,61 @@ func (de *DAGExecutor) executeSubWorkflow(
 ) error {
 	cfg, err := parseSubWorkflowConfig(node)
 	if err != nil {
-		return fmt.Errorf("invalid sub_workflow config: %w", err)
+		return fmt.Errorf("invalid %s config: %w", node.Type, err)
 	}
 
-	// 1. Evaluate for_each expression to get items array
 	parentNodes := GetRegularParentNodes(execState.Workflow, node)
 	nodeCtx := PrepareNodeContext(execState, node, parentNodes, opts)
+	mapped, err := mapChildInput(cfg.InputMapping, nodeCtx.DirectParentOutput)
+	if err != nil {
+		return fmt.Errorf("input_mapping evaluation failed: %w", err)
+	}
+
+	// Load child workflow; foreach nodes and embedding sub_workflow nodes run their inline body
+	childWF := cfg.Body
+	if childWF == nil {
+		childWF, err = de.workflowLoader.LoadWorkflow(ctx, cfg.WorkflowID)
+		if err != nil {
+			return fmt.Errorf("failed to load child workflow %s: %w", cfg.WorkflowID, err)
+		}
+	}
+
+	// Without for_each the child runs once, as a step of the parent workflow
+	if cfg.ForEach == "" {
+		return de.executeSubWorkflowOnce(ctx, execState, node, childWF, cfg, nodeCtx.DirectParentOutput, mapped, opts)
+	}
+
+	// 1. Evaluate for_each expression to get items array
 	items, err := evaluateForEach(cfg.ForEach, nodeCtx.DirectParentOutput)
 	if err != nil {
 		return fmt.Errorf("for_each evaluation failed: %w", err)
 	}
 
-	// 2. Load child workflow
-	childWF, err := de.workflowLoader.LoadWorkflow(ctx, cfg.WorkflowID)
-	if err != nil {
-		return fmt.Errorf("failed to load child workflow %s: %w", cfg.WorkflowID, err)
+	var reducerWF *models.Workflow
+	if cfg.ReducerWorkflowID != "" {
+		reducerWF, err = de.workflowLoader.LoadWorkflow(ctx, cfg.ReducerWorkflowID)
+		if err != nil {
+			return fmt.Errorf("failed to load reducer workflow %s: %w", cfg.ReducerWorkflowID, err)
+		}
+	}
+
+	// Large arrays are split into chunks so that no child receives the whole array
+	totalItems := len(items)
+	if cfg.ChunkSize > 0 {
+		items = chunkItems(items, cfg.ChunkSize)
 	}
 
 	// 3. Handle empty array
 	if len(items) == 0 {
 		output := map[string]any{
 			"items":   []any{},
-			"summary": map[string]any{"total": 0, "completed": 0, "failed": 0},
+			"summary": subWorkflowSummary(cfg, 0, 0, 0, totalItems),
+		}
+		if node.Type == NodeTypeForEach {
+			output["results"] = []any{}
+		}
+		if reducerWF != nil {
+			return de.reduceSubWorkflow(ctx, execState, node, reducerWF, cfg, output, nil, opts)
 		}
 		execState.SetNodeOutput(node.ID, output)
 		execState.SetNodeStatus(node.ID, models.NodeExecutionStatusCompleted)
@@ -108,7 +152,7 @@ func (de *DAGExecutor) executeSubWorkflow(
 				defer func() { <-semaphore }()
 			}
 
-			result := de.executeSubWorkflowItem(cancelCtx, execState, node, childWF, cfg, idx, len(items), itm, opts)
+			result := de.executeSubWorkflowItem(cancelCtx, execState, node, childWF, cfg, idx, len(items), totalItems, itm, mapped, opts)
 			results[idx] = result
 
 			if result.Status == "completed" {
@@ -169,7 +213,11 @@ func (de *DAGExecutor) executeSubWorkflow(
 
 	// 5. Build output
 	itemOutputs := make([]any, len(results))
+	var completedOutputs []any
 	for i, r := range results {
+		if r.Status == "completed" {
+			completedOutputs = append(completedOutputs, r.Output)
+		}
 		itemOutputs[i] = map[string]any{
 			"index":        r.Index,
 			"status":       r.Status,
@@ -184,12 +232,11 @@ func (de *DAGExecutor) executeSubWorkflow(
 	finalFailed := int(atomic.LoadInt64(&failed))
 
 	output := map[string]any{
-		"items": itemOutputs,
-		"summary": map[string]any{
-			"total":     len(items),
-			"completed": finalCompleted,
-			"failed":    finalFailed,
-		},
+		"items":   itemOutputs,
+		"summary": subWorkflowSummary(cfg, len(items), finalCompleted, finalFailed, totalItems),
+	}
+	if node.Type == NodeTypeForEach {
+		output["results"] = forEachResults(results)
 	}
 
 	execState.SetNodeOutput(node.ID, output)
@@ -202,11 +249,135 @@ func (de *DAGExecutor) executeSubWorkflow(
 		return firstErr
 	}
 
+	if reducerWF != nil {
+		return de.reduceSubWorkflow(ctx, execState, node, reducerWF, cfg, output, completedOutputs, opts)
+	}
+
+	execState.SetNodeStatus(node.ID, models.NodeExecutionStatusCompleted)
+	return nil
+}
+
+// reduceSubWorkflow runs the reducer workflow over the outputs of the completed children
+// and adds its result to the node output as "reduced".
+func (de *DAGExecutor) reduceSubWorkflow(
+	ctx context.Context,
+	execState *ExecutionState,
+	node *models.Node,
+	reducerWF *models.Workflow,
+	cfg *subWorkflowConfig,
+	output map[string]any,
+	results []any,
+	opts *ExecutionOptions,
+) error {
+	if results == nil {
+		results = []any{}
+	}
+	input := map[string]any{
+		"results": results,
+		"summary": output["summary"],
+	}
+	result := de.runChildWorkflow(ctx, execState, node, reducerWF, input, nil, cfg.TimeoutPerItem, opts)
+
+	output["reducer"] = map[string]any{
+		"status":       result.Status,
+		"execution_id": result.ExecutionID,
+		"error":        result.Error,
+		"duration_ms":  result.DurationMs,
+	}
+	output["reduced"] = result.Output
+	execState.SetNodeOutput(node.ID, output)
+
+	if result.Status != "completed" {
+		err := fmt.Errorf("reducer workflow %s failed: %s", cfg.ReducerWorkflowID, result.Error)
+		execState.SetNodeStatus(node.ID, models.NodeExecutionStatusFailed)
+		execState.SetNodeError(node.ID, err)
+		return err
+	}
+
+	execState.SetNodeStatus(node.ID, models.NodeExecutionStatusCompleted)
+	return nil
+}
+
+// subWorkflowSummary builds the summary of a fan-out; total counts child executions,
+// which are chunks when chunk_size is set.
+func subWorkflowSummary(cfg *subWorkflowConfig, total, completed, failed, totalItems int) map[string]any {
+	summary := map[string]any{
+		"total":     total,
+		"completed": completed,
+		"failed":    failed,
+	}
+	if cfg.ChunkSize > 0 {
+		summary["total_items"] = totalItems
+		summary["chunk_size"] = cfg.ChunkSize
+	}
+	return summary
+}
+
+// executeSubWorkflowOnce runs the child workflow once and makes its output the node
+// output. The child input is the mapped input, or the node input without a mapping.
+func (de *DAGExecutor) executeSubWorkflowOnce(
+	ctx context.Context,
+	execState *ExecutionState,
+	node *models.Node,
+	childWF *models.Workflow,
+	cfg *subWorkflowConfig,
+	input map[string]any,
+	mapped map[string]any,
+	opts *ExecutionOptions,
+) error {
+	childInput := mapped
+	if cfg.InputMapping == nil {
+		childInput = make(map[string]any, len(input))
+		for k, v := range input {
+			childInput[k] = v
+		}
+	}
+
+	result := de.runChildWorkflow(ctx, execState, node, childWF, childInput, nil, cfg.TimeoutPerItem, opts)
+
+	execState.SetNodeInput(node.ID, input)
+	execState.SetNodeConfig(node.ID, node.Config)
+	if result.Status != "completed" {
+		err := fmt.Errorf("child workflow %s failed: %s", childWF.ID, result.Error)
+		execState.SetNodeStatus(node.ID, models.NodeExecutionStatusFailed)
+		execState.SetNodeError(node.ID, err)
+		return err
+	}
+
+	execState.SetNodeOutput(node.ID, result.Output)
 	execState.SetNodeStatus(node.ID, models.NodeExecutionStatusCompleted)
 	return nil
 }
 
-// executeSubWorkflowItem executes a single child workflow for one array item.
+// mapChildInput evaluates an input mapping against the node input. It returns nil without
+// a mapping.
+func mapChildInput(mapping map[string]string, input map[string]any) (map[string]any, error) {
+	if mapping == nil {
+		return nil, nil
+	}
+	mapped := make(map[string]any, len(mapping))
+	for key, path := range mapping {
+		value, err := evaluatePath(path, input)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		mapped[key] = value
+	}
+	return mapped, nil
+}
+
+// chunkItems splits items into consecutive arrays of at most size items.
+func chunkItems(items []any, size int) []any {
+	chunks := make([]any, 0, (len(items)+size-1)/size)
+	for start := 0; start < len(items); start += size {
+		end := min(start+size, len(items))
+		chunks = append(chunks, items[start:end:end])
+	}
+	return chunks
+}
+
+// executeSubWorkflowItem executes a single child workflow for one array item,
+// or for one chunk of items when chunk_size is set.
 func (de *DAGExecutor) executeSubWorkflowItem(
 	ctx context.Context,
 	parentState *ExecutionState,
@@ -214,15 +385,46 @@ func (de *DAGExecutor) executeSubWorkflowItem(
 	childWF *models.Workflow,
 	cfg *subWorkflowConfig,
 	index int,
+	total int,
 	totalItems int,
 	item any,
+	mapped map[string]any,
+	opts *ExecutionOptions,
+) subWorkflowItemResult {
+	// Build child input; the item fields take precedence over mapped ones
+	childInput := make(map[string]any, len(mapped)+3)
+	for k, v := range mapped {
+		childInput[k] = v
+	}
+	childInput[cfg.ItemVar] = item
+	childInput["index"] = index
+	childInput["total"] = total
+	if cfg.ChunkSize > 0 {
+		childInput["offset"] = index * cfg.ChunkSize
+		childInput["total_items"] = totalItems
+	}
+
+	result := de.runChildWorkflow(ctx, parentState, parentNode, childWF, childInput, &index, cfg.TimeoutPerItem, opts)
+	result.Index = index
+	return result
+}
+
+// runChildWorkflow executes a workflow as a child of the parent node. The parent
+// execution input is inherited for keys the child input does not set.
+func (de *DAGExecutor) runChildWorkflow(
+	ctx context.Context,
+	parentState *ExecutionState,
+	parentNode *models.Node,
+	childWF *models.Workflow,
+	childInput map[string]any,
+	itemIndex *int,
+	timeout time.Duration,
 	opts *ExecutionOptions,
 ) subWorkflowItemResult {
 	startTime := time.Now()
 	childExecID := uuid.New().String()
 
 	result := subWorkflowItemResult{
-		Index:       index,
 		ExecutionID: childExecID,
 	}
 
@@ -234,12 +436,6 @@ func (de *DAGExecutor) executeSubWorkflowItem(
 		return result
 	}
 
-	// Build child input
-	childInput := map[string]any{
-		cfg.ItemVar: item,
-		"index":     index,
-		"total":     totalItems,
-	}
 	// Inherit parent execution input as context
 	for k, v := range parentState.Input {
 		if _, exists := childInput[k]; !exists {
@@ -251,20 +447,32 @@ func (de *DAGExecutor) executeSubWorkflowItem(
 	childState := NewExecutionState(childExecID, clonedWF.ID, clonedWF, childInput, parentState.Variables)
 	childState.ParentExecutionID = parentState.ExecutionID
 	childState.ParentNodeID = parentNode.ID
-	idx := index
-	childState.ItemIndex = &idx
+	childState.ItemIndex = itemIndex
 	childState.Resources = parentState.Resources
+	childState.Propagation = parentState.Propagation
+	childState.typeLimiter = parentState.typeLimiter
 
 	// Apply per-item timeout
 	execCtx := ctx
-	if cfg.TimeoutPerItem > 0 {
+	if timeout > 0 {
 		var cancel context.CancelFunc
-		execCtx, cancel = context.WithTimeout(ctx, cfg.TimeoutPerItem)
+		execCtx, cancel = context.WithTimeout(ctx, timeout)
 		defer cancel()
 	}
 
+	// The node selection of a partial run and its breakpoints apply to the parent workflow
+	// only, and child executions are not persisted, so their delays wait inline
+	childOpts := opts
+	if opts.Selection != nil || opts.AllowSuspend || len(opts.Breakpoints) > 0 {
+		copied := *opts
+		copied.Selection = nil
+		copied.AllowSuspend = false
+		copied.Breakpoints = nil
+		childOpts = &copied
+	}
+
 	// Execute child workflow
-	err = de.Execute(execCtx, childState, opts)
+	err = de.Execute(execCtx, childState, childOpts)
 
 	result.DurationMs = time.Since(startTime).Milliseconds()
 
@@ -284,15 +492,18 @@ func (de *DAGExecutor) executeSubWorkflowItem(
 func collectChildOutput(state *ExecutionState) any {
 	// Find terminal nodes (nodes with no outgoing edges)
 	outgoing := make(map[string]bool)
+	compensation := make(map[string]bool)
 	for _, edge := range state.Workflow.Edges {
-		if !edge.IsLoop() {
+		if edge.IsCompensation() {
+			compensation[edge.To] = true
+		} else if !edge.IsLoop() {
 			outgoing[edge.From] = true
 		}
 	}
 
 	outputs := make(map[string]any)
 	for _, node := range state.Workflow.Nodes {
-		if !outgoing[node.ID] {
+		if !outgoing[node.ID] && !compensation[node.ID] {
 			if output, ok := state.GetNodeOutput(node.ID); ok {
 				outputs[node.ID] = output
 			}
@@ -308,29 +519,74 @@ func collectChildOutput(state *ExecutionState) any {
 	return outputs
 }
 
-// parseSubWorkflowConfig extracts and validates sub_workflow config from node.
+// parseSubWorkflowConfig extracts and validates sub_workflow or foreach config from node.
 func parseSubWorkflowConfig(node *models.Node) (*subWorkflowConfig, error) {
 	cfg := &subWorkflowConfig{
 		ItemVar: SubWorkflowDefaultItemVar,
 		OnError: SubWorkflowDefaultOnError,
 	}
 
-	wfID, ok := node.Config["workflow_id"].(string)
-	if !ok || wfID == "" {
-		return nil, fmt.Errorf("workflow_id is required")
+	_, embedded := node.Config["body"]
+	if node.Type == NodeTypeForEach || embedded {
+		body, err := parseInlineBody(node)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Body = body
+	} else {
+		wfID, ok := node.Config["workflow_id"].(string)
+		if !ok || wfID == "" {
+			return nil, fmt.Errorf("workflow_id or body is required")
+		}
+		cfg.WorkflowID = wfID
 	}
-	cfg.WorkflowID = wfID
 
-	forEach, ok := node.Config["for_each"].(string)
-	if !ok || forEach == "" {
+	// A sub_workflow node without for_each runs its child once
+	forEach, _ := node.Config["for_each"].(string)
+	if forEach == "" && node.Type == NodeTypeForEach {
 		return nil, fmt.Errorf("for_each is required")
 	}
 	cfg.ForEach = forEach
 
+	if raw, ok := node.Config["input_mapping"]; ok && raw != nil {
+		mapping, err := parseInputMapping(raw)
+		if err != nil {
+			return nil, err
+		}
+		cfg.InputMapping = mapping
+	}
+
+	if cs, ok := node.Config["chunk_size"]; ok {
+		switch v := cs.(type) {
+		case float64:
+			cfg.ChunkSize = int(v)
+		case int:
+			cfg.ChunkSize = v
+		case json.Number:
+			n, err := v.Int64()
+			if err != nil {
+				return nil, fmt.Errorf("chunk_size must be an integer")
+			}
+			cfg.ChunkSize = int(n)
+		default:
+			return nil, fmt.Errorf("chunk_size must be a number")
+		}
+		if cfg.ChunkSize < 0 {
+			return nil, fmt.Errorf("chunk_size must be non-negative")
+		}
+		if cfg.ChunkSize > 0 {
+			cfg.ItemVar = SubWorkflowChunkItemVar
+		}
+	}
+
 	if iv, ok := node.Config["item_var"].(string); ok && iv != "" {
 		cfg.ItemVar = iv
 	}
 
+	if rw, ok := node.Config["reducer_workflow_id"].(string); ok {
+		cfg.ReducerWorkflowID = rw
+	}
+
 	if mp, ok := node.Config["max_parallelism"]; ok {
 		switch v := mp.(type) {
 		case float64:
@@ -353,11 +609,49 @@ func parseSubWorkflowConfig(node *models.Node) (*subWorkflowConfig, error) {
 		}
 	}
 
+	if forEach == "" && (cfg.ChunkSize > 0 || cfg.ReducerWorkflowID != "") {
+		return nil, fmt.Errorf("chunk_size and reducer_workflow_id require for_each")
+	}
+
 	return cfg, nil
 }
 
+// parseInputMapping converts the input_mapping config, an object of child input keys to
+// paths, into a map.
+func parseInputMapping(raw any) (map[string]string, error) {
+	var entries map[string]any
+	switch v := raw.(type) {
+	case map[string]string:
+		return v, nil
+	case map[string]any:
+		entries = v
+	default:
+		return nil, fmt.Errorf("input_mapping must be an object")
+	}
+	mapping := make(map[string]string, len(entries))
+	for key, value := range entries {
+		path, ok := value.(string)
+		if !ok || path == "" {
+			return nil, fmt.Errorf("input_mapping.%s must be a non-empty path", key)
+		}
+		mapping[key] = path
+	}
+	return mapping, nil
+}
+
 // evaluateForEach evaluates the for_each expression and returns items as a slice.
 func evaluateForEach(expression string, input map[string]any) ([]any, error) {
+	current, err := evaluatePath(expression, input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to []any
+	return toSlice(current)
+}
+
+// evaluatePath returns the value at a dot-separated path in the input.
+func evaluatePath(expression string, input map[string]any) (any, error) {
 	// Navigate dot-separated path: "input.cells" -> input["cells"]
 	parts := splitDotPath(expression)
 
@@ -375,9 +669,7 @@ func evaluateForEach(expression string, input map[string]any) ([]any, error) {
 			return nil, fmt.Errorf("path %q: key %q not found", expression, part)
 		}
 	}
-
-	// Convert to []any
-	return toSlice(current)
+	return current, nil
 }
 
 // splitDotPath splits "input.cells" into ["input", "cells"].
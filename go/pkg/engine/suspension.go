@@ -0,0 +1,209 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// Suspension records a node waiting until ResumeAt, such as a delay node of an execution
+// that is persisted while it waits. Output becomes the node's output once it resumes.
+// A suspension with an EventKey resumes early when the event is delivered (see DeliverEvent).
+type Suspension struct {
+	ResumeAt time.Time `json:"resume_at"`
+	Output   any       `json:"output,omitempty"`
+	EventKey string    `json:"event_key,omitempty"`
+}
+
+// SuspendNode records that the node waits until the suspension's ResumeAt.
+// The node stays running; nodes downstream of it are deferred until it resumes.
+func (es *ExecutionState) SuspendNode(nodeID string, suspension *Suspension) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	es.Suspensions[nodeID] = suspension
+	es.NodeStatus[nodeID] = models.NodeExecutionStatusRunning
+}
+
+// GetSuspension returns the suspension of a node, if it is suspended.
+func (es *ExecutionState) GetSuspension(nodeID string) (*Suspension, bool) {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+	suspension, ok := es.Suspensions[nodeID]
+	return suspension, ok
+}
+
+// NextResumeAt returns the earliest time a suspended node resumes, or false if no node is suspended.
+func (es *ExecutionState) NextResumeAt() (time.Time, bool) {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+	var next time.Time
+	for _, suspension := range es.Suspensions {
+		if next.IsZero() || suspension.ResumeAt.Before(next) {
+			next = suspension.ResumeAt
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// EventKeys returns the event keys suspended nodes wait for.
+func (es *ExecutionState) EventKeys() []string {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+	var keys []string
+	for _, suspension := range es.Suspensions {
+		if suspension.EventKey != "" {
+			keys = append(keys, suspension.EventKey)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// DeliverEvent resumes the nodes waiting for the event key at now, completing them with the
+// event. It reports whether any node was waiting for the key.
+func (es *ExecutionState) DeliverEvent(key string, event any, now time.Time) bool {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	delivered := false
+	for _, suspension := range es.Suspensions {
+		if key == "" || suspension.EventKey != key {
+			continue
+		}
+		suspension.ResumeAt = now
+		suspension.Output = executor.EventOutput(key, event, false)
+		delivered = true
+	}
+	return delivered
+}
+
+// deferNode marks a node that did not run because a node upstream of it is suspended.
+func (es *ExecutionState) deferNode(nodeID string) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	es.deferred[nodeID] = true
+}
+
+// blockedBySuspension returns the first regular parent of the node that is suspended or
+// deferred, or an empty string if none is.
+func (es *ExecutionState) blockedBySuspension(node *models.Node) string {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+	if len(es.Suspensions) == 0 {
+		return ""
+	}
+	for _, edge := range CollectRegularIncomingEdges(es.Workflow.Edges, node.ID) {
+		if _, ok := es.Suspensions[edge.From]; ok || es.deferred[edge.From] {
+			return edge.From
+		}
+	}
+	return ""
+}
+
+// settledNodes returns the nodes a resumed execution must not run again: nodes that reached a
+// terminal status before the execution was suspended and nodes that are still suspended.
+func (es *ExecutionState) settledNodes() map[string]bool {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	es.deferred = make(map[string]bool)
+
+	settled := make(map[string]bool)
+	for nodeID, status := range es.NodeStatus {
+		if status.IsTerminal() {
+			settled[nodeID] = true
+		}
+	}
+	for nodeID := range es.Suspensions {
+		settled[nodeID] = true
+	}
+	return settled
+}
+
+// resumeDueNodes completes the suspended nodes whose ResumeAt has passed with their stored output.
+func (de *DAGExecutor) resumeDueNodes(ctx context.Context, execState *ExecutionState, now time.Time) {
+	for _, node := range execState.Workflow.Nodes {
+		suspension, ok := execState.GetSuspension(node.ID)
+		if !ok || suspension.ResumeAt.After(now) {
+			continue
+		}
+
+		execState.mu.Lock()
+		delete(execState.Suspensions, node.ID)
+		execState.mu.Unlock()
+
+		execState.SetNodeOutput(node.ID, suspension.Output)
+		execState.SetNodeStatus(node.ID, models.NodeExecutionStatusCompleted)
+		execState.SetNodeEndTime(node.ID, now)
+
+		de.safeNotify(ctx, ExecutionEvent{
+			Type:        EventTypeNodeCompleted,
+			ExecutionID: execState.ExecutionID,
+			WorkflowID:  execState.WorkflowID,
+			Timestamp:   now,
+			Status:      "completed",
+			NodeID:      node.ID,
+			NodeName:    node.Name,
+			NodeType:    node.Type,
+			Output:      ToMapInterface(suspension.Output),
+			Metadata:    map[string]any{"resumed": true},
+		})
+	}
+}
+
+// suspendNode records a node suspension and notifies observers.
+func (de *DAGExecutor) suspendNode(
+	ctx context.Context,
+	execState *ExecutionState,
+	node *models.Node,
+	execResult *NodeExecutionResult,
+	suspend *executor.SuspendError,
+) {
+	if execResult != nil {
+		execState.SetNodeInput(node.ID, execResult.Input)
+		execState.SetNodeConfig(node.ID, execResult.Config)
+		execState.SetNodeResolvedConfig(node.ID, execResult.ResolvedConfig)
+	}
+	execState.SuspendNode(node.ID, &Suspension{ResumeAt: suspend.ResumeAt, Output: suspend.Output, EventKey: suspend.EventKey})
+
+	metadata := map[string]any{"resume_at": suspend.ResumeAt.UTC().Format(time.RFC3339)}
+	if suspend.EventKey != "" {
+		metadata["event_key"] = suspend.EventKey
+	}
+
+	de.safeNotify(ctx, ExecutionEvent{
+		Type:        EventTypeNodeSuspended,
+		ExecutionID: execState.ExecutionID,
+		WorkflowID:  execState.WorkflowID,
+		Timestamp:   time.Now(),
+		Status:      "suspended",
+		NodeID:      node.ID,
+		NodeName:    node.Name,
+		NodeType:    node.Type,
+		Metadata:    metadata,
+	})
+}
+
+// waitUntil blocks until t or until ctx is done.
+func waitUntil(ctx context.Context, t time.Time) error {
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("execution cancelled while waiting until %s: %w", t.UTC().Format(time.RFC3339), ctx.Err())
+	}
+}
+
+// suspendedError returns the error reporting that the execution stopped with suspended nodes,
+// or nil if no node is suspended.
+func suspendedError(execState *ExecutionState) error {
+	resumeAt, ok := execState.NextResumeAt()
+	if !ok {
+		return nil
+	}
+	return fmt.Errorf("%w until %s", models.ErrExecutionSuspended, resumeAt.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,312 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// suspensionTestExecutor returns a DAG executor whose "wait" nodes suspend for delay
+// and whose "test" nodes record their calls.
+func suspensionTestExecutor(delay time.Duration) (*DAGExecutor, map[string]int, *sync.Mutex) {
+	calls := make(map[string]int)
+	var mu sync.Mutex
+	record := func(config map[string]any) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls[config["nodeID"].(string)]++
+	}
+
+	registry := executor.NewManager()
+	registry.Register("wait", &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			record(config)
+			return nil, executor.Suspend(time.Now().Add(delay), map[string]any{"waited": true})
+		},
+	})
+	registry.Register("test", &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			record(config)
+			return map[string]any{"node": config["nodeID"]}, nil
+		},
+	})
+
+	return NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), &recordingNotifier{}, NewNilWorkflowLoader()), calls, &mu
+}
+
+func suspensionTestWorkflow() *models.Workflow {
+	return &models.Workflow{
+		ID: "wf-delay",
+		Nodes: []*models.Node{
+			{ID: "start", Name: "Start", Type: "test", Config: map[string]any{"nodeID": "start"}},
+			{ID: "wait", Name: "Wait", Type: "wait", Config: map[string]any{"nodeID": "wait"}},
+			{ID: "after", Name: "After", Type: "test", Config: map[string]any{"nodeID": "after"}},
+			{ID: "other", Name: "Other", Type: "test", Config: map[string]any{"nodeID": "other"}},
+		},
+		Edges: []*models.Edge{
+			{ID: "e1", From: "start", To: "wait"},
+			{ID: "e2", From: "wait", To: "after"},
+			{ID: "e3", From: "start", To: "other"},
+		},
+	}
+}
+
+func TestDAGExecutor_SuspendAndResume(t *testing.T) {
+	t.Parallel()
+
+	dagExec, calls, mu := suspensionTestExecutor(time.Hour)
+	execState := NewExecutionState("exec-1", "wf-delay", suspensionTestWorkflow(), map[string]any{}, nil)
+	opts := DefaultExecutionOptions()
+	opts.AllowSuspend = true
+
+	err := dagExec.Execute(context.Background(), execState, opts)
+	if !errors.Is(err, models.ErrExecutionSuspended) {
+		t.Fatalf("expected ErrExecutionSuspended, got %v", err)
+	}
+
+	suspension, ok := execState.GetSuspension("wait")
+	if !ok {
+		t.Fatal("expected wait node to be suspended")
+	}
+	if status, _ := execState.GetNodeStatus("wait"); status != models.NodeExecutionStatusRunning {
+		t.Errorf("expected suspended node to stay running, got %s", status)
+	}
+	if status, _ := execState.GetNodeStatus("other"); status != models.NodeExecutionStatusCompleted {
+		t.Errorf("expected independent branch to complete, got %s", status)
+	}
+	if status, _ := execState.GetNodeStatus("after"); status.IsTerminal() {
+		t.Errorf("expected downstream node to be deferred, got %s", status)
+	}
+	if next, ok := execState.NextResumeAt(); !ok || !next.Equal(suspension.ResumeAt) {
+		t.Errorf("expected next resume at %v, got %v", suspension.ResumeAt, next)
+	}
+
+	// Resuming before the delay is over runs nothing
+	err = dagExec.Execute(context.Background(), execState, opts)
+	if !errors.Is(err, models.ErrExecutionSuspended) {
+		t.Fatalf("expected execution to stay suspended, got %v", err)
+	}
+
+	suspension.ResumeAt = time.Now().Add(-time.Second)
+	if err := dagExec.Execute(context.Background(), execState, opts); err != nil {
+		t.Fatalf("resumed execution failed: %v", err)
+	}
+
+	if output, _ := execState.GetNodeOutput("wait"); output.(map[string]any)["waited"] != true {
+		t.Errorf("expected suspended node to complete with its stored output, got %v", output)
+	}
+	if status, _ := execState.GetNodeStatus("after"); status != models.NodeExecutionStatusCompleted {
+		t.Errorf("expected downstream node to complete after resume, got %s", status)
+	}
+	if _, ok := execState.GetSuspension("wait"); ok {
+		t.Error("expected suspension to be cleared after resume")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := map[string]int{"start": 1, "wait": 1, "after": 1, "other": 1}
+	for nodeID, count := range want {
+		if calls[nodeID] != count {
+			t.Errorf("expected %s to run %d time(s), got %d", nodeID, count, calls[nodeID])
+		}
+	}
+}
+
+func TestDAGExecutor_SuspendWaitsInlineWithoutAllowSuspend(t *testing.T) {
+	t.Parallel()
+
+	dagExec, _, _ := suspensionTestExecutor(20 * time.Millisecond)
+	execState := NewExecutionState("exec-1", "wf-delay", suspensionTestWorkflow(), map[string]any{}, nil)
+
+	if err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions()); err != nil {
+		t.Fatalf("DAG execution failed: %v", err)
+	}
+
+	if output, _ := execState.GetNodeOutput("wait"); output.(map[string]any)["waited"] != true {
+		t.Errorf("expected waiting node to complete with its output, got %v", output)
+	}
+	if status, _ := execState.GetNodeStatus("after"); status != models.NodeExecutionStatusCompleted {
+		t.Errorf("expected downstream node to complete, got %s", status)
+	}
+}
+
+func TestDAGExecutor_SuspendInlineWaitCancelled(t *testing.T) {
+	t.Parallel()
+
+	dagExec, _, _ := suspensionTestExecutor(time.Hour)
+	execState := NewExecutionState("exec-1", "wf-delay", suspensionTestWorkflow(), map[string]any{}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := dagExec.Execute(ctx, execState, DefaultExecutionOptions())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if status, _ := execState.GetNodeStatus("wait"); status != models.NodeExecutionStatusFailed {
+		t.Errorf("expected waiting node to fail, got %s", status)
+	}
+}
+
+func TestInternalRetryPolicy_DoesNotRetrySuspend(t *testing.T) {
+	t.Parallel()
+
+	policy := DefaultInternalRetryPolicy()
+	if policy.ShouldRetry(executor.Suspend(time.Now().Add(time.Hour), nil)) {
+		t.Error("expected suspension not to be retried")
+	}
+}
+
+// eventTestExecution returns a DAG executor and a workflow whose wait_for_event node waits for
+// "order-1" and routes to "received" on the event and to "expired" on timeout.
+func eventTestExecution(timeout time.Duration) (*DAGExecutor, *ExecutionState) {
+	registry := executor.NewManager()
+	registry.Register(NodeTypeWaitForEvent, &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			return nil, executor.SuspendForEvent("order-1", time.Now().Add(timeout))
+		},
+	})
+	registry.Register("test", &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			return map[string]any{"node": config["nodeID"]}, nil
+		},
+	})
+
+	workflow := &models.Workflow{
+		ID: "wf-event",
+		Nodes: []*models.Node{
+			{ID: "wait", Name: "Wait", Type: NodeTypeWaitForEvent},
+			{ID: "received", Name: "Received", Type: "test", Config: map[string]any{"nodeID": "received"}},
+			{ID: "expired", Name: "Expired", Type: "test", Config: map[string]any{"nodeID": "expired"}},
+		},
+		Edges: []*models.Edge{
+			{ID: "e1", From: "wait", To: "received", SourceHandle: SourceHandleEvent},
+			{ID: "e2", From: "wait", To: "expired", SourceHandle: SourceHandleTimeout},
+		},
+	}
+
+	dagExec := NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), &recordingNotifier{}, NewNilWorkflowLoader())
+	return dagExec, NewExecutionState("exec-event", "wf-event", workflow, map[string]any{}, nil)
+}
+
+func TestDAGExecutor_WaitForEventDelivered(t *testing.T) {
+	t.Parallel()
+
+	dagExec, execState := eventTestExecution(time.Hour)
+	opts := DefaultExecutionOptions()
+	opts.AllowSuspend = true
+
+	if err := dagExec.Execute(context.Background(), execState, opts); !errors.Is(err, models.ErrExecutionSuspended) {
+		t.Fatalf("expected ErrExecutionSuspended, got %v", err)
+	}
+	if keys := execState.EventKeys(); len(keys) != 1 || keys[0] != "order-1" {
+		t.Fatalf("expected execution to wait for order-1, got %v", keys)
+	}
+
+	if execState.DeliverEvent("order-2", "ignored", time.Now()) {
+		t.Error("expected event with another key not to be delivered")
+	}
+	if !execState.DeliverEvent("order-1", map[string]any{"paid": true}, time.Now()) {
+		t.Fatal("expected event to be delivered")
+	}
+	if err := dagExec.Execute(context.Background(), execState, opts); err != nil {
+		t.Fatalf("resumed execution failed: %v", err)
+	}
+
+	output, _ := execState.GetNodeOutput("wait")
+	event, _ := output.(map[string]any)
+	if event["timed_out"] != false || event["event"].(map[string]any)["paid"] != true {
+		t.Errorf("expected wait node to complete with the event, got %v", output)
+	}
+	if status, _ := execState.GetNodeStatus("received"); status != models.NodeExecutionStatusCompleted {
+		t.Errorf("expected event branch to run, got %s", status)
+	}
+	if status, _ := execState.GetNodeStatus("expired"); status != models.NodeExecutionStatusSkipped {
+		t.Errorf("expected timeout branch to be skipped, got %s", status)
+	}
+}
+
+func TestDAGExecutor_WaitForEventTimeout(t *testing.T) {
+	t.Parallel()
+
+	// Without AllowSuspend the node waits inline until the timeout
+	dagExec, execState := eventTestExecution(10 * time.Millisecond)
+	if err := dagExec.Execute(context.Background(), execState, DefaultExecutionOptions()); err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	output, _ := execState.GetNodeOutput("wait")
+	if output.(map[string]any)["timed_out"] != true {
+		t.Errorf("expected wait node to time out, got %v", output)
+	}
+	if status, _ := execState.GetNodeStatus("expired"); status != models.NodeExecutionStatusCompleted {
+		t.Errorf("expected timeout branch to run, got %s", status)
+	}
+	if status, _ := execState.GetNodeStatus("received"); status != models.NodeExecutionStatusSkipped {
+		t.Errorf("expected event branch to be skipped, got %s", status)
+	}
+}
+
+func TestDAGExecutor_ApprovalRoutesOnDecision(t *testing.T) {
+	t.Parallel()
+
+	registry := executor.NewManager()
+	registry.Register(NodeTypeApproval, &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			return nil, &executor.SuspendError{
+				ResumeAt: time.Now().Add(time.Hour),
+				Output:   executor.EventOutput("approval:exec-approval:review", map[string]any{"decision": "timeout"}, true),
+				EventKey: "approval:exec-approval:review",
+			}
+		},
+	})
+	registry.Register("test", &mockExecutor{
+		executeFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			return map[string]any{"node": config["nodeID"]}, nil
+		},
+	})
+
+	workflow := &models.Workflow{
+		ID: "wf-approval",
+		Nodes: []*models.Node{
+			{ID: "review", Name: "Review", Type: NodeTypeApproval},
+			{ID: "ship", Name: "Ship", Type: "test", Config: map[string]any{"nodeID": "ship"}},
+			{ID: "refund", Name: "Refund", Type: "test", Config: map[string]any{"nodeID": "refund"}},
+			{ID: "escalate", Name: "Escalate", Type: "test", Config: map[string]any{"nodeID": "escalate"}},
+		},
+		Edges: []*models.Edge{
+			{ID: "e1", From: "review", To: "ship", SourceHandle: SourceHandleApproved},
+			{ID: "e2", From: "review", To: "refund", SourceHandle: SourceHandleRejected},
+			{ID: "e3", From: "review", To: "escalate", SourceHandle: SourceHandleTimeout},
+		},
+	}
+
+	dagExec := NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), &recordingNotifier{}, NewNilWorkflowLoader())
+	execState := NewExecutionState("exec-approval", "wf-approval", workflow, map[string]any{}, nil)
+	opts := DefaultExecutionOptions()
+	opts.AllowSuspend = true
+
+	if err := dagExec.Execute(context.Background(), execState, opts); !errors.Is(err, models.ErrExecutionSuspended) {
+		t.Fatalf("expected ErrExecutionSuspended, got %v", err)
+	}
+	execState.DeliverEvent("approval:exec-approval:review", map[string]any{"decision": "rejected", "comment": "Out of stock"}, time.Now())
+	if err := dagExec.Execute(context.Background(), execState, opts); err != nil {
+		t.Fatalf("resumed execution failed: %v", err)
+	}
+
+	want := map[string]models.NodeExecutionStatus{
+		"ship":     models.NodeExecutionStatusSkipped,
+		"refund":   models.NodeExecutionStatusCompleted,
+		"escalate": models.NodeExecutionStatusSkipped,
+	}
+	for nodeID, status := range want {
+		if got, _ := execState.GetNodeStatus(nodeID); got != status {
+			t.Errorf("expected %s to be %s, got %s", nodeID, status, got)
+		}
+	}
+}
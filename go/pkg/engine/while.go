@@ -0,0 +1,163 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// NodeTypeWhile is a loop node that runs its inline body again while a condition over the
+// body's output holds.
+//
+// Config:
+//   - body: The subgraph run per iteration, {"nodes": [...], "edges": [...]} (required)
+//   - condition: expr-lang expression over the iteration's output as "output", e.g.
+//     "output.has_more" (required); the body runs again while it is true
+//   - max_iterations: Hard cap on iterations (default 10, at most MaxWhileIterations)
+//
+// The body always runs once. Each iteration receives the node input merged with the
+// previous iteration's output, plus "iteration" (0 for the first run), so templates can
+// read {{input.iteration}}. Output: {"output": last iteration's output, "iterations": n,
+// "exhausted": true if the cap stopped the loop while the condition still held}.
+const NodeTypeWhile = "while"
+
+// MaxWhileIterations bounds max_iterations of while nodes.
+const MaxWhileIterations = 1000
+
+// whileConfig holds parsed configuration for a while node.
+type whileConfig struct {
+	Body          *models.Workflow
+	Condition     string
+	MaxIterations int
+}
+
+// executeWhile runs the body of a while node until its condition is false or the
+// iteration cap is reached.
+func (de *DAGExecutor) executeWhile(
+	ctx context.Context,
+	execState *ExecutionState,
+	node *models.Node,
+	opts *ExecutionOptions,
+) error {
+	cfg, err := parseWhileConfig(node)
+	if err != nil {
+		return fmt.Errorf("invalid while config: %w", err)
+	}
+
+	parentNodes := GetRegularParentNodes(execState.Workflow, node)
+	nodeCtx := PrepareNodeContext(execState, node, parentNodes, opts)
+	execState.SetNodeInput(node.ID, nodeCtx.DirectParentOutput)
+	execState.SetNodeConfig(node.ID, node.Config)
+
+	var lastOutput any
+	iterations := 0
+	exhausted := false
+	for {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("execution cancelled: %w", err)
+		}
+
+		input := make(map[string]any, len(nodeCtx.DirectParentOutput)+1)
+		for k, v := range nodeCtx.DirectParentOutput {
+			input[k] = v
+		}
+		if outputMap, ok := lastOutput.(map[string]any); ok {
+			for k, v := range outputMap {
+				input[k] = v
+			}
+		}
+		input["iteration"] = iterations
+
+		iteration := iterations
+		result := de.runChildWorkflow(ctx, execState, node, cfg.Body, input, &iteration, 0, opts)
+		iterations++
+		if result.Status != "completed" {
+			err := fmt.Errorf("iteration %d failed: %s", iteration, result.Error)
+			execState.SetNodeStatus(node.ID, models.NodeExecutionStatusFailed)
+			execState.SetNodeError(node.ID, err)
+			return err
+		}
+		lastOutput = result.Output
+
+		again, err := de.conditionEvaluator.Evaluate(cfg.Condition, lastOutput)
+		if err != nil {
+			err = fmt.Errorf("while condition after iteration %d: %w", iteration, err)
+			execState.SetNodeStatus(node.ID, models.NodeExecutionStatusFailed)
+			execState.SetNodeError(node.ID, err)
+			return err
+		}
+		if !again {
+			break
+		}
+
+		if iterations >= cfg.MaxIterations {
+			exhausted = true
+			de.safeNotify(ctx, ExecutionEvent{
+				Type:          EventTypeLoopExhausted,
+				ExecutionID:   execState.ExecutionID,
+				WorkflowID:    execState.WorkflowID,
+				Timestamp:     time.Now(),
+				NodeID:        node.ID,
+				LoopIteration: iterations,
+				LoopMaxIter:   cfg.MaxIterations,
+				Message:       fmt.Sprintf("while %s exhausted after %d iterations", node.ID, iterations),
+			})
+			break
+		}
+
+		de.safeNotify(ctx, ExecutionEvent{
+			Type:          EventTypeLoopIteration,
+			ExecutionID:   execState.ExecutionID,
+			WorkflowID:    execState.WorkflowID,
+			Timestamp:     time.Now(),
+			NodeID:        node.ID,
+			LoopIteration: iterations,
+			LoopMaxIter:   cfg.MaxIterations,
+			Message:       fmt.Sprintf("while %s iteration %d/%d", node.ID, iterations, cfg.MaxIterations),
+		})
+	}
+
+	execState.SetNodeOutput(node.ID, map[string]any{
+		"output":     lastOutput,
+		"iterations": iterations,
+		"exhausted":  exhausted,
+	})
+	execState.SetNodeStatus(node.ID, models.NodeExecutionStatusCompleted)
+	return nil
+}
+
+// parseWhileConfig extracts and validates while config from node.
+func parseWhileConfig(node *models.Node) (*whileConfig, error) {
+	body, err := parseInlineBody(node)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &whileConfig{
+		Body:          body,
+		MaxIterations: DefaultMaxLoopIterations,
+	}
+
+	condition, ok := node.Config["condition"].(string)
+	if !ok || condition == "" {
+		return nil, fmt.Errorf("condition is required")
+	}
+	cfg.Condition = condition
+
+	if mi, ok := node.Config["max_iterations"]; ok {
+		switch v := mi.(type) {
+		case float64:
+			cfg.MaxIterations = int(v)
+		case int:
+			cfg.MaxIterations = v
+		default:
+			return nil, fmt.Errorf("max_iterations must be a number")
+		}
+		if cfg.MaxIterations <= 0 || cfg.MaxIterations > MaxWhileIterations {
+			return nil, fmt.Errorf("max_iterations must be between 1 and %d", MaxWhileIterations)
+		}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,126 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// whileTestExecutor returns a DAG executor whose "page" nodes return the page after
+// input.cursor, with has_more until the cursor reaches last.
+func whileTestExecutor(last int) *DAGExecutor {
+	registry := executor.NewManager()
+	registry.Register("page", &executor.ExecutorFunc{
+		ExecuteFn: func(ctx context.Context, config map[string]any, input any) (any, error) {
+			in := input.(map[string]any)
+			cursor := 0
+			if c, ok := in["cursor"].(int); ok {
+				cursor = c
+			}
+			if cursor < 0 {
+				return nil, fmt.Errorf("invalid cursor")
+			}
+			return map[string]any{
+				"cursor":   cursor + 1,
+				"seen":     in["iteration"],
+				"has_more": cursor+1 < last,
+			}, nil
+		},
+	})
+	return NewDAGExecutor(NewNodeExecutor(registry), NewExprConditionEvaluator(), NewNoOpNotifier(), nil)
+}
+
+func whileTestWorkflow(config map[string]any) *models.Workflow {
+	config["body"] = map[string]any{
+		"nodes": []any{map[string]any{"id": "fetch", "name": "Fetch", "type": "page"}},
+	}
+	return &models.Workflow{
+		ID:    "parent-wf",
+		Name:  "Parent",
+		Nodes: []*models.Node{{ID: "pages", Name: "Pages", Type: NodeTypeWhile, Config: config}},
+	}
+}
+
+func TestWhile_RepeatsWhileConditionHolds(t *testing.T) {
+	t.Parallel()
+
+	workflow := whileTestWorkflow(map[string]any{"condition": "output.has_more"})
+	execState := NewExecutionState("exec-1", "parent-wf", workflow, map[string]any{}, nil)
+
+	if err := whileTestExecutor(4).Execute(context.Background(), execState, DefaultExecutionOptions()); err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	output, _ := execState.GetNodeOutput("pages")
+	out := output.(map[string]any)
+	if out["iterations"] != 4 {
+		t.Errorf("expected 4 iterations, got %v", out["iterations"])
+	}
+	if out["exhausted"] != false {
+		t.Errorf("expected loop not exhausted, got %v", out["exhausted"])
+	}
+	last := out["output"].(map[string]any)
+	if last["cursor"] != 4 || last["seen"] != 3 {
+		t.Errorf("unexpected last output: %v", last)
+	}
+}
+
+func TestWhile_StopsAtMaxIterations(t *testing.T) {
+	t.Parallel()
+
+	workflow := whileTestWorkflow(map[string]any{"condition": "output.has_more", "max_iterations": float64(3)})
+	execState := NewExecutionState("exec-1", "parent-wf", workflow, map[string]any{}, nil)
+
+	if err := whileTestExecutor(100).Execute(context.Background(), execState, DefaultExecutionOptions()); err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	output, _ := execState.GetNodeOutput("pages")
+	out := output.(map[string]any)
+	if out["iterations"] != 3 || out["exhausted"] != true {
+		t.Errorf("expected exhausted after 3 iterations, got %v", out)
+	}
+}
+
+func TestWhile_FailsWhenIterationFails(t *testing.T) {
+	t.Parallel()
+
+	workflow := whileTestWorkflow(map[string]any{"condition": "output.has_more"})
+	execState := NewExecutionState("exec-1", "parent-wf", workflow, map[string]any{"cursor": -1}, nil)
+
+	if err := whileTestExecutor(4).Execute(context.Background(), execState, DefaultExecutionOptions()); err == nil {
+		t.Fatal("expected execution to fail")
+	}
+	if status, _ := execState.GetNodeStatus("pages"); status != models.NodeExecutionStatusFailed {
+		t.Errorf("expected node failed, got %s", status)
+	}
+}
+
+func TestParseWhileConfig(t *testing.T) {
+	t.Parallel()
+
+	body := map[string]any{"nodes": []any{map[string]any{"id": "a", "name": "A", "type": "page"}}}
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr bool
+	}{
+		{"defaults", map[string]any{"body": body, "condition": "output.more"}, false},
+		{"missing body", map[string]any{"condition": "output.more"}, true},
+		{"missing condition", map[string]any{"body": body}, true},
+		{"zero cap", map[string]any{"body": body, "condition": "true", "max_iterations": 0}, true},
+		{"cap too high", map[string]any{"body": body, "condition": "true", "max_iterations": MaxWhileIterations + 1}, true},
+	}
+	for _, tt := range tests {
+		cfg, err := parseWhileConfig(&models.Node{ID: "w", Type: NodeTypeWhile, Config: tt.config})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+		if err == nil && cfg.MaxIterations != DefaultMaxLoopIterations {
+			t.Errorf("%s: expected default max iterations, got %d", tt.name, cfg.MaxIterations)
+		}
+	}
+}
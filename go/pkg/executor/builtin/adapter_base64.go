@@ -23,6 +23,9 @@ func NewBase64ToBytesExecutor() *Base64ToBytesExecutor {
 	}
 }
 
+// SideEffectFree reports true. Decoding base64 has no side effects.
+func (e *Base64ToBytesExecutor) SideEffectFree() bool { return true }
+
 // Execute decodes base64 string to bytes
 //
 // Config:
@@ -174,6 +177,9 @@ func NewBytesToBase64Executor() *BytesToBase64Executor {
 	}
 }
 
+// SideEffectFree reports true. Encoding base64 has no side effects.
+func (e *BytesToBase64Executor) SideEffectFree() bool { return true }
+
 // Execute encodes bytes to base64 string
 //
 // Config:
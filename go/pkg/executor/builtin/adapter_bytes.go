@@ -27,6 +27,9 @@ func NewBytesToJsonExecutor() *BytesToJsonExecutor {
 	}
 }
 
+// SideEffectFree reports true. Decoding bytes has no side effects.
+func (e *BytesToJsonExecutor) SideEffectFree() bool { return true }
+
 // Execute decodes bytes to JSON
 //
 // Config:
@@ -24,6 +24,9 @@ func NewStringToJsonExecutor() *StringToJsonExecutor {
 	}
 }
 
+// SideEffectFree reports true. Parsing JSON has no side effects.
+func (e *StringToJsonExecutor) SideEffectFree() bool { return true }
+
 // Execute parses JSON string
 //
 // Config:
@@ -118,6 +121,9 @@ func NewJsonToStringExecutor() *JsonToStringExecutor {
 	}
 }
 
+// SideEffectFree reports true. Serializing JSON has no side effects.
+func (e *JsonToStringExecutor) SideEffectFree() bool { return true }
+
 // Execute serializes JSON to string
 //
 // Config:
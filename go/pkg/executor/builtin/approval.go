@@ -0,0 +1,94 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// DefaultApprovalTimeout is how long an approval node waits when no timeout is configured.
+const DefaultApprovalTimeout = 7 * 24 * time.Hour
+
+// ApprovalExecutor suspends its branch of the workflow until a user approves or rejects it
+// (POST /api/v1/executions/{id}/approvals/{node_id}/approve or /reject) or until it times out.
+//
+// Config:
+//   - message: What the approvers are asked to decide, e.g. "Refund {{input.amount}} EUR?"
+//   - approvers: User ID or list of user IDs allowed to decide (default: any user)
+//   - timeout: Go duration string or a number of seconds (default 168h)
+//
+// Output: {"correlation_key": ..., "event": {"decision": "approved" | "rejected" | "timeout",
+// "comment", "decided_by", "decided_at"}, "timed_out": ...}. Edges with source handle
+// "approved", "rejected" or "timeout" follow only the matching decision.
+//
+// Approvals can only be decided on stored executions, which are persisted while they wait;
+// executions that cannot be persisted (standalone, ephemeral) wait inline until the timeout.
+type ApprovalExecutor struct {
+	*executor.BaseExecutor
+	now func() time.Time
+}
+
+// NewApprovalExecutor creates a new approval executor.
+func NewApprovalExecutor() *ApprovalExecutor {
+	return &ApprovalExecutor{
+		BaseExecutor: executor.NewBaseExecutor("approval"),
+		now:          time.Now,
+	}
+}
+
+// Execute suspends the node until the approval is decided or times out.
+func (e *ApprovalExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+
+	execCtx, ok := executor.GetExecutionContext(ctx)
+	if !ok || execCtx.ExecutionID == "" || execCtx.NodeID == "" {
+		return nil, fmt.Errorf("approval nodes require an execution context")
+	}
+
+	timeout := DefaultApprovalTimeout
+	if value, ok := config["timeout"]; ok {
+		d, err := parseDelayDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout: %w", err)
+		}
+		timeout = d
+	}
+
+	key := models.ApprovalEventKey(execCtx.ExecutionID, execCtx.NodeID)
+	return nil, &executor.SuspendError{
+		ResumeAt: e.now().Add(timeout),
+		Output:   executor.EventOutput(key, map[string]any{"decision": string(models.ApprovalStatusTimeout)}, true),
+		EventKey: key,
+	}
+}
+
+// Validate validates the approval configuration. Templated values are checked at execution.
+func (e *ApprovalExecutor) Validate(config map[string]any) error {
+	if message, ok := config["message"]; ok {
+		if _, ok := message.(string); !ok {
+			return fmt.Errorf("message must be a string")
+		}
+	}
+
+	if _, err := models.ApprovalApprovers(config); err != nil {
+		return err
+	}
+
+	timeout, ok := config["timeout"]
+	if !ok {
+		return nil
+	}
+	if s, ok := timeout.(string); ok && strings.Contains(s, "{{") {
+		return nil
+	}
+	if _, err := parseDelayDuration(timeout); err != nil {
+		return fmt.Errorf("invalid timeout: %w", err)
+	}
+	return nil
+}
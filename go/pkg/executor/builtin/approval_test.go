@@ -0,0 +1,62 @@
+package builtin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApprovalExecutor_Suspends(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	exec := NewApprovalExecutor()
+	exec.now = func() time.Time { return now }
+
+	ctx := context.WithValue(context.Background(), executor.ExecutionContextKey{}, &executor.ExecutionContextData{
+		ExecutionID: "exec-1",
+		NodeID:      "review",
+	})
+
+	tests := []struct {
+		name   string
+		config map[string]any
+		want   time.Duration
+	}{
+		{name: "default timeout", config: map[string]any{"message": "Refund?"}, want: DefaultApprovalTimeout},
+		{name: "duration string", config: map[string]any{"timeout": "2h", "approvers": []any{"user-1"}}, want: 2 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := exec.Execute(ctx, tt.config, nil)
+
+			suspend, ok := executor.AsSuspend(err)
+			require.True(t, ok, "expected a suspension, got %v", err)
+			assert.Equal(t, "approval:exec-1:review", suspend.EventKey)
+			assert.Equal(t, now.Add(tt.want), suspend.ResumeAt)
+			assert.Equal(t, executor.EventOutput("approval:exec-1:review", map[string]any{"decision": "timeout"}, true), suspend.Output)
+		})
+	}
+}
+
+func TestApprovalExecutor_RequiresExecutionContext(t *testing.T) {
+	_, err := NewApprovalExecutor().Execute(context.Background(), map[string]any{}, nil)
+	require.Error(t, err)
+	_, ok := executor.AsSuspend(err)
+	assert.False(t, ok)
+}
+
+func TestApprovalExecutor_Validate(t *testing.T) {
+	exec := NewApprovalExecutor()
+
+	assert.NoError(t, exec.Validate(map[string]any{}))
+	assert.NoError(t, exec.Validate(map[string]any{"message": "Ship {{input.order}}?", "approvers": "user-1", "timeout": "{{input.timeout}}"}))
+	assert.Error(t, exec.Validate(map[string]any{"message": 1}))
+	assert.Error(t, exec.Validate(map[string]any{"approvers": map[string]any{}}))
+	assert.Error(t, exec.Validate(map[string]any{"timeout": "soon"}))
+	assert.Equal(t, "approval:exec-1:review", models.ApprovalEventKey("exec-1", "review"))
+}
@@ -0,0 +1,245 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+)
+
+// BatchStore keeps the items of the open batches of batcher nodes.
+type BatchStore interface {
+	// Add appends item to the open batch under key, opening a batch if there is none
+	Add(ctx context.Context, key string, item []byte) (OpenBatch, error)
+
+	// Take removes and returns the items of the batch under key if it is still the batch
+	// with the ID; ok is false if that batch was released already
+	Take(ctx context.Context, key, batchID string) (items [][]byte, ok bool, err error)
+}
+
+// OpenBatch describes the open batch under a key after an item was added.
+type OpenBatch struct {
+	ID       string
+	Size     int
+	OpenedAt time.Time
+}
+
+// BatcherExecutor accumulates items across executions of a workflow and releases them as
+// one batch when the batch holds max_size items or its window is over.
+//
+// Config:
+//   - max_size: Release the batch when it holds this many items
+//   - window: Release the batch this long after its first item; Go duration string or a
+//     number of seconds (at least one of max_size and window is required)
+//   - item: The value to add (default: the node input)
+//   - group: Batches items separately per group, e.g. "{{input.customer_id}}"
+//
+// Output: {"released": ..., "batch_id", "size", "items": [...] (when released),
+// "reason": "size" | "window" (when released)}. Edges with source handle "released"
+// continue only in the execution that releases the batch, "pending" only in the others.
+//
+// The execution that opens a batch with a window waits for the window to end and then
+// releases whatever the batch holds, unless it was released by size before. Batches are
+// kept in the store, so with a Redis store they survive restarts; a batch whose window
+// ended while no execution waited for it is released with the next item.
+type BatcherExecutor struct {
+	*executor.BaseExecutor
+	store BatchStore
+	now   func() time.Time
+}
+
+// NewBatcherExecutor creates a new batcher executor keeping batches in memory until
+// SetBatchStore sets a shared store.
+func NewBatcherExecutor() *BatcherExecutor {
+	return &BatcherExecutor{
+		BaseExecutor: executor.NewBaseExecutor("batcher"),
+		store:        NewMemoryBatchStore(),
+		now:          time.Now,
+	}
+}
+
+// SetBatchStore sets the store keeping open batches. It must be set before executions start.
+func (e *BatcherExecutor) SetBatchStore(store BatchStore) {
+	e.store = store
+}
+
+// Execute adds the item to the open batch and releases the batch when it is full or its
+// window is over.
+func (e *BatcherExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+
+	execCtx, ok := executor.GetExecutionContext(ctx)
+	if !ok || execCtx.WorkflowID == "" || execCtx.NodeID == "" {
+		return nil, fmt.Errorf("batcher nodes require an execution context")
+	}
+	key := "batcher:" + execCtx.WorkflowID + ":" + execCtx.NodeID
+	if group, _ := config["group"].(string); group != "" {
+		key += ":" + group
+	}
+
+	maxSize, window, err := batcherLimits(config)
+	if err != nil {
+		return nil, err
+	}
+
+	item := input
+	if value, ok := config["item"]; ok {
+		item = value
+	}
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode item: %w", err)
+	}
+
+	batch, err := e.store.Add(ctx, key, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add item to batch: %w", err)
+	}
+
+	if maxSize > 0 && batch.Size >= maxSize {
+		return e.release(ctx, key, batch, "size")
+	}
+	if window > 0 {
+		closesAt := batch.OpenedAt.Add(window)
+		if !e.now().Before(closesAt) {
+			return e.release(ctx, key, batch, "window")
+		}
+		if batch.Size == 1 {
+			timer := time.NewTimer(closesAt.Sub(e.now()))
+			defer timer.Stop()
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-timer.C:
+			}
+			return e.release(ctx, key, batch, "window")
+		}
+	}
+
+	return batcherPending(batch), nil
+}
+
+// release takes the items of the batch; the output is pending if another execution
+// released the batch first.
+func (e *BatcherExecutor) release(ctx context.Context, key string, batch OpenBatch, reason string) (any, error) {
+	items, ok, err := e.store.Take(ctx, key, batch.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to release batch: %w", err)
+	}
+	if !ok {
+		return batcherPending(batch), nil
+	}
+
+	decoded := make([]any, len(items))
+	for i, data := range items {
+		if err := json.Unmarshal(data, &decoded[i]); err != nil {
+			return nil, fmt.Errorf("failed to decode batch item %d: %w", i, err)
+		}
+	}
+	return map[string]any{
+		"released": true,
+		"batch_id": batch.ID,
+		"size":     len(decoded),
+		"items":    decoded,
+		"reason":   reason,
+	}, nil
+}
+
+func batcherPending(batch OpenBatch) map[string]any {
+	return map[string]any{
+		"released": false,
+		"batch_id": batch.ID,
+		"size":     batch.Size,
+	}
+}
+
+// Validate validates the batcher configuration. Templated values are checked at execution.
+func (e *BatcherExecutor) Validate(config map[string]any) error {
+	if group, ok := config["group"]; ok {
+		if _, ok := group.(string); !ok {
+			return fmt.Errorf("group must be a string")
+		}
+	}
+	for _, key := range []string{"max_size", "window"} {
+		if s, ok := config[key].(string); ok && strings.Contains(s, "{{") {
+			return nil
+		}
+	}
+	_, _, err := batcherLimits(config)
+	return err
+}
+
+// batcherLimits returns the max_size and window of a batcher config.
+func batcherLimits(config map[string]any) (int, time.Duration, error) {
+	maxSize, err := batchInt(config, "max_size", 0)
+	if err != nil {
+		return 0, 0, fmt.Errorf("max_size must be an integer")
+	}
+	if maxSize < 0 {
+		return 0, 0, fmt.Errorf("max_size must not be negative")
+	}
+
+	var window time.Duration
+	if value, ok := config["window"]; ok {
+		if window, err = parseDelayDuration(value); err != nil {
+			return 0, 0, fmt.Errorf("invalid window: %w", err)
+		}
+	}
+
+	if maxSize == 0 && window == 0 {
+		return 0, 0, fmt.Errorf("max_size or window is required")
+	}
+	return maxSize, window, nil
+}
+
+// MemoryBatchStore is an in-process BatchStore. Batches are lost on restart and not
+// shared between instances.
+type MemoryBatchStore struct {
+	mu      sync.Mutex
+	batches map[string]*memoryBatch
+}
+
+type memoryBatch struct {
+	OpenBatch
+	items [][]byte
+}
+
+// NewMemoryBatchStore creates an empty in-memory batch store.
+func NewMemoryBatchStore() *MemoryBatchStore {
+	return &MemoryBatchStore{batches: make(map[string]*memoryBatch)}
+}
+
+// Add appends item to the open batch under key.
+func (s *MemoryBatchStore) Add(ctx context.Context, key string, item []byte) (OpenBatch, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	batch, ok := s.batches[key]
+	if !ok {
+		batch = &memoryBatch{OpenBatch: OpenBatch{ID: uuid.New().String(), OpenedAt: time.Now()}}
+		s.batches[key] = batch
+	}
+	batch.items = append(batch.items, item)
+	batch.Size = len(batch.items)
+	return batch.OpenBatch, nil
+}
+
+// Take removes and returns the items of the batch under key if it has the ID.
+func (s *MemoryBatchStore) Take(ctx context.Context, key, batchID string) ([][]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	batch, ok := s.batches[key]
+	if !ok || batch.ID != batchID {
+		return nil, false, nil
+	}
+	delete(s.batches, key)
+	return batch.items, true, nil
+}
@@ -0,0 +1,111 @@
+package builtin
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func batcherContext(nodeID string) context.Context {
+	return context.WithValue(context.Background(), executor.ExecutionContextKey{}, &executor.ExecutionContextData{
+		ExecutionID: "exec-1",
+		WorkflowID:  "wf-1",
+		NodeID:      nodeID,
+	})
+}
+
+func TestBatcherExecutor_ReleasesBySize(t *testing.T) {
+	exec := NewBatcherExecutor()
+	ctx := batcherContext("batch")
+	config := map[string]any{"max_size": float64(3)}
+
+	for i, item := range []any{"a", "b"} {
+		out, err := exec.Execute(ctx, config, item)
+		require.NoError(t, err)
+		result := out.(map[string]any)
+		assert.Equal(t, false, result["released"])
+		assert.Equal(t, i+1, result["size"])
+	}
+
+	out, err := exec.Execute(ctx, config, "c")
+	require.NoError(t, err)
+	result := out.(map[string]any)
+	assert.Equal(t, true, result["released"])
+	assert.Equal(t, []any{"a", "b", "c"}, result["items"])
+	assert.Equal(t, "size", result["reason"])
+
+	// The next item opens a new batch
+	out, err = exec.Execute(ctx, config, "d")
+	require.NoError(t, err)
+	assert.Equal(t, 1, out.(map[string]any)["size"])
+}
+
+func TestBatcherExecutor_ReleasesByWindow(t *testing.T) {
+	exec := NewBatcherExecutor()
+	ctx := batcherContext("batch")
+	config := map[string]any{"window": "50ms", "item": map[string]any{"id": float64(1)}}
+
+	var wg sync.WaitGroup
+	var opener map[string]any
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		out, err := exec.Execute(ctx, config, nil)
+		assert.NoError(t, err)
+		opener, _ = out.(map[string]any)
+	}()
+
+	// Wait for the opener to add its item
+	store := exec.store.(*MemoryBatchStore)
+	require.Eventually(t, func() bool {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		_, ok := store.batches["batcher:wf-1:batch"]
+		return ok
+	}, time.Second, time.Millisecond)
+	out, err := exec.Execute(ctx, config, nil)
+	require.NoError(t, err)
+	assert.Equal(t, false, out.(map[string]any)["released"])
+
+	wg.Wait()
+	assert.Equal(t, true, opener["released"])
+	assert.Equal(t, "window", opener["reason"])
+	assert.Len(t, opener["items"], 2)
+}
+
+func TestBatcherExecutor_ReleasesOverdueBatchWithNextItem(t *testing.T) {
+	store := NewMemoryBatchStore()
+	_, err := store.Add(context.Background(), "batcher:wf-1:batch:eu", []byte(`"old"`))
+	require.NoError(t, err)
+
+	exec := NewBatcherExecutor()
+	exec.SetBatchStore(store)
+	exec.now = func() time.Time { return time.Now().Add(time.Hour) }
+
+	out, err := exec.Execute(batcherContext("batch"), map[string]any{"window": float64(60), "group": "eu"}, "new")
+	require.NoError(t, err)
+	result := out.(map[string]any)
+	assert.Equal(t, true, result["released"])
+	assert.Equal(t, []any{"old", "new"}, result["items"])
+}
+
+func TestBatcherExecutor_Validate(t *testing.T) {
+	exec := NewBatcherExecutor()
+
+	assert.NoError(t, exec.Validate(map[string]any{"max_size": 10}))
+	assert.NoError(t, exec.Validate(map[string]any{"window": "1m"}))
+	assert.NoError(t, exec.Validate(map[string]any{"max_size": "{{env.batch_size}}"}))
+	assert.Error(t, exec.Validate(map[string]any{}))
+	assert.Error(t, exec.Validate(map[string]any{"max_size": -1}))
+	assert.Error(t, exec.Validate(map[string]any{"max_size": 1.5}))
+	assert.Error(t, exec.Validate(map[string]any{"window": "soon"}))
+	assert.Error(t, exec.Validate(map[string]any{"max_size": 10, "group": 5}))
+
+	_, err := exec.Execute(context.Background(), map[string]any{"max_size": 10}, "a")
+	assert.Error(t, err)
+}
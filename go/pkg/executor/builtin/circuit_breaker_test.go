@@ -0,0 +1,90 @@
+package builtin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPExecutor_CircuitBreaker(t *testing.T) {
+	var requests atomic.Int32
+	status := atomic.Int32{}
+	status.Store(http.StatusServiceUnavailable)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer server.Close()
+
+	exec := NewHTTPExecutor()
+	exec.SetCircuitBreaker(executor.NewCircuitBreaker(2, time.Minute))
+	config := map[string]any{"method": "GET", "url": server.URL}
+
+	for range 2 {
+		_, err := exec.Execute(context.Background(), config, nil)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, models.ErrCircuitOpen)
+	}
+
+	_, err := exec.Execute(context.Background(), config, nil)
+	require.ErrorIs(t, err, models.ErrCircuitOpen)
+	assert.Equal(t, int32(2), requests.Load(), "an open breaker must not send the request")
+}
+
+func TestHTTPExecutor_CircuitBreaker_ClientErrorsDoNotCount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	exec := NewHTTPExecutor()
+	exec.SetCircuitBreaker(executor.NewCircuitBreaker(1, time.Minute))
+	config := map[string]any{"method": "GET", "url": server.URL}
+
+	for range 3 {
+		_, err := exec.Execute(context.Background(), config, nil)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, models.ErrCircuitOpen)
+	}
+}
+
+func TestLLMExecutor_CircuitBreaker(t *testing.T) {
+	var calls atomic.Int32
+	exec := NewLLMExecutor()
+	exec.RegisterProvider("mock", &MockLLMProvider{
+		ExecuteFn: func(ctx context.Context, req *models.LLMRequest) (*models.LLMResponse, error) {
+			calls.Add(1)
+			return nil, errors.New("503 service unavailable")
+		},
+	})
+	exec.SetCircuitBreaker(executor.NewCircuitBreaker(2, time.Minute))
+	config := map[string]any{"provider": "mock", "model": "gpt-4", "prompt": "Hello!"}
+
+	for range 2 {
+		_, err := exec.Execute(context.Background(), config, nil)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, models.ErrCircuitOpen)
+	}
+
+	_, err := exec.Execute(context.Background(), config, nil)
+	require.ErrorIs(t, err, models.ErrCircuitOpen)
+	var circuitErr *executor.CircuitOpenError
+	require.ErrorAs(t, err, &circuitErr)
+	assert.Equal(t, "mock", circuitErr.Key)
+	assert.Equal(t, int32(2), calls.Load())
+
+	// Requests to another base URL are guarded separately
+	config["base_url"] = "https://llm.internal"
+	_, err = exec.Execute(context.Background(), config, nil)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, models.ErrCircuitOpen)
+}
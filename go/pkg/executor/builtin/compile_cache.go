@@ -0,0 +1,47 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+	"github.com/itchyny/gojq"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/executor/compilecache"
+)
+
+// Compiled jq filters and expressions shared by all transform and conditional nodes.
+var (
+	jqPrograms         = compilecache.New[*gojq.Code]("jq", compilecache.DefaultCapacity)
+	expressionPrograms = compilecache.New[*vm.Program]("expression", compilecache.DefaultCapacity)
+)
+
+// compileJQ parses and compiles a jq filter, reusing an earlier compilation of the same filter.
+func compileJQ(filter string) (*gojq.Code, error) {
+	return jqPrograms.GetOrCompile(filter, func() (*gojq.Code, error) {
+		query, err := gojq.Parse(filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse jq filter: %w", err)
+		}
+		code, err := gojq.Compile(query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile jq filter: %w", err)
+		}
+		return code, nil
+	})
+}
+
+// compileExpression compiles an expression against env, reusing an earlier compilation.
+// The program is type-checked against the values in env, so the key includes their types.
+// The decimal functions of the executor package are available to every expression.
+func compileExpression(source string, env map[string]any) (*vm.Program, error) {
+	key := source
+	for _, name := range sortedKeys(env) {
+		key += fmt.Sprintf("\x00%s:%T", name, env[name])
+	}
+	return expressionPrograms.GetOrCompile(key, func() (*vm.Program, error) {
+		options := append([]expr.Option{expr.Env(env)}, executor.DecimalFunctions...)
+		return expr.Compile(source, options...)
+	})
+}
@@ -20,6 +20,9 @@ func NewConditionalExecutor() *ConditionalExecutor {
 	}
 }
 
+// SideEffectFree reports true. Conditions are evaluated in dry runs, so that they route the run as a real execution would.
+func (e *ConditionalExecutor) SideEffectFree() bool { return true }
+
 // Execute executes the conditional logic.
 func (e *ConditionalExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
 	// Get condition type
@@ -35,11 +38,11 @@ func (e *ConditionalExecutor) Execute(ctx context.Context, config map[string]any
 
 		// Prepare environment for expression evaluation
 		env := map[string]any{
-			"input": input,
+			"input": executor.NormalizeNumbers(input),
 		}
 
 		// Compile expression with environment
-		program, err := expr.Compile(exprStr, expr.Env(env))
+		program, err := compileExpression(exprStr, env)
 		if err != nil {
 			return nil, fmt.Errorf("failed to compile expression: %w", err)
 		}
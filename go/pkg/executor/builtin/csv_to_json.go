@@ -23,6 +23,9 @@ func NewCSVToJSONExecutor() *CSVToJSONExecutor {
 	}
 }
 
+// SideEffectFree reports true. Converting CSV has no side effects.
+func (e *CSVToJSONExecutor) SideEffectFree() bool { return true }
+
 // Execute converts CSV input to JSON array of objects.
 func (e *CSVToJSONExecutor) Execute(_ context.Context, config map[string]any, input any) (any, error) {
 	startTime := time.Now()
@@ -0,0 +1,124 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+)
+
+// DelayExecutor suspends its branch of the workflow for a duration or until a timestamp and
+// then passes its input through unchanged.
+//
+// Config:
+//   - duration: Go duration string ("30s", "2h", "1h30m") or a number of seconds
+//   - until: RFC 3339 timestamp, e.g. "{{input.send_at}}"
+//
+// Exactly one of duration and until is required. Stored executions are persisted while
+// suspended and resumed when the delay is over, so no goroutine is held in the meantime;
+// executions that cannot be persisted (standalone, ephemeral) wait inline.
+type DelayExecutor struct {
+	*executor.BaseExecutor
+	now func() time.Time
+}
+
+// NewDelayExecutor creates a new delay executor.
+func NewDelayExecutor() *DelayExecutor {
+	return &DelayExecutor{
+		BaseExecutor: executor.NewBaseExecutor("delay"),
+		now:          time.Now,
+	}
+}
+
+// Execute suspends the node until the delay is over. A delay that is already over
+// completes the node immediately.
+func (e *DelayExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	resumeAt, err := e.resumeAt(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if !e.now().Before(resumeAt) {
+		return input, nil
+	}
+	return nil, executor.Suspend(resumeAt, input)
+}
+
+// Validate validates the delay configuration. Templated values are checked at execution.
+func (e *DelayExecutor) Validate(config map[string]any) error {
+	duration, hasDuration := config["duration"]
+	until, hasUntil := config["until"]
+	if hasDuration == hasUntil {
+		return fmt.Errorf("exactly one of duration and until is required")
+	}
+
+	if hasDuration {
+		if s, ok := duration.(string); ok && strings.Contains(s, "{{") {
+			return nil
+		}
+		_, err := parseDelayDuration(duration)
+		return err
+	}
+
+	s, ok := until.(string)
+	if !ok {
+		return fmt.Errorf("until must be an RFC 3339 timestamp string")
+	}
+	if strings.Contains(s, "{{") {
+		return nil
+	}
+	if _, err := time.Parse(time.RFC3339, s); err != nil {
+		return fmt.Errorf("invalid until timestamp %q: must be RFC 3339", s)
+	}
+	return nil
+}
+
+// resumeAt returns when the delay configured by the resolved config is over.
+func (e *DelayExecutor) resumeAt(config map[string]any) (time.Time, error) {
+	if err := e.Validate(config); err != nil {
+		return time.Time{}, err
+	}
+
+	if duration, ok := config["duration"]; ok {
+		d, err := parseDelayDuration(duration)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return e.now().Add(d), nil
+	}
+
+	s, _ := config["until"].(string)
+	until, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid until timestamp %q: must be RFC 3339", s)
+	}
+	return until, nil
+}
+
+// parseDelayDuration accepts a Go duration string or a number of seconds.
+func parseDelayDuration(value any) (time.Duration, error) {
+	var d time.Duration
+	switch v := value.(type) {
+	case string:
+		parsed, err := time.ParseDuration(strings.TrimSpace(v))
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", v, err)
+		}
+		d = parsed
+	case float64:
+		d = time.Duration(v * float64(time.Second))
+	case int:
+		d = time.Duration(v) * time.Second
+	case int64:
+		d = time.Duration(v) * time.Second
+	default:
+		return 0, fmt.Errorf("duration must be a duration string or a number of seconds")
+	}
+
+	if d < 0 {
+		return 0, fmt.Errorf("duration must not be negative")
+	}
+	return d, nil
+}
@@ -0,0 +1,102 @@
+package builtin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDelayExecutor(now time.Time) *DelayExecutor {
+	exec := NewDelayExecutor()
+	exec.now = func() time.Time { return now }
+	return exec
+}
+
+func TestDelayExecutor_Duration(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	exec := newTestDelayExecutor(now)
+	input := map[string]any{"order_id": "o-1"}
+
+	tests := []struct {
+		name     string
+		duration any
+		want     time.Duration
+	}{
+		{name: "duration string", duration: "1h30m", want: 90 * time.Minute},
+		{name: "seconds as float", duration: 45.0, want: 45 * time.Second},
+		{name: "seconds as int", duration: 10, want: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := exec.Execute(context.Background(), map[string]any{"duration": tt.duration}, input)
+
+			suspend, ok := executor.AsSuspend(err)
+			require.True(t, ok, "expected a suspension, got %v", err)
+			assert.Equal(t, now.Add(tt.want), suspend.ResumeAt)
+			assert.Equal(t, input, suspend.Output)
+		})
+	}
+}
+
+func TestDelayExecutor_Until(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	exec := newTestDelayExecutor(now)
+
+	_, err := exec.Execute(context.Background(), map[string]any{"until": "2026-10-17T09:00:00Z"}, "payload")
+
+	suspend, ok := executor.AsSuspend(err)
+	require.True(t, ok, "expected a suspension, got %v", err)
+	assert.Equal(t, time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC), suspend.ResumeAt.UTC())
+	assert.Equal(t, "payload", suspend.Output)
+}
+
+func TestDelayExecutor_ElapsedDelayPassesThrough(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	exec := newTestDelayExecutor(now)
+
+	result, err := exec.Execute(context.Background(), map[string]any{"until": "2026-10-16T11:00:00Z"}, "payload")
+	require.NoError(t, err)
+	assert.Equal(t, "payload", result)
+
+	result, err = exec.Execute(context.Background(), map[string]any{"duration": "0s"}, "payload")
+	require.NoError(t, err)
+	assert.Equal(t, "payload", result)
+}
+
+func TestDelayExecutor_Validate(t *testing.T) {
+	exec := NewDelayExecutor()
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{name: "duration", config: map[string]any{"duration": "30s"}},
+		{name: "until", config: map[string]any{"until": "2026-10-17T09:00:00Z"}},
+		{name: "templated until", config: map[string]any{"until": "{{input.send_at}}"}},
+		{name: "templated duration", config: map[string]any{"duration": "{{env.wait}}"}},
+		{name: "neither", config: map[string]any{}, wantErr: "exactly one of duration and until"},
+		{name: "both", config: map[string]any{"duration": "30s", "until": "2026-10-17T09:00:00Z"}, wantErr: "exactly one of duration and until"},
+		{name: "invalid duration", config: map[string]any{"duration": "soon"}, wantErr: "invalid duration"},
+		{name: "negative duration", config: map[string]any{"duration": "-5m"}, wantErr: "must not be negative"},
+		{name: "invalid until", config: map[string]any{"until": "tomorrow"}, wantErr: "must be RFC 3339"},
+		{name: "non-string until", config: map[string]any{"until": 42}, wantErr: "RFC 3339 timestamp string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exec.Validate(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
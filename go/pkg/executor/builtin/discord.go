@@ -0,0 +1,585 @@
+package builtin
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+)
+
+const (
+	// discordMaxContentLength is Discord's limit for message content.
+	discordMaxContentLength = 2000
+
+	// discordMaxEmbeds is the maximum number of embeds per message.
+	discordMaxEmbeds = 10
+
+	// discordMaxFileSize is the upload limit for servers without boosts.
+	discordMaxFileSize = 25 * 1024 * 1024
+
+	// Message flags, see https://discord.com/developers/docs/resources/message#message-object-message-flags
+	discordFlagSuppressEmbeds        = 1 << 2
+	discordFlagSuppressNotifications = 1 << 12
+)
+
+// DiscordExecutor sends messages, embeds and files to Discord
+// through an incoming webhook or a bot token.
+type DiscordExecutor struct {
+	*executor.BaseExecutor
+	httpClient *http.Client
+	baseURL    string // For testing purposes
+}
+
+// NewDiscordExecutor creates a new Discord executor.
+func NewDiscordExecutor() *DiscordExecutor {
+	return &DiscordExecutor{
+		BaseExecutor: executor.NewBaseExecutor("discord"),
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		baseURL: "https://discord.com/api/v10",
+	}
+}
+
+// Execute executes a Discord request.
+//
+// Templates in config are resolved before this method is called.
+//
+// Example workflow configuration (webhook):
+//
+//	config: {
+//	  "webhook_url": "{{env.discord_webhook_url}}",
+//	  "message_type": "embed",
+//	  "text": "Deploy finished",
+//	  "embeds": [{"title": "{{input.service}}", "description": "Version {{input.version}}", "color": 3066993}]
+//	}
+//
+// Example workflow configuration (bot):
+//
+//	config: {
+//	  "bot_token": "{{env.discord_bot_token}}",
+//	  "channel_id": "1234567890123456789",
+//	  "message_type": "text",
+//	  "text": "Workflow {{input.workflow_name}} completed with status: {{input.status}}"
+//	}
+func (e *DiscordExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	startTime := time.Now()
+
+	req, err := e.parseConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse discord config: %w", err)
+	}
+
+	var response *DiscordResponse
+	switch req.MessageType {
+	case "text", "embed":
+		response, err = e.sendMessage(ctx, req)
+	case "file":
+		response, err = e.sendFile(ctx, req)
+	default:
+		return nil, fmt.Errorf("unsupported message_type: %s", req.MessageType)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("discord execution failed: %w", err)
+	}
+
+	response.MessageType = req.MessageType
+	response.DurationMS = time.Since(startTime).Milliseconds()
+
+	return e.responseToMap(response), nil
+}
+
+// Validate validates the Discord executor configuration.
+func (e *DiscordExecutor) Validate(config map[string]any) error {
+	if err := e.ValidateRequired(config, "message_type"); err != nil {
+		return err
+	}
+
+	// Validate delivery: webhook_url or bot_token + channel_id
+	webhookURL := e.GetStringDefault(config, "webhook_url", "")
+	botToken := e.GetStringDefault(config, "bot_token", "")
+	switch {
+	case webhookURL != "" && botToken != "":
+		return fmt.Errorf("webhook_url and bot_token are mutually exclusive")
+	case webhookURL != "":
+		if err := validateDiscordWebhookURL(webhookURL); err != nil {
+			return err
+		}
+		if _, ok := config["reply_to_message_id"]; ok {
+			return fmt.Errorf("reply_to_message_id requires bot_token")
+		}
+	case botToken != "":
+		if channelID := e.GetStringDefault(config, "channel_id", ""); channelID == "" {
+			return fmt.Errorf("channel_id is required with bot_token")
+		}
+		for _, field := range []string{"username", "avatar_url", "thread_id"} {
+			if _, ok := config[field]; ok {
+				return fmt.Errorf("%s is only supported with webhook_url", field)
+			}
+		}
+	default:
+		return fmt.Errorf("webhook_url or bot_token is required")
+	}
+
+	// Validate message_type
+	messageType, err := e.GetString(config, "message_type")
+	if err != nil {
+		return err
+	}
+	validTypes := map[string]bool{"text": true, "embed": true, "file": true}
+	if !validTypes[messageType] {
+		return fmt.Errorf("invalid message_type: %s (must be: text, embed, file)", messageType)
+	}
+
+	// Validate message content based on type
+	text := e.GetStringDefault(config, "text", "")
+	if len([]rune(text)) > discordMaxContentLength {
+		return fmt.Errorf("text exceeds %d characters", discordMaxContentLength)
+	}
+
+	switch messageType {
+	case "text":
+		if text == "" {
+			return fmt.Errorf("text is required for message_type=text")
+		}
+	case "embed":
+		embeds, err := parseDiscordEmbeds(config["embeds"])
+		if err != nil {
+			return err
+		}
+		if len(embeds) == 0 {
+			return fmt.Errorf("embeds are required for message_type=embed")
+		}
+		if len(embeds) > discordMaxEmbeds {
+			return fmt.Errorf("at most %d embeds are allowed", discordMaxEmbeds)
+		}
+	case "file":
+		if err := e.ValidateRequired(config, "file_source", "file_data"); err != nil {
+			return fmt.Errorf("file_source and file_data required for file messages: %w", err)
+		}
+
+		fileSource, _ := e.GetString(config, "file_source")
+		validSources := map[string]bool{"base64": true, "url": true}
+		if !validSources[fileSource] {
+			return fmt.Errorf("invalid file_source: %s (must be: base64, url)", fileSource)
+		}
+	}
+
+	// Validate timeout
+	if timeout := e.GetIntDefault(config, "timeout", 30); timeout < 1 || timeout > 300 {
+		return fmt.Errorf("timeout must be between 1 and 300 seconds")
+	}
+
+	return nil
+}
+
+// parseConfig parses executor config into DiscordRequest.
+func (e *DiscordExecutor) parseConfig(config map[string]any) (*DiscordRequest, error) {
+	req := &DiscordRequest{}
+
+	// Delivery
+	req.WebhookURL = e.GetStringDefault(config, "webhook_url", "")
+	req.BotToken = strings.TrimPrefix(e.GetStringDefault(config, "bot_token", ""), "Bot ")
+	req.ChannelID = e.GetStringDefault(config, "channel_id", "")
+
+	req.MessageType = e.GetStringDefault(config, "message_type", "")
+
+	// Content
+	req.Text = e.GetStringDefault(config, "text", "")
+	embeds, err := parseDiscordEmbeds(config["embeds"])
+	if err != nil {
+		return nil, err
+	}
+	req.Embeds = embeds
+
+	// Webhook identity overrides
+	req.Username = e.GetStringDefault(config, "username", "")
+	req.AvatarURL = e.GetStringDefault(config, "avatar_url", "")
+
+	// Flags
+	req.TTS = e.GetBoolDefault(config, "tts", false)
+	req.DisableNotification = e.GetBoolDefault(config, "disable_notification", false)
+	req.SuppressEmbeds = e.GetBoolDefault(config, "suppress_embeds", false)
+
+	// Optional IDs (Discord snowflakes are strings)
+	req.ReplyToMessageID = e.GetStringDefault(config, "reply_to_message_id", "")
+	req.ThreadID = e.GetStringDefault(config, "thread_id", "")
+
+	// File fields
+	if req.MessageType == "file" {
+		req.FileSource = e.GetStringDefault(config, "file_source", "")
+		req.FileData = e.GetStringDefault(config, "file_data", "")
+		req.FileName = e.GetStringDefault(config, "file_name", "")
+	}
+
+	// Timeout
+	req.Timeout = e.GetIntDefault(config, "timeout", 30)
+
+	return req, nil
+}
+
+// sendMessage sends a text or embed message.
+func (e *DiscordExecutor) sendMessage(ctx context.Context, req *DiscordRequest) (*DiscordResponse, error) {
+	if req.Text == "" && len(req.Embeds) == 0 {
+		return nil, fmt.Errorf("text or embeds are required")
+	}
+
+	jsonData, err := json.Marshal(e.buildPayload(req))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	return e.executeRequest(ctx, req, bytes.NewReader(jsonData), "application/json")
+}
+
+// sendFile uploads a file with an optional message.
+func (e *DiscordExecutor) sendFile(ctx context.Context, req *DiscordRequest) (*DiscordResponse, error) {
+	var fileBytes []byte
+	var err error
+
+	switch req.FileSource {
+	case "base64":
+		fileBytes, err = base64.StdEncoding.DecodeString(req.FileData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode base64: %w", err)
+		}
+	case "url":
+		fileBytes, err = e.downloadFile(ctx, req.FileData, time.Duration(req.Timeout)*time.Second)
+		if err != nil {
+			return nil, err
+		}
+		if req.FileName == "" {
+			req.FileName = fileNameFromURL(req.FileData)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported file_source: %s", req.FileSource)
+	}
+
+	if len(fileBytes) > discordMaxFileSize {
+		return nil, fmt.Errorf("file size %d exceeds limit of %d bytes", len(fileBytes), discordMaxFileSize)
+	}
+
+	fileName := req.FileName
+	if fileName == "" {
+		fileName = "file.bin"
+	}
+
+	payload := e.buildPayload(req)
+	payload["attachments"] = []map[string]any{{"id": 0, "filename": fileName}}
+
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	if err := writer.WriteField("payload_json", string(payloadJSON)); err != nil {
+		return nil, fmt.Errorf("failed to write payload: %w", err)
+	}
+
+	part, err := writer.CreateFormFile("files[0]", fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create form file: %w", err)
+	}
+	if _, err := part.Write(fileBytes); err != nil {
+		return nil, fmt.Errorf("failed to write file data: %w", err)
+	}
+
+	writer.Close()
+
+	return e.executeRequest(ctx, req, body, writer.FormDataContentType())
+}
+
+// buildPayload builds the JSON message payload shared by all message types.
+func (e *DiscordExecutor) buildPayload(req *DiscordRequest) map[string]any {
+	payload := map[string]any{}
+
+	if req.Text != "" {
+		payload["content"] = req.Text
+	}
+	if len(req.Embeds) > 0 {
+		payload["embeds"] = req.Embeds
+	}
+	if req.TTS {
+		payload["tts"] = true
+	}
+
+	flags := 0
+	if req.SuppressEmbeds {
+		flags |= discordFlagSuppressEmbeds
+	}
+	if req.DisableNotification {
+		flags |= discordFlagSuppressNotifications
+	}
+	if flags != 0 {
+		payload["flags"] = flags
+	}
+
+	if req.WebhookURL != "" {
+		if req.Username != "" {
+			payload["username"] = req.Username
+		}
+		if req.AvatarURL != "" {
+			payload["avatar_url"] = req.AvatarURL
+		}
+	} else if req.ReplyToMessageID != "" {
+		payload["message_reference"] = map[string]any{"message_id": req.ReplyToMessageID}
+	}
+
+	return payload
+}
+
+// endpoint returns the URL and authorization header for the configured delivery.
+func (e *DiscordExecutor) endpoint(req *DiscordRequest) (string, string, error) {
+	if req.WebhookURL != "" {
+		u, err := url.Parse(req.WebhookURL)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid webhook_url: %w", err)
+		}
+		q := u.Query()
+		// wait=true makes Discord return the created message instead of 204
+		q.Set("wait", "true")
+		if req.ThreadID != "" {
+			q.Set("thread_id", req.ThreadID)
+		}
+		u.RawQuery = q.Encode()
+		return u.String(), "", nil
+	}
+
+	if req.BotToken == "" || req.ChannelID == "" {
+		return "", "", fmt.Errorf("webhook_url or bot_token with channel_id is required")
+	}
+
+	apiURL := fmt.Sprintf("%s/channels/%s/messages", e.baseURL, url.PathEscape(req.ChannelID))
+	return apiURL, "Bot " + req.BotToken, nil
+}
+
+// executeRequest posts a message and parses the response.
+func (e *DiscordExecutor) executeRequest(ctx context.Context, req *DiscordRequest, body io.Reader, contentType string) (*DiscordResponse, error) {
+	apiURL, authorization, err := e.endpoint(req)
+	if err != nil {
+		return nil, err
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, time.Duration(req.Timeout)*time.Second)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(reqCtx, http.MethodPost, apiURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", contentType)
+	if authorization != "" {
+		httpReq.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := e.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return e.parseAPIResponse(resp)
+}
+
+// downloadFile fetches a file for upload, capped at the Discord upload limit.
+func (e *DiscordExecutor) downloadFile(ctx context.Context, fileURL string, timeout time.Duration) ([]byte, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(reqCtx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create download request: %w", err)
+	}
+
+	resp, err := e.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed to download file: HTTP %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, discordMaxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return data, nil
+}
+
+// parseAPIResponse parses Discord API response.
+func (e *DiscordExecutor) parseAPIResponse(resp *http.Response) (*DiscordResponse, error) {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Check for API errors
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr discordAPIError
+		_ = json.Unmarshal(body, &apiErr)
+
+		errorMsg := apiErr.Message
+		if errorMsg == "" {
+			errorMsg = fmt.Sprintf("HTTP %d", resp.StatusCode)
+		}
+
+		return &DiscordResponse{
+			Success:    false,
+			Error:      errorMsg,
+			ErrorCode:  apiErr.Code,
+			StatusCode: resp.StatusCode,
+			RetryAfter: apiErr.RetryAfter,
+		}, nil
+	}
+
+	var msg discordMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return e.buildSuccessResponse(&msg), nil
+}
+
+// buildSuccessResponse builds DiscordResponse from API message.
+func (e *DiscordExecutor) buildSuccessResponse(msg *discordMessage) *DiscordResponse {
+	response := &DiscordResponse{
+		Success:     true,
+		MessageID:   msg.ID,
+		ChannelID:   msg.ChannelID,
+		Timestamp:   msg.Timestamp,
+		Text:        msg.Content,
+		EmbedsCount: len(msg.Embeds),
+	}
+
+	if len(msg.Attachments) > 0 {
+		attachment := msg.Attachments[0]
+		response.FileURL = attachment.URL
+		response.FileName = attachment.Filename
+		response.FileSize = attachment.Size
+	}
+
+	return response
+}
+
+// responseToMap converts DiscordResponse to output map.
+func (e *DiscordExecutor) responseToMap(response *DiscordResponse) map[string]any {
+	result := map[string]any{
+		"success":      response.Success,
+		"message_type": response.MessageType,
+		"duration_ms":  response.DurationMS,
+	}
+
+	if response.Success {
+		result["message_id"] = response.MessageID
+		result["channel_id"] = response.ChannelID
+		result["timestamp"] = response.Timestamp
+
+		if response.Text != "" {
+			result["text"] = response.Text
+		}
+		if response.EmbedsCount > 0 {
+			result["embeds_count"] = response.EmbedsCount
+		}
+
+		// Add file info for file messages
+		if response.FileURL != "" {
+			result["file_url"] = response.FileURL
+			result["file_name"] = response.FileName
+			result["file_size"] = response.FileSize
+		}
+	} else {
+		result["error"] = response.Error
+		result["status_code"] = response.StatusCode
+		if response.ErrorCode > 0 {
+			result["error_code"] = response.ErrorCode
+		}
+		if response.RetryAfter > 0 {
+			result["retry_after"] = response.RetryAfter
+		}
+	}
+
+	return result
+}
+
+// validateDiscordWebhookURL checks that the URL looks like a Discord webhook.
+func validateDiscordWebhookURL(webhookURL string) error {
+	u, err := url.Parse(webhookURL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid webhook_url")
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return fmt.Errorf("webhook_url must be an http(s) URL")
+	}
+	if !strings.Contains(u.Path, "/webhooks/") {
+		return fmt.Errorf("invalid webhook_url format (expected: .../api/webhooks/<id>/<token>)")
+	}
+	return nil
+}
+
+// parseDiscordEmbeds accepts embeds as an array, a single object, or a JSON string
+// (e.g. rendered from a template).
+func parseDiscordEmbeds(raw any) ([]map[string]any, error) {
+	switch v := raw.(type) {
+	case nil:
+		return nil, nil
+	case []map[string]any:
+		return v, nil
+	case map[string]any:
+		return []map[string]any{v}, nil
+	case []any:
+		embeds := make([]map[string]any, 0, len(v))
+		for i, item := range v {
+			embed, ok := item.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("embeds[%d] must be an object", i)
+			}
+			embeds = append(embeds, embed)
+		}
+		return embeds, nil
+	case string:
+		trimmed := strings.TrimSpace(v)
+		if trimmed == "" {
+			return nil, nil
+		}
+		if strings.HasPrefix(trimmed, "{") {
+			var embed map[string]any
+			if err := json.Unmarshal([]byte(trimmed), &embed); err != nil {
+				return nil, fmt.Errorf("embeds must be a JSON object or array: %w", err)
+			}
+			return []map[string]any{embed}, nil
+		}
+		var embeds []map[string]any
+		if err := json.Unmarshal([]byte(trimmed), &embeds); err != nil {
+			return nil, fmt.Errorf("embeds must be a JSON object or array: %w", err)
+		}
+		return embeds, nil
+	default:
+		return nil, fmt.Errorf("embeds must be an array of objects")
+	}
+}
+
+// fileNameFromURL returns the last path segment of a URL, or "" if there is none.
+func fileNameFromURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	segments := strings.Split(strings.TrimSuffix(u.Path, "/"), "/")
+	return segments[len(segments)-1]
+}
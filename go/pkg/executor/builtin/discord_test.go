@@ -0,0 +1,389 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDiscordWebhookURL = "https://discord.com/api/webhooks/123/abc"
+
+func TestDiscordExecutor_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name: "valid webhook text message",
+			config: map[string]any{
+				"webhook_url":  testDiscordWebhookURL,
+				"message_type": "text",
+				"text":         "Hello, World!",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid bot embed message",
+			config: map[string]any{
+				"bot_token":    "bot-token",
+				"channel_id":   "987654321",
+				"message_type": "embed",
+				"embeds":       []any{map[string]any{"title": "Deploy"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid embed as JSON string",
+			config: map[string]any{
+				"webhook_url":  testDiscordWebhookURL,
+				"message_type": "embed",
+				"embeds":       `[{"title": "Deploy"}]`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing delivery",
+			config: map[string]any{
+				"message_type": "text",
+				"text":         "Hello",
+			},
+			wantErr: true,
+			errMsg:  "webhook_url or bot_token is required",
+		},
+		{
+			name: "webhook and bot token together",
+			config: map[string]any{
+				"webhook_url":  testDiscordWebhookURL,
+				"bot_token":    "bot-token",
+				"channel_id":   "987654321",
+				"message_type": "text",
+				"text":         "Hello",
+			},
+			wantErr: true,
+			errMsg:  "mutually exclusive",
+		},
+		{
+			name: "invalid webhook url",
+			config: map[string]any{
+				"webhook_url":  "https://example.com/hook",
+				"message_type": "text",
+				"text":         "Hello",
+			},
+			wantErr: true,
+			errMsg:  "invalid webhook_url format",
+		},
+		{
+			name: "bot token without channel_id",
+			config: map[string]any{
+				"bot_token":    "bot-token",
+				"message_type": "text",
+				"text":         "Hello",
+			},
+			wantErr: true,
+			errMsg:  "channel_id",
+		},
+		{
+			name: "username with bot token",
+			config: map[string]any{
+				"bot_token":    "bot-token",
+				"channel_id":   "987654321",
+				"message_type": "text",
+				"text":         "Hello",
+				"username":     "mbflow",
+			},
+			wantErr: true,
+			errMsg:  "username is only supported with webhook_url",
+		},
+		{
+			name: "reply with webhook",
+			config: map[string]any{
+				"webhook_url":         testDiscordWebhookURL,
+				"message_type":        "text",
+				"text":                "Hello",
+				"reply_to_message_id": "111",
+			},
+			wantErr: true,
+			errMsg:  "reply_to_message_id requires bot_token",
+		},
+		{
+			name: "missing message_type",
+			config: map[string]any{
+				"webhook_url": testDiscordWebhookURL,
+				"text":        "Hello",
+			},
+			wantErr: true,
+			errMsg:  "message_type",
+		},
+		{
+			name: "invalid message_type",
+			config: map[string]any{
+				"webhook_url":  testDiscordWebhookURL,
+				"message_type": "photo",
+			},
+			wantErr: true,
+			errMsg:  "invalid message_type",
+		},
+		{
+			name: "text message without text",
+			config: map[string]any{
+				"webhook_url":  testDiscordWebhookURL,
+				"message_type": "text",
+			},
+			wantErr: true,
+			errMsg:  "text is required",
+		},
+		{
+			name: "embed message without embeds",
+			config: map[string]any{
+				"webhook_url":  testDiscordWebhookURL,
+				"message_type": "embed",
+			},
+			wantErr: true,
+			errMsg:  "embeds are required",
+		},
+		{
+			name: "embeds with non-object item",
+			config: map[string]any{
+				"webhook_url":  testDiscordWebhookURL,
+				"message_type": "embed",
+				"embeds":       []any{"title"},
+			},
+			wantErr: true,
+			errMsg:  "embeds[0] must be an object",
+		},
+		{
+			name: "file message without file_data",
+			config: map[string]any{
+				"webhook_url":  testDiscordWebhookURL,
+				"message_type": "file",
+				"file_source":  "url",
+			},
+			wantErr: true,
+			errMsg:  "file_data",
+		},
+		{
+			name: "invalid file_source",
+			config: map[string]any{
+				"webhook_url":  testDiscordWebhookURL,
+				"message_type": "file",
+				"file_source":  "file_id",
+				"file_data":    "abc",
+			},
+			wantErr: true,
+			errMsg:  "invalid file_source",
+		},
+		{
+			name: "timeout too large",
+			config: map[string]any{
+				"webhook_url":  testDiscordWebhookURL,
+				"message_type": "text",
+				"text":         "Hello",
+				"timeout":      301,
+			},
+			wantErr: true,
+			errMsg:  "timeout must be between 1 and 300 seconds",
+		},
+	}
+
+	executor := NewDiscordExecutor()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := executor.Validate(tt.config)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDiscordExecutor_Execute_WebhookText(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/webhooks/123/abc", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("wait"))
+		assert.Equal(t, "555", r.URL.Query().Get("thread_id"))
+		assert.Empty(t, r.Header.Get("Authorization"))
+
+		var payload map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+
+		assert.Equal(t, "Build passed", payload["content"])
+		assert.Equal(t, "mbflow", payload["username"])
+		assert.Equal(t, float64(discordFlagSuppressNotifications), payload["flags"])
+
+		json.NewEncoder(w).Encode(discordMessage{
+			ID:        "1001",
+			ChannelID: "2002",
+			Content:   "Build passed",
+			Timestamp: "2024-01-01T00:00:00+00:00",
+		})
+	}))
+	defer server.Close()
+
+	executor := NewDiscordExecutor()
+
+	result, err := executor.Execute(context.Background(), map[string]any{
+		"webhook_url":          server.URL + "/api/webhooks/123/abc",
+		"message_type":         "text",
+		"text":                 "Build passed",
+		"username":             "mbflow",
+		"thread_id":            "555",
+		"disable_notification": true,
+	}, nil)
+
+	require.NoError(t, err)
+	output := result.(map[string]any)
+	assert.Equal(t, true, output["success"])
+	assert.Equal(t, "1001", output["message_id"])
+	assert.Equal(t, "2002", output["channel_id"])
+	assert.Equal(t, "Build passed", output["text"])
+	assert.Equal(t, "text", output["message_type"])
+}
+
+func TestDiscordExecutor_Execute_BotEmbed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/channels/987654321/messages", r.URL.Path)
+		assert.Equal(t, "Bot bot-token", r.Header.Get("Authorization"))
+
+		var payload map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+
+		embeds := payload["embeds"].([]any)
+		require.Len(t, embeds, 1)
+		assert.Equal(t, "Deploy", embeds[0].(map[string]any)["title"])
+		assert.Equal(t, map[string]any{"message_id": "111"}, payload["message_reference"])
+		assert.NotContains(t, payload, "username")
+
+		json.NewEncoder(w).Encode(discordMessage{
+			ID:        "1002",
+			ChannelID: "987654321",
+			Embeds:    []map[string]any{{"title": "Deploy"}},
+		})
+	}))
+	defer server.Close()
+
+	executor := NewDiscordExecutor()
+	executor.baseURL = server.URL
+
+	result, err := executor.Execute(context.Background(), map[string]any{
+		"bot_token":           "bot-token",
+		"channel_id":          "987654321",
+		"message_type":        "embed",
+		"embeds":              `{"title": "Deploy"}`,
+		"reply_to_message_id": "111",
+	}, nil)
+
+	require.NoError(t, err)
+	output := result.(map[string]any)
+	assert.Equal(t, true, output["success"])
+	assert.Equal(t, "1002", output["message_id"])
+	assert.Equal(t, 1, output["embeds_count"])
+}
+
+func TestDiscordExecutor_Execute_FileByBase64(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseMultipartForm(10<<20))
+
+		var payload map[string]any
+		require.NoError(t, json.Unmarshal([]byte(r.FormValue("payload_json")), &payload))
+		assert.Equal(t, "Report attached", payload["content"])
+
+		file, header, err := r.FormFile("files[0]")
+		require.NoError(t, err)
+		defer file.Close()
+		data, _ := io.ReadAll(file)
+		assert.Equal(t, "report.txt", header.Filename)
+		assert.Equal(t, "Hello World!", string(data))
+
+		json.NewEncoder(w).Encode(discordMessage{
+			ID:        "1003",
+			ChannelID: "2002",
+			Attachments: []discordAttachment{{
+				ID:       "1",
+				Filename: "report.txt",
+				Size:     12,
+				URL:      "https://cdn.discordapp.com/attachments/report.txt",
+			}},
+		})
+	}))
+	defer server.Close()
+
+	executor := NewDiscordExecutor()
+
+	result, err := executor.Execute(context.Background(), map[string]any{
+		"webhook_url":  server.URL + "/api/webhooks/123/abc",
+		"message_type": "file",
+		"text":         "Report attached",
+		"file_source":  "base64",
+		"file_data":    "SGVsbG8gV29ybGQh",
+		"file_name":    "report.txt",
+	}, nil)
+
+	require.NoError(t, err)
+	output := result.(map[string]any)
+	assert.Equal(t, true, output["success"])
+	assert.Equal(t, "report.txt", output["file_name"])
+	assert.Equal(t, 12, output["file_size"])
+	assert.Equal(t, "https://cdn.discordapp.com/attachments/report.txt", output["file_url"])
+}
+
+func TestDiscordExecutor_Execute_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		json.NewEncoder(w).Encode(map[string]any{
+			"message":     "You are being rate limited.",
+			"code":        0,
+			"retry_after": 1.5,
+		})
+	}))
+	defer server.Close()
+
+	executor := NewDiscordExecutor()
+	executor.baseURL = server.URL
+
+	result, err := executor.Execute(context.Background(), map[string]any{
+		"bot_token":    "bot-token",
+		"channel_id":   "987654321",
+		"message_type": "text",
+		"text":         "Hello",
+	}, nil)
+
+	require.NoError(t, err)
+	output := result.(map[string]any)
+	assert.Equal(t, false, output["success"])
+	assert.Equal(t, "You are being rate limited.", output["error"])
+	assert.Equal(t, http.StatusTooManyRequests, output["status_code"])
+	assert.Equal(t, 1.5, output["retry_after"])
+}
+
+func TestParseDiscordEmbeds(t *testing.T) {
+	embeds, err := parseDiscordEmbeds([]map[string]any{{"title": "a"}, {"title": "b"}})
+	require.NoError(t, err)
+	assert.Len(t, embeds, 2)
+
+	embeds, err = parseDiscordEmbeds(map[string]any{"title": "a"})
+	require.NoError(t, err)
+	assert.Len(t, embeds, 1)
+
+	embeds, err = parseDiscordEmbeds("")
+	require.NoError(t, err)
+	assert.Nil(t, embeds)
+
+	_, err = parseDiscordEmbeds("not json")
+	assert.Error(t, err)
+
+	_, err = parseDiscordEmbeds(42)
+	assert.Error(t, err)
+}
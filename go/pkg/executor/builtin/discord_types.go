@@ -0,0 +1,82 @@
+package builtin
+
+// discordMessage represents a Discord message object returned by the API.
+type discordMessage struct {
+	ID          string              `json:"id"`
+	ChannelID   string              `json:"channel_id"`
+	Content     string              `json:"content,omitempty"`
+	Timestamp   string              `json:"timestamp"`
+	Embeds      []map[string]any    `json:"embeds,omitempty"`
+	Attachments []discordAttachment `json:"attachments,omitempty"`
+}
+
+// discordAttachment represents file information in Discord API.
+type discordAttachment struct {
+	ID       string `json:"id"`
+	Filename string `json:"filename"`
+	Size     int    `json:"size"`
+	URL      string `json:"url"`
+}
+
+// discordAPIError represents an error response from Discord API.
+type discordAPIError struct {
+	Message    string  `json:"message"`
+	Code       int     `json:"code"`
+	RetryAfter float64 `json:"retry_after,omitempty"` // For rate limiting (429), in seconds
+}
+
+// DiscordRequest represents a processed request ready for API call.
+type DiscordRequest struct {
+	// Delivery: either WebhookURL or BotToken + ChannelID
+	WebhookURL string
+	BotToken   string
+	ChannelID  string
+
+	MessageType string
+	Text        string
+	Embeds      []map[string]any
+
+	// Webhook identity overrides
+	Username  string
+	AvatarURL string
+
+	TTS                 bool
+	DisableNotification bool
+	SuppressEmbeds      bool
+
+	ReplyToMessageID string // Bot only
+	ThreadID         string // Webhook only
+
+	// File fields
+	FileSource string
+	FileData   string
+	FileName   string
+
+	Timeout int // Timeout in seconds
+}
+
+// DiscordResponse represents the output from Discord executor.
+type DiscordResponse struct {
+	Success   bool   `json:"success"`
+	MessageID string `json:"message_id,omitempty"`
+	ChannelID string `json:"channel_id,omitempty"`
+	Timestamp string `json:"timestamp,omitempty"`
+
+	MessageType string `json:"message_type"`
+	Text        string `json:"text,omitempty"`
+	EmbedsCount int    `json:"embeds_count,omitempty"`
+
+	// File info (for file messages)
+	FileURL  string `json:"file_url,omitempty"`
+	FileName string `json:"file_name,omitempty"`
+	FileSize int    `json:"file_size,omitempty"`
+
+	// Error information
+	Error      string  `json:"error,omitempty"`
+	ErrorCode  int     `json:"error_code,omitempty"`
+	StatusCode int     `json:"status_code,omitempty"`
+	RetryAfter float64 `json:"retry_after,omitempty"`
+
+	// Request metadata
+	DurationMS int64 `json:"duration_ms"`
+}
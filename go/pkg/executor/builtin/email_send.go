@@ -0,0 +1,652 @@
+package builtin
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/smilemakc/mbflow/go/internal/application/filestorage"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+const (
+	emailTLSStartTLS = "starttls"
+	emailTLSImplicit = "tls"
+	emailTLSNone     = "none"
+
+	// emailMaxAttachmentBytes caps the combined size of attachments (most providers reject > 25 MB).
+	emailMaxAttachmentBytes = 25 * 1024 * 1024
+)
+
+// CredentialResolver resolves a credentials resource by ID and returns its decrypted data.
+// It is satisfied by credentials.Service.
+type CredentialResolver interface {
+	GetDecrypted(ctx context.Context, resourceID string) (*models.CredentialsResource, error)
+}
+
+// EmailSendExecutor sends email over SMTP.
+// Passwords are never part of the node config: authentication uses a credentials
+// resource referenced by ID (basic_auth, or custom with username/password fields).
+type EmailSendExecutor struct {
+	*executor.BaseExecutor
+	credentials CredentialResolver
+	storage     filestorage.Manager
+}
+
+// NewEmailSendExecutor creates a new email send executor.
+// credentials may be nil, in which case only unauthenticated relays can be used;
+// storage may be nil, in which case attachments are not available.
+func NewEmailSendExecutor(credentials CredentialResolver, storage filestorage.Manager) *EmailSendExecutor {
+	return &EmailSendExecutor{
+		BaseExecutor: executor.NewBaseExecutor("email_send"),
+		credentials:  credentials,
+		storage:      storage,
+	}
+}
+
+// emailAttachment is a file loaded from storage and ready to be encoded.
+type emailAttachment struct {
+	name     string
+	mimeType string
+	data     []byte
+}
+
+// Execute sends an email.
+//
+// Config:
+//   - host: SMTP server host (required)
+//   - port: SMTP server port (default: 587, or 465 for tls: "tls")
+//   - tls: "starttls" (default) | "tls" (implicit TLS) | "none"
+//   - insecure_skip_verify: Skip TLS certificate verification (default: false)
+//   - credential_id: ID of a credentials resource holding username/password; the credential
+//     must be attached to the workflow, e.g. credential_id: "{{resource.smtp.id}}"
+//   - from: Sender address, e.g. "Reports <reports@example.com>" (required)
+//   - to, cc, bcc: Recipient address or list of addresses (to is required)
+//   - reply_to: Reply-To address
+//   - subject: Subject line (templates are resolved before execution)
+//   - text_body: Plain text body
+//   - html_body: HTML body (sent as multipart/alternative when text_body is also set)
+//   - attachments: Array of {file_id, storage_id (default: "default"), file_name}
+//   - headers: Extra headers as a map
+//   - timeout: Timeout in seconds (default: 30)
+//
+// Output:
+//   - success: true
+//   - message_id: Message-ID header of the sent message
+//   - recipients: Number of envelope recipients
+//   - attachments: Number of attachments
+//   - duration_ms: Send duration
+func (e *EmailSendExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	startTime := time.Now()
+
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+
+	from, err := mail.ParseAddress(e.GetStringDefault(config, "from", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address: %w", err)
+	}
+	to, err := parseEmailAddresses(config["to"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid to address: %w", err)
+	}
+	if len(to) == 0 {
+		return nil, fmt.Errorf("at least one recipient is required")
+	}
+	cc, err := parseEmailAddresses(config["cc"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid cc address: %w", err)
+	}
+	bcc, err := parseEmailAddresses(config["bcc"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid bcc address: %w", err)
+	}
+
+	var replyTo *mail.Address
+	if v := e.GetStringDefault(config, "reply_to", ""); v != "" {
+		replyTo, err = mail.ParseAddress(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reply_to address: %w", err)
+		}
+	}
+
+	username, password, err := e.resolveCredentials(ctx, e.GetStringDefault(config, "credential_id", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := e.loadAttachments(ctx, config["attachments"])
+	if err != nil {
+		return nil, err
+	}
+
+	messageID := fmt.Sprintf("<%s@%s>", uuid.New().String(), emailDomain(from.Address))
+	message, err := buildEmailMessage(emailMessage{
+		from:        from,
+		to:          to,
+		cc:          cc,
+		replyTo:     replyTo,
+		subject:     e.GetStringDefault(config, "subject", ""),
+		textBody:    e.GetStringDefault(config, "text_body", ""),
+		htmlBody:    e.GetStringDefault(config, "html_body", ""),
+		headers:     stringMap(config["headers"]),
+		messageID:   messageID,
+		date:        time.Now(),
+		attachments: attachments,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build message: %w", err)
+	}
+
+	recipients := make([]string, 0, len(to)+len(cc)+len(bcc))
+	for _, list := range [][]*mail.Address{to, cc, bcc} {
+		for _, addr := range list {
+			recipients = append(recipients, addr.Address)
+		}
+	}
+
+	tlsMode := e.GetStringDefault(config, "tls", emailTLSStartTLS)
+	defaultPort := 587
+	if tlsMode == emailTLSImplicit {
+		defaultPort = 465
+	}
+
+	host := e.GetStringDefault(config, "host", "")
+	timeout := time.Duration(e.GetIntDefault(config, "timeout", 30)) * time.Second
+	sendCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err = sendSMTP(sendCtx, smtpParams{
+		host:               host,
+		port:               e.GetIntDefault(config, "port", defaultPort),
+		tlsMode:            tlsMode,
+		insecureSkipVerify: e.GetBoolDefault(config, "insecure_skip_verify", false),
+		username:           username,
+		password:           password,
+		from:               from.Address,
+		recipients:         recipients,
+		message:            message,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to send email via %s: %w", host, err)
+	}
+
+	return map[string]any{
+		"success":     true,
+		"message_id":  messageID,
+		"recipients":  len(recipients),
+		"attachments": len(attachments),
+		"duration_ms": time.Since(startTime).Milliseconds(),
+	}, nil
+}
+
+// Validate validates the email send executor configuration.
+func (e *EmailSendExecutor) Validate(config map[string]any) error {
+	if err := e.ValidateRequired(config, "host", "from", "to"); err != nil {
+		return err
+	}
+
+	for _, key := range []string{"password", "username", "smtp_password"} {
+		if _, ok := config[key]; ok {
+			return fmt.Errorf("%s must not be set inline: store it in a credentials resource and reference it with credential_id", key)
+		}
+	}
+
+	tlsMode := e.GetStringDefault(config, "tls", emailTLSStartTLS)
+	switch tlsMode {
+	case emailTLSStartTLS, emailTLSImplicit, emailTLSNone:
+	default:
+		return fmt.Errorf("invalid tls mode: %s (valid: starttls, tls, none)", tlsMode)
+	}
+
+	if port := e.GetIntDefault(config, "port", 0); port < 0 || port > 65535 {
+		return fmt.Errorf("invalid port: %d", port)
+	}
+
+	if e.GetStringDefault(config, "text_body", "") == "" && e.GetStringDefault(config, "html_body", "") == "" {
+		return fmt.Errorf("text_body or html_body is required")
+	}
+
+	if attachments, ok := config["attachments"]; ok && attachments != nil {
+		if _, ok := attachments.([]any); !ok {
+			return fmt.Errorf("attachments must be an array")
+		}
+		if e.storage == nil {
+			return fmt.Errorf("attachments require file storage")
+		}
+	}
+
+	return nil
+}
+
+// resolveCredentials loads SMTP username and password from a credentials resource.
+func (e *EmailSendExecutor) resolveCredentials(ctx context.Context, credentialID string) (string, string, error) {
+	return resolveUsernamePassword(ctx, e.credentials, credentialID)
+}
+
+// resolveUsernamePassword loads a username and password from a basic_auth or custom
+// credentials resource. An empty credentialID resolves to empty credentials.
+func resolveUsernamePassword(ctx context.Context, credentials CredentialResolver, credentialID string) (string, string, error) {
+	if credentialID == "" {
+		return "", "", nil
+	}
+	if credentials == nil {
+		return "", "", fmt.Errorf("credential_id is set but credentials are not available")
+	}
+	if !credentialAttached(ctx, credentialID) {
+		return "", "", fmt.Errorf("credential %s is not attached to the workflow as a resource", credentialID)
+	}
+
+	cred, err := credentials.GetDecrypted(ctx, credentialID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to resolve credential %s: %w", credentialID, err)
+	}
+
+	switch cred.CredentialType {
+	case models.CredentialTypeBasicAuth:
+		username, password := cred.GetBasicAuth()
+		return username, password, nil
+	case models.CredentialTypeCustom:
+		return cred.DecryptedData["username"], cred.DecryptedData["password"], nil
+	default:
+		return "", "", fmt.Errorf("credential %s has unsupported type %s (expected basic_auth or custom)",
+			credentialID, cred.CredentialType)
+	}
+}
+
+// credentialAttached reports whether the credential is one of the workflow's resources.
+// Resource ownership is validated by the engine when the execution starts, so this keeps a
+// workflow from using credentials that belong to someone else. Outside of a workflow
+// execution (no execution context) the check is skipped.
+func credentialAttached(ctx context.Context, credentialID string) bool {
+	execCtx, ok := executor.GetExecutionContext(ctx)
+	if !ok {
+		return true
+	}
+	for _, res := range execCtx.Resources {
+		if data, ok := res.(map[string]any); ok && data["id"] == credentialID {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveAuthorizationHeader loads an Authorization header value from a credentials resource:
+// api_key credentials become "Bearer <key>" and basic_auth credentials "Basic <base64>".
+func resolveAuthorizationHeader(ctx context.Context, credentials CredentialResolver, credentialID string) (string, error) {
+	if credentials == nil {
+		return "", fmt.Errorf("credential_id is set but credentials are not available")
+	}
+	if !credentialAttached(ctx, credentialID) {
+		return "", fmt.Errorf("credential %s is not attached to the workflow as a resource", credentialID)
+	}
+
+	cred, err := credentials.GetDecrypted(ctx, credentialID)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve credential %s: %w", credentialID, err)
+	}
+
+	switch cred.CredentialType {
+	case models.CredentialTypeAPIKey:
+		return "Bearer " + cred.GetAPIKey(), nil
+	case models.CredentialTypeBasicAuth:
+		username, password := cred.GetBasicAuth()
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password)), nil
+	default:
+		return "", fmt.Errorf("credential %s has unsupported type %s (expected api_key or basic_auth)",
+			credentialID, cred.CredentialType)
+	}
+}
+
+// loadAttachments reads attachment files from storage.
+func (e *EmailSendExecutor) loadAttachments(ctx context.Context, raw any) ([]emailAttachment, error) {
+	items, _ := raw.([]any)
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	attachments := make([]emailAttachment, 0, len(items))
+	var total int64
+	for i, item := range items {
+		spec, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("attachment %d must be an object", i)
+		}
+
+		fileID, _ := spec["file_id"].(string)
+		if fileID == "" {
+			return nil, fmt.Errorf("attachment %d: file_id is required", i)
+		}
+		storageID := e.GetStringDefault(spec, "storage_id", "default")
+
+		storage, err := e.storage.GetStorage(storageID)
+		if err != nil {
+			return nil, fmt.Errorf("attachment %d: failed to get storage: %w", i, err)
+		}
+
+		entry, reader, err := storage.Get(ctx, fileID)
+		if err != nil {
+			return nil, fmt.Errorf("attachment %d: failed to get file %s: %w", i, fileID, err)
+		}
+		data, err := io.ReadAll(io.LimitReader(reader, emailMaxAttachmentBytes-total+1))
+		reader.Close()
+		if err != nil {
+			return nil, fmt.Errorf("attachment %d: failed to read file %s: %w", i, fileID, err)
+		}
+
+		total += int64(len(data))
+		if total > emailMaxAttachmentBytes {
+			return nil, fmt.Errorf("attachments exceed %d bytes", emailMaxAttachmentBytes)
+		}
+
+		name := e.GetStringDefault(spec, "file_name", entry.Name)
+		mimeType := entry.MimeType
+		if mimeType == "" {
+			mimeType = "application/octet-stream"
+		}
+		attachments = append(attachments, emailAttachment{name: name, mimeType: mimeType, data: data})
+	}
+
+	return attachments, nil
+}
+
+// emailMessage holds everything needed to render an RFC 5322 message.
+type emailMessage struct {
+	from        *mail.Address
+	to          []*mail.Address
+	cc          []*mail.Address
+	replyTo     *mail.Address
+	subject     string
+	textBody    string
+	htmlBody    string
+	headers     map[string]string
+	messageID   string
+	date        time.Time
+	attachments []emailAttachment
+}
+
+// buildEmailMessage renders the message. Bcc recipients are deliberately left out of the headers.
+func buildEmailMessage(msg emailMessage) ([]byte, error) {
+	var buf bytes.Buffer
+
+	writeHeader := func(key, value string) {
+		buf.WriteString(key + ": " + value + "\r\n")
+	}
+
+	writeHeader("From", msg.from.String())
+	writeHeader("To", joinAddresses(msg.to))
+	if len(msg.cc) > 0 {
+		writeHeader("Cc", joinAddresses(msg.cc))
+	}
+	if msg.replyTo != nil {
+		writeHeader("Reply-To", msg.replyTo.String())
+	}
+	writeHeader("Subject", mime.QEncoding.Encode("utf-8", msg.subject))
+	writeHeader("Date", msg.date.Format(time.RFC1123Z))
+	writeHeader("Message-ID", msg.messageID)
+	writeHeader("MIME-Version", "1.0")
+	for key, value := range msg.headers {
+		if strings.ContainsAny(key+value, "\r\n") {
+			return nil, fmt.Errorf("header %q contains a line break", key)
+		}
+		writeHeader(textproto.CanonicalMIMEHeaderKey(key), mime.QEncoding.Encode("utf-8", value))
+	}
+
+	bodyHeader, body, err := buildEmailBody(msg.textBody, msg.htmlBody)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(msg.attachments) == 0 {
+		for _, key := range []string{"Content-Type", "Content-Transfer-Encoding"} {
+			if value := bodyHeader.Get(key); value != "" {
+				writeHeader(key, value)
+			}
+		}
+		buf.WriteString("\r\n")
+		buf.Write(body)
+		return buf.Bytes(), nil
+	}
+
+	mixed := multipart.NewWriter(&buf)
+	writeHeader("Content-Type", "multipart/mixed; boundary="+mixed.Boundary())
+	buf.WriteString("\r\n")
+
+	part, err := mixed.CreatePart(bodyHeader)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(body); err != nil {
+		return nil, err
+	}
+
+	for _, att := range msg.attachments {
+		part, err := mixed.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType(att.mimeType, map[string]string{"name": att.name})},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": att.name})},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeBase64Lines(part, att.data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := mixed.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// buildEmailBody returns the headers and encoded content of the body entity.
+// With both text and HTML it produces multipart/alternative, text first.
+func buildEmailBody(textBody, htmlBody string) (textproto.MIMEHeader, []byte, error) {
+	var buf bytes.Buffer
+
+	if textBody != "" && htmlBody != "" {
+		alt := multipart.NewWriter(&buf)
+		for _, p := range []struct{ contentType, content string }{
+			{"text/plain; charset=utf-8", textBody},
+			{"text/html; charset=utf-8", htmlBody},
+		} {
+			part, err := alt.CreatePart(textproto.MIMEHeader{
+				"Content-Type":              {p.contentType},
+				"Content-Transfer-Encoding": {"quoted-printable"},
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+			if err := writeQuotedPrintable(part, p.content); err != nil {
+				return nil, nil, err
+			}
+		}
+		if err := alt.Close(); err != nil {
+			return nil, nil, err
+		}
+		header := textproto.MIMEHeader{"Content-Type": {"multipart/alternative; boundary=" + alt.Boundary()}}
+		return header, buf.Bytes(), nil
+	}
+
+	contentType, content := "text/plain; charset=utf-8", textBody
+	if htmlBody != "" {
+		contentType, content = "text/html; charset=utf-8", htmlBody
+	}
+	if err := writeQuotedPrintable(&buf, content); err != nil {
+		return nil, nil, err
+	}
+	header := textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	}
+	return header, buf.Bytes(), nil
+}
+
+func writeQuotedPrintable(w io.Writer, content string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(content)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// writeBase64Lines writes base64 wrapped at 76 characters as required by RFC 2045.
+func writeBase64Lines(w io.Writer, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 0 {
+		n := min(76, len(encoded))
+		if _, err := io.WriteString(w, encoded[:n]+"\r\n"); err != nil {
+			return err
+		}
+		encoded = encoded[n:]
+	}
+	return nil
+}
+
+// smtpParams holds connection and envelope data for a single send.
+type smtpParams struct {
+	host               string
+	port               int
+	tlsMode            string
+	insecureSkipVerify bool
+	username           string
+	password           string
+	from               string
+	recipients         []string
+	message            []byte
+}
+
+// sendSMTP delivers the message, honoring ctx for dialing and as an overall deadline.
+func sendSMTP(ctx context.Context, p smtpParams) error {
+	addr := net.JoinHostPort(p.host, strconv.Itoa(p.port))
+	tlsConfig := &tls.Config{ServerName: p.host, InsecureSkipVerify: p.insecureSkipVerify}
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	if p.tlsMode == emailTLSImplicit {
+		conn = tls.Client(conn, tlsConfig)
+	}
+
+	client, err := smtp.NewClient(conn, p.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start session: %w", err)
+	}
+	defer client.Close()
+
+	if p.tlsMode == emailTLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("server does not support STARTTLS (use tls: \"tls\" or \"none\")")
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("STARTTLS failed: %w", err)
+		}
+	}
+
+	if p.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", p.username, p.password, p.host)); err != nil {
+			return fmt.Errorf("authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(p.from); err != nil {
+		return fmt.Errorf("MAIL FROM rejected: %w", err)
+	}
+	for _, rcpt := range p.recipients {
+		if err := client.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("recipient %s rejected: %w", rcpt, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("DATA rejected: %w", err)
+	}
+	if _, err := w.Write(p.message); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("message rejected: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// parseEmailAddresses accepts a comma-separated string or an array of addresses.
+func parseEmailAddresses(raw any) ([]*mail.Address, error) {
+	switch v := raw.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return nil, nil
+		}
+		return mail.ParseAddressList(v)
+	case []string:
+		return parseEmailAddresses(strings.Join(v, ","))
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("address must be a string, got %T", item)
+			}
+			if strings.TrimSpace(s) != "" {
+				parts = append(parts, s)
+			}
+		}
+		return parseEmailAddresses(strings.Join(parts, ","))
+	default:
+		return nil, fmt.Errorf("addresses must be a string or array, got %T", raw)
+	}
+}
+
+func joinAddresses(addrs []*mail.Address) string {
+	parts := make([]string, len(addrs))
+	for i, addr := range addrs {
+		parts[i] = addr.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+func emailDomain(address string) string {
+	if _, domain, ok := strings.Cut(address, "@"); ok && domain != "" {
+		return domain
+	}
+	return "mbflow.local"
+}
+
+func stringMap(raw any) map[string]string {
+	m, ok := raw.(map[string]any)
+	if !ok {
+		return nil
+	}
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[k] = fmt.Sprint(v)
+	}
+	return result
+}
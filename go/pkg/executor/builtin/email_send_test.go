@@ -0,0 +1,287 @@
+package builtin
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer accepts a single plaintext session with AUTH PLAIN and records the envelope.
+type fakeSMTPServer struct {
+	addr string
+
+	mu         sync.Mutex
+	authPlain  string
+	from       string
+	recipients []string
+	data       string
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	s := &fakeSMTPServer{addr: ln.Addr().String()}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+	reply("220 fake ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(line)
+
+		s.mu.Lock()
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"):
+			reply("250-fake")
+			reply("250 AUTH PLAIN")
+		case strings.HasPrefix(cmd, "AUTH PLAIN"):
+			s.authPlain = strings.TrimSpace(line[len("AUTH PLAIN"):])
+			reply("235 ok")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			s.from = strings.Trim(line[len("MAIL FROM:"):], "<> ")
+			reply("250 ok")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			s.recipients = append(s.recipients, strings.Trim(line[len("RCPT TO:"):], "<> "))
+			reply("250 ok")
+		case cmd == "DATA":
+			reply("354 go ahead")
+			var b strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					s.mu.Unlock()
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				b.WriteString(l)
+			}
+			s.data = b.String()
+			reply("250 queued")
+		case cmd == "QUIT":
+			reply("221 bye")
+			s.mu.Unlock()
+			return
+		default:
+			reply("250 ok")
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (s *fakeSMTPServer) hostPort(t *testing.T) (string, int) {
+	host, port, err := net.SplitHostPort(s.addr)
+	require.NoError(t, err)
+	p, err := net.LookupPort("tcp", port)
+	require.NoError(t, err)
+	return host, p
+}
+
+type fakeCredentialResolver struct {
+	creds map[string]*models.CredentialsResource
+}
+
+func (f *fakeCredentialResolver) GetDecrypted(ctx context.Context, resourceID string) (*models.CredentialsResource, error) {
+	cred, ok := f.creds[resourceID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return cred, nil
+}
+
+func newSMTPCredentials() *fakeCredentialResolver {
+	cred := models.NewCredentialsResource("owner-1", "smtp", models.CredentialTypeBasicAuth)
+	cred.DecryptedData = map[string]string{"username": "mailer", "password": "s3cret"}
+	return &fakeCredentialResolver{creds: map[string]*models.CredentialsResource{"cred-1": cred}}
+}
+
+func TestEmailSendExecutor_Validate(t *testing.T) {
+	exec := NewEmailSendExecutor(nil, nil)
+
+	base := func() map[string]any {
+		return map[string]any{
+			"host":      "smtp.example.com",
+			"from":      "bot@example.com",
+			"to":        "user@example.com",
+			"text_body": "hi",
+		}
+	}
+
+	assert.NoError(t, exec.Validate(base()))
+
+	tests := []struct {
+		name   string
+		mutate func(map[string]any)
+		errMsg string
+	}{
+		{name: "missing host", mutate: func(c map[string]any) { delete(c, "host") }, errMsg: "host"},
+		{name: "missing to", mutate: func(c map[string]any) { delete(c, "to") }, errMsg: "to"},
+		{name: "inline password", mutate: func(c map[string]any) { c["password"] = "x" }, errMsg: "credential_id"},
+		{name: "invalid tls", mutate: func(c map[string]any) { c["tls"] = "ssl3" }, errMsg: "tls"},
+		{name: "no body", mutate: func(c map[string]any) { delete(c, "text_body") }, errMsg: "body"},
+		{name: "attachments without storage", mutate: func(c map[string]any) {
+			c["attachments"] = []any{map[string]any{"file_id": "f1"}}
+		}, errMsg: "file storage"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := base()
+			tt.mutate(cfg)
+			err := exec.Validate(cfg)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestEmailSendExecutor_Execute(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	host, port := server.hostPort(t)
+
+	exec := NewEmailSendExecutor(newSMTPCredentials(), nil)
+	out, err := exec.Execute(context.Background(), map[string]any{
+		"host":          host,
+		"port":          port,
+		"tls":           "none",
+		"credential_id": "cred-1",
+		"from":          "Reports <reports@example.com>",
+		"to":            []any{"alice@example.com", "bob@example.com"},
+		"bcc":           "audit@example.com",
+		"subject":       "Weekly report – ready",
+		"text_body":     "Plain body",
+		"html_body":     "<p>HTML body</p>",
+	}, nil)
+	require.NoError(t, err)
+
+	result := out.(map[string]any)
+	assert.Equal(t, true, result["success"])
+	assert.Equal(t, 3, result["recipients"])
+	assert.Contains(t, result["message_id"], "@example.com>")
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	auth, err := base64.StdEncoding.DecodeString(server.authPlain)
+	require.NoError(t, err)
+	assert.Equal(t, "\x00mailer\x00s3cret", string(auth))
+	assert.Equal(t, "reports@example.com", server.from)
+	assert.Equal(t, []string{"alice@example.com", "bob@example.com", "audit@example.com"}, server.recipients)
+
+	msg, err := mail.ReadMessage(strings.NewReader(server.data))
+	require.NoError(t, err)
+	assert.Empty(t, msg.Header.Get("Bcc"), "bcc must not leak into headers")
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Weekly report – ready", subject)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	var types []string
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		types = append(types, part.Header.Get("Content-Type"))
+	}
+	assert.Equal(t, []string{"text/plain; charset=utf-8", "text/html; charset=utf-8"}, types)
+}
+
+func TestEmailSendExecutor_CredentialMustBeAttached(t *testing.T) {
+	exec := NewEmailSendExecutor(newSMTPCredentials(), nil)
+
+	ctx := executor.WithExecutionContext(context.Background(), &executor.ExecutionContextData{
+		Resources: map[string]any{"other": map[string]any{"id": "cred-2"}},
+	})
+	_, _, err := exec.resolveCredentials(ctx, "cred-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not attached")
+
+	ctx = executor.WithExecutionContext(context.Background(), &executor.ExecutionContextData{
+		Resources: map[string]any{"smtp": map[string]any{"id": "cred-1"}},
+	})
+	username, password, err := exec.resolveCredentials(ctx, "cred-1")
+	require.NoError(t, err)
+	assert.Equal(t, "mailer", username)
+	assert.Equal(t, "s3cret", password)
+}
+
+func TestBuildEmailMessage_Attachments(t *testing.T) {
+	from, _ := mail.ParseAddress("bot@example.com")
+	to, _ := mail.ParseAddress("user@example.com")
+
+	raw, err := buildEmailMessage(emailMessage{
+		from:      from,
+		to:        []*mail.Address{to},
+		subject:   "Invoice",
+		textBody:  "See attached",
+		messageID: "<id@example.com>",
+		attachments: []emailAttachment{
+			{name: "invoice.pdf", mimeType: "application/pdf", data: []byte("%PDF-1.4 fake")},
+		},
+	})
+	require.NoError(t, err)
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	require.NoError(t, err)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/mixed", mediaType)
+
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+
+	body, err := mr.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "text/plain; charset=utf-8", body.Header.Get("Content-Type"))
+
+	att, err := mr.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "invoice.pdf", att.FileName())
+	encoded, err := io.ReadAll(att)
+	require.NoError(t, err)
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(encoded), "\r\n", ""))
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF-1.4 fake", string(decoded))
+}
@@ -0,0 +1,499 @@
+package builtin
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+)
+
+// Embedding providers.
+const (
+	EmbeddingProviderOpenAI = "openai"
+	EmbeddingProviderGemini = "gemini"
+)
+
+const (
+	embeddingDefaultTimeout = 60
+	// embeddingMaxTexts caps the texts embedded by one node; larger sets belong in chunks.
+	embeddingMaxTexts = 10000
+	// embeddingGeminiMaxBatch is the most texts Gemini accepts in one batchEmbedContents call.
+	embeddingGeminiMaxBatch = 100
+	embeddingOpenAIMaxBatch = 2048
+)
+
+// embeddingProviderDefaults are the default model and base URL of each provider.
+var embeddingProviderDefaults = map[string]struct{ model, baseURL string }{
+	EmbeddingProviderOpenAI: {"text-embedding-3-small", "https://api.openai.com/v1"},
+	EmbeddingProviderGemini: {"text-embedding-004", "https://generativelanguage.googleapis.com/v1beta"},
+}
+
+// embeddingRequest describes a call to an embeddings API.
+type embeddingRequest struct {
+	provider   string
+	apiKey     string
+	baseURL    string
+	model      string
+	dimensions int
+	taskType   string // Gemini only, e.g. RETRIEVAL_DOCUMENT or RETRIEVAL_QUERY
+	batchSize  int
+	timeout    time.Duration
+}
+
+// embeddingResult holds the vectors of a request, in input order.
+type embeddingResult struct {
+	vectors      [][]float64
+	promptTokens int
+}
+
+// EmbeddingExecutor turns texts into embedding vectors and optionally stores them in a
+// Postgres table with the pgvector extension, for retrieval with vector_search.
+type EmbeddingExecutor struct {
+	*executor.BaseExecutor
+	credentials CredentialResolver
+	client      *http.Client
+	openDB      func(conn pgvectorConnection) (*sql.DB, error)
+}
+
+// NewEmbeddingExecutor creates a new embedding executor.
+// credentials resolves the database credential of the store and may be nil, in which case
+// only servers without authentication can be used.
+func NewEmbeddingExecutor(credentials CredentialResolver) *EmbeddingExecutor {
+	return &EmbeddingExecutor{
+		BaseExecutor: executor.NewBaseExecutor("embedding"),
+		credentials:  credentials,
+		client:       &http.Client{},
+		openDB:       openPGVector,
+	}
+}
+
+// Execute embeds the texts and stores them when a store is configured.
+//
+// Config:
+//   - provider: "openai" (default; also any OpenAI-compatible API via base_url) | "gemini"
+//   - api_key: Provider API key (required)
+//   - model: Embedding model (default: "text-embedding-3-small" for openai, "text-embedding-004" for gemini)
+//   - base_url: API base URL (default: the provider's public API)
+//   - dimensions: Length of the vectors, for models that can shorten them
+//   - task_type: Gemini task type (default: "RETRIEVAL_DOCUMENT")
+//   - input: Text, array of texts, or array of documents (default: the input, or its
+//     documents, texts, text or content field)
+//   - text_field: Field holding the text of a document (default: "text")
+//   - id_field: Field holding the ID of a document (default: "id"); documents without an ID
+//     get one derived from their text
+//   - batch_size: Texts per API request (default: 100)
+//   - include_vectors: Return the vectors (default: true, false when storing)
+//   - timeout: Request timeout in seconds (default: 60)
+//   - store: pgvector table receiving the documents, with host, port, database,
+//     credential_id, sslmode, table, metric ("cosine" | "l2" | "inner_product") and
+//     create_table (default: true)
+//
+// Document fields other than the text and ID are stored as metadata, unless the
+// document has a metadata object.
+//
+// Output:
+//   - embeddings: Vectors in input order (when include_vectors is true)
+//   - documents: [{id, text, metadata}] in input order
+//   - count: Number of texts embedded
+//   - dimensions: Length of the vectors
+//   - provider, model: Embedding model used
+//   - usage: {prompt_tokens, total_tokens} as reported by the provider
+//   - stored: {table, count} when a store is configured
+//   - duration_ms: Execution duration
+func (e *EmbeddingExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	startTime := time.Now()
+
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+
+	docs, err := e.documents(config, input)
+	if err != nil {
+		return nil, err
+	}
+
+	req := newEmbeddingRequest(e.BaseExecutor, config, "RETRIEVAL_DOCUMENT", e.GetIntDefault(config, "batch_size", 100))
+	texts := make([]string, len(docs))
+	for i, doc := range docs {
+		texts[i] = doc.Content
+	}
+	result, err := embedTexts(ctx, e.client, req, texts)
+	if err != nil {
+		return nil, err
+	}
+
+	dimensions := len(result.vectors[0])
+	documents := make([]any, len(docs))
+	for i := range docs {
+		docs[i].Vector = result.vectors[i]
+		documents[i] = map[string]any{
+			"id":       docs[i].ID,
+			"text":     docs[i].Content,
+			"metadata": docs[i].Metadata,
+		}
+	}
+
+	output := map[string]any{
+		"documents":  documents,
+		"count":      len(docs),
+		"dimensions": dimensions,
+		"provider":   req.provider,
+		"model":      req.model,
+		"usage": map[string]any{
+			"prompt_tokens": result.promptTokens,
+			"total_tokens":  result.promptTokens,
+		},
+	}
+
+	store, storing := config["store"].(map[string]any)
+	if e.GetBoolDefault(config, "include_vectors", !storing) {
+		output["embeddings"] = result.vectors
+	}
+
+	if storing {
+		if err := e.store(ctx, store, docs, dimensions); err != nil {
+			return nil, err
+		}
+		output["stored"] = map[string]any{
+			"table": store["table"],
+			"count": len(docs),
+		}
+	}
+
+	output["duration_ms"] = time.Since(startTime).Milliseconds()
+	return output, nil
+}
+
+// Validate validates the embedding executor configuration.
+func (e *EmbeddingExecutor) Validate(config map[string]any) error {
+	if err := validateEmbeddingConfig(e.BaseExecutor, config, ""); err != nil {
+		return err
+	}
+	if batchSize := e.GetIntDefault(config, "batch_size", 100); batchSize < 1 || batchSize > embeddingOpenAIMaxBatch {
+		return fmt.Errorf("batch_size must be between 1 and %d", embeddingOpenAIMaxBatch)
+	}
+	if raw, ok := config["store"]; ok {
+		store, ok := raw.(map[string]any)
+		if !ok {
+			return fmt.Errorf("store must be an object")
+		}
+		if err := validatePGVectorConnection(store, "store."); err != nil {
+			return err
+		}
+		if metric := e.GetStringDefault(store, "metric", VectorMetricCosine); pgvectorOperators[metric].operator == "" {
+			return fmt.Errorf("invalid store.metric: %s (valid: cosine, l2, inner_product)", metric)
+		}
+	}
+	return nil
+}
+
+// documents collects the texts to embed from the config or the input.
+func (e *EmbeddingExecutor) documents(config map[string]any, input any) ([]pgvectorDocument, error) {
+	source, ok := config["input"]
+	if !ok || source == nil {
+		source = input
+		if m, ok := input.(map[string]any); ok {
+			source = nil
+			for _, key := range []string{"documents", "texts", "text", "content"} {
+				if v, ok := m[key]; ok && v != nil {
+					source = v
+					break
+				}
+			}
+		}
+	}
+
+	textField := e.GetStringDefault(config, "text_field", "text")
+	idField := e.GetStringDefault(config, "id_field", "id")
+
+	var items []any
+	switch v := source.(type) {
+	case string:
+		items = []any{v}
+	case []any:
+		items = v
+	case []string:
+		for _, s := range v {
+			items = append(items, s)
+		}
+	default:
+		return nil, fmt.Errorf("input must be a text, an array of texts or an array of documents (or an input with documents, texts, text or content)")
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no texts to embed")
+	}
+	if len(items) > embeddingMaxTexts {
+		return nil, fmt.Errorf("too many texts: %d (max %d per node; use sub_workflow chunk_size for larger sets)", len(items), embeddingMaxTexts)
+	}
+
+	docs := make([]pgvectorDocument, len(items))
+	for i, item := range items {
+		var doc pgvectorDocument
+		switch v := item.(type) {
+		case string:
+			doc.Content = v
+		case map[string]any:
+			text, ok := v[textField].(string)
+			if !ok {
+				return nil, fmt.Errorf("document %d has no %s text", i, textField)
+			}
+			doc.Content = text
+			if id, ok := v[idField]; ok && id != nil {
+				doc.ID = fmt.Sprint(id)
+			}
+			if metadata, ok := v["metadata"].(map[string]any); ok {
+				doc.Metadata = metadata
+			} else {
+				doc.Metadata = make(map[string]any)
+				for k, value := range v {
+					if k != textField && k != idField {
+						doc.Metadata[k] = value
+					}
+				}
+			}
+		default:
+			return nil, fmt.Errorf("item %d must be a text or a document object, got %T", i, item)
+		}
+		if strings.TrimSpace(doc.Content) == "" {
+			return nil, fmt.Errorf("item %d has an empty text", i)
+		}
+		if doc.ID == "" {
+			doc.ID = pgvectorDocumentID(doc.Content)
+		}
+		if doc.Metadata == nil {
+			doc.Metadata = make(map[string]any)
+		}
+		docs[i] = doc
+	}
+	return docs, nil
+}
+
+// store writes the documents to the pgvector table.
+func (e *EmbeddingExecutor) store(ctx context.Context, store map[string]any, docs []pgvectorDocument, dimensions int) error {
+	conn, err := parsePGVectorConnection(ctx, e.BaseExecutor, e.credentials, store)
+	if err != nil {
+		return err
+	}
+	db, err := e.openDB(conn)
+	if err != nil {
+		return fmt.Errorf("failed to open connection to %s: %w", conn.Addr, err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	storeCtx, cancel := context.WithTimeout(ctx, conn.Timeout)
+	defer cancel()
+
+	table := e.GetStringDefault(store, "table", "")
+	if e.GetBoolDefault(store, "create_table", true) {
+		if err := ensurePGVectorTable(storeCtx, db, table, dimensions, e.GetStringDefault(store, "metric", VectorMetricCosine)); err != nil {
+			return err
+		}
+	}
+	if err := upsertPGVectorDocuments(storeCtx, db, table, docs); err != nil {
+		return fmt.Errorf("failed to store embeddings in %s: %w", table, err)
+	}
+	return nil
+}
+
+// validateEmbeddingConfig checks the provider settings of an embedding config block.
+func validateEmbeddingConfig(base *executor.BaseExecutor, config map[string]any, prefix string) error {
+	provider := base.GetStringDefault(config, "provider", EmbeddingProviderOpenAI)
+	if _, ok := embeddingProviderDefaults[provider]; !ok {
+		return fmt.Errorf("invalid %sprovider: %s (valid: openai, gemini)", prefix, provider)
+	}
+	if base.GetStringDefault(config, "api_key", "") == "" {
+		return fmt.Errorf("%sapi_key is required", prefix)
+	}
+	if raw, ok := config["dimensions"]; ok {
+		if dimensions, ok := toFloat(raw); !ok || dimensions < 1 || dimensions > 16000 {
+			return fmt.Errorf("%sdimensions must be between 1 and 16000", prefix)
+		}
+	}
+	if base.GetIntDefault(config, "timeout", embeddingDefaultTimeout) < 1 {
+		return fmt.Errorf("%stimeout must be positive", prefix)
+	}
+	return nil
+}
+
+// newEmbeddingRequest builds an embeddings API request from the provider settings.
+func newEmbeddingRequest(base *executor.BaseExecutor, config map[string]any, taskType string, batchSize int) embeddingRequest {
+	provider := base.GetStringDefault(config, "provider", EmbeddingProviderOpenAI)
+	defaults := embeddingProviderDefaults[provider]
+	if provider == EmbeddingProviderGemini {
+		batchSize = min(batchSize, embeddingGeminiMaxBatch)
+	}
+	return embeddingRequest{
+		provider:   provider,
+		apiKey:     base.GetStringDefault(config, "api_key", ""),
+		baseURL:    strings.TrimRight(base.GetStringDefault(config, "base_url", defaults.baseURL), "/"),
+		model:      base.GetStringDefault(config, "model", defaults.model),
+		dimensions: base.GetIntDefault(config, "dimensions", 0),
+		taskType:   base.GetStringDefault(config, "task_type", taskType),
+		batchSize:  batchSize,
+		timeout:    time.Duration(base.GetIntDefault(config, "timeout", embeddingDefaultTimeout)) * time.Second,
+	}
+}
+
+// embedTexts calls the embeddings API in batches and returns one vector per text.
+func embedTexts(ctx context.Context, client *http.Client, req embeddingRequest, texts []string) (*embeddingResult, error) {
+	result := &embeddingResult{vectors: make([][]float64, 0, len(texts))}
+	for start := 0; start < len(texts); start += req.batchSize {
+		batch := texts[start:min(start+req.batchSize, len(texts))]
+
+		var (
+			vectors [][]float64
+			tokens  int
+			err     error
+		)
+		if req.provider == EmbeddingProviderGemini {
+			vectors, err = embedGemini(ctx, client, req, batch)
+		} else {
+			vectors, tokens, err = embedOpenAI(ctx, client, req, batch)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(vectors) != len(batch) {
+			return nil, fmt.Errorf("%s returned %d embeddings for %d texts", req.provider, len(vectors), len(batch))
+		}
+		result.vectors = append(result.vectors, vectors...)
+		result.promptTokens += tokens
+	}
+
+	dimensions := len(result.vectors[0])
+	for i, vector := range result.vectors {
+		if len(vector) == 0 || len(vector) != dimensions {
+			return nil, fmt.Errorf("%s returned an embedding of %d dimensions for text %d (expected %d)", req.provider, len(vector), i, dimensions)
+		}
+	}
+	return result, nil
+}
+
+// embedOpenAI calls the OpenAI embeddings endpoint, which compatible APIs also provide.
+func embedOpenAI(ctx context.Context, client *http.Client, req embeddingRequest, texts []string) ([][]float64, int, error) {
+	body := map[string]any{
+		"model":           req.model,
+		"input":           texts,
+		"encoding_format": "float",
+	}
+	if req.dimensions > 0 {
+		body["dimensions"] = req.dimensions
+	}
+
+	var resp struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float64 `json:"embedding"`
+		} `json:"data"`
+		Usage struct {
+			PromptTokens int `json:"prompt_tokens"`
+		} `json:"usage"`
+	}
+	headers := map[string]string{"Authorization": "Bearer " + req.apiKey}
+	if err := postEmbeddingJSON(ctx, client, req, req.baseURL+"/embeddings", headers, body, &resp); err != nil {
+		return nil, 0, err
+	}
+
+	vectors := make([][]float64, len(texts))
+	for _, item := range resp.Data {
+		if item.Index < 0 || item.Index >= len(vectors) {
+			return nil, 0, fmt.Errorf("openai returned an embedding for unknown index %d", item.Index)
+		}
+		vectors[item.Index] = item.Embedding
+	}
+	return vectors, resp.Usage.PromptTokens, nil
+}
+
+// embedGemini calls the Gemini batchEmbedContents endpoint.
+func embedGemini(ctx context.Context, client *http.Client, req embeddingRequest, texts []string) ([][]float64, error) {
+	model := req.model
+	if !strings.HasPrefix(model, "models/") {
+		model = "models/" + model
+	}
+	requests := make([]map[string]any, len(texts))
+	for i, text := range texts {
+		r := map[string]any{
+			"model":   model,
+			"content": map[string]any{"parts": []map[string]any{{"text": text}}},
+		}
+		if req.taskType != "" {
+			r["taskType"] = req.taskType
+		}
+		if req.dimensions > 0 {
+			r["outputDimensionality"] = req.dimensions
+		}
+		requests[i] = r
+	}
+
+	var resp struct {
+		Embeddings []struct {
+			Values []float64 `json:"values"`
+		} `json:"embeddings"`
+	}
+	headers := map[string]string{"x-goog-api-key": req.apiKey}
+	url := req.baseURL + "/" + model + ":batchEmbedContents"
+	if err := postEmbeddingJSON(ctx, client, req, url, headers, map[string]any{"requests": requests}, &resp); err != nil {
+		return nil, err
+	}
+
+	vectors := make([][]float64, len(resp.Embeddings))
+	for i, embedding := range resp.Embeddings {
+		vectors[i] = embedding.Values
+	}
+	return vectors, nil
+}
+
+// postEmbeddingJSON sends a JSON request and decodes the JSON response into out.
+func postEmbeddingJSON(ctx context.Context, client *http.Client, req embeddingRequest, url string, headers map[string]string, body, out any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s request: %w", req.provider, err)
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, req.timeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(reqCtx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", req.provider, err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("%s embeddings request failed: %w", req.provider, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", req.provider, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		// OpenAI and Gemini both report errors as {"error": {"message": ...}}
+		var errorResp struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(respBody, &errorResp); err == nil && errorResp.Error.Message != "" {
+			return fmt.Errorf("%s embeddings API error (status %d): %s", req.provider, resp.StatusCode, errorResp.Error.Message)
+		}
+		return fmt.Errorf("%s embeddings API error (status %d)", req.provider, resp.StatusCode)
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", req.provider, err)
+	}
+	return nil
+}
@@ -0,0 +1,278 @@
+package builtin
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testEmbedding is the vector the fake providers return for a text.
+func testEmbedding(text string) []float64 {
+	return []float64{float64(len(text)), 1, 0.5}
+}
+
+// newOpenAIEmbeddingServer fakes the OpenAI embeddings endpoint. It returns the data in
+// reverse order, as the API does not guarantee ordering, and records the batch sizes.
+func newOpenAIEmbeddingServer(t *testing.T, batches *[]int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/embeddings", r.URL.Path)
+		assert.Equal(t, "Bearer sk-test", r.Header.Get("Authorization"))
+
+		var body struct {
+			Model      string   `json:"model"`
+			Input      []string `json:"input"`
+			Dimensions int      `json:"dimensions"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if batches != nil {
+			*batches = append(*batches, len(body.Input))
+		}
+
+		data := make([]map[string]any, 0, len(body.Input))
+		for i := len(body.Input) - 1; i >= 0; i-- {
+			data = append(data, map[string]any{"index": i, "embedding": testEmbedding(body.Input[i])})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"model": body.Model,
+			"data":  data,
+			"usage": map[string]any{"prompt_tokens": 3 * len(body.Input), "total_tokens": 3 * len(body.Input)},
+		})
+	}))
+}
+
+func TestEmbeddingExecutor_OpenAI(t *testing.T) {
+	var batches []int
+	server := newOpenAIEmbeddingServer(t, &batches)
+	defer server.Close()
+
+	exec := NewEmbeddingExecutor(nil)
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"api_key":    "sk-test",
+		"base_url":   server.URL + "/v1",
+		"batch_size": 2,
+	}, map[string]any{"texts": []any{"alpha", "be", "gamma!"}})
+	require.NoError(t, err)
+
+	output := result.(map[string]any)
+	assert.Equal(t, []int{2, 1}, batches)
+	assert.Equal(t, 3, output["count"])
+	assert.Equal(t, 3, output["dimensions"])
+	assert.Equal(t, "openai", output["provider"])
+	assert.Equal(t, "text-embedding-3-small", output["model"])
+	assert.Equal(t, map[string]any{"prompt_tokens": 9, "total_tokens": 9}, output["usage"])
+	assert.Equal(t, [][]float64{testEmbedding("alpha"), testEmbedding("be"), testEmbedding("gamma!")}, output["embeddings"])
+	assert.Nil(t, output["stored"])
+
+	docs := output["documents"].([]any)
+	assert.Equal(t, "be", docs[1].(map[string]any)["text"])
+	assert.Equal(t, pgvectorDocumentID("be"), docs[1].(map[string]any)["id"])
+}
+
+func TestEmbeddingExecutor_Gemini(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/models/text-embedding-004:batchEmbedContents", r.URL.Path)
+		assert.Equal(t, "g-key", r.Header.Get("x-goog-api-key"))
+
+		var body struct {
+			Requests []struct {
+				Model   string `json:"model"`
+				Content struct {
+					Parts []struct {
+						Text string `json:"text"`
+					} `json:"parts"`
+				} `json:"content"`
+				TaskType             string `json:"taskType"`
+				OutputDimensionality int    `json:"outputDimensionality"`
+			} `json:"requests"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		embeddings := make([]map[string]any, len(body.Requests))
+		for i, req := range body.Requests {
+			assert.Equal(t, "models/text-embedding-004", req.Model)
+			assert.Equal(t, "RETRIEVAL_DOCUMENT", req.TaskType)
+			assert.Equal(t, 3, req.OutputDimensionality)
+			embeddings[i] = map[string]any{"values": testEmbedding(req.Content.Parts[0].Text)}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"embeddings": embeddings})
+	}))
+	defer server.Close()
+
+	result, err := NewEmbeddingExecutor(nil).Execute(context.Background(), map[string]any{
+		"provider":   "gemini",
+		"api_key":    "g-key",
+		"base_url":   server.URL,
+		"dimensions": 3,
+		"input":      "hello",
+	}, nil)
+	require.NoError(t, err)
+
+	output := result.(map[string]any)
+	assert.Equal(t, "gemini", output["provider"])
+	assert.Equal(t, [][]float64{testEmbedding("hello")}, output["embeddings"])
+}
+
+func TestEmbeddingExecutor_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error": {"message": "Incorrect API key provided"}}`))
+	}))
+	defer server.Close()
+
+	_, err := NewEmbeddingExecutor(nil).Execute(context.Background(), map[string]any{
+		"api_key":  "sk-bad",
+		"base_url": server.URL,
+		"input":    []any{"x"},
+	}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "openai embeddings API error (status 401): Incorrect API key provided")
+}
+
+func TestEmbeddingExecutor_Store(t *testing.T) {
+	server := newOpenAIEmbeddingServer(t, nil)
+	defer server.Close()
+
+	cred := models.NewCredentialsResource("owner-1", "pg", models.CredentialTypeBasicAuth)
+	cred.DecryptedData = map[string]string{"username": "rag", "password": "s3cret"}
+	credentials := &fakeCredentialResolver{creds: map[string]*models.CredentialsResource{"cred-1": cred}}
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	var conn pgvectorConnection
+	exec := NewEmbeddingExecutor(credentials)
+	exec.openDB = func(c pgvectorConnection) (*sql.DB, error) {
+		conn = c
+		return db, nil
+	}
+
+	mock.ExpectExec("CREATE EXTENSION IF NOT EXISTS vector").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS "rag"."chunks" \(.*embedding vector\(3\) NOT NULL`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE INDEX IF NOT EXISTS "rag_chunks_embedding_cosine_idx" ON "rag"."chunks" USING hnsw \(embedding vector_cosine_ops\)`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "rag"."chunks" \(id, content, metadata, embedding\) VALUES \(\$1, \$2, \$3::jsonb, \$4::vector\), \(\$5, \$6, \$7::jsonb, \$8::vector\)\s+ON CONFLICT \(id\) DO UPDATE`).
+		WithArgs(
+			"doc-1#0", "Refunds take 5 days", `{"page":3,"source":"handbook"}`, "[19,1,0.5]",
+			"doc-1#1", "Shipping is free", `{"source":"faq"}`, "[16,1,0.5]",
+		).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"api_key":  "sk-test",
+		"base_url": server.URL + "/v1",
+		"id_field": "chunk_id",
+		"store": map[string]any{
+			"host":          "pg.internal",
+			"database":      "knowledge",
+			"credential_id": "cred-1",
+			"sslmode":       "disable",
+			"table":         "rag.chunks",
+		},
+	}, map[string]any{"documents": []any{
+		map[string]any{"chunk_id": "doc-1#0", "text": "Refunds take 5 days", "source": "handbook", "page": 3},
+		map[string]any{"chunk_id": "doc-1#1", "text": "Shipping is free", "metadata": map[string]any{"source": "faq"}},
+	}})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	assert.Equal(t, "pg.internal:5432", conn.Addr)
+	assert.Equal(t, "knowledge", conn.Database)
+	assert.Equal(t, "rag", conn.User)
+	assert.Equal(t, "s3cret", conn.Password)
+	assert.Equal(t, "disable", conn.SSLMode)
+
+	output := result.(map[string]any)
+	assert.Equal(t, map[string]any{"table": "rag.chunks", "count": 2}, output["stored"])
+	assert.Nil(t, output["embeddings"], "vectors are not returned when storing unless asked for")
+	assert.Equal(t, "doc-1#0", output["documents"].([]any)[0].(map[string]any)["id"])
+}
+
+func TestEmbeddingExecutor_InvalidInput(t *testing.T) {
+	exec := NewEmbeddingExecutor(nil)
+	config := map[string]any{"api_key": "sk-test", "base_url": "http://127.0.0.1:0"}
+
+	tests := []struct {
+		name    string
+		input   any
+		wantErr string
+	}{
+		{"nothing", map[string]any{"other": 1}, "input must be a text"},
+		{"empty array", []any{}, "no texts to embed"},
+		{"blank text", []any{"ok", "  "}, "item 1 has an empty text"},
+		{"document without text", []any{map[string]any{"body": "x"}}, "document 0 has no text text"},
+		{"wrong item", []any{42}, "item 0 must be a text or a document object"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := exec.Execute(context.Background(), config, tt.input)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestEmbeddingExecutor_Validate(t *testing.T) {
+	exec := NewEmbeddingExecutor(nil)
+	store := func(overrides map[string]any) map[string]any {
+		s := map[string]any{"host": "pg", "table": "chunks"}
+		for k, v := range overrides {
+			s[k] = v
+		}
+		return map[string]any{"api_key": "k", "store": s}
+	}
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{"valid", map[string]any{"api_key": "k"}, ""},
+		{"valid store", store(nil), ""},
+		{"missing api_key", map[string]any{}, "api_key is required"},
+		{"invalid provider", map[string]any{"api_key": "k", "provider": "acme"}, "invalid provider"},
+		{"invalid dimensions", map[string]any{"api_key": "k", "dimensions": 0}, "dimensions must be"},
+		{"invalid batch_size", map[string]any{"api_key": "k", "batch_size": 5000}, "batch_size must be"},
+		{"store not object", map[string]any{"api_key": "k", "store": "pg"}, "store must be an object"},
+		{"store without host", store(map[string]any{"host": ""}), "store.host is required"},
+		{"store table injection", store(map[string]any{"table": "chunks; DROP TABLE users"}), "store.table must be a table name"},
+		{"store inline password", store(map[string]any{"password": "x"}), "store.password must not be set inline"},
+		{"store sslmode", store(map[string]any{"sslmode": "prefer"}), "invalid store.sslmode"},
+		{"store metric", store(map[string]any{"metric": "dot"}), "invalid store.metric"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exec.Validate(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestPGVectorLiteral(t *testing.T) {
+	vector := []float64{0.25, -1, 1e-7, 3}
+	literal := pgvectorLiteral(vector)
+	assert.Equal(t, "[0.25,-1,1e-07,3]", literal)
+
+	parsed, err := parsePGVectorLiteral(literal)
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, vector, parsed, 1e-9)
+
+	_, err = parsePGVectorLiteral("1,2")
+	assert.Error(t, err)
+	assert.True(t, strings.HasPrefix(pgvectorQuoteTable("public.docs"), `"public"."docs"`))
+}
@@ -117,8 +117,12 @@ func (e *FileStorageExecutor) executeStore(ctx context.Context, config map[strin
 			mimeType = filestorage.DetectMimeType(decoded[:min(512, len(decoded))])
 		}
 	} else if fileURL := e.GetStringDefault(config, "file_url", ""); fileURL != "" {
-		// Download from URL
-		resp, err := http.Get(fileURL)
+		// Download from URL, aborted if the execution is cancelled
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid file URL: %w", err)
+		}
+		resp, err := http.DefaultClient.Do(req)
 		if err != nil {
 			return nil, fmt.Errorf("failed to download file from URL: %w", err)
 		}
@@ -0,0 +1,523 @@
+package builtin
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/smilemakc/mbflow/go/internal/application/filestorage"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+)
+
+// grpcMaxDescriptorSetBytes limits descriptor sets loaded from file storage.
+const grpcMaxDescriptorSetBytes = 16 << 20
+
+// GRPCCallExecutor invokes unary gRPC methods without generated code.
+// Method descriptors come from server reflection or from a FileDescriptorSet
+// (protoc --include_imports --descriptor_set_out), either inline as base64 or
+// uploaded to file storage. The request is built from JSON and the response is
+// returned as JSON using the canonical protobuf JSON mapping.
+// Connections are pooled per target and TLS settings and reused across executions;
+// call Close to close them. Tokens are never part of the node config: authentication
+// uses a credentials resource referenced by ID.
+type GRPCCallExecutor struct {
+	*executor.BaseExecutor
+	credentials CredentialResolver
+	storage     filestorage.Manager
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+	// methods caches resolved method descriptors by descriptor source and method name.
+	methods map[string]protoreflect.MethodDescriptor
+}
+
+// NewGRPCCallExecutor creates a new gRPC call executor.
+// credentials may be nil, in which case only unauthenticated calls can be made;
+// storage may be nil, in which case descriptor sets can only be given inline.
+func NewGRPCCallExecutor(credentials CredentialResolver, storage filestorage.Manager) *GRPCCallExecutor {
+	return &GRPCCallExecutor{
+		BaseExecutor: executor.NewBaseExecutor("grpc_call"),
+		credentials:  credentials,
+		storage:      storage,
+		conns:        make(map[string]*grpc.ClientConn),
+		methods:      make(map[string]protoreflect.MethodDescriptor),
+	}
+}
+
+// Execute invokes a unary gRPC method.
+//
+// Config:
+//   - address: Target as host:port or any gRPC target URI, e.g. "dns:///orders.internal:443" (required)
+//   - method: Full method name, "package.Service/Method" or "package.Service.Method" (required)
+//   - request: Request message as a JSON object (default: empty message)
+//   - descriptor_set: Base64-encoded FileDescriptorSet; server reflection is used when neither
+//     descriptor_set nor descriptor_file_id is set
+//   - descriptor_file_id: ID of an uploaded FileDescriptorSet in file storage
+//   - storage_id: Storage holding descriptor_file_id (default: "default")
+//   - metadata: Request metadata as an object of strings
+//   - credential_id: ID of a credentials resource sent as the authorization metadata:
+//     api_key as "Bearer <key>", basic_auth as "Basic <base64>"; the credential must be
+//     attached to the workflow
+//   - tls: Use TLS (default: false)
+//   - tls_ca_cert: PEM-encoded CA certificate to verify the server with (default: system roots)
+//   - tls_server_name: Server name to verify, when it differs from the address
+//   - tls_insecure_skip_verify: Skip certificate verification (default: false)
+//   - timeout: Timeout in seconds (default: 30)
+//
+// Output:
+//   - response: Response message as JSON; fields with default values are included
+//   - headers: Response header metadata
+//   - trailers: Response trailer metadata
+//   - duration_ms: Execution duration
+func (e *GRPCCallExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	startTime := time.Now()
+
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+
+	timeout := time.Duration(e.GetIntDefault(config, "timeout", 30)) * time.Second
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	md, err := e.requestMetadata(callCtx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := e.conn(config)
+	if err != nil {
+		return nil, err
+	}
+
+	method, err := e.method(callCtx, conn, config)
+	if err != nil {
+		return nil, err
+	}
+	if method.IsStreamingClient() || method.IsStreamingServer() {
+		return nil, fmt.Errorf("method %s is streaming; only unary methods are supported", method.FullName())
+	}
+
+	request := dynamicpb.NewMessage(method.Input())
+	if raw, ok := config["request"]; ok && raw != nil {
+		data, err := json.Marshal(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid request: %w", err)
+		}
+		if err := protojson.Unmarshal(data, request); err != nil {
+			return nil, fmt.Errorf("request does not match %s: %w", method.Input().FullName(), err)
+		}
+	}
+
+	response := dynamicpb.NewMessage(method.Output())
+	var header, trailer metadata.MD
+	fullMethod := "/" + string(method.Parent().FullName()) + "/" + string(method.Name())
+	err = conn.Invoke(metadata.NewOutgoingContext(callCtx, md), fullMethod, request, response,
+		grpc.Header(&header), grpc.Trailer(&trailer))
+	if err != nil {
+		st := status.Convert(err)
+		return nil, fmt.Errorf("grpc call %s failed: %s: %s", fullMethod, st.Code(), st.Message())
+	}
+
+	data, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode response: %w", err)
+	}
+	var decoded any
+	if err := executor.UnmarshalJSON(ctx, data, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return map[string]any{
+		"response":    decoded,
+		"headers":     grpcMetadataToMap(header),
+		"trailers":    grpcMetadataToMap(trailer),
+		"duration_ms": time.Since(startTime).Milliseconds(),
+	}, nil
+}
+
+// Validate validates the gRPC call executor configuration.
+func (e *GRPCCallExecutor) Validate(config map[string]any) error {
+	if err := e.ValidateRequired(config, "address", "method"); err != nil {
+		return err
+	}
+
+	if _, _, err := splitGRPCMethod(e.GetStringDefault(config, "method", "")); err != nil {
+		return err
+	}
+
+	if config["descriptor_set"] != nil && config["descriptor_file_id"] != nil {
+		return fmt.Errorf("descriptor_set and descriptor_file_id are mutually exclusive")
+	}
+	if raw, ok := config["descriptor_set"].(string); ok {
+		if _, err := base64.StdEncoding.DecodeString(raw); err != nil {
+			return fmt.Errorf("descriptor_set must be base64-encoded: %w", err)
+		}
+	}
+
+	if raw, ok := config["request"]; ok && raw != nil {
+		if _, ok := raw.(map[string]any); !ok {
+			return fmt.Errorf("request must be an object")
+		}
+	}
+
+	if raw, ok := config["metadata"]; ok && raw != nil {
+		md, ok := raw.(map[string]any)
+		if !ok {
+			return fmt.Errorf("metadata must be an object")
+		}
+		for key, value := range md {
+			if strings.EqualFold(key, "authorization") {
+				return fmt.Errorf("authorization must not be set inline: store it in a credentials resource and reference it with credential_id")
+			}
+			if _, ok := value.(string); !ok {
+				return fmt.Errorf("metadata %s must be a string", key)
+			}
+		}
+	}
+
+	if timeout := e.GetIntDefault(config, "timeout", 30); timeout < 1 {
+		return fmt.Errorf("timeout must be at least 1 second")
+	}
+
+	return nil
+}
+
+// Close closes all pooled connections.
+func (e *GRPCCallExecutor) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var firstErr error
+	for key, conn := range e.conns {
+		if err := conn.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(e.conns, key)
+	}
+	for key := range e.methods {
+		delete(e.methods, key)
+	}
+	return firstErr
+}
+
+// conn returns the pooled connection for the target and TLS settings, creating it on first use.
+// Connections are established lazily by gRPC, so creating one does not block.
+func (e *GRPCCallExecutor) conn(config map[string]any) (*grpc.ClientConn, error) {
+	address := e.GetStringDefault(config, "address", "")
+	useTLS := e.GetBoolDefault(config, "tls", false)
+	caCert := e.GetStringDefault(config, "tls_ca_cert", "")
+	serverName := e.GetStringDefault(config, "tls_server_name", "")
+	skipVerify := e.GetBoolDefault(config, "tls_insecure_skip_verify", false)
+
+	key := grpcConnKey(address, useTLS, caCert, serverName, skipVerify)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if conn, ok := e.conns[key]; ok {
+		return conn, nil
+	}
+
+	transport := insecure.NewCredentials()
+	if useTLS {
+		tlsConfig := &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			ServerName:         serverName,
+			InsecureSkipVerify: skipVerify,
+		}
+		if caCert != "" {
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM([]byte(caCert)) {
+				return nil, fmt.Errorf("tls_ca_cert does not contain a valid PEM certificate")
+			}
+			tlsConfig.RootCAs = pool
+		}
+		transport = credentials.NewTLS(tlsConfig)
+	}
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(transport))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for %s: %w", address, err)
+	}
+	e.conns[key] = conn
+	return conn, nil
+}
+
+func grpcConnKey(address string, useTLS bool, caCert, serverName string, skipVerify bool) string {
+	caHash := sha256.Sum256([]byte(caCert))
+	return fmt.Sprintf("%s\x00%t\x00%x\x00%s\x00%t", address, useTLS, caHash, serverName, skipVerify)
+}
+
+// requestMetadata builds the outgoing metadata from the metadata config and the credential.
+func (e *GRPCCallExecutor) requestMetadata(ctx context.Context, config map[string]any) (metadata.MD, error) {
+	md := metadata.MD{}
+	if raw, ok := config["metadata"].(map[string]any); ok {
+		for key, value := range raw {
+			md.Append(strings.ToLower(key), value.(string))
+		}
+	}
+
+	credentialID := e.GetStringDefault(config, "credential_id", "")
+	if credentialID == "" {
+		return md, nil
+	}
+
+	authorization, err := resolveAuthorizationHeader(ctx, e.credentials, credentialID)
+	if err != nil {
+		return nil, err
+	}
+	md.Set("authorization", authorization)
+	return md, nil
+}
+
+// method resolves the method descriptor from the configured descriptor source.
+func (e *GRPCCallExecutor) method(ctx context.Context, conn *grpc.ClientConn, config map[string]any) (protoreflect.MethodDescriptor, error) {
+	service, name, _ := splitGRPCMethod(e.GetStringDefault(config, "method", ""))
+
+	var (
+		sourceKey string
+		load      func() (*protoregistry.Files, error)
+	)
+	switch {
+	case e.GetStringDefault(config, "descriptor_set", "") != "":
+		data, _ := base64.StdEncoding.DecodeString(e.GetStringDefault(config, "descriptor_set", ""))
+		sum := sha256.Sum256(data)
+		sourceKey = "set:" + hex.EncodeToString(sum[:])
+		load = func() (*protoregistry.Files, error) { return parseDescriptorSet(data) }
+	case e.GetStringDefault(config, "descriptor_file_id", "") != "":
+		storageID := e.GetStringDefault(config, "storage_id", "default")
+		fileID := e.GetStringDefault(config, "descriptor_file_id", "")
+		// Stored files are immutable, so the file ID identifies the descriptor set.
+		sourceKey = "file:" + storageID + "/" + fileID
+		load = func() (*protoregistry.Files, error) { return e.loadDescriptorFile(ctx, storageID, fileID) }
+	default:
+		sourceKey = "reflection:" + conn.Target()
+		load = func() (*protoregistry.Files, error) { return reflectServiceFiles(ctx, conn, service) }
+	}
+
+	cacheKey := sourceKey + "\x00" + service + "/" + name
+	e.mu.Lock()
+	cached, ok := e.methods[cacheKey]
+	e.mu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	files, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, fmt.Errorf("service %s not found: %w", service, err)
+	}
+	serviceDesc, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", service)
+	}
+	method := serviceDesc.Methods().ByName(protoreflect.Name(name))
+	if method == nil {
+		return nil, fmt.Errorf("method %s not found in service %s", name, service)
+	}
+
+	e.mu.Lock()
+	e.methods[cacheKey] = method
+	e.mu.Unlock()
+	return method, nil
+}
+
+// loadDescriptorFile reads a FileDescriptorSet from file storage.
+func (e *GRPCCallExecutor) loadDescriptorFile(ctx context.Context, storageID, fileID string) (*protoregistry.Files, error) {
+	if e.storage == nil {
+		return nil, fmt.Errorf("descriptor_file_id is set but file storage is not available")
+	}
+
+	storage, err := e.storage.GetStorage(storageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage: %w", err)
+	}
+	_, reader, err := storage.Get(ctx, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get descriptor file %s: %w", fileID, err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, grpcMaxDescriptorSetBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read descriptor file %s: %w", fileID, err)
+	}
+	if len(data) > grpcMaxDescriptorSetBytes {
+		return nil, fmt.Errorf("descriptor file %s exceeds %d bytes", fileID, grpcMaxDescriptorSetBytes)
+	}
+	return parseDescriptorSet(data)
+}
+
+// parseDescriptorSet builds a registry from a serialized FileDescriptorSet.
+// Imports missing from the set are taken from the well-known types linked into the binary.
+func parseDescriptorSet(data []byte) (*protoregistry.Files, error) {
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid descriptor set: %w", err)
+	}
+	return buildDescriptorFiles(set.GetFile(), nil)
+}
+
+// buildDescriptorFiles links file descriptors into a registry. Imports not in files are
+// fetched with fetch (may be nil) or taken from the global registry.
+func buildDescriptorFiles(files []*descriptorpb.FileDescriptorProto, fetch func(name string) ([]*descriptorpb.FileDescriptorProto, error)) (*protoregistry.Files, error) {
+	byName := make(map[string]*descriptorpb.FileDescriptorProto, len(files))
+	queue := make([]*descriptorpb.FileDescriptorProto, 0, len(files))
+	for _, fd := range files {
+		if _, ok := byName[fd.GetName()]; !ok {
+			byName[fd.GetName()] = fd
+			queue = append(queue, fd)
+		}
+	}
+
+	for len(queue) > 0 {
+		fd := queue[0]
+		queue = queue[1:]
+		for _, dep := range fd.GetDependency() {
+			if _, ok := byName[dep]; ok {
+				continue
+			}
+			if global, err := protoregistry.GlobalFiles.FindFileByPath(dep); err == nil {
+				byName[dep] = protodesc.ToFileDescriptorProto(global)
+				queue = append(queue, byName[dep])
+				continue
+			}
+			if fetch == nil {
+				return nil, fmt.Errorf("descriptor set is missing %s (imported by %s); build it with --include_imports", dep, fd.GetName())
+			}
+			fetched, err := fetch(dep)
+			if err != nil {
+				return nil, err
+			}
+			for _, f := range fetched {
+				if _, ok := byName[f.GetName()]; !ok {
+					byName[f.GetName()] = f
+					queue = append(queue, f)
+				}
+			}
+			if _, ok := byName[dep]; !ok {
+				return nil, fmt.Errorf("server reflection did not return %s", dep)
+			}
+		}
+	}
+
+	set := &descriptorpb.FileDescriptorSet{File: make([]*descriptorpb.FileDescriptorProto, 0, len(byName))}
+	for _, fd := range byName {
+		set.File = append(set.File, fd)
+	}
+	registry, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid descriptors: %w", err)
+	}
+	return registry, nil
+}
+
+// reflectServiceFiles loads the files defining service and their imports over the
+// gRPC server reflection protocol (grpc.reflection.v1).
+func reflectServiceFiles(ctx context.Context, conn *grpc.ClientConn, service string) (*protoregistry.Files, error) {
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("server reflection failed: %w", err)
+	}
+	defer stream.CloseSend()
+
+	request := func(req *reflectionpb.ServerReflectionRequest) ([]*descriptorpb.FileDescriptorProto, error) {
+		if err := stream.Send(req); err != nil {
+			return nil, fmt.Errorf("server reflection failed: %w", err)
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			return nil, fmt.Errorf("server reflection failed: %w", err)
+		}
+		if errResp := resp.GetErrorResponse(); errResp != nil {
+			return nil, fmt.Errorf("server reflection failed: %s", errResp.GetErrorMessage())
+		}
+
+		var files []*descriptorpb.FileDescriptorProto
+		for _, raw := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			fd := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(raw, fd); err != nil {
+				return nil, fmt.Errorf("server reflection returned an invalid descriptor: %w", err)
+			}
+			files = append(files, fd)
+		}
+		return files, nil
+	}
+
+	files, err := request(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return nil, fmt.Errorf("server does not support reflection; set descriptor_set or descriptor_file_id")
+		}
+		return nil, err
+	}
+
+	return buildDescriptorFiles(files, func(name string) ([]*descriptorpb.FileDescriptorProto, error) {
+		return request(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: name},
+		})
+	})
+}
+
+// splitGRPCMethod splits "package.Service/Method" (or "package.Service.Method",
+// with an optional leading slash) into the service and method names.
+func splitGRPCMethod(full string) (service, method string, err error) {
+	full = strings.TrimPrefix(full, "/")
+	sep := strings.LastIndex(full, "/")
+	if sep < 0 {
+		sep = strings.LastIndex(full, ".")
+	}
+	if sep <= 0 || sep == len(full)-1 {
+		return "", "", fmt.Errorf("method must be a full method name like package.Service/Method, got %q", full)
+	}
+	return full[:sep], full[sep+1:], nil
+}
+
+// grpcMetadataToMap converts metadata to a map of single values, or lists for repeated keys.
+func grpcMetadataToMap(md metadata.MD) map[string]any {
+	out := make(map[string]any, len(md))
+	for key, values := range md {
+		if len(values) == 1 {
+			out[key] = values[0]
+			continue
+		}
+		list := make([]any, len(values))
+		for i, v := range values {
+			list[i] = v
+		}
+		out[key] = list
+	}
+	return out
+}
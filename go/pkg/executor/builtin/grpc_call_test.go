@@ -0,0 +1,207 @@
+package builtin
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// fakeGRPCServer serves the standard health service and records request metadata.
+type fakeGRPCServer struct {
+	addr string
+
+	mu       sync.Mutex
+	metadata metadata.MD
+}
+
+func newFakeGRPCServer(t *testing.T, withReflection bool) *fakeGRPCServer {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &fakeGRPCServer{addr: lis.Addr().String()}
+	server := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		s.mu.Lock()
+		s.metadata = md
+		s.mu.Unlock()
+		grpc.SetHeader(ctx, metadata.Pairs("x-served-by", "fake"))
+		return handler(ctx, req)
+	}))
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("orders", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	if withReflection {
+		reflection.Register(server)
+	}
+
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return s
+}
+
+func (s *fakeGRPCServer) lastMetadata() metadata.MD {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.metadata
+}
+
+func healthDescriptorSet(t *testing.T) string {
+	t.Helper()
+	set := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{
+		protodesc.ToFileDescriptorProto(healthpb.File_grpc_health_v1_health_proto),
+	}}
+	data, err := proto.Marshal(set)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+func TestGRPCCallExecutor_Validate(t *testing.T) {
+	exec := NewGRPCCallExecutor(nil, nil)
+	base := func(extra map[string]any) map[string]any {
+		config := map[string]any{"address": "localhost:50051", "method": "grpc.health.v1.Health/Check"}
+		for k, v := range extra {
+			config[k] = v
+		}
+		return config
+	}
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{"valid", base(nil), ""},
+		{"dotted method", base(map[string]any{"method": "grpc.health.v1.Health.Check"}), ""},
+		{"missing address", map[string]any{"method": "a.B/C"}, "address"},
+		{"invalid method", base(map[string]any{"method": "Check"}), "full method name"},
+		{"both descriptor sources", base(map[string]any{"descriptor_set": "", "descriptor_file_id": "f"}), "mutually exclusive"},
+		{"invalid descriptor set", base(map[string]any{"descriptor_set": "not base64!"}), "base64"},
+		{"request not an object", base(map[string]any{"request": "x"}), "request must be an object"},
+		{"inline authorization", base(map[string]any{"metadata": map[string]any{"Authorization": "Bearer x"}}), "must not be set inline"},
+		{"non-string metadata", base(map[string]any{"metadata": map[string]any{"x-tenant": 1}}), "must be a string"},
+		{"invalid timeout", base(map[string]any{"timeout": 0}), "timeout"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exec.Validate(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestGRPCCallExecutor_Reflection(t *testing.T) {
+	server := newFakeGRPCServer(t, true)
+
+	cred := models.NewCredentialsResource("owner-1", "orders", models.CredentialTypeAPIKey)
+	cred.DecryptedData = map[string]string{"api_key": "tok"}
+	exec := NewGRPCCallExecutor(&fakeCredentialResolver{creds: map[string]*models.CredentialsResource{"cred-1": cred}}, nil)
+	t.Cleanup(func() { exec.Close() })
+
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"address":       server.addr,
+		"method":        "grpc.health.v1.Health/Check",
+		"request":       map[string]any{"service": "orders"},
+		"metadata":      map[string]any{"X-Tenant": "acme"},
+		"credential_id": "cred-1",
+	}, nil)
+	require.NoError(t, err)
+
+	output := result.(map[string]any)
+	assert.Equal(t, map[string]any{"status": "SERVING"}, output["response"])
+	assert.Equal(t, "fake", output["headers"].(map[string]any)["x-served-by"])
+
+	md := server.lastMetadata()
+	assert.Equal(t, []string{"acme"}, md.Get("x-tenant"))
+	assert.Equal(t, []string{"Bearer tok"}, md.Get("authorization"))
+
+	// Unknown services are reported by the server as a gRPC status
+	_, err = exec.Execute(context.Background(), map[string]any{
+		"address": server.addr,
+		"method":  "grpc.health.v1.Health/Check",
+		"request": map[string]any{"service": "unknown"},
+	}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "NotFound")
+}
+
+func TestGRPCCallExecutor_DescriptorSet(t *testing.T) {
+	server := newFakeGRPCServer(t, false)
+	exec := NewGRPCCallExecutor(nil, nil)
+	t.Cleanup(func() { exec.Close() })
+
+	config := map[string]any{
+		"address": server.addr,
+		"method":  "grpc.health.v1.Health.Check",
+		"request": map[string]any{"service": "orders"},
+	}
+
+	_, err := exec.Execute(context.Background(), config, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support reflection")
+
+	config["descriptor_set"] = healthDescriptorSet(t)
+	result, err := exec.Execute(context.Background(), config, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"status": "SERVING"}, result.(map[string]any)["response"])
+}
+
+func TestGRPCCallExecutor_Errors(t *testing.T) {
+	server := newFakeGRPCServer(t, true)
+	exec := NewGRPCCallExecutor(nil, nil)
+	t.Cleanup(func() { exec.Close() })
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{"unknown method", map[string]any{"method": "grpc.health.v1.Health/Nope"}, "method Nope not found"},
+		{"unknown service", map[string]any{"method": "acme.Orders/Get"}, "server reflection failed"},
+		{"streaming method", map[string]any{"method": "grpc.health.v1.Health/Watch"}, "only unary methods"},
+		{"request mismatch", map[string]any{
+			"method":  "grpc.health.v1.Health/Check",
+			"request": map[string]any{"unknown_field": 1},
+		}, "request does not match grpc.health.v1.HealthCheckRequest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config["address"] = server.addr
+			_, err := exec.Execute(context.Background(), tt.config, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSplitGRPCMethod(t *testing.T) {
+	for _, full := range []string{"grpc.health.v1.Health/Check", "/grpc.health.v1.Health/Check", "grpc.health.v1.Health.Check"} {
+		service, method, err := splitGRPCMethod(full)
+		require.NoError(t, err, full)
+		assert.Equal(t, "grpc.health.v1.Health", service)
+		assert.Equal(t, "Check", method)
+	}
+}
@@ -27,6 +27,9 @@ func NewHTMLCleanExecutor() *HTMLCleanExecutor {
 	}
 }
 
+// SideEffectFree reports true. Cleaning HTML has no side effects.
+func (e *HTMLCleanExecutor) SideEffectFree() bool { return true }
+
 // buildOutput creates a map[string]any output.
 // This is required because execution_manager.go only saves output if it's map[string]any.
 func buildOutput(textContent, htmlContent, title, author, excerpt, siteName string, length, wordCount int, isHTML, passthrough bool) map[string]any {
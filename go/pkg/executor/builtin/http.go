@@ -17,7 +17,8 @@ import (
 // HTTPExecutor executes HTTP requests.
 type HTTPExecutor struct {
 	*executor.BaseExecutor
-	client *http.Client
+	client  *http.Client
+	breaker *executor.CircuitBreaker
 }
 
 // NewHTTPExecutor creates a new HTTP executor.
@@ -30,6 +31,13 @@ func NewHTTPExecutor() *HTTPExecutor {
 	}
 }
 
+// SetCircuitBreaker sets the circuit breaker failing requests fast while their host keeps
+// failing. Connection errors, 5xx and 429 responses count as failures. A nil breaker
+// disables it. It must be set before executions start.
+func (e *HTTPExecutor) SetCircuitBreaker(breaker *executor.CircuitBreaker) {
+	e.breaker = breaker
+}
+
 // Execute executes an HTTP request.
 func (e *HTTPExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
 	// Get required fields
@@ -81,16 +89,24 @@ func (e *HTTPExecutor) Execute(ctx context.Context, config map[string]any, input
 	}
 
 	// Set default content type
+	executor.InjectHeaders(ctx, req.Header)
+
 	if req.Header.Get("Content-Type") == "" && body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	// Execute request
+	// Execute request, failing fast while the host's circuit breaker is open
+	host := req.URL.Host
+	if err := e.breaker.Allow(host); err != nil {
+		return nil, err
+	}
 	resp, err := e.client.Do(req)
 	if err != nil {
+		e.breaker.Record(host, ctx.Err() == nil)
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
+	e.breaker.Record(host, resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests)
 
 	// Read response
 	respBody, err := io.ReadAll(resp.Body)
@@ -146,7 +162,7 @@ func (e *HTTPExecutor) Execute(ctx context.Context, config map[string]any, input
 		// Parse response as JSON or string
 		var parsedBody any
 		if len(respBody) > 0 {
-			if err := json.Unmarshal(respBody, &parsedBody); err != nil {
+			if err := executor.UnmarshalJSON(ctx, respBody, &parsedBody); err != nil {
 				// If not JSON, return as string
 				parsedBody = string(respBody)
 			}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/smilemakc/mbflow/go/pkg/executor"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -185,6 +186,41 @@ func TestHTTPExecutor_BinaryResponse_JPEG(t *testing.T) {
 	assert.NotEmpty(t, resultMap["body_base64"])
 }
 
+func TestHTTPExecutor_PropagatesExecutionContext(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx := executor.WithExecutionContext(context.Background(), &executor.ExecutionContextData{
+		ExecutionID: "exec-1",
+		NodeID:      "fetch",
+		Propagation: executor.Propagation{
+			CorrelationID: "corr-1",
+			UserID:        "user-1",
+			Baggage:       map[string]string{"tenant": "acme"},
+		},
+	})
+
+	exec := NewHTTPExecutor()
+	_, err := exec.Execute(ctx, map[string]any{
+		"method":  "GET",
+		"url":     server.URL,
+		"headers": map[string]any{"X-Correlation-ID": "from-config"},
+	}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "from-config", received.Get(executor.HeaderCorrelationID))
+	assert.Equal(t, "exec-1", received.Get(executor.HeaderExecutionID))
+	assert.Equal(t, "fetch", received.Get(executor.HeaderNodeID))
+	assert.Equal(t, "tenant=acme", received.Get(executor.HeaderBaggage))
+	for name := range received {
+		assert.NotContains(t, received.Get(name), "user-1", "user IDs must not leave the engine")
+	}
+}
+
 // ============== Integration Tests with Public APIs ==============
 // These tests use real public APIs and may be skipped in CI
 
@@ -0,0 +1,602 @@
+package builtin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/image/bmp"
+	"golang.org/x/image/tiff"
+	_ "golang.org/x/image/webp" // register the WebP decoder
+
+	"github.com/smilemakc/mbflow/go/internal/application/filestorage"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+const (
+	// imageMaxFileBytes caps the size of an image read from storage.
+	imageMaxFileBytes = 50 << 20
+	// imageMaxPixels caps the decoded size of an image, which can be far larger than its file.
+	imageMaxPixels = 64 << 20
+	// imageMaxFiles caps the number of images processed by one node.
+	imageMaxFiles = 100
+
+	imageDefaultQuality = 85
+)
+
+// imageFormats maps output formats to their MIME type and file extension.
+var imageFormats = map[string]struct{ mimeType, ext string }{
+	"png":  {"image/png", ".png"},
+	"jpeg": {"image/jpeg", ".jpg"},
+	"gif":  {"image/gif", ".gif"},
+	"bmp":  {"image/bmp", ".bmp"},
+	"tiff": {"image/tiff", ".tiff"},
+}
+
+// ImageExecutor resizes, crops, converts and watermarks images in file storage.
+type ImageExecutor struct {
+	*executor.BaseExecutor
+	storage filestorage.Manager
+}
+
+// NewImageExecutor creates a new image executor.
+func NewImageExecutor(storage filestorage.Manager) *ImageExecutor {
+	return &ImageExecutor{
+		BaseExecutor: executor.NewBaseExecutor("image"),
+		storage:      storage,
+	}
+}
+
+// Execute applies the operations to each input image and stores the results as new files.
+//
+// Config:
+//   - operation: "resize" | "crop" | "convert" | "watermark", with the options of that operation
+//   - operations: Several operations applied in order, as [{operation, ...options}], instead of operation
+//   - file_id: ID of the image in file storage (default: the input, or its file_id field)
+//   - file_ids: Several images, processed independently (default: the input's file_ids or files)
+//   - storage_id: Storage holding the images and receiving the results (default: "default")
+//   - format: Output format: "png" | "jpeg" | "gif" | "bmp" | "tiff" (default: the source format; WebP becomes PNG)
+//   - quality: JPEG quality, 1-100 (default: 85)
+//   - file_name: Name of the result; the extension follows the format (default: the source name)
+//   - access_scope, ttl, tags: As for bytes_to_file
+//
+// Resize: width, height, scale, fit ("contain" | "cover" | "fill"), upscale, filter ("catmullrom" | "bilinear" | "nearest")
+// Crop: x, y, width, height; or width and height, or aspect_ratio ("16:9"), placed at gravity
+// Convert: format, quality, background (fills transparency for JPEG and BMP)
+// Watermark: text or image_file_id, position (gravity or "tile"), opacity, margin, font_size, color, bold, scale
+//
+// Gravity: "center", "top", "bottom", "left", "right", "top-left", "top-right", "bottom-left", "bottom-right".
+//
+// Output:
+//   - success: true
+//   - file_ids: IDs of the new files, in input order
+//   - files: [{file_id, storage_id, file_name, mime_type, size, width, height, format, source_file_id}]
+//   - file_id, file_name, mime_type, size, width, height, format: The new file, when one image was processed
+//   - duration_ms: Execution time
+func (e *ImageExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	startTime := time.Now()
+
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+	if e.storage == nil {
+		return nil, fmt.Errorf("file storage is not available")
+	}
+
+	fileIDs := e.fileIDs(config, input)
+	if len(fileIDs) == 0 {
+		return nil, fmt.Errorf("file_id is required (or an input with file_id or file_ids)")
+	}
+	if len(fileIDs) > imageMaxFiles {
+		return nil, fmt.Errorf("too many images: %d (max %d)", len(fileIDs), imageMaxFiles)
+	}
+
+	storage, err := e.storage.GetStorage(e.GetStringDefault(config, "storage_id", "default"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage: %w", err)
+	}
+
+	steps := imageSteps(config)
+	marks := make(map[string]image.Image)
+	for _, step := range steps {
+		if id, _ := step["image_file_id"].(string); id != "" && marks[id] == nil {
+			mark, _, _, err := loadImage(ctx, storage, id)
+			if err != nil {
+				return nil, fmt.Errorf("watermark image: %w", err)
+			}
+			marks[id] = mark
+		}
+	}
+
+	files := make([]any, 0, len(fileIDs))
+	ids := make([]string, 0, len(fileIDs))
+	for i, fileID := range fileIDs {
+		file, err := e.process(ctx, storage, config, steps, marks, fileID, i, len(fileIDs))
+		if err != nil {
+			if len(fileIDs) > 1 {
+				return nil, fmt.Errorf("image %s: %w", fileID, err)
+			}
+			return nil, err
+		}
+		files = append(files, file)
+		ids = append(ids, file["file_id"].(string))
+	}
+
+	result := map[string]any{
+		"success":     true,
+		"file_ids":    ids,
+		"files":       files,
+		"duration_ms": time.Since(startTime).Milliseconds(),
+	}
+	if len(files) == 1 {
+		for k, v := range files[0].(map[string]any) {
+			result[k] = v
+		}
+	}
+	return result, nil
+}
+
+// Validate validates the image executor configuration.
+func (e *ImageExecutor) Validate(config map[string]any) error {
+	if raw, ok := config["operations"]; ok {
+		operations, ok := raw.([]any)
+		if !ok || len(operations) == 0 {
+			return fmt.Errorf("operations must be a non-empty array of {operation, ...} objects")
+		}
+		for i, item := range operations {
+			step, ok := item.(map[string]any)
+			if !ok {
+				return fmt.Errorf("operations[%d] must be an object", i)
+			}
+			if err := e.validateStep(step); err != nil {
+				return fmt.Errorf("operations[%d]: %w", i, err)
+			}
+		}
+	} else if err := e.validateStep(config); err != nil {
+		return err
+	}
+
+	if format := e.GetStringDefault(config, "format", ""); format != "" && imageFormat(format) == "" {
+		return fmt.Errorf("invalid format: %s (valid: png, jpeg, gif, bmp, tiff)", format)
+	}
+	if quality := e.GetIntDefault(config, "quality", imageDefaultQuality); quality < 1 || quality > 100 {
+		return fmt.Errorf("quality must be between 1 and 100")
+	}
+	if accessScope := e.GetStringDefault(config, "access_scope", "workflow"); !models.AccessScope(accessScope).IsValid() {
+		return fmt.Errorf("invalid access_scope: %s (must be: workflow, edge, result)", accessScope)
+	}
+	if e.GetIntDefault(config, "ttl", 0) < 0 {
+		return fmt.Errorf("ttl must be >= 0")
+	}
+	for _, key := range []string{"tags", "file_ids"} {
+		if raw, ok := config[key]; ok {
+			if _, err := toStringSlice(raw, key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateStep validates the options of one operation.
+func (e *ImageExecutor) validateStep(step map[string]any) error {
+	dimension := func(key string) error {
+		if raw, ok := step[key]; ok {
+			if v, ok := toFloat(raw); !ok || v < 1 || v > imageMaxDimension || v != float64(int(v)) {
+				return fmt.Errorf("%s must be an integer between 1 and %d", key, imageMaxDimension)
+			}
+		}
+		return nil
+	}
+	gravity := func(key string, extra ...string) error {
+		value := e.GetStringDefault(step, key, "")
+		if _, ok := imageGravities[value]; value == "" || ok || (len(extra) > 0 && value == extra[0]) {
+			return nil
+		}
+		return fmt.Errorf("invalid %s: %s", key, value)
+	}
+
+	switch operation := e.GetStringDefault(step, "operation", ""); operation {
+	case imageOperationResize:
+		for _, key := range []string{"width", "height"} {
+			if err := dimension(key); err != nil {
+				return err
+			}
+		}
+		_, hasWidth := step["width"]
+		_, hasHeight := step["height"]
+		if raw, ok := step["scale"]; ok {
+			if scale, ok := toFloat(raw); !ok || scale <= 0 || scale > 10 {
+				return fmt.Errorf("scale must be a number greater than 0 and at most 10")
+			}
+		} else if !hasWidth && !hasHeight {
+			return fmt.Errorf("resize requires width, height or scale")
+		}
+		switch fit := e.GetStringDefault(step, "fit", "contain"); fit {
+		case "contain":
+		case "cover", "fill":
+			if !hasWidth || !hasHeight {
+				return fmt.Errorf("fit %s requires width and height", fit)
+			}
+		default:
+			return fmt.Errorf("invalid fit: %s (valid: contain, cover, fill)", fit)
+		}
+		if filter := e.GetStringDefault(step, "filter", "catmullrom"); imageFilters[filter] == nil {
+			return fmt.Errorf("invalid filter: %s (valid: catmullrom, bilinear, nearest)", filter)
+		}
+
+	case imageOperationCrop:
+		for _, key := range []string{"width", "height"} {
+			if err := dimension(key); err != nil {
+				return err
+			}
+		}
+		_, hasWidth := step["width"]
+		_, hasHeight := step["height"]
+		_, hasX := step["x"]
+		_, hasY := step["y"]
+		for _, key := range []string{"x", "y"} {
+			if raw, ok := step[key]; ok {
+				if v, ok := toFloat(raw); !ok || v < 0 || v != float64(int(v)) {
+					return fmt.Errorf("%s must be a non-negative integer", key)
+				}
+			}
+		}
+		if ratio := e.GetStringDefault(step, "aspect_ratio", ""); ratio != "" {
+			if _, err := parseAspectRatio(ratio); err != nil {
+				return err
+			}
+			if hasWidth || hasHeight || hasX || hasY {
+				return fmt.Errorf("aspect_ratio cannot be combined with x, y, width or height")
+			}
+		} else if !hasWidth || !hasHeight {
+			return fmt.Errorf("crop requires width and height, or aspect_ratio")
+		}
+		if err := gravity("gravity"); err != nil {
+			return err
+		}
+
+	case imageOperationConvert:
+		format, err := e.GetString(step, "format")
+		if err != nil {
+			return fmt.Errorf("convert requires format")
+		}
+		if imageFormat(format) == "" {
+			return fmt.Errorf("invalid format: %s (valid: png, jpeg, gif, bmp, tiff)", format)
+		}
+		if quality := e.GetIntDefault(step, "quality", imageDefaultQuality); quality < 1 || quality > 100 {
+			return fmt.Errorf("quality must be between 1 and 100")
+		}
+		if background := e.GetStringDefault(step, "background", ""); background != "" {
+			if _, err := parseImageColor(background); err != nil {
+				return err
+			}
+		}
+
+	case imageOperationWatermark:
+		text := e.GetStringDefault(step, "text", "")
+		markID := e.GetStringDefault(step, "image_file_id", "")
+		if (text == "") == (markID == "") {
+			return fmt.Errorf("watermark requires either text or image_file_id")
+		}
+		if err := gravity("position", "tile"); err != nil {
+			return err
+		}
+		if raw, ok := step["opacity"]; ok {
+			if opacity, ok := toFloat(raw); !ok || opacity < 0 || opacity > 1 {
+				return fmt.Errorf("opacity must be a number between 0 and 1")
+			}
+		}
+		if e.GetIntDefault(step, "margin", 0) < 0 {
+			return fmt.Errorf("margin must be >= 0")
+		}
+		if raw, ok := step["font_size"]; ok {
+			if size, ok := toFloat(raw); !ok || size <= 0 || size > 1000 {
+				return fmt.Errorf("font_size must be a number between 0 and 1000")
+			}
+		}
+		if raw, ok := step["scale"]; ok {
+			if scale, ok := toFloat(raw); !ok || scale <= 0 || scale > 1 {
+				return fmt.Errorf("scale must be a number greater than 0 and at most 1")
+			}
+		}
+		if c := e.GetStringDefault(step, "color", ""); c != "" {
+			if _, err := parseImageColor(c); err != nil {
+				return err
+			}
+		}
+
+	case "":
+		return fmt.Errorf("operation is required (resize, crop, convert, watermark)")
+	default:
+		return fmt.Errorf("invalid operation: %s (valid: resize, crop, convert, watermark)", operation)
+	}
+	return nil
+}
+
+// fileIDs returns the images to process from the config or the input.
+func (e *ImageExecutor) fileIDs(config map[string]any, input any) []string {
+	if id := e.GetStringDefault(config, "file_id", ""); id != "" {
+		return []string{id}
+	}
+	if raw, ok := config["file_ids"]; ok {
+		ids, _ := toStringSlice(raw, "file_ids")
+		return ids
+	}
+
+	var fromItems func(items []any) []string
+	fromItems = func(items []any) []string {
+		var ids []string
+		for _, item := range items {
+			switch v := item.(type) {
+			case string:
+				ids = append(ids, v)
+			case map[string]any:
+				if id, _ := v["file_id"].(string); id != "" {
+					ids = append(ids, id)
+				}
+			}
+		}
+		return ids
+	}
+
+	switch v := input.(type) {
+	case string:
+		if v != "" {
+			return []string{v}
+		}
+	case []any:
+		return fromItems(v)
+	case map[string]any:
+		if id, _ := v["file_id"].(string); id != "" {
+			return []string{id}
+		}
+		for _, key := range []string{"file_ids", "files"} {
+			switch items := v[key].(type) {
+			case []any:
+				return fromItems(items)
+			case []string:
+				return items
+			}
+		}
+	}
+	return nil
+}
+
+// process applies the steps to one image and stores the result.
+func (e *ImageExecutor) process(
+	ctx context.Context,
+	storage filestorage.Storage,
+	config map[string]any,
+	steps []map[string]any,
+	marks map[string]image.Image,
+	fileID string,
+	index, count int,
+) (map[string]any, error) {
+	img, sourceFormat, source, err := loadImage(ctx, storage, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	format := imageFormat(e.GetStringDefault(config, "format", sourceFormat))
+	if format == "" {
+		format = "png"
+	}
+	quality := e.GetIntDefault(config, "quality", imageDefaultQuality)
+	background := color.NRGBA{255, 255, 255, 255}
+
+	for _, step := range steps {
+		switch e.GetStringDefault(step, "operation", "") {
+		case imageOperationResize:
+			img = resizeImage(img, imageResizeOptions{
+				width:   e.GetIntDefault(step, "width", 0),
+				height:  e.GetIntDefault(step, "height", 0),
+				scale:   floatOption(step, "scale", 0),
+				fit:     e.GetStringDefault(step, "fit", "contain"),
+				upscale: e.GetBoolDefault(step, "upscale", false),
+				filter:  imageFilters[e.GetStringDefault(step, "filter", "catmullrom")],
+			})
+
+		case imageOperationCrop:
+			bounds := img.Bounds()
+			gravity := e.GetStringDefault(step, "gravity", "center")
+			var rect image.Rectangle
+			if ratio := e.GetStringDefault(step, "aspect_ratio", ""); ratio != "" {
+				value, _ := parseAspectRatio(ratio)
+				rect = aspectCropRect(bounds, value, gravity)
+			} else {
+				width, height := e.GetIntDefault(step, "width", 0), e.GetIntDefault(step, "height", 0)
+				_, hasX := step["x"]
+				_, hasY := step["y"]
+				if hasX || hasY {
+					x, y := e.GetIntDefault(step, "x", 0), e.GetIntDefault(step, "y", 0)
+					rect = image.Rect(x, y, x+width, y+height).Add(bounds.Min)
+				} else {
+					rect = imageGravityRect(bounds, min(width, bounds.Dx()), min(height, bounds.Dy()), gravity)
+				}
+			}
+			if rect.Intersect(bounds).Empty() {
+				return nil, fmt.Errorf("crop area is outside the %dx%d image", bounds.Dx(), bounds.Dy())
+			}
+			img = cropImage(img, rect)
+
+		case imageOperationConvert:
+			format = imageFormat(e.GetStringDefault(step, "format", format))
+			quality = e.GetIntDefault(step, "quality", quality)
+			if c := e.GetStringDefault(step, "background", ""); c != "" {
+				background, _ = parseImageColor(c)
+			}
+
+		case imageOperationWatermark:
+			textColor := color.NRGBA{255, 255, 255, 255}
+			if c := e.GetStringDefault(step, "color", ""); c != "" {
+				textColor, _ = parseImageColor(c)
+			}
+			img, err = watermarkImage(img, imageWatermarkOptions{
+				text:     e.GetStringDefault(step, "text", ""),
+				mark:     marks[e.GetStringDefault(step, "image_file_id", "")],
+				position: e.GetStringDefault(step, "position", "bottom-right"),
+				opacity:  floatOption(step, "opacity", 0.5),
+				margin:   e.GetIntDefault(step, "margin", 16),
+				fontSize: floatOption(step, "font_size", 0),
+				color:    textColor,
+				bold:     e.GetBoolDefault(step, "bold", true),
+				scale:    floatOption(step, "scale", 0.2),
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	data, err := encodeImage(img, format, quality, background)
+	if err != nil {
+		return nil, err
+	}
+
+	name := e.GetStringDefault(config, "file_name", source.Name)
+	name = strings.TrimSuffix(name, path.Ext(name))
+	if name == "" {
+		name = "image"
+	}
+	if count > 1 && e.GetStringDefault(config, "file_name", "") != "" {
+		name += "-" + strconv.Itoa(index+1)
+	}
+	var tags []string
+	if raw, ok := config["tags"]; ok {
+		tags, _ = toStringSlice(raw, "tags")
+	}
+	size := img.Bounds().Size()
+	entry := &models.FileEntry{
+		StorageID:   e.GetStringDefault(config, "storage_id", "default"),
+		Name:        name + imageFormats[format].ext,
+		MimeType:    imageFormats[format].mimeType,
+		Size:        int64(len(data)),
+		AccessScope: models.AccessScope(e.GetStringDefault(config, "access_scope", "workflow")),
+		Tags:        tags,
+		Metadata: map[string]any{
+			"source_file_id": fileID,
+			"width":          size.X,
+			"height":         size.Y,
+		},
+	}
+	if ttl := e.GetIntDefault(config, "ttl", 0); ttl > 0 {
+		entry.SetTTL(time.Duration(ttl) * time.Second)
+	}
+
+	stored, err := storage.Store(ctx, entry, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to store file: %w", err)
+	}
+
+	return map[string]any{
+		"file_id":        stored.ID,
+		"storage_id":     stored.StorageID,
+		"file_name":      stored.Name,
+		"mime_type":      stored.MimeType,
+		"size":           stored.Size,
+		"width":          size.X,
+		"height":         size.Y,
+		"format":         format,
+		"source_file_id": fileID,
+	}, nil
+}
+
+// imageSteps returns the operations to apply: the operations list, or the config itself.
+func imageSteps(config map[string]any) []map[string]any {
+	operations, ok := config["operations"].([]any)
+	if !ok {
+		return []map[string]any{config}
+	}
+	steps := make([]map[string]any, len(operations))
+	for i, item := range operations {
+		steps[i] = item.(map[string]any) // Checked by Validate
+	}
+	return steps
+}
+
+// loadImage reads and decodes an image from storage, rejecting files and images above the limits.
+func loadImage(ctx context.Context, storage filestorage.Storage, fileID string) (image.Image, string, *models.FileEntry, error) {
+	entry, reader, err := storage.Get(ctx, fileID)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, imageMaxFileBytes+1))
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to read file content: %w", err)
+	}
+	if len(data) > imageMaxFileBytes {
+		return nil, "", nil, fmt.Errorf("file exceeds %d bytes", imageMaxFileBytes)
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("file %s is not a supported image (png, jpeg, gif, bmp, tiff, webp): %w", fileID, err)
+	}
+	if cfg.Width*cfg.Height > imageMaxPixels {
+		return nil, "", nil, fmt.Errorf("image is %dx%d, above the limit of %d pixels", cfg.Width, cfg.Height, imageMaxPixels)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to decode %s image: %w", format, err)
+	}
+	return img, format, entry, nil
+}
+
+// encodeImage encodes img in format; formats without transparency are flattened onto background.
+func encodeImage(img image.Image, format string, quality int, background color.Color) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case "jpeg":
+		err = jpeg.Encode(&buf, flattenImage(img, background), &jpeg.Options{Quality: quality})
+	case "gif":
+		err = gif.Encode(&buf, img, &gif.Options{NumColors: 256})
+	case "bmp":
+		err = bmp.Encode(&buf, flattenImage(img, background))
+	case "tiff":
+		err = tiff.Encode(&buf, img, &tiff.Options{Compression: tiff.Deflate, Predictor: true})
+	default:
+		err = (&png.Encoder{CompressionLevel: png.DefaultCompression}).Encode(&buf, img)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s image: %w", format, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// imageFormat returns the canonical output format name, or "" when it cannot be written.
+func imageFormat(name string) string {
+	switch name = strings.ToLower(strings.TrimSpace(name)); name {
+	case "jpg":
+		return "jpeg"
+	case "tif":
+		return "tiff"
+	default:
+		if _, ok := imageFormats[name]; ok {
+			return name
+		}
+		return ""
+	}
+}
+
+// floatOption returns a numeric option, or def when it is missing or not a number.
+func floatOption(config map[string]any, key string, def float64) float64 {
+	if v, ok := toFloat(config[key]); ok {
+		return v
+	}
+	return def
+}
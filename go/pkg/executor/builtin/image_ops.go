@@ -0,0 +1,289 @@
+package builtin
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	stddraw "image/draw"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	imageOperationResize    = "resize"
+	imageOperationCrop      = "crop"
+	imageOperationConvert   = "convert"
+	imageOperationWatermark = "watermark"
+
+	// imageMaxDimension caps the width and height of processed images.
+	imageMaxDimension = 16384
+)
+
+// imageGravities are the anchors for crops and watermarks.
+var imageGravities = map[string][2]float64{
+	"center":       {0.5, 0.5},
+	"top":          {0.5, 0},
+	"bottom":       {0.5, 1},
+	"left":         {0, 0.5},
+	"right":        {1, 0.5},
+	"top-left":     {0, 0},
+	"top-right":    {1, 0},
+	"bottom-left":  {0, 1},
+	"bottom-right": {1, 1},
+}
+
+// imageFilters are the resampling filters for resize.
+var imageFilters = map[string]draw.Interpolator{
+	"catmullrom": draw.CatmullRom,
+	"bilinear":   draw.BiLinear,
+	"nearest":    draw.NearestNeighbor,
+}
+
+// imageResizeOptions describes a resize step.
+type imageResizeOptions struct {
+	width, height int
+	scale         float64
+	fit           string // "contain", "cover" or "fill"
+	upscale       bool
+	filter        draw.Interpolator
+}
+
+// resizeImage scales src to the requested size. With fit "contain" the image fits
+// inside width x height, with "cover" it fills the box and the overflow is cropped
+// from the center, and with "fill" it is stretched to exactly width x height.
+func resizeImage(src image.Image, opts imageResizeOptions) image.Image {
+	bounds := src.Bounds()
+	sw, sh := float64(bounds.Dx()), float64(bounds.Dy())
+
+	var dw, dh float64
+	switch {
+	case opts.scale > 0:
+		dw, dh = sw*opts.scale, sh*opts.scale
+	case opts.width > 0 && opts.height > 0 && opts.fit == "fill":
+		dw, dh = float64(opts.width), float64(opts.height)
+	case opts.width > 0 && opts.height > 0:
+		ratio := math.Min(float64(opts.width)/sw, float64(opts.height)/sh)
+		if opts.fit == "cover" {
+			ratio = math.Max(float64(opts.width)/sw, float64(opts.height)/sh)
+		}
+		dw, dh = sw*ratio, sh*ratio
+	case opts.width > 0:
+		dw, dh = float64(opts.width), sh*float64(opts.width)/sw
+	default:
+		dw, dh = sw*float64(opts.height)/sh, float64(opts.height)
+	}
+	if !opts.upscale && (dw > sw || dh > sh) {
+		ratio := math.Min(sw/dw, sh/dh)
+		dw, dh = dw*ratio, dh*ratio
+	}
+
+	width := min(max(1, int(math.Round(dw))), imageMaxDimension)
+	height := min(max(1, int(math.Round(dh))), imageMaxDimension)
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	opts.filter.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+
+	if opts.fit == "cover" && opts.width > 0 && opts.height > 0 && opts.scale == 0 {
+		cropWidth, cropHeight := min(opts.width, width), min(opts.height, height)
+		return cropImage(dst, imageGravityRect(dst.Bounds(), cropWidth, cropHeight, "center"))
+	}
+	return dst
+}
+
+// cropImage returns the part of src inside rect, clipped to its bounds.
+func cropImage(src image.Image, rect image.Rectangle) image.Image {
+	rect = rect.Intersect(src.Bounds())
+	dst := image.NewNRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	stddraw.Draw(dst, dst.Bounds(), src, rect.Min, stddraw.Src)
+	return dst
+}
+
+// imageGravityRect places a width x height rectangle inside bounds at gravity.
+func imageGravityRect(bounds image.Rectangle, width, height int, gravity string) image.Rectangle {
+	anchor, ok := imageGravities[gravity]
+	if !ok {
+		anchor = imageGravities["center"]
+	}
+	x := bounds.Min.X + int(math.Round(float64(bounds.Dx()-width)*anchor[0]))
+	y := bounds.Min.Y + int(math.Round(float64(bounds.Dy()-height)*anchor[1]))
+	return image.Rect(x, y, x+width, y+height)
+}
+
+// parseAspectRatio parses "16:9" or "1.5" into width / height.
+func parseAspectRatio(s string) (float64, error) {
+	if w, h, ok := strings.Cut(s, ":"); ok {
+		width, err1 := strconv.ParseFloat(strings.TrimSpace(w), 64)
+		height, err2 := strconv.ParseFloat(strings.TrimSpace(h), 64)
+		if err1 != nil || err2 != nil || width <= 0 || height <= 0 {
+			return 0, fmt.Errorf("invalid aspect_ratio %q (expected W:H, e.g. 16:9)", s)
+		}
+		return width / height, nil
+	}
+	ratio, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || ratio <= 0 {
+		return 0, fmt.Errorf("invalid aspect_ratio %q (expected W:H, e.g. 16:9)", s)
+	}
+	return ratio, nil
+}
+
+// aspectCropRect returns the largest rectangle of the given aspect ratio inside bounds, at gravity.
+func aspectCropRect(bounds image.Rectangle, ratio float64, gravity string) image.Rectangle {
+	width, height := bounds.Dx(), bounds.Dy()
+	if float64(width)/float64(height) > ratio {
+		width = max(1, int(math.Round(float64(height)*ratio)))
+	} else {
+		height = max(1, int(math.Round(float64(width)/ratio)))
+	}
+	return imageGravityRect(bounds, width, height, gravity)
+}
+
+// parseImageColor parses "#RGB", "#RRGGBB", "#RRGGBBAA", "white", "black" or "transparent".
+func parseImageColor(s string) (color.NRGBA, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "white":
+		return color.NRGBA{255, 255, 255, 255}, nil
+	case "black":
+		return color.NRGBA{0, 0, 0, 255}, nil
+	case "transparent":
+		return color.NRGBA{}, nil
+	}
+
+	hex := strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if len(hex) != 8 || err != nil {
+		return color.NRGBA{}, fmt.Errorf("invalid color %q (expected #RRGGBB or #RRGGBBAA)", s)
+	}
+	return color.NRGBA{R: uint8(value >> 24), G: uint8(value >> 16), B: uint8(value >> 8), A: uint8(value)}, nil
+}
+
+// flattenImage draws src over an opaque background, for formats without transparency.
+func flattenImage(src image.Image, background color.Color) image.Image {
+	dst := image.NewRGBA(image.Rect(0, 0, src.Bounds().Dx(), src.Bounds().Dy()))
+	stddraw.Draw(dst, dst.Bounds(), image.NewUniform(background), image.Point{}, stddraw.Src)
+	stddraw.Draw(dst, dst.Bounds(), src, src.Bounds().Min, stddraw.Over)
+	return dst
+}
+
+// imageWatermarkOptions describes a watermark step.
+type imageWatermarkOptions struct {
+	text     string
+	mark     image.Image // image watermark, instead of text
+	position string      // gravity or "tile"
+	opacity  float64
+	margin   int
+	fontSize float64 // in pixels; 0 scales with the image
+	color    color.NRGBA
+	bold     bool
+	scale    float64 // image watermark width relative to the image
+}
+
+// watermarkImage draws a text or image watermark over src.
+func watermarkImage(src image.Image, opts imageWatermarkOptions) (image.Image, error) {
+	bounds := src.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	stddraw.Draw(dst, dst.Bounds(), src, bounds.Min, stddraw.Src)
+
+	var mark image.Image
+	if opts.mark != nil {
+		markBounds := opts.mark.Bounds()
+		width := max(1, int(float64(dst.Bounds().Dx())*opts.scale))
+		height := max(1, int(float64(width)*float64(markBounds.Dy())/float64(markBounds.Dx())))
+		scaled := image.NewNRGBA(image.Rect(0, 0, width, height))
+		draw.CatmullRom.Scale(scaled, scaled.Bounds(), opts.mark, markBounds, draw.Src, nil)
+		mark = scaled
+	} else {
+		size := opts.fontSize
+		if size == 0 {
+			size = max(12, float64(min(dst.Bounds().Dx(), dst.Bounds().Dy()))/20)
+		}
+		text, err := renderWatermarkText(opts.text, size, opts.bold, opts.color)
+		if err != nil {
+			return nil, err
+		}
+		mark = text
+	}
+
+	alpha := image.NewUniform(color.Alpha{A: uint8(math.Round(opts.opacity * 255))})
+	markSize := mark.Bounds().Size()
+	if opts.position == "tile" {
+		stepX, stepY := markSize.X+max(opts.margin, markSize.X/2), markSize.Y+max(opts.margin, markSize.Y*2)
+		for row, y := 0, opts.margin; y < dst.Bounds().Dy(); row, y = row+1, y+stepY {
+			// Offset every other row so the tiles do not line up in columns
+			for x := opts.margin - (row%2)*stepX/2; x < dst.Bounds().Dx(); x += stepX {
+				rect := image.Rectangle{Min: image.Pt(x, y), Max: image.Pt(x, y).Add(markSize)}
+				stddraw.DrawMask(dst, rect, mark, mark.Bounds().Min, alpha, image.Point{}, stddraw.Over)
+			}
+		}
+		return dst, nil
+	}
+
+	area := dst.Bounds().Inset(opts.margin)
+	if area.Empty() {
+		area = dst.Bounds()
+	}
+	rect := imageGravityRect(area, markSize.X, markSize.Y, opts.position)
+	stddraw.DrawMask(dst, rect, mark, mark.Bounds().Min, alpha, image.Point{}, stddraw.Over)
+	return dst, nil
+}
+
+var (
+	watermarkFontsOnce sync.Once
+	watermarkFonts     [2]*opentype.Font
+	watermarkFontsErr  error
+)
+
+// renderWatermarkText draws text in the Go font on a transparent image just large enough to hold it.
+func renderWatermarkText(text string, size float64, bold bool, textColor color.NRGBA) (image.Image, error) {
+	watermarkFontsOnce.Do(func() {
+		if watermarkFonts[0], watermarkFontsErr = opentype.Parse(goregular.TTF); watermarkFontsErr == nil {
+			watermarkFonts[1], watermarkFontsErr = opentype.Parse(gobold.TTF)
+		}
+	})
+	if watermarkFontsErr != nil {
+		return nil, fmt.Errorf("failed to load watermark font: %w", watermarkFontsErr)
+	}
+
+	parsed := watermarkFonts[0]
+	if bold {
+		parsed = watermarkFonts[1]
+	}
+	face, err := opentype.NewFace(parsed, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create watermark font face: %w", err)
+	}
+	defer face.Close()
+
+	lines := strings.Split(text, "\n")
+	metrics := face.Metrics()
+	lineHeight := metrics.Height.Ceil()
+	width := 1
+	for _, line := range lines {
+		width = max(width, font.MeasureString(face, line).Ceil())
+	}
+	height := lineHeight * len(lines)
+	if width > imageMaxDimension || height > imageMaxDimension {
+		return nil, fmt.Errorf("watermark text is too large")
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	drawer := &font.Drawer{Dst: dst, Src: image.NewUniform(textColor), Face: face}
+	for i, line := range lines {
+		drawer.Dot = fixed.Point26_6{X: 0, Y: fixed.I(i*lineHeight) + metrics.Ascent}
+		drawer.DrawString(line)
+	}
+	return dst, nil
+}
@@ -0,0 +1,381 @@
+package builtin
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// storeTestImage encodes a width x height image split into a red left half and a blue
+// right half, and stores it under name.
+func storeTestImage(t *testing.T, manager *mockManager, name string, width, height int, opaque bool) string {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	alpha := uint8(255)
+	if !opaque {
+		alpha = 0
+	}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := color.NRGBA{255, 0, 0, alpha}
+			if x >= width/2 {
+				c = color.NRGBA{0, 0, 255, alpha}
+			}
+			img.Set(x, y, c)
+		}
+	}
+
+	var buf bytes.Buffer
+	if name[len(name)-4:] == ".jpg" {
+		require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}))
+	} else {
+		require.NoError(t, png.Encode(&buf, img))
+	}
+
+	storage, err := manager.GetStorage("default")
+	require.NoError(t, err)
+	entry, err := storage.Store(context.Background(), &models.FileEntry{Name: name, AccessScope: models.ScopeWorkflow}, &buf)
+	require.NoError(t, err)
+	return entry.ID
+}
+
+// loadTestImage decodes a stored file and returns the image and its format.
+func loadTestImage(t *testing.T, manager *mockManager, fileID string) (image.Image, string) {
+	t.Helper()
+	storage, err := manager.GetStorage("default")
+	require.NoError(t, err)
+	_, reader, err := storage.Get(context.Background(), fileID)
+	require.NoError(t, err)
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	img, format, err := image.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	return img, format
+}
+
+func TestImageExecutor_Resize(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        map[string]any
+		width, height int
+	}{
+		{"width keeps aspect", map[string]any{"width": 100}, 100, 50},
+		{"height keeps aspect", map[string]any{"height": 25}, 50, 25},
+		{"scale", map[string]any{"scale": 0.5}, 100, 50},
+		{"contain", map[string]any{"width": 100, "height": 100}, 100, 50},
+		{"cover", map[string]any{"width": 100, "height": 100, "fit": "cover"}, 100, 100},
+		{"fill", map[string]any{"width": 60, "height": 60, "fit": "fill"}, 60, 60},
+		{"no upscale", map[string]any{"width": 400}, 200, 100},
+		{"upscale", map[string]any{"width": 400, "upscale": true}, 400, 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := newMockManager()
+			fileID := storeTestImage(t, manager, "photo.png", 200, 100, true)
+
+			config := map[string]any{"operation": "resize", "file_id": fileID, "file_name": "thumb"}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			result, err := NewImageExecutor(manager).Execute(context.Background(), config, nil)
+			require.NoError(t, err)
+
+			output := result.(map[string]any)
+			assert.Equal(t, true, output["success"])
+			assert.Equal(t, "thumb.png", output["file_name"])
+			assert.Equal(t, "image/png", output["mime_type"])
+			assert.Equal(t, tt.width, output["width"])
+			assert.Equal(t, tt.height, output["height"])
+			assert.Equal(t, fileID, output["source_file_id"])
+			assert.Equal(t, []string{output["file_id"].(string)}, output["file_ids"])
+
+			img, format := loadTestImage(t, manager, output["file_id"].(string))
+			assert.Equal(t, "png", format)
+			assert.Equal(t, image.Pt(tt.width, tt.height), img.Bounds().Size())
+		})
+	}
+}
+
+func TestImageExecutor_Crop(t *testing.T) {
+	manager := newMockManager()
+	fileID := storeTestImage(t, manager, "photo.png", 200, 100, true)
+	exec := NewImageExecutor(manager)
+
+	red, blue := color.NRGBA{255, 0, 0, 255}, color.NRGBA{0, 0, 255, 255}
+	tests := []struct {
+		name          string
+		config        map[string]any
+		width, height int
+		corner        color.NRGBA
+	}{
+		{"explicit area", map[string]any{"x": 120, "y": 10, "width": 50, "height": 40}, 50, 40, blue},
+		{"gravity", map[string]any{"width": 80, "height": 80, "gravity": "left"}, 80, 80, red},
+		{"aspect ratio", map[string]any{"aspect_ratio": "1:1", "gravity": "right"}, 100, 100, blue},
+		{"clipped to image", map[string]any{"x": 150, "y": 50, "width": 100, "height": 100}, 50, 50, blue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]any{"operation": "crop", "file_id": fileID, "file_name": "crop-" + tt.name}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			result, err := exec.Execute(context.Background(), config, nil)
+			require.NoError(t, err)
+
+			img, _ := loadTestImage(t, manager, result.(map[string]any)["file_id"].(string))
+			assert.Equal(t, image.Pt(tt.width, tt.height), img.Bounds().Size())
+			assert.Equal(t, tt.corner, color.NRGBAModel.Convert(img.At(0, 0)))
+		})
+	}
+
+	_, err := exec.Execute(context.Background(), map[string]any{
+		"operation": "crop", "file_id": fileID, "x": 500, "y": 0, "width": 10, "height": 10,
+	}, nil)
+	assert.ErrorContains(t, err, "outside the 200x100 image")
+}
+
+func TestImageExecutor_Convert(t *testing.T) {
+	manager := newMockManager()
+	fileID := storeTestImage(t, manager, "overlay.png", 40, 20, false)
+
+	result, err := NewImageExecutor(manager).Execute(context.Background(), map[string]any{
+		"operation":  "convert",
+		"format":     "jpg",
+		"quality":    90,
+		"background": "#00ff00",
+	}, map[string]any{"file_id": fileID})
+	require.NoError(t, err)
+
+	output := result.(map[string]any)
+	assert.Equal(t, "overlay.jpg", output["file_name"])
+	assert.Equal(t, "image/jpeg", output["mime_type"])
+	assert.Equal(t, "jpeg", output["format"])
+
+	// Transparent pixels are flattened onto the background
+	img, format := loadTestImage(t, manager, output["file_id"].(string))
+	assert.Equal(t, "jpeg", format)
+	r, g, b, _ := img.At(10, 10).RGBA()
+	assert.Less(t, r>>8, uint32(30))
+	assert.Greater(t, g>>8, uint32(225))
+	assert.Less(t, b>>8, uint32(30))
+}
+
+func TestImageExecutor_Watermark(t *testing.T) {
+	manager := newMockManager()
+	fileID := storeTestImage(t, manager, "photo.jpg", 400, 200, true)
+	logoID := storeTestImage(t, manager, "logo.png", 40, 40, true)
+	exec := NewImageExecutor(manager)
+
+	t.Run("text", func(t *testing.T) {
+		result, err := exec.Execute(context.Background(), map[string]any{
+			"operation": "watermark",
+			"file_id":   fileID,
+			"file_name": "text-mark",
+			"text":      "CONFIDENTIAL",
+			"color":     "#ffffff",
+			"opacity":   1,
+			"position":  "center",
+		}, nil)
+		require.NoError(t, err)
+
+		output := result.(map[string]any)
+		assert.Equal(t, "text-mark.jpg", output["file_name"])
+		img, _ := loadTestImage(t, manager, output["file_id"].(string))
+		assert.Equal(t, image.Pt(400, 200), img.Bounds().Size())
+		assert.True(t, hasWhitePixel(img, image.Rect(100, 80, 300, 120)), "text drawn in the center")
+		assert.False(t, hasWhitePixel(img, image.Rect(0, 0, 400, 40)), "nothing drawn at the top")
+	})
+
+	t.Run("image", func(t *testing.T) {
+		result, err := exec.Execute(context.Background(), map[string]any{
+			"operation":     "watermark",
+			"file_id":       fileID,
+			"file_name":     "logo-mark",
+			"format":        "png",
+			"image_file_id": logoID,
+			"position":      "top-left",
+			"margin":        0,
+			"opacity":       1,
+			"scale":         0.25,
+		}, nil)
+		require.NoError(t, err)
+
+		// The 100x100 logo covers the top-left corner of the red half: red on its left, blue on its right
+		img, _ := loadTestImage(t, manager, result.(map[string]any)["file_id"].(string))
+		assert.Equal(t, color.NRGBA{255, 0, 0, 255}, color.NRGBAModel.Convert(img.At(10, 10)))
+		assert.Equal(t, color.NRGBA{0, 0, 255, 255}, color.NRGBAModel.Convert(img.At(90, 90)))
+		r, _, b, _ := img.At(150, 10).RGBA()
+		assert.Greater(t, r>>8, uint32(225))
+		assert.Less(t, b>>8, uint32(30))
+	})
+
+	t.Run("missing watermark image", func(t *testing.T) {
+		_, err := exec.Execute(context.Background(), map[string]any{
+			"operation": "watermark", "file_id": fileID, "image_file_id": "missing",
+		}, nil)
+		assert.ErrorContains(t, err, "watermark image")
+	})
+}
+
+func TestImageExecutor_Pipeline(t *testing.T) {
+	manager := newMockManager()
+	first := storeTestImage(t, manager, "first.png", 300, 200, true)
+	second := storeTestImage(t, manager, "second.jpg", 200, 300, true)
+
+	result, err := NewImageExecutor(manager).Execute(context.Background(), map[string]any{
+		"operations": []any{
+			map[string]any{"operation": "crop", "aspect_ratio": "1:1"},
+			map[string]any{"operation": "resize", "width": 64},
+			map[string]any{"operation": "watermark", "text": "mb"},
+			map[string]any{"operation": "convert", "format": "jpeg"},
+		},
+		"tags": []any{"thumbnail"},
+		"ttl":  3600,
+	}, map[string]any{"files": []any{
+		map[string]any{"file_id": first},
+		map[string]any{"file_id": second},
+	}})
+	require.NoError(t, err)
+
+	output := result.(map[string]any)
+	files := output["files"].([]any)
+	require.Len(t, files, 2)
+	assert.Nil(t, output["file_id"])
+	assert.Len(t, output["file_ids"], 2)
+
+	storage, err := manager.GetStorage("default")
+	require.NoError(t, err)
+	for i, name := range []string{"first.jpg", "second.jpg"} {
+		file := files[i].(map[string]any)
+		assert.Equal(t, name, file["file_name"])
+		assert.Equal(t, 64, file["width"])
+		assert.Equal(t, 64, file["height"])
+		assert.Equal(t, []any{first, second}[i], file["source_file_id"])
+
+		entry, err := storage.GetMetadata(context.Background(), file["file_id"].(string))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"thumbnail"}, entry.Tags)
+		assert.NotNil(t, entry.ExpiresAt)
+		assert.Equal(t, []any{first, second}[i], entry.Metadata["source_file_id"])
+	}
+}
+
+func TestImageExecutor_Errors(t *testing.T) {
+	manager := newMockManager()
+	exec := NewImageExecutor(manager)
+
+	storage, err := manager.GetStorage("default")
+	require.NoError(t, err)
+	text, err := storage.Store(context.Background(), &models.FileEntry{Name: "notes.txt"}, bytes.NewReader([]byte("hello")))
+	require.NoError(t, err)
+
+	_, err = exec.Execute(context.Background(), map[string]any{"operation": "resize", "width": 10}, nil)
+	assert.ErrorContains(t, err, "file_id is required")
+
+	_, err = exec.Execute(context.Background(), map[string]any{"operation": "resize", "width": 10, "file_id": "missing"}, nil)
+	assert.ErrorContains(t, err, "failed to read file")
+
+	_, err = exec.Execute(context.Background(), map[string]any{"operation": "resize", "width": 10, "file_id": text.ID}, nil)
+	assert.ErrorContains(t, err, "not a supported image")
+
+	_, err = NewImageExecutor(nil).Execute(context.Background(), map[string]any{"operation": "resize", "width": 10}, "id")
+	assert.ErrorContains(t, err, "file storage is not available")
+}
+
+func TestImageExecutor_Validate(t *testing.T) {
+	exec := NewImageExecutor(newMockManager())
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{"resize", map[string]any{"operation": "resize", "width": 100}, ""},
+		{"crop", map[string]any{"operation": "crop", "aspect_ratio": "16:9", "gravity": "top"}, ""},
+		{"convert", map[string]any{"operation": "convert", "format": "webp"}, "invalid format"},
+		{"watermark", map[string]any{"operation": "watermark", "text": "x", "position": "tile"}, ""},
+		{"pipeline", map[string]any{"operations": []any{
+			map[string]any{"operation": "resize", "scale": 2},
+			map[string]any{"operation": "convert", "format": "tif"},
+		}}, ""},
+		{"missing operation", map[string]any{}, "operation is required"},
+		{"unknown operation", map[string]any{"operation": "rotate"}, "invalid operation: rotate"},
+		{"empty operations", map[string]any{"operations": []any{}}, "non-empty array"},
+		{"bad step", map[string]any{"operations": []any{map[string]any{"operation": "resize"}}}, "operations[0]: resize requires width"},
+		{"resize size", map[string]any{"operation": "resize", "width": 0}, "width must be an integer"},
+		{"resize scale", map[string]any{"operation": "resize", "scale": 20}, "scale must be"},
+		{"resize cover", map[string]any{"operation": "resize", "width": 10, "fit": "cover"}, "fit cover requires width and height"},
+		{"resize filter", map[string]any{"operation": "resize", "width": 10, "filter": "lanczos"}, "invalid filter"},
+		{"crop size", map[string]any{"operation": "crop", "x": 10}, "crop requires width and height"},
+		{"crop ratio", map[string]any{"operation": "crop", "aspect_ratio": "wide"}, "invalid aspect_ratio"},
+		{"crop ratio with area", map[string]any{"operation": "crop", "aspect_ratio": "1:1", "width": 10}, "cannot be combined"},
+		{"crop gravity", map[string]any{"operation": "crop", "width": 10, "height": 10, "gravity": "middle"}, "invalid gravity"},
+		{"crop offset", map[string]any{"operation": "crop", "x": -1, "width": 10, "height": 10}, "x must be a non-negative integer"},
+		{"convert format", map[string]any{"operation": "convert"}, "convert requires format"},
+		{"convert background", map[string]any{"operation": "convert", "format": "jpeg", "background": "#zzz"}, "invalid color"},
+		{"watermark source", map[string]any{"operation": "watermark"}, "either text or image_file_id"},
+		{"watermark both", map[string]any{"operation": "watermark", "text": "x", "image_file_id": "y"}, "either text or image_file_id"},
+		{"watermark opacity", map[string]any{"operation": "watermark", "text": "x", "opacity": 2}, "opacity must be"},
+		{"watermark position", map[string]any{"operation": "watermark", "text": "x", "position": "middle"}, "invalid position"},
+		{"watermark scale", map[string]any{"operation": "watermark", "image_file_id": "y", "scale": 1.5}, "scale must be"},
+		{"output format", map[string]any{"operation": "resize", "width": 10, "format": "svg"}, "invalid format"},
+		{"quality", map[string]any{"operation": "resize", "width": 10, "quality": 0}, "quality must be"},
+		{"access scope", map[string]any{"operation": "resize", "width": 10, "access_scope": "public"}, "invalid access_scope"},
+		{"ttl", map[string]any{"operation": "resize", "width": 10, "ttl": -1}, "ttl must be"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exec.Validate(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseImageColor(t *testing.T) {
+	tests := map[string]color.NRGBA{
+		"#fff":      {255, 255, 255, 255},
+		"#336699":   {0x33, 0x66, 0x99, 255},
+		"#33669980": {0x33, 0x66, 0x99, 0x80},
+		"Black":     {0, 0, 0, 255},
+	}
+	for input, want := range tests {
+		got, err := parseImageColor(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	for _, input := range []string{"", "#12", "#1234567", "red"} {
+		_, err := parseImageColor(input)
+		assert.Error(t, err, input)
+	}
+}
+
+// hasWhitePixel reports whether any pixel inside rect is close to white.
+func hasWhitePixel(img image.Image, rect image.Rectangle) bool {
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			if r>>8 > 200 && g>>8 > 200 && b>>8 > 200 {
+				return true
+			}
+		}
+	}
+	return false
+}
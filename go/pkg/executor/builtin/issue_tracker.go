@@ -0,0 +1,306 @@
+package builtin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+)
+
+// Issue tracker providers and operations.
+const (
+	IssueTrackerGitHub = "github"
+	IssueTrackerGitLab = "gitlab"
+	IssueTrackerJira   = "jira"
+
+	issueOperationCreate  = "create"
+	issueOperationComment = "comment"
+
+	// issueTrackerMaxErrorBytes caps the API error body quoted in errors.
+	issueTrackerMaxErrorBytes = 512
+)
+
+var issueTrackerDefaultBaseURLs = map[string]string{
+	IssueTrackerGitHub: "https://api.github.com",
+	IssueTrackerGitLab: "https://gitlab.com/api/v4",
+}
+
+// IssueTrackerExecutor creates issues and comments in GitHub, GitLab and Jira.
+// The API token is never part of the node config: it is read from a credentials
+// resource referenced by ID (api_key is sent as a bearer token, basic_auth as
+// basic authentication, e.g. a Jira Cloud email and API token).
+type IssueTrackerExecutor struct {
+	*executor.BaseExecutor
+	credentials CredentialResolver
+	httpClient  *http.Client
+}
+
+// NewIssueTrackerExecutor creates a new issue tracker executor.
+func NewIssueTrackerExecutor(credentials CredentialResolver) *IssueTrackerExecutor {
+	return &IssueTrackerExecutor{
+		BaseExecutor: executor.NewBaseExecutor("issue_tracker"),
+		credentials:  credentials,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// Execute creates an issue or comments on one.
+//
+// Config:
+//   - provider: "github" | "gitlab" | "jira" (required)
+//   - credential_id: ID of a credentials resource with the API token (required); the credential
+//     must be attached to the workflow, e.g. credential_id: "{{resource.tracker.id}}"
+//   - project: "owner/repo" (GitHub), project ID or path (GitLab), or project key (Jira) (required)
+//   - base_url: API base URL (default: public GitHub and GitLab APIs; required for Jira, e.g.
+//     "https://acme.atlassian.net")
+//   - operation: "create" (default) | "comment"
+//
+// Create operation:
+//   - title: Issue title (required)
+//   - body: Issue description
+//   - labels: Labels to add
+//   - issue_type: Jira issue type (default: "Task")
+//
+// Comment operation:
+//   - issue: Issue number (GitHub), IID (GitLab) or key (Jira) (required)
+//   - body: Comment text (required)
+//
+// Output:
+//   - success: true
+//   - provider, project: The tracker and project
+//   - id: ID of the created issue or comment
+//   - key: Issue number, IID or key
+//   - url: Link to the issue in the tracker (when the API returns one)
+//   - duration_ms: Request duration
+func (e *IssueTrackerExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	startTime := time.Now()
+
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+
+	auth, err := resolveAuthorizationHeader(ctx, e.credentials, e.GetStringDefault(config, "credential_id", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := e.GetStringDefault(config, "provider", "")
+	project := e.GetStringDefault(config, "project", "")
+	baseURL := strings.TrimRight(e.GetStringDefault(config, "base_url", issueTrackerDefaultBaseURLs[provider]), "/")
+	labels, _ := toStringSlice(config["labels"], "labels")
+
+	var result map[string]any
+	switch e.GetStringDefault(config, "operation", issueOperationCreate) {
+	case issueOperationComment:
+		result, err = e.comment(ctx, auth, provider, baseURL, project,
+			e.GetStringDefault(config, "issue", ""), e.GetStringDefault(config, "body", ""))
+	default:
+		result, err = e.create(ctx, auth, provider, baseURL, project, issueFields{
+			title:     e.GetStringDefault(config, "title", ""),
+			body:      e.GetStringDefault(config, "body", ""),
+			labels:    labels,
+			issueType: e.GetStringDefault(config, "issue_type", "Task"),
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result["success"] = true
+	result["provider"] = provider
+	result["project"] = project
+	result["duration_ms"] = time.Since(startTime).Milliseconds()
+	return result, nil
+}
+
+// Validate validates the issue tracker executor configuration.
+func (e *IssueTrackerExecutor) Validate(config map[string]any) error {
+	if err := e.ValidateRequired(config, "provider", "credential_id", "project"); err != nil {
+		return err
+	}
+
+	for _, key := range []string{"token", "api_key", "password"} {
+		if _, ok := config[key]; ok {
+			return fmt.Errorf("%s must not be set inline: store it in a credentials resource and reference it with credential_id", key)
+		}
+	}
+
+	provider := e.GetStringDefault(config, "provider", "")
+	switch provider {
+	case IssueTrackerGitHub:
+		if owner, repo, ok := strings.Cut(e.GetStringDefault(config, "project", ""), "/"); !ok || owner == "" || repo == "" {
+			return fmt.Errorf("project must be \"owner/repo\" for github")
+		}
+	case IssueTrackerGitLab:
+	case IssueTrackerJira:
+		if e.GetStringDefault(config, "base_url", "") == "" {
+			return fmt.Errorf("base_url is required for jira")
+		}
+	default:
+		return fmt.Errorf("invalid provider: %s (valid: github, gitlab, jira)", provider)
+	}
+
+	if raw, ok := config["base_url"]; ok {
+		baseURL, _ := raw.(string)
+		if u, err := url.Parse(baseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("base_url must be an http or https URL")
+		}
+	}
+
+	switch e.GetStringDefault(config, "operation", issueOperationCreate) {
+	case issueOperationCreate:
+		if e.GetStringDefault(config, "title", "") == "" {
+			return fmt.Errorf("title is required")
+		}
+		if raw, ok := config["labels"]; ok {
+			if _, err := toStringSlice(raw, "labels"); err != nil {
+				return err
+			}
+		}
+	case issueOperationComment:
+		if err := e.ValidateRequired(config, "issue", "body"); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("invalid operation: %s (valid: create, comment)",
+			e.GetStringDefault(config, "operation", ""))
+	}
+
+	return nil
+}
+
+// issueFields are the fields of a new issue.
+type issueFields struct {
+	title     string
+	body      string
+	labels    []string
+	issueType string
+}
+
+// create creates an issue and returns its id, key and url.
+func (e *IssueTrackerExecutor) create(ctx context.Context, auth, provider, baseURL, project string, fields issueFields) (map[string]any, error) {
+	var response struct {
+		ID      json.Number `json:"id"`
+		Number  json.Number `json:"number"`   // GitHub
+		HTMLURL string      `json:"html_url"` // GitHub
+		IID     json.Number `json:"iid"`      // GitLab
+		WebURL  string      `json:"web_url"`  // GitLab
+		Key     string      `json:"key"`      // Jira
+	}
+
+	switch provider {
+	case IssueTrackerGitHub:
+		payload := map[string]any{"title": fields.title, "body": fields.body}
+		if len(fields.labels) > 0 {
+			payload["labels"] = fields.labels
+		}
+		if err := e.call(ctx, auth, provider, baseURL+"/repos/"+project+"/issues", payload, &response); err != nil {
+			return nil, err
+		}
+		return map[string]any{"id": response.ID.String(), "key": response.Number.String(), "url": response.HTMLURL}, nil
+
+	case IssueTrackerGitLab:
+		payload := map[string]any{"title": fields.title, "description": fields.body}
+		if len(fields.labels) > 0 {
+			payload["labels"] = strings.Join(fields.labels, ",")
+		}
+		endpoint := baseURL + "/projects/" + url.PathEscape(project) + "/issues"
+		if err := e.call(ctx, auth, provider, endpoint, payload, &response); err != nil {
+			return nil, err
+		}
+		return map[string]any{"id": response.ID.String(), "key": response.IID.String(), "url": response.WebURL}, nil
+
+	default:
+		jiraFields := map[string]any{
+			"project":     map[string]any{"key": project},
+			"summary":     fields.title,
+			"description": fields.body,
+			"issuetype":   map[string]any{"name": fields.issueType},
+		}
+		if len(fields.labels) > 0 {
+			jiraFields["labels"] = fields.labels
+		}
+		if err := e.call(ctx, auth, provider, baseURL+"/rest/api/2/issue", map[string]any{"fields": jiraFields}, &response); err != nil {
+			return nil, err
+		}
+		return map[string]any{"id": response.ID.String(), "key": response.Key, "url": baseURL + "/browse/" + response.Key}, nil
+	}
+}
+
+// comment adds a comment to an issue.
+func (e *IssueTrackerExecutor) comment(ctx context.Context, auth, provider, baseURL, project, issue, body string) (map[string]any, error) {
+	var response struct {
+		ID      json.Number `json:"id"`
+		HTMLURL string      `json:"html_url"` // GitHub
+	}
+
+	var endpoint string
+	switch provider {
+	case IssueTrackerGitHub:
+		endpoint = baseURL + "/repos/" + project + "/issues/" + url.PathEscape(issue) + "/comments"
+	case IssueTrackerGitLab:
+		endpoint = baseURL + "/projects/" + url.PathEscape(project) + "/issues/" + url.PathEscape(issue) + "/notes"
+	default:
+		endpoint = baseURL + "/rest/api/2/issue/" + url.PathEscape(issue) + "/comment"
+	}
+
+	if err := e.call(ctx, auth, provider, endpoint, map[string]any{"body": body}, &response); err != nil {
+		return nil, err
+	}
+
+	result := map[string]any{"id": response.ID.String(), "key": issue, "url": response.HTMLURL}
+	if provider == IssueTrackerJira {
+		result["url"] = baseURL + "/browse/" + issue
+	}
+	return result, nil
+}
+
+// call posts a JSON payload and decodes the JSON response.
+func (e *IssueTrackerExecutor) call(ctx context.Context, auth, provider, endpoint string, payload, out any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", auth)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if provider == IssueTrackerGitHub {
+		req.Header.Set("Accept", "application/vnd.github+json")
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w", provider, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", provider, err)
+	}
+	if resp.StatusCode >= 300 {
+		if len(respBody) > issueTrackerMaxErrorBytes {
+			respBody = respBody[:issueTrackerMaxErrorBytes]
+		}
+		return fmt.Errorf("%s API returned status %d: %s", provider, resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", provider, err)
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTrackerAPI records requests and answers like GitHub, GitLab and Jira.
+type fakeTrackerAPI struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	paths    []string
+	auth     []string
+	payloads []map[string]any
+	status   int
+}
+
+func newFakeTrackerAPI(t *testing.T) *fakeTrackerAPI {
+	f := &fakeTrackerAPI{}
+	f.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		var payload map[string]any
+		json.NewDecoder(r.Body).Decode(&payload)
+		f.paths = append(f.paths, r.URL.EscapedPath())
+		f.auth = append(f.auth, r.Header.Get("Authorization"))
+		f.payloads = append(f.payloads, payload)
+
+		if f.status != 0 {
+			w.WriteHeader(f.status)
+			w.Write([]byte(`{"message":"Not Found"}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		switch r.URL.Path {
+		case "/repos/acme/api/issues":
+			w.Write([]byte(`{"id": 9001, "number": 42, "html_url": "https://github.com/acme/api/issues/42"}`))
+		case "/projects/acme/api/issues":
+			w.Write([]byte(`{"id": 77, "iid": 5, "web_url": "https://gitlab.com/acme/api/-/issues/5"}`))
+		case "/rest/api/2/issue":
+			w.Write([]byte(`{"id": "10001", "key": "OPS-12", "self": "https://jira/rest/api/2/issue/10001"}`))
+		default:
+			w.Write([]byte(`{"id": 123, "html_url": "https://github.com/acme/api/issues/42#issuecomment-123"}`))
+		}
+	}))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func newTrackerCredentials() *fakeCredentialResolver {
+	apiKey := models.NewCredentialsResource("owner-1", "tracker", models.CredentialTypeAPIKey)
+	apiKey.DecryptedData = map[string]string{"api_key": "tok"}
+	basic := models.NewCredentialsResource("owner-1", "jira", models.CredentialTypeBasicAuth)
+	basic.DecryptedData = map[string]string{"username": "ops@acme.io", "password": "jira-token"}
+	return &fakeCredentialResolver{creds: map[string]*models.CredentialsResource{
+		"cred-1": apiKey,
+		"cred-2": basic,
+	}}
+}
+
+func TestIssueTrackerExecutor_Validate(t *testing.T) {
+	exec := NewIssueTrackerExecutor(nil)
+	base := func(extra map[string]any) map[string]any {
+		config := map[string]any{"provider": "github", "credential_id": "cred-1", "project": "acme/api", "title": "Down"}
+		for k, v := range extra {
+			config[k] = v
+		}
+		return config
+	}
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{"valid github", base(nil), ""},
+		{"valid gitlab", base(map[string]any{"provider": "gitlab", "project": "42"}), ""},
+		{"valid jira", base(map[string]any{"provider": "jira", "project": "OPS", "base_url": "https://acme.atlassian.net"}), ""},
+		{"valid comment", base(map[string]any{"operation": "comment", "issue": "42", "body": "again"}), ""},
+		{"missing credential", map[string]any{"provider": "github", "project": "acme/api", "title": "x"}, "credential_id"},
+		{"inline token", base(map[string]any{"token": "x"}), "must not be set inline"},
+		{"invalid provider", base(map[string]any{"provider": "trello"}), "invalid provider"},
+		{"github project", base(map[string]any{"project": "api"}), "owner/repo"},
+		{"jira without base_url", base(map[string]any{"provider": "jira", "project": "OPS"}), "base_url is required"},
+		{"invalid base_url", base(map[string]any{"base_url": "ftp://x"}), "base_url"},
+		{"missing title", base(map[string]any{"title": ""}), "title is required"},
+		{"invalid labels", base(map[string]any{"labels": "bug"}), "labels"},
+		{"comment without body", base(map[string]any{"operation": "comment", "issue": "42"}), "body"},
+		{"invalid operation", base(map[string]any{"operation": "close"}), "invalid operation"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exec.Validate(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestIssueTrackerExecutor_Create(t *testing.T) {
+	api := newFakeTrackerAPI(t)
+	exec := NewIssueTrackerExecutor(newTrackerCredentials())
+
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"provider":      "github",
+		"base_url":      api.server.URL,
+		"credential_id": "cred-1",
+		"project":       "acme/api",
+		"title":         "Checkout is failing",
+		"body":          "See execution abc",
+		"labels":        []any{"incident"},
+	}, nil)
+	require.NoError(t, err)
+
+	output := result.(map[string]any)
+	assert.Equal(t, "9001", output["id"])
+	assert.Equal(t, "42", output["key"])
+	assert.Equal(t, "https://github.com/acme/api/issues/42", output["url"])
+	assert.Equal(t, "github", output["provider"])
+	assert.Equal(t, "Bearer tok", api.auth[0])
+	assert.Equal(t, map[string]any{"title": "Checkout is failing", "body": "See execution abc", "labels": []any{"incident"}}, api.payloads[0])
+
+	result, err = exec.Execute(context.Background(), map[string]any{
+		"provider":      "gitlab",
+		"base_url":      api.server.URL,
+		"credential_id": "cred-1",
+		"project":       "acme/api",
+		"title":         "Checkout is failing",
+		"labels":        []any{"incident", "p1"},
+	}, nil)
+	require.NoError(t, err)
+	output = result.(map[string]any)
+	assert.Equal(t, "5", output["key"])
+	assert.Equal(t, "/projects/acme%2Fapi/issues", api.paths[1])
+	assert.Equal(t, "incident,p1", api.payloads[1]["labels"])
+
+	result, err = exec.Execute(context.Background(), map[string]any{
+		"provider":      "jira",
+		"base_url":      api.server.URL + "/",
+		"credential_id": "cred-2",
+		"project":       "OPS",
+		"title":         "Checkout is failing",
+		"issue_type":    "Incident",
+	}, nil)
+	require.NoError(t, err)
+	output = result.(map[string]any)
+	assert.Equal(t, "10001", output["id"])
+	assert.Equal(t, "OPS-12", output["key"])
+	assert.Equal(t, api.server.URL+"/browse/OPS-12", output["url"])
+	assert.Contains(t, api.auth[2], "Basic ")
+	fields := api.payloads[2]["fields"].(map[string]any)
+	assert.Equal(t, map[string]any{"key": "OPS"}, fields["project"])
+	assert.Equal(t, map[string]any{"name": "Incident"}, fields["issuetype"])
+}
+
+func TestIssueTrackerExecutor_Comment(t *testing.T) {
+	api := newFakeTrackerAPI(t)
+	exec := NewIssueTrackerExecutor(newTrackerCredentials())
+
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"provider":      "github",
+		"base_url":      api.server.URL,
+		"credential_id": "cred-1",
+		"project":       "acme/api",
+		"operation":     "comment",
+		"issue":         "42",
+		"body":          "Failed again",
+	}, nil)
+	require.NoError(t, err)
+
+	output := result.(map[string]any)
+	assert.Equal(t, "123", output["id"])
+	assert.Equal(t, "42", output["key"])
+	assert.Equal(t, "/repos/acme/api/issues/42/comments", api.paths[0])
+	assert.Equal(t, map[string]any{"body": "Failed again"}, api.payloads[0])
+}
+
+func TestIssueTrackerExecutor_Errors(t *testing.T) {
+	api := newFakeTrackerAPI(t)
+	api.status = http.StatusNotFound
+	exec := NewIssueTrackerExecutor(newTrackerCredentials())
+	config := map[string]any{
+		"provider":      "github",
+		"base_url":      api.server.URL,
+		"credential_id": "cred-1",
+		"project":       "acme/api",
+		"title":         "Down",
+	}
+
+	_, err := exec.Execute(context.Background(), config, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "github API returned status 404")
+
+	_, err = NewIssueTrackerExecutor(nil).Execute(context.Background(), config, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "credentials are not available")
+
+	ctx := executor.WithExecutionContext(context.Background(), &executor.ExecutionContextData{})
+	_, err = exec.Execute(ctx, config, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not attached to the workflow")
+}
@@ -0,0 +1,491 @@
+package builtin
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// schemaViolation is a value that does not match its JSON Schema.
+type schemaViolation struct {
+	Path    string // Location of the value, e.g. "$.items[0].sku"
+	Keyword string // Schema keyword that failed, e.g. "required"
+	Message string
+}
+
+// jsonSchemaValidator validates values against a JSON Schema (draft 7 / 2020-12 subset):
+// type, enum, const, properties, required, additionalProperties, patternProperties,
+// minProperties, maxProperties, items, minItems, maxItems, uniqueItems, contains,
+// minLength, maxLength, pattern, format (date-time, date, email, uri, uuid), minimum,
+// maximum, exclusiveMinimum, exclusiveMaximum, multipleOf, allOf, anyOf, oneOf, not
+// and local $ref ("#/definitions/..." or "#/$defs/..."). Unknown keywords are ignored.
+type jsonSchemaValidator struct {
+	root map[string]any
+}
+
+// validateJSONSchema returns the violations of value against schema.
+func validateJSONSchema(schema map[string]any, value any) []schemaViolation {
+	v := &jsonSchemaValidator{root: schema}
+	return v.validate(schema, value, "$", 0)
+}
+
+// maxSchemaDepth bounds $ref resolution so recursive schemas cannot loop forever.
+const maxSchemaDepth = 64
+
+func (v *jsonSchemaValidator) validate(schema map[string]any, value any, path string, depth int) []schemaViolation {
+	if depth > maxSchemaDepth {
+		return []schemaViolation{{Path: path, Keyword: "$ref", Message: "schema nesting is too deep"}}
+	}
+
+	if ref, ok := schema["$ref"].(string); ok {
+		target, err := v.resolveRef(ref)
+		if err != nil {
+			return []schemaViolation{{Path: path, Keyword: "$ref", Message: err.Error()}}
+		}
+		return v.validate(target, value, path, depth+1)
+	}
+
+	var violations []schemaViolation
+	add := func(keyword, format string, args ...any) {
+		violations = append(violations, schemaViolation{Path: path, Keyword: keyword, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if types, ok := schemaTypes(schema["type"]); ok {
+		matched := false
+		for _, t := range types {
+			if jsonTypeMatches(t, value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			add("type", "expected %s, got %s", strings.Join(types, " or "), jsonTypeName(value))
+			// The other keywords describe values of the expected type
+			return violations
+		}
+	}
+
+	if enum, ok := schema["enum"].([]any); ok {
+		found := false
+		for _, option := range enum {
+			if jsonEqual(option, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			add("enum", "value must be one of %s", compactJSON(enum))
+		}
+	}
+	if constant, ok := schema["const"]; ok && !jsonEqual(constant, value) {
+		add("const", "value must be %s", compactJSON(constant))
+	}
+
+	switch val := value.(type) {
+	case map[string]any:
+		violations = append(violations, v.validateObject(schema, val, path, depth)...)
+	case []any:
+		violations = append(violations, v.validateArray(schema, val, path, depth)...)
+	case string:
+		violations = append(violations, validateString(schema, val, path)...)
+	default:
+		if n, ok := jsonNumber(value); ok {
+			violations = append(violations, validateNumber(schema, n, path)...)
+		}
+	}
+
+	violations = append(violations, v.validateCombinators(schema, value, path, depth)...)
+	return violations
+}
+
+func (v *jsonSchemaValidator) validateObject(schema map[string]any, obj map[string]any, path string, depth int) []schemaViolation {
+	var violations []schemaViolation
+	add := func(p, keyword, format string, args ...any) {
+		violations = append(violations, schemaViolation{Path: p, Keyword: keyword, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if required, ok := schema["required"].([]any); ok {
+		for _, name := range required {
+			if key, ok := name.(string); ok {
+				if _, exists := obj[key]; !exists {
+					add(path, "required", "missing required property %q", key)
+				}
+			}
+		}
+	}
+	if n, ok := schemaInt(schema["minProperties"]); ok && len(obj) < n {
+		add(path, "minProperties", "must have at least %d properties", n)
+	}
+	if n, ok := schemaInt(schema["maxProperties"]); ok && len(obj) > n {
+		add(path, "maxProperties", "must have at most %d properties", n)
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	patternProperties, _ := schema["patternProperties"].(map[string]any)
+
+	// Sorted keys keep the violations in a stable order
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		propPath := path + "." + key
+		matched := false
+		if propSchema, ok := properties[key].(map[string]any); ok {
+			matched = true
+			violations = append(violations, v.validate(propSchema, obj[key], propPath, depth+1)...)
+		}
+		for pattern, raw := range patternProperties {
+			re, err := regexp.Compile(pattern)
+			if err != nil || !re.MatchString(key) {
+				continue
+			}
+			matched = true
+			if propSchema, ok := raw.(map[string]any); ok {
+				violations = append(violations, v.validate(propSchema, obj[key], propPath, depth+1)...)
+			}
+		}
+		if matched {
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				add(propPath, "additionalProperties", "property %q is not allowed", key)
+			}
+		case map[string]any:
+			violations = append(violations, v.validate(additional, obj[key], propPath, depth+1)...)
+		}
+	}
+	return violations
+}
+
+func (v *jsonSchemaValidator) validateArray(schema map[string]any, arr []any, path string, depth int) []schemaViolation {
+	var violations []schemaViolation
+	add := func(keyword, format string, args ...any) {
+		violations = append(violations, schemaViolation{Path: path, Keyword: keyword, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if n, ok := schemaInt(schema["minItems"]); ok && len(arr) < n {
+		add("minItems", "must have at least %d items", n)
+	}
+	if n, ok := schemaInt(schema["maxItems"]); ok && len(arr) > n {
+		add("maxItems", "must have at most %d items", n)
+	}
+	if unique, _ := schema["uniqueItems"].(bool); unique {
+	outer:
+		for i := range arr {
+			for j := i + 1; j < len(arr); j++ {
+				if jsonEqual(arr[i], arr[j]) {
+					add("uniqueItems", "items %d and %d are equal", i, j)
+					break outer
+				}
+			}
+		}
+	}
+	if items, ok := schema["items"].(map[string]any); ok {
+		for i, item := range arr {
+			violations = append(violations, v.validate(items, item, fmt.Sprintf("%s[%d]", path, i), depth+1)...)
+		}
+	}
+	if contains, ok := schema["contains"].(map[string]any); ok {
+		found := false
+		for _, item := range arr {
+			if len(v.validate(contains, item, path, depth+1)) == 0 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			add("contains", "no item matches the contains schema")
+		}
+	}
+	return violations
+}
+
+func (v *jsonSchemaValidator) validateCombinators(schema map[string]any, value any, path string, depth int) []schemaViolation {
+	var violations []schemaViolation
+	add := func(keyword, format string, args ...any) {
+		violations = append(violations, schemaViolation{Path: path, Keyword: keyword, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if allOf, ok := schema["allOf"].([]any); ok {
+		for _, raw := range allOf {
+			if sub, ok := raw.(map[string]any); ok {
+				violations = append(violations, v.validate(sub, value, path, depth+1)...)
+			}
+		}
+	}
+	if anyOf, ok := schema["anyOf"].([]any); ok && v.countMatches(anyOf, value, path, depth) == 0 {
+		add("anyOf", "value does not match any of the schemas")
+	}
+	if oneOf, ok := schema["oneOf"].([]any); ok {
+		if n := v.countMatches(oneOf, value, path, depth); n != 1 {
+			add("oneOf", "value must match exactly one schema, matched %d", n)
+		}
+	}
+	if not, ok := schema["not"].(map[string]any); ok && len(v.validate(not, value, path, depth+1)) == 0 {
+		add("not", "value must not match the schema")
+	}
+	return violations
+}
+
+func (v *jsonSchemaValidator) countMatches(schemas []any, value any, path string, depth int) int {
+	n := 0
+	for _, raw := range schemas {
+		if sub, ok := raw.(map[string]any); ok && len(v.validate(sub, value, path, depth+1)) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// resolveRef resolves a local JSON pointer reference such as "#/$defs/address".
+func (v *jsonSchemaValidator) resolveRef(ref string) (map[string]any, error) {
+	if ref == "#" {
+		return v.root, nil
+	}
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("unsupported $ref %q: only local references are supported", ref)
+	}
+	var current any = v.root
+	for _, part := range strings.Split(ref[2:], "/") {
+		part = strings.NewReplacer("~1", "/", "~0", "~").Replace(part)
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unresolvable $ref %q", ref)
+		}
+		if current, ok = m[part]; !ok {
+			return nil, fmt.Errorf("unresolvable $ref %q", ref)
+		}
+	}
+	target, ok := current.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("$ref %q does not point to a schema", ref)
+	}
+	return target, nil
+}
+
+func validateString(schema map[string]any, s, path string) []schemaViolation {
+	var violations []schemaViolation
+	add := func(keyword, format string, args ...any) {
+		violations = append(violations, schemaViolation{Path: path, Keyword: keyword, Message: fmt.Sprintf(format, args...)})
+	}
+
+	length := utf8.RuneCountInString(s)
+	if n, ok := schemaInt(schema["minLength"]); ok && length < n {
+		add("minLength", "must be at least %d characters", n)
+	}
+	if n, ok := schemaInt(schema["maxLength"]); ok && length > n {
+		add("maxLength", "must be at most %d characters", n)
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			add("pattern", "invalid pattern %q", pattern)
+		} else if !re.MatchString(s) {
+			add("pattern", "must match pattern %q", pattern)
+		}
+	}
+	if format, ok := schema["format"].(string); ok && !stringHasFormat(format, s) {
+		add("format", "must be a valid %s", format)
+	}
+	return violations
+}
+
+// stringHasFormat checks the known formats; unknown formats are not checked.
+func stringHasFormat(format, s string) bool {
+	switch format {
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	case "date":
+		_, err := time.Parse(time.DateOnly, s)
+		return err == nil
+	case "email":
+		addr, err := mail.ParseAddress(s)
+		return err == nil && addr.Address == s
+	case "uri":
+		u, err := url.Parse(s)
+		return err == nil && u.Scheme != ""
+	case "uuid":
+		_, err := uuid.Parse(s)
+		return err == nil && len(s) == 36
+	default:
+		return true
+	}
+}
+
+func validateNumber(schema map[string]any, n float64, path string) []schemaViolation {
+	var violations []schemaViolation
+	add := func(keyword, format string, args ...any) {
+		violations = append(violations, schemaViolation{Path: path, Keyword: keyword, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if limit, ok := jsonNumber(schema["minimum"]); ok && n < limit {
+		add("minimum", "must be >= %v", limit)
+	}
+	if limit, ok := jsonNumber(schema["maximum"]); ok && n > limit {
+		add("maximum", "must be <= %v", limit)
+	}
+	if limit, ok := jsonNumber(schema["exclusiveMinimum"]); ok && n <= limit {
+		add("exclusiveMinimum", "must be > %v", limit)
+	}
+	if limit, ok := jsonNumber(schema["exclusiveMaximum"]); ok && n >= limit {
+		add("exclusiveMaximum", "must be < %v", limit)
+	}
+	if divisor, ok := jsonNumber(schema["multipleOf"]); ok && divisor > 0 {
+		if q := n / divisor; math.Abs(q-math.Round(q)) > 1e-9 {
+			add("multipleOf", "must be a multiple of %v", divisor)
+		}
+	}
+	return violations
+}
+
+// schemaTypes returns the types a schema allows: a type name or a list of them.
+func schemaTypes(raw any) ([]string, bool) {
+	switch t := raw.(type) {
+	case string:
+		return []string{t}, true
+	case []any:
+		types := make([]string, 0, len(t))
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types, len(types) > 0
+	default:
+		return nil, false
+	}
+}
+
+func jsonTypeMatches(t string, value any) bool {
+	switch t {
+	case "null":
+		return value == nil
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "number":
+		_, ok := jsonNumber(value)
+		return ok
+	case "integer":
+		n, ok := jsonNumber(value)
+		return ok && n == math.Trunc(n)
+	default:
+		return false
+	}
+}
+
+func jsonTypeName(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	}
+	if n, ok := jsonNumber(value); ok {
+		if n == math.Trunc(n) {
+			return "integer"
+		}
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// jsonNumber returns value as a float64 if it is a number.
+func jsonNumber(value any) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func schemaInt(raw any) (int, bool) {
+	n, ok := jsonNumber(raw)
+	return int(n), ok
+}
+
+// jsonEqual compares two JSON values, treating numbers of different Go types as equal.
+func jsonEqual(a, b any) bool {
+	if x, ok := jsonNumber(a); ok {
+		y, ok := jsonNumber(b)
+		return ok && x == y
+	}
+	switch x := a.(type) {
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !jsonEqual(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, xv := range x {
+			yv, ok := y[k]
+			if !ok || !jsonEqual(xv, yv) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
+
+func compactJSON(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,107 @@
+package builtin
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseTestSchema(t *testing.T, raw string) map[string]any {
+	t.Helper()
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal([]byte(raw), &schema))
+	return schema
+}
+
+func TestValidateJSONSchema(t *testing.T) {
+	schema := parseTestSchema(t, `{
+		"type": "object",
+		"required": ["id", "email", "items"],
+		"additionalProperties": false,
+		"properties": {
+			"id": {"type": "string", "format": "uuid"},
+			"email": {"type": "string", "format": "email"},
+			"status": {"enum": ["new", "paid"]},
+			"total": {"type": "number", "minimum": 0, "multipleOf": 0.01},
+			"items": {"type": "array", "minItems": 1, "items": {"$ref": "#/$defs/item"}}
+		},
+		"$defs": {
+			"item": {
+				"type": "object",
+				"required": ["sku", "qty"],
+				"properties": {
+					"sku": {"type": "string", "pattern": "^[A-Z]{3}-[0-9]+$"},
+					"qty": {"type": "integer", "exclusiveMinimum": 0}
+				}
+			}
+		}
+	}`)
+
+	valid := map[string]any{
+		"id":     "6f1b1c9a-3a2e-4f4e-9b7a-0c6d8e2f1a3b",
+		"email":  "ana@example.com",
+		"status": "paid",
+		"total":  19.99,
+		"items":  []any{map[string]any{"sku": "ABC-1", "qty": float64(2)}},
+	}
+	assert.Empty(t, validateJSONSchema(schema, valid))
+
+	invalid := map[string]any{
+		"id":     "not-a-uuid",
+		"status": "lost",
+		"total":  -1.0,
+		"extra":  true,
+		"items":  []any{map[string]any{"sku": "abc", "qty": 1.5}},
+	}
+	got := map[string]string{}
+	for _, v := range validateJSONSchema(schema, invalid) {
+		got[v.Path+" "+v.Keyword] = v.Message
+	}
+	for _, want := range []string{
+		"$ required",
+		"$.extra additionalProperties",
+		"$.id format",
+		"$.status enum",
+		"$.total minimum",
+		"$.items[0].sku pattern",
+		"$.items[0].qty type",
+	} {
+		assert.Contains(t, got, want)
+	}
+	assert.Equal(t, `missing required property "email"`, got["$ required"])
+}
+
+func TestValidateJSONSchema_Combinators(t *testing.T) {
+	schema := parseTestSchema(t, `{
+		"oneOf": [
+			{"type": "string", "maxLength": 3},
+			{"type": "integer"}
+		],
+		"not": {"const": "bad"}
+	}`)
+
+	assert.Empty(t, validateJSONSchema(schema, "ok"))
+	assert.Empty(t, validateJSONSchema(schema, float64(7)))
+	assert.NotEmpty(t, validateJSONSchema(schema, "too long"))
+	assert.NotEmpty(t, validateJSONSchema(schema, "bad"))
+	assert.NotEmpty(t, validateJSONSchema(schema, true))
+
+	anyOf := parseTestSchema(t, `{"type": ["array", "null"], "uniqueItems": true, "contains": {"const": 1}}`)
+	assert.Empty(t, validateJSONSchema(anyOf, nil))
+	assert.Empty(t, validateJSONSchema(anyOf, []any{float64(1), float64(2)}))
+	assert.Len(t, validateJSONSchema(anyOf, []any{float64(2), float64(2)}), 2)
+}
+
+func TestValidateJSONSchema_RecursiveRef(t *testing.T) {
+	schema := parseTestSchema(t, `{"$ref": "#"}`)
+	violations := validateJSONSchema(schema, "x")
+	require.Len(t, violations, 1)
+	assert.Equal(t, "$ref", violations[0].Keyword)
+
+	unresolvable := parseTestSchema(t, `{"properties": {"a": {"$ref": "#/$defs/missing"}}}`)
+	violations = validateJSONSchema(unresolvable, map[string]any{"a": 1})
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "unresolvable")
+}
@@ -16,11 +16,24 @@ type LLMProvider interface {
 	Execute(ctx context.Context, req *models.LLMRequest) (*models.LLMResponse, error)
 }
 
+// StreamingLLMProvider is implemented by providers that can stream the response text.
+type StreamingLLMProvider interface {
+	LLMProvider
+
+	// ExecuteStream calls onDelta (if not nil) with each chunk of text as it is generated
+	// and returns the assembled response.
+	ExecuteStream(ctx context.Context, req *models.LLMRequest, onDelta func(delta string)) (*models.LLMResponse, error)
+}
+
 // LLMExecutor executes LLM requests with support for multiple providers.
 type LLMExecutor struct {
 	*executor.BaseExecutor
 	providers           map[models.LLMProvider]LLMProvider
 	toolCallingRegistry *ToolCallingRegistry
+	credentials         CredentialResolver
+	cache               LLMResponseCache
+	cacheTTL            time.Duration
+	breaker             *executor.CircuitBreaker
 	mu                  sync.RWMutex
 }
 
@@ -39,6 +52,35 @@ func (e *LLMExecutor) SetToolCallingRegistry(registry *ToolCallingRegistry) {
 	e.toolCallingRegistry = registry
 }
 
+// SetCredentialResolver sets the resolver of credential_id references, used by the
+// bedrock provider to load AWS credentials.
+func (e *LLMExecutor) SetCredentialResolver(credentials CredentialResolver) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.credentials = credentials
+}
+
+// SetResponseCache sets the cache serving nodes that opt into response caching, and the
+// TTL of entries whose node sets none (DefaultLLMCacheTTL when ttl <= 0). A nil cache
+// disables caching.
+func (e *LLMExecutor) SetResponseCache(cache LLMResponseCache, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = DefaultLLMCacheTTL
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.cache = cache
+	e.cacheTTL = ttl
+}
+
+// SetCircuitBreaker sets the circuit breaker failing requests fast while their provider
+// keeps failing. Providers are told apart by type and base_url. A nil breaker disables it.
+func (e *LLMExecutor) SetCircuitBreaker(breaker *executor.CircuitBreaker) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.breaker = breaker
+}
+
 // RegisterProvider registers a custom LLM provider.
 func (e *LLMExecutor) RegisterProvider(providerType models.LLMProvider, provider LLMProvider) {
 	e.mu.Lock()
@@ -97,6 +139,16 @@ func (e *LLMExecutor) Execute(ctx context.Context, config map[string]any, input
 		return nil, fmt.Errorf("failed to parse LLM config: %w", err)
 	}
 
+	// Tag the request with the execution it belongs to; providers that accept metadata forward it
+	for k, v := range executor.LLMMetadata(ctx) {
+		if req.Metadata == nil {
+			req.Metadata = make(map[string]any)
+		}
+		if _, exists := req.Metadata[k]; !exists {
+			req.Metadata[k] = v
+		}
+	}
+
 	// If config doesn't explicitly set Input field and input parameter is provided,
 	// check if we should use it directly (useful for Responses API or structured inputs)
 	if req.Input == nil && input != nil {
@@ -106,12 +158,41 @@ func (e *LLMExecutor) Execute(ctx context.Context, config map[string]any, input
 		}
 	}
 
+	// Bedrock signs requests with AWS credentials loaded from a credentials resource
+	if req.Provider == models.LLMProviderBedrock && !e.hasProvider(req.Provider) {
+		awsConfig, err := e.resolveBedrockCredentials(ctx, e.GetStringDefault(config, "credential_id", ""))
+		if err != nil {
+			return nil, err
+		}
+		req.ProviderConfig["aws_credentials"] = awsConfig
+	}
+
 	// Create provider with config
 	provider, err := e.getOrCreateProvider(req)
 	if err != nil {
 		return nil, err
 	}
 
+	// Batch mode runs the request once per input item
+	if rawBatch, ok := config["batch"]; ok && rawBatch != nil {
+		batch, err := parseBatchConfig(rawBatch)
+		if err != nil {
+			return nil, err
+		}
+		return e.executeBatch(ctx, req, provider, batch, input)
+	}
+
+	response, err := e.executeRequest(ctx, req, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert response to map for output
+	return e.responseToMap(response, req.ResponseFormat), nil
+}
+
+// executeRequest sends a single request, running the tool calling cycle in auto mode.
+func (e *LLMExecutor) executeRequest(ctx context.Context, req *models.LLMRequest, provider LLMProvider) (*models.LLMResponse, error) {
 	// Check if auto mode tool calling is enabled
 	if req.ToolCallConfig != nil && req.ToolCallConfig.Mode == models.ToolCallModeAuto {
 		// Use automatic tool calling mode
@@ -119,24 +200,115 @@ func (e *LLMExecutor) Execute(ctx context.Context, config map[string]any, input
 		if err != nil {
 			return nil, fmt.Errorf("auto mode tool calling failed: %w", err)
 		}
-		return e.responseToMap(response, req.ResponseFormat), nil
+		return response, nil
 	}
 
-	// Execute request (manual mode or no tool calling)
-	response, err := provider.Execute(ctx, req)
+	// Nodes opting into caching reuse the response of an identical earlier request
+	if e.cacheable(req) {
+		return e.executeCached(ctx, req, provider)
+	}
+	return e.send(ctx, req, provider)
+}
+
+// send sends a request to the provider, streaming the response when requested.
+func (e *LLMExecutor) send(ctx context.Context, req *models.LLMRequest, provider LLMProvider) (*models.LLMResponse, error) {
+	response, err := e.callProvider(ctx, req, func() (*models.LLMResponse, error) {
+		// Streamed requests forward text chunks to the execution as they arrive
+		if req.Stream {
+			return e.executeStream(ctx, req, provider)
+		}
+		// Execute request (manual mode or no tool calling)
+		return provider.Execute(ctx, req)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("LLM execution failed: %w", err)
 	}
+	return response, nil
+}
 
-	// Convert response to map for output
-	return e.responseToMap(response, req.ResponseFormat), nil
+// callProvider makes a provider call through the circuit breaker of the request's provider.
+func (e *LLMExecutor) callProvider(ctx context.Context, req *models.LLMRequest, call func() (*models.LLMResponse, error)) (*models.LLMResponse, error) {
+	e.mu.RLock()
+	breaker := e.breaker
+	e.mu.RUnlock()
+
+	key := string(req.Provider)
+	if baseURL, _ := req.ProviderConfig["base_url"].(string); baseURL != "" {
+		key += ":" + baseURL
+	}
+	if err := breaker.Allow(key); err != nil {
+		return nil, err
+	}
+	response, err := call()
+	breaker.Record(key, err != nil && ctx.Err() == nil)
+	return response, err
+}
+
+// executeStream streams the response of providers that support it, passing each text chunk
+// to the execution's output delta function. Other providers deliver the content as one chunk.
+func (e *LLMExecutor) executeStream(ctx context.Context, req *models.LLMRequest, provider LLMProvider) (*models.LLMResponse, error) {
+	onDelta := executor.OutputDeltaFunc(ctx)
+
+	if streamer, ok := provider.(StreamingLLMProvider); ok {
+		return streamer.ExecuteStream(ctx, req, onDelta)
+	}
+
+	response, err := provider.Execute(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if onDelta != nil && response.Content != "" {
+		onDelta(response.Content)
+	}
+	return response, nil
 }
 
 // Validate validates the LLM executor configuration.
 func (e *LLMExecutor) Validate(config map[string]any) error {
-	// Validate required fields
-	if err := e.ValidateRequired(config, "provider", "model", "prompt", "api_key"); err != nil {
-		return err
+	if rawBatch, ok := config["batch"]; ok && rawBatch != nil {
+		batch, err := parseBatchConfig(rawBatch)
+		if err != nil {
+			return err
+		}
+		if batch.Mode == LLMBatchModeProvider {
+			if provider := e.GetStringDefault(config, "provider", ""); provider != string(models.LLMProviderOpenAI) {
+				return fmt.Errorf("batch mode %q is only supported by the %s provider", LLMBatchModeProvider, models.LLMProviderOpenAI)
+			}
+			if toolCallConfig, ok := config["tool_call_config"].(map[string]any); ok && toolCallConfig["mode"] == string(models.ToolCallModeAuto) {
+				return fmt.Errorf("batch mode %q does not support auto mode tool calling", LLMBatchModeProvider)
+			}
+		}
+	}
+
+	if rawCache, ok := config["cache"]; ok && rawCache != nil {
+		if _, err := parseCacheConfig(rawCache); err != nil {
+			return err
+		}
+	}
+
+	// Validate required fields; the mock provider calls no API and needs no model or key
+	if e.GetStringDefault(config, "provider", "") == string(models.LLMProviderMock) {
+		if err := e.ValidateRequired(config, "prompt"); err != nil {
+			return err
+		}
+		if _, err := parseMockConfig(config["mock"]); err != nil {
+			return err
+		}
+		return nil
+	}
+	switch e.GetStringDefault(config, "provider", "") {
+	case string(models.LLMProviderAzureOpenAI):
+		if err := e.validateAzureOpenAI(config); err != nil {
+			return err
+		}
+	case string(models.LLMProviderBedrock):
+		if err := e.validateBedrock(config); err != nil {
+			return err
+		}
+	default:
+		if err := e.ValidateRequired(config, "provider", "model", "prompt", "api_key"); err != nil {
+			return err
+		}
 	}
 
 	// Validate provider
@@ -151,17 +323,28 @@ func (e *LLMExecutor) Validate(config map[string]any) error {
 		models.LLMProviderOpenAIResponses: true,
 		models.LLMProviderAnthropic:       true,
 		models.LLMProviderGemini:          true,
+		models.LLMProviderAzureOpenAI:     true,
+		models.LLMProviderBedrock:         true,
+		models.LLMProviderMistral:         true,
+		models.LLMProviderCohere:          true,
+		models.LLMProviderMock:            true,
 	}
 	if !validProviders[provider] {
 		return fmt.Errorf("unsupported LLM provider: %s", providerStr)
 	}
 
-	// Validate model
-	model, err := e.GetString(config, "model")
-	if err != nil {
-		return err
+	if rawSafety, ok := config["safety_settings"]; ok && rawSafety != nil {
+		if provider != models.LLMProviderGemini {
+			return fmt.Errorf("safety_settings are only supported by the %s provider", models.LLMProviderGemini)
+		}
+		if _, err := parseGeminiSafetySettings(rawSafety); err != nil {
+			return err
+		}
 	}
-	if model == "" {
+
+	// Validate model; an Azure OpenAI deployment stands in for it
+	model := e.GetStringDefault(config, "model", "")
+	if model == "" && e.GetStringDefault(config, "deployment", "") == "" {
 		return fmt.Errorf("model cannot be empty")
 	}
 
@@ -195,6 +378,85 @@ func (e *LLMExecutor) Validate(config map[string]any) error {
 	return nil
 }
 
+// validateAzureOpenAI checks the fields an Azure OpenAI request needs: the resource
+// endpoint, a deployment (or a model naming it) and an API key or Azure AD token.
+func (e *LLMExecutor) validateAzureOpenAI(config map[string]any) error {
+	if err := e.ValidateRequired(config, "prompt", "base_url"); err != nil {
+		return err
+	}
+	if e.GetStringDefault(config, "deployment", "") == "" && e.GetStringDefault(config, "model", "") == "" {
+		return fmt.Errorf("deployment or model is required for the %s provider", models.LLMProviderAzureOpenAI)
+	}
+	if e.GetStringDefault(config, "api_key", "") == "" && e.GetStringDefault(config, "azure_ad_token", "") == "" {
+		return fmt.Errorf("api_key or azure_ad_token is required for the %s provider", models.LLMProviderAzureOpenAI)
+	}
+	return nil
+}
+
+// bedrockInlineCredentialKeys are AWS credential fields that must not be set in a node config.
+var bedrockInlineCredentialKeys = []string{"access_key_id", "secret_access_key", "session_token", "aws_access_key_id", "aws_secret_access_key"}
+
+// validateBedrock checks the fields a Bedrock request needs. AWS keys are never part of
+// the node config: they come from the credentials resource referenced by credential_id.
+func (e *LLMExecutor) validateBedrock(config map[string]any) error {
+	if err := e.ValidateRequired(config, "model", "prompt", "credential_id"); err != nil {
+		return err
+	}
+	for _, key := range bedrockInlineCredentialKeys {
+		if _, ok := config[key]; ok {
+			return fmt.Errorf("%s must not be set inline: store it in a credentials resource and reference it with credential_id", key)
+		}
+	}
+	return nil
+}
+
+// resolveBedrockCredentials loads AWS credentials from a credentials resource: a custom
+// credential with access_key_id, secret_access_key and optionally session_token, region,
+// role_arn and external_id, or a basic_auth credential holding the access key ID as username
+// and the secret access key as password.
+func (e *LLMExecutor) resolveBedrockCredentials(ctx context.Context, credentialID string) (BedrockConfig, error) {
+	e.mu.RLock()
+	credentials := e.credentials
+	e.mu.RUnlock()
+
+	if credentialID == "" {
+		return BedrockConfig{}, fmt.Errorf("credential_id is required for the %s provider", models.LLMProviderBedrock)
+	}
+	if credentials == nil {
+		return BedrockConfig{}, fmt.Errorf("credential_id is set but credentials are not available")
+	}
+	if !credentialAttached(ctx, credentialID) {
+		return BedrockConfig{}, fmt.Errorf("credential %s is not attached to the workflow as a resource", credentialID)
+	}
+
+	cred, err := credentials.GetDecrypted(ctx, credentialID)
+	if err != nil {
+		return BedrockConfig{}, fmt.Errorf("failed to resolve credential %s: %w", credentialID, err)
+	}
+
+	var cfg BedrockConfig
+	switch cred.CredentialType {
+	case models.CredentialTypeCustom:
+		cfg = BedrockConfig{
+			Region:          cred.GetCustomValue("region"),
+			AccessKeyID:     cred.GetCustomValue("access_key_id"),
+			SecretAccessKey: cred.GetCustomValue("secret_access_key"),
+			SessionToken:    cred.GetCustomValue("session_token"),
+			RoleARN:         cred.GetCustomValue("role_arn"),
+			ExternalID:      cred.GetCustomValue("external_id"),
+		}
+	case models.CredentialTypeBasicAuth:
+		cfg.AccessKeyID, cfg.SecretAccessKey = cred.GetBasicAuth()
+	default:
+		return BedrockConfig{}, fmt.Errorf("credential %s has unsupported type %s (expected custom or basic_auth)",
+			credentialID, cred.CredentialType)
+	}
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return BedrockConfig{}, fmt.Errorf("credential %s does not contain access_key_id and secret_access_key", credentialID)
+	}
+	return cfg, nil
+}
+
 // parseConfig parses the executor config into an LLMRequest.
 func (e *LLMExecutor) parseConfig(config map[string]any) (*models.LLMRequest, error) {
 	req := &models.LLMRequest{}
@@ -208,9 +470,23 @@ func (e *LLMExecutor) parseConfig(config map[string]any) (*models.LLMRequest, er
 
 	// Optional fields
 	req.Instruction = e.GetStringDefault(config, "instruction", "")
+	if req.Instruction == "" {
+		// builder.LLMSystemPrompt stores the system message as system_prompt
+		req.Instruction = e.GetStringDefault(config, "system_prompt", "")
+	}
 	req.MaxTokens = e.GetIntDefault(config, "max_tokens", 0)
 	req.VectorStoreID = e.GetStringDefault(config, "vector_store_id", "")
 	req.PreviousResponseID = e.GetStringDefault(config, "previous_response_id", "")
+	req.Stream = e.GetBoolDefault(config, "stream", false)
+
+	// Response caching is opt-in per node
+	if rawCache, ok := config["cache"]; ok && rawCache != nil {
+		cache, err := parseCacheConfig(rawCache)
+		if err != nil {
+			return nil, err
+		}
+		req.Cache = cache
+	}
 
 	// Numeric parameters
 	if temp, ok := config["temperature"].(float64); ok {
@@ -267,6 +543,15 @@ func (e *LLMExecutor) parseConfig(config map[string]any) (*models.LLMRequest, er
 		req.ResponseFormat = parsedFormat
 	}
 
+	// Gemini safety settings
+	if rawSafety, ok := config["safety_settings"]; ok && rawSafety != nil {
+		safetySettings, err := parseGeminiSafetySettings(rawSafety)
+		if err != nil {
+			return nil, err
+		}
+		req.SafetySettings = safetySettings
+	}
+
 	// Extract provider configuration
 	req.ProviderConfig = e.extractProviderConfig(config)
 
@@ -524,6 +809,40 @@ func (e *LLMExecutor) getOrCreateProvider(req *models.LLMRequest) (LLMProvider,
 		apiKey, _ := req.ProviderConfig["api_key"].(string)
 		baseURL, _ := req.ProviderConfig["base_url"].(string)
 		return NewGeminiProvider(apiKey, baseURL)
+	case models.LLMProviderAzureOpenAI:
+		cfg := AzureOpenAIConfig{Deployment: req.Model}
+		cfg.Endpoint, _ = req.ProviderConfig["base_url"].(string)
+		cfg.APIKey, _ = req.ProviderConfig["api_key"].(string)
+		cfg.AzureADToken, _ = req.ProviderConfig["azure_ad_token"].(string)
+		cfg.APIVersion, _ = req.ProviderConfig["api_version"].(string)
+		if deployment, _ := req.ProviderConfig["deployment"].(string); deployment != "" {
+			cfg.Deployment = deployment
+		}
+		return NewAzureOpenAIProvider(cfg)
+	case models.LLMProviderBedrock:
+		cfg, _ := req.ProviderConfig["aws_credentials"].(BedrockConfig)
+		cfg.Endpoint, _ = req.ProviderConfig["base_url"].(string)
+		// The node config overrides the region and role of the credential
+		if region, _ := req.ProviderConfig["region"].(string); region != "" {
+			cfg.Region = region
+		}
+		if roleARN, _ := req.ProviderConfig["role_arn"].(string); roleARN != "" {
+			cfg.RoleARN = roleARN
+		}
+		if externalID, _ := req.ProviderConfig["external_id"].(string); externalID != "" {
+			cfg.ExternalID = externalID
+		}
+		return NewBedrockProvider(cfg)
+	case models.LLMProviderMistral:
+		apiKey, _ := req.ProviderConfig["api_key"].(string)
+		baseURL, _ := req.ProviderConfig["base_url"].(string)
+		return NewMistralProvider(apiKey, baseURL)
+	case models.LLMProviderCohere:
+		apiKey, _ := req.ProviderConfig["api_key"].(string)
+		baseURL, _ := req.ProviderConfig["base_url"].(string)
+		return NewCohereProvider(apiKey, baseURL)
+	case models.LLMProviderMock:
+		return NewCannedLLMProvider(), nil
 	default:
 		return nil, fmt.Errorf("unsupported provider: %s", req.Provider)
 	}
@@ -739,6 +1058,25 @@ func (e *LLMExecutor) extractProviderConfig(config map[string]any) map[string]an
 		providerConfig["org_id"] = orgID
 	}
 
+	// Azure OpenAI-specific fields
+	for _, key := range []string{"deployment", "api_version", "azure_ad_token"} {
+		if value := e.GetStringDefault(config, key, ""); value != "" {
+			providerConfig[key] = value
+		}
+	}
+
+	// Bedrock-specific fields
+	for _, key := range []string{"region", "role_arn", "external_id"} {
+		if value := e.GetStringDefault(config, key, ""); value != "" {
+			providerConfig[key] = value
+		}
+	}
+
+	// Mock provider responses, rules and fixtures
+	if mock, ok := config["mock"]; ok {
+		providerConfig["mock"] = mock
+	}
+
 	return providerConfig
 }
 
@@ -1077,7 +1415,9 @@ func (e *LLMExecutor) executeWithToolCalling(
 		reqCopy.Messages = messages
 
 		// Call LLM
-		response, err := provider.Execute(ctx, &reqCopy)
+		response, err := e.callProvider(ctx, &reqCopy, func() (*models.LLMResponse, error) {
+			return provider.Execute(ctx, &reqCopy)
+		})
 		if err != nil {
 			return nil, fmt.Errorf("LLM call failed at iteration %d: %w", iteration, err)
 		}
@@ -0,0 +1,84 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// DefaultAzureOpenAIAPIVersion is the Azure OpenAI API version used when a node sets none.
+const DefaultAzureOpenAIAPIVersion = "2024-10-21"
+
+// AzureOpenAIConfig configures an Azure OpenAI provider.
+type AzureOpenAIConfig struct {
+	Endpoint     string // Resource endpoint, e.g. https://my-resource.openai.azure.com
+	Deployment   string // Deployment name; it selects the model
+	APIVersion   string // Defaults to DefaultAzureOpenAIAPIVersion
+	APIKey       string // Sent as the api-key header
+	AzureADToken string // Microsoft Entra ID (Azure AD) access token, used instead of APIKey
+}
+
+// AzureOpenAIProvider implements the LLM provider for Azure OpenAI deployments.
+// Azure serves the OpenAI Chat Completions API per deployment, so requests and
+// responses are handled by the OpenAI provider; only the URL and auth differ.
+type AzureOpenAIProvider struct {
+	openai       *OpenAIProvider
+	url          string
+	apiKey       string
+	azureADToken string
+}
+
+// NewAzureOpenAIProvider creates a new Azure OpenAI provider with the given configuration.
+func NewAzureOpenAIProvider(cfg AzureOpenAIConfig) (*AzureOpenAIProvider, error) {
+	if cfg.Endpoint == "" {
+		return nil, fmt.Errorf("base_url (the Azure OpenAI resource endpoint) is required for Azure OpenAI provider")
+	}
+	if cfg.Deployment == "" {
+		return nil, fmt.Errorf("deployment is required for Azure OpenAI provider")
+	}
+	if cfg.APIKey == "" && cfg.AzureADToken == "" {
+		return nil, fmt.Errorf("api_key or azure_ad_token is required for Azure OpenAI provider")
+	}
+	if cfg.APIVersion == "" {
+		cfg.APIVersion = DefaultAzureOpenAIAPIVersion
+	}
+
+	endpoint := strings.TrimSuffix(cfg.Endpoint, "/")
+	return &AzureOpenAIProvider{
+		openai: &OpenAIProvider{
+			baseURL: endpoint,
+			client: &http.Client{
+				Timeout: 120 * time.Second,
+			},
+		},
+		url: fmt.Sprintf("%s/openai/deployments/%s/chat/completions?api-version=%s",
+			endpoint, url.PathEscape(cfg.Deployment), url.QueryEscape(cfg.APIVersion)),
+		apiKey:       cfg.APIKey,
+		azureADToken: cfg.AzureADToken,
+	}, nil
+}
+
+// Execute executes an LLM request against the Azure OpenAI deployment.
+func (p *AzureOpenAIProvider) Execute(ctx context.Context, req *models.LLMRequest) (*models.LLMResponse, error) {
+	return p.openai.executeChatCompletion(ctx, req, models.LLMProviderAzureOpenAI, p.url, p.setAuth)
+}
+
+// ExecuteStream executes an LLM request against the Azure OpenAI deployment with a streamed
+// response, calling onDelta (if not nil) with each chunk of text as it is generated.
+func (p *AzureOpenAIProvider) ExecuteStream(ctx context.Context, req *models.LLMRequest, onDelta func(delta string)) (*models.LLMResponse, error) {
+	return p.openai.streamChatCompletion(ctx, req, models.LLMProviderAzureOpenAI, p.url, p.setAuth, onDelta)
+}
+
+// setAuth authenticates with the Azure AD token when one is set, otherwise with the API key.
+func (p *AzureOpenAIProvider) setAuth(header http.Header) {
+	if p.azureADToken != "" {
+		header.Set("Authorization", "Bearer "+p.azureADToken)
+		return
+	}
+	header.Set("api-key", p.apiKey)
+}
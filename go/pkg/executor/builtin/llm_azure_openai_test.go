@@ -0,0 +1,215 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAzureOpenAIProvider_NewAzureOpenAIProvider(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     AzureOpenAIConfig
+		wantErr string
+	}{
+		{
+			name: "api key",
+			cfg:  AzureOpenAIConfig{Endpoint: "https://res.openai.azure.com", Deployment: "gpt-4o", APIKey: "key"},
+		},
+		{
+			name: "azure ad token",
+			cfg:  AzureOpenAIConfig{Endpoint: "https://res.openai.azure.com", Deployment: "gpt-4o", AzureADToken: "token"},
+		},
+		{
+			name:    "missing endpoint",
+			cfg:     AzureOpenAIConfig{Deployment: "gpt-4o", APIKey: "key"},
+			wantErr: "base_url",
+		},
+		{
+			name:    "missing deployment",
+			cfg:     AzureOpenAIConfig{Endpoint: "https://res.openai.azure.com", APIKey: "key"},
+			wantErr: "deployment",
+		},
+		{
+			name:    "missing credentials",
+			cfg:     AzureOpenAIConfig{Endpoint: "https://res.openai.azure.com", Deployment: "gpt-4o"},
+			wantErr: "api_key or azure_ad_token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewAzureOpenAIProvider(tt.cfg)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t,
+				"https://res.openai.azure.com/openai/deployments/gpt-4o/chat/completions?api-version="+DefaultAzureOpenAIAPIVersion,
+				provider.url)
+		})
+	}
+}
+
+func TestAzureOpenAIProvider_Execute(t *testing.T) {
+	var gotPath, gotVersion, gotAPIKey, gotAuth string
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotVersion = r.URL.Query().Get("api-version")
+		gotAPIKey = r.Header.Get("api-key")
+		gotAuth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"id": "chatcmpl-1",
+			"model": "gpt-4o-2024-08-06",
+			"choices": [{"index": 0, "message": {"role": "assistant", "content": "Hello from Azure"}, "finish_reason": "stop"}],
+			"usage": {"prompt_tokens": 5, "completion_tokens": 3, "total_tokens": 8}
+		}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewAzureOpenAIProvider(AzureOpenAIConfig{
+		Endpoint:   server.URL + "/",
+		Deployment: "chat-prod",
+		APIVersion: "2025-01-01-preview",
+		APIKey:     "azure-key",
+	})
+	require.NoError(t, err)
+
+	resp, err := provider.Execute(context.Background(), &models.LLMRequest{
+		Instruction: "Be brief",
+		Prompt:      "Hello",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Hello from Azure", resp.Content)
+	assert.Equal(t, 8, resp.Usage.TotalTokens)
+	assert.Equal(t, "/openai/deployments/chat-prod/chat/completions", gotPath)
+	assert.Equal(t, "2025-01-01-preview", gotVersion)
+	assert.Equal(t, "azure-key", gotAPIKey)
+	assert.Empty(t, gotAuth)
+	assert.Len(t, gotBody["messages"], 2)
+
+	provider, err = NewAzureOpenAIProvider(AzureOpenAIConfig{
+		Endpoint:     server.URL,
+		Deployment:   "chat-prod",
+		AzureADToken: "entra-token",
+	})
+	require.NoError(t, err)
+
+	_, err = provider.Execute(context.Background(), &models.LLMRequest{Prompt: "Hello"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer entra-token", gotAuth)
+	assert.Empty(t, gotAPIKey)
+	assert.Equal(t, DefaultAzureOpenAIAPIVersion, gotVersion)
+}
+
+func TestAzureOpenAIProvider_Execute_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error": {"code": "DeploymentNotFound", "message": "The API deployment for this resource does not exist."}}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewAzureOpenAIProvider(AzureOpenAIConfig{Endpoint: server.URL, Deployment: "missing", APIKey: "key"})
+	require.NoError(t, err)
+
+	_, err = provider.Execute(context.Background(), &models.LLMRequest{Prompt: "Hello"})
+
+	var llmErr *models.LLMError
+	require.True(t, errors.As(err, &llmErr))
+	assert.Equal(t, models.LLMProviderAzureOpenAI, llmErr.Provider)
+	assert.Equal(t, "DeploymentNotFound", llmErr.Code)
+}
+
+func TestLLMExecutor_Validate_AzureOpenAI(t *testing.T) {
+	executor := NewLLMExecutor()
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{
+			name: "deployment with api key",
+			config: map[string]any{
+				"provider": "azure_openai", "prompt": "Hi", "base_url": "https://res.openai.azure.com",
+				"deployment": "gpt-4o", "api_key": "key",
+			},
+		},
+		{
+			name: "model as deployment with azure ad token",
+			config: map[string]any{
+				"provider": "azure_openai", "prompt": "Hi", "base_url": "https://res.openai.azure.com",
+				"model": "gpt-4o", "azure_ad_token": "{{env.AZURE_TOKEN}}",
+			},
+		},
+		{
+			name: "missing endpoint",
+			config: map[string]any{
+				"provider": "azure_openai", "prompt": "Hi", "deployment": "gpt-4o", "api_key": "key",
+			},
+			wantErr: "base_url",
+		},
+		{
+			name: "missing deployment",
+			config: map[string]any{
+				"provider": "azure_openai", "prompt": "Hi", "base_url": "https://res.openai.azure.com", "api_key": "key",
+			},
+			wantErr: "deployment or model",
+		},
+		{
+			name: "missing credentials",
+			config: map[string]any{
+				"provider": "azure_openai", "prompt": "Hi", "base_url": "https://res.openai.azure.com", "deployment": "gpt-4o",
+			},
+			wantErr: "api_key or azure_ad_token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := executor.Validate(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLLMExecutor_GetOrCreateProvider_AzureOpenAI(t *testing.T) {
+	executor := NewLLMExecutor()
+
+	req, err := executor.parseConfig(map[string]any{
+		"provider":       "azure_openai",
+		"model":          "gpt-4o",
+		"prompt":         "Hi",
+		"base_url":       "https://res.openai.azure.com",
+		"deployment":     "chat-prod",
+		"api_version":    "2025-01-01-preview",
+		"azure_ad_token": "entra-token",
+	})
+	require.NoError(t, err)
+
+	provider, err := executor.getOrCreateProvider(req)
+	require.NoError(t, err)
+
+	azure, ok := provider.(*AzureOpenAIProvider)
+	require.True(t, ok)
+	assert.Equal(t, "https://res.openai.azure.com/openai/deployments/chat-prod/chat/completions?api-version=2025-01-01-preview", azure.url)
+	assert.Equal(t, "entra-token", azure.azureADToken)
+}
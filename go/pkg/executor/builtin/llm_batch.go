@@ -0,0 +1,406 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// Batch modes of the llm executor.
+const (
+	// LLMBatchModeParallel sends one request per item with bounded concurrency and rate.
+	LLMBatchModeParallel = "parallel"
+	// LLMBatchModeProvider submits all items to the asynchronous batch API of the provider.
+	LLMBatchModeProvider = "provider"
+)
+
+const (
+	llmBatchDefaultConcurrency  = 4
+	llmBatchMaxItems            = 50000
+	llmBatchDefaultPollInterval = 30 * time.Second
+)
+
+// LLMBatchProvider is implemented by providers with an asynchronous batch API,
+// which is cheaper than individual requests for bulk work that can wait.
+type LLMBatchProvider interface {
+	ExecuteBatch(ctx context.Context, reqs []*models.LLMRequest, opts LLMBatchOptions) (*LLMBatchRun, error)
+}
+
+// LLMBatchOptions configures a provider batch.
+type LLMBatchOptions struct {
+	// PollInterval is how often the batch status is checked.
+	PollInterval time.Duration
+	// CompletionWindow is the time the provider has to finish the batch, e.g. "24h".
+	CompletionWindow string
+}
+
+// LLMBatchRun is a finished provider batch.
+type LLMBatchRun struct {
+	ID     string
+	Status string
+	// Results are aligned to the submitted requests.
+	Results []LLMBatchResult
+}
+
+// LLMBatchResult is the outcome of one request of a batch; exactly one field is set.
+type LLMBatchResult struct {
+	Response *models.LLMResponse
+	Err      error
+}
+
+// llmBatchConfig is the parsed batch block of an llm node.
+type llmBatchConfig struct {
+	Items            []any
+	ItemsPath        string
+	ItemField        string
+	Mode             string
+	Concurrency      int
+	RequestsPerMin   int
+	PollInterval     time.Duration
+	CompletionWindow string
+	FailOnError      bool
+}
+
+// parseBatchConfig parses and validates the batch block of an llm node.
+func parseBatchConfig(raw any) (*llmBatchConfig, error) {
+	obj, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("batch must be an object")
+	}
+
+	cfg := &llmBatchConfig{
+		Mode:             LLMBatchModeParallel,
+		Concurrency:      llmBatchDefaultConcurrency,
+		PollInterval:     llmBatchDefaultPollInterval,
+		CompletionWindow: "24h",
+	}
+
+	if value, ok := obj["items"]; ok && value != nil {
+		items, ok := value.([]any)
+		if !ok {
+			return nil, fmt.Errorf("batch.items must be an array")
+		}
+		cfg.Items = items
+	}
+	if value, ok := obj["items_path"]; ok {
+		path, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("batch.items_path must be a string")
+		}
+		cfg.ItemsPath = path
+	}
+	if cfg.Items != nil && cfg.ItemsPath != "" {
+		return nil, fmt.Errorf("batch.items and batch.items_path are mutually exclusive")
+	}
+	if value, ok := obj["item_field"]; ok {
+		field, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("batch.item_field must be a string")
+		}
+		cfg.ItemField = field
+	}
+
+	if value, ok := obj["mode"]; ok {
+		mode, _ := value.(string)
+		switch mode {
+		case LLMBatchModeParallel, LLMBatchModeProvider:
+			cfg.Mode = mode
+		default:
+			return nil, fmt.Errorf("batch.mode must be %q or %q", LLMBatchModeParallel, LLMBatchModeProvider)
+		}
+	}
+
+	var err error
+	if cfg.Concurrency, err = batchInt(obj, "concurrency", cfg.Concurrency); err != nil {
+		return nil, err
+	}
+	if cfg.Concurrency < 1 {
+		return nil, fmt.Errorf("batch.concurrency must be at least 1")
+	}
+	if cfg.RequestsPerMin, err = batchInt(obj, "requests_per_minute", 0); err != nil {
+		return nil, err
+	}
+	if cfg.RequestsPerMin < 0 {
+		return nil, fmt.Errorf("batch.requests_per_minute must not be negative")
+	}
+	pollSeconds, err := batchInt(obj, "poll_interval", int(llmBatchDefaultPollInterval/time.Second))
+	if err != nil {
+		return nil, err
+	}
+	if pollSeconds < 1 {
+		return nil, fmt.Errorf("batch.poll_interval must be at least 1 second")
+	}
+	cfg.PollInterval = time.Duration(pollSeconds) * time.Second
+
+	if value, ok := obj["completion_window"]; ok {
+		window, ok := value.(string)
+		if !ok || window == "" {
+			return nil, fmt.Errorf("batch.completion_window must be a non-empty string")
+		}
+		cfg.CompletionWindow = window
+	}
+	if value, ok := obj["fail_on_error"]; ok {
+		failOnError, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("batch.fail_on_error must be a boolean")
+		}
+		cfg.FailOnError = failOnError
+	}
+
+	return cfg, nil
+}
+
+// batchInt reads an integer field of the batch block.
+func batchInt(obj map[string]any, key string, defaultValue int) (int, error) {
+	switch v := obj[key].(type) {
+	case nil:
+		return defaultValue, nil
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != float64(int(v)) {
+			return 0, fmt.Errorf("batch.%s must be an integer", key)
+		}
+		return int(v), nil
+	default:
+		return 0, fmt.Errorf("batch.%s must be an integer", key)
+	}
+}
+
+// batchItems returns the items of a batch: the configured items, the array at
+// items_path in the input, or the input itself when it is an array.
+func (c *llmBatchConfig) batchItems(input any) ([]any, error) {
+	if c.Items != nil {
+		return c.Items, nil
+	}
+
+	value := input
+	if c.ItemsPath != "" {
+		for _, key := range strings.Split(c.ItemsPath, ".") {
+			obj, ok := value.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("batch.items_path %q not found in input", c.ItemsPath)
+			}
+			if value, ok = obj[key]; !ok {
+				return nil, fmt.Errorf("batch.items_path %q not found in input", c.ItemsPath)
+			}
+		}
+	}
+
+	items, ok := value.([]any)
+	if !ok {
+		if c.ItemsPath != "" {
+			return nil, fmt.Errorf("batch.items_path %q is not an array", c.ItemsPath)
+		}
+		return nil, fmt.Errorf("batch requires items, items_path or an array input")
+	}
+	return items, nil
+}
+
+// itemText renders an item for the prompt: strings as-is, item_field of objects,
+// and other values as JSON.
+func (c *llmBatchConfig) itemText(item any) (string, error) {
+	if c.ItemField != "" {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			return "", fmt.Errorf("item is not an object with field %q", c.ItemField)
+		}
+		value, ok := obj[c.ItemField]
+		if !ok {
+			return "", fmt.Errorf("item has no field %q", c.ItemField)
+		}
+		item = value
+	}
+
+	if s, ok := item.(string); ok {
+		return s, nil
+	}
+	data, err := json.Marshal(item)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode item: %w", err)
+	}
+	return string(data), nil
+}
+
+// executeBatch runs the request once per item and returns the results aligned to the items.
+func (e *LLMExecutor) executeBatch(ctx context.Context, req *models.LLMRequest, provider LLMProvider, cfg *llmBatchConfig, input any) (map[string]any, error) {
+	startTime := time.Now()
+
+	items, err := cfg.batchItems(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) > llmBatchMaxItems {
+		return nil, fmt.Errorf("batch has %d items, at most %d are allowed", len(items), llmBatchMaxItems)
+	}
+
+	results := make([]LLMBatchResult, len(items))
+	var reqs []*models.LLMRequest
+	var indexes []int
+	for i, item := range items {
+		text, err := cfg.itemText(item)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		itemReq := *req
+		if req.Prompt != "" {
+			itemReq.Prompt = req.Prompt + "\n\n" + text
+		} else {
+			itemReq.Prompt = text
+		}
+		reqs = append(reqs, &itemReq)
+		indexes = append(indexes, i)
+	}
+
+	output := map[string]any{"mode": cfg.Mode}
+	var run []LLMBatchResult
+	if cfg.Mode == LLMBatchModeProvider {
+		batchProvider, ok := provider.(LLMBatchProvider)
+		if !ok {
+			return nil, fmt.Errorf("provider %s has no batch API; use batch mode %q", req.Provider, LLMBatchModeParallel)
+		}
+		batch, err := batchProvider.ExecuteBatch(ctx, reqs, LLMBatchOptions{
+			PollInterval:     cfg.PollInterval,
+			CompletionWindow: cfg.CompletionWindow,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("LLM batch failed: %w", err)
+		}
+		output["batch_id"] = batch.ID
+		output["batch_status"] = batch.Status
+		run = batch.Results
+	} else {
+		run = e.executeParallel(ctx, reqs, provider, cfg)
+	}
+	for j, result := range run {
+		results[indexes[j]] = result
+	}
+
+	entries := make([]any, len(results))
+	var usage models.LLMUsage
+	succeeded, failed := 0, 0
+	var firstErr error
+	for i, result := range results {
+		if result.Err != nil || result.Response == nil {
+			failed++
+			errMsg := "no result"
+			if result.Err != nil {
+				errMsg = result.Err.Error()
+			}
+			if firstErr == nil {
+				firstErr = fmt.Errorf("item %d: %s", i, errMsg)
+			}
+			entries[i] = map[string]any{"index": i, "success": false, "error": errMsg}
+			continue
+		}
+		succeeded++
+		usage.PromptTokens += result.Response.Usage.PromptTokens
+		usage.CompletionTokens += result.Response.Usage.CompletionTokens
+		usage.TotalTokens += result.Response.Usage.TotalTokens
+
+		entry := e.responseToMap(result.Response, req.ResponseFormat)
+		entry["index"] = i
+		entry["success"] = true
+		entries[i] = entry
+	}
+
+	if cfg.FailOnError && firstErr != nil {
+		return nil, fmt.Errorf("LLM batch: %d of %d items failed, first: %w", failed, len(items), firstErr)
+	}
+
+	output["results"] = entries
+	output["total"] = len(items)
+	output["succeeded"] = succeeded
+	output["failed"] = failed
+	output["usage"] = map[string]any{
+		"prompt_tokens":     usage.PromptTokens,
+		"completion_tokens": usage.CompletionTokens,
+		"total_tokens":      usage.TotalTokens,
+	}
+	output["duration_ms"] = time.Since(startTime).Milliseconds()
+	return output, nil
+}
+
+// executeParallel sends the requests with at most cfg.Concurrency in flight and,
+// when requests_per_minute is set, spaces their start times evenly.
+func (e *LLMExecutor) executeParallel(ctx context.Context, reqs []*models.LLMRequest, provider LLMProvider, cfg *llmBatchConfig) []LLMBatchResult {
+	results := make([]LLMBatchResult, len(reqs))
+	limiter := newBatchLimiter(cfg.RequestsPerMin)
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, cfg.Concurrency)
+	for i, req := range reqs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			for j := i; j < len(reqs); j++ {
+				results[j].Err = ctx.Err()
+			}
+			wg.Wait()
+			return results
+		}
+		if err := limiter.wait(ctx); err != nil {
+			<-sem
+			for j := i; j < len(reqs); j++ {
+				results[j].Err = err
+			}
+			wg.Wait()
+			return results
+		}
+
+		wg.Add(1)
+		go func(i int, req *models.LLMRequest) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i].Response, results[i].Err = e.executeRequest(ctx, req, provider)
+		}(i, req)
+	}
+	wg.Wait()
+	return results
+}
+
+// batchLimiter spaces request starts evenly to stay under a rate per minute.
+type batchLimiter struct {
+	interval time.Duration
+	next     time.Time
+}
+
+func newBatchLimiter(perMinute int) *batchLimiter {
+	if perMinute <= 0 {
+		return &batchLimiter{}
+	}
+	return &batchLimiter{interval: time.Minute / time.Duration(perMinute)}
+}
+
+// wait blocks until the next request may start.
+func (l *batchLimiter) wait(ctx context.Context) error {
+	if l.interval == 0 {
+		return nil
+	}
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
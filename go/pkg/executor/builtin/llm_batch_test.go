@@ -0,0 +1,326 @@
+package builtin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLLMExecutor_Validate_Batch(t *testing.T) {
+	exec := NewLLMExecutor()
+	base := func(batch any) map[string]any {
+		return map[string]any{
+			"provider": "openai",
+			"model":    "gpt-4o-mini",
+			"prompt":   "Classify:",
+			"api_key":  "sk-test",
+			"batch":    batch,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{"valid parallel", base(map[string]any{"items_path": "reviews", "concurrency": 8, "requests_per_minute": 600}), ""},
+		{"valid provider", base(map[string]any{"mode": "provider", "poll_interval": 60}), ""},
+		{"valid mock", map[string]any{"provider": "mock", "prompt": "Classify:", "batch": map[string]any{"items": []any{"a"}}}, ""},
+		{"not an object", base("yes"), "batch must be an object"},
+		{"items not array", base(map[string]any{"items": "a,b"}), "batch.items must be an array"},
+		{"items and path", base(map[string]any{"items": []any{"a"}, "items_path": "x"}), "mutually exclusive"},
+		{"unknown mode", base(map[string]any{"mode": "turbo"}), "batch.mode"},
+		{"zero concurrency", base(map[string]any{"concurrency": 0}), "batch.concurrency"},
+		{"fractional concurrency", base(map[string]any{"concurrency": 1.5}), "must be an integer"},
+		{"negative rate", base(map[string]any{"requests_per_minute": -1}), "requests_per_minute"},
+		{"zero poll interval", base(map[string]any{"mode": "provider", "poll_interval": 0}), "poll_interval"},
+		{"provider mode on gemini", map[string]any{
+			"provider": "gemini", "model": "gemini-2.5-flash", "prompt": "x", "api_key": "k",
+			"batch": map[string]any{"mode": "provider"},
+		}, "only supported by the openai provider"},
+		{"provider mode with auto tools", func() map[string]any {
+			config := base(map[string]any{"mode": "provider"})
+			config["tool_call_config"] = map[string]any{"mode": "auto"}
+			return config
+		}(), "auto mode tool calling"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exec.Validate(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLLMExecutor_Batch_Parallel(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	provider := &MockLLMProvider{ExecuteFn: func(ctx context.Context, req *models.LLMRequest) (*models.LLMResponse, error) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			peak := maxInFlight.Load()
+			if current <= peak || maxInFlight.CompareAndSwap(peak, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		if strings.Contains(req.Prompt, "broken") {
+			return nil, errors.New("rate limited")
+		}
+		label := "negative"
+		if strings.Contains(req.Prompt, "great") {
+			label = "positive"
+		}
+		return &models.LLMResponse{
+			Content: label,
+			Model:   req.Model,
+			Usage:   models.LLMUsage{PromptTokens: 5, CompletionTokens: 1, TotalTokens: 6},
+		}, nil
+	}}
+	exec := NewLLMExecutor()
+	exec.RegisterProvider(models.LLMProviderOpenAI, provider)
+
+	input := map[string]any{"data": map[string]any{"reviews": []any{
+		map[string]any{"text": "great product"},
+		map[string]any{"text": "broken on arrival"},
+		map[string]any{"rating": 1},
+		map[string]any{"text": "awful"},
+		map[string]any{"text": "great value"},
+	}}}
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"provider": "openai",
+		"model":    "gpt-4o-mini",
+		"api_key":  "sk-test",
+		"prompt":   "Classify the sentiment:",
+		"batch": map[string]any{
+			"items_path":  "data.reviews",
+			"item_field":  "text",
+			"concurrency": 2,
+		},
+	}, input)
+	require.NoError(t, err)
+
+	output := result.(map[string]any)
+	assert.Equal(t, LLMBatchModeParallel, output["mode"])
+	assert.Equal(t, 5, output["total"])
+	assert.Equal(t, 3, output["succeeded"])
+	assert.Equal(t, 2, output["failed"])
+	assert.Equal(t, map[string]any{"prompt_tokens": 15, "completion_tokens": 3, "total_tokens": 18}, output["usage"])
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(2))
+
+	results := output["results"].([]any)
+	require.Len(t, results, 5)
+	for i, raw := range results {
+		assert.Equal(t, i, raw.(map[string]any)["index"])
+	}
+	assert.Equal(t, "positive", results[0].(map[string]any)["content"])
+	assert.Equal(t, true, results[0].(map[string]any)["success"])
+	assert.Equal(t, false, results[1].(map[string]any)["success"])
+	assert.Contains(t, results[1].(map[string]any)["error"], "rate limited")
+	assert.Contains(t, results[2].(map[string]any)["error"], `no field "text"`)
+	assert.Equal(t, "negative", results[3].(map[string]any)["content"])
+	assert.Equal(t, "positive", results[4].(map[string]any)["content"])
+}
+
+func TestLLMExecutor_Batch_PromptAndFailOnError(t *testing.T) {
+	var mu sync.Mutex
+	var prompts []string
+	provider := &MockLLMProvider{ExecuteFn: func(ctx context.Context, req *models.LLMRequest) (*models.LLMResponse, error) {
+		mu.Lock()
+		prompts = append(prompts, req.Prompt)
+		mu.Unlock()
+		if strings.Contains(req.Prompt, `"id":2`) {
+			return nil, errors.New("bad request")
+		}
+		return &models.LLMResponse{Content: "ok"}, nil
+	}}
+	exec := NewLLMExecutor()
+	exec.RegisterProvider(models.LLMProviderOpenAI, provider)
+
+	config := map[string]any{
+		"provider": "openai",
+		"model":    "gpt-4o-mini",
+		"api_key":  "sk-test",
+		"prompt":   "Summarize:",
+		"batch":    map[string]any{"concurrency": 1},
+	}
+	input := []any{"plain text", map[string]any{"id": 2}}
+
+	result, err := exec.Execute(context.Background(), config, input)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.(map[string]any)["failed"])
+	assert.Equal(t, []string{"Summarize:\n\nplain text", "Summarize:\n\n{\"id\":2}"}, prompts)
+
+	config["batch"] = map[string]any{"fail_on_error": true}
+	_, err = exec.Execute(context.Background(), config, input)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 2 items failed, first: item 1")
+
+	_, err = exec.Execute(context.Background(), config, map[string]any{"text": "not a list"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "array input")
+}
+
+func TestLLMExecutor_Batch_RateLimit(t *testing.T) {
+	exec := NewLLMExecutor()
+	exec.RegisterProvider(models.LLMProviderOpenAI, &MockLLMProvider{})
+
+	start := time.Now()
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"provider": "openai",
+		"model":    "gpt-4o-mini",
+		"api_key":  "sk-test",
+		"prompt":   "x",
+		"batch":    map[string]any{"items": []any{"a", "b", "c"}, "concurrency": 3, "requests_per_minute": 600},
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.(map[string]any)["succeeded"])
+	// 600 requests per minute start one request every 100ms
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
+
+func TestLLMExecutor_Batch_MockProvider(t *testing.T) {
+	exec := NewLLMExecutor()
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"provider": "mock",
+		"prompt":   "Classify:",
+		"mock": map[string]any{
+			"response": "neutral",
+			"rules":    []any{map[string]any{"contains": "love", "response": "positive"}},
+		},
+		"batch": map[string]any{"items": []any{"I love it", "It is a chair"}},
+	}, nil)
+	require.NoError(t, err)
+
+	results := result.(map[string]any)["results"].([]any)
+	assert.Equal(t, "positive", results[0].(map[string]any)["content"])
+	assert.Equal(t, "neutral", results[1].(map[string]any)["content"])
+}
+
+func TestLLMExecutor_Batch_ProviderModeRequiresBatchAPI(t *testing.T) {
+	exec := NewLLMExecutor()
+	exec.RegisterProvider(models.LLMProviderOpenAI, &MockLLMProvider{})
+
+	_, err := exec.Execute(context.Background(), map[string]any{
+		"provider": "openai",
+		"model":    "gpt-4o-mini",
+		"api_key":  "sk-test",
+		"prompt":   "x",
+		"batch":    map[string]any{"items": []any{"a"}, "mode": "provider"},
+	}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has no batch API")
+}
+
+// fakeOpenAIBatchAPI serves the files and batches endpoints of the OpenAI Batch API.
+// The batch completes on the second poll; the first request succeeds, the second is
+// in the error file and the third has no result, as when a batch expires.
+type fakeOpenAIBatchAPI struct {
+	mu       sync.Mutex
+	requests []map[string]any
+	polls    int
+}
+
+func (f *fakeOpenAIBatchAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if r.Header.Get("Authorization") != "Bearer sk-batch" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/files":
+		file, _, err := r.FormFile("file")
+		if err != nil || r.FormValue("purpose") != "batch" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			var line map[string]any
+			json.Unmarshal(scanner.Bytes(), &line)
+			f.requests = append(f.requests, line)
+		}
+		io.WriteString(w, `{"id":"file-in"}`)
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/batches":
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["input_file_id"] != "file-in" || body["endpoint"] != "/v1/chat/completions" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		io.WriteString(w, `{"id":"batch-1","status":"validating"}`)
+	case r.Method == http.MethodGet && r.URL.Path == "/v1/batches/batch-1":
+		f.polls++
+		if f.polls < 2 {
+			io.WriteString(w, `{"id":"batch-1","status":"in_progress"}`)
+			return
+		}
+		io.WriteString(w, `{"id":"batch-1","status":"expired","output_file_id":"file-out","error_file_id":"file-err"}`)
+	case r.URL.Path == "/v1/files/file-out/content":
+		fmt.Fprintln(w, `{"custom_id":"item-0","response":{"status_code":200,"body":{"id":"chatcmpl-1","model":"gpt-4o-mini","choices":[{"message":{"role":"assistant","content":"{\"label\":\"positive\"}"},"finish_reason":"stop"}],"usage":{"prompt_tokens":9,"completion_tokens":4,"total_tokens":13}}}}`)
+	case r.URL.Path == "/v1/files/file-err/content":
+		fmt.Fprintln(w, `{"custom_id":"item-1","response":{"status_code":400,"body":{"error":{"message":"Invalid prompt","type":"invalid_request_error","code":"invalid_prompt"}}}}`)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestLLMExecutor_Batch_OpenAIBatchAPI(t *testing.T) {
+	api := &fakeOpenAIBatchAPI{}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	exec := NewLLMExecutor()
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"provider":        "openai",
+		"model":           "gpt-4o-mini",
+		"api_key":         "sk-batch",
+		"base_url":        server.URL + "/v1",
+		"prompt":          "Classify:",
+		"response_format": map[string]any{"type": "json_object"},
+		"batch":           map[string]any{"mode": "provider", "poll_interval": 1},
+	}, []any{"love it", "???", "meh"})
+	require.NoError(t, err)
+
+	require.Len(t, api.requests, 3)
+	assert.Equal(t, "item-2", api.requests[2]["custom_id"])
+	assert.Equal(t, "/v1/chat/completions", api.requests[2]["url"])
+	messages := api.requests[2]["body"].(map[string]any)["messages"].([]any)
+	assert.Equal(t, "Classify:\n\nmeh", messages[0].(map[string]any)["content"])
+
+	output := result.(map[string]any)
+	assert.Equal(t, LLMBatchModeProvider, output["mode"])
+	assert.Equal(t, "batch-1", output["batch_id"])
+	assert.Equal(t, "expired", output["batch_status"])
+	assert.Equal(t, 1, output["succeeded"])
+	assert.Equal(t, 2, output["failed"])
+
+	results := output["results"].([]any)
+	assert.Equal(t, map[string]any{"label": "positive"}, results[0].(map[string]any)["content"])
+	assert.Contains(t, results[1].(map[string]any)["error"], "Invalid prompt")
+	assert.Contains(t, results[2].(map[string]any)["error"], "batch ended with status expired")
+	assert.Equal(t, map[string]any{"prompt_tokens": 9, "completion_tokens": 4, "total_tokens": 13}, output["usage"])
+}
@@ -0,0 +1,605 @@
+package builtin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// DefaultBedrockRegion is the AWS region used when a node sets none.
+const DefaultBedrockRegion = "us-east-1"
+
+// BedrockConfig configures an Amazon Bedrock provider.
+type BedrockConfig struct {
+	Region          string // AWS region; defaults to DefaultBedrockRegion
+	Endpoint        string // Overrides https://bedrock-runtime.<region>.amazonaws.com, e.g. for VPC endpoints
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // Set for temporary credentials
+	RoleARN         string // Role assumed with the keys above before calling Bedrock
+	ExternalID      string // External ID required by the role's trust policy
+	STSEndpoint     string // Overrides https://sts.<region>.amazonaws.com
+}
+
+// BedrockProvider implements the LLM provider for Amazon Bedrock using the Converse API,
+// which serves Claude, Titan, Llama and the other Bedrock models with one request format.
+// Requests are signed with AWS Signature Version 4.
+type BedrockProvider struct {
+	config   BedrockConfig
+	endpoint string
+	client   *http.Client
+}
+
+// NewBedrockProvider creates a new Bedrock provider with the given configuration.
+func NewBedrockProvider(cfg BedrockConfig) (*BedrockProvider, error) {
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, fmt.Errorf("access_key_id and secret_access_key are required for Bedrock provider")
+	}
+	if cfg.Region == "" {
+		cfg.Region = DefaultBedrockRegion
+	}
+
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://bedrock-runtime.%s.amazonaws.com", cfg.Region)
+	}
+
+	return &BedrockProvider{
+		config:   cfg,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client: &http.Client{
+			Timeout: 120 * time.Second,
+		},
+	}, nil
+}
+
+// Execute executes an LLM request using the Bedrock Converse API.
+// Requests with Stream set use ConverseStream and return the assembled response.
+func (p *BedrockProvider) Execute(ctx context.Context, req *models.LLMRequest) (*models.LLMResponse, error) {
+	if req.Stream {
+		return p.ExecuteStream(ctx, req, nil)
+	}
+
+	resp, err := p.send(ctx, req, "converse")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var apiResp bedrockConverseResponse
+	if err := json.Unmarshal(respBody, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	response := &models.LLMResponse{
+		ResponseID:   resp.Header.Get("X-Amzn-Requestid"),
+		Model:        req.Model,
+		FinishReason: p.normalizeStopReason(apiResp.StopReason),
+		CreatedAt:    time.Now(),
+		Usage:        apiResp.Usage.toLLMUsage(),
+	}
+	for _, block := range apiResp.Output.Message.Content {
+		response.Content += block.Text
+		if block.ToolUse != nil {
+			args, err := json.Marshal(block.ToolUse.Input)
+			if err != nil || block.ToolUse.Input == nil {
+				args = []byte("{}")
+			}
+			response.ToolCalls = append(response.ToolCalls, models.LLMToolCall{
+				ID:       block.ToolUse.ToolUseID,
+				Type:     "function",
+				Function: models.LLMFunctionCall{Name: block.ToolUse.Name, Arguments: string(args)},
+			})
+		}
+	}
+	return response, nil
+}
+
+// ExecuteStream executes an LLM request using the Bedrock ConverseStream API, calling
+// onDelta (if not nil) with each chunk of text as it is generated. The returned
+// response holds the assembled content, tool calls and usage.
+func (p *BedrockProvider) ExecuteStream(ctx context.Context, req *models.LLMRequest, onDelta func(delta string)) (*models.LLMResponse, error) {
+	resp, err := p.send(ctx, req, "converse-stream")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &models.LLMResponse{
+		ResponseID: resp.Header.Get("X-Amzn-Requestid"),
+		Model:      req.Model,
+		CreatedAt:  time.Now(),
+	}
+
+	var content strings.Builder
+	var toolCalls []*models.LLMToolCall
+	toolCallsByBlock := map[int]*models.LLMToolCall{}
+
+	reader := bufio.NewReader(resp.Body)
+	for {
+		message, err := readEventStreamMessage(reader)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response stream: %w", err)
+		}
+
+		switch message.headers[":message-type"] {
+		case "exception":
+			return nil, p.streamError(message.headers[":exception-type"], message.payload)
+		case "error":
+			return nil, &models.LLMError{
+				Provider: models.LLMProviderBedrock,
+				Code:     message.headers[":error-code"],
+				Message:  message.headers[":error-message"],
+			}
+		}
+
+		var event bedrockStreamEvent
+		if err := json.Unmarshal(message.payload, &event); err != nil {
+			return nil, fmt.Errorf("failed to parse stream event %s: %w", message.headers[":event-type"], err)
+		}
+
+		switch message.headers[":event-type"] {
+		case "contentBlockStart":
+			if event.Start.ToolUse != nil {
+				call := &models.LLMToolCall{
+					ID:       event.Start.ToolUse.ToolUseID,
+					Type:     "function",
+					Function: models.LLMFunctionCall{Name: event.Start.ToolUse.Name},
+				}
+				toolCallsByBlock[event.ContentBlockIndex] = call
+				toolCalls = append(toolCalls, call)
+			}
+		case "contentBlockDelta":
+			if event.Delta.Text != "" {
+				content.WriteString(event.Delta.Text)
+				if onDelta != nil {
+					onDelta(event.Delta.Text)
+				}
+			}
+			if event.Delta.ToolUse != nil {
+				if call, ok := toolCallsByBlock[event.ContentBlockIndex]; ok {
+					call.Function.Arguments += event.Delta.ToolUse.Input
+				}
+			}
+		case "messageStop":
+			response.FinishReason = p.normalizeStopReason(event.StopReason)
+		case "metadata":
+			response.Usage = event.Usage.toLLMUsage()
+		}
+	}
+
+	response.Content = content.String()
+	for _, call := range toolCalls {
+		if call.Function.Arguments == "" {
+			call.Function.Arguments = "{}"
+		}
+		response.ToolCalls = append(response.ToolCalls, *call)
+	}
+	return response, nil
+}
+
+// send signs and sends a Converse request to the given action of the model,
+// returning the response once its status is OK.
+func (p *BedrockProvider) send(ctx context.Context, req *models.LLMRequest, action string) (*http.Response, error) {
+	if len(req.ImageURLs) > 0 {
+		return nil, fmt.Errorf("image_url is not supported by the Bedrock provider: attach images as files")
+	}
+
+	creds, err := p.credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(p.buildRequestBody(req))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	// Model IDs contain ':' (e.g. anthropic.claude-3-5-sonnet-20240620-v1:0), which Bedrock expects escaped
+	url := fmt.Sprintf("%s/model/%s/%s", p.endpoint, awsEscape(req.Model), action)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	if action == "converse-stream" {
+		httpReq.Header.Set("Accept", "application/vnd.amazon.eventstream")
+	} else {
+		httpReq.Header.Set("Accept", "application/json")
+	}
+	executor.InjectHeaders(ctx, httpReq.Header)
+	signAWSRequest(httpReq, body, creds, p.config.Region, "bedrock", time.Now().UTC())
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, p.apiError(resp, respBody)
+	}
+	return resp, nil
+}
+
+// credentials returns the keys to sign Bedrock requests with, assuming the
+// configured role first when one is set.
+func (p *BedrockProvider) credentials(ctx context.Context) (awsCredentials, error) {
+	creds := awsCredentials{
+		AccessKeyID:     p.config.AccessKeyID,
+		SecretAccessKey: p.config.SecretAccessKey,
+		SessionToken:    p.config.SessionToken,
+	}
+	if p.config.RoleARN == "" {
+		return creds, nil
+	}
+	return assumeAWSRole(ctx, p.client, p.config.STSEndpoint, p.config.Region, creds, p.config.RoleARN, p.config.ExternalID)
+}
+
+// buildRequestBody builds the Converse API request body.
+func (p *BedrockProvider) buildRequestBody(req *models.LLMRequest) map[string]any {
+	body := map[string]any{}
+
+	var system []map[string]any
+	if req.Instruction != "" {
+		system = append(system, map[string]any{"text": req.Instruction})
+	}
+
+	// Converse has no JSON mode, so structured output is requested in the system prompt
+	if req.ResponseFormat != nil {
+		switch req.ResponseFormat.Type {
+		case "json_object":
+			system = append(system, map[string]any{"text": "Respond only with a single JSON object, without any other text."})
+		case "json_schema":
+			if req.ResponseFormat.JSONSchema != nil {
+				schema, _ := json.Marshal(req.ResponseFormat.JSONSchema.Schema)
+				system = append(system, map[string]any{"text": "Respond only with a single JSON object, without any other text, " +
+					"that matches this JSON schema: " + string(schema)})
+			}
+		}
+	}
+
+	var messages []map[string]any
+	if len(req.Messages) > 0 {
+		var historySystem []map[string]any
+		messages, historySystem = p.buildMessages(req.Messages)
+		system = append(historySystem, system...)
+	} else {
+		messages = []map[string]any{{"role": "user", "content": p.buildUserContent(req)}}
+	}
+	body["messages"] = messages
+	if len(system) > 0 {
+		body["system"] = system
+	}
+
+	inferenceConfig := map[string]any{}
+	if req.MaxTokens > 0 {
+		inferenceConfig["maxTokens"] = req.MaxTokens
+	}
+	if req.Temperature > 0 {
+		inferenceConfig["temperature"] = req.Temperature
+	}
+	if req.TopP > 0 {
+		inferenceConfig["topP"] = req.TopP
+	}
+	if len(req.StopSequences) > 0 {
+		inferenceConfig["stopSequences"] = req.StopSequences
+	}
+	if len(inferenceConfig) > 0 {
+		body["inferenceConfig"] = inferenceConfig
+	}
+
+	if len(req.Tools) > 0 {
+		tools := make([]map[string]any, len(req.Tools))
+		for i, tool := range req.Tools {
+			parameters := tool.Function.Parameters
+			if parameters == nil {
+				parameters = map[string]any{"type": "object", "properties": map[string]any{}}
+			}
+			tools[i] = map[string]any{
+				"toolSpec": map[string]any{
+					"name":        tool.Function.Name,
+					"description": tool.Function.Description,
+					"inputSchema": map[string]any{"json": parameters},
+				},
+			}
+		}
+		body["toolConfig"] = map[string]any{"tools": tools}
+	}
+
+	return body
+}
+
+// buildUserContent builds the user message content: the prompt, images and PDF documents.
+func (p *BedrockProvider) buildUserContent(req *models.LLMRequest) []map[string]any {
+	content := []map[string]any{}
+	if req.Prompt != "" {
+		content = append(content, map[string]any{"text": req.Prompt})
+	}
+
+	documents := 0
+	for _, file := range req.Files {
+		switch {
+		case file.IsImage():
+			content = append(content, map[string]any{
+				"image": map[string]any{
+					"format": strings.TrimPrefix(file.MimeType, "image/"),
+					"source": map[string]any{"bytes": file.Data},
+				},
+			})
+		case file.IsPDF():
+			// Document names may only hold letters, digits, spaces, hyphens, parentheses and brackets
+			documents++
+			content = append(content, map[string]any{
+				"document": map[string]any{
+					"format": "pdf",
+					"name":   fmt.Sprintf("document-%d", documents),
+					"source": map[string]any{"bytes": file.Data},
+				},
+			})
+		}
+	}
+
+	return content
+}
+
+// buildMessages converts the conversation history to Converse messages, returning system
+// messages separately. Converse requires alternating roles and tool results in user
+// messages, so consecutive messages of the same role are merged.
+func (p *BedrockProvider) buildMessages(history []models.LLMMessage) (messages, system []map[string]any) {
+	for _, msg := range history {
+		role := msg.Role
+		var content []map[string]any
+
+		switch msg.Role {
+		case "system":
+			system = append(system, map[string]any{"text": msg.Content})
+			continue
+		case "tool":
+			role = "user"
+			content = append(content, map[string]any{
+				"toolResult": map[string]any{
+					"toolUseId": msg.ToolCallID,
+					"content":   []map[string]any{{"text": msg.Content}},
+				},
+			})
+		default:
+			if msg.Content != "" {
+				content = append(content, map[string]any{"text": msg.Content})
+			}
+			for _, call := range msg.ToolCalls {
+				input := map[string]any{}
+				if call.Function.Arguments != "" {
+					_ = json.Unmarshal([]byte(call.Function.Arguments), &input)
+				}
+				content = append(content, map[string]any{
+					"toolUse": map[string]any{"toolUseId": call.ID, "name": call.Function.Name, "input": input},
+				})
+			}
+		}
+		if len(content) == 0 {
+			continue
+		}
+
+		if last := len(messages) - 1; last >= 0 && messages[last]["role"] == role {
+			messages[last]["content"] = append(messages[last]["content"].([]map[string]any), content...)
+			continue
+		}
+		messages = append(messages, map[string]any{"role": role, "content": content})
+	}
+	return messages, system
+}
+
+// apiError converts an error response to an LLM error. Bedrock reports the error type
+// in the X-Amzn-Errortype header, e.g. "ValidationException:http://internal.amazon.com/...".
+func (p *BedrockProvider) apiError(resp *http.Response, body []byte) error {
+	var errResp struct {
+		Message      string `json:"message"`
+		MessageUpper string `json:"Message"`
+	}
+	_ = json.Unmarshal(body, &errResp)
+	message := errResp.Message
+	if message == "" {
+		message = errResp.MessageUpper
+	}
+
+	errorType, _, _ := strings.Cut(resp.Header.Get("X-Amzn-Errortype"), ":")
+	if message == "" && errorType == "" {
+		return fmt.Errorf("Bedrock API error (status %d): %s", resp.StatusCode, string(body))
+	}
+	return &models.LLMError{
+		Provider: models.LLMProviderBedrock,
+		Code:     errorType,
+		Message:  message,
+		Type:     fmt.Sprintf("%d", resp.StatusCode),
+	}
+}
+
+// streamError converts an exception event of a response stream to an LLM error.
+func (p *BedrockProvider) streamError(exceptionType string, payload []byte) error {
+	var errResp struct {
+		Message string `json:"message"`
+	}
+	_ = json.Unmarshal(payload, &errResp)
+	return &models.LLMError{
+		Provider: models.LLMProviderBedrock,
+		Code:     exceptionType,
+		Message:  errResp.Message,
+	}
+}
+
+// normalizeStopReason maps Converse stop reasons to finish reasons.
+func (p *BedrockProvider) normalizeStopReason(reason string) string {
+	switch reason {
+	case "end_turn", "stop_sequence":
+		return "stop"
+	case "max_tokens":
+		return "length"
+	case "tool_use":
+		return "tool_calls"
+	case "guardrail_intervened", "content_filtered":
+		return "content_filter"
+	default:
+		return reason
+	}
+}
+
+// eventStreamMessage is a message of the AWS event stream encoding used by streaming APIs.
+type eventStreamMessage struct {
+	headers map[string]string
+	payload []byte
+}
+
+// readEventStreamMessage reads one message of an AWS event stream: a prelude with the total
+// and headers length and its CRC, the headers, the payload and a CRC of the whole message.
+// Only string header values are kept. io.EOF is returned at the end of the stream.
+func readEventStreamMessage(r io.Reader) (*eventStreamMessage, error) {
+	prelude := make([]byte, 12)
+	if _, err := io.ReadFull(r, prelude); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("truncated message prelude")
+		}
+		return nil, err
+	}
+	totalLength := binary.BigEndian.Uint32(prelude[0:4])
+	headersLength := binary.BigEndian.Uint32(prelude[4:8])
+	if crc32.ChecksumIEEE(prelude[0:8]) != binary.BigEndian.Uint32(prelude[8:12]) {
+		return nil, fmt.Errorf("message prelude checksum mismatch")
+	}
+	if totalLength < 16 || uint64(headersLength) > uint64(totalLength)-16 || totalLength > 16<<20 {
+		return nil, fmt.Errorf("invalid message length %d", totalLength)
+	}
+
+	rest := make([]byte, totalLength-12)
+	if _, err := io.ReadFull(r, rest); err != nil {
+		return nil, fmt.Errorf("truncated message: %w", err)
+	}
+	checksum := crc32.NewIEEE()
+	checksum.Write(prelude)
+	checksum.Write(rest[:len(rest)-4])
+	if checksum.Sum32() != binary.BigEndian.Uint32(rest[len(rest)-4:]) {
+		return nil, fmt.Errorf("message checksum mismatch")
+	}
+
+	headers, err := parseEventStreamHeaders(rest[:headersLength])
+	if err != nil {
+		return nil, err
+	}
+	return &eventStreamMessage{headers: headers, payload: rest[headersLength : len(rest)-4]}, nil
+}
+
+// eventStreamValueSizes are the sizes of the fixed-length header value types, by type code.
+var eventStreamValueSizes = map[byte]int{0: 0, 1: 0, 2: 1, 3: 2, 4: 4, 5: 8, 8: 8, 9: 16}
+
+// parseEventStreamHeaders parses the headers of an event stream message.
+func parseEventStreamHeaders(data []byte) (map[string]string, error) {
+	headers := map[string]string{}
+	for len(data) > 0 {
+		nameLength := int(data[0])
+		if len(data) < 1+nameLength+1 {
+			return nil, fmt.Errorf("truncated message header")
+		}
+		name := string(data[1 : 1+nameLength])
+		valueType := data[1+nameLength]
+		data = data[2+nameLength:]
+
+		switch valueType {
+		case 6, 7: // byte array, string
+			if len(data) < 2 {
+				return nil, fmt.Errorf("truncated message header %s", name)
+			}
+			valueLength := int(binary.BigEndian.Uint16(data[0:2]))
+			if len(data) < 2+valueLength {
+				return nil, fmt.Errorf("truncated message header %s", name)
+			}
+			if valueType == 7 {
+				headers[name] = string(data[2 : 2+valueLength])
+			}
+			data = data[2+valueLength:]
+		default:
+			size, ok := eventStreamValueSizes[valueType]
+			if !ok || len(data) < size {
+				return nil, fmt.Errorf("invalid message header %s", name)
+			}
+			data = data[size:]
+		}
+	}
+	return headers, nil
+}
+
+// Bedrock Converse API response types
+type bedrockConverseResponse struct {
+	Output struct {
+		Message struct {
+			Role    string                `json:"role"`
+			Content []bedrockContentBlock `json:"content"`
+		} `json:"message"`
+	} `json:"output"`
+	StopReason string       `json:"stopReason"`
+	Usage      bedrockUsage `json:"usage"`
+}
+
+type bedrockContentBlock struct {
+	Text    string          `json:"text,omitempty"`
+	ToolUse *bedrockToolUse `json:"toolUse,omitempty"`
+}
+
+type bedrockToolUse struct {
+	ToolUseID string         `json:"toolUseId"`
+	Name      string         `json:"name"`
+	Input     map[string]any `json:"input"`
+}
+
+type bedrockUsage struct {
+	InputTokens  int `json:"inputTokens"`
+	OutputTokens int `json:"outputTokens"`
+	TotalTokens  int `json:"totalTokens"`
+}
+
+func (u bedrockUsage) toLLMUsage() models.LLMUsage {
+	return models.LLMUsage{
+		PromptTokens:     u.InputTokens,
+		CompletionTokens: u.OutputTokens,
+		TotalTokens:      u.TotalTokens,
+	}
+}
+
+// bedrockStreamEvent holds the fields of the ConverseStream events.
+type bedrockStreamEvent struct {
+	ContentBlockIndex int `json:"contentBlockIndex"`
+	Start             struct {
+		ToolUse *struct {
+			ToolUseID string `json:"toolUseId"`
+			Name      string `json:"name"`
+		} `json:"toolUse"`
+	} `json:"start"`
+	Delta struct {
+		Text    string `json:"text"`
+		ToolUse *struct {
+			Input string `json:"input"`
+		} `json:"toolUse"`
+	} `json:"delta"`
+	StopReason string       `json:"stopReason"`
+	Usage      bedrockUsage `json:"usage"`
+}
@@ -0,0 +1,389 @@
+package builtin
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+const bedrockTestModel = "anthropic.claude-3-5-sonnet-20240620-v1:0"
+
+// encodeEventStreamMessage encodes a message in the AWS event stream format with string headers.
+func encodeEventStreamMessage(headers map[string]string, payload string) []byte {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var headerBytes bytes.Buffer
+	for _, name := range names {
+		headerBytes.WriteByte(byte(len(name)))
+		headerBytes.WriteString(name)
+		headerBytes.WriteByte(7)
+		_ = binary.Write(&headerBytes, binary.BigEndian, uint16(len(headers[name])))
+		headerBytes.WriteString(headers[name])
+	}
+
+	var message bytes.Buffer
+	_ = binary.Write(&message, binary.BigEndian, uint32(12+headerBytes.Len()+len(payload)+4))
+	_ = binary.Write(&message, binary.BigEndian, uint32(headerBytes.Len()))
+	_ = binary.Write(&message, binary.BigEndian, crc32.ChecksumIEEE(message.Bytes()[:8]))
+	message.Write(headerBytes.Bytes())
+	message.WriteString(payload)
+	_ = binary.Write(&message, binary.BigEndian, crc32.ChecksumIEEE(message.Bytes()))
+	return message.Bytes()
+}
+
+func bedrockEvent(eventType, payload string) []byte {
+	return encodeEventStreamMessage(map[string]string{
+		":message-type": "event",
+		":event-type":   eventType,
+		":content-type": "application/json",
+	}, payload)
+}
+
+func TestBedrockProvider_NewBedrockProvider(t *testing.T) {
+	provider, err := NewBedrockProvider(BedrockConfig{AccessKeyID: "AKID", SecretAccessKey: "secret"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://bedrock-runtime.us-east-1.amazonaws.com", provider.endpoint)
+
+	provider, err = NewBedrockProvider(BedrockConfig{AccessKeyID: "AKID", SecretAccessKey: "secret", Region: "eu-central-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://bedrock-runtime.eu-central-1.amazonaws.com", provider.endpoint)
+
+	_, err = NewBedrockProvider(BedrockConfig{AccessKeyID: "AKID"})
+	assert.ErrorContains(t, err, "secret_access_key")
+}
+
+func TestBedrockProvider_Execute(t *testing.T) {
+	var gotPath, gotAuth, gotToken string
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		gotAuth = r.Header.Get("Authorization")
+		gotToken = r.Header.Get("X-Amz-Security-Token")
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+
+		w.Header().Set("X-Amzn-Requestid", "req-1")
+		_, _ = w.Write([]byte(`{
+			"output": {"message": {"role": "assistant", "content": [
+				{"text": "Checking the weather."},
+				{"toolUse": {"toolUseId": "tool-1", "name": "get_weather", "input": {"city": "Paris"}}}
+			]}},
+			"stopReason": "tool_use",
+			"usage": {"inputTokens": 12, "outputTokens": 8, "totalTokens": 20}
+		}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewBedrockProvider(BedrockConfig{
+		Region: "us-west-2", Endpoint: server.URL, AccessKeyID: "AKID", SecretAccessKey: "secret", SessionToken: "token",
+	})
+	require.NoError(t, err)
+
+	resp, err := provider.Execute(context.Background(), &models.LLMRequest{
+		Model:         bedrockTestModel,
+		Instruction:   "Be brief",
+		Prompt:        "Weather in Paris?",
+		MaxTokens:     256,
+		Temperature:   0.5,
+		StopSequences: []string{"END"},
+		Tools: []models.LLMTool{{Type: "function", Function: models.LLMFunctionTool{
+			Name: "get_weather", Parameters: map[string]any{"type": "object"},
+		}}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "/model/anthropic.claude-3-5-sonnet-20240620-v1%3A0/converse", gotPath)
+	assert.True(t, strings.HasPrefix(gotAuth, "AWS4-HMAC-SHA256 Credential=AKID/"), gotAuth)
+	assert.Contains(t, gotAuth, "/us-west-2/bedrock/aws4_request")
+	assert.Equal(t, "token", gotToken)
+
+	assert.Equal(t, []any{map[string]any{"text": "Be brief"}}, gotBody["system"])
+	assert.Equal(t, []any{map[string]any{"role": "user", "content": []any{map[string]any{"text": "Weather in Paris?"}}}}, gotBody["messages"])
+	assert.Equal(t, map[string]any{"maxTokens": 256.0, "temperature": 0.5, "stopSequences": []any{"END"}}, gotBody["inferenceConfig"])
+	assert.NotNil(t, gotBody["toolConfig"])
+
+	assert.Equal(t, "Checking the weather.", resp.Content)
+	assert.Equal(t, "req-1", resp.ResponseID)
+	assert.Equal(t, bedrockTestModel, resp.Model)
+	assert.Equal(t, "tool_calls", resp.FinishReason)
+	assert.Equal(t, models.LLMUsage{PromptTokens: 12, CompletionTokens: 8, TotalTokens: 20}, resp.Usage)
+	require.Len(t, resp.ToolCalls, 1)
+	assert.Equal(t, "tool-1", resp.ToolCalls[0].ID)
+	assert.JSONEq(t, `{"city": "Paris"}`, resp.ToolCalls[0].Function.Arguments)
+}
+
+func TestBedrockProvider_Execute_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Amzn-Errortype", "AccessDeniedException:http://internal.amazon.com/coral/com.amazon.bedrock/")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "You don't have access to the model with the specified model ID."}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewBedrockProvider(BedrockConfig{Endpoint: server.URL, AccessKeyID: "AKID", SecretAccessKey: "secret"})
+	require.NoError(t, err)
+
+	_, err = provider.Execute(context.Background(), &models.LLMRequest{Model: bedrockTestModel, Prompt: "Hi"})
+
+	var llmErr *models.LLMError
+	require.True(t, errors.As(err, &llmErr))
+	assert.Equal(t, models.LLMProviderBedrock, llmErr.Provider)
+	assert.Equal(t, "AccessDeniedException", llmErr.Code)
+	assert.Contains(t, llmErr.Message, "don't have access")
+}
+
+func TestBedrockProvider_ExecuteStream(t *testing.T) {
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		w.Header().Set("Content-Type", "application/vnd.amazon.eventstream")
+		for _, event := range [][]byte{
+			bedrockEvent("messageStart", `{"role": "assistant"}`),
+			bedrockEvent("contentBlockDelta", `{"contentBlockIndex": 0, "delta": {"text": "Hello"}}`),
+			bedrockEvent("contentBlockDelta", `{"contentBlockIndex": 0, "delta": {"text": ", world"}}`),
+			bedrockEvent("contentBlockStop", `{"contentBlockIndex": 0}`),
+			bedrockEvent("contentBlockStart", `{"contentBlockIndex": 1, "start": {"toolUse": {"toolUseId": "tool-1", "name": "lookup"}}}`),
+			bedrockEvent("contentBlockDelta", `{"contentBlockIndex": 1, "delta": {"toolUse": {"input": "{\"q\":"}}}`),
+			bedrockEvent("contentBlockDelta", `{"contentBlockIndex": 1, "delta": {"toolUse": {"input": "\"go\"}"}}}`),
+			bedrockEvent("messageStop", `{"stopReason": "tool_use"}`),
+			bedrockEvent("metadata", `{"usage": {"inputTokens": 3, "outputTokens": 4, "totalTokens": 7}, "metrics": {"latencyMs": 120}}`),
+		} {
+			_, _ = w.Write(event)
+		}
+	}))
+	defer server.Close()
+
+	provider, err := NewBedrockProvider(BedrockConfig{Endpoint: server.URL, AccessKeyID: "AKID", SecretAccessKey: "secret"})
+	require.NoError(t, err)
+
+	var deltas []string
+	resp, err := provider.ExecuteStream(context.Background(), &models.LLMRequest{Model: bedrockTestModel, Prompt: "Hi"},
+		func(delta string) { deltas = append(deltas, delta) })
+
+	require.NoError(t, err)
+	assert.Equal(t, "/model/anthropic.claude-3-5-sonnet-20240620-v1%3A0/converse-stream", gotPath)
+	assert.Equal(t, []string{"Hello", ", world"}, deltas)
+	assert.Equal(t, "Hello, world", resp.Content)
+	assert.Equal(t, "tool_calls", resp.FinishReason)
+	assert.Equal(t, 7, resp.Usage.TotalTokens)
+	require.Len(t, resp.ToolCalls, 1)
+	assert.Equal(t, "lookup", resp.ToolCalls[0].Function.Name)
+	assert.Equal(t, `{"q":"go"}`, resp.ToolCalls[0].Function.Arguments)
+}
+
+func TestBedrockProvider_ExecuteStream_Exception(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(bedrockEvent("contentBlockDelta", `{"contentBlockIndex": 0, "delta": {"text": "Hel"}}`))
+		_, _ = w.Write(encodeEventStreamMessage(map[string]string{
+			":message-type":   "exception",
+			":exception-type": "throttlingException",
+		}, `{"message": "Too many requests"}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewBedrockProvider(BedrockConfig{Endpoint: server.URL, AccessKeyID: "AKID", SecretAccessKey: "secret"})
+	require.NoError(t, err)
+
+	_, err = provider.Execute(context.Background(), &models.LLMRequest{Model: bedrockTestModel, Prompt: "Hi", Stream: true})
+
+	var llmErr *models.LLMError
+	require.True(t, errors.As(err, &llmErr))
+	assert.Equal(t, "throttlingException", llmErr.Code)
+	assert.Equal(t, "Too many requests", llmErr.Message)
+}
+
+func TestBedrockProvider_AssumeRole(t *testing.T) {
+	var gotForm map[string][]string
+	sts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.Header.Get("Authorization"), "Credential=AKID/")
+		assert.Contains(t, r.Header.Get("Authorization"), "/eu-west-1/sts/aws4_request")
+		require.NoError(t, r.ParseForm())
+		gotForm = r.PostForm
+		_, _ = w.Write([]byte(`<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+			<AssumeRoleResult><Credentials>
+				<AccessKeyId>ASIAROLE</AccessKeyId>
+				<SecretAccessKey>role-secret</SecretAccessKey>
+				<SessionToken>role-token</SessionToken>
+				<Expiration>2030-01-01T00:00:00Z</Expiration>
+			</Credentials></AssumeRoleResult>
+		</AssumeRoleResponse>`))
+	}))
+	defer sts.Close()
+
+	var gotAuth, gotToken string
+	bedrock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotToken = r.Header.Get("X-Amz-Security-Token")
+		_, _ = w.Write([]byte(`{"output": {"message": {"content": [{"text": "ok"}]}}, "stopReason": "end_turn"}`))
+	}))
+	defer bedrock.Close()
+
+	provider, err := NewBedrockProvider(BedrockConfig{
+		Region: "eu-west-1", Endpoint: bedrock.URL, STSEndpoint: sts.URL,
+		AccessKeyID: "AKID", SecretAccessKey: "secret",
+		RoleARN: "arn:aws:iam::123456789012:role/bedrock-invoke", ExternalID: "ext-1",
+	})
+	require.NoError(t, err)
+
+	resp, err := provider.Execute(context.Background(), &models.LLMRequest{Model: bedrockTestModel, Prompt: "Hi"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp.Content)
+	assert.Equal(t, "stop", resp.FinishReason)
+	assert.Equal(t, []string{"AssumeRole"}, gotForm["Action"])
+	assert.Equal(t, []string{"arn:aws:iam::123456789012:role/bedrock-invoke"}, gotForm["RoleArn"])
+	assert.Equal(t, []string{"ext-1"}, gotForm["ExternalId"])
+	assert.Contains(t, gotAuth, "Credential=ASIAROLE/")
+	assert.Equal(t, "role-token", gotToken)
+}
+
+func TestBedrockProvider_BuildRequestBody_Messages(t *testing.T) {
+	provider := &BedrockProvider{}
+
+	body := provider.buildRequestBody(&models.LLMRequest{
+		Messages: []models.LLMMessage{
+			{Role: "system", Content: "Be brief"},
+			{Role: "user", Content: "Weather in Paris and Rome?"},
+			{Role: "assistant", ToolCalls: []models.LLMToolCall{
+				{ID: "t1", Function: models.LLMFunctionCall{Name: "get_weather", Arguments: `{"city":"Paris"}`}},
+				{ID: "t2", Function: models.LLMFunctionCall{Name: "get_weather", Arguments: `{"city":"Rome"}`}},
+			}},
+			{Role: "tool", ToolCallID: "t1", Content: "sunny"},
+			{Role: "tool", ToolCallID: "t2", Content: "rainy"},
+		},
+		ResponseFormat: &models.LLMResponseFormat{Type: "json_object"},
+	})
+
+	messages := body["messages"].([]map[string]any)
+	require.Len(t, messages, 3)
+	assert.Equal(t, "user", messages[0]["role"])
+	assert.Equal(t, "assistant", messages[1]["role"])
+	assert.Len(t, messages[1]["content"], 2)
+	assert.Equal(t, "user", messages[2]["role"])
+	assert.Len(t, messages[2]["content"], 2, "tool results are merged into one user message")
+
+	system := body["system"].([]map[string]any)
+	require.Len(t, system, 2)
+	assert.Equal(t, "Be brief", system[0]["text"])
+	assert.Contains(t, system[1]["text"], "JSON object")
+}
+
+func TestReadEventStreamMessage_ChecksumMismatch(t *testing.T) {
+	message := bedrockEvent("messageStop", `{"stopReason": "end_turn"}`)
+	message[len(message)-5] ^= 0xff
+
+	_, err := readEventStreamMessage(bytes.NewReader(message))
+	assert.ErrorContains(t, err, "checksum")
+
+	_, err = readEventStreamMessage(bytes.NewReader(nil))
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func newBedrockCredentials() *fakeCredentialResolver {
+	cred := models.NewCredentialsResource("owner-1", "aws", models.CredentialTypeCustom)
+	cred.DecryptedData = map[string]string{
+		"access_key_id":     "AKIDCRED",
+		"secret_access_key": "cred-secret",
+		"region":            "eu-central-1",
+	}
+	return &fakeCredentialResolver{creds: map[string]*models.CredentialsResource{"cred-aws": cred}}
+}
+
+func TestLLMExecutor_Validate_Bedrock(t *testing.T) {
+	exec := NewLLMExecutor()
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{
+			name:   "credential reference",
+			config: map[string]any{"provider": "bedrock", "model": bedrockTestModel, "prompt": "Hi", "credential_id": "cred-aws", "region": "us-west-2"},
+		},
+		{
+			name:    "missing credential",
+			config:  map[string]any{"provider": "bedrock", "model": bedrockTestModel, "prompt": "Hi"},
+			wantErr: "credential_id",
+		},
+		{
+			name: "inline secret",
+			config: map[string]any{
+				"provider": "bedrock", "model": bedrockTestModel, "prompt": "Hi", "credential_id": "cred-aws", "secret_access_key": "x",
+			},
+			wantErr: "must not be set inline",
+		},
+		{
+			name:    "missing model",
+			config:  map[string]any{"provider": "bedrock", "prompt": "Hi", "credential_id": "cred-aws"},
+			wantErr: "model",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exec.Validate(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLLMExecutor_Execute_Bedrock(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"output": {"message": {"content": [{"text": "Bonjour"}]}}, "stopReason": "end_turn",
+			"usage": {"inputTokens": 2, "outputTokens": 1, "totalTokens": 3}}`))
+	}))
+	defer server.Close()
+
+	exec := NewLLMExecutor()
+	exec.SetCredentialResolver(newBedrockCredentials())
+
+	config := map[string]any{
+		"provider":      "bedrock",
+		"model":         bedrockTestModel,
+		"prompt":        "Say hello in French",
+		"credential_id": "cred-aws",
+		"base_url":      server.URL,
+	}
+	result, err := exec.Execute(context.Background(), config, nil)
+
+	require.NoError(t, err)
+	output := result.(map[string]any)
+	assert.Equal(t, "Bonjour", output["content"])
+	assert.Contains(t, gotAuth, "Credential=AKIDCRED/")
+	assert.Contains(t, gotAuth, "/eu-central-1/bedrock/", "region comes from the credential")
+
+	config["region"] = "us-west-2"
+	_, err = exec.Execute(context.Background(), config, nil)
+	require.NoError(t, err)
+	assert.Contains(t, gotAuth, "/us-west-2/bedrock/", "the node region overrides the credential")
+
+	// Within an execution the credential must be attached to the workflow
+	ctx := executor.WithExecutionContext(context.Background(), &executor.ExecutionContextData{Resources: map[string]any{}})
+	_, err = exec.Execute(ctx, config, nil)
+	assert.ErrorContains(t, err, "not attached")
+}
@@ -0,0 +1,227 @@
+package builtin
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// DefaultLLMCacheTTL is how long cached responses are kept when neither the node nor
+// SetResponseCache sets a TTL.
+const DefaultLLMCacheTTL = 24 * time.Hour
+
+// LLMResponseCache stores serialized LLM responses of nodes that opt into caching.
+type LLMResponseCache interface {
+	// Get returns the value stored under key; ok is false on a miss
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+
+	// Set stores value under key for ttl
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// llmCacheSecretKeys are provider config entries that are not serializable and do not
+// change the response.
+var llmCacheSecretKeys = []string{"aws_credentials"}
+
+// parseCacheConfig parses the cache field of an llm node: true, or an object with an
+// optional ttl in seconds.
+func parseCacheConfig(raw any) (*models.LLMCacheConfig, error) {
+	switch v := raw.(type) {
+	case bool:
+		if !v {
+			return nil, nil
+		}
+		return &models.LLMCacheConfig{}, nil
+	case map[string]any:
+		ttl, err := batchInt(v, "ttl", 0)
+		if err != nil {
+			return nil, fmt.Errorf("cache.ttl must be an integer")
+		}
+		if ttl < 0 {
+			return nil, fmt.Errorf("cache.ttl must not be negative")
+		}
+		return &models.LLMCacheConfig{TTL: ttl}, nil
+	default:
+		return nil, fmt.Errorf("cache must be a boolean or an object")
+	}
+}
+
+// llmCacheKey returns the cache key of a request: a hash of everything that shapes the
+// response, including the provider config (API key, endpoint), so tenants with their own
+// keys never share entries. Execution metadata, streaming and the cache options are left out.
+func llmCacheKey(req *models.LLMRequest) (string, error) {
+	keyed := *req
+	keyed.Metadata = nil
+	keyed.Stream = false
+	keyed.Cache = nil
+
+	providerConfig := maps.Clone(req.ProviderConfig)
+	for _, key := range llmCacheSecretKeys {
+		delete(providerConfig, key)
+	}
+	keyed.ProviderConfig = providerConfig
+
+	data, err := json.Marshal(keyed)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash request: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf("llm:%s:%s", req.Provider, hex.EncodeToString(sum[:])), nil
+}
+
+// cacheable reports whether the response of a request may be served from the cache.
+// Auto mode tool calling runs tools with side effects and background responses are
+// polled later, so both always reach the provider.
+func (e *LLMExecutor) cacheable(req *models.LLMRequest) bool {
+	if req.Cache == nil || req.Background {
+		return false
+	}
+	if req.ToolCallConfig != nil && req.ToolCallConfig.Mode == models.ToolCallModeAuto {
+		return false
+	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.cache != nil
+}
+
+// executeCached serves a request from the response cache, sending it to the provider and
+// storing the response on a miss. Cache failures never fail the node: the request is sent
+// as if nothing was cached.
+func (e *LLMExecutor) executeCached(ctx context.Context, req *models.LLMRequest, provider LLMProvider) (*models.LLMResponse, error) {
+	e.mu.RLock()
+	cache, ttl := e.cache, e.cacheTTL
+	e.mu.RUnlock()
+	if req.Cache.TTL > 0 {
+		ttl = time.Duration(req.Cache.TTL) * time.Second
+	}
+
+	key, err := llmCacheKey(req)
+	if err != nil {
+		return e.send(ctx, req, provider)
+	}
+
+	if value, ok, err := cache.Get(ctx, key); err == nil && ok {
+		var cached models.LLMResponse
+		if err := json.Unmarshal(value, &cached); err == nil {
+			return cachedResponse(ctx, req, &cached), nil
+		}
+	}
+
+	response, err := e.send(ctx, req, provider)
+	if err != nil {
+		return nil, err
+	}
+	if value, err := json.Marshal(response); err == nil {
+		_ = cache.Set(ctx, key, value, ttl)
+	}
+	return response, nil
+}
+
+// cachedResponse prepares a cached response for the node output: usage is zeroed as the
+// provider was not called, and metadata marks the hit. Streamed requests receive the
+// content as one chunk.
+func cachedResponse(ctx context.Context, req *models.LLMRequest, response *models.LLMResponse) *models.LLMResponse {
+	metadata := maps.Clone(response.Metadata)
+	if metadata == nil {
+		metadata = make(map[string]any)
+	}
+	metadata["cached"] = true
+	metadata["cached_at"] = response.CreatedAt
+	response.Metadata = metadata
+	response.Usage = models.LLMUsage{}
+
+	if req.Stream && response.Content != "" {
+		if onDelta := executor.OutputDeltaFunc(ctx); onDelta != nil {
+			onDelta(response.Content)
+		}
+	}
+	return response
+}
+
+// MemoryLLMCache is an in-process LLMResponseCache that evicts the least recently used
+// entry when full. Entries are lost on restart and not shared between instances.
+type MemoryLLMCache struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // front = most recently used
+}
+
+type memoryLLMCacheEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemoryLLMCache creates an in-memory cache holding up to maxEntries responses
+// (unlimited when maxEntries <= 0).
+func NewMemoryLLMCache(maxEntries int) *MemoryLLMCache {
+	return &MemoryLLMCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Get returns the value stored under key unless it has expired.
+func (c *MemoryLLMCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := elem.Value.(*memoryLLMCacheEntry)
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return nil, false, nil
+	}
+	c.order.MoveToFront(elem)
+	return entry.value, true, nil
+}
+
+// Set stores value under key for ttl (no expiry when ttl <= 0).
+func (c *MemoryLLMCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*memoryLLMCacheEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(elem)
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(&memoryLLMCacheEntry{key: key, value: value, expiresAt: expiresAt})
+	for c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryLLMCacheEntry).key)
+	}
+	return nil
+}
+
+// Len returns the number of stored entries, including expired ones not yet evicted.
+func (c *MemoryLLMCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
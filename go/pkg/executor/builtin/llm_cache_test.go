@@ -0,0 +1,144 @@
+package builtin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func countingProvider(calls *int) *MockLLMProvider {
+	return &MockLLMProvider{
+		ExecuteFn: func(ctx context.Context, req *models.LLMRequest) (*models.LLMResponse, error) {
+			*calls++
+			return &models.LLMResponse{
+				Content:   "deterministic answer",
+				Model:     req.Model,
+				Usage:     models.LLMUsage{PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5},
+				CreatedAt: time.Now(),
+			}, nil
+		},
+	}
+}
+
+func TestLLMExecutor_ResponseCache_HitSkipsProvider(t *testing.T) {
+	exec := NewLLMExecutor()
+	exec.SetResponseCache(NewMemoryLLMCache(10), time.Hour)
+
+	calls := 0
+	provider := countingProvider(&calls)
+	req := func() *models.LLMRequest {
+		return &models.LLMRequest{Provider: "custom", Model: "m", Prompt: "2+2?", Cache: &models.LLMCacheConfig{}}
+	}
+
+	first, err := exec.executeRequest(context.Background(), req(), provider)
+	require.NoError(t, err)
+	assert.Equal(t, 5, first.Usage.TotalTokens)
+
+	second, err := exec.executeRequest(context.Background(), req(), provider)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, "deterministic answer", second.Content)
+	assert.Equal(t, models.LLMUsage{}, second.Usage)
+	assert.Equal(t, true, second.Metadata["cached"])
+}
+
+func TestLLMExecutor_ResponseCache_KeyedOnRequest(t *testing.T) {
+	exec := NewLLMExecutor()
+	exec.SetResponseCache(NewMemoryLLMCache(10), time.Hour)
+
+	calls := 0
+	provider := countingProvider(&calls)
+
+	for _, prompt := range []string{"a", "b"} {
+		_, err := exec.executeRequest(context.Background(), &models.LLMRequest{
+			Provider: "custom", Model: "m", Prompt: prompt, Cache: &models.LLMCacheConfig{},
+		}, provider)
+		require.NoError(t, err)
+	}
+	_, err := exec.executeRequest(context.Background(), &models.LLMRequest{
+		Provider: "custom", Model: "m", Prompt: "a",
+		ProviderConfig: map[string]any{"api_key": "other-tenant"}, Cache: &models.LLMCacheConfig{},
+	}, provider)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, calls)
+}
+
+func TestLLMExecutor_ResponseCache_NotUsedWithoutOptIn(t *testing.T) {
+	exec := NewLLMExecutor()
+	exec.SetResponseCache(NewMemoryLLMCache(10), time.Hour)
+
+	calls := 0
+	provider := countingProvider(&calls)
+	for i := 0; i < 2; i++ {
+		_, err := exec.executeRequest(context.Background(), &models.LLMRequest{Provider: "custom", Model: "m", Prompt: "x"}, provider)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, calls)
+}
+
+func TestLLMExecutor_ResponseCache_ErrorsNotCached(t *testing.T) {
+	exec := NewLLMExecutor()
+	cache := NewMemoryLLMCache(10)
+	exec.SetResponseCache(cache, time.Hour)
+
+	provider := &MockLLMProvider{
+		ExecuteFn: func(ctx context.Context, req *models.LLMRequest) (*models.LLMResponse, error) {
+			return nil, errors.New("rate limited")
+		},
+	}
+	_, err := exec.executeRequest(context.Background(), &models.LLMRequest{
+		Provider: "custom", Model: "m", Prompt: "x", Cache: &models.LLMCacheConfig{},
+	}, provider)
+
+	require.Error(t, err)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestParseCacheConfig(t *testing.T) {
+	cfg, err := parseCacheConfig(true)
+	require.NoError(t, err)
+	assert.Equal(t, &models.LLMCacheConfig{}, cfg)
+
+	cfg, err = parseCacheConfig(false)
+	require.NoError(t, err)
+	assert.Nil(t, cfg)
+
+	cfg, err = parseCacheConfig(map[string]any{"ttl": float64(600)})
+	require.NoError(t, err)
+	assert.Equal(t, 600, cfg.TTL)
+
+	_, err = parseCacheConfig(map[string]any{"ttl": float64(-1)})
+	assert.Error(t, err)
+
+	_, err = parseCacheConfig("yes")
+	assert.Error(t, err)
+}
+
+func TestMemoryLLMCache_EvictsAndExpires(t *testing.T) {
+	cache := NewMemoryLLMCache(2)
+	ctx := context.Background()
+
+	require.NoError(t, cache.Set(ctx, "a", []byte("1"), time.Hour))
+	require.NoError(t, cache.Set(ctx, "b", []byte("2"), time.Hour))
+	_, _, _ = cache.Get(ctx, "a")
+	require.NoError(t, cache.Set(ctx, "c", []byte("3"), time.Hour))
+
+	_, ok, _ := cache.Get(ctx, "b")
+	assert.False(t, ok, "least recently used entry is evicted")
+	value, ok, _ := cache.Get(ctx, "a")
+	assert.True(t, ok)
+	assert.Equal(t, "1", string(value))
+
+	require.NoError(t, cache.Set(ctx, "d", []byte("4"), time.Nanosecond))
+	time.Sleep(time.Millisecond)
+	_, ok, _ = cache.Get(ctx, "d")
+	assert.False(t, ok, "expired entry is not served")
+}
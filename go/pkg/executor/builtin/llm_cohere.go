@@ -0,0 +1,334 @@
+package builtin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// DefaultCohereBaseURL is the Cohere API endpoint used when a node sets no base_url.
+const DefaultCohereBaseURL = "https://api.cohere.com/v2"
+
+// CohereProvider implements the LLM provider for Cohere using the v2 Chat API.
+type CohereProvider struct {
+	apiKey  string
+	baseURL string
+	client  *http.Client
+}
+
+// NewCohereProvider creates a new Cohere provider with the given configuration.
+func NewCohereProvider(apiKey, baseURL string) (*CohereProvider, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("api_key is required for Cohere provider")
+	}
+
+	if baseURL == "" {
+		baseURL = DefaultCohereBaseURL
+	}
+
+	return &CohereProvider{
+		apiKey:  apiKey,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client: &http.Client{
+			Timeout: 120 * time.Second,
+		},
+	}, nil
+}
+
+// Execute executes an LLM request using Cohere.
+func (p *CohereProvider) Execute(ctx context.Context, req *models.LLMRequest) (*models.LLMResponse, error) {
+	// Build request body
+	reqBody := p.buildRequestBody(req)
+
+	// Marshal to JSON
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	// Create HTTP request
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", p.baseURL+"/chat", bytes.NewReader(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+p.apiKey)
+	executor.InjectHeaders(ctx, httpReq.Header)
+
+	// Execute request
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Check for errors
+	if resp.StatusCode != http.StatusOK {
+		var errorResp cohereErrorResponse
+		if err := json.Unmarshal(respBody, &errorResp); err == nil && errorResp.Message != "" {
+			return nil, &models.LLMError{
+				Provider: models.LLMProviderCohere,
+				Code:     fmt.Sprintf("%d", resp.StatusCode),
+				Message:  errorResp.Message,
+			}
+		}
+		return nil, fmt.Errorf("Cohere API error (status %d): %s", resp.StatusCode, string(respBody))
+	}
+
+	// Parse response
+	var apiResp cohereChatResponse
+	if err := json.Unmarshal(respBody, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// Convert to our model
+	return p.convertResponse(&apiResp, req), nil
+}
+
+// buildRequestBody builds the Cohere Chat API request body.
+func (p *CohereProvider) buildRequestBody(req *models.LLMRequest) map[string]any {
+	body := map[string]any{
+		"model": req.Model,
+	}
+
+	// Build messages
+	messages := []map[string]any{}
+
+	// System message (instruction)
+	if req.Instruction != "" {
+		messages = append(messages, map[string]any{
+			"role":    "system",
+			"content": req.Instruction,
+		})
+	}
+
+	// User message with multimodal support
+	messages = append(messages, map[string]any{
+		"role":    "user",
+		"content": p.buildUserContent(req),
+	})
+
+	body["messages"] = messages
+
+	// Optional parameters
+	if req.MaxTokens > 0 {
+		body["max_tokens"] = req.MaxTokens
+	}
+	if req.Temperature > 0 {
+		body["temperature"] = req.Temperature
+	}
+	if req.TopP > 0 {
+		body["p"] = req.TopP
+	}
+	if req.FrequencyPenalty != 0 {
+		body["frequency_penalty"] = req.FrequencyPenalty
+	}
+	if req.PresencePenalty != 0 {
+		body["presence_penalty"] = req.PresencePenalty
+	}
+	if len(req.StopSequences) > 0 {
+		body["stop_sequences"] = req.StopSequences
+	}
+
+	// Tools (function calling) use the OpenAI function format
+	if len(req.Tools) > 0 {
+		tools := make([]map[string]any, len(req.Tools))
+		for i, tool := range req.Tools {
+			tools[i] = map[string]any{
+				"type": "function",
+				"function": map[string]any{
+					"name":        tool.Function.Name,
+					"description": tool.Function.Description,
+					"parameters":  tool.Function.Parameters,
+				},
+			}
+		}
+		body["tools"] = tools
+	}
+
+	// Response format: Cohere has one JSON mode, optionally constrained by a schema
+	if req.ResponseFormat != nil {
+		if req.ResponseFormat.Type == "json_object" {
+			body["response_format"] = map[string]any{"type": "json_object"}
+		} else if req.ResponseFormat.Type == "json_schema" && req.ResponseFormat.JSONSchema != nil {
+			body["response_format"] = map[string]any{
+				"type":        "json_object",
+				"json_schema": req.ResponseFormat.JSONSchema.Schema,
+			}
+		}
+	}
+
+	return body
+}
+
+// buildUserContent builds the user message content with image support.
+func (p *CohereProvider) buildUserContent(req *models.LLMRequest) any {
+	// If no images, just return text
+	hasImages := len(req.ImageURLs) > 0
+	for _, file := range req.Files {
+		if file.IsImage() {
+			hasImages = true
+		}
+	}
+	if !hasImages {
+		return req.Prompt
+	}
+
+	content := []map[string]any{}
+
+	// Add text
+	if req.Prompt != "" {
+		content = append(content, map[string]any{
+			"type": "text",
+			"text": req.Prompt,
+		})
+	}
+
+	// Add images from URLs
+	for _, imageURL := range req.ImageURLs {
+		content = append(content, map[string]any{
+			"type":      "image_url",
+			"image_url": map[string]any{"url": imageURL},
+		})
+	}
+
+	// Add base64 encoded images as data URLs; Cohere does not accept PDFs
+	for _, file := range req.Files {
+		if !file.IsImage() {
+			continue
+		}
+		imageURL := map[string]any{"url": "data:" + file.MimeType + ";base64," + file.Data}
+		if file.Detail != "" {
+			imageURL["detail"] = file.Detail
+		}
+		content = append(content, map[string]any{
+			"type":      "image_url",
+			"image_url": imageURL,
+		})
+	}
+
+	return content
+}
+
+// convertResponse converts Cohere API response to our model.
+func (p *CohereProvider) convertResponse(resp *cohereChatResponse, req *models.LLMRequest) *models.LLMResponse {
+	// Extract text content
+	var content strings.Builder
+	for _, part := range resp.Message.Content {
+		if part.Type == "text" {
+			content.WriteString(part.Text)
+		}
+	}
+
+	// Billed units are what Cohere charges for; the token counts also include its prompt template
+	usage := resp.Usage.BilledUnits
+	if usage.InputTokens == 0 && usage.OutputTokens == 0 {
+		usage = resp.Usage.Tokens
+	}
+	promptTokens := int(usage.InputTokens)
+	completionTokens := int(usage.OutputTokens)
+
+	response := &models.LLMResponse{
+		Content:      content.String(),
+		ResponseID:   resp.ID,
+		Model:        req.Model, // Cohere does not echo the model
+		FinishReason: p.normalizeFinishReason(resp.FinishReason),
+		CreatedAt:    time.Now(),
+		Usage: models.LLMUsage{
+			PromptTokens:     promptTokens,
+			CompletionTokens: completionTokens,
+			TotalTokens:      promptTokens + completionTokens,
+		},
+	}
+
+	// Convert tool calls
+	if len(resp.Message.ToolCalls) > 0 {
+		response.ToolCalls = make([]models.LLMToolCall, len(resp.Message.ToolCalls))
+		for i, tc := range resp.Message.ToolCalls {
+			response.ToolCalls[i] = models.LLMToolCall{
+				ID:   tc.ID,
+				Type: "function",
+				Function: models.LLMFunctionCall{
+					Name:      tc.Function.Name,
+					Arguments: tc.Function.Arguments,
+				},
+			}
+		}
+	}
+
+	return response
+}
+
+// normalizeFinishReason normalizes Cohere finish reasons to our standard format.
+func (p *CohereProvider) normalizeFinishReason(reason string) string {
+	switch strings.ToUpper(reason) {
+	case "COMPLETE", "STOP_SEQUENCE":
+		return "stop"
+	case "MAX_TOKENS":
+		return "length"
+	case "TOOL_CALL":
+		return "tool_calls"
+	default:
+		return strings.ToLower(reason)
+	}
+}
+
+// Cohere API response types
+type cohereChatResponse struct {
+	ID           string        `json:"id"`
+	FinishReason string        `json:"finish_reason"`
+	Message      cohereMessage `json:"message"`
+	Usage        cohereUsage   `json:"usage"`
+}
+
+type cohereMessage struct {
+	Role      string              `json:"role"`
+	Content   []cohereContentPart `json:"content"`
+	ToolPlan  string              `json:"tool_plan,omitempty"`
+	ToolCalls []cohereToolCall    `json:"tool_calls,omitempty"`
+}
+
+type cohereContentPart struct {
+	Type string `json:"type"`
+	Text string `json:"text,omitempty"`
+}
+
+type cohereToolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+type cohereUsage struct {
+	BilledUnits cohereTokenCounts `json:"billed_units"`
+	Tokens      cohereTokenCounts `json:"tokens"`
+}
+
+// cohereTokenCounts are documented as numbers, not integers.
+type cohereTokenCounts struct {
+	InputTokens  float64 `json:"input_tokens"`
+	OutputTokens float64 `json:"output_tokens"`
+}
+
+type cohereErrorResponse struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,178 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCohereProvider_NewCohereProvider(t *testing.T) {
+	_, err := NewCohereProvider("", "")
+	assert.ErrorContains(t, err, "api_key")
+
+	provider, err := NewCohereProvider("key", "")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultCohereBaseURL, provider.baseURL)
+}
+
+func TestCohereProvider_Execute(t *testing.T) {
+	var gotPath, gotAuth string
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"id": "c14c80c3",
+			"finish_reason": "COMPLETE",
+			"message": {"role": "assistant", "content": [{"type": "text", "text": "{\"sentiment\":"}, {"type": "text", "text": "\"positive\"}"}]},
+			"usage": {"billed_units": {"input_tokens": 12, "output_tokens": 6}, "tokens": {"input_tokens": 210.0, "output_tokens": 6.0}}
+		}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewCohereProvider("cohere-key", server.URL+"/v2")
+	require.NoError(t, err)
+
+	resp, err := provider.Execute(context.Background(), &models.LLMRequest{
+		Model:         "command-r-plus",
+		Instruction:   "Classify sentiment",
+		Prompt:        "I love it",
+		MaxTokens:     100,
+		Temperature:   0.2,
+		TopP:          0.9,
+		StopSequences: []string{"END"},
+		ResponseFormat: &models.LLMResponseFormat{
+			Type:       "json_schema",
+			JSONSchema: &models.LLMJSONSchema{Name: "sentiment", Schema: map[string]any{"type": "object"}},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, `{"sentiment":"positive"}`, resp.Content)
+	assert.Equal(t, "command-r-plus", resp.Model)
+	assert.Equal(t, "stop", resp.FinishReason)
+	assert.Equal(t, models.LLMUsage{PromptTokens: 12, CompletionTokens: 6, TotalTokens: 18}, resp.Usage)
+
+	assert.Equal(t, "/v2/chat", gotPath)
+	assert.Equal(t, "Bearer cohere-key", gotAuth)
+	assert.Equal(t, "command-r-plus", gotBody["model"])
+	assert.Equal(t, float64(100), gotBody["max_tokens"])
+	assert.Equal(t, 0.9, gotBody["p"])
+	assert.Equal(t, []any{"END"}, gotBody["stop_sequences"])
+	assert.Equal(t, map[string]any{"type": "json_object", "json_schema": map[string]any{"type": "object"}}, gotBody["response_format"])
+	assert.Equal(t, []any{
+		map[string]any{"role": "system", "content": "Classify sentiment"},
+		map[string]any{"role": "user", "content": "I love it"},
+	}, gotBody["messages"])
+}
+
+func TestCohereProvider_Execute_ToolCalls(t *testing.T) {
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		_, _ = w.Write([]byte(`{
+			"id": "tc-1",
+			"finish_reason": "TOOL_CALL",
+			"message": {
+				"role": "assistant",
+				"tool_plan": "I will look up the weather.",
+				"tool_calls": [{"id": "call_1", "type": "function", "function": {"name": "get_weather", "arguments": "{\"city\":\"Berlin\"}"}}]
+			},
+			"usage": {"tokens": {"input_tokens": 30, "output_tokens": 10}}
+		}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewCohereProvider("key", server.URL)
+	require.NoError(t, err)
+
+	resp, err := provider.Execute(context.Background(), &models.LLMRequest{
+		Model:  "command-r",
+		Prompt: "Weather in Berlin?",
+		Tools: []models.LLMTool{{
+			Type:     "function",
+			Function: models.LLMFunctionTool{Name: "get_weather", Parameters: map[string]any{"type": "object"}},
+		}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "tool_calls", resp.FinishReason)
+	require.Len(t, resp.ToolCalls, 1)
+	assert.Equal(t, "call_1", resp.ToolCalls[0].ID)
+	assert.Equal(t, "get_weather", resp.ToolCalls[0].Function.Name)
+	assert.Equal(t, `{"city":"Berlin"}`, resp.ToolCalls[0].Function.Arguments)
+	assert.Equal(t, 40, resp.Usage.TotalTokens)
+	assert.Len(t, gotBody["tools"], 1)
+}
+
+func TestCohereProvider_BuildUserContent_Images(t *testing.T) {
+	provider, err := NewCohereProvider("key", "")
+	require.NoError(t, err)
+
+	content := provider.buildUserContent(&models.LLMRequest{
+		Prompt:    "Describe",
+		ImageURLs: []string{"https://example.com/cat.png"},
+		Files: []models.LLMFileAttachment{
+			{Data: "aGVsbG8=", MimeType: "image/png"},
+			{Data: "JVBERi0=", MimeType: "application/pdf"},
+		},
+	})
+
+	assert.Equal(t, []map[string]any{
+		{"type": "text", "text": "Describe"},
+		{"type": "image_url", "image_url": map[string]any{"url": "https://example.com/cat.png"}},
+		{"type": "image_url", "image_url": map[string]any{"url": "data:image/png;base64,aGVsbG8="}},
+	}, content)
+}
+
+func TestCohereProvider_Execute_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"id": "err-1", "message": "invalid api token"}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewCohereProvider("bad-key", server.URL)
+	require.NoError(t, err)
+
+	_, err = provider.Execute(context.Background(), &models.LLMRequest{Model: "command-r", Prompt: "Hello"})
+
+	var llmErr *models.LLMError
+	require.True(t, errors.As(err, &llmErr))
+	assert.Equal(t, models.LLMProviderCohere, llmErr.Provider)
+	assert.Equal(t, "401", llmErr.Code)
+	assert.Equal(t, "invalid api token", llmErr.Message)
+}
+
+func TestLLMExecutor_GetOrCreateProvider_Cohere(t *testing.T) {
+	executor := NewLLMExecutor()
+
+	config := map[string]any{
+		"provider": "cohere",
+		"model":    "command-r-plus",
+		"prompt":   "Hi",
+		"api_key":  "key",
+		"base_url": "https://cohere.internal/v2",
+	}
+	require.NoError(t, executor.Validate(config))
+
+	req, err := executor.parseConfig(config)
+	require.NoError(t, err)
+
+	provider, err := executor.getOrCreateProvider(req)
+	require.NoError(t, err)
+
+	cohere, ok := provider.(*CohereProvider)
+	require.True(t, ok)
+	assert.Equal(t, "https://cohere.internal/v2", cohere.baseURL)
+}
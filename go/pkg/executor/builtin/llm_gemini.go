@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/smilemakc/mbflow/go/pkg/executor"
 	"github.com/smilemakc/mbflow/go/pkg/models"
 )
 
@@ -60,6 +62,7 @@ func (p *GeminiProvider) Execute(ctx context.Context, req *models.LLMRequest) (*
 	// Set headers
 	httpReq.Header.Set("Content-Type", "application/json")
 	httpReq.Header.Set("x-goog-api-key", p.apiKey)
+	executor.InjectHeaders(ctx, httpReq.Header)
 
 	// Execute request
 	resp, err := p.client.Do(httpReq)
@@ -158,6 +161,24 @@ func (p *GeminiProvider) buildRequestBody(req *models.LLMRequest) map[string]any
 		body["tools"] = tools
 	}
 
+	// Safety settings, sorted by category for a stable request body
+	if len(req.SafetySettings) > 0 {
+		categories := make([]string, 0, len(req.SafetySettings))
+		for category := range req.SafetySettings {
+			categories = append(categories, category)
+		}
+		sort.Strings(categories)
+
+		safetySettings := make([]map[string]any, 0, len(categories))
+		for _, category := range categories {
+			safetySettings = append(safetySettings, map[string]any{
+				"category":  category,
+				"threshold": req.SafetySettings[category],
+			})
+		}
+		body["safetySettings"] = safetySettings
+	}
+
 	return body
 }
 
@@ -236,9 +257,14 @@ func (p *GeminiProvider) convertResponse(resp *geminiGenerateContentResponse, re
 		if model == "" {
 			model = req.Model
 		}
+		// A prompt rejected by the safety filters yields no candidates, only a block reason
+		finishReason := "error"
+		if resp.PromptFeedback != nil && resp.PromptFeedback.BlockReason != "" {
+			finishReason = "content_filter"
+		}
 		return &models.LLMResponse{
 			Model:        model,
-			FinishReason: "error",
+			FinishReason: finishReason,
 			CreatedAt:    time.Now(),
 		}
 	}
@@ -251,7 +277,7 @@ func (p *GeminiProvider) convertResponse(resp *geminiGenerateContentResponse, re
 
 	for _, part := range candidate.Content.Parts {
 		if part.Text != "" {
-			content = part.Text
+			content += part.Text
 		}
 
 		if part.FunctionCall != nil {
@@ -300,6 +326,54 @@ func (p *GeminiProvider) convertResponse(resp *geminiGenerateContentResponse, re
 	return response
 }
 
+// geminiHarmCategories are the harm categories accepted in safety_settings.
+var geminiHarmCategories = map[string]bool{
+	"HARM_CATEGORY_HARASSMENT":        true,
+	"HARM_CATEGORY_HATE_SPEECH":       true,
+	"HARM_CATEGORY_SEXUALLY_EXPLICIT": true,
+	"HARM_CATEGORY_DANGEROUS_CONTENT": true,
+	"HARM_CATEGORY_CIVIC_INTEGRITY":   true,
+}
+
+// geminiBlockThresholds are the block thresholds accepted in safety_settings.
+var geminiBlockThresholds = map[string]bool{
+	"BLOCK_NONE":             true,
+	"BLOCK_ONLY_HIGH":        true,
+	"BLOCK_MEDIUM_AND_ABOVE": true,
+	"BLOCK_LOW_AND_ABOVE":    true,
+	"OFF":                    true,
+}
+
+// parseGeminiSafetySettings parses the safety_settings config, a map of harm
+// category to block threshold, e.g. {"HARM_CATEGORY_HARASSMENT": "BLOCK_ONLY_HIGH"}.
+func parseGeminiSafetySettings(raw any) (map[string]string, error) {
+	var entries map[string]any
+	switch v := raw.(type) {
+	case map[string]any:
+		entries = v
+	case map[string]string:
+		entries = make(map[string]any, len(v))
+		for category, threshold := range v {
+			entries[category] = threshold
+		}
+	default:
+		return nil, fmt.Errorf("safety_settings must be an object mapping harm category to threshold")
+	}
+
+	settings := make(map[string]string, len(entries))
+	for category, rawThreshold := range entries {
+		if !geminiHarmCategories[category] {
+			return nil, fmt.Errorf("safety_settings: unknown harm category %q", category)
+		}
+		threshold, ok := rawThreshold.(string)
+		if !ok || !geminiBlockThresholds[threshold] {
+			return nil, fmt.Errorf("safety_settings: invalid threshold %v for %s", rawThreshold, category)
+		}
+		settings[category] = threshold
+	}
+	return settings, nil
+}
+
 // normalizeFinishReason normalizes Gemini finish reasons to our standard format.
 func (p *GeminiProvider) normalizeFinishReason(reason string) string {
 	switch strings.ToUpper(reason) {
@@ -316,10 +390,15 @@ func (p *GeminiProvider) normalizeFinishReason(reason string) string {
 
 // Gemini API response types
 type geminiGenerateContentResponse struct {
-	Candidates    []geminiCandidate   `json:"candidates"`
-	UsageMetadata geminiUsageMetadata `json:"usageMetadata"`
-	ModelVersion  string              `json:"modelVersion"`
-	ResponseID    string              `json:"responseId"`
+	Candidates     []geminiCandidate     `json:"candidates"`
+	PromptFeedback *geminiPromptFeedback `json:"promptFeedback,omitempty"`
+	UsageMetadata  geminiUsageMetadata   `json:"usageMetadata"`
+	ModelVersion   string                `json:"modelVersion"`
+	ResponseID     string                `json:"responseId"`
+}
+
+type geminiPromptFeedback struct {
+	BlockReason string `json:"blockReason"`
 }
 
 type geminiCandidate struct {
@@ -563,6 +563,35 @@ func TestGeminiProvider_ConvertResponse(t *testing.T) {
 				assert.Empty(t, result.ToolCalls)
 			},
 		},
+		{
+			name: "prompt blocked by safety filters",
+			resp: &geminiGenerateContentResponse{
+				PromptFeedback: &geminiPromptFeedback{BlockReason: "SAFETY"},
+			},
+			req: &models.LLMRequest{Model: "gemini-2.5-flash"},
+			validate: func(t *testing.T, result *models.LLMResponse) {
+				assert.Empty(t, result.Content)
+				assert.Equal(t, "content_filter", result.FinishReason)
+			},
+		},
+		{
+			name: "multiple text parts are concatenated",
+			resp: &geminiGenerateContentResponse{
+				Candidates: []geminiCandidate{
+					{
+						Content: geminiContent{
+							Role:  "model",
+							Parts: []geminiPart{{Text: "{\"answer\": "}, {Text: "42}"}},
+						},
+						FinishReason: "STOP",
+					},
+				},
+			},
+			req: &models.LLMRequest{Model: "gemini-2.5-flash"},
+			validate: func(t *testing.T, result *models.LLMResponse) {
+				assert.Equal(t, `{"answer": 42}`, result.Content)
+			},
+		},
 		{
 			name: "empty candidates with no ModelVersion (uses request model)",
 			resp: &geminiGenerateContentResponse{
@@ -759,6 +788,27 @@ func TestGeminiProvider_BuildRequestBody_NoGenerationConfigWhenEmpty(t *testing.
 	assert.False(t, hasGenerationConfig, "generationConfig should be omitted when empty")
 }
 
+func TestGeminiProvider_BuildRequestBody_SafetySettings(t *testing.T) {
+	provider, err := NewGeminiProvider("test-key", "")
+	require.NoError(t, err)
+
+	body := provider.buildRequestBody(&models.LLMRequest{
+		Prompt: "Hello",
+		SafetySettings: map[string]string{
+			"HARM_CATEGORY_HATE_SPEECH": "BLOCK_LOW_AND_ABOVE",
+			"HARM_CATEGORY_HARASSMENT":  "BLOCK_ONLY_HIGH",
+		},
+	})
+
+	assert.Equal(t, []map[string]any{
+		{"category": "HARM_CATEGORY_HARASSMENT", "threshold": "BLOCK_ONLY_HIGH"},
+		{"category": "HARM_CATEGORY_HATE_SPEECH", "threshold": "BLOCK_LOW_AND_ABOVE"},
+	}, body["safetySettings"])
+
+	body = provider.buildRequestBody(&models.LLMRequest{Prompt: "Hello"})
+	assert.NotContains(t, body, "safetySettings")
+}
+
 // TestGeminiProvider_BuildTools tests tool building
 func TestGeminiProvider_BuildTools(t *testing.T) {
 	provider, err := NewGeminiProvider("test-key", "")
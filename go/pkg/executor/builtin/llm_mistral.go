@@ -0,0 +1,59 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// DefaultMistralBaseURL is the Mistral API endpoint used when a node sets no base_url.
+const DefaultMistralBaseURL = "https://api.mistral.ai/v1"
+
+// MistralProvider implements the LLM provider for Mistral AI.
+// Mistral serves an OpenAI-compatible Chat Completions API, so requests and responses
+// are handled by the OpenAI provider; only the endpoint and error format differ.
+type MistralProvider struct {
+	openai *OpenAIProvider
+	apiKey string
+}
+
+// NewMistralProvider creates a new Mistral provider with the given configuration.
+func NewMistralProvider(apiKey, baseURL string) (*MistralProvider, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("api_key is required for Mistral provider")
+	}
+
+	if baseURL == "" {
+		baseURL = DefaultMistralBaseURL
+	}
+
+	return &MistralProvider{
+		openai: &OpenAIProvider{
+			baseURL: strings.TrimSuffix(baseURL, "/"),
+			client: &http.Client{
+				Timeout: 120 * time.Second,
+			},
+		},
+		apiKey: apiKey,
+	}, nil
+}
+
+// Execute executes an LLM request using Mistral.
+func (p *MistralProvider) Execute(ctx context.Context, req *models.LLMRequest) (*models.LLMResponse, error) {
+	return p.openai.executeChatCompletion(ctx, req, models.LLMProviderMistral, p.openai.baseURL+"/chat/completions", p.setAuth)
+}
+
+// ExecuteStream executes an LLM request using Mistral with a streamed response, calling
+// onDelta (if not nil) with each chunk of text as it is generated.
+func (p *MistralProvider) ExecuteStream(ctx context.Context, req *models.LLMRequest, onDelta func(delta string)) (*models.LLMResponse, error) {
+	return p.openai.streamChatCompletion(ctx, req, models.LLMProviderMistral, p.openai.baseURL+"/chat/completions", p.setAuth, onDelta)
+}
+
+// setAuth sets the Mistral authentication header.
+func (p *MistralProvider) setAuth(header http.Header) {
+	header.Set("Authorization", "Bearer "+p.apiKey)
+}
@@ -0,0 +1,104 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMistralProvider_NewMistralProvider(t *testing.T) {
+	_, err := NewMistralProvider("", "")
+	assert.ErrorContains(t, err, "api_key")
+
+	provider, err := NewMistralProvider("key", "")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultMistralBaseURL, provider.openai.baseURL)
+}
+
+func TestMistralProvider_Execute(t *testing.T) {
+	var gotPath, gotAuth string
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"id": "cmpl-1",
+			"object": "chat.completion",
+			"model": "mistral-small-latest",
+			"choices": [{"index": 0, "message": {"role": "assistant", "content": "Bonjour"}, "finish_reason": "stop"}],
+			"usage": {"prompt_tokens": 7, "completion_tokens": 2, "total_tokens": 9}
+		}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewMistralProvider("mistral-key", server.URL+"/v1/")
+	require.NoError(t, err)
+
+	resp, err := provider.Execute(context.Background(), &models.LLMRequest{
+		Model:       "mistral-small-latest",
+		Instruction: "Answer in French",
+		Prompt:      "Hello",
+		MaxTokens:   64,
+		Temperature: 0.3,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Bonjour", resp.Content)
+	assert.Equal(t, "mistral-small-latest", resp.Model)
+	assert.Equal(t, 9, resp.Usage.TotalTokens)
+	assert.Equal(t, "/v1/chat/completions", gotPath)
+	assert.Equal(t, "Bearer mistral-key", gotAuth)
+	assert.Equal(t, "mistral-small-latest", gotBody["model"])
+	assert.Equal(t, float64(64), gotBody["max_tokens"])
+	assert.Equal(t, 0.3, gotBody["temperature"])
+	assert.Len(t, gotBody["messages"], 2)
+}
+
+func TestMistralProvider_Execute_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"object": "error", "message": "Invalid model: mistral-huge", "type": "invalid_model", "param": null, "code": "1500"}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewMistralProvider("key", server.URL)
+	require.NoError(t, err)
+
+	_, err = provider.Execute(context.Background(), &models.LLMRequest{Model: "mistral-huge", Prompt: "Hello"})
+
+	var llmErr *models.LLMError
+	require.True(t, errors.As(err, &llmErr))
+	assert.Equal(t, models.LLMProviderMistral, llmErr.Provider)
+	assert.Equal(t, "1500", llmErr.Code)
+	assert.Equal(t, "invalid_model", llmErr.Type)
+	assert.Contains(t, llmErr.Message, "Invalid model")
+}
+
+func TestLLMExecutor_GetOrCreateProvider_Mistral(t *testing.T) {
+	executor := NewLLMExecutor()
+
+	config := map[string]any{
+		"provider": "mistral",
+		"model":    "mistral-large-latest",
+		"prompt":   "Hi",
+		"api_key":  "key",
+	}
+	require.NoError(t, executor.Validate(config))
+
+	req, err := executor.parseConfig(config)
+	require.NoError(t, err)
+
+	provider, err := executor.getOrCreateProvider(req)
+	require.NoError(t, err)
+	assert.IsType(t, &MistralProvider{}, provider)
+}
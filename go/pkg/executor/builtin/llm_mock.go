@@ -0,0 +1,466 @@
+package builtin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// mockDefaultModel is reported when a mock node does not set a model.
+const mockDefaultModel = "mock"
+
+// MockLLMResponse is a canned response of the mock provider.
+// In node config it may also be given as a plain string, which sets Content.
+type MockLLMResponse struct {
+	// Content is the response text. Non-string values are returned as JSON,
+	// which pairs with response_format json_object or json_schema.
+	Content      any               `json:"content,omitempty"`
+	ToolCalls    []MockLLMToolCall `json:"tool_calls,omitempty"`
+	FinishReason string            `json:"finish_reason,omitempty"`
+	Usage        *models.LLMUsage  `json:"usage,omitempty"`
+}
+
+// MockLLMToolCall is a function call returned by the mock provider.
+type MockLLMToolCall struct {
+	Name      string `json:"name"`
+	Arguments any    `json:"arguments,omitempty"` // object, or a JSON string
+}
+
+// LLMFixtureMatch selects the requests a fixture answers. Empty fields match any value.
+type LLMFixtureMatch struct {
+	Model       string `json:"model,omitempty"`
+	Instruction string `json:"instruction,omitempty"`
+	Prompt      string `json:"prompt,omitempty"`
+}
+
+// LLMFixture is a recorded request/response pair replayed by the mock provider.
+type LLMFixture struct {
+	Match    LLMFixtureMatch `json:"match"`
+	Response MockLLMResponse `json:"response"`
+}
+
+// mockRule answers prompts that contain a substring or match a regular expression.
+type mockRule struct {
+	Contains string          `json:"contains,omitempty"`
+	Pattern  string          `json:"pattern,omitempty"`
+	Response MockLLMResponse `json:"-"`
+
+	re *regexp.Regexp
+}
+
+// mockConfig is the "mock" object of a node using provider "mock".
+type mockConfig struct {
+	Response *MockLLMResponse
+	Rules    []mockRule
+	Fixtures []LLMFixture
+}
+
+// CannedLLMProvider implements provider "mock": it returns deterministic responses without
+// calling any API, for examples, tests and local development. A response is chosen in this order:
+//  1. the first fixture matching the request (node fixtures, then provider fixtures)
+//  2. the first node rule matching the prompt
+//  3. the node's canned response
+//  4. a generated response: for json_schema an object satisfying the schema,
+//     for json_object "{}", otherwise the prompt echoed back
+//
+// Tool calls in a response are only returned while the conversation holds no tool
+// results, so auto mode tool calling terminates with the text of the same response.
+type CannedLLMProvider struct {
+	fixtures []LLMFixture
+}
+
+// NewCannedLLMProvider creates a mock provider that replays the given fixtures in addition
+// to the fixtures and rules configured on each node.
+func NewCannedLLMProvider(fixtures ...LLMFixture) *CannedLLMProvider {
+	return &CannedLLMProvider{fixtures: fixtures}
+}
+
+// Execute answers the request from fixtures, rules or the canned response.
+func (p *CannedLLMProvider) Execute(ctx context.Context, req *models.LLMRequest) (*models.LLMResponse, error) {
+	cfg, err := parseMockConfig(req.ProviderConfig["mock"])
+	if err != nil {
+		return nil, err
+	}
+
+	model := req.Model
+	if model == "" {
+		model = mockDefaultModel
+	}
+	prompt := mockPrompt(req)
+
+	var spec *MockLLMResponse
+	for _, fixtures := range [][]LLMFixture{cfg.Fixtures, p.fixtures} {
+		if spec != nil {
+			break
+		}
+		for i := range fixtures {
+			// Once tools have answered, a recorded tool call turn is not the reply being asked for
+			if len(fixtures[i].Response.ToolCalls) > 0 && mockHasToolResults(req) {
+				continue
+			}
+			if fixtures[i].Match.matches(model, req.Instruction, prompt) {
+				spec = &fixtures[i].Response
+				break
+			}
+		}
+	}
+	if spec == nil {
+		for i := range cfg.Rules {
+			if cfg.Rules[i].matches(prompt) {
+				spec = &cfg.Rules[i].Response
+				break
+			}
+		}
+	}
+	if spec == nil {
+		spec = cfg.Response
+	}
+
+	return buildMockResponse(spec, req, model, prompt)
+}
+
+// buildMockResponse renders a response spec, falling back to a generated response.
+func buildMockResponse(spec *MockLLMResponse, req *models.LLMRequest, model, prompt string) (*models.LLMResponse, error) {
+	resp := &models.LLMResponse{
+		Model:        model,
+		FinishReason: "stop",
+		CreatedAt:    time.Now(),
+	}
+
+	var content any
+	if spec != nil {
+		content = spec.Content
+		if spec.FinishReason != "" {
+			resp.FinishReason = spec.FinishReason
+		}
+		if len(spec.ToolCalls) > 0 && !mockHasToolResults(req) {
+			for i, call := range spec.ToolCalls {
+				args, err := mockArguments(call.Arguments)
+				if err != nil {
+					return nil, fmt.Errorf("mock tool call %s: %w", call.Name, err)
+				}
+				resp.ToolCalls = append(resp.ToolCalls, models.LLMToolCall{
+					ID:       fmt.Sprintf("call_mock_%d", i+1),
+					Type:     "function",
+					Function: models.LLMFunctionCall{Name: call.Name, Arguments: args},
+				})
+			}
+			if spec.FinishReason == "" {
+				resp.FinishReason = "tool_calls"
+			}
+		}
+	} else {
+		content = mockGeneratedContent(req, prompt)
+	}
+
+	switch v := content.(type) {
+	case nil:
+	case string:
+		resp.Content = v
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("mock content is not JSON-serializable: %w", err)
+		}
+		resp.Content = string(data)
+	}
+
+	if spec != nil && spec.Usage != nil {
+		resp.Usage = *spec.Usage
+	} else {
+		resp.Usage = models.LLMUsage{
+			PromptTokens:     mockTokenCount(req.Instruction) + mockTokenCount(prompt),
+			CompletionTokens: mockTokenCount(resp.Content),
+		}
+		resp.Usage.TotalTokens = resp.Usage.PromptTokens + resp.Usage.CompletionTokens
+	}
+
+	sum := sha256.Sum256([]byte(model + "\x00" + req.Instruction + "\x00" + prompt))
+	resp.ResponseID = "mock-" + hex.EncodeToString(sum[:6])
+	return resp, nil
+}
+
+// mockGeneratedContent is the response of a mock node without any canned answer.
+func mockGeneratedContent(req *models.LLMRequest, prompt string) any {
+	if req.ResponseFormat != nil {
+		switch req.ResponseFormat.Type {
+		case "json_schema":
+			if req.ResponseFormat.JSONSchema != nil {
+				return mockValueForSchema(req.ResponseFormat.JSONSchema.Schema)
+			}
+			return map[string]any{}
+		case "json_object":
+			return map[string]any{}
+		}
+	}
+	return "Mock response to: " + prompt
+}
+
+// mockValueForSchema builds the simplest value satisfying a JSON schema:
+// the first enum value, empty strings, zeros, false and empty arrays.
+func mockValueForSchema(schema map[string]any) any {
+	if values, ok := schema["enum"].([]any); ok && len(values) > 0 {
+		return values[0]
+	}
+	if value, ok := schema["const"]; ok {
+		return value
+	}
+
+	typ := schema["type"]
+	if types, ok := typ.([]any); ok && len(types) > 0 {
+		typ = types[0]
+	}
+	switch typ {
+	case "object":
+		obj := map[string]any{}
+		props, _ := schema["properties"].(map[string]any)
+		for name, raw := range props {
+			if prop, ok := raw.(map[string]any); ok {
+				obj[name] = mockValueForSchema(prop)
+			}
+		}
+		return obj
+	case "array":
+		return []any{}
+	case "string":
+		return ""
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return false
+	default:
+		return nil
+	}
+}
+
+// mockPrompt returns the text the request asks about: the prompt, or the last user
+// message of a conversation, or a string input.
+func mockPrompt(req *models.LLMRequest) string {
+	if req.Prompt != "" {
+		return req.Prompt
+	}
+	for i := len(req.Messages) - 1; i >= 0; i-- {
+		if req.Messages[i].Role == "user" {
+			return req.Messages[i].Content
+		}
+	}
+	if s, ok := req.Input.(string); ok {
+		return s
+	}
+	return ""
+}
+
+// mockHasToolResults reports whether the conversation already contains tool results.
+func mockHasToolResults(req *models.LLMRequest) bool {
+	for _, msg := range req.Messages {
+		if msg.Role == "tool" {
+			return true
+		}
+	}
+	return false
+}
+
+func mockArguments(raw any) (string, error) {
+	switch v := raw.(type) {
+	case nil:
+		return "{}", nil
+	case string:
+		return v, nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+}
+
+// mockTokenCount approximates tokens as one per four characters.
+func mockTokenCount(s string) int {
+	return (len(s) + 3) / 4
+}
+
+func (m LLMFixtureMatch) matches(model, instruction, prompt string) bool {
+	return (m.Model == "" || m.Model == model) &&
+		(m.Instruction == "" || m.Instruction == instruction) &&
+		(m.Prompt == "" || m.Prompt == prompt)
+}
+
+func (r *mockRule) matches(prompt string) bool {
+	if r.Contains != "" && !strings.Contains(prompt, r.Contains) {
+		return false
+	}
+	if r.re != nil && !r.re.MatchString(prompt) {
+		return false
+	}
+	return true
+}
+
+// parseMockConfig parses the "mock" object of a node. A nil value is an empty config.
+func parseMockConfig(raw any) (*mockConfig, error) {
+	cfg := &mockConfig{}
+	if raw == nil {
+		return cfg, nil
+	}
+	obj, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("mock must be an object")
+	}
+
+	if value, ok := obj["response"]; ok {
+		resp, err := parseMockResponse(value)
+		if err != nil {
+			return nil, fmt.Errorf("mock.response: %w", err)
+		}
+		cfg.Response = &resp
+	}
+
+	if value, ok := obj["rules"]; ok {
+		items, ok := value.([]any)
+		if !ok {
+			return nil, fmt.Errorf("mock.rules must be an array")
+		}
+		for i, item := range items {
+			ruleObj, ok := item.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("mock.rules[%d] must be an object", i)
+			}
+			rule := mockRule{}
+			rule.Contains, _ = ruleObj["contains"].(string)
+			rule.Pattern, _ = ruleObj["pattern"].(string)
+			if rule.Contains == "" && rule.Pattern == "" {
+				return nil, fmt.Errorf("mock.rules[%d] requires contains or pattern", i)
+			}
+			if rule.Pattern != "" {
+				re, err := regexp.Compile(rule.Pattern)
+				if err != nil {
+					return nil, fmt.Errorf("mock.rules[%d].pattern: %w", i, err)
+				}
+				rule.re = re
+			}
+			resp, err := parseMockResponse(ruleObj["response"])
+			if err != nil {
+				return nil, fmt.Errorf("mock.rules[%d].response: %w", i, err)
+			}
+			rule.Response = resp
+			cfg.Rules = append(cfg.Rules, rule)
+		}
+	}
+
+	if value, ok := obj["fixtures"]; ok {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("mock.fixtures: %w", err)
+		}
+		if err := json.Unmarshal(data, &cfg.Fixtures); err != nil {
+			return nil, fmt.Errorf("mock.fixtures must be an array of {match, response}: %w", err)
+		}
+	}
+
+	return cfg, nil
+}
+
+// parseMockResponse accepts a string (the content) or a MockLLMResponse object.
+func parseMockResponse(raw any) (MockLLMResponse, error) {
+	switch v := raw.(type) {
+	case nil:
+		return MockLLMResponse{}, fmt.Errorf("response is required")
+	case string:
+		return MockLLMResponse{Content: v}, nil
+	case map[string]any:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return MockLLMResponse{}, err
+		}
+		var resp MockLLMResponse
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return MockLLMResponse{}, err
+		}
+		for _, call := range resp.ToolCalls {
+			if call.Name == "" {
+				return MockLLMResponse{}, fmt.Errorf("tool call name is required")
+			}
+		}
+		return resp, nil
+	default:
+		return MockLLMResponse{}, fmt.Errorf("response must be a string or an object")
+	}
+}
+
+// LoadLLMFixtures reads fixtures from a JSON file holding an array of {match, response}.
+func LoadLLMFixtures(path string) ([]LLMFixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read LLM fixtures: %w", err)
+	}
+	var fixtures []LLMFixture
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return nil, fmt.Errorf("failed to parse LLM fixtures %s: %w", path, err)
+	}
+	return fixtures, nil
+}
+
+// SaveLLMFixtures writes fixtures to a JSON file readable by LoadLLMFixtures.
+func SaveLLMFixtures(path string, fixtures []LLMFixture) error {
+	data, err := json.MarshalIndent(fixtures, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode LLM fixtures: %w", err)
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// RecordingLLMProvider wraps a real provider and records each exchange as a fixture,
+// so a run against a real API can later be replayed with CannedLLMProvider.
+type RecordingLLMProvider struct {
+	provider LLMProvider
+
+	mu       sync.Mutex
+	fixtures []LLMFixture
+}
+
+// NewRecordingLLMProvider creates a recording wrapper around provider.
+func NewRecordingLLMProvider(provider LLMProvider) *RecordingLLMProvider {
+	return &RecordingLLMProvider{provider: provider}
+}
+
+// Execute forwards the request and records the response.
+func (p *RecordingLLMProvider) Execute(ctx context.Context, req *models.LLMRequest) (*models.LLMResponse, error) {
+	resp, err := p.provider.Execute(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	fixture := LLMFixture{
+		Match: LLMFixtureMatch{Model: req.Model, Instruction: req.Instruction, Prompt: mockPrompt(req)},
+		Response: MockLLMResponse{
+			Content:      resp.Content,
+			FinishReason: resp.FinishReason,
+			Usage:        &resp.Usage,
+		},
+	}
+	for _, call := range resp.ToolCalls {
+		fixture.Response.ToolCalls = append(fixture.Response.ToolCalls,
+			MockLLMToolCall{Name: call.Function.Name, Arguments: call.Function.Arguments})
+	}
+
+	p.mu.Lock()
+	p.fixtures = append(p.fixtures, fixture)
+	p.mu.Unlock()
+	return resp, nil
+}
+
+// Fixtures returns the exchanges recorded so far.
+func (p *RecordingLLMProvider) Fixtures() []LLMFixture {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]LLMFixture(nil), p.fixtures...)
+}
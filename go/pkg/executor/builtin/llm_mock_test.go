@@ -0,0 +1,214 @@
+package builtin
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLLMExecutor_Mock_Validate(t *testing.T) {
+	exec := NewLLMExecutor()
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{"no api key or model", map[string]any{"provider": "mock", "prompt": "Hi"}, ""},
+		{"with rules", map[string]any{"provider": "mock", "prompt": "Hi", "mock": map[string]any{
+			"rules": []any{map[string]any{"pattern": "^order \\d+$", "response": "ok"}},
+		}}, ""},
+		{"missing prompt", map[string]any{"provider": "mock"}, "prompt"},
+		{"mock not an object", map[string]any{"provider": "mock", "prompt": "Hi", "mock": "x"}, "mock must be an object"},
+		{"rule without matcher", map[string]any{"provider": "mock", "prompt": "Hi", "mock": map[string]any{
+			"rules": []any{map[string]any{"response": "ok"}},
+		}}, "requires contains or pattern"},
+		{"invalid pattern", map[string]any{"provider": "mock", "prompt": "Hi", "mock": map[string]any{
+			"rules": []any{map[string]any{"pattern": "(", "response": "ok"}},
+		}}, "mock.rules[0].pattern"},
+		{"unnamed tool call", map[string]any{"provider": "mock", "prompt": "Hi", "mock": map[string]any{
+			"response": map[string]any{"tool_calls": []any{map[string]any{}}},
+		}}, "tool call name is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exec.Validate(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLLMExecutor_Mock_Responses(t *testing.T) {
+	exec := NewLLMExecutor()
+	mock := map[string]any{
+		"response": "default answer",
+		"rules": []any{
+			map[string]any{"contains": "refund", "response": "negative"},
+			map[string]any{"pattern": `(?i)^thanks`, "response": "positive"},
+		},
+		"fixtures": []any{
+			map[string]any{
+				"match":    map[string]any{"prompt": "thanks for the refund"},
+				"response": map[string]any{"content": "mixed"},
+			},
+		},
+	}
+
+	tests := []struct {
+		prompt string
+		want   string
+	}{
+		{"thanks for the refund", "mixed"},
+		{"I want a refund", "negative"},
+		{"Thanks a lot", "positive"},
+		{"hello", "default answer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prompt, func(t *testing.T) {
+			result, err := exec.Execute(context.Background(), map[string]any{
+				"provider": "mock",
+				"prompt":   tt.prompt,
+				"mock":     mock,
+			}, nil)
+			require.NoError(t, err)
+
+			output := result.(map[string]any)
+			assert.Equal(t, tt.want, output["content"])
+			assert.Equal(t, "mock", output["model"])
+			assert.Equal(t, "stop", output["finish_reason"])
+		})
+	}
+}
+
+func TestLLMExecutor_Mock_Deterministic(t *testing.T) {
+	exec := NewLLMExecutor()
+	config := map[string]any{"provider": "mock", "model": "gpt-4o", "prompt": "Summarize the report"}
+
+	first, err := exec.Execute(context.Background(), config, nil)
+	require.NoError(t, err)
+	second, err := exec.Execute(context.Background(), config, nil)
+	require.NoError(t, err)
+
+	out := first.(map[string]any)
+	assert.Equal(t, "Mock response to: Summarize the report", out["content"])
+	assert.Equal(t, "gpt-4o", out["model"])
+	assert.Equal(t, out["response_id"], second.(map[string]any)["response_id"])
+	assert.Equal(t, out["content"], second.(map[string]any)["content"])
+}
+
+func TestLLMExecutor_Mock_JSONSchema(t *testing.T) {
+	exec := NewLLMExecutor()
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"provider": "mock",
+		"prompt":   "Classify",
+		"response_format": map[string]any{
+			"type": "json_schema",
+			"json_schema": map[string]any{
+				"name": "classification",
+				"schema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"label":      map[string]any{"type": "string", "enum": []any{"spam", "ham"}},
+						"confidence": map[string]any{"type": "number"},
+						"tags":       map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+					},
+				},
+			},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"label":      "spam",
+		"confidence": float64(0),
+		"tags":       []any{},
+	}, result.(map[string]any)["content"])
+}
+
+func TestLLMExecutor_Mock_AutoModeToolCalls(t *testing.T) {
+	exec := NewLLMExecutor()
+
+	funcRegistry := models.NewFunctionRegistry()
+	var calledWith map[string]any
+	funcRegistry.Register("get_weather", func(args map[string]any) (any, error) {
+		calledWith = args
+		return map[string]any{"temperature": 22}, nil
+	})
+	exec.SetToolCallingRegistry(NewToolCallingRegistry(funcRegistry))
+
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"provider": "mock",
+		"prompt":   "What's the weather in London?",
+		"mock": map[string]any{
+			"response": map[string]any{
+				"content":    "It is 22°C in London",
+				"tool_calls": []any{map[string]any{"name": "get_weather", "arguments": map[string]any{"location": "London"}}},
+			},
+		},
+		"tool_call_config": map[string]any{"mode": "auto", "max_iterations": 5},
+		"functions": []any{
+			map[string]any{
+				"type":         "builtin",
+				"name":         "get_weather",
+				"builtin_name": "get_weather",
+				"parameters":   map[string]any{"type": "object"},
+			},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	output := result.(map[string]any)
+	assert.Equal(t, map[string]any{"location": "London"}, calledWith)
+	assert.Equal(t, "It is 22°C in London", output["content"])
+	assert.Equal(t, "finish", output["stopped_reason"])
+	assert.Equal(t, 2, output["total_iterations"])
+}
+
+func TestRecordingLLMProvider_Replay(t *testing.T) {
+	recorder := NewRecordingLLMProvider(&MockLLMProvider{})
+	req := &models.LLMRequest{Model: "gpt-4", Instruction: "Be brief", Prompt: "Hello"}
+
+	_, err := recorder.Execute(context.Background(), req)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "fixtures.json")
+	require.NoError(t, SaveLLMFixtures(path, recorder.Fixtures()))
+	fixtures, err := LoadLLMFixtures(path)
+	require.NoError(t, err)
+	require.Len(t, fixtures, 1)
+	assert.Equal(t, LLMFixtureMatch{Model: "gpt-4", Instruction: "Be brief", Prompt: "Hello"}, fixtures[0].Match)
+
+	replay := NewCannedLLMProvider(fixtures...)
+	resp, err := replay.Execute(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "Mock response", resp.Content)
+	assert.Equal(t, 30, resp.Usage.TotalTokens)
+
+	// Requests without a recording fall back to the generated response
+	resp, err = replay.Execute(context.Background(), &models.LLMRequest{Model: "gpt-4", Prompt: "Other"})
+	require.NoError(t, err)
+	assert.Equal(t, "Mock response to: Other", resp.Content)
+
+	// Registered providers take precedence, so replays can be plugged into an executor
+	exec := NewLLMExecutor()
+	exec.RegisterProvider(models.LLMProviderMock, replay)
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"provider":    "mock",
+		"model":       "gpt-4",
+		"instruction": "Be brief",
+		"prompt":      "Hello",
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Mock response", result.(map[string]any)["content"])
+}
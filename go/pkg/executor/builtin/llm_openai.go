@@ -1,14 +1,17 @@
 package builtin
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/smilemakc/mbflow/go/pkg/executor"
 	"github.com/smilemakc/mbflow/go/pkg/models"
 )
 
@@ -42,9 +45,160 @@ func NewOpenAIProvider(apiKey, baseURL, orgID string) (*OpenAIProvider, error) {
 
 // Execute executes an LLM request using OpenAI.
 func (p *OpenAIProvider) Execute(ctx context.Context, req *models.LLMRequest) (*models.LLMResponse, error) {
-	// Build request body
-	reqBody := p.buildRequestBody(req)
+	return p.executeChatCompletion(ctx, req, models.LLMProviderOpenAI, p.baseURL+"/chat/completions", p.setAuth)
+}
+
+// ExecuteStream executes an LLM request using OpenAI with a streamed response, calling
+// onDelta (if not nil) with each chunk of text as it is generated. The returned response
+// holds the assembled content, tool calls and usage.
+func (p *OpenAIProvider) ExecuteStream(ctx context.Context, req *models.LLMRequest, onDelta func(delta string)) (*models.LLMResponse, error) {
+	return p.streamChatCompletion(ctx, req, models.LLMProviderOpenAI, p.baseURL+"/chat/completions", p.setAuth, onDelta)
+}
+
+// setAuth sets the OpenAI authentication headers.
+func (p *OpenAIProvider) setAuth(header http.Header) {
+	header.Set("Authorization", "Bearer "+p.apiKey)
+	if p.orgID != "" {
+		header.Set("OpenAI-Organization", p.orgID)
+	}
+}
+
+// executeChatCompletion posts a Chat Completions request to url, letting setAuth add the
+// authentication headers. It serves OpenAI and the OpenAI-compatible APIs of Azure OpenAI and Mistral.
+// Requests with Stream set are streamed and return the assembled response.
+func (p *OpenAIProvider) executeChatCompletion(ctx context.Context, req *models.LLMRequest, provider models.LLMProvider, url string, setAuth func(http.Header)) (*models.LLMResponse, error) {
+	if req.Stream {
+		return p.streamChatCompletion(ctx, req, provider, url, setAuth, nil)
+	}
+
+	resp, err := p.postChatCompletion(ctx, p.buildRequestBody(req), provider, url, setAuth)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Parse response
+	var apiResp openAIChatCompletionResponse
+	if err := json.Unmarshal(respBody, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// Convert to our model
+	return p.convertResponse(&apiResp), nil
+}
+
+// streamChatCompletion posts a streamed Chat Completions request and assembles the
+// server-sent chunks into a response, calling onDelta (if not nil) with each text chunk.
+func (p *OpenAIProvider) streamChatCompletion(ctx context.Context, req *models.LLMRequest, provider models.LLMProvider, url string, setAuth func(http.Header), onDelta func(delta string)) (*models.LLMResponse, error) {
+	body := p.buildRequestBody(req)
+	body["stream"] = true
+	// Mistral reports usage in the last chunk without being asked and rejects unknown fields
+	if provider != models.LLMProviderMistral {
+		body["stream_options"] = map[string]any{"include_usage": true}
+	}
+
+	resp, err := p.postChatCompletion(ctx, body, provider, url, setAuth)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &models.LLMResponse{
+		Model:     req.Model,
+		CreatedAt: time.Now(),
+	}
+
+	var content strings.Builder
+	var toolCalls []*models.LLMToolCall
+	toolCallsByIndex := map[int]*models.LLMToolCall{}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			break
+		}
+
+		var chunk openAIChatCompletionChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return nil, fmt.Errorf("failed to parse stream chunk: %w", err)
+		}
+		if chunk.Error != nil {
+			return nil, &models.LLMError{
+				Provider: provider,
+				Code:     fmt.Sprintf("%v", chunk.Error.Code),
+				Message:  chunk.Error.Message,
+				Type:     chunk.Error.Type,
+			}
+		}
+
+		if chunk.ID != "" {
+			response.ResponseID = chunk.ID
+		}
+		if chunk.Model != "" {
+			response.Model = chunk.Model
+		}
+		if chunk.Usage != nil {
+			response.Usage = models.LLMUsage{
+				PromptTokens:     chunk.Usage.PromptTokens,
+				CompletionTokens: chunk.Usage.CompletionTokens,
+				TotalTokens:      chunk.Usage.TotalTokens,
+			}
+		}
+
+		for _, choice := range chunk.Choices {
+			if choice.Index != 0 {
+				continue
+			}
+			if choice.Delta.Content != "" {
+				content.WriteString(choice.Delta.Content)
+				if onDelta != nil {
+					onDelta(choice.Delta.Content)
+				}
+			}
+			for _, tc := range choice.Delta.ToolCalls {
+				call, ok := toolCallsByIndex[tc.Index]
+				if !ok {
+					call = &models.LLMToolCall{Type: "function"}
+					toolCallsByIndex[tc.Index] = call
+					toolCalls = append(toolCalls, call)
+				}
+				if tc.ID != "" {
+					call.ID = tc.ID
+				}
+				call.Function.Name += tc.Function.Name
+				call.Function.Arguments += tc.Function.Arguments
+			}
+			if choice.FinishReason != "" {
+				response.FinishReason = choice.FinishReason
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read response stream: %w", err)
+	}
 
+	response.Content = content.String()
+	for _, call := range toolCalls {
+		response.ToolCalls = append(response.ToolCalls, *call)
+	}
+	return response, nil
+}
+
+// postChatCompletion sends a Chat Completions request body and returns the response,
+// converting error responses into errors.
+func (p *OpenAIProvider) postChatCompletion(ctx context.Context, reqBody map[string]any, provider models.LLMProvider, url string, setAuth func(http.Header)) (*http.Response, error) {
 	// Marshal to JSON
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
@@ -52,24 +206,24 @@ func (p *OpenAIProvider) Execute(ctx context.Context, req *models.LLMRequest) (*
 	}
 
 	// Create HTTP request
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", p.baseURL+"/chat/completions", bytes.NewReader(jsonData))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
 	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+p.apiKey)
-
-	if p.orgID != "" {
-		httpReq.Header.Set("OpenAI-Organization", p.orgID)
-	}
+	setAuth(httpReq.Header)
+	executor.InjectHeaders(ctx, httpReq.Header)
 
 	// Execute request
 	resp, err := p.client.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
+	if resp.StatusCode == http.StatusOK {
+		return resp, nil
+	}
 	defer resp.Body.Close()
 
 	// Read response body
@@ -79,29 +233,34 @@ func (p *OpenAIProvider) Execute(ctx context.Context, req *models.LLMRequest) (*
 	}
 
 	// Check for errors
-	if resp.StatusCode != http.StatusOK {
-		var errorResp map[string]any
-		if err := json.Unmarshal(respBody, &errorResp); err == nil {
-			if errorData, ok := errorResp["error"].(map[string]any); ok {
-				return nil, &models.LLMError{
-					Provider: models.LLMProviderOpenAI,
-					Code:     fmt.Sprintf("%v", errorData["code"]),
-					Message:  fmt.Sprintf("%v", errorData["message"]),
-					Type:     fmt.Sprintf("%v", errorData["type"]),
-				}
+	var errorResp map[string]any
+	if err := json.Unmarshal(respBody, &errorResp); err == nil {
+		if errorData, ok := errorResp["error"].(map[string]any); ok {
+			return nil, &models.LLMError{
+				Provider: provider,
+				Code:     fmt.Sprintf("%v", errorData["code"]),
+				Message:  fmt.Sprintf("%v", errorData["message"]),
+				Type:     fmt.Sprintf("%v", errorData["type"]),
+			}
+		}
+		// Mistral reports errors at the top level of the body
+		if message, ok := errorResp["message"].(string); ok && message != "" {
+			code, _ := errorResp["code"].(string)
+			if code == "" {
+				code = fmt.Sprintf("%d", resp.StatusCode)
 			}
+			errType, _ := errorResp["type"].(string)
+			return nil, &models.LLMError{Provider: provider, Code: code, Message: message, Type: errType}
 		}
-		return nil, fmt.Errorf("OpenAI API error (status %d): %s", resp.StatusCode, string(respBody))
 	}
-
-	// Parse response
-	var apiResp openAIChatCompletionResponse
-	if err := json.Unmarshal(respBody, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	apiName := "OpenAI"
+	switch provider {
+	case models.LLMProviderAzureOpenAI:
+		apiName = "Azure OpenAI"
+	case models.LLMProviderMistral:
+		apiName = "Mistral"
 	}
-
-	// Convert to our model
-	return p.convertResponse(&apiResp), nil
+	return nil, fmt.Errorf("%s API error (status %d): %s", apiName, resp.StatusCode, string(respBody))
 }
 
 // buildRequestBody builds the OpenAI API request body.
@@ -339,3 +498,34 @@ type openAIChatCompletionResponse struct {
 		TotalTokens      int `json:"total_tokens"`
 	} `json:"usage"`
 }
+
+// openAIChatCompletionChunk is a server-sent chunk of a streamed Chat Completions response.
+type openAIChatCompletionChunk struct {
+	ID      string `json:"id"`
+	Model   string `json:"model"`
+	Choices []struct {
+		Index int `json:"index"`
+		Delta struct {
+			Content   string `json:"content"`
+			ToolCalls []struct {
+				Index    int    `json:"index"`
+				ID       string `json:"id"`
+				Function struct {
+					Name      string `json:"name"`
+					Arguments string `json:"arguments"`
+				} `json:"function"`
+			} `json:"tool_calls,omitempty"`
+		} `json:"delta"`
+		FinishReason string `json:"finish_reason"`
+	} `json:"choices"`
+	Usage *struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	} `json:"usage,omitempty"`
+	Error *struct {
+		Code    any    `json:"code"`
+		Message string `json:"message"`
+		Type    string `json:"type"`
+	} `json:"error,omitempty"`
+}
@@ -0,0 +1,337 @@
+package builtin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// openAIBatchMaxFileBytes limits the size of a downloaded batch result file.
+const openAIBatchMaxFileBytes = 512 << 20
+
+// openAIBatch is a batch object of the OpenAI Batch API.
+type openAIBatch struct {
+	ID           string `json:"id"`
+	Status       string `json:"status"`
+	OutputFileID string `json:"output_file_id"`
+	ErrorFileID  string `json:"error_file_id"`
+	Errors       *struct {
+		Data []struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+			Line    *int   `json:"line"`
+		} `json:"data"`
+	} `json:"errors"`
+}
+
+// openAIBatchLine is a line of a batch output or error file.
+type openAIBatchLine struct {
+	CustomID string `json:"custom_id"`
+	Response *struct {
+		StatusCode int             `json:"status_code"`
+		Body       json.RawMessage `json:"body"`
+	} `json:"response"`
+	Error *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// ExecuteBatch runs the requests through the OpenAI Batch API: the requests are uploaded
+// as a JSONL file, a batch is created for the chat completions endpoint and polled until
+// it ends, and the output and error files are mapped back to the requests.
+// When ctx is cancelled while waiting, the batch is cancelled.
+func (p *OpenAIProvider) ExecuteBatch(ctx context.Context, reqs []*models.LLMRequest, opts LLMBatchOptions) (*LLMBatchRun, error) {
+	if len(reqs) == 0 {
+		return &LLMBatchRun{Status: "completed"}, nil
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = llmBatchDefaultPollInterval
+	}
+	if opts.CompletionWindow == "" {
+		opts.CompletionWindow = "24h"
+	}
+
+	var input bytes.Buffer
+	encoder := json.NewEncoder(&input)
+	for i, req := range reqs {
+		line := map[string]any{
+			"custom_id": openAIBatchCustomID(i),
+			"method":    http.MethodPost,
+			"url":       "/v1/chat/completions",
+			"body":      p.buildRequestBody(req),
+		}
+		if err := encoder.Encode(line); err != nil {
+			return nil, fmt.Errorf("failed to encode batch request %d: %w", i, err)
+		}
+	}
+
+	fileID, err := p.uploadBatchFile(ctx, input.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	var batch openAIBatch
+	err = p.batchRequest(ctx, http.MethodPost, "/batches", map[string]any{
+		"input_file_id":     fileID,
+		"endpoint":          "/v1/chat/completions",
+		"completion_window": opts.CompletionWindow,
+	}, &batch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create batch: %w", err)
+	}
+
+	for !openAIBatchFinished(batch.Status) {
+		timer := time.NewTimer(opts.PollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			cancelCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+			_ = p.batchRequest(cancelCtx, http.MethodPost, "/batches/"+batch.ID+"/cancel", nil, nil)
+			cancel()
+			return nil, fmt.Errorf("batch %s cancelled: %w", batch.ID, ctx.Err())
+		case <-timer.C:
+		}
+		if err := p.batchRequest(ctx, http.MethodGet, "/batches/"+batch.ID, nil, &batch); err != nil {
+			return nil, fmt.Errorf("failed to poll batch %s: %w", batch.ID, err)
+		}
+	}
+
+	run := &LLMBatchRun{ID: batch.ID, Status: batch.Status, Results: make([]LLMBatchResult, len(reqs))}
+	for _, fileID := range []string{batch.OutputFileID, batch.ErrorFileID} {
+		if fileID == "" {
+			continue
+		}
+		if err := p.readBatchResults(ctx, fileID, run.Results); err != nil {
+			return nil, err
+		}
+	}
+
+	// Requests without a line failed as a whole, e.g. when the batch expired or failed validation
+	reason := fmt.Sprintf("batch ended with status %s", batch.Status)
+	if batch.Errors != nil && len(batch.Errors.Data) > 0 {
+		reason += ": " + batch.Errors.Data[0].Message
+	}
+	for i := range run.Results {
+		if run.Results[i].Response == nil && run.Results[i].Err == nil {
+			run.Results[i].Err = fmt.Errorf("%s", reason)
+		}
+	}
+	return run, nil
+}
+
+// uploadBatchFile uploads the JSONL input of a batch and returns its file ID.
+func (p *OpenAIProvider) uploadBatchFile(ctx context.Context, data []byte) (string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.WriteField("purpose", "batch"); err != nil {
+		return "", fmt.Errorf("failed to build batch upload: %w", err)
+	}
+	part, err := writer.CreateFormFile("file", "batch.jsonl")
+	if err != nil {
+		return "", fmt.Errorf("failed to build batch upload: %w", err)
+	}
+	if _, err := part.Write(data); err != nil {
+		return "", fmt.Errorf("failed to build batch upload: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("failed to build batch upload: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/files", &body)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", writer.FormDataContentType())
+
+	var file struct {
+		ID string `json:"id"`
+	}
+	if err := p.doBatchHTTP(httpReq, &file); err != nil {
+		return "", fmt.Errorf("failed to upload batch file: %w", err)
+	}
+	return file.ID, nil
+}
+
+// readBatchResults downloads a batch output or error file into results.
+func (p *OpenAIProvider) readBatchResults(ctx context.Context, fileID string, results []LLMBatchResult) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/files/"+fileID+"/content", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	p.setBatchHeaders(ctx, httpReq)
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to download batch file %s: %w", fileID, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download batch file %s: HTTP %d", fileID, resp.StatusCode)
+	}
+
+	scanner := bufio.NewScanner(io.LimitReader(resp.Body, openAIBatchMaxFileBytes))
+	scanner.Buffer(make([]byte, 0, 64*1024), 64<<20)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var line openAIBatchLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			return fmt.Errorf("failed to parse batch file %s: %w", fileID, err)
+		}
+		index, ok := openAIBatchIndex(line.CustomID, len(results))
+		if !ok {
+			continue
+		}
+		results[index] = p.convertBatchLine(&line)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read batch file %s: %w", fileID, err)
+	}
+	return nil
+}
+
+// convertBatchLine converts a line of a batch file into a result.
+func (p *OpenAIProvider) convertBatchLine(line *openAIBatchLine) LLMBatchResult {
+	if line.Error != nil {
+		return LLMBatchResult{Err: &models.LLMError{
+			Provider: models.LLMProviderOpenAI,
+			Code:     line.Error.Code,
+			Message:  line.Error.Message,
+		}}
+	}
+	if line.Response == nil {
+		return LLMBatchResult{Err: fmt.Errorf("batch line has no response")}
+	}
+
+	if line.Response.StatusCode != http.StatusOK {
+		var errorResp struct {
+			Error struct {
+				Code    any    `json:"code"`
+				Message string `json:"message"`
+				Type    string `json:"type"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(line.Response.Body, &errorResp); err == nil && errorResp.Error.Message != "" {
+			return LLMBatchResult{Err: &models.LLMError{
+				Provider: models.LLMProviderOpenAI,
+				Code:     fmt.Sprintf("%v", errorResp.Error.Code),
+				Message:  errorResp.Error.Message,
+				Type:     errorResp.Error.Type,
+			}}
+		}
+		return LLMBatchResult{Err: fmt.Errorf("OpenAI API error (status %d)", line.Response.StatusCode)}
+	}
+
+	var apiResp openAIChatCompletionResponse
+	if err := json.Unmarshal(line.Response.Body, &apiResp); err != nil {
+		return LLMBatchResult{Err: fmt.Errorf("failed to parse response: %w", err)}
+	}
+	return LLMBatchResult{Response: p.convertResponse(&apiResp)}
+}
+
+// batchRequest sends a JSON request to the Batch API and decodes the response into out.
+func (p *OpenAIProvider) batchRequest(ctx context.Context, method, path string, body any, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, p.baseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	return p.doBatchHTTP(httpReq, out)
+}
+
+// doBatchHTTP sends a Batch API request and decodes the JSON response into out, which may be nil.
+func (p *OpenAIProvider) doBatchHTTP(httpReq *http.Request, out any) error {
+	p.setBatchHeaders(httpReq.Context(), httpReq)
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var errorResp struct {
+			Error struct {
+				Code    any    `json:"code"`
+				Message string `json:"message"`
+				Type    string `json:"type"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(respBody, &errorResp); err == nil && errorResp.Error.Message != "" {
+			return &models.LLMError{
+				Provider: models.LLMProviderOpenAI,
+				Code:     fmt.Sprintf("%v", errorResp.Error.Code),
+				Message:  errorResp.Error.Message,
+				Type:     errorResp.Error.Type,
+			}
+		}
+		return fmt.Errorf("OpenAI API error (status %d): %s", resp.StatusCode, string(respBody))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+func (p *OpenAIProvider) setBatchHeaders(ctx context.Context, httpReq *http.Request) {
+	httpReq.Header.Set("Authorization", "Bearer "+p.apiKey)
+	if p.orgID != "" {
+		httpReq.Header.Set("OpenAI-Organization", p.orgID)
+	}
+	executor.InjectHeaders(ctx, httpReq.Header)
+}
+
+// openAIBatchFinished reports whether a batch status is final.
+func openAIBatchFinished(status string) bool {
+	switch status {
+	case "completed", "failed", "expired", "cancelled":
+		return true
+	default:
+		return false
+	}
+}
+
+func openAIBatchCustomID(index int) string {
+	return "item-" + strconv.Itoa(index)
+}
+
+// openAIBatchIndex parses the request index from a custom_id.
+func openAIBatchIndex(customID string, count int) (int, bool) {
+	index, err := strconv.Atoi(strings.TrimPrefix(customID, "item-"))
+	if err != nil || !strings.HasPrefix(customID, "item-") || index < 0 || index >= count {
+		return 0, false
+	}
+	return index, true
+}
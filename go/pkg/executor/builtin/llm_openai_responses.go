@@ -7,8 +7,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/smilemakc/mbflow/go/pkg/executor"
 	"github.com/smilemakc/mbflow/go/pkg/models"
 )
 
@@ -65,6 +68,7 @@ func (p *OpenAIResponsesProvider) Execute(ctx context.Context, req *models.LLMRe
 	if p.orgID != "" {
 		httpReq.Header.Set("OpenAI-Organization", p.orgID)
 	}
+	executor.InjectHeaders(ctx, httpReq.Header)
 
 	// Execute request
 	resp, err := p.client.Do(httpReq)
@@ -148,6 +152,9 @@ func (p *OpenAIResponsesProvider) buildRequestBody(req *models.LLMRequest) map[s
 	if req.Store != nil {
 		body["store"] = *req.Store
 	}
+	if metadata := openAIMetadata(req.Metadata); len(metadata) > 0 {
+		body["metadata"] = metadata
+	}
 
 	// Reasoning configuration (for o3-mini, etc.)
 	if req.Reasoning != nil {
@@ -222,6 +229,48 @@ func (p *OpenAIResponsesProvider) buildRequestBody(req *models.LLMRequest) map[s
 	return body
 }
 
+// OpenAI limits request metadata to 16 pairs with keys of up to 64 and values of up to 512 characters.
+const (
+	openAIMaxMetadataPairs    = 16
+	openAIMaxMetadataKeyLen   = 64
+	openAIMaxMetadataValueLen = 512
+)
+
+// openAIMetadata converts request metadata to the string pairs OpenAI accepts.
+// When there are too many pairs, the mbflow_* keys that identify the execution are kept.
+func openAIMetadata(metadata map[string]any) map[string]string {
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		if k != "" && len(k) <= openAIMaxMetadataKeyLen {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		iBuiltin, jBuiltin := strings.HasPrefix(keys[i], "mbflow_"), strings.HasPrefix(keys[j], "mbflow_")
+		if iBuiltin != jBuiltin {
+			return iBuiltin
+		}
+		return keys[i] < keys[j]
+	})
+	if len(keys) > openAIMaxMetadataPairs {
+		keys = keys[:openAIMaxMetadataPairs]
+	}
+
+	result := make(map[string]string, len(keys))
+	for _, k := range keys {
+		value := fmt.Sprint(metadata[k])
+		if len(value) > openAIMaxMetadataValueLen {
+			value = value[:openAIMaxMetadataValueLen]
+		}
+		result[k] = value
+	}
+	return result
+}
+
 // buildResponseFormat builds the response format for structured outputs.
 func (p *OpenAIResponsesProvider) buildResponseFormat(format *models.LLMResponseFormat) map[string]any {
 	result := map[string]any{
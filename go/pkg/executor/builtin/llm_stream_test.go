@@ -0,0 +1,176 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sseServer serves the given chunks as a Chat Completions event stream and records the request body.
+func sseServer(t *testing.T, body *map[string]any, chunks ...string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(body))
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, chunk := range chunks {
+			fmt.Fprintf(w, "data: %s\n\n", chunk)
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestOpenAIProvider_ExecuteStream(t *testing.T) {
+	var body map[string]any
+	server := sseServer(t, &body,
+		`{"id":"chatcmpl-1","model":"gpt-4o","choices":[{"index":0,"delta":{"role":"assistant","content":""}}]}`,
+		`{"id":"chatcmpl-1","model":"gpt-4o","choices":[{"index":0,"delta":{"content":"Hel"}}]}`,
+		`{"id":"chatcmpl-1","model":"gpt-4o","choices":[{"index":0,"delta":{"content":"lo!"}}]}`,
+		`{"id":"chatcmpl-1","model":"gpt-4o","choices":[{"index":0,"delta":{},"finish_reason":"stop"}]}`,
+		`{"id":"chatcmpl-1","model":"gpt-4o","choices":[],"usage":{"prompt_tokens":5,"completion_tokens":2,"total_tokens":7}}`,
+	)
+
+	provider, err := NewOpenAIProvider("sk-test", server.URL, "")
+	require.NoError(t, err)
+
+	var deltas []string
+	resp, err := provider.ExecuteStream(context.Background(), &models.LLMRequest{
+		Model:  "gpt-4o",
+		Prompt: "Say hello",
+	}, func(delta string) {
+		deltas = append(deltas, delta)
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Hel", "lo!"}, deltas)
+	assert.Equal(t, "Hello!", resp.Content)
+	assert.Equal(t, "chatcmpl-1", resp.ResponseID)
+	assert.Equal(t, "stop", resp.FinishReason)
+	assert.Equal(t, models.LLMUsage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7}, resp.Usage)
+
+	assert.Equal(t, true, body["stream"])
+	assert.Equal(t, map[string]any{"include_usage": true}, body["stream_options"])
+}
+
+func TestOpenAIProvider_ExecuteStream_ToolCalls(t *testing.T) {
+	var body map[string]any
+	server := sseServer(t, &body,
+		`{"id":"chatcmpl-2","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"get_weather","arguments":""}}]}}]}`,
+		`{"id":"chatcmpl-2","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"{\"city\":"}}]}}]}`,
+		`{"id":"chatcmpl-2","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"\"Berlin\"}"}}]}}]}`,
+		`{"id":"chatcmpl-2","choices":[{"index":0,"delta":{},"finish_reason":"tool_calls"}]}`,
+	)
+
+	provider, err := NewOpenAIProvider("sk-test", server.URL, "")
+	require.NoError(t, err)
+
+	resp, err := provider.ExecuteStream(context.Background(), &models.LLMRequest{
+		Model:  "gpt-4o",
+		Prompt: "Weather in Berlin?",
+	}, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "tool_calls", resp.FinishReason)
+	require.Len(t, resp.ToolCalls, 1)
+	assert.Equal(t, "call_1", resp.ToolCalls[0].ID)
+	assert.Equal(t, "get_weather", resp.ToolCalls[0].Function.Name)
+	assert.Equal(t, `{"city":"Berlin"}`, resp.ToolCalls[0].Function.Arguments)
+}
+
+func TestOpenAIProvider_ExecuteStream_ErrorChunk(t *testing.T) {
+	var body map[string]any
+	server := sseServer(t, &body,
+		`{"id":"chatcmpl-3","choices":[{"index":0,"delta":{"content":"Par"}}]}`,
+		`{"error":{"message":"The server had an error","type":"server_error","code":"500"}}`,
+	)
+
+	provider, err := NewOpenAIProvider("sk-test", server.URL, "")
+	require.NoError(t, err)
+
+	_, err = provider.ExecuteStream(context.Background(), &models.LLMRequest{Model: "gpt-4o", Prompt: "Hi"}, nil)
+
+	var llmErr *models.LLMError
+	require.True(t, errors.As(err, &llmErr))
+	assert.Equal(t, models.LLMProviderOpenAI, llmErr.Provider)
+	assert.Equal(t, "server_error", llmErr.Type)
+}
+
+func TestMistralProvider_ExecuteStream(t *testing.T) {
+	var body map[string]any
+	server := sseServer(t, &body,
+		`{"id":"cmpl-1","model":"mistral-small-latest","choices":[{"index":0,"delta":{"content":"Bonjour"},"finish_reason":"stop"}],"usage":{"prompt_tokens":4,"completion_tokens":1,"total_tokens":5}}`,
+	)
+
+	provider, err := NewMistralProvider("key", server.URL)
+	require.NoError(t, err)
+
+	resp, err := provider.ExecuteStream(context.Background(), &models.LLMRequest{Model: "mistral-small-latest", Prompt: "Hello"}, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Bonjour", resp.Content)
+	assert.Equal(t, 5, resp.Usage.TotalTokens)
+	assert.Equal(t, true, body["stream"])
+	assert.NotContains(t, body, "stream_options")
+}
+
+func TestLLMExecutor_Execute_StreamsToExecutionContext(t *testing.T) {
+	var body map[string]any
+	server := sseServer(t, &body,
+		`{"id":"chatcmpl-4","choices":[{"index":0,"delta":{"content":"Once upon"}}]}`,
+		`{"id":"chatcmpl-4","choices":[{"index":0,"delta":{"content":" a time"},"finish_reason":"stop"}]}`,
+	)
+
+	var deltas []string
+	ctx := executor.WithExecutionContext(context.Background(), &executor.ExecutionContextData{
+		ExecutionID:   "exec-1",
+		NodeID:        "story",
+		OnOutputDelta: func(delta string) { deltas = append(deltas, delta) },
+	})
+
+	exec := NewLLMExecutor()
+	result, err := exec.Execute(ctx, map[string]any{
+		"provider": "openai",
+		"model":    "gpt-4o",
+		"prompt":   "Tell a story",
+		"stream":   true,
+		"api_key":  "sk-test",
+		"base_url": server.URL,
+	}, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Once upon", " a time"}, deltas)
+	output, ok := result.(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "Once upon a time", output["content"])
+}
+
+func TestLLMExecutor_Execute_StreamFallsBackToSingleDelta(t *testing.T) {
+	exec := NewLLMExecutor()
+	provider := &MockLLMProvider{
+		ExecuteFn: func(ctx context.Context, req *models.LLMRequest) (*models.LLMResponse, error) {
+			return &models.LLMResponse{Content: "whole answer", Model: req.Model}, nil
+		},
+	}
+
+	var deltas []string
+	ctx := executor.WithExecutionContext(context.Background(), &executor.ExecutionContextData{
+		OnOutputDelta: func(delta string) { deltas = append(deltas, delta) },
+	})
+
+	req := &models.LLMRequest{Provider: "custom", Model: "custom-model", Prompt: "Hi", Stream: true}
+	resp, err := exec.executeRequest(ctx, req, provider)
+
+	require.NoError(t, err)
+	assert.Equal(t, "whole answer", resp.Content)
+	assert.Equal(t, []string{"whole answer"}, deltas)
+}
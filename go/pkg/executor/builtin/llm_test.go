@@ -2,7 +2,11 @@ package builtin
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -707,6 +711,53 @@ func TestLLMExecutor_Validate_GeminiProvider(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestLLMExecutor_Validate_GeminiSafetySettings(t *testing.T) {
+	executor := NewLLMExecutor()
+
+	newConfig := func(provider string, safety any) map[string]any {
+		return map[string]any{
+			"provider":        provider,
+			"model":           "gemini-2.5-flash",
+			"prompt":          "Hello",
+			"api_key":         "test-key",
+			"safety_settings": safety,
+		}
+	}
+
+	assert.NoError(t, executor.Validate(newConfig("gemini", map[string]any{
+		"HARM_CATEGORY_HARASSMENT":        "BLOCK_ONLY_HIGH",
+		"HARM_CATEGORY_DANGEROUS_CONTENT": "BLOCK_NONE",
+	})))
+
+	err := executor.Validate(newConfig("gemini", map[string]any{"HARM_CATEGORY_SPAM": "BLOCK_NONE"}))
+	assert.ErrorContains(t, err, "unknown harm category")
+
+	err = executor.Validate(newConfig("gemini", map[string]any{"HARM_CATEGORY_HARASSMENT": "BLOCK_SOME"}))
+	assert.ErrorContains(t, err, "invalid threshold")
+
+	err = executor.Validate(newConfig("gemini", []any{"HARM_CATEGORY_HARASSMENT"}))
+	assert.ErrorContains(t, err, "must be an object")
+
+	err = executor.Validate(newConfig("openai", map[string]any{"HARM_CATEGORY_HARASSMENT": "BLOCK_NONE"}))
+	assert.ErrorContains(t, err, "only supported by the gemini provider")
+}
+
+func TestLLMExecutor_ParseConfig_GeminiOptions(t *testing.T) {
+	executor := NewLLMExecutor()
+
+	req, err := executor.parseConfig(map[string]any{
+		"provider":        "gemini",
+		"model":           "gemini-2.5-flash",
+		"prompt":          "Hello",
+		"system_prompt":   "You are terse",
+		"safety_settings": map[string]any{"HARM_CATEGORY_HATE_SPEECH": "BLOCK_LOW_AND_ABOVE"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "You are terse", req.Instruction)
+	assert.Equal(t, map[string]string{"HARM_CATEGORY_HATE_SPEECH": "BLOCK_LOW_AND_ABOVE"}, req.SafetySettings)
+}
+
 func TestLLMExecutor_WithInputTemplates(t *testing.T) {
 	exec := NewLLMExecutor()
 
@@ -1691,3 +1742,62 @@ func TestLLMExecutor_getOrCreateProvider_OpenAIMinimalConfig(t *testing.T) {
 	_, ok := provider.(*OpenAIProvider)
 	assert.True(t, ok)
 }
+
+// TestLLMExecutor_Execute_PropagatesExecutionContext tests that requests carry execution metadata and headers
+func TestLLMExecutor_Execute_PropagatesExecutionContext(t *testing.T) {
+	var body map[string]any
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"resp-1","model":"gpt-4.1","status":"completed","output":[]}`)
+	}))
+	defer server.Close()
+
+	provider, err := NewOpenAIResponsesProvider("sk-test", server.URL, "")
+	require.NoError(t, err)
+
+	exec := NewLLMExecutor()
+	exec.RegisterProvider(models.LLMProviderOpenAIResponses, provider)
+
+	ctx := executor.WithExecutionContext(context.Background(), &executor.ExecutionContextData{
+		ExecutionID: "exec-1",
+		WorkflowID:  "wf-1",
+		NodeID:      "summarize",
+		Propagation: executor.Propagation{
+			CorrelationID: "corr-1",
+			RentalKeyID:   "rk-1",
+		},
+	})
+
+	_, err = exec.Execute(ctx, map[string]any{
+		"provider": "openai-responses",
+		"model":    "gpt-4.1",
+		"prompt":   "Hello",
+	}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"mbflow_correlation_id": "corr-1",
+		"mbflow_execution_id":   "exec-1",
+		"mbflow_workflow_id":    "wf-1",
+		"mbflow_node_id":        "summarize",
+	}, body["metadata"])
+	assert.Equal(t, "corr-1", headers.Get(executor.HeaderCorrelationID))
+	assert.Equal(t, "summarize", headers.Get(executor.HeaderNodeID))
+}
+
+func TestOpenAIMetadata_Limits(t *testing.T) {
+	metadata := map[string]any{"mbflow_execution_id": "exec-1", "a_long": strings.Repeat("x", 600)}
+	for i := 0; i < 20; i++ {
+		metadata[fmt.Sprintf("k%02d", i)] = i
+	}
+
+	result := openAIMetadata(metadata)
+	assert.Len(t, result, openAIMaxMetadataPairs)
+	assert.Equal(t, "exec-1", result["mbflow_execution_id"])
+	assert.Len(t, result["a_long"], openAIMaxMetadataValueLen)
+	assert.Equal(t, "0", result["k00"])
+	assert.NotContains(t, result, "k19")
+}
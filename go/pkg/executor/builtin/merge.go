@@ -19,6 +19,9 @@ func NewMergeExecutor() *MergeExecutor {
 	}
 }
 
+// SideEffectFree reports true. Merging parent outputs has no side effects.
+func (e *MergeExecutor) SideEffectFree() bool { return true }
+
 // Execute executes the merge logic.
 func (e *MergeExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
 	mergeStrategy := e.GetStringDefault(config, "merge_strategy", "all")
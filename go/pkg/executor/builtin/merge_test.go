@@ -4,6 +4,8 @@ import (
 	"context"
 	"reflect"
 	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
 )
 
 func TestMergeExecutor_Execute_StrategyAll(t *testing.T) {
@@ -249,3 +251,12 @@ func TestMergeExecutor_Execute_ComplexNestedInput(t *testing.T) {
 		t.Errorf("Expected result to equal input, got: %v", result)
 	}
 }
+
+func TestMergeExecutor_SideEffectFree(t *testing.T) {
+	if !executor.IsSideEffectFree(NewMergeExecutor()) {
+		t.Error("expected merge to run in dry runs")
+	}
+	if executor.IsSideEffectFree(NewHTTPExecutor()) {
+		t.Error("expected http to be stubbed in dry runs")
+	}
+}
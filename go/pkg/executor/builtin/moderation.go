@@ -0,0 +1,494 @@
+package builtin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+)
+
+// Moderation providers.
+const (
+	ModerationProviderOpenAI = "openai"
+	ModerationProviderLocal  = "local"
+)
+
+// Moderation actions.
+const (
+	ModerationActionRoute = "route"
+	ModerationActionBlock = "block"
+)
+
+const (
+	moderationDefaultModel   = "omni-moderation-latest"
+	moderationDefaultTimeout = 30
+	moderationMaxItems       = 32
+)
+
+// moderationItemResult is the classification of one content item.
+type moderationItemResult struct {
+	Flagged    bool
+	Categories map[string]bool
+	Scores     map[string]float64
+	Matches    map[string][]string
+}
+
+// ModerationExecutor scores content for policy categories, either with the OpenAI
+// moderation API or with a local classifier built from regular expressions.
+// Flagged content is either returned for routing or blocks the workflow.
+type ModerationExecutor struct {
+	*executor.BaseExecutor
+	client *http.Client
+}
+
+// NewModerationExecutor creates a new moderation executor.
+func NewModerationExecutor() *ModerationExecutor {
+	return &ModerationExecutor{
+		BaseExecutor: executor.NewBaseExecutor("moderation"),
+		client:       &http.Client{},
+	}
+}
+
+// Execute moderates the content.
+//
+// Config:
+//   - content: Text or array of texts to moderate (required)
+//   - provider: "openai" or "local" (default: "openai")
+//   - api_key: OpenAI API key (required for openai)
+//   - base_url: OpenAI API base URL (default: "https://api.openai.com/v1")
+//   - model: Moderation model (default: "omni-moderation-latest")
+//   - rules: Map of category to regular expressions (required for local); a category
+//     scores 1 when one of its expressions matches, case-insensitively
+//   - categories: Categories that decide whether content is flagged (default: all)
+//   - threshold: Score from which a category is flagged (default: the provider's verdict
+//     for openai, 1 for local)
+//   - thresholds: Per-category thresholds overriding threshold
+//   - action: "route" returns the verdict, "block" fails the node when content is
+//     flagged (default: "route")
+//   - timeout: Request timeout in seconds for openai (default: 30)
+//
+// Output:
+//   - flagged: Whether any content item was flagged
+//   - flagged_categories: Sorted categories flagged in any item
+//   - scores: Highest score per category across items
+//   - results: Per item flagged, flagged_categories, scores and, for local, matches
+//   - content: The moderated content, for downstream nodes
+//   - provider, model: Classifier used
+//   - duration_ms: Execution duration
+func (e *ModerationExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	startTime := time.Now()
+
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+
+	items, _ := moderationContent(config["content"])
+	provider := e.GetStringDefault(config, "provider", ModerationProviderOpenAI)
+
+	var (
+		results []*moderationItemResult
+		model   string
+		err     error
+	)
+	switch provider {
+	case ModerationProviderOpenAI:
+		model = e.GetStringDefault(config, "model", moderationDefaultModel)
+		results, err = e.moderateOpenAI(ctx, config, model, items)
+	case ModerationProviderLocal:
+		rules, _ := compileModerationRules(config["rules"])
+		results = moderateLocal(rules, items)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	policy := parseModerationPolicy(config, provider)
+	flagged := false
+	flaggedCategories := make(map[string]bool)
+	scores := make(map[string]float64)
+	outputResults := make([]map[string]any, len(results))
+
+	for i, result := range results {
+		itemCategories := policy.apply(result)
+		for _, category := range itemCategories {
+			flaggedCategories[category] = true
+		}
+		for category, score := range result.Scores {
+			if current, ok := scores[category]; !ok || score > current {
+				scores[category] = score
+			}
+		}
+		flagged = flagged || result.Flagged
+
+		outputResult := map[string]any{
+			"flagged":            result.Flagged,
+			"flagged_categories": itemCategories,
+			"scores":             result.Scores,
+		}
+		if result.Matches != nil {
+			outputResult["matches"] = result.Matches
+		}
+		outputResults[i] = outputResult
+	}
+
+	categories := sortedKeys(flaggedCategories)
+	if flagged && e.GetStringDefault(config, "action", ModerationActionRoute) == ModerationActionBlock {
+		return nil, fmt.Errorf("content blocked by moderation: %s", strings.Join(categories, ", "))
+	}
+
+	output := map[string]any{
+		"flagged":            flagged,
+		"flagged_categories": categories,
+		"scores":             scores,
+		"results":            outputResults,
+		"content":            config["content"],
+		"provider":           provider,
+		"duration_ms":        time.Since(startTime).Milliseconds(),
+	}
+	if model != "" {
+		output["model"] = model
+	}
+	return output, nil
+}
+
+// Validate validates the moderation executor configuration.
+func (e *ModerationExecutor) Validate(config map[string]any) error {
+	if _, ok := config["content"]; !ok {
+		return fmt.Errorf("content is required")
+	}
+	items, err := moderationContent(config["content"])
+	if err != nil {
+		return err
+	}
+	if len(items) > moderationMaxItems {
+		return fmt.Errorf("content must have at most %d items", moderationMaxItems)
+	}
+
+	switch provider := e.GetStringDefault(config, "provider", ModerationProviderOpenAI); provider {
+	case ModerationProviderOpenAI:
+		if e.GetStringDefault(config, "api_key", "") == "" {
+			return fmt.Errorf("api_key is required for the openai provider")
+		}
+		if timeout := e.GetIntDefault(config, "timeout", moderationDefaultTimeout); timeout <= 0 {
+			return fmt.Errorf("timeout must be positive")
+		}
+	case ModerationProviderLocal:
+		if _, ok := config["rules"]; !ok {
+			return fmt.Errorf("rules is required for the local provider")
+		}
+		if _, err := compileModerationRules(config["rules"]); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("provider must be %q or %q, got %q", ModerationProviderOpenAI, ModerationProviderLocal, provider)
+	}
+
+	switch action := e.GetStringDefault(config, "action", ModerationActionRoute); action {
+	case ModerationActionRoute, ModerationActionBlock:
+	default:
+		return fmt.Errorf("action must be %q or %q, got %q", ModerationActionRoute, ModerationActionBlock, action)
+	}
+
+	if raw, ok := config["categories"]; ok {
+		if _, err := toStringSlice(raw, "categories"); err != nil {
+			return err
+		}
+	}
+	if raw, ok := config["threshold"]; ok {
+		threshold, ok := toFloat(raw)
+		if !ok || threshold < 0 || threshold > 1 {
+			return fmt.Errorf("threshold must be a number between 0 and 1")
+		}
+	}
+	if raw, ok := config["thresholds"]; ok {
+		thresholds, ok := raw.(map[string]any)
+		if !ok {
+			return fmt.Errorf("thresholds must be an object")
+		}
+		for category, value := range thresholds {
+			threshold, ok := toFloat(value)
+			if !ok || threshold < 0 || threshold > 1 {
+				return fmt.Errorf("thresholds.%s must be a number between 0 and 1", category)
+			}
+		}
+	}
+
+	return nil
+}
+
+// openAIModerationResponse is the response of the OpenAI moderations endpoint.
+type openAIModerationResponse struct {
+	Model   string `json:"model"`
+	Results []struct {
+		Flagged        bool               `json:"flagged"`
+		Categories     map[string]bool    `json:"categories"`
+		CategoryScores map[string]float64 `json:"category_scores"`
+	} `json:"results"`
+}
+
+// moderateOpenAI classifies the items with the OpenAI moderation API.
+func (e *ModerationExecutor) moderateOpenAI(ctx context.Context, config map[string]any, model string, items []string) ([]*moderationItemResult, error) {
+	baseURL := strings.TrimRight(e.GetStringDefault(config, "base_url", "https://api.openai.com/v1"), "/")
+	timeout := time.Duration(e.GetIntDefault(config, "timeout", moderationDefaultTimeout)) * time.Second
+
+	body, err := json.Marshal(map[string]any{"model": model, "input": items})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/moderations", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+e.GetStringDefault(config, "api_key", ""))
+	executor.InjectHeaders(ctx, httpReq.Header)
+
+	resp, err := e.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("moderation request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var errorResp struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(respBody, &errorResp); err == nil && errorResp.Error.Message != "" {
+			return nil, fmt.Errorf("moderation API error (status %d): %s", resp.StatusCode, errorResp.Error.Message)
+		}
+		return nil, fmt.Errorf("moderation API error (status %d)", resp.StatusCode)
+	}
+
+	var apiResp openAIModerationResponse
+	if err := json.Unmarshal(respBody, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(apiResp.Results) != len(items) {
+		return nil, fmt.Errorf("moderation API returned %d results for %d items", len(apiResp.Results), len(items))
+	}
+
+	results := make([]*moderationItemResult, len(items))
+	for i, r := range apiResp.Results {
+		scores := r.CategoryScores
+		if scores == nil {
+			scores = make(map[string]float64)
+		}
+		results[i] = &moderationItemResult{Flagged: r.Flagged, Categories: r.Categories, Scores: scores}
+	}
+	return results, nil
+}
+
+// moderationRule is a compiled category of the local classifier.
+type moderationRule struct {
+	category string
+	patterns []*regexp.Regexp
+}
+
+// compileModerationRules compiles the rules of the local classifier, sorted by category.
+func compileModerationRules(raw any) ([]moderationRule, error) {
+	rulesMap, ok := raw.(map[string]any)
+	if !ok || len(rulesMap) == 0 {
+		return nil, fmt.Errorf("rules must be a non-empty object of category to patterns")
+	}
+
+	rules := make([]moderationRule, 0, len(rulesMap))
+	for _, category := range sortedKeys(rulesMap) {
+		patterns, err := toStringSlice(rulesMap[category], "rules."+category)
+		if err != nil {
+			return nil, err
+		}
+		if len(patterns) == 0 {
+			return nil, fmt.Errorf("rules.%s must have at least one pattern", category)
+		}
+		rule := moderationRule{category: category}
+		for i, pattern := range patterns {
+			re, err := regexp.Compile("(?i)" + pattern)
+			if err != nil {
+				return nil, fmt.Errorf("rules.%s[%d] is not a valid regular expression: %w", category, i, err)
+			}
+			rule.patterns = append(rule.patterns, re)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// moderateLocal classifies the items with the local rules.
+func moderateLocal(rules []moderationRule, items []string) []*moderationItemResult {
+	results := make([]*moderationItemResult, len(items))
+	for i, item := range items {
+		result := &moderationItemResult{
+			Categories: make(map[string]bool),
+			Scores:     make(map[string]float64),
+			Matches:    make(map[string][]string),
+		}
+		for _, rule := range rules {
+			var matches []string
+			for _, re := range rule.patterns {
+				if match := re.FindString(item); match != "" {
+					matches = append(matches, match)
+				}
+			}
+			result.Scores[rule.category] = 0
+			if len(matches) > 0 {
+				result.Scores[rule.category] = 1
+				result.Categories[rule.category] = true
+				result.Matches[rule.category] = matches
+			}
+		}
+		results[i] = result
+	}
+	return results
+}
+
+// moderationPolicy decides which categories flag content.
+type moderationPolicy struct {
+	categories   map[string]bool
+	threshold    float64
+	hasThreshold bool
+	thresholds   map[string]float64
+}
+
+func parseModerationPolicy(config map[string]any, provider string) *moderationPolicy {
+	policy := &moderationPolicy{thresholds: make(map[string]float64)}
+	if raw, ok := config["categories"]; ok {
+		categories, _ := toStringSlice(raw, "categories")
+		policy.categories = make(map[string]bool, len(categories))
+		for _, category := range categories {
+			policy.categories[category] = true
+		}
+	}
+	if raw, ok := config["threshold"]; ok {
+		policy.threshold, policy.hasThreshold = toFloat(raw)
+	} else if provider == ModerationProviderLocal {
+		policy.threshold, policy.hasThreshold = 1, true
+	}
+	if raw, ok := config["thresholds"].(map[string]any); ok {
+		for category, value := range raw {
+			if threshold, ok := toFloat(value); ok {
+				policy.thresholds[category] = threshold
+			}
+		}
+	}
+	return policy
+}
+
+// apply decides the flagged categories of a result, updates its Flagged verdict and
+// returns the sorted flagged categories.
+func (p *moderationPolicy) apply(result *moderationItemResult) []string {
+	candidates := make(map[string]bool)
+	for category := range result.Scores {
+		candidates[category] = true
+	}
+	for category := range result.Categories {
+		candidates[category] = true
+	}
+
+	flagged := make(map[string]bool)
+	for category := range candidates {
+		if p.categories != nil && !p.categories[category] {
+			continue
+		}
+		if threshold, ok := p.thresholds[category]; ok {
+			if result.Scores[category] >= threshold {
+				flagged[category] = true
+			}
+		} else if p.hasThreshold {
+			if result.Scores[category] >= p.threshold {
+				flagged[category] = true
+			}
+		} else if result.Categories[category] {
+			flagged[category] = true
+		}
+	}
+
+	result.Flagged = len(flagged) > 0
+	return sortedKeys(flagged)
+}
+
+// moderationContent returns the content items to moderate.
+func moderationContent(raw any) ([]string, error) {
+	switch v := raw.(type) {
+	case string:
+		return []string{v}, nil
+	case []string:
+		if len(v) == 0 {
+			return nil, fmt.Errorf("content must not be empty")
+		}
+		return v, nil
+	case []any:
+		if len(v) == 0 {
+			return nil, fmt.Errorf("content must not be empty")
+		}
+		return toStringSlice(v, "content")
+	default:
+		return nil, fmt.Errorf("content must be a string or an array of strings")
+	}
+}
+
+// toStringSlice converts a []any or []string config value into a string slice.
+func toStringSlice(raw any, field string) ([]string, error) {
+	switch v := raw.(type) {
+	case []string:
+		return v, nil
+	case []any:
+		result := make([]string, len(v))
+		for i, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s[%d] must be a string", field, i)
+			}
+			result[i] = s
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("%s must be an array of strings", field)
+	}
+}
+
+// toFloat converts a numeric config value into a float64.
+func toFloat(raw any) (float64, bool) {
+	switch v := raw.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// sortedKeys returns the keys of a map in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,166 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModerationExecutor_Validate(t *testing.T) {
+	exec := NewModerationExecutor()
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{"valid openai", map[string]any{"content": "hi", "api_key": "sk-test"}, ""},
+		{"valid local", map[string]any{"provider": "local", "content": []any{"a", "b"}, "rules": map[string]any{"spam": []any{"buy now"}}}, ""},
+		{"missing content", map[string]any{"api_key": "sk-test"}, "content is required"},
+		{"empty content", map[string]any{"content": []any{}, "api_key": "sk-test"}, "must not be empty"},
+		{"non-string content", map[string]any{"content": []any{"a", 1}, "api_key": "sk-test"}, "content[1]"},
+		{"missing api key", map[string]any{"content": "hi"}, "api_key is required"},
+		{"unknown provider", map[string]any{"content": "hi", "provider": "other"}, "provider must be"},
+		{"missing rules", map[string]any{"content": "hi", "provider": "local"}, "rules is required"},
+		{"invalid pattern", map[string]any{"content": "hi", "provider": "local", "rules": map[string]any{"spam": []any{"("}}}, "rules.spam[0]"},
+		{"unknown action", map[string]any{"content": "hi", "api_key": "sk-test", "action": "drop"}, "action must be"},
+		{"threshold out of range", map[string]any{"content": "hi", "api_key": "sk-test", "threshold": 2}, "threshold must be"},
+		{"category threshold", map[string]any{"content": "hi", "api_key": "sk-test", "thresholds": map[string]any{"hate": "high"}}, "thresholds.hate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exec.Validate(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func newModerationServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/moderations", r.URL.Path)
+		assert.Equal(t, "Bearer sk-test", r.Header.Get("Authorization"))
+
+		var req struct {
+			Model string   `json:"model"`
+			Input []string `json:"input"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		results := make([]map[string]any, len(req.Input))
+		for i, text := range req.Input {
+			hate := 0.01
+			if text == "hateful" {
+				hate = 0.4
+			}
+			results[i] = map[string]any{
+				"flagged":         hate > 0.5,
+				"categories":      map[string]bool{"hate": hate > 0.5, "violence": false},
+				"category_scores": map[string]float64{"hate": hate, "violence": 0.02},
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"model": req.Model, "results": results})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestModerationExecutor_OpenAI(t *testing.T) {
+	server := newModerationServer(t)
+	exec := NewModerationExecutor()
+
+	config := map[string]any{
+		"content":  []any{"hello", "hateful"},
+		"api_key":  "sk-test",
+		"base_url": server.URL,
+	}
+	result, err := exec.Execute(context.Background(), config, nil)
+	require.NoError(t, err)
+
+	output := result.(map[string]any)
+	assert.Equal(t, false, output["flagged"])
+	assert.Equal(t, []string{}, output["flagged_categories"])
+	assert.Equal(t, "omni-moderation-latest", output["model"])
+	assert.Equal(t, map[string]float64{"hate": 0.4, "violence": 0.02}, output["scores"])
+
+	// A stricter threshold for one category overrides the provider verdict
+	config["thresholds"] = map[string]any{"hate": 0.3}
+	result, err = exec.Execute(context.Background(), config, nil)
+	require.NoError(t, err)
+
+	output = result.(map[string]any)
+	assert.Equal(t, true, output["flagged"])
+	assert.Equal(t, []string{"hate"}, output["flagged_categories"])
+	results := output["results"].([]map[string]any)
+	assert.Equal(t, false, results[0]["flagged"])
+	assert.Equal(t, true, results[1]["flagged"])
+
+	// Categories outside the configured list never flag
+	config["categories"] = []any{"violence"}
+	result, err = exec.Execute(context.Background(), config, nil)
+	require.NoError(t, err)
+	assert.Equal(t, false, result.(map[string]any)["flagged"])
+}
+
+func TestModerationExecutor_OpenAIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":{"message":"Incorrect API key provided"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := NewModerationExecutor().Execute(context.Background(), map[string]any{
+		"content":  "hello",
+		"api_key":  "sk-test",
+		"base_url": server.URL,
+	}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 401): Incorrect API key provided")
+}
+
+func TestModerationExecutor_Local(t *testing.T) {
+	exec := NewModerationExecutor()
+	config := map[string]any{
+		"provider": "local",
+		"content":  "Limited offer: BUY NOW at https://spam.example",
+		"rules": map[string]any{
+			"spam":      []any{`buy now`, `limited offer`},
+			"profanity": []any{`\bdarn\b`},
+		},
+	}
+
+	result, err := exec.Execute(context.Background(), config, nil)
+	require.NoError(t, err)
+
+	output := result.(map[string]any)
+	assert.Equal(t, true, output["flagged"])
+	assert.Equal(t, []string{"spam"}, output["flagged_categories"])
+	assert.Equal(t, map[string]float64{"spam": 1, "profanity": 0}, output["scores"])
+	assert.Equal(t, "local", output["provider"])
+	assert.NotContains(t, output, "model")
+
+	results := output["results"].([]map[string]any)
+	assert.Equal(t, map[string][]string{"spam": {"BUY NOW", "Limited offer"}}, results[0]["matches"])
+
+	config["action"] = "block"
+	_, err = exec.Execute(context.Background(), config, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "content blocked by moderation: spam")
+
+	config["content"] = "A perfectly polite message"
+	result, err = exec.Execute(context.Background(), config, nil)
+	require.NoError(t, err)
+	assert.Equal(t, false, result.(map[string]any)["flagged"])
+	assert.Equal(t, "A perfectly polite message", result.(map[string]any)["content"])
+}
@@ -0,0 +1,472 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	mongoOpFind      = "find"
+	mongoOpInsert    = "insert"
+	mongoOpUpdate    = "update"
+	mongoOpAggregate = "aggregate"
+
+	// mongoDefaultMaxDocuments caps the documents read from a cursor unless max_documents is set.
+	mongoDefaultMaxDocuments = 1000
+	// mongoMaxDocumentsLimit is the largest allowed max_documents.
+	mongoMaxDocumentsLimit = 10000
+)
+
+// MongoDBExecutor runs find, insert, update and aggregate operations against MongoDB.
+// Clients are pooled per connection string and credential and reused across executions;
+// call Close to disconnect them. Passwords are never part of the node config: authentication
+// uses a credentials resource referenced by ID (basic_auth, or custom with username/password fields).
+type MongoDBExecutor struct {
+	*executor.BaseExecutor
+	credentials CredentialResolver
+	connect     func(ctx context.Context, opts *options.ClientOptions) (*mongo.Client, error)
+
+	mu      sync.Mutex
+	clients map[string]*mongo.Client
+}
+
+// NewMongoDBExecutor creates a new MongoDB executor.
+// credentials may be nil, in which case only deployments without authentication can be used.
+func NewMongoDBExecutor(credentials CredentialResolver) *MongoDBExecutor {
+	return &MongoDBExecutor{
+		BaseExecutor: executor.NewBaseExecutor("mongodb"),
+		credentials:  credentials,
+		connect: func(ctx context.Context, opts *options.ClientOptions) (*mongo.Client, error) {
+			return mongo.Connect(ctx, opts)
+		},
+		clients: make(map[string]*mongo.Client),
+	}
+}
+
+// Execute runs a MongoDB operation.
+//
+// Config:
+//   - uri: Connection string without credentials, e.g. "mongodb://mongo.internal:27017" (required)
+//   - credential_id: ID of a credentials resource holding username/password; the credential
+//     must be attached to the workflow, e.g. credential_id: "{{resource.mongo.id}}"
+//   - auth_source: Authentication database (default: the driver default, usually "admin")
+//   - database: Database name (required)
+//   - collection: Collection name (required)
+//   - operation: "find" | "insert" | "update" | "aggregate" (required)
+//   - filter: Query filter for find and update (default: {})
+//   - projection, sort, skip: Find options
+//   - document / documents: Document or array of documents to insert
+//   - update: Update document, e.g. {"$set": {...}} (required for update)
+//   - many: Update all matching documents instead of the first (default: false)
+//   - upsert: Insert a document if none matches (default: false)
+//   - pipeline: Aggregation pipeline stages (required for aggregate)
+//   - max_documents: Maximum number of documents read from the cursor (default: 1000, max: 10000)
+//   - timeout: Timeout in seconds (default: 30)
+//
+// Filters, documents and pipelines accept MongoDB Extended JSON, e.g.
+// {"_id": {"$oid": "..."}} or {"created_at": {"$gte": {"$date": "2024-01-01T00:00:00Z"}}}.
+//
+// Output:
+//   - find, aggregate: documents (relaxed Extended JSON), count, truncated
+//   - insert: inserted_ids, inserted_count
+//   - update: matched_count, modified_count, upserted_id
+//   - duration_ms: Execution duration
+func (e *MongoDBExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	startTime := time.Now()
+
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+
+	timeout := time.Duration(e.GetIntDefault(config, "timeout", 30)) * time.Second
+	opCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	client, err := e.client(opCtx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	coll := client.Database(e.GetStringDefault(config, "database", "")).
+		Collection(e.GetStringDefault(config, "collection", ""))
+
+	operation := e.GetStringDefault(config, "operation", "")
+	var output map[string]any
+	switch operation {
+	case mongoOpFind:
+		output, err = e.find(opCtx, coll, config)
+	case mongoOpInsert:
+		output, err = e.insert(opCtx, coll, config)
+	case mongoOpUpdate:
+		output, err = e.update(opCtx, coll, config)
+	case mongoOpAggregate:
+		output, err = e.aggregate(opCtx, coll, config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("mongodb %s failed: %w", operation, err)
+	}
+
+	output["duration_ms"] = time.Since(startTime).Milliseconds()
+	return output, nil
+}
+
+// Validate validates the MongoDB executor configuration.
+func (e *MongoDBExecutor) Validate(config map[string]any) error {
+	if err := e.ValidateRequired(config, "uri", "database", "collection", "operation"); err != nil {
+		return err
+	}
+
+	for _, key := range []string{"password", "username"} {
+		if _, ok := config[key]; ok {
+			return fmt.Errorf("%s must not be set inline: store it in a credentials resource and reference it with credential_id", key)
+		}
+	}
+
+	uri, err := url.Parse(e.GetStringDefault(config, "uri", ""))
+	if err != nil || (uri.Scheme != "mongodb" && uri.Scheme != "mongodb+srv") {
+		return fmt.Errorf("uri must be a mongodb:// or mongodb+srv:// connection string")
+	}
+	if uri.User != nil {
+		return fmt.Errorf("uri must not contain credentials: store them in a credentials resource and reference it with credential_id")
+	}
+
+	switch operation := e.GetStringDefault(config, "operation", ""); operation {
+	case mongoOpFind, mongoOpAggregate:
+		if operation == mongoOpAggregate {
+			if _, ok := config["pipeline"].([]any); !ok {
+				return fmt.Errorf("pipeline must be an array of stages")
+			}
+		}
+	case mongoOpInsert:
+		_, hasDocument := config["document"].(map[string]any)
+		_, hasDocuments := config["documents"].([]any)
+		if !hasDocument && !hasDocuments {
+			return fmt.Errorf("insert requires document (object) or documents (array)")
+		}
+	case mongoOpUpdate:
+		if _, ok := config["update"]; !ok {
+			return fmt.Errorf("update requires an update document")
+		}
+	default:
+		return fmt.Errorf("invalid operation: %s (valid: find, insert, update, aggregate)", operation)
+	}
+
+	if maxDocs := e.GetIntDefault(config, "max_documents", mongoDefaultMaxDocuments); maxDocs < 1 || maxDocs > mongoMaxDocumentsLimit {
+		return fmt.Errorf("max_documents must be between 1 and %d", mongoMaxDocumentsLimit)
+	}
+
+	return nil
+}
+
+// Close disconnects all pooled clients.
+func (e *MongoDBExecutor) Close(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var firstErr error
+	for key, client := range e.clients {
+		if err := client.Disconnect(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(e.clients, key)
+	}
+	return firstErr
+}
+
+// Health pings the pooled clients, so deployments that became unreachable make the executor
+// unhealthy. An executor without pooled clients is healthy.
+func (e *MongoDBExecutor) Health(ctx context.Context) error {
+	var errs []error
+	for _, err := range e.HealthTargets(ctx) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// HealthTargets pings the pooled clients and returns the results by pool key, so that a
+// deployment one workflow connects to being unreachable does not hold back nodes connecting
+// to others.
+func (e *MongoDBExecutor) HealthTargets(ctx context.Context) map[string]error {
+	e.mu.Lock()
+	clients := make(map[string]*mongo.Client, len(e.clients))
+	for key, client := range e.clients {
+		clients[key] = client
+	}
+	e.mu.Unlock()
+
+	results := make(map[string]error, len(clients))
+	for key, client := range clients {
+		results[key] = nil
+		if err := client.Ping(ctx, nil); err != nil {
+			// Report the hosts only, in case the connection string carries credentials
+			uri, _, _ := strings.Cut(key, "\x00")
+			host := "deployment"
+			if parsed, parseErr := url.Parse(uri); parseErr == nil {
+				host = parsed.Host
+			}
+			results[key] = fmt.Errorf("mongodb %s: %w", host, err)
+		}
+	}
+	return results
+}
+
+// HealthTarget returns the key of the pooled client a node with the config uses.
+func (e *MongoDBExecutor) HealthTarget(config map[string]any) string {
+	return e.poolKey(config)
+}
+
+// poolKey returns the key clients are pooled by: the connection string, credential and auth source.
+func (e *MongoDBExecutor) poolKey(config map[string]any) string {
+	uri := e.GetStringDefault(config, "uri", "")
+	credentialID := e.GetStringDefault(config, "credential_id", "")
+	authSource := e.GetStringDefault(config, "auth_source", "")
+	return uri + "\x00" + credentialID + "\x00" + authSource
+}
+
+// client returns the pooled client for the connection string and credential, connecting on first use.
+func (e *MongoDBExecutor) client(ctx context.Context, config map[string]any) (*mongo.Client, error) {
+	uri := e.GetStringDefault(config, "uri", "")
+	credentialID := e.GetStringDefault(config, "credential_id", "")
+	authSource := e.GetStringDefault(config, "auth_source", "")
+
+	// Resolve first so the attachment check runs on every execution, not only when connecting
+	username, password, err := resolveUsernamePassword(ctx, e.credentials, credentialID)
+	if err != nil {
+		return nil, err
+	}
+
+	key := e.poolKey(config)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if client, ok := e.clients[key]; ok {
+		return client, nil
+	}
+
+	opts := options.Client().ApplyURI(uri)
+	if username != "" {
+		opts.SetAuth(options.Credential{Username: username, Password: password, AuthSource: authSource})
+	}
+
+	client, err := e.connect(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to mongodb: %w", err)
+	}
+	e.clients[key] = client
+	return client, nil
+}
+
+func (e *MongoDBExecutor) find(ctx context.Context, coll *mongo.Collection, config map[string]any) (map[string]any, error) {
+	filter, err := mongoDocument(config["filter"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+
+	maxDocs := e.GetIntDefault(config, "max_documents", mongoDefaultMaxDocuments)
+	// Ask for one more document than allowed to detect truncation
+	opts := options.Find().SetLimit(int64(maxDocs) + 1)
+	if raw, ok := config["projection"]; ok {
+		projection, err := mongoDocument(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid projection: %w", err)
+		}
+		opts.SetProjection(projection)
+	}
+	if raw, ok := config["sort"]; ok {
+		sort, err := mongoDocument(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sort: %w", err)
+		}
+		opts.SetSort(sort)
+	}
+	if skip := e.GetIntDefault(config, "skip", 0); skip > 0 {
+		opts.SetSkip(int64(skip))
+	}
+
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	return readMongoCursor(ctx, cursor, maxDocs)
+}
+
+func (e *MongoDBExecutor) insert(ctx context.Context, coll *mongo.Collection, config map[string]any) (map[string]any, error) {
+	raw, ok := config["documents"].([]any)
+	if !ok {
+		raw = []any{config["document"]}
+	}
+
+	docs := make([]any, len(raw))
+	for i, item := range raw {
+		doc, err := mongoDocument(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid document %d: %w", i, err)
+		}
+		docs[i] = doc
+	}
+
+	res, err := coll.InsertMany(ctx, docs)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := mongoToJSON(res.InsertedIDs)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"inserted_ids":   ids,
+		"inserted_count": len(res.InsertedIDs),
+	}, nil
+}
+
+func (e *MongoDBExecutor) update(ctx context.Context, coll *mongo.Collection, config map[string]any) (map[string]any, error) {
+	filter, err := mongoDocument(config["filter"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+
+	var update any
+	if stages, ok := config["update"].([]any); ok {
+		// Aggregation pipeline update
+		update, err = mongoPipeline(stages)
+	} else {
+		update, err = mongoDocument(config["update"])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid update: %w", err)
+	}
+
+	opts := options.Update().SetUpsert(e.GetBoolDefault(config, "upsert", false))
+
+	var res *mongo.UpdateResult
+	if e.GetBoolDefault(config, "many", false) {
+		res, err = coll.UpdateMany(ctx, filter, update, opts)
+	} else {
+		res, err = coll.UpdateOne(ctx, filter, update, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	upsertedID, err := mongoToJSON(res.UpsertedID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"matched_count":  res.MatchedCount,
+		"modified_count": res.ModifiedCount,
+		"upserted_id":    upsertedID,
+	}, nil
+}
+
+func (e *MongoDBExecutor) aggregate(ctx context.Context, coll *mongo.Collection, config map[string]any) (map[string]any, error) {
+	stages, _ := config["pipeline"].([]any)
+	pipeline, err := mongoPipeline(stages)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pipeline: %w", err)
+	}
+
+	maxDocs := e.GetIntDefault(config, "max_documents", mongoDefaultMaxDocuments)
+	opts := options.Aggregate().SetBatchSize(int32(min(maxDocs+1, 1000)))
+
+	cursor, err := coll.Aggregate(ctx, pipeline, opts)
+	if err != nil {
+		return nil, err
+	}
+	return readMongoCursor(ctx, cursor, maxDocs)
+}
+
+// readMongoCursor reads up to maxDocs documents and closes the cursor.
+func readMongoCursor(ctx context.Context, cursor *mongo.Cursor, maxDocs int) (map[string]any, error) {
+	defer cursor.Close(ctx)
+
+	docs := make([]any, 0)
+	truncated := false
+	for cursor.Next(ctx) {
+		if len(docs) == maxDocs {
+			truncated = true
+			break
+		}
+		doc, err := mongoToJSON(cursor.Current)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"documents": docs,
+		"count":     len(docs),
+		"truncated": truncated,
+	}, nil
+}
+
+// mongoDocument converts a config value written in Extended JSON to a BSON document.
+// A nil value is an empty document.
+func mongoDocument(value any) (bson.D, error) {
+	if value == nil {
+		return bson.D{}, nil
+	}
+	if _, ok := value.(map[string]any); !ok {
+		return nil, fmt.Errorf("expected an object, got %T", value)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var doc bson.D
+	if err := bson.UnmarshalExtJSON(data, false, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// mongoPipeline converts pipeline stages written in Extended JSON to BSON documents.
+func mongoPipeline(stages []any) (mongo.Pipeline, error) {
+	pipeline := make(mongo.Pipeline, len(stages))
+	for i, stage := range stages {
+		doc, err := mongoDocument(stage)
+		if err != nil {
+			return nil, fmt.Errorf("stage %d: %w", i, err)
+		}
+		pipeline[i] = doc
+	}
+	return pipeline, nil
+}
+
+// mongoToJSON converts a BSON value to plain JSON values using relaxed Extended JSON,
+// so ObjectIDs, dates and decimals survive as {"$oid": ...}, {"$date": ...} and {"$numberDecimal": ...}.
+func mongoToJSON(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	data, err := bson.MarshalExtJSON(bson.D{{Key: "v", Value: value}}, false, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert BSON value: %w", err)
+	}
+	var wrapped map[string]any
+	if err := json.Unmarshal(data, &wrapped); err != nil {
+		return nil, fmt.Errorf("failed to convert BSON value: %w", err)
+	}
+	return wrapped["v"], nil
+}
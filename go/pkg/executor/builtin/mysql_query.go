@@ -0,0 +1,326 @@
+package builtin
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+)
+
+const (
+	sqlModeAuto  = "auto"
+	sqlModeQuery = "query"
+	sqlModeExec  = "exec"
+
+	// sqlDefaultMaxRows caps the rows returned by a query unless max_rows is set.
+	sqlDefaultMaxRows = 1000
+)
+
+// mysqlTLSModes maps the tls config value to the driver's tls parameter.
+var mysqlTLSModes = map[string]string{
+	"preferred":   "preferred",
+	"true":        "true",
+	"skip-verify": "skip-verify",
+	"false":       "false",
+}
+
+// MySQLQueryExecutor runs SQL statements against MySQL or MariaDB.
+// Passwords are never part of the node config: authentication uses a credentials
+// resource referenced by ID (basic_auth, or custom with username/password fields).
+type MySQLQueryExecutor struct {
+	*executor.BaseExecutor
+	credentials CredentialResolver
+	openDB      func(cfg *mysql.Config) (*sql.DB, error)
+}
+
+// NewMySQLQueryExecutor creates a new MySQL query executor.
+// credentials may be nil, in which case only servers without authentication can be used.
+func NewMySQLQueryExecutor(credentials CredentialResolver) *MySQLQueryExecutor {
+	return &MySQLQueryExecutor{
+		BaseExecutor: executor.NewBaseExecutor("mysql_query"),
+		credentials:  credentials,
+		openDB: func(cfg *mysql.Config) (*sql.DB, error) {
+			connector, err := mysql.NewConnector(cfg)
+			if err != nil {
+				return nil, err
+			}
+			return sql.OpenDB(connector), nil
+		},
+	}
+}
+
+// Execute runs a single SQL statement.
+//
+// Config:
+//   - host: Server host (required)
+//   - port: Server port (default: 3306)
+//   - database: Default database
+//   - credential_id: ID of a credentials resource holding username/password; the credential
+//     must be attached to the workflow, e.g. credential_id: "{{resource.mysql.id}}"
+//   - tls: "preferred" (default) | "true" | "skip-verify" | "false"
+//   - query: SQL statement with ? placeholders (required)
+//   - params: Array of values bound to the placeholders in order
+//   - mode: "auto" (default) | "query" | "exec"; auto treats SELECT, SHOW, WITH,
+//     DESCRIBE and EXPLAIN statements as queries
+//   - max_rows: Maximum number of rows returned by a query (default: 1000)
+//   - timeout: Timeout in seconds (default: 30)
+//
+// Output for queries:
+//   - rows: Array of objects keyed by column name
+//   - columns: Column names in result order
+//   - row_count: Number of rows returned
+//   - truncated: true if the result had more than max_rows rows
+//   - duration_ms: Execution duration
+//
+// Output for other statements:
+//   - rows_affected: Number of affected rows
+//   - last_insert_id: Last AUTO_INCREMENT value generated by the statement
+//   - duration_ms: Execution duration
+func (e *MySQLQueryExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	startTime := time.Now()
+
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+
+	params, err := sqlParams(config["params"])
+	if err != nil {
+		return nil, err
+	}
+
+	username, password, err := resolveUsernamePassword(ctx, e.credentials, e.GetStringDefault(config, "credential_id", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := time.Duration(e.GetIntDefault(config, "timeout", 30)) * time.Second
+	host := e.GetStringDefault(config, "host", "")
+
+	cfg := mysql.NewConfig()
+	cfg.User = username
+	cfg.Passwd = password
+	cfg.Net = "tcp"
+	cfg.Addr = net.JoinHostPort(host, strconv.Itoa(e.GetIntDefault(config, "port", 3306)))
+	cfg.DBName = e.GetStringDefault(config, "database", "")
+	cfg.TLSConfig = mysqlTLSModes[e.GetStringDefault(config, "tls", "preferred")]
+	cfg.ParseTime = true
+	cfg.Loc = time.UTC
+	cfg.Timeout = timeout
+
+	db, err := e.openDB(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open connection to %s: %w", cfg.Addr, err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	queryCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := e.GetStringDefault(config, "query", "")
+	mode := e.GetStringDefault(config, "mode", sqlModeAuto)
+	if mode == sqlModeAuto {
+		mode = sqlStatementMode(query)
+	}
+
+	var output map[string]any
+	if mode == sqlModeQuery {
+		output, err = runSQLQuery(queryCtx, db, query, params, e.GetIntDefault(config, "max_rows", sqlDefaultMaxRows), convertMySQLValue)
+	} else {
+		output, err = runSQLExec(queryCtx, db, query, params)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("mysql %s failed: %w", mode, err)
+	}
+
+	output["duration_ms"] = time.Since(startTime).Milliseconds()
+	return output, nil
+}
+
+// Validate validates the MySQL query executor configuration.
+func (e *MySQLQueryExecutor) Validate(config map[string]any) error {
+	if err := e.ValidateRequired(config, "host", "query"); err != nil {
+		return err
+	}
+
+	for _, key := range []string{"password", "username", "user", "dsn"} {
+		if _, ok := config[key]; ok {
+			return fmt.Errorf("%s must not be set inline: store it in a credentials resource and reference it with credential_id", key)
+		}
+	}
+
+	if strings.TrimSpace(e.GetStringDefault(config, "query", "")) == "" {
+		return fmt.Errorf("query must not be empty")
+	}
+
+	tlsMode := e.GetStringDefault(config, "tls", "preferred")
+	if _, ok := mysqlTLSModes[tlsMode]; !ok {
+		return fmt.Errorf("invalid tls mode: %s (valid: preferred, true, skip-verify, false)", tlsMode)
+	}
+
+	if port := e.GetIntDefault(config, "port", 0); port < 0 || port > 65535 {
+		return fmt.Errorf("invalid port: %d", port)
+	}
+
+	switch mode := e.GetStringDefault(config, "mode", sqlModeAuto); mode {
+	case sqlModeAuto, sqlModeQuery, sqlModeExec:
+	default:
+		return fmt.Errorf("invalid mode: %s (valid: auto, query, exec)", mode)
+	}
+
+	if maxRows := e.GetIntDefault(config, "max_rows", sqlDefaultMaxRows); maxRows < 1 {
+		return fmt.Errorf("max_rows must be positive")
+	}
+
+	if params, ok := config["params"]; ok && params != nil {
+		if _, ok := params.([]any); !ok {
+			return fmt.Errorf("params must be an array")
+		}
+	}
+
+	return nil
+}
+
+// sqlStatementMode guesses whether a statement returns rows from its first keyword.
+func sqlStatementMode(query string) string {
+	fields := strings.Fields(strings.TrimLeft(query, " \t\r\n("))
+	if len(fields) == 0 {
+		return sqlModeExec
+	}
+	switch strings.ToUpper(fields[0]) {
+	case "SELECT", "SHOW", "WITH", "DESCRIBE", "DESC", "EXPLAIN", "VALUES", "TABLE":
+		return sqlModeQuery
+	default:
+		return sqlModeExec
+	}
+}
+
+// sqlParams converts the params config into driver arguments.
+// Objects and arrays are passed as JSON strings, e.g. for JSON columns.
+func sqlParams(raw any) ([]any, error) {
+	items, _ := raw.([]any)
+	params := make([]any, len(items))
+	for i, item := range items {
+		switch v := item.(type) {
+		case map[string]any, []any:
+			data, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode param %d: %w", i+1, err)
+			}
+			params[i] = string(data)
+		default:
+			params[i] = v
+		}
+	}
+	return params, nil
+}
+
+// runSQLQuery runs a row-returning statement and collects up to maxRows rows.
+// convert maps a scanned value to its output representation using the column's database type.
+func runSQLQuery(ctx context.Context, db *sql.DB, query string, params []any, maxRows int, convert func(value any, dbType string) any) (map[string]any, error) {
+	rows, err := db.QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	dbTypes := make([]string, len(columns))
+	if columnTypes, err := rows.ColumnTypes(); err == nil {
+		for i, ct := range columnTypes {
+			dbTypes[i] = ct.DatabaseTypeName()
+		}
+	}
+
+	result := make([]map[string]any, 0)
+	truncated := false
+	for rows.Next() {
+		if len(result) == maxRows {
+			truncated = true
+			break
+		}
+
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]any, len(columns))
+		for i, column := range columns {
+			row[column] = convert(values[i], dbTypes[i])
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"rows":      result,
+		"columns":   columns,
+		"row_count": len(result),
+		"truncated": truncated,
+	}, nil
+}
+
+// runSQLExec runs a statement that does not return rows.
+func runSQLExec(ctx context.Context, db *sql.DB, query string, params []any) (map[string]any, error) {
+	res, err := db.ExecContext(ctx, query, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	lastInsertID, _ := res.LastInsertId()
+	return map[string]any{
+		"rows_affected":  rowsAffected,
+		"last_insert_id": lastInsertID,
+	}, nil
+}
+
+// convertMySQLValue converts raw column bytes to numbers, JSON values or strings.
+// DECIMAL values stay strings to keep their precision; binary columns stay bytes.
+func convertMySQLValue(value any, dbType string) any {
+	raw, ok := value.([]byte)
+	if !ok {
+		return value
+	}
+
+	dbType = strings.ToUpper(dbType)
+	switch {
+	case strings.HasPrefix(dbType, "UNSIGNED ") && strings.HasSuffix(dbType, "INT"):
+		if n, err := strconv.ParseUint(string(raw), 10, 64); err == nil {
+			return n
+		}
+	case strings.HasSuffix(dbType, "INT"), dbType == "YEAR":
+		if n, err := strconv.ParseInt(string(raw), 10, 64); err == nil {
+			return n
+		}
+	case dbType == "FLOAT", dbType == "DOUBLE":
+		if f, err := strconv.ParseFloat(string(raw), 64); err == nil {
+			return f
+		}
+	case dbType == "JSON":
+		var v any
+		if err := json.Unmarshal(raw, &v); err == nil {
+			return v
+		}
+	case strings.HasSuffix(dbType, "BLOB"), strings.HasSuffix(dbType, "BINARY"), dbType == "BIT", dbType == "GEOMETRY":
+		return raw
+	}
+	return string(raw)
+}
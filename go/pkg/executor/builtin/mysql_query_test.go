@@ -0,0 +1,168 @@
+package builtin
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMockMySQLExecutor returns a mysql_query executor connected to go-sqlmock.
+// The driver config passed to openDB is stored in cfg.
+func newMockMySQLExecutor(t *testing.T, credentials CredentialResolver, cfg **mysql.Config) (*MySQLQueryExecutor, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	exec := NewMySQLQueryExecutor(credentials)
+	exec.openDB = func(c *mysql.Config) (*sql.DB, error) {
+		if cfg != nil {
+			*cfg = c
+		}
+		return db, nil
+	}
+	return exec, mock
+}
+
+func TestMySQLQueryExecutor_Validate(t *testing.T) {
+	exec := NewMySQLQueryExecutor(nil)
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{"valid", map[string]any{"host": "db", "query": "SELECT 1"}, ""},
+		{"missing host", map[string]any{"query": "SELECT 1"}, "host"},
+		{"missing query", map[string]any{"host": "db"}, "query"},
+		{"inline password", map[string]any{"host": "db", "query": "SELECT 1", "password": "x"}, "must not be set inline"},
+		{"invalid tls", map[string]any{"host": "db", "query": "SELECT 1", "tls": "always"}, "invalid tls mode"},
+		{"invalid mode", map[string]any{"host": "db", "query": "SELECT 1", "mode": "stream"}, "invalid mode"},
+		{"invalid max_rows", map[string]any{"host": "db", "query": "SELECT 1", "max_rows": 0}, "max_rows"},
+		{"params not array", map[string]any{"host": "db", "query": "SELECT ?", "params": "x"}, "params must be an array"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exec.Validate(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestMySQLQueryExecutor_Query(t *testing.T) {
+	cred := models.NewCredentialsResource("owner-1", "mysql", models.CredentialTypeBasicAuth)
+	cred.DecryptedData = map[string]string{"username": "app", "password": "s3cret"}
+	credentials := &fakeCredentialResolver{creds: map[string]*models.CredentialsResource{"cred-1": cred}}
+
+	var cfg *mysql.Config
+	exec, mock := newMockMySQLExecutor(t, credentials, &cfg)
+
+	rows := mock.NewRowsWithColumnDefinition(
+		sqlmock.NewColumn("id").OfType("BIGINT", int64(0)),
+		sqlmock.NewColumn("name").OfType("VARCHAR", ""),
+		sqlmock.NewColumn("price").OfType("DECIMAL", ""),
+		sqlmock.NewColumn("tags").OfType("JSON", ""),
+	).
+		AddRow([]byte("1"), []byte("Widget"), []byte("9.90"), []byte(`["a","b"]`)).
+		AddRow([]byte("2"), []byte("Gadget"), []byte("19.00"), nil).
+		AddRow([]byte("3"), []byte("Gizmo"), []byte("5.00"), nil)
+
+	mock.ExpectQuery("SELECT id, name, price, tags FROM products WHERE active = \\? AND meta = \\?").
+		WithArgs(true, `{"k":"v"}`).
+		WillReturnRows(rows)
+	mock.ExpectClose()
+
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"host":          "db.internal",
+		"database":      "shop",
+		"credential_id": "cred-1",
+		"query":         "SELECT id, name, price, tags FROM products WHERE active = ? AND meta = ?",
+		"params":        []any{true, map[string]any{"k": "v"}},
+		"max_rows":      2,
+	}, nil)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	assert.Equal(t, "app", cfg.User)
+	assert.Equal(t, "s3cret", cfg.Passwd)
+	assert.Equal(t, "db.internal:3306", cfg.Addr)
+	assert.Equal(t, "shop", cfg.DBName)
+	assert.True(t, cfg.ParseTime)
+
+	output := result.(map[string]any)
+	assert.Equal(t, []string{"id", "name", "price", "tags"}, output["columns"])
+	assert.Equal(t, 2, output["row_count"])
+	assert.Equal(t, true, output["truncated"])
+
+	resultRows := output["rows"].([]map[string]any)
+	assert.Equal(t, int64(1), resultRows[0]["id"])
+	assert.Equal(t, "Widget", resultRows[0]["name"])
+	assert.Equal(t, "9.90", resultRows[0]["price"])
+	assert.Equal(t, []any{"a", "b"}, resultRows[0]["tags"])
+	assert.Nil(t, resultRows[1]["tags"])
+}
+
+func TestMySQLQueryExecutor_Exec(t *testing.T) {
+	exec, mock := newMockMySQLExecutor(t, nil, nil)
+
+	mock.ExpectExec("INSERT INTO events").
+		WithArgs("signup", 42).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectClose()
+
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"host":   "db.internal",
+		"query":  "INSERT INTO events (kind, user_id) VALUES (?, ?)",
+		"params": []any{"signup", 42},
+	}, nil)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	output := result.(map[string]any)
+	assert.Equal(t, int64(1), output["rows_affected"])
+	assert.Equal(t, int64(7), output["last_insert_id"])
+}
+
+func TestMySQLQueryExecutor_Error(t *testing.T) {
+	exec, mock := newMockMySQLExecutor(t, nil, nil)
+
+	mock.ExpectExec("DELETE FROM events").WillReturnError(errors.New("table is locked"))
+
+	_, err := exec.Execute(context.Background(), map[string]any{
+		"host":  "db.internal",
+		"query": "DELETE FROM events",
+	}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "mysql exec failed: table is locked")
+}
+
+func TestSQLStatementMode(t *testing.T) {
+	assert.Equal(t, sqlModeQuery, sqlStatementMode("  select 1"))
+	assert.Equal(t, sqlModeQuery, sqlStatementMode("(SELECT 1) UNION (SELECT 2)"))
+	assert.Equal(t, sqlModeQuery, sqlStatementMode("WITH t AS (SELECT 1) SELECT * FROM t"))
+	assert.Equal(t, sqlModeQuery, sqlStatementMode("SHOW TABLES"))
+	assert.Equal(t, sqlModeExec, sqlStatementMode("UPDATE users SET active = 0"))
+	assert.Equal(t, sqlModeExec, sqlStatementMode(""))
+}
+
+func TestConvertMySQLValue(t *testing.T) {
+	assert.Equal(t, int64(-5), convertMySQLValue([]byte("-5"), "INT"))
+	assert.Equal(t, uint64(18446744073709551615), convertMySQLValue([]byte("18446744073709551615"), "UNSIGNED BIGINT"))
+	assert.Equal(t, 1.5, convertMySQLValue([]byte("1.5"), "DOUBLE"))
+	assert.Equal(t, "1.50", convertMySQLValue([]byte("1.50"), "DECIMAL"))
+	assert.Equal(t, map[string]any{"a": 1.0}, convertMySQLValue([]byte(`{"a":1}`), "JSON"))
+	assert.Equal(t, []byte{0x01, 0x02}, convertMySQLValue([]byte{0x01, 0x02}, "VARBINARY"))
+	assert.Equal(t, "text", convertMySQLValue([]byte("text"), "VARCHAR"))
+	assert.Equal(t, int64(3), convertMySQLValue(int64(3), "INT"))
+}
@@ -0,0 +1,782 @@
+package builtin
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	xhtml "golang.org/x/net/html"
+)
+
+// pdfStyle is the inline style of a run of text.
+type pdfStyle struct {
+	bold   bool
+	italic bool
+	mono   bool
+	link   bool
+}
+
+func (s pdfStyle) font() pdfFont {
+	switch {
+	case s.mono && s.bold:
+		return pdfFontMonoBold
+	case s.mono:
+		return pdfFontMono
+	case s.bold && s.italic:
+		return pdfFontBoldItalic
+	case s.bold:
+		return pdfFontBold
+	case s.italic:
+		return pdfFontItalic
+	default:
+		return pdfFontRegular
+	}
+}
+
+// pdfRun is text with one style. Whitespace outside preformatted blocks is already
+// collapsed, so "\n" marks a <br>.
+type pdfRun struct {
+	text  string
+	style pdfStyle
+}
+
+type pdfBlockKind int
+
+const (
+	pdfBlockParagraph pdfBlockKind = iota
+	pdfBlockHeading
+	pdfBlockListItem
+	pdfBlockCode
+	pdfBlockRule
+	pdfBlockTable
+	pdfBlockPageBreak
+)
+
+// pdfBlock is a unit of layout: a paragraph, heading, list item, code block,
+// horizontal rule, table or page break.
+type pdfBlock struct {
+	kind   pdfBlockKind
+	level  int    // heading level (1-6)
+	indent int    // list and quote nesting depth
+	quote  bool   // inside a blockquote
+	marker string // list item bullet or number
+	align  string // "left", "center" or "right"
+	runs   []pdfRun
+	rows   []pdfTableRow
+}
+
+type pdfTableRow struct {
+	header bool
+	cells  [][]pdfRun
+	aligns []string
+}
+
+// pdfDocument is the result of parsing the HTML.
+type pdfDocument struct {
+	title  string
+	blocks []*pdfBlock
+}
+
+// pdfSkippedElements are not rendered.
+var pdfSkippedElements = map[string]bool{
+	"head": true, "script": true, "style": true, "noscript": true, "template": true,
+	"iframe": true, "object": true, "svg": true, "canvas": true, "button": true,
+	"input": true, "select": true, "textarea": true,
+}
+
+// pdfBlockElements end the current paragraph.
+var pdfBlockElements = map[string]bool{
+	"p": true, "div": true, "section": true, "article": true, "header": true, "footer": true,
+	"main": true, "nav": true, "aside": true, "figure": true, "figcaption": true, "address": true,
+	"dl": true, "dt": true, "dd": true, "center": true, "form": true, "fieldset": true,
+	"details": true, "summary": true, "body": true, "html": true,
+}
+
+// pdfParser turns an HTML tree into layout blocks.
+type pdfParser struct {
+	doc     pdfDocument
+	runs    []pdfRun
+	style   pdfStyle
+	indent  int
+	quote   bool
+	align   string
+	pre     bool
+	lists   []*pdfList
+	pending *pdfBlock // list item waiting for its first paragraph
+}
+
+type pdfList struct {
+	ordered bool
+	next    int
+}
+
+// parsePDFHTML parses an HTML document or fragment into layout blocks.
+func parsePDFHTML(source string) (*pdfDocument, error) {
+	root, err := xhtml.Parse(strings.NewReader(source))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+	p := &pdfParser{align: "left"}
+	p.walk(root)
+	p.flush()
+	return &p.doc, nil
+}
+
+func (p *pdfParser) walk(n *xhtml.Node) {
+	switch n.Type {
+	case xhtml.TextNode:
+		text := n.Data
+		if !p.pre {
+			text = pdfWhitespace.ReplaceAllString(text, " ")
+		}
+		p.runs = append(p.runs, pdfRun{text: text, style: p.style})
+		return
+	case xhtml.ElementNode:
+		p.element(n)
+		return
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		p.walk(c)
+	}
+}
+
+func (p *pdfParser) children(n *xhtml.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		p.walk(c)
+	}
+}
+
+func (p *pdfParser) element(n *xhtml.Node) {
+	tag := n.Data
+	if tag == "title" && p.doc.title == "" {
+		p.doc.title = strings.TrimSpace(pdfNodeText(n))
+		return
+	}
+	if pdfSkippedElements[tag] || pdfHidden(n) {
+		return
+	}
+
+	style := pdfAttr(n, "style")
+	if pdfPageBreak(style, "before") {
+		p.flush()
+		p.doc.blocks = append(p.doc.blocks, &pdfBlock{kind: pdfBlockPageBreak})
+	}
+	defer func() {
+		if pdfPageBreak(style, "after") {
+			p.flush()
+			p.doc.blocks = append(p.doc.blocks, &pdfBlock{kind: pdfBlockPageBreak})
+		}
+	}()
+
+	savedStyle, savedAlign := p.style, p.align
+	defer func() { p.style, p.align = savedStyle, savedAlign }()
+	if align := pdfAlignment(n, style); align != "" {
+		if pdfBlockElements[tag] || pdfHeadingLevel(tag) > 0 {
+			p.flush()
+		}
+		p.align = align
+	}
+
+	switch tag {
+	case "b", "strong":
+		p.style.bold = true
+	case "i", "em", "cite", "var", "dfn":
+		p.style.italic = true
+	case "code", "kbd", "samp", "tt":
+		p.style.mono = true
+	case "a":
+		if pdfAttr(n, "href") != "" {
+			p.style.link = true
+		}
+	}
+	if strings.Contains(strings.ReplaceAll(style, " ", ""), "font-weight:bold") {
+		p.style.bold = true
+	}
+
+	switch {
+	case pdfHeadingLevel(tag) > 0:
+		p.flush()
+		p.style.bold = true
+		p.children(n)
+		p.emit(&pdfBlock{kind: pdfBlockHeading, level: pdfHeadingLevel(tag)})
+
+	case tag == "br":
+		p.runs = append(p.runs, pdfRun{text: "\n", style: p.style})
+
+	case tag == "hr":
+		p.flush()
+		p.doc.blocks = append(p.doc.blocks, &pdfBlock{kind: pdfBlockRule, indent: p.indent})
+
+	case tag == "img":
+		if alt := strings.TrimSpace(pdfAttr(n, "alt")); alt != "" {
+			p.runs = append(p.runs, pdfRun{text: "[" + alt + "]", style: pdfStyle{italic: true}})
+		}
+
+	case tag == "pre":
+		p.flush()
+		p.style.mono = true
+		p.pre = true
+		p.children(n)
+		p.pre = false
+		text := strings.TrimSuffix(strings.TrimPrefix(pdfRunsText(p.runs), "\n"), "\n")
+		p.runs = nil
+		p.doc.blocks = append(p.doc.blocks, &pdfBlock{
+			kind:   pdfBlockCode,
+			indent: p.indent,
+			quote:  p.quote,
+			runs:   []pdfRun{{text: text, style: p.style}},
+		})
+
+	case tag == "blockquote":
+		p.flush()
+		savedQuote := p.quote
+		p.quote = true
+		p.indent++
+		p.children(n)
+		p.flush()
+		p.indent--
+		p.quote = savedQuote
+
+	case tag == "ul" || tag == "ol":
+		p.flush()
+		list := &pdfList{ordered: tag == "ol", next: 1}
+		if start, err := strconv.Atoi(pdfAttr(n, "start")); err == nil {
+			list.next = start
+		}
+		p.lists = append(p.lists, list)
+		p.indent++
+		p.children(n)
+		p.flush()
+		p.indent--
+		p.lists = p.lists[:len(p.lists)-1]
+
+	case tag == "li":
+		p.flush()
+		marker := "•"
+		if len(p.lists) > 0 {
+			list := p.lists[len(p.lists)-1]
+			if list.ordered {
+				if value, err := strconv.Atoi(pdfAttr(n, "value")); err == nil {
+					list.next = value
+				}
+				marker = strconv.Itoa(list.next) + "."
+				list.next++
+			} else if len(p.lists)%2 == 0 {
+				marker = "–"
+			}
+		}
+		p.pending = &pdfBlock{kind: pdfBlockListItem, marker: marker}
+		p.children(n)
+		p.flush()
+		if p.pending != nil {
+			// An empty item still shows its marker
+			p.emit(&pdfBlock{kind: pdfBlockParagraph})
+		}
+
+	case tag == "table":
+		p.flush()
+		p.table(n)
+
+	case pdfBlockElements[tag]:
+		p.flush()
+		if tag == "dd" {
+			p.indent++
+			defer func() { p.indent-- }()
+		}
+		if tag == "dt" {
+			p.style.bold = true
+		}
+		p.children(n)
+		p.flush()
+
+	default:
+		p.children(n)
+	}
+}
+
+// flush ends the current paragraph, if it has any text.
+func (p *pdfParser) flush() {
+	if strings.TrimSpace(pdfRunsText(p.runs)) == "" {
+		p.runs = nil
+		return
+	}
+	p.emit(&pdfBlock{kind: pdfBlockParagraph})
+}
+
+// emit adds a block with the collected runs; the first paragraph of a list item
+// becomes the item itself.
+func (p *pdfParser) emit(block *pdfBlock) {
+	block.runs = p.runs
+	block.indent = p.indent
+	block.quote = p.quote
+	block.align = p.align
+	p.runs = nil
+	if p.pending != nil && block.kind == pdfBlockParagraph {
+		block.kind = pdfBlockListItem
+		block.marker = p.pending.marker
+		p.pending = nil
+	}
+	p.doc.blocks = append(p.doc.blocks, block)
+}
+
+// table collects the rows of a table; nested tables are flattened into their cell.
+func (p *pdfParser) table(n *xhtml.Node) {
+	block := &pdfBlock{kind: pdfBlockTable, indent: p.indent, quote: p.quote}
+
+	var visit func(n *xhtml.Node, inHead bool)
+	visit = func(n *xhtml.Node, inHead bool) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != xhtml.ElementNode {
+				continue
+			}
+			switch c.Data {
+			case "thead":
+				visit(c, true)
+			case "tbody", "tfoot":
+				visit(c, false)
+			case "caption":
+				p.runs = nil
+				p.style = pdfStyle{italic: true}
+				p.children(c)
+				p.style = pdfStyle{}
+				if caption := p.runs; len(caption) > 0 {
+					p.runs = nil
+					p.doc.blocks = append(p.doc.blocks, &pdfBlock{
+						kind: pdfBlockParagraph, indent: p.indent, quote: p.quote, align: "center", runs: caption,
+					})
+				}
+			case "tr":
+				row := pdfTableRow{header: inHead}
+				allHeaders := true
+				for cell := c.FirstChild; cell != nil; cell = cell.NextSibling {
+					if cell.Type != xhtml.ElementNode || (cell.Data != "td" && cell.Data != "th") {
+						continue
+					}
+					allHeaders = allHeaders && cell.Data == "th"
+					row.cells = append(row.cells, p.cellRuns(cell))
+					row.aligns = append(row.aligns, pdfAlignment(cell, pdfAttr(cell, "style")))
+				}
+				if len(row.cells) > 0 {
+					row.header = row.header || allHeaders
+					block.rows = append(block.rows, row)
+				}
+			}
+		}
+	}
+	visit(n, false)
+
+	if len(block.rows) > 0 {
+		p.doc.blocks = append(p.doc.blocks, block)
+	}
+}
+
+// cellRuns collects the text of a table cell, with block content separated by line breaks.
+func (p *pdfParser) cellRuns(cell *xhtml.Node) []pdfRun {
+	inner := &pdfParser{align: "left"}
+	inner.style.bold = cell.Data == "th"
+	inner.children(cell)
+	inner.flush()
+
+	var runs []pdfRun
+	for _, block := range inner.doc.blocks {
+		if len(runs) > 0 {
+			runs = append(runs, pdfRun{text: "\n"})
+		}
+		if block.kind == pdfBlockListItem {
+			runs = append(runs, pdfRun{text: block.marker + " "})
+		}
+		runs = append(runs, block.runs...)
+		for _, row := range block.rows {
+			for i, cellRuns := range row.cells {
+				if i > 0 {
+					runs = append(runs, pdfRun{text: " | "})
+				}
+				runs = append(runs, cellRuns...)
+			}
+			runs = append(runs, pdfRun{text: "\n"})
+		}
+	}
+	return runs
+}
+
+func pdfHeadingLevel(tag string) int {
+	if len(tag) == 2 && tag[0] == 'h' && tag[1] >= '1' && tag[1] <= '6' {
+		return int(tag[1] - '0')
+	}
+	return 0
+}
+
+func pdfAttr(n *xhtml.Node, name string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == name {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+func pdfNodeText(n *xhtml.Node) string {
+	var b strings.Builder
+	var visit func(*xhtml.Node)
+	visit = func(n *xhtml.Node) {
+		if n.Type == xhtml.TextNode {
+			b.WriteString(n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			visit(c)
+		}
+	}
+	visit(n)
+	return b.String()
+}
+
+func pdfRunsText(runs []pdfRun) string {
+	var b strings.Builder
+	for _, run := range runs {
+		b.WriteString(run.text)
+	}
+	return b.String()
+}
+
+// pdfHidden reports whether an element is hidden with the hidden attribute or display:none.
+func pdfHidden(n *xhtml.Node) bool {
+	for _, attr := range n.Attr {
+		if attr.Key == "hidden" {
+			return true
+		}
+	}
+	return strings.Contains(strings.ReplaceAll(pdfAttr(n, "style"), " ", ""), "display:none")
+}
+
+// pdfPageBreak reports whether an inline style forces a page break before or after the element.
+func pdfPageBreak(style, side string) bool {
+	style = strings.ReplaceAll(strings.ToLower(style), " ", "")
+	return strings.Contains(style, "page-break-"+side+":always") || strings.Contains(style, "break-"+side+":page")
+}
+
+// pdfAlignment returns the alignment set by the align attribute or text-align style.
+func pdfAlignment(n *xhtml.Node, style string) string {
+	if n.Data == "center" {
+		return "center"
+	}
+	align := strings.ToLower(pdfAttr(n, "align"))
+	style = strings.ReplaceAll(strings.ToLower(style), " ", "")
+	if i := strings.Index(style, "text-align:"); i >= 0 {
+		align = strings.SplitN(style[i+len("text-align:"):], ";", 2)[0]
+	}
+	switch align {
+	case "left", "center", "right":
+		return align
+	case "justify":
+		return "left"
+	default:
+		return ""
+	}
+}
+
+var pdfWhitespace = regexp.MustCompile(`\s+`)
+
+var (
+	mdHeadingPattern     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdRulePattern        = regexp.MustCompile(`^\s{0,3}(-(\s*-){2,}|\*(\s*\*){2,}|_(\s*_){2,})\s*$`)
+	mdListPattern        = regexp.MustCompile(`^(\s*)([-*+]|\d{1,9}[.)])\s+(.*)$`)
+	mdFencePattern       = regexp.MustCompile("^\\s{0,3}(```+|~~~+)\\s*([\\w+-]*)")
+	mdTableDivider       = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	mdPageBreakPattern   = regexp.MustCompile(`(?i)^\s*(<!--\s*pagebreak\s*-->|\\pagebreak|\\newpage)\s*$`)
+	mdInlineCodePattern  = regexp.MustCompile("`([^`]+)`")
+	mdImagePattern       = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	mdLinkPattern        = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)[^)]*\)`)
+	mdBoldPattern        = regexp.MustCompile(`\*\*(\S(?:.*?\S)?)\*\*|__(\S(?:.*?\S)?)__`)
+	mdItalicPattern      = regexp.MustCompile(`(^|[^\w*])[*_](\S(?:[^*_]*?\S)?)[*_]($|[^\w*])`)
+	mdStrikePattern      = regexp.MustCompile(`~~(\S(?:.*?\S)?)~~`)
+	mdCodePlaceholderFmt = "\x00%d\x00"
+)
+
+// markdownToHTML converts the common subset of Markdown used in reports to HTML:
+// headings, paragraphs, emphasis, inline code, links, nested lists, blockquotes,
+// fenced and indented code, tables, horizontal rules and page breaks.
+func markdownToHTML(source string) string {
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+	var out strings.Builder
+	markdownBlocks(lines, &out)
+	return out.String()
+}
+
+func markdownBlocks(lines []string, out *strings.Builder) {
+	var paragraph []string
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			out.WriteString("<p>" + markdownInline(strings.Join(paragraph, "\n")) + "</p>\n")
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flushParagraph()
+
+		case mdPageBreakPattern.MatchString(line):
+			flushParagraph()
+			out.WriteString(`<div style="page-break-after: always"></div>` + "\n")
+
+		case mdFencePattern.MatchString(line):
+			flushParagraph()
+			fence := mdFencePattern.FindStringSubmatch(line)[1]
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			out.WriteString("<pre>" + html.EscapeString(strings.Join(code, "\n")) + "</pre>\n")
+
+		case strings.HasPrefix(line, "    ") && len(paragraph) == 0:
+			var code []string
+			for ; i < len(lines) && (strings.HasPrefix(lines[i], "    ") || strings.TrimSpace(lines[i]) == ""); i++ {
+				code = append(code, strings.TrimPrefix(lines[i], "    "))
+			}
+			i--
+			out.WriteString("<pre>" + html.EscapeString(strings.TrimRight(strings.Join(code, "\n"), "\n")) + "</pre>\n")
+
+		case mdHeadingPattern.MatchString(trimmed):
+			flushParagraph()
+			match := mdHeadingPattern.FindStringSubmatch(trimmed)
+			level := len(match[1])
+			fmt.Fprintf(out, "<h%d>%s</h%d>\n", level, markdownInline(match[2]), level)
+
+		case len(paragraph) > 0 && i < len(lines) && isSetextUnderline(trimmed):
+			// "Title\n=====" and "Title\n-----" headings
+			level := 1
+			if trimmed[0] == '-' {
+				level = 2
+			}
+			fmt.Fprintf(out, "<h%d>%s</h%d>\n", level, markdownInline(strings.Join(paragraph, " ")), level)
+			paragraph = nil
+
+		case mdRulePattern.MatchString(line):
+			flushParagraph()
+			out.WriteString("<hr>\n")
+
+		case strings.HasPrefix(trimmed, ">"):
+			flushParagraph()
+			var quoted []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				text := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quoted = append(quoted, strings.TrimPrefix(text, " "))
+			}
+			i--
+			out.WriteString("<blockquote>\n")
+			markdownBlocks(quoted, out)
+			out.WriteString("</blockquote>\n")
+
+		case mdListPattern.MatchString(line) && (len(paragraph) == 0 || !strings.HasPrefix(line, " ")):
+			flushParagraph()
+			end := markdownList(lines, i, out)
+			i = end - 1
+
+		case strings.Contains(trimmed, "|") && i+1 < len(lines) && mdTableDivider.MatchString(lines[i+1]):
+			flushParagraph()
+			end := markdownTable(lines, i, out)
+			i = end - 1
+
+		default:
+			// Trailing spaces are kept for hard line breaks
+			paragraph = append(paragraph, strings.TrimLeft(line, " \t"))
+		}
+	}
+	flushParagraph()
+}
+
+func isSetextUnderline(line string) bool {
+	if line == "" {
+		return false
+	}
+	return strings.Trim(line, "=") == "" || (strings.Trim(line, "-") == "" && len(line) >= 2)
+}
+
+// markdownList writes the list starting at lines[start] and returns the index of
+// the first line after it. Items indented further than the list's marker nest.
+func markdownList(lines []string, start int, out *strings.Builder) int {
+	first := mdListPattern.FindStringSubmatch(lines[start])
+	baseIndent := len(first[1])
+	ordered := markdownOrdered(first[2])
+	if ordered {
+		number, _ := strconv.Atoi(strings.TrimRight(first[2], ".)"))
+		if number != 1 {
+			fmt.Fprintf(out, "<ol start=\"%d\">\n", number)
+		} else {
+			out.WriteString("<ol>\n")
+		}
+	} else {
+		out.WriteString("<ul>\n")
+	}
+
+	i := start
+	for i < len(lines) {
+		match := mdListPattern.FindStringSubmatch(lines[i])
+		if match == nil || len(match[1]) != baseIndent || markdownOrdered(match[2]) != ordered {
+			break
+		}
+		item := []string{match[3]}
+		contentIndent := len(match[1]) + len(match[2]) + 1
+		i++
+
+		// Continuation lines and nested blocks belong to the item while indented
+		// past its marker; a blank line followed by unindented text ends the list
+		for i < len(lines) {
+			line := lines[i]
+			if strings.TrimSpace(line) == "" {
+				if i+1 < len(lines) && pdfLeadingSpaces(lines[i+1]) > baseIndent {
+					item = append(item, "")
+					i++
+					continue
+				}
+				break
+			}
+			if pdfLeadingSpaces(line) <= baseIndent {
+				trimmed := strings.TrimSpace(line)
+				if mdListPattern.MatchString(line) || pdfLeadingSpaces(line) < baseIndent ||
+					strings.TrimSpace(item[len(item)-1]) == "" || mdHeadingPattern.MatchString(trimmed) ||
+					mdFencePattern.MatchString(line) || strings.HasPrefix(trimmed, ">") {
+					break
+				}
+				// Lazy continuation of the item's paragraph
+			}
+			item = append(item, strings.TrimPrefix(line, strings.Repeat(" ", min(pdfLeadingSpaces(line), contentIndent))))
+			i++
+		}
+
+		out.WriteString("<li>")
+		if len(item) == 1 || !markdownHasBlocks(item[1:]) {
+			out.WriteString(markdownInline(strings.TrimSpace(strings.Join(item, "\n"))))
+		} else {
+			markdownBlocks(item, out)
+		}
+		out.WriteString("</li>\n")
+
+		if i < len(lines) && strings.TrimSpace(lines[i]) == "" {
+			if i+1 < len(lines) {
+				next := mdListPattern.FindStringSubmatch(lines[i+1])
+				if next != nil && len(next[1]) == baseIndent && markdownOrdered(next[2]) == ordered {
+					i++
+					continue
+				}
+			}
+			break
+		}
+	}
+
+	if ordered {
+		out.WriteString("</ol>\n")
+	} else {
+		out.WriteString("</ul>\n")
+	}
+	return i
+}
+
+// markdownOrdered reports whether a list marker is a number.
+func markdownOrdered(marker string) bool {
+	return !strings.ContainsAny(marker[:1], "-*+")
+}
+
+// markdownHasBlocks reports whether item continuation lines contain nested lists,
+// code, quotes or paragraph breaks rather than one wrapped paragraph.
+func markdownHasBlocks(lines []string) bool {
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || mdListPattern.MatchString(line) || mdFencePattern.MatchString(line) ||
+			strings.HasPrefix(trimmed, ">") || mdHeadingPattern.MatchString(trimmed) {
+			return true
+		}
+	}
+	return false
+}
+
+func pdfLeadingSpaces(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// markdownTable writes the pipe table starting at lines[start] and returns the
+// index of the first line after it.
+func markdownTable(lines []string, start int, out *strings.Builder) int {
+	splitRow := func(line string) []string {
+		line = strings.TrimSpace(line)
+		line = strings.TrimPrefix(line, "|")
+		if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+			line = line[:len(line)-1]
+		}
+		cells := strings.Split(strings.ReplaceAll(line, `\|`, "\x01"), "|")
+		for i, cell := range cells {
+			cells[i] = strings.ReplaceAll(strings.TrimSpace(cell), "\x01", "|")
+		}
+		return cells
+	}
+
+	var aligns []string
+	for _, cell := range splitRow(lines[start+1]) {
+		switch {
+		case strings.HasPrefix(cell, ":") && strings.HasSuffix(cell, ":"):
+			aligns = append(aligns, "center")
+		case strings.HasSuffix(cell, ":"):
+			aligns = append(aligns, "right")
+		default:
+			aligns = append(aligns, "")
+		}
+	}
+	writeRow := func(cells []string, tag string) {
+		out.WriteString("<tr>")
+		for i, cell := range cells {
+			if i < len(aligns) && aligns[i] != "" {
+				fmt.Fprintf(out, `<%s align="%s">`, tag, aligns[i])
+			} else {
+				out.WriteString("<" + tag + ">")
+			}
+			out.WriteString(markdownInline(cell) + "</" + tag + ">")
+		}
+		out.WriteString("</tr>\n")
+	}
+
+	out.WriteString("<table>\n<thead>\n")
+	writeRow(splitRow(lines[start]), "th")
+	out.WriteString("</thead>\n<tbody>\n")
+	i := start + 2
+	for ; i < len(lines) && strings.TrimSpace(lines[i]) != "" && strings.Contains(lines[i], "|"); i++ {
+		writeRow(splitRow(lines[i]), "td")
+	}
+	out.WriteString("</tbody>\n</table>\n")
+	return i
+}
+
+// markdownInline converts inline Markdown in escaped text: code spans, images,
+// links, bold, italic and strikethrough, and hard line breaks.
+func markdownInline(text string) string {
+	// Code spans are taken out first so their content is not formatted
+	var codes []string
+	text = mdInlineCodePattern.ReplaceAllStringFunc(text, func(match string) string {
+		codes = append(codes, "<code>"+html.EscapeString(mdInlineCodePattern.FindStringSubmatch(match)[1])+"</code>")
+		return fmt.Sprintf(mdCodePlaceholderFmt, len(codes)-1)
+	})
+
+	text = html.EscapeString(text)
+	text = mdImagePattern.ReplaceAllString(text, `<img alt="$1">`)
+	text = mdLinkPattern.ReplaceAllString(text, `<a href="$2">$1</a>`)
+	text = mdBoldPattern.ReplaceAllString(text, "<strong>$1$2</strong>")
+	// Adjacent matches share the character between them, so repeat until none is left
+	for formatted := ""; formatted != text; {
+		formatted = text
+		text = mdItalicPattern.ReplaceAllString(text, "$1<em>$2</em>$3")
+	}
+	text = mdStrikePattern.ReplaceAllString(text, "<s>$1</s>")
+	// Two trailing spaces or a backslash before a newline is a hard break
+	text = strings.ReplaceAll(text, "  \n", "<br>")
+	text = strings.ReplaceAll(text, "\\\n", "<br>")
+
+	for i, code := range codes {
+		text = strings.Replace(text, fmt.Sprintf(mdCodePlaceholderFmt, i), code, 1)
+	}
+	return text
+}
@@ -0,0 +1,460 @@
+package builtin
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+var (
+	pdfColorText   = [3]float64{0.1, 0.1, 0.1}
+	pdfColorMuted  = [3]float64{0.35, 0.35, 0.35}
+	pdfColorLink   = [3]float64{0.05, 0.35, 0.75}
+	pdfColorBorder = [3]float64{0.75, 0.75, 0.75}
+	pdfColorShade  = [3]float64{0.94, 0.94, 0.94}
+)
+
+// pdfHeadingScale is the font size of h1-h6 relative to the body text.
+var pdfHeadingScale = [6]float64{1.9, 1.5, 1.25, 1.1, 1, 0.9}
+
+const (
+	pdfLineHeight  = 1.35 // line height relative to the font size
+	pdfCellPadding = 4    // table cell padding in points
+)
+
+// pdfLayoutOptions controls page geometry and typography, in points.
+type pdfLayoutOptions struct {
+	width       float64
+	height      float64
+	margin      float64
+	fontSize    float64
+	header      string
+	footer      string
+	pageNumbers bool
+}
+
+// pdfWord is a word ready to be placed on a line.
+type pdfWord struct {
+	text  []byte
+	font  pdfFont
+	size  float64
+	color [3]float64
+	x     float64 // offset from the start of the line
+	width float64
+}
+
+type pdfLine struct {
+	words []pdfWord
+	width float64
+}
+
+// pdfTypesetter lays out blocks on pages.
+type pdfTypesetter struct {
+	opts  pdfLayoutOptions
+	pages []*pdfCanvas
+	page  *pdfCanvas
+	y     float64 // top of the next line
+	blank bool    // nothing has been drawn on the current page
+}
+
+// layoutPDF lays out the document and returns its pages.
+func layoutPDF(doc *pdfDocument, opts pdfLayoutOptions) []*pdfCanvas {
+	t := &pdfTypesetter{opts: opts}
+	t.newPage()
+	for _, block := range doc.blocks {
+		t.block(block)
+	}
+	t.decorate()
+	return t.pages
+}
+
+func (t *pdfTypesetter) top() float64    { return t.opts.height - t.opts.margin }
+func (t *pdfTypesetter) bottom() float64 { return t.opts.margin }
+func (t *pdfTypesetter) right() float64  { return t.opts.width - t.opts.margin }
+
+func (t *pdfTypesetter) newPage() {
+	t.page = &pdfCanvas{}
+	t.pages = append(t.pages, t.page)
+	t.y = t.top()
+	t.blank = true
+}
+
+// ensure starts a new page when height does not fit below the cursor.
+func (t *pdfTypesetter) ensure(height float64) {
+	if !t.blank && t.y-height < t.bottom() {
+		t.newPage()
+	}
+}
+
+// space moves the cursor down, except at the top of a page.
+func (t *pdfTypesetter) space(height float64) {
+	if !t.blank {
+		t.y -= height
+	}
+}
+
+func (t *pdfTypesetter) indentWidth() float64 {
+	return t.opts.fontSize * 1.8
+}
+
+func (t *pdfTypesetter) block(block *pdfBlock) {
+	size := t.opts.fontSize
+	left := t.opts.margin + float64(block.indent)*t.indentWidth()
+	color := pdfColorText
+	if block.quote {
+		color = pdfColorMuted
+	}
+
+	switch block.kind {
+	case pdfBlockHeading:
+		size *= pdfHeadingScale[block.level-1]
+		lines := pdfWrap(block.runs, size, color, t.right()-left)
+		t.space(size * 0.7)
+		// Keep the heading with the first line that follows it
+		t.ensure(float64(len(lines))*size*pdfLineHeight + t.opts.fontSize*pdfLineHeight*2)
+		t.lines(lines, left, t.right()-left, size, block)
+		t.space(size * 0.35)
+
+	case pdfBlockParagraph, pdfBlockListItem:
+		lines := pdfWrap(block.runs, size, color, t.right()-left)
+		if block.kind == pdfBlockListItem && len(lines) == 0 {
+			lines = []pdfLine{{}}
+		}
+		if len(lines) > 0 {
+			t.ensure(size * pdfLineHeight)
+		}
+		if block.kind == pdfBlockListItem {
+			marker := pdfEncode(block.marker)
+			markerWidth := pdfTextWidth(pdfFontRegular, size, marker)
+			t.page.text(left-markerWidth-size*0.4, t.y-size*1.05, pdfFontRegular, size, color, marker)
+		}
+		t.lines(lines, left, t.right()-left, size, block)
+		if block.kind == pdfBlockListItem {
+			t.space(size * 0.25)
+		} else {
+			t.space(size * 0.6)
+		}
+
+	case pdfBlockCode:
+		t.code(block, left)
+
+	case pdfBlockRule:
+		t.space(size * 0.4)
+		t.ensure(size)
+		t.page.line(left, t.y, t.right(), t.y, 0.75, pdfColorBorder)
+		t.blank = false
+		t.space(size * 0.8)
+
+	case pdfBlockPageBreak:
+		if !t.blank {
+			t.newPage()
+		}
+
+	case pdfBlockTable:
+		t.table(block, left)
+		t.space(size * 0.8)
+	}
+}
+
+// lines draws wrapped lines, aligned within width.
+func (t *pdfTypesetter) lines(lines []pdfLine, left, width, size float64, block *pdfBlock) {
+	lineHeight := size * pdfLineHeight
+	for _, line := range lines {
+		t.ensure(lineHeight)
+		x := left
+		switch block.align {
+		case "center":
+			x += (width - line.width) / 2
+		case "right":
+			x += width - line.width
+		}
+		if block.quote {
+			// The quote bar spans the line box
+			barX := t.opts.margin + float64(block.indent-1)*t.indentWidth() + size*0.3
+			t.page.fillRect(barX, t.y-lineHeight, 2, lineHeight, pdfColorBorder)
+		}
+		for _, word := range line.words {
+			t.page.text(x+word.x, t.y-size*1.05, word.font, word.size, word.color, word.text)
+		}
+		t.y -= lineHeight
+		t.blank = false
+	}
+}
+
+// code draws a preformatted block on a shaded background, breaking long lines.
+func (t *pdfTypesetter) code(block *pdfBlock, left float64) {
+	size := t.opts.fontSize * 0.9
+	lineHeight := size * pdfLineHeight
+	padding := size * 0.6
+	width := t.right() - left
+
+	var lines [][]byte
+	charsPerLine := max(1, int((width-2*padding)/(size*0.6)))
+	for _, line := range bytes.Split(pdfEncode(pdfRunsText(block.runs)), []byte("\n")) {
+		for len(line) > charsPerLine {
+			lines = append(lines, line[:charsPerLine])
+			line = line[charsPerLine:]
+		}
+		lines = append(lines, line)
+	}
+
+	t.ensure(lineHeight + 2*padding)
+	t.page.fillRect(left, t.y-padding, width, padding, pdfColorShade)
+	t.y -= padding
+	for _, line := range lines {
+		if !t.blank && t.y-lineHeight-padding < t.bottom() {
+			t.page.fillRect(left, t.y-padding, width, padding, pdfColorShade)
+			t.newPage()
+			t.page.fillRect(left, t.y-padding, width, padding, pdfColorShade)
+			t.y -= padding
+		}
+		t.page.fillRect(left, t.y-lineHeight, width, lineHeight, pdfColorShade)
+		t.page.text(left+padding, t.y-size*1.05, pdfFontMono, size, pdfColorText, line)
+		t.y -= lineHeight
+		t.blank = false
+	}
+	t.page.fillRect(left, t.y-padding, width, padding, pdfColorShade)
+	t.y -= padding
+	t.space(t.opts.fontSize * 0.8)
+}
+
+// table draws a table with bordered cells, repeating the header rows on each page.
+func (t *pdfTypesetter) table(block *pdfBlock, left float64) {
+	size := t.opts.fontSize * 0.9
+	lineHeight := size * pdfLineHeight
+	avail := t.right() - left
+
+	columns := 0
+	for _, row := range block.rows {
+		columns = max(columns, len(row.cells))
+	}
+	widths := pdfColumnWidths(block.rows, columns, size, avail)
+
+	type laidOutRow struct {
+		row    pdfTableRow
+		cells  [][]pdfLine
+		height float64
+	}
+	rows := make([]laidOutRow, len(block.rows))
+	headerRows := 0
+	for i, row := range block.rows {
+		laid := laidOutRow{row: row, cells: make([][]pdfLine, columns)}
+		maxLines := 1
+		for c := range columns {
+			if c < len(row.cells) {
+				laid.cells[c] = pdfWrap(row.cells[c], size, pdfColorText, widths[c]-2*pdfCellPadding)
+				maxLines = max(maxLines, len(laid.cells[c]))
+			}
+		}
+		laid.height = float64(maxLines)*lineHeight + 2*pdfCellPadding
+		rows[i] = laid
+		if row.header && headerRows == i {
+			headerRows++
+		}
+	}
+
+	drawRow := func(laid laidOutRow) {
+		x := left
+		for c := range columns {
+			if laid.row.header {
+				t.page.fillRect(x, t.y-laid.height, widths[c], laid.height, pdfColorShade)
+			}
+			t.page.strokeRect(x, t.y-laid.height, widths[c], laid.height, 0.5, pdfColorBorder)
+			align := ""
+			if c < len(laid.row.aligns) {
+				align = laid.row.aligns[c]
+			}
+			lineY := t.y - pdfCellPadding
+			for _, line := range laid.cells[c] {
+				textX := x + pdfCellPadding
+				switch align {
+				case "center":
+					textX += (widths[c] - 2*pdfCellPadding - line.width) / 2
+				case "right":
+					textX += widths[c] - 2*pdfCellPadding - line.width
+				}
+				for _, word := range line.words {
+					font := word.font
+					if laid.row.header && font == pdfFontRegular {
+						font = pdfFontBold
+					}
+					t.page.text(textX+word.x, lineY-size*1.05, font, word.size, word.color, word.text)
+				}
+				lineY -= lineHeight
+			}
+			x += widths[c]
+		}
+		t.y -= laid.height
+		t.blank = false
+	}
+
+	for i, laid := range rows {
+		if !t.blank && t.y-laid.height < t.bottom() {
+			t.newPage()
+			if i >= headerRows {
+				for _, header := range rows[:headerRows] {
+					drawRow(header)
+				}
+			}
+		}
+		drawRow(laid)
+	}
+}
+
+// pdfColumnWidths sizes table columns to their content: columns narrower than an
+// equal share keep their natural width and the rest share the remaining space.
+func pdfColumnWidths(rows []pdfTableRow, columns int, size, avail float64) []float64 {
+	natural := make([]float64, columns)
+	for _, row := range rows {
+		for c, cell := range row.cells {
+			for _, line := range pdfWrap(cell, size, pdfColorText, 1e9) {
+				width := line.width + 2*pdfCellPadding
+				if row.header {
+					width *= 1.1 // header cells are bold
+				}
+				natural[c] = max(natural[c], width)
+			}
+		}
+	}
+
+	widths := make([]float64, columns)
+	total := 0.0
+	for _, width := range natural {
+		total += max(width, 2*pdfCellPadding+size)
+	}
+	if total <= avail {
+		// Stretch to the full width, like a table with width: 100%
+		for c, width := range natural {
+			widths[c] = max(width, 2*pdfCellPadding+size) * avail / total
+		}
+		return widths
+	}
+
+	share := avail / float64(columns)
+	remaining, wide := avail, 0.0
+	for c, width := range natural {
+		if width <= share {
+			widths[c] = width
+			remaining -= width
+		} else {
+			wide += width
+		}
+	}
+	for c, width := range natural {
+		if width > share {
+			widths[c] = width * remaining / wide
+		}
+	}
+	return widths
+}
+
+// decorate draws the header, footer and page numbers on every page.
+func (t *pdfTypesetter) decorate() {
+	size := t.opts.fontSize * 0.8
+	footerY := t.opts.margin/2 - size/2
+	for i, page := range t.pages {
+		if t.opts.header != "" {
+			text := pdfEncode(t.opts.header)
+			x := (t.opts.width - pdfTextWidth(pdfFontRegular, size, text)) / 2
+			page.text(x, t.opts.height-t.opts.margin/2, pdfFontRegular, size, pdfColorMuted, text)
+		}
+		if t.opts.footer != "" {
+			page.text(t.opts.margin, footerY, pdfFontRegular, size, pdfColorMuted, pdfEncode(t.opts.footer))
+		}
+		if t.opts.pageNumbers {
+			text := pdfEncode("Page " + strconv.Itoa(i+1) + " of " + strconv.Itoa(len(t.pages)))
+			x := t.right() - pdfTextWidth(pdfFontRegular, size, text)
+			page.text(x, footerY, pdfFontRegular, size, pdfColorMuted, text)
+		}
+	}
+}
+
+// pdfWrap breaks runs into lines no wider than width. Words longer than a line are
+// split; "\n" forces a break.
+func pdfWrap(runs []pdfRun, size float64, color [3]float64, width float64) []pdfLine {
+	var lines []pdfLine
+	var line pdfLine
+	pendingSpace := false
+	started := false
+
+	place := func(text []byte, font pdfFont, wordSize float64, wordColor [3]float64) {
+		wordWidth := pdfTextWidth(font, wordSize, text)
+		spaceWidth := 0.0
+		if pendingSpace && len(line.words) > 0 {
+			spaceWidth = pdfTextWidth(font, wordSize, []byte(" "))
+		}
+		if len(line.words) > 0 && line.width+spaceWidth+wordWidth > width {
+			lines = append(lines, line)
+			line = pdfLine{}
+			spaceWidth = 0
+		}
+		// Split words that do not fit on a line of their own
+		for wordWidth > width && len(text) > 1 {
+			n := len(text) - 1
+			for n > 1 && pdfTextWidth(font, wordSize, text[:n]) > width {
+				n--
+			}
+			pieceWidth := pdfTextWidth(font, wordSize, text[:n])
+			lines = append(lines, pdfLine{
+				words: []pdfWord{{text: text[:n], font: font, size: wordSize, color: wordColor, width: pieceWidth}},
+				width: pieceWidth,
+			})
+			text = text[n:]
+			wordWidth = pdfTextWidth(font, wordSize, text)
+		}
+		line.words = append(line.words, pdfWord{
+			text: text, font: font, size: wordSize, color: wordColor, x: line.width + spaceWidth, width: wordWidth,
+		})
+		line.width += spaceWidth + wordWidth
+		pendingSpace = false
+		started = true
+	}
+
+	for _, run := range runs {
+		font := run.style.font()
+		wordColor := color
+		if run.style.link {
+			wordColor = pdfColorLink
+		}
+		wordSize := size
+		if run.style.mono {
+			wordSize = size * 0.92
+		}
+
+		encoded := pdfEncode(run.text)
+		for len(encoded) > 0 {
+			switch encoded[0] {
+			case ' ':
+				pendingSpace = started
+				encoded = encoded[1:]
+			case '\n':
+				lines = append(lines, line)
+				line = pdfLine{}
+				pendingSpace = false
+				started = true
+				encoded = encoded[1:]
+			default:
+				end := bytes.IndexAny(encoded, " \n")
+				if end < 0 {
+					end = len(encoded)
+				}
+				place(encoded[:end], font, wordSize, wordColor)
+				encoded = encoded[end:]
+			}
+		}
+	}
+	if len(line.words) > 0 {
+		lines = append(lines, line)
+	}
+
+	// Drop the trailing empty lines left by <br> at the end of a paragraph
+	for len(lines) > 0 && len(lines[len(lines)-1].words) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// pdfPlainText returns the text of runs with whitespace collapsed.
+func pdfPlainText(runs []pdfRun) string {
+	return strings.Join(strings.Fields(pdfRunsText(runs)), " ")
+}
@@ -0,0 +1,298 @@
+package builtin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/internal/application/filestorage"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+const (
+	pdfFormatAuto     = "auto"
+	pdfFormatHTML     = "html"
+	pdfFormatMarkdown = "markdown"
+
+	pdfMimeType = "application/pdf"
+
+	// pdfMaxSourceBytes caps the size of the HTML or Markdown source.
+	pdfMaxSourceBytes = 10 << 20
+)
+
+// pdfPageSizes are portrait page sizes in points.
+var pdfPageSizes = map[string][2]float64{
+	"A3":     {841.89, 1190.55},
+	"A4":     {595.28, 841.89},
+	"A5":     {419.53, 595.28},
+	"Letter": {612, 792},
+	"Legal":  {612, 1008},
+}
+
+// PDFRenderExecutor renders HTML or Markdown into a PDF document stored in file storage.
+type PDFRenderExecutor struct {
+	*executor.BaseExecutor
+	storage filestorage.Manager
+}
+
+// NewPDFRenderExecutor creates a new pdf_render executor.
+func NewPDFRenderExecutor(storage filestorage.Manager) *PDFRenderExecutor {
+	return &PDFRenderExecutor{
+		BaseExecutor: executor.NewBaseExecutor("pdf_render"),
+		storage:      storage,
+	}
+}
+
+// Execute renders the template into a PDF and stores it.
+//
+// Config:
+//   - template: HTML or Markdown source; {{input.*}} placeholders are resolved before rendering
+//     (default: the input, or its html, markdown, content or text field)
+//   - format: "auto" (default, HTML when the source starts with a tag) | "html" | "markdown"
+//   - file_name: Name of the stored file; ".pdf" is appended when missing (required)
+//   - storage_id: Storage receiving the file (default: "default")
+//   - page_size: "A4" (default) | "A3" | "A5" | "Letter" | "Legal"
+//   - orientation: "portrait" (default) | "landscape"
+//   - margin: Page margin in millimetres (default: 20)
+//   - font_size: Body font size in points (default: 11)
+//   - title, author, subject: Document properties; title defaults to the HTML title or first heading
+//   - header: Text at the top of every page
+//   - footer: Text at the bottom of every page
+//   - page_numbers: Print "Page N of M" at the bottom of every page (default: true)
+//   - access_scope, ttl, tags: As for bytes_to_file
+//
+// Rendering uses the standard PDF fonts (Helvetica and Courier), which cover Western
+// European text; other characters are replaced and images are shown by their alt text.
+// Inline styles other than text-align, font-weight:bold, display:none and page breaks
+// are ignored.
+//
+// Output:
+//   - success: true
+//   - file_id, storage_id, file_name, mime_type, size: The stored file
+//   - page_count: Number of pages
+//   - title: Document title
+//   - duration_ms: Execution time
+func (e *PDFRenderExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	startTime := time.Now()
+
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+	if e.storage == nil {
+		return nil, fmt.Errorf("file storage is not available")
+	}
+
+	source, err := e.source(config, input)
+	if err != nil {
+		return nil, err
+	}
+
+	format := e.GetStringDefault(config, "format", pdfFormatAuto)
+	if format == pdfFormatAuto {
+		format = pdfFormatMarkdown
+		if strings.HasPrefix(strings.TrimSpace(source), "<") {
+			format = pdfFormatHTML
+		}
+	}
+	if format == pdfFormatMarkdown {
+		source = markdownToHTML(source)
+	}
+	doc, err := parsePDFHTML(source)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := e.layoutOptions(config)
+	if err != nil {
+		return nil, err
+	}
+	pages := layoutPDF(doc, opts)
+
+	title := e.GetStringDefault(config, "title", doc.title)
+	if title == "" {
+		for _, block := range doc.blocks {
+			if block.kind == pdfBlockHeading {
+				title = pdfPlainText(block.runs)
+				break
+			}
+		}
+	}
+	data, err := writePDF(pages, opts.width, opts.height, pdfDocumentInfo{
+		Title:   title,
+		Author:  e.GetStringDefault(config, "author", ""),
+		Subject: e.GetStringDefault(config, "subject", ""),
+		Created: startTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := e.store(ctx, config, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"success":     true,
+		"file_id":     stored.ID,
+		"storage_id":  stored.StorageID,
+		"file_name":   stored.Name,
+		"mime_type":   stored.MimeType,
+		"size":        stored.Size,
+		"page_count":  len(pages),
+		"title":       title,
+		"duration_ms": time.Since(startTime).Milliseconds(),
+	}, nil
+}
+
+// Validate validates the pdf_render executor configuration.
+func (e *PDFRenderExecutor) Validate(config map[string]any) error {
+	if _, err := e.GetString(config, "file_name"); err != nil {
+		return fmt.Errorf("file_name is required")
+	}
+	switch format := e.GetStringDefault(config, "format", pdfFormatAuto); format {
+	case pdfFormatAuto, pdfFormatHTML, pdfFormatMarkdown:
+	default:
+		return fmt.Errorf("invalid format: %s (valid: auto, html, markdown)", format)
+	}
+	if pageSize := e.GetStringDefault(config, "page_size", "A4"); pdfPageSize(pageSize) == "" {
+		return fmt.Errorf("invalid page_size: %s (valid: A3, A4, A5, Letter, Legal)", pageSize)
+	}
+	switch orientation := e.GetStringDefault(config, "orientation", "portrait"); orientation {
+	case "portrait", "landscape":
+	default:
+		return fmt.Errorf("invalid orientation: %s (valid: portrait, landscape)", orientation)
+	}
+	if raw, ok := config["margin"]; ok {
+		if margin, ok := toFloat(raw); !ok || margin < 0 || margin > 100 {
+			return fmt.Errorf("margin must be a number of millimetres between 0 and 100")
+		}
+	}
+	if raw, ok := config["font_size"]; ok {
+		if size, ok := toFloat(raw); !ok || size < 6 || size > 36 {
+			return fmt.Errorf("font_size must be a number between 6 and 36")
+		}
+	}
+	if accessScope := e.GetStringDefault(config, "access_scope", "workflow"); !models.AccessScope(accessScope).IsValid() {
+		return fmt.Errorf("invalid access_scope: %s (must be: workflow, edge, result)", accessScope)
+	}
+	if e.GetIntDefault(config, "ttl", 0) < 0 {
+		return fmt.Errorf("ttl must be >= 0")
+	}
+	if raw, ok := config["tags"]; ok {
+		if _, err := toStringSlice(raw, "tags"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// source returns the template, or the document found in the input.
+func (e *PDFRenderExecutor) source(config map[string]any, input any) (string, error) {
+	source := e.GetStringDefault(config, "template", "")
+	if source == "" {
+		switch v := input.(type) {
+		case string:
+			source = v
+		case map[string]any:
+			for _, key := range []string{"html", "markdown", "content", "text"} {
+				if text, ok := v[key].(string); ok && text != "" {
+					source = text
+					break
+				}
+			}
+		}
+	}
+	if strings.TrimSpace(source) == "" {
+		return "", fmt.Errorf("template is required (or an input with html, markdown, content or text)")
+	}
+	if len(source) > pdfMaxSourceBytes {
+		return "", fmt.Errorf("template exceeds %d bytes", pdfMaxSourceBytes)
+	}
+	return source, nil
+}
+
+// layoutOptions converts the page settings to points.
+func (e *PDFRenderExecutor) layoutOptions(config map[string]any) (pdfLayoutOptions, error) {
+	size := pdfPageSizes[pdfPageSize(e.GetStringDefault(config, "page_size", "A4"))]
+	width, height := size[0], size[1]
+	if e.GetStringDefault(config, "orientation", "portrait") == "landscape" {
+		width, height = height, width
+	}
+
+	margin := 20.0
+	if raw, ok := config["margin"]; ok {
+		margin, _ = toFloat(raw)
+	}
+	fontSize := 11.0
+	if raw, ok := config["font_size"]; ok {
+		fontSize, _ = toFloat(raw)
+	}
+
+	opts := pdfLayoutOptions{
+		width:       width,
+		height:      height,
+		margin:      margin * 72 / 25.4,
+		fontSize:    fontSize,
+		header:      e.GetStringDefault(config, "header", ""),
+		footer:      e.GetStringDefault(config, "footer", ""),
+		pageNumbers: e.GetBoolDefault(config, "page_numbers", true),
+	}
+	if width-2*opts.margin < fontSize*10 || height-2*opts.margin < fontSize*10 {
+		return opts, fmt.Errorf("margin leaves no room for content on a %s page", e.GetStringDefault(config, "page_size", "A4"))
+	}
+	return opts, nil
+}
+
+// store saves the document in file storage.
+func (e *PDFRenderExecutor) store(ctx context.Context, config map[string]any, data []byte) (*models.FileEntry, error) {
+	storageID := e.GetStringDefault(config, "storage_id", "default")
+	storage, err := e.storage.GetStorage(storageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage: %w", err)
+	}
+
+	fileName := e.GetStringDefault(config, "file_name", "")
+	if !strings.HasSuffix(strings.ToLower(fileName), ".pdf") {
+		fileName += ".pdf"
+	}
+	var tags []string
+	if raw, ok := config["tags"]; ok {
+		if tags, err = toStringSlice(raw, "tags"); err != nil {
+			return nil, err
+		}
+	}
+
+	entry := &models.FileEntry{
+		StorageID:   storageID,
+		Name:        fileName,
+		MimeType:    pdfMimeType,
+		Size:        int64(len(data)),
+		AccessScope: models.AccessScope(e.GetStringDefault(config, "access_scope", "workflow")),
+		Tags:        tags,
+		Metadata:    make(map[string]any),
+	}
+	if ttl := e.GetIntDefault(config, "ttl", 0); ttl > 0 {
+		entry.SetTTL(time.Duration(ttl) * time.Second)
+	}
+
+	stored, err := storage.Store(ctx, entry, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to store file: %w", err)
+	}
+	return stored, nil
+}
+
+// pdfPageSize returns the canonical name of a page size, matched case-insensitively,
+// or "" when it is unknown.
+func pdfPageSize(name string) string {
+	for size := range pdfPageSizes {
+		if strings.EqualFold(size, name) {
+			return size
+		}
+	}
+	return ""
+}
@@ -0,0 +1,286 @@
+package builtin
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPDFReport = `# Sales Report
+
+Revenue grew **12%** to *$1,250.50* this week. See [the dashboard](https://example.com).
+
+## Top Products
+
+| Product | Revenue | Units |
+|:--------|--------:|:-----:|
+| Bolt    | 500.00  | 20    |
+| Cable   | 750.50  | 31    |
+
+- Highlights
+  - Orders up
+- Concerns
+
+1. Restock bolts
+2. Review pricing
+
+> Quoted note
+
+` + "```" + `
+total = sum(revenue)
+` + "```" + `
+`
+
+// readPDF checks the file structure and returns the decompressed content of each page.
+func readPDF(t *testing.T, data []byte) []string {
+	t.Helper()
+	require.True(t, bytes.HasPrefix(data, []byte("%PDF-1.4\n")))
+	require.True(t, bytes.HasSuffix(data, []byte("%%EOF\n")))
+
+	// Every xref entry must point at the start of its object
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(data)
+	require.NotNil(t, startxref)
+	xrefOffset, _ := strconv.Atoi(string(startxref[1]))
+	require.True(t, bytes.HasPrefix(data[xrefOffset:], []byte("xref\n")))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(data[xrefOffset:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		require.True(t, bytes.HasPrefix(data[offset:], fmt.Appendf(nil, "%d 0 obj\n", i+1)), "object %d", i+1)
+	}
+
+	var pages []string
+	streams := regexp.MustCompile(`(?s)<< /Length (\d+) /Filter /FlateDecode >>\nstream\n`)
+	for _, loc := range streams.FindAllSubmatchIndex(data, -1) {
+		length, _ := strconv.Atoi(string(data[loc[2]:loc[3]]))
+		zr, err := zlib.NewReader(bytes.NewReader(data[loc[1] : loc[1]+length]))
+		require.NoError(t, err)
+		content, err := io.ReadAll(zr)
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(data[loc[1]+length:], []byte("\nendstream")))
+		pages = append(pages, string(content))
+	}
+	return pages
+}
+
+// pdfShownText returns the strings drawn with Tj, in order.
+func pdfShownText(content string) []string {
+	var texts []string
+	for _, match := range regexp.MustCompile(`\(((?:\\.|[^\\)])*)\) Tj`).FindAllStringSubmatch(content, -1) {
+		texts = append(texts, strings.NewReplacer(`\(`, "(", `\)`, ")", `\\`, `\`).Replace(match[1]))
+	}
+	return texts
+}
+
+func TestPDFRenderExecutor_Markdown(t *testing.T) {
+	manager := newMockManager()
+	exec := NewPDFRenderExecutor(manager)
+
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"template":  testPDFReport,
+		"file_name": "weekly-report",
+		"author":    "Reports (bot)",
+		"footer":    "Acme Corp",
+	}, nil)
+	require.NoError(t, err)
+
+	output := result.(map[string]any)
+	assert.Equal(t, true, output["success"])
+	assert.Equal(t, "weekly-report.pdf", output["file_name"])
+	assert.Equal(t, pdfMimeType, output["mime_type"])
+	assert.Equal(t, 1, output["page_count"])
+	assert.Equal(t, "Sales Report", output["title"])
+
+	storage, err := manager.GetStorage("default")
+	require.NoError(t, err)
+	entry, reader, err := storage.Get(context.Background(), output["file_id"].(string))
+	require.NoError(t, err)
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, output["size"], entry.Size)
+
+	assert.Contains(t, string(data), `/Title (Sales Report) /Author (Reports \(bot\))`)
+	assert.Contains(t, string(data), "/BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding")
+
+	pages := readPDF(t, data)
+	require.Len(t, pages, 1)
+	text := strings.Join(pdfShownText(pages[0]), " ")
+	for _, want := range []string{
+		"Sales Report", "Revenue grew 12% to $1,250.50 this week.", "the dashboard",
+		"Top Products", "Product Revenue Units", "Bolt 500.00 20",
+		"\x95 Highlights", "\x96 Orders up", "1. Restock bolts", "2. Review pricing",
+		"Quoted note", "total = sum(revenue)", "Acme Corp", "Page 1 of 1",
+	} {
+		assert.Contains(t, text, want)
+	}
+
+	// Headings and table headers are bold, emphasis italic, code monospaced
+	assert.Contains(t, pages[0], "/F2 20.9 Tf")
+	assert.Regexp(t, `/F2 [\d.]+ Tf [\d.]+ [\d.]+ Td \(Product\) Tj`, pages[0])
+	assert.Regexp(t, `/F3 11 Tf [\d.]+ [\d.]+ Td \(\$1,250.50\) Tj`, pages[0])
+	assert.Regexp(t, `/F5 [\d.]+ Tf [\d.]+ [\d.]+ Td \(total = sum\\\(revenue\\\)\) Tj`, pages[0])
+}
+
+func TestPDFRenderExecutor_HTML(t *testing.T) {
+	manager := newMockManager()
+	exec := NewPDFRenderExecutor(manager)
+
+	rows := strings.Repeat("<tr><td>Item</td><td>1.00</td></tr>\n", 120)
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Invoice   42</title><style>body { color: red; }</style></head>
+<body>
+  <h1>Invoice <span>42</span></h1>
+  <p style="text-align: right">Due:<br>2024-05-01</p>
+  <p hidden>secret</p>
+  <script>alert("x")</script>
+  <table>
+    <thead><tr><th>Description</th><th>Amount</th></tr></thead>
+    <tbody>` + rows + `</tbody>
+  </table>
+  <div style="page-break-before: always"><p>Terms &amp; conditions – Größe €</p></div>
+</body>
+</html>`
+
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"file_name":   "invoice.PDF",
+		"page_size":   "letter",
+		"orientation": "landscape",
+		"margin":      15,
+		"header":      "ACME",
+	}, map[string]any{"html": html})
+	require.NoError(t, err)
+
+	output := result.(map[string]any)
+	assert.Equal(t, "invoice.PDF", output["file_name"])
+	assert.Equal(t, "Invoice 42", output["title"])
+	pageCount := output["page_count"].(int)
+	assert.Greater(t, pageCount, 2)
+
+	storage, _ := manager.GetStorage("default")
+	_, reader, err := storage.Get(context.Background(), output["file_id"].(string))
+	require.NoError(t, err)
+	data, _ := io.ReadAll(reader)
+	assert.Contains(t, string(data), "/MediaBox [0 0 792 612]")
+
+	pages := readPDF(t, data)
+	require.Len(t, pages, pageCount)
+	all := strings.Join(pages, "\n")
+	assert.NotContains(t, all, "secret")
+	assert.NotContains(t, all, "alert")
+	assert.NotContains(t, all, "color")
+
+	// The table header repeats on every page it continues on
+	for _, page := range pages[:pageCount-1] {
+		texts := pdfShownText(page)
+		assert.Contains(t, texts, "ACME")
+		assert.Contains(t, texts, "Description")
+	}
+	// The page break starts a page with only the terms
+	last := pdfShownText(pages[pageCount-1])
+	assert.Contains(t, strings.Join(last, " "), "Terms & conditions \x96 Gr\xf6\xdfe \x80")
+	assert.NotContains(t, last, "Item")
+	assert.Contains(t, last, fmt.Sprintf("Page %d of %d", pageCount, pageCount))
+}
+
+func TestPDFRenderExecutor_Validate(t *testing.T) {
+	exec := NewPDFRenderExecutor(newMockManager())
+
+	valid := []map[string]any{
+		{"file_name": "r"},
+		{"file_name": "r", "format": "html", "page_size": "a3", "orientation": "landscape", "margin": 0, "font_size": 9.5},
+		{"file_name": "r", "tags": []any{"report"}, "ttl": 3600, "access_scope": "result"},
+	}
+	for _, config := range valid {
+		assert.NoError(t, exec.Validate(config), config)
+	}
+
+	invalid := map[string]map[string]any{
+		"file_name is required": {},
+		"invalid format":        {"file_name": "r", "format": "docx"},
+		"invalid page_size":     {"file_name": "r", "page_size": "B5"},
+		"invalid orientation":   {"file_name": "r", "orientation": "sideways"},
+		"margin":                {"file_name": "r", "margin": 150},
+		"font_size":             {"file_name": "r", "font_size": "big"},
+		"invalid access_scope":  {"file_name": "r", "access_scope": "public"},
+		"ttl":                   {"file_name": "r", "ttl": -1},
+	}
+	for message, config := range invalid {
+		assert.ErrorContains(t, exec.Validate(config), message)
+	}
+
+	_, err := exec.Execute(context.Background(), map[string]any{"file_name": "r"}, map[string]any{"rows": []any{}})
+	assert.ErrorContains(t, err, "template is required")
+
+	_, err = exec.Execute(context.Background(), map[string]any{"file_name": "r", "margin": 100, "page_size": "A5"}, "# Hi")
+	assert.ErrorContains(t, err, "margin leaves no room")
+
+	_, err = NewPDFRenderExecutor(nil).Execute(context.Background(), map[string]any{"file_name": "r"}, "# Hi")
+	assert.ErrorContains(t, err, "file storage is not available")
+}
+
+func TestMarkdownToHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{"heading", "## Title ##", "<h2>Title</h2>\n"},
+		{"setext", "Title\n=====", "<h1>Title</h1>\n"},
+		{"paragraph", "one\ntwo\n\nthree", "<p>one\ntwo</p>\n<p>three</p>\n"},
+		{"escaping", "a < b & `x<y`", "<p>a &lt; b &amp; <code>x&lt;y</code></p>\n"},
+		{"emphasis", "**bold** and *it* _too_ in snake_case_name", "<p><strong>bold</strong> and <em>it</em> <em>too</em> in snake_case_name</p>\n"},
+		{"link", "[docs](https://example.com \"Docs\") ![logo](x.png)", `<p><a href="https://example.com">docs</a> <img alt="logo"></p>` + "\n"},
+		{"hard break", "line one  \nline two", "<p>line one<br>line two</p>\n"},
+		{"rule", "a\n\n---\n\nb", "<p>a</p>\n<hr>\n<p>b</p>\n"},
+		{"nested list", "- a\n  - b\n- c", "<ul>\n<li><p>a</p>\n<ul>\n<li>b</li>\n</ul>\n</li>\n<li>c</li>\n</ul>\n"},
+		{"ordered list", "3. c\n4. d", "<ol start=\"3\">\n<li>c</li>\n<li>d</li>\n</ol>\n"},
+		{"loose list", "- a\n\n- b\n\nafter", "<ul>\n<li>a</li>\n<li>b</li>\n</ul>\n<p>after</p>\n"},
+		{"quote", "> # Note\n> text", "<blockquote>\n<h1>Note</h1>\n<p>text</p>\n</blockquote>\n"},
+		{"fence", "```go\nx := 1 < 2\n```", "<pre>x := 1 &lt; 2</pre>\n"},
+		{"indented code", "    code\n    more", "<pre>code\nmore</pre>\n"},
+		{"table", "a | b\n--|--:\n1 | 2", "<table>\n<thead>\n<tr><th>a</th><th align=\"right\">b</th></tr>\n</thead>\n<tbody>\n<tr><td>1</td><td align=\"right\">2</td></tr>\n</tbody>\n</table>\n"},
+		{"page break", "a\n\n<!-- pagebreak -->\n\nb", "<p>a</p>\n<div style=\"page-break-after: always\"></div>\n<p>b</p>\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, markdownToHTML(tt.markdown))
+		})
+	}
+}
+
+func TestPDFWrap(t *testing.T) {
+	runs := []pdfRun{
+		{text: "  The quick brown "},
+		{text: "fox", style: pdfStyle{bold: true}},
+		{text: " jumps\nover the lazy dog "},
+	}
+	lines := pdfWrap(runs, 10, pdfColorText, 80)
+
+	var texts []string
+	for _, line := range lines {
+		var words []string
+		for _, word := range line.words {
+			words = append(words, string(word.text))
+		}
+		texts = append(texts, strings.Join(words, " "))
+		assert.LessOrEqual(t, line.width, 80.0)
+	}
+	assert.Equal(t, []string{"The quick brown", "fox jumps", "over the lazy dog"}, texts)
+	assert.Equal(t, pdfFontBold, lines[1].words[0].font)
+
+	// Words wider than the line are split
+	lines = pdfWrap([]pdfRun{{text: strings.Repeat("W", 30)}}, 10, pdfColorText, 50)
+	assert.Len(t, lines, 6)
+
+	assert.Equal(t, []byte("caf\xe9 \x93ok\x94 ?"), pdfEncode("café “ok” 🎉ж"))
+	assert.InDelta(t, 22.78, pdfTextWidth(pdfFontRegular, 10, []byte("Hello")), 0.01)
+}
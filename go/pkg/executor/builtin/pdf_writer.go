@@ -0,0 +1,279 @@
+package builtin
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// pdfFont identifies one of the standard PDF fonts used for rendering. The standard
+// fonts need no embedding, which keeps documents small, but they only cover the
+// Windows-1252 character set.
+type pdfFont int
+
+const (
+	pdfFontRegular pdfFont = iota
+	pdfFontBold
+	pdfFontItalic
+	pdfFontBoldItalic
+	pdfFontMono
+	pdfFontMonoBold
+)
+
+var pdfFontNames = []string{
+	"Helvetica",
+	"Helvetica-Bold",
+	"Helvetica-Oblique",
+	"Helvetica-BoldOblique",
+	"Courier",
+	"Courier-Bold",
+}
+
+// resource returns the name of the font in page resources.
+func (f pdfFont) resource() string {
+	return "F" + strconv.Itoa(int(f)+1)
+}
+
+// Glyph widths of printable ASCII (32-126) in 1/1000 em, from the Adobe font metrics.
+// The oblique fonts share the widths of their upright variants; Courier is monospaced.
+var (
+	pdfHelveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	pdfHelveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// pdfAverageWidth is used for characters outside ASCII, whose exact widths are not tabled.
+const pdfAverageWidth = 556
+
+// pdfWinAnsi maps the characters of Windows-1252 that differ from Latin-1.
+var pdfWinAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, 'ˆ': 0x88,
+	'‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E, '‘': 0x91, '’': 0x92, '“': 0x93,
+	'”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98, '™': 0x99, 'š': 0x9A, '›': 0x9B,
+	'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// pdfEncode converts text to Windows-1252 for the standard fonts. Symbols such as
+// emoji are dropped and other characters the fonts cannot show become '?'.
+func pdfEncode(text string) []byte {
+	encoded := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r == '\t':
+			encoded = append(encoded, ' ')
+		case r == '\n':
+			// Kept for the layout, which breaks lines on it
+			encoded = append(encoded, '\n')
+		case r >= 0x20 && r < 0x7F, r >= 0xA0 && r <= 0xFF:
+			encoded = append(encoded, byte(r))
+		case pdfWinAnsi[r] != 0:
+			encoded = append(encoded, pdfWinAnsi[r])
+		case unicode.In(r, unicode.So, unicode.Sk, unicode.Mn, unicode.Cf, unicode.Cc):
+			// emoji, combining marks and joiners have no fallback worth printing
+		default:
+			encoded = append(encoded, '?')
+		}
+	}
+	return encoded
+}
+
+// pdfTextWidth returns the width of encoded text in points.
+func pdfTextWidth(font pdfFont, size float64, encoded []byte) float64 {
+	if font == pdfFontMono || font == pdfFontMonoBold {
+		return float64(len(encoded)) * 600 * size / 1000
+	}
+	widths := &pdfHelveticaWidths
+	if font == pdfFontBold || font == pdfFontBoldItalic {
+		widths = &pdfHelveticaBoldWidths
+	}
+	total := 0
+	for _, c := range encoded {
+		if c >= 32 && c <= 126 {
+			total += widths[c-32]
+		} else if c == 0xA0 {
+			total += widths[0]
+		} else {
+			total += pdfAverageWidth
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// pdfString writes encoded text as a PDF literal string.
+func pdfString(encoded []byte) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, c := range encoded {
+		switch c {
+		case '(', ')', '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// pdfNumber formats a coordinate with at most two decimals.
+func pdfNumber(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// pdfCanvas collects the content stream of one page.
+type pdfCanvas struct {
+	buf bytes.Buffer
+}
+
+// text draws encoded text with its baseline starting at (x, y).
+func (c *pdfCanvas) text(x, y float64, font pdfFont, size float64, color [3]float64, encoded []byte) {
+	fmt.Fprintf(&c.buf, "BT %s rg /%s %s Tf %s %s Td %s Tj ET\n",
+		pdfColor(color), font.resource(), pdfNumber(size), pdfNumber(x), pdfNumber(y), pdfString(encoded))
+}
+
+// fillRect fills a rectangle whose lower-left corner is (x, y).
+func (c *pdfCanvas) fillRect(x, y, width, height float64, color [3]float64) {
+	fmt.Fprintf(&c.buf, "%s rg %s %s %s %s re f\n",
+		pdfColor(color), pdfNumber(x), pdfNumber(y), pdfNumber(width), pdfNumber(height))
+}
+
+// strokeRect outlines a rectangle whose lower-left corner is (x, y).
+func (c *pdfCanvas) strokeRect(x, y, width, height, lineWidth float64, color [3]float64) {
+	fmt.Fprintf(&c.buf, "%s RG %s w %s %s %s %s re S\n",
+		pdfColor(color), pdfNumber(lineWidth), pdfNumber(x), pdfNumber(y), pdfNumber(width), pdfNumber(height))
+}
+
+// line draws a straight line.
+func (c *pdfCanvas) line(x1, y1, x2, y2, lineWidth float64, color [3]float64) {
+	fmt.Fprintf(&c.buf, "%s RG %s w %s %s m %s %s l S\n",
+		pdfColor(color), pdfNumber(lineWidth), pdfNumber(x1), pdfNumber(y1), pdfNumber(x2), pdfNumber(y2))
+}
+
+func pdfColor(color [3]float64) string {
+	return pdfNumber(color[0]) + " " + pdfNumber(color[1]) + " " + pdfNumber(color[2])
+}
+
+// pdfDocumentInfo is written to the document information dictionary.
+type pdfDocumentInfo struct {
+	Title   string
+	Author  string
+	Subject string
+	Created time.Time
+}
+
+// writePDF serializes pages of the given size (in points) into a PDF 1.4 file.
+func writePDF(pages []*pdfCanvas, width, height float64, info pdfDocumentInfo) ([]byte, error) {
+	var out bytes.Buffer
+	var offsets []int
+
+	// Objects are numbered in the order they are written: catalog, page tree,
+	// info, fonts, then a page and its content stream per page
+	begin := func() int {
+		offsets = append(offsets, out.Len())
+		id := len(offsets)
+		fmt.Fprintf(&out, "%d 0 obj\n", id)
+		return id
+	}
+	end := func() {
+		out.WriteString("endobj\n")
+	}
+
+	const (
+		catalogID = 1
+		pagesID   = 2
+		infoID    = 3
+		firstFont = 4
+	)
+	firstPage := firstFont + len(pdfFontNames)
+
+	out.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+
+	begin()
+	fmt.Fprintf(&out, "<< /Type /Catalog /Pages %d 0 R >>\n", pagesID)
+	end()
+
+	begin()
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	fmt.Fprintf(&out, "<< /Type /Pages /Kids [%s] /Count %d /MediaBox [0 0 %s %s] >>\n",
+		strings.Join(kids, " "), len(pages), pdfNumber(width), pdfNumber(height))
+	end()
+
+	begin()
+	out.WriteString("<< /Producer (MBFlow)")
+	for _, field := range []struct{ key, value string }{
+		{"Title", info.Title}, {"Author", info.Author}, {"Subject", info.Subject},
+	} {
+		if field.value != "" {
+			fmt.Fprintf(&out, " /%s %s", field.key, pdfString(pdfEncode(field.value)))
+		}
+	}
+	if !info.Created.IsZero() {
+		fmt.Fprintf(&out, " /CreationDate (D:%s)", info.Created.UTC().Format("20060102150405Z"))
+	}
+	out.WriteString(" >>\n")
+	end()
+
+	var fontRefs strings.Builder
+	for i, name := range pdfFontNames {
+		begin()
+		fmt.Fprintf(&out, "<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>\n", name)
+		end()
+		fmt.Fprintf(&fontRefs, " /%s %d 0 R", pdfFont(i).resource(), firstFont+i)
+	}
+
+	for _, page := range pages {
+		pageID := begin()
+		fmt.Fprintf(&out, "<< /Type /Page /Parent %d 0 R /Resources << /Font <<%s >> >> /Contents %d 0 R >>\n",
+			pagesID, fontRefs.String(), pageID+1)
+		end()
+
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		if _, err := zw.Write(page.buf.Bytes()); err != nil {
+			return nil, fmt.Errorf("failed to compress page: %w", err)
+		}
+		if err := zw.Close(); err != nil {
+			return nil, fmt.Errorf("failed to compress page: %w", err)
+		}
+		begin()
+		fmt.Fprintf(&out, "<< /Length %d /Filter /FlateDecode >>\nstream\n", compressed.Len())
+		out.Write(compressed.Bytes())
+		out.WriteString("\nendstream\n")
+		end()
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(offsets)+1, catalogID, infoID, xref)
+	return out.Bytes(), nil
+}
@@ -0,0 +1,319 @@
+package builtin
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun/driver/pgdriver"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+)
+
+// pgvector distance metrics.
+const (
+	VectorMetricCosine       = "cosine"
+	VectorMetricL2           = "l2"
+	VectorMetricInnerProduct = "inner_product"
+)
+
+const (
+	// pgvectorUpsertBatch is the number of rows written per INSERT statement.
+	pgvectorUpsertBatch = 100
+	// pgvectorMaxIndexDimensions is the largest vector pgvector can index with HNSW.
+	pgvectorMaxIndexDimensions = 2000
+)
+
+// pgvectorOperators maps a metric to its distance operator and HNSW operator class.
+var pgvectorOperators = map[string]struct{ operator, opclass string }{
+	VectorMetricCosine:       {"<=>", "vector_cosine_ops"},
+	VectorMetricL2:           {"<->", "vector_l2_ops"},
+	VectorMetricInnerProduct: {"<#>", "vector_ip_ops"},
+}
+
+// pgvectorSSLModes are the accepted sslmode values.
+var pgvectorSSLModes = map[string]bool{"disable": true, "require": true, "verify-full": true}
+
+// pgvectorTablePattern matches a table name, optionally qualified with a schema.
+var pgvectorTablePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,62}(\.[A-Za-z_][A-Za-z0-9_]{0,62})?$`)
+
+// pgvectorConnection holds the settings for connecting to a Postgres server with pgvector.
+type pgvectorConnection struct {
+	Addr     string
+	Database string
+	User     string
+	Password string
+	SSLMode  string
+	Timeout  time.Duration
+}
+
+// pgvectorDocument is a text with its embedding, as stored in a pgvector table.
+type pgvectorDocument struct {
+	ID       string
+	Content  string
+	Metadata map[string]any
+	Vector   []float64
+}
+
+// openPGVector opens a connection pool to a Postgres server using bun's pgdriver.
+func openPGVector(conn pgvectorConnection) (*sql.DB, error) {
+	opts := []pgdriver.Option{
+		pgdriver.WithAddr(conn.Addr),
+		pgdriver.WithTimeout(conn.Timeout),
+		pgdriver.WithApplicationName("mbflow"),
+	}
+	if conn.Database != "" {
+		opts = append(opts, pgdriver.WithDatabase(conn.Database))
+	}
+	if conn.User != "" {
+		opts = append(opts, pgdriver.WithUser(conn.User), pgdriver.WithPassword(conn.Password))
+	}
+	switch conn.SSLMode {
+	case "disable":
+		opts = append(opts, pgdriver.WithInsecure(true))
+	case "verify-full":
+		host, _, _ := net.SplitHostPort(conn.Addr)
+		opts = append(opts, pgdriver.WithTLSConfig(&tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}))
+	}
+	return sql.OpenDB(pgdriver.NewConnector(opts...)), nil
+}
+
+// parsePGVectorConnection reads the connection settings from config and resolves the
+// credential holding the username and password.
+func parsePGVectorConnection(ctx context.Context, base *executor.BaseExecutor, credentials CredentialResolver, config map[string]any) (pgvectorConnection, error) {
+	username, password, err := resolveUsernamePassword(ctx, credentials, base.GetStringDefault(config, "credential_id", ""))
+	if err != nil {
+		return pgvectorConnection{}, err
+	}
+	return pgvectorConnection{
+		Addr:     net.JoinHostPort(base.GetStringDefault(config, "host", ""), strconv.Itoa(base.GetIntDefault(config, "port", 5432))),
+		Database: base.GetStringDefault(config, "database", ""),
+		User:     username,
+		Password: password,
+		SSLMode:  base.GetStringDefault(config, "sslmode", "require"),
+		Timeout:  time.Duration(base.GetIntDefault(config, "timeout", 30)) * time.Second,
+	}, nil
+}
+
+// validatePGVectorConnection checks the connection settings of a pgvector config block.
+func validatePGVectorConnection(config map[string]any, prefix string) error {
+	host, _ := config["host"].(string)
+	if host == "" {
+		return fmt.Errorf("%shost is required", prefix)
+	}
+	table, _ := config["table"].(string)
+	if !pgvectorTablePattern.MatchString(table) {
+		return fmt.Errorf("%stable must be a table name, optionally qualified with a schema (got %q)", prefix, table)
+	}
+	for _, key := range []string{"password", "username", "user", "dsn"} {
+		if _, ok := config[key]; ok {
+			return fmt.Errorf("%s%s must not be set inline: store it in a credentials resource and reference it with credential_id", prefix, key)
+		}
+	}
+	if sslmode, ok := config["sslmode"].(string); ok && !pgvectorSSLModes[sslmode] {
+		return fmt.Errorf("invalid %ssslmode: %s (valid: disable, require, verify-full)", prefix, sslmode)
+	}
+	if raw, ok := config["port"]; ok {
+		if port, ok := toFloat(raw); !ok || port < 1 || port > 65535 {
+			return fmt.Errorf("invalid %sport: %v", prefix, raw)
+		}
+	}
+	return nil
+}
+
+// pgvectorQuoteTable quotes a validated, possibly schema-qualified, table name.
+func pgvectorQuoteTable(table string) string {
+	parts := strings.Split(table, ".")
+	for i, part := range parts {
+		parts[i] = `"` + part + `"`
+	}
+	return strings.Join(parts, ".")
+}
+
+// pgvectorLiteral formats a vector in pgvector's text representation.
+func pgvectorLiteral(vector []float64) string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, v := range vector {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(v, 'g', -1, 32))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// parsePGVectorLiteral parses pgvector's text representation.
+func parsePGVectorLiteral(s string) ([]float64, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") {
+		return nil, fmt.Errorf("invalid vector %q", s)
+	}
+	s = strings.TrimSpace(s[1 : len(s)-1])
+	if s == "" {
+		return []float64{}, nil
+	}
+	parts := strings.Split(s, ",")
+	vector := make([]float64, len(parts))
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vector component %q", part)
+		}
+		vector[i] = v
+	}
+	return vector, nil
+}
+
+// pgvectorDocumentID derives a stable ID from the content, so that storing the same text
+// again updates its row instead of adding a duplicate.
+func pgvectorDocumentID(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:16])
+}
+
+// ensurePGVectorTable creates the pgvector extension, the table and, for vectors pgvector
+// can index, an HNSW index for the metric.
+func ensurePGVectorTable(ctx context.Context, db *sql.DB, table string, dimensions int, metric string) error {
+	quoted := pgvectorQuoteTable(table)
+	statements := []string{
+		"CREATE EXTENSION IF NOT EXISTS vector",
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	id TEXT PRIMARY KEY,
+	content TEXT NOT NULL DEFAULT '',
+	metadata JSONB NOT NULL DEFAULT '{}',
+	embedding vector(%d) NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`, quoted, dimensions),
+	}
+	if dimensions <= pgvectorMaxIndexDimensions {
+		name := strings.ReplaceAll(table, ".", "_") + "_embedding_" + metric + "_idx"
+		statements = append(statements, fmt.Sprintf(`CREATE INDEX IF NOT EXISTS "%s" ON %s USING hnsw (embedding %s)`,
+			name, quoted, pgvectorOperators[metric].opclass))
+	}
+	for _, statement := range statements {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("failed to prepare table %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// upsertPGVectorDocuments inserts the documents, replacing rows with the same ID, in one transaction.
+func upsertPGVectorDocuments(ctx context.Context, db *sql.DB, table string, docs []pgvectorDocument) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	quoted := pgvectorQuoteTable(table)
+	for start := 0; start < len(docs); start += pgvectorUpsertBatch {
+		batch := docs[start:min(start+pgvectorUpsertBatch, len(docs))]
+		values := make([]string, len(batch))
+		args := make([]any, 0, len(batch)*4)
+		for i, doc := range batch {
+			metadata, err := json.Marshal(doc.Metadata)
+			if err != nil {
+				return fmt.Errorf("failed to encode metadata of %s: %w", doc.ID, err)
+			}
+			n := i * 4
+			values[i] = fmt.Sprintf("($%d, $%d, $%d::jsonb, $%d::vector)", n+1, n+2, n+3, n+4)
+			args = append(args, doc.ID, doc.Content, string(metadata), pgvectorLiteral(doc.Vector))
+		}
+		query := fmt.Sprintf(`INSERT INTO %s (id, content, metadata, embedding) VALUES %s
+ON CONFLICT (id) DO UPDATE SET content = EXCLUDED.content, metadata = EXCLUDED.metadata, embedding = EXCLUDED.embedding, updated_at = now()`,
+			quoted, strings.Join(values, ", "))
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// pgvectorMatch is a row returned by a similarity search.
+type pgvectorMatch struct {
+	ID       string
+	Content  string
+	Metadata map[string]any
+	Distance float64
+	Vector   []float64
+}
+
+// searchPGVector returns the topK rows nearest to vector whose metadata contains filter.
+func searchPGVector(ctx context.Context, db *sql.DB, table string, vector []float64, metric string, topK int, filter map[string]any, withVectors bool) ([]pgvectorMatch, error) {
+	distance := fmt.Sprintf("embedding %s $1::vector", pgvectorOperators[metric].operator)
+	columns := "id, content, metadata, " + distance + " AS distance"
+	if withVectors {
+		columns += ", embedding::text"
+	}
+	args := []any{pgvectorLiteral(vector), topK}
+	where := ""
+	if len(filter) > 0 {
+		data, err := json.Marshal(filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode filter: %w", err)
+		}
+		where = " WHERE metadata @> $3::jsonb"
+		args = append(args, string(data))
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s%s ORDER BY %s LIMIT $2", columns, pgvectorQuoteTable(table), where, distance)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var matches []pgvectorMatch
+	for rows.Next() {
+		var (
+			match    pgvectorMatch
+			metadata []byte
+			text     string
+		)
+		dest := []any{&match.ID, &match.Content, &metadata, &match.Distance}
+		if withVectors {
+			dest = append(dest, &text)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		if len(metadata) > 0 {
+			if err := json.Unmarshal(metadata, &match.Metadata); err != nil {
+				return nil, fmt.Errorf("invalid metadata of %s: %w", match.ID, err)
+			}
+		}
+		if withVectors {
+			if match.Vector, err = parsePGVectorLiteral(text); err != nil {
+				return nil, err
+			}
+		}
+		matches = append(matches, match)
+	}
+	return matches, rows.Err()
+}
+
+// pgvectorScore converts a distance to a similarity score where higher is closer:
+// cosine similarity, the inner product, or 1 / (1 + distance) for l2.
+func pgvectorScore(metric string, distance float64) float64 {
+	switch metric {
+	case VectorMetricL2:
+		return 1 / (1 + distance)
+	case VectorMetricInnerProduct:
+		return -distance
+	default:
+		return 1 - distance
+	}
+}
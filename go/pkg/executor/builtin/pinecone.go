@@ -0,0 +1,230 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+)
+
+// pineconeAPIVersion is the Pinecone data plane API version requested.
+const pineconeAPIVersion = "2025-01"
+
+// PineconeExecutor upserts, queries and deletes vectors in a Pinecone index.
+// The API key is never part of the node config: it comes from a credentials resource
+// referenced by ID (api_key, or custom with an api_key field).
+type PineconeExecutor struct {
+	*executor.BaseExecutor
+	credentials CredentialResolver
+	client      *http.Client
+}
+
+// NewPineconeExecutor creates a new pinecone executor.
+// credentials resolves the API key; without it the executor cannot authenticate.
+func NewPineconeExecutor(credentials CredentialResolver) *PineconeExecutor {
+	return &PineconeExecutor{
+		BaseExecutor: executor.NewBaseExecutor("pinecone"),
+		credentials:  credentials,
+		client:       &http.Client{},
+	}
+}
+
+// Execute runs the operation against the index.
+//
+// Config:
+//   - host: Index host shown in the Pinecone console, e.g.
+//     "https://docs-abc123.svc.aped-4627-b74a.pinecone.io" (required)
+//   - credential_id: ID of a credentials resource holding the API key (required)
+//   - namespace: Index namespace (default: the default namespace)
+//   - operation: "upsert" | "query" | "delete" (required)
+//   - text_key: Metadata key holding the text of a vector (default: "text")
+//   - timeout: Timeout in seconds (default: 30)
+//
+// Upsert:
+//   - points: [{id, vector, metadata, text}] (default: the input's points, or the documents
+//     and embeddings of an embedding node)
+//
+// Query:
+//   - query, vector, embedding: As for vector_search
+//   - top_k: Number of matches (default: 5)
+//   - filter: Pinecone metadata filter, e.g. {"source": "handbook"} or {"year": {"$gte": 2024}}
+//   - min_score: Matches scoring lower are dropped
+//   - include_vectors: Return the vectors of the matches (default: false)
+//
+// Delete:
+//   - ids: IDs of the vectors to delete
+//   - filter: Metadata filter selecting the vectors to delete, instead of ids (pod-based indexes only)
+//
+// Output:
+//   - upsert: {upserted, ids, namespace}
+//   - query: {matches: [{id, content, metadata, score}], count, context, query}
+//   - delete: {ids} or {filter}
+//   - duration_ms: Execution duration
+func (e *PineconeExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	startTime := time.Now()
+
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+
+	apiKey, err := resolveVectorStoreAPIKey(ctx, e.credentials, e.GetStringDefault(config, "credential_id", ""))
+	if err != nil {
+		return nil, err
+	}
+	headers := map[string]string{
+		"Api-Key":                apiKey,
+		"X-Pinecone-API-Version": pineconeAPIVersion,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(e.GetIntDefault(config, "timeout", 30))*time.Second)
+	defer cancel()
+
+	host := strings.TrimRight(e.GetStringDefault(config, "host", ""), "/")
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	textKey := e.GetStringDefault(config, "text_key", vectorStoreDefaultTextKey)
+
+	var result map[string]any
+	switch e.GetStringDefault(config, "operation", "") {
+	case VectorStoreUpsert:
+		result, err = e.upsert(ctx, host, headers, config, input, textKey)
+	case VectorStoreQuery:
+		result, err = e.query(ctx, host, headers, config, input, textKey)
+	case VectorStoreDelete:
+		result, err = e.delete(ctx, host, headers, config)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result["duration_ms"] = time.Since(startTime).Milliseconds()
+	return result, nil
+}
+
+// Validate validates the pinecone executor configuration.
+func (e *PineconeExecutor) Validate(config map[string]any) error {
+	if err := e.ValidateRequired(config, "host", "credential_id", "operation"); err != nil {
+		return err
+	}
+	host := e.GetStringDefault(config, "host", "")
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	if u, err := url.Parse(host); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("host must be the host name or URL of the index")
+	}
+	return validateVectorStoreConfig(e.BaseExecutor, config)
+}
+
+// upsert writes the vectors in batches.
+func (e *PineconeExecutor) upsert(ctx context.Context, host string, headers map[string]string, config map[string]any, input any, textKey string) (map[string]any, error) {
+	points, err := vectorStorePoints(config, input, textKey)
+	if err != nil {
+		return nil, err
+	}
+	namespace := e.GetStringDefault(config, "namespace", "")
+
+	ids := make([]any, len(points))
+	upserted := 0
+	for start := 0; start < len(points); start += vectorStoreBatchSize {
+		batch := points[start:min(start+vectorStoreBatchSize, len(points))]
+		vectors := make([]map[string]any, len(batch))
+		for i, p := range batch {
+			vector := map[string]any{"id": p.ID, "values": p.Vector}
+			if len(p.Metadata) > 0 {
+				vector["metadata"] = p.Metadata
+			}
+			vectors[i] = vector
+			ids[start+i] = p.ID
+		}
+
+		body := map[string]any{"vectors": vectors}
+		if namespace != "" {
+			body["namespace"] = namespace
+		}
+		var resp struct {
+			UpsertedCount int `json:"upsertedCount"`
+		}
+		if err := vectorStoreRequest(ctx, e.client, "pinecone", http.MethodPost, host+"/vectors/upsert", headers, body, &resp); err != nil {
+			return nil, fmt.Errorf("failed to upsert vectors %d-%d: %w", start, start+len(batch)-1, err)
+		}
+		upserted += resp.UpsertedCount
+	}
+
+	return map[string]any{
+		"upserted":  upserted,
+		"ids":       ids,
+		"namespace": namespace,
+	}, nil
+}
+
+// query searches the vectors nearest to the query vector.
+func (e *PineconeExecutor) query(ctx context.Context, host string, headers map[string]string, config map[string]any, input any, textKey string) (map[string]any, error) {
+	query, vector, err := resolveQueryVector(ctx, e.BaseExecutor, e.client, config, input)
+	if err != nil {
+		return nil, err
+	}
+	withVectors := e.GetBoolDefault(config, "include_vectors", false)
+
+	body := map[string]any{
+		"vector":          vector,
+		"topK":            e.GetIntDefault(config, "top_k", vectorSearchDefaultTopK),
+		"includeMetadata": true,
+		"includeValues":   withVectors,
+	}
+	if namespace := e.GetStringDefault(config, "namespace", ""); namespace != "" {
+		body["namespace"] = namespace
+	}
+	if filter, ok := config["filter"].(map[string]any); ok && len(filter) > 0 {
+		body["filter"] = filter
+	}
+
+	var resp struct {
+		Matches []struct {
+			ID       string         `json:"id"`
+			Score    float64        `json:"score"`
+			Values   []float64      `json:"values"`
+			Metadata map[string]any `json:"metadata"`
+		} `json:"matches"`
+	}
+	if err := vectorStoreRequest(ctx, e.client, "pinecone", http.MethodPost, host+"/query", headers, body, &resp); err != nil {
+		return nil, err
+	}
+
+	found := make([]vectorStoreMatch, len(resp.Matches))
+	for i, m := range resp.Matches {
+		found[i] = vectorStoreMatch{ID: m.ID, Score: m.Score, Metadata: m.Metadata, Vector: m.Values}
+	}
+	return vectorStoreQueryOutput(config, query, found, textKey, withVectors), nil
+}
+
+// delete removes the vectors with the given IDs, or those matching the filter.
+func (e *PineconeExecutor) delete(ctx context.Context, host string, headers map[string]string, config map[string]any) (map[string]any, error) {
+	body := map[string]any{}
+	if namespace := e.GetStringDefault(config, "namespace", ""); namespace != "" {
+		body["namespace"] = namespace
+	}
+
+	result := map[string]any{}
+	if raw, ok := config["ids"]; ok {
+		ids, _ := vectorStoreIDs(raw) // Checked by Validate
+		body["ids"] = ids
+		result["ids"] = ids
+	} else {
+		filter, _ := config["filter"].(map[string]any)
+		if len(filter) == 0 {
+			return nil, fmt.Errorf("filter must not be empty: deleting every vector is not allowed")
+		}
+		body["filter"] = filter
+		result["filter"] = filter
+	}
+
+	if err := vectorStoreRequest(ctx, e.client, "pinecone", http.MethodPost, host+"/vectors/delete", headers, body, nil); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,164 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPineconeExecutor_Upsert(t *testing.T) {
+	var batches []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/vectors/upsert", r.URL.Path)
+		assert.Equal(t, "qd-key", r.Header.Get("Api-Key"))
+		assert.Equal(t, pineconeAPIVersion, r.Header.Get("X-Pinecone-API-Version"))
+
+		var body struct {
+			Vectors   []map[string]any `json:"vectors"`
+			Namespace string           `json:"namespace"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "support", body.Namespace)
+		batches = append(batches, len(body.Vectors))
+		if len(batches) == 1 {
+			assert.Equal(t, map[string]any{"id": "p-0", "values": []any{0.0, 1.0}, "metadata": map[string]any{"text": "chunk 0", "page": 0.0}}, body.Vectors[0])
+		}
+		_, _ = fmt.Fprintf(w, `{"upsertedCount": %d}`, len(body.Vectors))
+	}))
+	defer server.Close()
+
+	points := make([]any, 150)
+	for i := range points {
+		points[i] = map[string]any{
+			"id":       fmt.Sprintf("p-%d", i),
+			"vector":   []any{float64(i), 1},
+			"text":     fmt.Sprintf("chunk %d", i),
+			"metadata": map[string]any{"page": i},
+		}
+	}
+
+	result, err := NewPineconeExecutor(newTestVectorStoreCredentials()).Execute(context.Background(), map[string]any{
+		"host":          server.URL,
+		"credential_id": "cred-1",
+		"namespace":     "support",
+		"operation":     "upsert",
+		"points":        points,
+	}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{100, 50}, batches)
+	output := result.(map[string]any)
+	assert.Equal(t, 150, output["upserted"])
+	assert.Len(t, output["ids"], 150)
+}
+
+func TestPineconeExecutor_QueryByText(t *testing.T) {
+	embeddings := newOpenAIEmbeddingServer(t, nil)
+	defer embeddings.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/query", r.URL.Path)
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, []any{14.0, 1.0, 0.5}, body["vector"])
+		assert.Equal(t, 3.0, body["topK"])
+		assert.Equal(t, true, body["includeMetadata"])
+		assert.Equal(t, map[string]any{"year": map[string]any{"$gte": 2024.0}}, body["filter"])
+
+		_, _ = w.Write([]byte(`{"matches": [
+			{"id": "a", "score": 0.88, "metadata": {"text": "Refunds take 5 days", "year": 2025}},
+			{"id": "b", "score": 0.12, "metadata": {"text": "Unrelated"}}
+		], "namespace": ""}`))
+	}))
+	defer server.Close()
+
+	result, err := NewPineconeExecutor(newTestVectorStoreCredentials()).Execute(context.Background(), map[string]any{
+		"host":          server.URL,
+		"credential_id": "cred-1",
+		"operation":     "query",
+		"top_k":         3,
+		"filter":        map[string]any{"year": map[string]any{"$gte": 2024}},
+		"min_score":     0.5,
+		"embedding":     map[string]any{"api_key": "sk-test", "base_url": embeddings.URL + "/v1"},
+	}, "refund policy?")
+	require.NoError(t, err)
+
+	output := result.(map[string]any)
+	assert.Equal(t, "refund policy?", output["query"])
+	assert.Equal(t, 1, output["count"])
+	assert.Equal(t, "Refunds take 5 days", output["context"])
+	match := output["matches"].([]any)[0].(map[string]any)
+	assert.Equal(t, "a", match["id"])
+	assert.Equal(t, map[string]any{"year": 2025.0}, match["metadata"])
+}
+
+func TestPineconeExecutor_Delete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/vectors/delete", r.URL.Path)
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]any{"ids": []any{"a", "b"}}, body)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	result, err := NewPineconeExecutor(newTestVectorStoreCredentials()).Execute(context.Background(), map[string]any{
+		"host":          server.URL,
+		"credential_id": "cred-1",
+		"operation":     "delete",
+		"ids":           []any{"a", "b"},
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, result.(map[string]any)["ids"])
+}
+
+func TestPineconeExecutor_CredentialNotAttached(t *testing.T) {
+	ctx := executor.WithExecutionContext(context.Background(), &executor.ExecutionContextData{
+		Resources: map[string]any{"other": map[string]any{"id": "cred-2"}},
+	})
+
+	_, err := NewPineconeExecutor(newTestVectorStoreCredentials()).Execute(ctx, map[string]any{
+		"host":          "https://idx.svc.pinecone.io",
+		"credential_id": "cred-1",
+		"operation":     "delete",
+		"ids":           "a",
+	}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not attached to the workflow")
+}
+
+func TestPineconeExecutor_Validate(t *testing.T) {
+	exec := NewPineconeExecutor(nil)
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{"valid", map[string]any{"host": "idx-abc.svc.pinecone.io", "credential_id": "c", "operation": "upsert"}, ""},
+		{"missing credential", map[string]any{"host": "idx-abc.svc.pinecone.io", "operation": "upsert"}, "credential_id"},
+		{"missing host", map[string]any{"credential_id": "c", "operation": "upsert"}, "host"},
+		{"bad operation", map[string]any{"host": "h", "credential_id": "c", "operation": "fetch"}, "invalid operation"},
+		{"bad filter", map[string]any{"host": "h", "credential_id": "c", "operation": "delete", "filter": "x"}, "filter must be an object"},
+		{"bad ids", map[string]any{"host": "h", "credential_id": "c", "operation": "delete", "ids": 5}, "ids must be"},
+		{"bad top_k", map[string]any{"host": "h", "credential_id": "c", "operation": "query", "vector": []any{1}, "top_k": 5000}, "top_k must be"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exec.Validate(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,327 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+)
+
+// qdrantDocumentIDKey is the payload key keeping IDs that Qdrant does not accept as point IDs.
+const qdrantDocumentIDKey = "document_id"
+
+// qdrantDistances maps a metric to the Qdrant distance name.
+var qdrantDistances = map[string]string{
+	VectorMetricCosine:       "Cosine",
+	VectorMetricL2:           "Euclid",
+	VectorMetricInnerProduct: "Dot",
+}
+
+// qdrantIDNamespace derives point IDs from document IDs that are neither integers nor UUIDs.
+var qdrantIDNamespace = uuid.MustParse("6f1c2a7e-0b1d-4f5e-9a3c-8d2e4b6a9c10")
+
+// qdrantCollectionPattern matches a collection name.
+var qdrantCollectionPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,255}$`)
+
+// QdrantExecutor upserts, queries and deletes points in a Qdrant collection.
+// The API key is never part of the node config: it comes from a credentials resource
+// referenced by ID (api_key, or custom with an api_key field).
+type QdrantExecutor struct {
+	*executor.BaseExecutor
+	credentials CredentialResolver
+	client      *http.Client
+}
+
+// NewQdrantExecutor creates a new qdrant executor.
+// credentials resolves the API key and may be nil, in which case only servers without
+// authentication can be used.
+func NewQdrantExecutor(credentials CredentialResolver) *QdrantExecutor {
+	return &QdrantExecutor{
+		BaseExecutor: executor.NewBaseExecutor("qdrant"),
+		credentials:  credentials,
+		client:       &http.Client{},
+	}
+}
+
+// Execute runs the operation against the collection.
+//
+// Config:
+//   - url: Qdrant URL, e.g. "https://xyz.cloud.qdrant.io:6333" (required)
+//   - credential_id: ID of a credentials resource holding the API key
+//   - collection: Collection name (required)
+//   - operation: "upsert" | "query" | "delete" (required)
+//   - vector_name: Name of the vector, for collections with named vectors
+//   - text_key: Payload key holding the text of a point (default: "text")
+//   - timeout: Timeout in seconds (default: 30)
+//
+// Upsert:
+//   - points: [{id, vector, metadata, text}] (default: the input's points, or the documents
+//     and embeddings of an embedding node)
+//   - create_collection: Create the collection when missing (default: true)
+//   - metric: Distance of a created collection: "cosine" (default) | "l2" | "inner_product"
+//
+// Query:
+//   - query, vector, embedding: As for vector_search
+//   - top_k: Number of matches (default: 5)
+//   - filter: Qdrant filter, or an object of payload values the points must match
+//   - min_score: Matches scoring lower are dropped
+//   - include_vectors: Return the vectors of the matches (default: false)
+//
+// Delete:
+//   - ids: IDs of the points to delete
+//   - filter: Filter selecting the points to delete, instead of ids
+//
+// Point IDs that are neither unsigned integers nor UUIDs are mapped to UUIDs, and the
+// original ID is kept in the payload under document_id and returned by queries.
+//
+// Output:
+//   - upsert: {upserted, ids, collection, created}
+//   - query: {matches: [{id, content, metadata, score}], count, context, query}
+//   - delete: {ids} or {filter}
+//   - duration_ms: Execution duration
+func (e *QdrantExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	startTime := time.Now()
+
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+
+	apiKey, err := resolveVectorStoreAPIKey(ctx, e.credentials, e.GetStringDefault(config, "credential_id", ""))
+	if err != nil {
+		return nil, err
+	}
+	headers := map[string]string{}
+	if apiKey != "" {
+		headers["api-key"] = apiKey
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(e.GetIntDefault(config, "timeout", 30))*time.Second)
+	defer cancel()
+
+	collection := e.GetStringDefault(config, "collection", "")
+	endpoint := strings.TrimRight(e.GetStringDefault(config, "url", ""), "/") + "/collections/" + collection
+	textKey := e.GetStringDefault(config, "text_key", vectorStoreDefaultTextKey)
+
+	var result map[string]any
+	switch e.GetStringDefault(config, "operation", "") {
+	case VectorStoreUpsert:
+		result, err = e.upsert(ctx, endpoint, headers, config, input, textKey)
+	case VectorStoreQuery:
+		result, err = e.query(ctx, endpoint, headers, config, input, textKey)
+	case VectorStoreDelete:
+		result, err = e.delete(ctx, endpoint, headers, config)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result["duration_ms"] = time.Since(startTime).Milliseconds()
+	return result, nil
+}
+
+// Validate validates the qdrant executor configuration.
+func (e *QdrantExecutor) Validate(config map[string]any) error {
+	if err := e.ValidateRequired(config, "url", "collection", "operation"); err != nil {
+		return err
+	}
+	if u, err := url.Parse(e.GetStringDefault(config, "url", "")); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url must be an http or https URL")
+	}
+	if collection := e.GetStringDefault(config, "collection", ""); !qdrantCollectionPattern.MatchString(collection) {
+		return fmt.Errorf("invalid collection name: %q", collection)
+	}
+	if metric := e.GetStringDefault(config, "metric", VectorMetricCosine); qdrantDistances[metric] == "" {
+		return fmt.Errorf("invalid metric: %s (valid: cosine, l2, inner_product)", metric)
+	}
+	return validateVectorStoreConfig(e.BaseExecutor, config)
+}
+
+// upsert writes the points, creating the collection first when needed.
+func (e *QdrantExecutor) upsert(ctx context.Context, endpoint string, headers map[string]string, config map[string]any, input any, textKey string) (map[string]any, error) {
+	points, err := vectorStorePoints(config, input, textKey)
+	if err != nil {
+		return nil, err
+	}
+	vectorName := e.GetStringDefault(config, "vector_name", "")
+
+	created := false
+	if e.GetBoolDefault(config, "create_collection", true) {
+		var exists struct {
+			Result struct {
+				Exists bool `json:"exists"`
+			} `json:"result"`
+		}
+		if err := vectorStoreRequest(ctx, e.client, "qdrant", http.MethodGet, endpoint+"/exists", headers, nil, &exists); err != nil {
+			return nil, err
+		}
+		if !exists.Result.Exists {
+			params := map[string]any{
+				"size":     len(points[0].Vector),
+				"distance": qdrantDistances[e.GetStringDefault(config, "metric", VectorMetricCosine)],
+			}
+			var vectors any = params
+			if vectorName != "" {
+				vectors = map[string]any{vectorName: params}
+			}
+			if err := vectorStoreRequest(ctx, e.client, "qdrant", http.MethodPut, endpoint, headers, map[string]any{"vectors": vectors}, nil); err != nil {
+				return nil, fmt.Errorf("failed to create collection: %w", err)
+			}
+			created = true
+		}
+	}
+
+	ids := make([]any, len(points))
+	for start := 0; start < len(points); start += vectorStoreBatchSize {
+		batch := points[start:min(start+vectorStoreBatchSize, len(points))]
+		body := make([]map[string]any, len(batch))
+		for i, p := range batch {
+			id, derived := qdrantPointID(p.ID)
+			payload := p.Metadata
+			if derived {
+				payload[qdrantDocumentIDKey] = p.ID
+			}
+			var vector any = p.Vector
+			if vectorName != "" {
+				vector = map[string]any{vectorName: p.Vector}
+			}
+			body[i] = map[string]any{"id": id, "vector": vector, "payload": payload}
+			ids[start+i] = p.ID
+		}
+		if err := vectorStoreRequest(ctx, e.client, "qdrant", http.MethodPut, endpoint+"/points?wait=true", headers, map[string]any{"points": body}, nil); err != nil {
+			return nil, fmt.Errorf("failed to upsert points %d-%d: %w", start, start+len(batch)-1, err)
+		}
+	}
+
+	return map[string]any{
+		"upserted":   len(points),
+		"ids":        ids,
+		"collection": e.GetStringDefault(config, "collection", ""),
+		"created":    created,
+	}, nil
+}
+
+// query searches the points nearest to the query vector.
+func (e *QdrantExecutor) query(ctx context.Context, endpoint string, headers map[string]string, config map[string]any, input any, textKey string) (map[string]any, error) {
+	query, vector, err := resolveQueryVector(ctx, e.BaseExecutor, e.client, config, input)
+	if err != nil {
+		return nil, err
+	}
+	withVectors := e.GetBoolDefault(config, "include_vectors", false)
+
+	body := map[string]any{
+		"vector":       vector,
+		"limit":        e.GetIntDefault(config, "top_k", vectorSearchDefaultTopK),
+		"with_payload": true,
+		"with_vector":  withVectors,
+	}
+	if name := e.GetStringDefault(config, "vector_name", ""); name != "" {
+		body["vector"] = map[string]any{"name": name, "vector": vector}
+	}
+	if filter, ok := config["filter"].(map[string]any); ok && len(filter) > 0 {
+		body["filter"] = qdrantFilter(filter)
+	}
+	if minScore, ok := toFloat(config["min_score"]); ok {
+		body["score_threshold"] = minScore
+	}
+
+	var resp struct {
+		Result []struct {
+			ID      any            `json:"id"`
+			Score   float64        `json:"score"`
+			Payload map[string]any `json:"payload"`
+			Vector  any            `json:"vector"`
+		} `json:"result"`
+	}
+	if err := vectorStoreRequest(ctx, e.client, "qdrant", http.MethodPost, endpoint+"/points/search", headers, body, &resp); err != nil {
+		return nil, err
+	}
+
+	found := make([]vectorStoreMatch, len(resp.Result))
+	for i, r := range resp.Result {
+		m := vectorStoreMatch{ID: fmt.Sprint(r.ID), Score: r.Score, Metadata: r.Payload}
+		if id, ok := r.Payload[qdrantDocumentIDKey].(string); ok {
+			m.ID = id
+			delete(m.Metadata, qdrantDocumentIDKey)
+		}
+		if withVectors {
+			raw := r.Vector
+			if named, ok := raw.(map[string]any); ok {
+				raw = named[e.GetStringDefault(config, "vector_name", "")]
+			}
+			m.Vector, _ = toVector(raw)
+		}
+		found[i] = m
+	}
+	return vectorStoreQueryOutput(config, query, found, textKey, withVectors), nil
+}
+
+// delete removes the points with the given IDs, or those matching the filter.
+func (e *QdrantExecutor) delete(ctx context.Context, endpoint string, headers map[string]string, config map[string]any) (map[string]any, error) {
+	if raw, ok := config["ids"]; ok {
+		ids, _ := vectorStoreIDs(raw) // Checked by Validate
+		points := make([]any, len(ids))
+		for i, id := range ids {
+			points[i], _ = qdrantPointID(id)
+		}
+		if err := vectorStoreRequest(ctx, e.client, "qdrant", http.MethodPost, endpoint+"/points/delete?wait=true", headers, map[string]any{"points": points}, nil); err != nil {
+			return nil, err
+		}
+		return map[string]any{"ids": ids}, nil
+	}
+
+	filter, _ := config["filter"].(map[string]any)
+	if len(filter) == 0 {
+		return nil, fmt.Errorf("filter must not be empty: deleting every point is not allowed")
+	}
+	if err := vectorStoreRequest(ctx, e.client, "qdrant", http.MethodPost, endpoint+"/points/delete?wait=true", headers, map[string]any{"filter": qdrantFilter(filter)}, nil); err != nil {
+		return nil, err
+	}
+	return map[string]any{"filter": filter}, nil
+}
+
+// qdrantPointID converts a document ID into a Qdrant point ID, which must be an unsigned
+// integer or a UUID. Other IDs are mapped to a UUID derived from them, and derived reports
+// that the original ID has to be kept in the payload.
+func qdrantPointID(id string) (pointID any, derived bool) {
+	if n, err := strconv.ParseUint(id, 10, 64); err == nil {
+		return n, false
+	}
+	if u, err := uuid.Parse(id); err == nil {
+		return u.String(), false
+	}
+	return uuid.NewSHA1(qdrantIDNamespace, []byte(id)).String(), true
+}
+
+// qdrantFilter returns filter unchanged when it is a Qdrant filter, and otherwise turns an
+// object of payload values into conditions that must all match.
+func qdrantFilter(filter map[string]any) map[string]any {
+	for _, key := range []string{"must", "should", "must_not", "min_should"} {
+		if _, ok := filter[key]; ok {
+			return filter
+		}
+	}
+	keys := make([]string, 0, len(filter))
+	for key := range filter {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	must := make([]any, 0, len(filter))
+	for _, key := range keys {
+		value := filter[key]
+		if values, ok := value.([]any); ok {
+			must = append(must, map[string]any{"key": key, "match": map[string]any{"any": values}})
+		} else {
+			must = append(must, map[string]any{"key": key, "match": map[string]any{"value": value}})
+		}
+	}
+	return map[string]any{"must": must}
+}
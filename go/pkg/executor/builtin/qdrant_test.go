@@ -0,0 +1,221 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// qdrantRequest is a request received by the fake Qdrant server.
+type qdrantRequest struct {
+	Method string
+	Path   string
+	Body   map[string]any
+}
+
+// newFakeQdrant starts a server recording requests and answering with the response
+// registered for "METHOD /path", or {"result": true}.
+func newFakeQdrant(t *testing.T, responses map[string]string) (*httptest.Server, *[]qdrantRequest) {
+	var requests []qdrantRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "qd-key", r.Header.Get("api-key"))
+		req := qdrantRequest{Method: r.Method, Path: r.URL.RequestURI()}
+		_ = json.NewDecoder(r.Body).Decode(&req.Body)
+		requests = append(requests, req)
+
+		resp, ok := responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			resp = `{"result": true, "status": "ok"}`
+		}
+		_, _ = w.Write([]byte(resp))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func newTestVectorStoreCredentials() *fakeCredentialResolver {
+	cred := models.NewCredentialsResource("owner-1", "vector db", models.CredentialTypeAPIKey)
+	cred.DecryptedData = map[string]string{"api_key": "qd-key"}
+	return &fakeCredentialResolver{creds: map[string]*models.CredentialsResource{"cred-1": cred}}
+}
+
+func TestQdrantExecutor_UpsertCreatesCollection(t *testing.T) {
+	server, requests := newFakeQdrant(t, map[string]string{
+		"GET /collections/docs/exists": `{"result": {"exists": false}}`,
+	})
+
+	exec := NewQdrantExecutor(newTestVectorStoreCredentials())
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"url":           server.URL,
+		"credential_id": "cred-1",
+		"collection":    "docs",
+		"operation":     "upsert",
+		"metric":        "inner_product",
+	}, map[string]any{
+		"documents": []any{
+			map[string]any{"id": "doc-1#0", "text": "Refunds take 5 days", "metadata": map[string]any{"source": "handbook"}},
+			map[string]any{"id": "42", "text": "Shipping is free", "metadata": map[string]any{}},
+		},
+		"embeddings": [][]float64{{0.1, 0.2}, {0.3, 0.4}},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, *requests, 3)
+	create := (*requests)[1]
+	assert.Equal(t, "PUT /collections/docs", create.Method+" "+create.Path)
+	assert.Equal(t, map[string]any{"size": float64(2), "distance": "Dot"}, create.Body["vectors"])
+
+	upsert := (*requests)[2]
+	assert.Equal(t, "/collections/docs/points?wait=true", upsert.Path)
+	points := upsert.Body["points"].([]any)
+	first := points[0].(map[string]any)
+	derivedID, _ := qdrantPointID("doc-1#0")
+	assert.Equal(t, derivedID, first["id"])
+	assert.Equal(t, map[string]any{"source": "handbook", "text": "Refunds take 5 days", "document_id": "doc-1#0"}, first["payload"])
+	assert.Equal(t, float64(42), points[1].(map[string]any)["id"])
+
+	output := result.(map[string]any)
+	assert.Equal(t, 2, output["upserted"])
+	assert.Equal(t, true, output["created"])
+	assert.Equal(t, []any{"doc-1#0", "42"}, output["ids"])
+}
+
+func TestQdrantExecutor_Query(t *testing.T) {
+	server, requests := newFakeQdrant(t, map[string]string{
+		"POST /collections/docs/points/search": `{"result": [
+			{"id": "0b6f1c2a-0000-5000-8000-000000000000", "score": 0.92, "payload": {"text": "Refunds take 5 days", "source": "handbook", "document_id": "doc-1#0"}},
+			{"id": 42, "score": 0.41, "payload": {"text": "Shipping is free", "source": "handbook"}}
+		]}`,
+	})
+
+	result, err := NewQdrantExecutor(newTestVectorStoreCredentials()).Execute(context.Background(), map[string]any{
+		"url":           server.URL,
+		"credential_id": "cred-1",
+		"collection":    "docs",
+		"operation":     "query",
+		"vector":        []any{0.1, 0.2},
+		"top_k":         2,
+		"filter":        map[string]any{"source": "handbook", "lang": []any{"en", "de"}},
+		"min_score":     0.4,
+	}, nil)
+	require.NoError(t, err)
+
+	body := (*requests)[0].Body
+	assert.Equal(t, float64(2), body["limit"])
+	assert.Equal(t, 0.4, body["score_threshold"])
+	assert.Equal(t, map[string]any{"must": []any{
+		map[string]any{"key": "lang", "match": map[string]any{"any": []any{"en", "de"}}},
+		map[string]any{"key": "source", "match": map[string]any{"value": "handbook"}},
+	}}, body["filter"])
+
+	output := result.(map[string]any)
+	assert.Equal(t, 2, output["count"])
+	assert.Equal(t, "Refunds take 5 days\n\nShipping is free", output["context"])
+	first := output["matches"].([]any)[0].(map[string]any)
+	assert.Equal(t, "doc-1#0", first["id"])
+	assert.Equal(t, "Refunds take 5 days", first["content"])
+	assert.Equal(t, map[string]any{"source": "handbook"}, first["metadata"])
+	assert.Equal(t, "42", output["matches"].([]any)[1].(map[string]any)["id"])
+}
+
+func TestQdrantExecutor_Delete(t *testing.T) {
+	server, requests := newFakeQdrant(t, nil)
+
+	result, err := NewQdrantExecutor(newTestVectorStoreCredentials()).Execute(context.Background(), map[string]any{
+		"url":           server.URL,
+		"credential_id": "cred-1",
+		"collection":    "docs",
+		"operation":     "delete",
+		"ids":           []any{"doc-1#0", 7},
+	}, nil)
+	require.NoError(t, err)
+
+	derivedID, _ := qdrantPointID("doc-1#0")
+	assert.Equal(t, "/collections/docs/points/delete?wait=true", (*requests)[0].Path)
+	assert.Equal(t, []any{derivedID, float64(7)}, (*requests)[0].Body["points"])
+	assert.Equal(t, []string{"doc-1#0", "7"}, result.(map[string]any)["ids"])
+}
+
+func TestQdrantExecutor_APIError(t *testing.T) {
+	server, _ := newFakeQdrant(t, nil)
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"status": {"error": "Wrong input: Vector dimension error: expected dim: 3, got 2"}}`))
+	})
+
+	_, err := NewQdrantExecutor(newTestVectorStoreCredentials()).Execute(context.Background(), map[string]any{
+		"url":           server.URL,
+		"credential_id": "cred-1",
+		"collection":    "docs",
+		"operation":     "query",
+		"vector":        []any{0.1, 0.2},
+	}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "qdrant API error (status 400): Wrong input: Vector dimension error")
+}
+
+func TestQdrantExecutor_Validate(t *testing.T) {
+	exec := NewQdrantExecutor(nil)
+	config := func(overrides map[string]any) map[string]any {
+		c := map[string]any{"url": "http://localhost:6333", "collection": "docs", "operation": "query", "vector": []any{1, 2}}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+			} else {
+				c[k] = v
+			}
+		}
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{"valid query", config(nil), ""},
+		{"valid upsert", config(map[string]any{"operation": "upsert", "vector": nil}), ""},
+		{"valid delete", config(map[string]any{"operation": "delete", "ids": []any{"a"}}), ""},
+		{"missing url", config(map[string]any{"url": nil}), "url"},
+		{"bad url", config(map[string]any{"url": "localhost:6333"}), "url must be an http or https URL"},
+		{"bad collection", config(map[string]any{"collection": "docs/../x"}), "invalid collection name"},
+		{"bad operation", config(map[string]any{"operation": "scroll"}), "invalid operation"},
+		{"inline api key", config(map[string]any{"api_key": "secret"}), "api_key must not be set inline"},
+		{"query without vector", config(map[string]any{"vector": nil}), "embedding settings are required"},
+		{"delete without ids", config(map[string]any{"operation": "delete"}), "ids or filter is required"},
+		{"bad metric", config(map[string]any{"metric": "manhattan"}), "invalid metric"},
+		{"bad points", config(map[string]any{"operation": "upsert", "points": "x"}), "points must be an array"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exec.Validate(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestQdrantPointID(t *testing.T) {
+	id, derived := qdrantPointID("123")
+	assert.Equal(t, uint64(123), id)
+	assert.False(t, derived)
+
+	id, derived = qdrantPointID("5C56C793-69F3-4FBF-87E6-C4BF54C28C26")
+	assert.Equal(t, "5c56c793-69f3-4fbf-87e6-c4bf54c28c26", id)
+	assert.False(t, derived)
+
+	id, derived = qdrantPointID("doc-1#0")
+	again, _ := qdrantPointID("doc-1#0")
+	assert.True(t, derived)
+	assert.Equal(t, id, again, "derived IDs are stable")
+}
@@ -0,0 +1,195 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+)
+
+// RateLimitStore keeps the state of the rate limits of rate_limit nodes.
+type RateLimitStore interface {
+	// Reserve reserves the next slot of the limit under key, which allows one slot per
+	// interval with up to burst slots at once, and returns how long the caller must wait
+	// for it. If the wait would exceed maxWait (when maxWait >= 0) nothing is reserved
+	// and ok is false.
+	Reserve(ctx context.Context, key string, interval time.Duration, burst int, maxWait time.Duration) (wait time.Duration, ok bool, err error)
+}
+
+// RateLimitExecutor throttles how fast the nodes after it run: each execution waits for a
+// slot of the limit, then passes its input through unchanged.
+//
+// Config:
+//   - limit: Number of executions allowed per period (required)
+//   - per: "second" (default), "minute", "hour", or a Go duration string such as "10s"
+//   - burst: Executions allowed at once after an idle period (default: 1, evenly spaced)
+//   - key: Name of the limit, shared by every node using it, e.g. "stripe-api"
+//     (default: one limit per workflow node)
+//   - max_wait: Fail instead of waiting longer than this; Go duration string or a number
+//     of seconds (default: wait as long as the node may run)
+//
+// Limits are kept in the store, so with a Redis store they are shared by every instance.
+type RateLimitExecutor struct {
+	*executor.BaseExecutor
+	store RateLimitStore
+}
+
+// NewRateLimitExecutor creates a new rate limit executor keeping limits in memory until
+// SetRateLimitStore sets a shared store.
+func NewRateLimitExecutor() *RateLimitExecutor {
+	return &RateLimitExecutor{
+		BaseExecutor: executor.NewBaseExecutor("rate_limit"),
+		store:        NewMemoryRateLimitStore(),
+	}
+}
+
+// SetRateLimitStore sets the store keeping the limits. It must be set before executions start.
+func (e *RateLimitExecutor) SetRateLimitStore(store RateLimitStore) {
+	e.store = store
+}
+
+// Execute waits for a slot of the limit and returns the input.
+func (e *RateLimitExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+	limit, err := parseRateLimit(config)
+	if err != nil {
+		return nil, err
+	}
+
+	key, _ := config["key"].(string)
+	if key == "" {
+		execCtx, ok := executor.GetExecutionContext(ctx)
+		if !ok || execCtx.WorkflowID == "" || execCtx.NodeID == "" {
+			return nil, fmt.Errorf("rate_limit nodes without a key require an execution context")
+		}
+		key = execCtx.WorkflowID + ":" + execCtx.NodeID
+	}
+
+	wait, ok, err := e.store.Reserve(ctx, "rate_limit:"+key, limit.interval, limit.burst, limit.maxWait)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve rate limit slot: %w", err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("rate limit %q exceeded: next slot in %s, max_wait is %s", key, wait.Round(time.Millisecond), limit.maxWait)
+	}
+
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return input, nil
+}
+
+// Validate validates the rate limit configuration. Templated values are checked at execution.
+func (e *RateLimitExecutor) Validate(config map[string]any) error {
+	for _, key := range []string{"limit", "per", "burst", "max_wait"} {
+		if s, ok := config[key].(string); ok && strings.Contains(s, "{{") {
+			return nil
+		}
+	}
+	if key, ok := config["key"]; ok {
+		if _, ok := key.(string); !ok {
+			return fmt.Errorf("key must be a string")
+		}
+	}
+	_, err := parseRateLimit(config)
+	return err
+}
+
+// rateLimit is a parsed rate_limit config.
+type rateLimit struct {
+	interval time.Duration // Time between two slots
+	burst    int
+	maxWait  time.Duration // -1 waits without limit
+}
+
+func parseRateLimit(config map[string]any) (*rateLimit, error) {
+	limit, err := batchInt(config, "limit", 0)
+	if err != nil {
+		return nil, fmt.Errorf("limit must be an integer")
+	}
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+
+	period := time.Second
+	switch per := config["per"].(type) {
+	case nil:
+	case string:
+		switch per {
+		case "second":
+		case "minute":
+			period = time.Minute
+		case "hour":
+			period = time.Hour
+		default:
+			if period, err = time.ParseDuration(per); err != nil || period <= 0 {
+				return nil, fmt.Errorf("per must be second, minute, hour or a positive duration")
+			}
+		}
+	default:
+		return nil, fmt.Errorf("per must be a string")
+	}
+
+	burst, err := batchInt(config, "burst", 1)
+	if err != nil {
+		return nil, fmt.Errorf("burst must be an integer")
+	}
+	if burst <= 0 {
+		return nil, fmt.Errorf("burst must be positive")
+	}
+
+	maxWait := time.Duration(-1)
+	if value, ok := config["max_wait"]; ok {
+		if maxWait, err = parseDelayDuration(value); err != nil {
+			return nil, fmt.Errorf("invalid max_wait: %w", err)
+		}
+	}
+
+	interval := period / time.Duration(limit)
+	if interval < time.Microsecond {
+		return nil, fmt.Errorf("limit is too high for the period")
+	}
+	return &rateLimit{interval: interval, burst: burst, maxWait: maxWait}, nil
+}
+
+// MemoryRateLimitStore is an in-process RateLimitStore. Limits are not shared between instances.
+type MemoryRateLimitStore struct {
+	mu   sync.Mutex
+	tats map[string]time.Time // Theoretical arrival time of the next slot per key
+	now  func() time.Time
+}
+
+// NewMemoryRateLimitStore creates an empty in-memory rate limit store.
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{tats: make(map[string]time.Time), now: time.Now}
+}
+
+// Reserve reserves the next slot of the limit under key (generic cell rate algorithm).
+func (s *MemoryRateLimitStore) Reserve(ctx context.Context, key string, interval time.Duration, burst int, maxWait time.Duration) (time.Duration, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	tat := s.tats[key]
+	if tat.Before(now) {
+		tat = now
+	}
+	next := tat.Add(interval)
+	wait := max(next.Add(-time.Duration(burst)*interval).Sub(now), 0)
+	if maxWait >= 0 && wait > maxWait {
+		return wait, false, nil
+	}
+	s.tats[key] = next
+	return wait, true, nil
+}
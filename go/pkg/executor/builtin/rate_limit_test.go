@@ -0,0 +1,76 @@
+package builtin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryRateLimitStore_Reserve(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// A burst of 2 passes at once, then slots are an interval apart
+	for i, want := range []time.Duration{0, 0, time.Second, 2 * time.Second} {
+		wait, ok, err := store.Reserve(ctx, "api", time.Second, 2, -1)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, want, wait, "reservation %d", i)
+	}
+
+	// Over max_wait nothing is reserved
+	wait, ok, err := store.Reserve(ctx, "api", time.Second, 2, time.Second)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 3*time.Second, wait)
+
+	// Limits are separate per key and refill while idle
+	wait, _, _ = store.Reserve(ctx, "other", time.Second, 2, -1)
+	assert.Zero(t, wait)
+	now = now.Add(time.Minute)
+	wait, _, _ = store.Reserve(ctx, "api", time.Second, 2, -1)
+	assert.Zero(t, wait)
+}
+
+func TestRateLimitExecutor_Execute(t *testing.T) {
+	exec := NewRateLimitExecutor()
+	config := map[string]any{"limit": float64(50), "key": "test-api"}
+	input := map[string]any{"order_id": "42"}
+
+	start := time.Now()
+	for range 3 {
+		out, err := exec.Execute(context.Background(), config, input)
+		require.NoError(t, err)
+		assert.Equal(t, input, out)
+	}
+	// 50 per second spaces the executions 20ms apart
+	assert.GreaterOrEqual(t, time.Since(start), 35*time.Millisecond)
+
+	_, err := exec.Execute(context.Background(), map[string]any{"limit": 1, "per": "hour", "key": "slow", "max_wait": 1}, nil)
+	require.NoError(t, err)
+	_, err = exec.Execute(context.Background(), map[string]any{"limit": 1, "per": "hour", "key": "slow", "max_wait": 1}, nil)
+	assert.ErrorContains(t, err, `rate limit "slow" exceeded`)
+
+	_, err = exec.Execute(context.Background(), map[string]any{"limit": 1}, nil)
+	assert.ErrorContains(t, err, "execution context")
+}
+
+func TestRateLimitExecutor_Validate(t *testing.T) {
+	exec := NewRateLimitExecutor()
+
+	assert.NoError(t, exec.Validate(map[string]any{"limit": 10}))
+	assert.NoError(t, exec.Validate(map[string]any{"limit": 10, "per": "minute", "burst": 5, "max_wait": "30s"}))
+	assert.NoError(t, exec.Validate(map[string]any{"limit": 10, "per": "15s"}))
+	assert.NoError(t, exec.Validate(map[string]any{"limit": "{{env.api_rate}}"}))
+	assert.Error(t, exec.Validate(map[string]any{}))
+	assert.Error(t, exec.Validate(map[string]any{"limit": 0}))
+	assert.Error(t, exec.Validate(map[string]any{"limit": 10, "per": "fortnight"}))
+	assert.Error(t, exec.Validate(map[string]any{"limit": 10, "burst": 0}))
+	assert.Error(t, exec.Validate(map[string]any{"limit": 10, "key": 7}))
+	assert.Error(t, exec.Validate(map[string]any{"limit": 10_000_000}))
+}
@@ -0,0 +1,380 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+)
+
+const (
+	redisOpGet     = "get"
+	redisOpSet     = "set"
+	redisOpIncr    = "incr"
+	redisOpExpire  = "expire"
+	redisOpPublish = "publish"
+
+	// redisDefaultPoolSize matches the default pool size of the server cache.
+	redisDefaultPoolSize = 10
+)
+
+// RedisExecutor reads and writes keys and publishes messages on a Redis instance.
+// Every node names its own target with the same settings as the server cache
+// (url, db, pool_size, key_prefix), so workflows never touch the server's Redis implicitly.
+// Clients are pooled per target and credential and reused across executions; call Close
+// to close them. Passwords are never part of the node config: authentication uses a
+// credentials resource referenced by ID (basic_auth, or custom with username/password fields).
+type RedisExecutor struct {
+	*executor.BaseExecutor
+	credentials CredentialResolver
+
+	mu      sync.Mutex
+	clients map[string]*redis.Client
+}
+
+// NewRedisExecutor creates a new Redis executor.
+// credentials may be nil, in which case only instances without authentication can be used.
+func NewRedisExecutor(credentials CredentialResolver) *RedisExecutor {
+	return &RedisExecutor{
+		BaseExecutor: executor.NewBaseExecutor("redis"),
+		credentials:  credentials,
+		clients:      make(map[string]*redis.Client),
+	}
+}
+
+// Execute runs a Redis operation.
+//
+// Config:
+//   - url: Redis URL without credentials, e.g. "redis://redis.internal:6379" or "rediss://..." (required)
+//   - db: Database number (default: 0, or the one in the URL path)
+//   - pool_size: Connection pool size (default: 10)
+//   - key_prefix: Prefix joined to keys with ":", e.g. "orders" turns "42" into "orders:42"
+//   - credential_id: ID of a credentials resource holding username/password (username may be
+//     empty for a plain requirepass); the credential must be attached to the workflow
+//   - operation: "get" | "set" | "incr" | "expire" | "publish" (required)
+//   - key: Key for get, set, incr and expire
+//   - value: Value for set; strings are stored as-is, other values as JSON
+//   - ttl: Expiration in seconds for set (default: none) and expire (required)
+//   - only_if_absent: For set, only set the key if it does not exist (SET NX)
+//   - by: Increment for incr (default: 1)
+//   - parse_json: For get, decode the stored value as JSON
+//   - channel, message: Channel and message for publish; non-string messages are sent as JSON
+//   - timeout: Timeout in seconds (default: 10)
+//
+// Output:
+//   - get: value (null when the key does not exist), exists
+//   - set: ok (false when only_if_absent is set and the key exists)
+//   - incr: value
+//   - expire: ok (false when the key does not exist)
+//   - publish: receivers
+//   - duration_ms: Execution duration
+func (e *RedisExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	startTime := time.Now()
+
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+
+	timeout := time.Duration(e.GetIntDefault(config, "timeout", 10)) * time.Second
+	opCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	client, err := e.client(opCtx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	operation := e.GetStringDefault(config, "operation", "")
+	var output map[string]any
+	switch operation {
+	case redisOpGet:
+		output, err = e.get(opCtx, client, config)
+	case redisOpSet:
+		output, err = e.set(opCtx, client, config)
+	case redisOpIncr:
+		output, err = e.incr(opCtx, client, config)
+	case redisOpExpire:
+		output, err = e.expire(opCtx, client, config)
+	case redisOpPublish:
+		output, err = e.publish(opCtx, client, config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("redis %s failed: %w", operation, err)
+	}
+
+	output["duration_ms"] = time.Since(startTime).Milliseconds()
+	return output, nil
+}
+
+// Validate validates the Redis executor configuration.
+func (e *RedisExecutor) Validate(config map[string]any) error {
+	if err := e.ValidateRequired(config, "url", "operation"); err != nil {
+		return err
+	}
+
+	for _, key := range []string{"password", "username"} {
+		if _, ok := config[key]; ok {
+			return fmt.Errorf("%s must not be set inline: store it in a credentials resource and reference it with credential_id", key)
+		}
+	}
+
+	target, err := url.Parse(e.GetStringDefault(config, "url", ""))
+	if err != nil || (target.Scheme != "redis" && target.Scheme != "rediss") {
+		return fmt.Errorf("url must be a redis:// or rediss:// URL")
+	}
+	if target.User != nil {
+		return fmt.Errorf("url must not contain credentials: store them in a credentials resource and reference it with credential_id")
+	}
+
+	if db := e.GetIntDefault(config, "db", 0); db < 0 {
+		return fmt.Errorf("db must not be negative")
+	}
+	if poolSize := e.GetIntDefault(config, "pool_size", redisDefaultPoolSize); poolSize < 1 {
+		return fmt.Errorf("pool_size must be at least 1")
+	}
+
+	switch operation := e.GetStringDefault(config, "operation", ""); operation {
+	case redisOpGet, redisOpIncr:
+		return e.ValidateRequired(config, "key")
+	case redisOpSet:
+		if err := e.ValidateRequired(config, "key"); err != nil {
+			return err
+		}
+		if _, ok := config["value"]; !ok {
+			return fmt.Errorf("set requires a value")
+		}
+		if ttl := e.GetIntDefault(config, "ttl", 0); ttl < 0 {
+			return fmt.Errorf("ttl must not be negative")
+		}
+	case redisOpExpire:
+		if err := e.ValidateRequired(config, "key"); err != nil {
+			return err
+		}
+		if ttl := e.GetIntDefault(config, "ttl", 0); ttl < 1 {
+			return fmt.Errorf("expire requires a ttl of at least 1 second")
+		}
+	case redisOpPublish:
+		if err := e.ValidateRequired(config, "channel"); err != nil {
+			return err
+		}
+		if _, ok := config["message"]; !ok {
+			return fmt.Errorf("publish requires a message")
+		}
+	default:
+		return fmt.Errorf("invalid operation: %s (valid: get, set, incr, expire, publish)", operation)
+	}
+
+	return nil
+}
+
+// Close closes all pooled clients.
+func (e *RedisExecutor) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var firstErr error
+	for key, client := range e.clients {
+		if err := client.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(e.clients, key)
+	}
+	return firstErr
+}
+
+// Health pings the pooled clients, so targets that became unreachable make the executor
+// unhealthy. An executor without pooled clients is healthy.
+func (e *RedisExecutor) Health(ctx context.Context) error {
+	var errs []error
+	for _, err := range e.HealthTargets(ctx) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// HealthTargets pings the pooled clients and returns the results by pool key, so that a
+// target one workflow connects to being unreachable does not hold back nodes connecting to
+// others.
+func (e *RedisExecutor) HealthTargets(ctx context.Context) map[string]error {
+	e.mu.Lock()
+	clients := make(map[string]*redis.Client, len(e.clients))
+	for key, client := range e.clients {
+		clients[key] = client
+	}
+	e.mu.Unlock()
+
+	results := make(map[string]error, len(clients))
+	for key, client := range clients {
+		results[key] = nil
+		if err := client.Ping(ctx).Err(); err != nil {
+			results[key] = fmt.Errorf("redis %s: %w", client.Options().Addr, err)
+		}
+	}
+	return results
+}
+
+// HealthTarget returns the key of the pooled client a node with the config uses, or "" if
+// its URL is invalid.
+func (e *RedisExecutor) HealthTarget(config map[string]any) string {
+	key, _, err := e.poolKey(config)
+	if err != nil {
+		return ""
+	}
+	return key
+}
+
+// poolKey returns the key clients are pooled by, the URL, database, credential and pool
+// size, along with the options parsed from the URL.
+func (e *RedisExecutor) poolKey(config map[string]any) (string, *redis.Options, error) {
+	rawURL := e.GetStringDefault(config, "url", "")
+	credentialID := e.GetStringDefault(config, "credential_id", "")
+	poolSize := e.GetIntDefault(config, "pool_size", redisDefaultPoolSize)
+
+	opts, err := redis.ParseURL(rawURL)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse Redis URL: %w", err)
+	}
+	if _, ok := config["db"]; ok {
+		opts.DB = e.GetIntDefault(config, "db", 0)
+	}
+	return rawURL + "\x00" + strconv.Itoa(opts.DB) + "\x00" + credentialID + "\x00" + strconv.Itoa(poolSize), opts, nil
+}
+
+// client returns the pooled client for the target and credential, creating it on first use.
+func (e *RedisExecutor) client(ctx context.Context, config map[string]any) (*redis.Client, error) {
+	credentialID := e.GetStringDefault(config, "credential_id", "")
+	poolSize := e.GetIntDefault(config, "pool_size", redisDefaultPoolSize)
+
+	// Resolve first so the attachment check runs on every execution, not only when connecting
+	username, password, err := resolveUsernamePassword(ctx, e.credentials, credentialID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, opts, err := e.poolKey(config)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if client, ok := e.clients[key]; ok {
+		return client, nil
+	}
+
+	opts.Username = username
+	opts.Password = password
+	opts.PoolSize = poolSize
+
+	// Same connection settings as the server cache
+	opts.DialTimeout = 5 * time.Second
+	opts.ReadTimeout = 3 * time.Second
+	opts.WriteTimeout = 3 * time.Second
+	opts.PoolTimeout = 4 * time.Second
+
+	client := redis.NewClient(opts)
+	e.clients[key] = client
+	return client, nil
+}
+
+// redisKey joins key_prefix and key.
+func (e *RedisExecutor) redisKey(config map[string]any) string {
+	key := e.GetStringDefault(config, "key", "")
+	if prefix := e.GetStringDefault(config, "key_prefix", ""); prefix != "" {
+		return prefix + ":" + key
+	}
+	return key
+}
+
+func (e *RedisExecutor) get(ctx context.Context, client *redis.Client, config map[string]any) (map[string]any, error) {
+	value, err := client.Get(ctx, e.redisKey(config)).Result()
+	if errors.Is(err, redis.Nil) {
+		return map[string]any{"value": nil, "exists": false}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	output := map[string]any{"value": value, "exists": true}
+	if e.GetBoolDefault(config, "parse_json", false) {
+		var decoded any
+		if err := executor.UnmarshalJSON(ctx, []byte(value), &decoded); err != nil {
+			return nil, fmt.Errorf("value is not valid JSON: %w", err)
+		}
+		output["value"] = decoded
+	}
+	return output, nil
+}
+
+func (e *RedisExecutor) set(ctx context.Context, client *redis.Client, config map[string]any) (map[string]any, error) {
+	value, err := redisPayload(config["value"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid value: %w", err)
+	}
+	ttl := time.Duration(e.GetIntDefault(config, "ttl", 0)) * time.Second
+
+	if e.GetBoolDefault(config, "only_if_absent", false) {
+		ok, err := client.SetNX(ctx, e.redisKey(config), value, ttl).Result()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"ok": ok}, nil
+	}
+
+	if err := client.Set(ctx, e.redisKey(config), value, ttl).Err(); err != nil {
+		return nil, err
+	}
+	return map[string]any{"ok": true}, nil
+}
+
+func (e *RedisExecutor) incr(ctx context.Context, client *redis.Client, config map[string]any) (map[string]any, error) {
+	value, err := client.IncrBy(ctx, e.redisKey(config), int64(e.GetIntDefault(config, "by", 1))).Result()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"value": value}, nil
+}
+
+func (e *RedisExecutor) expire(ctx context.Context, client *redis.Client, config map[string]any) (map[string]any, error) {
+	ttl := time.Duration(e.GetIntDefault(config, "ttl", 0)) * time.Second
+	ok, err := client.Expire(ctx, e.redisKey(config), ttl).Result()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"ok": ok}, nil
+}
+
+func (e *RedisExecutor) publish(ctx context.Context, client *redis.Client, config map[string]any) (map[string]any, error) {
+	message, err := redisPayload(config["message"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid message: %w", err)
+	}
+
+	receivers, err := client.Publish(ctx, e.GetStringDefault(config, "channel", ""), message).Result()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"receivers": receivers}, nil
+}
+
+// redisPayload returns strings as-is and encodes other values as JSON.
+func redisPayload(v any) (string, error) {
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
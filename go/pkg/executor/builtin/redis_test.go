@@ -0,0 +1,171 @@
+package builtin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisExecutor_Validate(t *testing.T) {
+	exec := NewRedisExecutor(nil)
+	base := func(extra map[string]any) map[string]any {
+		config := map[string]any{"url": "redis://redis:6379", "operation": "get", "key": "k"}
+		for k, v := range extra {
+			config[k] = v
+		}
+		return config
+	}
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{"valid get", base(nil), ""},
+		{"missing url", map[string]any{"operation": "get", "key": "k"}, "url"},
+		{"invalid scheme", base(map[string]any{"url": "http://redis"}), "redis://"},
+		{"credentials in url", base(map[string]any{"url": "redis://:secret@redis:6379"}), "must not contain credentials"},
+		{"inline password", base(map[string]any{"password": "secret"}), "must not be set inline"},
+		{"invalid operation", base(map[string]any{"operation": "flushall"}), "invalid operation"},
+		{"get without key", map[string]any{"url": "redis://redis", "operation": "get"}, "key"},
+		{"set without value", base(map[string]any{"operation": "set"}), "value"},
+		{"expire without ttl", base(map[string]any{"operation": "expire"}), "ttl"},
+		{"publish without channel", base(map[string]any{"operation": "publish", "message": "hi"}), "channel"},
+		{"publish without message", base(map[string]any{"operation": "publish", "channel": "events"}), "message"},
+		{"invalid pool size", base(map[string]any{"pool_size": 0}), "pool_size"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exec.Validate(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRedisExecutor_Operations(t *testing.T) {
+	s := miniredis.RunT(t)
+	exec := NewRedisExecutor(nil)
+	t.Cleanup(func() { exec.Close() })
+
+	run := func(config map[string]any) map[string]any {
+		t.Helper()
+		config["url"] = "redis://" + s.Addr()
+		config["key_prefix"] = "orders"
+		result, err := exec.Execute(context.Background(), config, nil)
+		require.NoError(t, err)
+		return result.(map[string]any)
+	}
+
+	output := run(map[string]any{"operation": "set", "key": "42", "value": map[string]any{"status": "paid"}, "ttl": 60})
+	assert.Equal(t, true, output["ok"])
+	assert.Equal(t, `{"status":"paid"}`, mustGet(t, s, "orders:42"))
+	assert.Equal(t, 60*time.Second, s.TTL("orders:42"))
+
+	output = run(map[string]any{"operation": "set", "key": "42", "value": "other", "only_if_absent": true})
+	assert.Equal(t, false, output["ok"])
+
+	output = run(map[string]any{"operation": "get", "key": "42", "parse_json": true})
+	assert.Equal(t, true, output["exists"])
+	assert.Equal(t, map[string]any{"status": "paid"}, output["value"])
+
+	output = run(map[string]any{"operation": "get", "key": "missing"})
+	assert.Equal(t, false, output["exists"])
+	assert.Nil(t, output["value"])
+
+	run(map[string]any{"operation": "incr", "key": "count"})
+	output = run(map[string]any{"operation": "incr", "key": "count", "by": 5})
+	assert.Equal(t, int64(6), output["value"])
+
+	output = run(map[string]any{"operation": "expire", "key": "count", "ttl": 30})
+	assert.Equal(t, true, output["ok"])
+	assert.Equal(t, 30*time.Second, s.TTL("orders:count"))
+
+	output = run(map[string]any{"operation": "expire", "key": "missing", "ttl": 30})
+	assert.Equal(t, false, output["ok"])
+}
+
+func TestRedisExecutor_Publish(t *testing.T) {
+	s := miniredis.RunT(t)
+	exec := NewRedisExecutor(nil)
+	t.Cleanup(func() { exec.Close() })
+
+	sub := s.NewSubscriber()
+	defer sub.Close()
+	sub.Subscribe("events")
+
+	// miniredis delivers synchronously, so receive while publishing
+	received := make(chan miniredis.PubsubMessage, 1)
+	go func() { received <- <-sub.Messages() }()
+
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"url":        "redis://" + s.Addr(),
+		"key_prefix": "ignored-for-channels",
+		"operation":  "publish",
+		"channel":    "events",
+		"message":    map[string]any{"type": "order.paid"},
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.(map[string]any)["receivers"])
+
+	msg := <-received
+	assert.Equal(t, "events", msg.Channel)
+	assert.Equal(t, `{"type":"order.paid"}`, msg.Message)
+}
+
+func TestRedisExecutor_TargetsAndCredentials(t *testing.T) {
+	first := miniredis.RunT(t)
+	second := miniredis.RunT(t)
+	second.RequireUserAuth("app", "s3cret")
+
+	cred := models.NewCredentialsResource("owner-1", "redis", models.CredentialTypeBasicAuth)
+	cred.DecryptedData = map[string]string{"username": "app", "password": "s3cret"}
+	exec := NewRedisExecutor(&fakeCredentialResolver{creds: map[string]*models.CredentialsResource{"cred-1": cred}})
+	t.Cleanup(func() { exec.Close() })
+
+	set := func(config map[string]any) error {
+		config["operation"] = "set"
+		config["key"] = "k"
+		_, err := exec.Execute(context.Background(), config, nil)
+		return err
+	}
+
+	require.NoError(t, set(map[string]any{"url": "redis://" + first.Addr(), "value": "one"}))
+	require.NoError(t, set(map[string]any{"url": "redis://" + second.Addr(), "credential_id": "cred-1", "value": "two"}))
+	require.NoError(t, set(map[string]any{"url": "redis://" + first.Addr(), "db": 2, "value": "three"}))
+
+	assert.Equal(t, "one", mustGet(t, first, "k"))
+	assert.Equal(t, "two", mustGet(t, second, "k"))
+	first.Select(2)
+	assert.Equal(t, "three", mustGet(t, first, "k"))
+
+	ctx := executor.WithExecutionContext(context.Background(), &executor.ExecutionContextData{
+		Resources: map[string]any{"other": map[string]any{"id": "cred-2"}},
+	})
+	_, err := exec.Execute(ctx, map[string]any{
+		"url":           "redis://" + second.Addr(),
+		"credential_id": "cred-1",
+		"operation":     "get",
+		"key":           "k",
+	}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not attached")
+}
+
+func mustGet(t *testing.T, s *miniredis.Miniredis, key string) string {
+	t.Helper()
+	value, err := s.Get(key)
+	require.NoError(t, err)
+	return value
+}
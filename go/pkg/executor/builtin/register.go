@@ -13,14 +13,23 @@ func RegisterBuiltins(manager executor.Manager) error {
 	executors := map[string]executor.Executor{
 		"http":              NewHTTPExecutor(),
 		"transform":         NewTransformExecutor(),
+		"script_js":         NewScriptJSExecutor(),
 		"llm":               NewLLMExecutor(),
+		"moderation":        NewModerationExecutor(),
 		"function_call":     NewFunctionCallExecutor(),
 		"telegram":          NewTelegramExecutor(),
 		"telegram_download": NewTelegramDownloadExecutor(),
 		"telegram_parse":    NewTelegramParseExecutor(),
 		"telegram_callback": NewTelegramCallbackExecutor(),
+		"discord":           NewDiscordExecutor(),
 		"conditional":       NewConditionalExecutor(),
 		"merge":             NewMergeExecutor(),
+		"delay":             NewDelayExecutor(),
+		"wait_for_event":    NewWaitForEventExecutor(),
+		"approval":          NewApprovalExecutor(),
+		"batcher":           NewBatcherExecutor(),
+		"validate":          NewValidateExecutor(),
+		"rate_limit":        NewRateLimitExecutor(),
 		"html_clean":        NewHTMLCleanExecutor(),
 		"rss_parser":        NewRSSParserExecutor(),
 		"google_sheets":     NewGoogleSheetsExecutor(),
@@ -42,6 +51,99 @@ func RegisterFileStorage(manager executor.Manager, storageManager filestorage.Ma
 	return manager.Register("file_storage", NewFileStorageExecutor(storageManager))
 }
 
+// RegisterScriptPython registers the script_python executor with the given manager.
+// It runs user code in subprocesses or containers, so applications opt in explicitly.
+func RegisterScriptPython(manager executor.Manager, opts ScriptPythonOptions) error {
+	return manager.Register("script_python", NewScriptPythonExecutor(opts))
+}
+
+// RegisterWASM registers the wasm executor with the given manager.
+// storageManager provides uploaded modules and may be nil, in which case modules
+// can only be given inline. Applications that need to release compiled modules on
+// shutdown should register the result of NewWASMExecutor themselves and call its
+// Close method.
+func RegisterWASM(manager executor.Manager, storageManager filestorage.Manager) error {
+	return manager.Register("wasm", NewWASMExecutor(storageManager))
+}
+
+// RegisterXLSX registers the xlsx executor with the given manager.
+// storageManager stores written workbooks and provides workbooks to read; it may be nil,
+// in which case only workbooks given inline as base64 can be read.
+func RegisterXLSX(manager executor.Manager, storageManager filestorage.Manager) error {
+	return manager.Register("xlsx", NewXLSXExecutor(storageManager))
+}
+
+// RegisterPDFRender registers the pdf_render executor with the given manager.
+// storageManager stores the rendered documents; without it the executor reports an error.
+func RegisterPDFRender(manager executor.Manager, storageManager filestorage.Manager) error {
+	return manager.Register("pdf_render", NewPDFRenderExecutor(storageManager))
+}
+
+// RegisterImage registers the image executor with the given manager.
+// storageManager holds the source images and the results; without it the executor reports an error.
+func RegisterImage(manager executor.Manager, storageManager filestorage.Manager) error {
+	return manager.Register("image", NewImageExecutor(storageManager))
+}
+
+// RegisterEmailSend registers the email_send executor with the given manager.
+// credentials resolves credential_id references; storageManager provides attachments.
+// Either may be nil to disable authenticated sending or attachments respectively.
+func RegisterEmailSend(manager executor.Manager, credentials CredentialResolver, storageManager filestorage.Manager) error {
+	return manager.Register("email_send", NewEmailSendExecutor(credentials, storageManager))
+}
+
+// RegisterSlack registers the slack executor with the given manager.
+// credentials resolves the bot token; storageManager provides files for uploads and may be nil.
+func RegisterSlack(manager executor.Manager, credentials CredentialResolver, storageManager filestorage.Manager) error {
+	return manager.Register("slack", NewSlackExecutor(credentials, storageManager))
+}
+
+// RegisterMySQLQuery registers the mysql_query executor with the given manager.
+// credentials resolves the database username and password and may be nil.
+func RegisterMySQLQuery(manager executor.Manager, credentials CredentialResolver) error {
+	return manager.Register("mysql_query", NewMySQLQueryExecutor(credentials))
+}
+
+// RegisterEmbedding registers the embedding executor with the given manager.
+// credentials resolves the pgvector username and password and may be nil.
+func RegisterEmbedding(manager executor.Manager, credentials CredentialResolver) error {
+	return manager.Register("embedding", NewEmbeddingExecutor(credentials))
+}
+
+// RegisterVectorSearch registers the vector_search executor with the given manager.
+// credentials resolves the pgvector username and password and may be nil.
+func RegisterVectorSearch(manager executor.Manager, credentials CredentialResolver) error {
+	return manager.Register("vector_search", NewVectorSearchExecutor(credentials))
+}
+
+// RegisterQdrant registers the qdrant executor with the given manager.
+// credentials resolves the API key and may be nil.
+func RegisterQdrant(manager executor.Manager, credentials CredentialResolver) error {
+	return manager.Register("qdrant", NewQdrantExecutor(credentials))
+}
+
+// RegisterPinecone registers the pinecone executor with the given manager.
+// credentials resolves the API key and may be nil.
+func RegisterPinecone(manager executor.Manager, credentials CredentialResolver) error {
+	return manager.Register("pinecone", NewPineconeExecutor(credentials))
+}
+
+// RegisterMongoDB registers the mongodb executor with the given manager.
+// credentials resolves the database username and password and may be nil.
+// Applications that need to disconnect pooled clients on shutdown should register
+// the result of NewMongoDBExecutor themselves and call its Close method.
+func RegisterMongoDB(manager executor.Manager, credentials CredentialResolver) error {
+	return manager.Register("mongodb", NewMongoDBExecutor(credentials))
+}
+
+// RegisterRedis registers the redis executor with the given manager.
+// credentials resolves the Redis username and password and may be nil.
+// Applications that need to close pooled clients on shutdown should register
+// the result of NewRedisExecutor themselves and call its Close method.
+func RegisterRedis(manager executor.Manager, credentials CredentialResolver) error {
+	return manager.Register("redis", NewRedisExecutor(credentials))
+}
+
 // MustRegisterBuiltins registers all built-in executors and panics on error.
 // This is a convenience function for initialization code.
 func MustRegisterBuiltins(manager executor.Manager) {
@@ -110,3 +212,30 @@ func MustRegisterFileAdapters(manager executor.Manager, storageManager filestora
 		panic("failed to register file adapter executors: " + err.Error())
 	}
 }
+
+// RegisterGRPCCall registers the grpc_call executor with the given manager.
+// credentials resolves the authorization credential and may be nil; storageManager
+// provides uploaded descriptor sets and may be nil.
+// Applications that need to close pooled connections on shutdown should register
+// the result of NewGRPCCallExecutor themselves and call its Close method.
+func RegisterGRPCCall(manager executor.Manager, credentials CredentialResolver, storageManager filestorage.Manager) error {
+	return manager.Register("grpc_call", NewGRPCCallExecutor(credentials, storageManager))
+}
+
+// RegisterSOAP registers the soap executor with the given manager.
+// credentials resolves the authorization credential and may be nil.
+func RegisterSOAP(manager executor.Manager, credentials CredentialResolver) error {
+	return manager.Register("soap", NewSOAPExecutor(credentials))
+}
+
+// RegisterIssueTracker registers the issue_tracker executor with the given manager.
+// credentials resolves the API token and may be nil.
+func RegisterIssueTracker(manager executor.Manager, credentials CredentialResolver) error {
+	return manager.Register("issue_tracker", NewIssueTrackerExecutor(credentials))
+}
+
+// RegisterWebSocketSend registers the websocket_send executor with the given manager.
+// credentials resolves the authorization credential and may be nil.
+func RegisterWebSocketSend(manager executor.Manager, credentials CredentialResolver) error {
+	return manager.Register("websocket_send", NewWebSocketSendExecutor(credentials))
+}
@@ -0,0 +1,506 @@
+package builtin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"runtime/metrics"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+)
+
+// Limits of the script_js executor.
+const (
+	scriptJSDefaultTimeoutMs  = 5000
+	scriptJSMaxTimeoutMs      = 300000
+	scriptJSDefaultMemoryMB   = 64
+	scriptJSMaxMemoryMB       = 1024
+	scriptJSMaxCallStackSize  = 1024
+	scriptJSMaxOutputBytes    = 16 << 20
+	scriptJSMaxLogs           = 100
+	scriptJSMaxLogLength      = 4096
+	scriptJSMaxFetchBodyBytes = 10 << 20
+	scriptJSMemorySampleEvery = 10 * time.Millisecond
+	scriptJSLiveHeapMetric    = "/gc/heap/live:bytes"
+)
+
+// Reasons a script is interrupted.
+var (
+	errScriptTimeout = errors.New("script exceeded its time limit")
+	errScriptMemory  = errors.New("script exceeded its memory limit")
+)
+
+// ScriptJSExecutor runs JavaScript (ES2015+, without Node.js or browser APIs) in an
+// embedded goja runtime. Each execution gets a fresh runtime with the node input and
+// the workflow variables, a time limit, a memory limit and a call stack limit.
+// Scripts have no network access unless the node allows it for a list of hosts.
+type ScriptJSExecutor struct {
+	*executor.BaseExecutor
+	client *http.Client
+}
+
+// NewScriptJSExecutor creates a new script_js executor.
+func NewScriptJSExecutor() *ScriptJSExecutor {
+	return &ScriptJSExecutor{
+		BaseExecutor: executor.NewBaseExecutor("script_js"),
+		client:       &http.Client{},
+	}
+}
+
+// Execute runs the script.
+//
+// Config:
+//   - script: Body of a function receiving input and env; its return value is the
+//     result (required). Returned promises are awaited.
+//   - timeout_ms: Time limit in milliseconds (default: 5000, max: 300000)
+//   - max_memory_mb: Heap growth limit in MB while the script runs (default: 64, max: 1024)
+//   - allow_network: Provide a synchronous fetch(url, {method, headers, body}) (default: false)
+//   - allowed_hosts: Hosts fetch may call, required with allow_network; "*.example.com"
+//     matches subdomains
+//
+// The script sees input (the node input), env (workflow variables overridden by
+// execution variables, read-only) and console.log/info/warn/error.
+//
+// Output:
+//   - result: Return value of the script, converted through JSON
+//   - logs: Console output lines
+//   - duration_ms: Execution duration
+func (e *ScriptJSExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	startTime := time.Now()
+
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+	program, err := compileScriptJS(e.GetStringDefault(config, "script", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := time.Duration(e.GetIntDefault(config, "timeout_ms", scriptJSDefaultTimeoutMs)) * time.Millisecond
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	vm := goja.New()
+	vm.SetMaxCallStackSize(scriptJSMaxCallStackSize)
+
+	logs := []any{}
+	if err := vm.Set("console", scriptJSConsole(vm, &logs)); err != nil {
+		return nil, fmt.Errorf("failed to set up console: %w", err)
+	}
+	if e.GetBoolDefault(config, "allow_network", false) {
+		hosts := e.toStrings(config["allowed_hosts"])
+		if err := vm.Set("fetch", e.scriptJSFetch(runCtx, vm, hosts)); err != nil {
+			return nil, fmt.Errorf("failed to set up fetch: %w", err)
+		}
+	}
+
+	inputValue, err := scriptJSValue(vm, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pass input to script: %w", err)
+	}
+	envValue, err := scriptJSValue(vm, scriptEnv(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to pass env to script: %w", err)
+	}
+	if obj := envValue.ToObject(vm); obj != nil {
+		freeze, _ := goja.AssertFunction(vm.Get("Object").ToObject(vm).Get("freeze"))
+		_, _ = freeze(goja.Undefined(), obj)
+	}
+
+	maxMemoryMB := e.GetIntDefault(config, "max_memory_mb", scriptJSDefaultMemoryMB)
+	stop := watchScriptJS(runCtx, vm, maxMemoryMB)
+	value, err := runScriptJS(vm, program, inputValue, envValue)
+	var result any
+	if err == nil {
+		result, err = scriptJSExport(vm, value)
+	}
+	stop()
+	if err != nil {
+		return nil, scriptJSError(ctx, err, timeout, maxMemoryMB)
+	}
+
+	return map[string]any{
+		"result":      result,
+		"logs":        logs,
+		"duration_ms": time.Since(startTime).Milliseconds(),
+	}, nil
+}
+
+// Validate validates the script_js executor configuration.
+func (e *ScriptJSExecutor) Validate(config map[string]any) error {
+	if err := e.ValidateRequired(config, "script"); err != nil {
+		return err
+	}
+	script, ok := config["script"].(string)
+	if !ok || strings.TrimSpace(script) == "" {
+		return fmt.Errorf("script must be a non-empty string")
+	}
+	if _, err := compileScriptJS(script); err != nil {
+		return err
+	}
+
+	if timeout := e.GetIntDefault(config, "timeout_ms", scriptJSDefaultTimeoutMs); timeout < 1 || timeout > scriptJSMaxTimeoutMs {
+		return fmt.Errorf("timeout_ms must be between 1 and %d", scriptJSMaxTimeoutMs)
+	}
+	if memory := e.GetIntDefault(config, "max_memory_mb", scriptJSDefaultMemoryMB); memory < 1 || memory > scriptJSMaxMemoryMB {
+		return fmt.Errorf("max_memory_mb must be between 1 and %d", scriptJSMaxMemoryMB)
+	}
+
+	raw, hasHosts := config["allowed_hosts"]
+	if hasHosts && raw != nil {
+		hosts, ok := raw.([]any)
+		if !ok {
+			return fmt.Errorf("allowed_hosts must be an array")
+		}
+		for i, host := range hosts {
+			if s, ok := host.(string); !ok || s == "" || strings.Contains(s, "/") {
+				return fmt.Errorf("allowed_hosts[%d] must be a host name", i)
+			}
+		}
+	}
+	if e.GetBoolDefault(config, "allow_network", false) {
+		if hosts, _ := raw.([]any); len(hosts) == 0 {
+			return fmt.Errorf("allow_network requires allowed_hosts")
+		}
+	} else if hosts, _ := raw.([]any); len(hosts) > 0 {
+		return fmt.Errorf("allowed_hosts requires allow_network")
+	}
+
+	return nil
+}
+
+func (e *ScriptJSExecutor) toStrings(raw any) []string {
+	items, _ := raw.([]any)
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			result = append(result, strings.ToLower(s))
+		}
+	}
+	return result
+}
+
+// compileScriptJS compiles a script as the body of a function of input and env.
+// The opening line is shared with the first script line so error positions match the script.
+func compileScriptJS(script string) (*goja.Program, error) {
+	program, err := goja.Compile("script.js", "(function(input, env) {"+script+"\n})", true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile script: %w", err)
+	}
+	return program, nil
+}
+
+// runScriptJS calls the compiled function and settles a returned promise.
+func runScriptJS(vm *goja.Runtime, program *goja.Program, input, env goja.Value) (goja.Value, error) {
+	fnValue, err := vm.RunProgram(program)
+	if err != nil {
+		return nil, err
+	}
+	fn, ok := goja.AssertFunction(fnValue)
+	if !ok {
+		return nil, fmt.Errorf("script did not compile to a function")
+	}
+	value, err := fn(goja.Undefined(), input, env)
+	if err != nil {
+		return nil, err
+	}
+
+	if promise, ok := value.Export().(*goja.Promise); ok {
+		switch promise.State() {
+		case goja.PromiseStateFulfilled:
+			return promise.Result(), nil
+		case goja.PromiseStateRejected:
+			return nil, fmt.Errorf("script promise rejected: %s", promise.Result().String())
+		default:
+			return nil, fmt.Errorf("script returned a promise that never settles")
+		}
+	}
+	return value, nil
+}
+
+// watchScriptJS interrupts the runtime when ctx ends or the live heap grows by more than
+// maxMemoryMB. The live heap is measured for the whole process at each garbage collection,
+// so it includes concurrent work; the limit stops runaway scripts rather than accounting
+// memory exactly.
+// The returned function stops watching.
+func watchScriptJS(ctx context.Context, vm *goja.Runtime, maxMemoryMB int) func() {
+	done := make(chan struct{})
+	limit := uint64(maxMemoryMB) << 20
+	sample := []metrics.Sample{{Name: scriptJSLiveHeapMetric}}
+	metrics.Read(sample)
+	baseline := sample[0].Value.Uint64()
+
+	go func() {
+		ticker := time.NewTicker(scriptJSMemorySampleEvery)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				vm.Interrupt(errScriptTimeout)
+				return
+			case <-ticker.C:
+				metrics.Read(sample)
+				if current := sample[0].Value.Uint64(); current > baseline && current-baseline > limit {
+					vm.Interrupt(errScriptMemory)
+					return
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		vm.ClearInterrupt()
+	}
+}
+
+// scriptJSError describes a script failure.
+func scriptJSError(ctx context.Context, err error, timeout time.Duration, maxMemoryMB int) error {
+	var interrupted *goja.InterruptedError
+	if errors.As(err, &interrupted) {
+		switch interrupted.Value() {
+		case errScriptMemory:
+			return fmt.Errorf("script exceeded the memory limit of %d MB", maxMemoryMB)
+		default:
+			if ctx.Err() != nil {
+				return fmt.Errorf("script cancelled: %w", ctx.Err())
+			}
+			return fmt.Errorf("script exceeded the time limit of %s", timeout)
+		}
+	}
+
+	var exception *goja.Exception
+	if errors.As(err, &exception) {
+		return fmt.Errorf("script error: %s", exception.Error())
+	}
+	var stackOverflow *goja.StackOverflowError
+	if errors.As(err, &stackOverflow) {
+		return fmt.Errorf("script exceeded the call stack limit")
+	}
+	return fmt.Errorf("script error: %w", err)
+}
+
+// scriptJSValue converts a Go value into plain JavaScript values through JSON.
+func scriptJSValue(vm *goja.Runtime, value any) (goja.Value, error) {
+	if value == nil {
+		return goja.Null(), nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	parse, _ := goja.AssertFunction(vm.Get("JSON").ToObject(vm).Get("parse"))
+	return parse(goja.Undefined(), vm.ToValue(string(data)))
+}
+
+// scriptJSExport converts the script result into JSON values.
+func scriptJSExport(vm *goja.Runtime, value goja.Value) (any, error) {
+	if value == nil || goja.IsUndefined(value) || goja.IsNull(value) {
+		return nil, nil
+	}
+	stringify, _ := goja.AssertFunction(vm.Get("JSON").ToObject(vm).Get("stringify"))
+	encoded, err := stringify(goja.Undefined(), value)
+	if err != nil {
+		var exception *goja.Exception
+		if errors.As(err, &exception) {
+			return nil, fmt.Errorf("script result is not JSON serializable: %s", exception.Value().String())
+		}
+		return nil, err
+	}
+	if goja.IsUndefined(encoded) {
+		return nil, nil
+	}
+	data := encoded.String()
+	if len(data) > scriptJSMaxOutputBytes {
+		return nil, fmt.Errorf("script result exceeds %d bytes", scriptJSMaxOutputBytes)
+	}
+
+	var result any
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		return nil, fmt.Errorf("failed to decode script result: %w", err)
+	}
+	return result, nil
+}
+
+// scriptEnv returns the workflow variables overridden by the execution variables.
+func scriptEnv(ctx context.Context) map[string]any {
+	env := map[string]any{}
+	execCtx, ok := executor.GetExecutionContext(ctx)
+	if !ok {
+		return env
+	}
+	for k, v := range execCtx.WorkflowVariables {
+		env[k] = v
+	}
+	for k, v := range execCtx.ExecutionVariables {
+		env[k] = v
+	}
+	return env
+}
+
+// scriptJSConsole builds a console object appending its output to logs.
+func scriptJSConsole(vm *goja.Runtime, logs *[]any) *goja.Object {
+	console := vm.NewObject()
+	for _, level := range []string{"log", "info", "warn", "error", "debug"} {
+		prefix := ""
+		if level != "log" && level != "info" {
+			prefix = "[" + level + "] "
+		}
+		_ = console.Set(level, func(call goja.FunctionCall) goja.Value {
+			if len(*logs) >= scriptJSMaxLogs {
+				return goja.Undefined()
+			}
+			parts := make([]string, len(call.Arguments))
+			for i, arg := range call.Arguments {
+				parts[i] = scriptJSLogString(vm, arg)
+			}
+			line := prefix + strings.Join(parts, " ")
+			if len(line) > scriptJSMaxLogLength {
+				line = line[:scriptJSMaxLogLength] + "..."
+			}
+			*logs = append(*logs, line)
+			return goja.Undefined()
+		})
+	}
+	return console
+}
+
+// scriptJSLogString formats a console argument: strings as-is, objects as JSON.
+func scriptJSLogString(vm *goja.Runtime, value goja.Value) string {
+	if _, ok := value.Export().(string); ok {
+		return value.String()
+	}
+	if obj, ok := value.(*goja.Object); ok && obj.ClassName() != "Function" && obj.ClassName() != "Error" {
+		stringify, _ := goja.AssertFunction(vm.Get("JSON").ToObject(vm).Get("stringify"))
+		if encoded, err := stringify(goja.Undefined(), value); err == nil && !goja.IsUndefined(encoded) {
+			return encoded.String()
+		}
+	}
+	return value.String()
+}
+
+// scriptJSFetch builds a synchronous fetch limited to the allowed hosts.
+// It returns {status, ok, headers, body, json()} and throws on network errors.
+func (e *ScriptJSExecutor) scriptJSFetch(ctx context.Context, vm *goja.Runtime, allowedHosts []string) func(goja.FunctionCall) goja.Value {
+	client := &http.Client{
+		Transport: e.client.Transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 5 {
+				return fmt.Errorf("too many redirects")
+			}
+			if !scriptJSHostAllowed(req.URL, allowedHosts) {
+				return fmt.Errorf("redirect to host %s is not allowed", req.URL.Hostname())
+			}
+			return nil
+		},
+	}
+
+	return func(call goja.FunctionCall) goja.Value {
+		rawURL := call.Argument(0).String()
+		target, err := url.Parse(rawURL)
+		if err != nil || (target.Scheme != "http" && target.Scheme != "https") {
+			panic(vm.NewTypeError("fetch: invalid URL %q", rawURL))
+		}
+		if !scriptJSHostAllowed(target, allowedHosts) {
+			panic(vm.NewTypeError("fetch: host %s is not allowed", target.Hostname()))
+		}
+
+		method := http.MethodGet
+		headers := http.Header{}
+		var body io.Reader
+		if opts, ok := call.Argument(1).Export().(map[string]any); ok {
+			if m, ok := opts["method"].(string); ok && m != "" {
+				method = strings.ToUpper(m)
+			}
+			if h, ok := opts["headers"].(map[string]any); ok {
+				for key, value := range h {
+					headers.Set(key, fmt.Sprint(value))
+				}
+			}
+			switch b := opts["body"].(type) {
+			case nil:
+			case string:
+				body = strings.NewReader(b)
+			default:
+				data, err := json.Marshal(b)
+				if err != nil {
+					panic(vm.NewTypeError("fetch: body is not JSON serializable"))
+				}
+				body = bytes.NewReader(data)
+				if headers.Get("Content-Type") == "" {
+					headers.Set("Content-Type", "application/json")
+				}
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, target.String(), body)
+		if err != nil {
+			panic(vm.NewGoError(fmt.Errorf("fetch: %w", err)))
+		}
+		req.Header = headers
+		executor.InjectHeaders(ctx, req.Header)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			panic(vm.NewGoError(fmt.Errorf("fetch: %w", err)))
+		}
+		defer resp.Body.Close()
+		data, err := io.ReadAll(io.LimitReader(resp.Body, scriptJSMaxFetchBodyBytes+1))
+		if err != nil {
+			panic(vm.NewGoError(fmt.Errorf("fetch: failed to read response: %w", err)))
+		}
+		if len(data) > scriptJSMaxFetchBodyBytes {
+			panic(vm.NewGoError(fmt.Errorf("fetch: response exceeds %d bytes", scriptJSMaxFetchBodyBytes)))
+		}
+
+		respHeaders := vm.NewObject()
+		for key := range resp.Header {
+			_ = respHeaders.Set(strings.ToLower(key), resp.Header.Get(key))
+		}
+		text := string(data)
+		result := vm.NewObject()
+		_ = result.Set("status", resp.StatusCode)
+		_ = result.Set("ok", resp.StatusCode >= 200 && resp.StatusCode < 300)
+		_ = result.Set("headers", respHeaders)
+		_ = result.Set("body", text)
+		_ = result.Set("text", func(goja.FunctionCall) goja.Value { return vm.ToValue(text) })
+		_ = result.Set("json", func(goja.FunctionCall) goja.Value {
+			parse, _ := goja.AssertFunction(vm.Get("JSON").ToObject(vm).Get("parse"))
+			value, err := parse(goja.Undefined(), vm.ToValue(text))
+			if err != nil {
+				panic(err)
+			}
+			return value
+		})
+		return result
+	}
+}
+
+// scriptJSHostAllowed reports whether the URL host is in the allow list;
+// "*.example.com" matches subdomains of example.com.
+func scriptJSHostAllowed(target *url.URL, allowedHosts []string) bool {
+	host := strings.ToLower(target.Hostname())
+	for _, allowed := range allowedHosts {
+		if suffix, ok := strings.CutPrefix(allowed, "*."); ok {
+			if strings.HasSuffix(host, "."+suffix) {
+				return true
+			}
+			continue
+		}
+		if host == allowed {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,186 @@
+package builtin
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScriptJSExecutor_Validate(t *testing.T) {
+	exec := NewScriptJSExecutor()
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{"valid", map[string]any{"script": "return input.a + 1"}, ""},
+		{"valid network", map[string]any{"script": "return 1", "allow_network": true, "allowed_hosts": []any{"api.example.com", "*.example.org"}}, ""},
+		{"missing script", map[string]any{}, "script"},
+		{"blank script", map[string]any{"script": "  "}, "non-empty"},
+		{"syntax error", map[string]any{"script": "return {"}, "failed to compile script"},
+		{"zero timeout", map[string]any{"script": "return 1", "timeout_ms": 0}, "timeout_ms"},
+		{"huge memory", map[string]any{"script": "return 1", "max_memory_mb": 4096}, "max_memory_mb"},
+		{"network without hosts", map[string]any{"script": "return 1", "allow_network": true}, "requires allowed_hosts"},
+		{"hosts without network", map[string]any{"script": "return 1", "allowed_hosts": []any{"api.example.com"}}, "requires allow_network"},
+		{"host with path", map[string]any{"script": "return 1", "allow_network": true, "allowed_hosts": []any{"example.com/api"}}, "allowed_hosts[0]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exec.Validate(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestScriptJSExecutor_InputEnvAndLogs(t *testing.T) {
+	ctx := executor.WithExecutionContext(context.Background(), &executor.ExecutionContextData{
+		WorkflowVariables:  map[string]any{"currency": "USD", "rate": 1},
+		ExecutionVariables: map[string]any{"rate": 0.9},
+	})
+
+	exec := NewScriptJSExecutor()
+	result, err := exec.Execute(ctx, map[string]any{
+		"script": `
+			const total = input.items.reduce((sum, item) => sum + item.price * item.qty, 0);
+			console.log("items:", input.items.length, {total});
+			console.warn("rounded");
+			let frozen = false;
+			try { env.rate = 100 } catch (e) { frozen = e instanceof TypeError }
+			return { total: Math.round(total * env.rate * 100) / 100, currency: env.currency, frozen, tags: [...new Set(input.tags)] };
+		`,
+	}, map[string]any{
+		"items": []any{map[string]any{"price": 10.5, "qty": 2}, map[string]any{"price": 4, "qty": 1}},
+		"tags":  []any{"a", "b", "a"},
+	})
+	require.NoError(t, err)
+
+	output := result.(map[string]any)
+	assert.Equal(t, map[string]any{"total": 22.5, "currency": "USD", "frozen": true, "tags": []any{"a", "b"}}, output["result"])
+	assert.Equal(t, []any{`items: 2 {"total":25}`, "[warn] rounded"}, output["logs"])
+}
+
+func TestScriptJSExecutor_Results(t *testing.T) {
+	exec := NewScriptJSExecutor()
+	run := func(script string, input any) (any, error) {
+		result, err := exec.Execute(context.Background(), map[string]any{"script": script}, input)
+		if err != nil {
+			return nil, err
+		}
+		return result.(map[string]any)["result"], nil
+	}
+
+	value, err := run("return input", "text")
+	require.NoError(t, err)
+	assert.Equal(t, "text", value)
+
+	value, err = run("const x = 1", nil)
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	value, err = run("return Promise.resolve(21).then(v => v * 2)", nil)
+	require.NoError(t, err)
+	assert.Equal(t, float64(42), value)
+
+	value, err = run("return new Date(0)", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "1970-01-01T00:00:00.000Z", value)
+
+	_, err = run("return Promise.reject(new Error('nope'))", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nope")
+
+	_, err = run("throw new Error('bad input')", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "script error: Error: bad input")
+
+	_, err = run("const o = {}; o.self = o; return o", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not JSON serializable")
+
+	_, err = run("const f = n => f(n + 1); return f(0)", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "call stack")
+}
+
+func TestScriptJSExecutor_NoHostAccess(t *testing.T) {
+	exec := NewScriptJSExecutor()
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"script": `return [typeof fetch, typeof require, typeof process, typeof XMLHttpRequest, typeof setTimeout]`,
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []any{"undefined", "undefined", "undefined", "undefined", "undefined"}, result.(map[string]any)["result"])
+}
+
+func TestScriptJSExecutor_TimeLimit(t *testing.T) {
+	exec := NewScriptJSExecutor()
+	start := time.Now()
+	_, err := exec.Execute(context.Background(), map[string]any{"script": "while (true) {}", "timeout_ms": 100}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "time limit of 100ms")
+	assert.Less(t, time.Since(start), 2*time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	_, err = exec.Execute(ctx, map[string]any{"script": "for (;;) {}"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "script cancelled")
+}
+
+func TestScriptJSExecutor_MemoryLimit(t *testing.T) {
+	exec := NewScriptJSExecutor()
+	_, err := exec.Execute(context.Background(), map[string]any{
+		"script":        "const keep = []; for (;;) { keep.push('x'.repeat(1024) + keep.length) }",
+		"max_memory_mb": 16,
+		"timeout_ms":    60000,
+	}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "memory limit of 16 MB")
+}
+
+func TestScriptJSExecutor_Fetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"method":"`+r.Method+`","type":"`+r.Header.Get("Content-Type")+`","body":`+string(body)+`}`)
+	}))
+	t.Cleanup(server.Close)
+
+	exec := NewScriptJSExecutor()
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"script": `
+			const resp = fetch(input.url, { method: "post", body: { id: 7 } });
+			return { status: resp.status, ok: resp.ok, type: resp.headers["content-type"], data: resp.json() };
+		`,
+		"allow_network": true,
+		"allowed_hosts": []any{"127.0.0.1"},
+	}, map[string]any{"url": server.URL})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"status": float64(200),
+		"ok":     true,
+		"type":   "application/json",
+		"data":   map[string]any{"method": "POST", "type": "application/json", "body": map[string]any{"id": float64(7)}},
+	}, result.(map[string]any)["result"])
+
+	_, err = exec.Execute(context.Background(), map[string]any{
+		"script":        `return fetch("https://example.com/")`,
+		"allow_network": true,
+		"allowed_hosts": []any{"127.0.0.1"},
+	}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "host example.com is not allowed")
+}
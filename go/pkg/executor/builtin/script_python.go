@@ -0,0 +1,382 @@
+package builtin
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+)
+
+// Runtimes of the script_python executor.
+const (
+	ScriptPythonRuntimeDocker  = "docker"
+	ScriptPythonRuntimeProcess = "process"
+)
+
+// Defaults and limits of the script_python executor.
+const (
+	scriptPythonDefaultImage       = "python:3.12-slim"
+	scriptPythonDefaultTimeoutMs   = 30000
+	scriptPythonMaxTimeoutMs       = 600000
+	scriptPythonDefaultMemoryMB    = 256
+	scriptPythonMinMemoryMB        = 16
+	scriptPythonMaxMemoryMB        = 16384
+	scriptPythonDefaultCPUs        = 1.0
+	scriptPythonMaxCPUs            = 16.0
+	scriptPythonPidsLimit          = 64
+	scriptPythonMaxOutputBytes     = 16 << 20
+	scriptPythonMaxStderrBytes     = 64 << 10
+	scriptPythonMaxLogs            = 100
+	scriptPythonWaitDelay          = 2 * time.Second
+	scriptPythonDockerStopDeadline = 10 * time.Second
+)
+
+// scriptPythonBootstrap runs inside the interpreter. It reads a header line with the
+// script and the resource limits from stdin, applies the limits, and runs the script
+// with the rest of stdin as its standard input.
+const scriptPythonBootstrap = `import io, json, sys
+header = json.loads(sys.stdin.buffer.readline())
+try:
+    import resource
+except ImportError:
+    resource = None
+if resource is not None:
+    for name, value in header.get("limits", {}).items():
+        limit = getattr(resource, name)
+        _, hard = resource.getrlimit(limit)
+        if hard != resource.RLIM_INFINITY:
+            value = min(value, hard)
+        resource.setrlimit(limit, (value, value))
+sys.stdin = io.TextIOWrapper(io.BytesIO(sys.stdin.buffer.read()), encoding="utf-8")
+sys.argv = ["script.py"]
+code = compile(header["script"], "script.py", "exec")
+del header
+exec(code, {"__name__": "__main__", "__file__": "script.py", "__builtins__": __builtins__})
+`
+
+// ScriptPythonOptions configures how the script_python executor runs scripts.
+type ScriptPythonOptions struct {
+	// Runtime is "docker" (default) or "process". The docker runtime runs every script
+	// in a new container without network access; the process runtime runs scripts as
+	// local subprocesses and only limits memory and CPU time.
+	Runtime string
+	// Interpreter is the Python executable of the process runtime (default: "python3").
+	Interpreter string
+	// DockerBinary is the docker CLI of the docker runtime (default: "docker").
+	DockerBinary string
+	// Image is the interpreter image of the docker runtime (default: "python:3.12-slim").
+	Image string
+	// AllowedImages are further images nodes may select with the image setting.
+	AllowedImages []string
+}
+
+// ScriptPythonExecutor runs Python scripts in an isolated subprocess or container.
+// The script reads {"input": ..., "env": ...} as JSON from stdin and writes its result
+// as JSON to stdout; stderr is returned as logs.
+type ScriptPythonExecutor struct {
+	*executor.BaseExecutor
+	opts ScriptPythonOptions
+}
+
+// NewScriptPythonExecutor creates a new script_python executor.
+func NewScriptPythonExecutor(opts ScriptPythonOptions) *ScriptPythonExecutor {
+	if opts.Runtime == "" {
+		opts.Runtime = ScriptPythonRuntimeDocker
+	}
+	if opts.Interpreter == "" {
+		opts.Interpreter = "python3"
+	}
+	if opts.DockerBinary == "" {
+		opts.DockerBinary = "docker"
+	}
+	if opts.Image == "" {
+		opts.Image = scriptPythonDefaultImage
+	}
+	return &ScriptPythonExecutor{
+		BaseExecutor: executor.NewBaseExecutor("script_python"),
+		opts:         opts,
+	}
+}
+
+// Execute runs the script.
+//
+// Config:
+//   - script: Python source reading JSON from stdin and writing JSON to stdout (required)
+//   - timeout_ms: Wall-clock time limit in milliseconds (default: 30000, max: 600000)
+//   - max_memory_mb: Memory limit in MB (default: 256, min: 16, max: 16384)
+//   - image: Interpreter image, the default image or one of the allowed images (docker runtime)
+//   - cpus: CPU quota (docker runtime, default: 1, max: 16)
+//   - allow_network: Give the container network access (docker runtime, default: false)
+//
+// Output:
+//   - result: JSON document the script wrote to stdout, null when it wrote nothing
+//   - logs: Lines the script wrote to stderr
+//   - runtime: "docker" or "process"
+//   - image: Interpreter image (docker runtime)
+//   - duration_ms: Execution duration
+func (e *ScriptPythonExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	startTime := time.Now()
+
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+
+	timeout := time.Duration(e.GetIntDefault(config, "timeout_ms", scriptPythonDefaultTimeoutMs)) * time.Millisecond
+	maxMemoryMB := e.GetIntDefault(config, "max_memory_mb", scriptPythonDefaultMemoryMB)
+
+	limits := map[string]int64{}
+	if e.opts.Runtime == ScriptPythonRuntimeProcess {
+		limits["RLIMIT_AS"] = int64(maxMemoryMB) << 20
+		limits["RLIMIT_CPU"] = int64(timeout/time.Second) + 1
+	}
+	header, err := json.Marshal(map[string]any{"script": config["script"], "limits": limits})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode script: %w", err)
+	}
+	payload, err := json.Marshal(map[string]any{"input": input, "env": scriptEnv(ctx)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode script input: %w", err)
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	image := ""
+	switch e.opts.Runtime {
+	case ScriptPythonRuntimeDocker:
+		image = e.GetStringDefault(config, "image", e.opts.Image)
+		cmd = e.dockerCommand(runCtx, config, image, maxMemoryMB)
+	default:
+		workDir, err := os.MkdirTemp("", "mbflow-script-*")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create script directory: %w", err)
+		}
+		defer os.RemoveAll(workDir)
+		cmd = e.processCommand(runCtx, workDir)
+	}
+
+	stdout := &cappedBuffer{limit: scriptPythonMaxOutputBytes}
+	stderr := &cappedBuffer{limit: scriptPythonMaxStderrBytes, keepTail: true}
+	cmd.Stdin = bytes.NewReader(append(append(header, '\n'), payload...))
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.WaitDelay = scriptPythonWaitDelay
+
+	runErr := cmd.Run()
+	logs := scriptPythonLogs(stderr.String())
+
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("script cancelled: %w", ctx.Err())
+	}
+	if runCtx.Err() != nil {
+		return nil, fmt.Errorf("script exceeded the time limit of %s", timeout)
+	}
+	if runErr != nil {
+		return nil, e.scriptError(runErr, logs, maxMemoryMB)
+	}
+	if stdout.truncated {
+		return nil, fmt.Errorf("script output exceeds %d bytes", scriptPythonMaxOutputBytes)
+	}
+
+	var result any
+	if out := bytes.TrimSpace(stdout.Bytes()); len(out) > 0 {
+		if err := executor.UnmarshalJSON(ctx, out, &result); err != nil {
+			return nil, fmt.Errorf("script output is not valid JSON: %w", err)
+		}
+	}
+
+	output := map[string]any{
+		"result":      result,
+		"logs":        logs,
+		"runtime":     e.opts.Runtime,
+		"duration_ms": time.Since(startTime).Milliseconds(),
+	}
+	if image != "" {
+		output["image"] = image
+	}
+	return output, nil
+}
+
+// Validate validates the script_python executor configuration.
+func (e *ScriptPythonExecutor) Validate(config map[string]any) error {
+	if err := e.ValidateRequired(config, "script"); err != nil {
+		return err
+	}
+	if script, ok := config["script"].(string); !ok || strings.TrimSpace(script) == "" {
+		return fmt.Errorf("script must be a non-empty string")
+	}
+
+	if timeout := e.GetIntDefault(config, "timeout_ms", scriptPythonDefaultTimeoutMs); timeout < 1 || timeout > scriptPythonMaxTimeoutMs {
+		return fmt.Errorf("timeout_ms must be between 1 and %d", scriptPythonMaxTimeoutMs)
+	}
+	if memory := e.GetIntDefault(config, "max_memory_mb", scriptPythonDefaultMemoryMB); memory < scriptPythonMinMemoryMB || memory > scriptPythonMaxMemoryMB {
+		return fmt.Errorf("max_memory_mb must be between %d and %d", scriptPythonMinMemoryMB, scriptPythonMaxMemoryMB)
+	}
+
+	if e.opts.Runtime != ScriptPythonRuntimeDocker {
+		for _, key := range []string{"image", "cpus", "allow_network"} {
+			if _, ok := config[key]; ok {
+				return fmt.Errorf("%s requires the docker runtime", key)
+			}
+		}
+		return nil
+	}
+
+	if raw, ok := config["image"]; ok {
+		image, ok := raw.(string)
+		if !ok || image == "" {
+			return fmt.Errorf("image must be a non-empty string")
+		}
+		if image != e.opts.Image && !slices.Contains(e.opts.AllowedImages, image) {
+			return fmt.Errorf("image %q is not allowed", image)
+		}
+	}
+	if raw, ok := config["cpus"]; ok {
+		cpus, ok := toFloat(raw)
+		if !ok || cpus <= 0 || cpus > scriptPythonMaxCPUs {
+			return fmt.Errorf("cpus must be a number between 0 and %g", scriptPythonMaxCPUs)
+		}
+	}
+
+	return nil
+}
+
+// processCommand runs the bootstrap with the local interpreter in isolated mode, inside
+// workDir and without the server environment.
+func (e *ScriptPythonExecutor) processCommand(ctx context.Context, workDir string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, e.opts.Interpreter, "-I", "-c", scriptPythonBootstrap)
+	cmd.Dir = workDir
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + workDir,
+		"TMPDIR=" + workDir,
+		"LANG=C.UTF-8",
+	}
+	setScriptProcessGroup(cmd)
+	return cmd
+}
+
+// dockerCommand runs the bootstrap in a new container. The container is removed when
+// the script ends and killed when the context ends.
+func (e *ScriptPythonExecutor) dockerCommand(ctx context.Context, config map[string]any, image string, maxMemoryMB int) *exec.Cmd {
+	name := "mbflow-script-" + randomHex(8)
+	cpus := scriptPythonDefaultCPUs
+	if raw, ok := config["cpus"]; ok {
+		cpus, _ = toFloat(raw)
+	}
+	network := "none"
+	if e.GetBoolDefault(config, "allow_network", false) {
+		network = "bridge"
+	}
+	memory := strconv.Itoa(maxMemoryMB) + "m"
+
+	cmd := exec.CommandContext(ctx, e.opts.DockerBinary,
+		"run", "--rm", "-i",
+		"--name", name,
+		"--network", network,
+		"--memory", memory,
+		"--memory-swap", memory,
+		"--cpus", strconv.FormatFloat(cpus, 'f', -1, 64),
+		"--pids-limit", strconv.Itoa(scriptPythonPidsLimit),
+		"--read-only",
+		"--tmpfs", "/tmp:rw,size=64m",
+		"--cap-drop", "ALL",
+		"--security-opt", "no-new-privileges",
+		"--user", "65534:65534",
+		"--workdir", "/tmp",
+		"--env", "HOME=/tmp",
+		image,
+		"python", "-I", "-c", scriptPythonBootstrap,
+	)
+	// Killing the CLI does not stop the container, so kill the container as well
+	cmd.Cancel = func() error {
+		killCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), scriptPythonDockerStopDeadline)
+		defer cancel()
+		_ = exec.CommandContext(killCtx, e.opts.DockerBinary, "kill", name).Run()
+		return cmd.Process.Kill()
+	}
+	return cmd
+}
+
+// scriptError describes a failed script run.
+func (e *ScriptPythonExecutor) scriptError(err error, logs []any, maxMemoryMB int) error {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return fmt.Errorf("failed to start python runtime: %w", err)
+	}
+
+	lastLine := ""
+	if len(logs) > 0 {
+		lastLine, _ = logs[len(logs)-1].(string)
+	}
+	switch {
+	case strings.HasPrefix(lastLine, "MemoryError"):
+		return fmt.Errorf("script exceeded the memory limit of %d MB", maxMemoryMB)
+	case e.opts.Runtime == ScriptPythonRuntimeDocker && exitErr.ExitCode() == 137:
+		return fmt.Errorf("script was killed, likely for exceeding the memory limit of %d MB", maxMemoryMB)
+	case exitErr.ExitCode() < 0:
+		return fmt.Errorf("script was terminated: %s", exitErr.Error())
+	case lastLine != "":
+		return fmt.Errorf("script exited with code %d: %s", exitErr.ExitCode(), lastLine)
+	default:
+		return fmt.Errorf("script exited with code %d", exitErr.ExitCode())
+	}
+}
+
+// scriptPythonLogs splits stderr into lines, keeping the last scriptPythonMaxLogs lines.
+func scriptPythonLogs(stderr string) []any {
+	logs := []any{}
+	for _, line := range strings.Split(strings.TrimRight(stderr, "\n"), "\n") {
+		if line = strings.TrimRight(line, "\r"); line != "" {
+			logs = append(logs, line)
+		}
+	}
+	if len(logs) > scriptPythonMaxLogs {
+		logs = logs[len(logs)-scriptPythonMaxLogs:]
+	}
+	return logs
+}
+
+// cappedBuffer collects up to limit bytes, keeping either the head or the tail.
+type cappedBuffer struct {
+	bytes.Buffer
+	limit     int
+	keepTail  bool
+	truncated bool
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if b.keepTail {
+		b.Buffer.Write(p)
+		if over := b.Len() - b.limit; over > 0 {
+			b.Next(over)
+			b.truncated = true
+		}
+		return n, nil
+	}
+	if room := b.limit - b.Len(); room < len(p) {
+		p = p[:max(room, 0)]
+		b.truncated = true
+	}
+	b.Buffer.Write(p)
+	return n, nil
+}
+
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
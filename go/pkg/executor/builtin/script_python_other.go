@@ -0,0 +1,9 @@
+//go:build !unix
+
+package builtin
+
+import "os/exec"
+
+// setScriptProcessGroup is a no-op on platforms without process groups; the script
+// process itself is killed on cancellation.
+func setScriptProcessGroup(cmd *exec.Cmd) {}
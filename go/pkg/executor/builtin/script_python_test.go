@@ -0,0 +1,182 @@
+package builtin
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPythonInterpreter returns the absolute path of a local Python 3 interpreter,
+// skipping the test when there is none.
+func testPythonInterpreter(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("script_python tests need a unix shell")
+	}
+	path, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 is not installed")
+	}
+	// Resolve shims (pyenv, asdf) that need the user environment
+	out, err := exec.Command(path, "-c", "import sys; print(sys.executable)").Output()
+	if err != nil {
+		t.Skipf("python3 is not usable: %v", err)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func newTestScriptPythonExecutor(t *testing.T) *ScriptPythonExecutor {
+	return NewScriptPythonExecutor(ScriptPythonOptions{
+		Runtime:     ScriptPythonRuntimeProcess,
+		Interpreter: testPythonInterpreter(t),
+	})
+}
+
+func TestScriptPythonExecutor_Validate(t *testing.T) {
+	process := NewScriptPythonExecutor(ScriptPythonOptions{Runtime: ScriptPythonRuntimeProcess})
+	docker := NewScriptPythonExecutor(ScriptPythonOptions{AllowedImages: []string{"ghcr.io/acme/pandas:2"}})
+
+	tests := []struct {
+		name    string
+		exec    *ScriptPythonExecutor
+		config  map[string]any
+		wantErr string
+	}{
+		{"valid", process, map[string]any{"script": "print(1)"}, ""},
+		{"missing script", process, map[string]any{}, "script"},
+		{"blank script", process, map[string]any{"script": " "}, "non-empty"},
+		{"timeout too long", process, map[string]any{"script": "print(1)", "timeout_ms": 600001}, "timeout_ms"},
+		{"memory too small", process, map[string]any{"script": "print(1)", "max_memory_mb": 8}, "max_memory_mb"},
+		{"image in process runtime", process, map[string]any{"script": "print(1)", "image": "python:3.12-slim"}, "image requires the docker runtime"},
+		{"network in process runtime", process, map[string]any{"script": "print(1)", "allow_network": true}, "allow_network requires the docker runtime"},
+		{"default image", docker, map[string]any{"script": "print(1)", "image": "python:3.12-slim"}, ""},
+		{"allowed image", docker, map[string]any{"script": "print(1)", "image": "ghcr.io/acme/pandas:2", "cpus": 0.5}, ""},
+		{"unknown image", docker, map[string]any{"script": "print(1)", "image": "evil/image"}, `image "evil/image" is not allowed`},
+		{"too many cpus", docker, map[string]any{"script": "print(1)", "cpus": 32}, "cpus"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.exec.Validate(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestScriptPythonExecutor_Process(t *testing.T) {
+	exec := newTestScriptPythonExecutor(t)
+	t.Setenv("MBFLOW_TEST_SECRET", "do-not-leak")
+
+	ctx := executor.WithExecutionContext(context.Background(), &executor.ExecutionContextData{
+		WorkflowVariables:  map[string]any{"factor": 2, "unit": "kg"},
+		ExecutionVariables: map[string]any{"factor": 3},
+	})
+	result, err := exec.Execute(ctx, map[string]any{
+		"script": `
+import json, os, sys
+data = json.load(sys.stdin)
+print("processing", len(data["input"]["values"]), "values", file=sys.stderr)
+values = [v * data["env"]["factor"] for v in data["input"]["values"]]
+json.dump({"values": values, "unit": data["env"]["unit"], "secret": os.environ.get("MBFLOW_TEST_SECRET")}, sys.stdout)
+`,
+	}, map[string]any{"values": []any{1, 2.5}})
+	require.NoError(t, err)
+
+	output := result.(map[string]any)
+	assert.Equal(t, map[string]any{"values": []any{float64(3), 7.5}, "unit": "kg", "secret": nil}, output["result"])
+	assert.Equal(t, []any{"processing 2 values"}, output["logs"])
+	assert.Equal(t, "process", output["runtime"])
+	assert.NotContains(t, output, "image")
+}
+
+func TestScriptPythonExecutor_Errors(t *testing.T) {
+	exec := newTestScriptPythonExecutor(t)
+	run := func(config map[string]any) (any, error) {
+		return exec.Execute(context.Background(), config, nil)
+	}
+
+	result, err := run(map[string]any{"script": "x = 1"})
+	require.NoError(t, err)
+	assert.Nil(t, result.(map[string]any)["result"])
+
+	_, err = run(map[string]any{"script": "raise ValueError('bad row')"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "script exited with code 1: ValueError: bad row")
+
+	_, err = run(map[string]any{"script": "print('done')"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "script output is not valid JSON")
+
+	_, err = run(map[string]any{"script": "import sys; sys.exit(3)"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "script exited with code 3")
+
+	_, err = run(map[string]any{"script": "data = bytearray(512 * 1024 * 1024)", "max_memory_mb": 64})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "memory limit of 64 MB")
+
+	start := time.Now()
+	_, err = run(map[string]any{"script": "import subprocess, time\nsubprocess.Popen(['sleep', '30'])\ntime.sleep(30)", "timeout_ms": 300})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "time limit of 300ms")
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	_, err = NewScriptPythonExecutor(ScriptPythonOptions{
+		Runtime:     ScriptPythonRuntimeProcess,
+		Interpreter: filepath.Join(t.TempDir(), "missing-python"),
+	}).Execute(context.Background(), map[string]any{"script": "print(1)"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to start python runtime")
+}
+
+func TestScriptPythonExecutor_Docker(t *testing.T) {
+	interpreter := testPythonInterpreter(t)
+
+	// A docker stand-in that records its arguments and runs the bootstrap locally
+	dir := t.TempDir()
+	argsFile := filepath.Join(dir, "args")
+	fakeDocker := filepath.Join(dir, "docker")
+	script := "#!/bin/sh\n" +
+		"printf '%s\\n' \"$@\" > " + argsFile + "\n" +
+		"for last; do :; done\n" +
+		"exec " + interpreter + " -I -c \"$last\"\n"
+	require.NoError(t, os.WriteFile(fakeDocker, []byte(script), 0o755))
+
+	exec := NewScriptPythonExecutor(ScriptPythonOptions{
+		DockerBinary:  fakeDocker,
+		AllowedImages: []string{"ghcr.io/acme/pandas:2"},
+	})
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"script":        "import json, sys\njson.dump(json.load(sys.stdin)['input'], sys.stdout)",
+		"image":         "ghcr.io/acme/pandas:2",
+		"max_memory_mb": 512,
+		"cpus":          0.5,
+	}, []any{"a", "b"})
+	require.NoError(t, err)
+
+	output := result.(map[string]any)
+	assert.Equal(t, []any{"a", "b"}, output["result"])
+	assert.Equal(t, "docker", output["runtime"])
+	assert.Equal(t, "ghcr.io/acme/pandas:2", output["image"])
+
+	data, err := os.ReadFile(argsFile)
+	require.NoError(t, err)
+	args := string(data)
+	for _, want := range []string{"run\n--rm\n-i\n", "--network\nnone\n", "--memory\n512m\n", "--cpus\n0.5\n", "--read-only\n", "--cap-drop\nALL\n", "ghcr.io/acme/pandas:2\npython\n-I\n-c\n"} {
+		assert.Contains(t, args, want)
+	}
+}
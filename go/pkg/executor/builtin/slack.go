@@ -0,0 +1,428 @@
+package builtin
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/internal/application/filestorage"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+const (
+	slackOperationMessage = "message"
+	slackOperationFile    = "file"
+
+	// slackMaxFileBytes caps uploaded files (Slack accepts up to 1 GB, workflows should not).
+	slackMaxFileBytes = 50 * 1024 * 1024
+)
+
+// SlackExecutor posts messages, Block Kit layouts and files to Slack channels.
+// The bot token is never part of the node config: it is read from a credentials
+// resource referenced by ID (api_key, or custom with a bot_token field).
+type SlackExecutor struct {
+	*executor.BaseExecutor
+	credentials CredentialResolver
+	storage     filestorage.Manager
+	httpClient  *http.Client
+	baseURL     string // For testing purposes
+}
+
+// NewSlackExecutor creates a new Slack executor.
+// storage may be nil, in which case files can only be uploaded from inline content.
+func NewSlackExecutor(credentials CredentialResolver, storage filestorage.Manager) *SlackExecutor {
+	return &SlackExecutor{
+		BaseExecutor: executor.NewBaseExecutor("slack"),
+		credentials:  credentials,
+		storage:      storage,
+		httpClient: &http.Client{
+			Timeout: 60 * time.Second,
+		},
+		baseURL: "https://slack.com/api",
+	}
+}
+
+// slackFile is a file loaded for upload.
+type slackFile struct {
+	name string
+	data []byte
+}
+
+// Execute posts to Slack.
+//
+// Config:
+//   - credential_id: ID of a credentials resource holding the bot token (required); the credential
+//     must be attached to the workflow, e.g. credential_id: "{{resource.slack.id}}"
+//   - operation: "message" (default) | "file"
+//   - channel: Channel ID or name (required)
+//   - thread_ts: Reply in a thread
+//
+// Message operation:
+//   - text: Message text (required unless blocks are set; used as the notification fallback)
+//   - blocks: Block Kit blocks as an array or JSON string
+//   - reply_broadcast: Also post a thread reply to the channel (default: false)
+//   - username, icon_emoji, icon_url: Override the bot identity (requires chat:write.customize)
+//   - unfurl_links, unfurl_media: Link previews
+//   - mrkdwn: Format text as mrkdwn (default: true)
+//
+// File operation:
+//   - file_id: File from storage; storage_id (default: "default")
+//   - content: Inline text content or base64 (with content_base64: true), instead of file_id
+//   - file_name: File name (defaults to the stored name)
+//   - title: File title
+//   - initial_comment: Message posted with the file
+//
+// Output:
+//   - success: true
+//   - channel: Channel ID
+//   - ts: Message timestamp (message operation)
+//   - file_id, file_name, size: Uploaded file (file operation)
+//   - duration_ms: Request duration
+func (e *SlackExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	startTime := time.Now()
+
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+
+	token, err := e.resolveToken(ctx, e.GetStringDefault(config, "credential_id", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[string]any
+	switch e.GetStringDefault(config, "operation", slackOperationMessage) {
+	case slackOperationFile:
+		result, err = e.uploadFile(ctx, token, config)
+	default:
+		result, err = e.postMessage(ctx, token, config)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result["success"] = true
+	result["duration_ms"] = time.Since(startTime).Milliseconds()
+	return result, nil
+}
+
+// Validate validates the Slack executor configuration.
+func (e *SlackExecutor) Validate(config map[string]any) error {
+	if err := e.ValidateRequired(config, "credential_id", "channel"); err != nil {
+		return err
+	}
+
+	for _, key := range []string{"token", "bot_token"} {
+		if _, ok := config[key]; ok {
+			return fmt.Errorf("%s must not be set inline: store it in a credentials resource and reference it with credential_id", key)
+		}
+	}
+
+	switch e.GetStringDefault(config, "operation", slackOperationMessage) {
+	case slackOperationMessage:
+		if e.GetStringDefault(config, "text", "") == "" && config["blocks"] == nil {
+			return fmt.Errorf("text or blocks is required")
+		}
+		if _, err := parseSlackBlocks(config["blocks"]); err != nil {
+			return err
+		}
+	case slackOperationFile:
+		fileID := e.GetStringDefault(config, "file_id", "")
+		_, hasContent := config["content"]
+		if fileID == "" && !hasContent {
+			return fmt.Errorf("file_id or content is required")
+		}
+		if fileID != "" && e.storage == nil {
+			return fmt.Errorf("file_id requires file storage")
+		}
+		if fileID == "" && e.GetStringDefault(config, "file_name", "") == "" {
+			return fmt.Errorf("file_name is required with content")
+		}
+	default:
+		return fmt.Errorf("invalid operation: %s (valid: message, file)",
+			e.GetStringDefault(config, "operation", ""))
+	}
+
+	return nil
+}
+
+// resolveToken loads the bot token from a credentials resource.
+func (e *SlackExecutor) resolveToken(ctx context.Context, credentialID string) (string, error) {
+	if e.credentials == nil {
+		return "", fmt.Errorf("credentials are not available")
+	}
+	if !credentialAttached(ctx, credentialID) {
+		return "", fmt.Errorf("credential %s is not attached to the workflow as a resource", credentialID)
+	}
+
+	cred, err := e.credentials.GetDecrypted(ctx, credentialID)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve credential %s: %w", credentialID, err)
+	}
+
+	var token string
+	switch cred.CredentialType {
+	case models.CredentialTypeAPIKey:
+		token = cred.GetAPIKey()
+	case models.CredentialTypeCustom:
+		token = cred.GetCustomValue("bot_token")
+		if token == "" {
+			token = cred.GetCustomValue("token")
+		}
+	default:
+		return "", fmt.Errorf("credential %s has unsupported type %s (expected api_key or custom)",
+			credentialID, cred.CredentialType)
+	}
+	if token == "" {
+		return "", fmt.Errorf("credential %s does not contain a bot token", credentialID)
+	}
+	return token, nil
+}
+
+// postMessage calls chat.postMessage.
+func (e *SlackExecutor) postMessage(ctx context.Context, token string, config map[string]any) (map[string]any, error) {
+	payload := map[string]any{
+		"channel": e.GetStringDefault(config, "channel", ""),
+	}
+	if text := e.GetStringDefault(config, "text", ""); text != "" {
+		payload["text"] = text
+	}
+	blocks, _ := parseSlackBlocks(config["blocks"])
+	if blocks != nil {
+		payload["blocks"] = blocks
+	}
+	if threadTS := e.GetStringDefault(config, "thread_ts", ""); threadTS != "" {
+		payload["thread_ts"] = threadTS
+		payload["reply_broadcast"] = e.GetBoolDefault(config, "reply_broadcast", false)
+	}
+	for _, key := range []string{"username", "icon_emoji", "icon_url"} {
+		if v := e.GetStringDefault(config, key, ""); v != "" {
+			payload[key] = v
+		}
+	}
+	for _, key := range []string{"unfurl_links", "unfurl_media", "mrkdwn"} {
+		if _, ok := config[key]; ok {
+			payload[key] = e.GetBoolDefault(config, key, false)
+		}
+	}
+
+	var resp struct {
+		slackResponse
+		Channel string `json:"channel"`
+		TS      string `json:"ts"`
+	}
+	if err := e.callJSON(ctx, token, "chat.postMessage", payload, &resp); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"channel": resp.Channel,
+		"ts":      resp.TS,
+	}, nil
+}
+
+// uploadFile uploads a file with the external upload flow
+// (files.getUploadURLExternal, upload, files.completeUploadExternal).
+func (e *SlackExecutor) uploadFile(ctx context.Context, token string, config map[string]any) (map[string]any, error) {
+	file, err := e.loadFile(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	var uploadURL struct {
+		slackResponse
+		UploadURL string `json:"upload_url"`
+		FileID    string `json:"file_id"`
+	}
+	form := url.Values{
+		"filename": {file.name},
+		"length":   {strconv.Itoa(len(file.data))},
+	}
+	if err := e.callForm(ctx, token, "files.getUploadURLExternal", form, &uploadURL); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL.UploadURL, bytes.NewReader(file.data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upload request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("slack file upload failed: %w", err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("slack file upload failed with status %d", resp.StatusCode)
+	}
+
+	title := e.GetStringDefault(config, "title", file.name)
+	channel := e.GetStringDefault(config, "channel", "")
+	complete := map[string]any{
+		"files":      []map[string]string{{"id": uploadURL.FileID, "title": title}},
+		"channel_id": channel,
+	}
+	if comment := e.GetStringDefault(config, "initial_comment", ""); comment != "" {
+		complete["initial_comment"] = comment
+	}
+	if threadTS := e.GetStringDefault(config, "thread_ts", ""); threadTS != "" {
+		complete["thread_ts"] = threadTS
+	}
+
+	var completed slackResponse
+	if err := e.callJSON(ctx, token, "files.completeUploadExternal", complete, &completed); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"channel":   channel,
+		"file_id":   uploadURL.FileID,
+		"file_name": file.name,
+		"size":      len(file.data),
+	}, nil
+}
+
+// loadFile reads the file to upload from storage or inline content.
+func (e *SlackExecutor) loadFile(ctx context.Context, config map[string]any) (*slackFile, error) {
+	fileID := e.GetStringDefault(config, "file_id", "")
+	if fileID == "" {
+		content := fmt.Sprint(config["content"])
+		data := []byte(content)
+		if e.GetBoolDefault(config, "content_base64", false) {
+			decoded, err := base64.StdEncoding.DecodeString(content)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode base64 content: %w", err)
+			}
+			data = decoded
+		}
+		if len(data) > slackMaxFileBytes {
+			return nil, fmt.Errorf("file exceeds %d bytes", slackMaxFileBytes)
+		}
+		return &slackFile{name: e.GetStringDefault(config, "file_name", ""), data: data}, nil
+	}
+
+	storage, err := e.storage.GetStorage(e.GetStringDefault(config, "storage_id", "default"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage: %w", err)
+	}
+	entry, reader, err := storage.Get(ctx, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file %s: %w", fileID, err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, slackMaxFileBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", fileID, err)
+	}
+	if len(data) > slackMaxFileBytes {
+		return nil, fmt.Errorf("file exceeds %d bytes", slackMaxFileBytes)
+	}
+
+	return &slackFile{name: e.GetStringDefault(config, "file_name", entry.Name), data: data}, nil
+}
+
+// slackResponse is the envelope shared by all Slack Web API responses.
+type slackResponse struct {
+	OK       bool   `json:"ok"`
+	Error    string `json:"error"`
+	Warning  string `json:"warning"`
+	Metadata struct {
+		Messages []string `json:"messages"`
+	} `json:"response_metadata"`
+}
+
+func (r *slackResponse) err(method string) error {
+	if r.OK {
+		return nil
+	}
+	if len(r.Metadata.Messages) > 0 {
+		return fmt.Errorf("slack %s failed: %s (%s)", method, r.Error, strings.Join(r.Metadata.Messages, "; "))
+	}
+	return fmt.Errorf("slack %s failed: %s", method, r.Error)
+}
+
+// slackEnvelope is implemented by response structs embedding slackResponse.
+type slackEnvelope interface {
+	err(method string) error
+}
+
+func (e *SlackExecutor) callJSON(ctx context.Context, token, method string, payload any, out slackEnvelope) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s payload: %w", method, err)
+	}
+	return e.call(ctx, token, method, "application/json; charset=utf-8", bytes.NewReader(body), out)
+}
+
+func (e *SlackExecutor) callForm(ctx context.Context, token, method string, form url.Values, out slackEnvelope) error {
+	return e.call(ctx, token, method, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()), out)
+}
+
+func (e *SlackExecutor) call(ctx context.Context, token, method, contentType string, body io.Reader, out slackEnvelope) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL+"/"+method, body)
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", method, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("slack %s request failed: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return fmt.Errorf("slack %s rate limited (retry after %ss)", method, resp.Header.Get("Retry-After"))
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 10*1024*1024))
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", method, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("slack %s failed with status %d: %s", method, resp.StatusCode, string(data))
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", method, err)
+	}
+	return out.err(method)
+}
+
+// parseSlackBlocks accepts blocks as an array or a JSON string (e.g. rendered from a template).
+func parseSlackBlocks(raw any) ([]any, error) {
+	switch v := raw.(type) {
+	case nil:
+		return nil, nil
+	case []any:
+		return v, nil
+	case []map[string]any:
+		blocks := make([]any, len(v))
+		for i, block := range v {
+			blocks[i] = block
+		}
+		return blocks, nil
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return nil, nil
+		}
+		var blocks []any
+		if err := json.Unmarshal([]byte(v), &blocks); err != nil {
+			return nil, fmt.Errorf("blocks must be a JSON array: %w", err)
+		}
+		return blocks, nil
+	default:
+		return nil, fmt.Errorf("blocks must be an array")
+	}
+}
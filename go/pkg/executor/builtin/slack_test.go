@@ -0,0 +1,241 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSlackAPI records Web API calls and serves the external file upload flow.
+type fakeSlackAPI struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	auth     []string
+	payloads map[string]map[string]any
+	forms    map[string]string
+	uploaded []byte
+	failWith string
+}
+
+func newFakeSlackAPI(t *testing.T) *fakeSlackAPI {
+	f := &fakeSlackAPI{payloads: map[string]map[string]any{}, forms: map[string]string{}}
+	f.server = httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeSlackAPI) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	method := strings.TrimPrefix(r.URL.Path, "/")
+
+	if method == "upload" {
+		f.uploaded = body
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	f.auth = append(f.auth, r.Header.Get("Authorization"))
+	if f.failWith != "" {
+		json.NewEncoder(w).Encode(map[string]any{"ok": false, "error": f.failWith})
+		return
+	}
+
+	switch method {
+	case "chat.postMessage":
+		var payload map[string]any
+		json.Unmarshal(body, &payload)
+		f.payloads[method] = payload
+		json.NewEncoder(w).Encode(map[string]any{"ok": true, "channel": "C123", "ts": "1700000000.000200"})
+	case "files.getUploadURLExternal":
+		f.forms[method] = string(body)
+		json.NewEncoder(w).Encode(map[string]any{"ok": true, "upload_url": f.server.URL + "/upload", "file_id": "F999"})
+	case "files.completeUploadExternal":
+		var payload map[string]any
+		json.Unmarshal(body, &payload)
+		f.payloads[method] = payload
+		json.NewEncoder(w).Encode(map[string]any{"ok": true})
+	default:
+		json.NewEncoder(w).Encode(map[string]any{"ok": false, "error": "unknown_method"})
+	}
+}
+
+func newSlackCredentials() *fakeCredentialResolver {
+	apiKey := models.NewCredentialsResource("owner-1", "slack", models.CredentialTypeAPIKey)
+	apiKey.DecryptedData = map[string]string{"api_key": "xoxb-api-key"}
+	custom := models.NewCredentialsResource("owner-1", "slack-custom", models.CredentialTypeCustom)
+	custom.DecryptedData = map[string]string{"bot_token": "xoxb-custom"}
+	basic := models.NewCredentialsResource("owner-1", "basic", models.CredentialTypeBasicAuth)
+	return &fakeCredentialResolver{creds: map[string]*models.CredentialsResource{
+		"cred-1": apiKey,
+		"cred-2": custom,
+		"cred-3": basic,
+	}}
+}
+
+func newTestSlackExecutor(api *fakeSlackAPI) *SlackExecutor {
+	exec := NewSlackExecutor(newSlackCredentials(), nil)
+	exec.baseURL = api.server.URL
+	return exec
+}
+
+func TestSlackExecutor_Validate(t *testing.T) {
+	exec := NewSlackExecutor(nil, nil)
+
+	base := func() map[string]any {
+		return map[string]any{
+			"credential_id": "cred-1",
+			"channel":       "C123",
+			"text":          "hi",
+		}
+	}
+
+	assert.NoError(t, exec.Validate(base()))
+
+	tests := []struct {
+		name   string
+		mutate func(map[string]any)
+		errMsg string
+	}{
+		{name: "missing credential", mutate: func(c map[string]any) { delete(c, "credential_id") }, errMsg: "credential_id"},
+		{name: "missing channel", mutate: func(c map[string]any) { delete(c, "channel") }, errMsg: "channel"},
+		{name: "inline token", mutate: func(c map[string]any) { c["bot_token"] = "xoxb" }, errMsg: "credential_id"},
+		{name: "no content", mutate: func(c map[string]any) { delete(c, "text") }, errMsg: "text or blocks"},
+		{name: "invalid blocks", mutate: func(c map[string]any) { c["blocks"] = "{not json" }, errMsg: "blocks"},
+		{name: "invalid operation", mutate: func(c map[string]any) { c["operation"] = "react" }, errMsg: "operation"},
+		{name: "file without source", mutate: func(c map[string]any) { c["operation"] = "file" }, errMsg: "file_id or content"},
+		{name: "file_id without storage", mutate: func(c map[string]any) {
+			c["operation"] = "file"
+			c["file_id"] = "f1"
+		}, errMsg: "file storage"},
+		{name: "content without name", mutate: func(c map[string]any) {
+			c["operation"] = "file"
+			c["content"] = "a,b"
+		}, errMsg: "file_name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := base()
+			tt.mutate(cfg)
+			err := exec.Validate(cfg)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestSlackExecutor_PostMessage(t *testing.T) {
+	api := newFakeSlackAPI(t)
+	exec := newTestSlackExecutor(api)
+
+	out, err := exec.Execute(context.Background(), map[string]any{
+		"credential_id":   "cred-1",
+		"channel":         "C123",
+		"text":            "Deploy finished",
+		"blocks":          `[{"type":"section","text":{"type":"mrkdwn","text":"*Done*"}}]`,
+		"thread_ts":       "1700000000.000100",
+		"reply_broadcast": true,
+		"unfurl_links":    false,
+	}, nil)
+	require.NoError(t, err)
+
+	result := out.(map[string]any)
+	assert.Equal(t, true, result["success"])
+	assert.Equal(t, "C123", result["channel"])
+	assert.Equal(t, "1700000000.000200", result["ts"])
+
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	assert.Equal(t, []string{"Bearer xoxb-api-key"}, api.auth)
+
+	payload := api.payloads["chat.postMessage"]
+	assert.Equal(t, "C123", payload["channel"])
+	assert.Equal(t, "Deploy finished", payload["text"])
+	assert.Equal(t, "1700000000.000100", payload["thread_ts"])
+	assert.Equal(t, true, payload["reply_broadcast"])
+	assert.Equal(t, false, payload["unfurl_links"])
+	require.Len(t, payload["blocks"], 1)
+	assert.NotContains(t, payload, "unfurl_media")
+}
+
+func TestSlackExecutor_UploadFile(t *testing.T) {
+	api := newFakeSlackAPI(t)
+	exec := newTestSlackExecutor(api)
+
+	out, err := exec.Execute(context.Background(), map[string]any{
+		"credential_id":   "cred-2",
+		"operation":       "file",
+		"channel":         "C123",
+		"content":         "aWQsY291bnQKNDIsMwo=",
+		"content_base64":  true,
+		"file_name":       "report.csv",
+		"initial_comment": "Nightly report",
+	}, nil)
+	require.NoError(t, err)
+
+	result := out.(map[string]any)
+	assert.Equal(t, "F999", result["file_id"])
+	assert.Equal(t, "report.csv", result["file_name"])
+	assert.Equal(t, 14, result["size"])
+
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	assert.Equal(t, "id,count\n42,3\n", string(api.uploaded))
+	assert.Contains(t, api.forms["files.getUploadURLExternal"], "filename=report.csv")
+	assert.Contains(t, api.forms["files.getUploadURLExternal"], "length=14")
+	assert.Equal(t, []string{"Bearer xoxb-custom", "Bearer xoxb-custom"}, api.auth)
+
+	complete := api.payloads["files.completeUploadExternal"]
+	assert.Equal(t, "C123", complete["channel_id"])
+	assert.Equal(t, "Nightly report", complete["initial_comment"])
+	files := complete["files"].([]any)
+	assert.Equal(t, "F999", files[0].(map[string]any)["id"])
+	assert.Equal(t, "report.csv", files[0].(map[string]any)["title"])
+}
+
+func TestSlackExecutor_APIError(t *testing.T) {
+	api := newFakeSlackAPI(t)
+	api.failWith = "channel_not_found"
+	exec := newTestSlackExecutor(api)
+
+	_, err := exec.Execute(context.Background(), map[string]any{
+		"credential_id": "cred-1",
+		"channel":       "C404",
+		"text":          "hi",
+	}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "channel_not_found")
+}
+
+func TestSlackExecutor_Credentials(t *testing.T) {
+	exec := NewSlackExecutor(newSlackCredentials(), nil)
+
+	_, err := exec.resolveToken(context.Background(), "cred-3")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported type")
+
+	ctx := executor.WithExecutionContext(context.Background(), &executor.ExecutionContextData{
+		Resources: map[string]any{"slack": map[string]any{"id": "cred-2"}},
+	})
+	_, err = exec.resolveToken(ctx, "cred-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not attached")
+
+	token, err := exec.resolveToken(ctx, "cred-2")
+	require.NoError(t, err)
+	assert.Equal(t, "xoxb-custom", token)
+}
@@ -0,0 +1,488 @@
+package builtin
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/antchfx/xpath"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+)
+
+// SOAP envelope namespaces by protocol version.
+const (
+	soap11EnvelopeNS = "http://schemas.xmlsoap.org/soap/envelope/"
+	soap12EnvelopeNS = "http://www.w3.org/2003/05/soap-envelope"
+)
+
+// soapMaxResponseBytes limits the size of a SOAP response.
+const soapMaxResponseBytes = 32 << 20
+
+// SOAPExecutor calls SOAP web services. The envelope is built from a body template
+// (or an operation with parameters, which are XML-escaped), posted over HTTP with the
+// SOAPAction of the protocol version, and the response is parsed into a map.
+// Values can be extracted from the response with XPath expressions.
+// Credentials are never part of the node config: authentication uses a credentials
+// resource referenced by ID.
+type SOAPExecutor struct {
+	*executor.BaseExecutor
+	credentials CredentialResolver
+	client      *http.Client
+}
+
+// NewSOAPExecutor creates a new SOAP executor.
+// credentials may be nil, in which case only unauthenticated calls can be made.
+func NewSOAPExecutor(credentials CredentialResolver) *SOAPExecutor {
+	return &SOAPExecutor{
+		BaseExecutor: executor.NewBaseExecutor("soap"),
+		credentials:  credentials,
+		client:       &http.Client{},
+	}
+}
+
+// Execute sends a SOAP request.
+//
+// Config:
+//   - url: Service endpoint (required)
+//   - action: SOAPAction of the operation
+//   - version: SOAP version, "1.1" or "1.2" (default: "1.1")
+//   - body: XML placed in the envelope Body; templates are resolved before execution,
+//     so values inserted into it are not escaped
+//   - operation: Name of the operation element, used when body is not set
+//   - namespace: Namespace of the operation element
+//   - params: Object of operation parameters, written as child elements with escaped values;
+//     nested objects become nested elements and arrays repeat the element
+//   - header: XML placed in the envelope Header
+//   - envelope: Complete envelope, replacing body, operation and header
+//   - headers: Additional HTTP headers as an object of strings
+//   - credential_id: ID of a credentials resource sent as the Authorization header:
+//     api_key as "Bearer <key>", basic_auth as "Basic <base64>"; the credential must be
+//     attached to the workflow
+//   - xpath: Object of name to XPath expression evaluated on the response envelope
+//   - namespaces: Object of prefix to namespace URI usable in xpath expressions;
+//     "soap" is bound to the envelope namespace of the version
+//   - timeout: Timeout in seconds (default: 30)
+//
+// Output:
+//   - status: HTTP status code
+//   - headers: Response headers
+//   - body: Contents of the response Body as a map (see xmlToMap)
+//   - header: Contents of the response Header as a map, when present
+//   - extracted: Results of the xpath expressions; node sets with one node give its text,
+//     larger node sets an array of texts, and other expressions their value
+//   - raw: Response XML
+//   - duration_ms: Execution duration
+//
+// A SOAP fault is returned as an error with its code and reason.
+func (e *SOAPExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	startTime := time.Now()
+
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+
+	version := e.GetStringDefault(config, "version", "1.1")
+	envelope, err := e.buildEnvelope(config, version)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := time.Duration(e.GetIntDefault(config, "timeout", 30)) * time.Second
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	url := e.GetStringDefault(config, "url", "")
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, url, strings.NewReader(envelope))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	action := e.GetStringDefault(config, "action", "")
+	if version == "1.2" {
+		contentType := "application/soap+xml; charset=utf-8"
+		if action != "" {
+			contentType += fmt.Sprintf("; action=%q", action)
+		}
+		req.Header.Set("Content-Type", contentType)
+	} else {
+		req.Header.Set("Content-Type", "text/xml; charset=utf-8")
+		req.Header.Set("SOAPAction", fmt.Sprintf("%q", action))
+	}
+	if headers, ok := config["headers"].(map[string]any); ok {
+		for key, value := range headers {
+			req.Header.Set(key, value.(string))
+		}
+	}
+	if credentialID := e.GetStringDefault(config, "credential_id", ""); credentialID != "" {
+		authorization, err := resolveAuthorizationHeader(ctx, e.credentials, credentialID)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", authorization)
+	}
+	executor.InjectHeaders(ctx, req.Header)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("SOAP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, soapMaxResponseBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if len(respBody) > soapMaxResponseBytes {
+		return nil, fmt.Errorf("SOAP response exceeds %d bytes", soapMaxResponseBytes)
+	}
+
+	doc, parseErr := xmlquery.Parse(bytes.NewReader(respBody))
+	if parseErr != nil {
+		if resp.StatusCode >= 400 {
+			return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(respBody))
+		}
+		return nil, fmt.Errorf("failed to parse SOAP response: %w", parseErr)
+	}
+
+	envelopeNode := soapChild(doc, "Envelope")
+	if envelopeNode == nil {
+		if resp.StatusCode >= 400 {
+			return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(respBody))
+		}
+		return nil, fmt.Errorf("SOAP response has no Envelope element")
+	}
+	bodyNode := soapChild(envelopeNode, "Body")
+	if bodyNode == nil {
+		return nil, fmt.Errorf("SOAP response has no Body element")
+	}
+	if fault := soapChild(bodyNode, "Fault"); fault != nil {
+		return nil, soapFaultError(fault)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	body, _ := xmlToMap(bodyNode).(map[string]any)
+	if body == nil {
+		body = map[string]any{}
+	}
+	result := map[string]any{
+		"status":  resp.StatusCode,
+		"headers": resp.Header,
+		"body":    body,
+		"raw":     string(respBody),
+	}
+	if headerNode := soapChild(envelopeNode, "Header"); headerNode != nil {
+		result["header"] = xmlToMap(headerNode)
+	}
+
+	if expressions, ok := config["xpath"].(map[string]any); ok && len(expressions) > 0 {
+		namespaces := soapNamespaces(config, version)
+		extracted := make(map[string]any, len(expressions))
+		for name, raw := range expressions {
+			value, err := evaluateXPath(doc, raw.(string), namespaces)
+			if err != nil {
+				return nil, fmt.Errorf("xpath %s: %w", name, err)
+			}
+			extracted[name] = value
+		}
+		result["extracted"] = extracted
+	}
+
+	result["duration_ms"] = time.Since(startTime).Milliseconds()
+	return result, nil
+}
+
+// Validate validates the SOAP executor configuration.
+func (e *SOAPExecutor) Validate(config map[string]any) error {
+	if err := e.ValidateRequired(config, "url"); err != nil {
+		return err
+	}
+
+	url := e.GetStringDefault(config, "url", "")
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return fmt.Errorf("url must be an http or https URL")
+	}
+
+	switch version := e.GetStringDefault(config, "version", "1.1"); version {
+	case "1.1", "1.2":
+	default:
+		return fmt.Errorf("unsupported SOAP version %q (expected 1.1 or 1.2)", version)
+	}
+
+	hasEnvelope := e.GetStringDefault(config, "envelope", "") != ""
+	hasBody := e.GetStringDefault(config, "body", "") != ""
+	hasOperation := e.GetStringDefault(config, "operation", "") != ""
+	switch {
+	case hasEnvelope && (hasBody || hasOperation):
+		return fmt.Errorf("envelope is mutually exclusive with body and operation")
+	case hasBody && hasOperation:
+		return fmt.Errorf("body and operation are mutually exclusive")
+	case !hasEnvelope && !hasBody && !hasOperation:
+		return fmt.Errorf("one of envelope, body or operation is required")
+	}
+
+	if raw, ok := config["params"]; ok && raw != nil {
+		if _, ok := raw.(map[string]any); !ok {
+			return fmt.Errorf("params must be an object")
+		}
+		if !hasOperation {
+			return fmt.Errorf("params requires operation")
+		}
+	}
+	if hasOperation {
+		if err := validateXMLName(e.GetStringDefault(config, "operation", "")); err != nil {
+			return fmt.Errorf("operation: %w", err)
+		}
+	}
+
+	if raw, ok := config["headers"]; ok && raw != nil {
+		headers, ok := raw.(map[string]any)
+		if !ok {
+			return fmt.Errorf("headers must be an object")
+		}
+		for key, value := range headers {
+			if strings.EqualFold(key, "authorization") {
+				return fmt.Errorf("authorization must not be set inline: store it in a credentials resource and reference it with credential_id")
+			}
+			if _, ok := value.(string); !ok {
+				return fmt.Errorf("header %s must be a string", key)
+			}
+		}
+	}
+
+	namespaces := map[string]string{}
+	if raw, ok := config["namespaces"]; ok && raw != nil {
+		ns, ok := raw.(map[string]any)
+		if !ok {
+			return fmt.Errorf("namespaces must be an object")
+		}
+		for prefix, uri := range ns {
+			s, ok := uri.(string)
+			if !ok {
+				return fmt.Errorf("namespace %s must be a string", prefix)
+			}
+			namespaces[prefix] = s
+		}
+	}
+
+	if raw, ok := config["xpath"]; ok && raw != nil {
+		expressions, ok := raw.(map[string]any)
+		if !ok {
+			return fmt.Errorf("xpath must be an object")
+		}
+		namespaces["soap"] = soap11EnvelopeNS
+		for name, expr := range expressions {
+			s, ok := expr.(string)
+			if !ok || s == "" {
+				return fmt.Errorf("xpath %s must be a non-empty string", name)
+			}
+			if _, err := xpath.CompileWithNS(s, namespaces); err != nil {
+				return fmt.Errorf("xpath %s: %w", name, err)
+			}
+		}
+	}
+
+	if timeout := e.GetIntDefault(config, "timeout", 30); timeout < 1 {
+		return fmt.Errorf("timeout must be at least 1 second")
+	}
+
+	return nil
+}
+
+// buildEnvelope returns the request envelope: the configured envelope, or one wrapping
+// the header and the body or operation element.
+func (e *SOAPExecutor) buildEnvelope(config map[string]any, version string) (string, error) {
+	if envelope := e.GetStringDefault(config, "envelope", ""); envelope != "" {
+		return envelope, nil
+	}
+
+	envNS := soap11EnvelopeNS
+	if version == "1.2" {
+		envNS = soap12EnvelopeNS
+	}
+
+	var buf strings.Builder
+	buf.WriteString(xml.Header)
+	fmt.Fprintf(&buf, `<soap:Envelope xmlns:soap="%s">`, envNS)
+	if header := e.GetStringDefault(config, "header", ""); header != "" {
+		buf.WriteString("<soap:Header>")
+		buf.WriteString(header)
+		buf.WriteString("</soap:Header>")
+	}
+	buf.WriteString("<soap:Body>")
+	if body := e.GetStringDefault(config, "body", ""); body != "" {
+		buf.WriteString(body)
+	} else {
+		operation := e.GetStringDefault(config, "operation", "")
+		buf.WriteString("<" + operation)
+		if namespace := e.GetStringDefault(config, "namespace", ""); namespace != "" {
+			buf.WriteString(` xmlns="`)
+			if err := xml.EscapeText(&buf, []byte(namespace)); err != nil {
+				return "", err
+			}
+			buf.WriteString(`"`)
+		}
+		buf.WriteString(">")
+		params, _ := config["params"].(map[string]any)
+		if err := writeXMLParams(&buf, params); err != nil {
+			return "", err
+		}
+		buf.WriteString("</" + operation + ">")
+	}
+	buf.WriteString("</soap:Body></soap:Envelope>")
+	return buf.String(), nil
+}
+
+// writeXMLParams writes params as child elements in name order, escaping values.
+func writeXMLParams(buf *strings.Builder, params map[string]any) error {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := validateXMLName(name); err != nil {
+			return fmt.Errorf("param %s: %w", name, err)
+		}
+		values, ok := params[name].([]any)
+		if !ok {
+			values = []any{params[name]}
+		}
+		for _, value := range values {
+			buf.WriteString("<" + name + ">")
+			switch v := value.(type) {
+			case nil:
+			case map[string]any:
+				if err := writeXMLParams(buf, v); err != nil {
+					return err
+				}
+			case string:
+				if err := xml.EscapeText(buf, []byte(v)); err != nil {
+					return err
+				}
+			default:
+				if err := xml.EscapeText(buf, []byte(fmt.Sprint(v))); err != nil {
+					return err
+				}
+			}
+			buf.WriteString("</" + name + ">")
+		}
+	}
+	return nil
+}
+
+// validateXMLName rejects names that cannot be written as an element name verbatim.
+func validateXMLName(name string) error {
+	if name == "" {
+		return fmt.Errorf("element name is empty")
+	}
+	for i, r := range name {
+		switch {
+		case r == '_' || r == ':' || (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') || r > 0x7f:
+		case i > 0 && (r == '-' || r == '.' || (r >= '0' && r <= '9')):
+		default:
+			return fmt.Errorf("%q is not a valid XML element name", name)
+		}
+	}
+	return nil
+}
+
+// soapNamespaces returns the namespace bindings for xpath expressions.
+func soapNamespaces(config map[string]any, version string) map[string]string {
+	namespaces := map[string]string{"soap": soap11EnvelopeNS}
+	if version == "1.2" {
+		namespaces["soap"] = soap12EnvelopeNS
+	}
+	if raw, ok := config["namespaces"].(map[string]any); ok {
+		for prefix, uri := range raw {
+			namespaces[prefix] = uri.(string)
+		}
+	}
+	return namespaces
+}
+
+// soapChild returns the first child element with the given local name.
+func soapChild(parent *xmlquery.Node, name string) *xmlquery.Node {
+	for child := parent.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == xmlquery.ElementNode && child.Data == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// soapFaultError describes a SOAP 1.1 (faultcode/faultstring) or
+// SOAP 1.2 (Code/Value, Reason/Text) fault.
+func soapFaultError(fault *xmlquery.Node) error {
+	code, reason := "", ""
+	if node := soapChild(fault, "faultcode"); node != nil {
+		code = strings.TrimSpace(node.InnerText())
+	}
+	if node := soapChild(fault, "faultstring"); node != nil {
+		reason = strings.TrimSpace(node.InnerText())
+	}
+	if node := soapChild(fault, "Code"); node != nil {
+		if value := soapChild(node, "Value"); value != nil {
+			code = strings.TrimSpace(value.InnerText())
+		}
+	}
+	if node := soapChild(fault, "Reason"); node != nil {
+		if text := soapChild(node, "Text"); text != nil {
+			reason = strings.TrimSpace(text.InnerText())
+		}
+	}
+	return fmt.Errorf("SOAP fault %s: %s", code, reason)
+}
+
+// evaluateXPath evaluates an expression against a document. Node sets with one node
+// return its text and larger node sets an array of texts; an empty node set returns nil.
+// Other expressions return their string, number or boolean value.
+func evaluateXPath(doc *xmlquery.Node, expr string, namespaces map[string]string) (any, error) {
+	return evaluateXPathAs(doc, expr, namespaces, func(nav *xmlquery.NodeNavigator) any {
+		return nav.Value()
+	})
+}
+
+// evaluateXPathAs is evaluateXPath with node set members converted by convert.
+func evaluateXPathAs(doc *xmlquery.Node, expr string, namespaces map[string]string, convert func(*xmlquery.NodeNavigator) any) (any, error) {
+	compiled, err := xpath.CompileWithNS(expr, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := compiled.Evaluate(xmlquery.CreateXPathNavigator(doc)).(type) {
+	case *xpath.NodeIterator:
+		var values []any
+		for v.MoveNext() {
+			values = append(values, convert(v.Current().(*xmlquery.NodeNavigator)))
+		}
+		switch len(values) {
+		case 0:
+			return nil, nil
+		case 1:
+			return values[0], nil
+		default:
+			return values, nil
+		}
+	default:
+		return v, nil
+	}
+}
+
+// xmlToMap converts an element to a value for downstream nodes. Namespace prefixes
+// are dropped. Elements with only text become their text; otherwise they become a map
+// with child elements by name (repeated elements as arrays), attributes as "@name"
+// and text as "#text". Empty elements become an empty string.
+func xmlToMap(node *xmlquery.Node) any {
+	return defaultXMLMapOptions.toValue(node)
+}
@@ -0,0 +1,226 @@
+package builtin
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const soapPriceResponse = `<?xml version="1.0" encoding="utf-8"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Header><s:Session xmlns:s="urn:session">abc</s:Session></soap:Header>
+  <soap:Body>
+    <m:GetPriceResponse xmlns:m="urn:prices">
+      <m:Price currency="EUR">9.99</m:Price>
+      <m:Tag>sale</m:Tag>
+      <m:Tag>new</m:Tag>
+    </m:GetPriceResponse>
+  </soap:Body>
+</soap:Envelope>`
+
+// soapRequest is the request seen by the fake SOAP service.
+type soapRequest struct {
+	header http.Header
+	body   string
+}
+
+func newFakeSOAPServer(t *testing.T, status int, response string) (*httptest.Server, *soapRequest) {
+	t.Helper()
+	seen := &soapRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		seen.header = r.Header.Clone()
+		seen.body = string(data)
+		w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+		w.WriteHeader(status)
+		io.WriteString(w, response)
+	}))
+	t.Cleanup(server.Close)
+	return server, seen
+}
+
+func TestSOAPExecutor_Validate(t *testing.T) {
+	exec := NewSOAPExecutor(nil)
+	base := func(extra map[string]any) map[string]any {
+		config := map[string]any{"url": "http://example.com/soap", "operation": "GetPrice"}
+		for k, v := range extra {
+			config[k] = v
+		}
+		return config
+	}
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{"valid operation", base(nil), ""},
+		{"valid body", map[string]any{"url": "https://example.com", "body": "<GetPrice/>"}, ""},
+		{"valid envelope", map[string]any{"url": "https://example.com", "envelope": "<Envelope/>"}, ""},
+		{"missing url", map[string]any{"operation": "GetPrice"}, "url"},
+		{"non-http url", base(map[string]any{"url": "ftp://example.com"}), "http or https"},
+		{"no request", map[string]any{"url": "https://example.com"}, "one of envelope, body or operation"},
+		{"body and operation", base(map[string]any{"body": "<x/>"}), "mutually exclusive"},
+		{"envelope and body", map[string]any{"url": "https://example.com", "envelope": "<e/>", "body": "<x/>"}, "mutually exclusive"},
+		{"invalid operation", base(map[string]any{"operation": "Get Price"}), "not a valid XML element name"},
+		{"params without operation", map[string]any{"url": "https://example.com", "body": "<x/>", "params": map[string]any{}}, "params requires operation"},
+		{"unsupported version", base(map[string]any{"version": "2.0"}), "unsupported SOAP version"},
+		{"inline authorization", base(map[string]any{"headers": map[string]any{"Authorization": "Basic x"}}), "must not be set inline"},
+		{"invalid xpath", base(map[string]any{"xpath": map[string]any{"price": "//[@"}}), "xpath price"},
+		{"unknown xpath prefix", base(map[string]any{"xpath": map[string]any{"price": "//m:Price"}}), "xpath price"},
+		{"bound xpath prefix", base(map[string]any{
+			"xpath":      map[string]any{"price": "//m:Price"},
+			"namespaces": map[string]any{"m": "urn:prices"},
+		}), ""},
+		{"invalid timeout", base(map[string]any{"timeout": 0}), "timeout"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exec.Validate(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSOAPExecutor_Execute(t *testing.T) {
+	server, seen := newFakeSOAPServer(t, http.StatusOK, soapPriceResponse)
+
+	cred := models.NewCredentialsResource("owner-1", "prices", models.CredentialTypeBasicAuth)
+	cred.DecryptedData = map[string]string{"username": "user", "password": "pass"}
+	exec := NewSOAPExecutor(&fakeCredentialResolver{creds: map[string]*models.CredentialsResource{"cred-1": cred}})
+
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"url":       server.URL,
+		"action":    "urn:prices/GetPrice",
+		"operation": "GetPrice",
+		"namespace": "urn:prices",
+		"params": map[string]any{
+			"Item":    "Fish & Chips <large>",
+			"Options": map[string]any{"Quantity": 2},
+			"Code":    []any{"a", "b"},
+		},
+		"header":        `<Session xmlns="urn:session">abc</Session>`,
+		"headers":       map[string]any{"X-Tenant": "acme"},
+		"credential_id": "cred-1",
+		"namespaces":    map[string]any{"m": "urn:prices"},
+		"xpath": map[string]any{
+			"price":    "//m:Price",
+			"currency": "string(//m:Price/@currency)",
+			"tags":     "//m:Tag",
+			"count":    "count(//m:Tag)",
+			"missing":  "//m:Discount",
+			"session":  "/soap:Envelope/soap:Header/*[local-name()='Session']",
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, `"urn:prices/GetPrice"`, seen.header.Get("SOAPAction"))
+	assert.Equal(t, "text/xml; charset=utf-8", seen.header.Get("Content-Type"))
+	assert.Equal(t, "acme", seen.header.Get("X-Tenant"))
+	assert.Equal(t, "Basic dXNlcjpwYXNz", seen.header.Get("Authorization"))
+	assert.Contains(t, seen.body, `<soap:Header><Session xmlns="urn:session">abc</Session></soap:Header>`)
+	assert.Contains(t, seen.body, `<soap:Body><GetPrice xmlns="urn:prices"><Code>a</Code><Code>b</Code>`+
+		`<Item>Fish &amp; Chips &lt;large&gt;</Item><Options><Quantity>2</Quantity></Options></GetPrice></soap:Body>`)
+
+	output := result.(map[string]any)
+	assert.Equal(t, http.StatusOK, output["status"])
+	assert.Equal(t, map[string]any{
+		"GetPriceResponse": map[string]any{
+			"Price": map[string]any{"@currency": "EUR", "#text": "9.99"},
+			"Tag":   []any{"sale", "new"},
+		},
+	}, output["body"])
+	assert.Equal(t, map[string]any{"Session": "abc"}, output["header"])
+	assert.Equal(t, map[string]any{
+		"price":    "9.99",
+		"currency": "EUR",
+		"tags":     []any{"sale", "new"},
+		"count":    float64(2),
+		"missing":  nil,
+		"session":  "abc",
+	}, output["extracted"])
+	assert.Contains(t, output["raw"], "GetPriceResponse")
+}
+
+func TestSOAPExecutor_Version12(t *testing.T) {
+	response := `<env:Envelope xmlns:env="http://www.w3.org/2003/05/soap-envelope"><env:Body><Ok/></env:Body></env:Envelope>`
+	server, seen := newFakeSOAPServer(t, http.StatusOK, response)
+	exec := NewSOAPExecutor(nil)
+
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"url":     server.URL,
+		"version": "1.2",
+		"action":  "urn:ping",
+		"body":    "<Ping/>",
+		"xpath":   map[string]any{"ok": "count(/soap:Envelope/soap:Body/Ok)"},
+	}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, `application/soap+xml; charset=utf-8; action="urn:ping"`, seen.header.Get("Content-Type"))
+	assert.Empty(t, seen.header.Get("SOAPAction"))
+	assert.Contains(t, seen.body, `<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope"><soap:Body><Ping/></soap:Body>`)
+	output := result.(map[string]any)
+	assert.Equal(t, map[string]any{"Ok": ""}, output["body"])
+	assert.Equal(t, float64(1), output["extracted"].(map[string]any)["ok"])
+}
+
+func TestSOAPExecutor_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		response string
+		wantErr  string
+	}{
+		{"soap 1.1 fault", http.StatusInternalServerError, `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body>
+			<soap:Fault><faultcode>soap:Client</faultcode><faultstring>Unknown item</faultstring></soap:Fault>
+		</soap:Body></soap:Envelope>`, "SOAP fault soap:Client: Unknown item"},
+		{"soap 1.2 fault", http.StatusInternalServerError, `<env:Envelope xmlns:env="http://www.w3.org/2003/05/soap-envelope"><env:Body>
+			<env:Fault><env:Code><env:Value>env:Sender</env:Value></env:Code><env:Reason><env:Text xml:lang="en">Bad request</env:Text></env:Reason></env:Fault>
+		</env:Body></env:Envelope>`, "SOAP fault env:Sender: Bad request"},
+		{"http error without envelope", http.StatusBadGateway, "upstream unavailable", "HTTP 502: upstream unavailable"},
+		{"not an envelope", http.StatusOK, "<html></html>", "no Envelope element"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newFakeSOAPServer(t, tt.status, tt.response)
+			_, err := NewSOAPExecutor(nil).Execute(context.Background(), map[string]any{
+				"url":  server.URL,
+				"body": "<GetPrice/>",
+			}, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSOAPExecutor_CredentialNotAttached(t *testing.T) {
+	server, _ := newFakeSOAPServer(t, http.StatusOK, soapPriceResponse)
+	cred := models.NewCredentialsResource("owner-1", "prices", models.CredentialTypeAPIKey)
+	cred.DecryptedData = map[string]string{"api_key": "tok"}
+	exec := NewSOAPExecutor(&fakeCredentialResolver{creds: map[string]*models.CredentialsResource{"cred-1": cred}})
+
+	ctx := executor.WithExecutionContext(context.Background(), &executor.ExecutionContextData{
+		Resources: map[string]any{"other": map[string]any{"id": "cred-2"}},
+	})
+	_, err := exec.Execute(ctx, map[string]any{
+		"url":           server.URL,
+		"body":          "<GetPrice/>",
+		"credential_id": "cred-1",
+	}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not attached")
+}
@@ -21,6 +21,9 @@ func NewTelegramParseExecutor() *TelegramParseExecutor {
 	}
 }
 
+// SideEffectFree reports true. Parsing updates does not call Telegram, so dry runs execute it.
+func (e *TelegramParseExecutor) SideEffectFree() bool { return true }
+
 // TelegramFileInfo contains extracted file information.
 type TelegramFileInfo struct {
 	Type         string `json:"type"`
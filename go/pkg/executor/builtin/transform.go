@@ -2,11 +2,9 @@ package builtin
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/expr-lang/expr"
-	"github.com/itchyny/gojq"
 	"github.com/smilemakc/mbflow/go/pkg/executor"
 )
 
@@ -22,6 +20,9 @@ func NewTransformExecutor() *TransformExecutor {
 	}
 }
 
+// SideEffectFree reports true. Transforms only reshape their input, so dry runs execute them.
+func (e *TransformExecutor) SideEffectFree() bool { return true }
+
 // Execute executes a data transformation.
 func (e *TransformExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
 	// Get transformation type
@@ -52,11 +53,11 @@ func (e *TransformExecutor) Execute(ctx context.Context, config map[string]any,
 
 		// Prepare environment for expression evaluation
 		env := map[string]any{
-			"input": input,
+			"input": executor.NormalizeNumbers(input),
 		}
 
 		// Compile expression with environment
-		program, err := expr.Compile(exprStr, expr.Env(env))
+		program, err := compileExpression(exprStr, env)
 		if err != nil {
 			return nil, fmt.Errorf("failed to compile expression: %w", err)
 		}
@@ -76,16 +77,10 @@ func (e *TransformExecutor) Execute(ctx context.Context, config map[string]any,
 			return nil, err
 		}
 
-		// Parse jq query
-		query, err := gojq.Parse(filterStr)
+		// Parse and compile jq query
+		code, err := compileJQ(filterStr)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse jq filter: %w", err)
-		}
-
-		// Compile jq query
-		code, err := gojq.Compile(query)
-		if err != nil {
-			return nil, fmt.Errorf("failed to compile jq filter: %w", err)
+			return nil, err
 		}
 
 		// Convert input to any if needed
@@ -93,13 +88,13 @@ func (e *TransformExecutor) Execute(ctx context.Context, config map[string]any,
 		switch v := input.(type) {
 		case string:
 			// Try to parse as JSON
-			if err := json.Unmarshal([]byte(v), &inputData); err != nil {
+			if err := executor.UnmarshalJSON(ctx, []byte(v), &inputData); err != nil {
 				// If not JSON, use as-is
 				inputData = v
 			}
 		case []byte:
 			// Try to parse as JSON
-			if err := json.Unmarshal(v, &inputData); err != nil {
+			if err := executor.UnmarshalJSON(ctx, v, &inputData); err != nil {
 				// If not JSON, convert to string
 				inputData = string(v)
 			}
@@ -121,6 +116,15 @@ func (e *TransformExecutor) Execute(ctx context.Context, config map[string]any,
 
 		return v, nil
 
+	case "csv_parse":
+		return e.csvParse(config, input)
+
+	case "csv_generate":
+		return e.csvGenerate(config, input)
+
+	case "xml":
+		return e.xmlTransform(config, input)
+
 	default:
 		return nil, fmt.Errorf("unknown transformation type: %s", transformType)
 	}
@@ -131,10 +135,13 @@ func (e *TransformExecutor) Validate(config map[string]any) error {
 	transformType := e.GetStringDefault(config, "type", "passthrough")
 
 	validTypes := map[string]bool{
-		"passthrough": true,
-		"template":    true,
-		"expression":  true,
-		"jq":          true,
+		"passthrough":  true,
+		"template":     true,
+		"expression":   true,
+		"jq":           true,
+		"csv_parse":    true,
+		"csv_generate": true,
+		"xml":          true,
 	}
 
 	if !validTypes[transformType] {
@@ -157,6 +164,12 @@ func (e *TransformExecutor) Validate(config map[string]any) error {
 		if _, err := e.GetString(config, "filter"); err != nil {
 			return fmt.Errorf("filter is required for jq transformation")
 		}
+
+	case "csv_parse", "csv_generate":
+		return e.validateCSVConfig(transformType, config)
+
+	case "xml":
+		return e.validateXMLConfig(config)
 	}
 
 	return nil
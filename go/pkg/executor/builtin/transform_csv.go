@@ -0,0 +1,417 @@
+package builtin
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Header modes of the csv_parse transformation.
+const (
+	CSVHeaderFirstRow = "first_row"
+	CSVHeaderNone     = "none"
+	CSVHeaderAuto     = "auto"
+)
+
+// csvNumberPattern matches numbers without leading zeros, so that codes such as
+// "007" or "01234" stay strings.
+var csvNumberPattern = regexp.MustCompile(`^[-+]?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][-+]?[0-9]+)?$`)
+
+// csvParse parses CSV into an array of row objects.
+//
+// Config:
+//   - csv: CSV text (default: the node input: a string, bytes, or an object with one of the
+//     fields csv, content, data, body, text or result; base64 content of file_to_bytes is decoded)
+//   - delimiter: Field delimiter, a single character, "\t" or "auto" (default: ",")
+//   - header: "first_row", "none" or "auto" (default: "first_row")
+//   - columns: Column names; replace the header row, or name the columns without one
+//   - infer_types: Convert numbers, booleans and empty fields to JSON types (default: true)
+//   - trim_spaces: Trim spaces around fields (default: true)
+//   - skip_empty_rows: Skip rows whose fields are all empty (default: true)
+//   - comment: Lines starting with this character are ignored
+func (e *TransformExecutor) csvParse(config map[string]any, input any) (any, error) {
+	text, err := csvText(config, input)
+	if err != nil {
+		return nil, err
+	}
+
+	delimiter := e.GetStringDefault(config, "delimiter", ",")
+	if delimiter == "auto" {
+		delimiter = detectCSVDelimiter(text)
+	}
+
+	reader := csv.NewReader(strings.NewReader(strings.TrimPrefix(text, "\uFEFF")))
+	reader.Comma = csvDelimiterRune(delimiter)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	if comment := e.GetStringDefault(config, "comment", ""); comment != "" {
+		reader.Comment, _ = utf8.DecodeRuneInString(comment)
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+
+	trimSpaces := e.GetBoolDefault(config, "trim_spaces", true)
+	skipEmpty := e.GetBoolDefault(config, "skip_empty_rows", true)
+	filtered := records[:0]
+	for _, record := range records {
+		if trimSpaces {
+			for i := range record {
+				record[i] = strings.TrimSpace(record[i])
+			}
+		}
+		if skipEmpty && isBlankCSVRecord(record) {
+			continue
+		}
+		filtered = append(filtered, record)
+	}
+	records = filtered
+
+	var headers []string
+	switch e.GetStringDefault(config, "header", CSVHeaderFirstRow) {
+	case CSVHeaderFirstRow:
+		if len(records) > 0 {
+			headers, records = records[0], records[1:]
+		}
+	case CSVHeaderAuto:
+		if len(records) > 0 && looksLikeCSVHeader(records[0]) {
+			headers, records = records[0], records[1:]
+		}
+	}
+	if raw, ok := config["columns"]; ok {
+		if headers, err = toStringSlice(raw, "columns"); err != nil {
+			return nil, err
+		}
+	}
+
+	inferTypes := e.GetBoolDefault(config, "infer_types", true)
+	rows := make([]any, 0, len(records))
+	for _, record := range records {
+		row := make(map[string]any, len(record))
+		for i, field := range record {
+			name := fmt.Sprintf("col_%d", i)
+			if i < len(headers) && headers[i] != "" {
+				name = headers[i]
+			}
+			if inferTypes {
+				row[name] = inferCSVValue(field)
+			} else {
+				row[name] = field
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// csvGenerate serializes an array of objects or arrays to CSV text.
+//
+// Config:
+//   - rows: Rows to serialize (default: the node input, or its rows, result, data or items field)
+//   - columns: Column order; object fields not listed are left out (default: all fields, sorted)
+//   - include_header: Write a header row (default: true)
+//   - delimiter: Field delimiter, a single character or "\t" (default: ",")
+//   - crlf: End lines with \r\n instead of \n (default: false)
+func (e *TransformExecutor) csvGenerate(config map[string]any, input any) (any, error) {
+	source := input
+	if raw, ok := config["rows"]; ok {
+		source = raw
+	}
+	rows, err := csvRows(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []string
+	if raw, ok := config["columns"]; ok {
+		if columns, err = toStringSlice(raw, "columns"); err != nil {
+			return nil, err
+		}
+	} else {
+		seen := make(map[string]bool)
+		for _, row := range rows {
+			if obj, ok := row.(map[string]any); ok {
+				for key := range obj {
+					seen[key] = true
+				}
+			}
+		}
+		columns = sortedKeys(seen)
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Comma = csvDelimiterRune(e.GetStringDefault(config, "delimiter", ","))
+	writer.UseCRLF = e.GetBoolDefault(config, "crlf", false)
+
+	if e.GetBoolDefault(config, "include_header", true) && len(columns) > 0 {
+		if err := writer.Write(columns); err != nil {
+			return nil, fmt.Errorf("failed to write CSV header: %w", err)
+		}
+	}
+
+	for i, row := range rows {
+		var record []string
+		switch v := row.(type) {
+		case map[string]any:
+			record = make([]string, len(columns))
+			for j, column := range columns {
+				if record[j], err = formatCSVValue(v[column]); err != nil {
+					return nil, fmt.Errorf("row %d, column %s: %w", i, column, err)
+				}
+			}
+		case []any:
+			record = make([]string, len(v))
+			for j, value := range v {
+				if record[j], err = formatCSVValue(value); err != nil {
+					return nil, fmt.Errorf("row %d, column %d: %w", i, j, err)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("row %d must be an object or an array, got %T", i, row)
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, fmt.Errorf("failed to write CSV row %d: %w", i, err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// validateCSVConfig validates the options shared by csv_parse and csv_generate.
+func (e *TransformExecutor) validateCSVConfig(transformType string, config map[string]any) error {
+	delimiter := e.GetStringDefault(config, "delimiter", ",")
+	if !(transformType == "csv_parse" && delimiter == "auto") && delimiter != `\t` && utf8.RuneCountInString(delimiter) != 1 {
+		return fmt.Errorf("delimiter must be a single character or \\t")
+	}
+	if r := csvDelimiterRune(delimiter); r == '"' || r == '\r' || r == '\n' {
+		return fmt.Errorf("delimiter must not be a quote or a line break")
+	}
+
+	if raw, ok := config["columns"]; ok {
+		if _, err := toStringSlice(raw, "columns"); err != nil {
+			return err
+		}
+	}
+
+	if transformType == "csv_parse" {
+		switch header := e.GetStringDefault(config, "header", CSVHeaderFirstRow); header {
+		case CSVHeaderFirstRow, CSVHeaderNone, CSVHeaderAuto:
+		default:
+			return fmt.Errorf("header must be %q, %q or %q", CSVHeaderFirstRow, CSVHeaderNone, CSVHeaderAuto)
+		}
+		if comment := e.GetStringDefault(config, "comment", ""); utf8.RuneCountInString(comment) > 1 {
+			return fmt.Errorf("comment must be a single character")
+		}
+	}
+	return nil
+}
+
+// csvText returns the CSV text from the config or the node input.
+func csvText(config map[string]any, input any) (string, error) {
+	return transformInputText(config, input, "csv", "CSV")
+}
+
+// transformInputText returns text content from the config field, or from the node
+// input: a string, bytes, or an object with the field or one of content, data, body,
+// text or result (base64 content of file_to_bytes is decoded).
+func transformInputText(config map[string]any, input any, field, kind string) (string, error) {
+	if raw, ok := config[field]; ok {
+		text, isString := raw.(string)
+		if !isString {
+			return "", fmt.Errorf("%s must be a string", field)
+		}
+		return text, nil
+	}
+
+	switch v := input.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case map[string]any:
+		fields := []string{field, "content", "data", "body", "text", "result"}
+		for _, name := range fields {
+			switch content := v[name].(type) {
+			case string:
+				// file_to_bytes returns file content as base64 by default
+				if v["format"] == "base64" {
+					decoded, err := base64.StdEncoding.DecodeString(content)
+					if err != nil {
+						return "", fmt.Errorf("invalid base64 in %s: %w", name, err)
+					}
+					return string(decoded), nil
+				}
+				return content, nil
+			case []byte:
+				return string(content), nil
+			}
+		}
+		return "", fmt.Errorf("no %s found in input (tried fields %s); set %s in the config", kind, strings.Join(fields, ", "), field)
+	case nil:
+		return "", fmt.Errorf("no %s input; set %s in the config", kind, field)
+	default:
+		return "", fmt.Errorf("unsupported %s input type %T", kind, input)
+	}
+}
+
+// csvRows returns the rows to serialize from an array or an object wrapping one.
+func csvRows(source any) ([]any, error) {
+	switch v := source.(type) {
+	case []any:
+		return v, nil
+	case []map[string]any:
+		rows := make([]any, len(v))
+		for i, row := range v {
+			rows[i] = row
+		}
+		return rows, nil
+	case map[string]any:
+		for _, field := range []string{"rows", "result", "data", "items"} {
+			if nested, ok := v[field]; ok {
+				if rows, err := csvRows(nested); err == nil {
+					return rows, nil
+				}
+			}
+		}
+		return nil, fmt.Errorf("no rows found in input (tried fields rows, result, data, items); set rows in the config")
+	default:
+		return nil, fmt.Errorf("rows must be an array, got %T", source)
+	}
+}
+
+func csvDelimiterRune(delimiter string) rune {
+	if delimiter == `\t` {
+		return '\t'
+	}
+	r, _ := utf8.DecodeRuneInString(delimiter)
+	if r == utf8.RuneError {
+		return ','
+	}
+	return r
+}
+
+// detectCSVDelimiter picks the most frequent of , ; tab and | in the first line,
+// outside quoted fields.
+func detectCSVDelimiter(text string) string {
+	line := strings.TrimPrefix(text, "\uFEFF")
+	for _, l := range strings.Split(line, "\n") {
+		if strings.TrimSpace(l) != "" {
+			line = l
+			break
+		}
+	}
+
+	counts := map[rune]int{}
+	inQuotes := false
+	for _, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case !inQuotes && strings.ContainsRune(",;\t|", r):
+			counts[r]++
+		}
+	}
+
+	best, bestCount := ',', 0
+	for _, r := range []rune{',', ';', '\t', '|'} {
+		if counts[r] > bestCount {
+			best, bestCount = r, counts[r]
+		}
+	}
+	if best == '\t' {
+		return `\t`
+	}
+	return string(best)
+}
+
+// looksLikeCSVHeader reports whether a row is a header: its fields are non-empty,
+// unique and none of them is a number or a boolean.
+func looksLikeCSVHeader(record []string) bool {
+	seen := make(map[string]bool, len(record))
+	for _, field := range record {
+		if field == "" || seen[field] {
+			return false
+		}
+		if _, isString := inferCSVValue(field).(string); !isString {
+			return false
+		}
+		seen[field] = true
+	}
+	return true
+}
+
+// inferCSVValue converts a field to an int64, float64, bool or nil when it looks like one.
+func inferCSVValue(field string) any {
+	if field == "" {
+		return nil
+	}
+	switch strings.ToLower(field) {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if csvNumberPattern.MatchString(field) {
+		if !strings.ContainsAny(field, ".eE") {
+			if n, err := strconv.ParseInt(field, 10, 64); err == nil {
+				return n
+			}
+		}
+		if f, err := strconv.ParseFloat(field, 64); err == nil {
+			return f
+		}
+	}
+	return field
+}
+
+// formatCSVValue formats a value as a CSV field; objects and arrays are written as JSON.
+func formatCSVValue(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v), nil
+	case json.Number:
+		return v.String(), nil
+	case time.Time:
+		return v.Format(time.RFC3339), nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+}
+
+func isBlankCSVRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,153 @@
+package builtin
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformExecutor_CSVParse(t *testing.T) {
+	exec := NewTransformExecutor()
+
+	input := "id,name,price,active,zip\n1, Widget ,9.5,true,01234\n\n2,\"Gadget, large\",12,FALSE,\n"
+	result, err := exec.Execute(context.Background(), map[string]any{"type": "csv_parse"}, input)
+	require.NoError(t, err)
+
+	assert.Equal(t, []any{
+		map[string]any{"id": int64(1), "name": "Widget", "price": 9.5, "active": true, "zip": "01234"},
+		map[string]any{"id": int64(2), "name": "Gadget, large", "price": int64(12), "active": false, "zip": nil},
+	}, result)
+}
+
+func TestTransformExecutor_CSVParse_Options(t *testing.T) {
+	exec := NewTransformExecutor()
+
+	// auto delimiter and header detection, no type inference
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"type":        "csv_parse",
+		"csv":         "sku;qty\nA-1;3\n",
+		"delimiter":   "auto",
+		"header":      "auto",
+		"infer_types": false,
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []any{map[string]any{"sku": "A-1", "qty": "3"}}, result)
+
+	// a first row of numbers is data
+	result, err = exec.Execute(context.Background(), map[string]any{
+		"type":      "csv_parse",
+		"csv":       "1\t2\n3\t4",
+		"delimiter": `\t`,
+		"header":    "auto",
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []any{
+		map[string]any{"col_0": int64(1), "col_1": int64(2)},
+		map[string]any{"col_0": int64(3), "col_1": int64(4)},
+	}, result)
+
+	// columns name the fields of a file without header
+	result, err = exec.Execute(context.Background(), map[string]any{
+		"type":    "csv_parse",
+		"csv":     "# export\na,1\n",
+		"header":  "none",
+		"columns": []any{"letter", "number"},
+		"comment": "#",
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []any{map[string]any{"letter": "a", "number": int64(1)}}, result)
+}
+
+func TestTransformExecutor_CSVParse_FileInput(t *testing.T) {
+	exec := NewTransformExecutor()
+
+	// output of file_to_bytes
+	input := map[string]any{
+		"result": base64.StdEncoding.EncodeToString([]byte("email\nann@example.com\n")),
+		"format": "base64",
+	}
+	result, err := exec.Execute(context.Background(), map[string]any{"type": "csv_parse"}, input)
+	require.NoError(t, err)
+	assert.Equal(t, []any{map[string]any{"email": "ann@example.com"}}, result)
+
+	_, err = exec.Execute(context.Background(), map[string]any{"type": "csv_parse"}, map[string]any{"rows": 1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no CSV found in input")
+}
+
+func TestTransformExecutor_CSVGenerate(t *testing.T) {
+	exec := NewTransformExecutor()
+
+	input := []any{
+		map[string]any{"name": "Widget", "price": 9.5, "tags": []any{"a", "b"}},
+		map[string]any{"name": "Gadget, large", "price": float64(12), "stock": nil},
+	}
+	result, err := exec.Execute(context.Background(), map[string]any{"type": "csv_generate"}, input)
+	require.NoError(t, err)
+	assert.Equal(t, "name,price,stock,tags\nWidget,9.5,,\"[\"\"a\"\",\"\"b\"\"]\"\n\"Gadget, large\",12,,\n", result)
+
+	result, err = exec.Execute(context.Background(), map[string]any{
+		"type":           "csv_generate",
+		"rows":           map[string]any{"result": input},
+		"columns":        []any{"price", "name"},
+		"delimiter":      ";",
+		"include_header": false,
+		"crlf":           true,
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "9.5;Widget\r\n12;Gadget, large\r\n", result)
+
+	result, err = exec.Execute(context.Background(), map[string]any{"type": "csv_generate"}, []any{[]any{1, "x"}})
+	require.NoError(t, err)
+	assert.Equal(t, "1,x\n", result)
+
+	_, err = exec.Execute(context.Background(), map[string]any{"type": "csv_generate"}, []any{"x"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "row 0 must be an object or an array")
+}
+
+func TestTransformExecutor_CSVRoundTrip(t *testing.T) {
+	exec := NewTransformExecutor()
+
+	csvText := "amount,id,note\n10.25,7,\"multi\nline\"\n"
+	rows, err := exec.Execute(context.Background(), map[string]any{"type": "csv_parse"}, csvText)
+	require.NoError(t, err)
+
+	result, err := exec.Execute(context.Background(), map[string]any{"type": "csv_generate"}, rows)
+	require.NoError(t, err)
+	assert.Equal(t, csvText, result)
+}
+
+func TestTransformExecutor_CSVValidate(t *testing.T) {
+	exec := NewTransformExecutor()
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{"parse defaults", map[string]any{"type": "csv_parse"}, ""},
+		{"parse auto delimiter", map[string]any{"type": "csv_parse", "delimiter": "auto", "header": "auto"}, ""},
+		{"generate tab", map[string]any{"type": "csv_generate", "delimiter": `\t`}, ""},
+		{"generate auto delimiter", map[string]any{"type": "csv_generate", "delimiter": "auto"}, "delimiter"},
+		{"long delimiter", map[string]any{"type": "csv_parse", "delimiter": "::"}, "delimiter"},
+		{"quote delimiter", map[string]any{"type": "csv_parse", "delimiter": `"`}, "quote"},
+		{"invalid header", map[string]any{"type": "csv_parse", "header": "yes"}, "header"},
+		{"invalid columns", map[string]any{"type": "csv_generate", "columns": "a,b"}, "columns"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exec.Validate(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
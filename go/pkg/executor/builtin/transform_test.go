@@ -3,11 +3,13 @@ package builtin
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/smilemakc/mbflow/go/internal/application/template"
 	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -323,6 +325,34 @@ func TestTransformExecutor_JQ_WithStringInput(t *testing.T) {
 	assert.Equal(t, "Alice", result)
 }
 
+func TestTransformExecutor_PreserveNumbers(t *testing.T) {
+	exec := NewTransformExecutor()
+	ctx := executor.WithExecutionContext(context.Background(), &executor.ExecutionContextData{
+		NumberMode: models.NumberModePreserve,
+	})
+
+	// jq keeps integers beyond float64 precision from JSON string input
+	result, err := exec.Execute(ctx, map[string]any{
+		"type":   "jq",
+		"filter": ".order.id",
+	}, `{"order": {"id": 9007199254740993}}`)
+	require.NoError(t, err)
+	assert.Equal(t, "9007199254740993", fmt.Sprint(result))
+
+	// expressions see preserved numbers as int64/float64 and compute exact sums with decimal functions
+	input := map[string]any{
+		"quantity": json.Number("3"),
+		"price":    json.Number("19.99"),
+		"fee":      json.Number("0.10"),
+	}
+	result, err = exec.Execute(ctx, map[string]any{
+		"type":       "expression",
+		"expression": "{count: input.quantity + 1, total: decimalAdd(decimalMul(input.price, input.quantity), input.fee)}",
+	}, input)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"count": 4, "total": json.Number("60.07")}, result)
+}
+
 func TestTransformExecutor_CompleteWorkflow(t *testing.T) {
 	// Simulate a complete workflow with multiple transform nodes
 	exec := NewTransformExecutor()
@@ -538,3 +568,38 @@ func TestTransformExecutor_JQ_ErrorHandling(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to parse jq filter")
 }
+
+func TestTransformExecutor_ReusesCompiledPrograms(t *testing.T) {
+	exec := NewTransformExecutor()
+	jqConfig := map[string]any{"type": "jq", "filter": ".cached_jq_test + 1"}
+	exprConfig := map[string]any{"type": "expression", "expression": "input.cached_expr_test * 2"}
+
+	jqBefore, exprBefore := jqPrograms.Stats(), expressionPrograms.Stats()
+	for i := 1; i <= 3; i++ {
+		out, err := exec.Execute(context.Background(), jqConfig, map[string]any{"cached_jq_test": i})
+		require.NoError(t, err)
+		assert.Equal(t, i+1, out)
+
+		out, err = exec.Execute(context.Background(), exprConfig, map[string]any{"cached_expr_test": i})
+		require.NoError(t, err)
+		assert.Equal(t, i*2, out)
+	}
+	jqAfter, exprAfter := jqPrograms.Stats(), expressionPrograms.Stats()
+
+	assert.Equal(t, uint64(1), jqAfter.Misses-jqBefore.Misses)
+	assert.Equal(t, uint64(2), jqAfter.Hits-jqBefore.Hits)
+	assert.Equal(t, uint64(1), exprAfter.Misses-exprBefore.Misses)
+	assert.Equal(t, uint64(2), exprAfter.Hits-exprBefore.Hits)
+}
+
+func TestCompileExpression_KeyedByInputType(t *testing.T) {
+	mapProgram, err := compileExpression("len(input)", map[string]any{"input": map[string]any{"a": 1}})
+	require.NoError(t, err)
+	sliceProgram, err := compileExpression("len(input)", map[string]any{"input": []any{1, 2}})
+	require.NoError(t, err)
+	assert.NotSame(t, mapProgram, sliceProgram)
+
+	again, err := compileExpression("len(input)", map[string]any{"input": []any{3}})
+	require.NoError(t, err)
+	assert.Same(t, sliceProgram, again)
+}
@@ -0,0 +1,478 @@
+package builtin
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/antchfx/xpath"
+)
+
+// Operations of the xml transformation.
+const (
+	XMLOperationParse     = "parse"
+	XMLOperationSerialize = "serialize"
+	XMLOperationXPath     = "xpath"
+)
+
+// xmlMapOptions controls how elements are converted to maps.
+type xmlMapOptions struct {
+	attributePrefix string
+	textKey         string
+	keepNamespaces  bool
+	inferTypes      bool
+	forceArray      map[string]bool
+}
+
+var defaultXMLMapOptions = xmlMapOptions{attributePrefix: "@", textKey: "#text"}
+
+// xmlTransform parses XML into a map, serializes a map into XML, or extracts values
+// with XPath, depending on the operation.
+//
+// Config:
+//   - operation: "parse", "serialize" or "xpath" (default: "parse")
+//   - xml: XML text for parse and xpath (default: the node input: a string, bytes, or an
+//     object with one of the fields xml, content, data, body, text or result; base64
+//     content of file_to_bytes is decoded)
+//   - attribute_prefix: Key prefix of attributes (default: "@")
+//   - text_key: Key of the text of elements that also have attributes or children (default: "#text")
+//   - keep_namespaces: Keep namespace prefixes in names and xmlns declarations as
+//     attributes when parsing (default: false)
+//   - force_array: Element names that are always parsed as arrays, even when they occur once
+//   - infer_types: Convert numeric and boolean text and attributes, and empty elements
+//     to JSON types when parsing (default: false)
+//   - data: Value to serialize (default: the node input)
+//   - root_name: Root element name when serializing a value that is not an object with
+//     a single key (default: "root")
+//   - namespaces: Object of prefix to namespace URI; declared on the root element when
+//     serializing and usable in xpath expressions ("" declares the default namespace)
+//   - declaration: Start serialized XML with an XML declaration (default: true)
+//   - indent: Indentation of serialized XML, e.g. "  " (default: none)
+//   - xpath: Expression, or object of name to expression, for the xpath operation
+//   - output: "text" to return the text of matched nodes, "object" to return matched
+//     elements converted like parse (default: "text")
+func (e *TransformExecutor) xmlTransform(config map[string]any, input any) (any, error) {
+	switch operation := e.GetStringDefault(config, "operation", XMLOperationParse); operation {
+	case XMLOperationParse:
+		return e.xmlParse(config, input)
+	case XMLOperationSerialize:
+		return e.xmlSerialize(config, input)
+	case XMLOperationXPath:
+		return e.xmlXPath(config, input)
+	default:
+		return nil, fmt.Errorf("unknown xml operation: %s", operation)
+	}
+}
+
+func (e *TransformExecutor) xmlParse(config map[string]any, input any) (any, error) {
+	doc, err := xmlDocument(config, input)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := e.xmlMapOptions(config)
+	if err != nil {
+		return nil, err
+	}
+
+	root := xmlRootElement(doc)
+	if root == nil {
+		return nil, fmt.Errorf("XML has no root element")
+	}
+	return map[string]any{opts.elementName(root): opts.toValue(root)}, nil
+}
+
+func (e *TransformExecutor) xmlSerialize(config map[string]any, input any) (any, error) {
+	opts, err := e.xmlMapOptions(config)
+	if err != nil {
+		return nil, err
+	}
+	namespaces, err := xmlNamespaces(config)
+	if err != nil {
+		return nil, err
+	}
+
+	source := input
+	if raw, ok := config["data"]; ok {
+		source = raw
+	}
+
+	rootName := e.GetStringDefault(config, "root_name", "root")
+	value := source
+	if obj, ok := source.(map[string]any); ok && len(obj) == 1 && config["root_name"] == nil {
+		for key, nested := range obj {
+			if !strings.HasPrefix(key, opts.attributePrefix) && key != opts.textKey {
+				rootName, value = key, nested
+			}
+		}
+	}
+	if items, ok := value.([]any); ok {
+		value = map[string]any{"item": items}
+	}
+
+	var rootAttrs []string
+	for _, prefix := range sortedKeys(namespaces) {
+		name := "xmlns"
+		if prefix != "" {
+			name += ":" + prefix
+		}
+		rootAttrs = append(rootAttrs, name, namespaces[prefix])
+	}
+
+	w := &xmlWriter{opts: opts, indent: e.GetStringDefault(config, "indent", "")}
+	if e.GetBoolDefault(config, "declaration", true) {
+		w.buf.WriteString(xml.Header)
+	}
+	if err := w.writeElement(rootName, value, 0, rootAttrs); err != nil {
+		return nil, err
+	}
+	return w.buf.String(), nil
+}
+
+func (e *TransformExecutor) xmlXPath(config map[string]any, input any) (any, error) {
+	doc, err := xmlDocument(config, input)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := e.xmlMapOptions(config)
+	if err != nil {
+		return nil, err
+	}
+	namespaces, err := xmlNamespaces(config)
+	if err != nil {
+		return nil, err
+	}
+
+	convert := func(nav *xmlquery.NodeNavigator) any {
+		return opts.scalar(nav.Value())
+	}
+	if e.GetStringDefault(config, "output", "text") == "object" {
+		convert = func(nav *xmlquery.NodeNavigator) any {
+			if nav.NodeType() == xpath.ElementNode && nav.Current().Type == xmlquery.ElementNode {
+				return opts.toValue(nav.Current())
+			}
+			return opts.scalar(nav.Value())
+		}
+	}
+
+	switch expressions := config["xpath"].(type) {
+	case string:
+		return evaluateXPathAs(doc, expressions, namespaces, convert)
+	case map[string]any:
+		result := make(map[string]any, len(expressions))
+		for name, raw := range expressions {
+			expr, ok := raw.(string)
+			if !ok {
+				return nil, fmt.Errorf("xpath %s must be a string", name)
+			}
+			value, err := evaluateXPathAs(doc, expr, namespaces, convert)
+			if err != nil {
+				return nil, fmt.Errorf("xpath %s: %w", name, err)
+			}
+			result[name] = value
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("xpath must be a string or an object of expressions")
+	}
+}
+
+// validateXMLConfig validates the options of the xml transformation.
+func (e *TransformExecutor) validateXMLConfig(config map[string]any) error {
+	operation := e.GetStringDefault(config, "operation", XMLOperationParse)
+	switch operation {
+	case XMLOperationParse, XMLOperationSerialize, XMLOperationXPath:
+	default:
+		return fmt.Errorf("operation must be %q, %q or %q", XMLOperationParse, XMLOperationSerialize, XMLOperationXPath)
+	}
+
+	if _, err := e.xmlMapOptions(config); err != nil {
+		return err
+	}
+	namespaces, err := xmlNamespaces(config)
+	if err != nil {
+		return err
+	}
+
+	switch operation {
+	case XMLOperationSerialize:
+		if raw, ok := config["root_name"]; ok {
+			name, _ := raw.(string)
+			if err := validateXMLName(name); err != nil {
+				return fmt.Errorf("root_name: %w", err)
+			}
+		}
+		for prefix := range namespaces {
+			if prefix == "" {
+				continue
+			}
+			if err := validateXMLName(prefix); err != nil || strings.Contains(prefix, ":") {
+				return fmt.Errorf("namespace prefix %q is not a valid XML name", prefix)
+			}
+		}
+
+	case XMLOperationXPath:
+		expressions := map[string]any{}
+		switch v := config["xpath"].(type) {
+		case string:
+			expressions["xpath"] = v
+		case map[string]any:
+			if len(v) == 0 {
+				return fmt.Errorf("xpath must not be empty")
+			}
+			expressions = v
+		case nil:
+			return fmt.Errorf("xpath is required for the xpath operation")
+		default:
+			return fmt.Errorf("xpath must be a string or an object of expressions")
+		}
+		for name, raw := range expressions {
+			expr, ok := raw.(string)
+			if !ok || expr == "" {
+				return fmt.Errorf("xpath %s must be a non-empty string", name)
+			}
+			// Expressions with templates are compiled once resolved
+			if strings.Contains(expr, "{{") {
+				continue
+			}
+			if _, err := xpath.CompileWithNS(expr, namespaces); err != nil {
+				return fmt.Errorf("xpath %s: %w", name, err)
+			}
+		}
+
+		switch output := e.GetStringDefault(config, "output", "text"); output {
+		case "text", "object":
+		default:
+			return fmt.Errorf("output must be \"text\" or \"object\"")
+		}
+	}
+	return nil
+}
+
+func (e *TransformExecutor) xmlMapOptions(config map[string]any) (xmlMapOptions, error) {
+	opts := xmlMapOptions{
+		attributePrefix: e.GetStringDefault(config, "attribute_prefix", defaultXMLMapOptions.attributePrefix),
+		textKey:         e.GetStringDefault(config, "text_key", defaultXMLMapOptions.textKey),
+		keepNamespaces:  e.GetBoolDefault(config, "keep_namespaces", false),
+		inferTypes:      e.GetBoolDefault(config, "infer_types", false),
+	}
+	if opts.attributePrefix == "" {
+		return opts, fmt.Errorf("attribute_prefix must not be empty")
+	}
+	if opts.textKey == "" {
+		return opts, fmt.Errorf("text_key must not be empty")
+	}
+	if raw, ok := config["force_array"]; ok {
+		names, err := toStringSlice(raw, "force_array")
+		if err != nil {
+			return opts, err
+		}
+		opts.forceArray = make(map[string]bool, len(names))
+		for _, name := range names {
+			opts.forceArray[name] = true
+		}
+	}
+	return opts, nil
+}
+
+// xmlNamespaces returns the prefix to namespace URI bindings of the config.
+func xmlNamespaces(config map[string]any) (map[string]string, error) {
+	namespaces := map[string]string{}
+	raw, ok := config["namespaces"]
+	if !ok || raw == nil {
+		return namespaces, nil
+	}
+	ns, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("namespaces must be an object")
+	}
+	for prefix, uri := range ns {
+		s, ok := uri.(string)
+		if !ok {
+			return nil, fmt.Errorf("namespace %s must be a string", prefix)
+		}
+		namespaces[prefix] = s
+	}
+	return namespaces, nil
+}
+
+// xmlDocument parses the XML text from the config or the node input.
+func xmlDocument(config map[string]any, input any) (*xmlquery.Node, error) {
+	text, err := transformInputText(config, input, "xml", "XML")
+	if err != nil {
+		return nil, err
+	}
+	doc, err := xmlquery.Parse(strings.NewReader(text))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse XML: %w", err)
+	}
+	return doc, nil
+}
+
+func xmlRootElement(doc *xmlquery.Node) *xmlquery.Node {
+	for child := doc.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == xmlquery.ElementNode {
+			return child
+		}
+	}
+	return nil
+}
+
+func (o xmlMapOptions) elementName(node *xmlquery.Node) string {
+	if o.keepNamespaces && node.Prefix != "" {
+		return node.Prefix + ":" + node.Data
+	}
+	return node.Data
+}
+
+// toValue converts an element. Elements with only text become their text; otherwise
+// they become a map with child elements by name (repeated elements as arrays),
+// attributes under the attribute prefix and text under the text key.
+func (o xmlMapOptions) toValue(node *xmlquery.Node) any {
+	result := map[string]any{}
+	for _, attr := range node.Attr {
+		isDeclaration := attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns")
+		switch {
+		case isDeclaration && !o.keepNamespaces:
+			continue
+		case o.keepNamespaces && attr.Name.Space != "":
+			result[o.attributePrefix+attr.Name.Space+":"+attr.Name.Local] = o.scalar(attr.Value)
+		default:
+			result[o.attributePrefix+attr.Name.Local] = o.scalar(attr.Value)
+		}
+	}
+
+	var text strings.Builder
+	hasChildren := false
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		switch child.Type {
+		case xmlquery.ElementNode:
+			hasChildren = true
+			name := o.elementName(child)
+			value := o.toValue(child)
+			switch existing := result[name].(type) {
+			case nil:
+				if o.forceArray[name] {
+					result[name] = []any{value}
+				} else {
+					result[name] = value
+				}
+			case []any:
+				result[name] = append(existing, value)
+			default:
+				result[name] = []any{existing, value}
+			}
+		case xmlquery.TextNode, xmlquery.CharDataNode:
+			text.WriteString(child.Data)
+		}
+	}
+
+	content := strings.TrimSpace(text.String())
+	if !hasChildren && len(result) == 0 {
+		return o.scalar(content)
+	}
+	if content != "" {
+		result[o.textKey] = o.scalar(content)
+	}
+	return result
+}
+
+func (o xmlMapOptions) scalar(text string) any {
+	if o.inferTypes {
+		return inferCSVValue(text)
+	}
+	return text
+}
+
+// xmlWriter serializes values converted like xmlMapOptions.toValue back to XML.
+type xmlWriter struct {
+	buf    strings.Builder
+	opts   xmlMapOptions
+	indent string
+}
+
+// writeElement writes one element. Objects are split into attributes, text and child
+// elements (in name order, arrays as repeated elements); other values become text.
+// attrs holds extra attribute name and value pairs.
+func (w *xmlWriter) writeElement(name string, value any, depth int, attrs []string) error {
+	if err := validateXMLName(name); err != nil {
+		return err
+	}
+
+	var text string
+	var children []string
+	obj, isObject := value.(map[string]any)
+	if isObject {
+		for _, key := range sortedKeys(obj) {
+			switch {
+			case key == w.opts.textKey:
+				s, err := formatCSVValue(obj[key])
+				if err != nil {
+					return fmt.Errorf("%s: %w", name, err)
+				}
+				text = s
+			case strings.HasPrefix(key, w.opts.attributePrefix):
+				s, err := formatCSVValue(obj[key])
+				if err != nil {
+					return fmt.Errorf("%s: %w", name, err)
+				}
+				attrName := strings.TrimPrefix(key, w.opts.attributePrefix)
+				if err := validateXMLName(attrName); err != nil {
+					return fmt.Errorf("%s: attribute %w", name, err)
+				}
+				attrs = append(attrs, attrName, s)
+			default:
+				children = append(children, key)
+			}
+		}
+	} else if value != nil {
+		s, err := formatCSVValue(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		text = s
+	}
+
+	w.buf.WriteString("<" + name)
+	for i := 0; i+1 < len(attrs); i += 2 {
+		w.buf.WriteString(" " + attrs[i] + `="`)
+		if err := xml.EscapeText(&w.buf, []byte(attrs[i+1])); err != nil {
+			return err
+		}
+		w.buf.WriteString(`"`)
+	}
+	if text == "" && len(children) == 0 {
+		w.buf.WriteString("/>")
+		return nil
+	}
+	w.buf.WriteString(">")
+	if err := xml.EscapeText(&w.buf, []byte(text)); err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		items, isArray := obj[child].([]any)
+		if !isArray {
+			items = []any{obj[child]}
+		}
+		for _, item := range items {
+			w.newline(depth + 1)
+			if err := w.writeElement(child, item, depth+1, nil); err != nil {
+				return err
+			}
+		}
+	}
+	if len(children) > 0 {
+		w.newline(depth)
+	}
+	w.buf.WriteString("</" + name + ">")
+	return nil
+}
+
+func (w *xmlWriter) newline(depth int) {
+	if w.indent == "" {
+		return
+	}
+	w.buf.WriteString("\n")
+	w.buf.WriteString(strings.Repeat(w.indent, depth))
+}
@@ -0,0 +1,228 @@
+package builtin
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOrderXML = `<?xml version="1.0"?>
+<order id="A-100" xmlns:x="urn:extra">
+  <customer>Acme</customer>
+  <item sku="B-2" qty="2">Bolt</item>
+  <item sku="C-3" qty="1">Cable</item>
+  <x:note>rush</x:note>
+  <total>42.50</total>
+  <empty/>
+</order>`
+
+func TestTransformExecutor_XMLParse(t *testing.T) {
+	exec := NewTransformExecutor()
+
+	result, err := exec.Execute(context.Background(), map[string]any{"type": "xml"}, testOrderXML)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"order": map[string]any{
+			"@id":      "A-100",
+			"customer": "Acme",
+			"item": []any{
+				map[string]any{"@sku": "B-2", "@qty": "2", "#text": "Bolt"},
+				map[string]any{"@sku": "C-3", "@qty": "1", "#text": "Cable"},
+			},
+			"note":  "rush",
+			"total": "42.50",
+			"empty": "",
+		},
+	}, result)
+}
+
+func TestTransformExecutor_XMLParse_Options(t *testing.T) {
+	exec := NewTransformExecutor()
+
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"type":             "xml",
+		"operation":        "parse",
+		"attribute_prefix": "_",
+		"text_key":         "value",
+		"keep_namespaces":  true,
+		"force_array":      []any{"customer"},
+		"infer_types":      true,
+	}, testOrderXML)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"order": map[string]any{
+			"_id":      "A-100",
+			"_xmlns:x": "urn:extra",
+			"customer": []any{"Acme"},
+			"x:note":   "rush",
+			"total":    42.5,
+			"empty":    nil,
+			"item": []any{
+				map[string]any{"_sku": "B-2", "_qty": int64(2), "value": "Bolt"},
+				map[string]any{"_sku": "C-3", "_qty": int64(1), "value": "Cable"},
+			},
+		},
+	}, result)
+}
+
+func TestTransformExecutor_XMLParse_Input(t *testing.T) {
+	exec := NewTransformExecutor()
+
+	// base64 content from file_to_bytes
+	result, err := exec.Execute(context.Background(), map[string]any{"type": "xml"}, map[string]any{
+		"content": base64.StdEncoding.EncodeToString([]byte("<a><b>1</b></a>")),
+		"format":  "base64",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"a": map[string]any{"b": "1"}}, result)
+
+	// the xml config field takes precedence over the input
+	result, err = exec.Execute(context.Background(), map[string]any{"type": "xml", "xml": "<c/>"}, "<ignored/>")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"c": ""}, result)
+
+	_, err = exec.Execute(context.Background(), map[string]any{"type": "xml"}, "<a><b></a>")
+	assert.ErrorContains(t, err, "failed to parse XML")
+
+	_, err = exec.Execute(context.Background(), map[string]any{"type": "xml"}, map[string]any{"rows": []any{}})
+	assert.ErrorContains(t, err, "no XML found in input")
+}
+
+func TestTransformExecutor_XMLSerialize(t *testing.T) {
+	exec := NewTransformExecutor()
+
+	data := map[string]any{
+		"order": map[string]any{
+			"@id":      "A-100",
+			"customer": "Acme & Sons",
+			"item": []any{
+				map[string]any{"@sku": "B-2", "#text": "Bolt"},
+				map[string]any{"@sku": "C-3", "#text": "Cable"},
+			},
+			"total": 42.5,
+			"empty": nil,
+		},
+	}
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"type":      "xml",
+		"operation": "serialize",
+		"indent":    "  ",
+	}, data)
+	require.NoError(t, err)
+
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<order id="A-100">
+  <customer>Acme &amp; Sons</customer>
+  <empty/>
+  <item sku="B-2">Bolt</item>
+  <item sku="C-3">Cable</item>
+  <total>42.5</total>
+</order>`, result)
+
+	// serialized XML parses back to the same structure
+	parsed, err := exec.Execute(context.Background(), map[string]any{"type": "xml", "infer_types": true}, result)
+	require.NoError(t, err)
+	order := parsed.(map[string]any)["order"].(map[string]any)
+	assert.Equal(t, "Acme & Sons", order["customer"])
+	assert.Equal(t, 42.5, order["total"])
+	assert.Len(t, order["item"], 2)
+}
+
+func TestTransformExecutor_XMLSerialize_Options(t *testing.T) {
+	exec := NewTransformExecutor()
+
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"type":        "xml",
+		"operation":   "serialize",
+		"data":        []any{"a", "b"},
+		"root_name":   "list",
+		"declaration": false,
+		"namespaces":  map[string]any{"": "urn:default", "x": "urn:extra"},
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, `<list xmlns="urn:default" xmlns:x="urn:extra"><item>a</item><item>b</item></list>`, result)
+
+	// objects with several keys are wrapped in the root element
+	result, err = exec.Execute(context.Background(), map[string]any{
+		"type":        "xml",
+		"operation":   "serialize",
+		"declaration": false,
+	}, map[string]any{"a": 1, "b": true})
+	require.NoError(t, err)
+	assert.Equal(t, `<root><a>1</a><b>true</b></root>`, result)
+
+	_, err = exec.Execute(context.Background(), map[string]any{
+		"type":      "xml",
+		"operation": "serialize",
+	}, map[string]any{"bad name": "x"})
+	assert.ErrorContains(t, err, "not a valid XML element name")
+}
+
+func TestTransformExecutor_XMLXPath(t *testing.T) {
+	exec := NewTransformExecutor()
+
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"type":      "xml",
+		"operation": "xpath",
+		"xpath": map[string]any{
+			"customer": "/order/customer",
+			"skus":     "//item/@sku",
+			"count":    "count(//item)",
+			"note":     "//e:note",
+			"missing":  "//nothing",
+		},
+		"namespaces": map[string]any{"e": "urn:extra"},
+	}, testOrderXML)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"customer": "Acme",
+		"skus":     []any{"B-2", "C-3"},
+		"count":    float64(2),
+		"note":     "rush",
+		"missing":  nil,
+	}, result)
+
+	// a single expression returns its value; output object converts elements
+	result, err = exec.Execute(context.Background(), map[string]any{
+		"type":      "xml",
+		"operation": "xpath",
+		"xpath":     "//item[@sku='B-2']",
+		"output":    "object",
+	}, testOrderXML)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"@sku": "B-2", "@qty": "2", "#text": "Bolt"}, result)
+}
+
+func TestTransformExecutor_XMLValidate(t *testing.T) {
+	exec := NewTransformExecutor()
+
+	valid := []map[string]any{
+		{"type": "xml"},
+		{"type": "xml", "operation": "serialize", "root_name": "orders", "namespaces": map[string]any{"x": "urn:x"}},
+		{"type": "xml", "operation": "xpath", "xpath": "//item/@sku"},
+		{"type": "xml", "operation": "xpath", "xpath": map[string]any{"a": "//{{input.tag}}"}},
+	}
+	for _, config := range valid {
+		assert.NoError(t, exec.Validate(config), config)
+	}
+
+	invalid := map[string]map[string]any{
+		"operation":         {"type": "xml", "operation": "convert"},
+		"xpath is required": {"type": "xml", "operation": "xpath"},
+		"xpath a":           {"type": "xml", "operation": "xpath", "xpath": map[string]any{"a": "//["}},
+		"output":            {"type": "xml", "operation": "xpath", "xpath": "/a", "output": "html"},
+		"root_name":         {"type": "xml", "operation": "serialize", "root_name": "1st"},
+		"namespace prefix":  {"type": "xml", "operation": "serialize", "namespaces": map[string]any{"a:b": "urn:x"}},
+		"namespaces":        {"type": "xml", "namespaces": "urn:x"},
+		"force_array":       {"type": "xml", "force_array": "item"},
+		"text_key":          {"type": "xml", "text_key": ""},
+	}
+	for message, config := range invalid {
+		assert.ErrorContains(t, exec.Validate(config), message)
+	}
+}
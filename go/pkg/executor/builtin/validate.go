@@ -0,0 +1,103 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+)
+
+const (
+	validateOnInvalidFail     = "fail"
+	validateOnInvalidRoute    = "route"
+	validateOnInvalidAnnotate = "annotate"
+)
+
+// ValidateExecutor validates a value against a JSON Schema.
+//
+// Config:
+//   - schema: The JSON Schema object (required); see jsonSchemaValidator for the keywords
+//   - data: The value to validate (default: the node input)
+//   - on_invalid: "fail" (default) fails the node, "route" completes it but follows only
+//     its "error" edges, "annotate" completes it normally with the violations in the output
+//
+// Output: {"valid": ..., "violations": [{"path", "keyword", "message"}], "data": ...}.
+type ValidateExecutor struct {
+	*executor.BaseExecutor
+}
+
+// NewValidateExecutor creates a new validate executor.
+func NewValidateExecutor() *ValidateExecutor {
+	return &ValidateExecutor{
+		BaseExecutor: executor.NewBaseExecutor("validate"),
+	}
+}
+
+// SideEffectFree reports true. Validation only inspects the input, so dry runs execute it.
+func (e *ValidateExecutor) SideEffectFree() bool { return true }
+
+// Execute validates the data against the schema.
+func (e *ValidateExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+	schema := config["schema"].(map[string]any)
+
+	data := input
+	if value, ok := config["data"]; ok {
+		data = value
+	}
+
+	// Typed Go values from other executors are compared as their JSON form
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode data: %w", err)
+	}
+	var normalized any
+	if err := json.Unmarshal(encoded, &normalized); err != nil {
+		return nil, fmt.Errorf("failed to decode data: %w", err)
+	}
+
+	found := validateJSONSchema(schema, normalized)
+	violations := make([]any, len(found))
+	for i, v := range found {
+		violations[i] = map[string]any{
+			"path":    v.Path,
+			"keyword": v.Keyword,
+			"message": v.Message,
+		}
+	}
+
+	onInvalid, _ := config["on_invalid"].(string)
+	if len(found) > 0 && (onInvalid == "" || onInvalid == validateOnInvalidFail) {
+		messages := make([]string, len(found))
+		for i, v := range found {
+			messages[i] = v.Path + ": " + v.Message
+		}
+		return nil, fmt.Errorf("schema validation failed: %s", strings.Join(messages, "; "))
+	}
+
+	return map[string]any{
+		"valid":      len(found) == 0,
+		"violations": violations,
+		"data":       data,
+	}, nil
+}
+
+// Validate validates the validate configuration.
+func (e *ValidateExecutor) Validate(config map[string]any) error {
+	if _, ok := config["schema"].(map[string]any); !ok {
+		return fmt.Errorf("schema is required and must be an object")
+	}
+
+	if onInvalid, ok := config["on_invalid"]; ok {
+		switch onInvalid {
+		case validateOnInvalidFail, validateOnInvalidRoute, validateOnInvalidAnnotate:
+		default:
+			return fmt.Errorf("on_invalid must be one of: fail, route, annotate")
+		}
+	}
+	return nil
+}
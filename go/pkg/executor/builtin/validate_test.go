@@ -0,0 +1,51 @@
+package builtin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateExecutor(t *testing.T) {
+	exec := NewValidateExecutor()
+	schema := map[string]any{
+		"type":     "object",
+		"required": []any{"email"},
+		"properties": map[string]any{
+			"age": map[string]any{"type": "integer", "minimum": 18},
+		},
+	}
+
+	out, err := exec.Execute(context.Background(), map[string]any{"schema": schema}, map[string]any{"email": "a@b.co", "age": 30})
+	require.NoError(t, err)
+	assert.Equal(t, true, out.(map[string]any)["valid"])
+
+	_, err = exec.Execute(context.Background(), map[string]any{"schema": schema}, map[string]any{"age": 12})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `$: missing required property "email"`)
+	assert.Contains(t, err.Error(), "$.age: must be >= 18")
+
+	for _, action := range []string{"route", "annotate"} {
+		out, err := exec.Execute(context.Background(), map[string]any{
+			"schema":     schema,
+			"data":       map[string]any{"age": 12},
+			"on_invalid": action,
+		}, nil)
+		require.NoError(t, err)
+		result := out.(map[string]any)
+		assert.Equal(t, false, result["valid"])
+		assert.Len(t, result["violations"], 2)
+		assert.Equal(t, map[string]any{"age": 12}, result["data"])
+	}
+}
+
+func TestValidateExecutor_Validate(t *testing.T) {
+	exec := NewValidateExecutor()
+
+	assert.NoError(t, exec.Validate(map[string]any{"schema": map[string]any{}, "on_invalid": "annotate"}))
+	assert.Error(t, exec.Validate(map[string]any{}))
+	assert.Error(t, exec.Validate(map[string]any{"schema": "object"}))
+	assert.Error(t, exec.Validate(map[string]any{"schema": map[string]any{}, "on_invalid": "ignore"}))
+}
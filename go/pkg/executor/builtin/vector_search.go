@@ -0,0 +1,238 @@
+package builtin
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+)
+
+const (
+	vectorSearchDefaultTopK = 5
+	vectorSearchMaxTopK     = 1000
+)
+
+// VectorSearchExecutor finds the documents nearest to a query in a Postgres table with
+// the pgvector extension, such as one filled by the embedding executor.
+type VectorSearchExecutor struct {
+	*executor.BaseExecutor
+	credentials CredentialResolver
+	client      *http.Client
+	openDB      func(conn pgvectorConnection) (*sql.DB, error)
+}
+
+// NewVectorSearchExecutor creates a new vector_search executor.
+// credentials resolves the database credential and may be nil, in which case only
+// servers without authentication can be used.
+func NewVectorSearchExecutor(credentials CredentialResolver) *VectorSearchExecutor {
+	return &VectorSearchExecutor{
+		BaseExecutor: executor.NewBaseExecutor("vector_search"),
+		credentials:  credentials,
+		client:       &http.Client{},
+		openDB:       openPGVector,
+	}
+}
+
+// Execute runs a similarity search.
+//
+// Config:
+//   - host: Server host (required)
+//   - port: Server port (default: 5432)
+//   - database: Database name
+//   - credential_id: ID of a credentials resource holding username/password
+//   - sslmode: "require" (default) | "verify-full" | "disable"
+//   - table: Table written by the embedding executor (required)
+//   - query: Text to search for (default: the input, or its query, text or content field)
+//   - vector: Query vector, instead of query
+//   - embedding: Provider settings embedding the query, as for the embedding executor:
+//     provider, api_key, model, base_url, dimensions, task_type (required with query)
+//   - metric: "cosine" (default) | "l2" | "inner_product"; use the metric of the stored index
+//   - top_k: Number of matches (default: 5)
+//   - filter: Object the document metadata must contain, e.g. {"source": "handbook"}
+//   - min_score: Matches scoring lower are dropped
+//   - include_vectors: Return the vectors of the matches (default: false)
+//   - timeout: Timeout in seconds (default: 30)
+//
+// Output:
+//   - matches: [{id, content, metadata, score, distance}] nearest first; score is the cosine
+//     similarity, the inner product, or 1 / (1 + distance) for l2
+//   - count: Number of matches
+//   - context: Contents of the matches separated by blank lines, for prompts
+//   - query: The query text
+//   - duration_ms: Execution duration
+func (e *VectorSearchExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	startTime := time.Now()
+
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+
+	query, vector, err := resolveQueryVector(ctx, e.BaseExecutor, e.client, config, input)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := parsePGVectorConnection(ctx, e.BaseExecutor, e.credentials, config)
+	if err != nil {
+		return nil, err
+	}
+	db, err := e.openDB(conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open connection to %s: %w", conn.Addr, err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	searchCtx, cancel := context.WithTimeout(ctx, conn.Timeout)
+	defer cancel()
+
+	metric := e.GetStringDefault(config, "metric", VectorMetricCosine)
+	filter, _ := config["filter"].(map[string]any)
+	withVectors := e.GetBoolDefault(config, "include_vectors", false)
+	table := e.GetStringDefault(config, "table", "")
+	found, err := searchPGVector(searchCtx, db, table, vector, metric, e.GetIntDefault(config, "top_k", vectorSearchDefaultTopK), filter, withVectors)
+	if err != nil {
+		return nil, fmt.Errorf("vector search in %s failed: %w", table, err)
+	}
+
+	minScore, hasMinScore := toFloat(config["min_score"])
+	matches := make([]any, 0, len(found))
+	contents := make([]string, 0, len(found))
+	for _, m := range found {
+		score := pgvectorScore(metric, m.Distance)
+		if hasMinScore && score < minScore {
+			continue
+		}
+		if m.Metadata == nil {
+			m.Metadata = make(map[string]any)
+		}
+		match := map[string]any{
+			"id":       m.ID,
+			"content":  m.Content,
+			"metadata": m.Metadata,
+			"score":    score,
+			"distance": m.Distance,
+		}
+		if withVectors {
+			match["vector"] = m.Vector
+		}
+		matches = append(matches, match)
+		contents = append(contents, m.Content)
+	}
+
+	return map[string]any{
+		"matches":     matches,
+		"count":       len(matches),
+		"context":     strings.Join(contents, "\n\n"),
+		"query":       query,
+		"duration_ms": time.Since(startTime).Milliseconds(),
+	}, nil
+}
+
+// Validate validates the vector_search executor configuration.
+func (e *VectorSearchExecutor) Validate(config map[string]any) error {
+	if err := validatePGVectorConnection(config, ""); err != nil {
+		return err
+	}
+
+	if err := validateQueryVector(e.BaseExecutor, config); err != nil {
+		return err
+	}
+
+	if metric := e.GetStringDefault(config, "metric", VectorMetricCosine); pgvectorOperators[metric].operator == "" {
+		return fmt.Errorf("invalid metric: %s (valid: cosine, l2, inner_product)", metric)
+	}
+	if topK := e.GetIntDefault(config, "top_k", vectorSearchDefaultTopK); topK < 1 || topK > vectorSearchMaxTopK {
+		return fmt.Errorf("top_k must be between 1 and %d", vectorSearchMaxTopK)
+	}
+	if raw, ok := config["filter"]; ok && raw != nil {
+		if _, ok := raw.(map[string]any); !ok {
+			return fmt.Errorf("filter must be an object")
+		}
+	}
+	if raw, ok := config["min_score"]; ok {
+		if _, ok := toFloat(raw); !ok {
+			return fmt.Errorf("min_score must be a number")
+		}
+	}
+	if e.GetIntDefault(config, "timeout", 30) < 1 {
+		return fmt.Errorf("timeout must be positive")
+	}
+	return nil
+}
+
+// validateQueryVector checks that config has a query vector, or the embedding settings
+// to embed the query text.
+func validateQueryVector(base *executor.BaseExecutor, config map[string]any) error {
+	if raw, ok := config["vector"]; ok {
+		_, err := toVector(raw)
+		return err
+	}
+	settings, ok := config["embedding"].(map[string]any)
+	if !ok {
+		return fmt.Errorf("embedding settings are required to search by query text (or set vector)")
+	}
+	return validateEmbeddingConfig(base, settings, "embedding.")
+}
+
+// resolveQueryVector returns the query text and its vector, embedding the text when no
+// vector is given. Config must have passed validateQueryVector.
+func resolveQueryVector(ctx context.Context, base *executor.BaseExecutor, client *http.Client, config map[string]any, input any) (string, []float64, error) {
+	if raw, ok := config["vector"]; ok {
+		vector, err := toVector(raw)
+		return "", vector, err
+	}
+
+	query := base.GetStringDefault(config, "query", "")
+	if query == "" {
+		switch v := input.(type) {
+		case string:
+			query = v
+		case map[string]any:
+			for _, key := range []string{"query", "text", "content"} {
+				if text, ok := v[key].(string); ok && text != "" {
+					query = text
+					break
+				}
+			}
+		}
+	}
+	if strings.TrimSpace(query) == "" {
+		return "", nil, fmt.Errorf("query is required (or an input with query, text or content)")
+	}
+
+	settings := config["embedding"].(map[string]any) // Checked by validateQueryVector
+	result, err := embedTexts(ctx, client, newEmbeddingRequest(base, settings, "RETRIEVAL_QUERY", 1), []string{query})
+	if err != nil {
+		return "", nil, err
+	}
+	return query, result.vectors[0], nil
+}
+
+// toVector converts an array of numbers into a vector.
+func toVector(raw any) ([]float64, error) {
+	switch v := raw.(type) {
+	case []float64:
+		if len(v) > 0 {
+			return v, nil
+		}
+	case []any:
+		if len(v) == 0 {
+			break
+		}
+		vector := make([]float64, len(v))
+		for i, item := range v {
+			f, ok := toFloat(item)
+			if !ok {
+				return nil, fmt.Errorf("vector[%d] must be a number", i)
+			}
+			vector[i] = f
+		}
+		return vector, nil
+	}
+	return nil, fmt.Errorf("vector must be a non-empty array of numbers")
+}
@@ -0,0 +1,142 @@
+package builtin
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMockVectorSearchExecutor returns a vector_search executor connected to go-sqlmock.
+func newMockVectorSearchExecutor(t *testing.T) (*VectorSearchExecutor, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	exec := NewVectorSearchExecutor(nil)
+	exec.openDB = func(pgvectorConnection) (*sql.DB, error) {
+		return db, nil
+	}
+	return exec, mock
+}
+
+func TestVectorSearchExecutor_Query(t *testing.T) {
+	server := newOpenAIEmbeddingServer(t, nil)
+	defer server.Close()
+
+	exec, mock := newMockVectorSearchExecutor(t)
+	rows := sqlmock.NewRows([]string{"id", "content", "metadata", "distance"}).
+		AddRow("doc-1#0", "Refunds take 5 days", []byte(`{"source":"handbook"}`), 0.1).
+		AddRow("doc-1#4", "Refunds go to the card", []byte(`{"source":"handbook"}`), 0.25).
+		AddRow("doc-2#1", "Unrelated", []byte(`{}`), 0.7)
+	mock.ExpectQuery(`SELECT id, content, metadata, embedding <=> \$1::vector AS distance FROM "chunks" WHERE metadata @> \$3::jsonb ORDER BY embedding <=> \$1::vector LIMIT \$2`).
+		WithArgs("[14,1,0.5]", 3, `{"source":"handbook"}`).
+		WillReturnRows(rows)
+	mock.ExpectClose()
+
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"host":      "pg.internal",
+		"table":     "chunks",
+		"top_k":     3,
+		"filter":    map[string]any{"source": "handbook"},
+		"min_score": 0.5,
+		"embedding": map[string]any{"api_key": "sk-test", "base_url": server.URL + "/v1"},
+	}, map[string]any{"query": "refund policy?"})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	output := result.(map[string]any)
+	assert.Equal(t, "refund policy?", output["query"])
+	assert.Equal(t, 2, output["count"])
+	assert.Equal(t, "Refunds take 5 days\n\nRefunds go to the card", output["context"])
+
+	matches := output["matches"].([]any)
+	first := matches[0].(map[string]any)
+	assert.Equal(t, "doc-1#0", first["id"])
+	assert.InDelta(t, 0.9, first["score"], 1e-9)
+	assert.Equal(t, 0.1, first["distance"])
+	assert.Equal(t, map[string]any{"source": "handbook"}, first["metadata"])
+}
+
+func TestVectorSearchExecutor_Vector(t *testing.T) {
+	exec, mock := newMockVectorSearchExecutor(t)
+	rows := sqlmock.NewRows([]string{"id", "content", "metadata", "distance", "embedding"}).
+		AddRow("a", "first", nil, 2.0, "[1,0]")
+	mock.ExpectQuery(`SELECT id, content, metadata, embedding <-> \$1::vector AS distance, embedding::text FROM "public"."items" ORDER BY embedding <-> \$1::vector LIMIT \$2`).
+		WithArgs("[1,0.5]", 5).
+		WillReturnRows(rows)
+	mock.ExpectClose()
+
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"host":            "pg.internal",
+		"table":           "public.items",
+		"metric":          "l2",
+		"vector":          []any{1, 0.5},
+		"include_vectors": true,
+	}, nil)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	match := result.(map[string]any)["matches"].([]any)[0].(map[string]any)
+	assert.InDelta(t, 1.0/3, match["score"], 1e-9)
+	assert.Equal(t, []float64{1, 0}, match["vector"])
+	assert.Equal(t, map[string]any{}, match["metadata"])
+}
+
+func TestVectorSearchExecutor_MissingQuery(t *testing.T) {
+	exec, _ := newMockVectorSearchExecutor(t)
+	_, err := exec.Execute(context.Background(), map[string]any{
+		"host":      "pg.internal",
+		"table":     "chunks",
+		"embedding": map[string]any{"api_key": "sk-test"},
+	}, map[string]any{"other": "x"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "query is required")
+}
+
+func TestVectorSearchExecutor_Validate(t *testing.T) {
+	exec := NewVectorSearchExecutor(nil)
+	config := func(overrides map[string]any) map[string]any {
+		c := map[string]any{"host": "pg", "table": "chunks", "vector": []any{1, 2}}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+			} else {
+				c[k] = v
+			}
+		}
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{"valid vector", config(nil), ""},
+		{"valid query", config(map[string]any{"vector": nil, "embedding": map[string]any{"api_key": "k", "provider": "gemini"}}), ""},
+		{"missing host", config(map[string]any{"host": nil}), "host is required"},
+		{"missing table", config(map[string]any{"table": nil}), "table must be a table name"},
+		{"missing embedding", config(map[string]any{"vector": nil}), "embedding settings are required"},
+		{"embedding without key", config(map[string]any{"vector": nil, "embedding": map[string]any{}}), "embedding.api_key is required"},
+		{"empty vector", config(map[string]any{"vector": []any{}}), "non-empty array of numbers"},
+		{"bad vector", config(map[string]any{"vector": []any{1, "x"}}), "vector[1] must be a number"},
+		{"metric", config(map[string]any{"metric": "hamming"}), "invalid metric"},
+		{"top_k", config(map[string]any{"top_k": 0}), "top_k must be"},
+		{"filter", config(map[string]any{"filter": "source=faq"}), "filter must be an object"},
+		{"min_score", config(map[string]any{"min_score": "high"}), "min_score must be a number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exec.Validate(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,385 @@
+package builtin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// Operations of the qdrant and pinecone executors.
+const (
+	VectorStoreUpsert = "upsert"
+	VectorStoreQuery  = "query"
+	VectorStoreDelete = "delete"
+)
+
+const (
+	// vectorStoreBatchSize is the number of points sent per upsert request.
+	vectorStoreBatchSize = 100
+	// vectorStoreMaxPoints is the maximum number of points one node upserts or deletes.
+	vectorStoreMaxPoints = 10000
+	// vectorStoreDefaultTextKey is the metadata key holding the text of a point.
+	vectorStoreDefaultTextKey = "text"
+)
+
+// vectorStorePoint is a vector with its ID and metadata, as written to a vector database.
+type vectorStorePoint struct {
+	ID       string
+	Vector   []float64
+	Metadata map[string]any
+}
+
+// vectorStoreMatch is a point returned by a similarity query.
+type vectorStoreMatch struct {
+	ID       string
+	Score    float64
+	Metadata map[string]any
+	Vector   []float64
+}
+
+// validateVectorStoreConfig checks the settings shared by the qdrant and pinecone executors.
+func validateVectorStoreConfig(base *executor.BaseExecutor, config map[string]any) error {
+	for _, key := range []string{"api_key", "password"} {
+		if _, ok := config[key]; ok {
+			return fmt.Errorf("%s must not be set inline: store it in a credentials resource and reference it with credential_id", key)
+		}
+	}
+
+	switch operation := base.GetStringDefault(config, "operation", ""); operation {
+	case VectorStoreUpsert:
+		if raw, ok := config["points"]; ok && raw != nil {
+			if _, ok := raw.([]any); !ok {
+				return fmt.Errorf("points must be an array")
+			}
+		}
+	case VectorStoreQuery:
+		if err := validateQueryVector(base, config); err != nil {
+			return err
+		}
+		if topK := base.GetIntDefault(config, "top_k", vectorSearchDefaultTopK); topK < 1 || topK > vectorSearchMaxTopK {
+			return fmt.Errorf("top_k must be between 1 and %d", vectorSearchMaxTopK)
+		}
+		if raw, ok := config["min_score"]; ok {
+			if _, ok := toFloat(raw); !ok {
+				return fmt.Errorf("min_score must be a number")
+			}
+		}
+	case VectorStoreDelete:
+		_, hasIDs := config["ids"]
+		_, hasFilter := config["filter"]
+		if !hasIDs && !hasFilter {
+			return fmt.Errorf("ids or filter is required to delete")
+		}
+		if hasIDs {
+			if _, err := vectorStoreIDs(config["ids"]); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("invalid operation: %s (valid: upsert, query, delete)", operation)
+	}
+
+	if raw, ok := config["filter"]; ok && raw != nil {
+		if _, ok := raw.(map[string]any); !ok {
+			return fmt.Errorf("filter must be an object")
+		}
+	}
+	if base.GetIntDefault(config, "timeout", 30) < 1 {
+		return fmt.Errorf("timeout must be positive")
+	}
+	return nil
+}
+
+// resolveVectorStoreAPIKey loads an API key from an api_key credential, or from the api_key
+// field of a custom credential. An empty credentialID resolves to an empty key.
+func resolveVectorStoreAPIKey(ctx context.Context, credentials CredentialResolver, credentialID string) (string, error) {
+	if credentialID == "" {
+		return "", nil
+	}
+	if credentials == nil {
+		return "", fmt.Errorf("credential_id is set but credentials are not available")
+	}
+	if !credentialAttached(ctx, credentialID) {
+		return "", fmt.Errorf("credential %s is not attached to the workflow as a resource", credentialID)
+	}
+
+	cred, err := credentials.GetDecrypted(ctx, credentialID)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve credential %s: %w", credentialID, err)
+	}
+
+	var key string
+	switch cred.CredentialType {
+	case models.CredentialTypeAPIKey:
+		key = cred.GetAPIKey()
+	case models.CredentialTypeCustom:
+		key = cred.GetCustomValue("api_key")
+	default:
+		return "", fmt.Errorf("credential %s has unsupported type %s (expected api_key or custom)",
+			credentialID, cred.CredentialType)
+	}
+	if key == "" {
+		return "", fmt.Errorf("credential %s does not contain an API key", credentialID)
+	}
+	return key, nil
+}
+
+// vectorStorePoints collects the points to upsert from the points config, the input's
+// points, or the documents and embeddings returned by the embedding executor. The text
+// of a document is kept in its metadata under textKey.
+func vectorStorePoints(config map[string]any, input any, textKey string) ([]vectorStorePoint, error) {
+	raw, ok := config["points"]
+	if !ok || raw == nil {
+		m, _ := input.(map[string]any)
+		switch {
+		case m["points"] != nil:
+			raw = m["points"]
+		case m["documents"] != nil:
+			return embeddedDocumentPoints(m, textKey)
+		default:
+			raw = input
+		}
+	}
+
+	items, ok := raw.([]any)
+	if !ok {
+		return nil, fmt.Errorf("points must be an array of {id, vector, metadata} (or an input with points, or documents and embeddings)")
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no points to upsert")
+	}
+	if len(items) > vectorStoreMaxPoints {
+		return nil, fmt.Errorf("too many points: %d (max %d per node; use sub_workflow chunk_size for larger sets)", len(items), vectorStoreMaxPoints)
+	}
+
+	points := make([]vectorStorePoint, len(items))
+	for i, item := range items {
+		p, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("point %d must be an object", i)
+		}
+		if p["id"] == nil || fmt.Sprint(p["id"]) == "" {
+			return nil, fmt.Errorf("point %d has no id", i)
+		}
+		vectorRaw, ok := p["vector"]
+		if !ok {
+			vectorRaw = p["values"]
+		}
+		vector, err := toVector(vectorRaw)
+		if err != nil {
+			return nil, fmt.Errorf("point %d: %w", i, err)
+		}
+
+		metadata := make(map[string]any)
+		if m, ok := p["metadata"].(map[string]any); ok {
+			for k, v := range m {
+				metadata[k] = v
+			}
+		} else if m, ok := p["payload"].(map[string]any); ok {
+			for k, v := range m {
+				metadata[k] = v
+			}
+		}
+		if text, ok := p["text"].(string); ok {
+			metadata[textKey] = text
+		}
+		points[i] = vectorStorePoint{ID: fmt.Sprint(p["id"]), Vector: vector, Metadata: metadata}
+	}
+	return points, nil
+}
+
+// embeddedDocumentPoints pairs the documents of an embedding executor output with its embeddings.
+func embeddedDocumentPoints(output map[string]any, textKey string) ([]vectorStorePoint, error) {
+	docs, _ := output["documents"].([]any)
+	var embeddings []any
+	switch v := output["embeddings"].(type) {
+	case []any:
+		embeddings = v
+	case [][]float64:
+		for _, vector := range v {
+			embeddings = append(embeddings, vector)
+		}
+	}
+	if len(embeddings) == 0 {
+		return nil, fmt.Errorf("input documents have no embeddings (set include_vectors on the embedding node)")
+	}
+	if len(docs) != len(embeddings) {
+		return nil, fmt.Errorf("input has %d documents but %d embeddings", len(docs), len(embeddings))
+	}
+	if len(docs) > vectorStoreMaxPoints {
+		return nil, fmt.Errorf("too many points: %d (max %d per node; use sub_workflow chunk_size for larger sets)", len(docs), vectorStoreMaxPoints)
+	}
+
+	points := make([]vectorStorePoint, len(docs))
+	for i, item := range docs {
+		doc, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("document %d must be an object", i)
+		}
+		vector, err := toVector(embeddings[i])
+		if err != nil {
+			return nil, fmt.Errorf("embedding %d: %w", i, err)
+		}
+		metadata := make(map[string]any)
+		if m, ok := doc["metadata"].(map[string]any); ok {
+			for k, v := range m {
+				metadata[k] = v
+			}
+		}
+		if text, ok := doc["text"].(string); ok {
+			metadata[textKey] = text
+		}
+		points[i] = vectorStorePoint{ID: fmt.Sprint(doc["id"]), Vector: vector, Metadata: metadata}
+	}
+	return points, nil
+}
+
+// vectorStoreIDs converts the ids config into point IDs.
+func vectorStoreIDs(raw any) ([]string, error) {
+	var ids []string
+	switch v := raw.(type) {
+	case string:
+		ids = []string{v}
+	case []string:
+		ids = v
+	case []any:
+		for _, id := range v {
+			if id == nil {
+				return nil, fmt.Errorf("ids must not contain null")
+			}
+			ids = append(ids, fmt.Sprint(id))
+		}
+	default:
+		return nil, fmt.Errorf("ids must be an ID or an array of IDs")
+	}
+	if len(ids) > vectorStoreMaxPoints {
+		return nil, fmt.Errorf("too many ids: %d (max %d)", len(ids), vectorStoreMaxPoints)
+	}
+	return ids, nil
+}
+
+// vectorStoreQueryOutput builds the output of a query in the shape of vector_search, with
+// the text of each match, taken from its metadata under textKey, as its content.
+func vectorStoreQueryOutput(config map[string]any, query string, found []vectorStoreMatch, textKey string, withVectors bool) map[string]any {
+	minScore, hasMinScore := toFloat(config["min_score"])
+	matches := make([]any, 0, len(found))
+	contents := make([]string, 0, len(found))
+	for _, m := range found {
+		if hasMinScore && m.Score < minScore {
+			continue
+		}
+		metadata := make(map[string]any, len(m.Metadata))
+		for k, v := range m.Metadata {
+			if k != textKey {
+				metadata[k] = v
+			}
+		}
+		content, _ := m.Metadata[textKey].(string)
+		match := map[string]any{
+			"id":       m.ID,
+			"content":  content,
+			"metadata": metadata,
+			"score":    m.Score,
+		}
+		if withVectors {
+			match["vector"] = m.Vector
+		}
+		matches = append(matches, match)
+		if content != "" {
+			contents = append(contents, content)
+		}
+	}
+
+	return map[string]any{
+		"matches": matches,
+		"count":   len(matches),
+		"context": strings.Join(contents, "\n\n"),
+		"query":   query,
+	}
+}
+
+// vectorStoreRequest sends a JSON request to a vector database API and decodes the JSON
+// response into out, which may be nil.
+func vectorStoreRequest(ctx context.Context, client *http.Client, service, method, url string, headers map[string]string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s request: %w", service, err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", service, err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w", service, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 32<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", service, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if message := vectorStoreErrorMessage(respBody); message != "" {
+			return fmt.Errorf("%s API error (status %d): %s", service, resp.StatusCode, message)
+		}
+		return fmt.Errorf("%s API error (status %d)", service, resp.StatusCode)
+	}
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", service, err)
+	}
+	return nil
+}
+
+// vectorStoreErrorMessage extracts the message of an error response: Qdrant reports
+// {"status": {"error": ...}}, Pinecone {"message": ...} or {"error": {"message": ...}}.
+func vectorStoreErrorMessage(body []byte) string {
+	var resp struct {
+		Status  any    `json:"status"`
+		Message string `json:"message"`
+		Error   any    `json:"error"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return strings.TrimSpace(string(body[:min(len(body), 200)]))
+	}
+	if status, ok := resp.Status.(map[string]any); ok {
+		if message, ok := status["error"].(string); ok {
+			return message
+		}
+	}
+	if resp.Message != "" {
+		return resp.Message
+	}
+	switch e := resp.Error.(type) {
+	case string:
+		return e
+	case map[string]any:
+		if message, ok := e["message"].(string); ok {
+			return message
+		}
+	}
+	return ""
+}
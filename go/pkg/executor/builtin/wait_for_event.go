@@ -0,0 +1,82 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+)
+
+// DefaultWaitForEventTimeout is how long a wait_for_event node waits when no timeout is configured.
+const DefaultWaitForEventTimeout = 24 * time.Hour
+
+// WaitForEventExecutor suspends its branch of the workflow until an external event with a
+// correlation key is delivered (POST /api/v1/events/{correlation_key}) or until it times out.
+//
+// Config:
+//   - correlation_key: key the event is delivered with, e.g. "payment-{{input.order_id}}"
+//   - timeout: Go duration string or a number of seconds (default 24h)
+//
+// Output: {"correlation_key": ..., "event": <event payload>, "timed_out": false}, or
+// {"correlation_key": ..., "event": nil, "timed_out": true} on timeout. Edges with source
+// handle "event" or "timeout" follow only the matching outcome.
+//
+// Events are only delivered to stored executions, which are persisted while they wait;
+// executions that cannot be persisted (standalone, ephemeral) wait inline until the timeout.
+type WaitForEventExecutor struct {
+	*executor.BaseExecutor
+	now func() time.Time
+}
+
+// NewWaitForEventExecutor creates a new wait_for_event executor.
+func NewWaitForEventExecutor() *WaitForEventExecutor {
+	return &WaitForEventExecutor{
+		BaseExecutor: executor.NewBaseExecutor("wait_for_event"),
+		now:          time.Now,
+	}
+}
+
+// Execute suspends the node until the event arrives or the timeout is over.
+func (e *WaitForEventExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+
+	key := strings.TrimSpace(config["correlation_key"].(string))
+	if key == "" {
+		return nil, fmt.Errorf("correlation_key resolved to an empty string")
+	}
+
+	timeout := DefaultWaitForEventTimeout
+	if value, ok := config["timeout"]; ok {
+		d, err := parseDelayDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout: %w", err)
+		}
+		timeout = d
+	}
+
+	return nil, executor.SuspendForEvent(key, e.now().Add(timeout))
+}
+
+// Validate validates the wait_for_event configuration. Templated values are checked at execution.
+func (e *WaitForEventExecutor) Validate(config map[string]any) error {
+	key, ok := config["correlation_key"].(string)
+	if !ok || strings.TrimSpace(key) == "" {
+		return fmt.Errorf("correlation_key is required")
+	}
+
+	timeout, ok := config["timeout"]
+	if !ok {
+		return nil
+	}
+	if s, ok := timeout.(string); ok && strings.Contains(s, "{{") {
+		return nil
+	}
+	if _, err := parseDelayDuration(timeout); err != nil {
+		return fmt.Errorf("invalid timeout: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package builtin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForEventExecutor_Suspends(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	exec := NewWaitForEventExecutor()
+	exec.now = func() time.Time { return now }
+
+	tests := []struct {
+		name   string
+		config map[string]any
+		want   time.Duration
+	}{
+		{name: "default timeout", config: map[string]any{"correlation_key": "order-1"}, want: DefaultWaitForEventTimeout},
+		{name: "duration string", config: map[string]any{"correlation_key": "order-1", "timeout": "15m"}, want: 15 * time.Minute},
+		{name: "seconds", config: map[string]any{"correlation_key": " order-1 ", "timeout": 30.0}, want: 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := exec.Execute(context.Background(), tt.config, nil)
+
+			suspend, ok := executor.AsSuspend(err)
+			require.True(t, ok, "expected a suspension, got %v", err)
+			assert.Equal(t, "order-1", suspend.EventKey)
+			assert.Equal(t, now.Add(tt.want), suspend.ResumeAt)
+			assert.Equal(t, executor.EventOutput("order-1", nil, true), suspend.Output)
+		})
+	}
+}
+
+func TestWaitForEventExecutor_Validate(t *testing.T) {
+	exec := NewWaitForEventExecutor()
+
+	assert.NoError(t, exec.Validate(map[string]any{"correlation_key": "order-{{input.id}}", "timeout": "{{input.timeout}}"}))
+	assert.Error(t, exec.Validate(map[string]any{}))
+	assert.Error(t, exec.Validate(map[string]any{"correlation_key": "  "}))
+	assert.Error(t, exec.Validate(map[string]any{"correlation_key": "order-1", "timeout": "soon"}))
+	assert.Error(t, exec.Validate(map[string]any{"correlation_key": "order-1", "timeout": -5}))
+}
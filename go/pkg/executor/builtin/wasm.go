@@ -0,0 +1,308 @@
+package builtin
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/tetratelabs/wazero/sys"
+
+	"github.com/smilemakc/mbflow/go/internal/application/filestorage"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+)
+
+// Limits of the wasm executor.
+const (
+	wasmDefaultFunction  = "run"
+	wasmDefaultTimeoutMs = 5000
+	wasmMaxTimeoutMs     = 300000
+	wasmDefaultMemoryMB  = 64
+	wasmMaxMemoryMB      = 1024
+	wasmMaxModuleBytes   = 32 << 20
+	wasmMaxOutputBytes   = 16 << 20
+	wasmMaxLogBytes      = 64 << 10
+	wasmPageBytes        = 64 << 10
+)
+
+// WASMExecutor runs a function exported by a WebAssembly module in an embedded
+// wazero runtime. Modules are uploaded to file storage (or given inline) and
+// compiled once; each execution gets a fresh instance with a time limit and a
+// memory limit. Modules may import WASI, but only stdout and stderr are provided:
+// there is no filesystem, network, environment or clock beyond what WASI requires.
+//
+// The function receives JSON and returns JSON through the module memory:
+//
+//	alloc(size i32) -> ptr i32        ; or malloc, reserves size bytes for the input
+//	<function>(ptr i32, len i32) -> i64 ; returns the output as ptr << 32 | len
+type WASMExecutor struct {
+	*executor.BaseExecutor
+	storage filestorage.Manager
+	cache   wazero.CompilationCache
+}
+
+// NewWASMExecutor creates a new wasm executor.
+// storage may be nil, in which case modules can only be given inline.
+func NewWASMExecutor(storage filestorage.Manager) *WASMExecutor {
+	return &WASMExecutor{
+		BaseExecutor: executor.NewBaseExecutor("wasm"),
+		storage:      storage,
+		cache:        wazero.NewCompilationCache(),
+	}
+}
+
+// Execute calls the exported function with the JSON input.
+//
+// Config:
+//   - file_id: ID of the module (.wasm) in file storage
+//   - storage_id: Storage holding file_id (default: "default")
+//   - module: Base64-encoded module, instead of file_id
+//   - function: Exported function to call (default: "run")
+//   - input: Value passed to the function as JSON (default: the node input)
+//   - timeout_ms: Time limit in milliseconds (default: 5000, max: 300000)
+//   - max_memory_mb: Limit of the module memory in MB (default: 64, max: 1024)
+//
+// Output:
+//   - result: The JSON returned by the function (null when it returns nothing)
+//   - logs: Lines the module wrote to stdout and stderr (last 100)
+//   - function: The function that was called
+//   - duration_ms: Execution duration
+func (e *WASMExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	startTime := time.Now()
+
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+
+	code, err := e.loadModule(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	if raw, ok := config["input"]; ok {
+		input = raw
+	}
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("input is not JSON serializable: %w", err)
+	}
+
+	timeout := time.Duration(e.GetIntDefault(config, "timeout_ms", wasmDefaultTimeoutMs)) * time.Millisecond
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	memoryMB := e.GetIntDefault(config, "max_memory_mb", wasmDefaultMemoryMB)
+	runtime := wazero.NewRuntimeWithConfig(runCtx, wazero.NewRuntimeConfig().
+		WithCompilationCache(e.cache).
+		WithMemoryLimitPages(uint32(memoryMB*(1<<20)/wasmPageBytes)).
+		WithCloseOnContextDone(true))
+	defer runtime.Close(context.Background())
+
+	compiled, err := runtime.CompileModule(runCtx, code)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wasm module: %w", err)
+	}
+	if _, err := wasi_snapshot_preview1.Instantiate(runCtx, runtime); err != nil {
+		return nil, fmt.Errorf("failed to instantiate WASI: %w", err)
+	}
+
+	logs := &cappedBuffer{limit: wasmMaxLogBytes, keepTail: true}
+	module, err := runtime.InstantiateModule(runCtx, compiled, wazero.NewModuleConfig().
+		WithName("").
+		WithStdout(logs).
+		WithStderr(logs).
+		// Reactor modules (TinyGo -buildmode=c-shared, Rust cdylib) initialize in
+		// _initialize; _start of command modules would run main and exit.
+		WithStartFunctions("_initialize"))
+	if err != nil {
+		return nil, wasmError(ctx, runCtx, timeout, "failed to instantiate module", err)
+	}
+
+	function := e.GetStringDefault(config, "function", wasmDefaultFunction)
+	output, err := callWASMFunction(runCtx, module, function, payload)
+	if err != nil {
+		return nil, wasmError(ctx, runCtx, timeout, "function "+function+" failed", err)
+	}
+
+	var result any
+	if len(output) > 0 {
+		if err := executor.UnmarshalJSON(ctx, output, &result); err != nil {
+			return nil, fmt.Errorf("function output is not valid JSON: %w", err)
+		}
+	}
+
+	return map[string]any{
+		"result":      result,
+		"logs":        scriptPythonLogs(logs.String()),
+		"function":    function,
+		"duration_ms": time.Since(startTime).Milliseconds(),
+	}, nil
+}
+
+// Validate validates the wasm executor configuration.
+func (e *WASMExecutor) Validate(config map[string]any) error {
+	hasFile := e.GetStringDefault(config, "file_id", "") != ""
+	hasModule := e.GetStringDefault(config, "module", "") != ""
+	switch {
+	case hasFile && hasModule:
+		return fmt.Errorf("file_id and module are mutually exclusive")
+	case !hasFile && !hasModule:
+		return fmt.Errorf("file_id or module is required")
+	case hasModule:
+		if _, err := base64.StdEncoding.DecodeString(e.GetStringDefault(config, "module", "")); err != nil {
+			return fmt.Errorf("module must be base64-encoded: %w", err)
+		}
+	}
+
+	if raw, ok := config["function"]; ok {
+		if name, isString := raw.(string); !isString || name == "" {
+			return fmt.Errorf("function must be a non-empty string")
+		}
+	}
+	if timeout := e.GetIntDefault(config, "timeout_ms", wasmDefaultTimeoutMs); timeout < 1 || timeout > wasmMaxTimeoutMs {
+		return fmt.Errorf("timeout_ms must be between 1 and %d", wasmMaxTimeoutMs)
+	}
+	if memory := e.GetIntDefault(config, "max_memory_mb", wasmDefaultMemoryMB); memory < 1 || memory > wasmMaxMemoryMB {
+		return fmt.Errorf("max_memory_mb must be between 1 and %d", wasmMaxMemoryMB)
+	}
+
+	return nil
+}
+
+// Close releases the compiled modules.
+func (e *WASMExecutor) Close() error {
+	return e.cache.Close(context.Background())
+}
+
+// loadModule returns the module bytes from the config or from file storage.
+func (e *WASMExecutor) loadModule(ctx context.Context, config map[string]any) ([]byte, error) {
+	if encoded := e.GetStringDefault(config, "module", ""); encoded != "" {
+		code, _ := base64.StdEncoding.DecodeString(encoded)
+		if len(code) > wasmMaxModuleBytes {
+			return nil, fmt.Errorf("module exceeds %d bytes", wasmMaxModuleBytes)
+		}
+		return code, nil
+	}
+
+	if e.storage == nil {
+		return nil, fmt.Errorf("file_id is set but file storage is not available")
+	}
+
+	fileID := e.GetStringDefault(config, "file_id", "")
+	storage, err := e.storage.GetStorage(e.GetStringDefault(config, "storage_id", "default"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage: %w", err)
+	}
+	_, reader, err := storage.Get(ctx, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get module file %s: %w", fileID, err)
+	}
+	defer reader.Close()
+
+	code, err := io.ReadAll(io.LimitReader(reader, wasmMaxModuleBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read module file %s: %w", fileID, err)
+	}
+	if len(code) > wasmMaxModuleBytes {
+		return nil, fmt.Errorf("module file %s exceeds %d bytes", fileID, wasmMaxModuleBytes)
+	}
+	return code, nil
+}
+
+// callWASMFunction copies the payload into the module memory, calls the function
+// and reads its output.
+func callWASMFunction(ctx context.Context, module api.Module, name string, payload []byte) ([]byte, error) {
+	fn := module.ExportedFunction(name)
+	if fn == nil {
+		return nil, fmt.Errorf("module does not export function %q", name)
+	}
+	if def := fn.Definition(); !wasmSignature(def, []api.ValueType{api.ValueTypeI32, api.ValueTypeI32}, api.ValueTypeI64) {
+		return nil, fmt.Errorf("function %q must have the signature (i32, i32) -> i64", name)
+	}
+
+	alloc := module.ExportedFunction("alloc")
+	if alloc == nil {
+		alloc = module.ExportedFunction("malloc")
+	}
+	if alloc == nil {
+		return nil, fmt.Errorf("module does not export alloc or malloc")
+	}
+	if !wasmSignature(alloc.Definition(), []api.ValueType{api.ValueTypeI32}, api.ValueTypeI32) {
+		return nil, fmt.Errorf("%s must have the signature (i32) -> i32", alloc.Definition().ExportNames()[0])
+	}
+
+	memory := module.Memory()
+	if memory == nil {
+		return nil, fmt.Errorf("module does not export its memory")
+	}
+
+	ptr := uint32(0)
+	if len(payload) > 0 {
+		res, err := alloc.Call(ctx, uint64(len(payload)))
+		if err != nil {
+			return nil, err
+		}
+		ptr = uint32(res[0])
+		if !memory.Write(ptr, payload) {
+			return nil, fmt.Errorf("alloc returned an address outside the module memory")
+		}
+	}
+
+	res, err := fn.Call(ctx, uint64(ptr), uint64(len(payload)))
+	if err != nil {
+		return nil, err
+	}
+
+	outPtr, outLen := uint32(res[0]>>32), uint32(res[0])
+	if outLen > wasmMaxOutputBytes {
+		return nil, fmt.Errorf("output exceeds %d bytes", wasmMaxOutputBytes)
+	}
+	output, ok := memory.Read(outPtr, outLen)
+	if !ok {
+		return nil, fmt.Errorf("output is outside the module memory")
+	}
+	// Read returns a view of the memory, which is released with the runtime.
+	return append([]byte(nil), output...), nil
+}
+
+func wasmSignature(def api.FunctionDefinition, params []api.ValueType, result api.ValueType) bool {
+	got := def.ParamTypes()
+	if len(got) != len(params) || len(def.ResultTypes()) != 1 || def.ResultTypes()[0] != result {
+		return false
+	}
+	for i := range params {
+		if got[i] != params[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// wasmError turns an instantiation or call failure into an error, reporting
+// time limits and cancellation of the node rather than the runtime's exit code.
+func wasmError(parent, runCtx context.Context, timeout time.Duration, action string, err error) error {
+	var exitErr *sys.ExitError
+	if errors.As(err, &exitErr) {
+		switch exitErr.ExitCode() {
+		case sys.ExitCodeDeadlineExceeded:
+			if parent.Err() == nil {
+				return fmt.Errorf("module exceeded the time limit of %s", timeout)
+			}
+			return fmt.Errorf("module cancelled: %w", parent.Err())
+		case sys.ExitCodeContextCanceled:
+			return fmt.Errorf("module cancelled: %w", parent.Err())
+		default:
+			return fmt.Errorf("%s: module exited with code %d", action, exitErr.ExitCode())
+		}
+	}
+	if runCtx.Err() != nil && parent.Err() == nil {
+		return fmt.Errorf("module exceeded the time limit of %s", timeout)
+	}
+	return fmt.Errorf("%s: %w", action, err)
+}
@@ -0,0 +1,168 @@
+package builtin
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testWASMModule is the binary form of:
+//
+//	(module
+//	  (import "wasi_snapshot_preview1" "fd_write" (func $fd_write (param i32 i32 i32 i32) (result i32)))
+//	  (memory (export "memory") 1)
+//	  (global $heap (mut i32) (i32.const 1024))
+//	  (func (export "alloc") (param $size i32) (result i32)
+//	    global.get $heap
+//	    (global.set $heap (i32.add (global.get $heap) (local.get $size))))
+//	  ;; echo returns its input
+//	  (func (export "echo") (param $ptr i32) (param $len i32) (result i64) ...)
+//	  ;; log writes its input to stderr, then echoes it
+//	  (func (export "log") (param i32 i32) (result i64) ...)
+//	  (func (export "spin") (param i32 i32) (result i64) (loop (br 0)) unreachable)
+//	  (func (export "trap") (param i32 i32) (result i64) unreachable)
+//	  ;; garbage returns three zero bytes at address 0
+//	  (func (export "garbage") (param i32 i32) (result i64) (i64.const 3))
+//	  ;; grow grows the memory by 64 pages (4 MB), trapping when that fails, then echoes
+//	  (func (export "grow") (param i32 i32) (result i64) ...))
+const testWASMModule = "AGFzbQEAAAABFANgBH9/f38Bf2ABfwF/YAJ/fwF+AiMBFndhc2lfc25hcHNob3RfcHJldmlldzEIZmRfd3JpdGUAAAMIBwECAgICAgIFAwEAAQYHAX8BQYAICwc+CAZtZW1vcnkCAAVhbGxvYwABBGVjaG8AAgNsb2cAAwRzcGluAAQEdHJhcAAFB2dhcmJhZ2UABgRncm93AAcKawcLACMAIwAgAGokAAsMACAArUIghiABrYQLJQBBECAANgIAQRQgATYCAEECQRBBAUEYEAAaIACtQiCGIAGthAsIAANADAALAAsDAAALBABCAwsYAEHAAEAAQX9GBEAACyAArUIghiABrYQL"
+
+func TestWASMExecutor_Validate(t *testing.T) {
+	exec := NewWASMExecutor(nil)
+	t.Cleanup(func() { _ = exec.Close() })
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{"valid file", map[string]any{"file_id": "file-1"}, ""},
+		{"valid inline", map[string]any{"module": testWASMModule, "function": "echo"}, ""},
+		{"missing module", map[string]any{}, "file_id or module is required"},
+		{"both sources", map[string]any{"file_id": "file-1", "module": testWASMModule}, "mutually exclusive"},
+		{"invalid base64", map[string]any{"module": "not base64!"}, "base64"},
+		{"empty function", map[string]any{"file_id": "file-1", "function": ""}, "function"},
+		{"timeout too long", map[string]any{"file_id": "file-1", "timeout_ms": 300001}, "timeout_ms"},
+		{"memory too large", map[string]any{"file_id": "file-1", "max_memory_mb": 2048}, "max_memory_mb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exec.Validate(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestWASMExecutor_Execute(t *testing.T) {
+	exec := NewWASMExecutor(nil)
+	t.Cleanup(func() { _ = exec.Close() })
+
+	input := map[string]any{"order_id": "A-1", "items": []any{"x", "y"}}
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"module":   testWASMModule,
+		"function": "echo",
+	}, input)
+	require.NoError(t, err)
+
+	output := result.(map[string]any)
+	assert.Equal(t, map[string]any{"order_id": "A-1", "items": []any{"x", "y"}}, output["result"])
+	assert.Equal(t, "echo", output["function"])
+	assert.Equal(t, []any{}, output["logs"])
+
+	// input overrides the node input; output written to stderr is returned as logs
+	result, err = exec.Execute(context.Background(), map[string]any{
+		"module":   testWASMModule,
+		"function": "log",
+		"input":    "hello",
+	}, input)
+	require.NoError(t, err)
+	output = result.(map[string]any)
+	assert.Equal(t, "hello", output["result"])
+	assert.Equal(t, []any{`"hello"`}, output["logs"])
+}
+
+func TestWASMExecutor_FromFileStorage(t *testing.T) {
+	code, err := base64.StdEncoding.DecodeString(testWASMModule)
+	require.NoError(t, err)
+
+	manager := newMockManager()
+	storage, err := manager.GetStorage("plugins")
+	require.NoError(t, err)
+	entry, err := storage.Store(context.Background(), &models.FileEntry{Name: "echo.wasm"}, bytes.NewReader(code))
+	require.NoError(t, err)
+
+	exec := NewWASMExecutor(manager)
+	t.Cleanup(func() { _ = exec.Close() })
+
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"file_id":    entry.ID,
+		"storage_id": "plugins",
+		"function":   "echo",
+	}, []any{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, []any{float64(1), float64(2)}, result.(map[string]any)["result"])
+
+	_, err = NewWASMExecutor(nil).Execute(context.Background(), map[string]any{"file_id": entry.ID}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "file storage is not available")
+}
+
+func TestWASMExecutor_Errors(t *testing.T) {
+	exec := NewWASMExecutor(nil)
+	t.Cleanup(func() { _ = exec.Close() })
+	run := func(config map[string]any) error {
+		config["module"] = testWASMModule
+		_, err := exec.Execute(context.Background(), config, map[string]any{"a": 1})
+		return err
+	}
+
+	err := run(map[string]any{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `module does not export function "run"`)
+
+	err = run(map[string]any{"function": "alloc"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must have the signature (i32, i32) -> i64")
+
+	err = run(map[string]any{"function": "trap"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "function trap failed")
+
+	err = run(map[string]any{"function": "garbage"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "function output is not valid JSON")
+
+	// 64 more pages fit into the default limit, but not into 1 MB
+	require.NoError(t, run(map[string]any{"function": "grow"}))
+	err = run(map[string]any{"function": "grow", "max_memory_mb": 1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "function grow failed")
+
+	start := time.Now()
+	err = run(map[string]any{"function": "spin", "timeout_ms": 100})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "time limit of 100ms")
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	_, err = exec.Execute(ctx, map[string]any{"module": testWASMModule, "function": "spin"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "module cancelled")
+
+	_, err = exec.Execute(context.Background(), map[string]any{"module": base64.StdEncoding.EncodeToString([]byte("not wasm"))}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid wasm module")
+}
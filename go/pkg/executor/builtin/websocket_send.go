@@ -0,0 +1,324 @@
+package builtin
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+	"github.com/gorilla/websocket"
+
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+)
+
+// websocketDefaultMaxMessageSize limits the size of a received message unless configured.
+const websocketDefaultMaxMessageSize = 1 << 20
+
+// WebSocketSendExecutor connects to a WebSocket endpoint, sends a message and
+// optionally waits for a number of messages or for a message matching an
+// expression before completing, for integrating with streaming APIs.
+// Credentials are never part of the node config: authentication uses a credentials
+// resource referenced by ID.
+type WebSocketSendExecutor struct {
+	*executor.BaseExecutor
+	credentials CredentialResolver
+}
+
+// NewWebSocketSendExecutor creates a new websocket_send executor.
+// credentials may be nil, in which case only unauthenticated connections can be made.
+func NewWebSocketSendExecutor(credentials CredentialResolver) *WebSocketSendExecutor {
+	return &WebSocketSendExecutor{
+		BaseExecutor: executor.NewBaseExecutor("websocket_send"),
+		credentials:  credentials,
+	}
+}
+
+// Execute connects, sends the message and collects responses.
+//
+// Config:
+//   - url: WebSocket endpoint, ws:// or wss:// (required)
+//   - message: Message to send after connecting; strings are sent as-is, other values as JSON
+//   - message_type: "text" or "binary" (default: "text"); binary messages are base64 strings
+//   - headers: Additional handshake headers as an object of strings
+//   - credential_id: ID of a credentials resource sent as the Authorization header:
+//     api_key as "Bearer <key>", basic_auth as "Basic <base64>"; the credential must be
+//     attached to the workflow
+//   - subprotocols: Array of subprotocols to offer
+//   - wait_messages: Number of messages to receive before completing (default: 0)
+//   - until: Expression completing the node when it is true for a received message;
+//     it sees message (the parsed message), messages (all received so far) and input.
+//     With wait_messages set, it bounds how many messages are inspected for a match
+//   - timeout: Timeout in seconds for connecting and waiting (default: 30)
+//   - fail_on_timeout: Fail when the timeout passes before completion (default: true);
+//     when false, the messages received so far are returned
+//   - max_message_size: Maximum size of a received message in bytes (default: 1048576)
+//
+// Without wait_messages and until the node completes once the message is sent.
+//
+// Output:
+//   - messages: Received messages; JSON text messages are parsed, binary messages are base64
+//   - count: Number of received messages
+//   - match: Message that satisfied until, when set
+//   - timed_out: Whether the timeout passed before completion
+//   - subprotocol: Subprotocol selected by the server
+//   - duration_ms: Execution duration
+func (e *WebSocketSendExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	startTime := time.Now()
+
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+
+	var until *vm.Program
+	if condition := e.GetStringDefault(config, "until", ""); condition != "" {
+		program, err := compileWebSocketUntil(condition)
+		if err != nil {
+			return nil, err
+		}
+		until = program
+	}
+
+	header := http.Header{}
+	if headers, ok := config["headers"].(map[string]any); ok {
+		for key, value := range headers {
+			header.Set(key, value.(string))
+		}
+	}
+	if credentialID := e.GetStringDefault(config, "credential_id", ""); credentialID != "" {
+		authorization, err := resolveAuthorizationHeader(ctx, e.credentials, credentialID)
+		if err != nil {
+			return nil, err
+		}
+		header.Set("Authorization", authorization)
+	}
+	executor.InjectHeaders(ctx, header)
+
+	timeout := time.Duration(e.GetIntDefault(config, "timeout", 30)) * time.Second
+	connCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	dialer := websocket.Dialer{Proxy: http.ProxyFromEnvironment}
+	if raw, ok := config["subprotocols"].([]any); ok {
+		for _, protocol := range raw {
+			dialer.Subprotocols = append(dialer.Subprotocols, protocol.(string))
+		}
+	}
+
+	url := e.GetStringDefault(config, "url", "")
+	conn, resp, err := dialer.DialContext(connCtx, url, header)
+	if err != nil {
+		if resp != nil {
+			return nil, fmt.Errorf("websocket handshake failed: HTTP %d", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("websocket connection failed: %w", err)
+	}
+	defer conn.Close()
+	conn.SetReadLimit(int64(e.GetIntDefault(config, "max_message_size", websocketDefaultMaxMessageSize)))
+
+	// Closing the connection unblocks reads once the timeout passes or the execution is cancelled.
+	stop := context.AfterFunc(connCtx, func() { conn.Close() })
+	defer stop()
+
+	if message, ok := config["message"]; ok && message != nil {
+		messageType, data, err := e.encodeMessage(config, message)
+		if err != nil {
+			return nil, err
+		}
+		if err := conn.WriteMessage(messageType, data); err != nil {
+			return nil, fmt.Errorf("failed to send message: %w", e.connError(connCtx, err))
+		}
+	}
+
+	waitMessages := e.GetIntDefault(config, "wait_messages", 0)
+	messages := []any{}
+	var match any
+	matched, timedOut := false, false
+
+	for (waitMessages > 0 || until != nil) && !matched && (waitMessages == 0 || len(messages) < waitMessages) {
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			if connCtx.Err() == nil || ctx.Err() != nil {
+				return nil, fmt.Errorf("websocket read failed after %d messages: %w", len(messages), e.connError(connCtx, err))
+			}
+			if e.GetBoolDefault(config, "fail_on_timeout", true) {
+				return nil, fmt.Errorf("websocket timed out after %s with %d messages received", timeout, len(messages))
+			}
+			timedOut = true
+			break
+		}
+
+		message := decodeWebSocketMessage(messageType, data)
+		messages = append(messages, message)
+
+		if until != nil {
+			ok, err := expr.Run(until, websocketUntilEnv{Message: message, Messages: messages, Input: input})
+			if err != nil {
+				return nil, fmt.Errorf("failed to evaluate until: %w", err)
+			}
+			if ok.(bool) {
+				matched = true
+				match = message
+			}
+		}
+	}
+
+	if until != nil && !matched && !timedOut {
+		return nil, fmt.Errorf("no message matched until within %d messages", len(messages))
+	}
+
+	if !timedOut {
+		deadline := time.Now().Add(time.Second)
+		_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), deadline)
+	}
+
+	result := map[string]any{
+		"messages":    messages,
+		"count":       len(messages),
+		"timed_out":   timedOut,
+		"subprotocol": conn.Subprotocol(),
+		"duration_ms": time.Since(startTime).Milliseconds(),
+	}
+	if until != nil {
+		result["match"] = match
+	}
+	return result, nil
+}
+
+// Validate validates the websocket_send executor configuration.
+func (e *WebSocketSendExecutor) Validate(config map[string]any) error {
+	if err := e.ValidateRequired(config, "url"); err != nil {
+		return err
+	}
+
+	url := e.GetStringDefault(config, "url", "")
+	if !strings.HasPrefix(url, "ws://") && !strings.HasPrefix(url, "wss://") {
+		return fmt.Errorf("url must be a ws or wss URL")
+	}
+
+	switch messageType := e.GetStringDefault(config, "message_type", "text"); messageType {
+	case "text":
+	case "binary":
+		if message, ok := config["message"]; ok && message != nil {
+			s, ok := message.(string)
+			if !ok {
+				return fmt.Errorf("binary message must be a base64 string")
+			}
+			if _, err := base64.StdEncoding.DecodeString(s); err != nil {
+				return fmt.Errorf("binary message must be a base64 string: %w", err)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported message_type %q (expected text or binary)", messageType)
+	}
+
+	if raw, ok := config["headers"]; ok && raw != nil {
+		headers, ok := raw.(map[string]any)
+		if !ok {
+			return fmt.Errorf("headers must be an object")
+		}
+		for key, value := range headers {
+			if strings.EqualFold(key, "authorization") {
+				return fmt.Errorf("authorization must not be set inline: store it in a credentials resource and reference it with credential_id")
+			}
+			if _, ok := value.(string); !ok {
+				return fmt.Errorf("header %s must be a string", key)
+			}
+		}
+	}
+
+	if raw, ok := config["subprotocols"]; ok && raw != nil {
+		protocols, ok := raw.([]any)
+		if !ok {
+			return fmt.Errorf("subprotocols must be an array")
+		}
+		for i, protocol := range protocols {
+			if s, ok := protocol.(string); !ok || s == "" {
+				return fmt.Errorf("subprotocols[%d] must be a non-empty string", i)
+			}
+		}
+	}
+
+	if e.GetIntDefault(config, "wait_messages", 0) < 0 {
+		return fmt.Errorf("wait_messages must not be negative")
+	}
+	if condition := e.GetStringDefault(config, "until", ""); condition != "" {
+		if _, err := compileWebSocketUntil(condition); err != nil {
+			return err
+		}
+	}
+	if timeout := e.GetIntDefault(config, "timeout", 30); timeout < 1 {
+		return fmt.Errorf("timeout must be at least 1 second")
+	}
+	if size := e.GetIntDefault(config, "max_message_size", websocketDefaultMaxMessageSize); size < 1 {
+		return fmt.Errorf("max_message_size must be positive")
+	}
+
+	return nil
+}
+
+// encodeMessage returns the frame type and payload of the configured message.
+func (e *WebSocketSendExecutor) encodeMessage(config map[string]any, message any) (int, []byte, error) {
+	if e.GetStringDefault(config, "message_type", "text") == "binary" {
+		data, err := base64.StdEncoding.DecodeString(message.(string))
+		if err != nil {
+			return 0, nil, fmt.Errorf("binary message must be a base64 string: %w", err)
+		}
+		return websocket.BinaryMessage, data, nil
+	}
+
+	if s, ok := message.(string); ok {
+		return websocket.TextMessage, []byte(s), nil
+	}
+	data, err := json.Marshal(message)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to encode message: %w", err)
+	}
+	return websocket.TextMessage, data, nil
+}
+
+// connError describes errors caused by closing the connection on timeout or cancellation.
+func (e *WebSocketSendExecutor) connError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	var closeErr *websocket.CloseError
+	if errors.As(err, &closeErr) {
+		return fmt.Errorf("connection closed by server (code %d)", closeErr.Code)
+	}
+	return err
+}
+
+// websocketUntilEnv is the environment of until expressions.
+type websocketUntilEnv struct {
+	Message  any   `expr:"message"`
+	Messages []any `expr:"messages"`
+	Input    any   `expr:"input"`
+}
+
+// compileWebSocketUntil compiles the until expression of a websocket_send node.
+func compileWebSocketUntil(condition string) (*vm.Program, error) {
+	program, err := expr.Compile(condition, expr.Env(websocketUntilEnv{}), expr.AsBool())
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile until: %w", err)
+	}
+	return program, nil
+}
+
+// decodeWebSocketMessage parses JSON text messages and returns other text messages as
+// strings and binary messages as base64.
+func decodeWebSocketMessage(messageType int, data []byte) any {
+	if messageType == websocket.BinaryMessage {
+		return base64.StdEncoding.EncodeToString(data)
+	}
+	var parsed any
+	if err := json.Unmarshal(data, &parsed); err == nil {
+		return parsed
+	}
+	return string(data)
+}
@@ -0,0 +1,256 @@
+package builtin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeWebSocketServer serves handle on a WebSocket endpoint and returns its ws:// URL
+// and the handshake headers of the last connection.
+func newFakeWebSocketServer(t *testing.T, handle func(conn *websocket.Conn)) (string, *http.Header) {
+	t.Helper()
+	seen := &http.Header{}
+	upgrader := websocket.Upgrader{Subprotocols: []string{"graphql-transport-ws"}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*seen = r.Header.Clone()
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		handle(conn)
+	}))
+	t.Cleanup(server.Close)
+	return "ws" + strings.TrimPrefix(server.URL, "http"), seen
+}
+
+// streamReplies answers the first message with the given messages, then waits for the client to close.
+func streamReplies(replies ...string) func(conn *websocket.Conn) {
+	return func(conn *websocket.Conn) {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+		for _, reply := range replies {
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(reply)); err != nil {
+				return
+			}
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func TestWebSocketSendExecutor_Validate(t *testing.T) {
+	exec := NewWebSocketSendExecutor(nil)
+	base := func(extra map[string]any) map[string]any {
+		config := map[string]any{"url": "wss://example.com/stream"}
+		for k, v := range extra {
+			config[k] = v
+		}
+		return config
+	}
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{"valid", base(map[string]any{"message": map[string]any{"type": "subscribe"}, "wait_messages": 2}), ""},
+		{"valid until", base(map[string]any{"until": `message.type == "done"`}), ""},
+		{"valid binary", base(map[string]any{"message_type": "binary", "message": "AAEC"}), ""},
+		{"missing url", map[string]any{"message": "hi"}, "url"},
+		{"http url", base(map[string]any{"url": "https://example.com"}), "ws or wss"},
+		{"unsupported message type", base(map[string]any{"message_type": "json"}), "unsupported message_type"},
+		{"binary not base64", base(map[string]any{"message_type": "binary", "message": "%%%"}), "base64"},
+		{"inline authorization", base(map[string]any{"headers": map[string]any{"authorization": "Bearer x"}}), "must not be set inline"},
+		{"non-string header", base(map[string]any{"headers": map[string]any{"X-Id": 1}}), "header X-Id"},
+		{"invalid subprotocols", base(map[string]any{"subprotocols": []any{""}}), "subprotocols[0]"},
+		{"negative wait", base(map[string]any{"wait_messages": -1}), "wait_messages"},
+		{"invalid until", base(map[string]any{"until": "message.type =="}), "failed to compile until"},
+		{"non-bool until", base(map[string]any{"until": "len(messages)"}), "failed to compile until"},
+		{"zero timeout", base(map[string]any{"timeout": 0}), "timeout"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exec.Validate(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestWebSocketSendExecutor_SendOnly(t *testing.T) {
+	received := make(chan string, 1)
+	url, _ := newFakeWebSocketServer(t, func(conn *websocket.Conn) {
+		_, data, err := conn.ReadMessage()
+		if err == nil {
+			received <- string(data)
+		}
+	})
+
+	exec := NewWebSocketSendExecutor(nil)
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"url":     url,
+		"message": map[string]any{"type": "ping", "id": 1},
+	}, nil)
+	require.NoError(t, err)
+
+	output := result.(map[string]any)
+	assert.Equal(t, 0, output["count"])
+	assert.Equal(t, []any{}, output["messages"])
+	assert.NotContains(t, output, "match")
+	assert.JSONEq(t, `{"type":"ping","id":1}`, <-received)
+}
+
+func TestWebSocketSendExecutor_WaitMessages(t *testing.T) {
+	url, seen := newFakeWebSocketServer(t, streamReplies(`{"seq":1}`, "plain text", `{"seq":3}`))
+
+	exec := NewWebSocketSendExecutor(nil)
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"url":           url,
+		"message":       "subscribe",
+		"wait_messages": 2,
+		"headers":       map[string]any{"X-Client": "mbflow"},
+		"subprotocols":  []any{"graphql-transport-ws"},
+	}, nil)
+	require.NoError(t, err)
+
+	output := result.(map[string]any)
+	assert.Equal(t, []any{map[string]any{"seq": float64(1)}, "plain text"}, output["messages"])
+	assert.Equal(t, 2, output["count"])
+	assert.Equal(t, false, output["timed_out"])
+	assert.Equal(t, "graphql-transport-ws", output["subprotocol"])
+	assert.Equal(t, "mbflow", seen.Get("X-Client"))
+}
+
+func TestWebSocketSendExecutor_Until(t *testing.T) {
+	url, _ := newFakeWebSocketServer(t, streamReplies(
+		`{"type":"progress","pct":50}`,
+		`{"type":"result","id":"job-1","value":42}`,
+		`{"type":"result","id":"job-2","value":7}`,
+	))
+
+	exec := NewWebSocketSendExecutor(nil)
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"url":     url,
+		"message": map[string]any{"job": "job-1"},
+		"until":   `message.type == "result" && message.id == input.job`,
+	}, map[string]any{"job": "job-1"})
+	require.NoError(t, err)
+
+	output := result.(map[string]any)
+	assert.Equal(t, 2, output["count"])
+	assert.Equal(t, map[string]any{"type": "result", "id": "job-1", "value": float64(42)}, output["match"])
+
+	_, err = exec.Execute(context.Background(), map[string]any{
+		"url":           url,
+		"message":       "go",
+		"until":         `message.type == "error"`,
+		"wait_messages": 2,
+	}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no message matched until within 2 messages")
+}
+
+func TestWebSocketSendExecutor_Timeout(t *testing.T) {
+	url, _ := newFakeWebSocketServer(t, streamReplies(`{"seq":1}`))
+	exec := NewWebSocketSendExecutor(nil)
+	config := map[string]any{"url": url, "message": "go", "wait_messages": 3, "timeout": 1}
+
+	_, err := exec.Execute(context.Background(), config, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out after 1s with 1 messages received")
+
+	config["fail_on_timeout"] = false
+	start := time.Now()
+	result, err := exec.Execute(context.Background(), config, nil)
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	output := result.(map[string]any)
+	assert.Equal(t, true, output["timed_out"])
+	assert.Equal(t, []any{map[string]any{"seq": float64(1)}}, output["messages"])
+}
+
+func TestWebSocketSendExecutor_ServerClose(t *testing.T) {
+	url, _ := newFakeWebSocketServer(t, func(conn *websocket.Conn) {
+		conn.ReadMessage()
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"seq":1}`))
+		conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "bye"))
+	})
+
+	exec := NewWebSocketSendExecutor(nil)
+	_, err := exec.Execute(context.Background(), map[string]any{"url": url, "message": "go", "wait_messages": 2}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "after 1 messages")
+	assert.Contains(t, err.Error(), "code 1008")
+}
+
+func TestWebSocketSendExecutor_Binary(t *testing.T) {
+	url, _ := newFakeWebSocketServer(t, func(conn *websocket.Conn) {
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		conn.WriteMessage(messageType, append(data, 0xff))
+		conn.ReadMessage()
+	})
+
+	exec := NewWebSocketSendExecutor(nil)
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"url":           url,
+		"message_type":  "binary",
+		"message":       "AAEC",
+		"wait_messages": 1,
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []any{"AAEC/w=="}, result.(map[string]any)["messages"])
+}
+
+func TestWebSocketSendExecutor_Credential(t *testing.T) {
+	url, seen := newFakeWebSocketServer(t, streamReplies())
+	cred := models.NewCredentialsResource("owner-1", "stream", models.CredentialTypeAPIKey)
+	cred.DecryptedData = map[string]string{"api_key": "sk-stream"}
+	exec := NewWebSocketSendExecutor(&fakeCredentialResolver{creds: map[string]*models.CredentialsResource{"cred-1": cred}})
+
+	_, err := exec.Execute(context.Background(), map[string]any{"url": url, "message": "hi", "credential_id": "cred-1"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer sk-stream", seen.Get("Authorization"))
+
+	ctx := executor.WithExecutionContext(context.Background(), &executor.ExecutionContextData{
+		Resources: map[string]any{"other": map[string]any{"id": "cred-2"}},
+	})
+	_, err = exec.Execute(ctx, map[string]any{"url": url, "message": "hi", "credential_id": "cred-1"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not attached")
+}
+
+func TestWebSocketSendExecutor_HandshakeRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(server.Close)
+
+	exec := NewWebSocketSendExecutor(nil)
+	_, err := exec.Execute(context.Background(), map[string]any{"url": "ws" + strings.TrimPrefix(server.URL, "http")}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP 401")
+}
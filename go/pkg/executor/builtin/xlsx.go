@@ -0,0 +1,530 @@
+package builtin
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/internal/application/filestorage"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+const (
+	xlsxOperationRead  = "read"
+	xlsxOperationWrite = "write"
+
+	xlsxMimeType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+	// xlsxMaxFileBytes caps the size of a workbook read from storage or config.
+	xlsxMaxFileBytes = 100 << 20
+)
+
+// XLSXExecutor reads Excel workbooks into row objects and writes arrays of rows
+// to formatted workbooks stored in file storage.
+type XLSXExecutor struct {
+	*executor.BaseExecutor
+	storage filestorage.Manager
+}
+
+// NewXLSXExecutor creates a new xlsx executor.
+// storage may be nil, in which case only workbooks given inline as base64 can be read.
+func NewXLSXExecutor(storage filestorage.Manager) *XLSXExecutor {
+	return &XLSXExecutor{
+		BaseExecutor: executor.NewBaseExecutor("xlsx"),
+		storage:      storage,
+	}
+}
+
+// Execute reads or writes a workbook.
+//
+// Config:
+//   - operation: "read" (default) | "write"
+//   - storage_id: Storage holding or receiving the file (default: "default")
+//
+// Read operation:
+//   - file_id: ID of the .xlsx file in file storage (default: the input, or its file_id field)
+//   - data: The workbook as base64, instead of file_id
+//   - sheet: Sheet to read (default: the first sheet)
+//   - all_sheets: Read every sheet (default: false)
+//   - header: Use the first row as column names (default: true); otherwise columns are named by letter
+//   - columns: Column names, replacing the header row
+//   - skip_rows: Rows to skip before the header (default: 0)
+//   - max_rows: Maximum number of data rows per sheet (default: 0, all)
+//   - skip_empty_rows: Leave out empty rows (default: true)
+//
+// Write operation:
+//   - file_name: Name of the stored file; ".xlsx" is appended when missing (required)
+//   - rows: Objects or arrays to write (default: the node input, or its rows, result, data or items field)
+//   - sheet: Sheet name (default: "Sheet1")
+//   - columns: Column keys, or objects {key, header, width, format} (default: all object fields, sorted)
+//   - sheets: Several sheets as [{name, rows, columns}], instead of rows and sheet
+//   - include_header: Write a bold header row (default: true)
+//   - freeze_header: Keep the header row visible when scrolling (default: true)
+//   - auto_filter: Add filter buttons to the header row (default: true)
+//   - access_scope, ttl, tags: As for bytes_to_file
+//
+// Column formats: "text", "integer", "decimal", "number", "percent", "date", "datetime",
+// or an Excel number format code such as "#,##0.00 \"EUR\"".
+//
+// Output (read):
+//   - success: true
+//   - sheet, columns, rows, row_count: The sheet read
+//   - sheets: Names of all sheets
+//   - data: With all_sheets, {sheet name: {columns, rows, row_count}}
+//   - duration_ms: Execution time
+//
+// Output (write):
+//   - success: true
+//   - file_id, storage_id, file_name, mime_type, size: The stored file
+//   - sheets: Names of the written sheets
+//   - row_count: Number of data rows written
+//   - duration_ms: Execution time
+func (e *XLSXExecutor) Execute(ctx context.Context, config map[string]any, input any) (any, error) {
+	startTime := time.Now()
+
+	if err := e.Validate(config); err != nil {
+		return nil, err
+	}
+
+	var (
+		result map[string]any
+		err    error
+	)
+	if e.GetStringDefault(config, "operation", xlsxOperationRead) == xlsxOperationWrite {
+		result, err = e.write(ctx, config, input)
+	} else {
+		result, err = e.read(ctx, config, input)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result["success"] = true
+	result["duration_ms"] = time.Since(startTime).Milliseconds()
+	return result, nil
+}
+
+// Validate validates the xlsx executor configuration.
+func (e *XLSXExecutor) Validate(config map[string]any) error {
+	switch operation := e.GetStringDefault(config, "operation", xlsxOperationRead); operation {
+	case xlsxOperationRead:
+		if e.GetIntDefault(config, "skip_rows", 0) < 0 {
+			return fmt.Errorf("skip_rows must be >= 0")
+		}
+		if e.GetIntDefault(config, "max_rows", 0) < 0 {
+			return fmt.Errorf("max_rows must be >= 0")
+		}
+		if raw, ok := config["columns"]; ok {
+			if _, err := toStringSlice(raw, "columns"); err != nil {
+				return err
+			}
+		}
+
+	case xlsxOperationWrite:
+		if _, err := e.GetString(config, "file_name"); err != nil {
+			return fmt.Errorf("file_name is required")
+		}
+		if accessScope := e.GetStringDefault(config, "access_scope", "workflow"); !models.AccessScope(accessScope).IsValid() {
+			return fmt.Errorf("invalid access_scope: %s (must be: workflow, edge, result)", accessScope)
+		}
+		if e.GetIntDefault(config, "ttl", 0) < 0 {
+			return fmt.Errorf("ttl must be >= 0")
+		}
+
+		if raw, ok := config["sheets"]; ok {
+			sheets, ok := raw.([]any)
+			if !ok || len(sheets) == 0 {
+				return fmt.Errorf("sheets must be a non-empty array of {name, rows, columns}")
+			}
+			for i, item := range sheets {
+				sheet, ok := item.(map[string]any)
+				if !ok {
+					return fmt.Errorf("sheets[%d] must be an object", i)
+				}
+				name, _ := sheet["name"].(string)
+				if err := validateXLSXSheetName(name); err != nil {
+					return fmt.Errorf("sheets[%d]: %w", i, err)
+				}
+				if _, err := parseXLSXColumns(sheet["columns"]); err != nil {
+					return fmt.Errorf("sheets[%d]: %w", i, err)
+				}
+			}
+			return nil
+		}
+		if err := validateXLSXSheetName(e.GetStringDefault(config, "sheet", "Sheet1")); err != nil {
+			return err
+		}
+		if _, err := parseXLSXColumns(config["columns"]); err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("invalid operation: %s (valid: read, write)", operation)
+	}
+	return nil
+}
+
+// read parses the selected sheets into row objects.
+func (e *XLSXExecutor) read(ctx context.Context, config map[string]any, input any) (map[string]any, error) {
+	data, err := e.loadWorkbook(ctx, config, input)
+	if err != nil {
+		return nil, err
+	}
+	wb, err := openXLSX(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []string
+	if raw, ok := config["columns"]; ok {
+		columns, _ = toStringSlice(raw, "columns")
+	}
+	opts := xlsxReadOptions{
+		header:        e.GetBoolDefault(config, "header", true),
+		columns:       columns,
+		skipRows:      e.GetIntDefault(config, "skip_rows", 0),
+		maxRows:       e.GetIntDefault(config, "max_rows", 0),
+		skipEmptyRows: e.GetBoolDefault(config, "skip_empty_rows", true),
+	}
+
+	result := map[string]any{"sheets": wb.sheetNames()}
+
+	if e.GetBoolDefault(config, "all_sheets", false) {
+		tables := make(map[string]any, len(wb.sheets))
+		for _, name := range wb.sheetNames() {
+			table, err := readXLSXTable(wb, name, opts)
+			if err != nil {
+				return nil, err
+			}
+			tables[name] = table
+		}
+		result["data"] = tables
+		return result, nil
+	}
+
+	name := e.GetStringDefault(config, "sheet", wb.sheets[0].name)
+	table, err := readXLSXTable(wb, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	result["sheet"] = name
+	for k, v := range table {
+		result[k] = v
+	}
+	return result, nil
+}
+
+// write renders the rows and stores the workbook.
+func (e *XLSXExecutor) write(ctx context.Context, config map[string]any, input any) (map[string]any, error) {
+	if e.storage == nil {
+		return nil, fmt.Errorf("file storage is not available")
+	}
+
+	specs, err := e.sheetSpecs(config, input)
+	if err != nil {
+		return nil, err
+	}
+	data, err := buildXLSX(specs, xlsxWriteOptions{
+		Header:       e.GetBoolDefault(config, "include_header", true),
+		FreezeHeader: e.GetBoolDefault(config, "freeze_header", true),
+		AutoFilter:   e.GetBoolDefault(config, "auto_filter", true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	storageID := e.GetStringDefault(config, "storage_id", "default")
+	storage, err := e.storage.GetStorage(storageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage: %w", err)
+	}
+
+	fileName := e.GetStringDefault(config, "file_name", "")
+	if !strings.HasSuffix(strings.ToLower(fileName), ".xlsx") {
+		fileName += ".xlsx"
+	}
+	var tags []string
+	if raw, ok := config["tags"]; ok {
+		if tags, err = toStringSlice(raw, "tags"); err != nil {
+			return nil, err
+		}
+	}
+
+	entry := &models.FileEntry{
+		StorageID:   storageID,
+		Name:        fileName,
+		MimeType:    xlsxMimeType,
+		Size:        int64(len(data)),
+		AccessScope: models.AccessScope(e.GetStringDefault(config, "access_scope", "workflow")),
+		Tags:        tags,
+		Metadata:    make(map[string]any),
+	}
+	if ttl := e.GetIntDefault(config, "ttl", 0); ttl > 0 {
+		entry.SetTTL(time.Duration(ttl) * time.Second)
+	}
+
+	stored, err := storage.Store(ctx, entry, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to store file: %w", err)
+	}
+
+	names := make([]string, len(specs))
+	rowCount := 0
+	for i, spec := range specs {
+		names[i] = spec.Name
+		rowCount += len(spec.Rows)
+	}
+
+	return map[string]any{
+		"file_id":    stored.ID,
+		"storage_id": stored.StorageID,
+		"file_name":  stored.Name,
+		"mime_type":  stored.MimeType,
+		"size":       stored.Size,
+		"sheets":     names,
+		"row_count":  rowCount,
+	}, nil
+}
+
+// sheetSpecs builds the sheets to write from the sheets option, or from rows and sheet.
+func (e *XLSXExecutor) sheetSpecs(config map[string]any, input any) ([]xlsxSheetSpec, error) {
+	type sheetSource struct {
+		name    string
+		rows    any
+		columns any
+	}
+
+	var sources []sheetSource
+	if raw, ok := config["sheets"].([]any); ok {
+		for _, item := range raw {
+			sheet := item.(map[string]any) // Checked by Validate
+			name, _ := sheet["name"].(string)
+			sources = append(sources, sheetSource{name: name, rows: sheet["rows"], columns: sheet["columns"]})
+		}
+	} else {
+		rows := input
+		if raw, ok := config["rows"]; ok {
+			rows = raw
+		}
+		sources = append(sources, sheetSource{
+			name:    e.GetStringDefault(config, "sheet", "Sheet1"),
+			rows:    rows,
+			columns: config["columns"],
+		})
+	}
+
+	seen := make(map[string]bool, len(sources))
+	specs := make([]xlsxSheetSpec, len(sources))
+	for i, src := range sources {
+		key := strings.ToLower(src.name)
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate sheet name %q", src.name)
+		}
+		seen[key] = true
+
+		rows, err := csvRows(src.rows)
+		if err != nil {
+			return nil, fmt.Errorf("sheet %q: %w", src.name, err)
+		}
+		columns, err := parseXLSXColumns(src.columns)
+		if err != nil {
+			return nil, fmt.Errorf("sheet %q: %w", src.name, err)
+		}
+		if src.columns == nil {
+			columns = xlsxAutoColumns(rows)
+		}
+		specs[i] = xlsxSheetSpec{Name: src.name, Columns: columns, Rows: rows}
+	}
+	return specs, nil
+}
+
+// loadWorkbook returns the workbook bytes from the data option or from file storage.
+func (e *XLSXExecutor) loadWorkbook(ctx context.Context, config map[string]any, input any) ([]byte, error) {
+	if encoded := e.GetStringDefault(config, "data", ""); encoded != "" {
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("data must be base64: %w", err)
+		}
+		return data, nil
+	}
+
+	fileID := e.GetStringDefault(config, "file_id", "")
+	if fileID == "" {
+		switch v := input.(type) {
+		case string:
+			fileID = v
+		case map[string]any:
+			fileID, _ = v["file_id"].(string)
+		}
+	}
+	if fileID == "" {
+		return nil, fmt.Errorf("file_id or data is required")
+	}
+	if e.storage == nil {
+		return nil, fmt.Errorf("file_id is set but file storage is not available")
+	}
+
+	storage, err := e.storage.GetStorage(e.GetStringDefault(config, "storage_id", "default"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage: %w", err)
+	}
+	_, reader, err := storage.Get(ctx, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, xlsxMaxFileBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file content: %w", err)
+	}
+	if len(data) > xlsxMaxFileBytes {
+		return nil, fmt.Errorf("file exceeds %d bytes", xlsxMaxFileBytes)
+	}
+	return data, nil
+}
+
+// xlsxReadOptions controls how sheet rows become objects.
+type xlsxReadOptions struct {
+	header        bool
+	columns       []string
+	skipRows      int
+	maxRows       int
+	skipEmptyRows bool
+}
+
+// readXLSXTable reads a sheet into {columns, rows, row_count}.
+func readXLSXTable(wb *xlsxWorkbook, name string, opts xlsxReadOptions) (map[string]any, error) {
+	sheetRows, err := wb.readSheet(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Drop the skipped rows; without skip_empty_rows, gaps become empty rows
+	var records [][]any
+	lastNum := opts.skipRows
+	for _, row := range sheetRows {
+		if row.num <= opts.skipRows {
+			continue
+		}
+		if !opts.skipEmptyRows && len(records) > 0 {
+			for gap := lastNum + 1; gap < row.num; gap++ {
+				records = append(records, nil)
+			}
+		}
+		lastNum = row.num
+		records = append(records, row.values)
+	}
+
+	var headers []string
+	if opts.header && len(records) > 0 {
+		headers = xlsxHeaders(records[0])
+		records = records[1:]
+	}
+	if opts.columns != nil {
+		headers = opts.columns
+	}
+	if opts.maxRows > 0 && len(records) > opts.maxRows {
+		records = records[:opts.maxRows]
+	}
+
+	width := len(headers)
+	for _, record := range records {
+		width = max(width, len(record))
+	}
+	columns := make([]string, width)
+	for i := range columns {
+		columns[i] = xlsxColumnName(i)
+		if i < len(headers) && headers[i] != "" {
+			columns[i] = headers[i]
+		}
+	}
+
+	rows := make([]any, len(records))
+	for i, record := range records {
+		row := make(map[string]any, width)
+		for j, column := range columns {
+			var value any
+			if j < len(record) {
+				value = record[j]
+			}
+			row[column] = value
+		}
+		rows[i] = row
+	}
+
+	return map[string]any{
+		"columns":   columns,
+		"rows":      rows,
+		"row_count": len(rows),
+	}, nil
+}
+
+// xlsxHeaders turns a header row into column names; repeated names get a _2, _3... suffix.
+func xlsxHeaders(record []any) []string {
+	headers := make([]string, len(record))
+	counts := make(map[string]int)
+	for i, value := range record {
+		name, _ := formatCSVValue(value)
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		counts[name]++
+		if n := counts[name]; n > 1 {
+			name = fmt.Sprintf("%s_%d", name, n)
+		}
+		headers[i] = name
+	}
+	return headers
+}
+
+// parseXLSXColumns parses column definitions: keys, or objects {key, header, width, format}.
+func parseXLSXColumns(raw any) ([]xlsxColumn, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	if keys, ok := raw.([]string); ok {
+		columns := make([]xlsxColumn, len(keys))
+		for i, key := range keys {
+			columns[i] = xlsxColumn{Key: key}
+		}
+		return columns, nil
+	}
+
+	items, ok := raw.([]any)
+	if !ok {
+		return nil, fmt.Errorf("columns must be an array of keys or {key, header, width, format} objects")
+	}
+	columns := make([]xlsxColumn, len(items))
+	for i, item := range items {
+		switch v := item.(type) {
+		case string:
+			columns[i] = xlsxColumn{Key: v}
+		case map[string]any:
+			key, _ := v["key"].(string)
+			if key == "" {
+				return nil, fmt.Errorf("columns[%d].key is required", i)
+			}
+			header, _ := v["header"].(string)
+			format, _ := v["format"].(string)
+			column := xlsxColumn{Key: key, Header: header, Format: format}
+			if rawWidth, ok := v["width"]; ok {
+				width, ok := toFloat(rawWidth)
+				if !ok || width <= 0 || width > 255 {
+					return nil, fmt.Errorf("columns[%d].width must be a number between 0 and 255", i)
+				}
+				column.Width = width
+			}
+			columns[i] = column
+		default:
+			return nil, fmt.Errorf("columns[%d] must be a key or an object", i)
+		}
+	}
+	return columns, nil
+}
@@ -0,0 +1,829 @@
+package builtin
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Minimal reader and writer of the Office Open XML spreadsheet format (.xlsx): enough
+// to read cell values, shared strings and date formats from files written by Excel,
+// LibreOffice or Google Sheets, and to write formatted sheets.
+
+const (
+	xlsxMainNS = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	xlsxRelNS  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+
+	// xlsxMaxPartBytes caps the uncompressed size of one part, guarding against zip bombs.
+	xlsxMaxPartBytes = 256 << 20
+
+	// Excel sheet limits.
+	xlsxMaxRows         = 1048576
+	xlsxMaxColumns      = 16384
+	xlsxMaxCellText     = 32767
+	xlsxMaxSheetName    = 31
+	xlsxMaxAutoWidth    = 60
+	xlsxMinAutoWidth    = 8
+	xlsxWidthSampleRows = 1000
+)
+
+type xlsxWorkbookXML struct {
+	WorkbookPr struct {
+		Date1904 bool `xml:"date1904,attr"`
+	} `xml:"workbookPr"`
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelsXML struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxRichText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (r xlsxRichText) text() string {
+	if len(r.Runs) == 0 {
+		return r.T
+	}
+	var b strings.Builder
+	b.WriteString(r.T)
+	for _, run := range r.Runs {
+		b.WriteString(run.T)
+	}
+	return b.String()
+}
+
+type xlsxSharedStringsXML struct {
+	Items []xlsxRichText `xml:"si"`
+}
+
+type xlsxStylesXML struct {
+	NumFmts []struct {
+		ID   int    `xml:"numFmtId,attr"`
+		Code string `xml:"formatCode,attr"`
+	} `xml:"numFmts>numFmt"`
+	CellXfs []struct {
+		NumFmtID int `xml:"numFmtId,attr"`
+	} `xml:"cellXfs>xf"`
+}
+
+type xlsxSheetXML struct {
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			Ref    string       `xml:"r,attr"`
+			Style  int          `xml:"s,attr"`
+			Type   string       `xml:"t,attr"`
+			Value  string       `xml:"v"`
+			Inline xlsxRichText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// xlsxSheetRef is a sheet of a workbook and the zip path of its part.
+type xlsxSheetRef struct {
+	name string
+	path string
+}
+
+// xlsxRow is a row of cell values indexed by column; num is the 1-based row number.
+type xlsxRow struct {
+	num    int
+	values []any
+}
+
+// xlsxWorkbook is an opened .xlsx file.
+type xlsxWorkbook struct {
+	files         map[string]*zip.File
+	sheets        []xlsxSheetRef
+	sharedStrings []string
+	dateStyles    map[int]bool // cell style index -> formats a date
+	date1904      bool
+}
+
+// openXLSX parses the workbook structure, shared strings and styles of an .xlsx file.
+func openXLSX(data []byte) (*xlsxWorkbook, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("not a valid xlsx file: %w", err)
+	}
+
+	wb := &xlsxWorkbook{files: make(map[string]*zip.File, len(archive.File)), dateStyles: make(map[int]bool)}
+	for _, f := range archive.File {
+		wb.files[strings.TrimPrefix(f.Name, "/")] = f
+	}
+
+	var workbook xlsxWorkbookXML
+	if err := wb.decode("xl/workbook.xml", &workbook); err != nil {
+		return nil, err
+	}
+	var rels xlsxRelsXML
+	if err := wb.decode("xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, err
+	}
+
+	targets := make(map[string]string, len(rels.Relationships))
+	for _, rel := range rels.Relationships {
+		target := strings.TrimPrefix(rel.Target, "/")
+		if !strings.HasPrefix(rel.Target, "/") {
+			target = path.Join("xl", rel.Target)
+		}
+		targets[rel.ID] = target
+	}
+	for _, sheet := range workbook.Sheets {
+		if target, ok := targets[sheet.RID]; ok {
+			wb.sheets = append(wb.sheets, xlsxSheetRef{name: sheet.Name, path: target})
+		}
+	}
+	if len(wb.sheets) == 0 {
+		return nil, fmt.Errorf("xlsx file has no sheets")
+	}
+	wb.date1904 = workbook.WorkbookPr.Date1904
+
+	if _, ok := wb.files["xl/sharedStrings.xml"]; ok {
+		var sst xlsxSharedStringsXML
+		if err := wb.decode("xl/sharedStrings.xml", &sst); err != nil {
+			return nil, err
+		}
+		wb.sharedStrings = make([]string, len(sst.Items))
+		for i, item := range sst.Items {
+			wb.sharedStrings[i] = item.text()
+		}
+	}
+
+	if _, ok := wb.files["xl/styles.xml"]; ok {
+		var styles xlsxStylesXML
+		if err := wb.decode("xl/styles.xml", &styles); err != nil {
+			return nil, err
+		}
+		customDates := make(map[int]bool)
+		for _, f := range styles.NumFmts {
+			customDates[f.ID] = isXLSXDateFormat(f.Code)
+		}
+		for i, xf := range styles.CellXfs {
+			if isXLSXBuiltinDateFormat(xf.NumFmtID) || customDates[xf.NumFmtID] {
+				wb.dateStyles[i] = true
+			}
+		}
+	}
+
+	return wb, nil
+}
+
+// sheetNames returns the names of the sheets in workbook order.
+func (wb *xlsxWorkbook) sheetNames() []string {
+	names := make([]string, len(wb.sheets))
+	for i, s := range wb.sheets {
+		names[i] = s.name
+	}
+	return names
+}
+
+// readSheet returns the non-empty rows of a sheet in row order.
+func (wb *xlsxWorkbook) readSheet(name string) ([]xlsxRow, error) {
+	var ref *xlsxSheetRef
+	for i := range wb.sheets {
+		if wb.sheets[i].name == name {
+			ref = &wb.sheets[i]
+			break
+		}
+	}
+	if ref == nil {
+		return nil, fmt.Errorf("sheet %q not found (sheets: %s)", name, strings.Join(wb.sheetNames(), ", "))
+	}
+
+	var sheet xlsxSheetXML
+	if err := wb.decode(ref.path, &sheet); err != nil {
+		return nil, err
+	}
+
+	rows := make([]xlsxRow, 0, len(sheet.Rows))
+	nextRow := 1
+	for _, r := range sheet.Rows {
+		row := xlsxRow{num: r.R}
+		if row.num == 0 {
+			row.num = nextRow
+		}
+		nextRow = row.num + 1
+
+		nextCol := 0
+		for _, c := range r.Cells {
+			col := nextCol
+			if c.Ref != "" {
+				parsed, ok := xlsxColumnIndex(c.Ref)
+				if !ok {
+					return nil, fmt.Errorf("sheet %q: invalid cell reference %q", name, c.Ref)
+				}
+				col = parsed
+			}
+			nextCol = col + 1
+
+			value, err := wb.cellValue(c.Type, c.Value, c.Inline, c.Style)
+			if err != nil {
+				return nil, fmt.Errorf("sheet %q, cell %s: %w", name, xlsxCellRef(col, row.num), err)
+			}
+			if value == nil {
+				continue
+			}
+			for len(row.values) <= col {
+				row.values = append(row.values, nil)
+			}
+			row.values[col] = value
+		}
+		if len(row.values) > 0 {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+// cellValue converts a cell to a string, number (int64 when integral), bool or date string.
+func (wb *xlsxWorkbook) cellValue(cellType, raw string, inline xlsxRichText, style int) (any, error) {
+	switch cellType {
+	case "s":
+		idx, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil || idx < 0 || idx >= len(wb.sharedStrings) {
+			return nil, fmt.Errorf("invalid shared string index %q", raw)
+		}
+		return wb.sharedStrings[idx], nil
+	case "inlineStr":
+		return inline.text(), nil
+	case "str", "e", "d":
+		// Formula results, errors such as #DIV/0! and ISO 8601 dates are kept as text
+		if raw == "" {
+			return nil, nil
+		}
+		return raw, nil
+	case "b":
+		return strings.TrimSpace(raw) == "1", nil
+	default:
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			return nil, nil
+		}
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", raw)
+		}
+		if wb.dateStyles[style] {
+			return xlsxSerialToTime(n, wb.date1904), nil
+		}
+		if n == math.Trunc(n) && math.Abs(n) < 1e15 {
+			return int64(n), nil
+		}
+		return n, nil
+	}
+}
+
+// decode unmarshals an XML part of the archive.
+func (wb *xlsxWorkbook) decode(name string, out any) error {
+	f, ok := wb.files[name]
+	if !ok {
+		return fmt.Errorf("xlsx file is missing %s", name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", name, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, xlsxMaxPartBytes+1))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if len(data) > xlsxMaxPartBytes {
+		return fmt.Errorf("%s exceeds %d bytes uncompressed", name, xlsxMaxPartBytes)
+	}
+	if err := xml.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return nil
+}
+
+// isXLSXBuiltinDateFormat reports whether a built-in number format shows a date or time.
+func isXLSXBuiltinDateFormat(id int) bool {
+	return (id >= 14 && id <= 22) || (id >= 45 && id <= 47)
+}
+
+// isXLSXDateFormat reports whether a custom number format code shows a date or time:
+// it has y, m, d, h or s outside quoted text, escapes and [color]/[locale] sections.
+func isXLSXDateFormat(code string) bool {
+	inQuote, inBracket := false, false
+	for i := 0; i < len(code); i++ {
+		ch := code[i]
+		switch {
+		case inQuote:
+			inQuote = ch != '"'
+		case inBracket:
+			inBracket = ch != ']'
+		case ch == '"':
+			inQuote = true
+		case ch == '[':
+			inBracket = true
+		case ch == '\\' || ch == '_' || ch == '*':
+			i++ // The next character is literal or padding
+		default:
+			switch ch | 0x20 { // lower case
+			case 'y', 'm', 'd', 'h', 's':
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// xlsxEpoch returns day zero of the workbook's date system.
+func xlsxEpoch(date1904 bool) time.Time {
+	if date1904 {
+		return time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+}
+
+// xlsxSerialToTime formats a date serial as "2006-01-02", or as "2006-01-02T15:04:05"
+// when it has a time part.
+func xlsxSerialToTime(serial float64, date1904 bool) string {
+	seconds := math.Round(serial * 86400)
+	t := xlsxEpoch(date1904).Add(time.Duration(seconds) * time.Second)
+	if math.Mod(seconds, 86400) == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02T15:04:05")
+}
+
+// xlsxTimeToSerial converts a time to a date serial of the 1900 date system.
+func xlsxTimeToSerial(t time.Time) float64 {
+	// Dates keep their wall-clock value: Excel has no time zones
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	return wall.Sub(xlsxEpoch(false)).Seconds() / 86400
+}
+
+// xlsxColumnIndex returns the 0-based column of a cell reference such as "AB12".
+func xlsxColumnIndex(ref string) (int, bool) {
+	col := 0
+	n := 0
+	for n < len(ref) {
+		ch := ref[n] | 0x20
+		if ch < 'a' || ch > 'z' {
+			break
+		}
+		col = col*26 + int(ch-'a'+1)
+		n++
+	}
+	if n == 0 || col > xlsxMaxColumns {
+		return 0, false
+	}
+	return col - 1, true
+}
+
+// xlsxColumnName returns the letters of a 0-based column: 0 -> "A", 27 -> "AB".
+func xlsxColumnName(col int) string {
+	name := ""
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = string(rune('A'+(col-1)%26)) + name
+	}
+	return name
+}
+
+// xlsxCellRef returns the reference of a cell, e.g. "B3" for column 1 of row 3.
+func xlsxCellRef(col, row int) string {
+	return xlsxColumnName(col) + strconv.Itoa(row)
+}
+
+// xlsxNamedFormats maps the format names accepted in column definitions to built-in number formats.
+var xlsxNamedFormats = map[string]int{
+	"text":     49, // @
+	"integer":  1,  // 0
+	"decimal":  2,  // 0.00
+	"number":   4,  // #,##0.00
+	"percent":  10, // 0.00%
+	"date":     14, // m/d/yyyy, localized by the spreadsheet application
+	"datetime": 22, // m/d/yyyy h:mm
+}
+
+// xlsxColumn is a column of a written sheet.
+type xlsxColumn struct {
+	Key    string
+	Header string
+	Width  float64 // 0 sizes the column to its content
+	Format string  // Name from xlsxNamedFormats or a custom number format code
+}
+
+// xlsxSheetSpec is a sheet to write.
+type xlsxSheetSpec struct {
+	Name    string
+	Columns []xlsxColumn
+	Rows    []any // Objects (read by column key) or arrays (by position)
+}
+
+// xlsxWriteOptions controls sheet formatting.
+type xlsxWriteOptions struct {
+	Header       bool // Write a bold header row
+	FreezeHeader bool
+	AutoFilter   bool
+}
+
+// xlsxWriter accumulates shared strings and number formats while sheets are rendered.
+type xlsxWriter struct {
+	strings     []string
+	stringIndex map[string]int
+	formats     []string       // Formats in style order; style index = 2 + position
+	formatStyle map[string]int // Format -> cell style index
+}
+
+// buildXLSX renders sheets into an .xlsx file.
+func buildXLSX(sheets []xlsxSheetSpec, opts xlsxWriteOptions) ([]byte, error) {
+	w := &xlsxWriter{stringIndex: make(map[string]int), formatStyle: make(map[string]int)}
+
+	sheetXML := make([][]byte, len(sheets))
+	var definedNames []string
+	for i, sheet := range sheets {
+		data, filterRef, err := w.renderSheet(sheet, opts)
+		if err != nil {
+			return nil, fmt.Errorf("sheet %q: %w", sheet.Name, err)
+		}
+		sheetXML[i] = data
+		if filterRef != "" {
+			definedNames = append(definedNames, fmt.Sprintf(
+				`<definedName name="_xlnm._FilterDatabase" localSheetId="%d" hidden="1">%s!%s</definedName>`,
+				i, xmlEscape(xlsxQuoteSheetName(sheet.Name)), filterRef))
+		}
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	add := func(name, content string) error {
+		f, err := archive.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(f, content)
+		return err
+	}
+
+	var contentTypes, workbook, workbookRels strings.Builder
+	contentTypes.WriteString(xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`<Override PartName="/xl/sharedStrings.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sharedStrings+xml"/>`)
+	workbook.WriteString(xml.Header + `<workbook xmlns="` + xlsxMainNS + `" xmlns:r="` + xlsxRelNS + `"><sheets>`)
+	workbookRels.WriteString(xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i, sheet := range sheets {
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(sheet.Name), i+1, i+1)
+		fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="%s/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, xlsxRelNS, i+1)
+	}
+	contentTypes.WriteString(`</Types>`)
+	workbook.WriteString(`</sheets>`)
+	if len(definedNames) > 0 {
+		workbook.WriteString(`<definedNames>` + strings.Join(definedNames, "") + `</definedNames>`)
+	}
+	workbook.WriteString(`</workbook>`)
+	fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="%s/styles" Target="styles.xml"/>`, len(sheets)+1, xlsxRelNS)
+	fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="%s/sharedStrings" Target="sharedStrings.xml"/>`, len(sheets)+2, xlsxRelNS)
+	workbookRels.WriteString(`</Relationships>`)
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", contentTypes.String()},
+		{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="` + xlsxRelNS + `/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+		{"xl/workbook.xml", workbook.String()},
+		{"xl/_rels/workbook.xml.rels", workbookRels.String()},
+		{"xl/styles.xml", w.stylesXML()},
+		{"xl/sharedStrings.xml", w.sharedStringsXML()},
+	}
+	for _, part := range parts {
+		if err := add(part.name, part.content); err != nil {
+			return nil, fmt.Errorf("failed to write xlsx: %w", err)
+		}
+	}
+	for i, data := range sheetXML {
+		if err := add(fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), string(data)); err != nil {
+			return nil, fmt.Errorf("failed to write xlsx: %w", err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write xlsx: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// renderSheet renders the worksheet part and returns the absolute range of the autofilter, if any.
+func (w *xlsxWriter) renderSheet(sheet xlsxSheetSpec, opts xlsxWriteOptions) ([]byte, string, error) {
+	width := len(sheet.Columns)
+	for _, row := range sheet.Rows {
+		if arr, ok := row.([]any); ok && len(arr) > width {
+			width = len(arr)
+		}
+	}
+	if width > xlsxMaxColumns {
+		return nil, "", fmt.Errorf("%d columns exceed the limit of %d", width, xlsxMaxColumns)
+	}
+	header := opts.Header && len(sheet.Columns) > 0
+	totalRows := len(sheet.Rows)
+	if header {
+		totalRows++
+	}
+	if totalRows > xlsxMaxRows {
+		return nil, "", fmt.Errorf("%d rows exceed the limit of %d", totalRows, xlsxMaxRows)
+	}
+
+	styles := make([]int, width)
+	dateColumns := make([]bool, width)
+	for i, col := range sheet.Columns {
+		if col.Format != "" {
+			styles[i] = w.formatStyleIndex(col.Format)
+			dateColumns[i] = col.Format == "date" || col.Format == "datetime" ||
+				(xlsxNamedFormats[col.Format] == 0 && isXLSXDateFormat(col.Format))
+		}
+	}
+
+	// Render rows first: the widths of auto-sized columns depend on the content
+	var data strings.Builder
+	widths := make([]int, width)
+	rowNum := 0
+	if header {
+		rowNum++
+		fmt.Fprintf(&data, `<row r="%d">`, rowNum)
+		for i, col := range sheet.Columns {
+			title := col.Header
+			if title == "" {
+				title = col.Key
+			}
+			widths[i] = utf8.RuneCountInString(title)
+			fmt.Fprintf(&data, `<c r="%s" s="1" t="s"><v>%d</v></c>`, xlsxCellRef(i, rowNum), w.sharedString(title))
+		}
+		data.WriteString(`</row>`)
+	}
+
+	for i, row := range sheet.Rows {
+		var values []any
+		switch v := row.(type) {
+		case map[string]any:
+			values = make([]any, len(sheet.Columns))
+			for j, col := range sheet.Columns {
+				values[j] = v[col.Key]
+			}
+		case []any:
+			values = v
+		default:
+			return nil, "", fmt.Errorf("row %d must be an object or an array, got %T", i, row)
+		}
+
+		rowNum++
+		fmt.Fprintf(&data, `<row r="%d">`, rowNum)
+		for j, value := range values {
+			cell, text, err := w.renderCell(value, xlsxCellRef(j, rowNum), styles[j], dateColumns[j])
+			if err != nil {
+				return nil, "", fmt.Errorf("row %d, column %s: %w", i, xlsxColumnName(j), err)
+			}
+			data.WriteString(cell)
+			if i < xlsxWidthSampleRows {
+				if n := utf8.RuneCountInString(text); n > widths[j] {
+					widths[j] = n
+				}
+			}
+		}
+		data.WriteString(`</row>`)
+	}
+
+	var out strings.Builder
+	out.WriteString(xml.Header + `<worksheet xmlns="` + xlsxMainNS + `" xmlns:r="` + xlsxRelNS + `">`)
+	if header && opts.FreezeHeader {
+		out.WriteString(`<sheetViews><sheetView workbookViewId="0">` +
+			`<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>` +
+			`<selection pane="bottomLeft" activeCell="A2" sqref="A2"/></sheetView></sheetViews>`)
+	}
+	if width > 0 {
+		out.WriteString(`<cols>`)
+		for i := 0; i < width; i++ {
+			colWidth := float64(min(max(widths[i]+2, xlsxMinAutoWidth), xlsxMaxAutoWidth))
+			if i < len(sheet.Columns) && sheet.Columns[i].Width > 0 {
+				colWidth = sheet.Columns[i].Width
+			}
+			fmt.Fprintf(&out, `<col min="%d" max="%d" width="%s" customWidth="1"/>`, i+1, i+1,
+				strconv.FormatFloat(colWidth, 'f', -1, 64))
+		}
+		out.WriteString(`</cols>`)
+	}
+	out.WriteString(`<sheetData>`)
+	out.WriteString(data.String())
+	out.WriteString(`</sheetData>`)
+
+	filterRef := ""
+	if header && opts.AutoFilter {
+		last := xlsxColumnName(len(sheet.Columns) - 1)
+		fmt.Fprintf(&out, `<autoFilter ref="A1:%s%d"/>`, last, rowNum)
+		filterRef = fmt.Sprintf("$A$1:$%s$%d", last, rowNum)
+	}
+	out.WriteString(`</worksheet>`)
+	return []byte(out.String()), filterRef, nil
+}
+
+// renderCell renders a cell and returns its display text for column sizing.
+// Strings in date columns that parse as RFC 3339 or YYYY-MM-DD dates are written as dates.
+func (w *xlsxWriter) renderCell(value any, ref string, style int, dateColumn bool) (string, string, error) {
+	styleAttr := ""
+	if style > 0 {
+		styleAttr = fmt.Sprintf(` s="%d"`, style)
+	}
+	number := func(n float64, text string) (string, string, error) {
+		if math.IsNaN(n) || math.IsInf(n, 0) {
+			return "", "", fmt.Errorf("%v cannot be written to a cell", n)
+		}
+		return fmt.Sprintf(`<c r="%s"%s><v>%s</v></c>`, ref, styleAttr, strconv.FormatFloat(n, 'g', -1, 64)), text, nil
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return "", "", nil
+	case bool:
+		b := 0
+		if v {
+			b = 1
+		}
+		return fmt.Sprintf(`<c r="%s"%s t="b"><v>%d</v></c>`, ref, styleAttr, b), strconv.FormatBool(v), nil
+	case time.Time:
+		return number(xlsxTimeToSerial(v), v.Format("2006-01-02 15:04"))
+	case string:
+		if dateColumn {
+			if t, ok := parseXLSXDate(v); ok {
+				return number(xlsxTimeToSerial(t), v)
+			}
+		}
+		if utf8.RuneCountInString(v) > xlsxMaxCellText {
+			return "", "", fmt.Errorf("text exceeds %d characters", xlsxMaxCellText)
+		}
+		return fmt.Sprintf(`<c r="%s"%s t="s"><v>%d</v></c>`, ref, styleAttr, w.sharedString(v)), v, nil
+	case json.Number:
+		n, err := v.Float64()
+		if err != nil {
+			return "", "", fmt.Errorf("invalid number %q", v)
+		}
+		return number(n, v.String())
+	}
+
+	if n, ok := toFloat(value); ok {
+		return number(n, fmt.Sprint(value))
+	}
+
+	// Objects and arrays are written as JSON text
+	text, err := formatCSVValue(value)
+	if err != nil {
+		return "", "", err
+	}
+	return w.renderCell(text, ref, style, false)
+}
+
+// parseXLSXDate parses the date formats written to date columns.
+func parseXLSXDate(s string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (w *xlsxWriter) sharedString(s string) int {
+	if idx, ok := w.stringIndex[s]; ok {
+		return idx
+	}
+	idx := len(w.strings)
+	w.strings = append(w.strings, s)
+	w.stringIndex[s] = idx
+	return idx
+}
+
+// formatStyleIndex returns the cell style of a number format, adding it when new.
+// Style 0 is the default and 1 the header.
+func (w *xlsxWriter) formatStyleIndex(format string) int {
+	if idx, ok := w.formatStyle[format]; ok {
+		return idx
+	}
+	idx := 2 + len(w.formats)
+	w.formats = append(w.formats, format)
+	w.formatStyle[format] = idx
+	return idx
+}
+
+func (w *xlsxWriter) sharedStringsXML() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, xml.Header+`<sst xmlns="%s" count="%d" uniqueCount="%d">`, xlsxMainNS, len(w.strings), len(w.strings))
+	for _, s := range w.strings {
+		b.WriteString(`<si><t xml:space="preserve">`)
+		b.WriteString(xmlEscape(s))
+		b.WriteString(`</t></si>`)
+	}
+	b.WriteString(`</sst>`)
+	return b.String()
+}
+
+func (w *xlsxWriter) stylesXML() string {
+	// Custom formats get IDs from 164 on, the first ID not reserved for built-in formats
+	customIDs := make(map[string]int)
+	var numFmts strings.Builder
+	for _, format := range w.formats {
+		if _, ok := xlsxNamedFormats[format]; ok {
+			continue
+		}
+		if _, ok := customIDs[format]; !ok {
+			customIDs[format] = 164 + len(customIDs)
+			fmt.Fprintf(&numFmts, `<numFmt numFmtId="%d" formatCode="%s"/>`, customIDs[format], xmlEscape(format))
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(xml.Header + `<styleSheet xmlns="` + xlsxMainNS + `">`)
+	if len(customIDs) > 0 {
+		fmt.Fprintf(&b, `<numFmts count="%d">%s</numFmts>`, len(customIDs), numFmts.String())
+	}
+	b.WriteString(`<fonts count="2">` +
+		`<font><sz val="11"/><name val="Calibri"/><family val="2"/></font>` +
+		`<font><b/><sz val="11"/><name val="Calibri"/><family val="2"/></font></fonts>` +
+		`<fills count="3"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill>` +
+		`<fill><patternFill patternType="solid"><fgColor rgb="FFD9E1F2"/><bgColor indexed="64"/></patternFill></fill></fills>` +
+		`<borders count="2"><border><left/><right/><top/><bottom/><diagonal/></border>` +
+		`<border><left/><right/><top/><bottom style="thin"><color auto="1"/></bottom><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>`)
+	fmt.Fprintf(&b, `<cellXfs count="%d">`, 2+len(w.formats))
+	b.WriteString(`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+		`<xf numFmtId="0" fontId="1" fillId="2" borderId="1" xfId="0" applyFont="1" applyFill="1" applyBorder="1"/>`)
+	for _, format := range w.formats {
+		id, ok := xlsxNamedFormats[format]
+		if !ok {
+			id = customIDs[format]
+		}
+		fmt.Fprintf(&b, `<xf numFmtId="%d" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>`, id)
+	}
+	b.WriteString(`</cellXfs><cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles></styleSheet>`)
+	return b.String()
+}
+
+// validateXLSXSheetName checks the sheet name rules of Excel.
+func validateXLSXSheetName(name string) error {
+	if name == "" {
+		return fmt.Errorf("sheet name is required")
+	}
+	if utf8.RuneCountInString(name) > xlsxMaxSheetName {
+		return fmt.Errorf("sheet name %q is longer than %d characters", name, xlsxMaxSheetName)
+	}
+	if strings.ContainsAny(name, `[]:*?/\`) {
+		return fmt.Errorf("sheet name %q must not contain any of [ ] : * ? / \\", name)
+	}
+	if strings.HasPrefix(name, "'") || strings.HasSuffix(name, "'") {
+		return fmt.Errorf("sheet name %q must not start or end with an apostrophe", name)
+	}
+	return nil
+}
+
+// xlsxQuoteSheetName quotes a sheet name for use in a formula reference.
+func xlsxQuoteSheetName(name string) string {
+	return "'" + strings.ReplaceAll(name, "'", "''") + "'"
+}
+
+// xlsxAutoColumns returns the sorted union of the object keys of rows.
+func xlsxAutoColumns(rows []any) []xlsxColumn {
+	seen := make(map[string]bool)
+	for _, row := range rows {
+		if obj, ok := row.(map[string]any); ok {
+			for key := range obj {
+				seen[key] = true
+			}
+		}
+	}
+	keys := sortedKeys(seen)
+	columns := make([]xlsxColumn, len(keys))
+	for i, key := range keys {
+		columns[i] = xlsxColumn{Key: key}
+	}
+	return columns
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
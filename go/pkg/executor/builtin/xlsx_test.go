@@ -0,0 +1,334 @@
+package builtin
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildTestXLSX zips parts into a workbook, as written by a spreadsheet application.
+func buildTestXLSX(t *testing.T, parts map[string]string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, content := range parts {
+		f, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = io.WriteString(f, content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+// excelWorkbook resembles a workbook saved by Excel: shared strings with rich text,
+// inline strings, a custom date format, sparse cells and a second sheet.
+func excelWorkbook(t *testing.T) string {
+	return buildTestXLSX(t, map[string]string{
+		"xl/workbook.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <sheets>
+    <sheet name="Orders" sheetId="1" r:id="rId1"/>
+    <sheet name="Notes" sheetId="2" r:id="rId2"/>
+  </sheets>
+</workbook>`,
+		"xl/_rels/workbook.xml.rels": `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+  <Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="/xl/worksheets/notes.xml"/>
+</Relationships>`,
+		"xl/sharedStrings.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <si><t>Order</t></si>
+  <si><t>Customer</t></si>
+  <si><t>Shipped</t></si>
+  <si><r><t>Acme </t></r><r><rPr><b/></rPr><t>Corp</t></r></si>
+  <si><t>Amount</t></si>
+  <si><t>Ordered</t></si>
+</sst>`,
+		"xl/styles.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <numFmts count="2">
+    <numFmt numFmtId="164" formatCode="dd\.mm\.yyyy"/>
+    <numFmt numFmtId="165" formatCode="&quot;Day &quot;0"/>
+  </numFmts>
+  <cellXfs count="4">
+    <xf numFmtId="0"/>
+    <xf numFmtId="164"/>
+    <xf numFmtId="165"/>
+    <xf numFmtId="22"/>
+  </cellXfs>
+</styleSheet>`,
+		"xl/worksheets/sheet1.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <sheetData>
+    <row r="1"><c r="A1"><v>2024</v></c></row>
+    <row r="3">
+      <c r="A3" t="s"><v>0</v></c><c r="B3" t="s"><v>1</v></c><c r="C3" t="s"><v>2</v></c>
+      <c r="D3" t="s"><v>4</v></c><c r="E3" t="s"><v>5</v></c><c r="G3" t="s"><v>4</v></c>
+    </row>
+    <row r="4">
+      <c r="A4"><v>1001</v></c><c r="B4" t="s"><v>3</v></c><c r="C4" t="b"><v>1</v></c>
+      <c r="D4"><v>19.5</v></c><c r="E4" s="1"><v>45306</v></c><c r="F4" s="2"><v>7</v></c>
+    </row>
+    <row r="6">
+      <c r="A6"><v>1002</v></c><c r="B6" t="inlineStr"><is><t>Globex</t></is></c><c r="C6" t="b"><v>0</v></c>
+      <c r="D6" t="str"><f>SUM(1,2)</f><v>3</v></c><c r="E6" s="3"><v>45306.75</v></c>
+    </row>
+    <row r="7"><c r="A7"><v>1003</v></c><c r="D7" t="e"><v>#DIV/0!</v></c></row>
+  </sheetData>
+</worksheet>`,
+		"xl/worksheets/notes.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <sheetData>
+    <row><c t="inlineStr"><is><t>Note</t></is></c></row>
+    <row><c t="inlineStr"><is><t>Call back</t></is></c></row>
+  </sheetData>
+</worksheet>`,
+	})
+}
+
+func TestXLSXExecutor_Validate(t *testing.T) {
+	exec := NewXLSXExecutor(nil)
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{"read", map[string]any{"file_id": "f1"}, ""},
+		{"write", map[string]any{"operation": "write", "file_name": "report"}, ""},
+		{"write sheets", map[string]any{"operation": "write", "file_name": "r", "sheets": []any{
+			map[string]any{"name": "A", "columns": []any{"id", map[string]any{"key": "total", "format": "number", "width": 12}}},
+		}}, ""},
+		{"invalid operation", map[string]any{"operation": "append"}, "invalid operation"},
+		{"negative skip_rows", map[string]any{"skip_rows": -1}, "skip_rows"},
+		{"invalid read columns", map[string]any{"columns": "a,b"}, "columns"},
+		{"missing file_name", map[string]any{"operation": "write"}, "file_name is required"},
+		{"invalid access_scope", map[string]any{"operation": "write", "file_name": "r", "access_scope": "public"}, "access_scope"},
+		{"invalid sheet name", map[string]any{"operation": "write", "file_name": "r", "sheet": "Q1/Q2"}, "must not contain"},
+		{"long sheet name", map[string]any{"operation": "write", "file_name": "r", "sheet": "A sheet name longer than 31 chars"}, "longer than 31"},
+		{"column without key", map[string]any{"operation": "write", "file_name": "r", "columns": []any{map[string]any{"header": "Id"}}}, "key is required"},
+		{"invalid width", map[string]any{"operation": "write", "file_name": "r", "columns": []any{map[string]any{"key": "a", "width": 300}}}, "width"},
+		{"empty sheets", map[string]any{"operation": "write", "file_name": "r", "sheets": []any{}}, "non-empty array"},
+		{"sheet without name", map[string]any{"operation": "write", "file_name": "r", "sheets": []any{map[string]any{"rows": []any{}}}}, "sheets[0]: sheet name is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exec.Validate(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestXLSXExecutor_ReadExcelFile(t *testing.T) {
+	exec := NewXLSXExecutor(nil)
+	data := excelWorkbook(t)
+
+	result, err := exec.Execute(context.Background(), map[string]any{"data": data, "skip_rows": 2}, nil)
+	require.NoError(t, err)
+
+	output := result.(map[string]any)
+	assert.Equal(t, "Orders", output["sheet"])
+	assert.Equal(t, []string{"Orders", "Notes"}, output["sheets"])
+	assert.Equal(t, []string{"Order", "Customer", "Shipped", "Amount", "Ordered", "F", "Amount_2"}, output["columns"])
+	assert.Equal(t, 3, output["row_count"])
+
+	rows := output["rows"].([]any)
+	assert.Equal(t, map[string]any{
+		"Order": int64(1001), "Customer": "Acme Corp", "Shipped": true, "Amount": 19.5,
+		"Ordered": "2024-01-15", "F": int64(7), "Amount_2": nil,
+	}, rows[0])
+	assert.Equal(t, map[string]any{
+		"Order": int64(1002), "Customer": "Globex", "Shipped": false, "Amount": "3",
+		"Ordered": "2024-01-15T18:00:00", "F": nil, "Amount_2": nil,
+	}, rows[1])
+	assert.Equal(t, "#DIV/0!", rows[2].(map[string]any)["Amount"])
+
+	// Without a header, columns are named by letter; the gap in row 5 is kept
+	result, err = exec.Execute(context.Background(), map[string]any{
+		"data":            data,
+		"header":          false,
+		"skip_rows":       3,
+		"skip_empty_rows": false,
+		"max_rows":        3,
+	}, nil)
+	require.NoError(t, err)
+	output = result.(map[string]any)
+	rows = output["rows"].([]any)
+	require.Len(t, rows, 3)
+	assert.Equal(t, int64(1001), rows[0].(map[string]any)["A"])
+	assert.Nil(t, rows[1].(map[string]any)["A"])
+	assert.Equal(t, int64(1002), rows[2].(map[string]any)["A"])
+
+	result, err = exec.Execute(context.Background(), map[string]any{"data": data, "all_sheets": true}, nil)
+	require.NoError(t, err)
+	tables := result.(map[string]any)["data"].(map[string]any)
+	notes := tables["Notes"].(map[string]any)
+	assert.Equal(t, []any{map[string]any{"Note": "Call back"}}, notes["rows"])
+
+	_, err = exec.Execute(context.Background(), map[string]any{"data": data, "sheet": "Missing"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `sheet "Missing" not found (sheets: Orders, Notes)`)
+
+	_, err = exec.Execute(context.Background(), map[string]any{"data": base64.StdEncoding.EncodeToString([]byte("a,b\n1,2"))}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a valid xlsx file")
+}
+
+func TestXLSXExecutor_WriteAndRead(t *testing.T) {
+	manager := newMockManager()
+	exec := NewXLSXExecutor(manager)
+
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"operation": "write",
+		"file_name": "sales",
+		"sheet":     "Q1 Sales",
+		"columns": []any{
+			"region",
+			map[string]any{"key": "total", "header": "Total", "format": "number"},
+			map[string]any{"key": "share", "format": "percent"},
+			map[string]any{"key": "closed", "header": "Closed", "format": "date", "width": 14},
+			"won",
+			"meta",
+		},
+	}, []any{
+		map[string]any{"region": "North & East", "total": 1250.5, "share": 0.25, "closed": "2024-03-31", "won": true, "meta": map[string]any{"rep": "Ann"}},
+		map[string]any{"region": "Süd <DE>", "total": 99, "share": 0.125, "closed": "not yet", "won": false},
+	})
+	require.NoError(t, err)
+
+	output := result.(map[string]any)
+	assert.Equal(t, "sales.xlsx", output["file_name"])
+	assert.Equal(t, xlsxMimeType, output["mime_type"])
+	assert.Equal(t, []string{"Q1 Sales"}, output["sheets"])
+	assert.Equal(t, 2, output["row_count"])
+
+	result, err = exec.Execute(context.Background(), map[string]any{"file_id": output["file_id"]}, nil)
+	require.NoError(t, err)
+	read := result.(map[string]any)
+	assert.Equal(t, []string{"region", "Total", "share", "Closed", "won", "meta"}, read["columns"])
+	assert.Equal(t, []any{
+		map[string]any{"region": "North & East", "Total": 1250.5, "share": 0.25, "Closed": "2024-03-31", "won": true, "meta": `{"rep":"Ann"}`},
+		map[string]any{"region": "Süd <DE>", "Total": int64(99), "share": 0.125, "Closed": "not yet", "won": false, "meta": nil},
+	}, read["rows"])
+
+	// The file_id can come from the previous node's output
+	result, err = exec.Execute(context.Background(), map[string]any{}, map[string]any{"file_id": output["file_id"]})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.(map[string]any)["row_count"])
+}
+
+func TestXLSXExecutor_WriteFormatting(t *testing.T) {
+	manager := newMockManager()
+	exec := NewXLSXExecutor(manager)
+
+	result, err := exec.Execute(context.Background(), map[string]any{
+		"operation": "write",
+		"file_name": "report.xlsx",
+		"sheets": []any{
+			map[string]any{
+				"name":    "Summary",
+				"rows":    []any{map[string]any{"b": 2, "a": 1}},
+				"columns": nil,
+			},
+			map[string]any{
+				"name":    "It's raw",
+				"rows":    []any{[]any{"x", 1.5}, []any{"y"}},
+				"columns": []any{map[string]any{"key": "amount", "format": `#,##0.00 "EUR"`}},
+			},
+		},
+		"auto_filter": true,
+	}, nil)
+	require.NoError(t, err)
+	output := result.(map[string]any)
+	assert.Equal(t, "report.xlsx", output["file_name"])
+	assert.Equal(t, []string{"Summary", "It's raw"}, output["sheets"])
+
+	storage, err := manager.GetStorage("default")
+	require.NoError(t, err)
+	_, reader, err := storage.Get(context.Background(), output["file_id"].(string))
+	require.NoError(t, err)
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+
+	parts := readZipParts(t, data)
+	assert.Contains(t, parts["xl/workbook.xml"], `<sheet name="It&#39;s raw" sheetId="2" r:id="rId2"/>`)
+	assert.Contains(t, parts["xl/workbook.xml"], `localSheetId="1" hidden="1">&#39;It&#39;&#39;s raw&#39;!$A$1:$A$3</definedName>`)
+	assert.Contains(t, parts["xl/worksheets/sheet1.xml"], `state="frozen"`)
+	assert.Contains(t, parts["xl/worksheets/sheet1.xml"], `<autoFilter ref="A1:B2"/>`)
+	assert.Contains(t, parts["xl/worksheets/sheet1.xml"], `<c r="A1" s="1" t="s">`)
+	assert.Contains(t, parts["xl/styles.xml"], `<numFmt numFmtId="164" formatCode="#,##0.00 &#34;EUR&#34;"/>`)
+	assert.Contains(t, parts["xl/worksheets/sheet2.xml"], `<c r="B2"><v>1.5</v></c>`)
+	assert.Contains(t, parts["xl/worksheets/sheet2.xml"], `<col min="2" max="2" width="8" customWidth="1"/>`)
+
+	// Arrays are written by position below the header
+	result, err = exec.Execute(context.Background(), map[string]any{"file_id": output["file_id"], "sheet": "It's raw"}, nil)
+	require.NoError(t, err)
+	read := result.(map[string]any)
+	assert.Equal(t, []string{"amount", "B"}, read["columns"])
+	assert.Equal(t, map[string]any{"amount": "x", "B": 1.5}, read["rows"].([]any)[0])
+
+	_, err = exec.Execute(context.Background(), map[string]any{
+		"operation": "write",
+		"file_name": "r",
+		"sheets": []any{
+			map[string]any{"name": "Data", "rows": []any{}},
+			map[string]any{"name": "data", "rows": []any{}},
+		},
+	}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `duplicate sheet name "data"`)
+
+	_, err = NewXLSXExecutor(nil).Execute(context.Background(), map[string]any{"operation": "write", "file_name": "r"}, []any{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "file storage is not available")
+}
+
+func TestXLSXHelpers(t *testing.T) {
+	for col, name := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA", 16383: "XFD"} {
+		assert.Equal(t, name, xlsxColumnName(col))
+		idx, ok := xlsxColumnIndex(name + "12")
+		assert.True(t, ok)
+		assert.Equal(t, col, idx)
+	}
+	_, ok := xlsxColumnIndex("12")
+	assert.False(t, ok)
+
+	assert.True(t, isXLSXDateFormat("yyyy-mm-dd"))
+	assert.True(t, isXLSXDateFormat("[$-409]h:mm AM/PM"))
+	assert.False(t, isXLSXDateFormat(`"Day "0`))
+	assert.False(t, isXLSXDateFormat("[Red]#,##0.00"))
+	assert.False(t, isXLSXDateFormat("General"))
+
+	assert.Equal(t, "1904-01-02", xlsxSerialToTime(1, true))
+	assert.Equal(t, "2024-01-15", xlsxSerialToTime(45306, false))
+}
+
+func readZipParts(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	parts := make(map[string]string)
+	for _, f := range archive.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		parts[f.Name] = string(content)
+	}
+	return parts
+}
@@ -0,0 +1,116 @@
+package executor
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// CircuitOpenErrorCode is the error code of calls rejected by an open circuit breaker.
+const CircuitOpenErrorCode = "CIRCUIT_OPEN"
+
+// CircuitOpenError is returned for calls to a dependency whose circuit breaker is open.
+// It wraps models.ErrCircuitOpen.
+type CircuitOpenError struct {
+	Key     string    // Host or provider the breaker guards
+	RetryAt time.Time // When the breaker lets a trial call through
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s: %s is failing, calls are rejected until %s",
+		models.ErrCircuitOpen, e.Key, e.RetryAt.Format(time.RFC3339))
+}
+
+func (e *CircuitOpenError) Unwrap() error {
+	return models.ErrCircuitOpen
+}
+
+// Code returns CircuitOpenErrorCode.
+func (e *CircuitOpenError) Code() string {
+	return CircuitOpenErrorCode
+}
+
+// CircuitBreaker stops calls to an external dependency, such as an HTTP host or an LLM
+// provider, once it keeps failing. After threshold consecutive failures under a key the
+// breaker opens: calls fail fast with a CircuitOpenError for the cooldown. Then one trial
+// call goes through; its success closes the breaker, its failure opens it again.
+//
+// A nil *CircuitBreaker lets every call through.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+type circuit struct {
+	failures  int       // Consecutive failures
+	openUntil time.Time // Zero while closed
+	trial     bool      // A trial call is in flight after the cooldown
+}
+
+// NewCircuitBreaker creates a circuit breaker opening after threshold consecutive failures
+// (5 when <= 0) for cooldown (30s when <= 0).
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold <= 0 {
+		threshold = 5
+	}
+	if cooldown <= 0 {
+		cooldown = 30 * time.Second
+	}
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+		circuits:  make(map[string]*circuit),
+	}
+}
+
+// Allow returns a CircuitOpenError if calls under key must fail fast. Every allowed call
+// must be followed by Record.
+func (b *CircuitBreaker) Allow(key string) error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[key]
+	if !ok || c.openUntil.IsZero() {
+		return nil
+	}
+	if b.now().Before(c.openUntil) || c.trial {
+		return &CircuitOpenError{Key: key, RetryAt: c.openUntil}
+	}
+	c.trial = true
+	return nil
+}
+
+// Record records the outcome of an allowed call under key.
+func (b *CircuitBreaker) Record(key string, failed bool) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !failed {
+		delete(b.circuits, key)
+		return
+	}
+
+	c, ok := b.circuits[key]
+	if !ok {
+		c = &circuit{}
+		b.circuits[key] = c
+	}
+	c.failures++
+	if c.trial || c.failures >= b.threshold {
+		c.openUntil = b.now().Add(b.cooldown)
+		c.trial = false
+	}
+}
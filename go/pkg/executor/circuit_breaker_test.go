@@ -0,0 +1,83 @@
+package executor
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+func TestCircuitBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	now := time.Now()
+	breaker := NewCircuitBreaker(3, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	breaker.Record("api.example.com", true)
+	breaker.Record("api.example.com", true)
+	breaker.Record("api.example.com", false)
+	breaker.Record("api.example.com", true)
+	breaker.Record("api.example.com", true)
+	if err := breaker.Allow("api.example.com"); err != nil {
+		t.Fatalf("expected a success to reset the failure count, got %v", err)
+	}
+
+	breaker.Record("api.example.com", true)
+	err := breaker.Allow("api.example.com")
+	if !errors.Is(err, models.ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	var circuitErr *CircuitOpenError
+	if !errors.As(err, &circuitErr) || circuitErr.Key != "api.example.com" || !circuitErr.RetryAt.Equal(now.Add(time.Minute)) {
+		t.Errorf("unexpected circuit error: %+v", circuitErr)
+	}
+	if circuitErr.Code() != CircuitOpenErrorCode {
+		t.Errorf("expected code %s, got %s", CircuitOpenErrorCode, circuitErr.Code())
+	}
+
+	if err := breaker.Allow("other.example.com"); err != nil {
+		t.Errorf("expected other keys to be unaffected, got %v", err)
+	}
+}
+
+func TestCircuitBreaker_TrialAfterCooldown(t *testing.T) {
+	now := time.Now()
+	breaker := NewCircuitBreaker(1, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	breaker.Record("openai", true)
+	if err := breaker.Allow("openai"); err == nil {
+		t.Fatal("expected the breaker to be open")
+	}
+
+	now = now.Add(time.Minute)
+	if err := breaker.Allow("openai"); err != nil {
+		t.Fatalf("expected a trial call after the cooldown, got %v", err)
+	}
+	if err := breaker.Allow("openai"); err == nil {
+		t.Fatal("expected only one trial call at a time")
+	}
+
+	// A failed trial opens the breaker for another cooldown
+	breaker.Record("openai", true)
+	if err := breaker.Allow("openai"); err == nil {
+		t.Fatal("expected the breaker to open again")
+	}
+
+	now = now.Add(time.Minute)
+	if err := breaker.Allow("openai"); err != nil {
+		t.Fatalf("expected a trial call, got %v", err)
+	}
+	breaker.Record("openai", false)
+	if err := breaker.Allow("openai"); err != nil {
+		t.Errorf("expected a successful trial to close the breaker, got %v", err)
+	}
+}
+
+func TestCircuitBreaker_Nil(t *testing.T) {
+	var breaker *CircuitBreaker
+	breaker.Record("host", true)
+	if err := breaker.Allow("host"); err != nil {
+		t.Errorf("expected a nil breaker to allow calls, got %v", err)
+	}
+}
@@ -0,0 +1,162 @@
+// Package compilecache caches compiled jq filters, expressions and templates so that
+// nodes which run many times do not recompile the same source on every execution.
+//
+// Entries are keyed by their source text (plus anything else the compiled form depends
+// on), not by node: identical filters in different nodes or workflow versions share one
+// entry, a new workflow version that changes a filter compiles it once, and entries no
+// longer used by any workflow age out of the LRU.
+package compilecache
+
+import (
+	"container/list"
+	"sort"
+	"sync"
+)
+
+// DefaultCapacity is the number of entries a cache holds when created with a
+// non-positive capacity.
+const DefaultCapacity = 1024
+
+// Stats reports the effectiveness of a cache.
+type Stats struct {
+	Name      string  `json:"name"`
+	Hits      uint64  `json:"hits"`
+	Misses    uint64  `json:"misses"`
+	Evictions uint64  `json:"evictions"`
+	Size      int     `json:"size"`
+	Capacity  int     `json:"capacity"`
+	HitRate   float64 `json:"hit_rate"`
+}
+
+// Cache is a thread-safe LRU cache of compiled values keyed by their source.
+type Cache[T any] struct {
+	name     string
+	capacity int
+
+	mu        sync.Mutex
+	items     map[string]*list.Element
+	order     *list.List
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+type entry[T any] struct {
+	key   string
+	value T
+}
+
+// New creates a cache and registers it for AllStats. Names should be unique.
+func New[T any](name string, capacity int) *Cache[T] {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	c := &Cache[T]{
+		name:     name,
+		capacity: capacity,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+	register(c)
+	return c
+}
+
+// GetOrCompile returns the cached value for key, calling compile on a miss.
+// Compilation errors are returned and not cached.
+func (c *Cache[T]) GetOrCompile(key string, compile func() (T, error)) (T, error) {
+	c.mu.Lock()
+	if element, ok := c.items[key]; ok {
+		c.order.MoveToFront(element)
+		c.hits++
+		value := element.Value.(*entry[T]).value
+		c.mu.Unlock()
+		return value, nil
+	}
+	c.misses++
+	c.mu.Unlock()
+
+	// Compile outside the lock; concurrent misses of one key may compile it twice,
+	// which is cheaper than serializing all compilation
+	value, err := compile()
+	if err != nil {
+		return value, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.items[key]; ok {
+		c.order.MoveToFront(element)
+		return element.Value.(*entry[T]).value, nil
+	}
+	c.items[key] = c.order.PushFront(&entry[T]{key: key, value: value})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*entry[T]).key)
+		c.evictions++
+	}
+	return value, nil
+}
+
+// Len returns the number of cached entries.
+func (c *Cache[T]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Clear removes all entries and resets the counters.
+func (c *Cache[T]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = make(map[string]*list.Element)
+	c.order = list.New()
+	c.hits, c.misses, c.evictions = 0, 0, 0
+}
+
+// Stats returns the current counters of the cache.
+func (c *Cache[T]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := Stats{
+		Name:      c.name,
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+		Size:      c.order.Len(),
+		Capacity:  c.capacity,
+	}
+	if total := c.hits + c.misses; total > 0 {
+		stats.HitRate = float64(c.hits) / float64(total)
+	}
+	return stats
+}
+
+type statser interface {
+	Stats() Stats
+}
+
+var (
+	registryMu sync.Mutex
+	registry   []statser
+)
+
+func register(c statser) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = append(registry, c)
+}
+
+// AllStats returns the stats of every cache created with New, sorted by name.
+func AllStats() []Stats {
+	registryMu.Lock()
+	caches := append([]statser(nil), registry...)
+	registryMu.Unlock()
+
+	stats := make([]Stats, 0, len(caches))
+	for _, c := range caches {
+		stats = append(stats, c.Stats())
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
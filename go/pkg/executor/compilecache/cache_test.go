@@ -0,0 +1,110 @@
+package compilecache
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_GetOrCompile(t *testing.T) {
+	c := New[string]("test_get_or_compile", 10)
+	compiles := 0
+	compile := func() (string, error) {
+		compiles++
+		return "compiled", nil
+	}
+
+	for i := 0; i < 3; i++ {
+		value, err := c.GetOrCompile("source", compile)
+		require.NoError(t, err)
+		assert.Equal(t, "compiled", value)
+	}
+
+	assert.Equal(t, 1, compiles)
+	stats := c.Stats()
+	assert.Equal(t, uint64(2), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, 1, stats.Size)
+	assert.InDelta(t, 2.0/3.0, stats.HitRate, 0.001)
+}
+
+func TestCache_ErrorsAreNotCached(t *testing.T) {
+	c := New[int]("test_errors", 10)
+
+	_, err := c.GetOrCompile("bad", func() (int, error) { return 0, errors.New("syntax error") })
+	require.Error(t, err)
+	assert.Equal(t, 0, c.Len())
+
+	value, err := c.GetOrCompile("bad", func() (int, error) { return 7, nil })
+	require.NoError(t, err)
+	assert.Equal(t, 7, value)
+	assert.Equal(t, uint64(2), c.Stats().Misses)
+}
+
+func TestCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := New[string]("test_eviction", 2)
+	compile := func(v string) func() (string, error) {
+		return func() (string, error) { return v, nil }
+	}
+
+	_, _ = c.GetOrCompile("a", compile("a"))
+	_, _ = c.GetOrCompile("b", compile("b"))
+	_, _ = c.GetOrCompile("a", compile("a")) // a is now the most recently used
+	_, _ = c.GetOrCompile("c", compile("c")) // evicts b
+
+	assert.Equal(t, 2, c.Len())
+	assert.Equal(t, uint64(1), c.Stats().Evictions)
+
+	recompiled := false
+	_, _ = c.GetOrCompile("b", func() (string, error) { recompiled = true; return "b", nil })
+	assert.True(t, recompiled)
+
+	recompiled = false
+	_, _ = c.GetOrCompile("c", func() (string, error) { recompiled = true; return "c", nil })
+	assert.False(t, recompiled)
+}
+
+func TestCache_Concurrent(t *testing.T) {
+	c := New[int]("test_concurrent", 8)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := string(rune('a' + (i+j)%12))
+				value, err := c.GetOrCompile(key, func() (int, error) { return len(key), nil })
+				assert.NoError(t, err)
+				assert.Equal(t, 1, value)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	stats := c.Stats()
+	assert.LessOrEqual(t, stats.Size, 8)
+	assert.Equal(t, uint64(1600), stats.Hits+stats.Misses)
+}
+
+func TestAllStats(t *testing.T) {
+	c := New[int]("test_all_stats", 4)
+	_, _ = c.GetOrCompile("x", func() (int, error) { return 1, nil })
+	c.Clear()
+	assert.Equal(t, Stats{Name: "test_all_stats", Capacity: 4}, c.Stats())
+
+	var found bool
+	stats := AllStats()
+	for i, s := range stats {
+		if i > 0 {
+			assert.LessOrEqual(t, stats[i-1].Name, s.Name)
+		}
+		if s.Name == "test_all_stats" {
+			found = true
+		}
+	}
+	assert.True(t, found)
+}
@@ -31,6 +31,9 @@ type HTTPAuthConfig struct {
 	Header   string `json:"header,omitempty"`   // Header name for API key
 }
 
+// NodeType returns the type of the nodes the configuration is for.
+func (c HTTPConfig) NodeType() string { return "http" }
+
 // Validate validates the HTTP configuration.
 func (c *HTTPConfig) Validate() error {
 	if c.Method == "" {
@@ -53,16 +56,40 @@ func (c *HTTPConfig) Validate() error {
 
 // TransformConfig represents the configuration for the Transform executor.
 type TransformConfig struct {
-	Type       string `json:"type"`                 // "passthrough", "template", "expression", "jq"
+	Type       string `json:"type"`                 // "passthrough", "template", "expression", "jq", "csv_parse", "csv_generate", "xml"
 	Template   string `json:"template,omitempty"`   // For template type
 	Expression string `json:"expression,omitempty"` // For expression type
 	Filter     string `json:"filter,omitempty"`     // For jq type
+
+	// For csv_parse and csv_generate types
+	Delimiter     string   `json:"delimiter,omitempty"`      // Single character, "\t", or "auto" (csv_parse)
+	Header        string   `json:"header,omitempty"`         // csv_parse: "first_row", "none" or "auto"
+	Columns       []string `json:"columns,omitempty"`        // Column names (csv_parse) or order (csv_generate)
+	InferTypes    *bool    `json:"infer_types,omitempty"`    // csv_parse: convert numbers, booleans and empty fields
+	IncludeHeader *bool    `json:"include_header,omitempty"` // csv_generate: write a header row
+
+	// For xml type (InferTypes also applies to parsing)
+	Operation       string            `json:"operation,omitempty"`        // "parse", "serialize" or "xpath"
+	XPath           any               `json:"xpath,omitempty"`            // Expression, or map of name to expression
+	Namespaces      map[string]string `json:"namespaces,omitempty"`       // Prefix to namespace URI
+	AttributePrefix string            `json:"attribute_prefix,omitempty"` // Key prefix of attributes (default "@")
+	TextKey         string            `json:"text_key,omitempty"`         // Key of element text (default "#text")
+	KeepNamespaces  bool              `json:"keep_namespaces,omitempty"`  // parse: keep namespace prefixes
+	ForceArray      []string          `json:"force_array,omitempty"`      // parse: elements always returned as arrays
+	RootName        string            `json:"root_name,omitempty"`        // serialize: root element name
+	Declaration     *bool             `json:"declaration,omitempty"`      // serialize: write an XML declaration
+	Indent          string            `json:"indent,omitempty"`           // serialize: indentation
+	Output          string            `json:"output,omitempty"`           // xpath: "text" or "object"
 }
 
+// NodeType returns the type of the nodes the configuration is for.
+func (c TransformConfig) NodeType() string { return "transform" }
+
 // Validate validates the Transform configuration.
 func (c *TransformConfig) Validate() error {
 	validTypes := map[string]bool{
 		"passthrough": true, "template": true, "expression": true, "jq": true,
+		"csv_parse": true, "csv_generate": true, "xml": true,
 	}
 
 	if c.Type == "" {
@@ -86,6 +113,22 @@ func (c *TransformConfig) Validate() error {
 		if c.Filter == "" {
 			return fmt.Errorf("filter is required for jq transformation")
 		}
+	case "csv_parse":
+		switch c.Header {
+		case "", "first_row", "none", "auto":
+		default:
+			return fmt.Errorf("invalid header mode: %s", c.Header)
+		}
+	case "xml":
+		switch c.Operation {
+		case "", "parse", "serialize":
+		case "xpath":
+			if c.XPath == nil {
+				return fmt.Errorf("xpath is required for xml xpath operation")
+			}
+		default:
+			return fmt.Errorf("invalid xml operation: %s", c.Operation)
+		}
 	}
 
 	return nil
@@ -93,7 +136,7 @@ func (c *TransformConfig) Validate() error {
 
 // LLMConfig represents the configuration for the LLM executor.
 type LLMConfig struct {
-	Provider         string             `json:"provider"` // "openai", "anthropic", "gemini"
+	Provider         string             `json:"provider"` // "openai", "anthropic", "gemini", "azure_openai", "bedrock", "mistral", "cohere", "mock"
 	Model            string             `json:"model"`
 	APIKey           string             `json:"api_key,omitempty"`
 	Prompt           string             `json:"prompt,omitempty"`
@@ -138,17 +181,20 @@ type LLMResponseFormat struct {
 	Schema map[string]any `json:"schema,omitempty"`
 }
 
+// NodeType returns the type of the nodes the configuration is for.
+func (c LLMConfig) NodeType() string { return "llm" }
+
 // Validate validates the LLM configuration.
 func (c *LLMConfig) Validate() error {
 	if c.Provider == "" {
 		return fmt.Errorf("provider is required")
 	}
-	if c.Model == "" {
+	if c.Model == "" && c.Provider != "mock" {
 		return fmt.Errorf("model is required")
 	}
 
 	validProviders := map[string]bool{
-		"openai": true, "anthropic": true, "gemini": true, "azure": true,
+		"openai": true, "anthropic": true, "gemini": true, "azure": true, "azure_openai": true, "bedrock": true, "mistral": true, "cohere": true, "mock": true,
 	}
 	if !validProviders[c.Provider] {
 		return fmt.Errorf("invalid LLM provider: %s", c.Provider)
@@ -172,6 +218,9 @@ type ConditionalBranch struct {
 	Value     any    `json:"value"`
 }
 
+// NodeType returns the type of the nodes the configuration is for.
+func (c ConditionalConfig) NodeType() string { return "conditional" }
+
 // Validate validates the Conditional configuration.
 func (c *ConditionalConfig) Validate() error {
 	if c.Condition == "" && len(c.Branches) == 0 {
@@ -186,6 +235,9 @@ type MergeConfig struct {
 	Keys     []string `json:"keys,omitempty"`     // Keys to merge (for selective merge)
 }
 
+// NodeType returns the type of the nodes the configuration is for.
+func (c MergeConfig) NodeType() string { return "merge" }
+
 // Validate validates the Merge configuration.
 func (c *MergeConfig) Validate() error {
 	validStrategies := map[string]bool{
@@ -206,6 +258,9 @@ type FileStorageConfig struct {
 	Metadata    map[string]any `json:"metadata,omitempty"`
 }
 
+// NodeType returns the type of the nodes the configuration is for.
+func (c FileStorageConfig) NodeType() string { return "file_storage" }
+
 // Validate validates the FileStorage configuration.
 func (c *FileStorageConfig) Validate() error {
 	if c.Operation == "" {
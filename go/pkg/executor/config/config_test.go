@@ -137,6 +137,24 @@ func TestTransformConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "filter is required",
 		},
+		{
+			name: "xml type with xpath",
+			config: TransformConfig{
+				Type:      "xml",
+				Operation: "xpath",
+				XPath:     "//item/@sku",
+			},
+			wantErr: false,
+		},
+		{
+			name: "xml xpath missing xpath",
+			config: TransformConfig{
+				Type:      "xml",
+				Operation: "xpath",
+			},
+			wantErr: true,
+			errMsg:  "xpath is required",
+		},
 		{
 			name: "invalid type",
 			config: TransformConfig{
@@ -11,10 +11,14 @@
 //   - Merge: Combines outputs from multiple nodes
 //
 // Custom executors can be registered at runtime using the Manager.
+// Executors with shared dependencies can implement HealthChecker to be self-tested
+// by a HealthMonitor. Executors without side effects implement SideEffectFree, so
+// that dry runs execute them instead of stubbing them out.
 package executor
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 )
 
@@ -30,6 +34,19 @@ type Executor interface {
 	Validate(config map[string]any) error
 }
 
+// SideEffectFree is implemented by executors that only compute their output from their
+// config and input, without calling external systems, waiting or keeping state. Dry runs
+// execute them and stub out every other executor.
+type SideEffectFree interface {
+	SideEffectFree() bool
+}
+
+// IsSideEffectFree reports whether the executor declares itself free of side effects.
+func IsSideEffectFree(exec Executor) bool {
+	pure, ok := exec.(SideEffectFree)
+	return ok && pure.SideEffectFree()
+}
+
 // Manager manages the registration and retrieval of executors.
 // It provides a central registry for all executor types.
 type Manager interface {
@@ -150,12 +167,18 @@ func (b *BaseExecutor) GetInt(config map[string]any, key string) (int, error) {
 		return 0, fmt.Errorf("field not found: %s", key)
 	}
 
-	// Handle both float64 (from JSON) and int
+	// Handle float64 and json.Number (from JSON) and int
 	switch v := val.(type) {
 	case float64:
 		return int(v), nil
 	case int:
 		return v, nil
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return 0, fmt.Errorf("field %s is not an integer", key)
+		}
+		return int(n), nil
 	default:
 		return 0, fmt.Errorf("field %s is not a number", key)
 	}
@@ -173,6 +196,11 @@ func (b *BaseExecutor) GetIntDefault(config map[string]any, key string, defaultV
 		return int(v)
 	case int:
 		return v
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return int(n)
+		}
+		return defaultValue
 	default:
 		return defaultValue
 	}
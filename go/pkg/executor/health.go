@@ -0,0 +1,249 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// HealthChecker is implemented by executors that can self-test the dependencies they share
+// across executions, such as pooled connections. Health returns nil when the executor can
+// serve nodes.
+type HealthChecker interface {
+	Health(ctx context.Context) error
+}
+
+// TargetHealthChecker is implemented by health-checked executors whose shared dependencies
+// belong to different connection targets, such as clients pooled per user-supplied host and
+// credential. The monitor keeps a status per target, so that an unreachable target only holds
+// back the nodes connecting to it.
+type TargetHealthChecker interface {
+	HealthChecker
+
+	// HealthTargets checks each target and returns the results by target key, nil when healthy.
+	HealthTargets(ctx context.Context) map[string]error
+
+	// HealthTarget returns the key of the target a node with the resolved config connects to.
+	HealthTarget(config map[string]any) string
+}
+
+// HealthStatus is the result of the latest health check of an executor.
+type HealthStatus struct {
+	Healthy   bool      `json:"healthy"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+	LatencyMs int64     `json:"latency_ms"`
+}
+
+// HealthMonitor runs the health checks of the registered executors that implement
+// HealthChecker, at startup and then periodically, and keeps the latest results.
+// Executors without a health check, and those not checked yet, count as healthy.
+type HealthMonitor struct {
+	manager Manager
+	timeout time.Duration
+
+	mu       sync.RWMutex
+	statuses map[string]HealthStatus
+	targets  map[string]map[string]HealthStatus // Of TargetHealthChecker executors, by node type and target
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewHealthMonitor creates a health monitor of the executors registered in manager.
+// timeout bounds each check (10s when <= 0).
+func NewHealthMonitor(manager Manager, timeout time.Duration) *HealthMonitor {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &HealthMonitor{
+		manager:  manager,
+		timeout:  timeout,
+		statuses: make(map[string]HealthStatus),
+		targets:  make(map[string]map[string]HealthStatus),
+	}
+}
+
+// Check runs the health checks of all registered executors concurrently and returns the
+// node types whose executor is unhealthy, sorted.
+func (m *HealthMonitor) Check(ctx context.Context) []string {
+	var wg sync.WaitGroup
+	for _, nodeType := range m.manager.List() {
+		exec, err := m.manager.Get(nodeType)
+		if err != nil {
+			continue
+		}
+		checker, ok := exec.(HealthChecker)
+		if !ok {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if targetChecker, ok := checker.(TargetHealthChecker); ok {
+				m.checkTargets(ctx, nodeType, targetChecker)
+				return
+			}
+			m.setStatus(nodeType, m.check(ctx, checker))
+		}()
+	}
+	wg.Wait()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var unhealthy []string
+	for nodeType, status := range m.statuses {
+		if !status.Healthy {
+			unhealthy = append(unhealthy, nodeType)
+		}
+	}
+	sort.Strings(unhealthy)
+	return unhealthy
+}
+
+// check runs one health check within the monitor timeout.
+func (m *HealthMonitor) check(ctx context.Context, checker HealthChecker) HealthStatus {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := checker.Health(ctx)
+	status := HealthStatus{
+		Healthy:   err == nil,
+		CheckedAt: start,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		status.Error = err.Error()
+	}
+	return status
+}
+
+// checkTargets runs the health check of each target of an executor within the monitor
+// timeout. The executor is healthy when all its targets are.
+func (m *HealthMonitor) checkTargets(ctx context.Context, nodeType string, checker TargetHealthChecker) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	start := time.Now()
+	results := checker.HealthTargets(ctx)
+	latency := time.Since(start).Milliseconds()
+
+	targets := make(map[string]HealthStatus, len(results))
+	keys := make([]string, 0, len(results))
+	for target := range results {
+		keys = append(keys, target)
+	}
+	sort.Strings(keys)
+	var errs []error
+	for _, target := range keys {
+		status := HealthStatus{Healthy: results[target] == nil, CheckedAt: start, LatencyMs: latency}
+		if err := results[target]; err != nil {
+			status.Error = err.Error()
+			errs = append(errs, err)
+		}
+		targets[target] = status
+	}
+
+	status := HealthStatus{Healthy: len(errs) == 0, CheckedAt: start, LatencyMs: latency}
+	if err := errors.Join(errs...); err != nil {
+		status.Error = err.Error()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.statuses[nodeType] = status
+	m.targets[nodeType] = targets
+}
+
+func (m *HealthMonitor) setStatus(nodeType string, status HealthStatus) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.statuses[nodeType] = status
+}
+
+// Status returns the latest health check result of a node type's executor, or false if
+// it has not been checked.
+func (m *HealthMonitor) Status(nodeType string) (HealthStatus, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	status, ok := m.statuses[nodeType]
+	return status, ok
+}
+
+// Healthy reports whether a node type's executor passed its latest health check.
+// Executors that have not been checked are healthy.
+func (m *HealthMonitor) Healthy(nodeType string) bool {
+	status, ok := m.Status(nodeType)
+	return !ok || status.Healthy
+}
+
+// StatusFor returns the latest health check result that applies to a node of the type with
+// the resolved config: that of the target it connects to for executors checked per target,
+// else that of the executor. It returns false if it has not been checked.
+func (m *HealthMonitor) StatusFor(nodeType string, config map[string]any) (HealthStatus, bool) {
+	if exec, err := m.manager.Get(nodeType); err == nil {
+		if checker, ok := exec.(TargetHealthChecker); ok {
+			target := checker.HealthTarget(config)
+			m.mu.RLock()
+			defer m.mu.RUnlock()
+			status, ok := m.targets[nodeType][target]
+			return status, ok
+		}
+	}
+	return m.Status(nodeType)
+}
+
+// HealthyFor reports whether a node of the type with the resolved config may run (see
+// StatusFor). Targets and executors that have not been checked are healthy.
+func (m *HealthMonitor) HealthyFor(nodeType string, config map[string]any) bool {
+	status, ok := m.StatusFor(nodeType, config)
+	return !ok || status.Healthy
+}
+
+// Snapshot returns the latest health check results by node type.
+func (m *HealthMonitor) Snapshot() map[string]HealthStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	snapshot := make(map[string]HealthStatus, len(m.statuses))
+	for nodeType, status := range m.statuses {
+		snapshot[nodeType] = status
+	}
+	return snapshot
+}
+
+// Start runs the health checks every interval until Stop is called.
+// Run Check first for a startup self-test.
+func (m *HealthMonitor) Start(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	m.done = make(chan struct{})
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				m.Check(context.Background())
+			case <-m.done:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the periodic health checks.
+func (m *HealthMonitor) Stop() {
+	if m.done == nil {
+		return
+	}
+	close(m.done)
+	m.wg.Wait()
+	m.done = nil
+}
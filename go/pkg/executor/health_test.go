@@ -0,0 +1,102 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// healthCheckedExecutor is a mock executor with a health check.
+type healthCheckedExecutor struct {
+	mockExecutor
+	err error
+}
+
+func (e *healthCheckedExecutor) Health(ctx context.Context) error {
+	return e.err
+}
+
+func TestHealthMonitor_Check(t *testing.T) {
+	manager := NewManager()
+	smtp := &healthCheckedExecutor{err: errors.New("login failed")}
+	redis := &healthCheckedExecutor{}
+	manager.Register("smtp", smtp)
+	manager.Register("redis", redis)
+	manager.Register("transform", &mockExecutor{})
+
+	monitor := NewHealthMonitor(manager, 0)
+	if !monitor.Healthy("smtp") {
+		t.Error("expected unchecked executor to be healthy")
+	}
+
+	unhealthy := monitor.Check(context.Background())
+	if len(unhealthy) != 1 || unhealthy[0] != "smtp" {
+		t.Fatalf("expected smtp to be unhealthy, got %v", unhealthy)
+	}
+	if monitor.Healthy("smtp") {
+		t.Error("expected smtp to be unhealthy")
+	}
+	if status, _ := monitor.Status("smtp"); status.Error != "login failed" || status.CheckedAt.IsZero() {
+		t.Errorf("unexpected smtp status: %+v", status)
+	}
+	if !monitor.Healthy("redis") || !monitor.Healthy("transform") {
+		t.Error("expected redis and transform to be healthy")
+	}
+	if _, ok := monitor.Status("transform"); ok {
+		t.Error("expected executor without health check not to be checked")
+	}
+
+	snapshot := monitor.Snapshot()
+	if len(snapshot) != 2 {
+		t.Errorf("expected 2 checked executors, got %d", len(snapshot))
+	}
+
+	smtp.err = nil
+	if unhealthy := monitor.Check(context.Background()); len(unhealthy) != 0 {
+		t.Errorf("expected all executors to recover, got %v", unhealthy)
+	}
+}
+
+// pooledExecutor is a mock executor with a health check per pooled target.
+type pooledExecutor struct {
+	mockExecutor
+	errs map[string]error
+}
+
+func (e *pooledExecutor) Health(ctx context.Context) error {
+	return errors.Join(e.errs["a"], e.errs["b"])
+}
+
+func (e *pooledExecutor) HealthTargets(ctx context.Context) map[string]error {
+	return e.errs
+}
+
+func (e *pooledExecutor) HealthTarget(config map[string]any) string {
+	target, _ := config["host"].(string)
+	return target
+}
+
+func TestHealthMonitor_CheckTargets(t *testing.T) {
+	manager := NewManager()
+	pool := &pooledExecutor{errs: map[string]error{"a": nil, "b": errors.New("connection refused")}}
+	manager.Register("redis", pool)
+
+	monitor := NewHealthMonitor(manager, 0)
+	unhealthy := monitor.Check(context.Background())
+	if len(unhealthy) != 1 || unhealthy[0] != "redis" {
+		t.Fatalf("expected redis to be reported unhealthy, got %v", unhealthy)
+	}
+
+	if !monitor.HealthyFor("redis", map[string]any{"host": "a"}) {
+		t.Error("expected the healthy target to be healthy")
+	}
+	if monitor.HealthyFor("redis", map[string]any{"host": "b"}) {
+		t.Error("expected the unreachable target to be unhealthy")
+	}
+	if status, _ := monitor.StatusFor("redis", map[string]any{"host": "b"}); status.Error != "connection refused" {
+		t.Errorf("unexpected target status: %+v", status)
+	}
+	if !monitor.HealthyFor("redis", map[string]any{"host": "c"}) {
+		t.Error("expected an unchecked target to be healthy")
+	}
+}
@@ -0,0 +1,258 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/expr-lang/expr"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+// NumberModeFromContext returns the number mode of the execution running in ctx.
+func NumberModeFromContext(ctx context.Context) models.NumberMode {
+	if data, ok := GetExecutionContext(ctx); ok && data.NumberMode != "" {
+		return data.NumberMode
+	}
+	return models.NumberModeFloat
+}
+
+// UnmarshalJSON decodes data like json.Unmarshal, except that in the preserve number
+// mode of the execution running in ctx, numbers in interface values become json.Number.
+// Executors use it for JSON they receive (response bodies, script output) so that
+// amounts and large IDs keep their exact digits.
+func UnmarshalJSON(ctx context.Context, data []byte, v any) error {
+	if NumberModeFromContext(ctx) != models.NumberModePreserve {
+		return json.Unmarshal(data, v)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid character after top-level value")
+	}
+	return nil
+}
+
+// NormalizeNumbers returns v with json.Number values converted to int64, or to float64
+// when they are not integers or overflow int64, so expressions can compute with them.
+// Maps and slices are copied only when they contain a json.Number.
+func NormalizeNumbers(v any) any {
+	normalized, _ := normalizeNumbers(v)
+	return normalized
+}
+
+func normalizeNumbers(v any) (any, bool) {
+	switch value := v.(type) {
+	case json.Number:
+		if n, err := value.Int64(); err == nil {
+			return n, true
+		}
+		if f, err := value.Float64(); err == nil {
+			return f, true
+		}
+		return value, false
+	case map[string]any:
+		var copied map[string]any
+		for key, item := range value {
+			normalized, changed := normalizeNumbers(item)
+			if !changed {
+				continue
+			}
+			if copied == nil {
+				copied = make(map[string]any, len(value))
+				for k, original := range value {
+					copied[k] = original
+				}
+			}
+			copied[key] = normalized
+		}
+		if copied == nil {
+			return value, false
+		}
+		return copied, true
+	case []any:
+		var copied []any
+		for i, item := range value {
+			normalized, changed := normalizeNumbers(item)
+			if !changed {
+				continue
+			}
+			if copied == nil {
+				copied = append([]any(nil), value...)
+			}
+			copied[i] = normalized
+		}
+		if copied == nil {
+			return value, false
+		}
+		return copied, true
+	default:
+		return v, false
+	}
+}
+
+// DecimalFunctions are expression functions for exact decimal arithmetic. Arguments are
+// numbers, json.Number or numeric strings; float64 arguments are taken as their shortest
+// decimal form, which is exact for values with up to 15 significant digits. Results are
+// json.Number, so they keep their digits in node output:
+//
+//	decimal(x)              x as an exact decimal
+//	decimalAdd(a, b)        a + b, with the larger scale of a and b
+//	decimalSub(a, b)        a - b, with the larger scale of a and b
+//	decimalMul(a, b)        a * b, with the sum of the scales of a and b
+//	decimalDiv(a, b, scale) a / b, rounded to scale decimal places
+//	decimalRound(x, scale)  x rounded to scale decimal places
+//	decimalCmp(a, b)        -1, 0 or 1
+//
+// Rounding is half away from zero.
+var DecimalFunctions = []expr.Option{
+	expr.Function("decimal", func(params ...any) (any, error) {
+		x, err := parseDecimal(params[0])
+		if err != nil {
+			return nil, err
+		}
+		return x.number(x.scale), nil
+	}, new(func(any) json.Number)),
+	expr.Function("decimalAdd", decimalBinary(func(a, b decimalValue) json.Number {
+		return decimalValue{new(big.Rat).Add(a.rat, b.rat), 0}.number(max(a.scale, b.scale))
+	}), new(func(any, any) json.Number)),
+	expr.Function("decimalSub", decimalBinary(func(a, b decimalValue) json.Number {
+		return decimalValue{new(big.Rat).Sub(a.rat, b.rat), 0}.number(max(a.scale, b.scale))
+	}), new(func(any, any) json.Number)),
+	expr.Function("decimalMul", decimalBinary(func(a, b decimalValue) json.Number {
+		return decimalValue{new(big.Rat).Mul(a.rat, b.rat), 0}.number(a.scale + b.scale)
+	}), new(func(any, any) json.Number)),
+	expr.Function("decimalDiv", func(params ...any) (any, error) {
+		a, b, err := parseDecimalPair(params)
+		if err != nil {
+			return nil, err
+		}
+		if b.rat.Sign() == 0 {
+			return nil, fmt.Errorf("decimalDiv: division by zero")
+		}
+		scale, err := decimalScaleArg(params[2])
+		if err != nil {
+			return nil, err
+		}
+		return decimalValue{new(big.Rat).Quo(a.rat, b.rat), 0}.number(scale), nil
+	}, new(func(any, any, int) json.Number)),
+	expr.Function("decimalRound", func(params ...any) (any, error) {
+		x, err := parseDecimal(params[0])
+		if err != nil {
+			return nil, err
+		}
+		scale, err := decimalScaleArg(params[1])
+		if err != nil {
+			return nil, err
+		}
+		return x.number(scale), nil
+	}, new(func(any, int) json.Number)),
+	expr.Function("decimalCmp", func(params ...any) (any, error) {
+		a, b, err := parseDecimalPair(params)
+		if err != nil {
+			return nil, err
+		}
+		return a.rat.Cmp(b.rat), nil
+	}, new(func(any, any) int)),
+}
+
+// maxDecimalScale bounds the scale of decimal results.
+const maxDecimalScale = 100
+
+type decimalValue struct {
+	rat   *big.Rat
+	scale int // decimal places of the value as written
+}
+
+// number formats the value with scale decimal places.
+func (d decimalValue) number(scale int) json.Number {
+	return json.Number(d.rat.FloatString(scale))
+}
+
+func decimalBinary(op func(a, b decimalValue) json.Number) func(params ...any) (any, error) {
+	return func(params ...any) (any, error) {
+		a, b, err := parseDecimalPair(params)
+		if err != nil {
+			return nil, err
+		}
+		return op(a, b), nil
+	}
+}
+
+func parseDecimalPair(params []any) (decimalValue, decimalValue, error) {
+	a, err := parseDecimal(params[0])
+	if err != nil {
+		return decimalValue{}, decimalValue{}, err
+	}
+	b, err := parseDecimal(params[1])
+	if err != nil {
+		return decimalValue{}, decimalValue{}, err
+	}
+	return a, b, nil
+}
+
+func parseDecimal(v any) (decimalValue, error) {
+	var text string
+	switch value := v.(type) {
+	case json.Number:
+		text = value.String()
+	case string:
+		text = strings.TrimSpace(value)
+	case float64:
+		text = strconv.FormatFloat(value, 'f', -1, 64)
+	case float32:
+		text = strconv.FormatFloat(float64(value), 'f', -1, 32)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		text = fmt.Sprint(value)
+	default:
+		return decimalValue{}, fmt.Errorf("cannot use %T as a decimal", v)
+	}
+
+	rat, ok := new(big.Rat).SetString(text)
+	if !ok || strings.ContainsAny(text, "/") {
+		return decimalValue{}, fmt.Errorf("%q is not a decimal number", text)
+	}
+
+	scale := 0
+	if strings.ContainsAny(text, "eE") {
+		// Find the places needed to write the value exactly
+		shifted := new(big.Rat).Set(rat)
+		ten := big.NewRat(10, 1)
+		for !shifted.IsInt() && scale < maxDecimalScale {
+			shifted.Mul(shifted, ten)
+			scale++
+		}
+	} else if dot := strings.IndexByte(text, '.'); dot >= 0 {
+		scale = len(text) - dot - 1
+	}
+	return decimalValue{rat: rat, scale: min(scale, maxDecimalScale)}, nil
+}
+
+func decimalScaleArg(v any) (int, error) {
+	var scale int
+	switch value := v.(type) {
+	case int:
+		scale = value
+	case int64:
+		scale = int(value)
+	case float64:
+		scale = int(value)
+	default:
+		return 0, fmt.Errorf("scale must be an integer, got %T", v)
+	}
+	if scale < 0 || scale > maxDecimalScale {
+		return 0, fmt.Errorf("scale must be between 0 and %d", maxDecimalScale)
+	}
+	return scale, nil
+}
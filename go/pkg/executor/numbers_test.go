@@ -0,0 +1,122 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+func TestUnmarshalJSON_NumberModes(t *testing.T) {
+	data := []byte(`{"id": 9007199254740993, "amount": 10.10, "items": [1, 2.5]}`)
+
+	var floats map[string]any
+	require.NoError(t, UnmarshalJSON(context.Background(), data, &floats))
+	assert.Equal(t, float64(9007199254740992), floats["id"])
+	assert.Equal(t, 10.1, floats["amount"])
+
+	ctx := WithExecutionContext(context.Background(), &ExecutionContextData{NumberMode: models.NumberModePreserve})
+	assert.Equal(t, models.NumberModePreserve, NumberModeFromContext(ctx))
+
+	var preserved map[string]any
+	require.NoError(t, UnmarshalJSON(ctx, data, &preserved))
+	assert.Equal(t, json.Number("9007199254740993"), preserved["id"])
+	assert.Equal(t, json.Number("10.10"), preserved["amount"])
+	assert.Equal(t, []any{json.Number("1"), json.Number("2.5")}, preserved["items"])
+
+	// re-encoding keeps the original digits
+	encoded, err := json.Marshal(preserved["amount"])
+	require.NoError(t, err)
+	assert.Equal(t, "10.10", string(encoded))
+
+	var v any
+	assert.Error(t, UnmarshalJSON(ctx, []byte(`{"a": 1} {"b": 2}`), &v))
+	assert.Error(t, UnmarshalJSON(ctx, []byte(`{"a": `), &v))
+}
+
+func TestNormalizeNumbers(t *testing.T) {
+	original := map[string]any{
+		"id":     json.Number("9007199254740993"),
+		"amount": json.Number("10.10"),
+		"name":   "order",
+		"items":  []any{json.Number("1"), "x"},
+		"nested": map[string]any{"flag": true},
+	}
+
+	normalized := NormalizeNumbers(original).(map[string]any)
+	assert.Equal(t, int64(9007199254740993), normalized["id"])
+	assert.Equal(t, 10.1, normalized["amount"])
+	assert.Equal(t, []any{int64(1), "x"}, normalized["items"])
+	assert.Equal(t, "order", normalized["name"])
+
+	// the input is not modified
+	assert.Equal(t, json.Number("10.10"), original["amount"])
+	assert.Equal(t, json.Number("1"), original["items"].([]any)[0])
+
+	// values without json.Number are returned as is
+	plain := map[string]any{"a": 1.5}
+	assert.Equal(t, plain, NormalizeNumbers(plain))
+}
+
+func TestDecimalFunctions(t *testing.T) {
+	env := map[string]any{
+		"amount": json.Number("19.99"),
+		"price":  0.1,
+		"qty":    3,
+		"rate":   "0.075",
+	}
+
+	tests := []struct {
+		expression string
+		want       any
+	}{
+		{`decimal(amount)`, json.Number("19.99")},
+		{`decimalAdd(price, 0.2)`, json.Number("0.3")},
+		{`decimalAdd(amount, "0.01")`, json.Number("20.00")},
+		{`decimalSub(amount, 20)`, json.Number("-0.01")},
+		{`decimalMul(amount, qty)`, json.Number("59.97")},
+		{`decimalMul(amount, rate)`, json.Number("1.49925")},
+		{`decimalRound(decimalMul(amount, rate), 2)`, json.Number("1.50")},
+		{`decimalDiv(10, 3, 4)`, json.Number("3.3333")},
+		{`decimalDiv("2", "3", 2)`, json.Number("0.67")},
+		{`decimalRound("-2.5", 0)`, json.Number("-3")},
+		{`decimal("1.5e3")`, json.Number("1500")},
+		{`decimal("1e-3")`, json.Number("0.001")},
+		{`decimalCmp(amount, "19.990")`, 0},
+		{`decimalCmp(price, 0.3) < 0`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			program, err := expr.Compile(tt.expression, append([]expr.Option{expr.Env(env)}, DecimalFunctions...)...)
+			require.NoError(t, err)
+			result, err := expr.Run(program, env)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func TestDecimalFunctions_Errors(t *testing.T) {
+	tests := map[string]string{
+		`decimalDiv(1, 0, 2)`: "division by zero",
+		`decimalRound(1, -1)`: "scale must be between",
+		`decimal("abc")`:      "is not a decimal number",
+		`decimal("1/3")`:      "is not a decimal number",
+		`decimalAdd(true, 1)`: "cannot use bool as a decimal",
+	}
+
+	for expression, message := range tests {
+		t.Run(expression, func(t *testing.T) {
+			program, err := expr.Compile(expression, DecimalFunctions...)
+			require.NoError(t, err)
+			_, err = expr.Run(program, nil)
+			assert.ErrorContains(t, err, message)
+		})
+	}
+}
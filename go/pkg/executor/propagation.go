@@ -0,0 +1,159 @@
+package executor
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Headers set on outbound requests by InjectHeaders.
+const (
+	HeaderCorrelationID = "X-Correlation-ID"
+	HeaderExecutionID   = "X-MBFlow-Execution-ID"
+	HeaderWorkflowID    = "X-MBFlow-Workflow-ID"
+	HeaderNodeID        = "X-MBFlow-Node-ID"
+	HeaderWorkspaceID   = "X-MBFlow-Workspace-ID"
+	HeaderDeadline      = "X-MBFlow-Deadline"
+	HeaderBaggage       = "Baggage" // W3C baggage
+)
+
+// Propagation is the caller-supplied context of an execution. The engine passes it to
+// every executor (see ExecutionContextData) and to sub-workflow executions, so executors
+// no longer need ad-hoc config values to learn who and what they are running for.
+type Propagation struct {
+	CorrelationID string            // ties the execution to an upstream request or trace
+	WorkspaceID   string            // workspace the execution runs in
+	UserID        string            // user the execution runs for
+	RentalKeyID   string            // rental key that pays for LLM calls, if any
+	Baggage       map[string]string // arbitrary key/values forwarded to outbound calls
+}
+
+// Tenant returns the ID of the workspace whose storage the execution uses: its workspace, or
+// the personal workspace of its user when it runs outside one. It is empty when neither is known.
+func (p Propagation) Tenant() string {
+	if p.WorkspaceID != "" {
+		return p.WorkspaceID
+	}
+	return p.UserID
+}
+
+// Remaining returns the time left until the node deadline, and false if the node has none.
+func (d *ExecutionContextData) Remaining() (time.Duration, bool) {
+	if d.Deadline.IsZero() {
+		return 0, false
+	}
+	return time.Until(d.Deadline), true
+}
+
+// PropagationHeaders returns the tracing headers for an outbound request made by the
+// node running in ctx. User and rental key IDs are internal and never sent to third parties.
+func PropagationHeaders(ctx context.Context) map[string]string {
+	data, ok := GetExecutionContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	headers := make(map[string]string)
+	setIfNotEmpty := func(name, value string) {
+		if value != "" {
+			headers[name] = value
+		}
+	}
+	setIfNotEmpty(HeaderCorrelationID, data.CorrelationID)
+	setIfNotEmpty(HeaderExecutionID, data.ExecutionID)
+	setIfNotEmpty(HeaderWorkflowID, data.WorkflowID)
+	setIfNotEmpty(HeaderNodeID, data.NodeID)
+	setIfNotEmpty(HeaderWorkspaceID, data.WorkspaceID)
+	if !data.Deadline.IsZero() {
+		headers[HeaderDeadline] = data.Deadline.UTC().Format(time.RFC3339Nano)
+	}
+	setIfNotEmpty(HeaderBaggage, EncodeBaggage(data.Baggage))
+	return headers
+}
+
+// InjectHeaders adds the propagation headers of ctx to h. Headers already set
+// (for example from the node config) are left untouched.
+func InjectHeaders(ctx context.Context, h http.Header) {
+	for name, value := range PropagationHeaders(ctx) {
+		if h.Get(name) == "" {
+			h.Set(name, value)
+		}
+	}
+}
+
+// LLMMetadata returns request metadata for LLM providers that accept it, so provider-side
+// logs can be traced back to the execution. Like PropagationHeaders it omits user and
+// rental key IDs. Baggage keys never override the built-in keys.
+func LLMMetadata(ctx context.Context) map[string]string {
+	data, ok := GetExecutionContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	metadata := make(map[string]string)
+	for k, v := range data.Baggage {
+		metadata[k] = v
+	}
+	setIfNotEmpty := func(key, value string) {
+		if value != "" {
+			metadata[key] = value
+		}
+	}
+	setIfNotEmpty("mbflow_correlation_id", data.CorrelationID)
+	setIfNotEmpty("mbflow_execution_id", data.ExecutionID)
+	setIfNotEmpty("mbflow_workflow_id", data.WorkflowID)
+	setIfNotEmpty("mbflow_node_id", data.NodeID)
+	setIfNotEmpty("mbflow_workspace_id", data.WorkspaceID)
+	if len(metadata) == 0 {
+		return nil
+	}
+	return metadata
+}
+
+// EncodeBaggage encodes key/values as a W3C baggage header value, sorted by key.
+func EncodeBaggage(baggage map[string]string) string {
+	if len(baggage) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(baggage))
+	for k := range baggage {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	members := make([]string, 0, len(keys))
+	for _, k := range keys {
+		members = append(members, url.QueryEscape(k)+"="+url.PathEscape(baggage[k]))
+	}
+	return strings.Join(members, ",")
+}
+
+// ParseBaggage decodes a W3C baggage header value. Malformed members and member
+// properties are ignored.
+func ParseBaggage(header string) map[string]string {
+	baggage := make(map[string]string)
+	for _, member := range strings.Split(header, ",") {
+		member, _, _ = strings.Cut(member, ";")
+		key, value, ok := strings.Cut(strings.TrimSpace(member), "=")
+		if !ok {
+			continue
+		}
+		key, err := url.QueryUnescape(strings.TrimSpace(key))
+		if err != nil || key == "" {
+			continue
+		}
+		value, err = url.PathUnescape(strings.TrimSpace(value))
+		if err != nil {
+			continue
+		}
+		baggage[key] = value
+	}
+	if len(baggage) == 0 {
+		return nil
+	}
+	return baggage
+}
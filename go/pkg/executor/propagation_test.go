@@ -0,0 +1,78 @@
+package executor
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPropagationHeaders(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, PropagationHeaders(context.Background()))
+
+	deadline := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	ctx := WithExecutionContext(context.Background(), &ExecutionContextData{
+		ExecutionID: "exec-1",
+		WorkflowID:  "wf-1",
+		NodeID:      "fetch",
+		Deadline:    deadline,
+		Propagation: Propagation{
+			CorrelationID: "corr-1",
+			UserID:        "user-1",
+			RentalKeyID:   "rk-1",
+			Baggage:       map[string]string{"tenant": "acme", "region": "eu west"},
+		},
+	})
+
+	assert.Equal(t, map[string]string{
+		HeaderCorrelationID: "corr-1",
+		HeaderExecutionID:   "exec-1",
+		HeaderWorkflowID:    "wf-1",
+		HeaderNodeID:        "fetch",
+		HeaderDeadline:      "2026-01-02T03:04:05Z",
+		HeaderBaggage:       "region=eu%20west,tenant=acme",
+	}, PropagationHeaders(ctx))
+
+	h := http.Header{}
+	h.Set(HeaderCorrelationID, "from-config")
+	InjectHeaders(ctx, h)
+	assert.Equal(t, "from-config", h.Get(HeaderCorrelationID))
+	assert.Equal(t, "exec-1", h.Get(HeaderExecutionID))
+}
+
+func TestLLMMetadata(t *testing.T) {
+	t.Parallel()
+
+	ctx := WithExecutionContext(context.Background(), &ExecutionContextData{
+		ExecutionID: "exec-1",
+		NodeID:      "summarize",
+		Propagation: Propagation{
+			WorkspaceID: "ws-1",
+			UserID:      "user-1",
+			Baggage:     map[string]string{"tenant": "acme", "mbflow_node_id": "spoofed"},
+		},
+	})
+
+	assert.Equal(t, map[string]string{
+		"mbflow_execution_id": "exec-1",
+		"mbflow_node_id":      "summarize",
+		"mbflow_workspace_id": "ws-1",
+		"tenant":              "acme",
+	}, LLMMetadata(ctx))
+	assert.Nil(t, LLMMetadata(WithExecutionContext(context.Background(), &ExecutionContextData{})))
+}
+
+func TestParseBaggage(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, map[string]string{"tenant": "acme", "region": "eu west"},
+		ParseBaggage(" tenant = acme ;prop=1, region=eu%20west, malformed"))
+	assert.Nil(t, ParseBaggage(""))
+
+	baggage := map[string]string{"a": "1,2", "b": "x=y"}
+	assert.Equal(t, baggage, ParseBaggage(EncodeBaggage(baggage)))
+}
@@ -0,0 +1,56 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SuspendError is returned by an executor to suspend its branch of the workflow until ResumeAt.
+// It is not a failure: when the execution can be persisted, the engine stops the branch and the
+// execution is resumed later; otherwise the engine waits inline. Either way the node completes
+// with Output once ResumeAt is reached, without the executor being called again.
+//
+// A suspension with an EventKey also resumes early when an event with that key is delivered;
+// the node then completes with EventOutput instead, and ResumeAt acts as the timeout.
+type SuspendError struct {
+	ResumeAt time.Time
+	Output   any
+	EventKey string
+}
+
+// Error implements the error interface.
+func (e *SuspendError) Error() string {
+	return fmt.Sprintf("node suspended until %s", e.ResumeAt.UTC().Format(time.RFC3339))
+}
+
+// Suspend returns an error suspending the node until resumeAt, completing it with output afterwards.
+func Suspend(resumeAt time.Time, output any) error {
+	return &SuspendError{ResumeAt: resumeAt, Output: output}
+}
+
+// SuspendForEvent returns an error suspending the node until an event with the key is delivered
+// or until timeoutAt, whichever comes first. On timeout the node completes with
+// EventOutput(key, nil, true).
+func SuspendForEvent(key string, timeoutAt time.Time) error {
+	return &SuspendError{ResumeAt: timeoutAt, Output: EventOutput(key, nil, true), EventKey: key}
+}
+
+// EventOutput is the output of a node that waited for an event: the event payload, or a
+// nil payload with timed_out set if the event did not arrive in time.
+func EventOutput(key string, event any, timedOut bool) map[string]any {
+	return map[string]any{
+		"correlation_key": key,
+		"event":           event,
+		"timed_out":       timedOut,
+	}
+}
+
+// AsSuspend reports whether err is (or wraps) a SuspendError and returns it.
+func AsSuspend(err error) (*SuspendError, bool) {
+	var suspend *SuspendError
+	if errors.As(err, &suspend) {
+		return suspend, true
+	}
+	return nil, false
+}
@@ -2,8 +2,10 @@ package executor
 
 import (
 	"context"
+	"time"
 
 	"github.com/smilemakc/mbflow/go/internal/application/template"
+	"github.com/smilemakc/mbflow/go/pkg/models"
 )
 
 // TemplateExecutorWrapper wraps an executor to automatically resolve templates in its configuration.
@@ -47,13 +49,26 @@ func (w *TemplateExecutorWrapper) Validate(config map[string]any) error {
 // ExecutionContextKey is used to store execution context in context.Context
 type ExecutionContextKey struct{}
 
-// ExecutionContextData holds data needed for template resolution during execution.
+// ExecutionContextData holds data needed for template resolution during execution,
+// and the identity and deadline of the running node for propagation to outbound calls.
 type ExecutionContextData struct {
 	WorkflowVariables  map[string]any
 	ExecutionVariables map[string]any
 	ParentNodeOutput   map[string]any
 	Resources          map[string]any // alias -> resource data
 	StrictMode         bool
+	NumberMode         models.NumberMode // how executors decode JSON numbers (empty = float)
+
+	ExecutionID string
+	WorkflowID  string
+	NodeID      string
+	Deadline    time.Time // zero when the node has no deadline
+
+	// OnOutputDelta receives incremental output while the node runs, e.g. streamed LLM
+	// tokens; nil when nobody listens
+	OnOutputDelta func(delta string)
+
+	Propagation
 }
 
 // GetExecutionContext retrieves execution context from context.Context.
@@ -62,6 +77,16 @@ func GetExecutionContext(ctx context.Context) (*ExecutionContextData, bool) {
 	return data, ok
 }
 
+// OutputDeltaFunc returns the function receiving incremental output of the running node,
+// or nil when the execution does not stream node output.
+func OutputDeltaFunc(ctx context.Context) func(delta string) {
+	data, ok := GetExecutionContext(ctx)
+	if !ok {
+		return nil
+	}
+	return data.OnOutputDelta
+}
+
 // WithExecutionContext adds execution context to context.Context.
 func WithExecutionContext(ctx context.Context, data *ExecutionContextData) context.Context {
 	return context.WithValue(ctx, ExecutionContextKey{}, data)
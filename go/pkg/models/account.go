@@ -18,6 +18,7 @@ type Account struct {
 	Balance   float64       `json:"balance"`
 	Currency  string        `json:"currency"`
 	Status    AccountStatus `json:"status"`
+	Quota     *UsageQuota   `json:"quota,omitempty"` // Daily usage quota of the user's executions; nil means the default
 	CreatedAt time.Time     `json:"created_at"`
 	UpdatedAt time.Time     `json:"updated_at"`
 }
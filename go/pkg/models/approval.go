@@ -0,0 +1,79 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// ApprovalStatus is the state of an approval node: pending while it waits for a decision,
+// then the decision.
+type ApprovalStatus string
+
+const (
+	ApprovalStatusPending  ApprovalStatus = "pending"
+	ApprovalStatusApproved ApprovalStatus = "approved"
+	ApprovalStatusRejected ApprovalStatus = "rejected"
+	ApprovalStatusTimeout  ApprovalStatus = "timeout" // No decision before the approval timed out
+)
+
+// Approval is a request for a human decision raised by an approval node of an execution.
+type Approval struct {
+	ExecutionID string         `json:"execution_id"`
+	NodeID      string         `json:"node_id"`
+	NodeName    string         `json:"node_name,omitempty"`
+	Status      ApprovalStatus `json:"status"`
+	Message     string         `json:"message,omitempty"`
+	Approvers   []string       `json:"approvers,omitempty"` // User IDs allowed to decide; empty allows any user
+	RequestedAt time.Time      `json:"requested_at"`
+	ExpiresAt   *time.Time     `json:"expires_at,omitempty"`
+	Comment     string         `json:"comment,omitempty"`
+	DecidedBy   string         `json:"decided_by,omitempty"`
+	DecidedAt   *time.Time     `json:"decided_at,omitempty"`
+}
+
+// ApprovalEventKey is the event key an approval node waits for: deciding the approval
+// delivers the decision under this key.
+func ApprovalEventKey(executionID, nodeID string) string {
+	return "approval:" + executionID + ":" + nodeID
+}
+
+// CanDecide reports whether the user may decide the approval.
+func (a *Approval) CanDecide(userID string) bool {
+	if len(a.Approvers) == 0 {
+		return true
+	}
+	for _, approver := range a.Approvers {
+		if approver == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// ApprovalApprovers returns the user IDs allowed to decide an approval node with the config:
+// its approvers entry, a user ID or a list of them. Nil allows any user.
+func ApprovalApprovers(config map[string]any) ([]string, error) {
+	switch v := config["approvers"].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if v == "" {
+			return nil, nil
+		}
+		return []string{v}, nil
+	case []string:
+		return v, nil
+	case []any:
+		approvers := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("approvers must be user IDs")
+			}
+			approvers = append(approvers, s)
+		}
+		return approvers, nil
+	default:
+		return nil, fmt.Errorf("approvers must be a user ID or a list of user IDs")
+	}
+}
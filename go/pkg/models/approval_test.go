@@ -0,0 +1,49 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApproval_CanDecide(t *testing.T) {
+	anyone := &Approval{}
+	if !anyone.CanDecide("user-1") {
+		t.Error("expected any user to decide an approval without approvers")
+	}
+
+	restricted := &Approval{Approvers: []string{"user-1", "user-2"}}
+	if !restricted.CanDecide("user-2") {
+		t.Error("expected an approver to decide")
+	}
+	if restricted.CanDecide("user-3") {
+		t.Error("expected other users not to decide")
+	}
+}
+
+func TestApprovalApprovers(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]any
+		want    []string
+		wantErr bool
+	}{
+		{name: "unset", config: map[string]any{}},
+		{name: "empty string", config: map[string]any{"approvers": ""}},
+		{name: "user ID", config: map[string]any{"approvers": "user-1"}, want: []string{"user-1"}},
+		{name: "list", config: map[string]any{"approvers": []any{"user-1", "user-2"}}, want: []string{"user-1", "user-2"}},
+		{name: "non-string item", config: map[string]any{"approvers": []any{"user-1", 2}}, wantErr: true},
+		{name: "invalid type", config: map[string]any{"approvers": 42}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApprovalApprovers(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
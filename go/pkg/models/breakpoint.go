@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// Breakpoint is a node a debug execution paused before. The execution stays paused until it
+// is continued, optionally with a replaced input for the node, or aborted.
+type Breakpoint struct {
+	ExecutionID string         `json:"execution_id"`
+	NodeID      string         `json:"node_id"`
+	NodeName    string         `json:"node_name,omitempty"`
+	NodeType    string         `json:"node_type,omitempty"`
+	Input       map[string]any `json:"input,omitempty"`  // Input the node would run with
+	Config      map[string]any `json:"config,omitempty"` // Config before template resolution
+	PausedAt    time.Time      `json:"paused_at"`
+}
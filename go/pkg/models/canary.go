@@ -0,0 +1,146 @@
+package models
+
+import (
+	"regexp"
+	"time"
+)
+
+// CanaryStatus is the health of a canary based on its most recent runs.
+type CanaryStatus string
+
+const (
+	// CanaryStatusUnknown means the canary has not run yet
+	CanaryStatusUnknown CanaryStatus = "unknown"
+
+	// CanaryStatusPassing means the last run completed and all assertions held
+	CanaryStatusPassing CanaryStatus = "passing"
+
+	// CanaryStatusFailing means the last run failed or an assertion did not hold
+	CanaryStatusFailing CanaryStatus = "failing"
+)
+
+// CanaryAssertionOp is a comparison used by a canary assertion.
+type CanaryAssertionOp string
+
+const (
+	CanaryOpEquals      CanaryAssertionOp = "eq"
+	CanaryOpNotEquals   CanaryAssertionOp = "neq"
+	CanaryOpExists      CanaryAssertionOp = "exists"
+	CanaryOpNotExists   CanaryAssertionOp = "not_exists"
+	CanaryOpContains    CanaryAssertionOp = "contains"
+	CanaryOpGreater     CanaryAssertionOp = "gt"
+	CanaryOpGreaterOrEq CanaryAssertionOp = "gte"
+	CanaryOpLess        CanaryAssertionOp = "lt"
+	CanaryOpLessOrEq    CanaryAssertionOp = "lte"
+	CanaryOpMatches     CanaryAssertionOp = "matches"
+)
+
+// IsValid reports whether the operator is supported.
+func (op CanaryAssertionOp) IsValid() bool {
+	switch op {
+	case CanaryOpEquals, CanaryOpNotEquals, CanaryOpExists, CanaryOpNotExists, CanaryOpContains,
+		CanaryOpGreater, CanaryOpGreaterOrEq, CanaryOpLess, CanaryOpLessOrEq, CanaryOpMatches:
+		return true
+	}
+	return false
+}
+
+// CanaryAssertion checks a value in the output of a canary execution.
+// Path is a dot-separated path (array indexes as numbers, e.g. "items.0.id").
+// When NodeID is empty the path is resolved against the execution output,
+// otherwise against the output of that node.
+type CanaryAssertion struct {
+	NodeID string            `json:"node_id,omitempty"`
+	Path   string            `json:"path"`
+	Op     CanaryAssertionOp `json:"op"`
+	Value  any               `json:"value,omitempty"`
+}
+
+// CanaryAssertionResult is the outcome of a single assertion.
+type CanaryAssertionResult struct {
+	Assertion CanaryAssertion `json:"assertion"`
+	Passed    bool            `json:"passed"`
+	Actual    any             `json:"actual,omitempty"`
+	Message   string          `json:"message,omitempty"`
+}
+
+// Canary marks a workflow as a synthetic monitor that runs on a schedule
+// and verifies its output with assertions.
+type Canary struct {
+	WorkflowID    string            `json:"workflow_id"`
+	WorkflowName  string            `json:"workflow_name,omitempty"`
+	Schedule      string            `json:"schedule"`
+	Input         map[string]any    `json:"input,omitempty"`
+	Assertions    []CanaryAssertion `json:"assertions,omitempty"`
+	MaxDurationMs int64             `json:"max_duration_ms,omitempty"`
+	RunbookURL    string            `json:"runbook_url,omitempty"` // Linked from alerts; overrides the workflow's incident policy runbook
+	Enabled       bool              `json:"enabled"`
+
+	Status              CanaryStatus `json:"status"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	LastRunAt           *time.Time   `json:"last_run_at,omitempty"`
+	LastExecutionID     string       `json:"last_execution_id,omitempty"`
+	LastError           string       `json:"last_error,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Validate validates the canary definition. The schedule expression itself
+// is parsed by the scheduler.
+func (c *Canary) Validate() error {
+	if c.WorkflowID == "" {
+		return &ValidationError{Field: "workflow_id", Message: "workflow ID is required"}
+	}
+	if c.Schedule == "" {
+		return &ValidationError{Field: "schedule", Message: "schedule is required"}
+	}
+	if c.MaxDurationMs < 0 {
+		return &ValidationError{Field: "max_duration_ms", Message: "max duration must be non-negative"}
+	}
+	if c.RunbookURL != "" && !isHTTPURL(c.RunbookURL) {
+		return &ValidationError{Field: "runbook_url", Message: "runbook URL must be an http or https URL"}
+	}
+
+	for _, a := range c.Assertions {
+		if !a.Op.IsValid() {
+			return &ValidationError{Field: "assertions", Message: "invalid operator: " + string(a.Op)}
+		}
+		if a.Path == "" && a.Op != CanaryOpExists && a.Op != CanaryOpNotExists {
+			return &ValidationError{Field: "assertions", Message: "path is required for operator " + string(a.Op)}
+		}
+		if a.Op == CanaryOpMatches {
+			pattern, ok := a.Value.(string)
+			if !ok {
+				return &ValidationError{Field: "assertions", Message: "matches requires a string pattern"}
+			}
+			if _, err := regexp.Compile(pattern); err != nil {
+				return &ValidationError{Field: "assertions", Message: "invalid pattern: " + err.Error()}
+			}
+		}
+	}
+
+	return nil
+}
+
+// CanaryRun is the recorded result of one canary run.
+type CanaryRun struct {
+	ID          string                  `json:"id"`
+	WorkflowID  string                  `json:"workflow_id"`
+	ExecutionID string                  `json:"execution_id,omitempty"`
+	Passed      bool                    `json:"passed"`
+	DurationMs  int64                   `json:"duration_ms"`
+	Error       string                  `json:"error,omitempty"`
+	Results     []CanaryAssertionResult `json:"results,omitempty"`
+	CreatedAt   time.Time               `json:"created_at"`
+}
+
+// CanarySummary aggregates canary health for the admin overview.
+type CanarySummary struct {
+	Total    int       `json:"total"`
+	Enabled  int       `json:"enabled"`
+	Passing  int       `json:"passing"`
+	Failing  int       `json:"failing"`
+	Unknown  int       `json:"unknown"`
+	Failures []*Canary `json:"failures"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// MaxDeadLetterPageSize bounds DeadLetterFilter.Limit.
+const MaxDeadLetterPageSize = 200
+
+// MaxDeadLetterBatch is the most dead letters retried or discarded in one request.
+const MaxDeadLetterBatch = 100
+
+// DeadLetter is a failed execution parked in the dead-letter queue: its nodes exhausted
+// their retries, or a worker gave up on it. It stays there until it is retried from its
+// failed nodes or discarded.
+type DeadLetter struct {
+	ID           string `json:"id"`
+	ExecutionID  string `json:"execution_id"`
+	WorkflowID   string `json:"workflow_id"`
+	WorkflowName string `json:"workflow_name,omitempty"`
+	// Error is the error the execution failed with
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DeadLetterFilter selects and pages dead letters. Empty fields do not filter.
+type DeadLetterFilter struct {
+	WorkflowID string
+	Limit      int
+	Offset     int
+}
+
+// DeadLetterResult is the outcome of retrying or discarding one dead letter.
+type DeadLetterResult struct {
+	ID          string `json:"id"`
+	ExecutionID string `json:"execution_id,omitempty"`
+	// Error explains why the dead letter was not retried or discarded; empty on success
+	Error string `json:"error,omitempty"`
+}
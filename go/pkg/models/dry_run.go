@@ -0,0 +1,29 @@
+package models
+
+// DryRunNode is what a dry run did with one node. Stubbed nodes have side effects and
+// were not executed; their output is the mock output given for them, or an empty object.
+// Nodes the run never reached stay pending.
+type DryRunNode struct {
+	NodeID         string              `json:"node_id"`
+	NodeName       string              `json:"node_name,omitempty"`
+	NodeType       string              `json:"node_type"`
+	Status         NodeExecutionStatus `json:"status"`
+	Stubbed        bool                `json:"stubbed,omitempty"`
+	Input          any                 `json:"input,omitempty"`
+	ResolvedConfig map[string]any      `json:"resolved_config,omitempty"`
+	Output         any                 `json:"output,omitempty"`
+	Error          string              `json:"error,omitempty"`
+}
+
+// DryRunReport is the result of a dry run: which nodes would run for the input, with
+// their resolved configs, and the output the workflow would produce. Nothing of a dry run
+// is stored.
+type DryRunReport struct {
+	WorkflowID string          `json:"workflow_id"`
+	Status     ExecutionStatus `json:"status"`
+	Error      string          `json:"error,omitempty"`
+	Output     map[string]any  `json:"output,omitempty"`
+	WouldRun   []string        `json:"would_run"` // IDs of the nodes that completed, in run order
+	Nodes      []*DryRunNode   `json:"nodes"`     // Nodes in run order; unreached nodes last
+	DurationMs int64           `json:"duration_ms"`
+}
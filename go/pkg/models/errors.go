@@ -21,16 +21,49 @@ var (
 	ErrEdgeNotFound      = errors.New("edge not found")
 	ErrInvalidEdge       = errors.New("invalid edge")
 
+	// Launch profile errors
+	ErrLaunchProfileNotFound = errors.New("launch profile not found")
+
+	// Partial execution errors
+	ErrInvalidNodeSelection = errors.New("invalid node selection")
+
+	// Fixture errors
+	ErrFixtureNotFound = errors.New("fixture not found")
+
 	// Execution errors
 	ErrInvalidExecutionID  = errors.New("invalid execution ID")
 	ErrExecutionNotFound   = errors.New("execution not found")
 	ErrExecutionFailed     = errors.New("execution failed")
 	ErrExecutionCancelled  = errors.New("execution cancelled")
 	ErrExecutionTimeout    = errors.New("execution timeout")
+	ErrExecutionSuspended  = errors.New("execution suspended")
+	ErrExecutionPaused     = errors.New("execution paused")
+	ErrExecutionNotPaused  = errors.New("execution not paused")
+	ErrExecutionNotFailed  = errors.New("execution not failed")
+	ErrExecutionPreempted  = errors.New("execution preempted")
+	ErrExecutionNotRunning = errors.New("execution not running")
+	ErrNotAtBreakpoint     = errors.New("execution not stopped at a breakpoint")
 	ErrNodeExecutionFailed = errors.New("node execution failed")
+	ErrNodeTimeout         = errors.New("node timed out")
 	ErrInvalidInput        = errors.New("invalid input")
 	ErrInvalidOutput       = errors.New("invalid output")
 
+	ErrConcurrencyLimitReached = errors.New("workflow concurrency limit reached")
+	ErrQuotaExceeded           = errors.New("daily usage quota exceeded")
+
+	// Approval errors
+	ErrApprovalNotFound   = errors.New("approval not found")
+	ErrApprovalNotPending = errors.New("approval already decided")
+
+	// Execution note errors
+	ErrExecutionNoteNotFound = errors.New("execution note not found")
+
+	// Dead letter errors
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+	// Execution archive errors
+	ErrExecutionArchiveNotFound = errors.New("execution archive not found")
+
 	// Trigger errors
 	ErrInvalidTriggerID     = errors.New("invalid trigger ID")
 	ErrTriggerNotFound      = errors.New("trigger not found")
@@ -38,10 +71,23 @@ var (
 	ErrInvalidTriggerConfig = errors.New("invalid trigger configuration")
 	ErrTriggerDisabled      = errors.New("trigger is disabled")
 
+	// Canary errors
+	ErrCanaryNotFound = errors.New("canary not found")
+
+	// Incident errors
+	ErrIncidentPolicyNotFound       = errors.New("incident policy not found")
+	ErrIncidentTrackerNotConfigured = errors.New("incident tracker not configured for workflow")
+
+	// Onboarding errors
+	ErrAlreadyOnboarded           = errors.New("workspace already onboarded")
+	ErrOnboardingTemplateNotFound = errors.New("onboarding template not found")
+
 	// Executor errors
-	ErrExecutorNotFound = errors.New("executor not found")
-	ErrExecutorFailed   = errors.New("executor failed")
-	ErrInvalidConfig    = errors.New("invalid configuration")
+	ErrExecutorNotFound  = errors.New("executor not found")
+	ErrExecutorFailed    = errors.New("executor failed")
+	ErrExecutorUnhealthy = errors.New("executor unhealthy")
+	ErrInvalidConfig     = errors.New("invalid configuration")
+	ErrCircuitOpen       = errors.New("circuit breaker open")
 
 	// Authorization errors
 	ErrUnauthorized       = errors.New("unauthorized")
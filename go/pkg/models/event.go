@@ -25,6 +25,8 @@ const (
 	EventTypeExecutionCancelled = "execution.cancelled"
 	EventTypeExecutionPaused    = "execution.paused"
 	EventTypeExecutionResumed   = "execution.resumed"
+	// EventTypeExecutionDeadLettered is emitted when a failed execution enters the dead-letter queue
+	EventTypeExecutionDeadLettered = "execution.dead_lettered"
 
 	// Node-level events
 	EventTypeNodeStarted   = "node.started"
@@ -32,6 +34,11 @@ const (
 	EventTypeNodeFailed    = "node.failed"
 	EventTypeNodeSkipped   = "node.skipped"
 	EventTypeNodeRetrying  = "node.retrying"
+	EventTypeNodeSuspended = "node.suspended"
+	// EventTypeNodeCompensated is emitted when a compensation node has undone a completed node
+	EventTypeNodeCompensated = "node.compensated"
+	// EventTypeNodeCancelled is emitted when a running node is aborted because its execution was cancelled
+	EventTypeNodeCancelled = "node.cancelled"
 
 	// Wave-level events (parallel execution batches)
 	EventTypeWaveStarted   = "wave.started"
@@ -48,7 +55,8 @@ const (
 func (e *Event) IsExecutionEvent() bool {
 	switch e.EventType {
 	case EventTypeExecutionStarted, EventTypeExecutionCompleted, EventTypeExecutionFailed,
-		EventTypeExecutionCancelled, EventTypeExecutionPaused, EventTypeExecutionResumed:
+		EventTypeExecutionCancelled, EventTypeExecutionPaused, EventTypeExecutionResumed,
+		EventTypeExecutionDeadLettered:
 		return true
 	}
 	return false
@@ -58,7 +66,7 @@ func (e *Event) IsExecutionEvent() bool {
 func (e *Event) IsNodeEvent() bool {
 	switch e.EventType {
 	case EventTypeNodeStarted, EventTypeNodeCompleted, EventTypeNodeFailed,
-		EventTypeNodeSkipped, EventTypeNodeRetrying:
+		EventTypeNodeSkipped, EventTypeNodeRetrying, EventTypeNodeCompensated, EventTypeNodeCancelled:
 		return true
 	}
 	return false
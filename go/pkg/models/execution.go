@@ -1,6 +1,7 @@
 package models
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -22,6 +23,10 @@ type Execution struct {
 	Duration       int64            `json:"duration,omitempty"` // milliseconds
 	TriggeredBy    string           `json:"triggered_by,omitempty"`
 	Metadata       map[string]any   `json:"metadata,omitempty"`
+
+	// ResumeAt is when a paused execution resumes its earliest suspended node, or when a
+	// scheduled pending execution starts.
+	ResumeAt *time.Time `json:"resume_at,omitempty"`
 }
 
 // ExecutionStatus represents the status of an execution.
@@ -34,6 +39,10 @@ const (
 	ExecutionStatusFailed    ExecutionStatus = "failed"
 	ExecutionStatusCancelled ExecutionStatus = "cancelled"
 	ExecutionStatusTimeout   ExecutionStatus = "timeout"
+
+	// ExecutionStatusPaused marks a persisted execution waiting for a suspended node, such as a
+	// delay node, to resume, or paused on request until it is resumed
+	ExecutionStatusPaused ExecutionStatus = "paused"
 )
 
 // NodeExecution represents the execution of a single node within a workflow execution.
@@ -54,6 +63,16 @@ type NodeExecution struct {
 	Duration       int64               `json:"duration,omitempty"` // milliseconds
 	RetryCount     int                 `json:"retry_count,omitempty"`
 	Metadata       map[string]any      `json:"metadata,omitempty"`
+
+	// PayloadArchived reports that input, output and configs were moved to cold storage.
+	// Fetch the execution with full=true to load them back.
+	PayloadArchived bool `json:"payload_archived,omitempty"`
+
+	// DataLabels are the node's data-classification and retention labels (see NodeDataLabelsConfigKey).
+	// PayloadExpiresAt is when a retention label purges input, output and configs.
+	DataLabels       []string   `json:"data_labels,omitempty"`
+	PayloadExpiresAt *time.Time `json:"payload_expires_at,omitempty"`
+	PayloadPurged    bool       `json:"payload_purged,omitempty"`
 }
 
 // NodeExecutionStatus represents the status of a node execution.
@@ -66,6 +85,10 @@ const (
 	NodeExecutionStatusFailed    NodeExecutionStatus = "failed"
 	NodeExecutionStatusSkipped   NodeExecutionStatus = "skipped"
 	NodeExecutionStatusCancelled NodeExecutionStatus = "cancelled"
+	NodeExecutionStatusTimedOut  NodeExecutionStatus = "timed_out"
+	// NodeExecutionStatusCompensated marks a completed node whose compensation node undid it
+	// after the execution failed
+	NodeExecutionStatusCompensated NodeExecutionStatus = "compensated"
 )
 
 // IsTerminal returns true if the execution status is terminal (completed, failed, cancelled, timeout).
@@ -81,7 +104,9 @@ func (s NodeExecutionStatus) IsTerminal() bool {
 	return s == NodeExecutionStatusCompleted ||
 		s == NodeExecutionStatusFailed ||
 		s == NodeExecutionStatusSkipped ||
-		s == NodeExecutionStatusCancelled
+		s == NodeExecutionStatusCancelled ||
+		s == NodeExecutionStatusTimedOut ||
+		s == NodeExecutionStatusCompensated
 }
 
 // GetNodeExecution returns a node execution by node ID.
@@ -136,3 +161,26 @@ func (e *Execution) GetFailedNodes() []*NodeExecution {
 	}
 	return failed
 }
+
+// NodeSelection restricts an execution to a subgraph of the workflow: either the listed
+// nodes, or the nodes between FromNode and ToNode (inclusive; an empty end is open).
+// Nodes outside the selection that feed it (upstream boundaries) do not run; their output
+// is taken from BoundaryOutputs instead.
+type NodeSelection struct {
+	NodeIDs         []string       `json:"node_ids,omitempty"`
+	FromNode        string         `json:"from_node,omitempty"`
+	ToNode          string         `json:"to_node,omitempty"`
+	BoundaryOutputs map[string]any `json:"boundary_outputs,omitempty"` // Node ID -> output the node is assumed to have produced
+}
+
+// Validate checks that the selection names either nodes or a range, but not both.
+func (s *NodeSelection) Validate() error {
+	hasRange := s.FromNode != "" || s.ToNode != ""
+	if len(s.NodeIDs) == 0 && !hasRange {
+		return fmt.Errorf("%w: node_ids, from_node or to_node is required", ErrInvalidNodeSelection)
+	}
+	if len(s.NodeIDs) > 0 && hasRange {
+		return fmt.Errorf("%w: node_ids cannot be combined with from_node or to_node", ErrInvalidNodeSelection)
+	}
+	return nil
+}
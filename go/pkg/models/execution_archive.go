@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// MaxExecutionArchivePageSize bounds ExecutionArchiveFilter.Limit.
+const MaxExecutionArchivePageSize = 200
+
+// ExecutionArchive is a finished execution moved out of the database: its rows, with those of
+// its sub-workflow executions, node executions, events and notes, are stored compressed in
+// object storage until the execution is restored.
+type ExecutionArchive struct {
+	ExecutionID  string          `json:"execution_id"`
+	WorkflowID   string          `json:"workflow_id,omitempty"`
+	WorkflowName string          `json:"workflow_name,omitempty"`
+	Status       ExecutionStatus `json:"status"`
+	StartedAt    *time.Time      `json:"started_at,omitempty"`
+	CompletedAt  *time.Time      `json:"completed_at,omitempty"`
+	// ArchiveRef is the storage path of the archive
+	ArchiveRef         string    `json:"archive_ref"`
+	SizeBytes          int64     `json:"size_bytes"`
+	ExecutionCount     int       `json:"execution_count"`
+	NodeExecutionCount int       `json:"node_execution_count"`
+	ArchivedAt         time.Time `json:"archived_at"`
+}
+
+// ExecutionArchiveFilter selects and pages execution archives. Empty fields do not filter.
+type ExecutionArchiveFilter struct {
+	WorkflowID string
+	Limit      int
+	Offset     int
+}
@@ -0,0 +1,78 @@
+package models
+
+// ComparisonVariant is one side of an execution comparison: a stored workflow,
+// optionally with node config overrides applied for the comparison runs only.
+type ComparisonVariant struct {
+	Label         string                    `json:"label,omitempty"`
+	WorkflowID    string                    `json:"workflow_id,omitempty"`    // Defaults to the compared workflow
+	NodeOverrides map[string]map[string]any `json:"node_overrides,omitempty"` // Config values merged over node configs, keyed by node ID
+}
+
+// ComparisonUsage sums the LLM usage of one or more executions.
+// CostUSD is estimated from catalog list prices; calls of models without a known
+// price are counted in UnpricedCalls and add nothing to the cost.
+type ComparisonUsage struct {
+	LLMCalls         int     `json:"llm_calls"`
+	PromptTokens     int     `json:"prompt_tokens"`
+	CompletionTokens int     `json:"completion_tokens"`
+	CostUSD          float64 `json:"cost_usd"`
+	UnpricedCalls    int     `json:"unpriced_calls,omitempty"`
+}
+
+// Add adds other to the usage.
+func (u *ComparisonUsage) Add(other ComparisonUsage) {
+	u.LLMCalls += other.LLMCalls
+	u.PromptTokens += other.PromptTokens
+	u.CompletionTokens += other.CompletionTokens
+	u.CostUSD += other.CostUSD
+	u.UnpricedCalls += other.UnpricedCalls
+}
+
+// ComparisonRun is the execution of one recorded input by one variant.
+type ComparisonRun struct {
+	ExecutionID string          `json:"execution_id,omitempty"`
+	Status      ExecutionStatus `json:"status,omitempty"`
+	Output      map[string]any  `json:"output,omitempty"`
+	Error       string          `json:"error,omitempty"`
+	DurationMs  int64           `json:"duration_ms"`
+	Usage       ComparisonUsage `json:"usage"`
+}
+
+// OutputDifference is a value that differs between the baseline and candidate runs.
+// A value missing on one side is reported as null.
+type OutputDifference struct {
+	Path      string `json:"path"`
+	Baseline  any    `json:"baseline"`
+	Candidate any    `json:"candidate"`
+}
+
+// ComparisonCase is the side-by-side result of one recorded input.
+type ComparisonCase struct {
+	SourceExecutionID string             `json:"source_execution_id,omitempty"`
+	Input             map[string]any     `json:"input,omitempty"`
+	Baseline          ComparisonRun      `json:"baseline"`
+	Candidate         ComparisonRun      `json:"candidate"`
+	Identical         bool               `json:"identical"`
+	Differences       []OutputDifference `json:"differences,omitempty"`
+}
+
+// ComparisonTotals aggregates the runs of one variant.
+type ComparisonTotals struct {
+	Completed     int             `json:"completed"`
+	Failed        int             `json:"failed"`
+	AvgDurationMs int64           `json:"avg_duration_ms"`
+	Usage         ComparisonUsage `json:"usage"`
+}
+
+// ComparisonReport is the result of running the same recorded inputs through two variants.
+type ComparisonReport struct {
+	WorkflowID      string            `json:"workflow_id"`
+	Baseline        ComparisonVariant `json:"baseline"`
+	Candidate       ComparisonVariant `json:"candidate"`
+	Total           int               `json:"total"`
+	Identical       int               `json:"identical"`
+	Different       int               `json:"different"`
+	BaselineTotals  ComparisonTotals  `json:"baseline_totals"`
+	CandidateTotals ComparisonTotals  `json:"candidate_totals"`
+	Cases           []ComparisonCase  `json:"cases"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxExecutionNoteLength is the maximum length of a note body in characters.
+const MaxExecutionNoteLength = 10000
+
+// ExecutionNote is a comment a user attached to an execution or to one of its node
+// executions, e.g. the findings of an on-call investigation into a failed run.
+type ExecutionNote struct {
+	ID          string `json:"id"`
+	ExecutionID string `json:"execution_id"`
+	// NodeID is the logical ID of the node the note is about; empty for notes on the
+	// whole execution
+	NodeID     string    `json:"node_id,omitempty"`
+	AuthorID   string    `json:"author_id,omitempty"`
+	AuthorName string    `json:"author_name,omitempty"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Validate validates the note body.
+func (n *ExecutionNote) Validate() error {
+	if strings.TrimSpace(n.Body) == "" {
+		return &ValidationError{Field: "body", Message: "body is required"}
+	}
+	if utf8.RuneCountInString(n.Body) > MaxExecutionNoteLength {
+		return &ValidationError{Field: "body", Message: "body must be at most 10000 characters"}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExecutionNote_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{"valid", "Upstream API returned 503", false},
+		{"empty", "", true},
+		{"blank", "  \n", true},
+		{"at limit", strings.Repeat("ü", MaxExecutionNoteLength), false},
+		{"too long", strings.Repeat("a", MaxExecutionNoteLength+1), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&ExecutionNote{Body: tt.body}).Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package models
+
+import "fmt"
+
+// ExecutionPriority ranks executions competing for capacity. When the engine is at its
+// running limit, a high-priority execution may preempt lower-priority ones, such as bulk
+// backfills, so urgent operational workflows are not blocked behind them.
+type ExecutionPriority string
+
+const (
+	ExecutionPriorityLow      ExecutionPriority = "low"
+	ExecutionPriorityNormal   ExecutionPriority = "normal"
+	ExecutionPriorityHigh     ExecutionPriority = "high"
+	ExecutionPriorityCritical ExecutionPriority = "critical"
+)
+
+// executionPriorityRanks orders the priorities; a higher rank wins.
+var executionPriorityRanks = map[ExecutionPriority]int{
+	ExecutionPriorityLow:      0,
+	ExecutionPriorityNormal:   1,
+	ExecutionPriorityHigh:     2,
+	ExecutionPriorityCritical: 3,
+}
+
+// ParseExecutionPriority parses a priority; an empty value is normal.
+func ParseExecutionPriority(value string) (ExecutionPriority, error) {
+	if value == "" {
+		return ExecutionPriorityNormal, nil
+	}
+	priority := ExecutionPriority(value)
+	if _, ok := executionPriorityRanks[priority]; !ok {
+		return "", &ValidationError{Field: "priority", Message: fmt.Sprintf("unknown priority %q (must be low, normal, high or critical)", value)}
+	}
+	return priority, nil
+}
+
+// Rank returns the order of the priority; unknown and empty priorities rank as normal.
+func (p ExecutionPriority) Rank() int {
+	if rank, ok := executionPriorityRanks[p]; ok {
+		return rank
+	}
+	return executionPriorityRanks[ExecutionPriorityNormal]
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseExecutionPriority(t *testing.T) {
+	priority, err := ParseExecutionPriority("")
+	if err != nil || priority != ExecutionPriorityNormal {
+		t.Errorf("expected empty priority to be normal, got %q, %v", priority, err)
+	}
+
+	priority, err = ParseExecutionPriority("critical")
+	if err != nil || priority != ExecutionPriorityCritical {
+		t.Errorf("expected critical, got %q, %v", priority, err)
+	}
+
+	var validationErr *ValidationError
+	if _, err := ParseExecutionPriority("urgent"); !errors.As(err, &validationErr) || validationErr.Field != "priority" {
+		t.Errorf("expected validation error on priority, got %v", err)
+	}
+}
+
+func TestExecutionPriority_Rank(t *testing.T) {
+	ordered := []ExecutionPriority{ExecutionPriorityLow, ExecutionPriorityNormal, ExecutionPriorityHigh, ExecutionPriorityCritical}
+	for i := 1; i < len(ordered); i++ {
+		if ordered[i].Rank() <= ordered[i-1].Rank() {
+			t.Errorf("expected %s to outrank %s", ordered[i], ordered[i-1])
+		}
+	}
+	if ExecutionPriority("").Rank() != ExecutionPriorityNormal.Rank() {
+		t.Error("expected empty priority to rank as normal")
+	}
+}
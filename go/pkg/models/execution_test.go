@@ -38,6 +38,7 @@ func TestNodeExecutionStatus_IsTerminal(t *testing.T) {
 		{"failed is terminal", NodeExecutionStatusFailed, true},
 		{"skipped is terminal", NodeExecutionStatusSkipped, true},
 		{"cancelled is terminal", NodeExecutionStatusCancelled, true},
+		{"timed out is terminal", NodeExecutionStatusTimedOut, true},
 		{"pending is not terminal", NodeExecutionStatusPending, false},
 		{"running is not terminal", NodeExecutionStatusRunning, false},
 	}
@@ -350,6 +351,7 @@ func TestNodeExecutionStatus_Constants(t *testing.T) {
 		NodeExecutionStatusFailed,
 		NodeExecutionStatusSkipped,
 		NodeExecutionStatusCancelled,
+		NodeExecutionStatusTimedOut,
 	}
 
 	expectedValues := []string{
@@ -359,6 +361,7 @@ func TestNodeExecutionStatus_Constants(t *testing.T) {
 		"failed",
 		"skipped",
 		"cancelled",
+		"timed_out",
 	}
 
 	for i, status := range statuses {
@@ -107,7 +107,8 @@ type StorageType string
 const (
 	StorageTypeLocal StorageType = "local"
 	StorageTypeS3    StorageType = "s3"
-	// Future: StorageTypeGCS, StorageTypeAzure, etc.
+	StorageTypeGCS   StorageType = "gcs"
+	StorageTypeAzure StorageType = "azure"
 )
 
 // StorageUsage contains storage usage statistics
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// InboxItemKind tells what an item of the work inbox is waiting for.
+type InboxItemKind string
+
+const (
+	// InboxItemFailedExecution is a failed execution awaiting review or a re-run; it leaves
+	// the inbox once a later execution of its workflow completes
+	InboxItemFailedExecution InboxItemKind = "failed_execution"
+
+	// InboxItemPendingApproval is an approval node waiting for a decision; it leaves the
+	// inbox once the approval is decided or times out
+	InboxItemPendingApproval InboxItemKind = "pending_approval"
+
+	// InboxItemBreakpoint is a debug execution stopped at breakpoints, waiting to be
+	// continued or aborted
+	InboxItemBreakpoint InboxItemKind = "breakpoint"
+)
+
+// InboxItemKinds lists the kinds of items the work inbox aggregates.
+var InboxItemKinds = []InboxItemKind{InboxItemFailedExecution, InboxItemPendingApproval, InboxItemBreakpoint}
+
+// MaxInboxPageSize bounds InboxFilter.Limit.
+const MaxInboxPageSize = 200
+
+// InboxItem is one piece of work awaiting human action, such as a failed run to re-drive
+// or an approval to decide.
+type InboxItem struct {
+	Kind         InboxItemKind `json:"kind"`
+	ExecutionID  string        `json:"execution_id"`
+	WorkflowID   string        `json:"workflow_id"`
+	WorkflowName string        `json:"workflow_name,omitempty"`
+	// NodeID and NodeName are the node the item is about, e.g. the node that failed, the
+	// approval node or the first breakpoint
+	NodeID   string `json:"node_id,omitempty"`
+	NodeName string `json:"node_name,omitempty"`
+	// Assignee is the user expected to act: the owner of the workflow
+	Assignee string `json:"assignee,omitempty"`
+	// Approvers are the users allowed to decide a pending approval; empty allows any user,
+	// and the approval is then the assignee's to decide
+	Approvers []string `json:"approvers,omitempty"`
+	Summary   string   `json:"summary,omitempty"`
+	// CreatedAt is when the item started waiting, e.g. when the execution failed
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// InboxFilter selects and pages work inbox items. Empty fields do not filter.
+type InboxFilter struct {
+	// Assignee selects the items the user is expected to act on: those assigned to the user,
+	// except pending approvals with approvers, which are selected for their approvers
+	Assignee   string
+	WorkflowID string
+	Kinds      []InboxItemKind
+	// Since hides items that started waiting earlier
+	Since  time.Time
+	Limit  int
+	Offset int
+}
+
+// Includes reports whether the filter selects items of the kind.
+func (f *InboxFilter) Includes(kind InboxItemKind) bool {
+	if len(f.Kinds) == 0 {
+		return true
+	}
+	for _, k := range f.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseInboxItemKinds parses a comma-separated list of inbox item kinds.
+func ParseInboxItemKinds(value string) ([]InboxItemKind, error) {
+	var kinds []InboxItemKind
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		kind := InboxItemKind(part)
+		if !kind.IsValid() {
+			return nil, &ValidationError{Field: "kind", Message: fmt.Sprintf("unknown inbox item kind %q", part)}
+		}
+		kinds = append(kinds, kind)
+	}
+	return kinds, nil
+}
+
+// IsValid reports whether the kind is one the work inbox aggregates.
+func (k InboxItemKind) IsValid() bool {
+	for _, kind := range InboxItemKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseInboxItemKinds(t *testing.T) {
+	kinds, err := ParseInboxItemKinds(" failed_execution ,")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(kinds, []InboxItemKind{InboxItemFailedExecution}) {
+		t.Errorf("unexpected kinds: %v", kinds)
+	}
+
+	kinds, err = ParseInboxItemKinds("pending_approval,breakpoint")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(kinds, []InboxItemKind{InboxItemPendingApproval, InboxItemBreakpoint}) {
+		t.Errorf("unexpected kinds: %v", kinds)
+	}
+
+	kinds, err = ParseInboxItemKinds("")
+	if err != nil || kinds != nil {
+		t.Errorf("expected no kinds for an empty value, got %v, %v", kinds, err)
+	}
+
+	var validationErr *ValidationError
+	if _, err := ParseInboxItemKinds("failed_execution,approval"); !errors.As(err, &validationErr) || validationErr.Field != "kind" {
+		t.Errorf("expected validation error on kind, got %v", err)
+	}
+}
+
+func TestInboxFilter_Includes(t *testing.T) {
+	all := &InboxFilter{}
+	if !all.Includes(InboxItemFailedExecution) {
+		t.Error("expected an empty filter to include every kind")
+	}
+
+	other := &InboxFilter{Kinds: []InboxItemKind{"other"}}
+	if other.Includes(InboxItemFailedExecution) {
+		t.Error("expected the filter to exclude kinds it does not list")
+	}
+}
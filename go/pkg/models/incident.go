@@ -0,0 +1,123 @@
+package models
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// IncidentSource tells what opened an incident.
+type IncidentSource string
+
+const (
+	// IncidentSourceCanaryAlert is an incident opened when a canary alert fired
+	IncidentSourceCanaryAlert IncidentSource = "canary_alert"
+
+	// IncidentSourceRepeatedFailures is an incident opened after consecutive failed executions
+	IncidentSourceRepeatedFailures IncidentSource = "repeated_failures"
+
+	// IncidentSourceManual is an incident a user opened for an execution
+	IncidentSourceManual IncidentSource = "manual"
+)
+
+// Supported incident trackers; they match the providers of the issue_tracker executor.
+const (
+	IncidentTrackerGitHub = "github"
+	IncidentTrackerGitLab = "gitlab"
+	IncidentTrackerJira   = "jira"
+)
+
+// MaxIncidentFailureThreshold bounds IncidentPolicy.FailureThreshold.
+const MaxIncidentFailureThreshold = 100
+
+// IncidentPolicy links a workflow to its runbook and to the incident-tracker project
+// where incidents are opened, and decides when incidents are opened automatically.
+type IncidentPolicy struct {
+	WorkflowID   string `json:"workflow_id"`
+	WorkflowName string `json:"workflow_name,omitempty"`
+	RunbookURL   string `json:"runbook_url,omitempty"`
+
+	// Tracker is the issue_tracker provider (github, gitlab, jira); empty when the
+	// workflow only references a runbook
+	Tracker      string   `json:"tracker,omitempty"`
+	Project      string   `json:"project,omitempty"`
+	BaseURL      string   `json:"base_url,omitempty"`
+	CredentialID string   `json:"credential_id,omitempty"`
+	Labels       []string `json:"labels,omitempty"`
+	IssueType    string   `json:"issue_type,omitempty"` // Jira only
+
+	// OnCanaryAlert opens an incident when the workflow's canary starts failing
+	OnCanaryAlert bool `json:"on_canary_alert"`
+	// FailureThreshold opens an incident after this many consecutive failed executions; 0 disables it
+	FailureThreshold int `json:"failure_threshold"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Validate validates the incident policy.
+func (p *IncidentPolicy) Validate() error {
+	if p.WorkflowID == "" {
+		return &ValidationError{Field: "workflow_id", Message: "workflow ID is required"}
+	}
+	if p.RunbookURL != "" && !isHTTPURL(p.RunbookURL) {
+		return &ValidationError{Field: "runbook_url", Message: "runbook URL must be an http or https URL"}
+	}
+	if p.FailureThreshold < 0 || p.FailureThreshold > MaxIncidentFailureThreshold {
+		return &ValidationError{Field: "failure_threshold", Message: "failure threshold must be between 0 and 100"}
+	}
+
+	switch p.Tracker {
+	case "":
+		if p.OnCanaryAlert || p.FailureThreshold > 0 {
+			return &ValidationError{Field: "tracker", Message: "tracker is required to open incidents automatically"}
+		}
+		return nil
+	case IncidentTrackerGitHub, IncidentTrackerGitLab:
+	case IncidentTrackerJira:
+		if p.BaseURL == "" {
+			return &ValidationError{Field: "base_url", Message: "base URL is required for jira"}
+		}
+	default:
+		return &ValidationError{Field: "tracker", Message: "tracker must be github, gitlab or jira"}
+	}
+
+	if strings.TrimSpace(p.Project) == "" {
+		return &ValidationError{Field: "project", Message: "project is required"}
+	}
+	if p.CredentialID == "" {
+		return &ValidationError{Field: "credential_id", Message: "credential ID is required"}
+	}
+	if p.BaseURL != "" && !isHTTPURL(p.BaseURL) {
+		return &ValidationError{Field: "base_url", Message: "base URL must be an http or https URL"}
+	}
+	return nil
+}
+
+// HasTracker reports whether incidents can be opened for the workflow.
+func (p *IncidentPolicy) HasTracker() bool {
+	return p != nil && p.Tracker != ""
+}
+
+// Incident is an issue opened in an incident tracker and linked to the execution
+// that caused it, so that the execution can be reviewed from the incident and back.
+type Incident struct {
+	ID          string         `json:"id"`
+	WorkflowID  string         `json:"workflow_id"`
+	ExecutionID string         `json:"execution_id,omitempty"`
+	Source      IncidentSource `json:"source"`
+	Title       string         `json:"title"`
+	Tracker     string         `json:"tracker"`
+	Project     string         `json:"project"`
+	ExternalID  string         `json:"external_id,omitempty"`
+	Key         string         `json:"key,omitempty"` // Issue number, IID or key in the tracker
+	URL         string         `json:"url,omitempty"`
+	RunbookURL  string         `json:"runbook_url,omitempty"`
+	CreatedBy   string         `json:"created_by,omitempty"` // User who opened a manual incident
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,69 @@
+package models
+
+import "testing"
+
+func TestIncidentPolicy_Validate(t *testing.T) {
+	github := func(mutate func(p *IncidentPolicy)) *IncidentPolicy {
+		p := &IncidentPolicy{
+			WorkflowID:   "wf-1",
+			Tracker:      IncidentTrackerGitHub,
+			Project:      "acme/api",
+			CredentialID: "cred-1",
+		}
+		if mutate != nil {
+			mutate(p)
+		}
+		return p
+	}
+
+	tests := []struct {
+		name    string
+		policy  *IncidentPolicy
+		wantErr string
+	}{
+		{"tracker", github(nil), ""},
+		{"runbook only", &IncidentPolicy{WorkflowID: "wf-1", RunbookURL: "https://wiki.acme.io/runbooks/api"}, ""},
+		{"automatic", github(func(p *IncidentPolicy) { p.OnCanaryAlert = true; p.FailureThreshold = 3 }), ""},
+		{"jira", github(func(p *IncidentPolicy) {
+			p.Tracker = IncidentTrackerJira
+			p.Project = "OPS"
+			p.BaseURL = "https://acme.atlassian.net"
+		}), ""},
+		{"missing workflow", &IncidentPolicy{}, "workflow_id"},
+		{"invalid runbook", github(func(p *IncidentPolicy) { p.RunbookURL = "wiki/runbook" }), "runbook_url"},
+		{"negative threshold", github(func(p *IncidentPolicy) { p.FailureThreshold = -1 }), "failure_threshold"},
+		{"automatic without tracker", &IncidentPolicy{WorkflowID: "wf-1", FailureThreshold: 2}, "tracker"},
+		{"unknown tracker", github(func(p *IncidentPolicy) { p.Tracker = "trello" }), "tracker"},
+		{"jira without base url", github(func(p *IncidentPolicy) { p.Tracker = IncidentTrackerJira }), "base_url"},
+		{"missing project", github(func(p *IncidentPolicy) { p.Project = " " }), "project"},
+		{"missing credential", github(func(p *IncidentPolicy) { p.CredentialID = "" }), "credential_id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			verr, ok := err.(*ValidationError)
+			if !ok || verr.Field != tt.wantErr {
+				t.Errorf("Validate() error = %v, want validation error on %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCanary_ValidateRunbookURL(t *testing.T) {
+	canary := &Canary{WorkflowID: "wf-1", Schedule: "@every 5m", RunbookURL: "https://wiki.acme.io/runbooks/api"}
+	if err := canary.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	canary.RunbookURL = "javascript:alert(1)"
+	if err := canary.Validate(); err == nil {
+		t.Error("Validate() accepted a non-http runbook URL")
+	}
+}
@@ -9,7 +9,12 @@ const (
 	LLMProviderOpenAI          LLMProvider = "openai"           // Chat Completions API
 	LLMProviderOpenAIResponses LLMProvider = "openai-responses" // Responses API (GPT-5, o3-mini, gpt-4.1+)
 	LLMProviderAnthropic       LLMProvider = "anthropic"
-	LLMProviderGemini          LLMProvider = "gemini" // Google Gemini API
+	LLMProviderGemini          LLMProvider = "gemini"       // Google Gemini API
+	LLMProviderAzureOpenAI     LLMProvider = "azure_openai" // Azure OpenAI Service deployments
+	LLMProviderBedrock         LLMProvider = "bedrock"      // Amazon Bedrock Converse API
+	LLMProviderMistral         LLMProvider = "mistral"      // Mistral AI Chat Completions API
+	LLMProviderCohere          LLMProvider = "cohere"       // Cohere Chat API v2
+	LLMProviderMock            LLMProvider = "mock"         // Deterministic responses for development and CI
 )
 
 // LLMRequest represents a request to an LLM.
@@ -32,6 +37,9 @@ type LLMRequest struct {
 	Tools              []LLMTool           `json:"tools,omitempty"`                // Function definitions
 	ResponseFormat     *LLMResponseFormat  `json:"response_format,omitempty"`      // Structured output format
 	PreviousResponseID string              `json:"previous_response_id,omitempty"` // For conversation chaining
+	SafetySettings     map[string]string   `json:"safety_settings,omitempty"`      // Gemini harm category -> block threshold
+	Stream             bool                `json:"stream,omitempty"`               // Stream the response from the provider
+	Cache              *LLMCacheConfig     `json:"cache,omitempty"`                // Reuse responses of identical requests (nil = no caching)
 	ProviderConfig     map[string]any      `json:"provider_config,omitempty"`      // Provider-specific configuration (api_key, base_url, org_id, etc.)
 	Metadata           map[string]any      `json:"metadata,omitempty"`
 
@@ -50,6 +58,12 @@ type LLMRequest struct {
 	Functions      []FunctionDefinition `json:"functions,omitempty"`        // Extended function definitions (built-in, sub-workflow, custom code, OpenAPI)
 }
 
+// LLMCacheConfig opts a request into the LLM response cache. Responses are keyed on the
+// request content, so it suits deterministic prompts (temperature 0).
+type LLMCacheConfig struct {
+	TTL int `json:"ttl,omitempty"` // Seconds to keep the response; 0 uses the executor default
+}
+
 // LLMFileAttachment represents a base64-encoded file attachment for multimodal LLM requests.
 // Supports images (JPEG, PNG, GIF, WebP) and PDFs.
 type LLMFileAttachment struct {
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// NodeAssertionsConfigKey is the node config key holding output assertions.
+const NodeAssertionsConfigKey = "assertions"
+
+// AssertionAction determines what happens when a node assertion fails.
+type AssertionAction string
+
+const (
+	// AssertionActionWarn records the failure and lets the node complete
+	AssertionActionWarn AssertionAction = "warn"
+
+	// AssertionActionFail fails the node
+	AssertionActionFail AssertionAction = "fail"
+
+	// AssertionActionRoute completes the node but follows only its "error" edges
+	AssertionActionRoute AssertionAction = "route"
+)
+
+// NodeAssertion is a boolean expression checked against a node's output after it completes,
+// e.g. "len(output.items) > 0".
+type NodeAssertion struct {
+	Name       string          `json:"name,omitempty"`
+	Expression string          `json:"expression"`
+	Action     AssertionAction `json:"action,omitempty"` // Default: warn
+	Message    string          `json:"message,omitempty"`
+}
+
+// ParseNodeAssertions reads assertions from node config.
+// Each entry is either an assertion object or a bare expression string (a warning).
+// Returns nil when the node has no assertions.
+func ParseNodeAssertions(config map[string]any) ([]*NodeAssertion, error) {
+	raw, ok := config[NodeAssertionsConfigKey]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("assertions must be an array")
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("assertions must be an array")
+	}
+
+	assertions := make([]*NodeAssertion, 0, len(items))
+	for i, item := range items {
+		assertion := &NodeAssertion{}
+
+		var expression string
+		if err := json.Unmarshal(item, &expression); err == nil {
+			assertion.Expression = expression
+		} else if err := json.Unmarshal(item, assertion); err != nil {
+			return nil, fmt.Errorf("assertions[%d] must be an object or an expression string", i)
+		}
+
+		assertion.Expression = strings.TrimSpace(assertion.Expression)
+		if assertion.Expression == "" {
+			return nil, fmt.Errorf("assertions[%d]: expression is required", i)
+		}
+
+		switch assertion.Action {
+		case "":
+			assertion.Action = AssertionActionWarn
+		case AssertionActionWarn, AssertionActionFail, AssertionActionRoute:
+		default:
+			return nil, fmt.Errorf("assertions[%d]: action must be one of: warn, fail, route", i)
+		}
+
+		if assertion.Name == "" {
+			assertion.Name = assertion.Expression
+		}
+
+		assertions = append(assertions, assertion)
+	}
+
+	return assertions, nil
+}
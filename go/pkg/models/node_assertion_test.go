@@ -0,0 +1,79 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestParseNodeAssertions(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]any
+		want    []*NodeAssertion
+		wantErr string
+	}{
+		{
+			name:   "no assertions",
+			config: map[string]any{"url": "https://example.com"},
+			want:   nil,
+		},
+		{
+			name:   "expression shorthand defaults to warn",
+			config: map[string]any{"assertions": []any{"len(output.items) > 0"}},
+			want: []*NodeAssertion{
+				{Name: "len(output.items) > 0", Expression: "len(output.items) > 0", Action: AssertionActionWarn},
+			},
+		},
+		{
+			name: "full assertion",
+			config: map[string]any{"assertions": []map[string]any{
+				{"name": "non_empty", "expression": "len(output.items) > 0", "action": "route", "message": "empty feed"},
+			}},
+			want: []*NodeAssertion{
+				{Name: "non_empty", Expression: "len(output.items) > 0", Action: AssertionActionRoute, Message: "empty feed"},
+			},
+		},
+		{
+			name:    "not an array",
+			config:  map[string]any{"assertions": "output != nil"},
+			wantErr: "assertions must be an array",
+		},
+		{
+			name:    "missing expression",
+			config:  map[string]any{"assertions": []any{map[string]any{"name": "x"}}},
+			wantErr: "expression is required",
+		},
+		{
+			name:    "invalid action",
+			config:  map[string]any{"assertions": []any{map[string]any{"expression": "true", "action": "skip"}}},
+			wantErr: "action must be one of",
+		},
+		{
+			name:    "invalid entry",
+			config:  map[string]any{"assertions": []any{42}},
+			wantErr: "must be an object or an expression string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseNodeAssertions(tt.config)
+			if tt.wantErr != "" {
+				if err == nil || !contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing '%s', got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d assertions, got %d", len(tt.want), len(got))
+			}
+			for i := range got {
+				if *got[i] != *tt.want[i] {
+					t.Errorf("assertion %d: expected %+v, got %+v", i, tt.want[i], got[i])
+				}
+			}
+		})
+	}
+}
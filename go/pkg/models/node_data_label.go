@@ -0,0 +1,147 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NodeDataLabelsConfigKey is the node config key holding data-classification and retention labels.
+const NodeDataLabelsConfigKey = "data_labels"
+
+// Built-in data labels. Any other label matching [a-z0-9][a-z0-9_.-]* is accepted as a
+// free-form classification tag and stored with the node execution without further effect.
+const (
+	// DataLabelPII marks personal data: payloads are kept out of exports and observer events
+	DataLabelPII = "pii"
+
+	// DataLabelConfidential is handled like DataLabelPII
+	DataLabelConfidential = "confidential"
+
+	// DataLabelNoExport keeps payloads out of exports only
+	DataLabelNoExport = "no-export"
+
+	// DataLabelRetainPrefix starts a retention label such as "retain-7d" or "retain-12h":
+	// the node's persisted payload is purged once that long has passed since the node finished
+	DataLabelRetainPrefix = "retain-"
+)
+
+var (
+	dataLabelPattern   = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]*$`)
+	retainLabelPattern = regexp.MustCompile(`^retain-([0-9]+)([hd])$`)
+)
+
+// DataPolicy is the combined effect of a node's data labels.
+type DataPolicy struct {
+	// Labels are the node's labels, sorted and deduplicated
+	Labels []string
+
+	// Retention is the shortest retention label, or zero to keep payloads as long as the execution
+	Retention time.Duration
+
+	// ExcludeFromExport keeps input, output and configs out of execution exports
+	ExcludeFromExport bool
+
+	// Redact keeps input and output out of events delivered to observers
+	Redact bool
+}
+
+// IsEmpty returns true if the node has no data labels.
+func (p *DataPolicy) IsEmpty() bool {
+	return p == nil || len(p.Labels) == 0
+}
+
+// ParseNodeDataLabels reads data labels from node config.
+// Returns an empty policy when the node has no labels.
+func ParseNodeDataLabels(config map[string]any) (*DataPolicy, error) {
+	raw, ok := config[NodeDataLabelsConfigKey]
+	if !ok || raw == nil {
+		return &DataPolicy{}, nil
+	}
+
+	var labels []string
+	switch v := raw.(type) {
+	case []string:
+		labels = v
+	case []any:
+		labels = make([]string, 0, len(v))
+		for i, item := range v {
+			label, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("data_labels[%d] must be a string", i)
+			}
+			labels = append(labels, label)
+		}
+	default:
+		return nil, fmt.Errorf("data_labels must be an array of strings")
+	}
+
+	return NewDataPolicy(labels)
+}
+
+// NewDataPolicy validates labels and returns their combined policy.
+func NewDataPolicy(labels []string) (*DataPolicy, error) {
+	policy := &DataPolicy{}
+	seen := make(map[string]bool, len(labels))
+
+	for i, label := range labels {
+		if !dataLabelPattern.MatchString(label) {
+			return nil, fmt.Errorf("data_labels[%d]: invalid label %q (use lowercase letters, digits, '-', '_' or '.')", i, label)
+		}
+		if seen[label] {
+			continue
+		}
+		seen[label] = true
+		policy.Labels = append(policy.Labels, label)
+
+		switch label {
+		case DataLabelPII, DataLabelConfidential:
+			policy.ExcludeFromExport = true
+			policy.Redact = true
+		case DataLabelNoExport:
+			policy.ExcludeFromExport = true
+		}
+
+		if strings.HasPrefix(label, DataLabelRetainPrefix) {
+			retention, err := parseRetainLabel(label)
+			if err != nil {
+				return nil, fmt.Errorf("data_labels[%d]: %w", i, err)
+			}
+			if policy.Retention == 0 || retention < policy.Retention {
+				policy.Retention = retention
+			}
+		}
+	}
+
+	sort.Strings(policy.Labels)
+	return policy, nil
+}
+
+// PayloadExpiresAt returns when a payload of a node finished at the given time must be purged,
+// or nil if the policy has no retention label.
+func (p *DataPolicy) PayloadExpiresAt(finishedAt time.Time) *time.Time {
+	if p == nil || p.Retention <= 0 {
+		return nil
+	}
+	expiresAt := finishedAt.Add(p.Retention)
+	return &expiresAt
+}
+
+func parseRetainLabel(label string) (time.Duration, error) {
+	match := retainLabelPattern.FindStringSubmatch(label)
+	if match == nil {
+		return 0, fmt.Errorf("invalid retention label %q (expected retain-<N>d or retain-<N>h)", label)
+	}
+	n, err := strconv.Atoi(match[1])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid retention label %q: period must be positive", label)
+	}
+	unit := time.Hour
+	if match[2] == "d" {
+		unit = 24 * time.Hour
+	}
+	return time.Duration(n) * unit, nil
+}
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseNodeDataLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]any
+		want    *DataPolicy
+		wantErr string
+	}{
+		{
+			name:   "no labels",
+			config: map[string]any{"url": "https://example.com"},
+			want:   &DataPolicy{},
+		},
+		{
+			name:   "pii with shortest retention",
+			config: map[string]any{"data_labels": []any{"retain-7d", "pii", "retain-12h", "pii"}},
+			want: &DataPolicy{
+				Labels:            []string{"pii", "retain-12h", "retain-7d"},
+				Retention:         12 * time.Hour,
+				ExcludeFromExport: true,
+				Redact:            true,
+			},
+		},
+		{
+			name:   "no-export and free-form tag",
+			config: map[string]any{"data_labels": []string{"no-export", "finance"}},
+			want:   &DataPolicy{Labels: []string{"finance", "no-export"}, ExcludeFromExport: true},
+		},
+		{
+			name:    "not an array",
+			config:  map[string]any{"data_labels": "pii"},
+			wantErr: "data_labels must be an array of strings",
+		},
+		{
+			name:    "non-string entry",
+			config:  map[string]any{"data_labels": []any{"pii", 7}},
+			wantErr: "data_labels[1] must be a string",
+		},
+		{
+			name:    "invalid label",
+			config:  map[string]any{"data_labels": []any{"PII"}},
+			wantErr: "invalid label",
+		},
+		{
+			name:    "invalid retention",
+			config:  map[string]any{"data_labels": []any{"retain-1w"}},
+			wantErr: "invalid retention label",
+		},
+		{
+			name:    "zero retention",
+			config:  map[string]any{"data_labels": []any{"retain-0d"}},
+			wantErr: "period must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseNodeDataLabels(tt.config)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDataPolicy_PayloadExpiresAt(t *testing.T) {
+	finished := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	policy, err := NewDataPolicy([]string{"retain-2d"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := policy.PayloadExpiresAt(finished); got == nil || !got.Equal(finished.Add(48*time.Hour)) {
+		t.Errorf("expected expiry two days after completion, got %v", got)
+	}
+
+	policy, _ = NewDataPolicy([]string{"pii"})
+	if got := policy.PayloadExpiresAt(finished); got != nil {
+		t.Errorf("expected no expiry without a retention label, got %v", got)
+	}
+}
+
+func TestNode_Validate_DataLabels(t *testing.T) {
+	node := &Node{ID: "n", Name: "N", Type: "http", Config: map[string]any{"data_labels": []any{"retain-soon"}}}
+	err := node.Validate()
+	if err == nil || !strings.Contains(err.Error(), "data_labels") {
+		t.Errorf("expected data_labels validation error, got %v", err)
+	}
+}
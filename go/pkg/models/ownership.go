@@ -0,0 +1,104 @@
+package models
+
+import "time"
+
+// OwnershipKind identifies a category of user-owned objects that can be transferred.
+type OwnershipKind string
+
+const (
+	// OwnershipKindWorkflows covers workflows created by the user; their triggers move with them.
+	OwnershipKindWorkflows OwnershipKind = "workflows"
+	// OwnershipKindCredentials covers credentials resources.
+	OwnershipKindCredentials OwnershipKind = "credentials"
+	// OwnershipKindResources covers all other resources (file storage, rental keys).
+	OwnershipKindResources OwnershipKind = "resources"
+)
+
+// AllOwnershipKinds lists every transferable kind.
+var AllOwnershipKinds = []OwnershipKind{
+	OwnershipKindWorkflows,
+	OwnershipKindCredentials,
+	OwnershipKindResources,
+}
+
+// OwnedWorkflow is a workflow entry in an ownership report.
+type OwnedWorkflow struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OwnedTrigger is a trigger attached to one of the user's workflows.
+type OwnedTrigger struct {
+	ID         string `json:"id"`
+	WorkflowID string `json:"workflow_id"`
+	Type       string `json:"type"`
+	Enabled    bool   `json:"enabled"`
+}
+
+// OwnedResource is a resource (credentials, file storage, rental key) owned by the user.
+type OwnedResource struct {
+	ID     string       `json:"id"`
+	Type   ResourceType `json:"type"`
+	Name   string       `json:"name"`
+	Status string       `json:"status"`
+}
+
+// OwnedServiceKey is an active service key belonging to the user.
+// Service keys authenticate as their owner and are never transferred; revoke them instead.
+type OwnedServiceKey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	KeyPrefix  string     `json:"key_prefix"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// OwnershipReport lists everything a user owns, typically reviewed before offboarding.
+type OwnershipReport struct {
+	UserID      string             `json:"user_id"`
+	Workflows   []*OwnedWorkflow   `json:"workflows"`
+	Triggers    []*OwnedTrigger    `json:"triggers"`
+	Credentials []*OwnedResource   `json:"credentials"`
+	Resources   []*OwnedResource   `json:"resources"`
+	ServiceKeys []*OwnedServiceKey `json:"service_keys"`
+}
+
+// IsEmpty reports whether the user owns nothing that would be orphaned.
+func (r *OwnershipReport) IsEmpty() bool {
+	return len(r.Workflows) == 0 && len(r.Credentials) == 0 && len(r.Resources) == 0 && len(r.ServiceKeys) == 0
+}
+
+// OwnershipTransfer describes a transfer of ownership between users.
+// Empty Kinds means all kinds. WorkflowIDs and ResourceIDs optionally restrict
+// the transfer to specific objects; empty means everything of that kind.
+type OwnershipTransfer struct {
+	FromUserID  string          `json:"from_user_id"`
+	ToUserID    string          `json:"to_user_id"`
+	Kinds       []OwnershipKind `json:"kinds,omitempty"`
+	WorkflowIDs []string        `json:"workflow_ids,omitempty"`
+	ResourceIDs []string        `json:"resource_ids,omitempty"`
+}
+
+// Includes reports whether the transfer covers the given kind.
+func (t *OwnershipTransfer) Includes(kind OwnershipKind) bool {
+	if len(t.Kinds) == 0 {
+		return true
+	}
+	for _, k := range t.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// OwnershipTransferResult reports how many objects changed owner.
+type OwnershipTransferResult struct {
+	FromUserID  string `json:"from_user_id"`
+	ToUserID    string `json:"to_user_id"`
+	Workflows   int    `json:"workflows"`
+	Triggers    int    `json:"triggers"`
+	Credentials int    `json:"credentials"`
+	Resources   int    `json:"resources"`
+}
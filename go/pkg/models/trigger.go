@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 )
 
@@ -38,6 +39,12 @@ const (
 
 	// TriggerTypeInterval represents an interval-based trigger
 	TriggerTypeInterval TriggerType = "interval"
+
+	// TriggerTypeEmail represents a trigger fired by new messages in an IMAP mailbox
+	TriggerTypeEmail TriggerType = "email"
+
+	// TriggerTypeSlack represents a trigger fired by Slack Events API callbacks and slash commands
+	TriggerTypeSlack TriggerType = "slack"
 )
 
 // Validate validates the trigger structure.
@@ -45,7 +52,12 @@ func (t *Trigger) Validate() error {
 	if t.WorkflowID == "" {
 		return &ValidationError{Field: "workflow_id", Message: "workflow ID is required"}
 	}
+	return t.ValidateDefinition()
+}
 
+// ValidateDefinition validates the trigger without its workflow, for triggers defined along
+// with a workflow that is not stored yet.
+func (t *Trigger) ValidateDefinition() error {
 	if t.Name == "" {
 		return &ValidationError{Field: "name", Message: "trigger name is required"}
 	}
@@ -72,12 +84,35 @@ func (t *Trigger) Validate() error {
 		if err := t.validateIntervalConfig(); err != nil {
 			return err
 		}
+	case TriggerTypeEmail:
+		if err := t.validateEmailConfig(); err != nil {
+			return err
+		}
+	case TriggerTypeSlack:
+		if err := t.validateSlackConfig(); err != nil {
+			return err
+		}
 	case TriggerTypeManual:
 		// Manual triggers don't require specific configuration
 	default:
 		return &ValidationError{Field: "type", Message: "invalid trigger type"}
 	}
 
+	// Any trigger may run the workflow with a launch profile
+	if profile, exists := t.Config["profile"]; exists {
+		if name, ok := profile.(string); !ok || name == "" {
+			return &ValidationError{Field: "config.profile", Message: "profile must be a non-empty string"}
+		}
+	}
+
+	// and a priority
+	if priority, exists := t.Config["priority"]; exists {
+		value, ok := priority.(string)
+		if _, err := ParseExecutionPriority(value); !ok || err != nil {
+			return &ValidationError{Field: "config.priority", Message: "priority must be low, normal, high or critical"}
+		}
+	}
+
 	return nil
 }
 
@@ -95,6 +130,28 @@ func (t *Trigger) validateCronConfig() error {
 // validateWebhookConfig validates webhook trigger configuration.
 func (t *Trigger) validateWebhookConfig() error {
 	// Webhook config is optional - the system will generate a webhook URL
+	if raw, ok := t.Config["path_pattern"]; ok && raw != nil {
+		pattern, ok := raw.(string)
+		if !ok || !strings.HasPrefix(pattern, "/") {
+			return &ValidationError{Field: "config.path_pattern", Message: "path pattern must be a string starting with /"}
+		}
+		segments := strings.Split(strings.Trim(pattern, "/"), "/")
+		for i, segment := range segments {
+			if segment == ":" || segment == "*" {
+				return &ValidationError{Field: "config.path_pattern", Message: "path parameters must be named, e.g. :order_id"}
+			}
+			if strings.HasPrefix(segment, "*") && i != len(segments)-1 {
+				return &ValidationError{Field: "config.path_pattern", Message: "a wildcard parameter must be the last segment"}
+			}
+		}
+	}
+
+	if raw, ok := t.Config["input_template"]; ok && raw != nil {
+		if _, ok := raw.(map[string]any); !ok {
+			return &ValidationError{Field: "config.input_template", Message: "input template must be an object"}
+		}
+	}
+
 	return nil
 }
 
@@ -132,6 +189,84 @@ func (t *Trigger) validateIntervalConfig() error {
 	return nil
 }
 
+// validateEmailConfig validates email (IMAP) trigger configuration.
+func (t *Trigger) validateEmailConfig() error {
+	host, ok := t.Config["host"].(string)
+	if !ok || host == "" {
+		return &ValidationError{Field: "config.host", Message: "IMAP host is required"}
+	}
+
+	credentialID, ok := t.Config["credential_id"].(string)
+	if !ok || credentialID == "" {
+		return &ValidationError{Field: "config.credential_id", Message: "credential ID is required"}
+	}
+
+	for _, field := range []string{"username", "password"} {
+		if _, ok := t.Config[field]; ok {
+			return &ValidationError{Field: "config." + field, Message: "inline credentials are not allowed, use credential_id"}
+		}
+	}
+
+	if tlsMode, ok := t.Config["tls"].(string); ok && tlsMode != "" {
+		switch tlsMode {
+		case "tls", "starttls", "none":
+		default:
+			return &ValidationError{Field: "config.tls", Message: "tls must be one of: tls, starttls, none"}
+		}
+	}
+
+	if mode, ok := t.Config["mode"].(string); ok && mode != "" && mode != "idle" && mode != "poll" {
+		return &ValidationError{Field: "config.mode", Message: "mode must be idle or poll"}
+	}
+
+	if interval, ok := t.Config["poll_interval"]; ok {
+		switch v := interval.(type) {
+		case float64:
+			if v <= 0 {
+				return &ValidationError{Field: "config.poll_interval", Message: "poll interval must be positive"}
+			}
+		case string:
+			if _, err := time.ParseDuration(v); err != nil {
+				return &ValidationError{Field: "config.poll_interval", Message: "invalid duration format"}
+			}
+		default:
+			return &ValidationError{Field: "config.poll_interval", Message: "poll interval must be a number or duration string"}
+		}
+	}
+
+	return nil
+}
+
+// validateSlackConfig validates Slack trigger configuration.
+func (t *Trigger) validateSlackConfig() error {
+	credentialID, ok := t.Config["credential_id"].(string)
+	if !ok || credentialID == "" {
+		return &ValidationError{Field: "config.credential_id", Message: "credential ID (signing secret) is required"}
+	}
+
+	if _, ok := t.Config["signing_secret"]; ok {
+		return &ValidationError{Field: "config.signing_secret", Message: "inline signing secret is not allowed, use credential_id"}
+	}
+
+	for _, field := range []string{"event_types", "commands", "channels"} {
+		raw, ok := t.Config[field]
+		if !ok || raw == nil {
+			continue
+		}
+		items, ok := raw.([]any)
+		if !ok {
+			return &ValidationError{Field: "config." + field, Message: field + " must be an array of strings"}
+		}
+		for _, item := range items {
+			if s, ok := item.(string); !ok || s == "" {
+				return &ValidationError{Field: "config." + field, Message: field + " must be an array of strings"}
+			}
+		}
+	}
+
+	return nil
+}
+
 // CronConfig represents the configuration for a cron trigger.
 type CronConfig struct {
 	Schedule string `json:"schedule"`
@@ -143,6 +278,12 @@ type WebhookConfig struct {
 	Secret      string            `json:"secret,omitempty"`
 	Headers     map[string]string `json:"headers,omitempty"`
 	ContentType string            `json:"content_type,omitempty"`
+	// PathPattern matches the path after the webhook URL, e.g. "/orders/:order_id/*rest";
+	// the captured segments are available as input.request.path_params
+	PathPattern string `json:"path_pattern,omitempty"`
+	// InputTemplate builds the workflow input from {{input.body}} and {{input.request}}
+	// instead of passing the payload through
+	InputTemplate map[string]any `json:"input_template,omitempty"`
 }
 
 // EventConfig represents the configuration for an event trigger.
@@ -156,3 +297,30 @@ type EventConfig struct {
 type IntervalConfig struct {
 	Interval string `json:"interval"` // Duration string like "30s", "5m", "1h"
 }
+
+// EmailConfig represents the configuration for an email (IMAP) trigger.
+type EmailConfig struct {
+	Host               string         `json:"host"`
+	Port               int            `json:"port,omitempty"` // Default: 993 (143 for starttls/none)
+	TLS                string         `json:"tls,omitempty"`  // tls (default), starttls, none
+	InsecureSkipVerify bool           `json:"insecure_skip_verify,omitempty"`
+	CredentialID       string         `json:"credential_id"`           // basic_auth credential attached to the workflow
+	Mailbox            string         `json:"mailbox,omitempty"`       // Default: INBOX
+	Mode               string         `json:"mode,omitempty"`          // idle (default) or poll
+	PollInterval       string         `json:"poll_interval,omitempty"` // Duration string, default "60s"
+	MarkSeen           *bool          `json:"mark_seen,omitempty"`     // Default: true
+	ProcessExisting    bool           `json:"process_existing,omitempty"`
+	StorageID          string         `json:"storage_id,omitempty"` // File storage for attachments, default "default"
+	Input              map[string]any `json:"input,omitempty"`
+}
+
+// SlackConfig represents the configuration for a Slack trigger.
+type SlackConfig struct {
+	CredentialID    string         `json:"credential_id"`              // Credential holding the app signing secret
+	EventTypes      []string       `json:"event_types,omitempty"`      // e.g. "message", "app_mention"; empty accepts all
+	Commands        []string       `json:"commands,omitempty"`         // e.g. "/deploy"; empty accepts all
+	Channels        []string       `json:"channels,omitempty"`         // Channel IDs; empty accepts all
+	IncludeBots     bool           `json:"include_bots,omitempty"`     // Default: false (bot messages are ignored)
+	CommandResponse string         `json:"command_response,omitempty"` // Ephemeral reply to slash commands
+	Input           map[string]any `json:"input,omitempty"`
+}
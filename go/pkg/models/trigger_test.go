@@ -182,6 +182,41 @@ func TestTrigger_Validate_WebhookTrigger_NoConfig(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestTrigger_Validate_WebhookTrigger_RequestTemplating(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{"path pattern", map[string]any{"path_pattern": "/orders/:order_id/*rest"}, ""},
+		{"input template", map[string]any{"input_template": map[string]any{"tenant": "{{input.request.headers.X-Tenant-Id}}"}}, ""},
+		{"relative path pattern", map[string]any{"path_pattern": "orders/:id"}, "starting with /"},
+		{"unnamed parameter", map[string]any{"path_pattern": "/orders/:"}, "must be named"},
+		{"wildcard not last", map[string]any{"path_pattern": "/files/*path/meta"}, "must be the last segment"},
+		{"template not object", map[string]any{"input_template": "{{input.body}}"}, "must be an object"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trigger := &Trigger{
+				WorkflowID: "wf_123",
+				Name:       "Webhook Trigger",
+				Type:       TriggerTypeWebhook,
+				Config:     tt.config,
+				Enabled:    true,
+			}
+
+			err := trigger.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
 // ==================== Event Trigger Tests ====================
 
 func TestTrigger_Validate_EventTrigger_Success(t *testing.T) {
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// QuotaOverflow is the policy for executions started over a user's daily quota.
+type QuotaOverflow string
+
+const (
+	// QuotaOverflowReject rejects the execution with ErrQuotaExceeded (the default).
+	QuotaOverflowReject QuotaOverflow = "reject"
+	// QuotaOverflowQueue schedules the execution for the start of the next day, when the
+	// quota resets, if that day's execution quota has room.
+	QuotaOverflowQueue QuotaOverflow = "queue"
+)
+
+// UsageQuota caps what the executions of a user may use per UTC day. A limit of 0 means no
+// limit. Node runs and LLM tokens are counted when executions finish, so an execution is
+// rejected once a limit is reached, not stopped while it runs.
+type UsageQuota struct {
+	ExecutionsPerDay int64         `json:"executions_per_day"`
+	NodeRunsPerDay   int64         `json:"node_runs_per_day"`
+	LLMTokensPerDay  int64         `json:"llm_tokens_per_day"`
+	Overflow         QuotaOverflow `json:"overflow,omitempty"`
+}
+
+// Validate validates the quota.
+func (q *UsageQuota) Validate() error {
+	limits := []struct {
+		field string
+		limit int64
+	}{
+		{"executions_per_day", q.ExecutionsPerDay},
+		{"node_runs_per_day", q.NodeRunsPerDay},
+		{"llm_tokens_per_day", q.LLMTokensPerDay},
+	}
+	for _, l := range limits {
+		if l.limit < 0 {
+			return &ValidationError{Field: l.field, Message: "limit cannot be negative"}
+		}
+	}
+	switch q.Overflow {
+	case "", QuotaOverflowReject, QuotaOverflowQueue:
+		return nil
+	default:
+		return &ValidationError{Field: "overflow", Message: fmt.Sprintf("unknown overflow policy %q (must be reject or queue)", q.Overflow)}
+	}
+}
+
+// OverflowPolicy returns the overflow policy, reject by default.
+func (q *UsageQuota) OverflowPolicy() QuotaOverflow {
+	if q.Overflow == QuotaOverflowQueue {
+		return QuotaOverflowQueue
+	}
+	return QuotaOverflowReject
+}
+
+// Exceeded describes the first limit the usage has reached, or returns "" if there is room
+// for another execution.
+func (q *UsageQuota) Exceeded(usage *DailyUsage) string {
+	switch {
+	case q.ExecutionsPerDay > 0 && usage.Executions >= q.ExecutionsPerDay:
+		return fmt.Sprintf("%d of %d executions per day used", usage.Executions, q.ExecutionsPerDay)
+	case q.NodeRunsPerDay > 0 && usage.NodeRuns >= q.NodeRunsPerDay:
+		return fmt.Sprintf("%d of %d node runs per day used", usage.NodeRuns, q.NodeRunsPerDay)
+	case q.LLMTokensPerDay > 0 && usage.LLMTokens >= q.LLMTokensPerDay:
+		return fmt.Sprintf("%d of %d LLM tokens per day used", usage.LLMTokens, q.LLMTokensPerDay)
+	default:
+		return ""
+	}
+}
+
+// DailyUsage is what the executions of a user used on one UTC day.
+type DailyUsage struct {
+	UserID     string    `json:"user_id"`
+	Day        time.Time `json:"day"`
+	Executions int64     `json:"executions"`
+	NodeRuns   int64     `json:"node_runs"`
+	LLMTokens  int64     `json:"llm_tokens"`
+}
+
+// UsageDay returns the UTC day the time falls on, as its midnight.
+func UsageDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// QuotaStatus is the usage of a user today against their quota.
+type QuotaStatus struct {
+	Quota    *UsageQuota `json:"quota"`
+	Usage    *DailyUsage `json:"usage"`
+	Exceeded string      `json:"exceeded,omitempty"` // The limit that was reached, if any
+	ResetsAt time.Time   `json:"resets_at"`
+}
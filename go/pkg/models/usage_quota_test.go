@@ -0,0 +1,56 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUsageQuota_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		quota   *UsageQuota
+		wantErr string
+	}{
+		{name: "unlimited", quota: &UsageQuota{}},
+		{name: "queue overflow", quota: &UsageQuota{ExecutionsPerDay: 10, Overflow: QuotaOverflowQueue}},
+		{name: "negative limit", quota: &UsageQuota{LLMTokensPerDay: -1}, wantErr: "llm_tokens_per_day"},
+		{name: "unknown overflow", quota: &UsageQuota{Overflow: "drop"}, wantErr: "overflow"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.quota.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			if !ok || validationErr.Field != tt.wantErr {
+				t.Fatalf("expected a validation error of %s, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestUsageQuota_Exceeded(t *testing.T) {
+	quota := &UsageQuota{ExecutionsPerDay: 5, NodeRunsPerDay: 100}
+
+	if exceeded := quota.Exceeded(&DailyUsage{Executions: 4, NodeRuns: 99, LLMTokens: 1 << 40}); exceeded != "" {
+		t.Errorf("expected room under the quota, got %q", exceeded)
+	}
+	if exceeded := quota.Exceeded(&DailyUsage{Executions: 1, NodeRuns: 100}); exceeded != "100 of 100 node runs per day used" {
+		t.Errorf("unexpected exceeded limit %q", exceeded)
+	}
+	if policy := quota.OverflowPolicy(); policy != QuotaOverflowReject {
+		t.Errorf("expected reject by default, got %s", policy)
+	}
+}
+
+func TestUsageDay(t *testing.T) {
+	at := time.Date(2026, 10, 17, 23, 30, 0, 0, time.FixedZone("UTC-2", -2*3600))
+	if day := UsageDay(at); !day.Equal(time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the UTC day, got %s", day)
+	}
+}
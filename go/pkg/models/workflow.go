@@ -8,20 +8,23 @@ import (
 
 // Workflow represents a complete workflow definition with its DAG structure.
 type Workflow struct {
-	ID          string             `json:"id"`
-	Name        string             `json:"name"`
-	Description string             `json:"description,omitempty"`
-	Version     int                `json:"version"`
-	Status      WorkflowStatus     `json:"status"`
-	Tags        []string           `json:"tags,omitempty"`
-	Nodes       []*Node            `json:"nodes"`
-	Edges       []*Edge            `json:"edges"`
-	Resources   []WorkflowResource `json:"resources,omitempty"` // Attached resources with aliases
-	Variables   map[string]any     `json:"variables,omitempty"` // Workflow-level variables for template substitution
-	Metadata    map[string]any     `json:"metadata,omitempty"`
-	CreatedBy   string             `json:"created_by,omitempty"` // User ID who created the workflow
-	CreatedAt   time.Time          `json:"created_at"`
-	UpdatedAt   time.Time          `json:"updated_at"`
+	ID             string                      `json:"id"`
+	Name           string                      `json:"name"`
+	Description    string                      `json:"description,omitempty"`
+	Version        int                         `json:"version"`
+	Status         WorkflowStatus              `json:"status"`
+	Tags           []string                    `json:"tags,omitempty"`
+	Nodes          []*Node                     `json:"nodes"`
+	Edges          []*Edge                     `json:"edges"`
+	Resources      []WorkflowResource          `json:"resources,omitempty"`       // Attached resources with aliases
+	Variables      map[string]any              `json:"variables,omitempty"`       // Workflow-level variables for template substitution
+	LaunchProfiles map[string]*LaunchProfile   `json:"launch_profiles,omitempty"` // Named run configurations, selected with ?profile=<name>
+	Fixtures       map[string]*WorkflowFixture `json:"fixtures,omitempty"`        // Named sample inputs with expected results
+	Metadata       map[string]any              `json:"metadata,omitempty"`
+	Triggers       []*Trigger                  `json:"triggers,omitempty"`   // Triggers to create with the workflow; not loaded with stored workflows
+	CreatedBy      string                      `json:"created_by,omitempty"` // User ID who created the workflow
+	CreatedAt      time.Time                   `json:"created_at"`
+	UpdatedAt      time.Time                   `json:"updated_at"`
 }
 
 // WorkflowStatus represents the status of a workflow.
@@ -61,6 +64,95 @@ func (wr *WorkflowResource) Validate() error {
 	return nil
 }
 
+// NumberMode selects how JSON numbers are decoded during an execution.
+type NumberMode string
+
+const (
+	// NumberModeFloat decodes numbers as float64, like encoding/json (the default).
+	NumberModeFloat NumberMode = "float"
+	// NumberModePreserve decodes numbers as json.Number, keeping the exact digits of
+	// large integers and decimal amounts.
+	NumberModePreserve NumberMode = "preserve"
+)
+
+// MetadataNumberMode is the workflow metadata key that selects the number mode of its executions.
+const MetadataNumberMode = "json_numbers"
+
+// ParseNumberMode parses a number mode; an empty string is the empty (default) mode.
+func ParseNumberMode(s string) (NumberMode, error) {
+	switch mode := NumberMode(s); mode {
+	case "", NumberModeFloat, NumberModePreserve:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid number mode %q (expected %q or %q)", s, NumberModeFloat, NumberModePreserve)
+	}
+}
+
+// LaunchProfile is a named, predefined way to run a workflow: a base input set
+// plus execution options. Input passed with the run is merged over Input.
+type LaunchProfile struct {
+	Description    string         `json:"description,omitempty" yaml:"description,omitempty"`
+	Input          map[string]any `json:"input,omitempty" yaml:"input,omitempty"`
+	Environment    map[string]any `json:"environment,omitempty" yaml:"environment,omitempty"`         // Execution variables ({{env.*}}) layered over workflow variables
+	MaxParallelism int            `json:"max_parallelism,omitempty" yaml:"max_parallelism,omitempty"` // 0 keeps the engine default
+	TimeoutSeconds int            `json:"timeout_seconds,omitempty" yaml:"timeout_seconds,omitempty"` // 0 keeps the engine default
+}
+
+// Validate validates the launch profile options.
+func (p *LaunchProfile) Validate() error {
+	if p.MaxParallelism < 0 {
+		return &ValidationError{Field: "max_parallelism", Message: "must be non-negative"}
+	}
+	if p.TimeoutSeconds < 0 {
+		return &ValidationError{Field: "timeout_seconds", Message: "must be non-negative"}
+	}
+	return nil
+}
+
+// MergeInput returns the profile input with input layered on top.
+// Keys are merged at the top level only; input wins on conflicts.
+func (p *LaunchProfile) MergeInput(input map[string]any) map[string]any {
+	merged := make(map[string]any, len(p.Input)+len(input))
+	for k, v := range p.Input {
+		merged[k] = v
+	}
+	for k, v := range input {
+		merged[k] = v
+	}
+	return merged
+}
+
+// ValidateLaunchProfiles validates profile names and options.
+func ValidateLaunchProfiles(profiles map[string]*LaunchProfile) error {
+	for name, profile := range profiles {
+		if !isValidProfileName(name) {
+			return &ValidationError{Field: "launch_profiles", Message: fmt.Sprintf("invalid profile name %q: must be alphanumeric with underscores or hyphens, starting with a letter", name)}
+		}
+		if profile == nil {
+			return &ValidationError{Field: "launch_profiles", Message: fmt.Sprintf("profile %q is empty", name)}
+		}
+		if err := profile.Validate(); err != nil {
+			return &ValidationError{Field: "launch_profiles." + name, Message: err.Error()}
+		}
+	}
+	return nil
+}
+
+func isValidProfileName(name string) bool {
+	if len(name) == 0 || len(name) > 100 {
+		return false
+	}
+	if !((name[0] >= 'a' && name[0] <= 'z') || (name[0] >= 'A' && name[0] <= 'Z')) {
+		return false
+	}
+	for _, c := range name {
+		if !((c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '_' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
 func isValidAlias(alias string) bool {
 	if len(alias) == 0 || len(alias) > 100 {
 		return false
@@ -78,40 +170,48 @@ func isValidAlias(alias string) bool {
 
 // Node represents a single node in the workflow DAG.
 type Node struct {
-	ID          string         `json:"id"`
-	Name        string         `json:"name"`
-	Type        string         `json:"type"`
-	Description string         `json:"description,omitempty"`
-	Config      map[string]any `json:"config"`
-	Position    *Position      `json:"position,omitempty"`
-	Metadata    map[string]any `json:"metadata,omitempty"`
+	ID          string         `json:"id" yaml:"id"`
+	Name        string         `json:"name" yaml:"name"`
+	Type        string         `json:"type" yaml:"type"`
+	Description string         `json:"description,omitempty" yaml:"description,omitempty"`
+	Config      map[string]any `json:"config" yaml:"config,omitempty"`
+	Position    *Position      `json:"position,omitempty" yaml:"position,omitempty"`
+	Metadata    map[string]any `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 }
 
+// NodeTimeoutConfigKey is the node config key holding how long the engine lets the node run,
+// in milliseconds. It is not "timeout", which many executors read as their own setting.
+const NodeTimeoutConfigKey = "node_timeout_ms"
+
 // Position represents the visual position of a node in the editor.
 type Position struct {
-	X float64 `json:"x"`
-	Y float64 `json:"y"`
+	X float64 `json:"x" yaml:"x"`
+	Y float64 `json:"y" yaml:"y"`
 }
 
 // LoopConfig configures a loop edge that allows controlled re-execution of a wave range.
 type LoopConfig struct {
-	MaxIterations int `json:"max_iterations"`
+	MaxIterations int `json:"max_iterations" yaml:"max_iterations"`
 }
 
 // Edge represents a directed edge between two nodes in the DAG.
 type Edge struct {
-	ID           string         `json:"id"`
-	From         string         `json:"from"`
-	To           string         `json:"to"`
-	SourceHandle string         `json:"source_handle,omitempty"`
-	Condition    string         `json:"condition,omitempty"`
-	Loop         *LoopConfig    `json:"loop,omitempty"`
-	Metadata     map[string]any `json:"metadata,omitempty"`
+	ID           string         `json:"id" yaml:"id"`
+	From         string         `json:"from" yaml:"from"`
+	To           string         `json:"to" yaml:"to"`
+	Type         EdgeType       `json:"type,omitempty" yaml:"type,omitempty"`
+	SourceHandle string         `json:"source_handle,omitempty" yaml:"source_handle,omitempty"`
+	Condition    string         `json:"condition,omitempty" yaml:"condition,omitempty"`
+	Loop         *LoopConfig    `json:"loop,omitempty" yaml:"loop,omitempty"`
+	Metadata     map[string]any `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 }
 
 // IsLoop returns true if this edge is a loop (back) edge.
 func (e *Edge) IsLoop() bool { return e.Loop != nil }
 
+// IsCompensation returns true if this edge links a step to its compensation node.
+func (e *Edge) IsCompensation() bool { return e.Type == EdgeTypeCompensation }
+
 // Validate validates the workflow structure.
 func (w *Workflow) Validate() error {
 	if w.Name == "" {
@@ -150,6 +250,10 @@ func (w *Workflow) Validate() error {
 		}
 	}
 
+	if err := w.validateCompensation(); err != nil {
+		return err
+	}
+
 	// Validate resources
 	aliasMap := make(map[string]bool)
 	for _, resource := range w.Resources {
@@ -162,9 +266,43 @@ func (w *Workflow) Validate() error {
 		aliasMap[resource.Alias] = true
 	}
 
+	if err := ValidateLaunchProfiles(w.LaunchProfiles); err != nil {
+		return err
+	}
+
+	if err := ValidateFixtures(w.Fixtures); err != nil {
+		return err
+	}
+
+	if raw, ok := w.Metadata[MetadataNumberMode]; ok {
+		mode, isString := raw.(string)
+		if _, err := ParseNumberMode(mode); err != nil || !isString {
+			if err == nil {
+				err = fmt.Errorf("must be a string")
+			}
+			return &ValidationError{Field: "metadata." + MetadataNumberMode, Message: err.Error()}
+		}
+	}
+
+	if _, err := ParsePublishChecks(w.Metadata[MetadataPublishChecks]); err != nil {
+		return &ValidationError{Field: "metadata." + MetadataPublishChecks, Message: err.Error()}
+	}
+
+	if err := w.validateConcurrency(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// NumberMode returns the number mode selected by the workflow metadata, or an empty
+// mode when the workflow does not set one.
+func (w *Workflow) NumberMode() NumberMode {
+	mode, _ := w.Metadata[MetadataNumberMode].(string)
+	parsed, _ := ParseNumberMode(mode)
+	return parsed
+}
+
 // Validate validates the node structure.
 func (n *Node) Validate() error {
 	if n.ID == "" {
@@ -179,6 +317,14 @@ func (n *Node) Validate() error {
 		return &ValidationError{Field: "type", Message: "node type is required"}
 	}
 
+	if _, err := ParseNodeAssertions(n.Config); err != nil {
+		return &ValidationError{Field: "config.assertions", Message: err.Error()}
+	}
+
+	if _, err := ParseNodeDataLabels(n.Config); err != nil {
+		return &ValidationError{Field: "config.data_labels", Message: err.Error()}
+	}
+
 	return nil
 }
 
@@ -200,6 +346,16 @@ func (e *Edge) Validate() error {
 		return &ValidationError{Field: "edge", Message: "self-loop edges are not allowed"}
 	}
 
+	switch e.Type {
+	case "":
+	case EdgeTypeCompensation:
+		if e.Loop != nil || e.Condition != "" || e.SourceHandle != "" {
+			return &ValidationError{Field: "type", Message: "compensation edges must not have loops, conditions or source handles"}
+		}
+	default:
+		return &ValidationError{Field: "type", Message: fmt.Sprintf("unknown edge type %q", e.Type)}
+	}
+
 	if e.Loop != nil {
 		if e.Loop.MaxIterations <= 0 {
 			return &ValidationError{Field: "loop.max_iterations", Message: "must be > 0"}
@@ -212,6 +368,14 @@ func (e *Edge) Validate() error {
 	return nil
 }
 
+// GetLaunchProfile returns a launch profile by name.
+func (w *Workflow) GetLaunchProfile(name string) (*LaunchProfile, error) {
+	if profile, ok := w.LaunchProfiles[name]; ok && profile != nil {
+		return profile, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrLaunchProfileNotFound, name)
+}
+
 // GetNode returns a node by ID.
 func (w *Workflow) GetNode(nodeID string) (*Node, error) {
 	for _, node := range w.Nodes {
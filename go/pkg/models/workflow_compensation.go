@@ -0,0 +1,45 @@
+package models
+
+import "fmt"
+
+// EdgeType distinguishes edges with special semantics from regular edges, which pass data
+// and control from one node to the next. Regular edges have no type.
+type EdgeType string
+
+const (
+	// EdgeTypeCompensation links a step to the compensation node that undoes it, e.g. a refund
+	// for a payment. When a node fails the execution, the compensation nodes of the steps that
+	// completed run one at a time, the last completed step first. A compensation node gets the
+	// output of its step as input and only ever runs as a compensation.
+	EdgeTypeCompensation EdgeType = "compensation"
+)
+
+// validateCompensation validates the compensation edges: a compensation node undoes exactly
+// one step and has no other edges, and a step has at most one compensation node.
+func (w *Workflow) validateCompensation() error {
+	compensates := make(map[string]string)   // compensation node -> step
+	compensatedBy := make(map[string]string) // step -> compensation node
+	for _, edge := range w.Edges {
+		if !edge.IsCompensation() {
+			continue
+		}
+		if step, ok := compensates[edge.To]; ok {
+			return &ValidationError{Field: "edges", Message: fmt.Sprintf("node %s already compensates node %s", edge.To, step)}
+		}
+		if node, ok := compensatedBy[edge.From]; ok {
+			return &ValidationError{Field: "edges", Message: fmt.Sprintf("node %s is already compensated by node %s", edge.From, node)}
+		}
+		compensates[edge.To] = edge.From
+		compensatedBy[edge.From] = edge.To
+	}
+
+	for _, edge := range w.Edges {
+		if _, ok := compensates[edge.From]; ok {
+			return &ValidationError{Field: "edges", Message: fmt.Sprintf("compensation node %s must not have outgoing edges", edge.From)}
+		}
+		if _, ok := compensates[edge.To]; ok && !edge.IsCompensation() {
+			return &ValidationError{Field: "edges", Message: fmt.Sprintf("compensation node %s must only be reached by its compensation edge", edge.To)}
+		}
+	}
+	return nil
+}
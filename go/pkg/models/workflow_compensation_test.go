@@ -0,0 +1,77 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWorkflow_ValidateCompensation(t *testing.T) {
+	newWorkflow := func(edges ...*Edge) *Workflow {
+		nodes := []*Node{}
+		for _, id := range []string{"charge", "ship", "refund", "notify"} {
+			nodes = append(nodes, &Node{ID: id, Name: id, Type: "http", Config: map[string]any{}})
+		}
+		return &Workflow{Name: "Orders", Nodes: nodes, Edges: edges}
+	}
+	compensation := func(id, from, to string) *Edge {
+		return &Edge{ID: id, From: from, To: to, Type: EdgeTypeCompensation}
+	}
+
+	valid := newWorkflow(
+		&Edge{ID: "e1", From: "charge", To: "ship"},
+		compensation("e2", "charge", "refund"),
+	)
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	invalid := []struct {
+		name     string
+		workflow *Workflow
+		field    string
+	}{
+		{
+			name:     "unknown edge type",
+			workflow: newWorkflow(&Edge{ID: "e1", From: "charge", To: "ship", Type: "rollback"}),
+			field:    "type",
+		},
+		{
+			name:     "conditional compensation edge",
+			workflow: newWorkflow(&Edge{ID: "e1", From: "charge", To: "refund", Type: EdgeTypeCompensation, Condition: "true"}),
+			field:    "type",
+		},
+		{
+			name:     "two compensations for a step",
+			workflow: newWorkflow(compensation("e1", "charge", "refund"), compensation("e2", "charge", "notify")),
+			field:    "edges",
+		},
+		{
+			name:     "compensation node shared by two steps",
+			workflow: newWorkflow(compensation("e1", "charge", "refund"), compensation("e2", "ship", "refund")),
+			field:    "edges",
+		},
+		{
+			name: "compensation node with outgoing edge",
+			workflow: newWorkflow(
+				compensation("e1", "charge", "refund"),
+				&Edge{ID: "e2", From: "refund", To: "notify"},
+			),
+			field: "edges",
+		},
+		{
+			name: "compensation node reached by a regular edge",
+			workflow: newWorkflow(
+				compensation("e1", "charge", "refund"),
+				&Edge{ID: "e2", From: "ship", To: "refund"},
+			),
+			field: "edges",
+		},
+	}
+	for _, tt := range invalid {
+		err := tt.workflow.Validate()
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != tt.field {
+			t.Errorf("%s: expected %s validation error, got %v", tt.name, tt.field, err)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// MetadataMaxConcurrentExecutions is the workflow metadata key limiting how many executions
+// of the workflow run at once, across all instances. 0 or unset means no limit.
+const MetadataMaxConcurrentExecutions = "max_concurrent_executions"
+
+// MetadataConcurrencyOverflow is the workflow metadata key selecting what happens to an
+// execution started while the workflow is at its concurrency limit.
+const MetadataConcurrencyOverflow = "concurrency_overflow"
+
+// ConcurrencyOverflow is the policy for executions started over a workflow's concurrency limit.
+type ConcurrencyOverflow string
+
+const (
+	// ConcurrencyOverflowQueue keeps the execution pending until a running one finishes (the default).
+	// Waiting executions start in the order they were started.
+	ConcurrencyOverflowQueue ConcurrencyOverflow = "queue"
+	// ConcurrencyOverflowReject rejects the execution with ErrConcurrencyLimitReached.
+	ConcurrencyOverflowReject ConcurrencyOverflow = "reject"
+)
+
+// ParseConcurrencyOverflow parses an overflow policy; an empty string is the queue policy.
+func ParseConcurrencyOverflow(s string) (ConcurrencyOverflow, error) {
+	switch policy := ConcurrencyOverflow(s); policy {
+	case "":
+		return ConcurrencyOverflowQueue, nil
+	case ConcurrencyOverflowQueue, ConcurrencyOverflowReject:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid concurrency overflow policy %q (expected %q or %q)", s, ConcurrencyOverflowQueue, ConcurrencyOverflowReject)
+	}
+}
+
+// ParseMaxConcurrentExecutions parses a concurrency limit, as stored in the workflow
+// metadata: a non-negative integer, where nil and 0 mean no limit.
+func ParseMaxConcurrentExecutions(raw any) (int, error) {
+	var limit float64
+	switch v := raw.(type) {
+	case nil:
+		return 0, nil
+	case int:
+		limit = float64(v)
+	case int64:
+		limit = float64(v)
+	case float64:
+		limit = v
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return 0, fmt.Errorf("must be an integer")
+		}
+		limit = f
+	default:
+		return 0, fmt.Errorf("must be an integer")
+	}
+	if limit != math.Trunc(limit) || limit > math.MaxInt32 {
+		return 0, fmt.Errorf("must be an integer")
+	}
+	if limit < 0 {
+		return 0, fmt.Errorf("must be non-negative")
+	}
+	return int(limit), nil
+}
+
+// validateConcurrency validates the concurrency settings in the workflow metadata.
+func (w *Workflow) validateConcurrency() error {
+	if _, err := ParseMaxConcurrentExecutions(w.Metadata[MetadataMaxConcurrentExecutions]); err != nil {
+		return &ValidationError{Field: "metadata." + MetadataMaxConcurrentExecutions, Message: err.Error()}
+	}
+	if raw, ok := w.Metadata[MetadataConcurrencyOverflow]; ok {
+		policy, isString := raw.(string)
+		if _, err := ParseConcurrencyOverflow(policy); err != nil || !isString {
+			if err == nil {
+				err = fmt.Errorf("must be a string")
+			}
+			return &ValidationError{Field: "metadata." + MetadataConcurrencyOverflow, Message: err.Error()}
+		}
+	}
+	return nil
+}
+
+// MaxConcurrentExecutions returns the concurrency limit set in the workflow metadata, or 0
+// when the workflow does not set one.
+func (w *Workflow) MaxConcurrentExecutions() int {
+	limit, _ := ParseMaxConcurrentExecutions(w.Metadata[MetadataMaxConcurrentExecutions])
+	return limit
+}
+
+// ConcurrencyOverflow returns the overflow policy set in the workflow metadata, queue by default.
+func (w *Workflow) ConcurrencyOverflow() ConcurrencyOverflow {
+	policy, _ := w.Metadata[MetadataConcurrencyOverflow].(string)
+	parsed, err := ParseConcurrencyOverflow(policy)
+	if err != nil {
+		return ConcurrencyOverflowQueue
+	}
+	return parsed
+}
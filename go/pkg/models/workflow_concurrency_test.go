@@ -0,0 +1,80 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseMaxConcurrentExecutions(t *testing.T) {
+	tests := []struct {
+		raw     any
+		want    int
+		wantErr bool
+	}{
+		{raw: nil, want: 0},
+		{raw: 3, want: 3},
+		{raw: float64(5), want: 5},
+		{raw: json.Number("2"), want: 2},
+		{raw: 0, want: 0},
+		{raw: -1, wantErr: true},
+		{raw: 1.5, wantErr: true},
+		{raw: "2", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMaxConcurrentExecutions(tt.raw)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseMaxConcurrentExecutions(%v) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseMaxConcurrentExecutions(%v) = %d, want %d", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestWorkflow_Concurrency(t *testing.T) {
+	newWorkflow := func(metadata map[string]any) *Workflow {
+		return &Workflow{
+			Name:     "Imports",
+			Nodes:    []*Node{{ID: "node-1", Name: "Node 1", Type: "http", Config: map[string]any{}}},
+			Metadata: metadata,
+		}
+	}
+
+	wf := newWorkflow(nil)
+	if limit := wf.MaxConcurrentExecutions(); limit != 0 {
+		t.Errorf("expected no limit, got %d", limit)
+	}
+	if policy := wf.ConcurrencyOverflow(); policy != ConcurrencyOverflowQueue {
+		t.Errorf("expected %q, got %q", ConcurrencyOverflowQueue, policy)
+	}
+
+	wf = newWorkflow(map[string]any{MetadataMaxConcurrentExecutions: float64(2), MetadataConcurrencyOverflow: "reject"})
+	if err := wf.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if limit := wf.MaxConcurrentExecutions(); limit != 2 {
+		t.Errorf("expected limit 2, got %d", limit)
+	}
+	if policy := wf.ConcurrencyOverflow(); policy != ConcurrencyOverflowReject {
+		t.Errorf("expected %q, got %q", ConcurrencyOverflowReject, policy)
+	}
+
+	invalid := []struct {
+		metadata map[string]any
+		field    string
+	}{
+		{metadata: map[string]any{MetadataMaxConcurrentExecutions: -2}, field: "metadata.max_concurrent_executions"},
+		{metadata: map[string]any{MetadataConcurrencyOverflow: "drop"}, field: "metadata.concurrency_overflow"},
+		{metadata: map[string]any{MetadataConcurrencyOverflow: 1}, field: "metadata.concurrency_overflow"},
+	}
+	for _, tt := range invalid {
+		err := newWorkflow(tt.metadata).Validate()
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != tt.field {
+			t.Errorf("expected %s validation error for %v, got %v", tt.field, tt.metadata, err)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WorkflowDefinitionFormat identifies the version of the workflow definition format.
+const WorkflowDefinitionFormat = "mbflow.workflow/v1"
+
+// WorkflowDefinition is the portable form of a workflow, written as YAML or JSON to keep
+// workflows in files under version control. It holds what the workflow does, not its
+// identity or history: no ID, status, owner or timestamps. Its layout is that of the
+// workflow import format, so definitions can also be imported through the API.
+type WorkflowDefinition struct {
+	Format         string                       `json:"format" yaml:"format"`
+	Metadata       WorkflowDefinitionMetadata   `json:"metadata" yaml:"metadata"`
+	Variables      map[string]any               `json:"variables,omitempty" yaml:"variables,omitempty"`
+	LaunchProfiles map[string]*LaunchProfile    `json:"launch_profiles,omitempty" yaml:"launch_profiles,omitempty"`
+	Fixtures       map[string]*WorkflowFixture  `json:"fixtures,omitempty" yaml:"fixtures,omitempty"`
+	Nodes          []*Node                      `json:"nodes" yaml:"nodes"`
+	Edges          []*Edge                      `json:"edges,omitempty" yaml:"edges,omitempty"`
+	Triggers       []*WorkflowDefinitionTrigger `json:"triggers,omitempty" yaml:"triggers,omitempty"`
+}
+
+// WorkflowDefinitionMetadata describes the workflow of a definition.
+type WorkflowDefinitionMetadata struct {
+	Name        string   `json:"name" yaml:"name"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Version     int      `json:"version,omitempty" yaml:"version,omitempty"`
+	Tags        []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+// WorkflowDefinitionTrigger is a trigger of a definition, created with the workflow when
+// the definition is imported. Enabled defaults to true.
+type WorkflowDefinitionTrigger struct {
+	Name        string         `json:"name" yaml:"name"`
+	Description string         `json:"description,omitempty" yaml:"description,omitempty"`
+	Type        TriggerType    `json:"type" yaml:"type"`
+	Enabled     *bool          `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Config      map[string]any `json:"config,omitempty" yaml:"config,omitempty"`
+	Metadata    map[string]any `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+}
+
+// NewWorkflowDefinition returns the definition of a workflow.
+func NewWorkflowDefinition(w *Workflow) *WorkflowDefinition {
+	d := &WorkflowDefinition{
+		Format: WorkflowDefinitionFormat,
+		Metadata: WorkflowDefinitionMetadata{
+			Name:        w.Name,
+			Description: w.Description,
+			Version:     w.Version,
+			Tags:        w.Tags,
+		},
+		Variables:      w.Variables,
+		LaunchProfiles: w.LaunchProfiles,
+		Fixtures:       w.Fixtures,
+		Nodes:          w.Nodes,
+		Edges:          w.Edges,
+	}
+	for _, trigger := range w.Triggers {
+		enabled := trigger.Enabled
+		d.Triggers = append(d.Triggers, &WorkflowDefinitionTrigger{
+			Name:        trigger.Name,
+			Description: trigger.Description,
+			Type:        trigger.Type,
+			Enabled:     &enabled,
+			Config:      trigger.Config,
+			Metadata:    trigger.Metadata,
+		})
+	}
+	return d
+}
+
+// Workflow reconstructs a draft workflow from the definition and validates it. The
+// workflow has no ID; it is assigned when the workflow is stored.
+func (d *WorkflowDefinition) Workflow() (*Workflow, error) {
+	if d.Format != "" && d.Format != WorkflowDefinitionFormat {
+		return nil, &ValidationError{Field: "format", Message: fmt.Sprintf("unsupported format %q, expected %q", d.Format, WorkflowDefinitionFormat)}
+	}
+
+	version := d.Metadata.Version
+	if version == 0 {
+		version = 1
+	}
+	w := &Workflow{
+		Name:           d.Metadata.Name,
+		Description:    d.Metadata.Description,
+		Version:        version,
+		Status:         WorkflowStatusDraft,
+		Tags:           d.Metadata.Tags,
+		Variables:      d.Variables,
+		LaunchProfiles: d.LaunchProfiles,
+		Fixtures:       d.Fixtures,
+		Nodes:          d.Nodes,
+		Edges:          d.Edges,
+	}
+	if w.Edges == nil {
+		w.Edges = []*Edge{}
+	}
+	for _, node := range w.Nodes {
+		if node != nil && node.Config == nil {
+			node.Config = make(map[string]any)
+		}
+	}
+
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+
+	for idx, t := range d.Triggers {
+		if t == nil {
+			continue
+		}
+		trigger := &Trigger{
+			Name:        t.Name,
+			Description: t.Description,
+			Type:        t.Type,
+			Config:      t.Config,
+			Enabled:     t.Enabled == nil || *t.Enabled,
+			Metadata:    t.Metadata,
+		}
+		if trigger.Config == nil {
+			trigger.Config = make(map[string]any)
+		}
+		if err := trigger.ValidateDefinition(); err != nil {
+			return nil, fmt.Errorf("triggers[%d]: %w", idx, err)
+		}
+		w.Triggers = append(w.Triggers, trigger)
+	}
+	return w, nil
+}
+
+// MarshalWorkflowYAML writes the definition of a workflow as YAML.
+func MarshalWorkflowYAML(w *Workflow) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(NewWorkflowDefinition(w)); err != nil {
+		return nil, fmt.Errorf("failed to encode workflow definition: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode workflow definition: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalWorkflowJSON writes the definition of a workflow as indented JSON.
+func MarshalWorkflowJSON(w *Workflow) ([]byte, error) {
+	data, err := json.MarshalIndent(NewWorkflowDefinition(w), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode workflow definition: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// LoadWorkflowDefinition reads a workflow definition written as YAML or JSON and
+// reconstructs its workflow, as WorkflowDefinition.Workflow does.
+func LoadWorkflowDefinition(data []byte) (*Workflow, error) {
+	var d WorkflowDefinition
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		if err := json.Unmarshal(trimmed, &d); err != nil {
+			return nil, fmt.Errorf("failed to parse workflow definition: %w", err)
+		}
+	} else if err := yaml.Unmarshal(trimmed, &d); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow definition: %w", err)
+	}
+	return d.Workflow()
+}
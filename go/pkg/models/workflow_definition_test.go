@@ -0,0 +1,121 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newDefinitionTestWorkflow() *Workflow {
+	return &Workflow{
+		ID:          "wf-1",
+		Name:        "Review",
+		Description: "Reviews drafts",
+		Version:     3,
+		Status:      WorkflowStatusActive,
+		Tags:        []string{"content"},
+		Variables:   map[string]any{"threshold": 80},
+		LaunchProfiles: map[string]*LaunchProfile{
+			"smoke": {Input: map[string]any{"topic": "tides"}, MaxParallelism: 2},
+		},
+		Nodes: []*Node{
+			{ID: "review", Name: "Review", Type: "llm", Config: map[string]any{"model": "gpt-4o", "max_tokens": 300}},
+			{ID: "improve", Name: "Improve", Type: "llm", Config: map[string]any{}, Position: &Position{X: 200, Y: 100}},
+			{ID: "publish", Name: "Publish", Type: "http", Config: map[string]any{"headers": map[string]any{"X-Source": "mbflow"}}},
+		},
+		Edges: []*Edge{
+			{ID: "e1", From: "review", To: "improve", Condition: "output.score < 80"},
+			{ID: "e2", From: "improve", To: "review", Loop: &LoopConfig{MaxIterations: 3}},
+			{ID: "e3", From: "review", To: "publish", Condition: "output.score >= 80"},
+		},
+		Triggers: []*Trigger{
+			{Name: "Daily", Type: TriggerTypeCron, Config: map[string]any{"schedule": "0 9 * * *"}, Enabled: true},
+			{Name: "Run now", Type: TriggerTypeManual, Config: map[string]any{}},
+		},
+		CreatedBy: "user-1",
+	}
+}
+
+func TestWorkflowDefinition_RoundTrip(t *testing.T) {
+	for name, marshal := range map[string]func(*Workflow) ([]byte, error){
+		"yaml": MarshalWorkflowYAML,
+		"json": MarshalWorkflowJSON,
+	} {
+		t.Run(name, func(t *testing.T) {
+			data, err := marshal(newDefinitionTestWorkflow())
+			if err != nil {
+				t.Fatalf("marshal failed: %v", err)
+			}
+			if strings.Contains(string(data), "wf-1") || strings.Contains(string(data), "user-1") {
+				t.Errorf("expected no identity in the definition:\n%s", data)
+			}
+
+			loaded, err := LoadWorkflowDefinition(data)
+			if err != nil {
+				t.Fatalf("load failed: %v\n%s", err, data)
+			}
+			want := newDefinitionTestWorkflow()
+			if loaded.ID != "" || loaded.Status != WorkflowStatusDraft || loaded.Version != 3 {
+				t.Errorf("unexpected identity: id=%q status=%s version=%d", loaded.ID, loaded.Status, loaded.Version)
+			}
+			if loaded.Name != want.Name || !reflect.DeepEqual(loaded.Tags, want.Tags) {
+				t.Errorf("unexpected metadata: %s %v", loaded.Name, loaded.Tags)
+			}
+			if len(loaded.Nodes) != 3 || loaded.Nodes[1].Position == nil || loaded.Nodes[1].Position.X != 200 {
+				t.Errorf("unexpected nodes: %+v", loaded.Nodes)
+			}
+			if headers, _ := loaded.Nodes[2].Config["headers"].(map[string]any); headers["X-Source"] != "mbflow" {
+				t.Errorf("expected nested config to survive, got: %v", loaded.Nodes[2].Config)
+			}
+			if loop := loaded.Edges[1].Loop; loop == nil || loop.MaxIterations != 3 {
+				t.Errorf("expected the loop edge to survive, got: %+v", loaded.Edges[1])
+			}
+			if loaded.Edges[2].Condition != "output.score >= 80" {
+				t.Errorf("unexpected condition: %q", loaded.Edges[2].Condition)
+			}
+			if profile := loaded.LaunchProfiles["smoke"]; profile == nil || profile.MaxParallelism != 2 {
+				t.Errorf("unexpected launch profiles: %v", loaded.LaunchProfiles)
+			}
+			if len(loaded.Triggers) != 2 || loaded.Triggers[0].Config["schedule"] != "0 9 * * *" ||
+				!loaded.Triggers[0].Enabled || loaded.Triggers[1].Enabled {
+				t.Errorf("unexpected triggers: %+v", loaded.Triggers)
+			}
+
+			again, err := marshal(loaded)
+			if err != nil {
+				t.Fatalf("marshal failed: %v", err)
+			}
+			if string(again) != string(data) {
+				t.Errorf("expected a stable definition, got:\n%s\nthen:\n%s", data, again)
+			}
+		})
+	}
+}
+
+func TestLoadWorkflowDefinition_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown format":  "format: mbflow.workflow/v9\nmetadata:\n  name: X\nnodes:\n  - {id: a, name: A, type: http}\n",
+		"no nodes":        "metadata:\n  name: X\n",
+		"unknown edge":    `{"metadata": {"name": "X"}, "nodes": [{"id": "a", "name": "A", "type": "http"}], "edges": [{"id": "e1", "from": "a", "to": "b"}]}`,
+		"malformed":       "metadata: [",
+		"invalid trigger": "metadata:\n  name: X\nnodes:\n  - {id: a, name: A, type: http}\ntriggers:\n  - {name: Daily, type: cron}\n",
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := LoadWorkflowDefinition([]byte(data)); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestLoadWorkflowDefinition_TriggerEnabledByDefault(t *testing.T) {
+	data := "metadata:\n  name: X\nnodes:\n  - {id: a, name: A, type: http}\ntriggers:\n  - {name: Hook, type: webhook}\n"
+	w, err := LoadWorkflowDefinition([]byte(data))
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if len(w.Triggers) != 1 || !w.Triggers[0].Enabled || w.Triggers[0].Config == nil {
+		t.Errorf("unexpected triggers: %+v", w.Triggers)
+	}
+}
@@ -0,0 +1,166 @@
+package models
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// WorkflowDiff lists what changed from one revision of a workflow to another. Nodes and
+// edges are matched by ID; layout (node positions) and metadata are not compared.
+type WorkflowDiff struct {
+	AddedNodes   []*Node       `json:"added_nodes,omitempty"`
+	RemovedNodes []*Node       `json:"removed_nodes,omitempty"`
+	ChangedNodes []*NodeChange `json:"changed_nodes,omitempty"`
+	AddedEdges   []*Edge       `json:"added_edges,omitempty"`
+	RemovedEdges []*Edge       `json:"removed_edges,omitempty"`
+	ChangedEdges []*EdgeChange `json:"changed_edges,omitempty"`
+}
+
+// NodeChange is a node present in both revisions whose fields or config differ.
+type NodeChange struct {
+	ID     string        `json:"id"`
+	Fields []FieldChange `json:"fields,omitempty"` // name, type, description
+	Config []FieldChange `json:"config,omitempty"` // Top-level config keys
+}
+
+// EdgeChange is an edge present in both revisions whose fields differ.
+type EdgeChange struct {
+	ID     string        `json:"id"`
+	Fields []FieldChange `json:"fields"`
+}
+
+// FieldChange is a value that differs between two revisions. A value missing from one
+// of them is null.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// IsEmpty reports whether the revisions have the same nodes and edges.
+func (d *WorkflowDiff) IsEmpty() bool {
+	return len(d.AddedNodes) == 0 && len(d.RemovedNodes) == 0 && len(d.ChangedNodes) == 0 &&
+		len(d.AddedEdges) == 0 && len(d.RemovedEdges) == 0 && len(d.ChangedEdges) == 0
+}
+
+// Diff compares the nodes and edges of two revisions of a workflow, a being the older.
+// Nodes and edges are reported in the order of the revision they are found in, and
+// config keys in alphabetical order. Values are compared by their JSON encoding, so
+// that 1 and 1.0 are equal, as they are once stored.
+func Diff(a, b *Workflow) *WorkflowDiff {
+	diff := &WorkflowDiff{}
+
+	oldNodes := make(map[string]*Node, len(a.Nodes))
+	for _, node := range a.Nodes {
+		oldNodes[node.ID] = node
+	}
+	newNodes := make(map[string]*Node, len(b.Nodes))
+	for _, node := range b.Nodes {
+		newNodes[node.ID] = node
+		old, ok := oldNodes[node.ID]
+		if !ok {
+			diff.AddedNodes = append(diff.AddedNodes, node)
+			continue
+		}
+		if change := diffNode(old, node); change != nil {
+			diff.ChangedNodes = append(diff.ChangedNodes, change)
+		}
+	}
+	for _, node := range a.Nodes {
+		if _, ok := newNodes[node.ID]; !ok {
+			diff.RemovedNodes = append(diff.RemovedNodes, node)
+		}
+	}
+
+	oldEdges := make(map[string]*Edge, len(a.Edges))
+	for _, edge := range a.Edges {
+		oldEdges[edge.ID] = edge
+	}
+	newEdges := make(map[string]*Edge, len(b.Edges))
+	for _, edge := range b.Edges {
+		newEdges[edge.ID] = edge
+		old, ok := oldEdges[edge.ID]
+		if !ok {
+			diff.AddedEdges = append(diff.AddedEdges, edge)
+			continue
+		}
+		if fields := diffEdge(old, edge); len(fields) > 0 {
+			diff.ChangedEdges = append(diff.ChangedEdges, &EdgeChange{ID: edge.ID, Fields: fields})
+		}
+	}
+	for _, edge := range a.Edges {
+		if _, ok := newEdges[edge.ID]; !ok {
+			diff.RemovedEdges = append(diff.RemovedEdges, edge)
+		}
+	}
+
+	return diff
+}
+
+// diffNode returns the changes of a node, or nil if it did not change.
+func diffNode(a, b *Node) *NodeChange {
+	var fields []FieldChange
+	fields = appendChange(fields, "name", a.Name, b.Name)
+	fields = appendChange(fields, "type", a.Type, b.Type)
+	fields = appendChange(fields, "description", a.Description, b.Description)
+
+	keys := make(map[string]struct{}, len(a.Config)+len(b.Config))
+	for key := range a.Config {
+		keys[key] = struct{}{}
+	}
+	for key := range b.Config {
+		keys[key] = struct{}{}
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	var config []FieldChange
+	for _, key := range sorted {
+		config = appendChange(config, key, a.Config[key], b.Config[key])
+	}
+
+	if len(fields) == 0 && len(config) == 0 {
+		return nil
+	}
+	return &NodeChange{ID: b.ID, Fields: fields, Config: config}
+}
+
+// diffEdge returns the fields of an edge that changed.
+func diffEdge(a, b *Edge) []FieldChange {
+	var fields []FieldChange
+	fields = appendChange(fields, "from", a.From, b.From)
+	fields = appendChange(fields, "to", a.To, b.To)
+	fields = appendChange(fields, "type", a.Type, b.Type)
+	fields = appendChange(fields, "source_handle", a.SourceHandle, b.SourceHandle)
+	fields = appendChange(fields, "condition", a.Condition, b.Condition)
+
+	var oldLoop, newLoop any
+	if a.Loop != nil {
+		oldLoop = a.Loop
+	}
+	if b.Loop != nil {
+		newLoop = b.Loop
+	}
+	return appendChange(fields, "loop", oldLoop, newLoop)
+}
+
+// appendChange appends a change of the field to changes if its values differ.
+func appendChange(changes []FieldChange, field string, oldValue, newValue any) []FieldChange {
+	if sameValue(oldValue, newValue) {
+		return changes
+	}
+	return append(changes, FieldChange{Field: field, Old: oldValue, New: newValue})
+}
+
+// sameValue reports whether two values have the same JSON encoding.
+func sameValue(a, b any) bool {
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return string(encodedA) == string(encodedB)
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	a := newDefinitionTestWorkflow()
+	b, err := a.Clone()
+	if err != nil {
+		t.Fatalf("clone failed: %v", err)
+	}
+
+	// Unchanged once stored, reordered or moved
+	b.Nodes[0].Config["max_tokens"] = 300.0
+	b.Nodes[1].Position = &Position{X: 400, Y: 100}
+
+	b.Nodes[0].Config["model"] = "gpt-4o-mini"
+	b.Nodes[0].Config["temperature"] = 0.2
+	b.Nodes[1].Name = "Rewrite"
+	b.Nodes = append(b.Nodes[:2], &Node{ID: "notify", Name: "Notify", Type: "telegram"})
+	b.Edges[0].Condition = "output.score < 70"
+	b.Edges[1].Loop = nil
+	b.Edges = append(b.Edges[:2], &Edge{ID: "e4", From: "review", To: "notify"})
+
+	diff := Diff(a, b)
+	if diff.IsEmpty() {
+		t.Fatal("expected differences")
+	}
+
+	if len(diff.AddedNodes) != 1 || diff.AddedNodes[0].ID != "notify" {
+		t.Errorf("unexpected added nodes: %+v", diff.AddedNodes)
+	}
+	if len(diff.RemovedNodes) != 1 || diff.RemovedNodes[0].ID != "publish" {
+		t.Errorf("unexpected removed nodes: %+v", diff.RemovedNodes)
+	}
+	want := []*NodeChange{
+		{ID: "review", Config: []FieldChange{
+			{Field: "model", Old: "gpt-4o", New: "gpt-4o-mini"},
+			{Field: "temperature", Old: nil, New: 0.2},
+		}},
+		{ID: "improve", Fields: []FieldChange{{Field: "name", Old: "Improve", New: "Rewrite"}}},
+	}
+	if !reflect.DeepEqual(diff.ChangedNodes, want) {
+		t.Errorf("unexpected changed nodes:\n got %+v\nwant %+v", diff.ChangedNodes, want)
+	}
+
+	if len(diff.AddedEdges) != 1 || diff.AddedEdges[0].ID != "e4" {
+		t.Errorf("unexpected added edges: %+v", diff.AddedEdges)
+	}
+	if len(diff.RemovedEdges) != 1 || diff.RemovedEdges[0].ID != "e3" {
+		t.Errorf("unexpected removed edges: %+v", diff.RemovedEdges)
+	}
+	if len(diff.ChangedEdges) != 2 {
+		t.Fatalf("expected 2 changed edges, got %d", len(diff.ChangedEdges))
+	}
+	if got := diff.ChangedEdges[0].Fields; len(got) != 1 || got[0].Field != "condition" || got[0].New != "output.score < 70" {
+		t.Errorf("unexpected changes of e1: %+v", got)
+	}
+	if got := diff.ChangedEdges[1].Fields; len(got) != 1 || got[0].Field != "loop" || got[0].New != nil {
+		t.Errorf("unexpected changes of e2: %+v", got)
+	}
+}
+
+func TestDiff_SameWorkflow(t *testing.T) {
+	a := newDefinitionTestWorkflow()
+	b, err := a.Clone()
+	if err != nil {
+		t.Fatalf("clone failed: %v", err)
+	}
+
+	if diff := Diff(a, b); !diff.IsEmpty() {
+		t.Errorf("expected no differences, got %+v", diff)
+	}
+}
@@ -0,0 +1,156 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// WorkflowFixture is a named sample input stored with a workflow, together with the
+// result the workflow is expected to produce for it. Fixtures are the workflow's own
+// smoke tests: they run from the editor and before publishing.
+type WorkflowFixture struct {
+	Description    string          `json:"description,omitempty" yaml:"description,omitempty"`
+	Input          map[string]any  `json:"input,omitempty" yaml:"input,omitempty"`
+	ExpectedStatus ExecutionStatus `json:"expected_status,omitempty" yaml:"expected_status,omitempty"` // Default: completed
+	ExpectedOutput map[string]any  `json:"expected_output,omitempty" yaml:"expected_output,omitempty"` // Subset of the execution output that must match
+	ExpectedError  string          `json:"expected_error,omitempty" yaml:"expected_error,omitempty"`   // Substring of the execution error
+}
+
+// Validate validates the fixture expectations.
+func (f *WorkflowFixture) Validate() error {
+	switch f.ExpectedStatus {
+	case "", ExecutionStatusCompleted, ExecutionStatusFailed, ExecutionStatusCancelled, ExecutionStatusTimeout:
+	default:
+		return &ValidationError{Field: "expected_status", Message: fmt.Sprintf("must be one of: completed, failed, cancelled, timeout (got %q)", f.ExpectedStatus)}
+	}
+	return nil
+}
+
+// Check compares an execution of the fixture input with the expectations and returns
+// the mismatches, or nil when the execution passes.
+//
+// The execution output must contain every key of ExpectedOutput: objects are matched
+// recursively as subsets, arrays element by element, and scalars by their JSON value,
+// so 10 and 10.0 are equal.
+func (f *WorkflowFixture) Check(execution *Execution) []string {
+	var failures []string
+
+	expectedStatus := f.ExpectedStatus
+	if expectedStatus == "" {
+		expectedStatus = ExecutionStatusCompleted
+	}
+	if execution.Status != expectedStatus {
+		failure := fmt.Sprintf("status: expected %s, got %s", expectedStatus, execution.Status)
+		if execution.Error != "" {
+			failure += " (" + execution.Error + ")"
+		}
+		failures = append(failures, failure)
+	}
+
+	if f.ExpectedError != "" && !strings.Contains(execution.Error, f.ExpectedError) {
+		failures = append(failures, fmt.Sprintf("error: expected to contain %q, got %q", f.ExpectedError, execution.Error))
+	}
+
+	if len(f.ExpectedOutput) > 0 {
+		failures = append(failures, matchFixtureValue("output", normalizeFixtureValue(f.ExpectedOutput), normalizeFixtureValue(execution.Output))...)
+	}
+
+	return failures
+}
+
+// normalizeFixtureValue round-trips a value through JSON so that Go types produced by
+// executors compare equal to their decoded counterparts.
+func normalizeFixtureValue(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return v
+	}
+	return normalized
+}
+
+// matchFixtureValue matches an actual value against an expected one and returns the
+// mismatches, each prefixed with the path of the value.
+func matchFixtureValue(path string, expected, actual any) []string {
+	switch exp := expected.(type) {
+	case map[string]any:
+		act, ok := actual.(map[string]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected an object, got %s", path, formatFixtureValue(actual))}
+		}
+		keys := make([]string, 0, len(exp))
+		for k := range exp {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		var failures []string
+		for _, k := range keys {
+			value, ok := act[k]
+			if !ok {
+				failures = append(failures, fmt.Sprintf("%s.%s: missing", path, k))
+				continue
+			}
+			failures = append(failures, matchFixtureValue(path+"."+k, exp[k], value)...)
+		}
+		return failures
+
+	case []any:
+		act, ok := actual.([]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected an array, got %s", path, formatFixtureValue(actual))}
+		}
+		if len(act) != len(exp) {
+			return []string{fmt.Sprintf("%s: expected %d items, got %d", path, len(exp), len(act))}
+		}
+		var failures []string
+		for i := range exp {
+			failures = append(failures, matchFixtureValue(fmt.Sprintf("%s[%d]", path, i), exp[i], act[i])...)
+		}
+		return failures
+
+	default:
+		if !reflect.DeepEqual(expected, actual) {
+			return []string{fmt.Sprintf("%s: expected %s, got %s", path, formatFixtureValue(expected), formatFixtureValue(actual))}
+		}
+		return nil
+	}
+}
+
+func formatFixtureValue(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+// ValidateFixtures validates fixture names and expectations.
+func ValidateFixtures(fixtures map[string]*WorkflowFixture) error {
+	for name, fixture := range fixtures {
+		if !isValidProfileName(name) {
+			return &ValidationError{Field: "fixtures", Message: fmt.Sprintf("invalid fixture name %q: must be alphanumeric with underscores or hyphens, starting with a letter", name)}
+		}
+		if fixture == nil {
+			return &ValidationError{Field: "fixtures", Message: fmt.Sprintf("fixture %q is empty", name)}
+		}
+		if err := fixture.Validate(); err != nil {
+			return &ValidationError{Field: "fixtures." + name, Message: err.Error()}
+		}
+	}
+	return nil
+}
+
+// GetFixture returns a fixture by name.
+func (w *Workflow) GetFixture(name string) (*WorkflowFixture, error) {
+	if fixture, ok := w.Fixtures[name]; ok && fixture != nil {
+		return fixture, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrFixtureNotFound, name)
+}
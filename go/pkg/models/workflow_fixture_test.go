@@ -0,0 +1,147 @@
+package models
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidateFixtures(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixtures map[string]*WorkflowFixture
+		wantErr  bool
+	}{
+		{
+			name:     "nil fixtures",
+			fixtures: nil,
+			wantErr:  false,
+		},
+		{
+			name: "valid fixtures",
+			fixtures: map[string]*WorkflowFixture{
+				"happy_path":   {Input: map[string]any{"id": 1}, ExpectedOutput: map[string]any{"ok": true}},
+				"missing-user": {ExpectedStatus: ExecutionStatusFailed, ExpectedError: "not found"},
+			},
+			wantErr: false,
+		},
+		{
+			name:     "invalid name",
+			fixtures: map[string]*WorkflowFixture{"happy path": {}},
+			wantErr:  true,
+		},
+		{
+			name:     "nil fixture",
+			fixtures: map[string]*WorkflowFixture{"smoke": nil},
+			wantErr:  true,
+		},
+		{
+			name:     "non-terminal expected status",
+			fixtures: map[string]*WorkflowFixture{"smoke": {ExpectedStatus: ExecutionStatusRunning}},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFixtures(tt.fixtures)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateFixtures() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWorkflowFixture_Check(t *testing.T) {
+	completed := &Execution{
+		Status: ExecutionStatusCompleted,
+		Output: map[string]any{
+			"total":    10,
+			"currency": "EUR",
+			"customer": map[string]any{"id": "c-1", "tier": "gold", "since": 2019},
+			"items":    []any{map[string]any{"sku": "A", "qty": 2}, map[string]any{"sku": "B", "qty": 1}},
+		},
+	}
+	failed := &Execution{Status: ExecutionStatusFailed, Error: "node fetch failed: customer not found"}
+
+	tests := []struct {
+		name      string
+		fixture   *WorkflowFixture
+		execution *Execution
+		want      []string
+	}{
+		{
+			name:      "no expectations",
+			fixture:   &WorkflowFixture{},
+			execution: completed,
+		},
+		{
+			name: "output subset",
+			fixture: &WorkflowFixture{ExpectedOutput: map[string]any{
+				"total":    10.0,
+				"customer": map[string]any{"tier": "gold"},
+				"items":    []any{map[string]any{"sku": "A"}, map[string]any{"qty": 1}},
+			}},
+			execution: completed,
+		},
+		{
+			name: "output mismatches",
+			fixture: &WorkflowFixture{ExpectedOutput: map[string]any{
+				"total":    12,
+				"customer": map[string]any{"tier": "silver", "email": "a@b.c"},
+				"items":    []any{map[string]any{"sku": "A"}},
+				"currency": map[string]any{"code": "EUR"},
+			}},
+			execution: completed,
+			want: []string{
+				`output.currency: expected an object, got "EUR"`,
+				`output.customer.email: missing`,
+				`output.customer.tier: expected "silver", got "gold"`,
+				`output.items: expected 1 items, got 2`,
+				`output.total: expected 12, got 10`,
+			},
+		},
+		{
+			name:      "unexpected failure",
+			fixture:   &WorkflowFixture{},
+			execution: failed,
+			want:      []string{"status: expected completed, got failed (node fetch failed: customer not found)"},
+		},
+		{
+			name:      "expected failure",
+			fixture:   &WorkflowFixture{ExpectedStatus: ExecutionStatusFailed, ExpectedError: "customer not found"},
+			execution: failed,
+		},
+		{
+			name:      "wrong error",
+			fixture:   &WorkflowFixture{ExpectedStatus: ExecutionStatusFailed, ExpectedError: "timeout"},
+			execution: failed,
+			want:      []string{`error: expected to contain "timeout", got "node fetch failed: customer not found"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.fixture.Check(tt.execution)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkflow_GetFixture(t *testing.T) {
+	w := &Workflow{Fixtures: map[string]*WorkflowFixture{"smoke": {Description: "Smoke test"}}}
+
+	fixture, err := w.GetFixture("smoke")
+	if err != nil {
+		t.Fatalf("GetFixture() error = %v", err)
+	}
+	if fixture.Description != "Smoke test" {
+		t.Errorf("GetFixture() description = %q", fixture.Description)
+	}
+
+	if _, err := w.GetFixture("missing"); !errors.Is(err, ErrFixtureNotFound) {
+		t.Errorf("GetFixture() error = %v, want ErrFixtureNotFound", err)
+	}
+}
@@ -0,0 +1,269 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// IDConflictStrategy controls how node and edge ID collisions are resolved when
+// a workflow is imported into, or cloned into, a workflow that already uses some IDs.
+type IDConflictStrategy string
+
+const (
+	// IDConflictFail rejects the operation on the first colliding ID (default)
+	IDConflictFail IDConflictStrategy = "fail"
+
+	// IDConflictSuffix renames colliding IDs by appending "_2", "_3", ...
+	IDConflictSuffix IDConflictStrategy = "suffix"
+
+	// IDConflictMap renames IDs using an explicit old -> new mapping; a collision
+	// without a mapping entry is an error
+	IDConflictMap IDConflictStrategy = "map"
+)
+
+// IsValid reports whether the strategy is supported.
+func (s IDConflictStrategy) IsValid() bool {
+	switch s {
+	case IDConflictFail, IDConflictSuffix, IDConflictMap:
+		return true
+	}
+	return false
+}
+
+// IDMap is an explicit old -> new renaming of node and edge IDs.
+type IDMap struct {
+	Nodes map[string]string `json:"nodes,omitempty" yaml:"nodes,omitempty"`
+	Edges map[string]string `json:"edges,omitempty" yaml:"edges,omitempty"`
+}
+
+// IDRemapOptions configures Workflow.RemapIDs.
+type IDRemapOptions struct {
+	// Strategy resolves collisions; empty means IDConflictFail.
+	Strategy IDConflictStrategy
+
+	// Mapping renames IDs explicitly. Entries are applied with every strategy,
+	// and are required for colliding IDs with IDConflictMap.
+	Mapping IDMap
+
+	// ReservedNodeIDs and ReservedEdgeIDs are the IDs already used by the target workflow.
+	ReservedNodeIDs map[string]bool
+	ReservedEdgeIDs map[string]bool
+}
+
+// IDRemapResult lists the IDs that were renamed (old -> new).
+type IDRemapResult struct {
+	Nodes map[string]string `json:"nodes,omitempty"`
+	Edges map[string]string `json:"edges,omitempty"`
+}
+
+// Renamed reports whether any ID was changed.
+func (r *IDRemapResult) Renamed() bool {
+	return len(r.Nodes) > 0 || len(r.Edges) > 0
+}
+
+// IDConflictError is returned when an ID collides and the strategy cannot resolve it.
+type IDConflictError struct {
+	Kind string // "node" or "edge"
+	ID   string
+}
+
+func (e *IDConflictError) Error() string {
+	return fmt.Sprintf("%s ID %q already exists in the target workflow", e.Kind, e.ID)
+}
+
+// RemapIDs renames node and edge IDs so they do not collide with the reserved IDs,
+// then rewrites every reference to a renamed node: edge endpoints, edge conditions
+// (output.<id>, output["<id>"]) and {{input.<id>...}} templates in node configs.
+// The workflow is modified in place; on error it is left unchanged.
+func (w *Workflow) RemapIDs(opts IDRemapOptions) (*IDRemapResult, error) {
+	strategy := opts.Strategy
+	if strategy == "" {
+		strategy = IDConflictFail
+	}
+	if !strategy.IsValid() {
+		return nil, &ValidationError{Field: "strategy", Message: fmt.Sprintf("invalid ID conflict strategy: %s", strategy)}
+	}
+
+	nodeIDs := make([]string, len(w.Nodes))
+	for i, node := range w.Nodes {
+		nodeIDs[i] = node.ID
+	}
+	edgeIDs := make([]string, len(w.Edges))
+	for i, edge := range w.Edges {
+		edgeIDs[i] = edge.ID
+	}
+
+	nodeMap, err := resolveIDs("node", nodeIDs, strategy, opts.Mapping.Nodes, opts.ReservedNodeIDs)
+	if err != nil {
+		return nil, err
+	}
+	edgeMap, err := resolveIDs("edge", edgeIDs, strategy, opts.Mapping.Edges, opts.ReservedEdgeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &IDRemapResult{Nodes: nodeMap, Edges: edgeMap}
+	if !result.Renamed() {
+		return result, nil
+	}
+
+	refs := newReferenceRewriter(nodeMap)
+	for _, node := range w.Nodes {
+		if newID, ok := nodeMap[node.ID]; ok {
+			node.ID = newID
+		}
+		if refs != nil {
+			node.Config = refs.rewriteMap(node.Config)
+		}
+	}
+	for _, edge := range w.Edges {
+		if newID, ok := edgeMap[edge.ID]; ok {
+			edge.ID = newID
+		}
+		if newID, ok := nodeMap[edge.From]; ok {
+			edge.From = newID
+		}
+		if newID, ok := nodeMap[edge.To]; ok {
+			edge.To = newID
+		}
+		if refs != nil && edge.Condition != "" {
+			edge.Condition = refs.rewriteCondition(edge.Condition)
+		}
+	}
+
+	return result, nil
+}
+
+// resolveIDs returns the renamed IDs (old -> new) for one kind of ID.
+func resolveIDs(kind string, ids []string, strategy IDConflictStrategy, mapping map[string]string, reserved map[string]bool) (map[string]string, error) {
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			// References to a duplicated ID are ambiguous, so no strategy can fix this.
+			return nil, &ValidationError{Field: kind + "s", Message: fmt.Sprintf("duplicate %s ID: %s", kind, id)}
+		}
+		seen[id] = true
+	}
+
+	used := make(map[string]bool, len(reserved)+len(ids))
+	for id := range reserved {
+		used[id] = true
+	}
+
+	renamed := make(map[string]string)
+	// Explicitly mapped IDs claim their new names first.
+	for _, id := range ids {
+		newID, ok := mapping[id]
+		if !ok || newID == "" || newID == id {
+			continue
+		}
+		if used[newID] {
+			return nil, &IDConflictError{Kind: kind, ID: newID}
+		}
+		used[newID] = true
+		renamed[id] = newID
+	}
+
+	for _, id := range ids {
+		if _, ok := renamed[id]; ok {
+			continue
+		}
+		if !used[id] {
+			used[id] = true
+			continue
+		}
+
+		if strategy != IDConflictSuffix {
+			return nil, &IDConflictError{Kind: kind, ID: id}
+		}
+		newID := id
+		for n := 2; used[newID]; n++ {
+			newID = fmt.Sprintf("%s_%d", id, n)
+		}
+		used[newID] = true
+		renamed[id] = newID
+	}
+
+	return renamed, nil
+}
+
+// referenceRewriter rewrites node ID references in templates and conditions.
+type referenceRewriter struct {
+	mapping   map[string]string
+	template  *regexp.Regexp
+	condition *regexp.Regexp
+}
+
+func newReferenceRewriter(nodeMap map[string]string) *referenceRewriter {
+	if len(nodeMap) == 0 {
+		return nil
+	}
+
+	// Longest IDs first so "fetch_all" is not matched as "fetch".
+	olds := make([]string, 0, len(nodeMap))
+	for old := range nodeMap {
+		olds = append(olds, old)
+	}
+	sort.Slice(olds, func(i, j int) bool { return len(olds[i]) > len(olds[j]) })
+	quoted := make([]string, len(olds))
+	for i, old := range olds {
+		quoted[i] = regexp.QuoteMeta(old)
+	}
+	alternation := strings.Join(quoted, "|")
+
+	return &referenceRewriter{
+		mapping:   nodeMap,
+		template:  regexp.MustCompile(`(\{\{\s*input\.)(` + alternation + `)([.\[\s}|])`),
+		condition: regexp.MustCompile(`(\boutput\.)(` + alternation + `)(\W|$)|(\boutput\[")(` + alternation + `)("\])`),
+	}
+}
+
+func (r *referenceRewriter) rewriteTemplate(s string) string {
+	if !strings.Contains(s, "{{") {
+		return s
+	}
+	return r.template.ReplaceAllStringFunc(s, func(match string) string {
+		parts := r.template.FindStringSubmatch(match)
+		return parts[1] + r.mapping[parts[2]] + parts[3]
+	})
+}
+
+func (r *referenceRewriter) rewriteCondition(s string) string {
+	return r.condition.ReplaceAllStringFunc(s, func(match string) string {
+		parts := r.condition.FindStringSubmatch(match)
+		if parts[1] != "" {
+			return parts[1] + r.mapping[parts[2]] + parts[3]
+		}
+		return parts[4] + r.mapping[parts[5]] + parts[6]
+	})
+}
+
+func (r *referenceRewriter) rewriteMap(m map[string]any) map[string]any {
+	for k, v := range m {
+		m[k] = r.rewriteValue(v)
+	}
+	return m
+}
+
+func (r *referenceRewriter) rewriteValue(v any) any {
+	switch val := v.(type) {
+	case string:
+		return r.rewriteTemplate(val)
+	case map[string]any:
+		return r.rewriteMap(val)
+	case []any:
+		for i, item := range val {
+			val[i] = r.rewriteValue(item)
+		}
+		return val
+	case []string:
+		for i, item := range val {
+			val[i] = r.rewriteTemplate(item)
+		}
+		return val
+	default:
+		return v
+	}
+}
@@ -0,0 +1,201 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func newRemapTestWorkflow() *Workflow {
+	return &Workflow{
+		ID:   "wf-1",
+		Name: "Imported",
+		Nodes: []*Node{
+			{ID: "fetch", Name: "Fetch", Type: "http", Config: map[string]any{"url": "https://example.com"}},
+			{ID: "fetch_all", Name: "Fetch All", Type: "http", Config: map[string]any{}},
+			{ID: "merge", Name: "Merge", Type: "transform", Config: map[string]any{
+				"expression": "{{input.fetch.body}} / {{input.fetch_all.body}} / {{ input.fetch }}",
+				"headers":    map[string]any{"X-Id": "{{input.fetch.id}}"},
+				"items":      []any{"{{input.fetch[0]}}", "{{input.fetcher.id}}", "{{env.fetch}}"},
+			}},
+		},
+		Edges: []*Edge{
+			{ID: "e1", From: "fetch", To: "merge", Condition: `output.fetch.status == 200 && output["fetch"].ok && output.fetcher == nil`},
+			{ID: "e2", From: "fetch_all", To: "merge"},
+		},
+	}
+}
+
+func TestWorkflow_RemapIDs_Suffix(t *testing.T) {
+	w := newRemapTestWorkflow()
+
+	result, err := w.RemapIDs(IDRemapOptions{
+		Strategy:        IDConflictSuffix,
+		ReservedNodeIDs: map[string]bool{"fetch": true, "fetch_2": true},
+		ReservedEdgeIDs: map[string]bool{"e1": true},
+	})
+	if err != nil {
+		t.Fatalf("RemapIDs() error = %v", err)
+	}
+
+	if got := result.Nodes["fetch"]; got != "fetch_3" {
+		t.Errorf("renamed node = %q, want fetch_3", got)
+	}
+	if len(result.Nodes) != 1 {
+		t.Errorf("renamed nodes = %v, want only fetch", result.Nodes)
+	}
+	if got := result.Edges["e1"]; got != "e1_2" {
+		t.Errorf("renamed edge = %q, want e1_2", got)
+	}
+
+	if w.Nodes[0].ID != "fetch_3" || w.Nodes[1].ID != "fetch_all" {
+		t.Errorf("node IDs = %s, %s", w.Nodes[0].ID, w.Nodes[1].ID)
+	}
+	if w.Edges[0].ID != "e1_2" || w.Edges[0].From != "fetch_3" || w.Edges[0].To != "merge" {
+		t.Errorf("edge e1 = %+v", w.Edges[0])
+	}
+	if w.Edges[1].From != "fetch_all" {
+		t.Errorf("edge e2 from = %s, want fetch_all", w.Edges[1].From)
+	}
+
+	wantCondition := `output.fetch_3.status == 200 && output["fetch_3"].ok && output.fetcher == nil`
+	if w.Edges[0].Condition != wantCondition {
+		t.Errorf("condition = %q, want %q", w.Edges[0].Condition, wantCondition)
+	}
+
+	cfg := w.Nodes[2].Config
+	wantExpr := "{{input.fetch_3.body}} / {{input.fetch_all.body}} / {{ input.fetch_3 }}"
+	if cfg["expression"] != wantExpr {
+		t.Errorf("expression = %q, want %q", cfg["expression"], wantExpr)
+	}
+	if got := cfg["headers"].(map[string]any)["X-Id"]; got != "{{input.fetch_3.id}}" {
+		t.Errorf("nested template = %q", got)
+	}
+	items := cfg["items"].([]any)
+	if items[0] != "{{input.fetch_3[0]}}" || items[1] != "{{input.fetcher.id}}" || items[2] != "{{env.fetch}}" {
+		t.Errorf("items = %v", items)
+	}
+}
+
+func TestWorkflow_RemapIDs_Fail(t *testing.T) {
+	w := newRemapTestWorkflow()
+
+	_, err := w.RemapIDs(IDRemapOptions{ReservedNodeIDs: map[string]bool{"merge": true}})
+
+	var conflictErr *IDConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatalf("RemapIDs() error = %v, want IDConflictError", err)
+	}
+	if conflictErr.Kind != "node" || conflictErr.ID != "merge" {
+		t.Errorf("conflict = %+v", conflictErr)
+	}
+	if w.Nodes[2].ID != "merge" || w.Edges[0].To != "merge" {
+		t.Error("workflow was modified despite the error")
+	}
+}
+
+func TestWorkflow_RemapIDs_Map(t *testing.T) {
+	t.Run("mapped collision", func(t *testing.T) {
+		w := newRemapTestWorkflow()
+
+		result, err := w.RemapIDs(IDRemapOptions{
+			Strategy:        IDConflictMap,
+			Mapping:         IDMap{Nodes: map[string]string{"merge": "combine"}},
+			ReservedNodeIDs: map[string]bool{"merge": true},
+		})
+		if err != nil {
+			t.Fatalf("RemapIDs() error = %v", err)
+		}
+		if result.Nodes["merge"] != "combine" {
+			t.Errorf("renamed = %v", result.Nodes)
+		}
+		if w.Edges[0].To != "combine" || w.Edges[1].To != "combine" {
+			t.Errorf("edges not rewired: %s, %s", w.Edges[0].To, w.Edges[1].To)
+		}
+	})
+
+	t.Run("unmapped collision", func(t *testing.T) {
+		w := newRemapTestWorkflow()
+
+		_, err := w.RemapIDs(IDRemapOptions{
+			Strategy:        IDConflictMap,
+			ReservedNodeIDs: map[string]bool{"fetch": true},
+		})
+		var conflictErr *IDConflictError
+		if !errors.As(err, &conflictErr) || conflictErr.ID != "fetch" {
+			t.Fatalf("RemapIDs() error = %v, want conflict on fetch", err)
+		}
+	})
+
+	t.Run("mapping onto an existing ID", func(t *testing.T) {
+		w := newRemapTestWorkflow()
+
+		_, err := w.RemapIDs(IDRemapOptions{
+			Strategy: IDConflictMap,
+			Mapping:  IDMap{Nodes: map[string]string{"fetch": "merge"}},
+		})
+		var conflictErr *IDConflictError
+		if !errors.As(err, &conflictErr) || conflictErr.ID != "merge" {
+			t.Fatalf("RemapIDs() error = %v, want conflict on merge", err)
+		}
+	})
+}
+
+func TestWorkflow_RemapIDs_NoReserved(t *testing.T) {
+	w := newRemapTestWorkflow()
+
+	result, err := w.RemapIDs(IDRemapOptions{})
+	if err != nil {
+		t.Fatalf("RemapIDs() error = %v", err)
+	}
+	if result.Renamed() {
+		t.Errorf("unexpected renames: %+v", result)
+	}
+}
+
+func TestWorkflow_RemapIDs_Invalid(t *testing.T) {
+	w := newRemapTestWorkflow()
+	w.Nodes[1].ID = "fetch"
+
+	if _, err := w.RemapIDs(IDRemapOptions{Strategy: IDConflictSuffix}); err == nil {
+		t.Error("expected error for duplicate node IDs")
+	}
+	if _, err := w.RemapIDs(IDRemapOptions{Strategy: "rename"}); err == nil {
+		t.Error("expected error for unknown strategy")
+	}
+}
+
+func TestWorkflow_RemapIDs_Clone(t *testing.T) {
+	original := newRemapTestWorkflow()
+	clone, err := original.Clone()
+	if err != nil {
+		t.Fatalf("Clone() error = %v", err)
+	}
+
+	reserved := make(map[string]bool)
+	for _, node := range original.Nodes {
+		reserved[node.ID] = true
+	}
+	edgeReserved := make(map[string]bool)
+	for _, edge := range original.Edges {
+		edgeReserved[edge.ID] = true
+	}
+
+	if _, err := clone.RemapIDs(IDRemapOptions{
+		Strategy:        IDConflictSuffix,
+		ReservedNodeIDs: reserved,
+		ReservedEdgeIDs: edgeReserved,
+	}); err != nil {
+		t.Fatalf("RemapIDs() error = %v", err)
+	}
+
+	merged := &Workflow{Name: "merged", Nodes: append(original.Nodes, clone.Nodes...), Edges: append(original.Edges, clone.Edges...)}
+	if err := merged.Validate(); err != nil {
+		t.Fatalf("merged workflow invalid: %v", err)
+	}
+	if original.Nodes[0].ID != "fetch" || original.Edges[0].From != "fetch" {
+		t.Error("original workflow was modified")
+	}
+	if clone.Edges[1].From != "fetch_all_2" || clone.Edges[1].To != "merge_2" {
+		t.Errorf("clone edge = %+v", clone.Edges[1])
+	}
+}
@@ -0,0 +1,217 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// PublishCheck names a check that must pass before a workflow can be published.
+type PublishCheck string
+
+const (
+	// PublishCheckLint requires a structurally valid, acyclic workflow whose node types are all registered.
+	PublishCheckLint PublishCheck = "lint"
+	// PublishCheckFixtures requires at least one fixture and every fixture to pass.
+	PublishCheckFixtures PublishCheck = "fixtures"
+	// PublishCheckNoPlaintextSecrets requires that no credentials are written into node configs or variables.
+	PublishCheckNoPlaintextSecrets PublishCheck = "no_plaintext_secrets"
+	// PublishCheckInputSchema requires an input schema in the workflow metadata.
+	PublishCheckInputSchema PublishCheck = "input_schema"
+)
+
+// PublishChecks lists every publish check in the order they are run.
+var PublishChecks = []PublishCheck{
+	PublishCheckLint,
+	PublishCheckNoPlaintextSecrets,
+	PublishCheckInputSchema,
+	PublishCheckFixtures,
+}
+
+// MetadataPublishChecks is the workflow metadata key listing the checks required to publish it.
+const MetadataPublishChecks = "publish_checks"
+
+// MetadataInputSchema is the workflow metadata key holding the JSON schema of the workflow input.
+const MetadataInputSchema = "input_schema"
+
+// PublishCheckResult is the outcome of one publish check.
+type PublishCheckResult struct {
+	Name     PublishCheck `json:"name"`
+	Passed   bool         `json:"passed"`
+	Problems []string     `json:"problems,omitempty"`
+}
+
+// PublishReport is the checklist produced when a workflow is checked for publishing.
+// Passed is true when every check passed, including when none are required.
+type PublishReport struct {
+	Passed bool                 `json:"passed"`
+	Checks []PublishCheckResult `json:"checks"`
+}
+
+// Add records the result of a check.
+func (r *PublishReport) Add(name PublishCheck, problems []string) {
+	r.Checks = append(r.Checks, PublishCheckResult{Name: name, Passed: len(problems) == 0, Problems: problems})
+	if len(problems) > 0 {
+		r.Passed = false
+	}
+}
+
+// Failed returns the names of the checks that did not pass.
+func (r *PublishReport) Failed() []string {
+	var failed []string
+	for _, check := range r.Checks {
+		if !check.Passed {
+			failed = append(failed, string(check.Name))
+		}
+	}
+	return failed
+}
+
+// ParsePublishChecks parses a list of check names, as stored in the workflow metadata.
+// Checks are returned in run order without duplicates.
+func ParsePublishChecks(raw any) ([]PublishCheck, error) {
+	var names []string
+	switch v := raw.(type) {
+	case nil:
+		return nil, nil
+	case []string:
+		names = v
+	case []any:
+		for _, item := range v {
+			name, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("check names must be strings")
+			}
+			names = append(names, name)
+		}
+	default:
+		return nil, fmt.Errorf("must be a list of check names")
+	}
+
+	requested := make(map[PublishCheck]bool, len(names))
+	for _, name := range names {
+		check := PublishCheck(strings.TrimSpace(name))
+		if !isKnownPublishCheck(check) {
+			return nil, fmt.Errorf("unknown publish check %q", name)
+		}
+		requested[check] = true
+	}
+
+	var checks []PublishCheck
+	for _, check := range PublishChecks {
+		if requested[check] {
+			checks = append(checks, check)
+		}
+	}
+	return checks, nil
+}
+
+func isKnownPublishCheck(check PublishCheck) bool {
+	for _, known := range PublishChecks {
+		if check == known {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiredPublishChecks returns the checks the workflow metadata requires before publishing.
+func (w *Workflow) RequiredPublishChecks() []PublishCheck {
+	checks, _ := ParsePublishChecks(w.Metadata[MetadataPublishChecks])
+	return checks
+}
+
+// CheckInputSchema reports problems with the input schema in the workflow metadata.
+func (w *Workflow) CheckInputSchema() []string {
+	raw, ok := w.Metadata[MetadataInputSchema]
+	if !ok || raw == nil {
+		return []string{"metadata." + MetadataInputSchema + " is missing"}
+	}
+	schema, ok := raw.(map[string]any)
+	if !ok {
+		return []string{"metadata." + MetadataInputSchema + " must be a JSON schema object"}
+	}
+	if _, hasType := schema["type"]; !hasType {
+		if _, hasProperties := schema["properties"]; !hasProperties {
+			return []string{"metadata." + MetadataInputSchema + " must declare a type or properties"}
+		}
+	}
+	return nil
+}
+
+// secretKeySuffixes are config key endings, compared without case, underscores or
+// hyphens, that mark a value as a credential.
+var secretKeySuffixes = []string{
+	"password", "passwd", "secret", "apikey", "token", "privatekey", "accesskey", "secretkey", "authorization",
+}
+
+// secretValuePatterns match well-known credential formats wherever they appear.
+var secretValuePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{20,}`),                 // OpenAI / Anthropic keys
+	regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`),                    // AWS access key IDs
+	regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}`),            // GitHub tokens
+	regexp.MustCompile(`\bxox[abpr]-[A-Za-z0-9-]{10,}`),           // Slack tokens
+	regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}`),                 // Google API keys
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`),      // PEM private keys
+	regexp.MustCompile(`\b[0-9]{8,10}:[A-Za-z0-9_-]{35}\b`),       // Telegram bot tokens
+	regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._~+/-]{20,}=*`), // Literal bearer tokens
+}
+
+// FindPlaintextSecrets returns the paths of node config values, variables and launch
+// profile environments that look like credentials written in plain text. Values that
+// contain a template reference, such as {{env.API_KEY}}, are not reported.
+func (w *Workflow) FindPlaintextSecrets() []string {
+	var found []string
+	for _, node := range w.Nodes {
+		found = appendPlaintextSecrets(found, "nodes."+node.ID+".config", "", node.Config)
+	}
+	found = appendPlaintextSecrets(found, "variables", "", w.Variables)
+	for name, profile := range w.LaunchProfiles {
+		if profile != nil {
+			found = appendPlaintextSecrets(found, "launch_profiles."+name+".environment", "", profile.Environment)
+		}
+	}
+	sort.Strings(found)
+	return found
+}
+
+func appendPlaintextSecrets(found []string, path, key string, value any) []string {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, item := range v {
+			found = appendPlaintextSecrets(found, path+"."+k, k, item)
+		}
+	case []any:
+		for i, item := range v {
+			found = appendPlaintextSecrets(found, fmt.Sprintf("%s[%d]", path, i), key, item)
+		}
+	case map[string]string:
+		for k, item := range v {
+			found = appendPlaintextSecrets(found, path+"."+k, k, item)
+		}
+	case string:
+		if looksLikePlaintextSecret(key, v) {
+			found = append(found, path)
+		}
+	}
+	return found
+}
+
+func looksLikePlaintextSecret(key, value string) bool {
+	if strings.TrimSpace(value) == "" || strings.Contains(value, "{{") {
+		return false
+	}
+	normalized := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+	for _, suffix := range secretKeySuffixes {
+		if strings.HasSuffix(normalized, suffix) {
+			return true
+		}
+	}
+	for _, pattern := range secretValuePatterns {
+		if pattern.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
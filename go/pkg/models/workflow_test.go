@@ -1,6 +1,7 @@
 package models
 
 import (
+	"errors"
 	"testing"
 	"time"
 )
@@ -156,6 +157,19 @@ func TestNode_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "node type is required",
 		},
+		{
+			name: "invalid assertion action",
+			node: &Node{
+				ID:   "node-1",
+				Name: "Test Node",
+				Type: "http",
+				Config: map[string]any{
+					"assertions": []any{map[string]any{"expression": "output != nil", "action": "ignore"}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "action must be one of",
+		},
 	}
 
 	for _, tt := range tests {
@@ -707,6 +721,109 @@ func TestWorkflowStatus_Values(t *testing.T) {
 	}
 }
 
+func TestValidateLaunchProfiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		profiles map[string]*LaunchProfile
+		wantErr  bool
+	}{
+		{
+			name:     "nil profiles",
+			profiles: nil,
+			wantErr:  false,
+		},
+		{
+			name: "valid profiles",
+			profiles: map[string]*LaunchProfile{
+				"nightly":    {Input: map[string]any{"mode": "full"}, MaxParallelism: 4, TimeoutSeconds: 3600},
+				"smoke_test": {},
+				"eu-west":    {Environment: map[string]any{"region": "eu-west-1"}},
+			},
+			wantErr: false,
+		},
+		{
+			name:     "name starting with digit",
+			profiles: map[string]*LaunchProfile{"1st": {}},
+			wantErr:  true,
+		},
+		{
+			name:     "name with spaces",
+			profiles: map[string]*LaunchProfile{"night run": {}},
+			wantErr:  true,
+		},
+		{
+			name:     "nil profile",
+			profiles: map[string]*LaunchProfile{"nightly": nil},
+			wantErr:  true,
+		},
+		{
+			name:     "negative parallelism",
+			profiles: map[string]*LaunchProfile{"nightly": {MaxParallelism: -1}},
+			wantErr:  true,
+		},
+		{
+			name:     "negative timeout",
+			profiles: map[string]*LaunchProfile{"nightly": {TimeoutSeconds: -5}},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLaunchProfiles(tt.profiles)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateLaunchProfiles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWorkflow_GetLaunchProfile(t *testing.T) {
+	workflow := &Workflow{
+		LaunchProfiles: map[string]*LaunchProfile{
+			"nightly": {Input: map[string]any{"mode": "full"}},
+		},
+	}
+
+	profile, err := workflow.GetLaunchProfile("nightly")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if profile.Input["mode"] != "full" {
+		t.Errorf("expected nightly profile, got %+v", profile)
+	}
+
+	_, err = workflow.GetLaunchProfile("weekly")
+	if !errors.Is(err, ErrLaunchProfileNotFound) {
+		t.Errorf("expected ErrLaunchProfileNotFound, got %v", err)
+	}
+}
+
+func TestLaunchProfile_MergeInput(t *testing.T) {
+	profile := &LaunchProfile{
+		Input: map[string]any{"mode": "full", "limit": 100},
+	}
+
+	merged := profile.MergeInput(map[string]any{"limit": 10, "dry_run": true})
+
+	expected := map[string]any{"mode": "full", "limit": 10, "dry_run": true}
+	if len(merged) != len(expected) {
+		t.Fatalf("expected %d keys, got %d: %v", len(expected), len(merged), merged)
+	}
+	for k, v := range expected {
+		if merged[k] != v {
+			t.Errorf("expected %s=%v, got %v", k, v, merged[k])
+		}
+	}
+	if profile.Input["limit"] != 100 {
+		t.Error("profile input must not be modified")
+	}
+
+	if got := profile.MergeInput(nil); len(got) != 2 {
+		t.Errorf("expected profile input for nil input, got %v", got)
+	}
+}
+
 // Helper function
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
@@ -721,3 +838,33 @@ func indexOf(s, substr string) int {
 	}
 	return -1
 }
+
+func TestWorkflow_NumberMode(t *testing.T) {
+	newWorkflow := func(metadata map[string]any) *Workflow {
+		return &Workflow{
+			Name:     "Payments",
+			Nodes:    []*Node{{ID: "node-1", Name: "Node 1", Type: "http", Config: map[string]any{}}},
+			Metadata: metadata,
+		}
+	}
+
+	if mode := newWorkflow(nil).NumberMode(); mode != "" {
+		t.Errorf("expected empty mode, got %q", mode)
+	}
+
+	wf := newWorkflow(map[string]any{MetadataNumberMode: "preserve"})
+	if err := wf.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mode := wf.NumberMode(); mode != NumberModePreserve {
+		t.Errorf("expected %q, got %q", NumberModePreserve, mode)
+	}
+
+	for _, invalid := range []any{"decimal", 1} {
+		err := newWorkflow(map[string]any{MetadataNumberMode: invalid}).Validate()
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != "metadata.json_numbers" {
+			t.Errorf("expected metadata.json_numbers validation error for %v, got %v", invalid, err)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+// Package filestore stores standalone workflows, executions and node events as JSON files.
+//
+// Layout of the store directory:
+//
+//	workflows/<workflow_id>.json
+//	executions/<execution_id>.json
+//	events/<execution_id>.jsonl   (one node event per line, in order)
+//
+// Store implements engine.StandalonePersistence and is safe for concurrent use
+// within one process; it is not meant to be shared by several processes.
+package filestore
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/smilemakc/mbflow/go/pkg/engine"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+var _ engine.StandalonePersistence = (*Store)(nil)
+
+// validID restricts IDs to characters that are safe in file names.
+var validID = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Store is a file-based store for standalone execution history.
+type Store struct {
+	dir string
+
+	mu sync.Mutex // serializes writes
+}
+
+// New creates a store in dir, creating the directory layout if needed.
+func New(dir string) (*Store, error) {
+	for _, sub := range []string{"workflows", "executions", "events"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create store directory: %w", err)
+		}
+	}
+	return &Store{dir: dir}, nil
+}
+
+// SaveWorkflow creates or replaces a workflow.
+func (s *Store) SaveWorkflow(ctx context.Context, workflow *models.Workflow) error {
+	path, err := s.path("workflows", workflow.ID, ".json")
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return writeJSON(path, workflow)
+}
+
+// LoadWorkflow loads a workflow by ID.
+func (s *Store) LoadWorkflow(ctx context.Context, workflowID string) (*models.Workflow, error) {
+	path, err := s.path("workflows", workflowID, ".json")
+	if err != nil {
+		return nil, err
+	}
+	var workflow models.Workflow
+	if err := readJSON(path, &workflow); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", models.ErrWorkflowNotFound, workflowID)
+		}
+		return nil, err
+	}
+	return &workflow, nil
+}
+
+// SaveExecution creates or replaces an execution.
+func (s *Store) SaveExecution(ctx context.Context, execution *models.Execution) error {
+	path, err := s.path("executions", execution.ID, ".json")
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return writeJSON(path, execution)
+}
+
+// LoadExecution loads an execution by ID.
+func (s *Store) LoadExecution(ctx context.Context, executionID string) (*models.Execution, error) {
+	path, err := s.path("executions", executionID, ".json")
+	if err != nil {
+		return nil, err
+	}
+	var execution models.Execution
+	if err := readJSON(path, &execution); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", models.ErrExecutionNotFound, executionID)
+		}
+		return nil, err
+	}
+	return &execution, nil
+}
+
+// ListExecutions returns the executions of a workflow (all executions if workflowID is empty),
+// most recently started first. A limit of zero or less returns all of them.
+func (s *Store) ListExecutions(ctx context.Context, workflowID string, limit int) ([]*models.Execution, error) {
+	entries, err := os.ReadDir(filepath.Join(s.dir, "executions"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list executions: %w", err)
+	}
+
+	executions := make([]*models.Execution, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		var execution models.Execution
+		if err := readJSON(filepath.Join(s.dir, "executions", entry.Name()), &execution); err != nil {
+			return nil, err
+		}
+		if workflowID == "" || execution.WorkflowID == workflowID {
+			executions = append(executions, &execution)
+		}
+	}
+
+	sort.Slice(executions, func(i, j int) bool {
+		return executions[i].StartedAt.After(executions[j].StartedAt)
+	})
+	if limit > 0 && len(executions) > limit {
+		executions = executions[:limit]
+	}
+	return executions, nil
+}
+
+// SaveNodeEvent appends a node event to the execution's event log.
+func (s *Store) SaveNodeEvent(ctx context.Context, event *models.Event) error {
+	path, err := s.path("events", event.ExecutionID, ".jsonl")
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open event log: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+	return f.Close()
+}
+
+// ListNodeEvents returns the node events of an execution in the order they were saved.
+func (s *Store) ListNodeEvents(ctx context.Context, executionID string) ([]*models.Event, error) {
+	path, err := s.path("events", executionID, ".jsonl")
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []*models.Event{}, nil
+		}
+		return nil, fmt.Errorf("failed to open event log: %w", err)
+	}
+	defer f.Close()
+
+	events := make([]*models.Event, 0)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		var event models.Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("failed to decode event: %w", err)
+		}
+		events = append(events, &event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read event log: %w", err)
+	}
+	return events, nil
+}
+
+// path returns the file path for an ID, rejecting IDs that are not safe file names.
+func (s *Store) path(kind, id, ext string) (string, error) {
+	if !validID.MatchString(id) {
+		return "", fmt.Errorf("invalid %s id: %q", strings.TrimSuffix(kind, "s"), id)
+	}
+	return filepath.Join(s.dir, kind, id+ext), nil
+}
+
+// writeJSON writes v to path atomically, so readers never see a partial file.
+func writeJSON(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", filepath.Base(path), err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+func readJSON(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
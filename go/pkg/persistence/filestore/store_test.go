@@ -0,0 +1,85 @@
+package filestore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smilemakc/mbflow/go/pkg/engine"
+	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/executor/builtin"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+func TestStore_Workflows(t *testing.T) {
+	store, err := New(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	workflow := &models.Workflow{ID: "wf-1", Name: "Report", Nodes: []*models.Node{{ID: "a", Name: "A", Type: "transform"}}}
+	require.NoError(t, store.SaveWorkflow(ctx, workflow))
+
+	loaded, err := store.LoadWorkflow(ctx, "wf-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Report", loaded.Name)
+	assert.Len(t, loaded.Nodes, 1)
+
+	_, err = store.LoadWorkflow(ctx, "missing")
+	assert.True(t, errors.Is(err, models.ErrWorkflowNotFound))
+
+	_, err = store.LoadWorkflow(ctx, "../etc/passwd")
+	assert.ErrorContains(t, err, "invalid workflow id")
+}
+
+func TestStore_StandaloneHistory(t *testing.T) {
+	store, err := New(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	manager := executor.NewManager()
+	require.NoError(t, builtin.RegisterBuiltins(manager))
+	standalone := engine.NewPersistentStandaloneExecutor(manager, store)
+
+	workflow := &models.Workflow{
+		ID:   "wf-1",
+		Name: "Double",
+		Nodes: []*models.Node{
+			{ID: "double", Name: "Double", Type: "transform", Config: map[string]any{"type": "expression", "expression": "input.n * 2"}},
+		},
+	}
+
+	execution, err := standalone.ExecuteStandalone(ctx, workflow, map[string]any{"n": 21}, nil)
+	require.NoError(t, err)
+
+	stored, err := store.LoadExecution(ctx, execution.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionStatusCompleted, stored.Status)
+	assert.Len(t, stored.NodeExecutions, 1)
+
+	events, err := store.ListNodeEvents(ctx, execution.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "node.started", events[0].EventType)
+	assert.Equal(t, "node.completed", events[1].EventType)
+	assert.Equal(t, "double", events[1].Payload["node_id"])
+	assert.Less(t, events[0].Sequence, events[1].Sequence)
+
+	older := &models.Execution{ID: "exec-old", WorkflowID: "wf-1", Status: models.ExecutionStatusFailed, StartedAt: time.Now().Add(-time.Hour)}
+	other := &models.Execution{ID: "exec-other", WorkflowID: "wf-2", Status: models.ExecutionStatusCompleted, StartedAt: time.Now()}
+	require.NoError(t, store.SaveExecution(ctx, older))
+	require.NoError(t, store.SaveExecution(ctx, other))
+
+	executions, err := store.ListExecutions(ctx, "wf-1", 0)
+	require.NoError(t, err)
+	require.Len(t, executions, 2)
+	assert.Equal(t, execution.ID, executions[0].ID)
+	assert.Equal(t, "exec-old", executions[1].ID)
+
+	executions, err = store.ListExecutions(ctx, "", 1)
+	require.NoError(t, err)
+	assert.Len(t, executions, 1)
+}
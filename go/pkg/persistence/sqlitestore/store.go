@@ -0,0 +1,237 @@
+// Package sqlitestore stores standalone workflows, executions and node events in SQLite.
+//
+// Store implements engine.StandalonePersistence. Open uses the github.com/mattn/go-sqlite3
+// driver, which requires cgo; New accepts a database opened with any SQLite driver.
+package sqlitestore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/smilemakc/mbflow/go/pkg/engine"
+	"github.com/smilemakc/mbflow/go/pkg/models"
+)
+
+var _ engine.StandalonePersistence = (*Store)(nil)
+
+// schema is applied by New; statements are idempotent.
+const schema = `
+CREATE TABLE IF NOT EXISTS mbflow_workflows (
+	id         TEXT PRIMARY KEY,
+	data       TEXT NOT NULL,
+	updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS mbflow_executions (
+	id          TEXT PRIMARY KEY,
+	workflow_id TEXT NOT NULL,
+	status      TEXT NOT NULL,
+	started_at  TIMESTAMP NOT NULL,
+	data        TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_mbflow_executions_workflow_started
+	ON mbflow_executions (workflow_id, started_at DESC);
+
+CREATE TABLE IF NOT EXISTS mbflow_node_events (
+	id           TEXT PRIMARY KEY,
+	execution_id TEXT NOT NULL,
+	sequence     INTEGER NOT NULL,
+	event_type   TEXT NOT NULL,
+	data         TEXT NOT NULL,
+	created_at   TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_mbflow_node_events_execution
+	ON mbflow_node_events (execution_id, sequence);
+`
+
+// Store is a SQLite store for standalone execution history.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens (or creates) the SQLite database at path and prepares the schema.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite3", "file:"+path+"?_busy_timeout=5000&_journal_mode=WAL&_foreign_keys=on")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+	// SQLite allows a single writer; one connection avoids SQLITE_BUSY between writers
+	db.SetMaxOpenConns(1)
+
+	store, err := New(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// New creates a store on an open SQLite database and prepares the schema.
+func New(db *sql.DB) (*Store, error) {
+	if _, err := db.Exec(schema); err != nil {
+		return nil, fmt.Errorf("failed to create sqlite schema: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// SaveWorkflow creates or replaces a workflow.
+func (s *Store) SaveWorkflow(ctx context.Context, workflow *models.Workflow) error {
+	data, err := json.Marshal(workflow)
+	if err != nil {
+		return fmt.Errorf("failed to encode workflow: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO mbflow_workflows (id, data, updated_at) VALUES (?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET data = excluded.data, updated_at = excluded.updated_at`,
+		workflow.ID, string(data), time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to save workflow: %w", err)
+	}
+	return nil
+}
+
+// LoadWorkflow loads a workflow by ID.
+func (s *Store) LoadWorkflow(ctx context.Context, workflowID string) (*models.Workflow, error) {
+	var data string
+	err := s.db.QueryRowContext(ctx, `SELECT data FROM mbflow_workflows WHERE id = ?`, workflowID).Scan(&data)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", models.ErrWorkflowNotFound, workflowID)
+		}
+		return nil, fmt.Errorf("failed to load workflow: %w", err)
+	}
+
+	var workflow models.Workflow
+	if err := json.Unmarshal([]byte(data), &workflow); err != nil {
+		return nil, fmt.Errorf("failed to decode workflow: %w", err)
+	}
+	return &workflow, nil
+}
+
+// SaveExecution creates or replaces an execution.
+func (s *Store) SaveExecution(ctx context.Context, execution *models.Execution) error {
+	data, err := json.Marshal(execution)
+	if err != nil {
+		return fmt.Errorf("failed to encode execution: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO mbflow_executions (id, workflow_id, status, started_at, data) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET status = excluded.status, data = excluded.data`,
+		execution.ID, execution.WorkflowID, string(execution.Status), execution.StartedAt.UTC(), string(data))
+	if err != nil {
+		return fmt.Errorf("failed to save execution: %w", err)
+	}
+	return nil
+}
+
+// LoadExecution loads an execution by ID.
+func (s *Store) LoadExecution(ctx context.Context, executionID string) (*models.Execution, error) {
+	var data string
+	err := s.db.QueryRowContext(ctx, `SELECT data FROM mbflow_executions WHERE id = ?`, executionID).Scan(&data)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", models.ErrExecutionNotFound, executionID)
+		}
+		return nil, fmt.Errorf("failed to load execution: %w", err)
+	}
+
+	var execution models.Execution
+	if err := json.Unmarshal([]byte(data), &execution); err != nil {
+		return nil, fmt.Errorf("failed to decode execution: %w", err)
+	}
+	return &execution, nil
+}
+
+// ListExecutions returns the executions of a workflow (all executions if workflowID is empty),
+// most recently started first. A limit of zero or less returns all of them.
+func (s *Store) ListExecutions(ctx context.Context, workflowID string, limit int) ([]*models.Execution, error) {
+	if limit <= 0 {
+		limit = -1 // no limit in SQLite
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT data FROM mbflow_executions
+		WHERE ? = '' OR workflow_id = ?
+		ORDER BY started_at DESC
+		LIMIT ?`, workflowID, workflowID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list executions: %w", err)
+	}
+	defer rows.Close()
+
+	executions := make([]*models.Execution, 0)
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to list executions: %w", err)
+		}
+		var execution models.Execution
+		if err := json.Unmarshal([]byte(data), &execution); err != nil {
+			return nil, fmt.Errorf("failed to decode execution: %w", err)
+		}
+		executions = append(executions, &execution)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list executions: %w", err)
+	}
+	return executions, nil
+}
+
+// SaveNodeEvent appends a node event of an execution.
+func (s *Store) SaveNodeEvent(ctx context.Context, event *models.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO mbflow_node_events (id, execution_id, sequence, event_type, data, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		event.ID, event.ExecutionID, event.Sequence, event.EventType, string(data), event.CreatedAt.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to save event: %w", err)
+	}
+	return nil
+}
+
+// ListNodeEvents returns the node events of an execution in order.
+func (s *Store) ListNodeEvents(ctx context.Context, executionID string) ([]*models.Event, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT data FROM mbflow_node_events WHERE execution_id = ? ORDER BY sequence`, executionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]*models.Event, 0)
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to list events: %w", err)
+		}
+		var event models.Event
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			return nil, fmt.Errorf("failed to decode event: %w", err)
+		}
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	return events, nil
+}
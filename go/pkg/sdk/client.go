@@ -84,6 +84,12 @@ type ClientConfig struct {
 	// Observer configuration (for real-time event notifications)
 	ObserverManager engine.ObserverManager
 
+	// NodeHooks are invoked around every node executed by the embedded engine
+	NodeHooks []engine.NodeHook
+
+	// Persistence optionally stores standalone executions and provides stored workflows
+	Persistence engine.StandalonePersistence
+
 	// Logging
 	Logger Logger
 }
@@ -259,7 +265,11 @@ func (c *Client) initializeEmbedded() error {
 	}
 
 	// Create standalone executor for in-memory workflow execution
-	c.standaloneExecutor = engine.NewStandaloneExecutor(c.executorManager)
+	if c.config.Persistence != nil {
+		c.standaloneExecutor = engine.NewPersistentStandaloneExecutor(c.executorManager, c.config.Persistence, c.config.NodeHooks...)
+	} else {
+		c.standaloneExecutor = engine.NewStandaloneExecutor(c.executorManager, c.config.NodeHooks...)
+	}
 
 	// Set observer manager if provided
 	if c.config.ObserverManager != nil {
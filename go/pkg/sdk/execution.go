@@ -123,6 +123,59 @@ func (e *ExecutionAPI) Cancel(ctx context.Context, executionID string) error {
 	return e.cancelEmbedded(ctx, executionID)
 }
 
+// Pause stops a running execution at the next node boundary: its running nodes finish and
+// it is saved as paused with a checkpoint of its state until Resume continues it.
+func (e *ExecutionAPI) Pause(ctx context.Context, executionID string) error {
+	if err := e.client.checkClosed(); err != nil {
+		return err
+	}
+
+	if executionID == "" {
+		return models.ErrInvalidExecutionID
+	}
+
+	if e.client.config.Mode == ModeRemote {
+		return e.pauseRemote(ctx, executionID)
+	}
+
+	return e.pauseEmbedded(ctx, executionID)
+}
+
+// Resume continues a paused execution from its checkpoint in the background.
+func (e *ExecutionAPI) Resume(ctx context.Context, executionID string) (*models.Execution, error) {
+	if err := e.client.checkClosed(); err != nil {
+		return nil, err
+	}
+
+	if executionID == "" {
+		return nil, models.ErrInvalidExecutionID
+	}
+
+	if e.client.config.Mode == ModeRemote {
+		return e.resumeRemote(ctx, executionID)
+	}
+
+	return e.resumeEmbedded(ctx, executionID)
+}
+
+// ResumeFailed continues a failed execution in the background from the nodes that did not
+// complete; completed nodes keep their outputs and are not run again.
+func (e *ExecutionAPI) ResumeFailed(ctx context.Context, executionID string) (*models.Execution, error) {
+	if err := e.client.checkClosed(); err != nil {
+		return nil, err
+	}
+
+	if executionID == "" {
+		return nil, models.ErrInvalidExecutionID
+	}
+
+	if e.client.config.Mode == ModeRemote {
+		return e.resumeFailedRemote(ctx, executionID)
+	}
+
+	return e.resumeFailedEmbedded(ctx, executionID)
+}
+
 // Retry retries a failed execution from the last failed node.
 func (e *ExecutionAPI) Retry(ctx context.Context, executionID string) (*models.Execution, error) {
 	if err := e.client.checkClosed(); err != nil {
@@ -285,6 +338,18 @@ func (e *ExecutionAPI) cancelEmbedded(ctx context.Context, executionID string) e
 	return errStandaloneModeNotSupported
 }
 
+func (e *ExecutionAPI) pauseEmbedded(ctx context.Context, executionID string) error {
+	return errStandaloneModeNotSupported
+}
+
+func (e *ExecutionAPI) resumeEmbedded(ctx context.Context, executionID string) (*models.Execution, error) {
+	return nil, errStandaloneModeNotSupported
+}
+
+func (e *ExecutionAPI) resumeFailedEmbedded(ctx context.Context, executionID string) (*models.Execution, error) {
+	return nil, errStandaloneModeNotSupported
+}
+
 func (e *ExecutionAPI) retryEmbedded(ctx context.Context, executionID string) (*models.Execution, error) {
 	return nil, errStandaloneModeNotSupported
 }
@@ -433,6 +498,66 @@ func (e *ExecutionAPI) cancelRemote(ctx context.Context, executionID string) err
 	return fmt.Errorf("execution cancellation not yet implemented")
 }
 
+func (e *ExecutionAPI) pauseRemote(ctx context.Context, executionID string) error {
+	resp, err := e.postRemote(ctx, executionID, "pause")
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (e *ExecutionAPI) resumeRemote(ctx context.Context, executionID string) (*models.Execution, error) {
+	return e.postRemoteExecution(ctx, executionID, "resume")
+}
+
+func (e *ExecutionAPI) resumeFailedRemote(ctx context.Context, executionID string) (*models.Execution, error) {
+	return e.postRemoteExecution(ctx, executionID, "resume-failed")
+}
+
+// postRemoteExecution posts an action on an execution and decodes the execution it returns.
+func (e *ExecutionAPI) postRemoteExecution(ctx context.Context, executionID, action string) (*models.Execution, error) {
+	resp, err := e.postRemote(ctx, executionID, action)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var execution models.Execution
+	if err := json.NewDecoder(resp.Body).Decode(&execution); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &execution, nil
+}
+
+// postRemote posts an action on an execution and returns the accepted response.
+func (e *ExecutionAPI) postRemote(ctx context.Context, executionID, action string) (*http.Response, error) {
+	u := fmt.Sprintf("%s/api/v1/executions/%s/%s", e.client.config.BaseURL, executionID, action)
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if e.client.config.APIKey != "" {
+		httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", e.client.config.APIKey))
+	}
+
+	resp, err := e.client.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(respBody))
+	}
+
+	return resp, nil
+}
+
 func (e *ExecutionAPI) retryRemote(ctx context.Context, executionID string) (*models.Execution, error) {
 	// Deferred for MVP
 	return nil, fmt.Errorf("execution retry not yet implemented")
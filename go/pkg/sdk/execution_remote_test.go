@@ -294,6 +294,120 @@ func TestExecutionAPI_RetryRemote_Success(t *testing.T) {
 	assert.Equal(t, models.ExecutionStatusPending, exec.Status)
 }
 
+// TestExecutionAPI_PauseRemote_Success tests successful remote execution pause
+func TestExecutionAPI_PauseRemote_Success(t *testing.T) {
+	server := httptest.NewServer(withHealthCheck(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/v1/executions/exec-123/pause", r.URL.Path)
+		assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"execution_id": "exec-123"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(
+		WithHTTPEndpoint(server.URL),
+		WithAPIKey("test-key"),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+
+	err = client.Executions().Pause(context.Background(), "exec-123")
+
+	assert.NoError(t, err)
+}
+
+// TestExecutionAPI_ResumeRemote_Success tests successful remote execution resume
+func TestExecutionAPI_ResumeRemote_Success(t *testing.T) {
+	server := httptest.NewServer(withHealthCheck(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/v1/executions/exec-123/resume", r.URL.Path)
+
+		execution := &models.Execution{
+			ID:         "exec-123",
+			WorkflowID: "wf-456",
+			Status:     models.ExecutionStatusRunning,
+			StartedAt:  time.Now(),
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(execution)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(
+		WithHTTPEndpoint(server.URL),
+		WithAPIKey("test-key"),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+
+	exec, err := client.Executions().Resume(context.Background(), "exec-123")
+
+	require.NoError(t, err)
+	assert.Equal(t, "exec-123", exec.ID)
+	assert.Equal(t, models.ExecutionStatusRunning, exec.Status)
+}
+
+// TestExecutionAPI_ResumeRemote_NotPaused tests remote resume of an execution that is not paused
+func TestExecutionAPI_ResumeRemote_NotPaused(t *testing.T) {
+	server := httptest.NewServer(withHealthCheck(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"code": "EXECUTION_NOT_PAUSED", "message": "Execution is not paused"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(
+		WithHTTPEndpoint(server.URL),
+		WithAPIKey("test-key"),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+
+	exec, err := client.Executions().Resume(context.Background(), "exec-123")
+
+	assert.Error(t, err)
+	assert.Nil(t, exec)
+	assert.Contains(t, err.Error(), "409")
+}
+
+// TestExecutionAPI_ResumeFailedRemote_Success tests successful remote resume of a failed execution
+func TestExecutionAPI_ResumeFailedRemote_Success(t *testing.T) {
+	server := httptest.NewServer(withHealthCheck(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/v1/executions/exec-123/resume-failed", r.URL.Path)
+
+		execution := &models.Execution{
+			ID:         "exec-123",
+			WorkflowID: "wf-456",
+			Status:     models.ExecutionStatusRunning,
+			StartedAt:  time.Now(),
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(execution)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(
+		WithHTTPEndpoint(server.URL),
+		WithAPIKey("test-key"),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+
+	exec, err := client.Executions().ResumeFailed(context.Background(), "exec-123")
+
+	require.NoError(t, err)
+	assert.Equal(t, "exec-123", exec.ID)
+	assert.Equal(t, models.ExecutionStatusRunning, exec.Status)
+}
+
 // TestExecutionAPI_WatchRemote_Success tests successful remote execution watch
 func TestExecutionAPI_WatchRemote_Success(t *testing.T) {
 	t.Skip("Watch remote implementation not yet complete")
@@ -120,6 +120,58 @@ func WithExecutorManager(manager executor.Manager) ClientOption {
 	}
 }
 
+// WithNodeHooks registers engine hooks invoked before and after every node
+// executed by the embedded engine. Hooks can enforce policies, rewrite
+// input or output, short-circuit nodes and annotate node executions.
+//
+// Example:
+//
+//	residency := engine.NodeHookFuncs{
+//	    Before: func(ctx context.Context, hc *engine.NodeHookContext) error {
+//	        if hc.Node.Type == "http" && !allowedHost(hc.Config["url"]) {
+//	            return errors.New("destination outside the EU")
+//	        }
+//	        hc.Annotate("residency", "eu")
+//	        return nil
+//	    },
+//	}
+//
+//	client, err := sdk.NewClient(sdk.WithStandaloneMode(), sdk.WithNodeHooks(residency))
+func WithNodeHooks(hooks ...engine.NodeHook) ClientOption {
+	return func(c *ClientConfig) error {
+		for _, hook := range hooks {
+			if hook == nil {
+				return fmt.Errorf("node hook cannot be nil")
+			}
+		}
+		c.NodeHooks = append(c.NodeHooks, hooks...)
+		return nil
+	}
+}
+
+// WithPersistence stores standalone executions and their node events in the given
+// persistence, and loads stored workflows (including sub-workflows) from it.
+// Use filestore or sqlitestore from pkg/persistence, or a custom implementation.
+//
+// Example:
+//
+//	store, err := sqlitestore.Open("mbflow.db")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer store.Close()
+//
+//	client, err := sdk.NewStandaloneClient(sdk.WithPersistence(store))
+func WithPersistence(persistence engine.StandalonePersistence) ClientOption {
+	return func(c *ClientConfig) error {
+		if persistence == nil {
+			return fmt.Errorf("persistence cannot be nil")
+		}
+		c.Persistence = persistence
+		return nil
+	}
+}
+
 // WithLogger sets a custom logger for the client.
 func WithLogger(logger Logger) ClientOption {
 	return func(c *ClientConfig) error {
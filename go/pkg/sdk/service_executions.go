@@ -53,6 +53,17 @@ func (a *ServiceExecutionsAPI) Start(ctx context.Context, workflowID string, inp
 	return decodeResponse[models.Execution](resp)
 }
 
+// StartPartial starts an execution that runs only the selected nodes of a workflow.
+// The outputs of the nodes feeding the selection are taken from selection.BoundaryOutputs.
+func (a *ServiceExecutionsAPI) StartPartial(ctx context.Context, workflowID string, input map[string]any, selection *models.NodeSelection, callOpts ...CallOption) (*models.Execution, error) {
+	body := map[string]any{"input": input, "selection": selection}
+	resp, err := a.client.doRequest(ctx, http.MethodPost, "/workflows/"+workflowID+"/execute", body, callOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return decodeResponse[models.Execution](resp)
+}
+
 // Cancel cancels an execution.
 func (a *ServiceExecutionsAPI) Cancel(ctx context.Context, executionID string, callOpts ...CallOption) error {
 	resp, err := a.client.doRequest(ctx, http.MethodPost, "/executions/"+executionID+"/cancel", nil, callOpts...)
@@ -62,6 +73,35 @@ func (a *ServiceExecutionsAPI) Cancel(ctx context.Context, executionID string, c
 	return checkResponse(resp)
 }
 
+// Pause stops a running execution at the next node boundary and saves it as paused with a
+// checkpoint of its state until Resume continues it.
+func (a *ServiceExecutionsAPI) Pause(ctx context.Context, executionID string, callOpts ...CallOption) error {
+	resp, err := a.client.doRequest(ctx, http.MethodPost, "/executions/"+executionID+"/pause", nil, callOpts...)
+	if err != nil {
+		return err
+	}
+	return checkResponse(resp)
+}
+
+// Resume continues a paused execution from its checkpoint in the background.
+func (a *ServiceExecutionsAPI) Resume(ctx context.Context, executionID string, callOpts ...CallOption) (*models.Execution, error) {
+	resp, err := a.client.doRequest(ctx, http.MethodPost, "/executions/"+executionID+"/resume", nil, callOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return decodeResponse[models.Execution](resp)
+}
+
+// ResumeFailed continues a failed execution in the background from the nodes that did not
+// complete; completed nodes keep their outputs and are not run again.
+func (a *ServiceExecutionsAPI) ResumeFailed(ctx context.Context, executionID string, callOpts ...CallOption) (*models.Execution, error) {
+	resp, err := a.client.doRequest(ctx, http.MethodPost, "/executions/"+executionID+"/resume-failed", nil, callOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return decodeResponse[models.Execution](resp)
+}
+
 // Retry retries an execution.
 func (a *ServiceExecutionsAPI) Retry(ctx context.Context, executionID string, callOpts ...CallOption) error {
 	resp, err := a.client.doRequest(ctx, http.MethodPost, "/executions/"+executionID+"/retry", nil, callOpts...)
@@ -67,6 +67,17 @@ func (a *ServiceWorkflowsAPI) Delete(ctx context.Context, workflowID string, cal
 	return checkResponse(resp)
 }
 
+// Compare replays recorded inputs of a workflow through a baseline and a candidate variant
+// and returns a side-by-side report of output differences, durations and LLM costs.
+// Replays are real executions, so the call takes as long as running every input twice.
+func (a *ServiceWorkflowsAPI) Compare(ctx context.Context, workflowID string, req *ServiceCompareWorkflowRequest, callOpts ...CallOption) (*models.ComparisonReport, error) {
+	resp, err := a.client.doRequest(ctx, http.MethodPost, "/workflows/"+workflowID+"/compare", req, callOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return decodeResponse[models.ComparisonReport](resp)
+}
+
 // ServiceCreateWorkflowRequest defines the request for creating a workflow via Service API.
 type ServiceCreateWorkflowRequest struct {
 	Name        string         `json:"name"`
@@ -109,3 +120,14 @@ type ServiceResourceRequest struct {
 	Alias      string `json:"alias"`
 	AccessType string `json:"access_type"`
 }
+
+// ServiceCompareWorkflowRequest defines the request for comparing two workflow variants via Service API.
+// An empty variant workflow ID refers to the compared workflow; without ExecutionIDs the most
+// recent finished executions are replayed.
+type ServiceCompareWorkflowRequest struct {
+	Baseline     models.ComparisonVariant `json:"baseline"`
+	Candidate    models.ComparisonVariant `json:"candidate"`
+	ExecutionIDs []string                 `json:"execution_ids,omitempty"`
+	SampleSize   int                      `json:"sample_size,omitempty"`
+	IgnorePaths  []string                 `json:"ignore_paths,omitempty"`
+}
@@ -233,8 +233,12 @@ func (w *WorkflowAPI) createEmbedded(ctx context.Context, workflow *models.Workf
 	return workflow, nil
 }
 
+// getEmbedded loads a workflow from the configured persistence, if any.
 func (w *WorkflowAPI) getEmbedded(ctx context.Context, workflowID string) (*models.Workflow, error) {
-	return nil, errWorkflowPersistenceNotAvailable
+	if w.client.config.Persistence == nil {
+		return nil, errWorkflowPersistenceNotAvailable
+	}
+	return w.client.config.Persistence.LoadWorkflow(ctx, workflowID)
 }
 
 func (w *WorkflowAPI) listEmbedded(ctx context.Context, opts *ListOptions) ([]*models.Workflow, error) {
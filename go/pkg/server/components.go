@@ -3,22 +3,33 @@ package server
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
+	"github.com/smilemakc/mbflow/go/internal/application/analytics"
 	"github.com/smilemakc/mbflow/go/internal/application/auth"
+	"github.com/smilemakc/mbflow/go/internal/application/canary"
+	"github.com/smilemakc/mbflow/go/internal/application/coldstorage"
+	"github.com/smilemakc/mbflow/go/internal/application/deadletter"
 	"github.com/smilemakc/mbflow/go/internal/application/engine"
 	"github.com/smilemakc/mbflow/go/internal/application/filestorage"
+	"github.com/smilemakc/mbflow/go/internal/application/incident"
 	"github.com/smilemakc/mbflow/go/internal/application/observer"
+	"github.com/smilemakc/mbflow/go/internal/application/quota"
 	"github.com/smilemakc/mbflow/go/internal/application/rentalkey"
+	"github.com/smilemakc/mbflow/go/internal/application/serviceapi"
 	"github.com/smilemakc/mbflow/go/internal/application/servicekey"
 	"github.com/smilemakc/mbflow/go/internal/application/systemkey"
 	"github.com/smilemakc/mbflow/go/internal/application/trigger"
 	"github.com/smilemakc/mbflow/go/internal/infrastructure/api/rest"
 	"github.com/smilemakc/mbflow/go/internal/infrastructure/cache"
 	"github.com/smilemakc/mbflow/go/internal/infrastructure/storage"
+	"github.com/smilemakc/mbflow/go/pkg/credentials"
 	"github.com/smilemakc/mbflow/go/pkg/crypto"
+	pkgengine "github.com/smilemakc/mbflow/go/pkg/engine"
 	"github.com/smilemakc/mbflow/go/pkg/executor"
 	"github.com/smilemakc/mbflow/go/pkg/executor/builtin"
+	"github.com/smilemakc/mbflow/go/pkg/models"
 )
 
 func (s *Server) initComponents() error {
@@ -51,6 +62,10 @@ func (s *Server) initComponents() error {
 		s.logger.Warn("Encryption service not available - credentials and rental keys features disabled", "error", err)
 	}
 
+	if err := s.initCredentialExecutors(); err != nil {
+		return fmt.Errorf("failed to initialize credential executors: %w", err)
+	}
+
 	if err := s.initAuthSystem(); err != nil {
 		return fmt.Errorf("failed to initialize auth system: %w", err)
 	}
@@ -63,10 +78,34 @@ func (s *Server) initComponents() error {
 		return fmt.Errorf("failed to initialize execution engine: %w", err)
 	}
 
+	if err := s.initExecutionQueue(); err != nil {
+		return fmt.Errorf("failed to initialize execution queue: %w", err)
+	}
+
+	s.initExecutorHealth()
+	s.initPreemption()
+	s.initConcurrencyLimiter()
+	s.initQuotas()
+	s.initIdempotency()
+
 	if err := s.initTriggerManager(); err != nil {
 		s.logger.Warn("Failed to initialize trigger manager", "error", err)
 	}
 
+	s.initIncidentService()
+	s.initDeadLetters()
+
+	if err := s.initCanaryService(); err != nil {
+		s.logger.Warn("Failed to start canary scheduler", "error", err)
+	}
+
+	s.initStatsRollup()
+	s.initPayloadArchive()
+	s.initExecutionArchive()
+	s.initDelayResumer()
+	s.initCrashRecovery()
+	s.initExecutionWorker()
+
 	return nil
 }
 
@@ -111,18 +150,172 @@ func (s *Server) initExecutorManager() error {
 		return fmt.Errorf("failed to register built-in executors: %w", err)
 	}
 
+	if cfg := s.config.ScriptPython; cfg.Enabled {
+		err := builtin.RegisterScriptPython(s.execution.ExecutorManager, builtin.ScriptPythonOptions{
+			Runtime:       cfg.Runtime,
+			Interpreter:   cfg.Interpreter,
+			DockerBinary:  cfg.DockerBinary,
+			Image:         cfg.Image,
+			AllowedImages: cfg.AllowedImages,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to register script_python executor: %w", err)
+		}
+	}
+
+	s.initLLMCache()
+	s.initBatchStore()
+	s.initRateLimitStore()
+	s.initCircuitBreakers()
+
 	s.logger.Info("Registered executors", "types", s.execution.ExecutorManager.List())
 	return nil
 }
 
+// initLLMCache gives the llm executor the response cache used by nodes with caching enabled.
+// The redis backend falls back to memory when Redis is not available.
+func (s *Server) initLLMCache() {
+	cfg := s.config.LLMCache
+	if cfg.Backend == "" {
+		return
+	}
+	llmExec, err := s.execution.ExecutorManager.Get("llm")
+	if err != nil {
+		return
+	}
+	llm, ok := llmExec.(*builtin.LLMExecutor)
+	if !ok {
+		return
+	}
+
+	if cfg.Backend == "redis" {
+		if s.data.RedisCache != nil {
+			llm.SetResponseCache(cache.NewLLMResponseStore(s.data.RedisCache), cfg.TTL)
+			s.logger.Info("LLM response cache enabled", "backend", "redis", "ttl", cfg.TTL)
+			return
+		}
+		s.logger.Warn("Redis not available - LLM response cache falls back to memory")
+	}
+	llm.SetResponseCache(builtin.NewMemoryLLMCache(cfg.MaxEntries), cfg.TTL)
+	s.logger.Info("LLM response cache enabled", "backend", "memory", "ttl", cfg.TTL, "max_entries", cfg.MaxEntries)
+}
+
+// initBatchStore keeps the open batches of batcher nodes in Redis, so they survive restarts
+// and are shared by all instances. Without Redis they stay in memory.
+func (s *Server) initBatchStore() {
+	batcherExec, err := s.execution.ExecutorManager.Get("batcher")
+	if err != nil {
+		return
+	}
+	batcher, ok := batcherExec.(*builtin.BatcherExecutor)
+	if !ok {
+		return
+	}
+
+	if s.data.RedisCache == nil {
+		s.logger.Warn("Redis not available - batcher nodes keep batches in memory")
+		return
+	}
+	batcher.SetBatchStore(cache.NewBatchStore(s.data.RedisCache))
+	s.logger.Info("Batcher nodes keep batches in Redis")
+}
+
+// initRateLimitStore keeps the limits of rate_limit nodes in Redis, so all instances share
+// them. Without Redis each instance limits on its own.
+func (s *Server) initRateLimitStore() {
+	rateLimitExec, err := s.execution.ExecutorManager.Get("rate_limit")
+	if err != nil {
+		return
+	}
+	rateLimiter, ok := rateLimitExec.(*builtin.RateLimitExecutor)
+	if !ok {
+		return
+	}
+
+	if s.data.RedisCache == nil {
+		s.logger.Warn("Redis not available - rate_limit nodes limit each instance separately")
+		return
+	}
+	rateLimiter.SetRateLimitStore(cache.NewRateLimitStore(s.data.RedisCache))
+	s.logger.Info("Rate limit nodes share limits in Redis")
+}
+
+// initCircuitBreakers gives the http and llm executors circuit breakers failing their
+// nodes fast while a host or provider keeps failing.
+func (s *Server) initCircuitBreakers() {
+	cfg := s.config.CircuitBreaker
+	if cfg.Threshold == 0 {
+		return
+	}
+	if httpExec, err := s.execution.ExecutorManager.Get("http"); err == nil {
+		if http, ok := httpExec.(*builtin.HTTPExecutor); ok {
+			http.SetCircuitBreaker(executor.NewCircuitBreaker(cfg.Threshold, cfg.Cooldown))
+		}
+	}
+	if llmExec, err := s.execution.ExecutorManager.Get("llm"); err == nil {
+		if llm, ok := llmExec.(*builtin.LLMExecutor); ok {
+			llm.SetCircuitBreaker(executor.NewCircuitBreaker(cfg.Threshold, cfg.Cooldown))
+		}
+	}
+	s.logger.Info("Circuit breakers enabled", "threshold", cfg.Threshold, "cooldown", cfg.Cooldown)
+}
+
 func (s *Server) initFileStorageManager() error {
 	fileStorageConfig := filestorage.DefaultManagerConfig()
 	fileStorageConfig.BasePath = s.config.FileStorage.StoragePath
 	fileStorageConfig.MaxFileSize = s.config.FileStorage.MaxFileSize
 
+	var backendFactory filestorage.ProviderFactory
+	switch s.config.FileStorage.Backend {
+	case string(models.StorageTypeS3):
+		s3Cfg := s.config.FileStorage.S3
+		backendFactory = filestorage.NewS3ProviderFactory(filestorage.S3Config{
+			Bucket:          s3Cfg.Bucket,
+			Endpoint:        s3Cfg.Endpoint,
+			Region:          s3Cfg.Region,
+			AccessKeyID:     s3Cfg.AccessKeyID,
+			SecretAccessKey: s3Cfg.SecretAccessKey,
+			SessionToken:    s3Cfg.SessionToken,
+			Prefix:          s3Cfg.Prefix,
+			UsePathStyle:    s3Cfg.UsePathStyle,
+		})
+	case string(models.StorageTypeGCS):
+		gcsCfg := s.config.FileStorage.GCS
+		credentials := []byte(gcsCfg.CredentialsJSON)
+		if len(credentials) == 0 && gcsCfg.CredentialsFile != "" {
+			data, err := os.ReadFile(gcsCfg.CredentialsFile)
+			if err != nil {
+				return fmt.Errorf("failed to read GCS credentials file: %w", err)
+			}
+			credentials = data
+		}
+		backendFactory = filestorage.NewGCSProviderFactory(filestorage.GCSConfig{
+			Bucket:          gcsCfg.Bucket,
+			CredentialsJSON: credentials,
+			Prefix:          gcsCfg.Prefix,
+		})
+	case string(models.StorageTypeAzure):
+		azureCfg := s.config.FileStorage.Azure
+		backendFactory = filestorage.NewAzureProviderFactory(filestorage.AzureConfig{
+			AccountName: azureCfg.AccountName,
+			AccountKey:  azureCfg.AccountKey,
+			SASToken:    azureCfg.SASToken,
+			Container:   azureCfg.Container,
+			Endpoint:    azureCfg.Endpoint,
+			Prefix:      azureCfg.Prefix,
+		})
+	}
+	if backendFactory != nil {
+		fileStorageConfig.StorageType = backendFactory.Type()
+	}
+
 	s.fileStorage.FileStorageManager = filestorage.NewStorageManager(fileStorageConfig, s.logger)
+	if backendFactory != nil {
+		s.fileStorage.FileStorageManager.RegisterFactory(backendFactory)
+	}
 
 	s.logger.Info("File storage manager initialized",
+		"backend", fileStorageConfig.StorageType,
 		"base_path", s.config.FileStorage.StoragePath,
 		"max_file_size", s.config.FileStorage.MaxFileSize,
 	)
@@ -139,6 +332,22 @@ func (s *Server) initFileStorageManager() error {
 		return fmt.Errorf("failed to register file adapter executors: %w", err)
 	}
 
+	if err := builtin.RegisterWASM(s.execution.ExecutorManager, s.fileStorage.FileStorageManager); err != nil {
+		return fmt.Errorf("failed to register wasm executor: %w", err)
+	}
+
+	if err := builtin.RegisterXLSX(s.execution.ExecutorManager, s.fileStorage.FileStorageManager); err != nil {
+		return fmt.Errorf("failed to register xlsx executor: %w", err)
+	}
+
+	if err := builtin.RegisterPDFRender(s.execution.ExecutorManager, s.fileStorage.FileStorageManager); err != nil {
+		return fmt.Errorf("failed to register pdf_render executor: %w", err)
+	}
+
+	if err := builtin.RegisterImage(s.execution.ExecutorManager, s.fileStorage.FileStorageManager); err != nil {
+		return fmt.Errorf("failed to register image executor: %w", err)
+	}
+
 	return nil
 }
 
@@ -229,6 +438,8 @@ func (s *Server) initRepositories() error {
 	s.data.ServiceKeyRepo = storage.NewServiceKeyRepository(s.data.DB)
 	s.data.SystemKeyRepo = storage.NewSystemKeyRepo(s.data.DB)
 	s.data.AuditLogRepo = storage.NewServiceAuditLogRepo(s.data.DB)
+	s.data.ExecutionNoteRepo = storage.NewExecutionNoteRepository(s.data.DB)
+	s.data.InboxRepo = storage.NewInboxRepository(s.data.DB)
 
 	s.logger.Info("Repositories initialized")
 	return nil
@@ -247,6 +458,74 @@ func (s *Server) initEncryptionServices() error {
 	s.auth.RentalKeyProvider = rentalkey.NewProvider(s.data.RentalKeyRepo, encryptionService)
 
 	s.logger.Info("Rental key provider initialized")
+
+	s.auth.CredentialService = credentials.NewService(s.data.CredentialsRepo, encryptionService)
+	return nil
+}
+
+// initCredentialExecutors registers executors that resolve credential references
+// (email_send, slack, mysql_query, embedding, vector_search, qdrant, pinecone, mongodb, redis, grpc_call, soap, websocket_send, issue_tracker)
+// once credentials and file storage are available, and gives the llm executor the resolver for bedrock nodes.
+// Without encryption email_send still works with unauthenticated relays.
+func (s *Server) initCredentialExecutors() error {
+	var resolver builtin.CredentialResolver
+	if s.auth.CredentialService != nil {
+		resolver = s.auth.CredentialService
+	}
+
+	if err := builtin.RegisterEmailSend(s.execution.ExecutorManager, resolver, s.fileStorage.FileStorageManager); err != nil {
+		return fmt.Errorf("failed to register email_send executor: %w", err)
+	}
+	if err := builtin.RegisterSlack(s.execution.ExecutorManager, resolver, s.fileStorage.FileStorageManager); err != nil {
+		return fmt.Errorf("failed to register slack executor: %w", err)
+	}
+	if err := builtin.RegisterMySQLQuery(s.execution.ExecutorManager, resolver); err != nil {
+		return fmt.Errorf("failed to register mysql_query executor: %w", err)
+	}
+	if err := builtin.RegisterEmbedding(s.execution.ExecutorManager, resolver); err != nil {
+		return fmt.Errorf("failed to register embedding executor: %w", err)
+	}
+	if err := builtin.RegisterVectorSearch(s.execution.ExecutorManager, resolver); err != nil {
+		return fmt.Errorf("failed to register vector_search executor: %w", err)
+	}
+	if err := builtin.RegisterQdrant(s.execution.ExecutorManager, resolver); err != nil {
+		return fmt.Errorf("failed to register qdrant executor: %w", err)
+	}
+	if err := builtin.RegisterPinecone(s.execution.ExecutorManager, resolver); err != nil {
+		return fmt.Errorf("failed to register pinecone executor: %w", err)
+	}
+
+	s.execution.MongoDBExecutor = builtin.NewMongoDBExecutor(resolver)
+	if err := s.execution.ExecutorManager.Register("mongodb", s.execution.MongoDBExecutor); err != nil {
+		return fmt.Errorf("failed to register mongodb executor: %w", err)
+	}
+
+	s.execution.RedisExecutor = builtin.NewRedisExecutor(resolver)
+	if err := s.execution.ExecutorManager.Register("redis", s.execution.RedisExecutor); err != nil {
+		return fmt.Errorf("failed to register redis executor: %w", err)
+	}
+
+	s.execution.GRPCCallExecutor = builtin.NewGRPCCallExecutor(resolver, s.fileStorage.FileStorageManager)
+	if err := s.execution.ExecutorManager.Register("grpc_call", s.execution.GRPCCallExecutor); err != nil {
+		return fmt.Errorf("failed to register grpc_call executor: %w", err)
+	}
+
+	if err := builtin.RegisterSOAP(s.execution.ExecutorManager, resolver); err != nil {
+		return fmt.Errorf("failed to register soap executor: %w", err)
+	}
+	if err := builtin.RegisterWebSocketSend(s.execution.ExecutorManager, resolver); err != nil {
+		return fmt.Errorf("failed to register websocket_send executor: %w", err)
+	}
+	if err := builtin.RegisterIssueTracker(s.execution.ExecutorManager, resolver); err != nil {
+		return fmt.Errorf("failed to register issue_tracker executor: %w", err)
+	}
+
+	// The llm executor is registered with the builtins; bedrock nodes load AWS credentials through it
+	if llmExec, err := s.execution.ExecutorManager.Get("llm"); err == nil {
+		if llm, ok := llmExec.(*builtin.LLMExecutor); ok {
+			llm.SetCredentialResolver(resolver)
+		}
+	}
 	return nil
 }
 
@@ -298,21 +577,118 @@ func (s *Server) initExecutionEngine() error {
 		s.execution.ObserverManager,
 		registry,
 	)
+	if s.config.Parallelism.Enabled {
+		s.execution.ExecutionManager.SetAdaptiveParallelism(s.newAdaptiveParallelism())
+	}
 
-	s.logger.Info("Execution engine initialized")
+	s.logger.Info("Execution engine initialized", "adaptive_parallelism", s.config.Parallelism.Enabled)
 	return nil
 }
 
+// initExecutionQueue queues executions started asynchronously for workers instead of running
+// them on this instance, when a queue is configured.
+func (s *Server) initExecutionQueue() error {
+	if s.config.Worker.Queue == "" {
+		return nil
+	}
+	if s.data.RedisCache == nil {
+		return fmt.Errorf("execution queue %q requires Redis", s.config.Worker.Queue)
+	}
+
+	s.execution.ExecutionQueue = cache.NewExecutionQueue(s.data.RedisCache, s.config.Worker.PriorityAging)
+	s.execution.ExecutionManager.SetExecutionQueue(s.execution.ExecutionQueue)
+	s.logger.Info("Execution queue enabled",
+		"backend", s.config.Worker.Queue,
+		"priority_aging", s.config.Worker.PriorityAging,
+	)
+	return nil
+}
+
+// initConcurrencyLimiter keeps the slots of workflow concurrency limits in Redis, so the limits
+// hold across all instances. Without Redis each instance limits its own executions.
+func (s *Server) initConcurrencyLimiter() {
+	if s.data.RedisCache == nil {
+		s.logger.Warn("Redis not available - workflow concurrency limits apply to each instance separately")
+		return
+	}
+	s.execution.ExecutionManager.SetConcurrencyLimiter(cache.NewConcurrencyLimiter(s.data.RedisCache))
+	s.logger.Info("Workflow concurrency limits shared in Redis")
+}
+
+// initQuotas counts the usage of users' executions per day and enforces their quotas: the
+// quota of their billing account, or the configured default.
+func (s *Server) initQuotas() {
+	cfg := s.config.Quotas
+	if !cfg.Enabled {
+		return
+	}
+	s.execution.Quotas = quota.NewService(s.data.AccountRepo, storage.NewUsageRepository(s.data.DB), models.UsageQuota{
+		ExecutionsPerDay: cfg.ExecutionsPerDay,
+		NodeRunsPerDay:   cfg.NodeRunsPerDay,
+		LLMTokensPerDay:  cfg.LLMTokensPerDay,
+		Overflow:         models.QuotaOverflow(cfg.Overflow),
+	})
+	s.execution.ExecutionManager.SetQuotaEnforcer(s.execution.Quotas)
+	s.logger.Info("Usage quotas enabled",
+		"executions_per_day", cfg.ExecutionsPerDay,
+		"node_runs_per_day", cfg.NodeRunsPerDay,
+		"llm_tokens_per_day", cfg.LLMTokensPerDay,
+		"overflow", cfg.Overflow,
+	)
+}
+
+// initIdempotency remembers the Idempotency-Key headers of execution starts, in Redis so a
+// start retried against any instance returns the original execution.
+func (s *Server) initIdempotency() {
+	ttl := s.config.Server.IdempotencyTTL
+	if ttl <= 0 {
+		return
+	}
+	if s.data.RedisCache == nil {
+		s.logger.Warn("Redis not available - idempotency keys are remembered by each instance separately")
+		s.execution.Idempotency = serviceapi.NewMemoryIdempotencyStore(ttl)
+		return
+	}
+	s.execution.Idempotency = cache.NewIdempotencyStore(s.data.RedisCache, ttl)
+	s.logger.Info("Idempotency keys shared in Redis", "ttl", ttl)
+}
+
+// newAdaptiveParallelism creates the controller that tunes concurrent calls per provider.
+func (s *Server) newAdaptiveParallelism() *pkgengine.AdaptiveParallelism {
+	cfg := s.config.Parallelism
+	providers := make(map[string]pkgengine.ParallelismBounds, len(cfg.Providers))
+	for provider, bounds := range cfg.Providers {
+		providers[provider] = pkgengine.ParallelismBounds{Min: bounds.Min, Max: bounds.Max}
+	}
+
+	return pkgengine.NewAdaptiveParallelism(pkgengine.AdaptiveParallelismConfig{
+		Default:       pkgengine.ParallelismBounds{Min: cfg.Min, Max: cfg.Max},
+		Providers:     providers,
+		LatencyTarget: cfg.LatencyTarget,
+		OnChange: func(provider string, limit int, reason string) {
+			s.logger.Info("Adaptive parallelism limit changed", "provider", provider, "limit", limit, "reason", reason)
+		},
+	})
+}
+
 func (s *Server) initTriggerManager() error {
 	if s.data.RedisCache == nil {
 		return fmt.Errorf("trigger manager disabled - Redis cache not available")
 	}
 
+	var resolver trigger.CredentialResolver
+	if s.auth.CredentialService != nil {
+		resolver = s.auth.CredentialService
+	}
+
 	triggerManager, err := trigger.NewManager(trigger.ManagerConfig{
 		TriggerRepo:  s.data.TriggerRepo,
 		WorkflowRepo: s.data.WorkflowRepo,
 		ExecutionMgr: s.execution.ExecutionManager,
 		Cache:        s.data.RedisCache,
+		Credentials:  resolver,
+		FileStorage:  s.fileStorage.FileStorageManager,
+		ReadOnly:     s.readOnly,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create trigger manager: %w", err)
@@ -329,6 +705,287 @@ func (s *Server) initTriggerManager() error {
 	return nil
 }
 
+// triggerListener returns the trigger manager as the listener for trigger changes
+// made through the API, or nil when the trigger manager is disabled.
+func (s *Server) triggerListener() serviceapi.TriggerListener {
+	if s.triggers.TriggerManager == nil {
+		return nil
+	}
+	return s.triggers.TriggerManager
+}
+
+// initIncidentService creates the incident service and subscribes it to failed executions
+// so that repeated failures open incidents; it is also a canary alert sink.
+func (s *Server) initIncidentService() {
+	s.triggers.IncidentService = incident.NewService(
+		storage.NewIncidentRepository(s.data.DB),
+		s.data.WorkflowRepo,
+		s.data.ExecutionRepo,
+		s.execution.ExecutorManager,
+		s.logger,
+	)
+
+	if err := s.execution.ObserverManager.Register(s.triggers.IncidentService); err != nil {
+		s.logger.Warn("Failed to register incident observer", "error", err)
+	}
+}
+
+// initDeadLetters creates the dead-letter service and subscribes it to failed executions,
+// so that they are parked in the dead-letter queue.
+func (s *Server) initDeadLetters() {
+	s.execution.DeadLetters = deadletter.NewService(
+		storage.NewDeadLetterRepository(s.data.DB),
+		s.execution.ExecutionManager,
+		s.execution.ObserverManager,
+		s.logger,
+	)
+
+	if err := s.execution.ObserverManager.Register(s.execution.DeadLetters); err != nil {
+		s.logger.Warn("Failed to register dead-letter observer", "error", err)
+	}
+}
+
+func (s *Server) initCanaryService() error {
+	sinks := []canary.AlertSink{canary.NewLogSink(s.logger)}
+	if s.config.Canary.AlertWebhookURL != "" {
+		sinks = append(sinks, canary.NewWebhookSink(s.config.Canary.AlertWebhookURL))
+	}
+	if s.triggers.IncidentService != nil {
+		sinks = append(sinks, s.triggers.IncidentService)
+	}
+
+	s.triggers.CanaryService = canary.NewService(
+		canary.Config{
+			FailureThreshold: s.config.Canary.FailureThreshold,
+			RunTimeout:       s.config.Canary.RunTimeout,
+		},
+		storage.NewCanaryRepository(s.data.DB),
+		s.data.WorkflowRepo,
+		s.execution.ExecutionManager,
+		s.logger,
+		sinks...,
+	)
+
+	if !s.config.Canary.Enabled {
+		s.logger.Info("Canary scheduler disabled")
+		return nil
+	}
+
+	if err := s.triggers.CanaryService.Start(context.Background()); err != nil {
+		return fmt.Errorf("failed to start canary scheduler: %w", err)
+	}
+
+	s.logger.Info("Canary scheduler started")
+	return nil
+}
+
+func (s *Server) initStatsRollup() {
+	s.execution.StatsRollup = analytics.NewRollupService(
+		analytics.Config{
+			Interval:        s.config.Stats.RollupInterval,
+			RawRetention:    time.Duration(s.config.Stats.RawRetentionDays) * 24 * time.Hour,
+			HourlyRetention: time.Duration(s.config.Stats.HourlyRetentionDays) * 24 * time.Hour,
+		},
+		storage.NewExecutionStatsRepository(s.data.DB),
+		s.logger,
+	)
+
+	if !s.config.Stats.RollupEnabled {
+		s.logger.Info("Execution stats rollup disabled")
+		return
+	}
+
+	s.execution.StatsRollup.Start()
+	s.logger.Info("Execution stats rollup started",
+		"interval", s.config.Stats.RollupInterval,
+		"raw_retention_days", s.config.Stats.RawRetentionDays,
+		"hourly_retention_days", s.config.Stats.HourlyRetentionDays,
+	)
+}
+
+// initPayloadArchive creates the cold storage archiver for node payloads. The archiver is
+// created even when archiving is disabled so payloads archived earlier can still be hydrated,
+// and it then runs purge-only so retention data labels are still honored.
+func (s *Server) initPayloadArchive() {
+	store, err := s.fileStorage.FileStorageManager.GetStorage(coldstorage.DefaultStorageID)
+	if err != nil {
+		s.logger.Warn("Node payload archive not available", "error", err)
+		return
+	}
+
+	s.execution.PayloadArchive = coldstorage.NewArchiver(
+		coldstorage.Config{
+			Interval:     s.config.PayloadArchive.Interval,
+			ArchiveAfter: time.Duration(s.config.PayloadArchive.ArchiveAfterDays) * 24 * time.Hour,
+			BatchSize:    s.config.PayloadArchive.BatchSize,
+			PurgeOnly:    !s.config.PayloadArchive.Enabled,
+		},
+		storage.NewPayloadArchiveRepository(s.data.DB),
+		store,
+		s.logger,
+	)
+
+	s.execution.PayloadArchive.Start()
+	if !s.config.PayloadArchive.Enabled {
+		s.logger.Info("Node payload retention purge started", "interval", s.config.PayloadArchive.Interval)
+		return
+	}
+
+	s.logger.Info("Node payload archive started",
+		"interval", s.config.PayloadArchive.Interval,
+		"archive_after_days", s.config.PayloadArchive.ArchiveAfterDays,
+	)
+}
+
+// initExecutionArchive creates the archiver of old executions. It is created even when
+// archiving is disabled so archived executions can still be listed and restored.
+func (s *Server) initExecutionArchive() {
+	store, err := s.fileStorage.FileStorageManager.GetStorage(coldstorage.DefaultStorageID)
+	if err != nil {
+		s.logger.Warn("Execution archive not available", "error", err)
+		return
+	}
+
+	s.execution.ExecutionArchive = coldstorage.NewExecutionArchiver(
+		coldstorage.ExecutionArchiveConfig{
+			Interval:     s.config.Archive.Interval,
+			ArchiveAfter: time.Duration(s.config.Archive.ArchiveAfterDays) * 24 * time.Hour,
+			BatchSize:    s.config.Archive.BatchSize,
+		},
+		storage.NewExecutionArchiveRepository(s.data.DB),
+		store,
+		s.logger,
+	)
+	if !s.config.Archive.Enabled {
+		return
+	}
+
+	s.execution.ExecutionArchive.Start()
+	s.logger.Info("Execution archive started",
+		"interval", s.config.Archive.Interval,
+		"archive_after_days", s.config.Archive.ArchiveAfterDays,
+	)
+}
+
+// initDelayResumer starts resuming executions paused by delay nodes once their delay is over,
+// and starting scheduled executions once their start time has come.
+func (s *Server) initDelayResumer() {
+	s.execution.DelayResumer = engine.NewExecutionResumer(
+		s.execution.ExecutionManager,
+		s.config.DelayResume.Interval,
+		s.config.DelayResume.BatchSize,
+		s.logger,
+	)
+
+	s.execution.DelayResumer.Start()
+	s.logger.Info("Delay resumer started", "interval", s.config.DelayResume.Interval)
+}
+
+// initCrashRecovery starts checkpointing running executions and resuming the executions left
+// running by an instance that crashed.
+func (s *Server) initCrashRecovery() {
+	cfg := s.config.CrashRecovery
+	if !cfg.Enabled {
+		return
+	}
+
+	s.execution.CrashRecovery = engine.NewExecutionRecoverer(
+		s.execution.ExecutionManager,
+		cfg.CheckpointInterval,
+		cfg.StaleAfter,
+		cfg.BatchSize,
+		s.logger,
+	)
+
+	s.execution.CrashRecovery.Start()
+	s.logger.Info("Crash recovery started",
+		"checkpoint_interval", cfg.CheckpointInterval,
+		"stale_after", cfg.StaleAfter,
+	)
+}
+
+// initExecutionWorker starts running queued executions on this instance, in worker mode or
+// when the worker is enabled.
+func (s *Server) initExecutionWorker() {
+	cfg := s.config.Worker
+	if s.execution.ExecutionQueue == nil || (!cfg.Enabled && s.config.Server.Mode != "worker") {
+		return
+	}
+
+	workerID := cfg.ID
+	if workerID == "" {
+		hostname, _ := os.Hostname()
+		workerID = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+
+	s.execution.ExecutionWorker = engine.NewExecutionWorker(
+		s.execution.ExecutionManager,
+		s.execution.ExecutionQueue,
+		engine.ExecutionWorkerOptions{
+			ID:           workerID,
+			Concurrency:  cfg.Concurrency,
+			LeaseTTL:     cfg.LeaseTTL,
+			PollInterval: cfg.PollInterval,
+			MaxAttempts:  cfg.MaxAttempts,
+			ReadOnly:     s.readOnly,
+		},
+		s.logger,
+	)
+
+	s.execution.ExecutionWorker.Start()
+	s.logger.Info("Execution worker started",
+		"worker_id", workerID,
+		"concurrency", cfg.Concurrency,
+		"lease_ttl", cfg.LeaseTTL,
+	)
+}
+
+// initExecutorHealth self-tests the executors that support it, keeps checking them on an
+// interval and keeps nodes off executors that fail.
+func (s *Server) initExecutorHealth() {
+	cfg := s.config.ExecutorHealth
+	s.execution.ExecutorHealth = executor.NewHealthMonitor(s.execution.ExecutorManager, cfg.Timeout)
+
+	policy := pkgengine.UnhealthyExecutorFail
+	if cfg.Policy == string(pkgengine.UnhealthyExecutorQueue) {
+		policy = pkgengine.UnhealthyExecutorQueue
+	}
+	s.execution.ExecutionManager.SetExecutorHealthGate(&pkgengine.ExecutorHealthGate{
+		Monitor:      s.execution.ExecutorHealth,
+		Policy:       policy,
+		QueueTimeout: cfg.QueueTimeout,
+	})
+
+	if unhealthy := s.execution.ExecutorHealth.Check(context.Background()); len(unhealthy) > 0 {
+		s.logger.Warn("Executor self-test failed", "executors", unhealthy, "policy", policy)
+	}
+	s.execution.ExecutorHealth.Start(cfg.Interval)
+	s.logger.Info("Executor health checks started", "interval", cfg.Interval, "policy", policy)
+}
+
+// initPreemption lets high-priority executions preempt lower-priority running ones.
+func (s *Server) initPreemption() {
+	cfg := s.config.Preemption
+	minPriority, _ := models.ParseExecutionPriority(cfg.MinPriority)
+	s.execution.ExecutionManager.SetPreemptionPolicy(&engine.PreemptionPolicy{
+		MaxRunning:   cfg.MaxRunning,
+		MinPriority:  minPriority,
+		Checkpoint:   cfg.Checkpoint,
+		RequeueDelay: cfg.RequeueDelay,
+	})
+	if cfg.MaxRunning > 0 {
+		s.logger.Info("Execution preemption enabled", "max_running", cfg.MaxRunning, "min_priority", minPriority, "checkpoint", cfg.Checkpoint)
+	}
+}
+
+// payloadHydrator returns the archiver as a serviceapi.PayloadHydrator, or nil when it is not available.
+func (s *Server) payloadHydrator() serviceapi.PayloadHydrator {
+	if s.execution.PayloadArchive == nil {
+		return nil
+	}
+	return s.execution.PayloadArchive
+}
+
 func (s *Server) initSystemKeySystem() error {
 	s.serviceAPI.SystemKeyService = systemkey.NewService(s.data.SystemKeyRepo, systemkey.Config{
 		MaxKeys:           s.config.ServiceAPI.MaxKeys,
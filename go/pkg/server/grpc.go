@@ -27,6 +27,8 @@ func (s *Server) setupGRPCServer() error {
 		EncryptionSvc:   s.auth.EncryptionService,
 		AuditService:    s.serviceAPI.AuditService,
 		Logger:          s.logger,
+		PayloadArchive:  s.payloadHydrator(),
+		TriggerListener: s.triggerListener(),
 	}
 
 	s.serviceAPI.GRPCServer = serviceapigrpc.NewServiceAPIServer(s.serviceAPI.Operations)
@@ -34,6 +36,7 @@ func (s *Server) setupGRPCServer() error {
 	s.serviceAPI.GRPCServerInstance = grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			serviceapigrpc.SystemKeyAuthInterceptor(s.serviceAPI.SystemKeyService),
+			serviceapigrpc.ReadOnlyInterceptor(s.readOnly),
 			serviceapigrpc.ImpersonationInterceptor(s.data.UserRepo, s.config.ServiceAPI.SystemUserID),
 			serviceapigrpc.AuditInterceptor(s.serviceAPI.AuditService, s.logger),
 		),
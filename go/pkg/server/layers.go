@@ -6,10 +6,16 @@ import (
 	"github.com/uptrace/bun"
 	grpclib "google.golang.org/grpc"
 
+	"github.com/smilemakc/mbflow/go/internal/application/analytics"
 	"github.com/smilemakc/mbflow/go/internal/application/auth"
+	"github.com/smilemakc/mbflow/go/internal/application/canary"
+	"github.com/smilemakc/mbflow/go/internal/application/coldstorage"
+	"github.com/smilemakc/mbflow/go/internal/application/deadletter"
 	"github.com/smilemakc/mbflow/go/internal/application/engine"
 	"github.com/smilemakc/mbflow/go/internal/application/filestorage"
+	"github.com/smilemakc/mbflow/go/internal/application/incident"
 	"github.com/smilemakc/mbflow/go/internal/application/observer"
+	"github.com/smilemakc/mbflow/go/internal/application/quota"
 	"github.com/smilemakc/mbflow/go/internal/application/rentalkey"
 	"github.com/smilemakc/mbflow/go/internal/application/serviceapi"
 	"github.com/smilemakc/mbflow/go/internal/application/servicekey"
@@ -20,8 +26,10 @@ import (
 	"github.com/smilemakc/mbflow/go/internal/infrastructure/api/rest"
 	"github.com/smilemakc/mbflow/go/internal/infrastructure/cache"
 	"github.com/smilemakc/mbflow/go/internal/infrastructure/storage"
+	"github.com/smilemakc/mbflow/go/pkg/credentials"
 	"github.com/smilemakc/mbflow/go/pkg/crypto"
 	"github.com/smilemakc/mbflow/go/pkg/executor"
+	"github.com/smilemakc/mbflow/go/pkg/executor/builtin"
 )
 
 // DataLayer holds database connections and all repositories.
@@ -30,21 +38,23 @@ type DataLayer struct {
 	RedisCache *cache.RedisCache
 
 	// Repositories
-	WorkflowRepo    *storage.WorkflowRepository
-	ExecutionRepo   *storage.ExecutionRepository
-	EventRepo       *storage.EventRepository
-	TriggerRepo     repository.TriggerRepository
-	UserRepo        *storage.UserRepository
-	FileRepo        *storage.FileRepository
-	AccountRepo     *storage.AccountRepositoryImpl
-	TransactionRepo *storage.TransactionRepositoryImpl
-	ResourceRepo    *storage.ResourceRepositoryImpl
-	PricingPlanRepo *storage.PricingPlanRepositoryImpl
-	CredentialsRepo *storage.CredentialsRepositoryImpl
-	ServiceKeyRepo  *storage.ServiceKeyRepositoryImpl
-	SystemKeyRepo   *storage.SystemKeyRepoImpl
-	AuditLogRepo    *storage.ServiceAuditLogRepoImpl
-	RentalKeyRepo   *storage.RentalKeyRepositoryImpl
+	WorkflowRepo      *storage.WorkflowRepository
+	ExecutionRepo     *storage.ExecutionRepository
+	EventRepo         *storage.EventRepository
+	TriggerRepo       repository.TriggerRepository
+	UserRepo          *storage.UserRepository
+	FileRepo          *storage.FileRepository
+	AccountRepo       *storage.AccountRepositoryImpl
+	TransactionRepo   *storage.TransactionRepositoryImpl
+	ResourceRepo      *storage.ResourceRepositoryImpl
+	PricingPlanRepo   *storage.PricingPlanRepositoryImpl
+	CredentialsRepo   *storage.CredentialsRepositoryImpl
+	ServiceKeyRepo    *storage.ServiceKeyRepositoryImpl
+	SystemKeyRepo     *storage.SystemKeyRepoImpl
+	AuditLogRepo      *storage.ServiceAuditLogRepoImpl
+	RentalKeyRepo     *storage.RentalKeyRepositoryImpl
+	ExecutionNoteRepo *storage.ExecutionNoteRepository
+	InboxRepo         *storage.InboxRepository
 }
 
 // AuthLayer holds authentication and authorization components.
@@ -56,6 +66,7 @@ type AuthLayer struct {
 	LoginRateLimiter  *rest.LoginRateLimiter
 	EncryptionService *crypto.EncryptionService
 	RentalKeyProvider *rentalkey.Provider
+	CredentialService *credentials.Service
 }
 
 // ExecutionLayer holds workflow execution components.
@@ -65,6 +76,20 @@ type ExecutionLayer struct {
 	ObserverManager   *observer.ObserverManager
 	WSHub             *observer.WebSocketHub
 	EphemeralRegistry *engine.EphemeralStreamRegistry
+	StatsRollup       *analytics.RollupService
+	PayloadArchive    *coldstorage.Archiver
+	ExecutionArchive  *coldstorage.ExecutionArchiver
+	DelayResumer      *engine.ExecutionResumer
+	CrashRecovery     *engine.ExecutionRecoverer
+	ExecutionQueue    engine.ExecutionQueue
+	ExecutionWorker   *engine.ExecutionWorker
+	Idempotency       serviceapi.IdempotencyStore
+	DeadLetters       *deadletter.Service
+	Quotas            *quota.Service
+	ExecutorHealth    *executor.HealthMonitor
+	MongoDBExecutor   *builtin.MongoDBExecutor
+	RedisExecutor     *builtin.RedisExecutor
+	GRPCCallExecutor  *builtin.GRPCCallExecutor
 }
 
 // ServiceAPILayer holds Service API and gRPC components.
@@ -81,7 +106,9 @@ type ServiceAPILayer struct {
 
 // TriggerLayer holds trigger management components.
 type TriggerLayer struct {
-	TriggerManager *trigger.Manager
+	TriggerManager  *trigger.Manager
+	CanaryService   *canary.Service
+	IncidentService *incident.Service
 }
 
 // FileStorageLayer holds file storage components.
@@ -12,11 +12,16 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 
 	"github.com/smilemakc/mbflow/go/internal/application/filestorage"
+	"github.com/smilemakc/mbflow/go/internal/application/inbox"
+	"github.com/smilemakc/mbflow/go/internal/application/llmcatalog"
 	"github.com/smilemakc/mbflow/go/internal/application/observer"
+	"github.com/smilemakc/mbflow/go/internal/application/onboarding"
+	"github.com/smilemakc/mbflow/go/internal/application/ownership"
 	"github.com/smilemakc/mbflow/go/internal/application/rentalkey"
 	"github.com/smilemakc/mbflow/go/internal/application/serviceapi"
 	"github.com/smilemakc/mbflow/go/internal/infrastructure/api/rest"
 	"github.com/smilemakc/mbflow/go/internal/infrastructure/storage"
+	"github.com/smilemakc/mbflow/go/pkg/executor/compilecache"
 )
 
 func (s *Server) setupRoutes() error {
@@ -65,7 +70,7 @@ func (s *Server) setupRoutes() error {
 			}
 
 			c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, PATCH, OPTIONS")
-			c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+			c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key, Idempotency-Key")
 			c.Writer.Header().Set("Access-Control-Max-Age", "86400")
 
 			if c.Request.Method == "OPTIONS" {
@@ -83,10 +88,20 @@ func (s *Server) setupRoutes() error {
 		}
 	}
 
+	// Registered after CORS so preflight requests are answered before the check
+	readOnlyMiddleware := rest.NewReadOnlyMiddleware(s.readOnly, s.logger,
+		"/api/v1/admin/read-only",
+		"/api/v1/auth/login",
+		"/api/v1/auth/refresh",
+		"/api/v1/auth/logout",
+	)
+	s.router.Use(readOnlyMiddleware.Enforce())
+
 	s.setupHealthEndpoints()
 	s.setupSwaggerEndpoint()
 	s.setupWebSocketEndpoints()
 	s.setupAPIv1Routes()
+	s.setupAPIv2Routes()
 
 	s.logger.Info("REST API routes registered")
 	return nil
@@ -115,7 +130,14 @@ func (s *Server) setupHealthEndpoints() {
 			}
 		}
 
-		c.JSON(http.StatusOK, gin.H{"status": "healthy"})
+		response := gin.H{"status": "healthy"}
+		if s.execution.ExecutorHealth != nil {
+			// Unhealthy executors only affect their nodes, so they do not fail the probe
+			if executors := s.execution.ExecutorHealth.Snapshot(); len(executors) > 0 {
+				response["executors"] = executors
+			}
+		}
+		c.JSON(http.StatusOK, response)
 	})
 
 	s.router.GET("/ready", func(c *gin.Context) {
@@ -144,6 +166,8 @@ func (s *Server) setupHealthEndpoints() {
 			}
 		}
 
+		metrics["compile_cache"] = compilecache.AllStats()
+
 		c.JSON(http.StatusOK, gin.H{"metrics": metrics})
 	})
 }
@@ -172,6 +196,7 @@ func (s *Server) setupWebSocketEndpoints() {
 
 func (s *Server) setupAPIv1Routes() {
 	apiV1 := s.router.Group("/api/v1")
+	apiV1.Use(rest.APIVersionMiddleware(rest.APIVersionV1, false))
 	{
 		s.setupAuthRoutes(apiV1)
 		s.setupAdminRoutes(apiV1)
@@ -183,12 +208,51 @@ func (s *Server) setupAPIv1Routes() {
 		s.setupAccountRoutes(apiV1)
 		s.setupCredentialsRoutes(apiV1)
 		s.setupRentalKeyRoutes(apiV1)
+		s.setupLLMCatalogRoutes(apiV1)
 		s.setupServiceKeyRoutes(apiV1)
+		s.setupOnboardingRoutes(apiV1)
 		s.setupWebhookRoutes(apiV1)
 		s.setupServiceAPIRoutes(apiV1)
 	}
 }
 
+// setupAPIv2Routes registers API v2. Its handlers share the serviceapi core with v1
+// and differ in pagination, error envelope and DTOs; v1 stays unchanged.
+func (s *Server) setupAPIv2Routes() {
+	s.router.GET("/api/versions", rest.HandleListVersions)
+
+	ops := &serviceapi.Operations{
+		WorkflowRepo:    s.data.WorkflowRepo,
+		ExecutionRepo:   s.data.ExecutionRepo,
+		TriggerRepo:     s.data.TriggerRepo,
+		CredentialsRepo: s.data.CredentialsRepo,
+		ExecutionMgr:    s.execution.ExecutionManager,
+		ExecutorManager: s.execution.ExecutorManager,
+		EncryptionSvc:   s.auth.EncryptionService,
+		AuditService:    s.serviceAPI.AuditService,
+		Logger:          s.logger,
+		PayloadArchive:  s.payloadHydrator(),
+	}
+
+	v2Handlers := rest.NewV2Handlers(ops, s.logger)
+
+	apiV2 := s.router.Group("/api/v2")
+	apiV2.Use(rest.APIVersionMiddleware(rest.APIVersionV2, true))
+	apiV2.Use(s.auth.AuthMiddleware.OptionalAuth())
+	{
+		apiV2.GET("/openapi.json", rest.HandleOpenAPIv2)
+
+		apiV2.GET("/workflows", v2Handlers.HandleListWorkflows)
+		apiV2.GET("/workflows/:workflow_id", v2Handlers.HandleGetWorkflow)
+		apiV2.POST("/workflows/:workflow_id/executions", v2Handlers.HandleStartExecution)
+
+		apiV2.GET("/executions", v2Handlers.HandleListExecutions)
+		apiV2.GET("/executions/:id", v2Handlers.HandleGetExecution)
+	}
+
+	s.logger.Info("API v2 endpoints registered")
+}
+
 func (s *Server) setupAuthRoutes(apiV1 *gin.RouterGroup) {
 	authHandlers := rest.NewAuthHandlers(s.auth.AuthService, s.auth.ProviderManager, s.auth.LoginRateLimiter)
 
@@ -227,6 +291,43 @@ func (s *Server) setupAdminRoutes(apiV1 *gin.RouterGroup) {
 		adminGroup.GET("/users/:id/roles", authHandlers.HandleGetUserRoles)
 		adminGroup.POST("/users/:id/roles", authHandlers.HandleAssignRole)
 		adminGroup.DELETE("/users/:id/roles/:role_id", authHandlers.HandleRemoveRole)
+
+		ownershipService := ownership.NewService(storage.NewOwnershipRepository(s.data.DB), s.data.UserRepo)
+		ownershipHandlers := rest.NewOwnershipHandlers(ownershipService, s.logger)
+		adminGroup.GET("/users/:id/ownership", ownershipHandlers.HandleGetOwnershipReport)
+		adminGroup.POST("/users/:id/transfer-ownership", ownershipHandlers.HandleTransferOwnership)
+
+		if s.execution.Quotas != nil {
+			usageHandlers := rest.NewUsageHandlers(s.execution.Quotas, s.logger)
+			adminGroup.GET("/users/:id/usage", usageHandlers.HandleGetUserUsage)
+			adminGroup.PUT("/users/:id/quota", usageHandlers.HandleSetUserQuota)
+			adminGroup.DELETE("/users/:id/quota", usageHandlers.HandleDeleteUserQuota)
+		}
+
+		canaryHandlers := rest.NewCanaryHandlers(s.triggers.CanaryService, s.logger)
+		adminGroup.GET("/canaries", canaryHandlers.HandleListCanaries)
+		adminGroup.GET("/canaries/:workflow_id", canaryHandlers.HandleGetCanary)
+		adminGroup.PUT("/canaries/:workflow_id", canaryHandlers.HandleSaveCanary)
+		adminGroup.DELETE("/canaries/:workflow_id", canaryHandlers.HandleDeleteCanary)
+		adminGroup.POST("/canaries/:workflow_id/run", canaryHandlers.HandleRunCanary)
+		adminGroup.GET("/canaries/:workflow_id/runs", canaryHandlers.HandleListCanaryRuns)
+
+		incidentHandlers := rest.NewIncidentHandlers(nil, s.triggers.IncidentService, s.logger)
+		adminGroup.GET("/incident-policies/:workflow_id", incidentHandlers.HandleGetPolicy)
+		adminGroup.PUT("/incident-policies/:workflow_id", incidentHandlers.HandleSavePolicy)
+		adminGroup.DELETE("/incident-policies/:workflow_id", incidentHandlers.HandleDeletePolicy)
+		adminGroup.GET("/incident-policies/:workflow_id/incidents", incidentHandlers.HandleListWorkflowIncidents)
+
+		analyticsHandlers := rest.NewAnalyticsHandlers(s.execution.StatsRollup, s.logger)
+		adminGroup.GET("/analytics/executions", analyticsHandlers.HandleGetExecutionStats)
+		adminGroup.POST("/analytics/rollup", analyticsHandlers.HandleRunRollup)
+
+		overviewHandlers := rest.NewAdminOverviewHandlers(s.triggers.CanaryService, s.readOnly, s.logger)
+		adminGroup.GET("/overview", overviewHandlers.HandleGetOverview)
+
+		maintenanceHandlers := rest.NewMaintenanceHandlers(s.readOnly, s.logger)
+		adminGroup.GET("/read-only", maintenanceHandlers.HandleGetReadOnly)
+		adminGroup.PUT("/read-only", maintenanceHandlers.HandleSetReadOnly)
 	}
 }
 
@@ -241,6 +342,7 @@ func (s *Server) setupWorkflowRoutes(apiV1 *gin.RouterGroup) {
 		EncryptionSvc:   s.auth.EncryptionService,
 		AuditService:    s.serviceAPI.AuditService,
 		Logger:          s.logger,
+		Idempotency:     s.execution.Idempotency,
 	}
 
 	workflowHandlers := rest.NewWorkflowHandlers(ops, s.logger)
@@ -249,6 +351,12 @@ func (s *Server) setupWorkflowRoutes(apiV1 *gin.RouterGroup) {
 	executionHandlers := rest.NewExecutionHandlers(ops, s.logger)
 	importHandlers := rest.NewImportHandlers(s.data.WorkflowRepo, s.data.TriggerRepo, s.logger, s.execution.ExecutorManager)
 
+	var wsHandler *observer.WebSocketHandler
+	if s.config.Observer.EnableWebSocket && s.execution.WSHub != nil {
+		wsHandler = observer.NewWebSocketHandler(s.execution.WSHub, s.logger)
+	}
+	watchHandlers := rest.NewWorkflowWatchHandlers(ops, wsHandler, s.logger)
+
 	workflows := apiV1.Group("/workflows")
 	workflows.Use(s.auth.AuthMiddleware.OptionalAuth())
 	{
@@ -259,8 +367,17 @@ func (s *Server) setupWorkflowRoutes(apiV1 *gin.RouterGroup) {
 		workflows.POST("/:workflow_id/execute", executionHandlers.HandleRunExecution)
 		workflows.DELETE("/:workflow_id", workflowHandlers.HandleDeleteWorkflow)
 		workflows.POST("/:workflow_id/publish", workflowHandlers.HandlePublishWorkflow)
+		workflows.GET("/:workflow_id/publish/checks", workflowHandlers.HandleCheckWorkflowPublish)
 		workflows.POST("/:workflow_id/unpublish", workflowHandlers.HandleUnpublishWorkflow)
 		workflows.GET("/:workflow_id/diagram", workflowHandlers.HandleGetWorkflowDiagram)
+		workflows.GET("/:workflow_id/watch", watchHandlers.HandleWatchWorkflow)
+		workflows.POST("/:workflow_id/compare", workflowHandlers.HandleCompareWorkflow)
+		workflows.POST("/:workflow_id/dry-run", workflowHandlers.HandleDryRunWorkflow)
+
+		workflows.GET("/:workflow_id/fixtures", workflowHandlers.HandleListWorkflowFixtures)
+		workflows.POST("/:workflow_id/fixtures/run", workflowHandlers.HandleRunWorkflowFixtures)
+		workflows.PUT("/:workflow_id/fixtures/:name", workflowHandlers.HandlePutWorkflowFixture)
+		workflows.DELETE("/:workflow_id/fixtures/:name", workflowHandlers.HandleDeleteWorkflowFixture)
 
 		workflows.POST("/:workflow_id/resources", workflowHandlers.AttachWorkflowResource)
 		workflows.GET("/:workflow_id/resources", workflowHandlers.GetWorkflowResources)
@@ -296,6 +413,7 @@ func (s *Server) setupExecutionRoutes(apiV1 *gin.RouterGroup) {
 		EncryptionSvc:   s.auth.EncryptionService,
 		AuditService:    s.serviceAPI.AuditService,
 		Logger:          s.logger,
+		PayloadArchive:  s.payloadHydrator(),
 	}
 
 	executionHandlers := rest.NewExecutionHandlers(ops, s.logger)
@@ -307,12 +425,82 @@ func (s *Server) setupExecutionRoutes(apiV1 *gin.RouterGroup) {
 		executions.GET("", executionHandlers.HandleListExecutions)
 		executions.GET("/:id", executionHandlers.HandleGetExecution)
 		executions.GET("/:id/logs", executionHandlers.HandleGetLogs)
+		executions.GET("/:id/export", executionHandlers.HandleExportExecution)
 		executions.GET("/:id/nodes/:node_id/result", executionHandlers.HandleGetNodeResult)
-		executions.POST("/:id/cancel", executionHandlers.HandleCancelExecution)
+		executions.POST("/:id/cancel", s.auth.AuthMiddleware.RequireAuth(), executionHandlers.HandleCancelExecution)
+		executions.POST("/:id/pause", s.auth.AuthMiddleware.RequireAuth(), executionHandlers.HandlePauseExecution)
+		executions.POST("/:id/resume", s.auth.AuthMiddleware.RequireAuth(), executionHandlers.HandleResumeExecution)
+		executions.POST("/:id/resume-failed", s.auth.AuthMiddleware.RequireAuth(), executionHandlers.HandleResumeFailedExecution)
+		executions.GET("/:id/breakpoints", executionHandlers.HandleListBreakpoints)
+		executions.POST("/:id/breakpoints/continue", s.auth.AuthMiddleware.RequireAuth(), executionHandlers.HandleContinueFromBreakpoints)
+		executions.POST("/:id/breakpoints/abort", s.auth.AuthMiddleware.RequireAuth(), executionHandlers.HandleAbortAtBreakpoints)
 		executions.POST("/:id/retry", executionHandlers.HandleRetryExecution)
 		executions.GET("/:id/watch", executionHandlers.HandleWatchExecution)
 		executions.GET("/:id/stream", executionHandlers.HandleStreamLogs)
 	}
+
+	noteHandlers := rest.NewExecutionNoteHandlers(ops, s.data.ExecutionNoteRepo, s.logger)
+
+	notes := executions.Group("/:id/notes")
+	{
+		notes.GET("", noteHandlers.HandleListNotes)
+		notes.POST("", s.auth.AuthMiddleware.RequireAuth(), noteHandlers.HandleCreateNote)
+		notes.PATCH("/:note_id", s.auth.AuthMiddleware.RequireAuth(), noteHandlers.HandleUpdateNote)
+		notes.DELETE("/:note_id", s.auth.AuthMiddleware.RequireAuth(), noteHandlers.HandleDeleteNote)
+	}
+
+	incidentHandlers := rest.NewIncidentHandlers(ops, s.triggers.IncidentService, s.logger)
+
+	incidents := executions.Group("/:id/incidents")
+	{
+		incidents.GET("", incidentHandlers.HandleListExecutionIncidents)
+		incidents.POST("", s.auth.AuthMiddleware.RequireAuth(), incidentHandlers.HandleOpenIncident)
+	}
+
+	apiV1.POST("/admin/executions/:id/preempt", s.auth.AuthMiddleware.RequireAdmin(), executionHandlers.HandlePreemptExecution)
+
+	deadLetterHandlers := rest.NewDeadLetterHandlers(ops, s.execution.DeadLetters, s.logger)
+
+	deadLetters := apiV1.Group("/admin/dead-letters")
+	deadLetters.Use(s.auth.AuthMiddleware.RequireAdmin())
+	{
+		deadLetters.GET("", deadLetterHandlers.HandleListDeadLetters)
+		deadLetters.GET("/:id", deadLetterHandlers.HandleGetDeadLetter)
+		deadLetters.POST("/retry", deadLetterHandlers.HandleRetryDeadLetters)
+		deadLetters.POST("/discard", deadLetterHandlers.HandleDiscardDeadLetters)
+	}
+
+	if s.execution.ExecutionArchive != nil {
+		archiveHandlers := rest.NewArchiveHandlers(s.execution.ExecutionArchive, s.logger)
+
+		archives := apiV1.Group("/admin/archives")
+		archives.Use(s.auth.AuthMiddleware.RequireAdmin())
+		{
+			archives.GET("", archiveHandlers.HandleListArchives)
+			archives.POST("/run", archiveHandlers.HandleRunArchive)
+			archives.GET("/:execution_id", archiveHandlers.HandleGetArchive)
+			archives.POST("/:execution_id/restore", archiveHandlers.HandleRestoreArchive)
+		}
+	}
+
+	approvalHandlers := rest.NewApprovalHandlers(ops, s.logger)
+
+	approvals := executions.Group("/:id/approvals")
+	{
+		approvals.GET("", approvalHandlers.HandleListApprovals)
+		approvals.POST("/:node_id/approve", s.auth.AuthMiddleware.RequireAuth(), approvalHandlers.HandleApprove)
+		approvals.POST("/:node_id/reject", s.auth.AuthMiddleware.RequireAuth(), approvalHandlers.HandleReject)
+	}
+
+	eventHandlers := rest.NewEventHandlers(ops, s.logger)
+	apiV1.POST("/events/:correlation_key", s.auth.AuthMiddleware.RequireAuth(), eventHandlers.HandleDeliverEvent)
+
+	inboxHandlers := rest.NewInboxHandlers(inbox.NewService(
+		inbox.FailedExecutions(s.data.InboxRepo),
+		inbox.PendingApprovals(s.data.InboxRepo),
+		inbox.Breakpoints(s.data.InboxRepo),
+	), s.logger)
+	apiV1.GET("/inbox", s.auth.AuthMiddleware.RequireAuth(), inboxHandlers.HandleListInbox)
 }
 
 func (s *Server) setupTriggerRoutes(apiV1 *gin.RouterGroup) {
@@ -326,6 +514,7 @@ func (s *Server) setupTriggerRoutes(apiV1 *gin.RouterGroup) {
 		EncryptionSvc:   s.auth.EncryptionService,
 		AuditService:    s.serviceAPI.AuditService,
 		Logger:          s.logger,
+		TriggerListener: s.triggerListener(),
 	}
 
 	triggerHandlers := rest.NewTriggerHandlers(ops, s.logger)
@@ -398,6 +587,10 @@ func (s *Server) setupAccountRoutes(apiV1 *gin.RouterGroup) {
 		account.POST("/deposit", accountHandlers.Deposit)
 		account.GET("/transactions", accountHandlers.ListTransactions)
 		account.GET("/transactions/:id", accountHandlers.GetTransaction)
+		if s.execution.Quotas != nil {
+			usageHandlers := rest.NewUsageHandlers(s.execution.Quotas, s.logger)
+			account.GET("/usage", usageHandlers.HandleGetUsage)
+		}
 	}
 }
 
@@ -463,6 +656,23 @@ func (s *Server) setupRentalKeyRoutes(apiV1 *gin.RouterGroup) {
 	s.logger.Info("Rental Keys endpoints registered")
 }
 
+// setupLLMCatalogRoutes registers LLM provider and model discovery. Without rental keys
+// models are listed from the catalog only.
+func (s *Server) setupLLMCatalogRoutes(apiV1 *gin.RouterGroup) {
+	var keys llmcatalog.KeySource
+	if s.auth.RentalKeyProvider != nil {
+		keys = s.auth.RentalKeyProvider
+	}
+	llmCatalogHandlers := rest.NewLLMCatalogHandlers(llmcatalog.NewService(keys, llmcatalog.Config{}), s.logger)
+
+	llm := apiV1.Group("/llm")
+	llm.Use(s.auth.AuthMiddleware.RequireAuth())
+	{
+		llm.GET("/providers", llmCatalogHandlers.HandleListProviders)
+		llm.GET("/models", llmCatalogHandlers.HandleListModels)
+	}
+}
+
 func (s *Server) setupServiceKeyRoutes(apiV1 *gin.RouterGroup) {
 	serviceKeyHandlers := rest.NewServiceKeyHandlers(s.auth.ServiceKeyService, s.logger)
 	serviceKeyAdminHandlers := rest.NewServiceKeyAdminHandlers(s.auth.ServiceKeyService, s.logger)
@@ -487,20 +697,51 @@ func (s *Server) setupServiceKeyRoutes(apiV1 *gin.RouterGroup) {
 	s.logger.Info("Service Keys endpoints registered")
 }
 
+func (s *Server) setupOnboardingRoutes(apiV1 *gin.RouterGroup) {
+	ops := &serviceapi.Operations{
+		WorkflowRepo:    s.data.WorkflowRepo,
+		ExecutionRepo:   s.data.ExecutionRepo,
+		TriggerRepo:     s.data.TriggerRepo,
+		CredentialsRepo: s.data.CredentialsRepo,
+		ExecutionMgr:    s.execution.ExecutionManager,
+		ExecutorManager: s.execution.ExecutorManager,
+		EncryptionSvc:   s.auth.EncryptionService,
+		AuditService:    s.serviceAPI.AuditService,
+		Logger:          s.logger,
+		TriggerListener: s.triggerListener(),
+	}
+
+	service := onboarding.NewService(ops, s.data.UserRepo, s.data.ResourceRepo, s.auth.ServiceKeyService, s.logger)
+	onboardingHandlers := rest.NewOnboardingHandlers(service, s.logger)
+
+	apiV1.GET("/onboarding/templates", onboardingHandlers.HandleListTemplates)
+
+	onboardingGroup := apiV1.Group("/onboarding")
+	onboardingGroup.Use(s.auth.AuthMiddleware.RequireAuth())
+	{
+		onboardingGroup.GET("", onboardingHandlers.HandleGetStatus)
+		onboardingGroup.POST("", onboardingHandlers.HandleProvision)
+	}
+}
+
 func (s *Server) setupWebhookRoutes(apiV1 *gin.RouterGroup) {
 	if s.triggers.TriggerManager == nil {
 		return
 	}
 
 	webhookHandlers := rest.NewWebhookHandlers(s.triggers.TriggerManager.WebhookRegistry(), s.logger)
-	apiV1.POST("/webhooks/:path", webhookHandlers.HandleWebhook)
-	apiV1.GET("/webhooks/:path", webhookHandlers.HandleWebhookGet)
+	apiV1.POST("/webhooks/:trigger_id", webhookHandlers.HandleWebhook)
+	apiV1.POST("/webhooks/:trigger_id/*path", webhookHandlers.HandleWebhook)
+	apiV1.GET("/webhooks/:trigger_id", webhookHandlers.HandleWebhookGet)
 
 	telegramWebhookHandlers := rest.NewTelegramWebhookHandlers(s.triggers.TriggerManager.WebhookRegistry(), s.logger)
 	apiV1.POST("/webhooks/telegram/:trigger_id", telegramWebhookHandlers.HandleTelegramWebhook)
 
+	slackWebhookHandlers := rest.NewSlackWebhookHandlers(s.triggers.TriggerManager.SlackRegistry(), s.logger)
+	apiV1.POST("/webhooks/slack/:trigger_id", slackWebhookHandlers.HandleSlackWebhook)
+
 	s.logger.Info("Webhook endpoints registered",
-		"endpoints", []string{"/api/v1/webhooks/:path", "/api/v1/webhooks/telegram/:trigger_id"},
+		"endpoints", []string{"/api/v1/webhooks/:trigger_id", "/api/v1/webhooks/:trigger_id/*path", "/api/v1/webhooks/telegram/:trigger_id", "/api/v1/webhooks/slack/:trigger_id"},
 	)
 }
 
@@ -531,6 +772,9 @@ func (s *Server) setupServiceAPIRoutes(apiV1 *gin.RouterGroup) {
 			EncryptionSvc:   s.auth.EncryptionService,
 			AuditService:    s.serviceAPI.AuditService,
 			Logger:          s.logger,
+			PayloadArchive:  s.payloadHydrator(),
+			TriggerListener: s.triggerListener(),
+			Idempotency:     s.execution.Idempotency,
 		}
 
 		wfh := rest.NewServiceAPIWorkflowHandlers(ops)
@@ -539,6 +783,7 @@ func (s *Server) setupServiceAPIRoutes(apiV1 *gin.RouterGroup) {
 		serviceAPI.POST("/workflows", wfh.CreateWorkflow)
 		serviceAPI.PUT("/workflows/:id", wfh.UpdateWorkflow)
 		serviceAPI.DELETE("/workflows/:id", wfh.DeleteWorkflow)
+		serviceAPI.POST("/workflows/:id/compare", wfh.CompareWorkflow)
 
 		exh := rest.NewServiceAPIExecutionHandlers(ops)
 		serviceAPI.GET("/executions", exh.ListExecutions)
@@ -546,6 +791,9 @@ func (s *Server) setupServiceAPIRoutes(apiV1 *gin.RouterGroup) {
 		serviceAPI.POST("/workflows/:id/execute", exh.StartExecution)
 		serviceAPI.POST("/executions/ephemeral", exh.StartEphemeralExecution)
 		serviceAPI.POST("/executions/:id/cancel", exh.CancelExecution)
+		serviceAPI.POST("/executions/:id/pause", exh.PauseExecution)
+		serviceAPI.POST("/executions/:id/resume", exh.ResumeExecution)
+		serviceAPI.POST("/executions/:id/resume-failed", exh.ResumeFailedExecution)
 		serviceAPI.POST("/executions/:id/retry", exh.RetryExecution)
 
 		trh := rest.NewServiceAPITriggerHandlers(ops)
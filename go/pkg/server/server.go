@@ -16,6 +16,7 @@ import (
 	"github.com/smilemakc/mbflow/go/internal/application/auth"
 	"github.com/smilemakc/mbflow/go/internal/application/engine"
 	"github.com/smilemakc/mbflow/go/internal/application/filestorage"
+	"github.com/smilemakc/mbflow/go/internal/application/maintenance"
 	"github.com/smilemakc/mbflow/go/internal/application/observer"
 	"github.com/smilemakc/mbflow/go/internal/application/servicekey"
 	"github.com/smilemakc/mbflow/go/internal/application/trigger"
@@ -40,6 +41,8 @@ type Server struct {
 	serviceAPI  ServiceAPILayer
 	triggers    TriggerLayer
 	fileStorage FileStorageLayer
+
+	readOnly *maintenance.ReadOnlyMode
 }
 
 // New creates a new server with the given options
@@ -65,6 +68,11 @@ func New(opts ...Option) (*Server, error) {
 		logger.SetDefault(s.logger)
 	}
 
+	s.readOnly = maintenance.NewReadOnlyMode(s.config.Server.ReadOnly, s.config.Server.ReadOnlyMessage)
+	if s.config.Server.ReadOnly {
+		s.logger.Warn("Server started in read-only mode")
+	}
+
 	if err := s.initComponents(); err != nil {
 		return nil, fmt.Errorf("failed to initialize components: %w", err)
 	}
@@ -73,8 +81,11 @@ func New(opts ...Option) (*Server, error) {
 		return nil, fmt.Errorf("failed to setup routes: %w", err)
 	}
 
-	if err := s.setupGRPCServer(); err != nil {
-		return nil, fmt.Errorf("failed to setup gRPC server: %w", err)
+	// Workers serve no API
+	if s.config.Server.Mode != "worker" {
+		if err := s.setupGRPCServer(); err != nil {
+			return nil, fmt.Errorf("failed to setup gRPC server: %w", err)
+		}
 	}
 
 	s.httpServer = &http.Server{
@@ -88,8 +99,13 @@ func New(opts ...Option) (*Server, error) {
 	return s, nil
 }
 
-// Run starts the server and blocks until a shutdown signal is received
+// Run starts the server and blocks until a shutdown signal is received.
+// In worker mode it only runs queued executions.
 func (s *Server) Run() error {
+	if s.config.Server.Mode == "worker" {
+		return s.runWorker()
+	}
+
 	s.logger.Info("Starting MBFlow Server",
 		"version", "1.0.0",
 		"host", s.config.Server.Host,
@@ -132,8 +148,70 @@ func (s *Server) Run() error {
 	}
 }
 
+// runWorker runs queued executions until a shutdown signal is received.
+func (s *Server) runWorker() error {
+	s.logger.Info("Starting MBFlow worker", "version", "1.0.0")
+
+	shutdown := make(chan os.Signal, 1)
+	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
+
+	sig := <-shutdown
+	s.logger.Info("Worker shutdown initiated", "signal", sig)
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.Server.ShutdownTimeout)
+	defer cancel()
+
+	return s.Shutdown(ctx)
+}
+
 // Shutdown gracefully stops the server
 func (s *Server) Shutdown(ctx context.Context) error {
+	if s.execution.ExecutionWorker != nil {
+		s.logger.Info("Stopping execution worker, waiting for running executions...")
+		s.execution.ExecutionWorker.Stop()
+		s.logger.Info("Execution worker stopped")
+	}
+
+	if s.triggers.CanaryService != nil {
+		s.logger.Info("Stopping canary scheduler...")
+		s.triggers.CanaryService.Stop()
+		s.logger.Info("Canary scheduler stopped")
+	}
+
+	if s.execution.StatsRollup != nil {
+		s.logger.Info("Stopping execution stats rollup...")
+		s.execution.StatsRollup.Stop()
+		s.logger.Info("Execution stats rollup stopped")
+	}
+
+	if s.execution.DelayResumer != nil {
+		s.logger.Info("Stopping delay resumer...")
+		s.execution.DelayResumer.Stop()
+		s.logger.Info("Delay resumer stopped")
+	}
+
+	if s.execution.CrashRecovery != nil {
+		s.logger.Info("Stopping crash recovery...")
+		s.execution.CrashRecovery.Stop()
+		s.logger.Info("Crash recovery stopped")
+	}
+
+	if s.execution.ExecutorHealth != nil {
+		s.execution.ExecutorHealth.Stop()
+	}
+
+	if s.execution.PayloadArchive != nil {
+		s.logger.Info("Stopping node payload archive...")
+		s.execution.PayloadArchive.Stop()
+		s.logger.Info("Node payload archive stopped")
+	}
+
+	if s.execution.ExecutionArchive != nil {
+		s.logger.Info("Stopping execution archive...")
+		s.execution.ExecutionArchive.Stop()
+		s.logger.Info("Execution archive stopped")
+	}
+
 	if s.triggers.TriggerManager != nil {
 		s.logger.Info("Stopping trigger manager...")
 		if err := s.triggers.TriggerManager.Stop(); err != nil {
@@ -165,6 +243,33 @@ func (s *Server) Shutdown(ctx context.Context) error {
 		}
 	}
 
+	if s.execution.MongoDBExecutor != nil {
+		s.logger.Info("Closing MongoDB clients...")
+		if err := s.execution.MongoDBExecutor.Close(ctx); err != nil {
+			s.logger.Error("MongoDB clients close failed", "error", err)
+		} else {
+			s.logger.Info("MongoDB clients closed")
+		}
+	}
+
+	if s.execution.RedisExecutor != nil {
+		s.logger.Info("Closing redis executor clients...")
+		if err := s.execution.RedisExecutor.Close(); err != nil {
+			s.logger.Error("Redis executor clients close failed", "error", err)
+		} else {
+			s.logger.Info("Redis executor clients closed")
+		}
+	}
+
+	if s.execution.GRPCCallExecutor != nil {
+		s.logger.Info("Closing grpc_call executor connections...")
+		if err := s.execution.GRPCCallExecutor.Close(); err != nil {
+			s.logger.Error("grpc_call executor connections close failed", "error", err)
+		} else {
+			s.logger.Info("grpc_call executor connections closed")
+		}
+	}
+
 	// Close Redis cache
 	if s.data.RedisCache != nil {
 		s.logger.Info("Closing Redis cache...")
@@ -242,6 +347,11 @@ func (s *Server) FileStorageManager() *filestorage.StorageManager {
 	return s.fileStorage.FileStorageManager
 }
 
+// ReadOnlyMode returns the instance-wide read-only maintenance switch
+func (s *Server) ReadOnlyMode() *maintenance.ReadOnlyMode {
+	return s.readOnly
+}
+
 // WorkflowRepository returns the workflow repository
 func (s *Server) WorkflowRepository() *storage.WorkflowRepository {
 	return s.data.WorkflowRepo
@@ -92,7 +92,7 @@ func (r *MermaidRenderer) Render(workflow *models.Workflow, opts *RenderOptions)
 				// Check if all edges have no conditions
 				allNoConditions := true
 				for _, edge := range edges {
-					if (opts.ShowConditions && edge.Condition != "") || edge.IsLoop() {
+					if (opts.ShowConditions && edge.Condition != "") || edge.IsLoop() || edge.IsCompensation() {
 						allNoConditions = false
 						break
 					}
@@ -297,6 +297,11 @@ func (r *MermaidRenderer) renderEdge(edge *models.Edge, opts *RenderOptions) str
 		return fmt.Sprintf(`%s -. "%s" .-> %s`, edge.From, label, edge.To)
 	}
 
+	// Compensation edges use dotted lines, as they only run on failure
+	if edge.IsCompensation() {
+		return fmt.Sprintf(`%s -. "compensate" .-> %s`, edge.From, edge.To)
+	}
+
 	// Check if edge has a condition
 	if opts.ShowConditions && edge.Condition != "" {
 		// Escape HTML entities in condition
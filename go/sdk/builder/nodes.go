@@ -151,6 +151,14 @@ func MaxParallelism(n int) SubWorkflowOption {
 	return func(nd *models.Node) { nd.Config["max_parallelism"] = n }
 }
 
+func ChunkSize(n int) SubWorkflowOption {
+	return func(nd *models.Node) { nd.Config["chunk_size"] = n }
+}
+
+func ReducerWorkflowID(id string) SubWorkflowOption {
+	return func(n *models.Node) { n.Config["reducer_workflow_id"] = id }
+}
+
 func OnError(strategy string) SubWorkflowOption {
 	return func(n *models.Node) { n.Config["on_error"] = strategy }
 }
@@ -99,6 +99,8 @@ func TestAddSubWorkflowNode(t *testing.T) {
 			builder.ForEach("input.items"),
 			builder.ItemVar("item"),
 			builder.MaxParallelism(5),
+			builder.ChunkSize(500),
+			builder.ReducerWorkflowID("reduce-wf"),
 		).
 		Build()
 
@@ -115,4 +117,10 @@ func TestAddSubWorkflowNode(t *testing.T) {
 	if node.Config["for_each"] != "input.items" {
 		t.Errorf("for_each = %v", node.Config["for_each"])
 	}
+	if node.Config["chunk_size"] != 500 {
+		t.Errorf("chunk_size = %v", node.Config["chunk_size"])
+	}
+	if node.Config["reducer_workflow_id"] != "reduce-wf" {
+		t.Errorf("reducer_workflow_id = %v", node.Config["reducer_workflow_id"])
+	}
 }
@@ -2,6 +2,7 @@ package mbflow
 
 import (
 	"context"
+	"fmt"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -204,6 +205,18 @@ func (e *grpcExecutionService) Retry(ctx context.Context, id string, opts ...Req
 	return grpcclient.ExecutionFromProto(resp.Execution), nil
 }
 
+func (e *grpcExecutionService) Pause(_ context.Context, _ string, _ ...RequestOption) error {
+	return fmt.Errorf("Pause is not supported over gRPC transport; use HTTP transport instead")
+}
+
+func (e *grpcExecutionService) Resume(_ context.Context, _ string, _ ...RequestOption) (*models.Execution, error) {
+	return nil, fmt.Errorf("Resume is not supported over gRPC transport; use HTTP transport instead")
+}
+
+func (e *grpcExecutionService) ResumeFailed(_ context.Context, _ string, _ ...RequestOption) (*models.Execution, error) {
+	return nil, fmt.Errorf("ResumeFailed is not supported over gRPC transport; use HTTP transport instead")
+}
+
 func (e *grpcExecutionService) RunEphemeral(ctx context.Context, req *models.EphemeralExecutionRequest, opts ...RequestOption) (*models.Execution, error) {
 	onBehalfOf := resolveOnBehalfOf(opts)
 	authCtx := e.tr.AuthContext(ctx, onBehalfOf)
@@ -184,6 +184,36 @@ func (e *executionClient) Retry(ctx context.Context, id string, opts ...RequestO
 	return internal.DecodeResponse[models.Execution](resp.Body)
 }
 
+func (e *executionClient) Pause(ctx context.Context, id string, opts ...RequestOption) error {
+	resp, err := e.tr.Do(ctx, &internal.Request{Method: internal.MethodPost, Path: "/executions/" + id + "/pause"})
+	if err != nil {
+		return err
+	}
+	return convertError(resp)
+}
+
+func (e *executionClient) Resume(ctx context.Context, id string, opts ...RequestOption) (*models.Execution, error) {
+	resp, err := e.tr.Do(ctx, &internal.Request{Method: internal.MethodPost, Path: "/executions/" + id + "/resume"})
+	if err != nil {
+		return nil, err
+	}
+	if err := convertError(resp); err != nil {
+		return nil, err
+	}
+	return internal.DecodeResponse[models.Execution](resp.Body)
+}
+
+func (e *executionClient) ResumeFailed(ctx context.Context, id string, opts ...RequestOption) (*models.Execution, error) {
+	resp, err := e.tr.Do(ctx, &internal.Request{Method: internal.MethodPost, Path: "/executions/" + id + "/resume-failed"})
+	if err != nil {
+		return nil, err
+	}
+	if err := convertError(resp); err != nil {
+		return nil, err
+	}
+	return internal.DecodeResponse[models.Execution](resp.Body)
+}
+
 func (e *executionClient) RunEphemeral(ctx context.Context, req *models.EphemeralExecutionRequest, opts ...RequestOption) (*models.Execution, error) {
 	resp, err := e.tr.Do(ctx, &internal.Request{
 		Method: internal.MethodPost,
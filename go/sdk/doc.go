@@ -16,4 +16,12 @@
 //	defer client.Close()
 //
 //	workflows, err := client.Workflows().List(ctx, nil)
+//
+// Interceptors wrap every API call on both transports, e.g. for metrics,
+// logging or custom auth headers:
+//
+//	client, err := mbflow.NewClient(
+//	    mbflow.WithGRPC("localhost:50051"),
+//	    mbflow.WithInterceptor(mbflow.LoggingInterceptor(slog.Default())),
+//	)
 package mbflow
\ No newline at end of file
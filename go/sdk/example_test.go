@@ -38,6 +38,35 @@ func ExampleNewClient() {
 	// Output: Hello
 }
 
+func ExampleWithInterceptor() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"id": "wf-1", "name": "Hello"})
+	}))
+	defer server.Close()
+
+	client, err := mbflow.NewClient(
+		mbflow.WithHTTP(server.URL),
+		mbflow.WithSystemKey("test-key"),
+		mbflow.WithInterceptor(func(ctx context.Context, call *mbflow.Call, next mbflow.Invoker) (*mbflow.CallResult, error) {
+			call.Header["X-Team"] = "payments"
+			result, err := next(ctx, call)
+			if err == nil {
+				fmt.Println(call.Method, call.Path, result.StatusCode)
+			}
+			return result, err
+		}),
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer client.Close()
+
+	if _, err := client.Workflows().Get(context.Background(), "wf-1"); err != nil {
+		panic(err)
+	}
+	// Output: GET /api/v1/service/workflows/wf-1 200
+}
+
 func Example_builder() {
 	wf, err := builder.NewWorkflow("Content Pipeline",
 		builder.WithDescription("Fetch and process content"),
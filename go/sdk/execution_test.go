@@ -80,6 +80,56 @@ func TestExecutions_Cancel(t *testing.T) {
 	}
 }
 
+func TestExecutions_PauseResume(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("method = %s", r.Method)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		switch r.URL.Path {
+		case "/api/v1/service/executions/exec-1/pause":
+			json.NewEncoder(w).Encode(map[string]any{"execution_id": "exec-1"})
+		case "/api/v1/service/executions/exec-1/resume":
+			json.NewEncoder(w).Encode(map[string]any{"id": "exec-1", "status": "running"})
+		default:
+			t.Errorf("path = %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := mbflow.NewClient(mbflow.WithHTTP(server.URL), mbflow.WithSystemKey("key"))
+	if err := client.Executions().Pause(context.Background(), "exec-1"); err != nil {
+		t.Fatalf("Pause: %v", err)
+	}
+	exec, err := client.Executions().Resume(context.Background(), "exec-1")
+	if err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	if exec.Status != models.ExecutionStatusRunning {
+		t.Errorf("Status = %q", exec.Status)
+	}
+}
+
+func TestExecutions_ResumeFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v1/service/executions/exec-1/resume-failed" {
+			t.Errorf("unexpected: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(map[string]any{"id": "exec-1", "status": "running"})
+	}))
+	defer server.Close()
+
+	client, _ := mbflow.NewClient(mbflow.WithHTTP(server.URL), mbflow.WithSystemKey("key"))
+	exec, err := client.Executions().ResumeFailed(context.Background(), "exec-1")
+	if err != nil {
+		t.Fatalf("ResumeFailed: %v", err)
+	}
+	if exec.Status != models.ExecutionStatusRunning {
+		t.Errorf("Status = %q", exec.Status)
+	}
+}
+
 func TestExecutions_List(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		json.NewEncoder(w).Encode(map[string]any{
@@ -0,0 +1,238 @@
+package mbflow
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// ErrNoResponse is returned when an interceptor returns neither a response nor an error.
+var ErrNoResponse = errors.New("mbflow: interceptor returned without calling next")
+
+// Transport names reported in Call.Transport.
+const (
+	TransportHTTP = "http"
+	TransportGRPC = "grpc"
+)
+
+// Call describes a single API call made by the client.
+type Call struct {
+	// Transport is TransportHTTP or TransportGRPC.
+	Transport string
+	// Method is the HTTP method, or the full gRPC method name (e.g. "/serviceapi.MBFlowServiceAPI/GetWorkflow").
+	Method string
+	// Path is the request URL path for HTTP calls and empty for gRPC calls.
+	Path string
+	// Header holds outgoing HTTP headers or gRPC metadata. Interceptors may add, change or delete entries.
+	Header map[string]string
+}
+
+// CallResult describes the outcome of a call.
+type CallResult struct {
+	// StatusCode is the HTTP status. For gRPC it is the HTTP equivalent of the status code, 200 on success.
+	StatusCode int
+}
+
+// Invoker performs a call, or passes it to the next interceptor.
+type Invoker func(ctx context.Context, call *Call) (*CallResult, error)
+
+// Interceptor wraps every API call of the client. It can inspect or modify the call,
+// measure it, or short-circuit it by returning an error without calling next.
+// The result is nil when the call failed before a response was received.
+type Interceptor func(ctx context.Context, call *Call, next Invoker) (*CallResult, error)
+
+// WithInterceptor adds interceptors to both HTTP and gRPC transports.
+// Interceptors run in the order they are added; the first one is the outermost.
+func WithInterceptor(interceptors ...Interceptor) Option {
+	return func(o *clientOptions) error { o.interceptors = append(o.interceptors, interceptors...); return nil }
+}
+
+// LoggingInterceptor logs every call with its status and duration.
+func LoggingInterceptor(logger *slog.Logger) Interceptor {
+	return func(ctx context.Context, call *Call, next Invoker) (*CallResult, error) {
+		start := time.Now()
+		result, err := next(ctx, call)
+
+		attrs := []any{
+			"transport", call.Transport,
+			"method", call.Method,
+			"duration", time.Since(start),
+		}
+		if call.Path != "" {
+			attrs = append(attrs, "path", call.Path)
+		}
+		if result != nil {
+			attrs = append(attrs, "status", result.StatusCode)
+		}
+		if err != nil {
+			logger.ErrorContext(ctx, "mbflow call failed", append(attrs, "error", err)...)
+		} else {
+			logger.DebugContext(ctx, "mbflow call", attrs...)
+		}
+		return result, err
+	}
+}
+
+// chainInterceptors builds an invoker that runs the interceptors around final.
+func chainInterceptors(interceptors []Interceptor, final Invoker) Invoker {
+	invoker := final
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, call *Call) (*CallResult, error) {
+			return interceptor(ctx, call, next)
+		}
+	}
+	return invoker
+}
+
+// interceptorRoundTripper runs interceptors around HTTP requests.
+type interceptorRoundTripper struct {
+	base         http.RoundTripper
+	interceptors []Interceptor
+}
+
+func (rt *interceptorRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	call := &Call{
+		Transport: TransportHTTP,
+		Method:    req.Method,
+		Path:      req.URL.Path,
+		Header:    make(map[string]string, len(req.Header)),
+	}
+	for key := range req.Header {
+		call.Header[key] = req.Header.Get(key)
+	}
+
+	var resp *http.Response
+	invoker := chainInterceptors(rt.interceptors, func(ctx context.Context, call *Call) (*CallResult, error) {
+		out := req.Clone(ctx)
+		for key := range out.Header {
+			if _, ok := call.Header[key]; !ok {
+				out.Header.Del(key)
+			}
+		}
+		for key, value := range call.Header {
+			out.Header.Set(key, value)
+		}
+
+		// Interceptors may call next more than once, e.g. to retry.
+		if resp != nil {
+			resp.Body.Close()
+			resp = nil
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			out.Body = body
+		}
+
+		var err error
+		resp, err = rt.base.RoundTrip(out)
+		if err != nil {
+			return nil, err
+		}
+		return &CallResult{StatusCode: resp.StatusCode}, nil
+	})
+
+	if _, err := invoker(req.Context(), call); err != nil {
+		if resp != nil {
+			resp.Body.Close()
+		}
+		return nil, err
+	}
+	if resp == nil {
+		return nil, ErrNoResponse
+	}
+	return resp, nil
+}
+
+// httpClient returns an HTTP client that runs the interceptors, or nil to use the transport default.
+func (o *clientOptions) httpClient() *http.Client {
+	if len(o.interceptors) == 0 {
+		return nil
+	}
+	return &http.Client{
+		Timeout: o.timeout,
+		Transport: &interceptorRoundTripper{
+			base:         http.DefaultTransport,
+			interceptors: o.interceptors,
+		},
+	}
+}
+
+// grpcDialOptions returns the dial options that install the interceptors.
+func (o *clientOptions) grpcDialOptions() []grpc.DialOption {
+	if len(o.interceptors) == 0 {
+		return nil
+	}
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(unaryClientInterceptor(o.interceptors)),
+		grpc.WithChainStreamInterceptor(streamClientInterceptor(o.interceptors)),
+	}
+}
+
+// grpcCall builds a Call from the outgoing metadata of a gRPC context.
+func grpcCall(ctx context.Context, method string) *Call {
+	call := &Call{Transport: TransportGRPC, Method: method, Header: map[string]string{}}
+	md, _ := metadata.FromOutgoingContext(ctx)
+	for key, values := range md {
+		if len(values) > 0 {
+			call.Header[key] = values[0]
+		}
+	}
+	return call
+}
+
+// grpcResult maps a gRPC call error to a CallResult.
+func grpcResult(err error) *CallResult {
+	if err == nil {
+		return &CallResult{StatusCode: http.StatusOK}
+	}
+	if apiErr, ok := convertGRPCError(err).(*APIError); ok {
+		return &CallResult{StatusCode: apiErr.StatusCode}
+	}
+	return nil
+}
+
+// unaryClientInterceptor adapts interceptors to a gRPC unary client interceptor.
+func unaryClientInterceptor(interceptors []Interceptor) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoke grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		invoked := false
+		invoker := chainInterceptors(interceptors, func(ctx context.Context, call *Call) (*CallResult, error) {
+			invoked = true
+			err := invoke(metadata.NewOutgoingContext(ctx, metadata.New(call.Header)), method, req, reply, cc, opts...)
+			return grpcResult(err), err
+		})
+		_, err := invoker(ctx, grpcCall(ctx, method))
+		if err == nil && !invoked {
+			return ErrNoResponse
+		}
+		return err
+	}
+}
+
+// streamClientInterceptor adapts interceptors to a gRPC stream client interceptor.
+// Interceptors see the opening of the stream, not the individual messages.
+func streamClientInterceptor(interceptors []Interceptor) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		var stream grpc.ClientStream
+		invoker := chainInterceptors(interceptors, func(ctx context.Context, call *Call) (*CallResult, error) {
+			var err error
+			stream, err = streamer(metadata.NewOutgoingContext(ctx, metadata.New(call.Header)), desc, cc, method, opts...)
+			return grpcResult(err), err
+		})
+		if _, err := invoker(ctx, grpcCall(ctx, method)); err != nil {
+			return nil, err
+		}
+		if stream == nil {
+			return nil, ErrNoResponse
+		}
+		return stream, nil
+	}
+}
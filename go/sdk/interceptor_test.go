@@ -0,0 +1,214 @@
+package mbflow_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	mbflow "github.com/smilemakc/mbflow/go/sdk"
+	pb "github.com/smilemakc/mbflow/go/sdk/internal/pb"
+	"github.com/smilemakc/mbflow/go/sdk/models"
+)
+
+func TestInterceptor_HTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer injected" {
+			t.Errorf("Authorization = %q, want injected token", got)
+		}
+		if got := r.Header.Get("X-System-Key"); got != "" {
+			t.Errorf("X-System-Key = %q, want removed", got)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": "wf-1", "name": "Test"})
+	}))
+	defer server.Close()
+
+	var order []string
+	var gotCall mbflow.Call
+	var gotStatus int
+
+	client, err := mbflow.NewClient(
+		mbflow.WithHTTP(server.URL),
+		mbflow.WithSystemKey("key"),
+		mbflow.WithInterceptor(
+			func(ctx context.Context, call *mbflow.Call, next mbflow.Invoker) (*mbflow.CallResult, error) {
+				order = append(order, "outer")
+				result, err := next(ctx, call)
+				if result != nil {
+					gotStatus = result.StatusCode
+				}
+				return result, err
+			},
+			func(ctx context.Context, call *mbflow.Call, next mbflow.Invoker) (*mbflow.CallResult, error) {
+				order = append(order, "inner")
+				gotCall = *call
+				delete(call.Header, "X-System-Key")
+				call.Header["Authorization"] = "Bearer injected"
+				return next(ctx, call)
+			},
+		),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.Workflows().Get(context.Background(), "wf-1"); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if len(order) != 2 || order[0] != "outer" || order[1] != "inner" {
+		t.Errorf("order = %v, want [outer inner]", order)
+	}
+	if gotCall.Transport != mbflow.TransportHTTP || gotCall.Method != "GET" || gotCall.Path != "/api/v1/service/workflows/wf-1" {
+		t.Errorf("unexpected call: %+v", gotCall)
+	}
+	if gotStatus != http.StatusOK {
+		t.Errorf("status = %d, want 200", gotStatus)
+	}
+}
+
+func TestInterceptor_HTTP_ShortCircuit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not reach the server")
+	}))
+	defer server.Close()
+
+	denied := errors.New("denied by policy")
+	client, _ := mbflow.NewClient(
+		mbflow.WithHTTP(server.URL),
+		mbflow.WithSystemKey("key"),
+		mbflow.WithInterceptor(func(ctx context.Context, call *mbflow.Call, next mbflow.Invoker) (*mbflow.CallResult, error) {
+			return nil, denied
+		}),
+	)
+	defer client.Close()
+
+	_, err := client.Workflows().Get(context.Background(), "wf-1")
+	if !errors.Is(err, denied) {
+		t.Errorf("err = %v, want %v", err, denied)
+	}
+}
+
+func TestInterceptor_HTTP_Retry(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := io.ReadAll(r.Body)
+		if len(body) == 0 {
+			t.Errorf("attempt %d: empty request body", attempts)
+		}
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]any{"id": "wf-1", "name": "Test"})
+	}))
+	defer server.Close()
+
+	client, _ := mbflow.NewClient(
+		mbflow.WithHTTP(server.URL),
+		mbflow.WithSystemKey("key"),
+		mbflow.WithInterceptor(func(ctx context.Context, call *mbflow.Call, next mbflow.Invoker) (*mbflow.CallResult, error) {
+			result, err := next(ctx, call)
+			if err == nil && result.StatusCode == http.StatusServiceUnavailable {
+				return next(ctx, call)
+			}
+			return result, err
+		}),
+	)
+	defer client.Close()
+
+	wf, err := client.Workflows().Create(context.Background(), &models.Workflow{Name: "Test"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if wf.ID != "wf-1" || attempts != 2 {
+		t.Errorf("ID = %q, attempts = %d", wf.ID, attempts)
+	}
+}
+
+type interceptorTestServer struct {
+	pb.UnimplementedMBFlowServiceAPIServer
+	t *testing.T
+}
+
+func (s *interceptorTestServer) GetWorkflow(ctx context.Context, req *pb.GetWorkflowRequest) (*pb.WorkflowResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if got := md.Get("x-request-source"); len(got) != 1 || got[0] != "platform" {
+		s.t.Errorf("x-request-source = %v, want [platform]", got)
+	}
+	if req.Id == "missing" {
+		return nil, status.Error(codes.NotFound, "workflow not found")
+	}
+	return &pb.WorkflowResponse{Workflow: &pb.Workflow{Id: req.Id, Name: "Test"}}, nil
+}
+
+func TestInterceptor_GRPC(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	pb.RegisterMBFlowServiceAPIServer(srv, &interceptorTestServer{t: t})
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	var calls []mbflow.Call
+	var statuses []int
+
+	client, err := mbflow.NewClient(
+		mbflow.WithGRPC(lis.Addr().String()),
+		mbflow.WithGRPCInsecure(),
+		mbflow.WithSystemKey("key"),
+		mbflow.WithInterceptor(func(ctx context.Context, call *mbflow.Call, next mbflow.Invoker) (*mbflow.CallResult, error) {
+			call.Header["x-request-source"] = "platform"
+			calls = append(calls, *call)
+			result, err := next(ctx, call)
+			if result != nil {
+				statuses = append(statuses, result.StatusCode)
+			}
+			return result, err
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	wf, err := client.Workflows().Get(context.Background(), "wf-1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if wf.ID != "wf-1" {
+		t.Errorf("ID = %q, want wf-1", wf.ID)
+	}
+
+	_, err = client.Workflows().Get(context.Background(), "missing")
+	if !errors.Is(err, mbflow.ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+
+	if len(calls) != 2 {
+		t.Fatalf("calls = %d, want 2", len(calls))
+	}
+	if calls[0].Transport != mbflow.TransportGRPC || calls[0].Method != pb.MBFlowServiceAPI_GetWorkflow_FullMethodName {
+		t.Errorf("unexpected call: %+v", calls[0])
+	}
+	if calls[0].Header["x-system-key"] != "key" {
+		t.Errorf("x-system-key = %q, want key", calls[0].Header["x-system-key"])
+	}
+	if len(statuses) != 2 || statuses[0] != http.StatusOK || statuses[1] != http.StatusNotFound {
+		t.Errorf("statuses = %v, want [200 404]", statuses)
+	}
+}
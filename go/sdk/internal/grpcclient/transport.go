@@ -17,6 +17,8 @@ type Config struct {
 	SystemKey  string
 	OnBehalfOf string
 	Insecure   bool
+	// DialOptions are appended to the options used to create the connection.
+	DialOptions []grpc.DialOption
 }
 
 // Transport wraps the gRPC connection and provides auth-aware access to the proto client.
@@ -32,6 +34,7 @@ func New(address string, config *Config) (*Transport, error) {
 	if config.Insecure {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
+	opts = append(opts, config.DialOptions...)
 
 	conn, err := grpc.NewClient(address, opts...)
 	if err != nil {
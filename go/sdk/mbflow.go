@@ -34,9 +34,10 @@ func NewClient(opts ...Option) (*Client, error) {
 	switch {
 	case options.grpcAddress != "":
 		grpcTr, err := grpcclient.New(options.grpcAddress, &grpcclient.Config{
-			SystemKey:  options.systemKey,
-			OnBehalfOf: options.onBehalfOf,
-			Insecure:   options.grpcInsecure,
+			SystemKey:   options.systemKey,
+			OnBehalfOf:  options.onBehalfOf,
+			Insecure:    options.grpcInsecure,
+			DialOptions: options.grpcDialOptions(),
 		})
 		if err != nil {
 			return nil, err
@@ -52,6 +53,7 @@ func NewClient(opts ...Option) (*Client, error) {
 			SystemKey:  options.systemKey,
 			OnBehalfOf: options.onBehalfOf,
 			Timeout:    options.timeout,
+			HTTPClient: options.httpClient(),
 		})
 		c.transport = tr
 		c.workflows = newWorkflowClient(tr)
@@ -161,6 +161,18 @@ func (m *ExecutionServiceMock) Retry(_ context.Context, id string, _ ...mbflow.R
 	return nil, fmt.Errorf("mock: unexpected Retry(%q) call", id)
 }
 
+func (m *ExecutionServiceMock) Pause(_ context.Context, id string, _ ...mbflow.RequestOption) error {
+	return fmt.Errorf("mock: unexpected Pause(%q) call", id)
+}
+
+func (m *ExecutionServiceMock) Resume(_ context.Context, id string, _ ...mbflow.RequestOption) (*models.Execution, error) {
+	return nil, fmt.Errorf("mock: unexpected Resume(%q) call", id)
+}
+
+func (m *ExecutionServiceMock) ResumeFailed(_ context.Context, id string, _ ...mbflow.RequestOption) (*models.Execution, error) {
+	return nil, fmt.Errorf("mock: unexpected ResumeFailed(%q) call", id)
+}
+
 func (m *ExecutionServiceMock) RunEphemeral(_ context.Context, _ *models.EphemeralExecutionRequest, _ ...mbflow.RequestOption) (*models.Execution, error) {
 	return nil, fmt.Errorf("mock: unexpected RunEphemeral call")
 }
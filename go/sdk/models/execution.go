@@ -34,6 +34,7 @@ const (
 	ExecutionStatusFailed    ExecutionStatus = "failed"
 	ExecutionStatusCancelled ExecutionStatus = "cancelled"
 	ExecutionStatusTimeout   ExecutionStatus = "timeout"
+	ExecutionStatusPaused    ExecutionStatus = "paused" // waiting for a delay node or an explicit resume
 )
 
 // NodeExecution represents the execution of a single node within a workflow execution.
@@ -66,4 +67,5 @@ const (
 	NodeExecutionStatusFailed    NodeExecutionStatus = "failed"
 	NodeExecutionStatusSkipped   NodeExecutionStatus = "skipped"
 	NodeExecutionStatusCancelled NodeExecutionStatus = "cancelled"
+	NodeExecutionStatusTimedOut  NodeExecutionStatus = "timed_out"
 )
@@ -34,4 +34,35 @@ const (
 	TriggerTypeEvent TriggerType = "event"
 	// TriggerTypeInterval represents an interval-based trigger
 	TriggerTypeInterval TriggerType = "interval"
+	// TriggerTypeEmail represents a trigger fired by new messages in an IMAP mailbox
+	TriggerTypeEmail TriggerType = "email"
+	// TriggerTypeSlack represents a trigger fired by Slack Events API callbacks and slash commands
+	TriggerTypeSlack TriggerType = "slack"
 )
+
+// EmailConfig represents the configuration for an email (IMAP) trigger.
+type EmailConfig struct {
+	Host               string         `json:"host"`
+	Port               int            `json:"port,omitempty"` // Default: 993 (143 for starttls/none)
+	TLS                string         `json:"tls,omitempty"`  // tls (default), starttls, none
+	InsecureSkipVerify bool           `json:"insecure_skip_verify,omitempty"`
+	CredentialID       string         `json:"credential_id"`           // basic_auth credential attached to the workflow
+	Mailbox            string         `json:"mailbox,omitempty"`       // Default: INBOX
+	Mode               string         `json:"mode,omitempty"`          // idle (default) or poll
+	PollInterval       string         `json:"poll_interval,omitempty"` // Duration string, default "60s"
+	MarkSeen           *bool          `json:"mark_seen,omitempty"`     // Default: true
+	ProcessExisting    bool           `json:"process_existing,omitempty"`
+	StorageID          string         `json:"storage_id,omitempty"` // File storage for attachments, default "default"
+	Input              map[string]any `json:"input,omitempty"`
+}
+
+// SlackConfig represents the configuration for a Slack trigger.
+type SlackConfig struct {
+	CredentialID    string         `json:"credential_id"`              // Credential holding the app signing secret
+	EventTypes      []string       `json:"event_types,omitempty"`      // e.g. "message", "app_mention"; empty accepts all
+	Commands        []string       `json:"commands,omitempty"`         // e.g. "/deploy"; empty accepts all
+	Channels        []string       `json:"channels,omitempty"`         // Channel IDs; empty accepts all
+	IncludeBots     bool           `json:"include_bots,omitempty"`     // Default: false (bot messages are ignored)
+	CommandResponse string         `json:"command_response,omitempty"` // Ephemeral reply to slash commands
+	Input           map[string]any `json:"input,omitempty"`
+}
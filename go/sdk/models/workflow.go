@@ -6,19 +6,21 @@ import "time"
 
 // Workflow represents a complete workflow definition with its DAG structure.
 type Workflow struct {
-	ID          string         `json:"id"`
-	Name        string         `json:"name"`
-	Description string         `json:"description,omitempty"`
-	Version     int            `json:"version"`
-	Status      WorkflowStatus `json:"status"`
-	Tags        []string       `json:"tags,omitempty"`
-	Nodes       []*Node        `json:"nodes"`
-	Edges       []*Edge        `json:"edges"`
-	Variables   map[string]any `json:"variables,omitempty"` // Workflow-level variables for template substitution
-	Metadata    map[string]any `json:"metadata,omitempty"`
-	CreatedBy   string         `json:"created_by,omitempty"` // User ID who created the workflow
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
+	ID             string                      `json:"id"`
+	Name           string                      `json:"name"`
+	Description    string                      `json:"description,omitempty"`
+	Version        int                         `json:"version"`
+	Status         WorkflowStatus              `json:"status"`
+	Tags           []string                    `json:"tags,omitempty"`
+	Nodes          []*Node                     `json:"nodes"`
+	Edges          []*Edge                     `json:"edges"`
+	Variables      map[string]any              `json:"variables,omitempty"`       // Workflow-level variables for template substitution
+	LaunchProfiles map[string]*LaunchProfile   `json:"launch_profiles,omitempty"` // Named run configurations, selected with ?profile=<name>
+	Fixtures       map[string]*WorkflowFixture `json:"fixtures,omitempty"`        // Named sample inputs with expected results
+	Metadata       map[string]any              `json:"metadata,omitempty"`
+	CreatedBy      string                      `json:"created_by,omitempty"` // User ID who created the workflow
+	CreatedAt      time.Time                   `json:"created_at"`
+	UpdatedAt      time.Time                   `json:"updated_at"`
 }
 
 // WorkflowStatus represents the status of a workflow.
@@ -31,6 +33,27 @@ const (
 	WorkflowStatusArchived WorkflowStatus = "archived"
 )
 
+// LaunchProfile is a named, predefined way to run a workflow: a base input set
+// plus execution options. Input passed with the run is merged over Input.
+type LaunchProfile struct {
+	Description    string         `json:"description,omitempty"`
+	Input          map[string]any `json:"input,omitempty"`
+	Environment    map[string]any `json:"environment,omitempty"`     // Execution variables ({{env.*}}) layered over workflow variables
+	MaxParallelism int            `json:"max_parallelism,omitempty"` // 0 keeps the engine default
+	TimeoutSeconds int            `json:"timeout_seconds,omitempty"` // 0 keeps the engine default
+}
+
+// WorkflowFixture is a named sample input stored with a workflow, together with the
+// result the workflow is expected to produce for it. Fixtures are the workflow's own
+// smoke tests: they run from the editor and before publishing.
+type WorkflowFixture struct {
+	Description    string          `json:"description,omitempty"`
+	Input          map[string]any  `json:"input,omitempty"`
+	ExpectedStatus ExecutionStatus `json:"expected_status,omitempty"` // Default: completed
+	ExpectedOutput map[string]any  `json:"expected_output,omitempty"` // Subset of the execution output that must match
+	ExpectedError  string          `json:"expected_error,omitempty"`  // Substring of the execution error
+}
+
 // Node represents a single node in the workflow DAG.
 type Node struct {
 	ID          string         `json:"id"`
@@ -19,6 +19,7 @@ type clientOptions struct {
 	retryCount   int
 	retryDelay   time.Duration
 	logger       *slog.Logger
+	interceptors []Interceptor
 }
 
 func defaultOptions() clientOptions {
@@ -22,6 +22,9 @@ type ExecutionService interface {
 	List(ctx context.Context, listOpts *models.ListOptions, opts ...RequestOption) (*models.Page[models.Execution], error)
 	Cancel(ctx context.Context, id string, opts ...RequestOption) (*models.Execution, error)
 	Retry(ctx context.Context, id string, opts ...RequestOption) (*models.Execution, error)
+	Pause(ctx context.Context, id string, opts ...RequestOption) error
+	Resume(ctx context.Context, id string, opts ...RequestOption) (*models.Execution, error)
+	ResumeFailed(ctx context.Context, id string, opts ...RequestOption) (*models.Execution, error)
 	RunEphemeral(ctx context.Context, req *models.EphemeralExecutionRequest, opts ...RequestOption) (*models.Execution, error)
 	StreamEvents(ctx context.Context, executionID string, opts ...RequestOption) (ExecutionEventStream, error)
 }
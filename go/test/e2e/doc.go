@@ -0,0 +1,11 @@
+// Package e2e holds the end-to-end tests of the MBFlow server.
+//
+// The tests start PostgreSQL and Redis in Docker, a mock LLM and mock HTTP
+// endpoints in-process, and run workflows through the real server: REST API,
+// triggers, webhooks, WebSocket watch and HTTP callbacks. Assertions are made
+// against the persisted state in PostgreSQL.
+//
+// The tests are behind the e2e build tag and require Docker:
+//
+//	make e2e
+package e2e
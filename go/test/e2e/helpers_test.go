@@ -0,0 +1,184 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// api sends a JSON request to the server under test and decodes the response into out.
+// It fails the test unless the response status is wantStatus.
+func api(t *testing.T, method, path string, body any, wantStatus int, out any) {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, env.httpSrv.URL+path, reader)
+	require.NoError(t, err)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, wantStatus, resp.StatusCode, "%s %s: %s", method, path, data)
+
+	if out != nil {
+		require.NoError(t, json.Unmarshal(data, out), "%s %s: %s", method, path, data)
+	}
+}
+
+// createWorkflow creates a workflow with the given nodes and edges through the API
+// and returns its ID.
+func createWorkflow(t *testing.T, name string, nodes, edges []map[string]any) string {
+	t.Helper()
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	api(t, http.MethodPost, "/api/v1/workflows", map[string]any{"name": name}, http.StatusCreated, &created)
+	require.NotEmpty(t, created.ID)
+
+	api(t, http.MethodPut, "/api/v1/workflows/"+created.ID, map[string]any{
+		"nodes": nodes,
+		"edges": edges,
+	}, http.StatusOK, nil)
+
+	return created.ID
+}
+
+// orderWorkflow returns the nodes and edges of the representative workflow used by
+// the tests: fetch an order from the upstream API, then summarize it with the LLM.
+func orderWorkflow() (nodes, edges []map[string]any) {
+	nodes = []map[string]any{
+		{
+			"id":   "fetch_order",
+			"name": "Fetch order",
+			"type": "http",
+			"config": map[string]any{
+				"method": "GET",
+				"url":    env.upstream.URL + "/orders/{{input.order_id}}",
+			},
+		},
+		{
+			"id":   "summarize",
+			"name": "Summarize order",
+			"type": "llm",
+			"config": map[string]any{
+				"provider": "openai",
+				"model":    "gpt-4o-mini",
+				"api_key":  "sk-e2e",
+				"base_url": env.llm.URL,
+				"prompt":   "Summarize the order, its status is {{input.body.status}}",
+			},
+		},
+	}
+	edges = []map[string]any{
+		{"id": "fetch_to_summarize", "from": "fetch_order", "to": "summarize"},
+	}
+	return nodes, edges
+}
+
+// persistedExecution is an execution row as stored in PostgreSQL.
+type persistedExecution struct {
+	ID         string
+	WorkflowID string
+	TriggerID  *string
+	Status     string
+	Error      *string
+	Output     map[string]any
+}
+
+// loadExecution reads an execution directly from the database, bypassing the API.
+func loadExecution(t *testing.T, executionID string) persistedExecution {
+	t.Helper()
+
+	var (
+		exec   persistedExecution
+		output []byte
+	)
+	err := env.db.QueryRowContext(context.Background(), `
+		SELECT id, workflow_id, trigger_id, status, error, output_data
+		FROM mbflow_executions
+		WHERE id = ?`, executionID).
+		Scan(&exec.ID, &exec.WorkflowID, &exec.TriggerID, &exec.Status, &exec.Error, &output)
+	require.NoError(t, err)
+	if len(output) > 0 {
+		require.NoError(t, json.Unmarshal(output, &exec.Output))
+	}
+	return exec
+}
+
+// nodeStatuses returns the persisted status of each node execution of an execution,
+// keyed by the node's ID within the workflow.
+func nodeStatuses(t *testing.T, executionID string) map[string]string {
+	t.Helper()
+
+	rows, err := env.db.QueryContext(context.Background(), `
+		SELECT n.node_id, ne.status
+		FROM mbflow_node_executions ne
+		JOIN mbflow_nodes n ON n.id = ne.node_id
+		WHERE ne.execution_id = ?`, executionID)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	statuses := make(map[string]string)
+	for rows.Next() {
+		var nodeID, status string
+		require.NoError(t, rows.Scan(&nodeID, &status))
+		statuses[nodeID] = status
+	}
+	require.NoError(t, rows.Err())
+	return statuses
+}
+
+// waitForExecution polls the database until the execution reaches a terminal status.
+func waitForExecution(t *testing.T, executionID string) persistedExecution {
+	t.Helper()
+
+	var exec persistedExecution
+	eventually(t, 30*time.Second, func() error {
+		exec = loadExecution(t, executionID)
+		switch exec.Status {
+		case "completed", "failed", "cancelled":
+			return nil
+		}
+		return fmt.Errorf("execution %s is %s", executionID, exec.Status)
+	})
+	return exec
+}
+
+// eventually retries cond until it returns nil or the timeout expires.
+func eventually(t *testing.T, timeout time.Duration, cond func() error) {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	for {
+		err := cond()
+		if err == nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("condition not met within %s: %v", timeout, err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
@@ -0,0 +1,200 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+)
+
+// mockLLM is an OpenAI-compatible chat completions endpoint. It answers every
+// request with a fixed completion and records the prompts it received.
+type mockLLM struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	prompts []string
+}
+
+const mockLLMAnswer = "e2e summary"
+
+func newMockLLM() *mockLLM {
+	m := &mockLLM{}
+	m.Server = httptest.NewServer(http.HandlerFunc(m.handle))
+	return m
+}
+
+func (m *mockLLM) handle(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/chat/completions" {
+		http.NotFound(w, r)
+		return
+	}
+
+	var req struct {
+		Model    string `json:"model"`
+		Messages []struct {
+			Role    string `json:"role"`
+			Content any    `json:"content"`
+		} `json:"messages"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	m.mu.Lock()
+	for _, msg := range req.Messages {
+		if text, ok := msg.Content.(string); ok && msg.Role == "user" {
+			m.prompts = append(m.prompts, text)
+		}
+	}
+	m.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"id":      "chatcmpl-e2e",
+		"object":  "chat.completion",
+		"created": time.Now().Unix(),
+		"model":   req.Model,
+		"choices": []map[string]any{{
+			"index":         0,
+			"message":       map[string]any{"role": "assistant", "content": mockLLMAnswer},
+			"finish_reason": "stop",
+		}},
+		"usage": map[string]any{"prompt_tokens": 10, "completion_tokens": 2, "total_tokens": 12},
+	})
+}
+
+// Prompts returns the user prompts received so far.
+func (m *mockLLM) Prompts() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.prompts...)
+}
+
+// recordedRequest is a request received by a mock HTTP endpoint.
+type recordedRequest struct {
+	Method string
+	Path   string
+	Body   []byte
+}
+
+// mockUpstream is the third-party API called by http nodes. It answers
+// GET /orders/{id} with an order and records every request.
+type mockUpstream struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []recordedRequest
+}
+
+func newMockUpstream() *mockUpstream {
+	m := &mockUpstream{}
+	m.Server = httptest.NewServer(http.HandlerFunc(m.handle))
+	return m
+}
+
+func (m *mockUpstream) handle(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	m.mu.Lock()
+	m.requests = append(m.requests, recordedRequest{Method: r.Method, Path: r.URL.Path, Body: body})
+	m.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"path":   r.URL.Path,
+		"status": "shipped",
+		"items":  []string{"book", "pen"},
+	})
+}
+
+// Requests returns the requests received on path.
+func (m *mockUpstream) Requests(path string) []recordedRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []recordedRequest
+	for _, req := range m.requests {
+		if req.Path == path {
+			out = append(out, req)
+		}
+	}
+	return out
+}
+
+// callbackDelivery is an HTTP observer callback as received by mockCallbacks.
+type callbackDelivery struct {
+	EventType   string `json:"event_type"`
+	ExecutionID string `json:"execution_id"`
+	WorkflowID  string `json:"workflow_id"`
+	Status      string `json:"status"`
+
+	// Attempt is 1 for the first delivery of a payload and grows with each retry.
+	Attempt int `json:"-"`
+	// Accepted reports whether the mock answered with 2xx.
+	Accepted bool `json:"-"`
+}
+
+// mockCallbacks receives the server's HTTP observer callbacks. The first delivery
+// of every payload is answered with 503, so each event is only accepted after
+// the observer retried it.
+type mockCallbacks struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	attempts   map[[sha256.Size]byte]int
+	deliveries []callbackDelivery
+}
+
+func newMockCallbacks() *mockCallbacks {
+	m := &mockCallbacks{attempts: make(map[[sha256.Size]byte]int)}
+	m.Server = httptest.NewServer(http.HandlerFunc(m.handle))
+	return m
+}
+
+func (m *mockCallbacks) handle(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var delivery callbackDelivery
+	if err := json.Unmarshal(body, &delivery); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Retries resend the same payload, so its hash identifies the delivery.
+	key := sha256.Sum256(body)
+
+	m.mu.Lock()
+	m.attempts[key]++
+	delivery.Attempt = m.attempts[key]
+	delivery.Accepted = delivery.Attempt > 1
+	m.deliveries = append(m.deliveries, delivery)
+	m.mu.Unlock()
+
+	if !delivery.Accepted {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Deliveries returns the callbacks received for an execution, in arrival order.
+func (m *mockCallbacks) Deliveries(executionID string) []callbackDelivery {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []callbackDelivery
+	for _, d := range m.deliveries {
+		if d.ExecutionID == executionID {
+			out = append(out, d)
+		}
+	}
+	return out
+}
@@ -0,0 +1,186 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestE2E_ExecuteWorkflow(t *testing.T) {
+	nodes, edges := orderWorkflow()
+	workflowID := createWorkflow(t, "e2e-execute", nodes, edges)
+
+	var started struct {
+		ID string `json:"id"`
+	}
+	api(t, http.MethodPost, "/api/v1/workflows/"+workflowID+"/execute", map[string]any{
+		"input": map[string]any{"order_id": "1001"},
+	}, http.StatusAccepted, &started)
+	require.NotEmpty(t, started.ID)
+
+	exec := waitForExecution(t, started.ID)
+	require.Equal(t, "completed", exec.Status, "execution error: %v", exec.Error)
+	assert.Equal(t, workflowID, exec.WorkflowID)
+	assert.Equal(t, map[string]string{
+		"fetch_order": "completed",
+		"summarize":   "completed",
+	}, nodeStatuses(t, started.ID))
+
+	assert.NotEmpty(t, env.upstream.Requests("/orders/1001"), "http node did not call the upstream API")
+	assert.True(t, promptContains("status is shipped"), "llm node did not receive the http node output")
+
+	var fetched struct {
+		Status string `json:"status"`
+	}
+	api(t, http.MethodGet, "/api/v1/executions/"+started.ID, nil, http.StatusOK, &fetched)
+	assert.Equal(t, "completed", fetched.Status)
+}
+
+func TestE2E_WebhookTrigger(t *testing.T) {
+	nodes, edges := orderWorkflow()
+	workflowID := createWorkflow(t, "e2e-webhook", nodes, edges)
+
+	var trigger struct {
+		ID string `json:"id"`
+	}
+	api(t, http.MethodPost, "/api/v1/triggers", map[string]any{
+		"workflow_id": workflowID,
+		"name":        "orders webhook",
+		"type":        "webhook",
+		"config":      map[string]any{},
+		"enabled":     true,
+	}, http.StatusCreated, &trigger)
+	require.NotEmpty(t, trigger.ID)
+
+	// The trigger must be live without a restart: creating it through the API
+	// registers it with the webhook registry.
+	var fired struct {
+		ExecutionID string `json:"execution_id"`
+	}
+	api(t, http.MethodPost, "/api/v1/webhooks/"+trigger.ID, map[string]any{
+		"order_id": "2002",
+	}, http.StatusAccepted, &fired)
+	require.NotEmpty(t, fired.ExecutionID)
+
+	exec := waitForExecution(t, fired.ExecutionID)
+	require.Equal(t, "completed", exec.Status, "execution error: %v", exec.Error)
+	assert.Equal(t, workflowID, exec.WorkflowID)
+	assert.NotEmpty(t, env.upstream.Requests("/orders/2002"))
+
+	var lastTriggered *time.Time
+	require.NoError(t, env.db.QueryRowContext(t.Context(),
+		"SELECT last_triggered_at FROM mbflow_triggers WHERE id = ?", trigger.ID).Scan(&lastTriggered))
+	assert.NotNil(t, lastTriggered, "webhook run was not recorded on the trigger")
+
+	// Disabling the trigger through the API must take effect immediately as well.
+	api(t, http.MethodPost, "/api/v1/triggers/"+trigger.ID+"/disable", nil, http.StatusOK, nil)
+	api(t, http.MethodPost, "/api/v1/webhooks/"+trigger.ID, map[string]any{"order_id": "2003"}, http.StatusNotFound, nil)
+}
+
+func TestE2E_WatchExecutionOverWebSocket(t *testing.T) {
+	nodes, edges := orderWorkflow()
+	workflowID := createWorkflow(t, "e2e-watch", nodes, edges)
+
+	// Subscribe to all executions before starting, so no event can be missed,
+	// and filter on the execution ID client side.
+	wsURL := "ws" + strings.TrimPrefix(env.httpSrv.URL, "http") + "/ws/executions"
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var started struct {
+		ID string `json:"id"`
+	}
+	api(t, http.MethodPost, "/api/v1/workflows/"+workflowID+"/execute", map[string]any{
+		"input": map[string]any{"order_id": "3003"},
+	}, http.StatusAccepted, &started)
+
+	var eventTypes []string
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(30*time.Second)))
+	for {
+		var msg struct {
+			Type  string `json:"type"`
+			Event *struct {
+				EventType   string `json:"event_type"`
+				ExecutionID string `json:"execution_id"`
+			} `json:"event"`
+		}
+		require.NoError(t, conn.ReadJSON(&msg), "events received so far: %v", eventTypes)
+		if msg.Type != "event" || msg.Event == nil || msg.Event.ExecutionID != started.ID {
+			continue
+		}
+		eventTypes = append(eventTypes, msg.Event.EventType)
+		if msg.Event.EventType == "execution.completed" || msg.Event.EventType == "execution.failed" {
+			break
+		}
+	}
+
+	assert.Equal(t, "execution.started", eventTypes[0])
+	assert.Equal(t, "execution.completed", eventTypes[len(eventTypes)-1])
+	assert.Equal(t, 2, count(eventTypes, "node.completed"), "events: %v", eventTypes)
+
+	exec := waitForExecution(t, started.ID)
+	assert.Equal(t, "completed", exec.Status)
+}
+
+func TestE2E_CallbackRetries(t *testing.T) {
+	nodes, edges := orderWorkflow()
+	workflowID := createWorkflow(t, "e2e-callbacks", nodes, edges)
+
+	var started struct {
+		ID string `json:"id"`
+	}
+	api(t, http.MethodPost, "/api/v1/workflows/"+workflowID+"/execute", map[string]any{
+		"input": map[string]any{"order_id": "4004"},
+	}, http.StatusAccepted, &started)
+
+	exec := waitForExecution(t, started.ID)
+	require.Equal(t, "completed", exec.Status, "execution error: %v", exec.Error)
+
+	// The callback mock rejects the first delivery of every event, so each event
+	// must arrive at least twice and end up accepted.
+	eventually(t, 10*time.Second, func() error {
+		rejected := make(map[string]bool)
+		accepted := make(map[string]bool)
+		for _, d := range env.callbacks.Deliveries(started.ID) {
+			if d.Accepted {
+				accepted[d.EventType] = true
+			} else {
+				rejected[d.EventType] = true
+			}
+		}
+		for _, eventType := range []string{"execution.started", "node.completed", "execution.completed"} {
+			if !rejected[eventType] || !accepted[eventType] {
+				return fmt.Errorf("%s: rejected=%t accepted=%t", eventType, rejected[eventType], accepted[eventType])
+			}
+		}
+		return nil
+	})
+}
+
+func promptContains(substr string) bool {
+	for _, prompt := range env.llm.Prompts() {
+		if strings.Contains(prompt, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+func count(values []string, want string) int {
+	n := 0
+	for _, v := range values {
+		if v == want {
+			n++
+		}
+	}
+	return n
+}
@@ -7,6 +7,7 @@ import {
     Check,
     ChevronRight,
     Database,
+    FlaskConical,
     Home,
     Keyboard,
     LayoutTemplate,
@@ -26,6 +27,7 @@ import {
 } from 'lucide-react';
 import {Button} from '@/components/ui';
 import {WorkflowResourcesPanel} from '@/components/builder/WorkflowResourcesPanel';
+import {WorkflowFixturesPanel} from '@/components/builder/WorkflowFixturesPanel';
 
 interface HeaderProps {
     onSave?: () => void;
@@ -69,6 +71,7 @@ export const Header: React.FC<HeaderProps> = ({ onSave }) => {
     const [editedName, setEditedName] = useState(dagName);
     const inputRef = useRef<HTMLInputElement>(null);
     const [showResourcesPanel, setShowResourcesPanel] = useState(false);
+    const [showFixturesPanel, setShowFixturesPanel] = useState(false);
 
     // Sync editedName when dagName changes externally
     useEffect(() => {
@@ -201,6 +204,12 @@ export const Header: React.FC<HeaderProps> = ({ onSave }) => {
                         onClick={() => setShowResourcesPanel(true)}
                         title={t.header.workflowResources}
                     />
+                    <Button
+                        variant="ghost"
+                        icon={<FlaskConical size={18} />}
+                        onClick={() => setShowFixturesPanel(true)}
+                        title={t.header.workflowFixtures}
+                    />
                     <Button
                         variant="ghost"
                         icon={<LayoutTemplate size={18} />}
@@ -293,6 +302,12 @@ export const Header: React.FC<HeaderProps> = ({ onSave }) => {
                 isOpen={showResourcesPanel}
                 onClose={() => setShowResourcesPanel(false)}
             />
+
+            {/* Workflow Fixtures Panel */}
+            <WorkflowFixturesPanel
+                isOpen={showFixturesPanel}
+                onClose={() => setShowFixturesPanel(false)}
+            />
         </header>
     );
 };
\ No newline at end of file
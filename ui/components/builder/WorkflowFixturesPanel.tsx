@@ -0,0 +1,330 @@
+import React, { useCallback, useEffect, useState } from 'react';
+import { CheckCircle2, FlaskConical, Loader2, Pencil, Play, Plus, Trash2, X, XCircle } from 'lucide-react';
+import { useDagStore } from '@/store/dagStore';
+import { useTranslation } from '@/store/translations';
+import { useToast } from '@/hooks/useToast';
+import { workflowService } from '@/services/workflowService';
+import { getErrorMessage } from '@/lib/api';
+import { FixtureExpectedStatus, FixtureResult, WorkflowFixture } from '@/types/workflow';
+import { Button } from '@/components/ui';
+import { FormField, Select, TextInput, Textarea } from '@/components/ui/form';
+
+interface WorkflowFixturesPanelProps {
+  isOpen: boolean;
+  onClose: () => void;
+}
+
+interface FixtureForm {
+  isNew: boolean;
+  name: string;
+  description: string;
+  input: string;
+  expectedStatus: FixtureExpectedStatus;
+  expectedOutput: string;
+  expectedError: string;
+}
+
+const emptyForm: FixtureForm = {
+  isNew: true,
+  name: '',
+  description: '',
+  input: '{}',
+  expectedStatus: 'completed',
+  expectedOutput: '',
+  expectedError: '',
+};
+
+const statusOptions: FixtureExpectedStatus[] = ['completed', 'failed', 'cancelled', 'timeout'];
+
+const toForm = (name: string, fixture: WorkflowFixture): FixtureForm => ({
+  isNew: false,
+  name,
+  description: fixture.description || '',
+  input: JSON.stringify(fixture.input || {}, null, 2),
+  expectedStatus: fixture.expected_status || 'completed',
+  expectedOutput: fixture.expected_output ? JSON.stringify(fixture.expected_output, null, 2) : '',
+  expectedError: fixture.expected_error || '',
+});
+
+const parseObject = (text: string, field: string): Record<string, any> | undefined => {
+  if (!text.trim()) return undefined;
+  const value = JSON.parse(text);
+  if (value === null || typeof value !== 'object' || Array.isArray(value)) {
+    throw new Error(`${field} must be a JSON object`);
+  }
+  return value;
+};
+
+export const WorkflowFixturesPanel: React.FC<WorkflowFixturesPanelProps> = ({ isOpen, onClose }) => {
+  const { dagId } = useDagStore();
+  const t = useTranslation();
+  const { showToast } = useToast();
+
+  const [fixtures, setFixtures] = useState<Record<string, WorkflowFixture>>({});
+  const [results, setResults] = useState<Record<string, FixtureResult>>({});
+  const [isLoading, setIsLoading] = useState(false);
+  const [running, setRunning] = useState<string | null>(null);
+  const [form, setForm] = useState<FixtureForm | null>(null);
+  const [formError, setFormError] = useState<string | null>(null);
+
+  const loadFixtures = useCallback(async () => {
+    if (!dagId) return;
+    setIsLoading(true);
+    try {
+      setFixtures(await workflowService.getFixtures(dagId));
+    } catch (error) {
+      console.error('Failed to load fixtures:', error);
+    } finally {
+      setIsLoading(false);
+    }
+  }, [dagId]);
+
+  useEffect(() => {
+    if (isOpen) {
+      loadFixtures();
+    }
+  }, [isOpen, loadFixtures]);
+
+  const handleSave = async () => {
+    if (!form || !dagId) return;
+
+    let fixture: WorkflowFixture;
+    try {
+      fixture = {
+        description: form.description || undefined,
+        input: parseObject(form.input, 'Input'),
+        expected_status: form.expectedStatus,
+        expected_output: parseObject(form.expectedOutput, 'Expected output'),
+        expected_error: form.expectedError || undefined,
+      };
+    } catch (error) {
+      setFormError(error instanceof Error ? error.message : String(error));
+      return;
+    }
+
+    try {
+      const saved = await workflowService.saveFixture(dagId, form.name.trim(), fixture);
+      setFixtures(prev => ({ ...prev, [form.name.trim()]: saved }));
+      setForm(null);
+      setFormError(null);
+    } catch (error) {
+      setFormError(getErrorMessage(error));
+    }
+  };
+
+  const handleDelete = async (name: string) => {
+    if (!dagId) return;
+    try {
+      await workflowService.deleteFixture(dagId, name);
+      setFixtures(prev => {
+        const next = { ...prev };
+        delete next[name];
+        return next;
+      });
+    } catch (error) {
+      showToast({ type: 'error', title: t.fixtures?.deleteFailed || 'Failed to delete fixture', message: getErrorMessage(error) });
+    }
+  };
+
+  const handleRun = async (name?: string) => {
+    if (!dagId) return;
+    setRunning(name || '*');
+    try {
+      const report = await workflowService.runFixtures(dagId, name ? [name] : undefined);
+      setResults(prev => {
+        const next = { ...prev };
+        report.results.forEach(r => { next[r.name] = r; });
+        return next;
+      });
+      showToast({
+        type: report.passed ? 'success' : 'error',
+        title: report.passed
+          ? (t.fixtures?.allPassed || 'All fixtures passed')
+          : `${report.failed}/${report.total} ${t.fixtures?.failedCount || 'fixtures failed'}`,
+      });
+    } catch (error) {
+      showToast({ type: 'error', title: t.fixtures?.runFailed || 'Failed to run fixtures', message: getErrorMessage(error) });
+    } finally {
+      setRunning(null);
+    }
+  };
+
+  if (!isOpen) return null;
+
+  const names = Object.keys(fixtures).sort();
+
+  return (
+    <div className="fixed inset-0 z-50 flex items-center justify-center bg-black/50 backdrop-blur-sm">
+      <div className="w-full max-w-3xl max-h-[85vh] bg-white dark:bg-slate-900 rounded-2xl shadow-2xl border border-slate-200 dark:border-slate-800 overflow-hidden flex flex-col">
+        {/* Header */}
+        <div className="p-4 border-b border-slate-100 dark:border-slate-800 flex justify-between items-center bg-slate-50 dark:bg-slate-800/50">
+          <div className="flex items-center gap-3">
+            <div className="p-2 bg-emerald-100 dark:bg-emerald-900/30 rounded-lg">
+              <FlaskConical size={20} className="text-emerald-600 dark:text-emerald-400" />
+            </div>
+            <div>
+              <h2 className="font-bold text-slate-800 dark:text-slate-100">
+                {t.fixtures?.title || 'Test Fixtures'}
+              </h2>
+              <p className="text-xs text-slate-500 dark:text-slate-400">
+                {t.fixtures?.subtitle || 'Sample inputs with expected results, run before publishing'}
+              </p>
+            </div>
+          </div>
+          <Button variant="ghost" size="sm" icon={<X size={18} />} onClick={onClose} />
+        </div>
+
+        {!dagId ? (
+          <div className="p-8 text-center text-sm text-slate-500">
+            {t.fixtures?.saveFirst || 'Save the workflow to add fixtures.'}
+          </div>
+        ) : (
+          <div className="flex-1 overflow-y-auto p-4 space-y-4">
+            {form ? (
+              <div className="p-4 bg-slate-50 dark:bg-slate-800/50 rounded-lg space-y-3">
+                <div className="grid grid-cols-2 gap-3">
+                  <FormField label={t.fixtures?.name || 'Name'} required>
+                    <TextInput
+                      value={form.name}
+                      onChange={name => setForm({ ...form, name })}
+                      placeholder="happy_path"
+                      disabled={!form.isNew}
+                    />
+                  </FormField>
+                  <FormField label={t.fixtures?.expectedStatus || 'Expected status'}>
+                    <Select
+                      value={form.expectedStatus}
+                      onChange={value => setForm({ ...form, expectedStatus: value as FixtureExpectedStatus })}
+                      options={statusOptions}
+                    />
+                  </FormField>
+                </div>
+                <FormField label={t.fixtures?.description || 'Description'}>
+                  <TextInput value={form.description} onChange={description => setForm({ ...form, description })} />
+                </FormField>
+                <FormField label={t.fixtures?.input || 'Input (JSON)'}>
+                  <Textarea value={form.input} onChange={input => setForm({ ...form, input })} monospace rows={5} />
+                </FormField>
+                <FormField
+                  label={t.fixtures?.expectedOutput || 'Expected output (JSON)'}
+                  hint={t.fixtures?.expectedOutputHint || 'Only the listed keys are compared; other output keys are ignored.'}
+                >
+                  <Textarea value={form.expectedOutput} onChange={expectedOutput => setForm({ ...form, expectedOutput })} monospace rows={5} />
+                </FormField>
+                {form.expectedStatus !== 'completed' && (
+                  <FormField label={t.fixtures?.expectedError || 'Expected error contains'}>
+                    <TextInput value={form.expectedError} onChange={expectedError => setForm({ ...form, expectedError })} />
+                  </FormField>
+                )}
+                {formError && <p className="text-sm text-red-600 dark:text-red-400">{formError}</p>}
+                <div className="flex justify-end gap-2">
+                  <Button variant="ghost" size="sm" onClick={() => { setForm(null); setFormError(null); }}>
+                    {t.common.cancel}
+                  </Button>
+                  <Button variant="primary" size="sm" onClick={handleSave} disabled={!form.name.trim()}>
+                    {t.common.save}
+                  </Button>
+                </div>
+              </div>
+            ) : (
+              <div className="flex justify-between">
+                <Button variant="outline" size="sm" icon={<Plus size={16} />} onClick={() => setForm({ ...emptyForm })}>
+                  {t.fixtures?.add || 'Add fixture'}
+                </Button>
+                <Button
+                  variant="primary"
+                  size="sm"
+                  icon={running === '*' ? <Loader2 size={16} className="animate-spin" /> : <Play size={16} />}
+                  onClick={() => handleRun()}
+                  disabled={names.length === 0 || running !== null}
+                >
+                  {t.fixtures?.runAll || 'Run all'}
+                </Button>
+              </div>
+            )}
+
+            {isLoading ? (
+              <div className="flex justify-center py-8">
+                <Loader2 size={24} className="animate-spin text-slate-400" />
+              </div>
+            ) : names.length === 0 ? (
+              <div className="text-center py-8 text-slate-400">
+                <FlaskConical size={32} className="mx-auto mb-2 opacity-50" />
+                <p className="text-sm">{t.fixtures?.empty || 'No fixtures yet'}</p>
+              </div>
+            ) : (
+              <div className="space-y-2">
+                {names.map(name => {
+                  const fixture = fixtures[name];
+                  const result = results[name];
+                  return (
+                    <div key={name} className="p-3 bg-slate-50 dark:bg-slate-800/50 rounded-lg group">
+                      <div className="flex items-center gap-3">
+                        {result ? (
+                          result.passed
+                            ? <CheckCircle2 size={16} className="text-green-500 shrink-0" />
+                            : <XCircle size={16} className="text-red-500 shrink-0" />
+                        ) : (
+                          <FlaskConical size={16} className="text-slate-400 shrink-0" />
+                        )}
+                        <div className="flex-1 min-w-0">
+                          <span className="font-mono text-sm text-slate-900 dark:text-white">{name}</span>
+                          <span className="ml-2 text-xs text-slate-500">
+                            {fixture.expected_status || 'completed'}
+                            {fixture.description ? ` · ${fixture.description}` : ''}
+                          </span>
+                        </div>
+                        {result && <span className="text-xs text-slate-400">{result.duration_ms} ms</span>}
+                        <div className="flex items-center gap-1">
+                          <Button
+                            variant="ghost"
+                            size="sm"
+                            icon={running === name ? <Loader2 size={14} className="animate-spin" /> : <Play size={14} />}
+                            onClick={() => handleRun(name)}
+                            disabled={running !== null}
+                            title={t.fixtures?.run || 'Run'}
+                          />
+                          <Button
+                            variant="ghost"
+                            size="sm"
+                            icon={<Pencil size={14} />}
+                            onClick={() => { setForm(toForm(name, fixture)); setFormError(null); }}
+                            title={t.common.edit}
+                          />
+                          <Button
+                            variant="ghost"
+                            size="sm"
+                            icon={<Trash2 size={14} />}
+                            onClick={() => handleDelete(name)}
+                            title={t.common.delete}
+                            className="text-red-500 hover:text-red-600 hover:bg-red-100 dark:hover:bg-red-900/30"
+                          />
+                        </div>
+                      </div>
+                      {result && !result.passed && result.failures && (
+                        <ul className="mt-2 ml-7 space-y-1">
+                          {result.failures.map((failure, i) => (
+                            <li key={i} className="text-xs font-mono text-red-600 dark:text-red-400">{failure}</li>
+                          ))}
+                        </ul>
+                      )}
+                    </div>
+                  );
+                })}
+              </div>
+            )}
+          </div>
+        )}
+
+        {/* Footer */}
+        <div className="p-4 border-t border-slate-100 dark:border-slate-800 flex justify-end bg-slate-50 dark:bg-slate-800/50">
+          <Button variant="outline" onClick={onClose}>
+            {t.common.close}
+          </Button>
+        </div>
+      </div>
+    </div>
+  );
+};
+
+export default WorkflowFixturesPanel;
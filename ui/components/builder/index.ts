@@ -11,4 +11,5 @@ export {QuickAddMenu} from './QuickAddMenu';
 export {VariableAutocomplete} from './VariableAutocomplete';
 export {WorkflowVariablesGuide} from './WorkflowVariablesGuide';
 export {WorkflowResourcesPanel} from './WorkflowResourcesPanel';
+export {WorkflowFixturesPanel} from './WorkflowFixturesPanel';
 export {ResourceSelector} from './ResourceSelector';
@@ -0,0 +1,187 @@
+import React, { useCallback, useEffect, useState } from 'react';
+import { Loader2, MessageSquare, Pencil, Trash2 } from 'lucide-react';
+import { Button } from '../ui';
+import { Select, Textarea } from '../ui/form';
+import { executionService } from '@/services/executionService';
+import { getErrorMessage } from '@/lib/api';
+import { useAuthStore } from '@/store/authStore';
+import { useTranslation } from '@/store/translations';
+import { useToast } from '@/hooks/useToast';
+import { ExecutionNote, NodeExecution } from '@/types/execution';
+import { formatDate } from './executionUtils';
+
+interface ExecutionNotesPanelProps {
+  executionId: string;
+  nodeExecutions?: NodeExecution[];
+}
+
+export const ExecutionNotesPanel: React.FC<ExecutionNotesPanelProps> = ({ executionId, nodeExecutions }) => {
+  const t = useTranslation();
+  const { showToast } = useToast();
+  const { user, isAuthenticated, isAdmin } = useAuthStore();
+
+  const [notes, setNotes] = useState<ExecutionNote[]>([]);
+  const [isLoading, setIsLoading] = useState(true);
+  const [body, setBody] = useState('');
+  const [nodeId, setNodeId] = useState('');
+  const [isSaving, setIsSaving] = useState(false);
+  const [editingId, setEditingId] = useState<string | null>(null);
+  const [editBody, setEditBody] = useState('');
+
+  const loadNotes = useCallback(async () => {
+    try {
+      setNotes(await executionService.getNotes(executionId));
+    } catch (error) {
+      console.error('Failed to load execution notes:', error);
+    } finally {
+      setIsLoading(false);
+    }
+  }, [executionId]);
+
+  useEffect(() => {
+    loadNotes();
+  }, [loadNotes]);
+
+  const nodeOptions = [
+    { value: '', label: t.executionDetail?.wholeExecution || 'Whole execution' },
+    ...(nodeExecutions || []).map(ne => ({ value: ne.node_id, label: ne.node_name || ne.node_id })),
+  ];
+
+  const nodeLabel = (id: string) => nodeExecutions?.find(ne => ne.node_id === id)?.node_name || id;
+
+  const canChange = (note: ExecutionNote) => isAdmin() || (!!user && user.id === note.author_id);
+
+  const handleAdd = async () => {
+    if (!body.trim()) return;
+
+    setIsSaving(true);
+    try {
+      const note = await executionService.addNote(executionId, body, nodeId || undefined);
+      setNotes(prev => [...prev, note]);
+      setBody('');
+    } catch (error) {
+      showToast({ type: 'error', title: t.executionDetail?.noteFailed || 'Failed to save note', message: getErrorMessage(error) });
+    } finally {
+      setIsSaving(false);
+    }
+  };
+
+  const handleUpdate = async (note: ExecutionNote) => {
+    if (!editBody.trim()) return;
+
+    try {
+      const updated = await executionService.updateNote(executionId, note.id, editBody);
+      setNotes(prev => prev.map(n => (n.id === note.id ? updated : n)));
+      setEditingId(null);
+    } catch (error) {
+      showToast({ type: 'error', title: t.executionDetail?.noteFailed || 'Failed to save note', message: getErrorMessage(error) });
+    }
+  };
+
+  const handleDelete = async (note: ExecutionNote) => {
+    try {
+      await executionService.deleteNote(executionId, note.id);
+      setNotes(prev => prev.filter(n => n.id !== note.id));
+    } catch (error) {
+      showToast({ type: 'error', title: t.executionDetail?.noteDeleteFailed || 'Failed to delete note', message: getErrorMessage(error) });
+    }
+  };
+
+  return (
+    <div className="space-y-4">
+      {isLoading ? (
+        <div className="flex items-center justify-center py-12">
+          <Loader2 className="animate-spin text-blue-600 dark:text-blue-400" size={28} />
+        </div>
+      ) : notes.length > 0 ? (
+        <div className="space-y-3">
+          {notes.map(note => (
+            <div
+              key={note.id}
+              className="bg-white dark:bg-slate-900 rounded-xl border border-slate-200 dark:border-slate-800 p-4"
+            >
+              <div className="flex items-center justify-between gap-3 mb-2">
+                <div className="flex items-center gap-2 text-sm">
+                  <span className="font-medium text-slate-900 dark:text-white">
+                    {note.author_name || t.executionDetail?.unknownAuthor || 'Unknown'}
+                  </span>
+                  <span className="text-slate-500 dark:text-slate-400">{formatDate(note.created_at)}</span>
+                  {note.node_id && (
+                    <span className="bg-slate-100 dark:bg-slate-800 text-slate-600 dark:text-slate-400 px-2 py-0.5 rounded-full text-xs font-mono">
+                      {nodeLabel(note.node_id)}
+                    </span>
+                  )}
+                </div>
+                {canChange(note) && editingId !== note.id && (
+                  <div className="flex items-center gap-1">
+                    <Button
+                      variant="ghost"
+                      size="sm"
+                      onClick={() => {
+                        setEditingId(note.id);
+                        setEditBody(note.body);
+                      }}
+                      icon={<Pencil size={14} />}
+                      title={t.executionDetail?.editNote || 'Edit'}
+                    />
+                    <Button
+                      variant="ghost"
+                      size="sm"
+                      onClick={() => handleDelete(note)}
+                      icon={<Trash2 size={14} />}
+                      title={t.executionDetail?.deleteNote || 'Delete'}
+                    />
+                  </div>
+                )}
+              </div>
+
+              {editingId === note.id ? (
+                <div className="space-y-2">
+                  <Textarea value={editBody} onChange={setEditBody} rows={3} />
+                  <div className="flex justify-end gap-2">
+                    <Button variant="ghost" size="sm" onClick={() => setEditingId(null)}>
+                      {t.executionDetail?.cancelEdit || 'Cancel'}
+                    </Button>
+                    <Button variant="primary" size="sm" onClick={() => handleUpdate(note)}>
+                      {t.executionDetail?.saveNote || 'Save'}
+                    </Button>
+                  </div>
+                </div>
+              ) : (
+                <p className="text-sm text-slate-700 dark:text-slate-300 whitespace-pre-wrap break-words">{note.body}</p>
+              )}
+            </div>
+          ))}
+        </div>
+      ) : (
+        <div className="bg-white dark:bg-slate-900 rounded-xl border border-slate-200 dark:border-slate-800 p-8 text-center">
+          <MessageSquare size={40} className="mx-auto text-slate-300 dark:text-slate-600 mb-3" />
+          <p className="text-slate-500 dark:text-slate-400">{t.executionDetail?.noNotes || 'No notes yet'}</p>
+        </div>
+      )}
+
+      {isAuthenticated ? (
+        <div className="bg-white dark:bg-slate-900 rounded-xl border border-slate-200 dark:border-slate-800 p-4 space-y-3">
+          <Textarea
+            value={body}
+            onChange={setBody}
+            rows={3}
+            placeholder={t.executionDetail?.notePlaceholder || 'Record findings, causes or follow-ups...'}
+          />
+          <div className="flex items-center justify-between gap-3">
+            <div className="w-64">
+              <Select value={nodeId} onChange={setNodeId} options={nodeOptions} />
+            </div>
+            <Button variant="primary" size="sm" onClick={handleAdd} loading={isSaving} disabled={!body.trim()}>
+              {t.executionDetail?.addNote || 'Add Note'}
+            </Button>
+          </div>
+        </div>
+      ) : (
+        <p className="text-sm text-slate-500 dark:text-slate-400">
+          {t.executionDetail?.signInToNote || 'Sign in to add notes'}
+        </p>
+      )}
+    </div>
+  );
+};
@@ -3,6 +3,7 @@ export { JsonViewer } from './JsonViewer';
 export { NodeExecutionCard } from './NodeExecutionCard';
 export { ExecutionStatsGrid } from './ExecutionStatsGrid';
 export { WebSocketStatusBadge } from './WebSocketStatusBadge';
+export { ExecutionNotesPanel } from './ExecutionNotesPanel';
 export {
   getStatusIcon,
   getStatusBadgeClass,
@@ -21,6 +21,8 @@ export const SubWorkflowNodeConfigComponent: React.FC<SubWorkflowNodeConfigProps
         item_var: config.item_var || 'item',
         max_parallelism: config.max_parallelism ?? 0,
         on_error: config.on_error || 'fail_fast',
+        chunk_size: config.chunk_size ?? 0,
+        reducer_workflow_id: config.reducer_workflow_id || '',
     });
 
     useEffect(() => {
@@ -30,6 +32,8 @@ export const SubWorkflowNodeConfigComponent: React.FC<SubWorkflowNodeConfigProps
             item_var: config.item_var || 'item',
             max_parallelism: config.max_parallelism ?? 0,
             on_error: config.on_error || 'fail_fast',
+            chunk_size: config.chunk_size ?? 0,
+            reducer_workflow_id: config.reducer_workflow_id || '',
         };
         if (JSON.stringify(newConfig) !== JSON.stringify(localConfig)) {
             setLocalConfig(newConfig);
@@ -116,6 +120,40 @@ export const SubWorkflowNodeConfigComponent: React.FC<SubWorkflowNodeConfigProps
                 </p>
             </div>
 
+            {/* Chunk Size */}
+            <div className="flex flex-col gap-1.5">
+                <label className="text-[13px] font-semibold text-slate-700 dark:text-slate-300">
+                    {tc.chunkSize}
+                </label>
+                <input
+                    type="number"
+                    min={0}
+                    value={localConfig.chunk_size ?? 0}
+                    onChange={e => handleChange('chunk_size', parseInt(e.target.value) || 0)}
+                    className="w-full px-3 py-2 border border-slate-200 dark:border-slate-700 rounded-lg text-sm bg-white dark:bg-slate-900 text-slate-900 dark:text-slate-100 focus:outline-none focus:ring-2 focus:ring-indigo-500 dark:focus:ring-indigo-400 focus:border-transparent"
+                />
+                <p className="text-xs text-slate-500 dark:text-slate-400">
+                    {tc.chunkSizeHint}
+                </p>
+            </div>
+
+            {/* Reducer Workflow ID */}
+            <div className="flex flex-col gap-1.5">
+                <label className="text-[13px] font-semibold text-slate-700 dark:text-slate-300">
+                    {tc.reducerWorkflowId}
+                </label>
+                <input
+                    type="text"
+                    value={localConfig.reducer_workflow_id || ''}
+                    onChange={e => handleChange('reducer_workflow_id', e.target.value)}
+                    placeholder={tc.reducerWorkflowIdPlaceholder}
+                    className="w-full px-3 py-2 border border-slate-200 dark:border-slate-700 rounded-lg text-sm font-mono bg-white dark:bg-slate-900 text-slate-900 dark:text-slate-100 focus:outline-none focus:ring-2 focus:ring-indigo-500 dark:focus:ring-indigo-400 focus:border-transparent"
+                />
+                <p className="text-xs text-slate-500 dark:text-slate-400">
+                    {tc.reducerWorkflowIdHint}
+                </p>
+            </div>
+
             {/* Error Handling */}
             <div className="flex flex-col gap-1.5">
                 <label className="text-[13px] font-semibold text-slate-700 dark:text-slate-300">
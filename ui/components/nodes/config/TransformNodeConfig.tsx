@@ -1,7 +1,7 @@
 import React from 'react';
-import { Zap, ArrowRight, FileText, Code } from 'lucide-react';
+import { Zap, ArrowRight, FileText, Code, Table, FileCode } from 'lucide-react';
 import type {TransformNodeConfig} from '@/types/nodeConfigs';
-import {TRANSFORM_TYPES} from '@/types/nodeConfigs';
+import {CSV_HEADER_MODES, TRANSFORM_TYPES, XML_OPERATIONS} from '@/types/nodeConfigs';
 import {VariableAutocomplete} from '@/components/builder/VariableAutocomplete';
 import {useTranslation} from '@/store/translations';
 
@@ -24,6 +24,30 @@ export const TransformNodeConfigComponent: React.FC<TransformNodeConfigProps> =
         expression: config?.expression || '',
         filter: config?.filter || '.',
         timeout_seconds: config?.timeout_seconds ?? 10,
+        delimiter: config?.delimiter,
+        header: config?.header,
+        columns: config?.columns,
+        infer_types: config?.infer_types,
+        include_header: config?.include_header,
+        operation: config?.operation,
+        xpath: config?.xpath,
+        namespaces: config?.namespaces,
+        keep_namespaces: config?.keep_namespaces,
+        force_array: config?.force_array,
+        root_name: config?.root_name,
+        output: config?.output,
+    };
+    const isCSV = safeConfig.type === 'csv_parse' || safeConfig.type === 'csv_generate';
+    const headerLabels: Record<(typeof CSV_HEADER_MODES)[number], string> = {
+        first_row: t.nodeConfig.transform.csvHeaderFirstRow,
+        none: t.nodeConfig.transform.csvHeaderNone,
+        auto: t.nodeConfig.transform.csvHeaderAuto,
+    };
+    const xmlOperation = safeConfig.operation ?? 'parse';
+    const operationLabels: Record<(typeof XML_OPERATIONS)[number], string> = {
+        parse: t.nodeConfig.transform.xmlParse,
+        serialize: t.nodeConfig.transform.xmlSerialize,
+        xpath: t.nodeConfig.transform.xmlXPath,
     };
 
     // Handlers call onChange directly with safeConfig spread
@@ -47,6 +71,32 @@ export const TransformNodeConfigComponent: React.FC<TransformNodeConfigProps> =
         onChange({...safeConfig, timeout_seconds});
     };
 
+    const handleColumnsChange = (value: string) => {
+        const columns = value.split(',').map((c) => c.trim()).filter(Boolean);
+        onChange({...safeConfig, columns: columns.length > 0 ? columns : undefined});
+    };
+
+    const handleForceArrayChange = (value: string) => {
+        const names = value.split(',').map((n) => n.trim()).filter(Boolean);
+        onChange({...safeConfig, force_array: names.length > 0 ? names : undefined});
+    };
+
+    // XPath is a single expression, or a JSON object of name to expression
+    const xpathText = typeof safeConfig.xpath === 'object'
+        ? JSON.stringify(safeConfig.xpath, null, 2)
+        : safeConfig.xpath ?? '';
+    const handleXPathChange = (value: string) => {
+        if (value.trim().startsWith('{')) {
+            try {
+                onChange({...safeConfig, xpath: JSON.parse(value)});
+                return;
+            } catch {
+                // Keep the text until the object is complete
+            }
+        }
+        onChange({...safeConfig, xpath: value || undefined});
+    };
+
     return (
         <div className="space-y-6">
             {/* Header */}
@@ -113,6 +163,30 @@ export const TransformNodeConfigComponent: React.FC<TransformNodeConfigProps> =
                             </div>
                         </div>
                     )}
+                    {safeConfig.type === 'csv_parse' && (
+                        <div className="flex items-start gap-2">
+                            <Table size={14} className="text-amber-500 flex-shrink-0 mt-0.5" />
+                            <div>
+                                <strong className="text-slate-700 dark:text-slate-300">{t.nodeConfig.transform.csvParse}:</strong> {t.nodeConfig.transform.csvParseDesc}
+                            </div>
+                        </div>
+                    )}
+                    {safeConfig.type === 'csv_generate' && (
+                        <div className="flex items-start gap-2">
+                            <Table size={14} className="text-amber-500 flex-shrink-0 mt-0.5" />
+                            <div>
+                                <strong className="text-slate-700 dark:text-slate-300">{t.nodeConfig.transform.csvGenerate}:</strong> {t.nodeConfig.transform.csvGenerateDesc}
+                            </div>
+                        </div>
+                    )}
+                    {safeConfig.type === 'xml' && (
+                        <div className="flex items-start gap-2">
+                            <FileCode size={14} className="text-amber-500 flex-shrink-0 mt-0.5" />
+                            <div>
+                                <strong className="text-slate-700 dark:text-slate-300">{t.nodeConfig.transform.xml}:</strong> {t.nodeConfig.transform.xmlDesc}
+                            </div>
+                        </div>
+                    )}
                 </div>
             </div>
 
@@ -196,6 +270,185 @@ export const TransformNodeConfigComponent: React.FC<TransformNodeConfigProps> =
                 </div>
             )}
 
+            {/* CSV options (only for types: csv_parse, csv_generate) */}
+            {isCSV && (
+                <div className="space-y-3">
+                    <label className="block">
+                        <span className="text-sm font-semibold text-slate-700 dark:text-slate-300 mb-2 block">
+                            {t.nodeConfig.transform.csvDelimiter}
+                        </span>
+                        <input
+                            type="text"
+                            value={safeConfig.delimiter ?? ''}
+                            onChange={(e) => onChange({...safeConfig, delimiter: e.target.value || undefined})}
+                            placeholder=","
+                            className="w-full px-3 py-2 bg-white dark:bg-slate-950 border border-slate-300 dark:border-slate-700 rounded-lg text-slate-900 dark:text-white focus:outline-none focus:ring-2 focus:ring-amber-500 text-sm font-mono"
+                        />
+                        <span className="text-xs text-slate-500 dark:text-slate-400 mt-1 block">
+                            {t.nodeConfig.transform.csvDelimiterHint}
+                        </span>
+                    </label>
+
+                    {safeConfig.type === 'csv_parse' && (
+                        <label className="block">
+                            <span className="text-sm font-semibold text-slate-700 dark:text-slate-300 mb-2 block">
+                                {t.nodeConfig.transform.csvHeader}
+                            </span>
+                            <select
+                                value={safeConfig.header ?? 'first_row'}
+                                onChange={(e) => onChange({...safeConfig, header: e.target.value as TransformNodeConfig['header']})}
+                                className="w-full px-3 py-2 bg-white dark:bg-slate-950 border border-slate-300 dark:border-slate-700 rounded-lg text-slate-900 dark:text-white focus:outline-none focus:ring-2 focus:ring-amber-500 text-sm"
+                            >
+                                {CSV_HEADER_MODES.map((mode) => (
+                                    <option key={mode} value={mode}>
+                                        {headerLabels[mode]}
+                                    </option>
+                                ))}
+                            </select>
+                        </label>
+                    )}
+
+                    <label className="block">
+                        <span className="text-sm font-semibold text-slate-700 dark:text-slate-300 mb-2 block">
+                            {t.nodeConfig.transform.csvColumns}
+                        </span>
+                        <input
+                            type="text"
+                            value={(safeConfig.columns ?? []).join(', ')}
+                            onChange={(e) => handleColumnsChange(e.target.value)}
+                            placeholder={t.nodeConfig.transform.csvColumnsPlaceholder}
+                            className="w-full px-3 py-2 bg-white dark:bg-slate-950 border border-slate-300 dark:border-slate-700 rounded-lg text-slate-900 dark:text-white focus:outline-none focus:ring-2 focus:ring-amber-500 text-sm font-mono"
+                        />
+                    </label>
+
+                    <label className="flex items-center gap-2 text-sm text-slate-700 dark:text-slate-300">
+                        {safeConfig.type === 'csv_parse' ? (
+                            <>
+                                <input
+                                    type="checkbox"
+                                    checked={safeConfig.infer_types ?? true}
+                                    onChange={(e) => onChange({...safeConfig, infer_types: e.target.checked})}
+                                    className="rounded border-slate-300 dark:border-slate-700 text-amber-600 focus:ring-amber-500"
+                                />
+                                {t.nodeConfig.transform.csvInferTypes}
+                            </>
+                        ) : (
+                            <>
+                                <input
+                                    type="checkbox"
+                                    checked={safeConfig.include_header ?? true}
+                                    onChange={(e) => onChange({...safeConfig, include_header: e.target.checked})}
+                                    className="rounded border-slate-300 dark:border-slate-700 text-amber-600 focus:ring-amber-500"
+                                />
+                                {t.nodeConfig.transform.csvIncludeHeader}
+                            </>
+                        )}
+                    </label>
+                </div>
+            )}
+
+            {/* XML options (only for type: xml) */}
+            {safeConfig.type === 'xml' && (
+                <div className="space-y-3">
+                    <label className="block">
+                        <span className="text-sm font-semibold text-slate-700 dark:text-slate-300 mb-2 block">
+                            {t.nodeConfig.transform.xmlOperation}
+                        </span>
+                        <select
+                            value={xmlOperation}
+                            onChange={(e) => onChange({...safeConfig, operation: e.target.value as TransformNodeConfig['operation']})}
+                            className="w-full px-3 py-2 bg-white dark:bg-slate-950 border border-slate-300 dark:border-slate-700 rounded-lg text-slate-900 dark:text-white focus:outline-none focus:ring-2 focus:ring-amber-500 text-sm"
+                        >
+                            {XML_OPERATIONS.map((operation) => (
+                                <option key={operation} value={operation}>
+                                    {operationLabels[operation]}
+                                </option>
+                            ))}
+                        </select>
+                    </label>
+
+                    {xmlOperation === 'xpath' && (
+                        <>
+                            <label className="block">
+                                <span className="text-sm font-semibold text-slate-700 dark:text-slate-300 mb-2 block">
+                                    {t.nodeConfig.transform.xmlXPathLabel}
+                                </span>
+                                <textarea
+                                    value={xpathText}
+                                    onChange={(e) => handleXPathChange(e.target.value)}
+                                    placeholder="//item/@sku"
+                                    rows={4}
+                                    className="w-full px-3 py-2 bg-white dark:bg-slate-950 border border-slate-300 dark:border-slate-700 rounded-lg text-slate-900 dark:text-white focus:outline-none focus:ring-2 focus:ring-amber-500 text-sm font-mono resize-none"
+                                />
+                                <span className="text-xs text-slate-500 dark:text-slate-400 mt-1 block">
+                                    {t.nodeConfig.transform.xmlXPathHint}
+                                </span>
+                            </label>
+                            <label className="flex items-center gap-2 text-sm text-slate-700 dark:text-slate-300">
+                                <input
+                                    type="checkbox"
+                                    checked={safeConfig.output === 'object'}
+                                    onChange={(e) => onChange({...safeConfig, output: e.target.checked ? 'object' : undefined})}
+                                    className="rounded border-slate-300 dark:border-slate-700 text-amber-600 focus:ring-amber-500"
+                                />
+                                {t.nodeConfig.transform.xmlOutputObject}
+                            </label>
+                        </>
+                    )}
+
+                    {xmlOperation === 'parse' && (
+                        <>
+                            <label className="block">
+                                <span className="text-sm font-semibold text-slate-700 dark:text-slate-300 mb-2 block">
+                                    {t.nodeConfig.transform.xmlForceArray}
+                                </span>
+                                <input
+                                    type="text"
+                                    value={(safeConfig.force_array ?? []).join(', ')}
+                                    onChange={(e) => handleForceArrayChange(e.target.value)}
+                                    placeholder={t.nodeConfig.transform.xmlForceArrayPlaceholder}
+                                    className="w-full px-3 py-2 bg-white dark:bg-slate-950 border border-slate-300 dark:border-slate-700 rounded-lg text-slate-900 dark:text-white focus:outline-none focus:ring-2 focus:ring-amber-500 text-sm font-mono"
+                                />
+                            </label>
+                            <label className="flex items-center gap-2 text-sm text-slate-700 dark:text-slate-300">
+                                <input
+                                    type="checkbox"
+                                    checked={safeConfig.keep_namespaces ?? false}
+                                    onChange={(e) => onChange({...safeConfig, keep_namespaces: e.target.checked})}
+                                    className="rounded border-slate-300 dark:border-slate-700 text-amber-600 focus:ring-amber-500"
+                                />
+                                {t.nodeConfig.transform.xmlKeepNamespaces}
+                            </label>
+                        </>
+                    )}
+
+                    {xmlOperation === 'serialize' ? (
+                        <label className="block">
+                            <span className="text-sm font-semibold text-slate-700 dark:text-slate-300 mb-2 block">
+                                {t.nodeConfig.transform.xmlRootName}
+                            </span>
+                            <input
+                                type="text"
+                                value={safeConfig.root_name ?? ''}
+                                onChange={(e) => onChange({...safeConfig, root_name: e.target.value || undefined})}
+                                placeholder="root"
+                                className="w-full px-3 py-2 bg-white dark:bg-slate-950 border border-slate-300 dark:border-slate-700 rounded-lg text-slate-900 dark:text-white focus:outline-none focus:ring-2 focus:ring-amber-500 text-sm font-mono"
+                            />
+                        </label>
+                    ) : (
+                        <label className="flex items-center gap-2 text-sm text-slate-700 dark:text-slate-300">
+                            <input
+                                type="checkbox"
+                                checked={safeConfig.infer_types ?? false}
+                                onChange={(e) => onChange({...safeConfig, infer_types: e.target.checked})}
+                                className="rounded border-slate-300 dark:border-slate-700 text-amber-600 focus:ring-amber-500"
+                            />
+                            {t.nodeConfig.transform.xmlInferTypes}
+                        </label>
+                    )}
+                </div>
+            )}
+
             {/* Timeout */}
             <div className="space-y-3">
                 <label className="block">
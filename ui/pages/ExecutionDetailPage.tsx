@@ -8,6 +8,7 @@ import {
     Info,
     Layers,
     Loader2,
+    MessageSquare,
     Play,
     RefreshCw,
     Workflow,
@@ -22,6 +23,7 @@ import {useExecutionWebSocket} from '@/hooks/useExecutionWebSocket';
 import {
     calculateStats,
     CopyButton,
+    ExecutionNotesPanel,
     ExecutionStatsGrid,
     formatDate,
     getStatusBadgeClass,
@@ -40,7 +42,7 @@ export const ExecutionDetailPage: React.FC = () => {
 
     const [isRetrying, setIsRetrying] = useState(false);
     const [expandedNodes, setExpandedNodes] = useState<Set<string>>(new Set());
-    const [activeTab, setActiveTab] = useState<'nodes' | 'overview'>('nodes');
+    const [activeTab, setActiveTab] = useState<'nodes' | 'overview' | 'notes'>('nodes');
 
     const {execution, workflow, isLoading, refetch, setExecution} = useExecutionData(id);
 
@@ -295,6 +297,20 @@ export const ExecutionDetailPage: React.FC = () => {
                                 {t.executionDetail?.overview || 'Overview'}
                             </div>
                         </button>
+
+                        <button
+                            onClick={() => setActiveTab('notes')}
+                            className={`py-3 px-1 border-b-2 font-medium text-sm transition-colors ${
+                                activeTab === 'notes'
+                                    ? 'border-blue-500 text-blue-600 dark:text-blue-400'
+                                    : 'border-transparent text-slate-500 hover:text-slate-700 dark:text-slate-400 dark:hover:text-slate-300'
+                            }`}
+                        >
+                            <div className="flex items-center gap-2">
+                                <MessageSquare size={16}/>
+                                {t.executionDetail?.notes || 'Notes'}
+                            </div>
+                        </button>
                     </nav>
                 </div>
 
@@ -451,6 +467,13 @@ export const ExecutionDetailPage: React.FC = () => {
                         )}
                     </div>
                 )}
+
+                {activeTab === 'notes' && (
+                    <ExecutionNotesPanel
+                        executionId={execution.id}
+                        nodeExecutions={sortedNodeExecutions}
+                    />
+                )}
             </div>
         </div>
     );
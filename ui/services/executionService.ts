@@ -1,5 +1,6 @@
 import { apiClient, ApiListResponse } from '../lib/api';
 import { NodeExecutionResult, ExecutionLog } from '@/types';
+import { ExecutionNote } from '@/types/execution';
 import {
   executionFromApi,
   ExecutionApiResponse
@@ -28,6 +29,11 @@ interface LogsResponse {
   total: number;
 }
 
+interface NotesResponse {
+  notes: ExecutionNote[];
+  total: number;
+}
+
 export const executionService = {
   // Trigger a new execution
   trigger: async (workflowId: string, input?: Record<string, any>) => {
@@ -102,5 +108,32 @@ export const executionService = {
       `/executions/${executionId}/retry`
     );
     return executionFromApi(response.data);
+  },
+
+  // Get notes attached to an execution, optionally only those on one node
+  getNotes: async (executionId: string, nodeId?: string) => {
+    const query = nodeId ? `?node_id=${encodeURIComponent(nodeId)}` : '';
+    const response = await apiClient.get<NotesResponse>(`/executions/${executionId}/notes${query}`);
+    return response.data.notes;
+  },
+
+  // Attach a note to an execution or one of its node executions
+  addNote: async (executionId: string, body: string, nodeId?: string) => {
+    const response = await apiClient.post<ExecutionNote>(`/executions/${executionId}/notes`, {
+      body,
+      node_id: nodeId || undefined,
+    });
+    return response.data;
+  },
+
+  // Edit the body of a note
+  updateNote: async (executionId: string, noteId: string, body: string) => {
+    const response = await apiClient.patch<ExecutionNote>(`/executions/${executionId}/notes/${noteId}`, { body });
+    return response.data;
+  },
+
+  // Delete a note
+  deleteNote: async (executionId: string, noteId: string) => {
+    await apiClient.delete(`/executions/${executionId}/notes/${noteId}`);
   }
 };
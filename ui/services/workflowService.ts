@@ -1,6 +1,6 @@
 import { apiClient, ApiListResponse } from '../lib/api';
 import { DAG, AppNode, AppEdge } from '@/types';
-import type { WorkflowResource } from '@/types/workflow';
+import type { FixtureRunReport, WorkflowFixture, WorkflowResource } from '@/types/workflow';
 import {
   workflowFromApi,
   workflowToApi,
@@ -94,4 +94,26 @@ export const workflowService = {
   // Update resource alias
   updateResourceAlias: (workflowId: string, resourceId: string, alias: string) =>
     apiClient.put<WorkflowResource>(`/workflows/${workflowId}/resources/${resourceId}`, { alias }),
+
+  // Get workflow test fixtures keyed by name
+  getFixtures: async (workflowId: string) => {
+    const response = await apiClient.get<{ fixtures: Record<string, WorkflowFixture> }>(`/workflows/${workflowId}/fixtures`);
+    return response.data.fixtures || {};
+  },
+
+  // Create or replace a test fixture
+  saveFixture: async (workflowId: string, name: string, fixture: WorkflowFixture) => {
+    const response = await apiClient.put<WorkflowFixture>(`/workflows/${workflowId}/fixtures/${encodeURIComponent(name)}`, fixture);
+    return response.data;
+  },
+
+  // Delete a test fixture
+  deleteFixture: (workflowId: string, name: string) =>
+    apiClient.delete(`/workflows/${workflowId}/fixtures/${encodeURIComponent(name)}`),
+
+  // Run test fixtures (all when names is empty)
+  runFixtures: async (workflowId: string, names?: string[]) => {
+    const response = await apiClient.post<FixtureRunReport>(`/workflows/${workflowId}/fixtures/run`, { names });
+    return response.data;
+  },
 };
@@ -228,7 +228,20 @@ const dictionaries = {
       notFound: "Execution not found",
       fetchError: "Failed to load execution details",
       retryStarted: "Retry started",
-      retryFailed: "Failed to retry execution"
+      retryFailed: "Failed to retry execution",
+      notes: "Notes",
+      noNotes: "No notes yet",
+      notePlaceholder: "Record findings, causes or follow-ups...",
+      wholeExecution: "Whole execution",
+      addNote: "Add Note",
+      editNote: "Edit",
+      deleteNote: "Delete",
+      saveNote: "Save",
+      cancelEdit: "Cancel",
+      unknownAuthor: "Unknown",
+      signInToNote: "Sign in to add notes",
+      noteFailed: "Failed to save note",
+      noteDeleteFailed: "Failed to delete note"
     },
     auth: {
       signIn: "Sign In",
@@ -598,12 +611,33 @@ const dictionaries = {
       clickToEdit: "Click to edit workflow name",
       workflowVariables: "Workflow Variables",
       workflowResources: "Workflow Resources",
+      workflowFixtures: "Test Fixtures",
       templates: "Templates",
       shortcuts: "Shortcuts",
       switchLanguage: "Switch Language",
       focusMode: "Focus Mode",
       toggleTheme: "Toggle Theme"
     },
+    fixtures: {
+      title: "Test Fixtures",
+      subtitle: "Sample inputs with expected results, run before publishing",
+      saveFirst: "Save the workflow to add fixtures.",
+      name: "Name",
+      description: "Description",
+      input: "Input (JSON)",
+      expectedStatus: "Expected status",
+      expectedOutput: "Expected output (JSON)",
+      expectedOutputHint: "Only the listed keys are compared; other output keys are ignored.",
+      expectedError: "Expected error contains",
+      add: "Add fixture",
+      run: "Run",
+      runAll: "Run all",
+      empty: "No fixtures yet",
+      allPassed: "All fixtures passed",
+      failedCount: "fixtures failed",
+      runFailed: "Failed to run fixtures",
+      deleteFailed: "Failed to delete fixture"
+    },
     canvas: {
       autoLayoutTopBottom: "Auto Layout (Top to Bottom)",
       autoLayoutLeftRight: "Auto Layout (Left to Right)"
@@ -743,6 +777,34 @@ const dictionaries = {
         jqLabel: "JQ Filter",
         jqPlaceholder: ".field | select(.value > 0)",
         jqExamples: "JQ Examples:",
+        csvParse: "Parse CSV",
+        csvParseDesc: "Parse CSV text or a file from file_to_bytes into an array of objects",
+        csvGenerate: "Generate CSV",
+        csvGenerateDesc: "Serialize an array of objects or arrays to CSV text",
+        csvDelimiter: "Delimiter",
+        csvDelimiterHint: "A single character, \\t for tabs, or auto to detect",
+        csvHeader: "Header",
+        csvHeaderFirstRow: "First row",
+        csvHeaderNone: "No header",
+        csvHeaderAuto: "Detect",
+        csvColumns: "Columns",
+        csvColumnsPlaceholder: "id, name, email",
+        csvInferTypes: "Convert numbers, booleans and empty fields",
+        csvIncludeHeader: "Write header row",
+        xml: "XML",
+        xmlDesc: "Parse XML into an object, serialize an object to XML, or extract values with XPath",
+        xmlOperation: "Operation",
+        xmlParse: "Parse XML",
+        xmlSerialize: "Serialize to XML",
+        xmlXPath: "Extract with XPath",
+        xmlXPathLabel: "XPath",
+        xmlXPathHint: "An expression, or a JSON object of name to expression",
+        xmlOutputObject: "Return matched elements as objects",
+        xmlForceArray: "Always arrays",
+        xmlForceArrayPlaceholder: "item, line",
+        xmlKeepNamespaces: "Keep namespace prefixes",
+        xmlInferTypes: "Convert numbers and booleans",
+        xmlRootName: "Root element",
         timeout: "Timeout (seconds)"
       },
       telegram: {
@@ -1067,6 +1129,11 @@ const dictionaries = {
         itemVarHint: "Variable name for the current item in child workflow input",
         maxParallelism: "Max Parallelism",
         maxParallelismHint: "Max concurrent child executions (0 = unlimited)",
+        chunkSize: "Chunk Size",
+        chunkSizeHint: "Split the array into chunks of this many items, one child per chunk (0 = one child per item). The child receives the chunk as the item variable, plus offset and total_items",
+        reducerWorkflowId: "Reducer Workflow ID",
+        reducerWorkflowIdPlaceholder: "e.g., merge-results-wf",
+        reducerWorkflowIdHint: "Optional workflow run once after all children, with their outputs in input.results; its output is returned as reduced",
         onError: "Error Handling",
         onErrorFailFast: "Fail Fast",
         onErrorFailFastHint: "Stop all on first error",
@@ -1078,7 +1145,9 @@ const dictionaries = {
           "Evaluates for_each to get an array of items",
           "Launches a child workflow for each item in parallel",
           "Each child receives: item, index, total + parent input",
-          "Results are collected into items[] array with summary"
+          "With a chunk size, each child receives a chunk of items instead of one item",
+          "Results are collected into items[] array with summary",
+          "An optional reducer workflow combines the results into reduced"
         ]
       },
       adapter: {
@@ -1355,7 +1424,20 @@ const dictionaries = {
       notFound: "Выполнение не найдено",
       fetchError: "Не удалось загрузить детали выполнения",
       retryStarted: "Повторный запуск начат",
-      retryFailed: "Не удалось повторить выполнение"
+      retryFailed: "Не удалось повторить выполнение",
+      notes: "Заметки",
+      noNotes: "Заметок пока нет",
+      notePlaceholder: "Запишите выводы, причины или дальнейшие шаги...",
+      wholeExecution: "Все выполнение",
+      addNote: "Добавить заметку",
+      editNote: "Изменить",
+      deleteNote: "Удалить",
+      saveNote: "Сохранить",
+      cancelEdit: "Отмена",
+      unknownAuthor: "Неизвестно",
+      signInToNote: "Войдите, чтобы добавлять заметки",
+      noteFailed: "Не удалось сохранить заметку",
+      noteDeleteFailed: "Не удалось удалить заметку"
     },
     auth: {
       signIn: "Войти",
@@ -1725,12 +1807,33 @@ const dictionaries = {
       clickToEdit: "Нажмите для редактирования названия",
       workflowVariables: "Переменные процесса",
       workflowResources: "Ресурсы процесса",
+      workflowFixtures: "Тестовые примеры",
       templates: "Шаблоны",
       shortcuts: "Горячие клавиши",
       switchLanguage: "Сменить язык",
       focusMode: "Режим фокуса",
       toggleTheme: "Сменить тему"
     },
+    fixtures: {
+      title: "Тестовые примеры",
+      subtitle: "Примеры входных данных с ожидаемым результатом, запускаются перед публикацией",
+      saveFirst: "Сохраните процесс, чтобы добавить примеры.",
+      name: "Название",
+      description: "Описание",
+      input: "Входные данные (JSON)",
+      expectedStatus: "Ожидаемый статус",
+      expectedOutput: "Ожидаемый результат (JSON)",
+      expectedOutputHint: "Сравниваются только указанные ключи, остальные ключи результата игнорируются.",
+      expectedError: "Ошибка содержит",
+      add: "Добавить пример",
+      run: "Запустить",
+      runAll: "Запустить все",
+      empty: "Примеров пока нет",
+      allPassed: "Все примеры прошли",
+      failedCount: "примеров не прошли",
+      runFailed: "Не удалось запустить примеры",
+      deleteFailed: "Не удалось удалить пример"
+    },
     canvas: {
       autoLayoutTopBottom: "Авто-раскладка (сверху вниз)",
       autoLayoutLeftRight: "Авто-раскладка (слева направо)"
@@ -1870,6 +1973,34 @@ const dictionaries = {
         jqLabel: "JQ-фильтр",
         jqPlaceholder: ".field | select(.value > 0)",
         jqExamples: "Примеры JQ:",
+        csvParse: "Разбор CSV",
+        csvParseDesc: "Разобрать CSV-текст или файл из file_to_bytes в массив объектов",
+        csvGenerate: "Генерация CSV",
+        csvGenerateDesc: "Сериализовать массив объектов или массивов в CSV-текст",
+        csvDelimiter: "Разделитель",
+        csvDelimiterHint: "Один символ, \\t для табуляции или auto для автоопределения",
+        csvHeader: "Заголовок",
+        csvHeaderFirstRow: "Первая строка",
+        csvHeaderNone: "Без заголовка",
+        csvHeaderAuto: "Определить",
+        csvColumns: "Колонки",
+        csvColumnsPlaceholder: "id, name, email",
+        csvInferTypes: "Преобразовывать числа, булевы значения и пустые поля",
+        csvIncludeHeader: "Записывать строку заголовка",
+        xml: "XML",
+        xmlDesc: "Разобрать XML в объект, сериализовать объект в XML или извлечь значения через XPath",
+        xmlOperation: "Операция",
+        xmlParse: "Разбор XML",
+        xmlSerialize: "Сериализация в XML",
+        xmlXPath: "Извлечение через XPath",
+        xmlXPathLabel: "XPath",
+        xmlXPathHint: "Выражение или JSON-объект вида имя → выражение",
+        xmlOutputObject: "Возвращать найденные элементы как объекты",
+        xmlForceArray: "Всегда массивы",
+        xmlForceArrayPlaceholder: "item, line",
+        xmlKeepNamespaces: "Сохранять префиксы пространств имён",
+        xmlInferTypes: "Преобразовывать числа и булевы значения",
+        xmlRootName: "Корневой элемент",
         timeout: "Таймаут (секунды)"
       },
       telegram: {
@@ -2194,6 +2325,11 @@ const dictionaries = {
         itemVarHint: "Имя переменной для текущего элемента во входных данных дочернего воркфлоу",
         maxParallelism: "Макс. параллелизм",
         maxParallelismHint: "Максимальное количество параллельных выполнений (0 = без ограничений)",
+        chunkSize: "Размер чанка",
+        chunkSizeHint: "Разбить массив на чанки из указанного числа элементов, один дочерний воркфлоу на чанк (0 = по одному на элемент). Дочерний получает чанк в переменной элемента, а также offset и total_items",
+        reducerWorkflowId: "ID воркфлоу-редьюсера",
+        reducerWorkflowIdPlaceholder: "напр., merge-results-wf",
+        reducerWorkflowIdHint: "Необязательный воркфлоу, запускаемый один раз после всех дочерних с их результатами в input.results; его результат возвращается в reduced",
         onError: "Обработка ошибок",
         onErrorFailFast: "Быстрый отказ",
         onErrorFailFastHint: "Остановить всё при первой ошибке",
@@ -2205,7 +2341,9 @@ const dictionaries = {
           "Вычисляет for_each для получения массива элементов",
           "Запускает дочерний воркфлоу для каждого элемента параллельно",
           "Каждый дочерний получает: item, index, total + входные данные родителя",
-          "Результаты собираются в массив items[] с суммарной статистикой"
+          "С размером чанка каждый дочерний получает чанк элементов вместо одного элемента",
+          "Результаты собираются в массив items[] с суммарной статистикой",
+          "Необязательный воркфлоу-редьюсер объединяет результаты в reduced"
         ]
       },
       adapter: {
@@ -48,6 +48,17 @@ export interface Execution {
   updated_at: string;
 }
 
+export interface ExecutionNote {
+  id: string;
+  execution_id: string;
+  node_id?: string;
+  author_id?: string;
+  author_name?: string;
+  body: string;
+  created_at: string;
+  updated_at: string;
+}
+
 export interface ExecutionListParams {
   workflow_id?: string;
   status?: ExecutionStatus;
@@ -87,10 +87,22 @@ export interface LLMNodeConfig extends BaseNodeConfig {
 
 // Transform Node
 export interface TransformNodeConfig extends BaseNodeConfig {
-  type: "passthrough" | "template" | "expression" | "jq";
+  type: "passthrough" | "template" | "expression" | "jq" | "csv_parse" | "csv_generate" | "xml";
   template?: string;      // For type: "template"
   expression?: string;    // For type: "expression" (expr-lang)
   filter?: string;        // For type: "jq" (gojq)
+  delimiter?: string;     // For CSV types: single character, "\t", or "auto" (csv_parse)
+  header?: "first_row" | "none" | "auto"; // For type: "csv_parse"
+  columns?: string[];     // For CSV types: column names (csv_parse) or order (csv_generate)
+  infer_types?: boolean;  // For type: "csv_parse"
+  include_header?: boolean; // For type: "csv_generate"
+  operation?: "parse" | "serialize" | "xpath"; // For type: "xml"
+  xpath?: string | Record<string, string>; // For type: "xml" with operation "xpath"
+  namespaces?: Record<string, string>; // For type: "xml": prefix to namespace URI
+  keep_namespaces?: boolean; // For type: "xml" with operation "parse"
+  force_array?: string[]; // For type: "xml" with operation "parse"
+  root_name?: string;     // For type: "xml" with operation "serialize"
+  output?: "text" | "object"; // For type: "xml" with operation "xpath"
   timeout_seconds?: number;
 }
 
@@ -267,6 +279,8 @@ export interface SubWorkflowNodeConfig extends BaseNodeConfig {
   item_var?: string;
   max_parallelism?: number;
   on_error?: "fail_fast" | "collect_partial";
+  chunk_size?: number;  // 0 = one child per item
+  reducer_workflow_id?: string;
 }
 
 // Google Drive Node
@@ -465,6 +479,8 @@ export const DEFAULT_NODE_CONFIGS: Record<string, NodeConfig> = {
     item_var: "item",
     max_parallelism: 0,
     on_error: "fail_fast",
+    chunk_size: 0,
+    reducer_workflow_id: "",
   },
 };
 
@@ -502,7 +518,19 @@ export const HTTP_METHODS = [
   "OPTIONS",
 ] as const;
 
-export const TRANSFORM_TYPES = ["passthrough", "template", "expression", "jq"] as const;
+export const TRANSFORM_TYPES = [
+  "passthrough",
+  "template",
+  "expression",
+  "jq",
+  "csv_parse",
+  "csv_generate",
+  "xml",
+] as const;
+
+export const CSV_HEADER_MODES = ["first_row", "none", "auto"] as const;
+
+export const XML_OPERATIONS = ["parse", "serialize", "xpath"] as const;
 
 export const TELEGRAM_MESSAGE_TYPES = [
   "text",
@@ -9,3 +9,32 @@ export interface WorkflowResource {
   resource_name?: string;
   resource_type?: string;
 }
+
+export type FixtureExpectedStatus = 'completed' | 'failed' | 'cancelled' | 'timeout';
+
+/** Named sample input stored with a workflow and the result it is expected to produce. */
+export interface WorkflowFixture {
+  description?: string;
+  input?: Record<string, any>;
+  expected_status?: FixtureExpectedStatus;
+  expected_output?: Record<string, any>; // Subset of the execution output that must match
+  expected_error?: string; // Substring of the execution error
+}
+
+export interface FixtureResult {
+  name: string;
+  passed: boolean;
+  status?: string;
+  execution_id?: string;
+  output?: Record<string, any>;
+  failures?: string[];
+  duration_ms: number;
+}
+
+export interface FixtureRunReport {
+  workflow_id: string;
+  passed: boolean;
+  total: number;
+  failed: number;
+  results: FixtureResult[];
+}